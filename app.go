@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/base64"
 	"encoding/json"
@@ -14,28 +15,64 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"orch/internal/agentgraph"
 	"orch/internal/ai"
 	"orch/internal/auth"
+	"orch/internal/backup"
+	"orch/internal/cliapi"
+	"orch/internal/clipboard"
+	"orch/internal/commandguard"
 	"orch/internal/config"
+	"orch/internal/coordination"
 	"orch/internal/database"
+	"orch/internal/deeplink"
+	"orch/internal/digest"
+	"orch/internal/diskusage"
 	"orch/internal/docker"
+	"orch/internal/editor"
+	"orch/internal/egress"
+	"orch/internal/eventbatch"
+	"orch/internal/filesearch"
 	fw "orch/internal/filewatcher"
+	"orch/internal/focus"
 	ga "orch/internal/gitactivity"
 	gh "orch/internal/github"
 	gp "orch/internal/gitpanel"
 	gpr "orch/internal/gitprs"
+	"orch/internal/gitshare"
+	"orch/internal/hooks"
+	"orch/internal/httpclient"
+	"orch/internal/i18n"
+	"orch/internal/issuetracker"
+	"orch/internal/jobs"
+	"orch/internal/kube"
+	"orch/internal/logging"
+	"orch/internal/mcp"
+	"orch/internal/notify"
+	"orch/internal/problems"
+	"orch/internal/restapi"
 	"orch/internal/security"
 	"orch/internal/session"
+	"orch/internal/sessionreview"
+	"orch/internal/snippets"
+	"orch/internal/startup"
+	"orch/internal/tasks"
 	"orch/internal/terminal"
+	"orch/internal/updater"
+	"orch/internal/workspacefs"
 
+	"github.com/google/uuid"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
@@ -51,11 +88,14 @@ const (
 	gitPanelAuthorLookupTimeout       = 4 * time.Second
 	gitPanelAuthorLookupPerRequest    = 40
 	gitPanelPRLocalBranchTimeout      = 12 * time.Second
+	gitPanelPRPushTimeout             = 2 * time.Minute
+	gitPanelPRCommandWaitDelay        = 2 * time.Second // espera por remote helpers após matar o git
 )
 
 var gitPanelCommitHashRegex = regexp.MustCompile(`^[a-f0-9]{7,40}$`)
 var gitPanelFullCommitHashRegex = regexp.MustCompile(`^[a-fA-F0-9]{40}$`)
 var gitPanelLabelColorRegex = regexp.MustCompile(`^#?[a-fA-F0-9]{6}$`)
+var gitHubOrgNameRegex = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
 
 type gitPanelInvalidationPlan struct {
 	Status    bool
@@ -90,18 +130,52 @@ type gitPanelRepoIdentityCacheEntry struct {
 	expiresAt time.Time
 }
 
+type gitPanelRemoteForkCacheEntry struct {
+	info      *gh.RepositoryForkInfo // nil quando a consulta falhou (cache negativo)
+	expiresAt time.Time
+}
+
+type gitPanelCoAuthorCacheEntry struct {
+	identity  *gh.UserIdentity // nil quando a consulta falhou (cache negativo)
+	expiresAt time.Time
+}
+
+// GitPanelCoAuthorDTO é um convidado conectado à sessão sugerido como co-autor do commit.
+type GitPanelCoAuthorDTO struct {
+	UserID  string `json:"userId"`
+	Name    string `json:"name"`
+	Login   string `json:"login"`
+	Email   string `json:"email"`
+	Trailer string `json:"trailer"` // "Nome <email>", pronto para CommitOptionsDTO.CoAuthors
+}
+
 // GitPanelPRRepositoryTargetDTO representa o repositorio remoto resolvido para operacoes de PR.
 type GitPanelPRRepositoryTargetDTO struct {
 	RepoPath          string `json:"repoPath"`
 	RepoRoot          string `json:"repoRoot"`
 	Owner             string `json:"owner"`
 	Repo              string `json:"repo"`
-	Source            string `json:"source"` // "origin" | "manual"
+	Source            string `json:"source"` // "origin" | "remote" | "manual"
 	OriginOwner       string `json:"originOwner,omitempty"`
 	OriginRepo        string `json:"originRepo,omitempty"`
 	ManualOwner       string `json:"manualOwner,omitempty"`
 	ManualRepo        string `json:"manualRepo,omitempty"`
 	OverrideConfirmed bool   `json:"overrideConfirmed,omitempty"`
+	// BaseRemote e o remote cujo repositorio recebe a PR ("" quando o alvo nao e um remote local).
+	BaseRemote string                `json:"baseRemote,omitempty"`
+	Remotes    []GitPanelPRRemoteDTO `json:"remotes,omitempty"`
+}
+
+// GitPanelPRRemoteDTO descreve um remote GitHub do repositorio e seu papel no fluxo de PR.
+type GitPanelPRRemoteDTO struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Owner       string `json:"owner"`
+	Repo        string `json:"repo"`
+	Role        string `json:"role"` // "upstream" | "fork" | "unknown"
+	ParentOwner string `json:"parentOwner,omitempty"`
+	ParentRepo  string `json:"parentRepo,omitempty"`
+	Preferred   bool   `json:"preferred,omitempty"`
 }
 
 // GitPanelPRCreatePayloadDTO representa o payload de criacao de PR via Git Panel.
@@ -115,6 +189,12 @@ type GitPanelPRCreatePayloadDTO struct {
 	ManualOwner         string `json:"manualOwner,omitempty"`
 	ManualRepo          string `json:"manualRepo,omitempty"`
 	AllowTargetOverride bool   `json:"allowTargetOverride,omitempty"`
+	// HeadRemote e o remote onde a branch head foi publicada; vazio usa o upstream da branch ou origin.
+	HeadRemote string `json:"headRemote,omitempty"`
+	// Reviewers (logins) e TeamReviewers (slugs) sao pedidos apos criar a PR; o prefill sugere
+	// os owners do CODEOWNERS para os arquivos alterados.
+	Reviewers     []string `json:"reviewers,omitempty"`
+	TeamReviewers []string `json:"teamReviewers,omitempty"`
 }
 
 // GitPanelPRCreateLabelPayloadDTO representa payload para criacao de label via aba de PR.
@@ -162,41 +242,109 @@ type App struct {
 	db          *database.Service
 	auth        *auth.Service
 	docker      *docker.Service
+	kube        *kube.Service
 	ptyMgr      *terminal.PTYManager
 	bridge      *terminal.Bridge
 	github      *gh.Service
 	fileWatcher *fw.Service
+	fileSearch  *filesearch.Service
 	gitActivity *ga.Service
 	gitPanel    *gp.Service
+	translator  *i18n.Translator // idioma das mensagens geradas no backend (UserConfig.Language)
 	poller      *gh.Poller
-	session     *session.Service
-	signaling   *session.SignalingService
-	sessionHTTP *session.GatewayServer
-	ai          *ai.Service
+	ghWebhook   *gh.WebhookBridge
+	backup      *backup.Service
+	updater     *updater.Service
+	configFile  *config.FileLoader // ~/.config/orch/config.toml (recarregado ao mudar)
+
+	ghAccountMu       sync.Mutex
+	ghAccountRoutes   map[string]gitHubAccountRoute // owner (minúsculo) -> conta vinculada
+	ghAccountRoutesAt time.Time
+	session           *session.Service
+	signaling         *session.SignalingService
+	sessionHTTP       *session.GatewayServer
+	ai                *ai.Service
+	testRuns          *terminal.TestRunTracker  // testes detectados nos terminais (digest de atividade)
+	terminalLinks     *terminal.LinkExtractor   // links clicáveis na saída dos terminais
+	problemCollector  *problems.Collector       // diagnósticos dos problem matchers (painel de problemas)
+	jobs              *jobs.Manager             // operações longas em background (push, fetch, build de stack)
+	operations        *jobs.Tokens              // tokens de cancelamento por chamada de binding (CancelOperation)
+	coordinator       *coordination.Coordinator // leases entre instâncias no mesmo data dir (poller, auto-fetch, backup, snapshots)
+	lifecycleHooks    *hooks.Runner             // scripts do usuário em eventos de app/terminal/sessão
+	notifications     *notify.Dispatcher        // webhooks de saída (generic/Slack/Discord)
+	focusTimer        *focus.Timer              // timer de foco (Pomodoro) com Não Perturbe
+	issueTracker      *issuetracker.Service     // tickets Jira/Linear citados em branches e commits
+	mcpServer         *mcp.Server               // servidor MCP local para agentes de IA externos
+	restAPI           *restapi.Server           // API REST local para scripts e extensões
+	clipboardHistory  *clipboard.History        // histórico de textos copiados (opt-in, cifrado)
+	taskComments      *tasks.Index              // TODO/FIXME do código por workspace (lista de tarefas)
 
 	logSanitizer      *security.LogSanitizer
-	sessionContainers map[string]string // sessionID -> containerID
+	logFollowMu       sync.Mutex
+	logFollowStop     func()
+	apiTraceMu        sync.Mutex
+	apiTraceStop      func()
+	searchMu          sync.Mutex
+	searches          map[string]*workspaceSearch // searchID -> busca de conteúdo em andamento
+	searchSeq         uint64
+	healthMu          sync.Mutex
+	healthLastErrors  map[string]subsystemErrorRecord // subsistema -> último erro observado
+	healthStop        chan struct{}
+	sessionContainers map[string]string       // sessionID -> containerID
+	sessionEgress     map[string]*egressRoute // sessionID -> rede interna + proxy da allow-list
 	mu                sync.RWMutex
 
 	terminalStateMu sync.RWMutex
 	terminalHistory map[string]string // sessionID -> ring buffer textual do terminal
+	terminalBytes   map[string]int64  // sessionID -> total de bytes já emitidos (cursor do orchctl tail)
 	sessionAgents   map[string]uint   // sessionID -> agentSessionID
 
+	// transcripts guarda a saída de cada terminal separada por comando (GetTerminalTranscript).
+	transcripts *terminal.TranscriptRecorder
+
+	// autoStartMu serializa o auto-start (launch e ativação de workspace podem coincidir no boot)
+	autoStartMu sync.Mutex
+
+	// Probes de prontidão da inicialização ordenada (StartWorkspaceAgents), por sessão
+	readinessMu     sync.Mutex
+	readinessProbes map[string]*agentgraph.Probe
+
+	// Aprovações pendentes de ferramentas MCP com política "ask", por ID
+	mcpApprovalsMu sync.Mutex
+	mcpApprovals   map[string]*mcpApproval
+
+	// Deep links aguardando confirmação do usuário, por ID
+	deepLinksMu sync.Mutex
+	deepLinks   map[string]DeepLinkRequestDTO
+
 	// Stack build state (persiste enquanto o app estiver aberto)
 	stackBuildMu      sync.RWMutex
 	stackBuildRunning bool
 	stackBuildLogs    []string
 	stackBuildStart   int64  // unix timestamp (seconds)
 	stackBuildResult  string // "", "success", "error"
+	stackBuildJobID   string // job do build atual/último (JobCancel)
 
 	gitIndexMu             sync.Mutex
 	lastIndexFingerprints  map[string]string // repoPath -> fingerprint do estado staged
 	gitPanelEventsMu       sync.Mutex
 	gitPanelPendingEvents  map[string]*gitPanelPendingInvalidation
+	gitPanelDebounce       time.Duration // protegido por gitPanelEventsMu; 0 usa gitPanelEventDebounceWindow
 	gitPanelAuthorMu       sync.Mutex
 	gitPanelAuthorCache    map[string]gitPanelCommitAuthorCacheEntry
 	gitPanelAuthorInFlight map[string]struct{}
 	gitPanelRepoIdentity   map[string]gitPanelRepoIdentityCacheEntry
+	gitPanelRemoteForks    map[string]gitPanelRemoteForkCacheEntry // owner/repo -> fork/parent (gitPanelAuthorMu)
+	gitPanelCoAuthors      map[string]gitPanelCoAuthorCacheEntry   // login -> identidade noreply (gitPanelAuthorMu)
+	gitPanelWarming        map[string]struct{}                     // raiz do repo -> warm-up de cache em andamento (gitPanelEventsMu)
+	gitShares              *gitshare.Registry                      // Git Panel compartilhado com os guests, por sessão
+	prReviews              *sessionreview.Store                    // modo de revisão de PR por guests, por sessão
+	gitEvents              *eventbatch.Batcher                     // agrupa rajadas de eventos git/gitpanel para o frontend
+	startupTimings         *startup.Tracker                        // duração das etapas do Startup e subsistemas async
+	githubReady            chan struct{}                           // fechado quando github/poller/ghWebhook estão montados
+	commandGuard           *commandguard.Guard                     // comandos perigosos de guests aguardando o host
+	sessionActivityMu      sync.Mutex
+	sessionActivityAt      map[string]time.Time // guestUserID -> último registro de atividade enviado (throttle)
 	anonymousGuestID       string
 	sessionGatewayOwner    bool
 	sessionGatewayAddr     string
@@ -207,44 +355,137 @@ type App struct {
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{
+	a := &App{
 		sessionContainers:      make(map[string]string),
+		sessionEgress:          make(map[string]*egressRoute),
 		terminalHistory:        make(map[string]string),
+		terminalBytes:          make(map[string]int64),
 		sessionAgents:          make(map[string]uint),
+		readinessProbes:        make(map[string]*agentgraph.Probe),
+		mcpApprovals:           make(map[string]*mcpApproval),
+		deepLinks:              make(map[string]DeepLinkRequestDTO),
 		lastIndexFingerprints:  make(map[string]string),
 		gitPanelPendingEvents:  make(map[string]*gitPanelPendingInvalidation),
 		gitPanelAuthorCache:    make(map[string]gitPanelCommitAuthorCacheEntry),
+		translator:             i18n.NewTranslator(i18n.DefaultLocale),
 		gitPanelAuthorInFlight: make(map[string]struct{}),
 		gitPanelRepoIdentity:   make(map[string]gitPanelRepoIdentityCacheEntry),
+		gitPanelRemoteForks:    make(map[string]gitPanelRemoteForkCacheEntry),
+		gitPanelCoAuthors:      make(map[string]gitPanelCoAuthorCacheEntry),
+		gitPanelWarming:        make(map[string]struct{}),
+		gitShares:              gitshare.NewRegistry(),
+		prReviews:              sessionreview.NewStore(),
+		commandGuard:           commandguard.NewGuard(),
+		sessionActivityAt:      make(map[string]time.Time),
+		healthLastErrors:       make(map[string]subsystemErrorRecord),
 		anonymousGuestID:       fmt.Sprintf("anonymous-%d", time.Now().UnixNano()),
 	}
+	a.operations = jobs.NewTokens()
+	a.lifecycleHooks = hooks.NewRunner(a.loadLifecycleHooks)
+	a.notifications = notify.NewDispatcher(httpclient.NewClient(httpclient.ServiceNotify, 15*time.Second), a.loadNotificationTargets)
+	a.issueTracker = issuetracker.NewService(httpclient.NewClient(httpclient.ServiceIssues, 10*time.Second))
+	a.mcpServer = mcp.NewServer("orch", config.AppVersion)
+	a.registerMCPTools()
+	a.restAPI = restapi.NewServer()
+	a.registerRESTRoutes()
+	a.clipboardHistory = clipboard.NewHistory(clipboard.DefaultPath(), clipboard.DefaultMaxEntries, security.NewLogSanitizer().Sanitize)
+	a.jobs = jobs.NewManager(func(eventName string, data interface{}) {
+		if a.ctx == nil {
+			return
+		}
+		runtime.EventsEmit(a.ctx, eventName, data)
+	})
+	a.focusTimer = focus.NewTimer(200, func(eventName string, data interface{}) {
+		if a.ctx == nil {
+			return
+		}
+		runtime.EventsEmit(a.ctx, eventName, data)
+	})
+	a.gitEvents = eventbatch.New(func(eventName string, data interface{}) {
+		if a.ctx == nil {
+			return
+		}
+		runtime.EventsEmit(a.ctx, eventName, data)
+	}, gitEventBypassesBatch)
+	return a
 }
 
 // Startup is called when the app starts
 // Inicializa banco, auth, PTY manager e emite evento de hydration para o frontend
 func (a *App) Startup(ctx context.Context) {
 	a.ctx = ctx
+	a.startupTimings = startup.NewTracker(func(phase startup.Phase) {
+		log.Printf("[ORCH] Subsystem %s %s in %dms", phase.Name, phase.Status, phase.DurationMs)
+		if a.ctx == nil {
+			return
+		}
+		runtime.EventsEmit(a.ctx, "startup:subsystem_ready", phase)
+	})
 	log.Println("[ORCH] Starting up...")
 	a.configureSessionNetworking()
 
-	// 1. Garantir diretórios existem
-	if err := config.EnsureDataDirs(); err != nil {
-		log.Printf("[ORCH] Error creating data dirs: %v", err)
+	// 1. Garantir diretórios existem (do perfil ativo, acessíveis só pelo dono)
+	dataDirErr := config.EnsureDataDirs()
+	if dataDirErr != nil {
+		log.Printf("[ORCH] Error creating data dirs: %v", dataDirErr)
+	}
+	log.Printf("[ORCH] Profile %q (data dir %s)", config.ActiveProfile(), config.DataDir())
+	if err := logging.Init(config.LogDir()); err != nil {
+		log.Printf("[ORCH] Error opening log file: %v", err)
 	}
 
 	// 2. Inicializar banco de dados SQLite
-	dbService, err := database.NewService()
+	doneDatabase := a.startupTimings.Begin("database")
+	var dbService *database.Service
+	var err error
+	if errors.Is(dataDirErr, config.ErrDataDirNotOwned) {
+		// Não abre o banco (histórico de terminal, configurações) de outro usuário do sistema.
+		err = dataDirErr
+	} else {
+		dbService, err = database.NewService()
+	}
 	if err != nil {
 		log.Printf("[ORCH] Error initializing database: %v", err)
 	} else {
 		a.db = dbService
 		log.Println("[ORCH] Database initialized")
+		if cfg, cfgErr := a.db.GetConfig(); cfgErr == nil {
+			a.translator.SetLocale(cfg.Language)
+		}
+	}
+	doneDatabase(err)
+
+	// 2.1 Backups completos (banco + configurações) com agendamento rotativo
+	if a.db != nil {
+		a.backup = backup.NewService(a.db, config.DataDir(), func(eventName string, data interface{}) {
+			if a.ctx == nil {
+				return
+			}
+			runtime.EventsEmit(a.ctx, eventName, data)
+		})
+		a.restoreBackupSchedule()
 	}
+	go a.pruneAuditLogs()
+
+	// 2.2 Proxy e CAs customizadas para todos os clients HTTP (GitHub, auth, IA, gateway)
+	a.applyNetworkSettings()
+
+	// 2.3 Issue tracker (Jira/Linear) para os tickets citados em branches e commits
+	a.applyIssueTrackerSettings()
 
 	// 3. Inicializar serviço de auth
+	doneAuth := a.startupTimings.Begin("auth")
 	authService := auth.NewService(a.db)
 	a.auth = authService
+	a.auth.SetTokenRefreshedHandler(func(event auth.TokenRefreshedEvent) {
+		if a.ctx == nil {
+			return
+		}
+		runtime.EventsEmit(a.ctx, "auth:token_refreshed", event)
+	})
+	a.auth.StartTokenRefresher()
 	log.Println("[ORCH] Auth service initialized")
+	doneAuth(nil)
 
 	// 4. Inicializar PTY Manager
 	a.ptyMgr = terminal.NewPTYManager()
@@ -253,40 +494,91 @@ func (a *App) Startup(ctx context.Context) {
 
 	// 4.1 Inicializar sanitizer de logs
 	a.logSanitizer = security.NewLogSanitizer()
+	logging.Default().SetSanitizer(a.logSanitizer.Sanitize)
 
-	// 4.2 Inicializar Docker service (sandbox opcional)
+	// 4.2 Inicializar Docker service (sandbox opcional). A sondagem do daemon roda em background:
+	// um daemon em cold start não segura a abertura da janela.
 	a.docker = docker.NewService()
-	if a.docker.IsDockerAvailable() {
+	a.docker.SetProfileDir(filepath.Join(config.CacheDir(), "docker"))
+	a.startupTimings.Go("docker", func() error {
+		if !a.docker.IsDockerAvailable() {
+			log.Println("[ORCH] Docker unavailable - fallback to Live Share enabled")
+			return fmt.Errorf("docker daemon unavailable")
+		}
 		log.Println("[ORCH] Docker service initialized")
-	} else {
-		log.Println("[ORCH] Docker unavailable - fallback to Live Share enabled")
-	}
+		return nil
+	})
 
-	// 5. Inicializar GitHub Service
-	a.github = gh.NewService(a.auth.GetGitHubToken)
-	a.github.SetTelemetryEmitter(func(eventName string, data interface{}) {
-		if a.ctx == nil {
-			return
-		}
-		if strings.TrimSpace(eventName) == "" {
-			return
+	// 4.3 Inicializar integração Kubernetes (kubectl opcional)
+	a.kube = kube.NewService()
+	a.startupTimings.Go("kube", func() error {
+		if !a.kube.IsAvailable() {
+			log.Println("[ORCH] kubectl unavailable - pod terminals disabled")
+			return fmt.Errorf("kubectl not found")
 		}
-		runtime.EventsEmit(a.ctx, eventName, data)
+		log.Println("[ORCH] Kube service initialized")
+		return nil
 	})
-	a.poller = gh.NewPoller(a.github, func(eventName string, data interface{}) {
-		runtime.EventsEmit(a.ctx, eventName, data)
+
+	// 5. Inicializar GitHub Service + Poller em background: a leitura do orçamento de rate limit
+	// não segura a janela. As bindings que dependem deles esperam githubReady (awaitGitHub), e o
+	// fim da etapa chega ao frontend como startup:subsystem_ready com phase "github".
+	a.githubReady = make(chan struct{})
+	a.startupTimings.Go("github", func() error {
+		defer close(a.githubReady)
+		a.initGitHub()
+		log.Println("[ORCH] GitHub Service + Poller initialized")
+		return nil
+	})
+	// O relay de webhooks conecta na rede; o poller cobre até ele ficar pronto.
+	a.startupTimings.Go("github_webhook", func() error {
+		if !a.awaitGitHub() {
+			return fmt.Errorf("github service unavailable")
+		}
+		a.restoreGitHubWebhookBridge()
+		return nil
 	})
-	log.Println("[ORCH] GitHub Service + Poller initialized")
 
 	// 6. Inicializar AI Service
 	a.ai = ai.NewService(ai.ServiceDeps{
-		GitHubCache: a.github,
+		GitHubCache: gitHubCacheReader{app: a},
 		TokenBudget: config.TokenBudget,
 	})
 	a.bridge.RegisterOutputObserver(a.ai.ObserveTerminalOutput)
 	a.bridge.RegisterOutputObserver(a.observeTerminalHistory)
+	a.bridge.RegisterOutputObserver(a.observeAgentReadiness)
 	log.Println("[ORCH] AI Service initialized")
 
+	a.testRuns = terminal.NewTestRunTracker(200, func(sessionID string) uint {
+		workspaceID, _ := a.resolveTerminalWorkspaceID(sessionID)
+		return workspaceID
+	})
+	a.testRuns.SetAgentResolver(func(sessionID string) uint {
+		a.terminalStateMu.RLock()
+		defer a.terminalStateMu.RUnlock()
+		return a.sessionAgents[sessionID]
+	})
+	a.testRuns.SetOnFinished(func(run terminal.TestRun) {
+		if a.ctx == nil {
+			return
+		}
+		runtime.EventsEmit(a.ctx, "terminal:test_run_finished", run)
+	})
+	a.bridge.RegisterOutputObserver(a.testRuns.ObserveOutput)
+
+	a.terminalLinks = terminal.NewLinkExtractor()
+	a.bridge.RegisterOutputObserver(a.observeTerminalLinks)
+
+	a.transcripts = terminal.NewTranscriptRecorder()
+	a.bridge.RegisterOutputObserver(a.transcripts.ObserveOutput)
+
+	a.problemCollector = problems.NewCollector(func(sessionID string) uint {
+		workspaceID, _ := a.resolveTerminalWorkspaceID(sessionID)
+		return workspaceID
+	}, a.emitProblemsUpdated)
+	a.reloadProblemMatchers()
+	a.bridge.RegisterOutputObserver(a.problemCollector.ObserveOutput)
+
 	// 6.1 Inicializar serviço de atividade Git (timeline em memória)
 	a.gitActivity = ga.NewService(200, 900*time.Millisecond)
 	log.Println("[ORCH] GitActivity service initialized")
@@ -295,9 +587,13 @@ func (a *App) Startup(ctx context.Context) {
 	a.gitPanel = gp.NewService(func(eventName string, data interface{}) {
 		a.emitGitPanelRuntimeEvent(eventName, data)
 	})
+	a.gitPanel.SetReadObserver(recordGitPanelRead)
 	log.Println("[ORCH] GitPanel service initialized")
 
-	// 7. Inicializar File Watcher
+	// 7. Inicializar File Watcher (e o índice do quick-open, atualizado pelos eventos do workspace)
+	doneFileWatcher := a.startupTimings.Begin("filewatcher")
+	a.fileSearch = filesearch.NewService()
+	a.taskComments = tasks.NewIndex(a.fileSearch.Files)
 	fwService, err := fw.NewService(func(eventName string, data interface{}) {
 		a.emitGitRuntimeEvent(eventName, data)
 	})
@@ -305,21 +601,29 @@ func (a *App) Startup(ctx context.Context) {
 		log.Printf("[ORCH] Error initializing FileWatcher: %v", err)
 	} else {
 		a.fileWatcher = fwService
+		a.fileWatcher.OnWorkspaceFileChange(a.fileSearch.ApplyChange)
+		a.fileWatcher.OnWorkspaceFileChange(a.taskComments.ApplyChange)
 		log.Println("[ORCH] FileWatcher initialized")
 
-		// Auto-watch workspace ativo se existir
+		// Auto-watch workspace ativo (path principal + repositórios anexados) se existir
 		if a.db != nil {
-			if ws, err := a.db.GetActiveWorkspace(); err == nil && ws.Path != "" {
-				if watchErr := a.fileWatcher.Watch(ws.Path); watchErr != nil {
-					log.Printf("[ORCH] Could not auto-watch workspace: %v", watchErr)
-				} else {
-					log.Printf("[ORCH] Auto-watching workspace: %s", ws.Path)
+			if ws, err := a.db.GetActiveWorkspace(); err == nil {
+				for _, repoPath := range a.workspaceRepoPaths(ws) {
+					if watchErr := a.fileWatcher.Watch(repoPath); watchErr != nil {
+						log.Printf("[ORCH] Could not auto-watch workspace repo %s: %v", repoPath, watchErr)
+					} else {
+						log.Printf("[ORCH] Auto-watching workspace repo: %s", repoPath)
+					}
 				}
 			}
+			a.restoreGitAutoFetchSettings()
+			a.restoreWorkspaceFileWatching()
 		}
 	}
+	doneFileWatcher(err)
 
 	// 8. Inicializar Session Service (P2P)
+	doneSession := a.startupTimings.Begin("session")
 	a.session = session.NewService(func(eventName string, data interface{}) {
 		a.observeSessionTelemetry(eventName, data)
 		runtime.EventsEmit(a.ctx, eventName, data)
@@ -333,6 +637,7 @@ func (a *App) Startup(ctx context.Context) {
 		}
 		if connected {
 			a.auditSessionEvent(sessionID, userID, "guest_connected", "Guest established signaling connection")
+			a.lifecycleHooks.Fire(hooks.EventSessionGuestJoined, map[string]string{"sessionId": sessionID, "userId": userID})
 			return
 		}
 		a.auditSessionEvent(sessionID, userID, "guest_disconnected", "Guest disconnected from signaling channel")
@@ -343,21 +648,38 @@ func (a *App) Startup(ctx context.Context) {
 		}
 	})
 
-	// Iniciar servidor de sinalização WebSocket configurável.
+	doneSession(nil)
+
+	// Iniciar servidor de sinalização WebSocket configurável (em background).
 	if shouldStartSessionListener(a.signalingAddr) {
-		if err := a.signaling.StartSignalingServerAddr(a.signalingAddr); err != nil {
-			log.Printf("[ORCH] Error starting signaling server on %s: %v", a.signalingAddr, err)
-		} else {
-			log.Printf("[ORCH] Signaling server started on %s", a.signalingAddr)
-		}
+		signalingAddr := a.signalingAddr
+		a.startupTimings.Go("signaling", func() error {
+			if err := a.signaling.StartSignalingServerAddr(signalingAddr); err != nil {
+				log.Printf("[ORCH] Error starting signaling server on %s: %v", signalingAddr, err)
+				return err
+			}
+			log.Printf("[ORCH] Signaling server started on %s", signalingAddr)
+			return nil
+		})
 	} else {
 		log.Printf("[ORCH] Signaling listener disabled (addr=%q)", a.signalingAddr)
 	}
 
 	// Iniciar gateway HTTP local de sessões (compartilha sessões entre instâncias locais).
+	// O dono do gateway também mantém a tabela de leases das demais instâncias.
+	doneGateway := a.startupTimings.Begin("gateway")
+	leaseTable := coordination.NewTable()
 	if shouldStartSessionListener(a.sessionGatewayAddr) {
 		a.sessionHTTP = session.NewGatewayServer(a.session, a.sessionGatewayAddr)
 		a.sessionHTTP.SetObservers(a.persistSessionState, a.deletePersistedSessionState)
+		a.sessionHTTP.Handle(coordination.RoutePrefix, coordination.NewHandler(leaseTable))
+		a.sessionHTTP.Handle(gitshare.RoutePrefix, gitshare.NewHandler(a.gitShares, gitShareBackend{a}))
+		a.sessionHTTP.Handle(sessionreview.RoutePrefix, sessionreview.NewHandler(a.prReviews, sessionReviewBackend{a}))
+		if token, err := cliapi.LoadOrCreateToken(cliapi.TokenPath()); err != nil {
+			log.Printf("[ORCH] orchctl routes disabled: %v", err)
+		} else {
+			a.sessionHTTP.Handle(cliapi.RoutePrefix, cliapi.NewHandler(cliBackend{a}, token))
+		}
 		if err := a.sessionHTTP.Start(); err != nil {
 			a.sessionGatewayOwner = false
 			log.Printf("[ORCH] Session gateway unavailable on %s (using client mode): %v", a.sessionGatewayAddr, err)
@@ -369,12 +691,60 @@ func (a *App) Startup(ctx context.Context) {
 		a.sessionGatewayOwner = false
 		log.Printf("[ORCH] Session gateway listener disabled (addr=%q); using client mode", a.sessionGatewayAddr)
 	}
+	a.startCoordination(leaseTable)
+	doneGateway(nil)
+	go a.startSessionPolicyMonitor()
 
 	// 9. Configuração finalizada
 	log.Println("[ORCH] Startup complete")
 
 	// 10. Iniciar monitoramento de contexto de terminais (Auto-Git Sync)
 	go a.startTerminalContextMonitor()
+
+	// 11. Health check periódico dos subsistemas (indicador da status bar)
+	a.startHealthMonitor()
+
+	// Subir agentes com auto-start (app_launch e workspace_activated do workspace ativo)
+	go a.autoStartOnLaunch()
+
+	// 12. Auto-update via GitHub Releases (verificação em background após o startup)
+	a.updater = updater.NewService(updater.Options{
+		Repository:     config.UpdateRepository,
+		CurrentVersion: config.AppVersion,
+		CacheDir:       config.CacheDir(),
+		Emit: func(eventName string, data interface{}) {
+			if a.ctx == nil {
+				return
+			}
+			runtime.EventsEmit(a.ctx, eventName, data)
+		},
+	})
+	time.AfterFunc(startupUpdateCheckDelay, a.checkForUpdatesInBackground)
+	time.AfterFunc(startupDiskUsageCheckDelay, a.checkDiskUsageInBackground)
+
+	// 13. Aplicar config.toml aos serviços e recarregar quando o arquivo mudar
+	a.applyConfigFile(a.effectiveConfig())
+	a.configFile.Watch(configFileWatchInterval, a.onConfigFileReloaded)
+	go a.wireGitHubWhenReady()
+
+	// 14. Hooks de usuário do startup
+	a.lifecycleHooks.Fire(hooks.EventAppStartup, map[string]string{"version": config.AppVersion, "dataDir": config.DataDir()})
+
+	// 15. Servidor MCP local (se habilitado nas configurações)
+	if err := a.applyMCPSettings(); err != nil {
+		log.Printf("[MCP] failed to start server: %v", err)
+	}
+
+	// 16. API REST local (se habilitada nas configurações)
+	if err := a.applyRESTAPISettings(); err != nil {
+		log.Printf("[REST] failed to start server: %v", err)
+	}
+
+	// 17. Histórico de clipboard (opt-in)
+	a.applyClipboardHistorySettings()
+
+	a.startupTimings.MarkWindowReady()
+	log.Printf("[ORCH] Window ready after %dms", a.startupTimings.Timings().WindowReadyMs)
 }
 
 func (a *App) startTerminalContextMonitor() {
@@ -518,13 +888,54 @@ func findGitRepoRoot(startPath string) string {
 }
 
 func (a *App) emitGitRuntimeEvent(eventName string, data interface{}) {
-	runtime.EventsEmit(a.ctx, eventName, data)
+	a.gitEvents.Emit(eventName, data)
 	a.bridgeLegacyGitEventToGitPanel(eventName, data)
 	a.appendGitActivityFromRuntimeEvent(eventName, data)
 }
 
 func (a *App) emitGitPanelRuntimeEvent(eventName string, data interface{}) {
-	runtime.EventsEmit(a.ctx, eventName, data)
+	payload, _ := data.(map[string]string)
+	if eventName == "gitpanel:status_changed" && payload != nil && a.ctx != nil {
+		a.gitEvents.Emit(eventName, a.gitPanelStatusChangedPayload(extractRepoPathFromGitEventData(data), payload))
+	} else {
+		a.gitEvents.Emit(eventName, data)
+	}
+	switch eventName {
+	case "gitpanel:status_changed", "gitpanel:history_invalidated", "gitpanel:conflicts_changed":
+		a.relayGitPanelEventToGuests(extractRepoPathFromGitEventData(data), eventName, payload["sourceEvent"], payload["reason"])
+	}
+}
+
+// gitPanelStatusChangedPayload anexa o delta do status ("delta") ao payload de
+// gitpanel:status_changed para o frontend aplicar sem novo GetStatus. Sem delta (repo inválido,
+// falha do git) o payload segue como antes e o frontend refaz a leitura.
+func (a *App) gitPanelStatusChangedPayload(repoPath string, base map[string]string) interface{} {
+	if a.gitPanel == nil || strings.TrimSpace(repoPath) == "" {
+		return base
+	}
+	delta, err := a.gitPanel.ComputeStatusDelta(repoPath)
+	if err != nil {
+		return base
+	}
+	payload := make(map[string]interface{}, len(base)+1)
+	for key, value := range base {
+		payload[key] = value
+	}
+	payload["delta"] = delta
+	return payload
+}
+
+// gitEventBypassesBatch indica os eventos que saem na hora mesmo durante uma rajada: conflitos e
+// tudo que não seja invalidação (resultados de comandos, progresso etc.). O guest relay e a
+// timeline continuam recebendo cada evento individualmente.
+func gitEventBypassesBatch(eventName string) bool {
+	switch eventName {
+	case "git:merge", "gitpanel:conflicts_changed":
+		return true
+	case "gitpanel:status_changed", "gitpanel:history_invalidated", fw.WorkspaceFileChangedEvent:
+		return false
+	}
+	return !strings.HasPrefix(eventName, "git:")
 }
 
 func (a *App) bridgeLegacyGitEventToGitPanel(eventName string, data interface{}) {
@@ -539,7 +950,7 @@ func (a *App) bridgeLegacyGitEventToGitPanel(eventName string, data interface{})
 
 func mapLegacyGitEventToGitPanelInvalidation(eventName string) gitPanelInvalidationPlan {
 	switch strings.TrimSpace(eventName) {
-	case "git:index":
+	case "git:index", "git:worktree":
 		return gitPanelInvalidationPlan{Status: true}
 	case "git:merge":
 		return gitPanelInvalidationPlan{Status: true, Conflicts: true}
@@ -578,7 +989,11 @@ func (a *App) queueGitPanelInvalidation(repoPath string, sourceEvent string, rea
 	pending.conflicts = pending.conflicts || plan.Conflicts
 
 	if pending.timer == nil {
-		pending.timer = time.AfterFunc(gitPanelEventDebounceWindow, func() {
+		debounce := a.gitPanelDebounce
+		if debounce <= 0 {
+			debounce = gitPanelEventDebounceWindow
+		}
+		pending.timer = time.AfterFunc(debounce, func() {
 			a.flushGitPanelInvalidation(key)
 		})
 	}
@@ -618,6 +1033,10 @@ func (a *App) emitGitPanelInvalidationEvents(repoPath string, sourceEvent string
 	}
 	if a.gitPanel != nil {
 		a.gitPanel.InvalidateRepoCache(repoPath)
+		// Após trocar de branch tudo muda de uma vez: recalcula em background antes do painel pedir.
+		if strings.TrimSpace(sourceEvent) == "git:branch_changed" {
+			a.warmGitPanelCacheAsync(repoPath)
+		}
 	}
 
 	basePayload := map[string]string{
@@ -625,15 +1044,21 @@ func (a *App) emitGitPanelInvalidationEvents(repoPath string, sourceEvent string
 		"sourceEvent": strings.TrimSpace(sourceEvent),
 		"reason":      strings.TrimSpace(reason),
 	}
+	workspaceIDs, agentIDs := a.resolveRepoScope(repoPath)
+	basePayload["workspaceIds"] = joinUintIDs(workspaceIDs)
+	basePayload["agentIds"] = joinUintIDs(agentIDs)
 
 	if status {
-		runtime.EventsEmit(a.ctx, "gitpanel:status_changed", cloneGitPanelEventPayload(basePayload))
+		runtime.EventsEmit(a.ctx, "gitpanel:status_changed", a.gitPanelStatusChangedPayload(repoPath, cloneGitPanelEventPayload(basePayload)))
+		a.relayGitPanelEventToGuests(repoPath, "gitpanel:status_changed", sourceEvent, reason)
 	}
 	if history {
 		runtime.EventsEmit(a.ctx, "gitpanel:history_invalidated", cloneGitPanelEventPayload(basePayload))
+		a.relayGitPanelEventToGuests(repoPath, "gitpanel:history_invalidated", sourceEvent, reason)
 	}
 	if conflicts {
 		runtime.EventsEmit(a.ctx, "gitpanel:conflicts_changed", cloneGitPanelEventPayload(basePayload))
+		a.relayGitPanelEventToGuests(repoPath, "gitpanel:conflicts_changed", sourceEvent, reason)
 	}
 }
 
@@ -704,6 +1129,10 @@ func (a *App) appendGitActivityFromRuntimeEvent(eventName string, data interface
 	if a.gitActivity == nil || !strings.HasPrefix(eventName, "git:") {
 		return
 	}
+	// Edições no working tree só invalidam o Git Panel; não entram no feed de atividade.
+	if eventName == "git:worktree" {
+		return
+	}
 
 	fileEvent, ok := toFileWatcherEvent(data)
 	if !ok {
@@ -753,7 +1182,7 @@ func (a *App) buildGitActivityEvent(eventName string, fileEvent fw.FileEvent) (g
 	if actor == "" {
 		actor = "local-user"
 	}
-	message := formatGitActivityMessage(actor, eventType, repoName, branch, ref)
+	message, messageRef := formatGitActivityMessage(a.translator.Locale(), actor, eventType, repoName, branch, ref)
 
 	extra := make(map[string]string)
 	for key, value := range fileEvent.Details {
@@ -795,16 +1224,18 @@ func (a *App) buildGitActivityEvent(eventName string, fileEvent fw.FileEvent) (g
 	}
 
 	return ga.Event{
-		Type:      eventType,
-		ActorName: actor,
-		RepoPath:  repoPath,
-		RepoName:  repoName,
-		Branch:    branch,
-		Message:   message,
-		Timestamp: timestamp,
-		Source:    source,
-		DedupeKey: dedupeKey,
-		Details:   details,
+		Type:          eventType,
+		ActorName:     actor,
+		RepoPath:      repoPath,
+		RepoName:      repoName,
+		Branch:        branch,
+		Message:       message,
+		MessageID:     messageRef.ID,
+		MessageParams: messageRef.Params,
+		Timestamp:     timestamp,
+		Source:        source,
+		DedupeKey:     dedupeKey,
+		Details:       details,
 	}, true
 }
 
@@ -827,34 +1258,36 @@ func mapGitRuntimeEventType(eventName string) ga.EventType {
 	}
 }
 
-func formatGitActivityMessage(actor string, eventType ga.EventType, repoName, branch, ref string) string {
+func formatGitActivityMessage(locale string, actor string, eventType ga.EventType, repoName, branch, ref string) (string, i18n.Message) {
 	repo := strings.TrimSpace(repoName)
 	if repo == "" {
-		repo = "repository"
+		repo = i18n.Localize(locale, i18n.GitActivityUnknownRepo, nil)
 	}
 
+	message := i18n.Message{ID: i18n.GitActivityGeneric, Params: map[string]string{"actor": actor, "repo": repo}}
 	switch eventType {
 	case ga.EventTypeBranchChanged:
+		message.ID = i18n.GitActivityBranchChangedUnknown
 		if branch != "" {
-			return fmt.Sprintf("%s mudou para a branch %s no repositório %s", actor, branch, repo)
+			message.ID = i18n.GitActivityBranchChanged
+			message.Params["branch"] = branch
 		}
-		return fmt.Sprintf("%s alterou branch no repositório %s", actor, repo)
 	case ga.EventTypeCommitCreated:
+		message.ID = i18n.GitActivityCommitCreated
 		if ref != "" {
-			return fmt.Sprintf("%s atualizou a ref %s no repositório %s", actor, ref, repo)
+			message.ID = i18n.GitActivityRefUpdated
+			message.Params["ref"] = ref
 		}
-		return fmt.Sprintf("%s criou um commit no repositório %s", actor, repo)
 	case ga.EventTypeCommitPreparing:
-		return fmt.Sprintf("%s iniciou preparação de commit no repositório %s", actor, repo)
+		message.ID = i18n.GitActivityCommitPreparing
 	case ga.EventTypeIndexUpdated:
-		return fmt.Sprintf("%s atualizou arquivos staged no repositório %s", actor, repo)
+		message.ID = i18n.GitActivityIndexUpdated
 	case ga.EventTypeMerge:
-		return fmt.Sprintf("%s executou merge no repositório %s", actor, repo)
+		message.ID = i18n.GitActivityMerge
 	case ga.EventTypeFetch:
-		return fmt.Sprintf("%s executou fetch no repositório %s", actor, repo)
-	default:
-		return fmt.Sprintf("%s executou uma ação Git no repositório %s", actor, repo)
+		message.ID = i18n.GitActivityFetch
 	}
+	return i18n.Localize(locale, message.ID, message.Params), message
 }
 
 func extractRepoPathFromGitEventPath(eventPath string) string {
@@ -966,12 +1399,122 @@ func (a *App) DomReady(ctx context.Context) {
 	a.emitHydration()
 }
 
+// githubReadyTimeout limita quanto uma binding espera pela etapa "github" do Startup.
+const githubReadyTimeout = 10 * time.Second
+
+// initGitHub monta o GitHub Service, o Poller e a bridge de webhooks (etapa async do Startup).
+func (a *App) initGitHub() {
+	a.github = gh.NewService(a.auth.GetGitHubToken)
+	a.github.SetRateBudgetStore(filepath.Join(config.CacheDir(), "github_rate_budget.json"))
+	a.github.SetTokenRouter(a.gitHubTokenForOwner)
+	a.github.SetCredentialInspector(a.gitHubCredentialForOwner)
+	a.github.SetUnauthorizedRefresher(a.refreshGitHubTokenForOwner)
+	a.auth.SetGitHubAppTokenMinter(a.mintGitHubAppToken)
+	a.github.SetTelemetryEmitter(func(eventName string, data interface{}) {
+		if a.ctx == nil {
+			return
+		}
+		if strings.TrimSpace(eventName) == "" {
+			return
+		}
+		runtime.EventsEmit(a.ctx, eventName, data)
+	})
+	a.poller = gh.NewPoller(a.github, func(eventName string, data interface{}) {
+		runtime.EventsEmit(a.ctx, eventName, data)
+	})
+	a.ghWebhook = gh.NewWebhookBridge(a.poller.HandleWebhookEvent, func(status gh.WebhookBridgeStatus) {
+		a.poller.SetPushMode(status.Connected)
+		if a.ctx == nil {
+			return
+		}
+		runtime.EventsEmit(a.ctx, "github:webhook:status", status)
+	})
+}
+
+// waitGitHubStartup espera a etapa "github" do Startup; false se ela não terminou a tempo.
+// Sem Startup (testes que montam o App direto) não há o que esperar.
+func (a *App) waitGitHubStartup() bool {
+	if a.githubReady == nil {
+		return true
+	}
+	select {
+	case <-a.githubReady:
+		return true
+	case <-time.After(githubReadyTimeout):
+		return false
+	}
+}
+
+// awaitGitHub espera a etapa "github" do Startup e informa se o GitHub Service está disponível.
+func (a *App) awaitGitHub() bool {
+	return a.waitGitHubStartup() && a.github != nil
+}
+
+// gitHubStarted é a versão sem espera de awaitGitHub, para Shutdown e reload de config.
+func (a *App) gitHubStarted() bool {
+	if a.githubReady != nil {
+		select {
+		case <-a.githubReady:
+		default:
+			return false
+		}
+	}
+	return a.github != nil
+}
+
+// wireGitHubWhenReady aplica config.toml e o lease do poller assim que a etapa "github" termina;
+// applyConfigFile e startCoordination rodam antes dela e pulam o poller.
+func (a *App) wireGitHubWhenReady() {
+	if !a.awaitGitHub() {
+		return
+	}
+	a.applyGitHubConfig(a.effectiveConfig())
+	if a.coordinator != nil {
+		a.poller.SetLeaseGate(a.coordinator.Acquire, a.coordinator.Release)
+	}
+}
+
+// gitHubCacheReader expõe o cache de PRs ao AI Service sem esperar a etapa "github":
+// até ela terminar o cache está vazio.
+type gitHubCacheReader struct {
+	app *App
+}
+
+func (r gitHubCacheReader) GetCachedPullRequest(owner, repo string, number int) (*gh.PullRequest, bool) {
+	if !r.app.gitHubStarted() {
+		return nil, false
+	}
+	return r.app.github.GetCachedPullRequest(owner, repo, number)
+}
+
 // Shutdown is called when the app is shutting down
 func (a *App) Shutdown(ctx context.Context) {
-	// Parar Poller
-	if a.poller != nil {
+	a.gitEvents.Close()
+
+	// Parar Poller e bridge de webhooks
+	if a.gitHubStarted() {
 		a.poller.StopPolling()
+		a.ghWebhook.Stop()
+		a.github.FlushRateBudget()
 	}
+	if a.auth != nil {
+		a.auth.StopTokenRefresher()
+	}
+	if a.backup != nil {
+		a.backup.Stop()
+	}
+	a.stopHealthMonitor()
+	if a.configFile != nil {
+		a.configFile.Stop()
+	}
+	// Cancelar pushes, fetches e builds em andamento antes de fechar os serviços que eles usam
+	a.jobs.Shutdown()
+	a.lifecycleHooks.Close()
+	a.notifications.Close()
+	a.mcpServer.Stop()
+	a.restAPI.Stop()
+	a.clipboardHistory.StopWatching()
+	a.focusTimer.Close()
 	log.Println("[ORCH] Shutting down...")
 
 	// Notificar frontend que app está fechando (para salvar snapshots)
@@ -1011,6 +1554,8 @@ func (a *App) Shutdown(ctx context.Context) {
 			pairs[sessionID] = containerID
 		}
 		a.sessionContainers = make(map[string]string)
+		routes := a.sessionEgress
+		a.sessionEgress = make(map[string]*egressRoute)
 		a.mu.Unlock()
 
 		for sessionID, containerID := range pairs {
@@ -1018,8 +1563,14 @@ func (a *App) Shutdown(ctx context.Context) {
 			_ = a.docker.RemoveContainer(containerID)
 			a.auditSessionEvent(sessionID, "system", "container_stopped", fmt.Sprintf("container=%s shutdown=true", containerID))
 		}
+		for _, route := range routes {
+			a.closeEgressRoute(route)
+		}
 	}
 
+	// Liberar leases antes de derrubar o gateway, para que outra instância assuma logo
+	a.coordinator.Close()
+
 	// Encerrar gateway HTTP de sessões
 	if a.sessionHTTP != nil {
 		shutdownCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
@@ -1035,6 +1586,13 @@ func (a *App) Shutdown(ctx context.Context) {
 			log.Printf("[ORCH] Error closing database: %v", err)
 		}
 	}
+
+	a.StopLogFollow()
+	a.SetAPIInspectorEnabled(false)
+	a.cancelWorkspaceSearches(0)
+	if err := logging.Default().Close(); err != nil {
+		log.Printf("[ORCH] Error closing log file: %v", err)
+	}
 }
 
 // emitHydration envia o estado inicial para o frontend
@@ -1123,7 +1681,7 @@ func (a *App) resolvePreferredLocalShell() string {
 
 // === Terminal Bindings (expostos ao Frontend) ===
 
-func (a *App) createTerminalWithArgs(shell string, args []string, cwd string, useDocker bool, cols uint16, rows uint16) (string, error) {
+func (a *App) createTerminalWithArgs(shell string, args []string, env []string, cwd string, useDocker bool, dockerImage string, cols uint16, rows uint16) (string, error) {
 	shell = strings.TrimSpace(shell)
 	if useDocker {
 		if shell == "" {
@@ -1149,6 +1707,7 @@ func (a *App) createTerminalWithArgs(shell string, args []string, cwd string, us
 	cfg := terminal.PTYConfig{
 		Shell:     shell,
 		Args:      append([]string(nil), args...),
+		Env:       append([]string(nil), env...),
 		Cwd:       cwd,
 		Cols:      cols,
 		Rows:      rows,
@@ -1160,12 +1719,19 @@ func (a *App) createTerminalWithArgs(shell string, args []string, cwd string, us
 		cfg.DockerMount = cwd
 	}
 
-	// Se for Docker, verificar se existe imagem customizada
+	// Se for Docker, usar a imagem explícita ou a imagem padrão do workspace/stack customizada
 	if useDocker && a.docker != nil {
-		customImage := "orch-custom-stack:latest"
-		if a.docker.ImageExists(customImage) {
-			cfg.DockerImage = customImage
-			log.Printf("[ORCH] CreateTerminal using custom stack: %s", customImage)
+		image := strings.TrimSpace(dockerImage)
+		if image == "" {
+			var activeWorkspace *database.Workspace
+			if a.db != nil {
+				activeWorkspace, _ = a.db.GetActiveWorkspace()
+			}
+			image = a.resolveWorkspaceDockerImage(activeWorkspace)
+		}
+		if image != "" {
+			cfg.DockerImage = image
+			log.Printf("[ORCH] CreateTerminal using docker image: %s", image)
 		}
 	}
 
@@ -1176,7 +1742,14 @@ func (a *App) createTerminalWithArgs(shell string, args []string, cwd string, us
 
 	a.terminalStateMu.Lock()
 	a.terminalHistory[sessionID] = ""
+	a.terminalBytes[sessionID] = 0
 	a.terminalStateMu.Unlock()
+	a.lifecycleHooks.Fire(hooks.EventTerminalCreated, map[string]string{
+		"sessionId": sessionID,
+		"shell":     shell,
+		"cwd":       cwd,
+		"useDocker": strconv.FormatBool(useDocker),
+	})
 
 	if a.ai != nil {
 		shellForAI := strings.TrimSpace(shell)
@@ -1223,7 +1796,47 @@ func (a *App) createTerminalWithArgs(shell string, args []string, cwd string, us
 
 // CreateTerminal cria um novo terminal PTY e retorna o session ID
 func (a *App) CreateTerminal(shell string, cwd string, useDocker bool, cols uint16, rows uint16) (string, error) {
-	return a.createTerminalWithArgs(shell, nil, cwd, useDocker, cols, rows)
+	return a.createTerminalWithArgs(shell, nil, nil, cwd, useDocker, "", cols, rows)
+}
+
+// CreateDockerTerminalWithImage cria um terminal Docker usando a imagem selecionada pelo usuário.
+func (a *App) CreateDockerTerminalWithImage(shell string, cwd string, dockerImage string, cols uint16, rows uint16) (string, error) {
+	image, err := normalizeDockerImageRef(dockerImage)
+	if err != nil {
+		return "", err
+	}
+	return a.createTerminalWithArgs(shell, nil, nil, cwd, true, image, cols, rows)
+}
+
+// resolveWorkspaceDockerImage resolve a imagem Docker preferida: imagem do workspace,
+// depois a stack default. Retorna vazio quando nenhuma imagem customizada está disponível.
+func (a *App) resolveWorkspaceDockerImage(ws *database.Workspace) string {
+	if a.docker == nil {
+		return ""
+	}
+	if ws != nil {
+		if image := strings.TrimSpace(ws.DockerImage); image != "" {
+			if !docker.IsStackImage(image) || a.docker.ImageExists(image) {
+				return image
+			}
+			log.Printf("[ORCH] workspace %d docker image %s not built; falling back", ws.ID, image)
+		}
+	}
+	if a.docker.ImageExists(docker.DefaultStackImage) {
+		return docker.DefaultStackImage
+	}
+	return ""
+}
+
+func (a *App) resolveAgentWorkspace(agent *database.AgentSession) *database.Workspace {
+	if a.db == nil || agent == nil || agent.WorkspaceID == 0 {
+		return nil
+	}
+	ws, err := a.db.GetWorkspace(agent.WorkspaceID)
+	if err != nil {
+		return nil
+	}
+	return ws
 }
 
 func (a *App) resolveEffectiveRuntimeShell(shell string, useDocker bool) string {
@@ -1275,11 +1888,20 @@ func (a *App) CreateTerminalForAgent(agentID uint, shell string, cwd string, use
 	if strings.TrimSpace(resolvedCwd) == "" {
 		resolvedCwd = agent.Cwd
 	}
+	if strings.TrimSpace(resolvedCwd) == "" {
+		resolvedCwd = agent.RepoPath
+	}
+
+	dockerImage := ""
+	if useDocker {
+		dockerImage = a.resolveWorkspaceDockerImage(a.resolveAgentWorkspace(agent))
+	}
 
-	sessionID, err := a.createTerminalWithArgs(resolvedShell, nil, resolvedCwd, useDocker, cols, rows)
+	sessionID, err := a.createTerminalWithArgs(resolvedShell, nil, decodeAgentStringList(agent.Env), resolvedCwd, useDocker, dockerImage, cols, rows)
 	if err != nil {
 		return "", err
 	}
+	a.runAgentStartupCommands(sessionID, agent)
 
 	effectiveShell := a.resolveEffectiveRuntimeShell(resolvedShell, useDocker)
 
@@ -1300,6 +1922,55 @@ func (a *App) CreateTerminalForAgent(agentID uint, shell string, cwd string, use
 
 // CreateTerminalForAgentResume cria sessão já com comando de resume da CLI, sem "digitar" comando no terminal.
 func (a *App) CreateTerminalForAgentResume(agentID uint, cliType string, shell string, cwd string, useDocker bool, cols uint16, rows uint16) (string, error) {
+	return a.createTerminalForAgentResume(agentID, cliType, shell, cwd, useDocker, nil, cols, rows)
+}
+
+// RestoreTerminalForAgent recria o terminal de um agente a partir do snapshot salvo, incluindo
+// os comandos de replay. Com CLI a retomar, eles entram no bootstrap do resume; sem CLI são
+// digitados no shell interativo (como os startup commands), preservando funções e variáveis.
+func (a *App) RestoreTerminalForAgent(agentID uint, snapshot TerminalSnapshotDTO, cols uint16, rows uint16) (string, error) {
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	replay, err := normalizeStartupCommands(snapshot.ReplayCommands)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := resumeCommandForCLI(snapshot.CLIType); ok {
+		return a.createTerminalForAgentResume(agentID, snapshot.CLIType, snapshot.Shell, snapshot.Cwd, snapshot.UseDocker, replay, cols, rows)
+	}
+
+	agent, err := a.db.GetAgent(agentID)
+	if err != nil {
+		return "", err
+	}
+	if agent.SessionID != "" && a.bridge != nil && a.bridge.IsTerminalAlive(agent.SessionID) {
+		// Terminal ainda vivo: nada foi perdido, então não há o que reexecutar.
+		a.bindTerminalToAgent(agent.SessionID, agent.ID)
+		return agent.SessionID, nil
+	}
+	sessionID, err := a.CreateTerminalForAgent(agentID, snapshot.Shell, snapshot.Cwd, snapshot.UseDocker, cols, rows)
+	if err != nil {
+		return "", err
+	}
+	a.writeTerminalCommands(sessionID, agent.ID, replay)
+	return sessionID, nil
+}
+
+// resumeBootstrapScript monta o script passado ao shell com -c: comandos de replay (sob set -a,
+// para que variáveis de `source .env` cheguem à CLI), o resume e, ao sair, o shell de login.
+func resumeBootstrapScript(replay []string, resumeCmd string, shell string) string {
+	parts := make([]string, 0, len(replay)+4)
+	if len(replay) > 0 {
+		parts = append(parts, "set -a")
+		parts = append(parts, replay...)
+		parts = append(parts, "set +a")
+	}
+	parts = append(parts, resumeCmd, "exec "+shellSingleQuote(shell)+" -l")
+	return strings.Join(parts, "; ")
+}
+
+func (a *App) createTerminalForAgentResume(agentID uint, cliType string, shell string, cwd string, useDocker bool, replay []string, cols uint16, rows uint16) (string, error) {
 	if a.db == nil {
 		return "", fmt.Errorf("database not initialized")
 	}
@@ -1341,8 +2012,12 @@ func (a *App) CreateTerminalForAgentResume(agentID uint, cliType string, shell s
 		resolvedCwd = home
 	}
 
-	bootstrap := fmt.Sprintf("%s; exec %s -l", resumeCmd, shellSingleQuote(resolvedShell))
-	sessionID, err := a.createTerminalWithArgs(resolvedShell, []string{"-c", bootstrap}, resolvedCwd, useDocker, cols, rows)
+	bootstrap := resumeBootstrapScript(replay, resumeCmd, resolvedShell)
+	dockerImage := ""
+	if useDocker {
+		dockerImage = a.resolveWorkspaceDockerImage(a.resolveAgentWorkspace(agent))
+	}
+	sessionID, err := a.createTerminalWithArgs(resolvedShell, []string{"-c", bootstrap}, decodeAgentStringList(agent.Env), resolvedCwd, useDocker, dockerImage, cols, rows)
 	if err != nil {
 		return "", err
 	}
@@ -1364,15 +2039,42 @@ func (a *App) CreateTerminalForAgentResume(agentID uint, cliType string, shell s
 // WriteTerminal envia dados para o terminal
 func (a *App) WriteTerminal(sessionID string, data string) error {
 	decoded := decodeTerminalInput(data)
+	if a.ptyMgr == nil {
+		a.observeTerminalInput(sessionID, decoded)
+		return fmt.Errorf("pty manager not initialized")
+	}
 
+	// Em um grupo de broadcast o input vai também para os demais membros habilitados; só a
+	// falha no terminal de origem é devolvida ao frontend.
+	for _, targetID := range a.ptyMgr.BroadcastTargets(sessionID) {
+		// Terminal com comando de guest retido só recebe input direto do host.
+		if targetID != sessionID && a.commandGuard.Holding(targetID) {
+			continue
+		}
+		a.observeTerminalInput(targetID, decoded)
+		if targetID == sessionID {
+			continue
+		}
+		if err := a.ptyMgr.Write(targetID, decoded); err != nil {
+			log.Printf("[ORCH] terminal broadcast to %s failed: %v", targetID, err)
+		}
+	}
+	return a.ptyMgr.Write(sessionID, decoded)
+}
+
+func (a *App) observeTerminalInput(sessionID string, decoded []byte) {
 	if a.ai != nil {
 		a.ai.ObserveTerminalInput(sessionID, decoded)
 	}
-
-	if a.ptyMgr == nil {
-		return fmt.Errorf("pty manager not initialized")
+	if a.testRuns != nil {
+		a.testRuns.ObserveInput(sessionID, decoded)
+	}
+	if a.problemCollector != nil {
+		a.problemCollector.ObserveInput(sessionID, decoded)
+	}
+	if a.transcripts != nil {
+		a.transcripts.ObserveInput(sessionID, decoded)
 	}
-	return a.ptyMgr.Write(sessionID, decoded)
 }
 
 func (a *App) resolveSessionHostUserID() string {
@@ -1472,15 +2174,21 @@ func (a *App) resolveTerminalWorkspaceID(terminalSessionID string) (uint, error)
 }
 
 func (a *App) resolveGuestTerminalPermission(terminalSessionID, guestUserID string) (terminal.TerminalPermission, error) {
-	guestUserID = strings.TrimSpace(guestUserID)
+	_, permission, err := a.resolveGuestTerminalAccess(terminalSessionID, guestUserID)
+	return permission, err
+}
+
+// resolveGuestTerminalAccess é resolveGuestTerminalPermission devolvendo também a sessão ativa.
+func (a *App) resolveGuestTerminalAccess(terminalSessionID, guestUserID string) (*session.Session, terminal.TerminalPermission, error) {
+	guestUserID = strings.TrimSpace(guestUserID)
 	if guestUserID == "" {
-		return terminal.PermissionNone, fmt.Errorf("guest userID is required")
+		return nil, terminal.PermissionNone, fmt.Errorf("guest userID is required")
 	}
 
 	hostUserID := a.resolveSessionHostUserID()
 	activeSession, err := a.resolveActiveCollabSession(hostUserID)
 	if err != nil {
-		return terminal.PermissionNone, err
+		return nil, terminal.PermissionNone, err
 	}
 
 	var guestSession session.SessionGuest
@@ -1493,22 +2201,22 @@ func (a *App) resolveGuestTerminalPermission(terminalSessionID, guestUserID stri
 		}
 	}
 	if !foundGuest {
-		return terminal.PermissionNone, fmt.Errorf("guest %s is not part of active session", guestUserID)
+		return nil, terminal.PermissionNone, fmt.Errorf("guest %s is not part of active session", guestUserID)
 	}
 
 	permission := guestTerminalPermissionFromSession(guestSession)
 	if permission == terminal.PermissionNone {
-		return terminal.PermissionNone, fmt.Errorf("guest %s has no write permission", guestUserID)
+		return nil, terminal.PermissionNone, fmt.Errorf("guest %s has no write permission", guestUserID)
 	}
 
 	scopedWorkspaceID := activeSession.Config.WorkspaceID
 	if scopedWorkspaceID > 0 {
 		terminalWorkspaceID, err := a.resolveTerminalWorkspaceID(terminalSessionID)
 		if err != nil {
-			return terminal.PermissionNone, err
+			return nil, terminal.PermissionNone, err
 		}
 		if terminalWorkspaceID != scopedWorkspaceID {
-			return terminal.PermissionNone, fmt.Errorf(
+			return nil, terminal.PermissionNone, fmt.Errorf(
 				"terminal %s is outside scoped workspace %d",
 				terminalSessionID,
 				scopedWorkspaceID,
@@ -1516,7 +2224,7 @@ func (a *App) resolveGuestTerminalPermission(terminalSessionID, guestUserID stri
 		}
 	}
 
-	return permission, nil
+	return activeSession, permission, nil
 }
 
 func (a *App) applyPermissionToAllPTY(guestUserID string, perm terminal.TerminalPermission) {
@@ -1583,28 +2291,49 @@ func (a *App) WriteTerminalAsGuest(sessionID, userID, data string) error {
 		return fmt.Errorf("pty manager not initialized")
 	}
 
-	permission, err := a.resolveGuestTerminalPermission(sessionID, userID)
+	activeSession, permission, err := a.resolveGuestTerminalAccess(sessionID, userID)
 	if err != nil {
 		return err
 	}
 	if err := a.ptyMgr.SetPermission(sessionID, userID, permission); err != nil {
 		return err
 	}
+	return a.forwardGuestTerminalInput(activeSession, sessionID, userID, decodeTerminalInput(data))
+}
 
-	decoded := decodeTerminalInput(data)
-	if err := a.ptyMgr.WriteWithPermission(sessionID, userID, decoded); err != nil {
+// forwardGuestTerminalInput passa o input do guest pelo guard de comandos perigosos e envia ao
+// PTY o que foi liberado; a linha retida espera SessionApproveCommand/SessionDenyCommand.
+func (a *App) forwardGuestTerminalInput(activeSession *session.Session, terminalID, userID string, data []byte) error {
+	if a.ptyMgr.GetPermission(terminalID, userID) != terminal.PermissionReadWrite {
+		return a.ptyMgr.WriteWithPermission(terminalID, userID, data)
+	}
+	forward, held, err := a.commandGuard.Inspect(activeSession.ID, terminalID, userID, data, activeSession.Config.RequireCommandApproval)
+	if err != nil {
 		return err
 	}
+	if len(forward) > 0 {
+		if err := a.ptyMgr.WriteWithPermission(terminalID, userID, forward); err != nil {
+			return err
+		}
+	}
+	a.touchSessionGuestActivity(userID)
+	if a.transcripts != nil {
+		a.transcripts.ObserveInput(terminalID, forward)
+	}
 
 	// Registra somente entradas com quebra de linha para reduzir ruído.
-	raw := string(decoded)
+	raw := string(forward)
 	if strings.ContainsAny(raw, "\r\n") {
 		command := strings.TrimSpace(raw)
 		if command != "" {
-			a.auditSessionEvent(sessionID, userID, "command_executed", command)
+			a.auditSessionEvent(activeSession.ID, userID, "command_executed", fmt.Sprintf("terminal=%s command=%s", terminalID, command))
 		}
 	}
 
+	if held != nil {
+		a.auditSessionEvent(held.SessionID, userID, "command_held", heldCommandAuditDetails(*held))
+		a.notifyHeldCommand("command_held", *held)
+	}
 	return nil
 }
 
@@ -1622,16 +2351,38 @@ func (a *App) DestroyTerminal(sessionID string) error {
 	if a.ai != nil {
 		a.ai.RemoveSession(sessionID)
 	}
+	if a.testRuns != nil {
+		a.testRuns.Forget(sessionID)
+	}
+	if a.terminalLinks != nil {
+		a.terminalLinks.Forget(sessionID)
+	}
+	if a.transcripts != nil {
+		a.transcripts.Forget(sessionID)
+	}
+	if a.problemCollector != nil {
+		a.problemCollector.Forget(sessionID)
+	}
+	a.commandGuard.Forget(sessionID)
 
+	inBroadcastGroup := false
+	if a.ptyMgr != nil {
+		_, inBroadcastGroup = a.ptyMgr.BroadcastGroupOf(sessionID)
+	}
 	if err := a.bridge.DestroyTerminal(sessionID); err != nil {
 		return err
 	}
+	if inBroadcastGroup {
+		a.emitTerminalBroadcastChanged()
+	}
 
-	if agentID, ok := a.unbindTerminalFromAgent(sessionID); ok && a.db != nil {
+	agentID, bound := a.unbindTerminalFromAgent(sessionID)
+	if bound && a.db != nil {
 		if err := a.db.ClearAgentRuntime(agentID); err != nil {
 			log.Printf("[ORCH] unable to clear agent runtime (agent=%d): %v", agentID, err)
 		}
 	}
+	a.fireTerminalDestroyedHooks(sessionID, agentID)
 
 	return nil
 }
@@ -1668,6 +2419,44 @@ func (a *App) AISetSessionState(sessionID string, state ai.SessionState) {
 	a.ai.SetSessionState(sessionID, state)
 }
 
+// GetLastTestResults retorna a última execução de testes concluída nos terminais do agente,
+// com contagens e testes falhos (nil se nenhuma foi detectada ainda).
+func (a *App) GetLastTestResults(agentID uint) (*terminal.TestRun, error) {
+	if agentID == 0 {
+		return nil, fmt.Errorf("agentID is required")
+	}
+	if a.testRuns == nil {
+		return nil, nil
+	}
+	run, ok := a.testRuns.LastRun(agentID)
+	if !ok {
+		return nil, nil
+	}
+	return &run, nil
+}
+
+// terminalSummaryAITimeout limita a espera pelo provedor ao resumir a saída de um terminal.
+const terminalSummaryAITimeout = 90 * time.Second
+
+// AISummarizeTerminalOutput resume as últimas lastNLines linhas do terminal (0 = 500): erros,
+// avisos e status final. O log passa antes por um pré-filtro heurístico (erros primeiro) para
+// caber no budget de tokens do provedor.
+func (a *App) AISummarizeTerminalOutput(sessionID string, lastNLines int) (ai.TerminalSummary, error) {
+	if a.ai == nil {
+		return ai.TerminalSummary{}, fmt.Errorf("ai service not initialized")
+	}
+	if sessionID == "" {
+		return ai.TerminalSummary{}, fmt.Errorf("sessionID is required")
+	}
+	parent := a.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, terminalSummaryAITimeout)
+	defer cancel()
+	return a.ai.SummarizeTerminalOutput(ctx, sessionID, a.getTerminalHistory(sessionID), lastNLines)
+}
+
 func decodeTerminalInput(data string) []byte {
 	// Input vindo do frontend local é enviado como texto bruto para reduzir
 	// overhead por tecla. Mantemos suporte opcional a payload base64 prefixado.
@@ -1785,6 +2574,28 @@ func (a *App) observeTerminalHistory(sessionID string, data []byte) {
 		current = current[len(current)-config.TerminalRingBufferSize:]
 	}
 	a.terminalHistory[sessionID] = current
+	a.terminalBytes[sessionID] += int64(len(data))
+}
+
+// TerminalLinksEvent é o payload de "terminal:links": links das linhas concluídas num chunk de saída.
+// WorkspaceID (quando o terminal pertence a um agente) é o workspace a passar para OpenInEditor.
+type TerminalLinksEvent struct {
+	SessionID   string                `json:"sessionID"`
+	WorkspaceID uint                  `json:"workspaceId,omitempty"`
+	Links       []terminal.OutputLink `json:"links"`
+}
+
+func (a *App) observeTerminalLinks(sessionID string, data []byte) {
+	links := a.terminalLinks.Observe(sessionID, data)
+	if len(links) == 0 || a.ctx == nil {
+		return
+	}
+	workspaceID, _ := a.resolveTerminalWorkspaceID(sessionID)
+	runtime.EventsEmit(a.ctx, "terminal:links", TerminalLinksEvent{
+		SessionID:   sessionID,
+		WorkspaceID: workspaceID,
+		Links:       links,
+	})
 }
 
 func (a *App) bindTerminalToAgent(sessionID string, agentID uint) {
@@ -1807,6 +2618,7 @@ func (a *App) unbindTerminalFromAgent(sessionID string) (uint, bool) {
 		delete(a.sessionAgents, sessionID)
 	}
 	delete(a.terminalHistory, sessionID)
+	delete(a.terminalBytes, sessionID)
 	return agentID, ok
 }
 
@@ -1827,16 +2639,31 @@ func (a *App) killTerminalSession(sessionID string) {
 	if a.ai != nil {
 		a.ai.RemoveSession(sessionID)
 	}
+	if a.testRuns != nil {
+		a.testRuns.Forget(sessionID)
+	}
+	if a.terminalLinks != nil {
+		a.terminalLinks.Forget(sessionID)
+	}
+	if a.transcripts != nil {
+		a.transcripts.Forget(sessionID)
+	}
+	if a.problemCollector != nil {
+		a.problemCollector.Forget(sessionID)
+	}
+	a.commandGuard.Forget(sessionID)
 
 	if err := a.bridge.DestroyTerminal(sessionID); err != nil {
 		log.Printf("[ORCH] unable to destroy terminal session %s: %v", sessionID, err)
 	}
 
-	if agentID, ok := a.unbindTerminalFromAgent(sessionID); ok && a.db != nil {
+	agentID, bound := a.unbindTerminalFromAgent(sessionID)
+	if bound && a.db != nil {
 		if err := a.db.ClearAgentRuntime(agentID); err != nil {
 			log.Printf("[ORCH] unable to clear agent runtime (agent=%d): %v", agentID, err)
 		}
 	}
+	a.fireTerminalDestroyedHooks(sessionID, agentID)
 }
 
 // GetTerminals retorna os terminais ativos
@@ -1849,6 +2676,100 @@ func (a *App) IsTerminalAlive(sessionID string) bool {
 	return a.bridge.IsTerminalAlive(sessionID)
 }
 
+// GetTerminalTranscript devolve a saída do terminal sem ANSI, separada pelos comandos digitados,
+// para leitores de tela: texto simples (plainText) ou Markdown com um título por comando.
+// As anotações seguem o idioma do usuário.
+func (a *App) GetTerminalTranscript(sessionID string, plainText bool) (terminal.Transcript, error) {
+	sessionID = strings.TrimSpace(sessionID)
+	if sessionID == "" {
+		return terminal.Transcript{}, fmt.Errorf("session id is required")
+	}
+	if a.transcripts == nil {
+		return terminal.Transcript{}, fmt.Errorf("terminal transcripts not initialized")
+	}
+	transcript, ok := a.transcripts.Transcript(sessionID, plainText, a.translator.Locale())
+	if !ok && (a.bridge == nil || !a.bridge.IsTerminalAlive(sessionID)) {
+		return terminal.Transcript{}, fmt.Errorf("terminal session not found: %s", sessionID)
+	}
+	return transcript, nil
+}
+
+// CreateTerminalBroadcastGroup agrupa terminais para que o input digitado em um seja enviado a
+// todos (ex.: o mesmo comando em vários serviços ou hosts SSH).
+func (a *App) CreateTerminalBroadcastGroup(name string, sessionIDs []string) (terminal.BroadcastGroup, error) {
+	if a.ptyMgr == nil {
+		return terminal.BroadcastGroup{}, fmt.Errorf("pty manager not initialized")
+	}
+	group, err := a.ptyMgr.CreateBroadcastGroup(name, sessionIDs)
+	if err != nil {
+		return terminal.BroadcastGroup{}, err
+	}
+	a.emitTerminalBroadcastChanged()
+	return group, nil
+}
+
+// AddTerminalToBroadcastGroup inclui um terminal no grupo (saindo do grupo anterior, se houver).
+func (a *App) AddTerminalToBroadcastGroup(groupID string, sessionID string) (terminal.BroadcastGroup, error) {
+	if a.ptyMgr == nil {
+		return terminal.BroadcastGroup{}, fmt.Errorf("pty manager not initialized")
+	}
+	group, err := a.ptyMgr.AddToBroadcastGroup(groupID, sessionID)
+	if err != nil {
+		return terminal.BroadcastGroup{}, err
+	}
+	a.emitTerminalBroadcastChanged()
+	return group, nil
+}
+
+// RemoveTerminalFromBroadcastGroup tira o terminal do seu grupo de broadcast.
+func (a *App) RemoveTerminalFromBroadcastGroup(sessionID string) {
+	if a.ptyMgr == nil {
+		return
+	}
+	a.ptyMgr.RemoveFromBroadcastGroup(sessionID)
+	a.emitTerminalBroadcastChanged()
+}
+
+// SetTerminalBroadcastEnabled liga/desliga o broadcast de um terminal sem tirá-lo do grupo.
+func (a *App) SetTerminalBroadcastEnabled(sessionID string, enabled bool) (terminal.BroadcastGroup, error) {
+	if a.ptyMgr == nil {
+		return terminal.BroadcastGroup{}, fmt.Errorf("pty manager not initialized")
+	}
+	group, err := a.ptyMgr.SetBroadcastEnabled(sessionID, enabled)
+	if err != nil {
+		return terminal.BroadcastGroup{}, err
+	}
+	a.emitTerminalBroadcastChanged()
+	return group, nil
+}
+
+// DeleteTerminalBroadcastGroup desfaz o grupo; os terminais continuam abertos.
+func (a *App) DeleteTerminalBroadcastGroup(groupID string) error {
+	if a.ptyMgr == nil {
+		return fmt.Errorf("pty manager not initialized")
+	}
+	if err := a.ptyMgr.DeleteBroadcastGroup(groupID); err != nil {
+		return err
+	}
+	a.emitTerminalBroadcastChanged()
+	return nil
+}
+
+// GetTerminalBroadcastGroups lista os grupos de broadcast ativos.
+func (a *App) GetTerminalBroadcastGroups() []terminal.BroadcastGroup {
+	if a.ptyMgr == nil {
+		return []terminal.BroadcastGroup{}
+	}
+	return a.ptyMgr.GetBroadcastGroups()
+}
+
+func (a *App) emitTerminalBroadcastChanged() {
+	if a.ctx == nil || a.ptyMgr == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, "terminal:broadcast_changed", a.ptyMgr.GetBroadcastGroups())
+}
+
 // === Métodos expostos ao Frontend (Wails Bindings) ===
 
 // GetAppInfo retorna informações do app
@@ -1856,7 +2777,42 @@ func (a *App) GetAppInfo() map[string]string {
 	return map[string]string{
 		"name":    config.AppName,
 		"version": config.AppVersion,
+		"profile": config.ActiveProfile(),
+	}
+}
+
+// ProfileSwitchDTO é o resultado de SwitchProfile: o novo perfil só é aberto no próximo início.
+type ProfileSwitchDTO struct {
+	Profile         config.Profile `json:"profile"`
+	RestartRequired bool           `json:"restartRequired"`
+}
+
+// GetProfiles lista os perfis locais (diretório de dados, histórico e tokens separados, sem
+// proteção entre perfis do mesmo usuário do sistema) e marca o perfil em uso.
+func (a *App) GetProfiles() []config.Profile {
+	return config.ListProfiles()
+}
+
+// CreateProfile cria um perfil vazio sem trocar o perfil em uso.
+func (a *App) CreateProfile(name string) (config.Profile, error) {
+	return config.CreateProfile(name)
+}
+
+// SwitchProfile escolhe o perfil aberto no próximo início do app. Um perfil fixado por
+// ORCH_PROFILE não pode ser trocado pela UI.
+func (a *App) SwitchProfile(name string) (ProfileSwitchDTO, error) {
+	if pinned := strings.TrimSpace(os.Getenv(config.ProfileEnv)); pinned != "" {
+		return ProfileSwitchDTO{}, fmt.Errorf("profile is pinned by %s=%s", config.ProfileEnv, pinned)
+	}
+	profile, err := config.SetActiveProfile(name)
+	if err != nil {
+		return ProfileSwitchDTO{}, err
+	}
+	restart := profile.Name != config.ActiveProfile()
+	if restart {
+		log.Printf("[ORCH] Profile switched to %q (restart required)", profile.Name)
 	}
+	return ProfileSwitchDTO{Profile: profile, RestartRequired: restart}, nil
 }
 
 // SaveAgentLayout salva o layout de um agente no banco
@@ -1867,6 +2823,86 @@ func (a *App) SaveAgentLayout(agentID uint, layoutJSON string) error {
 	return a.db.UpdateAgentLayout(agentID, layoutJSON)
 }
 
+// GetDatabaseInfo retorna versão do schema, migrações aplicadas e tamanho do banco local.
+func (a *App) GetDatabaseInfo() (*database.DatabaseInfo, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	return a.db.GetDatabaseInfo()
+}
+
+// DatabaseBackup grava um backup criptografado (banco + configurações) em path.
+// Quando path é um diretório, o nome do arquivo é gerado automaticamente.
+func (a *App) DatabaseBackup(path string) (*backup.Manifest, error) {
+	if a.backup == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	return a.backup.Backup(path)
+}
+
+// DatabaseRestore restaura um backup após validar checksums; o banco atual ganha backup antes.
+func (a *App) DatabaseRestore(path string) (*backup.Manifest, error) {
+	if a.backup == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	manifest, err := a.backup.Restore(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reaplica estado derivado do banco restaurado.
+	a.invalidateGitHubAccountRoutes()
+	a.restoreGitHubWebhookBridge()
+	a.restoreGitAutoFetchSettings()
+	a.restoreWorkspaceFileWatching()
+	a.restoreBackupSchedule()
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "database:restored", manifest)
+	}
+	return manifest, nil
+}
+
+// GetBackupSchedule retorna o agendamento de backups automáticos e o último resultado.
+func (a *App) GetBackupSchedule() (backup.ScheduleStatus, error) {
+	if a.backup == nil {
+		return backup.ScheduleStatus{}, fmt.Errorf("database not initialized")
+	}
+	return a.backup.ScheduleStatus(), nil
+}
+
+// SetBackupSchedule persiste e aplica o agendamento de backups automáticos.
+func (a *App) SetBackupSchedule(schedule backup.Schedule) (backup.ScheduleStatus, error) {
+	if a.backup == nil || a.db == nil {
+		return backup.ScheduleStatus{}, fmt.Errorf("database not initialized")
+	}
+	schedule = backup.NormalizeSchedule(schedule, config.DataDir())
+	dir := schedule.Dir
+	if dir == backup.DefaultDir(config.DataDir()) {
+		dir = ""
+	}
+	if err := a.db.SetBackupSettings(schedule.Enabled, schedule.IntervalHours, schedule.Retention, dir); err != nil {
+		return backup.ScheduleStatus{}, err
+	}
+	return a.backup.ConfigureSchedule(schedule), nil
+}
+
+func (a *App) restoreBackupSchedule() {
+	if a.db == nil || a.backup == nil {
+		return
+	}
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		log.Printf("[ORCH] Could not load backup settings: %v", err)
+		return
+	}
+	a.backup.ConfigureSchedule(backup.Schedule{
+		Enabled:       cfg.BackupEnabled,
+		IntervalHours: cfg.BackupIntervalHours,
+		Retention:     cfg.BackupRetention,
+		Dir:           cfg.BackupDir,
+	})
+}
+
 // CreateWorkspace cria um novo workspace persistente.
 func (a *App) CreateWorkspace(name string) (*database.Workspace, error) {
 	if a.db == nil {
@@ -1957,7 +2993,28 @@ func (a *App) SetActiveWorkspace(id uint) error {
 	if a.db == nil {
 		return fmt.Errorf("database not initialized")
 	}
-	return a.db.SetActiveWorkspace(id)
+	if err := a.db.SetActiveWorkspace(id); err != nil {
+		return err
+	}
+
+	payload := map[string]string{"workspaceId": strconv.FormatUint(uint64(id), 10)}
+	if ws, err := a.db.GetWorkspace(id); err == nil {
+		payload["workspaceName"] = ws.Name
+		payload["workspacePath"] = ws.Path
+		if a.fileWatcher != nil {
+			for _, repoPath := range a.workspaceRepoPaths(ws) {
+				if watchErr := a.fileWatcher.Watch(repoPath); watchErr != nil {
+					log.Printf("[ORCH] Could not watch workspace repo %s: %v", repoPath, watchErr)
+				}
+			}
+		}
+	}
+
+	// Auto-start em background para não atrasar a troca de workspace na UI.
+	go a.autoStartWorkspaceAgents(id)
+
+	a.lifecycleHooks.Fire(hooks.EventWorkspaceActivated, payload)
+	return nil
 }
 
 // GetWorkspacesWithAgents retorna workspaces com seus agentes filhos.
@@ -2019,3840 +3076,13307 @@ func (a *App) DeleteWorkspace(id uint) error {
 	if errors.Is(err, database.ErrLastWorkspace) {
 		return fmt.Errorf("cannot delete last workspace")
 	}
+	if err == nil && a.fileWatcher != nil {
+		a.fileWatcher.UnwatchWorkspaceFiles(id)
+	}
+	if err == nil && a.fileSearch != nil {
+		a.cancelWorkspaceSearches(id)
+		a.fileSearch.Drop(id)
+	}
+	if err == nil && a.taskComments != nil {
+		a.taskComments.Drop(id)
+	}
 	return err
 }
 
-// CreateAgentSession cria um novo agente/sessão no workspace informado.
-func (a *App) CreateAgentSession(workspaceID uint, name string, agentType string) (*database.AgentSession, error) {
-	if a.db == nil {
-		return nil, fmt.Errorf("database not initialized")
+// === Multi-repo Workspace Bindings ===
+
+// workspaceRepoPaths retorna o path principal do workspace (se for repo) + repositórios anexados, sem duplicatas.
+func (a *App) workspaceRepoPaths(ws *database.Workspace) []string {
+	if ws == nil {
+		return nil
 	}
 
-	// Regra de negócio: primeiro terminal sempre nasce no workspace "Default".
-	if totalAgents, countErr := a.db.CountAgents(); countErr == nil && totalAgents == 0 {
-		defaultWorkspaceID := uint(0)
-		if workspaces, listErr := a.db.ListWorkspaces(); listErr == nil {
-			for _, ws := range workspaces {
-				if strings.EqualFold(strings.TrimSpace(ws.Name), "default") {
-					defaultWorkspaceID = ws.ID
-					break
-				}
-			}
+	paths := make([]string, 0, 4)
+	seen := make(map[string]struct{})
+	add := func(raw string) {
+		trimmed := strings.TrimSpace(raw)
+		if trimmed == "" {
+			return
+		}
+		clean := filepath.Clean(trimmed)
+		if _, exists := seen[clean]; exists {
+			return
 		}
+		seen[clean] = struct{}{}
+		paths = append(paths, clean)
+	}
 
-		if defaultWorkspaceID == 0 {
-			defaultWorkspace := &database.Workspace{
-				UserID: "local",
-				Name:   "Default",
-				Path:   "",
+	if ws.Path != "" && findGitRepoRoot(ws.Path) != "" {
+		add(ws.Path)
+	}
+	repos := ws.Repos
+	if len(repos) == 0 && a.db != nil {
+		repos, _ = a.db.ListWorkspaceRepos(ws.ID)
+	}
+	for _, repo := range repos {
+		add(repo.Path)
+	}
+	return paths
+}
+
+// resolveRepoScope retorna os workspaces e agentes vinculados a um repositório.
+func (a *App) resolveRepoScope(repoPath string) ([]uint, []uint) {
+	repoPath = strings.TrimSpace(repoPath)
+	if a.db == nil || repoPath == "" {
+		return nil, nil
+	}
+	repoPath = filepath.Clean(repoPath)
+
+	workspaceSet := make(map[uint]struct{})
+	if workspaces, err := a.db.ListWorkspaces(); err == nil {
+		for _, ws := range workspaces {
+			if strings.TrimSpace(ws.Path) != "" && filepath.Clean(ws.Path) == repoPath {
+				workspaceSet[ws.ID] = struct{}{}
 			}
-			if createErr := a.db.CreateWorkspace(defaultWorkspace); createErr == nil {
-				defaultWorkspaceID = defaultWorkspace.ID
+		}
+	}
+	if repos, err := a.db.ListAllWorkspaceRepos(); err == nil {
+		for _, repo := range repos {
+			if filepath.Clean(repo.Path) == repoPath {
+				workspaceSet[repo.WorkspaceID] = struct{}{}
 			}
 		}
+	}
 
-		if defaultWorkspaceID != 0 {
-			workspaceID = defaultWorkspaceID
-			_ = a.db.SetActiveWorkspace(defaultWorkspaceID)
+	agentIDs := make([]uint, 0)
+	if agents, err := a.db.ListAgentsByRepoPath(repoPath); err == nil {
+		for _, agent := range agents {
+			agentIDs = append(agentIDs, agent.ID)
+			workspaceSet[agent.WorkspaceID] = struct{}{}
 		}
 	}
 
-	if workspaceID == 0 {
-		ws, err := a.db.GetActiveWorkspace()
-		if err != nil {
-			return nil, err
-		}
-		workspaceID = ws.ID
+	workspaceIDs := make([]uint, 0, len(workspaceSet))
+	for id := range workspaceSet {
+		workspaceIDs = append(workspaceIDs, id)
 	}
+	sort.Slice(workspaceIDs, func(i, j int) bool { return workspaceIDs[i] < workspaceIDs[j] })
+	return workspaceIDs, agentIDs
+}
 
-	if _, err := a.db.GetWorkspace(workspaceID); err != nil {
-		return nil, err
+func joinUintIDs(ids []uint) string {
+	if len(ids) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatUint(uint64(id), 10))
 	}
+	return strings.Join(parts, ",")
+}
 
-	trimmedName := strings.TrimSpace(name)
-	if trimmedName == "" {
-		trimmedName = fmt.Sprintf("Terminal %d", time.Now().Unix()%10000)
+// ListWorkspaceRepositories lista os repositórios anexados a um workspace.
+func (a *App) ListWorkspaceRepositories(workspaceID uint) ([]database.WorkspaceRepo, error) {
+	if a.db == nil {
+		return []database.WorkspaceRepo{}, nil
 	}
+	return a.db.ListWorkspaceRepos(workspaceID)
+}
 
-	normalizedType, err := normalizeAgentSessionType(agentType)
-	if err != nil {
+// AddWorkspaceRepository anexa um repositório Git ao workspace e inicia o monitoramento.
+func (a *App) AddWorkspaceRepository(workspaceID uint, repoPath string, name string) (*database.WorkspaceRepo, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	if _, err := a.db.GetWorkspace(workspaceID); err != nil {
 		return nil, err
 	}
 
-	existing, err := a.db.ListAgents(workspaceID)
-	if err != nil {
-		return nil, err
+	trimmed := strings.TrimSpace(repoPath)
+	if trimmed == "" {
+		return nil, fmt.Errorf("repository path is required")
+	}
+	repoRoot := findGitRepoRoot(trimmed)
+	if repoRoot == "" {
+		return nil, fmt.Errorf("not a git repository: %s", trimmed)
 	}
 
-	agent := &database.AgentSession{
-		WorkspaceID: workspaceID,
-		Name:        trimmedName,
-		Type:        normalizedType,
-		Shell:       a.resolvePreferredLocalShell(),
-		Status:      "idle",
-		SortOrder:   len(existing),
+	repoName := strings.TrimSpace(name)
+	if repoName == "" {
+		repoName = filepath.Base(repoRoot)
 	}
 
-	if err := a.db.CreateAgent(agent); err != nil {
+	repo := &database.WorkspaceRepo{
+		WorkspaceID: workspaceID,
+		Name:        repoName,
+		Path:        repoRoot,
+	}
+	if err := a.db.AddWorkspaceRepo(repo); err != nil {
 		return nil, err
 	}
 
-	if agent == nil || agent.ID == 0 {
-		return nil, fmt.Errorf("invalid agent session persisted: empty id")
+	if a.fileWatcher != nil {
+		if err := a.fileWatcher.Watch(repoRoot); err != nil {
+			log.Printf("[ORCH][MULTIREPO] could not watch repo %s: %v", repoRoot, err)
+		}
+		a.primeIndexActivityBaseline(repoRoot)
 	}
+	return repo, nil
+}
 
-	persisted, err := a.db.GetAgent(agent.ID)
+// RemoveWorkspaceRepository desanexa um repositório e para o watch se nenhum workspace o usa mais.
+func (a *App) RemoveWorkspaceRepository(repoID uint) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	repo, err := a.db.GetWorkspaceRepo(repoID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load created agent session: %w", err)
+		return err
 	}
-	if persisted == nil || persisted.ID == 0 || persisted.WorkspaceID == 0 {
-		return nil, fmt.Errorf("invalid created agent session payload")
+	if err := a.db.RemoveWorkspaceRepo(repoID); err != nil {
+		return err
 	}
 
-	return persisted, nil
+	if a.fileWatcher != nil {
+		if workspaceIDs, _ := a.resolveRepoScope(repo.Path); len(workspaceIDs) == 0 {
+			if err := a.fileWatcher.Unwatch(repo.Path); err != nil {
+				log.Printf("[ORCH][MULTIREPO] could not unwatch repo %s: %v", repo.Path, err)
+			}
+		}
+	}
+	return nil
 }
 
-func normalizeAgentSessionType(agentType string) (string, error) {
-	normalized := strings.ToLower(strings.TrimSpace(agentType))
-	switch normalized {
-	case "", "terminal":
-		return "terminal", nil
-	case "ai_agent":
-		return "ai_agent", nil
-	case "github":
-		return "", fmt.Errorf("agent type 'github' foi descontinuado; abra o Git Panel dedicado")
-	default:
-		return "", fmt.Errorf("unsupported agent type: %s", agentType)
+// BindAgentToRepository vincula um agente a um repositório do seu workspace (vazio desfaz o vínculo).
+func (a *App) BindAgentToRepository(agentID uint, repoPath string) (*database.AgentSession, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
 	}
-}
-
-func isLegacyGitHubAgentType(agentType string) bool {
-	return strings.EqualFold(strings.TrimSpace(agentType), "github")
-}
 
-func filterLegacyGitHubAgents(workspaces []database.Workspace) ([]database.Workspace, []uint) {
-	sanitized := make([]database.Workspace, 0, len(workspaces))
-	legacyAgentIDs := make([]uint, 0)
+	agent, err := a.db.GetAgent(agentID)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, ws := range workspaces {
-		keptAgents := make([]database.AgentSession, 0, len(ws.Agents))
-		for _, agent := range ws.Agents {
-			if isLegacyGitHubAgentType(agent.Type) {
-				legacyAgentIDs = append(legacyAgentIDs, agent.ID)
-				continue
+	target := strings.TrimSpace(repoPath)
+	if target != "" {
+		target = filepath.Clean(target)
+		ws, wsErr := a.db.GetWorkspace(agent.WorkspaceID)
+		if wsErr != nil {
+			return nil, wsErr
+		}
+		allowed := false
+		for _, candidate := range a.workspaceRepoPaths(ws) {
+			if candidate == target {
+				allowed = true
+				break
 			}
-			keptAgents = append(keptAgents, agent)
 		}
-
-		ws.Agents = keptAgents
-		sanitized = append(sanitized, ws)
+		if !allowed {
+			return nil, fmt.Errorf("repository %s is not attached to workspace %d", target, agent.WorkspaceID)
+		}
 	}
 
-	return sanitized, legacyAgentIDs
+	if err := a.db.SetAgentRepoPath(agentID, target); err != nil {
+		return nil, err
+	}
+	return a.db.GetAgent(agentID)
 }
 
-// DeleteAgentSession remove o agente e encerra seu processo associado.
-func (a *App) DeleteAgentSession(agentID uint) error {
+// GitPanelResolveAgentRepository resolve o repositório que o Git Panel deve exibir para o agente:
+// vínculo explícito > repositório primário do workspace > path do workspace.
+func (a *App) GitPanelResolveAgentRepository(agentID uint) (string, error) {
 	if a.db == nil {
-		return fmt.Errorf("database not initialized")
+		return "", fmt.Errorf("database not initialized")
 	}
 
 	agent, err := a.db.GetAgent(agentID)
 	if err != nil {
-		return err
+		return "", err
+	}
+	if repoPath := strings.TrimSpace(agent.RepoPath); repoPath != "" {
+		return repoPath, nil
 	}
 
-	if agent.SessionID != "" {
-		a.killTerminalSession(agent.SessionID)
+	repos, err := a.db.ListWorkspaceRepos(agent.WorkspaceID)
+	if err != nil {
+		return "", err
+	}
+	for _, repo := range repos {
+		if repo.IsPrimary {
+			return repo.Path, nil
+		}
 	}
 
-	return a.db.DeleteAgent(agentID)
+	ws, err := a.db.GetWorkspace(agent.WorkspaceID)
+	if err != nil {
+		return "", err
+	}
+	if root := findGitRepoRoot(strings.TrimSpace(ws.Path)); strings.TrimSpace(ws.Path) != "" && root != "" {
+		return root, nil
+	}
+	return "", fmt.Errorf("agent %d has no repository bound", agentID)
 }
 
-// MoveAgentSessionToWorkspace move um agente/sessão para outro workspace.
-func (a *App) MoveAgentSessionToWorkspace(agentID uint, workspaceID uint) (*database.AgentSession, error) {
-	log.Printf("[ORCH][MOVE] request received agentID=%d targetWorkspaceID=%d", agentID, workspaceID)
+// GitPanelGetStatusForAgent retorna o status do repositório vinculado ao agente.
+func (a *App) GitPanelGetStatusForAgent(agentID uint) (gp.StatusDTO, error) {
+	repoPath, err := a.GitPanelResolveAgentRepository(agentID)
+	if err != nil {
+		return gp.StatusDTO{}, err
+	}
+	return a.GitPanelGetStatus(repoPath)
+}
 
+// === Git Panel Path Scope (monorepo) ===
+
+// GitPanelPathScopeDTO descreve o filtro de subdiretorio do Git Panel de um workspace.
+type GitPanelPathScopeDTO struct {
+	WorkspaceID    uint                 `json:"workspaceId"`
+	RepoRoot       string               `json:"repoRoot"`
+	PathScope      string               `json:"pathScope"`
+	SparseCheckout gp.SparseCheckoutDTO `json:"sparseCheckout"`
+}
+
+// GitPanelGetPathScope retorna o filtro persistido do workspace e o estado de sparse-checkout do repo.
+func (a *App) GitPanelGetPathScope(workspaceID uint) (GitPanelPathScopeDTO, error) {
 	if a.db == nil {
-		log.Printf("[ORCH][MOVE] aborted: database not initialized")
-		return nil, fmt.Errorf("database not initialized")
+		return GitPanelPathScopeDTO{}, fmt.Errorf("database not initialized")
+	}
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return GitPanelPathScopeDTO{}, err
 	}
 
-	moved, err := a.db.MoveAgentToWorkspace(agentID, workspaceID)
+	ws, err := a.db.GetWorkspace(workspaceID)
 	if err != nil {
-		log.Printf("[ORCH][MOVE] failed agentID=%d targetWorkspaceID=%d err=%v", agentID, workspaceID, err)
-		return nil, err
+		return GitPanelPathScopeDTO{}, err
 	}
+	return a.buildGitPanelPathScope(svc, ws, ws.GitPathScope)
+}
 
-	if moved == nil {
-		log.Printf("[ORCH][MOVE] failed agentID=%d targetWorkspaceID=%d err=empty payload", agentID, workspaceID)
-		return nil, fmt.Errorf("move returned empty payload")
+// GitPanelSetPathScope persiste o subdiretorio (relativo a raiz do repo) usado para filtrar
+// status, diff e historico do workspace. Escopo vazio remove o filtro.
+func (a *App) GitPanelSetPathScope(workspaceID uint, pathScope string) (GitPanelPathScopeDTO, error) {
+	if a.db == nil {
+		return GitPanelPathScopeDTO{}, fmt.Errorf("database not initialized")
+	}
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return GitPanelPathScopeDTO{}, err
 	}
 
-	log.Printf(
-		"[ORCH][MOVE] success agentID=%d targetWorkspaceID=%d finalWorkspaceID=%d sortOrder=%d sessionID=%q",
-		agentID,
-		workspaceID,
-		moved.WorkspaceID,
-		moved.SortOrder,
-		moved.SessionID,
-	)
+	ws, err := a.db.GetWorkspace(workspaceID)
+	if err != nil {
+		return GitPanelPathScopeDTO{}, err
+	}
+	result, err := a.buildGitPanelPathScope(svc, ws, pathScope)
+	if err != nil {
+		return GitPanelPathScopeDTO{}, err
+	}
+	if result.PathScope != "" {
+		info, statErr := os.Stat(filepath.Join(result.RepoRoot, filepath.FromSlash(result.PathScope)))
+		if statErr != nil || !info.IsDir() {
+			return GitPanelPathScopeDTO{}, fmt.Errorf("path scope %q is not a directory of %s", result.PathScope, result.RepoRoot)
+		}
+	}
 
-	return moved, nil
+	if err := a.db.SetWorkspaceGitPathScope(workspaceID, result.PathScope); err != nil {
+		return GitPanelPathScopeDTO{}, err
+	}
+	svc.InvalidateRepoCache(result.RepoRoot)
+	a.emitGitPanelInvalidationEvents(result.RepoRoot, "gitpanel:path_scope_changed", "path_scope_changed", true, true, false)
+	return result, nil
 }
 
-// GetWorkspaceHistoryBuffer retorna o histórico textual dos terminais de um workspace.
-// map key = agentID serializado como string.
-func (a *App) GetWorkspaceHistoryBuffer(workspaceID uint) (map[string]string, error) {
-	if a.db == nil {
-		return map[string]string{}, nil
+func (a *App) buildGitPanelPathScope(svc *gp.Service, ws *database.Workspace, pathScope string) (GitPanelPathScopeDTO, error) {
+	repoPath := strings.TrimSpace(ws.Path)
+	if repoPath == "" || findGitRepoRoot(repoPath) == "" {
+		return GitPanelPathScopeDTO{}, fmt.Errorf("workspace %d is not a git repository", ws.ID)
 	}
 
-	if workspaceID == 0 {
-		active, err := a.db.GetActiveWorkspace()
-		if err != nil {
-			return map[string]string{}, err
-		}
-		workspaceID = active.ID
+	preflight, err := svc.Preflight(repoPath)
+	if err != nil {
+		return GitPanelPathScopeDTO{}, a.normalizeGitPanelBindingError(err)
+	}
+	normalized, err := gp.NormalizePathScope(preflight.RepoRoot, pathScope)
+	if err != nil {
+		return GitPanelPathScopeDTO{}, a.normalizeGitPanelBindingError(err)
+	}
+	sparse, err := svc.GetSparseCheckout(repoPath)
+	if err != nil {
+		return GitPanelPathScopeDTO{}, a.normalizeGitPanelBindingError(err)
 	}
 
-	agents, err := a.db.ListAgents(workspaceID)
+	return GitPanelPathScopeDTO{
+		WorkspaceID:    ws.ID,
+		RepoRoot:       preflight.RepoRoot,
+		PathScope:      normalized,
+		SparseCheckout: sparse,
+	}, nil
+}
+
+// resolveGitPathScope retorna o filtro persistido para o repositório, priorizando o workspace ativo.
+func (a *App) resolveGitPathScope(svc *gp.Service, repoPath string) string {
+	if a.db == nil || svc == nil {
+		return ""
+	}
+	workspaces, err := a.db.ListWorkspaces()
 	if err != nil {
-		return map[string]string{}, err
+		return ""
 	}
+	sort.SliceStable(workspaces, func(i, j int) bool {
+		return workspaces[i].IsActive && !workspaces[j].IsActive
+	})
 
-	history := make(map[string]string, len(agents))
-	for _, agent := range agents {
-		if agent.ID == 0 || agent.SessionID == "" {
+	var repoRoot string
+	for _, ws := range workspaces {
+		if strings.TrimSpace(ws.GitPathScope) == "" || strings.TrimSpace(ws.Path) == "" {
 			continue
 		}
-
-		buffer := a.getTerminalHistory(agent.SessionID)
-		if buffer == "" {
-			continue
+		if repoRoot == "" {
+			preflight, preflightErr := svc.Preflight(repoPath)
+			if preflightErr != nil {
+				return ""
+			}
+			repoRoot = filepath.Clean(preflight.RepoRoot)
+		}
+		if wsRoot := findGitRepoRoot(ws.Path); wsRoot != "" && filepath.Clean(wsRoot) == repoRoot {
+			return ws.GitPathScope
 		}
-		history[strconv.FormatUint(uint64(agent.ID), 10)] = buffer
 	}
-
-	return history, nil
+	return ""
 }
 
-// CreateAgent cria um novo agente no workspace ativo (compatibilidade legada).
-func (a *App) CreateAgent(name string, agentType string) (*database.AgentInstance, error) {
-	agent, err := a.CreateAgentSession(0, name, agentType)
+// GitPanelGetSparseCheckout retorna o estado de sparse-checkout do repositório.
+func (a *App) GitPanelGetSparseCheckout(repoPath string) (gp.SparseCheckoutDTO, error) {
+	svc, err := a.requireGitPanelService()
 	if err != nil {
-		return nil, err
+		return gp.SparseCheckoutDTO{}, err
 	}
-	return agent, nil
-}
 
-// DeleteAgent remove um agente (compatibilidade legada).
-func (a *App) DeleteAgent(agentID uint) error {
-	return a.DeleteAgentSession(agentID)
+	sparse, sparseErr := svc.GetSparseCheckout(repoPath)
+	if sparseErr != nil {
+		return gp.SparseCheckoutDTO{}, a.normalizeGitPanelBindingError(sparseErr)
+	}
+	return sparse, nil
 }
 
-// ListAgents lista agentes do workspace ativo (compatibilidade legada).
-func (a *App) ListAgents() ([]database.AgentInstance, error) {
-	if a.db == nil {
-		return []database.AgentInstance{}, nil
+// GitPanelSetSparseCheckout ativa o sparse-checkout (modo cone) com os diretórios informados.
+func (a *App) GitPanelSetSparseCheckout(repoPath string, directories []string) (gp.SparseCheckoutDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.SparseCheckoutDTO{}, err
 	}
 
-	ws, err := a.db.GetActiveWorkspace()
+	sparse, setErr := svc.SetSparseCheckout(repoPath, directories)
+	if setErr != nil {
+		return gp.SparseCheckoutDTO{}, a.normalizeGitPanelBindingError(setErr)
+	}
+	return sparse, nil
+}
+
+// GitPanelDisableSparseCheckout desativa o sparse-checkout e materializa o working tree completo.
+func (a *App) GitPanelDisableSparseCheckout(repoPath string) (gp.SparseCheckoutDTO, error) {
+	svc, err := a.requireGitPanelService()
 	if err != nil {
-		return []database.AgentInstance{}, nil
+		return gp.SparseCheckoutDTO{}, err
 	}
 
-	return a.db.ListAgents(ws.ID)
+	sparse, disableErr := svc.DisableSparseCheckout(repoPath)
+	if disableErr != nil {
+		return gp.SparseCheckoutDTO{}, a.normalizeGitPanelBindingError(disableErr)
+	}
+	return sparse, nil
 }
 
-// SaveLayoutState salva o estado do layout (JSON) na config do usuário
-func (a *App) SaveLayoutState(layoutJSON string) error {
-	if a.db == nil {
-		return nil
-	}
-	cfg, err := a.db.GetConfig()
+// GitPanelGetPartialClone retorna o filtro de partial clone e os avisos de desempenho do repositório.
+func (a *App) GitPanelGetPartialClone(repoPath string) (gp.PartialCloneDTO, error) {
+	svc, err := a.requireGitPanelService()
 	if err != nil {
-		// Criar config se não existe
-		cfg = &database.UserConfig{
-			Theme:       "dark",
-			LayoutState: layoutJSON,
-		}
-		return a.db.UpdateConfig(cfg)
+		return gp.PartialCloneDTO{}, err
 	}
-	cfg.LayoutState = layoutJSON
-	return a.db.UpdateConfig(cfg)
-}
 
-// GetLayoutState retorna o estado do layout salvo
-func (a *App) GetLayoutState() (string, error) {
-	if a.db == nil {
-		return "", fmt.Errorf("database not initialized")
+	state, stateErr := svc.GetPartialClone(repoPath)
+	if stateErr != nil {
+		return gp.PartialCloneDTO{}, a.normalizeGitPanelBindingError(stateErr)
 	}
-	cfg, err := a.db.GetConfig()
+	return state, nil
+}
+
+// GitPanelSetPartialClone converte o clone em partial (ex.: blob:none) ou, com filtro vazio, de volta em completo.
+func (a *App) GitPanelSetPartialClone(repoPath string, remote string, filter string) (gp.PartialCloneDTO, error) {
+	svc, err := a.requireGitPanelService()
 	if err != nil {
-		return "", err
+		return gp.PartialCloneDTO{}, err
 	}
-	return cfg.LayoutState, nil
+
+	state, setErr := svc.SetPartialClone(repoPath, remote, filter)
+	if setErr != nil {
+		return gp.PartialCloneDTO{}, a.normalizeGitPanelBindingError(setErr)
+	}
+	return state, nil
 }
 
-// GetHydrationData retorna o payload inicial (para fallback do frontend)
-func (a *App) GetHydrationData() HydrationPayload {
-	return a.getHydrationPayload()
+// === Git Panel Repo Insights ===
+
+// GitPanelGetRepoStats retorna as métricas de saúde do repositório (cacheadas).
+func (a *App) GitPanelGetRepoStats(repoPath string) (gp.RepoStatsDTO, error) {
+	return a.gitPanelRepoStats(repoPath, false)
 }
 
-func normalizeTheme(theme string) string {
-	switch strings.ToLower(strings.TrimSpace(theme)) {
-	case "light":
-		return "light"
-	case "hacker":
-		return "hacker"
-	case "nvim":
-		return "nvim"
-	case "min-dark", "mindark", "min_dark", "min dark":
-		return "min-dark"
-	default:
-		return "dark"
-	}
+// GitPanelRefreshRepoStats recalcula as métricas de saúde do repositório ignorando o cache.
+func (a *App) GitPanelRefreshRepoStats(repoPath string) (gp.RepoStatsDTO, error) {
+	return a.gitPanelRepoStats(repoPath, true)
 }
 
-func normalizeLanguage(language string) string {
-	normalized := strings.ToLower(strings.TrimSpace(language))
-	switch normalized {
-	case "en", "en-us", "en_us":
-		return "en-US"
-	default:
-		return "pt-BR"
+func (a *App) gitPanelRepoStats(repoPath string, refresh bool) (gp.RepoStatsDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.RepoStatsDTO{}, err
 	}
+
+	result, statsErr := svc.GetRepoStats(repoPath, 0, refresh)
+	if statsErr != nil {
+		return gp.RepoStatsDTO{}, a.normalizeGitPanelBindingError(statsErr)
+	}
+	return result, nil
 }
 
-const defaultTerminalFontFamily = "JetBrains Mono"
-const defaultTerminalCursorStyle = "line"
+// GitPanelGetContributorStats retorna uma página de estatísticas por autor dos últimos rangeDays
+// (cacheadas). Logins GitHub ainda não resolvidos chegam depois pelo evento
+// "gitpanel:history_authors_enriched", casando pelo lastCommitHash.
+func (a *App) GitPanelGetContributorStats(repoPath string, rangeDays int, cursor string, limit int) (gp.ContributorStatsPageDTO, error) {
+	return a.gitPanelContributorStats(repoPath, rangeDays, cursor, limit, false)
+}
 
-func normalizeTerminalFontSize(size int) int {
-	if size < 10 {
-		return 10
-	}
-	if size > 24 {
-		return 24
-	}
-	return size
+// GitPanelRefreshContributorStats recalcula as estatísticas por autor e retorna a primeira página.
+func (a *App) GitPanelRefreshContributorStats(repoPath string, rangeDays int, limit int) (gp.ContributorStatsPageDTO, error) {
+	return a.gitPanelContributorStats(repoPath, rangeDays, "", limit, true)
 }
 
-func normalizeTerminalFontFamily(family string) string {
-	cleaned := strings.TrimSpace(family)
-	if cleaned == "" {
-		return defaultTerminalFontFamily
+func (a *App) gitPanelContributorStats(repoPath string, rangeDays int, cursor string, limit int, refresh bool) (gp.ContributorStatsPageDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.ContributorStatsPageDTO{}, err
 	}
 
-	cleaned = strings.ReplaceAll(cleaned, "\n", " ")
-	cleaned = strings.ReplaceAll(cleaned, "\r", " ")
-	cleaned = strings.ReplaceAll(cleaned, "\t", " ")
-	cleaned = strings.Join(strings.Fields(cleaned), " ")
-	if cleaned == "" {
-		return defaultTerminalFontFamily
+	result, statsErr := svc.GetContributorStats(repoPath, rangeDays, cursor, limit, refresh)
+	if statsErr != nil {
+		return gp.ContributorStatsPageDTO{}, a.normalizeGitPanelBindingError(statsErr)
 	}
+	a.enrichContributorStatsWithGitHubIdentity(result.RepoRoot, result.Items)
+	return result, nil
+}
 
-	// Famílias iniciadas com "." são internas do sistema e não são estáveis no xterm.
-	if strings.HasPrefix(cleaned, ".") {
-		return defaultTerminalFontFamily
+// enrichContributorStatsWithGitHubIdentity reaproveita o cache de autores do histórico usando o
+// último commit de cada contribuidor como referência.
+func (a *App) enrichContributorStatsWithGitHubIdentity(repoRoot string, items []gp.ContributorStatsDTO) {
+	if len(items) == 0 {
+		return
 	}
 
-	if len(cleaned) > 120 {
-		cleaned = strings.TrimSpace(cleaned[:120])
+	page := gp.HistoryPageDTO{Items: make([]gp.HistoryItemDTO, 0, len(items))}
+	for _, item := range items {
+		page.Items = append(page.Items, gp.HistoryItemDTO{
+			Hash:        item.LastCommitHash,
+			Author:      item.Name,
+			AuthorEmail: item.Email,
+		})
 	}
+	a.enrichGitPanelHistoryWithAuthIdentity(repoRoot, &page)
 
-	return cleaned
+	for index := range items {
+		items[index].GitHubLogin = page.Items[index].GitHubLogin
+		items[index].GitHubAvatarURL = page.Items[index].GitHubAvatarURL
+	}
 }
 
-func normalizeTerminalCursorStyle(style string) string {
-	switch strings.ToLower(strings.TrimSpace(style)) {
-	case "block":
-		return "block"
-	case "underline", "under-line", "under_line":
-		return "underline"
-	case "line", "bar", "beam", "vertical":
-		return "line"
-	default:
-		return defaultTerminalCursorStyle
+// === Git Panel Cache ===
+
+// GitPanelGetCacheTTLs retorna os TTLs efetivos dos caches de leitura do repositório.
+func (a *App) GitPanelGetCacheTTLs(repoPath string) (gp.CacheTTLsDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.CacheTTLsDTO{}, err
 	}
+	result, ttlErr := svc.GetRepoCacheTTLs(repoPath)
+	if ttlErr != nil {
+		return gp.CacheTTLsDTO{}, a.normalizeGitPanelBindingError(ttlErr)
+	}
+	return result, nil
 }
 
-type shortcutBindingPayload struct {
-	Key   string `json:"key"`
-	Meta  bool   `json:"meta"`
-	Shift bool   `json:"shift"`
-	Alt   bool   `json:"alt"`
+// GitPanelSetCacheTTLs define TTLs do repositório (0 herda o padrão de config.toml; tudo 0
+// remove o override). O override vale até o app reiniciar.
+func (a *App) GitPanelSetCacheTTLs(repoPath string, ttls gp.CacheTTLsDTO) (gp.CacheTTLsDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.CacheTTLsDTO{}, err
+	}
+	result, ttlErr := svc.SetRepoCacheTTLs(repoPath, ttls)
+	if ttlErr != nil {
+		return gp.CacheTTLsDTO{}, a.normalizeGitPanelBindingError(ttlErr)
+	}
+	return result, nil
 }
 
-func normalizeShortcutBindingsJSON(raw string) (string, error) {
-	trimmed := strings.TrimSpace(raw)
-	if trimmed == "" || trimmed == "{}" {
-		return "{}", nil
+// GitPanelGetCacheMetrics retorna hit rate e tempo médio de cálculo dos caches do Git Panel.
+func (a *App) GitPanelGetCacheMetrics() (gp.CacheMetricsDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.CacheMetricsDTO{}, err
 	}
+	return svc.GetCacheMetrics(), nil
+}
 
-	var parsed map[string]shortcutBindingPayload
-	if err := json.Unmarshal([]byte(trimmed), &parsed); err != nil {
-		return "", fmt.Errorf("invalid shortcut bindings JSON: %w", err)
+// GitPanelResetCacheMetrics zera as métricas de cache.
+func (a *App) GitPanelResetCacheMetrics() error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
 	}
+	svc.ResetCacheMetrics()
+	return nil
+}
 
-	cleaned := make(map[string]shortcutBindingPayload, len(parsed))
-	for id, binding := range parsed {
-		normalizedID := strings.TrimSpace(id)
-		if normalizedID == "" {
-			continue
-		}
-
-		key := strings.TrimSpace(binding.Key)
-		if key == "" {
-			continue
-		}
-
-		if len(key) > 24 {
-			continue
-		}
+// GitPanelGetReadBackend retorna o backend de leitura (cli ou gogit) efetivo do repositório e o
+// último motivo de fallback para o CLI.
+func (a *App) GitPanelGetReadBackend(repoPath string) (gp.ReadBackendDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.ReadBackendDTO{}, err
+	}
+	result, backendErr := svc.GetRepoReadBackend(repoPath)
+	if backendErr != nil {
+		return gp.ReadBackendDTO{}, a.normalizeGitPanelBindingError(backendErr)
+	}
+	return result, nil
+}
 
-		cleaned[normalizedID] = shortcutBindingPayload{
-			Key:   key,
-			Meta:  binding.Meta,
-			Shift: binding.Shift,
-			Alt:   binding.Alt,
-		}
+// GitPanelSetReadBackend escolhe o backend de leitura do repositório (vazio volta ao padrão de
+// config.toml). O override vale até o app reiniciar.
+func (a *App) GitPanelSetReadBackend(repoPath string, backend string) (gp.ReadBackendDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.ReadBackendDTO{}, err
 	}
+	result, backendErr := svc.SetRepoReadBackend(repoPath, backend)
+	if backendErr != nil {
+		return gp.ReadBackendDTO{}, a.normalizeGitPanelBindingError(backendErr)
+	}
+	return result, nil
+}
 
-	serialized, err := json.Marshal(cleaned)
+// GitPanelBenchmarkReadBackends compara CLI e go-git em status e histórico do repositório; com
+// o API inspector ligado, cada leitura também aparece no trace (serviço "git").
+func (a *App) GitPanelBenchmarkReadBackends(repoPath string, iterations int) (gp.ReadBenchmarkDTO, error) {
+	svc, err := a.requireGitPanelService()
 	if err != nil {
-		return "", fmt.Errorf("failed to serialize shortcut bindings: %w", err)
+		return gp.ReadBenchmarkDTO{}, err
 	}
+	result, benchErr := svc.BenchmarkReadBackends(repoPath, iterations)
+	if benchErr != nil {
+		return gp.ReadBenchmarkDTO{}, a.normalizeGitPanelBindingError(benchErr)
+	}
+	return result, nil
+}
 
-	return string(serialized), nil
+// recordGitPanelRead leva as leituras do Git Panel ao API inspector (host = backend usado).
+func recordGitPanelRead(sample gp.ReadSample) {
+	httpclient.RecordLocalOperation(httpclient.ServiceGit, sample.Operation, sample.Backend, sample.RepoRoot, sample.Duration, sample.Err)
 }
 
-// SaveTheme persiste o tema escolhido pelo usuário.
-func (a *App) SaveTheme(theme string) error {
-	if a.db == nil {
-		return nil
+// GitPanelGetExecMetrics retorna a ocupação do pool de processos git e, por repositório, a espera
+// na fila e a duração dos comandos — útil para diagnosticar repositórios lentos.
+func (a *App) GitPanelGetExecMetrics() (gp.ExecMetricsDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.ExecMetricsDTO{}, err
 	}
+	return svc.GetExecMetrics(), nil
+}
 
-	cfg, err := a.db.GetConfig()
+// GitPanelResetExecMetrics zera as métricas de execução.
+func (a *App) GitPanelResetExecMetrics() error {
+	svc, err := a.requireGitPanelService()
 	if err != nil {
 		return err
 	}
-
-	cfg.Theme = normalizeTheme(theme)
-	return a.db.UpdateConfig(cfg)
+	svc.ResetExecMetrics()
+	return nil
 }
 
-// SaveLanguage persiste o idioma escolhido pelo usuário.
-func (a *App) SaveLanguage(language string) error {
-	if a.db == nil {
-		return nil
-	}
-
-	cfg, err := a.db.GetConfig()
+// GitPanelWarmCache pré-calcula status/histórico/diffs do repositório em background. O
+// resultado chega pelo evento "gitpanel:cache_warmed"; chamadas repetidas enquanto um
+// warm-up do mesmo repo está em andamento são ignoradas.
+func (a *App) GitPanelWarmCache(repoPath string) error {
+	svc, err := a.requireGitPanelService()
 	if err != nil {
 		return err
 	}
-
-	cfg.Language = normalizeLanguage(language)
-	return a.db.UpdateConfig(cfg)
+	if _, preflightErr := svc.Preflight(repoPath); preflightErr != nil {
+		return a.normalizeGitPanelBindingError(preflightErr)
+	}
+	a.warmGitPanelCacheAsync(repoPath)
+	return nil
 }
 
-// SaveDefaultShell persiste o shell escolhido pelo usuário.
-func (a *App) SaveDefaultShell(shell string) error {
-	if a.db == nil {
-		return nil
+func (a *App) warmGitPanelCacheAsync(repoPath string) {
+	svc := a.gitPanel
+	if svc == nil {
+		return
+	}
+	key, normalizedRepoPath := normalizeGitPanelInvalidationRepo(repoPath)
+	if normalizedRepoPath == "" {
+		return
 	}
 
-	cfg, err := a.db.GetConfig()
-	if err != nil {
-		return err
+	a.gitPanelEventsMu.Lock()
+	if _, running := a.gitPanelWarming[key]; running {
+		a.gitPanelEventsMu.Unlock()
+		return
 	}
+	a.gitPanelWarming[key] = struct{}{}
+	a.gitPanelEventsMu.Unlock()
 
-	cfg.DefaultShell = strings.TrimSpace(shell)
-	return a.db.UpdateConfig(cfg)
+	go func() {
+		defer func() {
+			a.gitPanelEventsMu.Lock()
+			delete(a.gitPanelWarming, key)
+			a.gitPanelEventsMu.Unlock()
+		}()
+
+		result, err := svc.WarmCache(normalizedRepoPath, a.resolveGitPathScope(svc, normalizedRepoPath))
+		if err != nil {
+			log.Printf("[ORCH][GitPanel] cache warm-up failed repo=%s err=%v", normalizedRepoPath, err)
+			return
+		}
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "gitpanel:cache_warmed", result)
+		}
+	}()
 }
 
-// SaveTerminalFontSize persiste o tamanho da fonte do terminal.
-func (a *App) SaveTerminalFontSize(size int) error {
-	if a.db == nil {
-		return nil
-	}
+// === Git Panel Commit & Hooks ===
 
-	cfg, err := a.db.GetConfig()
-	if err != nil {
-		return err
-	}
+// GitPanelCommit cria um commit com o stage atual e retorna a saída de cada hook executado.
+// options.NoVerify só é aceito quando o workspace do repositório permite pular hooks; a mensagem
+// é validada (Conventional Commits) conforme o modo de lint do workspace.
+func (a *App) GitPanelCommit(repoPath string, message string, options gp.CommitOptionsDTO) (gp.CommitResultDTO, error) {
+	return a.gitPanelCommit(context.Background(), repoPath, message, options)
+}
 
-	cfg.FontSize = normalizeTerminalFontSize(size)
-	return a.db.UpdateConfig(cfg)
+// GitPanelCommitCancelable é GitPanelCommit abortável via CancelOperation(token); os hooks em
+// execução são mortos junto com o git commit.
+func (a *App) GitPanelCommitCancelable(token string, repoPath string, message string, options gp.CommitOptionsDTO) (gp.CommitResultDTO, error) {
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+	return a.gitPanelCommit(ctx, repoPath, message, options)
 }
 
-// SaveTerminalFontFamily persiste a família de fonte do terminal.
-func (a *App) SaveTerminalFontFamily(family string) error {
-	if a.db == nil {
-		return nil
+func (a *App) gitPanelCommit(ctx context.Context, repoPath string, message string, options gp.CommitOptionsDTO) (gp.CommitResultDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.CommitResultDTO{}, err
+	}
+	ws := a.resolveGitWorkspace(svc, repoPath)
+	options.LintMode = resolveGitCommitLintMode(ws)
+	if options.NoVerify {
+		if ws == nil || !ws.GitAllowNoVerify {
+			return gp.CommitResultDTO{}, a.normalizeGitPanelBindingError(gp.NewBindingError(
+				gp.CodeHookBypassDenied,
+				"Commit com --no-verify desabilitado para este workspace.",
+				"Habilite a opção nas configurações Git do workspace.",
+			))
+		}
 	}
 
-	cfg, err := a.db.GetConfig()
-	if err != nil {
-		return err
+	if ws != nil && ws.GitCoAuthorGuests {
+		for _, coAuthor := range a.suggestGitPanelCoAuthors(ws) {
+			options.CoAuthors = append(options.CoAuthors, coAuthor.Trailer)
+		}
 	}
 
-	cfg.FontFamily = normalizeTerminalFontFamily(family)
-	return a.db.UpdateConfig(cfg)
+	result, commitErr := svc.CommitContext(ctx, repoPath, message, options)
+	if commitErr != nil {
+		return gp.CommitResultDTO{}, a.normalizeGitPanelBindingError(commitErr)
+	}
+	if result.Committed {
+		a.auditGitSecretOverrides(result)
+	} else if result.Secrets != nil && result.Secrets.Blocking > 0 {
+		a.notifyEvent(notify.EventSecretDetected, "Commit blocked: secrets detected",
+			fmt.Sprintf("%d possible secret(s) found in staged changes.", result.Secrets.Blocking),
+			map[string]string{"repo": filepath.Base(result.Secrets.RepoPath), "findings": strconv.Itoa(result.Secrets.Blocking)})
+	}
+	return result, nil
 }
 
-// SaveTerminalCursorStyle persiste o estilo do cursor do terminal.
-func (a *App) SaveTerminalCursorStyle(style string) error {
-	if a.db == nil {
-		return nil
+// GitPanelCheckPushSafety avalia branch protegida, force push e arquivos grandes antes do push.
+func (a *App) GitPanelCheckPushSafety(repoPath string, options gp.PushOptionsDTO) (gp.PushSafetyReportDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.PushSafetyReportDTO{}, err
 	}
+	options.ProtectedBranches = a.gitHubProtectedBranches(repoPath)
 
-	cfg, err := a.db.GetConfig()
+	report, checkErr := svc.CheckPushSafety(repoPath, options)
+	if checkErr != nil {
+		return gp.PushSafetyReportDTO{}, a.normalizeGitPanelBindingError(checkErr)
+	}
+	return report, nil
+}
+
+// GitPanelPush envia a branch ao remoto; riscos detectados exigem as confirmações do payload.
+func (a *App) GitPanelPush(repoPath string, options gp.PushOptionsDTO) (gp.PushResultDTO, error) {
+	svc, err := a.requireGitPanelService()
 	if err != nil {
-		return err
+		return gp.PushResultDTO{}, err
 	}
+	options.ProtectedBranches = a.gitHubProtectedBranches(repoPath)
 
-	cfg.CursorStyle = normalizeTerminalCursorStyle(style)
-	return a.db.UpdateConfig(cfg)
+	result, pushErr := svc.Push(repoPath, options)
+	if pushErr != nil {
+		return gp.PushResultDTO{}, a.normalizeGitPanelBindingError(pushErr)
+	}
+	return result, nil
 }
 
-// SaveShortcutBindings persiste os atalhos customizados do usuário como JSON.
-func (a *App) SaveShortcutBindings(bindingsJSON string) error {
-	if a.db == nil {
+// gitHubProtectedBranches busca a proteção de branches no GitHub; falhas (sem conta, sem rede) são ignoradas
+// e o guard segue com a config local e a branch default.
+func (a *App) gitHubProtectedBranches(repoPath string) []string {
+	if !a.awaitGitHub() {
 		return nil
 	}
-
-	normalized, err := normalizeShortcutBindingsJSON(bindingsJSON)
+	owner, repo, err := a.resolveGitPanelPROwnerRepo(repoPath)
 	if err != nil {
-		return err
+		return nil
 	}
-
-	cfg, err := a.db.GetConfig()
+	branches, err := a.github.ListProtectedBranches(a.requestContext(), owner, repo)
 	if err != nil {
-		return err
+		return nil
 	}
-
-	cfg.ShortcutBindings = normalized
-	return a.db.UpdateConfig(cfg)
+	names := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		names = append(names, branch.Name)
+	}
+	return names
 }
 
-// GetAvailableShells retorna a lista de shells disponíveis no sistema.
-func (a *App) GetAvailableShells() ([]string, error) {
-	return terminal.GetAvailableShells(), nil
-}
+// GitPanelScanStagedSecrets procura segredos nas linhas em stage (filePath vazio = stage inteiro).
+func (a *App) GitPanelScanStagedSecrets(repoPath string, filePath string) (gp.SecretScanResultDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.SecretScanResultDTO{}, err
+	}
 
-// GetAvailableTerminalFonts retorna famílias de fontes instaladas no sistema.
-func (a *App) GetAvailableTerminalFonts() ([]string, error) {
-	return terminal.GetAvailableFonts(), nil
+	result, scanErr := svc.ScanStagedSecrets(repoPath, filePath)
+	if scanErr != nil {
+		return gp.SecretScanResultDTO{}, a.normalizeGitPanelBindingError(scanErr)
+	}
+	return result, nil
 }
 
-// CompleteOnboarding marca onboarding como concluído.
-func (a *App) CompleteOnboarding() error {
+// GitPanelListSecretOverrides lista os overrides de segredo auditados do repositório.
+func (a *App) GitPanelListSecretOverrides(repoPath string, limit int) ([]database.AuditLog, error) {
 	if a.db == nil {
-		return nil
+		return nil, fmt.Errorf("database not initialized")
 	}
-
-	cfg, err := a.db.GetConfig()
+	svc, err := a.requireGitPanelService()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	preflight, err := svc.Preflight(repoPath)
+	if err != nil {
+		return nil, a.normalizeGitPanelBindingError(err)
 	}
+	return a.db.ListAuditEvents(gitSecretAuditSessionID(preflight.RepoRoot), limit)
+}
 
-	cfg.OnboardingCompleted = true
-	return a.db.UpdateConfig(cfg)
+// auditGitSecretOverrides registra cada achado de segredo commitado com override explícito.
+func (a *App) auditGitSecretOverrides(result gp.CommitResultDTO) {
+	if result.Secrets == nil {
+		return
+	}
+	userID := a.resolveSessionHostUserID()
+	for _, finding := range result.Secrets.Findings {
+		if !finding.Overridden {
+			continue
+		}
+		a.auditSessionEvent(
+			gitSecretAuditSessionID(result.Secrets.RepoPath),
+			userID,
+			"git_secret_override",
+			fmt.Sprintf("finding=%s rule=%s file=%s:%d commit=%s", finding.ID, finding.RuleID, finding.FilePath, finding.Line, result.CommitHash),
+		)
+	}
 }
 
-// === GitHub Bindings (expostos ao Frontend) ===
+func gitSecretAuditSessionID(repoRoot string) string {
+	return "gitpanel:" + filepath.Clean(repoRoot)
+}
 
-// GHListRepositories lista repositórios do usuário
-func (a *App) GHListRepositories() ([]gh.Repository, error) {
-	if a.github == nil {
-		return nil, nil
+// GitPanelGetHookSetup lista hooks de commit instalados, frameworks detectados e se --no-verify é permitido.
+func (a *App) GitPanelGetHookSetup(repoPath string) (gp.HookSetupDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.HookSetupDTO{}, err
 	}
-	return a.github.ListRepositories()
-}
 
-// GHListPullRequests lista PRs de um repositório
-func (a *App) GHListPullRequests(owner, repo, state string, first int) ([]gh.PullRequest, error) {
-	if a.github == nil {
-		return nil, nil
+	setup, setupErr := svc.GetHookSetup(repoPath)
+	if setupErr != nil {
+		return gp.HookSetupDTO{}, a.normalizeGitPanelBindingError(setupErr)
 	}
-	filters := gh.PRFilters{State: state, First: first}
-	return a.github.ListPullRequests(owner, repo, filters)
+	if ws := a.resolveGitWorkspace(svc, repoPath); ws != nil {
+		setup.AllowNoVerify = ws.GitAllowNoVerify
+	}
+	return setup, nil
 }
 
-// GHGetPullRequest busca detalhes de um PR
-func (a *App) GHGetPullRequest(owner, repo string, number int) (*gh.PullRequest, error) {
-	if a.github == nil {
-		return nil, nil
+// GitPanelSetAllowNoVerify habilita/desabilita commits com --no-verify no workspace.
+func (a *App) GitPanelSetAllowNoVerify(workspaceID uint, allow bool) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
 	}
-	return a.github.GetPullRequest(owner, repo, number)
+	return a.db.SetWorkspaceGitAllowNoVerify(workspaceID, allow)
 }
 
-// GHGetPullRequestDiff busca o diff de um PR
-func (a *App) GHGetPullRequestDiff(owner, repo string, number int, first int, after string) (*gh.Diff, error) {
-	if a.github == nil {
-		return nil, nil
+// GitPanelSuggestCoAuthors lista os convidados conectados à sessão colaborativa ativa do workspace
+// do repositório, com o e-mail noreply do GitHub de cada um.
+func (a *App) GitPanelSuggestCoAuthors(repoPath string) ([]GitPanelCoAuthorDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return nil, err
 	}
-	pagination := gh.DiffPagination{First: first}
-	if after != "" {
-		pagination.After = &after
+	if _, preflightErr := svc.Preflight(repoPath); preflightErr != nil {
+		return nil, a.normalizeGitPanelBindingError(preflightErr)
 	}
-	return a.github.GetPullRequestDiff(owner, repo, number, pagination)
+	return a.suggestGitPanelCoAuthors(a.resolveGitWorkspace(svc, repoPath)), nil
 }
 
-// GHCreatePullRequest cria um novo PR
-func (a *App) GHCreatePullRequest(owner, repo, title, body, head, base string, isDraft bool) (*gh.PullRequest, error) {
-	if a.github == nil {
-		return nil, nil
+// GitPanelSetCoAuthorGuests habilita/desabilita os trailers Co-authored-by automáticos no workspace.
+func (a *App) GitPanelSetCoAuthorGuests(workspaceID uint, enabled bool) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
 	}
-	return a.github.CreatePullRequest(gh.CreatePRInput{
-		Owner: owner, Repo: repo, Title: title, Body: body,
-		HeadBranch: head, BaseBranch: base, IsDraft: isDraft,
-	})
+	return a.db.SetWorkspaceGitCoAuthorGuests(workspaceID, enabled)
 }
 
-// GHMergePullRequest faz merge de um PR
-func (a *App) GHMergePullRequest(owner, repo string, number int, method string) error {
-	if a.github == nil {
-		return nil
+// suggestGitPanelCoAuthors ignora sessões de outro workspace e convidados sem login do GitHub
+// (ex.: orchctl); falhas da API apenas omitem o convidado.
+func (a *App) suggestGitPanelCoAuthors(ws *database.Workspace) []GitPanelCoAuthorDTO {
+	suggestions := []GitPanelCoAuthorDTO{}
+	sess, err := a.resolveActiveCollabSession(a.resolveSessionHostUserID())
+	if err != nil || sess == nil {
+		return suggestions
 	}
-	return a.github.MergePullRequest(owner, repo, number, gh.MergeMethod(method))
+	if sess.Config.WorkspaceID != 0 && (ws == nil || ws.ID != sess.Config.WorkspaceID) {
+		return suggestions
+	}
+
+	seen := map[string]bool{}
+	for _, guest := range sess.Guests {
+		login := strings.TrimSpace(guest.Login)
+		if guest.Status != session.GuestConnected || login == "" || seen[strings.ToLower(login)] {
+			continue
+		}
+		seen[strings.ToLower(login)] = true
+		identity := a.lookupGitPanelCoAuthorIdentity(login)
+		if identity == nil {
+			continue
+		}
+		name := strings.TrimSpace(guest.Name)
+		if name == "" || name == guest.UserID {
+			name = cmp.Or(identity.Name, identity.Login)
+		}
+		trailer, ok := gp.NormalizeCoAuthor(name + " <" + identity.NoReplyEmail + ">")
+		if !ok {
+			continue
+		}
+		suggestions = append(suggestions, GitPanelCoAuthorDTO{
+			UserID:  guest.UserID,
+			Name:    name,
+			Login:   identity.Login,
+			Email:   identity.NoReplyEmail,
+			Trailer: trailer,
+		})
+	}
+	return suggestions
 }
 
-// GHClosePullRequest fecha um PR
-func (a *App) GHClosePullRequest(owner, repo string, number int) error {
-	if a.github == nil {
+// lookupGitPanelCoAuthorIdentity consulta (com cache) o id do usuário para o e-mail noreply.
+func (a *App) lookupGitPanelCoAuthorIdentity(login string) *gh.UserIdentity {
+	if !a.awaitGitHub() {
 		return nil
 	}
-	return a.github.ClosePullRequest(owner, repo, number)
-}
+	key := strings.ToLower(login)
+	now := time.Now()
 
-// GHListReviews lista reviews de um PR
-func (a *App) GHListReviews(owner, repo string, prNumber int) ([]gh.Review, error) {
-	if a.github == nil {
-		return nil, nil
+	a.gitPanelAuthorMu.Lock()
+	if cached, ok := a.gitPanelCoAuthors[key]; ok && now.Before(cached.expiresAt) {
+		a.gitPanelAuthorMu.Unlock()
+		return cached.identity
 	}
-	return a.github.ListReviews(owner, repo, prNumber)
-}
+	a.gitPanelAuthorMu.Unlock()
 
-// GHCreateReview cria um review em um PR
-func (a *App) GHCreateReview(owner, repo string, prNumber int, body, event string) (*gh.Review, error) {
-	if a.github == nil {
-		return nil, nil
+	identity, err := a.github.GetUserIdentity(a.requestContext(), login)
+	entry := gitPanelCoAuthorCacheEntry{identity: identity, expiresAt: now.Add(gitPanelAuthorCacheTTL)}
+	if err != nil {
+		entry = gitPanelCoAuthorCacheEntry{expiresAt: now.Add(gitPanelAuthorMissTTL)}
 	}
-	return a.github.CreateReview(gh.CreateReviewInput{
-		Owner: owner, Repo: repo, PRNumber: prNumber, Body: body, Event: event,
-	})
+	a.gitPanelAuthorMu.Lock()
+	a.gitPanelCoAuthors[key] = entry
+	a.gitPanelAuthorMu.Unlock()
+	return entry.identity
 }
 
-// GHListComments lista comentários de um PR
-func (a *App) GHListComments(owner, repo string, prNumber int) ([]gh.Comment, error) {
-	if a.github == nil {
-		return nil, nil
+// GitPanelLintCommitMessage valida a mensagem contra Conventional Commits (commitlint do repo, se houver).
+func (a *App) GitPanelLintCommitMessage(repoPath string, message string) (gp.CommitLintResultDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.CommitLintResultDTO{}, err
 	}
-	return a.github.ListComments(owner, repo, prNumber)
-}
 
-// GHCreateComment cria um comentário em um PR
-func (a *App) GHCreateComment(owner, repo string, prNumber int, body string) (*gh.Comment, error) {
-	if a.github == nil {
-		return nil, nil
+	result, lintErr := svc.LintCommitMessage(repoPath, message, resolveGitCommitLintMode(a.resolveGitWorkspace(svc, repoPath)))
+	if lintErr != nil {
+		return gp.CommitLintResultDTO{}, a.normalizeGitPanelBindingError(lintErr)
 	}
-	return a.github.CreateComment(gh.CreateCommentInput{
-		Owner: owner, Repo: repo, PRNumber: prNumber, Body: body,
-	})
+	return result, nil
 }
 
-// GHCreateInlineComment cria um comentário inline no diff
-func (a *App) GHCreateInlineComment(owner, repo string, prNumber int, body, path string, line int, side string) (*gh.Comment, error) {
-	if a.github == nil {
-		return nil, nil
+// GitPanelSuggestCommitScopes sugere types/scopes de Conventional Commits a partir do stage.
+func (a *App) GitPanelSuggestCommitScopes(repoPath string) (gp.CommitSuggestionsDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.CommitSuggestionsDTO{}, err
 	}
-	return a.github.CreateInlineComment(gh.InlineCommentInput{
-		Owner: owner, Repo: repo, PRNumber: prNumber,
-		Body: body, Path: path, Line: line, Side: side,
-	})
-}
 
-// GHListIssues lista issues de um repositório
-func (a *App) GHListIssues(owner, repo, state string, first int) ([]gh.Issue, error) {
-	if a.github == nil {
-		return nil, nil
+	result, suggestErr := svc.SuggestCommitScopes(repoPath)
+	if suggestErr != nil {
+		return gp.CommitSuggestionsDTO{}, a.normalizeGitPanelBindingError(suggestErr)
 	}
-	filters := gh.IssueFilters{State: state, First: first}
-	return a.github.ListIssues(owner, repo, filters)
+	return result, nil
 }
 
-// GHCreateIssue cria uma nova issue
-func (a *App) GHCreateIssue(owner, repo, title, body string) (*gh.Issue, error) {
-	if a.github == nil {
-		return nil, nil
+// GitPanelSetCommitLintMode define se mensagens fora do padrão são ignoradas, avisadas ou bloqueadas.
+func (a *App) GitPanelSetCommitLintMode(workspaceID uint, mode string) (string, error) {
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
 	}
-	return a.github.CreateIssue(gh.CreateIssueInput{
-		Owner: owner, Repo: repo, Title: title, Body: body,
-	})
+	normalized := strings.ToLower(strings.TrimSpace(mode))
+	if normalized != gp.CommitLintModeOff && normalized != gp.CommitLintModeWarn && normalized != gp.CommitLintModeBlock {
+		return "", fmt.Errorf("invalid commit lint mode: %q", mode)
+	}
+	if err := a.db.SetWorkspaceGitCommitLintMode(workspaceID, normalized); err != nil {
+		return "", err
+	}
+	return normalized, nil
 }
 
-// GHUpdateIssue atualiza uma issue
-func (a *App) GHUpdateIssue(owner, repo string, number int, title, body, state *string) error {
-	if a.github == nil {
-		return nil
+// branchNamingAITimeout limita a sugestão de slug pela IA; estourado, vale a heurística.
+const branchNamingAITimeout = 20 * time.Second
+
+// GitPanelGetBranchNamingPolicy retorna os templates de nome de branch do repositório (vazio = sem política).
+func (a *App) GitPanelGetBranchNamingPolicy(repoPath string) (gp.BranchNamingPolicyDTO, error) {
+	policy := gp.BranchNamingPolicyDTO{Templates: []string{}, Mode: gp.BranchNamingModeWarn}
+	repoRoot := findGitRepoRoot(strings.TrimSpace(repoPath))
+	if a.db == nil || strings.TrimSpace(repoPath) == "" || repoRoot == "" {
+		return policy, nil
 	}
-	return a.github.UpdateIssue(owner, repo, number, gh.UpdateIssueInput{
-		Title: title, Body: body, State: state,
-	})
+	stored, err := a.db.GetGitBranchNamingPolicy(repoRoot)
+	if err != nil || stored == nil {
+		return policy, err
+	}
+	policy.Templates = gp.ParseBranchNamingTemplates(stored.Templates)
+	policy.Mode = gp.NormalizeBranchNamingMode(stored.Mode)
+	return policy, nil
 }
 
-// GHListBranches lista branches de um repositório
-func (a *App) GHListBranches(owner, repo string) ([]gh.Branch, error) {
-	if a.github == nil {
-		return nil, nil
+// GitPanelSetBranchNamingPolicy salva os templates (ex.: feature/{ticket}-{slug}) e o modo off | warn | block.
+// Sem templates a política do repositório é removida.
+func (a *App) GitPanelSetBranchNamingPolicy(repoPath string, policy gp.BranchNamingPolicyDTO) (gp.BranchNamingPolicyDTO, error) {
+	if a.db == nil {
+		return gp.BranchNamingPolicyDTO{}, fmt.Errorf("database not initialized")
 	}
-	return a.github.ListBranches(owner, repo)
+	repoRoot := findGitRepoRoot(strings.TrimSpace(repoPath))
+	if strings.TrimSpace(repoPath) == "" || repoRoot == "" {
+		return gp.BranchNamingPolicyDTO{}, fmt.Errorf("not a git repository: %s", repoPath)
+	}
+	normalized, err := gp.ValidateBranchNamingPolicy(policy)
+	if err != nil {
+		return gp.BranchNamingPolicyDTO{}, err
+	}
+	if err := a.db.SaveGitBranchNamingPolicy(repoRoot, strings.Join(normalized.Templates, "\n"), normalized.Mode); err != nil {
+		return gp.BranchNamingPolicyDTO{}, err
+	}
+	return normalized, nil
 }
 
-// GHCreateBranch cria uma nova branch
-func (a *App) GHCreateBranch(owner, repo, name, sourceBranch string) (*gh.Branch, error) {
-	if a.github == nil {
-		return nil, nil
+// GitPanelValidateBranchName confere o nome contra a política do repositório e explica as violações.
+func (a *App) GitPanelValidateBranchName(repoPath string, branch string) (gp.BranchNamingValidationDTO, error) {
+	policy, err := a.GitPanelGetBranchNamingPolicy(repoPath)
+	if err != nil {
+		return gp.BranchNamingValidationDTO{}, err
 	}
-	return a.github.CreateBranch(owner, repo, name, sourceBranch)
+	return gp.ValidateBranchName(branch, policy), nil
 }
 
-// GHInvalidateCache invalida o cache de um repositório
-func (a *App) GHInvalidateCache(owner, repo string) {
-	if a.github == nil {
-		return
+// GitPanelSuggestBranchName gera um nome de branch que segue a política a partir da descrição e do
+// ticket; com useAI o slug é resumido pela IA (falhas caem na heurística).
+func (a *App) GitPanelSuggestBranchName(repoPath string, description string, ticket string, useAI bool) (gp.BranchNameSuggestionDTO, error) {
+	policy, err := a.GitPanelGetBranchNamingPolicy(repoPath)
+	if err != nil {
+		return gp.BranchNameSuggestionDTO{}, err
+	}
+	user := ""
+	if repoRoot := findGitRepoRoot(strings.TrimSpace(repoPath)); repoRoot != "" {
+		if out, userErr := runGitPanelPRCommand("-C", repoRoot, "config", "user.name"); userErr == nil {
+			user = strings.TrimSpace(out)
+		}
 	}
-	a.github.InvalidateCache(owner, repo)
-}
 
-// === FileWatcher Bindings (expostos ao Frontend) ===
+	slug, source, warning := "", gp.BranchSuggestionSourceHeuristic, ""
+	if useAI && strings.TrimSpace(description) != "" {
+		if a.ai == nil {
+			warning = "IA indisponível: slug gerado pela heurística"
+		} else {
+			parent := a.ctx
+			if parent == nil {
+				parent = context.Background()
+			}
+			ctx, cancel := context.WithTimeout(parent, branchNamingAITimeout)
+			text, aiErr := a.ai.Complete(ctx, gp.BranchSlugPrompt(description))
+			cancel()
+			if aiErr != nil {
+				warning = fmt.Sprintf("IA: slug não gerado (%v)", aiErr)
+			} else if slug = gp.SlugifyBranchDescription(text); slug != "" {
+				source = gp.BranchSuggestionSourceAI
+			}
+		}
+	}
 
-// WatchProject inicia monitoramento do .git de um projeto
-func (a *App) WatchProject(projectPath string) error {
-	if a.fileWatcher == nil {
-		return nil
+	suggestion, err := gp.SuggestBranchName(description, ticket, slug, user, policy)
+	if err != nil {
+		return gp.BranchNameSuggestionDTO{}, err
 	}
-	return a.fileWatcher.Watch(projectPath)
+	suggestion.Source, suggestion.Warning = source, warning
+	return suggestion, nil
 }
 
-// UnwatchProject para monitoramento de um projeto
-func (a *App) UnwatchProject(projectPath string) error {
-	if a.fileWatcher == nil {
-		return nil
+// GitPanelGetSigningStatus detecta a configuração de assinatura de commits (GPG/SSH) do repositório.
+func (a *App) GitPanelGetSigningStatus(repoPath string) (gp.SigningStatusDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.SigningStatusDTO{}, err
 	}
-	return a.fileWatcher.Unwatch(projectPath)
-}
 
-// GetCurrentBranch retorna a branch atual do projeto
-func (a *App) GetCurrentBranch(projectPath string) (string, error) {
-	if a.fileWatcher == nil {
-		return "", nil
+	status, statusErr := svc.GetSigningStatus(repoPath)
+	if statusErr != nil {
+		return gp.SigningStatusDTO{}, a.normalizeGitPanelBindingError(statusErr)
 	}
-	return a.fileWatcher.GetCurrentBranch(projectPath)
+	return status, nil
 }
 
-// GetLastCommit retorna informações do último commit
-func (a *App) GetLastCommit(projectPath string) (*fw.CommitInfo, error) {
-	if a.fileWatcher == nil {
-		return nil, nil
+// GitPanelConfigureSigning grava gpg.format/user.signingkey/commit.gpgsign no config local do repositório.
+func (a *App) GitPanelConfigureSigning(repoPath string, setup gp.SigningSetupDTO) (gp.SigningStatusDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.SigningStatusDTO{}, err
 	}
-	return a.fileWatcher.GetLastCommit(projectPath)
+
+	status, setupErr := svc.ConfigureSigning(repoPath, setup)
+	if setupErr != nil {
+		return gp.SigningStatusDTO{}, a.normalizeGitPanelBindingError(setupErr)
+	}
+	return status, nil
 }
 
-// === GitActivity Bindings (expostos ao Frontend) ===
+// GitPanelGetReflog lista as entradas recentes do reflog do HEAD.
+func (a *App) GitPanelGetReflog(repoPath string, limit int) ([]gp.ReflogEntryDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return nil, err
+	}
 
-// GitActivityList retorna eventos de atividade Git em ordem mais recente primeiro.
-func (a *App) GitActivityList(limit int, eventType string, repoPath string) []ga.Event {
-	if a.gitActivity == nil {
-		return []ga.Event{}
+	entries, reflogErr := svc.GetReflog(repoPath, limit)
+	if reflogErr != nil {
+		return nil, a.normalizeGitPanelBindingError(reflogErr)
 	}
+	return entries, nil
+}
 
-	opts := ga.ListOptions{
-		Limit:    limit,
-		RepoPath: repoPath,
+// GitPanelUndoLast desfaz a última operação (commit, merge, reset, rebase...); dryRun só mostra o plano.
+func (a *App) GitPanelUndoLast(repoPath string, dryRun bool) (gp.UndoPlanDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.UndoPlanDTO{}, err
 	}
-	if normalized := strings.TrimSpace(eventType); normalized != "" {
-		opts.Type = ga.EventType(normalized)
+
+	plan, undoErr := svc.UndoLast(repoPath, dryRun)
+	if undoErr != nil {
+		return gp.UndoPlanDTO{}, a.normalizeGitPanelBindingError(undoErr)
+	}
+	return plan, nil
+}
+
+// GitPanelGetConfig lista as chaves editáveis do git config no escopo (local | global).
+func (a *App) GitPanelGetConfig(repoPath string, scope string) (gp.GitConfigDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.GitConfigDTO{}, err
 	}
 
-	return a.gitActivity.ListEvents(opts)
+	config, configErr := svc.GetConfig(a.requestContext(), repoPath, scope)
+	if configErr != nil {
+		return gp.GitConfigDTO{}, a.normalizeGitPanelBindingError(configErr)
+	}
+	return a.withGitHubIdentityWarning(config), nil
 }
 
-// GitActivityGet retorna um evento específico por ID.
-func (a *App) GitActivityGet(eventID string) (*ga.Event, error) {
-	if a.gitActivity == nil {
-		return nil, fmt.Errorf("git activity service not initialized")
+// GitPanelSetConfig grava uma chave da whitelist no escopo; valor vazio remove a chave.
+func (a *App) GitPanelSetConfig(repoPath string, scope string, key string, value string) (gp.GitConfigDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.GitConfigDTO{}, err
 	}
-	event, ok := a.gitActivity.GetEvent(strings.TrimSpace(eventID))
-	if !ok {
-		return nil, fmt.Errorf("git activity event not found")
+
+	config, setErr := svc.SetConfig(a.requestContext(), repoPath, scope, key, value)
+	if setErr != nil {
+		return gp.GitConfigDTO{}, a.normalizeGitPanelBindingError(setErr)
 	}
-	return event, nil
+	return a.withGitHubIdentityWarning(config), nil
 }
 
-// GitActivityClear remove todos os eventos armazenados.
-func (a *App) GitActivityClear() {
-	if a.gitActivity == nil {
-		return
+// withGitHubIdentityWarning avisa quando o e-mail efetivo de commit não é o da conta GitHub
+// autenticada: os commits não seriam associados ao perfil.
+func (a *App) withGitHubIdentityWarning(config gp.GitConfigDTO) gp.GitConfigDTO {
+	if a.auth == nil {
+		return config
 	}
-	a.gitActivity.Clear()
+	user, err := a.auth.GetCurrentUser()
+	if err != nil || user == nil || !strings.EqualFold(strings.TrimSpace(user.Provider), "github") {
+		return config
+	}
+	email := gp.GitConfigEffectiveValue(config, "user.email")
+	if email == "" || gitHubIdentityMatchesEmail(user, email) {
+		return config
+	}
+	login := strings.TrimSpace(user.Username)
+	if login == "" {
+		login = strings.TrimSpace(user.Email)
+	}
+	config.Warnings = append(config.Warnings, fmt.Sprintf(
+		"O e-mail de commit %s não é o da conta GitHub autenticada (%s); confirme que ele está verificado na conta ou os commits não aparecerão no seu perfil.",
+		email,
+		login,
+	))
+	return config
 }
 
-// GitActivityCount retorna quantidade atual de eventos no buffer.
-func (a *App) GitActivityCount() int {
-	if a.gitActivity == nil {
-		return 0
+// gitHubIdentityMatchesEmail aceita o e-mail da conta e os endereços noreply do GitHub
+// (<login>@users.noreply.github.com e <id>+<login>@users.noreply.github.com).
+func gitHubIdentityMatchesEmail(user *auth.User, email string) bool {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if accountEmail := strings.ToLower(strings.TrimSpace(user.Email)); accountEmail != "" && accountEmail == email {
+		return true
 	}
-	return a.gitActivity.Count()
+	local, ok := strings.CutSuffix(email, "@users.noreply.github.com")
+	login := strings.ToLower(strings.TrimSpace(user.Username))
+	if !ok || login == "" {
+		return false
+	}
+	if _, afterPlus, found := strings.Cut(local, "+"); found {
+		local = afterPlus
+	}
+	return local == login
 }
 
-// GitActivityGetStagedFiles retorna resumo de arquivos staged para um repositório.
-func (a *App) GitActivityGetStagedFiles(repoPath string) ([]ga.EventFile, error) {
-	return ga.CollectStagedFiles(repoPath)
+// ensureGitPanelBranchNamingPolicy bloqueia branches novas fora da política quando o modo é block.
+func (a *App) ensureGitPanelBranchNamingPolicy(repoRoot string, branch string) error {
+	if _, err := runGitPanelPRCommand("-C", repoRoot, "show-ref", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		return nil // branch existente: só faz checkout
+	}
+	validation, err := a.GitPanelValidateBranchName(repoRoot, branch)
+	if err != nil || !validation.Blocking {
+		return nil
+	}
+	return gpr.NewBindingError(
+		gpr.CodeValidationFailed,
+		"Nome de branch fora da politica de nomes do repositorio.",
+		strings.Join(validation.Violations, "\n"),
+	)
 }
 
-// GitActivityGetStagedDiff retorna diff staged (geral ou por arquivo).
-func (a *App) GitActivityGetStagedDiff(repoPath string, filePath string) (string, error) {
-	return ga.GetStagedDiff(repoPath, filePath)
+func resolveGitCommitLintMode(ws *database.Workspace) string {
+	if ws == nil {
+		return gp.CommitLintModeWarn
+	}
+	return gp.NormalizeCommitLintMode(ws.GitCommitLintMode)
 }
 
-// GitActivityUnstageFile remove um arquivo do stage.
-func (a *App) GitActivityUnstageFile(repoPath string, filePath string) error {
-	return ga.UnstageFile(repoPath, filePath)
+// resolveGitWorkspace retorna o workspace que contém o repositório (principal ou anexado),
+// priorizando o workspace ativo.
+func (a *App) resolveGitWorkspace(svc *gp.Service, repoPath string) *database.Workspace {
+	if a.db == nil || svc == nil {
+		return nil
+	}
+	preflight, err := svc.Preflight(repoPath)
+	if err != nil {
+		return nil
+	}
+	repoRoot := filepath.Clean(preflight.RepoRoot)
+
+	workspaces, err := a.db.ListWorkspaces()
+	if err != nil {
+		return nil
+	}
+	sort.SliceStable(workspaces, func(i, j int) bool {
+		return workspaces[i].IsActive && !workspaces[j].IsActive
+	})
+	for idx := range workspaces {
+		for _, path := range a.workspaceRepoPaths(&workspaces[idx]) {
+			if root := findGitRepoRoot(path); root != "" && filepath.Clean(root) == repoRoot {
+				return &workspaces[idx]
+			}
+		}
+	}
+	return nil
 }
 
-// GitActivityDiscardFile descarta mudanças locais de um arquivo.
-func (a *App) GitActivityDiscardFile(repoPath string, filePath string) error {
-	return ga.DiscardFile(repoPath, filePath)
+// === Workspace Template Bindings ===
+
+const maxTemplateStartupCommands = 20
+
+var envVarKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// WorkspaceTemplateAgentDTO descreve um agente provisionado por um template.
+type WorkspaceTemplateAgentDTO struct {
+	Name            string   `json:"name"`
+	Type            string   `json:"type,omitempty"` // "terminal" | "ai_agent"
+	Shell           string   `json:"shell,omitempty"`
+	Cwd             string   `json:"cwd,omitempty"` // relativo ao path do workspace ou absoluto
+	UseDocker       bool     `json:"useDocker,omitempty"`
+	Env             []string `json:"env,omitempty"`
+	StartupCommands []string `json:"startupCommands,omitempty"`
 }
 
-// === GitPanel Bindings (expostos ao Frontend) ===
+// WorkspaceTemplateDTO representa um template de workspace para o frontend.
+type WorkspaceTemplateDTO struct {
+	ID          uint                        `json:"id"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description,omitempty"`
+	Path        string                      `json:"path,omitempty"`
+	DockerImage string                      `json:"dockerImage,omitempty"`
+	Env         []string                    `json:"env,omitempty"`
+	Agents      []WorkspaceTemplateAgentDTO `json:"agents"`
+}
 
-func (a *App) requireGitPanelService() (*gp.Service, error) {
-	if a.gitPanel == nil {
-		return nil, gp.NewBindingError(
-			gp.CodeServiceUnavailable,
-			"Git Panel service indisponível.",
-			"O backend do Git Panel ainda não foi inicializado.",
-		)
+func normalizeEnvAssignments(entries []string) ([]string, error) {
+	normalized := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		trimmed := strings.TrimSpace(entry)
+		if trimmed == "" {
+			continue
+		}
+		key, _, ok := strings.Cut(trimmed, "=")
+		if !ok || !envVarKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("invalid env entry %q: expected KEY=VALUE", entry)
+		}
+		normalized = append(normalized, trimmed)
 	}
-	return a.gitPanel, nil
+	return normalized, nil
 }
 
-func (a *App) normalizeGitPanelBindingError(err error) error {
-	if err == nil {
-		return nil
+func normalizeStartupCommands(commands []string) ([]string, error) {
+	normalized := make([]string, 0, len(commands))
+	for _, command := range commands {
+		trimmed := strings.TrimSpace(command)
+		if trimmed == "" {
+			continue
+		}
+		if strings.ContainsAny(trimmed, "\r\n") {
+			return nil, fmt.Errorf("startup command must be a single line: %q", command)
+		}
+		normalized = append(normalized, trimmed)
 	}
-	return gp.NormalizeBindingError(err)
+	if len(normalized) > maxTemplateStartupCommands {
+		return nil, fmt.Errorf("too many startup commands (max %d)", maxTemplateStartupCommands)
+	}
+	return normalized, nil
 }
 
-// GitPanelPreflight valida runtime e contexto de repositório antes das operações.
-func (a *App) GitPanelPreflight(repoPath string) (gp.PreflightResult, error) {
-	svc, err := a.requireGitPanelService()
+func normalizeWorkspaceTemplate(dto WorkspaceTemplateDTO) (WorkspaceTemplateDTO, error) {
+	dto.Name = strings.TrimSpace(dto.Name)
+	if dto.Name == "" {
+		return dto, fmt.Errorf("template name cannot be empty")
+	}
+	dto.Description = strings.TrimSpace(dto.Description)
+	dto.Path = strings.TrimSpace(dto.Path)
+
+	image, err := normalizeDockerImageRef(dto.DockerImage)
 	if err != nil {
-		return gp.PreflightResult{}, err
+		return dto, err
 	}
+	dto.DockerImage = image
 
-	result, preflightErr := svc.Preflight(repoPath)
-	if preflightErr != nil {
-		return gp.PreflightResult{}, a.normalizeGitPanelBindingError(preflightErr)
+	if dto.Env, err = normalizeEnvAssignments(dto.Env); err != nil {
+		return dto, err
 	}
-	return result, nil
-}
 
-// GitPanelPRResolveRepository resolve owner/repo via origin e aceita fallback manual validado.
-func (a *App) GitPanelPRResolveRepository(repoPath, manualOwner, manualRepo string, allowTargetOverride bool) (GitPanelPRRepositoryTargetDTO, error) {
-	result := GitPanelPRRepositoryTargetDTO{}
-	normalizedRepoPath := strings.TrimSpace(repoPath)
-	if normalizedRepoPath == "" {
-		return result, gpr.NewBindingError(
-			gpr.CodeRepoPathRequired,
-			"repoPath obrigatorio para resolver destino de Pull Request.",
-			"Abra um repositorio Git valido antes de acessar a aba PRs.",
-		)
+	if len(dto.Agents) > config.MaxAgents {
+		return dto, fmt.Errorf("too many agents in template (max %d)", config.MaxAgents)
 	}
 
-	svc, svcErr := a.requireGitPanelService()
-	if svcErr != nil {
-		return result, gpr.NewBindingError(
-			gpr.CodeServiceUnavailable,
-			"Git Panel service indisponivel para resolver Pull Requests.",
-			"O backend do Git Panel ainda nao foi inicializado.",
-		)
+	agents := make([]WorkspaceTemplateAgentDTO, 0, len(dto.Agents))
+	for idx, agent := range dto.Agents {
+		agent.Name = strings.TrimSpace(agent.Name)
+		if agent.Name == "" {
+			agent.Name = fmt.Sprintf("Terminal %d", idx+1)
+		}
+		agentType, typeErr := normalizeAgentSessionType(agent.Type)
+		if typeErr != nil {
+			return dto, typeErr
+		}
+		if agentType == kubePodAgentType {
+			return dto, fmt.Errorf("kube pod agents cannot be provisioned by templates")
+		}
+		agent.Type = agentType
+		agent.Shell = strings.TrimSpace(agent.Shell)
+		agent.Cwd = strings.TrimSpace(agent.Cwd)
+		if agent.Env, err = normalizeEnvAssignments(agent.Env); err != nil {
+			return dto, err
+		}
+		if agent.StartupCommands, err = normalizeStartupCommands(agent.StartupCommands); err != nil {
+			return dto, err
+		}
+		agents = append(agents, agent)
 	}
+	dto.Agents = agents
 
-	preflight, preflightErr := svc.Preflight(normalizedRepoPath)
-	if preflightErr != nil {
-		if bindingErr := gp.AsBindingError(preflightErr); bindingErr != nil {
-			return result, gpr.NewBindingError(
-				gpr.CodeRepoUnavailable,
-				"Repositorio Git invalido para operacoes de Pull Request.",
-				fmt.Sprintf("%s (%s)", strings.TrimSpace(bindingErr.Message), strings.TrimSpace(bindingErr.Code)),
-			)
+	return dto, nil
+}
+
+func workspaceTemplateToDTO(tpl database.WorkspaceTemplate) WorkspaceTemplateDTO {
+	dto := WorkspaceTemplateDTO{
+		ID:          tpl.ID,
+		Name:        tpl.Name,
+		Description: tpl.Description,
+		Path:        tpl.Path,
+		DockerImage: tpl.DockerImage,
+		Env:         decodeAgentStringList(tpl.Env),
+		Agents:      []WorkspaceTemplateAgentDTO{},
+	}
+	if strings.TrimSpace(tpl.Agents) != "" {
+		if err := json.Unmarshal([]byte(tpl.Agents), &dto.Agents); err != nil {
+			log.Printf("[ORCH][TEMPLATE] invalid agents payload for template id=%d: %v", tpl.ID, err)
+			dto.Agents = []WorkspaceTemplateAgentDTO{}
 		}
-		return result, gpr.NewBindingError(
-			gpr.CodeRepoUnavailable,
-			"Repositorio Git invalido para operacoes de Pull Request.",
-			preflightErr.Error(),
-		)
 	}
+	return dto
+}
 
-	repoRoot := strings.TrimSpace(preflight.RepoRoot)
-	if repoRoot == "" {
-		repoRoot = strings.TrimSpace(preflight.RepoPath)
+func encodeStringList(values []string) string {
+	if len(values) == 0 {
+		return ""
 	}
-	if repoRoot == "" {
-		repoRoot = filepath.Clean(normalizedRepoPath)
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return ""
 	}
+	return string(encoded)
+}
 
-	result.RepoPath = normalizedRepoPath
-	result.RepoRoot = repoRoot
+// decodeAgentStringList decodifica listas JSON persistidas (env/startup) tolerando vazio/legado.
+func decodeAgentStringList(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return nil
+	}
+	return values
+}
 
-	manualProvided := strings.TrimSpace(manualOwner) != "" || strings.TrimSpace(manualRepo) != ""
-	var normalizedManualOwner string
-	var normalizedManualRepo string
-	if manualProvided {
-		var normalizeErr error
-		normalizedManualOwner, normalizedManualRepo, normalizeErr = gpr.NormalizeOwnerRepo(manualOwner, manualRepo)
-		if normalizeErr != nil {
-			return GitPanelPRRepositoryTargetDTO{}, gpr.NewBindingError(
-				gpr.CodeManualRepoInvalid,
-				"Fallback manual owner/repo invalido.",
-				normalizeErr.Error(),
-			)
+// runAgentStartupCommands envia os comandos de inicialização configurados para o PTY recém-criado.
+func (a *App) runAgentStartupCommands(sessionID string, agent *database.AgentSession) {
+	if agent == nil {
+		return
+	}
+	a.writeTerminalCommands(sessionID, agent.ID, decodeAgentStringList(agent.StartupCmds))
+}
+
+// writeTerminalCommands digita os comandos no PTY, um por linha.
+func (a *App) writeTerminalCommands(sessionID string, agentID uint, commands []string) {
+	if a.ptyMgr == nil {
+		return
+	}
+	for _, command := range commands {
+		if err := a.ptyMgr.Write(sessionID, []byte(command+"\r")); err != nil {
+			log.Printf("[ORCH] terminal command failed agent=%d session=%s: %v", agentID, sessionID, err)
+			return
 		}
-		result.ManualOwner = normalizedManualOwner
-		result.ManualRepo = normalizedManualRepo
 	}
+}
 
-	originOwner, originRepo, originResolved := a.resolveGitHubOwnerRepo(repoRoot)
-	if originResolved {
-		result.OriginOwner = originOwner
-		result.OriginRepo = originRepo
+// GetAgentReplayCommands retorna os comandos reexecutados ao restaurar o terminal do agente.
+func (a *App) GetAgentReplayCommands(agentID uint) ([]string, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	agent, err := a.db.GetAgent(agentID)
+	if err != nil {
+		return nil, err
+	}
+	commands := decodeAgentStringList(agent.ReplayCmds)
+	if commands == nil {
+		commands = []string{}
+	}
+	return commands, nil
+}
 
-		if manualProvided && !gpr.SameOwnerRepo(originOwner, originRepo, normalizedManualOwner, normalizedManualRepo) {
-			if !allowTargetOverride {
-				return GitPanelPRRepositoryTargetDTO{}, gpr.NewBindingError(
-					gpr.CodeRepoTargetMismatch,
-					"Repositorio manual diverge do origin detectado.",
-					fmt.Sprintf("origin=%s/%s manual=%s/%s", originOwner, originRepo, normalizedManualOwner, normalizedManualRepo),
-				)
-			}
+// SetAgentReplayCommands marca os comandos (ex.: source .env, nvm use) reexecutados ao restaurar
+// o terminal do agente; lista vazia desliga o replay. Vale para os próximos snapshots.
+func (a *App) SetAgentReplayCommands(agentID uint, commands []string) ([]string, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	normalized, err := normalizeStartupCommands(commands)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.db.GetAgent(agentID); err != nil {
+		return nil, err
+	}
+	if err := a.db.UpdateAgentReplayCommands(agentID, encodeStringList(normalized)); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
 
-			result.Owner = normalizedManualOwner
-			result.Repo = normalizedManualRepo
-			result.Source = "manual"
-			result.OverrideConfirmed = true
-			return result, nil
-		}
+// Políticas de auto-start de agentes.
+const (
+	agentAutoStartManual             = "manual"
+	agentAutoStartAppLaunch          = "app_launch"
+	agentAutoStartWorkspaceActivated = "workspace_activated"
+)
 
-		result.Owner = originOwner
-		result.Repo = originRepo
-		result.Source = "origin"
-		return result, nil
+// AgentAutoStartResult descreve o resultado do auto-start de um agente (evento agent:autostart).
+type AgentAutoStartResult struct {
+	AgentID     uint   `json:"agentId"`
+	WorkspaceID uint   `json:"workspaceId"`
+	Trigger     string `json:"trigger"`
+	SessionID   string `json:"sessionId,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+func normalizeAgentAutoStart(policy string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(policy)) {
+	case "", agentAutoStartManual:
+		return agentAutoStartManual, nil
+	case agentAutoStartAppLaunch:
+		return agentAutoStartAppLaunch, nil
+	case agentAutoStartWorkspaceActivated:
+		return agentAutoStartWorkspaceActivated, nil
+	default:
+		return "", fmt.Errorf("unsupported auto-start policy: %s", policy)
 	}
+}
 
-	if manualProvided {
-		result.Owner = normalizedManualOwner
-		result.Repo = normalizedManualRepo
-		result.Source = "manual"
-		return result, nil
+// SetAgentAutoStart define quando o terminal do agente sobe sozinho: manual, ao abrir o app
+// (app_launch) ou ao ativar o workspace (workspace_activated).
+func (a *App) SetAgentAutoStart(agentID uint, policy string) (*database.AgentSession, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	normalized, err := normalizeAgentAutoStart(policy)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.db.GetAgent(agentID); err != nil {
+		return nil, err
 	}
+	if err := a.db.UpdateAgentAutoStart(agentID, normalized); err != nil {
+		return nil, err
+	}
+	return a.db.GetAgent(agentID)
+}
 
-	return GitPanelPRRepositoryTargetDTO{}, gpr.NewBindingError(
-		gpr.CodeRepoResolveFailed,
-		"Nao foi possivel resolver owner/repo via remote origin.",
-		"Informe owner/repo manualmente para continuar.",
-	)
+// AgentDependenciesDTO configura a ordem de inicialização de um agente no workspace.
+type AgentDependenciesDTO struct {
+	DependsOn  []uint `json:"dependsOn"`
+	ReadyMode  string `json:"readyMode"`  // "started" | "pattern" | "exit"
+	ReadyRegex string `json:"readyRegex"` // obrigatório no modo "pattern"
 }
 
-func (a *App) requireGitHubServiceForPRs() (*gh.Service, error) {
-	if a.github == nil {
-		return nil, gpr.NewBindingError(
-			gpr.CodeServiceUnavailable,
-			"GitHub service indisponivel para operacoes de Pull Request.",
-			"Conecte uma conta GitHub valida antes de usar a aba PRs.",
-		)
+// agentReadinessEvent é o payload do evento agent:readiness.
+type agentReadinessEvent struct {
+	agentgraph.Event
+	WorkspaceID uint   `json:"workspaceId"`
+	JobID       string `json:"jobId"`
+}
+
+func encodeAgentIDList(ids []uint) string {
+	if len(ids) == 0 {
+		return ""
 	}
-	return a.github, nil
+	encoded, err := json.Marshal(ids)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
 }
 
-func (a *App) normalizeGitPanelPRError(err error) error {
-	if err == nil {
+func decodeAgentIDList(raw string) []uint {
+	if strings.TrimSpace(raw) == "" {
 		return nil
 	}
-
-	if bindingErr := gpr.AsBindingError(err); bindingErr != nil {
-		return bindingErr
+	var ids []uint
+	if err := json.Unmarshal([]byte(raw), &ids); err != nil {
+		return nil
 	}
+	return ids
+}
 
-	var githubErr *gh.GitHubError
-	if errors.As(err, &githubErr) {
-		details := strings.TrimSpace(githubErr.Message)
-		normalizedType := strings.TrimSpace(githubErr.Type)
-		if normalizedType != "" {
-			if details == "" {
-				details = "type=" + normalizedType
-			} else {
-				details = fmt.Sprintf("%s (type=%s)", details, normalizedType)
+// agentGraphNodes monta o grafo do workspace; dependências para agentes removidos são ignoradas.
+func agentGraphNodes(agents []database.AgentSession) []agentgraph.Node {
+	present := make(map[uint]struct{}, len(agents))
+	for _, agent := range agents {
+		present[agent.ID] = struct{}{}
+	}
+	nodes := make([]agentgraph.Node, 0, len(agents))
+	for _, agent := range agents {
+		if isLegacyGitHubAgentType(agent.Type) {
+			continue
+		}
+		node := agentgraph.Node{ID: agent.ID, Name: agent.Name, ReadyMode: agent.ReadyMode, Pattern: agent.ReadyRegex}
+		for _, dep := range decodeAgentIDList(agent.DependsOn) {
+			if _, ok := present[dep]; ok {
+				node.DependsOn = append(node.DependsOn, dep)
 			}
 		}
-		return gpr.NewHTTPBindingError(githubErr.StatusCode, details)
+		nodes = append(nodes, node)
 	}
-
-	return gpr.NormalizeBindingError(err)
+	return nodes
 }
 
-func (a *App) logGitPanelPROperationError(operation, owner, repo string, prNumber int, err error) {
-	if a == nil || err == nil {
-		return
+// SetAgentDependencies define de quais agentes do mesmo workspace este depende e quando ele
+// conta como pronto (regex na saída ou startup commands terminando com 0). Ciclos são rejeitados.
+func (a *App) SetAgentDependencies(agentID uint, deps AgentDependenciesDTO) (*database.AgentSession, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	agent, err := a.db.GetAgent(agentID)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := agentgraph.NormalizeReadyMode(deps.ReadyMode)
+	if err != nil {
+		return nil, err
+	}
+	pattern := strings.TrimSpace(deps.ReadyRegex)
+	if mode != agentgraph.ReadyOnPattern {
+		pattern = ""
+	}
+	if _, err := agentgraph.NewProbe(mode, pattern); err != nil {
+		return nil, err
 	}
 
-	sanitizedOperation := strings.TrimSpace(operation)
-	if sanitizedOperation == "" {
-		sanitizedOperation = "unknown"
+	siblings, err := a.db.ListAgents(agent.WorkspaceID)
+	if err != nil {
+		return nil, err
 	}
-	sanitizedOwner := strings.TrimSpace(owner)
-	if sanitizedOwner == "" {
-		sanitizedOwner = "-"
+	inWorkspace := make(map[uint]struct{}, len(siblings))
+	for _, sibling := range siblings {
+		inWorkspace[sibling.ID] = struct{}{}
 	}
-	sanitizedRepo := strings.TrimSpace(repo)
-	if sanitizedRepo == "" {
-		sanitizedRepo = "-"
+	dependsOn := make([]uint, 0, len(deps.DependsOn))
+	for _, dep := range deps.DependsOn {
+		if _, ok := inWorkspace[dep]; !ok {
+			return nil, fmt.Errorf("agent %d is not in the same workspace", dep)
+		}
+		if !slices.Contains(dependsOn, dep) {
+			dependsOn = append(dependsOn, dep)
+		}
+	}
+	for i := range siblings {
+		if siblings[i].ID == agent.ID {
+			siblings[i].DependsOn = encodeAgentIDList(dependsOn)
+		}
+	}
+	if _, err := agentgraph.Levels(agentGraphNodes(siblings)); err != nil {
+		return nil, err
 	}
 
-	bindingErr := gpr.NormalizeBindingError(err)
-	code := strings.TrimSpace(bindingErr.Code)
-	if code == "" {
-		code = gpr.CodeUnknown
+	if err := a.db.UpdateAgentDependencies(agent.ID, encodeAgentIDList(dependsOn), mode, pattern); err != nil {
+		return nil, err
 	}
+	return a.db.GetAgent(agent.ID)
+}
 
-	log.Printf(
-		"[GitPanel][PR] operation=%s owner=%s repo=%s pr=%d code=%s message=%s details=%s",
-		sanitizedOperation,
-		sanitizedOwner,
-		sanitizedRepo,
-		prNumber,
-		code,
-		a.sanitizeForLogs(strings.TrimSpace(bindingErr.Message)),
-		a.sanitizeForLogs(strings.TrimSpace(bindingErr.Details)),
-	)
+// StartWorkspaceAgents sobe os agentes do workspace em ordem de dependência, como job
+// cancelável. Cada mudança de estado sai no evento agent:readiness; o resultado final
+// (estado por agente) fica em Job.Result.
+func (a *App) StartWorkspaceAgents(workspaceID uint) (jobs.Job, error) {
+	if a.db == nil {
+		return jobs.Job{}, fmt.Errorf("database not initialized")
+	}
+	ws, err := a.db.GetWorkspace(workspaceID)
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	agents, err := a.db.ListAgents(ws.ID)
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	nodes := agentGraphNodes(agents)
+	if _, err := agentgraph.Levels(nodes); err != nil {
+		return jobs.Job{}, err
+	}
+
+	return a.submitJob(jobs.Spec{
+		Kind:         "agents.start",
+		Module:       "agents",
+		Title:        "Iniciar agentes",
+		Target:       ws.Name,
+		ExclusiveKey: fmt.Sprintf("agents.start:%d", ws.ID),
+	}, func(ctx context.Context, report *jobs.Reporter) (interface{}, error) {
+		var finished atomic.Int32
+		emit := func(event agentgraph.Event) {
+			switch event.State {
+			case agentgraph.StateReady, agentgraph.StateFailed, agentgraph.StateSkipped:
+				done := finished.Add(1)
+				report.Progress(float64(done)/float64(len(nodes)), fmt.Sprintf("%s: %s", event.Name, event.State))
+				if event.Detail != "" {
+					report.Log(fmt.Sprintf("%s %s (%s)", event.Name, event.State, event.Detail))
+				}
+			}
+			if a.ctx != nil {
+				runtime.EventsEmit(a.ctx, "agent:readiness", agentReadinessEvent{Event: event, WorkspaceID: ws.ID, JobID: report.JobID()})
+			}
+		}
+		return agentgraph.Run(ctx, nodes, a.startAgentForGraph, emit, agentgraph.DefaultReadyTimeout)
+	})
 }
 
-func (a *App) resolveGitPanelPROwnerRepo(repoPath string) (string, string, error) {
-	target, err := a.GitPanelPRResolveRepository(repoPath, "", "", false)
+// startAgentForGraph sobe (ou reaproveita) o terminal do agente e registra o probe de prontidão.
+func (a *App) startAgentForGraph(ctx context.Context, node agentgraph.Node) (string, *agentgraph.Probe, error) {
+	agent, err := a.db.GetAgent(node.ID)
 	if err != nil {
-		return "", "", a.normalizeGitPanelPRError(err)
+		return "", nil, err
+	}
+	probe, err := agentgraph.NewProbe(node.ReadyMode, node.Pattern)
+	if err != nil {
+		return "", nil, err
 	}
 
-	owner, repo, normalizeErr := gpr.NormalizeOwnerRepo(target.Owner, target.Repo)
-	if normalizeErr != nil {
-		return "", "", gpr.NewBindingError(
-			gpr.CodeRepoResolveFailed,
-			"Nao foi possivel validar owner/repo para operacoes de Pull Request.",
-			normalizeErr.Error(),
-		)
+	alive := agent.SessionID != "" && a.bridge != nil && a.bridge.IsTerminalAlive(agent.SessionID)
+	var sessionID string
+	if agent.Type == kubePodAgentType {
+		sessionID, err = a.CreateTerminalForKubePod(agent.ID, 0, 0)
+	} else {
+		sessionID, err = a.CreateTerminalForAgent(agent.ID, agent.Shell, agent.Cwd, agent.UseDocker, 0, 0)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if alive {
+		// Terminal que já estava rodando conta como pronto: a saída de boot já passou.
+		probe, _ = agentgraph.NewProbe(agentgraph.ReadyOnStart, "")
+		return sessionID, probe, nil
 	}
 
-	return owner, repo, nil
+	a.watchAgentReadiness(ctx, sessionID, probe)
+	if node.ReadyMode == agentgraph.ReadyOnExit {
+		a.writeTerminalCommands(sessionID, agent.ID, []string{agentgraph.ExitMarkerCommand})
+	}
+	return sessionID, probe, nil
 }
 
-func (a *App) resolveGitPanelPRRepoRoot(repoPath string) (string, error) {
-	normalizedRepoPath := strings.TrimSpace(repoPath)
-	if normalizedRepoPath == "" {
-		return "", gpr.NewBindingError(
-			gpr.CodeRepoPathRequired,
-			"repoPath obrigatorio para operacoes de Pull Request.",
-			"Abra um repositorio Git valido antes de executar operacoes locais.",
-		)
-	}
-
-	svc, svcErr := a.requireGitPanelService()
-	if svcErr != nil {
-		return "", gpr.NewBindingError(
-			gpr.CodeServiceUnavailable,
-			"Git Panel service indisponivel para operacoes de Pull Request.",
-			"O backend do Git Panel ainda nao foi inicializado.",
-		)
-	}
+// watchAgentReadiness liga o probe à saída da sessão (incluindo o que já foi impresso) e o
+// encerra se o terminal morrer antes de ficar pronto.
+func (a *App) watchAgentReadiness(ctx context.Context, sessionID string, probe *agentgraph.Probe) {
+	a.readinessMu.Lock()
+	a.readinessProbes[sessionID] = probe
+	a.readinessMu.Unlock()
+	probe.Feed([]byte(a.getTerminalHistory(sessionID)))
 
-	preflight, preflightErr := svc.Preflight(normalizedRepoPath)
-	if preflightErr != nil {
-		if bindingErr := gp.AsBindingError(preflightErr); bindingErr != nil {
-			return "", gpr.NewBindingError(
-				gpr.CodeRepoUnavailable,
-				"Repositorio Git invalido para operacoes de Pull Request.",
-				fmt.Sprintf("%s (%s)", strings.TrimSpace(bindingErr.Message), strings.TrimSpace(bindingErr.Code)),
-			)
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		defer func() {
+			a.readinessMu.Lock()
+			if a.readinessProbes[sessionID] == probe {
+				delete(a.readinessProbes, sessionID)
+			}
+			a.readinessMu.Unlock()
+		}()
+		for {
+			select {
+			case <-probe.Done():
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if a.bridge != nil && !a.bridge.IsTerminalAlive(sessionID) {
+					probe.Fail("terminal exited")
+					return
+				}
+			}
 		}
-		return "", gpr.NewBindingError(
-			gpr.CodeRepoUnavailable,
-			"Repositorio Git invalido para operacoes de Pull Request.",
-			preflightErr.Error(),
-		)
-	}
+	}()
+}
 
-	repoRoot := strings.TrimSpace(preflight.RepoRoot)
-	if repoRoot == "" {
-		repoRoot = strings.TrimSpace(preflight.RepoPath)
+func (a *App) observeAgentReadiness(sessionID string, data []byte) {
+	a.readinessMu.Lock()
+	probe := a.readinessProbes[sessionID]
+	a.readinessMu.Unlock()
+	probe.Feed(data)
+}
+
+// autoStartOnLaunch sobe os agentes app_launch de todos os workspaces e os workspace_activated
+// do workspace ativo (abrir o app também ativa o último workspace).
+func (a *App) autoStartOnLaunch() {
+	if a.db == nil {
+		return
 	}
-	if repoRoot == "" {
-		repoRoot = filepath.Clean(normalizedRepoPath)
+	agents, err := a.db.ListAgentsByAutoStart(agentAutoStartAppLaunch, 0)
+	if err != nil {
+		log.Printf("[ORCH] auto-start: failed to list app_launch agents: %v", err)
 	}
+	a.autoStartAgents(agentAutoStartAppLaunch, agents)
 
-	return repoRoot, nil
+	if ws, err := a.db.GetActiveWorkspace(); err == nil && ws != nil {
+		a.autoStartWorkspaceAgents(ws.ID)
+	}
 }
 
-func normalizeGitPanelPRLocalRef(value string, fieldName string, required bool) (string, error) {
-	normalized := strings.TrimSpace(value)
-	if normalized == "" {
-		if required {
-			return "", gpr.NewBindingError(
-				gpr.CodeValidationFailed,
-				fmt.Sprintf(`Campo "%s" obrigatorio para criar branch local.`, fieldName),
-				fmt.Sprintf(`Campo "%s" deve ser preenchido.`, fieldName),
-			)
-		}
-		return "", nil
+// autoStartWorkspaceAgents sobe os agentes workspace_activated do workspace.
+func (a *App) autoStartWorkspaceAgents(workspaceID uint) []AgentAutoStartResult {
+	if a.db == nil || workspaceID == 0 {
+		return nil
 	}
-
-	if strings.ContainsAny(normalized, " \t\r\n") {
-		return "", gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			fmt.Sprintf(`Campo "%s" invalido para criar branch local.`, fieldName),
-			fmt.Sprintf(`Campo "%s" nao pode conter espacos.`, fieldName),
-		)
+	agents, err := a.db.ListAgentsByAutoStart(agentAutoStartWorkspaceActivated, workspaceID)
+	if err != nil {
+		log.Printf("[ORCH] auto-start: failed to list agents of workspace %d: %v", workspaceID, err)
+		return nil
 	}
-
-	return normalized, nil
+	return a.autoStartAgents(agentAutoStartWorkspaceActivated, agents)
 }
 
-func runGitPanelPRCommand(args ...string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), gitPanelPRLocalBranchTimeout)
-	defer cancel()
+// autoStartAgents cria os terminais que ainda não estão vivos; agentes já rodando são mantidos.
+// O frontend reaproveita a sessão ao montar o pane, pois CreateTerminalForAgent devolve a viva.
+func (a *App) autoStartAgents(trigger string, agents []database.AgentSession) []AgentAutoStartResult {
+	if len(agents) == 0 {
+		return nil
+	}
+	a.autoStartMu.Lock()
+	defer a.autoStartMu.Unlock()
 
-	cmd := exec.CommandContext(ctx, "git", args...)
-	rawOut, err := cmd.CombinedOutput()
-	output := strings.TrimSpace(string(rawOut))
-	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-		if output != "" {
-			return output, context.DeadlineExceeded
+	results := make([]AgentAutoStartResult, 0, len(agents))
+	for _, agent := range agents {
+		if isLegacyGitHubAgentType(agent.Type) {
+			continue
+		}
+		if agent.SessionID != "" && a.bridge != nil && a.bridge.IsTerminalAlive(agent.SessionID) {
+			continue
+		}
+
+		result := AgentAutoStartResult{AgentID: agent.ID, WorkspaceID: agent.WorkspaceID, Trigger: trigger}
+		var sessionID string
+		var err error
+		if agent.Type == kubePodAgentType {
+			sessionID, err = a.CreateTerminalForKubePod(agent.ID, 0, 0)
+		} else {
+			sessionID, err = a.CreateTerminalForAgent(agent.ID, agent.Shell, agent.Cwd, agent.UseDocker, 0, 0)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			log.Printf("[ORCH] auto-start failed agent=%d trigger=%s: %v", agent.ID, trigger, err)
+		} else {
+			result.SessionID = sessionID
+		}
+		results = append(results, result)
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "agent:autostart", result)
 		}
-		return "", context.DeadlineExceeded
 	}
-	return output, err
+	return results
 }
 
-func ensureGitPanelPRValidBranchName(repoRoot string, branch string) error {
-	output, err := runGitPanelPRCommand(
-		"-C", repoRoot,
-		"check-ref-format",
-		"--branch",
-		branch,
-	)
-	if err == nil {
-		return nil
+// ListWorkspaceTemplates lista os templates de workspace salvos.
+func (a *App) ListWorkspaceTemplates() ([]WorkspaceTemplateDTO, error) {
+	if a.db == nil {
+		return []WorkspaceTemplateDTO{}, nil
 	}
-
-	details := strings.TrimSpace(output)
-	if details == "" {
-		details = err.Error()
+	templates, err := a.db.ListWorkspaceTemplates()
+	if err != nil {
+		return nil, err
 	}
-	return gpr.NewBindingError(
-		gpr.CodeValidationFailed,
-		"Nome de branch invalido para criacao local.",
-		details,
-	)
+	result := make([]WorkspaceTemplateDTO, 0, len(templates))
+	for _, tpl := range templates {
+		result = append(result, workspaceTemplateToDTO(tpl))
+	}
+	return result, nil
 }
 
-func resolveGitPanelPRLocalStartPoint(repoRoot string, base string) (string, error) {
-	candidates := []string{base}
-	if !strings.HasPrefix(base, "origin/") {
-		candidates = append(candidates, "origin/"+base)
+// SaveWorkspaceTemplate cria (id=0) ou atualiza um template de workspace.
+func (a *App) SaveWorkspaceTemplate(dto WorkspaceTemplateDTO) (WorkspaceTemplateDTO, error) {
+	if a.db == nil {
+		return WorkspaceTemplateDTO{}, fmt.Errorf("database not initialized")
 	}
 
-	for _, candidate := range candidates {
-		if candidate == "" {
-			continue
-		}
-		_, err := runGitPanelPRCommand(
-			"-C", repoRoot,
-			"rev-parse",
-			"--verify",
-			"--quiet",
-			candidate,
-		)
-		if err == nil {
-			return candidate, nil
-		}
+	normalized, err := normalizeWorkspaceTemplate(dto)
+	if err != nil {
+		return WorkspaceTemplateDTO{}, err
 	}
 
-	return "", gpr.NewBindingError(
-		gpr.CodeValidationFailed,
-		"Referencia base nao encontrada para criar branch local.",
-		fmt.Sprintf(`Nao foi possivel resolver a referencia "%s" localmente nem em origin/%s.`, base, base),
-	)
-}
+	agentsJSON, err := json.Marshal(normalized.Agents)
+	if err != nil {
+		return WorkspaceTemplateDTO{}, err
+	}
 
-func normalizeGitPanelPRListState(state string) (string, error) {
-	normalized := strings.ToLower(strings.TrimSpace(state))
-	switch normalized {
-	case "", "open":
-		return "open", nil
-	case "closed":
-		return "closed", nil
-	case "all":
-		return "all", nil
-	default:
-		return "", gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Filtro de estado de Pull Request invalido.",
-			`Use "open", "closed" ou "all".`,
-		)
+	tpl := &database.WorkspaceTemplate{}
+	if normalized.ID != 0 {
+		existing, getErr := a.db.GetWorkspaceTemplate(normalized.ID)
+		if getErr != nil {
+			return WorkspaceTemplateDTO{}, getErr
+		}
+		tpl = existing
+	}
+	tpl.Name = normalized.Name
+	tpl.Description = normalized.Description
+	tpl.Path = normalized.Path
+	tpl.DockerImage = normalized.DockerImage
+	tpl.Env = encodeStringList(normalized.Env)
+	tpl.Agents = string(agentsJSON)
+
+	if err := a.db.SaveWorkspaceTemplate(tpl); err != nil {
+		return WorkspaceTemplateDTO{}, err
 	}
+	return workspaceTemplateToDTO(*tpl), nil
 }
 
-type normalizedGitPanelPRCreatePayload struct {
-	Input               gh.CreatePRInput
-	ManualOwner         string
-	ManualRepo          string
-	AllowTargetOverride bool
+// DeleteWorkspaceTemplate remove um template de workspace.
+func (a *App) DeleteWorkspaceTemplate(id uint) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return a.db.DeleteWorkspaceTemplate(id)
 }
 
-func normalizeGitPanelPRCreatePayload(payload GitPanelPRCreatePayloadDTO) (normalizedGitPanelPRCreatePayload, error) {
-	normalizedTitle := strings.TrimSpace(payload.Title)
-	if normalizedTitle == "" {
-		return normalizedGitPanelPRCreatePayload{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Titulo da Pull Request obrigatorio.",
-			`Campo "title" deve ser preenchido.`,
-		)
+// CreateWorkspaceFromTemplate provisiona workspace + agentes (shell, cwd, env, startup, imagem) em uma chamada.
+// name/path vazios usam os valores do template.
+func (a *App) CreateWorkspaceFromTemplate(templateID uint, name string, path string) (*database.Workspace, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
 	}
 
-	normalizedHead := strings.TrimSpace(payload.Head)
-	if normalizedHead == "" {
-		return normalizedGitPanelPRCreatePayload{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Branch de origem obrigatoria para criar Pull Request.",
-			`Campo "head" deve ser preenchido.`,
-		)
+	tpl, err := a.db.GetWorkspaceTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	dto, err := normalizeWorkspaceTemplate(workspaceTemplateToDTO(*tpl))
+	if err != nil {
+		return nil, fmt.Errorf("template %q is invalid: %w", tpl.Name, err)
 	}
 
-	normalizedBase := strings.TrimSpace(payload.Base)
-	if normalizedBase == "" {
-		return normalizedGitPanelPRCreatePayload{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Branch de destino obrigatoria para criar Pull Request.",
-			`Campo "base" deve ser preenchido.`,
-		)
+	workspacePath := strings.TrimSpace(path)
+	if workspacePath == "" {
+		workspacePath = dto.Path
+	}
+	if workspacePath != "" {
+		workspacePath = filepath.Clean(workspacePath)
+		if info, statErr := os.Stat(workspacePath); statErr != nil || !info.IsDir() {
+			return nil, fmt.Errorf("workspace path is not a directory: %s", workspacePath)
+		}
 	}
 
-	input := gh.CreatePRInput{
-		Title:      normalizedTitle,
-		Body:       payload.Body,
-		HeadBranch: normalizedHead,
-		BaseBranch: normalizedBase,
-		IsDraft:    payload.Draft,
+	workspaceName := strings.TrimSpace(name)
+	if workspaceName == "" {
+		workspaceName = dto.Name
 	}
-	if payload.MaintainerCanModify != nil {
-		flag := *payload.MaintainerCanModify
-		input.MaintainerCanModify = &flag
+
+	ws := &database.Workspace{
+		UserID:      "local",
+		Name:        workspaceName,
+		Path:        workspacePath,
+		DockerImage: dto.DockerImage,
+	}
+
+	defaultShell := a.resolvePreferredLocalShell()
+	agents := make([]database.AgentSession, 0, len(dto.Agents))
+	for _, spec := range dto.Agents {
+		cwd := spec.Cwd
+		if cwd != "" && !filepath.IsAbs(cwd) && workspacePath != "" {
+			cwd = filepath.Join(workspacePath, cwd)
+		} else if cwd == "" {
+			cwd = workspacePath
+		}
+
+		shell := spec.Shell
+		if shell == "" {
+			shell = defaultShell
+		}
+
+		agents = append(agents, database.AgentSession{
+			Name:        spec.Name,
+			Type:        spec.Type,
+			Shell:       shell,
+			Cwd:         cwd,
+			UseDocker:   spec.UseDocker,
+			Status:      "idle",
+			Env:         encodeStringList(append(append([]string(nil), dto.Env...), spec.Env...)),
+			StartupCmds: encodeStringList(spec.StartupCommands),
+		})
 	}
 
-	normalizedManualOwner := strings.TrimSpace(payload.ManualOwner)
-	normalizedManualRepo := strings.TrimSpace(payload.ManualRepo)
-	hasManualOwner := normalizedManualOwner != ""
-	hasManualRepo := normalizedManualRepo != ""
-	if hasManualOwner != hasManualRepo {
-		return normalizedGitPanelPRCreatePayload{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Destino manual invalido para criar Pull Request.",
-			`Informe "manualOwner" e "manualRepo" juntos quando usar override manual.`,
-		)
+	if err := a.db.CreateWorkspaceWithAgents(ws, agents); err != nil {
+		return nil, err
 	}
 
-	if hasManualOwner {
-		owner, repo, normalizeErr := gpr.NormalizeOwnerRepo(normalizedManualOwner, normalizedManualRepo)
-		if normalizeErr != nil {
-			return normalizedGitPanelPRCreatePayload{}, gpr.NewBindingError(
-				gpr.CodeManualRepoInvalid,
-				"Destino manual invalido para criar Pull Request.",
-				normalizeErr.Error(),
-			)
+	if workspacePath != "" && a.fileWatcher != nil {
+		if watchErr := a.fileWatcher.Watch(workspacePath); watchErr != nil {
+			log.Printf("[ORCH][TEMPLATE] could not watch workspace path %s: %v", workspacePath, watchErr)
 		}
-		normalizedManualOwner = owner
-		normalizedManualRepo = repo
 	}
 
-	return normalizedGitPanelPRCreatePayload{
-		Input:               input,
-		ManualOwner:         normalizedManualOwner,
-		ManualRepo:          normalizedManualRepo,
-		AllowTargetOverride: payload.AllowTargetOverride,
-	}, nil
+	log.Printf("[ORCH][TEMPLATE] workspace id=%d created from template id=%d agents=%d", ws.ID, tpl.ID, len(agents))
+	return ws, nil
 }
 
-func normalizeGitPanelPRCreateLabelPayload(payload GitPanelPRCreateLabelPayloadDTO) (gh.CreateLabelInput, error) {
-	normalizedName := strings.TrimSpace(payload.Name)
-	if normalizedName == "" {
-		return gh.CreateLabelInput{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Nome da etiqueta obrigatorio.",
-			`Campo "name" deve ser preenchido.`,
-		)
+// === Command Snippet Bindings ===
+
+// SnippetDTO representa um snippet da palette; Placeholders vem do próprio comando.
+type SnippetDTO struct {
+	ID           uint                   `json:"id"`
+	WorkspaceID  uint                   `json:"workspaceId"` // 0 = global
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description,omitempty"`
+	Command      string                 `json:"command"`
+	Placeholders []snippets.Placeholder `json:"placeholders"`
+}
+
+func commandSnippetToDTO(snippet database.CommandSnippet) SnippetDTO {
+	return SnippetDTO{
+		ID:           snippet.ID,
+		WorkspaceID:  snippet.WorkspaceID,
+		Name:         snippet.Name,
+		Description:  snippet.Description,
+		Command:      snippet.Command,
+		Placeholders: snippets.Placeholders(snippet.Command),
 	}
+}
 
-	normalizedColor := strings.TrimSpace(payload.Color)
-	if normalizedColor == "" {
-		return gh.CreateLabelInput{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Cor da etiqueta obrigatoria.",
-			`Campo "color" deve ser preenchido com 6 caracteres hexadecimais.`,
-		)
+// ListSnippets lista os snippets globais e os do workspace (0 = só globais).
+func (a *App) ListSnippets(workspaceID uint) ([]SnippetDTO, error) {
+	if a.db == nil {
+		return []SnippetDTO{}, nil
 	}
-	if !gitPanelLabelColorRegex.MatchString(normalizedColor) {
-		return gh.CreateLabelInput{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Cor da etiqueta invalida.",
-			`Campo "color" deve usar formato hexadecimal de 6 caracteres (ex: #0e8a16).`,
-		)
+	list, err := a.db.ListCommandSnippets(workspaceID)
+	if err != nil {
+		return nil, err
 	}
+	result := make([]SnippetDTO, 0, len(list))
+	for _, snippet := range list {
+		result = append(result, commandSnippetToDTO(snippet))
+	}
+	return result, nil
+}
 
-	normalizedColor = strings.TrimPrefix(normalizedColor, "#")
-	normalizedColor = strings.ToLower(normalizedColor)
+// SaveSnippet cria (id=0) ou atualiza um snippet; workspaceId 0 o torna global.
+func (a *App) SaveSnippet(dto SnippetDTO) (SnippetDTO, error) {
+	if a.db == nil {
+		return SnippetDTO{}, fmt.Errorf("database not initialized")
+	}
+	command := strings.TrimSpace(strings.ReplaceAll(dto.Command, "\r\n", "\n"))
+	if dto.WorkspaceID != 0 {
+		if _, err := a.db.GetWorkspace(dto.WorkspaceID); err != nil {
+			return SnippetDTO{}, err
+		}
+	}
 
-	var normalizedDescription *string
-	if payload.Description != nil {
-		description := strings.TrimSpace(*payload.Description)
-		if description != "" {
-			normalizedDescription = &description
+	snippet := &database.CommandSnippet{}
+	if dto.ID != 0 {
+		existing, err := a.db.GetCommandSnippet(dto.ID)
+		if err != nil {
+			return SnippetDTO{}, err
 		}
+		snippet = existing
 	}
+	snippet.WorkspaceID = dto.WorkspaceID
+	snippet.Name = dto.Name
+	snippet.Description = strings.TrimSpace(dto.Description)
+	snippet.Command = command
 
-	return gh.CreateLabelInput{
-		Name:        normalizedName,
-		Color:       normalizedColor,
-		Description: normalizedDescription,
-	}, nil
+	if err := a.db.SaveCommandSnippet(snippet); err != nil {
+		return SnippetDTO{}, err
+	}
+	return commandSnippetToDTO(*snippet), nil
 }
 
-func normalizeGitPanelPRUpdatePayload(payload GitPanelPRUpdatePayloadDTO) (gh.UpdatePRInput, error) {
-	input := gh.UpdatePRInput{}
-	hasField := false
+// DeleteSnippet remove um snippet.
+func (a *App) DeleteSnippet(id uint) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return a.db.DeleteCommandSnippet(id)
+}
 
-	if payload.Title != nil {
-		hasField = true
-		normalizedTitle := strings.TrimSpace(*payload.Title)
-		if normalizedTitle == "" {
-			return gh.UpdatePRInput{}, gpr.NewBindingError(
-				gpr.CodeValidationFailed,
-				"Titulo invalido para atualizacao de Pull Request.",
-				`Campo "title" nao pode ser vazio quando informado.`,
-			)
+// RunSnippet expande os placeholders com vars e digita o comando no terminal do agente.
+// Retorna o comando expandido. O snippet precisa ser global ou do workspace do agente.
+func (a *App) RunSnippet(agentID uint, snippetID uint, vars map[string]string) (string, error) {
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	agent, err := a.db.GetAgent(agentID)
+	if err != nil {
+		return "", err
+	}
+	snippet, err := a.db.GetCommandSnippet(snippetID)
+	if err != nil {
+		return "", err
+	}
+	if snippet.WorkspaceID != 0 && snippet.WorkspaceID != agent.WorkspaceID {
+		return "", fmt.Errorf("snippet %q belongs to another workspace", snippet.Name)
+	}
+	expanded, err := snippets.Expand(snippet.Command, vars)
+	if err != nil {
+		return "", err
+	}
+	if agent.SessionID == "" || a.bridge == nil || !a.bridge.IsTerminalAlive(agent.SessionID) {
+		return "", fmt.Errorf("agent terminal is not running")
+	}
+
+	lines := make([]string, 0, 1)
+	for _, line := range strings.Split(expanded, "\n") {
+		if line = strings.TrimRight(line, "\r"); strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
 		}
-		input.Title = &normalizedTitle
 	}
+	a.writeTerminalCommands(agent.SessionID, agent.ID, lines)
+	return expanded, nil
+}
 
-	if payload.Body != nil {
-		hasField = true
-		body := *payload.Body
-		input.Body = &body
+// === Workspace Task Bindings ===
+
+// WorkspaceTaskDTO une tarefas manuais e TODOs do código. ID é "manual:<id>" ou "comment:<key>";
+// Path/Line/Column (relativos à raiz do workspace) alimentam o OpenInEditor.
+type WorkspaceTaskDTO struct {
+	ID        string     `json:"id"`
+	Source    string     `json:"source"`         // manual | comment
+	Kind      string     `json:"kind,omitempty"` // TODO | FIXME | HACK | XXX (comentários)
+	Title     string     `json:"title"`
+	Author    string     `json:"author,omitempty"`
+	Path      string     `json:"path,omitempty"`
+	Line      int        `json:"line,omitempty"`
+	Column    int        `json:"column,omitempty"`
+	Done      bool       `json:"done"`
+	DoneAt    *time.Time `json:"doneAt,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// WorkspaceTaskListDTO é a lista de tarefas do workspace com os totais de conclusão.
+type WorkspaceTaskListDTO struct {
+	Tasks     []WorkspaceTaskDTO `json:"tasks"`
+	Open      int                `json:"open"`
+	Done      int                `json:"done"`
+	Truncated bool               `json:"truncated"` // varredura de TODOs atingiu o limite
+}
+
+const (
+	taskIDManualPrefix  = "manual:"
+	taskIDCommentPrefix = "comment:"
+)
+
+func manualTaskToDTO(task database.WorkspaceTask) WorkspaceTaskDTO {
+	createdAt := task.CreatedAt
+	return WorkspaceTaskDTO{
+		ID:        taskIDManualPrefix + strconv.FormatUint(uint64(task.ID), 10),
+		Source:    database.TaskSourceManual,
+		Title:     task.Title,
+		Path:      task.Path,
+		Line:      task.Line,
+		Done:      task.Done,
+		DoneAt:    task.DoneAt,
+		CreatedAt: &createdAt,
 	}
+}
 
-	if payload.State != nil {
-		hasField = true
-		normalizedState := strings.ToLower(strings.TrimSpace(*payload.State))
-		switch normalizedState {
-		case "open", "closed":
-			input.State = &normalizedState
-		default:
-			return gh.UpdatePRInput{}, gpr.NewBindingError(
-				gpr.CodeValidationFailed,
-				"Estado invalido para atualizacao de Pull Request.",
-				`Campo "state" aceita apenas "open" ou "closed".`,
-			)
+func commentTaskToDTO(comment tasks.Comment, completion *database.WorkspaceTask) WorkspaceTaskDTO {
+	dto := WorkspaceTaskDTO{
+		ID:     taskIDCommentPrefix + comment.Key,
+		Source: database.TaskSourceComment,
+		Kind:   comment.Kind,
+		Title:  comment.Text,
+		Author: comment.Author,
+		Path:   comment.Path,
+		Line:   comment.Line,
+		Column: comment.Column,
+	}
+	if dto.Title == "" {
+		dto.Title = comment.Kind
+	}
+	if completion != nil && completion.Done {
+		dto.Done, dto.DoneAt = true, completion.DoneAt
+	}
+	return dto
+}
+
+// ListWorkspaceTasks lista as tarefas manuais seguidas dos TODO/FIXME do código do workspace.
+// Com o file watching do workspace ligado os TODOs são atualizados incrementalmente.
+func (a *App) ListWorkspaceTasks(workspaceID uint, includeDone bool) (WorkspaceTaskListDTO, error) {
+	result := WorkspaceTaskListDTO{Tasks: []WorkspaceTaskDTO{}}
+	if a.db == nil {
+		return result, fmt.Errorf("database not initialized")
+	}
+	if _, err := a.db.GetWorkspace(workspaceID); err != nil {
+		return result, err
+	}
+	stored, err := a.db.ListWorkspaceTasks(workspaceID)
+	if err != nil {
+		return result, err
+	}
+
+	all := make([]WorkspaceTaskDTO, 0, len(stored))
+	completions := make(map[string]*database.WorkspaceTask)
+	for i := range stored {
+		if stored[i].Source == database.TaskSourceComment {
+			completions[stored[i].CommentKey] = &stored[i]
+			continue
 		}
+		all = append(all, manualTaskToDTO(stored[i]))
+	}
+	comments, truncated, err := a.workspaceTaskComments(workspaceID)
+	if err != nil {
+		log.Printf("[ORCH][TASKS] comment scan failed workspace=%d: %v", workspaceID, err)
+	}
+	result.Truncated = truncated
+	for _, comment := range comments {
+		all = append(all, commentTaskToDTO(comment, completions[comment.Key]))
 	}
 
-	if payload.Base != nil {
-		hasField = true
-		normalizedBase := strings.TrimSpace(*payload.Base)
-		if normalizedBase == "" {
-			return gh.UpdatePRInput{}, gpr.NewBindingError(
-				gpr.CodeValidationFailed,
-				"Branch base invalida para atualizacao de Pull Request.",
-				`Campo "base" nao pode ser vazio quando informado.`,
-			)
+	for _, task := range all {
+		if task.Done {
+			result.Done++
+			if !includeDone {
+				continue
+			}
+		} else {
+			result.Open++
 		}
-		input.BaseBranch = &normalizedBase
+		result.Tasks = append(result.Tasks, task)
 	}
+	return result, nil
+}
 
-	if payload.MaintainerCanModify != nil {
-		hasField = true
-		flag := *payload.MaintainerCanModify
-		input.MaintainerCanModify = &flag
+// CreateWorkspaceTask cria uma tarefa manual; path/line (opcionais) apontam para o código.
+func (a *App) CreateWorkspaceTask(workspaceID uint, title string, path string, line int) (WorkspaceTaskDTO, error) {
+	if a.db == nil {
+		return WorkspaceTaskDTO{}, fmt.Errorf("database not initialized")
 	}
+	if _, err := a.db.GetWorkspace(workspaceID); err != nil {
+		return WorkspaceTaskDTO{}, err
+	}
+	task := &database.WorkspaceTask{WorkspaceID: workspaceID, Source: database.TaskSourceManual, Title: title}
+	if err := setWorkspaceTaskLocation(task, path, line); err != nil {
+		return WorkspaceTaskDTO{}, err
+	}
+	if err := a.db.SaveWorkspaceTask(task); err != nil {
+		return WorkspaceTaskDTO{}, err
+	}
+	a.emitWorkspaceTasksChanged(workspaceID)
+	return manualTaskToDTO(*task), nil
+}
 
-	if !hasField {
-		return gh.UpdatePRInput{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Payload de atualizacao de Pull Request vazio.",
-			`Informe ao menos um campo: "title", "body", "state", "base" ou "maintainerCanModify".`,
-		)
+// UpdateWorkspaceTask altera título e local de uma tarefa manual.
+func (a *App) UpdateWorkspaceTask(id uint, title string, path string, line int) (WorkspaceTaskDTO, error) {
+	task, err := a.manualWorkspaceTask(id)
+	if err != nil {
+		return WorkspaceTaskDTO{}, err
 	}
+	task.Title = title
+	if err := setWorkspaceTaskLocation(task, path, line); err != nil {
+		return WorkspaceTaskDTO{}, err
+	}
+	if err := a.db.SaveWorkspaceTask(task); err != nil {
+		return WorkspaceTaskDTO{}, err
+	}
+	a.emitWorkspaceTasksChanged(task.WorkspaceID)
+	return manualTaskToDTO(*task), nil
+}
 
-	return input, nil
+// DeleteWorkspaceTask remove uma tarefa manual.
+func (a *App) DeleteWorkspaceTask(id uint) error {
+	task, err := a.manualWorkspaceTask(id)
+	if err != nil {
+		return err
+	}
+	if err := a.db.DeleteWorkspaceTask(task.ID); err != nil {
+		return err
+	}
+	a.emitWorkspaceTasksChanged(task.WorkspaceID)
+	return nil
 }
 
-func normalizeGitPanelOptionalFullSHA(value *string, fieldName string) (*string, error) {
-	if value == nil {
-		return nil, nil
+// SetWorkspaceTaskDone marca uma tarefa (manual ou TODO do código) como concluída ou aberta.
+// A conclusão de um TODO acompanha o comentário mesmo que ele mude de linha.
+func (a *App) SetWorkspaceTaskDone(workspaceID uint, taskID string, done bool) (WorkspaceTaskDTO, error) {
+	if a.db == nil {
+		return WorkspaceTaskDTO{}, fmt.Errorf("database not initialized")
 	}
+	taskID = strings.TrimSpace(taskID)
 
-	normalized := strings.TrimSpace(*value)
-	if normalized == "" {
-		return nil, nil
+	if raw, ok := strings.CutPrefix(taskID, taskIDManualPrefix); ok {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return WorkspaceTaskDTO{}, fmt.Errorf("invalid task id: %s", taskID)
+		}
+		task, err := a.manualWorkspaceTask(uint(id))
+		if err != nil {
+			return WorkspaceTaskDTO{}, err
+		}
+		if task.WorkspaceID != workspaceID {
+			return WorkspaceTaskDTO{}, fmt.Errorf("task %s belongs to another workspace", taskID)
+		}
+		task.Done, task.DoneAt = done, nil
+		if done {
+			now := time.Now()
+			task.DoneAt = &now
+		}
+		if err := a.db.SaveWorkspaceTask(task); err != nil {
+			return WorkspaceTaskDTO{}, err
+		}
+		a.emitWorkspaceTasksChanged(workspaceID)
+		return manualTaskToDTO(*task), nil
 	}
 
-	if !gitPanelFullCommitHashRegex.MatchString(normalized) {
-		return nil, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			fmt.Sprintf(`Campo "%s" invalido para operacao de Pull Request.`, fieldName),
-			fmt.Sprintf(`Campo "%s" deve conter um SHA completo de 40 caracteres hexadecimais.`, fieldName),
-		)
+	key, ok := strings.CutPrefix(taskID, taskIDCommentPrefix)
+	if !ok || key == "" {
+		return WorkspaceTaskDTO{}, fmt.Errorf("invalid task id: %s", taskID)
+	}
+	comments, _, err := a.workspaceTaskComments(workspaceID)
+	if err != nil {
+		return WorkspaceTaskDTO{}, err
+	}
+	idx := slices.IndexFunc(comments, func(comment tasks.Comment) bool { return comment.Key == key })
+	if idx < 0 {
+		return WorkspaceTaskDTO{}, fmt.Errorf("task not found: %s", taskID)
+	}
+	comment := comments[idx]
+	completion, err := a.db.SetCommentTaskDone(database.WorkspaceTask{
+		WorkspaceID: workspaceID,
+		CommentKey:  comment.Key,
+		Title:       comment.Kind + ": " + comment.Text,
+		Path:        comment.Path,
+		Line:        comment.Line,
+	}, done)
+	if err != nil {
+		return WorkspaceTaskDTO{}, err
 	}
+	a.emitWorkspaceTasksChanged(workspaceID)
+	return commentTaskToDTO(comment, completion), nil
+}
 
-	normalized = strings.ToLower(normalized)
-	return &normalized, nil
+func (a *App) manualWorkspaceTask(id uint) (*database.WorkspaceTask, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	task, err := a.db.GetWorkspaceTask(id)
+	if err != nil {
+		return nil, err
+	}
+	if task.Source != database.TaskSourceManual {
+		return nil, fmt.Errorf("task %d comes from a code comment", id)
+	}
+	return task, nil
 }
 
-func normalizeGitPanelPRMergePayload(payload GitPanelPRMergePayloadDTO) (gh.MergePRInput, error) {
-	normalizedMethod := strings.ToLower(strings.TrimSpace(payload.MergeMethod))
-	switch normalizedMethod {
-	case "", "merge":
-		normalizedMethod = "merge"
-	case "squash":
-		normalizedMethod = "squash"
-	case "rebase":
-		normalizedMethod = "rebase"
-	default:
-		return gh.MergePRInput{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Metodo de merge invalido para Pull Request.",
-			`Campo "mergeMethod" aceita apenas "merge", "squash" ou "rebase".`,
-		)
+// workspaceTaskComments lê os TODOs do workspace; sem raiz (workspace sem pasta) a lista é vazia.
+func (a *App) workspaceTaskComments(workspaceID uint) ([]tasks.Comment, bool, error) {
+	if a.taskComments == nil {
+		return nil, false, nil
+	}
+	root, err := a.workspaceFileRootByID(workspaceID)
+	if err != nil {
+		return nil, false, nil
 	}
+	live := a.fileWatcher != nil && a.fileWatcher.WatchedWorkspaces()[workspaceID] == root
+	a.taskComments.SetLiveUpdates(workspaceID, live)
+	return a.taskComments.Comments(workspaceID, root)
+}
 
-	normalizedSHA, shaErr := normalizeGitPanelOptionalFullSHA(payload.SHA, "sha")
-	if shaErr != nil {
-		return gh.MergePRInput{}, shaErr
+// setWorkspaceTaskLocation valida o arquivo opcional da tarefa (relativo à raiz do workspace).
+func setWorkspaceTaskLocation(task *database.WorkspaceTask, path string, line int) error {
+	path = filepath.ToSlash(strings.TrimSpace(path))
+	if path != "" && !filepath.IsLocal(filepath.FromSlash(path)) {
+		return fmt.Errorf("task path must be relative to the workspace: %s", path)
+	}
+	if line < 0 || (path == "" && line != 0) {
+		return fmt.Errorf("invalid task line: %d", line)
 	}
+	task.Path, task.Line = path, line
+	return nil
+}
 
-	return gh.MergePRInput{
-		MergeMethod: gh.PRMergeMethod(normalizedMethod),
-		SHA:         normalizedSHA,
-	}, nil
+func (a *App) emitWorkspaceTasksChanged(workspaceID uint) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, "workspace:tasks_changed", map[string]uint{"workspaceId": workspaceID})
 }
 
-func normalizeGitPanelPRUpdateBranchPayload(payload GitPanelPRUpdateBranchPayloadDTO) (gh.UpdatePRBranchInput, error) {
-	normalizedSHA, shaErr := normalizeGitPanelOptionalFullSHA(payload.ExpectedHeadSHA, "expectedHeadSha")
-	if shaErr != nil {
-		return gh.UpdatePRBranchInput{}, shaErr
+// === Workspace Bundle (Import/Export) Bindings ===
+
+const (
+	workspaceBundleFormat     = "orch.workspace"
+	workspaceBundleVersion    = 1
+	workspaceBundleSecretMark = "{{secret}}"
+	maxWorkspaceBundleSize    = 4 * 1024 * 1024
+)
+
+var secretEnvKeyRegex = regexp.MustCompile(`(?i)(token|secret|passw|api[_-]?key|private[_-]?key|credential|auth)`)
+var workspaceBundleSlugRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// WorkspaceBundle é o formato JSON portátil de um workspace (sem segredos).
+type WorkspaceBundle struct {
+	Format     string                  `json:"format"`
+	Version    int                     `json:"version"`
+	AppVersion string                  `json:"appVersion"`
+	ExportedAt time.Time               `json:"exportedAt"`
+	Workspace  WorkspaceBundleMeta     `json:"workspace"`
+	Agents     []WorkspaceBundleAgent  `json:"agents"`
+	Repos      []WorkspaceBundleRepo   `json:"repos,omitempty"`
+	Settings   WorkspaceBundleSettings `json:"settings"`
+	Secrets    []string                `json:"secrets,omitempty"` // chaves de env substituídas por placeholder
+}
+
+// WorkspaceBundleMeta contém os metadados exportados do workspace.
+type WorkspaceBundleMeta struct {
+	Name        string `json:"name"`
+	Path        string `json:"path,omitempty"`
+	Color       string `json:"color,omitempty"`
+	DockerImage string `json:"dockerImage,omitempty"`
+	GitRemote   string `json:"gitRemote,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	Repo        string `json:"repo,omitempty"`
+}
+
+// WorkspaceBundleRepo contém um repositório anexado ao workspace.
+type WorkspaceBundleRepo struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// WorkspaceBundleAgent contém a configuração exportada de um agente.
+type WorkspaceBundleAgent struct {
+	Name            string   `json:"name"`
+	Type            string   `json:"type"`
+	Shell           string   `json:"shell,omitempty"`
+	Cwd             string   `json:"cwd,omitempty"` // relativo ao path do workspace quando possível
+	UseDocker       bool     `json:"useDocker,omitempty"`
+	LayoutJSON      string   `json:"layoutJson,omitempty"`
+	IsMinimized     bool     `json:"isMinimized,omitempty"`
+	Env             []string `json:"env,omitempty"`
+	StartupCommands []string `json:"startupCommands,omitempty"`
+	KubeTarget      string   `json:"kubeTarget,omitempty"`
+	RepoPath        string   `json:"repoPath,omitempty"`
+}
+
+// WorkspaceBundleSettings contém preferências relevantes para reproduzir o setup.
+type WorkspaceBundleSettings struct {
+	ShortcutBindings    string `json:"shortcutBindings,omitempty"`
+	DefaultShell        string `json:"defaultShell,omitempty"`
+	TerminalFontSize    int    `json:"terminalFontSize,omitempty"`
+	TerminalFontFamily  string `json:"terminalFontFamily,omitempty"`
+	TerminalCursorStyle string `json:"terminalCursorStyle,omitempty"`
+}
+
+// redactSecretEnv substitui valores de variáveis sensíveis por placeholder e retorna as chaves afetadas.
+func redactSecretEnv(entries []string) ([]string, []string) {
+	redacted := make([]string, 0, len(entries))
+	secretKeys := make([]string, 0)
+	for _, entry := range entries {
+		key, _, ok := strings.Cut(entry, "=")
+		if ok && secretEnvKeyRegex.MatchString(key) {
+			redacted = append(redacted, key+"="+workspaceBundleSecretMark)
+			secretKeys = append(secretKeys, key)
+			continue
+		}
+		redacted = append(redacted, entry)
+	}
+	return redacted, secretKeys
+}
+
+func relativizeBundlePath(base, target string) string {
+	if base == "" || target == "" {
+		return target
+	}
+	rel, err := filepath.Rel(base, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return target
+	}
+	if rel == "." {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (a *App) buildWorkspaceBundle(id uint) (*WorkspaceBundle, error) {
+	ws, err := a.db.GetWorkspace(id)
+	if err != nil {
+		return nil, err
+	}
+	agents, err := a.db.ListAgents(id)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &WorkspaceBundle{
+		Format:     workspaceBundleFormat,
+		Version:    workspaceBundleVersion,
+		AppVersion: config.AppVersion,
+		ExportedAt: time.Now().UTC(),
+		Workspace: WorkspaceBundleMeta{
+			Name:        ws.Name,
+			Path:        ws.Path,
+			Color:       ws.Color,
+			DockerImage: ws.DockerImage,
+			GitRemote:   ws.GitRemote,
+			Owner:       ws.Owner,
+			Repo:        ws.Repo,
+		},
+		Agents: make([]WorkspaceBundleAgent, 0, len(agents)),
+	}
+
+	secretSet := make(map[string]struct{})
+	for _, agent := range agents {
+		env, secretKeys := redactSecretEnv(decodeAgentStringList(agent.Env))
+		for _, key := range secretKeys {
+			secretSet[key] = struct{}{}
+		}
+		bundle.Agents = append(bundle.Agents, WorkspaceBundleAgent{
+			Name:            agent.Name,
+			Type:            agent.Type,
+			Shell:           agent.Shell,
+			Cwd:             relativizeBundlePath(ws.Path, agent.Cwd),
+			UseDocker:       agent.UseDocker,
+			LayoutJSON:      agent.LayoutJSON,
+			IsMinimized:     agent.IsMinimized,
+			Env:             env,
+			StartupCommands: decodeAgentStringList(agent.StartupCmds),
+			KubeTarget:      agent.KubeTarget,
+			RepoPath:        agent.RepoPath,
+		})
+	}
+	if repos, repoErr := a.db.ListWorkspaceRepos(id); repoErr == nil {
+		for _, repo := range repos {
+			bundle.Repos = append(bundle.Repos, WorkspaceBundleRepo{Name: repo.Name, Path: repo.Path})
+		}
+	}
+	for key := range secretSet {
+		bundle.Secrets = append(bundle.Secrets, key)
+	}
+	sort.Strings(bundle.Secrets)
+
+	if cfg, cfgErr := a.db.GetConfig(); cfgErr == nil {
+		bundle.Settings = WorkspaceBundleSettings{
+			ShortcutBindings:    cfg.ShortcutBindings,
+			DefaultShell:        cfg.DefaultShell,
+			TerminalFontSize:    cfg.FontSize,
+			TerminalFontFamily:  cfg.FontFamily,
+			TerminalCursorStyle: cfg.CursorStyle,
+		}
+	}
+
+	return bundle, nil
+}
+
+func workspaceBundleFileName(name string, now time.Time) string {
+	slug := strings.Trim(workspaceBundleSlugRegex.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if slug == "" {
+		slug = "workspace"
+	}
+	return fmt.Sprintf("%s-%s.orch-workspace.json", slug, now.Format("20060102-150405"))
+}
+
+// ExportWorkspace serializa workspace, agentes, layouts e atalhos em um bundle JSON portátil.
+// Valores de env sensíveis são substituídos por placeholder. Retorna o caminho do arquivo gerado.
+func (a *App) ExportWorkspace(id uint) (string, error) {
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+
+	bundle, err := a.buildWorkspaceBundle(id)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	exportDir := filepath.Join(config.DataDir(), "exports")
+	if err := os.MkdirAll(exportDir, 0700); err != nil {
+		return "", err
+	}
+	exportPath := filepath.Join(exportDir, workspaceBundleFileName(bundle.Workspace.Name, bundle.ExportedAt))
+	if err := os.WriteFile(exportPath, data, 0600); err != nil {
+		return "", err
+	}
+
+	log.Printf("[ORCH][BUNDLE] workspace id=%d exported agents=%d secrets=%d path=%s", id, len(bundle.Agents), len(bundle.Secrets), exportPath)
+	return exportPath, nil
+}
+
+func parseWorkspaceBundle(data []byte) (*WorkspaceBundle, error) {
+	var bundle WorkspaceBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid workspace bundle: %w", err)
+	}
+	if bundle.Format != workspaceBundleFormat {
+		return nil, fmt.Errorf("unsupported bundle format: %q", bundle.Format)
+	}
+	if bundle.Version <= 0 || bundle.Version > workspaceBundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version: %d", bundle.Version)
+	}
+	if len(bundle.Agents) > config.MaxAgents {
+		return nil, fmt.Errorf("too many agents in bundle (max %d)", config.MaxAgents)
+	}
+	return &bundle, nil
+}
+
+func (a *App) uniqueWorkspaceName(name string) string {
+	base := strings.TrimSpace(name)
+	if base == "" {
+		base = "Imported Workspace"
+	}
+	workspaces, err := a.db.ListWorkspaces()
+	if err != nil {
+		return base
+	}
+	taken := make(map[string]struct{}, len(workspaces))
+	for _, ws := range workspaces {
+		taken[strings.ToLower(strings.TrimSpace(ws.Name))] = struct{}{}
+	}
+	candidate := base
+	for i := 2; ; i++ {
+		if _, exists := taken[strings.ToLower(candidate)]; !exists {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s (%d)", base, i)
+	}
+}
+
+// ImportWorkspace cria um novo workspace a partir de um bundle exportado.
+// O path original só é reaproveitado se existir nesta máquina; atalhos são aplicados
+// apenas quando não há atalhos customizados locais.
+func (a *App) ImportWorkspace(path string) (*database.Workspace, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	bundlePath := filepath.Clean(strings.TrimSpace(path))
+	info, err := os.Stat(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxWorkspaceBundleSize {
+		return nil, fmt.Errorf("workspace bundle too large (%d bytes)", info.Size())
+	}
+	data, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := parseWorkspaceBundle(data)
+	if err != nil {
+		return nil, err
+	}
+
+	workspacePath := ""
+	if candidate := strings.TrimSpace(bundle.Workspace.Path); candidate != "" {
+		if stat, statErr := os.Stat(candidate); statErr == nil && stat.IsDir() {
+			workspacePath = filepath.Clean(candidate)
+		}
+	}
+
+	dockerImage, err := normalizeDockerImageRef(bundle.Workspace.DockerImage)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &database.Workspace{
+		UserID:      "local",
+		Name:        a.uniqueWorkspaceName(bundle.Workspace.Name),
+		Path:        workspacePath,
+		Color:       bundle.Workspace.Color,
+		DockerImage: dockerImage,
+		GitRemote:   bundle.Workspace.GitRemote,
+		Owner:       bundle.Workspace.Owner,
+		Repo:        bundle.Workspace.Repo,
+	}
+
+	// Repositórios só são reanexados quando existem nesta máquina.
+	availableRepos := make(map[string]WorkspaceBundleRepo)
+	for _, repo := range bundle.Repos {
+		repoPath := strings.TrimSpace(repo.Path)
+		if repoPath == "" || findGitRepoRoot(repoPath) == "" {
+			continue
+		}
+		availableRepos[filepath.Clean(repoPath)] = repo
+	}
+
+	defaultShell := a.resolvePreferredLocalShell()
+	agents := make([]database.AgentSession, 0, len(bundle.Agents))
+	for _, item := range bundle.Agents {
+		agentType, typeErr := normalizeAgentSessionType(item.Type)
+		if typeErr != nil {
+			log.Printf("[ORCH][BUNDLE] skipping agent %q: %v", item.Name, typeErr)
+			continue
+		}
+		env, envErr := normalizeEnvAssignments(item.Env)
+		if envErr != nil {
+			return nil, envErr
+		}
+		startup, startupErr := normalizeStartupCommands(item.StartupCommands)
+		if startupErr != nil {
+			return nil, startupErr
+		}
+
+		cwd := filepath.FromSlash(strings.TrimSpace(item.Cwd))
+		if !filepath.IsAbs(cwd) {
+			cwd = filepath.Join(workspacePath, cwd)
+			if workspacePath == "" {
+				cwd = ""
+			}
+		}
+
+		shell := strings.TrimSpace(item.Shell)
+		if shell == "" {
+			shell = defaultShell
+		}
+
+		agent := database.AgentSession{
+			Name:        strings.TrimSpace(item.Name),
+			Type:        agentType,
+			Shell:       shell,
+			Cwd:         cwd,
+			UseDocker:   item.UseDocker,
+			Status:      "idle",
+			LayoutJSON:  item.LayoutJSON,
+			IsMinimized: item.IsMinimized,
+			Env:         encodeStringList(env),
+			StartupCmds: encodeStringList(startup),
+		}
+		if agent.Name == "" {
+			agent.Name = fmt.Sprintf("Terminal %d", len(agents)+1)
+		}
+		if repoPath := strings.TrimSpace(item.RepoPath); repoPath != "" {
+			repoPath = filepath.Clean(repoPath)
+			if _, ok := availableRepos[repoPath]; ok || (workspacePath != "" && repoPath == workspacePath) {
+				agent.RepoPath = repoPath
+			}
+		}
+		if agentType == kubePodAgentType {
+			var target kube.PodTarget
+			if jsonErr := json.Unmarshal([]byte(item.KubeTarget), &target); jsonErr != nil {
+				log.Printf("[ORCH][BUNDLE] skipping kube agent %q: invalid target", item.Name)
+				continue
+			}
+			normalized, targetErr := normalizeKubePodTarget(target)
+			if targetErr != nil {
+				log.Printf("[ORCH][BUNDLE] skipping kube agent %q: %v", item.Name, targetErr)
+				continue
+			}
+			encoded, _ := json.Marshal(normalized)
+			agent.KubeTarget = string(encoded)
+		}
+		agents = append(agents, agent)
+	}
+
+	if err := a.db.CreateWorkspaceWithAgents(ws, agents); err != nil {
+		return nil, err
+	}
+
+	for _, repo := range bundle.Repos {
+		repoPath := filepath.Clean(strings.TrimSpace(repo.Path))
+		if _, ok := availableRepos[repoPath]; !ok {
+			continue
+		}
+		if _, repoErr := a.AddWorkspaceRepository(ws.ID, repoPath, repo.Name); repoErr != nil {
+			log.Printf("[ORCH][BUNDLE] unable to attach repo %s: %v", repoPath, repoErr)
+		}
+	}
+
+	if bindings := strings.TrimSpace(bundle.Settings.ShortcutBindings); bindings != "" {
+		if cfg, cfgErr := a.db.GetConfig(); cfgErr == nil && strings.TrimSpace(cfg.ShortcutBindings) == "" {
+			if normalized, normErr := normalizeShortcutBindingsJSON(bindings); normErr == nil {
+				cfg.ShortcutBindings = normalized
+				if saveErr := a.db.UpdateConfig(cfg); saveErr != nil {
+					log.Printf("[ORCH][BUNDLE] unable to apply shortcut bindings: %v", saveErr)
+				}
+			}
+		}
+	}
+
+	if workspacePath != "" && a.fileWatcher != nil {
+		if watchErr := a.fileWatcher.Watch(workspacePath); watchErr != nil {
+			log.Printf("[ORCH][BUNDLE] could not watch workspace path %s: %v", workspacePath, watchErr)
+		}
+	}
+
+	log.Printf("[ORCH][BUNDLE] workspace id=%d imported from %s agents=%d pendingSecrets=%d", ws.ID, bundlePath, len(agents), len(bundle.Secrets))
+	return ws, nil
+}
+
+// CreateAgentSession cria um novo agente/sessão no workspace informado.
+func (a *App) CreateAgentSession(workspaceID uint, name string, agentType string) (*database.AgentSession, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	// Regra de negócio: primeiro terminal sempre nasce no workspace "Default".
+	if totalAgents, countErr := a.db.CountAgents(); countErr == nil && totalAgents == 0 {
+		defaultWorkspaceID := uint(0)
+		if workspaces, listErr := a.db.ListWorkspaces(); listErr == nil {
+			for _, ws := range workspaces {
+				if strings.EqualFold(strings.TrimSpace(ws.Name), "default") {
+					defaultWorkspaceID = ws.ID
+					break
+				}
+			}
+		}
+
+		if defaultWorkspaceID == 0 {
+			defaultWorkspace := &database.Workspace{
+				UserID: "local",
+				Name:   "Default",
+				Path:   "",
+			}
+			if createErr := a.db.CreateWorkspace(defaultWorkspace); createErr == nil {
+				defaultWorkspaceID = defaultWorkspace.ID
+			}
+		}
+
+		if defaultWorkspaceID != 0 {
+			workspaceID = defaultWorkspaceID
+			_ = a.db.SetActiveWorkspace(defaultWorkspaceID)
+		}
+	}
+
+	if workspaceID == 0 {
+		ws, err := a.db.GetActiveWorkspace()
+		if err != nil {
+			return nil, err
+		}
+		workspaceID = ws.ID
+	}
+
+	if _, err := a.db.GetWorkspace(workspaceID); err != nil {
+		return nil, err
+	}
+
+	trimmedName := strings.TrimSpace(name)
+	if trimmedName == "" {
+		trimmedName = fmt.Sprintf("Terminal %d", time.Now().Unix()%10000)
+	}
+
+	normalizedType, err := normalizeAgentSessionType(agentType)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := a.db.ListAgents(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	agent := &database.AgentSession{
+		WorkspaceID: workspaceID,
+		Name:        trimmedName,
+		Type:        normalizedType,
+		Shell:       a.resolvePreferredLocalShell(),
+		Status:      "idle",
+		SortOrder:   len(existing),
+	}
+
+	if err := a.db.CreateAgent(agent); err != nil {
+		return nil, err
+	}
+
+	if agent == nil || agent.ID == 0 {
+		return nil, fmt.Errorf("invalid agent session persisted: empty id")
+	}
+
+	persisted, err := a.db.GetAgent(agent.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load created agent session: %w", err)
+	}
+	if persisted == nil || persisted.ID == 0 || persisted.WorkspaceID == 0 {
+		return nil, fmt.Errorf("invalid created agent session payload")
+	}
+
+	return persisted, nil
+}
+
+func normalizeAgentSessionType(agentType string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(agentType))
+	switch normalized {
+	case "", "terminal":
+		return "terminal", nil
+	case "ai_agent":
+		return "ai_agent", nil
+	case kubePodAgentType:
+		return kubePodAgentType, nil
+	case "github":
+		return "", fmt.Errorf("agent type 'github' foi descontinuado; abra o Git Panel dedicado")
+	default:
+		return "", fmt.Errorf("unsupported agent type: %s", agentType)
+	}
+}
+
+func isLegacyGitHubAgentType(agentType string) bool {
+	return strings.EqualFold(strings.TrimSpace(agentType), "github")
+}
+
+func filterLegacyGitHubAgents(workspaces []database.Workspace) ([]database.Workspace, []uint) {
+	sanitized := make([]database.Workspace, 0, len(workspaces))
+	legacyAgentIDs := make([]uint, 0)
+
+	for _, ws := range workspaces {
+		keptAgents := make([]database.AgentSession, 0, len(ws.Agents))
+		for _, agent := range ws.Agents {
+			if isLegacyGitHubAgentType(agent.Type) {
+				legacyAgentIDs = append(legacyAgentIDs, agent.ID)
+				continue
+			}
+			keptAgents = append(keptAgents, agent)
+		}
+
+		ws.Agents = keptAgents
+		sanitized = append(sanitized, ws)
+	}
+
+	return sanitized, legacyAgentIDs
+}
+
+// DeleteAgentSession remove o agente e encerra seu processo associado.
+func (a *App) DeleteAgentSession(agentID uint) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	agent, err := a.db.GetAgent(agentID)
+	if err != nil {
+		return err
+	}
+
+	if agent.SessionID != "" {
+		a.killTerminalSession(agent.SessionID)
+	}
+
+	return a.db.DeleteAgent(agentID)
+}
+
+// MoveAgentSessionToWorkspace move um agente/sessão para outro workspace.
+func (a *App) MoveAgentSessionToWorkspace(agentID uint, workspaceID uint) (*database.AgentSession, error) {
+	log.Printf("[ORCH][MOVE] request received agentID=%d targetWorkspaceID=%d", agentID, workspaceID)
+
+	if a.db == nil {
+		log.Printf("[ORCH][MOVE] aborted: database not initialized")
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	moved, err := a.db.MoveAgentToWorkspace(agentID, workspaceID)
+	if err != nil {
+		log.Printf("[ORCH][MOVE] failed agentID=%d targetWorkspaceID=%d err=%v", agentID, workspaceID, err)
+		return nil, err
+	}
+
+	if moved == nil {
+		log.Printf("[ORCH][MOVE] failed agentID=%d targetWorkspaceID=%d err=empty payload", agentID, workspaceID)
+		return nil, fmt.Errorf("move returned empty payload")
+	}
+
+	log.Printf(
+		"[ORCH][MOVE] success agentID=%d targetWorkspaceID=%d finalWorkspaceID=%d sortOrder=%d sessionID=%q",
+		agentID,
+		workspaceID,
+		moved.WorkspaceID,
+		moved.SortOrder,
+		moved.SessionID,
+	)
+
+	return moved, nil
+}
+
+// GetWorkspaceHistoryBuffer retorna o histórico textual dos terminais de um workspace.
+// map key = agentID serializado como string.
+func (a *App) GetWorkspaceHistoryBuffer(workspaceID uint) (map[string]string, error) {
+	if a.db == nil {
+		return map[string]string{}, nil
+	}
+
+	if workspaceID == 0 {
+		active, err := a.db.GetActiveWorkspace()
+		if err != nil {
+			return map[string]string{}, err
+		}
+		workspaceID = active.ID
+	}
+
+	agents, err := a.db.ListAgents(workspaceID)
+	if err != nil {
+		return map[string]string{}, err
+	}
+
+	history := make(map[string]string, len(agents))
+	for _, agent := range agents {
+		if agent.ID == 0 || agent.SessionID == "" {
+			continue
+		}
+
+		buffer := a.getTerminalHistory(agent.SessionID)
+		if buffer == "" {
+			continue
+		}
+		history[strconv.FormatUint(uint64(agent.ID), 10)] = buffer
+	}
+
+	return history, nil
+}
+
+// CreateAgent cria um novo agente no workspace ativo (compatibilidade legada).
+func (a *App) CreateAgent(name string, agentType string) (*database.AgentInstance, error) {
+	agent, err := a.CreateAgentSession(0, name, agentType)
+	if err != nil {
+		return nil, err
+	}
+	return agent, nil
+}
+
+// DeleteAgent remove um agente (compatibilidade legada).
+func (a *App) DeleteAgent(agentID uint) error {
+	return a.DeleteAgentSession(agentID)
+}
+
+// ListAgents lista agentes do workspace ativo (compatibilidade legada).
+func (a *App) ListAgents() ([]database.AgentInstance, error) {
+	if a.db == nil {
+		return []database.AgentInstance{}, nil
+	}
+
+	ws, err := a.db.GetActiveWorkspace()
+	if err != nil {
+		return []database.AgentInstance{}, nil
+	}
+
+	return a.db.ListAgents(ws.ID)
+}
+
+// SaveLayoutState salva o estado do layout (JSON) na config do usuário
+func (a *App) SaveLayoutState(layoutJSON string) error {
+	if a.db == nil {
+		return nil
+	}
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		// Criar config se não existe
+		cfg = &database.UserConfig{
+			Theme:       "dark",
+			LayoutState: layoutJSON,
+		}
+		return a.db.UpdateConfig(cfg)
+	}
+	cfg.LayoutState = layoutJSON
+	return a.db.UpdateConfig(cfg)
+}
+
+// GetLayoutState retorna o estado do layout salvo
+func (a *App) GetLayoutState() (string, error) {
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return "", err
+	}
+	return cfg.LayoutState, nil
+}
+
+// GetHydrationData retorna o payload inicial (para fallback do frontend)
+func (a *App) GetHydrationData() HydrationPayload {
+	return a.getHydrationPayload()
+}
+
+func normalizeTheme(theme string) string {
+	switch strings.ToLower(strings.TrimSpace(theme)) {
+	case "light":
+		return "light"
+	case "hacker":
+		return "hacker"
+	case "nvim":
+		return "nvim"
+	case "min-dark", "mindark", "min_dark", "min dark":
+		return "min-dark"
+	default:
+		return "dark"
+	}
+}
+
+func normalizeLanguage(language string) string {
+	return i18n.NormalizeLocale(language)
+}
+
+const defaultTerminalFontFamily = "JetBrains Mono"
+const defaultTerminalCursorStyle = "line"
+
+func normalizeTerminalFontSize(size int) int {
+	if size < 10 {
+		return 10
+	}
+	if size > 24 {
+		return 24
+	}
+	return size
+}
+
+func normalizeTerminalFontFamily(family string) string {
+	cleaned := strings.TrimSpace(family)
+	if cleaned == "" {
+		return defaultTerminalFontFamily
+	}
+
+	cleaned = strings.ReplaceAll(cleaned, "\n", " ")
+	cleaned = strings.ReplaceAll(cleaned, "\r", " ")
+	cleaned = strings.ReplaceAll(cleaned, "\t", " ")
+	cleaned = strings.Join(strings.Fields(cleaned), " ")
+	if cleaned == "" {
+		return defaultTerminalFontFamily
+	}
+
+	// Famílias iniciadas com "." são internas do sistema e não são estáveis no xterm.
+	if strings.HasPrefix(cleaned, ".") {
+		return defaultTerminalFontFamily
+	}
+
+	if len(cleaned) > 120 {
+		cleaned = strings.TrimSpace(cleaned[:120])
+	}
+
+	return cleaned
+}
+
+func normalizeTerminalCursorStyle(style string) string {
+	switch strings.ToLower(strings.TrimSpace(style)) {
+	case "block":
+		return "block"
+	case "underline", "under-line", "under_line":
+		return "underline"
+	case "line", "bar", "beam", "vertical":
+		return "line"
+	default:
+		return defaultTerminalCursorStyle
+	}
+}
+
+type shortcutBindingPayload struct {
+	Key   string `json:"key"`
+	Meta  bool   `json:"meta"`
+	Shift bool   `json:"shift"`
+	Alt   bool   `json:"alt"`
+}
+
+func normalizeShortcutBindingsJSON(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" || trimmed == "{}" {
+		return "{}", nil
+	}
+
+	var parsed map[string]shortcutBindingPayload
+	if err := json.Unmarshal([]byte(trimmed), &parsed); err != nil {
+		return "", fmt.Errorf("invalid shortcut bindings JSON: %w", err)
+	}
+
+	cleaned := make(map[string]shortcutBindingPayload, len(parsed))
+	for id, binding := range parsed {
+		normalizedID := strings.TrimSpace(id)
+		if normalizedID == "" {
+			continue
+		}
+
+		key := strings.TrimSpace(binding.Key)
+		if key == "" {
+			continue
+		}
+
+		if len(key) > 24 {
+			continue
+		}
+
+		cleaned[normalizedID] = shortcutBindingPayload{
+			Key:   key,
+			Meta:  binding.Meta,
+			Shift: binding.Shift,
+			Alt:   binding.Alt,
+		}
+	}
+
+	serialized, err := json.Marshal(cleaned)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize shortcut bindings: %w", err)
+	}
+
+	return string(serialized), nil
+}
+
+// SaveTheme persiste o tema escolhido pelo usuário.
+func (a *App) SaveTheme(theme string) error {
+	if a.db == nil {
+		return nil
+	}
+
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.Theme = normalizeTheme(theme)
+	return a.db.UpdateConfig(cfg)
+}
+
+// SaveLanguage persiste o idioma escolhido pelo usuário.
+func (a *App) SaveLanguage(language string) error {
+	if a.db == nil {
+		return nil
+	}
+
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.Language = normalizeLanguage(language)
+	if err := a.db.UpdateConfig(cfg); err != nil {
+		return err
+	}
+	a.translator.SetLocale(cfg.Language)
+	return nil
+}
+
+// GetMessageCatalog devolve o catálogo de mensagens do backend (IDs usados em messageId dos
+// DTOs); locale vazio usa o idioma atual.
+func (a *App) GetMessageCatalog(locale string) map[string]string {
+	if strings.TrimSpace(locale) == "" {
+		locale = a.translator.Locale()
+	}
+	return i18n.Catalog(locale)
+}
+
+// SaveDefaultShell persiste o shell escolhido pelo usuário.
+func (a *App) SaveDefaultShell(shell string) error {
+	if a.db == nil {
+		return nil
+	}
+
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.DefaultShell = strings.TrimSpace(shell)
+	return a.db.UpdateConfig(cfg)
+}
+
+// SaveTerminalFontSize persiste o tamanho da fonte do terminal.
+func (a *App) SaveTerminalFontSize(size int) error {
+	if a.db == nil {
+		return nil
+	}
+
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.FontSize = normalizeTerminalFontSize(size)
+	return a.db.UpdateConfig(cfg)
+}
+
+// SaveTerminalFontFamily persiste a família de fonte do terminal.
+func (a *App) SaveTerminalFontFamily(family string) error {
+	if a.db == nil {
+		return nil
+	}
+
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.FontFamily = normalizeTerminalFontFamily(family)
+	return a.db.UpdateConfig(cfg)
+}
+
+// SaveTerminalCursorStyle persiste o estilo do cursor do terminal.
+func (a *App) SaveTerminalCursorStyle(style string) error {
+	if a.db == nil {
+		return nil
+	}
+
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.CursorStyle = normalizeTerminalCursorStyle(style)
+	return a.db.UpdateConfig(cfg)
+}
+
+// SaveShortcutBindings persiste os atalhos customizados do usuário como JSON.
+func (a *App) SaveShortcutBindings(bindingsJSON string) error {
+	if a.db == nil {
+		return nil
+	}
+
+	normalized, err := normalizeShortcutBindingsJSON(bindingsJSON)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.ShortcutBindings = normalized
+	return a.db.UpdateConfig(cfg)
+}
+
+// GetAvailableShells retorna a lista de shells disponíveis no sistema.
+func (a *App) GetAvailableShells() ([]string, error) {
+	return terminal.GetAvailableShells(), nil
+}
+
+// GetAvailableTerminalFonts retorna famílias de fontes instaladas no sistema.
+func (a *App) GetAvailableTerminalFonts() ([]string, error) {
+	return terminal.GetAvailableFonts(), nil
+}
+
+// CompleteOnboarding marca onboarding como concluído.
+func (a *App) CompleteOnboarding() error {
+	if a.db == nil {
+		return nil
+	}
+
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.OnboardingCompleted = true
+	return a.db.UpdateConfig(cfg)
+}
+
+// === GitHub Bindings (expostos ao Frontend) ===
+
+// GHListRepositories lista repositórios do usuário
+func (a *App) GHListRepositories() ([]gh.Repository, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.ListRepositories(a.requestContext())
+}
+
+// GHListPullRequests lista PRs de um repositório
+func (a *App) GHListPullRequests(owner, repo, state string, first int) ([]gh.PullRequest, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	filters := gh.PRFilters{State: state, First: first}
+	return a.github.ListPullRequests(a.requestContext(), owner, repo, filters)
+}
+
+// GHGetMyPullRequestDashboard agrega os PRs do usuário em todos os repositórios (tela inicial)
+func (a *App) GHGetMyPullRequestDashboard() (*gh.PullRequestDashboard, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.GetMyPullRequestDashboard(a.requestContext())
+}
+
+// GHGetPullRequest busca detalhes de um PR
+func (a *App) GHGetPullRequest(owner, repo string, number int) (*gh.PullRequest, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.GetPullRequest(a.requestContext(), owner, repo, number)
+}
+
+// GHGetPullRequestDiff busca o diff de um PR
+func (a *App) GHGetPullRequestDiff(owner, repo string, number int, first int, after string) (*gh.Diff, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	pagination := gh.DiffPagination{First: first}
+	if after != "" {
+		pagination.After = &after
+	}
+	return a.github.GetPullRequestDiff(a.requestContext(), owner, repo, number, pagination)
+}
+
+// GHCreatePullRequest cria um novo PR
+func (a *App) GHCreatePullRequest(owner, repo, title, body, head, base string, isDraft bool) (*gh.PullRequest, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.CreatePullRequest(a.requestContext(), gh.CreatePRInput{
+		Owner: owner, Repo: repo, Title: title, Body: body,
+		HeadBranch: head, BaseBranch: base, IsDraft: isDraft,
+	})
+}
+
+// GHMergePullRequest faz merge de um PR
+func (a *App) GHMergePullRequest(owner, repo string, number int, method string) error {
+	if !a.awaitGitHub() {
+		return nil
+	}
+	if err := a.github.MergePullRequest(a.requestContext(), owner, repo, number, gh.MergeMethod(method)); err != nil {
+		return err
+	}
+	a.notifyPRMerged(owner, repo, number, "")
+	return nil
+}
+
+func (a *App) notifyPRMerged(owner, repo string, number int, sha string) {
+	fields := map[string]string{"repo": owner + "/" + repo, "number": strconv.Itoa(number)}
+	if sha != "" {
+		fields["sha"] = sha
+	}
+	a.notifyEvent(notify.EventPRMerged, fmt.Sprintf("PR #%d merged in %s/%s", number, owner, repo), "", fields)
+}
+
+// GHClosePullRequest fecha um PR
+func (a *App) GHClosePullRequest(owner, repo string, number int) error {
+	if !a.awaitGitHub() {
+		return nil
+	}
+	return a.github.ClosePullRequest(a.requestContext(), owner, repo, number)
+}
+
+// GHListReviews lista reviews de um PR
+func (a *App) GHListReviews(owner, repo string, prNumber int) ([]gh.Review, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.ListReviews(a.requestContext(), owner, repo, prNumber)
+}
+
+// GHCreateReview cria um review em um PR
+func (a *App) GHCreateReview(owner, repo string, prNumber int, body, event string) (*gh.Review, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.CreateReview(a.requestContext(), gh.CreateReviewInput{
+		Owner: owner, Repo: repo, PRNumber: prNumber, Body: body, Event: event,
+	})
+}
+
+// GHListComments lista comentários de um PR
+func (a *App) GHListComments(owner, repo string, prNumber int) ([]gh.Comment, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.ListComments(a.requestContext(), owner, repo, prNumber)
+}
+
+// GHCreateComment cria um comentário em um PR
+func (a *App) GHCreateComment(owner, repo string, prNumber int, body string) (*gh.Comment, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.CreateComment(a.requestContext(), gh.CreateCommentInput{
+		Owner: owner, Repo: repo, PRNumber: prNumber, Body: body,
+	})
+}
+
+// GHCreateInlineComment cria um comentário inline no diff
+func (a *App) GHCreateInlineComment(owner, repo string, prNumber int, body, path string, line int, side string) (*gh.Comment, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.CreateInlineComment(a.requestContext(), gh.InlineCommentInput{
+		Owner: owner, Repo: repo, PRNumber: prNumber,
+		Body: body, Path: path, Line: line, Side: side,
+	})
+}
+
+// GHListIssues lista issues de um repositório
+func (a *App) GHListIssues(owner, repo, state string, first int) ([]gh.Issue, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	filters := gh.IssueFilters{State: state, First: first}
+	return a.github.ListIssues(a.requestContext(), owner, repo, filters)
+}
+
+// GitHubIssueFilterDTO representa um filtro salvo do quadro de triagem de issues.
+type GitHubIssueFilterDTO struct {
+	ID       uint     `json:"id"`
+	Owner    string   `json:"owner"`
+	Repo     string   `json:"repo"`
+	Name     string   `json:"name"`
+	Labels   []string `json:"labels"`
+	Assignee string   `json:"assignee,omitempty"`
+	State    string   `json:"state"`
+	Query    string   `json:"query,omitempty"`
+}
+
+// GitHubIssueFilterCountDTO é o total de issues de um filtro salvo (sidebar de triagem).
+type GitHubIssueFilterCountDTO struct {
+	FilterID uint `json:"filterId"`
+	Count    int  `json:"count"`
+}
+
+func normalizeGitHubIssueFilter(dto GitHubIssueFilterDTO) (GitHubIssueFilterDTO, error) {
+	owner, repo, err := gpr.NormalizeOwnerRepo(dto.Owner, dto.Repo)
+	if err != nil {
+		return dto, err
+	}
+	dto.Owner, dto.Repo = owner, repo
+	dto.Name = strings.TrimSpace(dto.Name)
+	if dto.Name == "" {
+		return dto, fmt.Errorf("filter name cannot be empty")
+	}
+
+	dto.State = strings.ToLower(strings.TrimSpace(dto.State))
+	switch dto.State {
+	case "":
+		dto.State = "open"
+	case "open", "closed", "all":
+	default:
+		return dto, fmt.Errorf("invalid issue state %q: expected open, closed or all", dto.State)
+	}
+
+	labels := make([]string, 0, len(dto.Labels))
+	for _, label := range dto.Labels {
+		label = strings.TrimSpace(label)
+		if label != "" && !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	dto.Labels = labels
+
+	dto.Assignee = strings.TrimSpace(dto.Assignee)
+	if dto.Assignee != "@me" {
+		dto.Assignee = strings.TrimPrefix(dto.Assignee, "@")
+	}
+	if strings.ContainsAny(dto.Assignee, " \t") {
+		return dto, fmt.Errorf("invalid assignee %q", dto.Assignee)
+	}
+	dto.Query = strings.TrimSpace(dto.Query)
+	if strings.ContainsAny(dto.Query, "\r\n") {
+		return dto, fmt.Errorf("filter query must be a single line")
+	}
+	return dto, nil
+}
+
+func gitHubIssueFilterToDTO(filter database.GitHubIssueFilter) GitHubIssueFilterDTO {
+	labels := decodeAgentStringList(filter.Labels)
+	if labels == nil {
+		labels = []string{}
+	}
+	return GitHubIssueFilterDTO{
+		ID:       filter.ID,
+		Owner:    filter.Owner,
+		Repo:     filter.Repo,
+		Name:     filter.Name,
+		Labels:   labels,
+		Assignee: filter.Assignee,
+		State:    filter.State,
+		Query:    filter.Query,
+	}
+}
+
+func (dto GitHubIssueFilterDTO) searchFilter() gh.IssueSearchFilter {
+	return gh.IssueSearchFilter{Labels: dto.Labels, Assignee: dto.Assignee, State: dto.State, Query: dto.Query}
+}
+
+// GHListIssueFilters lista os filtros de issues salvos para o repositório.
+func (a *App) GHListIssueFilters(owner, repo string) ([]GitHubIssueFilterDTO, error) {
+	if a.db == nil {
+		return []GitHubIssueFilterDTO{}, nil
+	}
+	filters, err := a.db.ListGitHubIssueFilters(strings.TrimSpace(owner), strings.TrimSpace(repo))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]GitHubIssueFilterDTO, 0, len(filters))
+	for _, filter := range filters {
+		result = append(result, gitHubIssueFilterToDTO(filter))
+	}
+	return result, nil
+}
+
+// GHSaveIssueFilter cria (id=0) ou atualiza um filtro de issues salvo.
+func (a *App) GHSaveIssueFilter(dto GitHubIssueFilterDTO) (GitHubIssueFilterDTO, error) {
+	if a.db == nil {
+		return GitHubIssueFilterDTO{}, fmt.Errorf("database not initialized")
+	}
+	normalized, err := normalizeGitHubIssueFilter(dto)
+	if err != nil {
+		return GitHubIssueFilterDTO{}, err
+	}
+
+	filter := &database.GitHubIssueFilter{}
+	if normalized.ID != 0 {
+		existing, getErr := a.db.GetGitHubIssueFilter(normalized.ID)
+		if getErr != nil {
+			return GitHubIssueFilterDTO{}, getErr
+		}
+		filter = existing
+	}
+	filter.Owner = normalized.Owner
+	filter.Repo = normalized.Repo
+	filter.Name = normalized.Name
+	filter.Labels = encodeStringList(normalized.Labels)
+	filter.Assignee = normalized.Assignee
+	filter.State = normalized.State
+	filter.Query = normalized.Query
+
+	if err := a.db.SaveGitHubIssueFilter(filter); err != nil {
+		return GitHubIssueFilterDTO{}, err
+	}
+	return gitHubIssueFilterToDTO(*filter), nil
+}
+
+// GHDeleteIssueFilter remove um filtro de issues salvo.
+func (a *App) GHDeleteIssueFilter(id uint) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return a.db.DeleteGitHubIssueFilter(id)
+}
+
+// GHListIssuesWithFilter busca (GraphQL search) uma página de issues do filtro salvo;
+// cursor vazio = primeira página.
+func (a *App) GHListIssuesWithFilter(filterID uint, cursor string) (*gh.IssueSearchPage, error) {
+	if !a.awaitGitHub() || a.db == nil {
+		return nil, nil
+	}
+	filter, err := a.db.GetGitHubIssueFilter(filterID)
+	if err != nil {
+		return nil, err
+	}
+	dto := gitHubIssueFilterToDTO(*filter)
+	return a.github.SearchIssues(a.requestContext(), dto.Owner, dto.Repo, dto.searchFilter(), 0, cursor)
+}
+
+// GHGetIssueFilterCounts retorna o total de issues de cada filtro salvo do repositório.
+func (a *App) GHGetIssueFilterCounts(owner, repo string) ([]GitHubIssueFilterCountDTO, error) {
+	if !a.awaitGitHub() {
+		return []GitHubIssueFilterCountDTO{}, nil
+	}
+	filters, err := a.GHListIssueFilters(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) == 0 {
+		return []GitHubIssueFilterCountDTO{}, nil
+	}
+
+	searches := make([]gh.IssueSearchFilter, 0, len(filters))
+	for _, filter := range filters {
+		searches = append(searches, filter.searchFilter())
+	}
+	counts, err := a.github.CountIssues(a.requestContext(), filters[0].Owner, filters[0].Repo, searches)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]GitHubIssueFilterCountDTO, 0, len(filters))
+	for i, filter := range filters {
+		result = append(result, GitHubIssueFilterCountDTO{FilterID: filter.ID, Count: counts[i]})
+	}
+	return result, nil
+}
+
+// GHCreateIssue cria uma nova issue
+func (a *App) GHCreateIssue(owner, repo, title, body string) (*gh.Issue, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.CreateIssue(a.requestContext(), gh.CreateIssueInput{
+		Owner: owner, Repo: repo, Title: title, Body: body,
+	})
+}
+
+// GHUpdateIssue atualiza uma issue
+func (a *App) GHUpdateIssue(owner, repo string, number int, title, body, state *string) error {
+	if !a.awaitGitHub() {
+		return nil
+	}
+	return a.github.UpdateIssue(a.requestContext(), owner, repo, number, gh.UpdateIssueInput{
+		Title: title, Body: body, State: state,
+	})
+}
+
+// GHListBranches lista branches de um repositório
+func (a *App) GHListBranches(owner, repo string) ([]gh.Branch, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.ListBranches(a.requestContext(), owner, repo)
+}
+
+// GHCreateBranch cria uma nova branch
+func (a *App) GHCreateBranch(owner, repo, name, sourceBranch string) (*gh.Branch, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.CreateBranch(a.requestContext(), owner, repo, name, sourceBranch)
+}
+
+// GHForkRepository cria o fork de owner/repo na conta conectada (ou retorna o fork existente)
+func (a *App) GHForkRepository(owner, repo string) (*gh.RepositoryForkInfo, error) {
+	if !a.awaitGitHub() {
+		return nil, nil
+	}
+	return a.github.ForkRepository(a.requestContext(), owner, repo)
+}
+
+// GHRerunCheckRun executa de novo um check run do PR (ex.: CI obrigatório que falhou)
+func (a *App) GHRerunCheckRun(owner, repo string, checkRunID int64) error {
+	if !a.awaitGitHub() {
+		return nil
+	}
+	return a.github.RerunCheckRun(a.requestContext(), owner, repo, checkRunID)
+}
+
+// GHRerunFailedJobs executa de novo os jobs que falharam numa execução do GitHub Actions
+func (a *App) GHRerunFailedJobs(owner, repo string, runID int64) error {
+	if !a.awaitGitHub() {
+		return nil
+	}
+	return a.github.RerunFailedJobs(a.requestContext(), owner, repo, runID)
+}
+
+// GHDispatchWorkflow dispara um workflow com workflow_dispatch (ex.: deploy a partir do PR)
+func (a *App) GHDispatchWorkflow(owner, repo, workflowFile, ref string, inputs map[string]string) error {
+	if !a.awaitGitHub() {
+		return nil
+	}
+	return a.github.DispatchWorkflow(a.requestContext(), gh.WorkflowDispatchInput{
+		Owner: owner, Repo: repo, Workflow: workflowFile, Ref: ref, Inputs: inputs,
+	})
+}
+
+// GHInvalidateCache invalida o cache de um repositório
+func (a *App) GHInvalidateCache(owner, repo string) {
+	if !a.awaitGitHub() {
+		return
+	}
+	a.github.InvalidateCache(owner, repo)
+}
+
+// === FileWatcher Bindings (expostos ao Frontend) ===
+
+// WatchProject inicia monitoramento do .git de um projeto
+func (a *App) WatchProject(projectPath string) error {
+	if a.fileWatcher == nil {
+		return nil
+	}
+	return a.fileWatcher.Watch(projectPath)
+}
+
+// UnwatchProject para monitoramento de um projeto
+func (a *App) UnwatchProject(projectPath string) error {
+	if a.fileWatcher == nil {
+		return nil
+	}
+	return a.fileWatcher.Unwatch(projectPath)
+}
+
+// GetCurrentBranch retorna a branch atual do projeto
+func (a *App) GetCurrentBranch(projectPath string) (string, error) {
+	if a.fileWatcher == nil {
+		return "", nil
+	}
+	return a.fileWatcher.GetCurrentBranch(projectPath)
+}
+
+// GetLastCommit retorna informações do último commit
+func (a *App) GetLastCommit(projectPath string) (*fw.CommitInfo, error) {
+	if a.fileWatcher == nil {
+		return nil, nil
+	}
+	return a.fileWatcher.GetLastCommit(projectPath)
+}
+
+// SetWorkspaceFileWatching liga/desliga o monitoramento dos arquivos do workspace
+// (eventos workspace:file_changed, com debounce e respeitando .gitignore).
+func (a *App) SetWorkspaceFileWatching(workspaceID uint, enabled bool) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	ws, err := a.db.GetWorkspace(workspaceID)
+	if err != nil {
+		return err
+	}
+	if a.fileWatcher != nil {
+		if !enabled {
+			a.fileWatcher.UnwatchWorkspaceFiles(ws.ID)
+		} else if root := a.workspaceFileRoot(ws); root == "" {
+			return fmt.Errorf("workspace %d has no path or repository to watch", ws.ID)
+		} else if err := a.fileWatcher.WatchWorkspaceFiles(ws.ID, root); err != nil {
+			return err
+		}
+	}
+	if err := a.db.SetWorkspaceWatchFiles(ws.ID, enabled); err != nil {
+		if enabled && a.fileWatcher != nil {
+			a.fileWatcher.UnwatchWorkspaceFiles(ws.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetFileWatcherMetrics retorna diretórios monitorados por repositório e contadores de eventos.
+func (a *App) GetFileWatcherMetrics() (*fw.WatcherMetrics, error) {
+	if a.fileWatcher == nil {
+		return nil, fmt.Errorf("file watcher not initialized")
+	}
+	metrics := a.fileWatcher.Metrics()
+	return &metrics, nil
+}
+
+// GitAutoFetchListSettings lista as configurações de auto-fetch por repositório.
+func (a *App) GitAutoFetchListSettings() ([]database.GitAutoFetchSetting, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	return a.db.ListGitAutoFetchSettings()
+}
+
+// GitAutoFetchConfigure liga/desliga o fetch periódico de um repositório.
+// intervalSeconds <= 0 usa o padrão (5 min); o mínimo é 60s.
+func (a *App) GitAutoFetchConfigure(repoPath string, enabled bool, intervalSeconds int) (*database.GitAutoFetchSetting, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	repoRoot := findGitRepoRoot(strings.TrimSpace(repoPath))
+	if strings.TrimSpace(repoPath) == "" || repoRoot == "" {
+		return nil, fmt.Errorf("not a git repository: %s", repoPath)
+	}
+
+	interval := fw.NormalizeAutoFetchInterval(intervalSeconds)
+	setting, err := a.db.SaveGitAutoFetchSetting(repoRoot, enabled, int(interval/time.Second))
+	if err != nil {
+		return nil, err
+	}
+	if a.fileWatcher != nil {
+		a.fileWatcher.SetAutoFetch(fw.AutoFetchConfig{
+			RepoPath:        setting.RepoPath,
+			Enabled:         setting.Enabled,
+			IntervalSeconds: setting.IntervalSeconds,
+		})
+	}
+	return setting, nil
+}
+
+// GitAutoFetchNow executa um fetch imediato e retorna ahead/behind do upstream.
+func (a *App) GitAutoFetchNow(repoPath string) (fw.AutoFetchResult, error) {
+	if a.fileWatcher == nil {
+		return fw.AutoFetchResult{}, fmt.Errorf("file watcher not initialized")
+	}
+	repoRoot := findGitRepoRoot(strings.TrimSpace(repoPath))
+	if strings.TrimSpace(repoPath) == "" || repoRoot == "" {
+		return fw.AutoFetchResult{}, fmt.Errorf("not a git repository: %s", repoPath)
+	}
+	return a.fileWatcher.FetchNow(repoRoot)
+}
+
+// GitAutoFetchLastResult retorna o resultado do último auto-fetch do repositório.
+func (a *App) GitAutoFetchLastResult(repoPath string) (*fw.AutoFetchResult, error) {
+	if a.fileWatcher == nil {
+		return nil, nil
+	}
+	repoRoot := findGitRepoRoot(strings.TrimSpace(repoPath))
+	if repoRoot == "" {
+		return nil, nil
+	}
+	result, ok := a.fileWatcher.LastAutoFetch(repoRoot)
+	if !ok {
+		return nil, nil
+	}
+	return &result, nil
+}
+
+func (a *App) restoreGitAutoFetchSettings() {
+	if a.db == nil || a.fileWatcher == nil {
+		return
+	}
+	settings, err := a.db.ListGitAutoFetchSettings()
+	if err != nil {
+		log.Printf("[ORCH] Could not load auto-fetch settings: %v", err)
+		return
+	}
+	for _, setting := range settings {
+		if !setting.Enabled {
+			continue
+		}
+		a.fileWatcher.SetAutoFetch(fw.AutoFetchConfig{
+			RepoPath:        setting.RepoPath,
+			Enabled:         true,
+			IntervalSeconds: setting.IntervalSeconds,
+		})
+	}
+}
+
+// workspaceFileRoot retorna a pasta monitorada pelo file watching: o path do workspace ou,
+// sem ele, o repositório principal anexado.
+func (a *App) workspaceFileRoot(ws *database.Workspace) string {
+	if path := strings.TrimSpace(ws.Path); path != "" {
+		return filepath.Clean(path)
+	}
+	repos := ws.Repos
+	if len(repos) == 0 && a.db != nil {
+		repos, _ = a.db.ListWorkspaceRepos(ws.ID)
+	}
+	for _, repo := range repos {
+		if repo.IsPrimary {
+			return filepath.Clean(repo.Path)
+		}
+	}
+	if len(repos) > 0 {
+		return filepath.Clean(repos[0].Path)
+	}
+	return ""
+}
+
+// FuzzyFindFiles busca arquivos do workspace por fuzzy match (respeitando .gitignore) para o quick-open.
+// Com o file watching do workspace ligado o índice é atualizado incrementalmente.
+func (a *App) FuzzyFindFiles(workspaceID uint, query string, limit int) ([]filesearch.FileMatch, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	if a.fileSearch == nil {
+		return nil, fmt.Errorf("file search not initialized")
+	}
+	root, err := a.workspaceFileRootByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	live := a.fileWatcher != nil && a.fileWatcher.WatchedWorkspaces()[workspaceID] == root
+	a.fileSearch.SetLiveUpdates(workspaceID, live)
+	return a.fileSearch.FuzzyFind(workspaceID, root, query, limit)
+}
+
+// workspaceSearch é uma busca de conteúdo em andamento.
+type workspaceSearch struct {
+	workspaceID uint
+	cancel      context.CancelFunc
+}
+
+// WorkspaceSearchBatch é o payload do evento "workspace:search_results".
+type WorkspaceSearchBatch struct {
+	SearchID string                    `json:"searchId"`
+	Matches  []filesearch.ContentMatch `json:"matches"`
+}
+
+// WorkspaceSearchDone é o payload do evento "workspace:search_done".
+type WorkspaceSearchDone struct {
+	SearchID  string                          `json:"searchId"`
+	Summary   filesearch.ContentSearchSummary `json:"summary"`
+	Cancelled bool                            `json:"cancelled"`
+	Error     string                          `json:"error,omitempty"`
+}
+
+// SearchInWorkspace inicia uma busca de conteúdo nos arquivos do workspace (respeitando .gitignore)
+// e retorna o searchID. Os resultados chegam em lotes por "workspace:search_results" e o fim por
+// "workspace:search_done". Uma nova busca no mesmo workspace cancela a anterior.
+func (a *App) SearchInWorkspace(workspaceID uint, query string, regex bool, globs []string) (string, error) {
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	if a.fileSearch == nil {
+		return "", fmt.Errorf("file search not initialized")
+	}
+	root, err := a.workspaceFileRootByID(workspaceID)
+	if err != nil {
+		return "", err
+	}
+	contentQuery := filesearch.ContentQuery{Query: query, Regex: regex, Globs: globs}
+	if err := filesearch.ValidateContentQuery(contentQuery); err != nil {
+		return "", err
+	}
+	live := a.fileWatcher != nil && a.fileWatcher.WatchedWorkspaces()[workspaceID] == root
+	a.fileSearch.SetLiveUpdates(workspaceID, live)
+
+	a.cancelWorkspaceSearches(workspaceID)
+	ctx, cancel := context.WithCancel(context.Background())
+	a.searchMu.Lock()
+	if a.searches == nil {
+		a.searches = make(map[string]*workspaceSearch)
+	}
+	a.searchSeq++
+	searchID := fmt.Sprintf("search-%d", a.searchSeq)
+	a.searches[searchID] = &workspaceSearch{workspaceID: workspaceID, cancel: cancel}
+	a.searchMu.Unlock()
+
+	go func() {
+		defer cancel()
+		summary, searchErr := a.fileSearch.SearchContent(ctx, workspaceID, root, contentQuery, func(matches []filesearch.ContentMatch) {
+			a.emitWorkspaceSearchEvent("workspace:search_results", WorkspaceSearchBatch{SearchID: searchID, Matches: matches})
+		})
+		done := WorkspaceSearchDone{SearchID: searchID, Summary: summary}
+		switch {
+		case errors.Is(searchErr, context.Canceled):
+			done.Cancelled = true
+		case searchErr != nil:
+			done.Error = searchErr.Error()
+		}
+
+		a.searchMu.Lock()
+		delete(a.searches, searchID)
+		a.searchMu.Unlock()
+		a.emitWorkspaceSearchEvent("workspace:search_done", done)
+	}()
+	return searchID, nil
+}
+
+// CancelWorkspaceSearch interrompe uma busca de conteúdo (buscas já finalizadas são ignoradas).
+func (a *App) CancelWorkspaceSearch(searchID string) {
+	a.searchMu.Lock()
+	search, exists := a.searches[searchID]
+	a.searchMu.Unlock()
+	if exists {
+		search.cancel()
+	}
+}
+
+// cancelWorkspaceSearches cancela as buscas do workspace (0 = todas).
+func (a *App) cancelWorkspaceSearches(workspaceID uint) {
+	a.searchMu.Lock()
+	defer a.searchMu.Unlock()
+	for _, search := range a.searches {
+		if workspaceID == 0 || search.workspaceID == workspaceID {
+			search.cancel()
+		}
+	}
+}
+
+func (a *App) emitWorkspaceSearchEvent(eventName string, payload interface{}) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, eventName, payload)
+}
+
+// workspaceFileRootByID retorna a pasta de arquivos do workspace (ver workspaceFileRoot).
+func (a *App) workspaceFileRootByID(workspaceID uint) (string, error) {
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	ws, err := a.db.GetWorkspace(workspaceID)
+	if err != nil {
+		return "", err
+	}
+	root := a.workspaceFileRoot(ws)
+	if root == "" {
+		return "", fmt.Errorf("workspace %d has no path or repository", ws.ID)
+	}
+	return root, nil
+}
+
+// ReadWorkspaceFile lê um arquivo do workspace (caminho relativo à raiz ou absoluto dentro dela),
+// opcionalmente só um intervalo de linhas. Binários voltam sem conteúdo.
+func (a *App) ReadWorkspaceFile(workspaceID uint, path string, lines workspacefs.ReadRange) (*workspacefs.FileContent, error) {
+	root, err := a.workspaceFileRootByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return workspacefs.ReadFile(root, path, lines)
+}
+
+// WriteWorkspaceFile grava um arquivo do workspace se ele ainda tiver o hash lido
+// (expectedHash vazio cria um arquivo novo). Conflitos retornam "file changed on disk".
+func (a *App) WriteWorkspaceFile(workspaceID uint, path string, content string, expectedHash string) (*workspacefs.WriteResult, error) {
+	root, err := a.workspaceFileRootByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return workspacefs.WriteFile(root, path, content, expectedHash)
+}
+
+// ListEditors retorna os editores externos suportados, marcando os instalados nesta máquina.
+func (a *App) ListEditors() []editor.Editor {
+	return editor.List()
+}
+
+// SetWorkspaceEditor define o editor externo do workspace (id de ListEditors; vazio = detecção automática).
+func (a *App) SetWorkspaceEditor(workspaceID uint, editorID string) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	editorID = strings.ToLower(strings.TrimSpace(editorID))
+	if !editor.IsKnown(editorID) {
+		return fmt.Errorf("%w: %s", editor.ErrUnknownEditor, editorID)
+	}
+	return a.db.SetWorkspaceEditor(workspaceID, editorID)
+}
+
+// OpenInEditor abre um arquivo do workspace no editor externo preferido, posicionado na linha/coluna
+// (1-based; 0 = sem posição). O caminho pode ser relativo à raiz ou absoluto dentro da raiz ou de um
+// repositório do workspace (diffs e conflitos do Git Panel). Editores de terminal (vim) abrem numa
+// sessão de terminal integrada, cujo sessionID é retornado; para os demais o retorno é vazio.
+func (a *App) OpenInEditor(workspaceID uint, path string, line int, column int) (string, error) {
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	ws, err := a.db.GetWorkspace(workspaceID)
+	if err != nil {
+		return "", err
+	}
+	target, dir, err := a.resolveEditorTarget(ws, path)
+	if err != nil {
+		return "", err
+	}
+	ed, err := editor.Resolve(ws.Editor)
+	if err != nil {
+		return "", err
+	}
+	if ed.Terminal {
+		return a.createTerminalWithArgs(ed.Command, ed.Args(target, line, column), nil, dir, false, "", 0, 0)
+	}
+	return "", editor.Start(ed, target, line, column, dir)
+}
+
+// resolveEditorTarget valida o arquivo a abrir contra as raízes do workspace e retorna o caminho
+// absoluto e a raiz que o contém (usada como diretório de trabalho do editor).
+func (a *App) resolveEditorTarget(ws *database.Workspace, path string) (string, string, error) {
+	root := a.workspaceFileRoot(ws)
+	if root == "" {
+		return "", "", fmt.Errorf("workspace %d has no path or repository", ws.ID)
+	}
+	roots := []string{root}
+	if filepath.IsAbs(strings.TrimSpace(path)) {
+		repos := ws.Repos
+		if len(repos) == 0 {
+			repos, _ = a.db.ListWorkspaceRepos(ws.ID)
+		}
+		for _, repo := range repos {
+			roots = append(roots, filepath.Clean(repo.Path))
+		}
+	}
+
+	err := workspacefs.ErrOutsideRoot
+	for _, candidate := range roots {
+		abs, _, resolveErr := workspacefs.ResolvePath(candidate, path)
+		if resolveErr != nil {
+			if !errors.Is(resolveErr, workspacefs.ErrOutsideRoot) {
+				err = resolveErr
+			}
+			continue
+		}
+		if _, statErr := os.Stat(abs); statErr != nil {
+			return "", "", statErr
+		}
+		return abs, candidate, nil
+	}
+	return "", "", err
+}
+
+// restoreWorkspaceFileWatching sincroniza o monitoramento de arquivos com os workspaces do banco.
+func (a *App) restoreWorkspaceFileWatching() {
+	if a.db == nil || a.fileWatcher == nil {
+		return
+	}
+	workspaces, err := a.db.ListWorkspaces()
+	if err != nil {
+		log.Printf("[ORCH] Could not load workspaces for file watching: %v", err)
+		return
+	}
+	enabled := make(map[uint]bool, len(workspaces))
+	for i := range workspaces {
+		ws := &workspaces[i]
+		root := a.workspaceFileRoot(ws)
+		if !ws.WatchFiles || root == "" {
+			continue
+		}
+		enabled[ws.ID] = true
+		if err := a.fileWatcher.WatchWorkspaceFiles(ws.ID, root); err != nil {
+			log.Printf("[ORCH] Could not watch files of workspace %d: %v", ws.ID, err)
+		}
+	}
+	for workspaceID := range a.fileWatcher.WatchedWorkspaces() {
+		if !enabled[workspaceID] {
+			a.fileWatcher.UnwatchWorkspaceFiles(workspaceID)
+		}
+	}
+}
+
+// === GitActivity Bindings (expostos ao Frontend) ===
+
+// GitActivityList retorna eventos de atividade Git em ordem mais recente primeiro.
+func (a *App) GitActivityList(limit int, eventType string, repoPath string) []ga.Event {
+	if a.gitActivity == nil {
+		return []ga.Event{}
+	}
+
+	opts := ga.ListOptions{
+		Limit:    limit,
+		RepoPath: repoPath,
+	}
+	if normalized := strings.TrimSpace(eventType); normalized != "" {
+		opts.Type = ga.EventType(normalized)
+	}
+
+	events := a.gitActivity.ListEvents(opts)
+	a.attachIssueLinksToActivity(events)
+	return events
+}
+
+// GitActivityGet retorna um evento específico por ID.
+func (a *App) GitActivityGet(eventID string) (*ga.Event, error) {
+	if a.gitActivity == nil {
+		return nil, fmt.Errorf("git activity service not initialized")
+	}
+	event, ok := a.gitActivity.GetEvent(strings.TrimSpace(eventID))
+	if !ok {
+		return nil, fmt.Errorf("git activity event not found")
+	}
+	return event, nil
+}
+
+// GitActivityClear remove todos os eventos armazenados.
+func (a *App) GitActivityClear() {
+	if a.gitActivity == nil {
+		return
+	}
+	a.gitActivity.Clear()
+}
+
+// GitActivityCount retorna quantidade atual de eventos no buffer.
+func (a *App) GitActivityCount() int {
+	if a.gitActivity == nil {
+		return 0
+	}
+	return a.gitActivity.Count()
+}
+
+// GitActivityGetStagedFiles retorna resumo de arquivos staged para um repositório.
+func (a *App) GitActivityGetStagedFiles(repoPath string) ([]ga.EventFile, error) {
+	return ga.CollectStagedFiles(repoPath)
+}
+
+// GitActivityGetStagedDiff retorna diff staged (geral ou por arquivo).
+func (a *App) GitActivityGetStagedDiff(repoPath string, filePath string) (string, error) {
+	return ga.GetStagedDiff(repoPath, filePath)
+}
+
+// GitActivityUnstageFile remove um arquivo do stage.
+func (a *App) GitActivityUnstageFile(repoPath string, filePath string) error {
+	return ga.UnstageFile(repoPath, filePath)
+}
+
+// GitActivityDiscardFile descarta mudanças locais de um arquivo.
+func (a *App) GitActivityDiscardFile(repoPath string, filePath string) error {
+	return ga.DiscardFile(repoPath, filePath)
+}
+
+// === Activity Digest Bindings (expostos ao Frontend) ===
+
+const (
+	activityDigestDefaultHours = 24
+	activityDigestMaxHours     = 24 * 14
+	activityDigestMaxPRs       = 50
+	activityDigestAITimeout    = 90 * time.Second
+)
+
+// digestActivityTypes são os eventos Git que entram no digest; commits já vêm do git log e
+// eventos de index/fetch são ruído para uma daily.
+var digestActivityTypes = map[ga.EventType]bool{
+	ga.EventTypeBranchCreated: true,
+	ga.EventTypeBranchChanged: true,
+	ga.EventTypeMerge:         true,
+}
+
+// GenerateActivityDigest monta o resumo das últimas sinceHours horas do workspace: commits
+// locais do usuário, eventos Git, PRs abertos/revisados, testes rodados nos terminais e tempo de foco.
+// Com summarize, a IA ativa gera também um resumo curto para a daily. Fontes que falham
+// viram avisos em Warnings em vez de erro.
+func (a *App) GenerateActivityDigest(workspaceID uint, sinceHours int, summarize bool) (digest.Digest, error) {
+	if a.db == nil {
+		return digest.Digest{}, fmt.Errorf("database not initialized")
+	}
+	if workspaceID == 0 {
+		return digest.Digest{}, fmt.Errorf("workspaceID is required")
+	}
+	ws, err := a.db.GetWorkspace(workspaceID)
+	if err != nil {
+		return digest.Digest{}, fmt.Errorf("workspace %d not found: %w", workspaceID, err)
+	}
+	if sinceHours <= 0 {
+		sinceHours = activityDigestDefaultHours
+	}
+	sinceHours = min(sinceHours, activityDigestMaxHours)
+
+	now := time.Now()
+	since := now.Add(-time.Duration(sinceHours) * time.Hour)
+	result := digest.Digest{
+		WorkspaceID:   ws.ID,
+		WorkspaceName: ws.Name,
+		Since:         since,
+		GeneratedAt:   now,
+	}
+
+	githubRepos := make([]string, 0, 2)
+	for _, repoPath := range a.workspaceRepoPaths(ws) {
+		repoName := filepath.Base(repoPath)
+		if a.gitPanel != nil {
+			commits, err := a.gitPanel.ListAuthoredCommits(a.requestContext(), repoPath, since)
+			if err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: não foi possível ler os commits (%v)", repoName, err))
+			}
+			for _, commit := range commits {
+				authoredAt, _ := time.Parse(time.RFC3339, commit.AuthoredAt)
+				result.Commits = append(result.Commits, digest.Commit{
+					Repo:       repoName,
+					Hash:       commit.Hash,
+					ShortHash:  commit.ShortHash,
+					Subject:    commit.Subject,
+					AuthoredAt: authoredAt,
+					Additions:  commit.Additions,
+					Deletions:  commit.Deletions,
+				})
+			}
+		}
+		if a.gitActivity != nil {
+			for _, event := range a.gitActivity.ListEvents(ga.ListOptions{Limit: 500, RepoPath: repoPath}) {
+				if !digestActivityTypes[event.Type] || event.Timestamp.Before(since) {
+					continue
+				}
+				result.Activity = append(result.Activity, digest.Activity{
+					Type:      string(event.Type),
+					Repo:      cmp.Or(event.RepoName, repoName),
+					Branch:    event.Branch,
+					Message:   event.Message,
+					Timestamp: event.Timestamp,
+				})
+			}
+		}
+		if a.awaitGitHub() {
+			if owner, repo, err := a.resolveGitPanelPROwnerRepo(repoPath); err == nil {
+				githubRepos = append(githubRepos, owner+"/"+repo)
+			}
+		}
+	}
+
+	// Sem repositório GitHub no workspace a busca traria PRs de qualquer lugar.
+	if a.awaitGitHub() && len(githubRepos) > 0 {
+		openedQuery, reviewedQuery := gh.PullRequestActivityQueries(githubRepos, since)
+		if opened, err := a.github.SearchPullRequests(a.requestContext(), openedQuery, activityDigestMaxPRs); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("GitHub: não foi possível listar PRs abertos (%v)", err))
+		} else {
+			result.PullRequestsOpened = digestPullRequests(opened.Items)
+		}
+		if reviewed, err := a.github.SearchPullRequests(a.requestContext(), reviewedQuery, activityDigestMaxPRs); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("GitHub: não foi possível listar PRs revisados (%v)", err))
+		} else {
+			result.PullRequestsReviewed = digestPullRequests(reviewed.Items)
+		}
+	}
+
+	if a.testRuns != nil {
+		for _, run := range a.testRuns.Runs(since, ws.ID) {
+			result.TestRuns = append(result.TestRuns, digest.TestRun{
+				Command:    run.Command,
+				Framework:  run.Framework,
+				Status:     run.Status,
+				Summary:    run.Summary,
+				StartedAt:  run.StartedAt,
+				FinishedAt: run.FinishedAt,
+			})
+		}
+	}
+
+	if a.focusTimer != nil {
+		for _, session := range a.focusTimer.Sessions(since, ws.ID) {
+			result.FocusSessions = append(result.FocusSessions, digest.FocusSession{
+				StartedAt: session.StartedAt,
+				EndedAt:   session.EndedAt,
+				Minutes:   session.FocusedSeconds / 60,
+				Completed: session.Completed,
+			})
+		}
+	}
+
+	result.Finalize()
+
+	if summarize {
+		if a.ai == nil {
+			result.Warnings = append(result.Warnings, "IA indisponível: resumo não gerado")
+			return result, nil
+		}
+		parent := a.ctx
+		if parent == nil {
+			parent = context.Background()
+		}
+		ctx, cancel := context.WithTimeout(parent, activityDigestAITimeout)
+		defer cancel()
+		summary, err := a.ai.Complete(ctx, digest.SummaryPrompt(result))
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("IA: resumo não gerado (%v)", err))
+		} else {
+			result.Summary = summary
+		}
+	}
+	return result, nil
+}
+
+func digestPullRequests(items []gh.DashboardPullRequest) []digest.PullRequest {
+	prs := make([]digest.PullRequest, 0, len(items))
+	for _, item := range items {
+		prs = append(prs, digest.PullRequest{
+			Repo:      item.Owner + "/" + item.Repo,
+			Number:    item.Number,
+			Title:     item.Title,
+			URL:       item.URL,
+			State:     item.State,
+			IsDraft:   item.IsDraft,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	return prs
+}
+
+// === Focus Timer Bindings (expostos ao Frontend) ===
+
+// FocusStart inicia um ciclo de foco (Pomodoro) no workspace. O progresso chega por
+// focus:tick e as trocas de fase por focus:phase_changed; com opts.DoNotDisturb os webhooks
+// de notificação ficam retidos até o fim de cada foco.
+func (a *App) FocusStart(workspaceID uint, opts focus.Options) (focus.State, error) {
+	if workspaceID == 0 {
+		return focus.State{}, fmt.Errorf("workspaceID is required")
+	}
+	if a.db != nil {
+		if _, err := a.db.GetWorkspace(workspaceID); err != nil {
+			return focus.State{}, fmt.Errorf("workspace %d not found: %w", workspaceID, err)
+		}
+	}
+	return a.focusTimer.Start(workspaceID, opts)
+}
+
+// FocusPause pausa o timer; o tempo pausado não conta como foco.
+func (a *App) FocusPause() (focus.State, error) {
+	return a.focusTimer.Pause()
+}
+
+// FocusResume retoma o timer pausado.
+func (a *App) FocusResume() (focus.State, error) {
+	return a.focusTimer.Resume()
+}
+
+// FocusStop encerra o ciclo e entrega as notificações retidas.
+func (a *App) FocusStop() focus.State {
+	return a.focusTimer.Stop()
+}
+
+// GetFocusState retorna o estado atual do timer (para a barra de status do terminal ao abrir).
+func (a *App) GetFocusState() focus.State {
+	return a.focusTimer.State()
+}
+
+// === GitPanel Bindings (expostos ao Frontend) ===
+
+func (a *App) requireGitPanelService() (*gp.Service, error) {
+	if a.gitPanel == nil {
+		return nil, gp.NewBindingError(
+			gp.CodeServiceUnavailable,
+			"Git Panel service indisponível.",
+			"O backend do Git Panel ainda não foi inicializado.",
+		)
+	}
+	return a.gitPanel, nil
+}
+
+func (a *App) normalizeGitPanelBindingError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return gp.NormalizeBindingError(err)
+}
+
+// GitPanelPreflight valida runtime e contexto de repositório antes das operações.
+func (a *App) GitPanelPreflight(repoPath string) (gp.PreflightResult, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.PreflightResult{}, err
+	}
+
+	result, preflightErr := svc.Preflight(repoPath)
+	if preflightErr != nil {
+		return gp.PreflightResult{}, a.normalizeGitPanelBindingError(preflightErr)
+	}
+	return result, nil
+}
+
+// GitPanelPRResolveRepository resolve owner/repo via origin e aceita fallback manual validado.
+func (a *App) GitPanelPRResolveRepository(repoPath, manualOwner, manualRepo string, allowTargetOverride bool) (GitPanelPRRepositoryTargetDTO, error) {
+	result := GitPanelPRRepositoryTargetDTO{}
+	normalizedRepoPath := strings.TrimSpace(repoPath)
+	if normalizedRepoPath == "" {
+		return result, gpr.NewBindingError(
+			gpr.CodeRepoPathRequired,
+			"repoPath obrigatorio para resolver destino de Pull Request.",
+			"Abra um repositorio Git valido antes de acessar a aba PRs.",
+		)
+	}
+
+	svc, svcErr := a.requireGitPanelService()
+	if svcErr != nil {
+		return result, gpr.NewBindingError(
+			gpr.CodeServiceUnavailable,
+			"Git Panel service indisponivel para resolver Pull Requests.",
+			"O backend do Git Panel ainda nao foi inicializado.",
+		)
+	}
+
+	preflight, preflightErr := svc.Preflight(normalizedRepoPath)
+	if preflightErr != nil {
+		if bindingErr := gp.AsBindingError(preflightErr); bindingErr != nil {
+			return result, gpr.NewBindingError(
+				gpr.CodeRepoUnavailable,
+				"Repositorio Git invalido para operacoes de Pull Request.",
+				fmt.Sprintf("%s (%s)", strings.TrimSpace(bindingErr.Message), strings.TrimSpace(bindingErr.Code)),
+			)
+		}
+		return result, gpr.NewBindingError(
+			gpr.CodeRepoUnavailable,
+			"Repositorio Git invalido para operacoes de Pull Request.",
+			preflightErr.Error(),
+		)
+	}
+
+	repoRoot := strings.TrimSpace(preflight.RepoRoot)
+	if repoRoot == "" {
+		repoRoot = strings.TrimSpace(preflight.RepoPath)
+	}
+	if repoRoot == "" {
+		repoRoot = filepath.Clean(normalizedRepoPath)
+	}
+
+	result.RepoPath = normalizedRepoPath
+	result.RepoRoot = repoRoot
+
+	manualProvided := strings.TrimSpace(manualOwner) != "" || strings.TrimSpace(manualRepo) != ""
+	var normalizedManualOwner string
+	var normalizedManualRepo string
+	if manualProvided {
+		var normalizeErr error
+		normalizedManualOwner, normalizedManualRepo, normalizeErr = gpr.NormalizeOwnerRepo(manualOwner, manualRepo)
+		if normalizeErr != nil {
+			return GitPanelPRRepositoryTargetDTO{}, gpr.NewBindingError(
+				gpr.CodeManualRepoInvalid,
+				"Fallback manual owner/repo invalido.",
+				normalizeErr.Error(),
+			)
+		}
+		result.ManualOwner = normalizedManualOwner
+		result.ManualRepo = normalizedManualRepo
+	}
+
+	remotes := a.listGitPanelPRRemotes(repoRoot)
+	for _, remote := range remotes {
+		if remote.Name == "origin" {
+			result.OriginOwner = remote.Owner
+			result.OriginRepo = remote.Repo
+		}
+	}
+	if len(remotes) > 0 {
+		result.Remotes = remotes
+	}
+
+	base, baseResolved := selectGitPanelPRBaseRemote(remotes)
+	if baseResolved {
+		if manualProvided && !gpr.SameOwnerRepo(base.Owner, base.Repo, normalizedManualOwner, normalizedManualRepo) {
+			// Um remote local com o owner/repo manual (ex.: o fork) nao exige confirmacao.
+			matched, matchedRemote := findGitPanelPRRemote(remotes, normalizedManualOwner, normalizedManualRepo)
+			if !matchedRemote && !allowTargetOverride {
+				return GitPanelPRRepositoryTargetDTO{}, gpr.NewBindingError(
+					gpr.CodeRepoTargetMismatch,
+					"Repositorio manual diverge do remote detectado.",
+					fmt.Sprintf("%s=%s/%s manual=%s/%s", base.Name, base.Owner, base.Repo, normalizedManualOwner, normalizedManualRepo),
+				)
+			}
+
+			result.Owner = normalizedManualOwner
+			result.Repo = normalizedManualRepo
+			result.Source = "manual"
+			result.BaseRemote = matched.Name
+			result.OverrideConfirmed = !matchedRemote
+			return result, nil
+		}
+
+		result.Owner = base.Owner
+		result.Repo = base.Repo
+		result.BaseRemote = base.Name
+		result.Source = "remote"
+		if base.Name == "origin" {
+			result.Source = "origin"
+		}
+		return result, nil
+	}
+
+	if manualProvided {
+		result.Owner = normalizedManualOwner
+		result.Repo = normalizedManualRepo
+		result.Source = "manual"
+		return result, nil
+	}
+
+	return GitPanelPRRepositoryTargetDTO{}, gpr.NewBindingError(
+		gpr.CodeRepoResolveFailed,
+		"Nao foi possivel resolver owner/repo via remotes do repositorio.",
+		"Informe owner/repo manualmente para continuar.",
+	)
+}
+
+// GitPanelPRSetBaseRemote lembra o remote base das PRs do repositorio (ex.: upstream num fork).
+// Remote vazio volta para a escolha automatica.
+func (a *App) GitPanelPRSetBaseRemote(repoPath string, remote string) (GitPanelPRRepositoryTargetDTO, error) {
+	if a.db == nil {
+		return GitPanelPRRepositoryTargetDTO{}, gpr.NewBindingError(
+			gpr.CodeServiceUnavailable,
+			"Banco de dados indisponivel para salvar o remote base.",
+			"O armazenamento local ainda nao foi inicializado.",
+		)
+	}
+	repoRoot, err := a.resolveGitPanelPRRepoRoot(repoPath)
+	if err != nil {
+		return GitPanelPRRepositoryTargetDTO{}, err
+	}
+
+	normalizedRemote := strings.TrimSpace(remote)
+	if normalizedRemote != "" {
+		known := false
+		for _, candidate := range a.listGitPanelPRRemotes(repoRoot) {
+			known = known || candidate.Name == normalizedRemote
+		}
+		if !known {
+			return GitPanelPRRepositoryTargetDTO{}, gpr.NewBindingError(
+				gpr.CodeValidationFailed,
+				"Remote base invalido.",
+				fmt.Sprintf(`O remote "%s" nao existe ou nao aponta para o GitHub.`, normalizedRemote),
+			)
+		}
+	}
+
+	if err := a.db.SetGitPRBaseRemote(findGitRepoRoot(repoRoot), normalizedRemote); err != nil {
+		return GitPanelPRRepositoryTargetDTO{}, gpr.NewBindingError(
+			gpr.CodeUnknown,
+			"Falha ao salvar o remote base.",
+			err.Error(),
+		)
+	}
+	return a.GitPanelPRResolveRepository(repoPath, "", "", false)
+}
+
+// GitPanelGetForkSyncStatus mostra quanto a branch padrao local e a do fork estao atras do upstream
+// (refs ja buscadas, sem rede).
+func (a *App) GitPanelGetForkSyncStatus(repoPath string) (gp.ForkSyncStatusDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.ForkSyncStatusDTO{}, err
+	}
+	upstream, fork, err := a.resolveGitPanelForkRemotes(repoPath)
+	if err != nil {
+		return gp.ForkSyncStatusDTO{}, err
+	}
+	status, statusErr := svc.GetForkSyncStatus(a.requestContext(), repoPath, upstream, fork)
+	if statusErr != nil {
+		return gp.ForkSyncStatusDTO{}, a.normalizeGitPanelBindingError(statusErr)
+	}
+	return status, nil
+}
+
+// GitPanelSyncForkWithUpstream busca o upstream e atualiza a branch padrao local por fast-forward
+// ou merge; o fork no GitHub acompanha no proximo push.
+func (a *App) GitPanelSyncForkWithUpstream(repoPath string) (gp.ForkSyncStatusDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.ForkSyncStatusDTO{}, err
+	}
+	upstream, fork, err := a.resolveGitPanelForkRemotes(repoPath)
+	if err != nil {
+		return gp.ForkSyncStatusDTO{}, err
+	}
+	status, syncErr := svc.SyncForkWithUpstream(a.requestContext(), repoPath, upstream, fork)
+	if syncErr != nil {
+		return gp.ForkSyncStatusDTO{}, a.normalizeGitPanelBindingError(syncErr)
+	}
+	return status, nil
+}
+
+// resolveGitPanelForkRemotes usa o remote base das PRs como upstream e o origin (ou outro remote
+// classificado como fork) como fork.
+func (a *App) resolveGitPanelForkRemotes(repoPath string) (string, string, error) {
+	target, err := a.GitPanelPRResolveRepository(repoPath, "", "", false)
+	if err != nil {
+		return "", "", a.normalizeGitPanelPRError(err)
+	}
+	upstream := target.BaseRemote
+	fork := ""
+	for _, remote := range target.Remotes {
+		if remote.Name == upstream {
+			continue
+		}
+		if remote.Name == "origin" {
+			fork = remote.Name
+			break
+		}
+		if fork == "" && remote.Role == "fork" {
+			fork = remote.Name
+		}
+	}
+	if upstream == "" || fork == "" {
+		return "", "", gp.NewBindingError(
+			gp.CodeCommandFailed,
+			"Repositorio sem par fork/upstream.",
+			"Adicione o repositorio original como remote (ex.: upstream) ao clone do fork.",
+		)
+	}
+	return upstream, fork, nil
+}
+
+// listGitPanelPRRemotes enumera os remotes GitHub do repositorio, classifica upstream/fork pela
+// API (repositorio pai) e marca o remote base preferido salvo.
+func (a *App) listGitPanelPRRemotes(repoRoot string) []GitPanelPRRemoteDTO {
+	output, err := runGitPanelPRCommand("-C", repoRoot, "remote", "-v")
+	if err != nil {
+		return nil
+	}
+	remotes := parseGitPanelPRRemotes(output)
+	if len(remotes) == 0 {
+		return remotes
+	}
+
+	preferred := ""
+	if a.db != nil {
+		preferred, _ = a.db.GetGitPRBaseRemote(findGitRepoRoot(repoRoot))
+	}
+	for i := range remotes {
+		remotes[i].Preferred = remotes[i].Name == preferred
+	}
+	classifyGitPanelPRRemotes(remotes, a.lookupGitPanelRemoteFork)
+	return remotes
+}
+
+// parseGitPanelPRRemotes le "git remote -v" (linhas de fetch) e mantem so remotes do GitHub.
+func parseGitPanelPRRemotes(output string) []GitPanelPRRemoteDTO {
+	remotes := []GitPanelPRRemoteDTO{}
+	seen := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[2] != "(fetch)" || seen[fields[0]] {
+			continue
+		}
+		owner, repo, ok := gpr.ParseGitHubRemoteURL(fields[1])
+		if !ok {
+			continue
+		}
+		seen[fields[0]] = true
+		remotes = append(remotes, GitPanelPRRemoteDTO{
+			Name:  fields[0],
+			URL:   fields[1],
+			Owner: owner,
+			Repo:  repo,
+			Role:  "unknown",
+		})
+	}
+	return remotes
+}
+
+// classifyGitPanelPRRemotes marca forks (com o pai) e upstreams. Sem resposta da API, o pai de um
+// fork conhecido e o remote chamado "upstream" contam como upstream.
+func classifyGitPanelPRRemotes(remotes []GitPanelPRRemoteDTO, lookup func(owner, repo string) *gh.RepositoryForkInfo) {
+	for i := range remotes {
+		info := lookup(remotes[i].Owner, remotes[i].Repo)
+		if info == nil {
+			continue
+		}
+		remotes[i].Role = "upstream"
+		if info.Fork {
+			remotes[i].Role = "fork"
+			remotes[i].ParentOwner = info.ParentOwner
+			remotes[i].ParentRepo = info.ParentRepo
+		}
+	}
+	for i := range remotes {
+		if remotes[i].Role != "unknown" {
+			continue
+		}
+		if remotes[i].Name == "upstream" {
+			remotes[i].Role = "upstream"
+			continue
+		}
+		for _, other := range remotes {
+			if other.Role == "fork" && gpr.SameOwnerRepo(other.ParentOwner, other.ParentRepo, remotes[i].Owner, remotes[i].Repo) {
+				remotes[i].Role = "upstream"
+				break
+			}
+		}
+	}
+}
+
+// selectGitPanelPRBaseRemote escolhe o remote base: o preferido salvo, o pai do origin (fork),
+// o remote "upstream", o origin e por fim o primeiro remote GitHub.
+func selectGitPanelPRBaseRemote(remotes []GitPanelPRRemoteDTO) (GitPanelPRRemoteDTO, bool) {
+	if len(remotes) == 0 {
+		return GitPanelPRRemoteDTO{}, false
+	}
+	byName := map[string]GitPanelPRRemoteDTO{}
+	for _, remote := range remotes {
+		if remote.Preferred {
+			return remote, true
+		}
+		byName[remote.Name] = remote
+	}
+	if origin, ok := byName["origin"]; ok && origin.Role == "fork" {
+		if parent, found := findGitPanelPRRemote(remotes, origin.ParentOwner, origin.ParentRepo); found {
+			return parent, true
+		}
+	}
+	if upstream, ok := byName["upstream"]; ok {
+		return upstream, true
+	}
+	if origin, ok := byName["origin"]; ok {
+		return origin, true
+	}
+	return remotes[0], true
+}
+
+func findGitPanelPRRemote(remotes []GitPanelPRRemoteDTO, owner, repo string) (GitPanelPRRemoteDTO, bool) {
+	for _, remote := range remotes {
+		if gpr.SameOwnerRepo(remote.Owner, remote.Repo, owner, repo) {
+			return remote, true
+		}
+	}
+	return GitPanelPRRemoteDTO{}, false
+}
+
+// lookupGitPanelRemoteFork consulta (com cache) se owner/repo e fork; nil sem GitHub ou em falha.
+func (a *App) lookupGitPanelRemoteFork(owner, repo string) *gh.RepositoryForkInfo {
+	if !a.awaitGitHub() {
+		return nil
+	}
+	key := strings.ToLower(owner + "/" + repo)
+	now := time.Now()
+
+	a.gitPanelAuthorMu.Lock()
+	if cached, ok := a.gitPanelRemoteForks[key]; ok && now.Before(cached.expiresAt) {
+		a.gitPanelAuthorMu.Unlock()
+		return cached.info
+	}
+	a.gitPanelAuthorMu.Unlock()
+
+	info, err := a.github.GetRepositoryForkInfo(a.requestContext(), owner, repo)
+	entry := gitPanelRemoteForkCacheEntry{info: info, expiresAt: now.Add(gitPanelRepoIdentityCacheTTL)}
+	if err != nil {
+		entry = gitPanelRemoteForkCacheEntry{expiresAt: now.Add(gitPanelAuthorMissTTL)}
+	}
+	a.gitPanelAuthorMu.Lock()
+	a.gitPanelRemoteForks[key] = entry
+	a.gitPanelAuthorMu.Unlock()
+	return entry.info
+}
+
+func (a *App) requireGitHubServiceForPRs() (*gh.Service, error) {
+	if !a.awaitGitHub() {
+		return nil, gpr.NewBindingError(
+			gpr.CodeServiceUnavailable,
+			"GitHub service indisponivel para operacoes de Pull Request.",
+			"Conecte uma conta GitHub valida antes de usar a aba PRs.",
+		)
+	}
+	return a.github, nil
+}
+
+func (a *App) normalizeGitPanelPRError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if bindingErr := gpr.AsBindingError(err); bindingErr != nil {
+		return bindingErr
+	}
+
+	var githubErr *gh.GitHubError
+	if errors.As(err, &githubErr) {
+		if githubErr.Type == "sso" {
+			return gpr.NewSSOBindingError(githubErr.SSOOrg, githubErr.SSOURL)
+		}
+		if githubErr.Type == "canceled" {
+			return gpr.NewCanceledBindingError(githubErr.Message)
+		}
+		details := strings.TrimSpace(githubErr.Message)
+		normalizedType := strings.TrimSpace(githubErr.Type)
+		if normalizedType != "" {
+			if details == "" {
+				details = "type=" + normalizedType
+			} else {
+				details = fmt.Sprintf("%s (type=%s)", details, normalizedType)
+			}
+		}
+		return gpr.NewHTTPBindingError(githubErr.StatusCode, details)
+	}
+
+	return gpr.NormalizeBindingError(err)
+}
+
+func (a *App) logGitPanelPROperationError(operation, owner, repo string, prNumber int, err error) {
+	if a == nil || err == nil {
+		return
+	}
+
+	sanitizedOperation := strings.TrimSpace(operation)
+	if sanitizedOperation == "" {
+		sanitizedOperation = "unknown"
+	}
+	sanitizedOwner := strings.TrimSpace(owner)
+	if sanitizedOwner == "" {
+		sanitizedOwner = "-"
+	}
+	sanitizedRepo := strings.TrimSpace(repo)
+	if sanitizedRepo == "" {
+		sanitizedRepo = "-"
+	}
+
+	bindingErr := gpr.NormalizeBindingError(err)
+	code := strings.TrimSpace(bindingErr.Code)
+	if code == "" {
+		code = gpr.CodeUnknown
+	}
+
+	log.Printf(
+		"[GitPanel][PR] operation=%s owner=%s repo=%s pr=%d code=%s message=%s details=%s",
+		sanitizedOperation,
+		sanitizedOwner,
+		sanitizedRepo,
+		prNumber,
+		code,
+		a.sanitizeForLogs(strings.TrimSpace(bindingErr.Message)),
+		a.sanitizeForLogs(strings.TrimSpace(bindingErr.Details)),
+	)
+}
+
+func (a *App) resolveGitPanelPROwnerRepo(repoPath string) (string, string, error) {
+	target, err := a.GitPanelPRResolveRepository(repoPath, "", "", false)
+	if err != nil {
+		return "", "", a.normalizeGitPanelPRError(err)
+	}
+
+	owner, repo, normalizeErr := gpr.NormalizeOwnerRepo(target.Owner, target.Repo)
+	if normalizeErr != nil {
+		return "", "", gpr.NewBindingError(
+			gpr.CodeRepoResolveFailed,
+			"Nao foi possivel validar owner/repo para operacoes de Pull Request.",
+			normalizeErr.Error(),
+		)
+	}
+
+	return owner, repo, nil
+}
+
+func (a *App) resolveGitPanelPRRepoRoot(repoPath string) (string, error) {
+	normalizedRepoPath := strings.TrimSpace(repoPath)
+	if normalizedRepoPath == "" {
+		return "", gpr.NewBindingError(
+			gpr.CodeRepoPathRequired,
+			"repoPath obrigatorio para operacoes de Pull Request.",
+			"Abra um repositorio Git valido antes de executar operacoes locais.",
+		)
+	}
+
+	svc, svcErr := a.requireGitPanelService()
+	if svcErr != nil {
+		return "", gpr.NewBindingError(
+			gpr.CodeServiceUnavailable,
+			"Git Panel service indisponivel para operacoes de Pull Request.",
+			"O backend do Git Panel ainda nao foi inicializado.",
+		)
+	}
+
+	preflight, preflightErr := svc.Preflight(normalizedRepoPath)
+	if preflightErr != nil {
+		if bindingErr := gp.AsBindingError(preflightErr); bindingErr != nil {
+			return "", gpr.NewBindingError(
+				gpr.CodeRepoUnavailable,
+				"Repositorio Git invalido para operacoes de Pull Request.",
+				fmt.Sprintf("%s (%s)", strings.TrimSpace(bindingErr.Message), strings.TrimSpace(bindingErr.Code)),
+			)
+		}
+		return "", gpr.NewBindingError(
+			gpr.CodeRepoUnavailable,
+			"Repositorio Git invalido para operacoes de Pull Request.",
+			preflightErr.Error(),
+		)
+	}
+
+	repoRoot := strings.TrimSpace(preflight.RepoRoot)
+	if repoRoot == "" {
+		repoRoot = strings.TrimSpace(preflight.RepoPath)
+	}
+	if repoRoot == "" {
+		repoRoot = filepath.Clean(normalizedRepoPath)
+	}
+
+	return repoRoot, nil
+}
+
+func normalizeGitPanelPRLocalRef(value string, fieldName string, required bool) (string, error) {
+	normalized := strings.TrimSpace(value)
+	if normalized == "" {
+		if required {
+			return "", gpr.NewBindingError(
+				gpr.CodeValidationFailed,
+				fmt.Sprintf(`Campo "%s" obrigatorio para criar branch local.`, fieldName),
+				fmt.Sprintf(`Campo "%s" deve ser preenchido.`, fieldName),
+			)
+		}
+		return "", nil
+	}
+
+	if strings.ContainsAny(normalized, " \t\r\n") {
+		return "", gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			fmt.Sprintf(`Campo "%s" invalido para criar branch local.`, fieldName),
+			fmt.Sprintf(`Campo "%s" nao pode conter espacos.`, fieldName),
+		)
+	}
+
+	return normalized, nil
+}
+
+// ensureGitPanelPRPushConfirmed aplica o guard de push do Git Panel à publicação de branch para PR.
+func (a *App) ensureGitPanelPRPushConfirmed(repoRoot string, branch string, confirm gp.PushConfirmationDTO) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gpr.NewBindingError(gpr.CodeServiceUnavailable, "Git Panel service indisponivel para validar push.", err.Error())
+	}
+
+	report, checkErr := svc.CheckPushSafety(repoRoot, gp.PushOptionsDTO{
+		Remote:            "origin",
+		Branch:            branch,
+		Confirm:           confirm,
+		ProtectedBranches: a.gitHubProtectedBranches(repoRoot),
+	})
+	if checkErr != nil {
+		details := checkErr.Error()
+		if bindingErr := gp.AsBindingError(checkErr); bindingErr != nil {
+			details = fmt.Sprintf("%s (%s)", strings.TrimSpace(bindingErr.Message), strings.TrimSpace(bindingErr.Code))
+		}
+		return gpr.NewBindingError(gpr.CodeValidationFailed, "Nao foi possivel validar a publicacao da branch.", details)
+	}
+	if !report.RequiresConfirmation {
+		return nil
+	}
+
+	pending := make([]string, 0, len(report.Violations))
+	for _, violation := range report.Violations {
+		if !violation.Confirmed {
+			pending = append(pending, violation.Kind+": "+violation.Message)
+		}
+	}
+	return gpr.NewBindingError(
+		gpr.CodePushNotConfirmed,
+		"Publicacao da branch exige confirmacao explicita.",
+		strings.Join(pending, "\n"),
+	)
+}
+
+func runGitPanelPRCommand(args ...string) (string, error) {
+	return runGitPanelPRCommandContext(context.Background(), gitPanelPRLocalBranchTimeout, args...)
+}
+
+// runGitPanelPRCommandContext permite cancelar pelo chamador e usar timeout maior (push).
+func runGitPanelPRCommandContext(parent context.Context, timeout time.Duration, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.WaitDelay = gitPanelPRCommandWaitDelay
+	rawOut, err := cmd.CombinedOutput()
+	output := strings.TrimSpace(string(rawOut))
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if output != "" {
+			return output, context.DeadlineExceeded
+		}
+		return "", context.DeadlineExceeded
+	}
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return output, context.Canceled
+	}
+	return output, err
+}
+
+func ensureGitPanelPRValidBranchName(repoRoot string, branch string) error {
+	output, err := runGitPanelPRCommand(
+		"-C", repoRoot,
+		"check-ref-format",
+		"--branch",
+		branch,
+	)
+	if err == nil {
+		return nil
+	}
+
+	details := strings.TrimSpace(output)
+	if details == "" {
+		details = err.Error()
+	}
+	return gpr.NewBindingError(
+		gpr.CodeValidationFailed,
+		"Nome de branch invalido para criacao local.",
+		details,
+	)
+}
+
+func resolveGitPanelPRLocalStartPoint(repoRoot string, base string) (string, error) {
+	candidates := []string{base}
+	if !strings.HasPrefix(base, "origin/") {
+		candidates = append(candidates, "origin/"+base)
+	}
+
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		_, err := runGitPanelPRCommand(
+			"-C", repoRoot,
+			"rev-parse",
+			"--verify",
+			"--quiet",
+			candidate,
+		)
+		if err == nil {
+			return candidate, nil
+		}
+	}
+
+	return "", gpr.NewBindingError(
+		gpr.CodeValidationFailed,
+		"Referencia base nao encontrada para criar branch local.",
+		fmt.Sprintf(`Nao foi possivel resolver a referencia "%s" localmente nem em origin/%s.`, base, base),
+	)
+}
+
+func normalizeGitPanelPRListState(state string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(state))
+	switch normalized {
+	case "", "open":
+		return "open", nil
+	case "closed":
+		return "closed", nil
+	case "all":
+		return "all", nil
+	default:
+		return "", gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Filtro de estado de Pull Request invalido.",
+			`Use "open", "closed" ou "all".`,
+		)
+	}
+}
+
+type normalizedGitPanelPRCreatePayload struct {
+	Input               gh.CreatePRInput
+	ManualOwner         string
+	ManualRepo          string
+	AllowTargetOverride bool
+	HeadRemote          string
+	Reviewers           []string
+	TeamReviewers       []string
+}
+
+func normalizeGitPanelPRCreatePayload(payload GitPanelPRCreatePayloadDTO) (normalizedGitPanelPRCreatePayload, error) {
+	normalizedTitle := strings.TrimSpace(payload.Title)
+	if normalizedTitle == "" {
+		return normalizedGitPanelPRCreatePayload{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Titulo da Pull Request obrigatorio.",
+			`Campo "title" deve ser preenchido.`,
+		)
+	}
+
+	normalizedHead := strings.TrimSpace(payload.Head)
+	if normalizedHead == "" {
+		return normalizedGitPanelPRCreatePayload{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Branch de origem obrigatoria para criar Pull Request.",
+			`Campo "head" deve ser preenchido.`,
+		)
+	}
+
+	normalizedBase := strings.TrimSpace(payload.Base)
+	if normalizedBase == "" {
+		return normalizedGitPanelPRCreatePayload{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Branch de destino obrigatoria para criar Pull Request.",
+			`Campo "base" deve ser preenchido.`,
+		)
+	}
+
+	input := gh.CreatePRInput{
+		Title:      normalizedTitle,
+		Body:       payload.Body,
+		HeadBranch: normalizedHead,
+		BaseBranch: normalizedBase,
+		IsDraft:    payload.Draft,
+	}
+	if payload.MaintainerCanModify != nil {
+		flag := *payload.MaintainerCanModify
+		input.MaintainerCanModify = &flag
+	}
+
+	normalizedManualOwner := strings.TrimSpace(payload.ManualOwner)
+	normalizedManualRepo := strings.TrimSpace(payload.ManualRepo)
+	hasManualOwner := normalizedManualOwner != ""
+	hasManualRepo := normalizedManualRepo != ""
+	if hasManualOwner != hasManualRepo {
+		return normalizedGitPanelPRCreatePayload{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Destino manual invalido para criar Pull Request.",
+			`Informe "manualOwner" e "manualRepo" juntos quando usar override manual.`,
+		)
+	}
+
+	if hasManualOwner {
+		owner, repo, normalizeErr := gpr.NormalizeOwnerRepo(normalizedManualOwner, normalizedManualRepo)
+		if normalizeErr != nil {
+			return normalizedGitPanelPRCreatePayload{}, gpr.NewBindingError(
+				gpr.CodeManualRepoInvalid,
+				"Destino manual invalido para criar Pull Request.",
+				normalizeErr.Error(),
+			)
+		}
+		normalizedManualOwner = owner
+		normalizedManualRepo = repo
+	}
+
+	return normalizedGitPanelPRCreatePayload{
+		Input:               input,
+		ManualOwner:         normalizedManualOwner,
+		ManualRepo:          normalizedManualRepo,
+		AllowTargetOverride: payload.AllowTargetOverride,
+		HeadRemote:          strings.TrimSpace(payload.HeadRemote),
+		Reviewers:           normalizeGitPanelPRReviewers(payload.Reviewers),
+		TeamReviewers:       normalizeGitPanelPRReviewers(payload.TeamReviewers),
+	}, nil
+}
+
+// normalizeGitPanelPRReviewers remove "@", prefixo org/ de times, vazios e repetidos.
+func normalizeGitPanelPRReviewers(values []string) []string {
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimPrefix(strings.TrimSpace(value), "@")
+		if idx := strings.LastIndex(value, "/"); idx >= 0 {
+			value = value[idx+1:]
+		}
+		if value != "" && !slices.ContainsFunc(normalized, func(existing string) bool { return strings.EqualFold(existing, value) }) {
+			normalized = append(normalized, value)
+		}
+	}
+	return normalized
+}
+
+func normalizeGitPanelPRCreateLabelPayload(payload GitPanelPRCreateLabelPayloadDTO) (gh.CreateLabelInput, error) {
+	normalizedName := strings.TrimSpace(payload.Name)
+	if normalizedName == "" {
+		return gh.CreateLabelInput{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Nome da etiqueta obrigatorio.",
+			`Campo "name" deve ser preenchido.`,
+		)
+	}
+
+	normalizedColor := strings.TrimSpace(payload.Color)
+	if normalizedColor == "" {
+		return gh.CreateLabelInput{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Cor da etiqueta obrigatoria.",
+			`Campo "color" deve ser preenchido com 6 caracteres hexadecimais.`,
+		)
+	}
+	if !gitPanelLabelColorRegex.MatchString(normalizedColor) {
+		return gh.CreateLabelInput{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Cor da etiqueta invalida.",
+			`Campo "color" deve usar formato hexadecimal de 6 caracteres (ex: #0e8a16).`,
+		)
+	}
+
+	normalizedColor = strings.TrimPrefix(normalizedColor, "#")
+	normalizedColor = strings.ToLower(normalizedColor)
+
+	var normalizedDescription *string
+	if payload.Description != nil {
+		description := strings.TrimSpace(*payload.Description)
+		if description != "" {
+			normalizedDescription = &description
+		}
+	}
+
+	return gh.CreateLabelInput{
+		Name:        normalizedName,
+		Color:       normalizedColor,
+		Description: normalizedDescription,
+	}, nil
+}
+
+func normalizeGitPanelPRUpdatePayload(payload GitPanelPRUpdatePayloadDTO) (gh.UpdatePRInput, error) {
+	input := gh.UpdatePRInput{}
+	hasField := false
+
+	if payload.Title != nil {
+		hasField = true
+		normalizedTitle := strings.TrimSpace(*payload.Title)
+		if normalizedTitle == "" {
+			return gh.UpdatePRInput{}, gpr.NewBindingError(
+				gpr.CodeValidationFailed,
+				"Titulo invalido para atualizacao de Pull Request.",
+				`Campo "title" nao pode ser vazio quando informado.`,
+			)
+		}
+		input.Title = &normalizedTitle
+	}
+
+	if payload.Body != nil {
+		hasField = true
+		body := *payload.Body
+		input.Body = &body
+	}
+
+	if payload.State != nil {
+		hasField = true
+		normalizedState := strings.ToLower(strings.TrimSpace(*payload.State))
+		switch normalizedState {
+		case "open", "closed":
+			input.State = &normalizedState
+		default:
+			return gh.UpdatePRInput{}, gpr.NewBindingError(
+				gpr.CodeValidationFailed,
+				"Estado invalido para atualizacao de Pull Request.",
+				`Campo "state" aceita apenas "open" ou "closed".`,
+			)
+		}
+	}
+
+	if payload.Base != nil {
+		hasField = true
+		normalizedBase := strings.TrimSpace(*payload.Base)
+		if normalizedBase == "" {
+			return gh.UpdatePRInput{}, gpr.NewBindingError(
+				gpr.CodeValidationFailed,
+				"Branch base invalida para atualizacao de Pull Request.",
+				`Campo "base" nao pode ser vazio quando informado.`,
+			)
+		}
+		input.BaseBranch = &normalizedBase
+	}
+
+	if payload.MaintainerCanModify != nil {
+		hasField = true
+		flag := *payload.MaintainerCanModify
+		input.MaintainerCanModify = &flag
+	}
+
+	if !hasField {
+		return gh.UpdatePRInput{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Payload de atualizacao de Pull Request vazio.",
+			`Informe ao menos um campo: "title", "body", "state", "base" ou "maintainerCanModify".`,
+		)
+	}
+
+	return input, nil
+}
+
+func normalizeGitPanelOptionalFullSHA(value *string, fieldName string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	normalized := strings.TrimSpace(*value)
+	if normalized == "" {
+		return nil, nil
+	}
+
+	if !gitPanelFullCommitHashRegex.MatchString(normalized) {
+		return nil, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			fmt.Sprintf(`Campo "%s" invalido para operacao de Pull Request.`, fieldName),
+			fmt.Sprintf(`Campo "%s" deve conter um SHA completo de 40 caracteres hexadecimais.`, fieldName),
+		)
+	}
+
+	normalized = strings.ToLower(normalized)
+	return &normalized, nil
+}
+
+func normalizeGitPanelPRMergePayload(payload GitPanelPRMergePayloadDTO) (gh.MergePRInput, error) {
+	normalizedMethod := strings.ToLower(strings.TrimSpace(payload.MergeMethod))
+	switch normalizedMethod {
+	case "", "merge":
+		normalizedMethod = "merge"
+	case "squash":
+		normalizedMethod = "squash"
+	case "rebase":
+		normalizedMethod = "rebase"
+	default:
+		return gh.MergePRInput{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Metodo de merge invalido para Pull Request.",
+			`Campo "mergeMethod" aceita apenas "merge", "squash" ou "rebase".`,
+		)
+	}
+
+	normalizedSHA, shaErr := normalizeGitPanelOptionalFullSHA(payload.SHA, "sha")
+	if shaErr != nil {
+		return gh.MergePRInput{}, shaErr
+	}
+
+	return gh.MergePRInput{
+		MergeMethod: gh.PRMergeMethod(normalizedMethod),
+		SHA:         normalizedSHA,
+	}, nil
+}
+
+func normalizeGitPanelPRUpdateBranchPayload(payload GitPanelPRUpdateBranchPayloadDTO) (gh.UpdatePRBranchInput, error) {
+	normalizedSHA, shaErr := normalizeGitPanelOptionalFullSHA(payload.ExpectedHeadSHA, "expectedHeadSha")
+	if shaErr != nil {
+		return gh.UpdatePRBranchInput{}, shaErr
+	}
+
+	return gh.UpdatePRBranchInput{
+		ExpectedHeadSHA: normalizedSHA,
+	}, nil
+}
+
+func (a *App) emitGitPanelPRMutationRefresh(owner, repo string, prNumber int, action string) {
+	if a == nil || a.ctx == nil {
+		return
+	}
+
+	normalizedOwner := strings.TrimSpace(owner)
+	normalizedRepo := strings.TrimSpace(repo)
+	if normalizedOwner == "" || normalizedRepo == "" {
+		return
+	}
+
+	normalizedAction := strings.ToLower(strings.TrimSpace(action))
+	if normalizedAction == "" {
+		normalizedAction = "updated"
+	}
+
+	runtime.EventsEmit(a.ctx, "github:prs:updated", map[string]interface{}{
+		"owner":  normalizedOwner,
+		"repo":   normalizedRepo,
+		"count":  1,
+		"source": "mutation",
+		"action": normalizedAction,
+		"changes": []map[string]interface{}{{
+			"number":     prNumber,
+			"changeType": normalizedAction,
+		}},
+	})
+}
+
+// GitPanelPRList retorna PRs do repositorio alvo com filtro e paginacao REST.
+func (a *App) GitPanelPRList(repoPath string, state string, page int, perPage int) ([]gh.PullRequest, error) {
+	normalizedState, stateErr := normalizeGitPanelPRListState(state)
+	if stateErr != nil {
+		return nil, stateErr
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return nil, resolveErr
+	}
+
+	items, err := githubService.ListPullRequests(a.requestContext(), owner, repo, gh.PRFilters{
+		State:   normalizedState,
+		Page:    page,
+		PerPage: perPage,
+	})
+	if err != nil {
+		return nil, a.normalizeGitPanelPRError(err)
+	}
+	if items == nil {
+		return []gh.PullRequest{}, nil
+	}
+
+	return items, nil
+}
+
+// GitPanelPRCreate cria um Pull Request no repositorio alvo via GitHub REST.
+func (a *App) GitPanelPRCreate(repoPath string, payload GitPanelPRCreatePayloadDTO) (gh.PullRequest, error) {
+	normalizedPayload, payloadErr := normalizeGitPanelPRCreatePayload(payload)
+	if payloadErr != nil {
+		return gh.PullRequest{}, payloadErr
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return gh.PullRequest{}, svcErr
+	}
+
+	createInput := normalizedPayload.Input
+	target, targetErr := a.GitPanelPRResolveRepository(
+		repoPath,
+		normalizedPayload.ManualOwner,
+		normalizedPayload.ManualRepo,
+		normalizedPayload.AllowTargetOverride,
+	)
+	if targetErr != nil {
+		return gh.PullRequest{}, a.normalizeGitPanelPRError(targetErr)
+	}
+
+	owner, repo, normalizeErr := gpr.NormalizeOwnerRepo(target.Owner, target.Repo)
+	if normalizeErr != nil {
+		return gh.PullRequest{}, gpr.NewBindingError(
+			gpr.CodeRepoResolveFailed,
+			"Nao foi possivel resolver owner/repo para criar Pull Request.",
+			normalizeErr.Error(),
+		)
+	}
+
+	head, headErr := resolveGitPanelPRCreateHead(target, createInput.HeadBranch, normalizedPayload.HeadRemote)
+	if headErr != nil {
+		return gh.PullRequest{}, headErr
+	}
+
+	createInput.Owner = owner
+	createInput.Repo = repo
+	createInput.HeadBranch = head
+
+	created, err := githubService.CreatePullRequest(a.requestContext(), createInput)
+	if err != nil {
+		normalizedErr := a.normalizeGitPanelPRError(err)
+		a.logGitPanelPROperationError("create", owner, repo, 0, normalizedErr)
+		return gh.PullRequest{}, normalizedErr
+	}
+	if created == nil {
+		return gh.PullRequest{}, nil
+	}
+
+	// O GitHub recusa o proprio autor como reviewer; falha aqui nao desfaz a PR criada.
+	reviewers := slices.DeleteFunc(slices.Clone(normalizedPayload.Reviewers), func(login string) bool {
+		return strings.EqualFold(login, created.Author.Login)
+	})
+	if err := githubService.RequestReviewers(a.requestContext(), owner, repo, created.Number, reviewers, normalizedPayload.TeamReviewers); err != nil {
+		a.logGitPanelPROperationError("request_reviewers", owner, repo, created.Number, a.normalizeGitPanelPRError(err))
+	}
+
+	a.emitGitPanelPRMutationRefresh(owner, repo, created.Number, "created")
+	return *created, nil
+}
+
+// resolveGitPanelPRCreateHead monta o head da PR. Quando a branch foi publicada num remote de outro
+// owner (fork), o GitHub exige "owner:branch"; heads ja qualificados passam direto.
+func resolveGitPanelPRCreateHead(target GitPanelPRRepositoryTargetDTO, head string, headRemote string) (string, error) {
+	if strings.Contains(head, ":") {
+		return head, nil
+	}
+
+	var remote GitPanelPRRemoteDTO
+	found := false
+	if headRemote != "" {
+		for _, candidate := range target.Remotes {
+			if candidate.Name == headRemote {
+				remote, found = candidate, true
+			}
+		}
+		if !found {
+			return "", gpr.NewBindingError(
+				gpr.CodeValidationFailed,
+				"Remote da branch head invalido.",
+				fmt.Sprintf(`O remote "%s" nao existe ou nao aponta para o GitHub.`, headRemote),
+			)
+		}
+	} else if target.RepoRoot != "" {
+		remote, found = gitPanelPRBranchPushRemote(target, head)
+	}
+
+	if !found || strings.EqualFold(remote.Owner, target.Owner) {
+		return head, nil
+	}
+	return remote.Owner + ":" + head, nil
+}
+
+// gitPanelPRBranchPushRemote descobre onde a branch local foi publicada: pushRemote/remote da
+// branch, remote.pushDefault e por fim origin (destino de GitPanelPRPushLocalBranch).
+func gitPanelPRBranchPushRemote(target GitPanelPRRepositoryTargetDTO, branch string) (GitPanelPRRemoteDTO, bool) {
+	names := []string{}
+	for _, key := range []string{"branch." + branch + ".pushRemote", "remote.pushDefault", "branch." + branch + ".remote"} {
+		if value, err := runGitPanelPRCommand("-C", target.RepoRoot, "config", "--get", key); err == nil && value != "" {
+			names = append(names, value)
+		}
+	}
+	names = append(names, "origin")
+	for _, name := range names {
+		for _, remote := range target.Remotes {
+			if remote.Name == name {
+				return remote, true
+			}
+		}
+	}
+	return GitPanelPRRemoteDTO{}, false
+}
+
+// GitPanelPRListTemplates lista os templates de descricao de PR do repositorio. Usa os
+// arquivos do clone e so consulta a API quando nao ha nenhum localmente.
+func (a *App) GitPanelPRListTemplates(repoPath string) ([]gpr.Template, error) {
+	repoRoot, err := a.resolveGitPanelPRRepoRoot(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	if local := gpr.FindLocalTemplates(repoRoot); len(local) > 0 {
+		return local, nil
+	}
+
+	// Sem conta GitHub ou remote fora do GitHub nao ha fonte remota; nao e erro.
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return []gpr.Template{}, nil
+	}
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return []gpr.Template{}, nil
+	}
+
+	remote, err := githubService.ListPullRequestTemplates(a.requestContext(), owner, repo)
+	if err != nil {
+		return nil, a.normalizeGitPanelPRError(err)
+	}
+	templates := make([]gpr.Template, 0, len(remote))
+	for _, tpl := range remote {
+		templates = append(templates, gpr.Template{
+			Name:   path.Base(tpl.Filename),
+			Path:   tpl.Filename,
+			Body:   tpl.Body,
+			Source: gpr.TemplateSourceRemote,
+		})
+	}
+	return templates, nil
+}
+
+// GitPanelPRPrefillCreate monta o payload de criacao com o template escolhido (templatePath
+// vazio = primeiro encontrado) e sugestoes "Fixes #N" tiradas do nome da branch head
+// (vazia = branch atual). Tickets Jira/Linear da branch viram referencias no corpo e, se for
+// um so, o titulo sugerido.
+func (a *App) GitPanelPRPrefillCreate(repoPath string, templatePath string, head string) (GitPanelPRCreatePayloadDTO, error) {
+	repoRoot, err := a.resolveGitPanelPRRepoRoot(repoPath)
+	if err != nil {
+		return GitPanelPRCreatePayloadDTO{}, err
+	}
+
+	head = strings.TrimSpace(head)
+	if head == "" {
+		if current, branchErr := runGitPanelPRCommand("-C", repoRoot, "branch", "--show-current"); branchErr == nil {
+			head = strings.TrimSpace(current)
+		}
+	}
+
+	templates, err := a.GitPanelPRListTemplates(repoPath)
+	if err != nil {
+		return GitPanelPRCreatePayloadDTO{}, err
+	}
+	body := ""
+	templatePath = strings.TrimSpace(templatePath)
+	switch {
+	case templatePath == "" && len(templates) > 0:
+		body = templates[0].Body
+	case templatePath != "":
+		idx := slices.IndexFunc(templates, func(tpl gpr.Template) bool {
+			return strings.EqualFold(tpl.Path, templatePath)
+		})
+		if idx < 0 {
+			return GitPanelPRCreatePayloadDTO{}, gpr.NewBindingError(
+				gpr.CodeValidationFailed,
+				"Template de Pull Request nao encontrado.",
+				fmt.Sprintf("Nenhum template em %q.", templatePath),
+			)
+		}
+		body = templates[idx].Body
+	}
+
+	linkSource := head
+	if a.issueTracker.Enabled() {
+		linkSource = issuetracker.StripBranchKeys(head, a.issueTracker.Projects())
+	}
+	payload := GitPanelPRCreatePayloadDTO{
+		Head: head,
+		Body: gpr.PrefillBody(body, gpr.SuggestIssueLinks(linkSource)),
+	}
+	if issues := a.suggestIssuesForPR(head); len(issues) > 0 {
+		payload.Body = issuetracker.AppendReferences(payload.Body, issues)
+		if len(issues) == 1 && issues[0].Title != "" {
+			payload.Title = issues[0].Key + ": " + issues[0].Title
+		}
+	}
+	// Base sugerida = branch padrao do remote; sem ela (ou sem CODEOWNERS) nao ha reviewers.
+	if base := gitPanelPRDefaultBase(repoRoot); base != "" && head != "" {
+		payload.Base = base
+		if report, err := a.GitPanelPRGetCodeOwners(repoPath, base, head); err == nil {
+			payload.Reviewers = report.SuggestedReviewers
+			payload.TeamReviewers = report.SuggestedTeams
+		}
+	}
+	return payload, nil
+}
+
+// GitPanelPRGetCodeOwners calcula os owners (CODEOWNERS) dos arquivos alterados entre base e
+// head (vazia = branch atual), os reviewers sugeridos e os caminhos sem dono.
+func (a *App) GitPanelPRGetCodeOwners(repoPath string, base string, head string) (gpr.CodeOwnersReport, error) {
+	repoRoot, err := a.resolveGitPanelPRRepoRoot(repoPath)
+	if err != nil {
+		return gpr.CodeOwnersReport{}, err
+	}
+	base = strings.TrimSpace(base)
+	if base == "" {
+		return gpr.CodeOwnersReport{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Branch de destino obrigatoria para calcular code owners.",
+			`Campo "base" deve ser preenchido.`,
+		)
+	}
+	head = strings.TrimSpace(head)
+	if head == "" {
+		head = "HEAD"
+	}
+
+	var files []string
+	var diffErr error
+	for _, baseRef := range []string{base, "origin/" + base} {
+		var output string
+		output, diffErr = runGitPanelPRCommand("-C", repoRoot, "-c", "core.quotePath=false", "diff", "--name-only", "--no-renames", baseRef+"..."+head, "--")
+		if diffErr == nil {
+			files = strings.FieldsFunc(output, func(r rune) bool { return r == '\n' })
+			break
+		}
+	}
+	if diffErr != nil {
+		return gpr.CodeOwnersReport{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Nao foi possivel listar os arquivos alterados da branch.",
+			fmt.Sprintf("git diff %s...%s: %v", base, head, diffErr),
+		)
+	}
+
+	codeOwners, found := a.loadGitPanelPRCodeOwners(repoPath, repoRoot, base)
+	return gpr.BuildCodeOwnersReport(codeOwners, found, files), nil
+}
+
+// GitPanelPRGetPullRequestCodeOwners calcula os owners dos arquivos de uma PR aberta, para
+// rotear o review; usa o CODEOWNERS da branch base da PR.
+func (a *App) GitPanelPRGetPullRequestCodeOwners(repoPath string, prNumber int) (gpr.CodeOwnersReport, error) {
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return gpr.CodeOwnersReport{}, svcErr
+	}
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return gpr.CodeOwnersReport{}, resolveErr
+	}
+	pr, err := githubService.GetPullRequest(a.requestContext(), owner, repo, prNumber)
+	if err != nil {
+		return gpr.CodeOwnersReport{}, a.normalizeGitPanelPRError(err)
+	}
+	if pr == nil {
+		return gpr.CodeOwnersReport{}, gpr.NewBindingError(gpr.CodeNotFound, "Pull Request nao encontrada.", fmt.Sprintf("#%d", prNumber))
+	}
+
+	files := []string{}
+	for page := 1; page > 0; {
+		filePage, err := githubService.GetPullRequestFiles(a.requestContext(), owner, repo, prNumber, page, 100)
+		if err != nil {
+			return gpr.CodeOwnersReport{}, a.normalizeGitPanelPRError(err)
+		}
+		for _, file := range filePage.Items {
+			files = append(files, file.Filename)
+		}
+		page = filePage.NextPage
+		if !filePage.HasNextPage {
+			page = 0
+		}
+	}
+
+	repoRoot, _ := a.resolveGitPanelPRRepoRoot(repoPath)
+	codeOwners, found := a.loadGitPanelPRCodeOwners(repoPath, repoRoot, pr.BaseBranch)
+	return gpr.BuildCodeOwnersReport(codeOwners, found, files), nil
+}
+
+// loadGitPanelPRCodeOwners le o CODEOWNERS como o GitHub: o da branch base (local ou do remote),
+// depois o da working tree e por fim o da API.
+func (a *App) loadGitPanelPRCodeOwners(repoPath string, repoRoot string, base string) (gpr.CodeOwners, bool) {
+	if repoRoot != "" {
+		if base != "" {
+			for _, baseRef := range []string{base, "origin/" + base} {
+				for _, candidate := range gpr.CodeOwnersPaths {
+					if content, err := runGitPanelPRCommand("-C", repoRoot, "show", baseRef+":"+candidate); err == nil {
+						return gpr.ParseCodeOwners(candidate, content), true
+					}
+				}
+			}
+		}
+		if local, ok := gpr.FindLocalCodeOwners(repoRoot); ok {
+			return local, true
+		}
+	}
+	if !a.awaitGitHub() {
+		return gpr.CodeOwners{}, false
+	}
+	owner, repo, err := a.resolveGitPanelPROwnerRepo(repoPath)
+	if err != nil {
+		return gpr.CodeOwners{}, false
+	}
+	remote, err := a.github.GetCodeOwnersFile(a.requestContext(), owner, repo, base)
+	if err != nil || remote == nil {
+		return gpr.CodeOwners{}, false
+	}
+	return gpr.ParseCodeOwners(remote.Path, remote.Content), true
+}
+
+// gitPanelPRDefaultBase retorna a branch padrao do origin (refs/remotes/origin/HEAD), se conhecida.
+func gitPanelPRDefaultBase(repoRoot string) string {
+	ref, err := runGitPanelPRCommand("-C", repoRoot, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(ref), "origin/")
+}
+
+// GitPanelPRCreateLabel cria uma label de repositorio a partir da aba de PR.
+func (a *App) GitPanelPRCreateLabel(repoPath string, payload GitPanelPRCreateLabelPayloadDTO) (gh.Label, error) {
+	normalizedPayload, payloadErr := normalizeGitPanelPRCreateLabelPayload(payload)
+	if payloadErr != nil {
+		return gh.Label{}, payloadErr
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return gh.Label{}, svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return gh.Label{}, resolveErr
+	}
+
+	normalizedPayload.Owner = owner
+	normalizedPayload.Repo = repo
+
+	created, err := githubService.CreateLabel(a.requestContext(), normalizedPayload)
+	if err != nil {
+		normalizedErr := a.normalizeGitPanelPRError(err)
+		a.logGitPanelPROperationError("create_label", owner, repo, 0, normalizedErr)
+		return gh.Label{}, normalizedErr
+	}
+	if created == nil {
+		return gh.Label{}, nil
+	}
+
+	return *created, nil
+}
+
+// GitPanelPRCreateLocalBranch cria e faz checkout de uma branch local sem usar API do GitHub.
+func (a *App) GitPanelPRCreateLocalBranch(repoPath string, branch string, base string) error {
+	repoRoot, repoErr := a.resolveGitPanelPRRepoRoot(repoPath)
+	if repoErr != nil {
+		return repoErr
+	}
+
+	normalizedBranch, branchErr := normalizeGitPanelPRLocalRef(branch, "branch", true)
+	if branchErr != nil {
+		return branchErr
+	}
+
+	normalizedBase, baseErr := normalizeGitPanelPRLocalRef(base, "base", false)
+	if baseErr != nil {
+		return baseErr
+	}
+
+	if validationErr := ensureGitPanelPRValidBranchName(repoRoot, normalizedBranch); validationErr != nil {
+		return validationErr
+	}
+	if policyErr := a.ensureGitPanelBranchNamingPolicy(repoRoot, normalizedBranch); policyErr != nil {
+		return policyErr
+	}
+
+	checkoutBase := normalizedBase
+	if checkoutBase != "" {
+		resolvedBase, resolveErr := resolveGitPanelPRLocalStartPoint(repoRoot, checkoutBase)
+		if resolveErr != nil {
+			return resolveErr
+		}
+		checkoutBase = resolvedBase
+	}
+
+	checkoutArgs := []string{"-C", repoRoot, "checkout", "-b", normalizedBranch}
+	if checkoutBase != "" {
+		checkoutArgs = append(checkoutArgs, checkoutBase)
+	}
+
+	output, checkoutErr := runGitPanelPRCommand(checkoutArgs...)
+	if checkoutErr == nil {
+		return nil
+	}
+
+	details := strings.TrimSpace(output)
+	loweredDetails := strings.ToLower(details)
+	if strings.Contains(loweredDetails, "already exists") {
+		switchOutput, switchErr := runGitPanelPRCommand("-C", repoRoot, "checkout", normalizedBranch)
+		if switchErr == nil {
+			return nil
+		}
+		switchDetails := strings.TrimSpace(switchOutput)
+		switch {
+		case details == "" && switchDetails != "":
+			details = switchDetails
+		case details != "" && switchDetails != "":
+			details = details + " | " + switchDetails
+		}
+		checkoutErr = switchErr
+	}
+
+	if details == "" {
+		details = checkoutErr.Error()
+	}
+
+	if errors.Is(checkoutErr, context.DeadlineExceeded) {
+		return gpr.NewBindingError(
+			gpr.CodeUnknown,
+			"Tempo limite excedido ao criar branch local.",
+			details,
+		)
+	}
+
+	return gpr.NewBindingError(
+		gpr.CodeConflict,
+		"Falha ao criar branch local para a Pull Request.",
+		details,
+	)
+}
+
+// GitPanelPRPushLocalBranch publica uma branch local no remote origin com upstream.
+func (a *App) GitPanelPRPushLocalBranch(repoPath string, branch string, confirm gp.PushConfirmationDTO) error {
+	repoRoot, normalizedBranch, err := a.prepareGitPanelPRLocalBranchPush(repoPath, branch)
+	if err != nil {
+		return err
+	}
+	return a.pushGitPanelPRLocalBranch(context.Background(), repoRoot, normalizedBranch, confirm)
+}
+
+// GitPanelPRPushLocalBranchCancelable é GitPanelPRPushLocalBranch abortável via CancelOperation(token).
+func (a *App) GitPanelPRPushLocalBranchCancelable(token string, repoPath string, branch string, confirm gp.PushConfirmationDTO) error {
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+
+	repoRoot, normalizedBranch, err := a.prepareGitPanelPRLocalBranchPush(repoPath, branch)
+	if err != nil {
+		return err
+	}
+	return a.pushGitPanelPRLocalBranch(ctx, repoRoot, normalizedBranch, confirm)
+}
+
+// prepareGitPanelPRLocalBranchPush valida repo e nome da branch antes do push (síncrono ou job).
+func (a *App) prepareGitPanelPRLocalBranchPush(repoPath string, branch string) (string, string, error) {
+	repoRoot, repoErr := a.resolveGitPanelPRRepoRoot(repoPath)
+	if repoErr != nil {
+		return "", "", repoErr
+	}
+
+	normalizedBranch, branchErr := normalizeGitPanelPRLocalRef(branch, "branch", true)
+	if branchErr != nil {
+		return "", "", branchErr
+	}
+
+	if validationErr := ensureGitPanelPRValidBranchName(repoRoot, normalizedBranch); validationErr != nil {
+		return "", "", validationErr
+	}
+	return repoRoot, normalizedBranch, nil
+}
+
+func (a *App) pushGitPanelPRLocalBranch(ctx context.Context, repoRoot string, normalizedBranch string, confirm gp.PushConfirmationDTO) error {
+
+	_, localLookupErr := runGitPanelPRCommand(
+		"-C", repoRoot,
+		"rev-parse",
+		"--verify",
+		"--quiet",
+		"refs/heads/"+normalizedBranch,
+	)
+	if localLookupErr != nil {
+		return gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Branch local nao encontrada para publicacao.",
+			fmt.Sprintf(`Crie a branch "%s" localmente antes de publicar no origin.`, normalizedBranch),
+		)
+	}
+
+	if guardErr := a.ensureGitPanelPRPushConfirmed(repoRoot, normalizedBranch, confirm); guardErr != nil {
+		return guardErr
+	}
+
+	pushOutput, pushErr := runGitPanelPRCommandContext(
+		ctx,
+		gitPanelPRPushTimeout,
+		"-C", repoRoot,
+		"push",
+		"-u",
+		"origin",
+		normalizedBranch,
+	)
+	if pushErr == nil {
+		return nil
+	}
+
+	details := strings.TrimSpace(pushOutput)
+	if details == "" {
+		details = pushErr.Error()
+	}
+
+	if errors.Is(pushErr, context.DeadlineExceeded) {
+		return gpr.NewBindingError(
+			gpr.CodeUnknown,
+			"Tempo limite excedido ao publicar branch local no origin.",
+			details,
+		)
+	}
+	if errors.Is(pushErr, context.Canceled) {
+		return gpr.NewCanceledBindingError(details)
+	}
+
+	return gpr.NewBindingError(
+		gpr.CodeConflict,
+		"Falha ao publicar branch local no origin.",
+		details,
+	)
+}
+
+// GitPanelPRUpdate atualiza um Pull Request existente no repositorio alvo via GitHub REST.
+func (a *App) GitPanelPRUpdate(repoPath string, prNumber int, payload GitPanelPRUpdatePayloadDTO) (gh.PullRequest, error) {
+	if prNumber <= 0 {
+		return gh.PullRequest{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Numero de Pull Request invalido.",
+			"Informe um numero de PR maior que zero.",
+		)
+	}
+
+	updateInput, payloadErr := normalizeGitPanelPRUpdatePayload(payload)
+	if payloadErr != nil {
+		return gh.PullRequest{}, payloadErr
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return gh.PullRequest{}, svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return gh.PullRequest{}, resolveErr
+	}
+
+	updateInput.Owner = owner
+	updateInput.Repo = repo
+	updateInput.Number = prNumber
+
+	updated, err := githubService.UpdatePullRequest(a.requestContext(), updateInput)
+	if err != nil {
+		normalizedErr := a.normalizeGitPanelPRError(err)
+		a.logGitPanelPROperationError("update", owner, repo, prNumber, normalizedErr)
+		return gh.PullRequest{}, normalizedErr
+	}
+	if updated == nil {
+		return gh.PullRequest{}, nil
+	}
+
+	a.emitGitPanelPRMutationRefresh(owner, repo, updated.Number, "updated")
+	return *updated, nil
+}
+
+// GitPanelPRCheckMerged verifica se a PR alvo ja foi mergeada.
+func (a *App) GitPanelPRCheckMerged(repoPath string, prNumber int) (bool, error) {
+	if prNumber <= 0 {
+		return false, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Numero de Pull Request invalido.",
+			"Informe um numero de PR maior que zero.",
+		)
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return false, svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return false, resolveErr
+	}
+
+	merged, err := githubService.CheckPullRequestMerged(a.requestContext(), owner, repo, prNumber)
+	if err != nil {
+		return false, a.normalizeGitPanelPRError(err)
+	}
+
+	return merged, nil
+}
+
+// GitPanelPRGetMergeRequirements resume protecao da branch, checks e reviews obrigatorios e o
+// mergeable_state da PR, listando o que bloqueia o merge.
+func (a *App) GitPanelPRGetMergeRequirements(repoPath string, prNumber int) (gh.MergeRequirements, error) {
+	if prNumber <= 0 {
+		return gh.MergeRequirements{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Numero de Pull Request invalido.",
+			"Informe um numero de PR maior que zero.",
+		)
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return gh.MergeRequirements{}, svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return gh.MergeRequirements{}, resolveErr
+	}
+
+	requirements, err := githubService.GetMergeRequirements(a.requestContext(), owner, repo, prNumber)
+	if err != nil {
+		return gh.MergeRequirements{}, a.normalizeGitPanelPRError(err)
+	}
+	if requirements == nil {
+		return gh.MergeRequirements{}, nil
+	}
+
+	return *requirements, nil
+}
+
+// GitPanelPRGetLinkedIssues lista as issues que a PR fecha ao ser mergeada.
+func (a *App) GitPanelPRGetLinkedIssues(repoPath string, prNumber int) ([]gh.LinkedIssue, error) {
+	if prNumber <= 0 {
+		return nil, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Numero de Pull Request invalido.",
+			"Informe um numero de PR maior que zero.",
+		)
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return nil, resolveErr
+	}
+
+	issues, err := githubService.GetLinkedIssues(a.requestContext(), owner, repo, prNumber)
+	if err != nil {
+		return nil, a.normalizeGitPanelPRError(err)
+	}
+	return issues, nil
+}
+
+// GitPanelPRGetIssueDevelopment retorna as PRs e branches vinculados a uma issue.
+func (a *App) GitPanelPRGetIssueDevelopment(repoPath string, issueNumber int) (gh.IssueDevelopment, error) {
+	if issueNumber <= 0 {
+		return gh.IssueDevelopment{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Numero de issue invalido.",
+			"Informe um numero de issue maior que zero.",
+		)
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return gh.IssueDevelopment{}, svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return gh.IssueDevelopment{}, resolveErr
+	}
+
+	development, err := githubService.GetIssueDevelopment(a.requestContext(), owner, repo, issueNumber)
+	if err != nil {
+		return gh.IssueDevelopment{}, a.normalizeGitPanelPRError(err)
+	}
+	if development == nil {
+		return gh.IssueDevelopment{}, nil
+	}
+	return *development, nil
+}
+
+// GitPanelPRMerge executa merge de PR no repositorio alvo via GitHub REST.
+func (a *App) GitPanelPRMerge(repoPath string, prNumber int, payload GitPanelPRMergePayloadDTO) (GitPanelPRMergeResultDTO, error) {
+	if prNumber <= 0 {
+		return GitPanelPRMergeResultDTO{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Numero de Pull Request invalido.",
+			"Informe um numero de PR maior que zero.",
+		)
+	}
+
+	mergeInput, payloadErr := normalizeGitPanelPRMergePayload(payload)
+	if payloadErr != nil {
+		return GitPanelPRMergeResultDTO{}, payloadErr
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return GitPanelPRMergeResultDTO{}, svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return GitPanelPRMergeResultDTO{}, resolveErr
+	}
+
+	mergeInput.Owner = owner
+	mergeInput.Repo = repo
+	mergeInput.Number = prNumber
+
+	result, err := githubService.MergePullRequestREST(a.requestContext(), mergeInput)
+	if err != nil {
+		normalizedErr := a.normalizeGitPanelPRError(err)
+		a.logGitPanelPROperationError("merge", owner, repo, prNumber, normalizedErr)
+		return GitPanelPRMergeResultDTO{}, normalizedErr
+	}
+	if result == nil {
+		return GitPanelPRMergeResultDTO{}, nil
+	}
+	if result.Merged {
+		a.notifyPRMerged(owner, repo, prNumber, strings.TrimSpace(result.SHA))
+	}
+
+	return GitPanelPRMergeResultDTO{
+		SHA:     strings.TrimSpace(result.SHA),
+		Merged:  result.Merged,
+		Message: strings.TrimSpace(result.Message),
+	}, nil
+}
+
+// GitPanelPRUpdateBranch solicita update da branch da PR alvo via GitHub REST.
+func (a *App) GitPanelPRUpdateBranch(repoPath string, prNumber int, payload GitPanelPRUpdateBranchPayloadDTO) (GitPanelPRUpdateBranchResultDTO, error) {
+	if prNumber <= 0 {
+		return GitPanelPRUpdateBranchResultDTO{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Numero de Pull Request invalido.",
+			"Informe um numero de PR maior que zero.",
+		)
+	}
+
+	updateInput, payloadErr := normalizeGitPanelPRUpdateBranchPayload(payload)
+	if payloadErr != nil {
+		return GitPanelPRUpdateBranchResultDTO{}, payloadErr
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return GitPanelPRUpdateBranchResultDTO{}, svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return GitPanelPRUpdateBranchResultDTO{}, resolveErr
+	}
+
+	updateInput.Owner = owner
+	updateInput.Repo = repo
+	updateInput.Number = prNumber
+
+	result, err := githubService.UpdatePullRequestBranch(a.requestContext(), updateInput)
+	if err != nil {
+		normalizedErr := a.normalizeGitPanelPRError(err)
+		a.logGitPanelPROperationError("update_branch", owner, repo, prNumber, normalizedErr)
+		return GitPanelPRUpdateBranchResultDTO{}, normalizedErr
+	}
+	if result == nil {
+		return GitPanelPRUpdateBranchResultDTO{}, nil
+	}
+
+	return GitPanelPRUpdateBranchResultDTO{
+		Message: strings.TrimSpace(result.Message),
+	}, nil
+}
+
+// GitPanelPRGet retorna detalhes do Pull Request alvo.
+func (a *App) GitPanelPRGet(repoPath string, prNumber int) (gh.PullRequest, error) {
+	if prNumber <= 0 {
+		return gh.PullRequest{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Numero de Pull Request invalido.",
+			"Informe um numero de PR maior que zero.",
+		)
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return gh.PullRequest{}, svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return gh.PullRequest{}, resolveErr
+	}
+
+	pr, err := githubService.GetPullRequest(a.requestContext(), owner, repo, prNumber)
+	if err != nil {
+		return gh.PullRequest{}, a.normalizeGitPanelPRError(err)
+	}
+	if pr == nil {
+		return gh.PullRequest{}, nil
+	}
+
+	return *pr, nil
+}
+
+// GitPanelPRGetCommits retorna commits paginados da PR alvo.
+func (a *App) GitPanelPRGetCommits(repoPath string, prNumber int, page int, perPage int) (gh.PRCommitPage, error) {
+	return a.gitPanelPRGetCommits(context.Background(), repoPath, prNumber, page, perPage)
+}
+
+// GitPanelPRGetCommitsCancelable é GitPanelPRGetCommits abortável via CancelOperation(token).
+func (a *App) GitPanelPRGetCommitsCancelable(token string, repoPath string, prNumber int, page int, perPage int) (gh.PRCommitPage, error) {
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+	return a.gitPanelPRGetCommits(ctx, repoPath, prNumber, page, perPage)
+}
+
+func (a *App) gitPanelPRGetCommits(ctx context.Context, repoPath string, prNumber int, page int, perPage int) (gh.PRCommitPage, error) {
+	if prNumber <= 0 {
+		return gh.PRCommitPage{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Numero de Pull Request invalido.",
+			"Informe um numero de PR maior que zero.",
+		)
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return gh.PRCommitPage{}, svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return gh.PRCommitPage{}, resolveErr
+	}
+
+	pageResult, err := githubService.GetPullRequestCommits(ctx, owner, repo, prNumber, page, perPage)
+	if err != nil {
+		return gh.PRCommitPage{}, a.normalizeGitPanelPRError(err)
+	}
+	if pageResult == nil {
+		return gh.PRCommitPage{}, nil
+	}
+
+	return *pageResult, nil
+}
+
+// GitPanelPRGetFiles retorna arquivos paginados da PR alvo.
+func (a *App) GitPanelPRGetFiles(repoPath string, prNumber int, page int, perPage int) (gh.PRFilePage, error) {
+	return a.gitPanelPRGetFiles(context.Background(), repoPath, prNumber, page, perPage)
+}
+
+// GitPanelPRGetFilesCancelable é GitPanelPRGetFiles abortável via CancelOperation(token).
+func (a *App) GitPanelPRGetFilesCancelable(token string, repoPath string, prNumber int, page int, perPage int) (gh.PRFilePage, error) {
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+	return a.gitPanelPRGetFiles(ctx, repoPath, prNumber, page, perPage)
+}
+
+func (a *App) gitPanelPRGetFiles(ctx context.Context, repoPath string, prNumber int, page int, perPage int) (gh.PRFilePage, error) {
+	if prNumber <= 0 {
+		return gh.PRFilePage{}, gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Numero de Pull Request invalido.",
+			"Informe um numero de PR maior que zero.",
+		)
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return gh.PRFilePage{}, svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return gh.PRFilePage{}, resolveErr
+	}
+
+	pageResult, err := githubService.GetPullRequestFiles(ctx, owner, repo, prNumber, page, perPage)
+	if err != nil {
+		return gh.PRFilePage{}, a.normalizeGitPanelPRError(err)
+	}
+	if pageResult == nil {
+		return gh.PRFilePage{}, nil
+	}
+
+	return *pageResult, nil
+}
+
+// GitPanelPRGetRawDiff retorna diff completo bruto da PR alvo sob demanda.
+func (a *App) GitPanelPRGetRawDiff(repoPath string, prNumber int) (string, error) {
+	return a.gitPanelPRGetRawDiff(context.Background(), repoPath, prNumber)
+}
+
+// GitPanelPRGetRawDiffCancelable é GitPanelPRGetRawDiff abortável via CancelOperation(token).
+func (a *App) GitPanelPRGetRawDiffCancelable(token string, repoPath string, prNumber int) (string, error) {
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+	return a.gitPanelPRGetRawDiff(ctx, repoPath, prNumber)
+}
+
+func (a *App) gitPanelPRGetRawDiff(ctx context.Context, repoPath string, prNumber int) (string, error) {
+	if prNumber <= 0 {
+		return "", gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Numero de Pull Request invalido.",
+			"Informe um numero de PR maior que zero.",
+		)
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return "", svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	rawDiff, err := githubService.GetPullRequestRawDiff(ctx, owner, repo, prNumber)
+	if err != nil {
+		return "", a.normalizeGitPanelPRError(err)
+	}
+
+	return rawDiff, nil
+}
+
+// GitPanelPRGetCommitRawDiff retorna diff bruto de um commit especifico da PR alvo.
+func (a *App) GitPanelPRGetCommitRawDiff(repoPath string, prNumber int, commitSHA string) (string, error) {
+	return a.gitPanelPRGetCommitRawDiff(context.Background(), repoPath, prNumber, commitSHA)
+}
+
+// GitPanelPRGetCommitRawDiffCancelable é GitPanelPRGetCommitRawDiff abortável via CancelOperation(token).
+func (a *App) GitPanelPRGetCommitRawDiffCancelable(token string, repoPath string, prNumber int, commitSHA string) (string, error) {
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+	return a.gitPanelPRGetCommitRawDiff(ctx, repoPath, prNumber, commitSHA)
+}
+
+func (a *App) gitPanelPRGetCommitRawDiff(ctx context.Context, repoPath string, prNumber int, commitSHA string) (string, error) {
+	if prNumber <= 0 {
+		return "", gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Numero de Pull Request invalido.",
+			"Informe um numero de PR maior que zero.",
+		)
+	}
+
+	normalizedSHA := strings.TrimSpace(commitSHA)
+	if normalizedSHA == "" {
+		return "", gpr.NewBindingError(
+			gpr.CodeValidationFailed,
+			"Commit SHA invalido.",
+			"Informe um commit SHA valido para carregar o diff.",
+		)
+	}
+
+	githubService, svcErr := a.requireGitHubServiceForPRs()
+	if svcErr != nil {
+		return "", svcErr
+	}
+
+	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	rawDiff, err := githubService.GetCommitRawDiff(ctx, owner, repo, normalizedSHA)
+	if err != nil {
+		return "", a.normalizeGitPanelPRError(err)
+	}
+
+	return rawDiff, nil
+}
+
+// GitPanelPickRepositoryDirectory abre o seletor nativo para escolher diretório do repositório.
+func (a *App) GitPanelPickRepositoryDirectory(defaultPath string) (string, error) {
+	if a.ctx == nil {
+		return "", gp.NewBindingError(
+			gp.CodeServiceUnavailable,
+			"Janela indisponível para seleção de diretório.",
+			"Contexto do runtime ainda não foi inicializado.",
+		)
+	}
+
+	defaultDirectory := resolveExistingDirectory(defaultPath)
+	selectedViaAppleScript, appleScriptErr := pickDirectoryWithAppleScript("Selecionar repositório Git", defaultDirectory)
+	if appleScriptErr == nil {
+		return strings.TrimSpace(selectedViaAppleScript), nil
+	}
+
+	options := runtime.OpenDialogOptions{
+		Title:                "Selecionar repositório Git",
+		ShowHiddenFiles:      true,
+		CanCreateDirectories: true,
+	}
+	if defaultDirectory != "" {
+		options.DefaultDirectory = defaultDirectory
+	}
+
+	selectedPath, err := runtime.OpenDirectoryDialog(a.ctx, options)
+	if err != nil {
+		details := strings.TrimSpace(err.Error())
+		if appleScriptErr != nil && !errors.Is(appleScriptErr, exec.ErrNotFound) {
+			details = fmt.Sprintf("apple script: %s | runtime: %s", strings.TrimSpace(appleScriptErr.Error()), details)
+		}
+		return "", gp.NewBindingError(
+			gp.CodeCommandFailed,
+			"Falha ao abrir seletor de diretório.",
+			details,
+		)
+	}
+
+	return strings.TrimSpace(selectedPath), nil
+}
+
+func resolveExistingDirectory(rawPath string) string {
+	trimmed := strings.TrimSpace(rawPath)
+	if trimmed == "" {
+		return ""
+	}
+
+	cleaned := filepath.Clean(trimmed)
+	info, err := os.Stat(cleaned)
+	if err == nil {
+		if info.IsDir() {
+			return cleaned
+		}
+		parent := filepath.Dir(cleaned)
+		if parentInfo, parentErr := os.Stat(parent); parentErr == nil && parentInfo.IsDir() {
+			return parent
+		}
+		return ""
+	}
+
+	parent := filepath.Dir(cleaned)
+	if parent == "" || parent == "." {
+		return ""
+	}
+	if parentInfo, parentErr := os.Stat(parent); parentErr == nil && parentInfo.IsDir() {
+		return parent
+	}
+	return ""
+}
+
+func pickDirectoryWithAppleScript(title string, defaultDirectory string) (string, error) {
+	if _, err := exec.LookPath("osascript"); err != nil {
+		return "", err
+	}
+
+	command := "set selectedFolder to choose folder"
+	trimmedTitle := strings.TrimSpace(title)
+	if trimmedTitle != "" {
+		command += " with prompt " + quoteAppleScriptString(trimmedTitle)
+	}
+
+	trimmedDefault := strings.TrimSpace(defaultDirectory)
+	if trimmedDefault != "" {
+		command += " default location POSIX file " + quoteAppleScriptString(trimmedDefault)
+	}
+
+	output, err := exec.Command(
+		"osascript",
+		"-e", command,
+		"-e", "POSIX path of selectedFolder",
+	).CombinedOutput()
+	if err != nil {
+		details := strings.TrimSpace(string(output))
+		lowerDetails := strings.ToLower(details)
+		if strings.Contains(lowerDetails, "user canceled") || strings.Contains(lowerDetails, "user cancelled") {
+			return "", nil
+		}
+		if details == "" {
+			details = strings.TrimSpace(err.Error())
+		}
+		return "", errors.New(details)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+func quoteAppleScriptString(value string) string {
+	escaped := strings.ReplaceAll(value, "\\", "\\\\")
+	escaped = strings.ReplaceAll(escaped, "\"", "\\\"")
+	return "\"" + escaped + "\""
+}
+
+// GitPanelGetStatus retorna snapshot de status staged/unstaged/conflicted.
+func (a *App) GitPanelGetStatus(repoPath string) (gp.StatusDTO, error) {
+	return a.GitPanelGetStatusDetail(repoPath, gp.DetailFull)
+}
+
+// GitPanelGetStatusDetail retorna o status com o nível de detalhe pedido ("summary" traz só
+// branch, ahead/behind e contadores, para refreshes frequentes como o badge da status bar).
+func (a *App) GitPanelGetStatusDetail(repoPath string, detail string) (gp.StatusDTO, error) {
+	return a.gitPanelGetStatusDetail(context.Background(), repoPath, detail)
+}
+
+// GitPanelGetStatusCancelable é GitPanelGetStatusDetail abortável via CancelOperation(token).
+func (a *App) GitPanelGetStatusCancelable(token string, repoPath string, detail string) (gp.StatusDTO, error) {
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+	return a.gitPanelGetStatusDetail(ctx, repoPath, detail)
+}
+
+func (a *App) gitPanelGetStatusDetail(ctx context.Context, repoPath string, detail string) (gp.StatusDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.StatusDTO{}, err
+	}
+
+	result, statusErr := svc.GetStatusWithDetailContext(ctx, repoPath, a.resolveGitPathScope(svc, repoPath), detail)
+	if statusErr != nil {
+		return gp.StatusDTO{}, a.normalizeGitPanelBindingError(statusErr)
+	}
+	return result, nil
+}
+
+// GitPanelGetHistory retorna página de histórico linear.
+func (a *App) GitPanelGetHistory(repoPath string, cursor string, limit int, query string) (gp.HistoryPageDTO, error) {
+	return a.GitPanelGetHistoryDetail(repoPath, cursor, limit, query, gp.DetailFull)
+}
+
+// GitPanelGetHistoryDetail retorna página de histórico com o nível de detalhe pedido;
+// "summary" dispensa numstat e a identidade GitHub dos autores.
+func (a *App) GitPanelGetHistoryDetail(repoPath string, cursor string, limit int, query string, detail string) (gp.HistoryPageDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.HistoryPageDTO{}, err
+	}
+
+	result, historyErr := svc.GetHistoryWithDetail(repoPath, cursor, limit, query, a.resolveGitPathScope(svc, repoPath), detail)
+	if historyErr != nil {
+		return gp.HistoryPageDTO{}, a.normalizeGitPanelBindingError(historyErr)
+	}
+	if result.Detail == gp.DetailSummary {
+		return result, nil
+	}
+
+	repoRoot := strings.TrimSpace(repoPath)
+	if preflight, preflightErr := svc.Preflight(repoPath); preflightErr == nil {
+		if normalized := strings.TrimSpace(preflight.RepoRoot); normalized != "" {
+			repoRoot = normalized
+		}
+	}
+
+	a.enrichGitPanelHistoryWithAuthIdentity(repoRoot, &result)
+	a.attachIssueLinksToHistory(&result)
+	return result, nil
+}
+
+// GitPanelGetHistoryCountEstimate retorna o total de commits do HEAD no escopo ativo,
+// para a UI mostrar o progresso da rolagem do histórico.
+func (a *App) GitPanelGetHistoryCountEstimate(repoPath string) (gp.HistoryCountEstimateDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.HistoryCountEstimateDTO{}, err
+	}
+	result, countErr := svc.GetHistoryCountEstimate(repoPath, a.resolveGitPathScope(svc, repoPath))
+	if countErr != nil {
+		return gp.HistoryCountEstimateDTO{}, a.normalizeGitPanelBindingError(countErr)
+	}
+	return result, nil
+}
+
+func (a *App) enrichGitPanelHistoryWithAuthIdentity(repoRoot string, page *gp.HistoryPageDTO) {
+	if page == nil || len(page.Items) == 0 {
+		return
+	}
+
+	a.applyLocalAuthIdentityToHistory(page)
+
+	pendingHashes := a.applyCachedGitHubAuthorsToHistory(repoRoot, page)
+	if len(pendingHashes) == 0 {
+		return
+	}
+
+	a.queueGitHubAuthorEnrichment(repoRoot, pendingHashes)
+}
+
+func (a *App) applyLocalAuthIdentityToHistory(page *gp.HistoryPageDTO) {
+	if page == nil || len(page.Items) == 0 || a.auth == nil {
+		return
+	}
+
+	user, err := a.auth.GetCurrentUser()
+	if err != nil || user == nil {
+		return
+	}
+	if !strings.EqualFold(strings.TrimSpace(user.Provider), "github") {
+		return
+	}
+
+	login := strings.TrimSpace(user.Username)
+	if login == "" {
+		login = strings.TrimSpace(user.Name)
+	}
+	avatarURL := strings.TrimSpace(user.AvatarURL)
+	if login == "" && avatarURL == "" {
+		return
+	}
+
+	emailToken := normalizeGitPanelIdentityToken(user.Email)
+	nameToken := normalizeGitPanelIdentityToken(user.Name)
+	usernameToken := normalizeGitPanelIdentityToken(user.Username)
+
+	for index := range page.Items {
+		item := &page.Items[index]
+		if !historyItemMatchesAuthUser(item, emailToken, nameToken, usernameToken) {
+			continue
+		}
+		item.GitHubLogin = login
+		item.GitHubAvatarURL = avatarURL
+	}
+}
+
+func (a *App) applyCachedGitHubAuthorsToHistory(repoRoot string, page *gp.HistoryPageDTO) []string {
+	if page == nil || len(page.Items) == 0 {
+		return nil
+	}
+
+	normalizedRoot := filepath.Clean(strings.TrimSpace(repoRoot))
+	if normalizedRoot == "" {
+		return nil
+	}
+
+	now := time.Now()
+	pending := make([]string, 0, gitPanelAuthorLookupPerRequest)
+
+	a.gitPanelAuthorMu.Lock()
+	defer a.gitPanelAuthorMu.Unlock()
+
+	for index := range page.Items {
+		item := &page.Items[index]
+		hash := normalizeGitPanelCommitHash(item.Hash)
+		if hash == "" {
+			continue
+		}
+		if strings.TrimSpace(item.GitHubLogin) != "" && strings.TrimSpace(item.GitHubAvatarURL) != "" {
+			continue
+		}
+
+		cacheKey := buildGitPanelCommitAuthorCacheKey(normalizedRoot, hash)
+		if cached, ok := a.gitPanelAuthorCache[cacheKey]; ok {
+			if now.After(cached.expiresAt) {
+				delete(a.gitPanelAuthorCache, cacheKey)
+			} else {
+				if cached.found {
+					if strings.TrimSpace(item.GitHubLogin) == "" {
+						item.GitHubLogin = cached.login
+					}
+					if strings.TrimSpace(item.GitHubAvatarURL) == "" {
+						item.GitHubAvatarURL = cached.avatarURL
+					}
+				}
+				continue
+			}
+		}
+
+		if len(pending) >= gitPanelAuthorLookupPerRequest {
+			continue
+		}
+		if _, inFlight := a.gitPanelAuthorInFlight[cacheKey]; inFlight {
+			continue
+		}
+
+		a.gitPanelAuthorInFlight[cacheKey] = struct{}{}
+		pending = append(pending, hash)
+	}
+
+	return pending
+}
+
+func (a *App) queueGitHubAuthorEnrichment(repoRoot string, hashes []string) {
+	normalizedRoot := filepath.Clean(strings.TrimSpace(repoRoot))
+	if normalizedRoot == "" || len(hashes) == 0 {
+		a.releaseGitPanelAuthorInFlight(normalizedRoot, hashes)
+		return
+	}
+	if !a.awaitGitHub() || a.auth == nil {
+		a.releaseGitPanelAuthorInFlight(normalizedRoot, hashes)
+		return
+	}
+
+	authState := a.auth.GetAuthState()
+	if authState == nil || !authState.IsAuthenticated || !authState.HasGitHubToken {
+		a.releaseGitPanelAuthorInFlight(normalizedRoot, hashes)
+		return
+	}
+
+	go a.resolveGitHubAuthorsForHistory(normalizedRoot, hashes)
+}
+
+func (a *App) resolveGitHubAuthorsForHistory(repoRoot string, hashes []string) {
+	defer a.releaseGitPanelAuthorInFlight(repoRoot, hashes)
+
+	owner, repo, ok := a.resolveGitHubOwnerRepo(repoRoot)
+	if !ok {
+		a.cacheGitPanelAuthorMisses(repoRoot, hashes)
+		return
+	}
+
+	resolvedAuthors, err := a.github.ResolveCommitAuthors(a.requestContext(), owner, repo, hashes)
+	if err != nil {
+		log.Printf("[ORCH][GitPanel] resolve commit authors failed repo=%s remote=%s/%s err=%v", repoRoot, owner, repo, err)
+		return
+	}
+
+	a.applyResolvedGitHubAuthors(repoRoot, hashes, resolvedAuthors)
+}
+
+func (a *App) applyResolvedGitHubAuthors(repoRoot string, requestedHashes []string, resolved map[string]gh.User) {
+	normalizedRoot := filepath.Clean(strings.TrimSpace(repoRoot))
+	if normalizedRoot == "" || len(requestedHashes) == 0 {
+		return
+	}
+
+	now := time.Now()
+	updates := make([]map[string]string, 0, len(requestedHashes))
+
+	a.gitPanelAuthorMu.Lock()
+	for _, rawHash := range requestedHashes {
+		hash := normalizeGitPanelCommitHash(rawHash)
+		if hash == "" {
+			continue
+		}
+
+		cacheKey := buildGitPanelCommitAuthorCacheKey(normalizedRoot, hash)
+		user, ok := resolved[hash]
+		if !ok {
+			a.gitPanelAuthorCache[cacheKey] = gitPanelCommitAuthorCacheEntry{
+				found:     false,
+				expiresAt: now.Add(gitPanelAuthorMissTTL),
+			}
+			continue
+		}
+
+		login := strings.TrimSpace(user.Login)
+		if login == "" {
+			a.gitPanelAuthorCache[cacheKey] = gitPanelCommitAuthorCacheEntry{
+				found:     false,
+				expiresAt: now.Add(gitPanelAuthorMissTTL),
+			}
+			continue
+		}
+
+		avatarURL := strings.TrimSpace(user.AvatarURL)
+		a.gitPanelAuthorCache[cacheKey] = gitPanelCommitAuthorCacheEntry{
+			login:     login,
+			avatarURL: avatarURL,
+			found:     true,
+			expiresAt: now.Add(gitPanelAuthorCacheTTL),
+		}
+
+		updates = append(updates, map[string]string{
+			"hash":            hash,
+			"githubLogin":     login,
+			"githubAvatarUrl": avatarURL,
+		})
+	}
+	a.gitPanelAuthorMu.Unlock()
+
+	if len(updates) == 0 || a.ctx == nil {
+		return
+	}
+
+	runtime.EventsEmit(a.ctx, "gitpanel:history_authors_enriched", map[string]interface{}{
+		"repoPath": normalizedRoot,
+		"items":    updates,
+	})
+}
+
+func (a *App) cacheGitPanelAuthorMisses(repoRoot string, hashes []string) {
+	normalizedRoot := filepath.Clean(strings.TrimSpace(repoRoot))
+	if normalizedRoot == "" || len(hashes) == 0 {
+		return
+	}
+
+	now := time.Now()
+	a.gitPanelAuthorMu.Lock()
+	defer a.gitPanelAuthorMu.Unlock()
+
+	for _, rawHash := range hashes {
+		hash := normalizeGitPanelCommitHash(rawHash)
+		if hash == "" {
+			continue
+		}
+		cacheKey := buildGitPanelCommitAuthorCacheKey(normalizedRoot, hash)
+		a.gitPanelAuthorCache[cacheKey] = gitPanelCommitAuthorCacheEntry{
+			found:     false,
+			expiresAt: now.Add(gitPanelAuthorMissTTL),
+		}
+	}
+}
+
+func (a *App) releaseGitPanelAuthorInFlight(repoRoot string, hashes []string) {
+	normalizedRoot := filepath.Clean(strings.TrimSpace(repoRoot))
+	if normalizedRoot == "" || len(hashes) == 0 {
+		return
+	}
+
+	a.gitPanelAuthorMu.Lock()
+	defer a.gitPanelAuthorMu.Unlock()
+
+	for _, rawHash := range hashes {
+		hash := normalizeGitPanelCommitHash(rawHash)
+		if hash == "" {
+			continue
+		}
+		cacheKey := buildGitPanelCommitAuthorCacheKey(normalizedRoot, hash)
+		delete(a.gitPanelAuthorInFlight, cacheKey)
+	}
+}
+
+func (a *App) resolveGitHubOwnerRepo(repoRoot string) (string, string, bool) {
+	normalizedRoot := filepath.Clean(strings.TrimSpace(repoRoot))
+	if normalizedRoot == "" {
+		return "", "", false
+	}
+
+	now := time.Now()
+
+	a.gitPanelAuthorMu.Lock()
+	if cached, ok := a.gitPanelRepoIdentity[normalizedRoot]; ok && now.Before(cached.expiresAt) {
+		a.gitPanelAuthorMu.Unlock()
+		if cached.owner != "" && cached.repo != "" {
+			return cached.owner, cached.repo, true
+		}
+		return "", "", false
+	}
+	a.gitPanelAuthorMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitPanelAuthorLookupTimeout)
+	defer cancel()
+
+	originOutput, err := exec.CommandContext(ctx, "git", "-C", normalizedRoot, "remote", "get-url", "origin").Output()
+	if err != nil {
+		a.gitPanelAuthorMu.Lock()
+		a.gitPanelRepoIdentity[normalizedRoot] = gitPanelRepoIdentityCacheEntry{
+			expiresAt: now.Add(gitPanelAuthorMissTTL),
+		}
+		a.gitPanelAuthorMu.Unlock()
+		return "", "", false
+	}
+
+	owner, repo, ok := gpr.ParseGitHubRemoteURL(string(originOutput))
+	a.gitPanelAuthorMu.Lock()
+	if ok {
+		a.gitPanelRepoIdentity[normalizedRoot] = gitPanelRepoIdentityCacheEntry{
+			owner:     owner,
+			repo:      repo,
+			expiresAt: now.Add(gitPanelRepoIdentityCacheTTL),
+		}
+	} else {
+		a.gitPanelRepoIdentity[normalizedRoot] = gitPanelRepoIdentityCacheEntry{
+			expiresAt: now.Add(gitPanelAuthorMissTTL),
+		}
+	}
+	a.gitPanelAuthorMu.Unlock()
+
+	return owner, repo, ok
+}
+
+func normalizeGitPanelCommitHash(value string) string {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if normalized == "" || !gitPanelCommitHashRegex.MatchString(normalized) {
+		return ""
+	}
+	return normalized
+}
+
+func buildGitPanelCommitAuthorCacheKey(repoRoot string, hash string) string {
+	root := filepath.Clean(strings.TrimSpace(repoRoot))
+	normalizedHash := normalizeGitPanelCommitHash(hash)
+	if root == "" || normalizedHash == "" {
+		return ""
+	}
+	return root + "|" + normalizedHash
+}
+
+func historyItemMatchesAuthUser(item *gp.HistoryItemDTO, emailToken string, nameToken string, usernameToken string) bool {
+	if item == nil {
+		return false
+	}
+
+	authorToken := normalizeGitPanelIdentityToken(item.Author)
+	authorEmailToken := normalizeGitPanelIdentityToken(item.AuthorEmail)
+
+	if emailToken != "" && authorEmailToken != "" && emailToken == authorEmailToken {
+		return true
+	}
+	if nameToken != "" && authorToken != "" && nameToken == authorToken {
+		return true
+	}
+	if usernameToken != "" && authorToken != "" && usernameToken == authorToken {
+		return true
+	}
+
+	return false
+}
+
+func normalizeGitPanelIdentityToken(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// GitPanelGetDiff retorna diff textual base para o frontend.
+func (a *App) GitPanelGetDiff(repoPath string, filePath string, mode string, contextLines int) (gp.DiffDTO, error) {
+	return a.GitPanelGetDiffWithOptions(repoPath, filePath, mode, contextLines, gp.DiffOptionsDTO{})
+}
+
+// GitPanelGetDiffWithOptions retorna o diff com enriquecimentos opcionais (ex: word diff).
+func (a *App) GitPanelGetDiffWithOptions(repoPath string, filePath string, mode string, contextLines int, options gp.DiffOptionsDTO) (gp.DiffDTO, error) {
+	return a.gitPanelGetDiff(context.Background(), repoPath, filePath, mode, contextLines, options)
+}
+
+// GitPanelGetDiffCancelable é GitPanelGetDiffWithOptions abortável via CancelOperation(token).
+func (a *App) GitPanelGetDiffCancelable(token string, repoPath string, filePath string, mode string, contextLines int, options gp.DiffOptionsDTO) (gp.DiffDTO, error) {
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+	return a.gitPanelGetDiff(ctx, repoPath, filePath, mode, contextLines, options)
+}
+
+func (a *App) gitPanelGetDiff(ctx context.Context, repoPath string, filePath string, mode string, contextLines int, options gp.DiffOptionsDTO) (gp.DiffDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.DiffDTO{}, err
+	}
+
+	result, diffErr := svc.GetDiffInScopeContext(ctx, repoPath, filePath, mode, contextLines, a.resolveGitPathScope(svc, repoPath))
+	if diffErr != nil {
+		return gp.DiffDTO{}, a.normalizeGitPanelBindingError(diffErr)
+	}
+	return gp.ApplyDiffOptions(result, options), nil
+}
+
+// GitPanelGetCommitDetails retorna detalhes de um commit específico (ex: lista de arquivos).
+func (a *App) GitPanelGetCommitDetails(repoPath string, commitHash string) (gp.CommitDetailsDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.CommitDetailsDTO{}, err
+	}
+	result, err := svc.GetCommitDetails(repoPath, commitHash)
+	if err != nil {
+		return gp.CommitDetailsDTO{}, a.normalizeGitPanelBindingError(err)
+	}
+	return result, nil
+}
+
+// GitPanelGetCommitDiff retorna diff textual de um arquivo em um commit específico.
+func (a *App) GitPanelGetCommitDiff(repoPath string, filePath string, commitHash string, contextLines int) (gp.DiffDTO, error) {
+	return a.GitPanelGetCommitDiffWithOptions(repoPath, filePath, commitHash, contextLines, gp.DiffOptionsDTO{})
+}
+
+// GitPanelGetCommitDiffWithOptions retorna o diff do commit com enriquecimentos opcionais.
+func (a *App) GitPanelGetCommitDiffWithOptions(repoPath string, filePath string, commitHash string, contextLines int, options gp.DiffOptionsDTO) (gp.DiffDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.DiffDTO{}, err
+	}
+	result, err := svc.GetCommitDiff(repoPath, filePath, commitHash, contextLines)
+	if err != nil {
+		return gp.DiffDTO{}, a.normalizeGitPanelBindingError(err)
+	}
+	return gp.ApplyDiffOptions(result, options), nil
+}
+
+// GitPanelGetIgnoreRules retorna o conteúdo e os padrões do .gitignore da raiz.
+func (a *App) GitPanelGetIgnoreRules(repoPath string) (gp.IgnoreRulesDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.IgnoreRulesDTO{}, err
+	}
+
+	result, rulesErr := svc.GetIgnoreRules(repoPath)
+	if rulesErr != nil {
+		return gp.IgnoreRulesDTO{}, a.normalizeGitPanelBindingError(rulesErr)
+	}
+	return result, nil
+}
+
+// GitPanelAddIgnorePattern acrescenta um padrão ao .gitignore (idempotente).
+func (a *App) GitPanelAddIgnorePattern(repoPath string, pattern string) (gp.IgnoreRulesDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.IgnoreRulesDTO{}, err
+	}
+
+	result, addErr := svc.AddIgnorePattern(repoPath, pattern)
+	if addErr != nil {
+		return gp.IgnoreRulesDTO{}, a.normalizeGitPanelBindingError(addErr)
+	}
+	return result, nil
+}
+
+// GitPanelSuggestIgnorePatterns sugere padrões a partir dos arquivos não rastreados.
+func (a *App) GitPanelSuggestIgnorePatterns(repoPath string) ([]gp.IgnoreSuggestionDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return nil, err
+	}
+
+	result, suggestErr := svc.SuggestIgnorePatterns(repoPath)
+	if suggestErr != nil {
+		return nil, a.normalizeGitPanelBindingError(suggestErr)
+	}
+	return result, nil
+}
+
+// GitPanelCheckIgnored informa se um caminho é ignorado e por qual regra.
+func (a *App) GitPanelCheckIgnored(repoPath string, filePath string) (gp.IgnoreCheckDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.IgnoreCheckDTO{}, err
+	}
+
+	result, checkErr := svc.CheckIgnored(repoPath, filePath)
+	if checkErr != nil {
+		return gp.IgnoreCheckDTO{}, a.normalizeGitPanelBindingError(checkErr)
+	}
+	return result, nil
+}
+
+// GitPanelGetFileHistory retorna o histórico paginado de um arquivo (segue renomeações).
+func (a *App) GitPanelGetFileHistory(repoPath string, filePath string, cursor string, limit int) (gp.FileHistoryPageDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.FileHistoryPageDTO{}, err
+	}
+
+	result, historyErr := svc.GetFileHistory(repoPath, filePath, cursor, limit)
+	if historyErr != nil {
+		return gp.FileHistoryPageDTO{}, a.normalizeGitPanelBindingError(historyErr)
+	}
+	return result, nil
+}
+
+// GitPanelCompareBranches mostra o que um PR de head para base conteria (git local, sem API).
+func (a *App) GitPanelCompareBranches(repoPath string, base string, head string) (gp.BranchComparisonDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.BranchComparisonDTO{}, err
+	}
+
+	result, compareErr := svc.CompareBranches(a.requestContext(), repoPath, base, head)
+	if compareErr != nil {
+		return gp.BranchComparisonDTO{}, a.normalizeGitPanelBindingError(compareErr)
+	}
+	return result, nil
+}
+
+// GitPanelGetRangeDiff compara duas revisões arbitrárias (toRev vazio = working tree).
+func (a *App) GitPanelGetRangeDiff(repoPath string, fromRev string, toRev string, filePath string) (gp.DiffDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.DiffDTO{}, err
+	}
+
+	result, diffErr := svc.GetRangeDiff(a.requestContext(), repoPath, fromRev, toRev, filePath, 0)
+	if diffErr != nil {
+		return gp.DiffDTO{}, a.normalizeGitPanelBindingError(diffErr)
+	}
+	return result, nil
+}
+
+// GitPanelChangelogPublishDTO define onde publicar o changelog gerado.
+type GitPanelChangelogPublishDTO struct {
+	WriteFile    bool   `json:"writeFile"`         // cria/atualiza o CHANGELOG.md da raiz
+	ReleaseDraft bool   `json:"releaseDraft"`      // cria/atualiza o rascunho de release no GitHub
+	TagName      string `json:"tagName,omitempty"` // tag da release; padrão é toRef quando é uma tag
+}
+
+// GitPanelChangelogPublishResultDTO representa o changelog publicado.
+type GitPanelChangelogPublishResultDTO struct {
+	Changelog gp.ChangelogDTO            `json:"changelog"`
+	File      *gp.ChangelogFileResultDTO `json:"file,omitempty"`
+	Release   *gh.Release                `json:"release,omitempty"`
+}
+
+// GitPanelGenerateChangelog agrupa os commits de fromRef..toRef por type Conventional Commits
+// (style: conventional | keepachangelog) e retorna o Markdown com os PRs linkados.
+func (a *App) GitPanelGenerateChangelog(repoPath string, fromRef string, toRef string, style string) (gp.ChangelogDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return gp.ChangelogDTO{}, err
+	}
+
+	result, genErr := svc.GenerateChangelog(a.requestContext(), repoPath, fromRef, toRef, style, a.changelogPRResolver(repoPath))
+	if genErr != nil {
+		return gp.ChangelogDTO{}, a.normalizeGitPanelBindingError(genErr)
+	}
+	return result, nil
+}
+
+// GitPanelPublishChangelog gera o changelog e o grava no CHANGELOG.md e/ou num rascunho de release.
+func (a *App) GitPanelPublishChangelog(repoPath string, fromRef string, toRef string, style string, options GitPanelChangelogPublishDTO) (GitPanelChangelogPublishResultDTO, error) {
+	if !options.WriteFile && !options.ReleaseDraft {
+		return GitPanelChangelogPublishResultDTO{}, fmt.Errorf("choose CHANGELOG.md and/or a release draft to publish")
+	}
+	changelog, err := a.GitPanelGenerateChangelog(repoPath, fromRef, toRef, style)
+	if err != nil {
+		return GitPanelChangelogPublishResultDTO{}, err
+	}
+	result := GitPanelChangelogPublishResultDTO{Changelog: changelog}
+
+	if options.ReleaseDraft {
+		tagName := strings.TrimSpace(options.TagName)
+		if tagName == "" && changelog.Version != gp.ChangelogUnreleased {
+			tagName = changelog.Version
+		}
+		if tagName == "" {
+			return result, fmt.Errorf("release draft requires a tag name when toRef is not a tag")
+		}
+		githubService, svcErr := a.requireGitHubServiceForPRs()
+		if svcErr != nil {
+			return result, svcErr
+		}
+		owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
+		if resolveErr != nil {
+			return result, resolveErr
+		}
+		release, releaseErr := githubService.UpsertReleaseDraft(a.requestContext(), gh.ReleaseDraftInput{
+			Owner:           owner,
+			Repo:            repo,
+			TagName:         tagName,
+			TargetCommitish: changelog.ToHash,
+			Body:            changelogReleaseBody(changelog.Markdown),
+		})
+		if releaseErr != nil {
+			normalizedErr := a.normalizeGitPanelPRError(releaseErr)
+			a.logGitPanelPROperationError("release_draft", owner, repo, 0, normalizedErr)
+			return result, normalizedErr
+		}
+		result.Release = release
+	}
+
+	if options.WriteFile {
+		svc, svcErr := a.requireGitPanelService()
+		if svcErr != nil {
+			return result, svcErr
+		}
+		file, writeErr := svc.WriteChangelogFile(repoPath, changelog)
+		if writeErr != nil {
+			return result, a.normalizeGitPanelBindingError(writeErr)
+		}
+		result.File = &file
+	}
+	return result, nil
+}
+
+// changelogPRResolver linka os PRs citados nos commits e completa título/autor com o cache do
+// GitHub (sem chamadas à API). Sem remoto GitHub os números ficam sem link.
+func (a *App) changelogPRResolver(repoPath string) gp.ChangelogPRResolver {
+	owner, repo, err := a.resolveGitPanelPROwnerRepo(repoPath)
+	if err != nil {
+		return nil
+	}
+	return func(number int) (gp.ChangelogPRDTO, bool) {
+		pr := gp.ChangelogPRDTO{Number: number, URL: fmt.Sprintf("https://github.com/%s/%s/pull/%d", owner, repo, number)}
+		if a.awaitGitHub() {
+			if cached, ok := a.github.GetCachedPullRequest(owner, repo, number); ok && cached != nil {
+				pr.Title = cached.Title
+				pr.Author = cached.Author.Login
+			}
+		}
+		return pr, true
+	}
+}
+
+// changelogReleaseBody remove o título da versão: a release já mostra a tag.
+func changelogReleaseBody(markdown string) string {
+	if strings.HasPrefix(markdown, "## ") {
+		if idx := strings.Index(markdown, "\n"); idx >= 0 {
+			markdown = markdown[idx+1:]
+		}
+	}
+	return strings.TrimSpace(markdown) + "\n"
+}
+
+// GitPanelGetConflicts retorna arquivos em estado de conflito.
+func (a *App) GitPanelGetConflicts(repoPath string) ([]gp.ConflictFileDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return nil, err
+	}
+
+	result, conflictsErr := svc.GetConflicts(repoPath)
+	if conflictsErr != nil {
+		return nil, a.normalizeGitPanelBindingError(conflictsErr)
+	}
+	return result, nil
+}
+
+// GitPanelStageFile adiciona arquivo ao stage.
+func (a *App) GitPanelStageFile(repoPath string, filePath string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	return a.normalizeGitPanelBindingError(svc.StageFile(repoPath, filePath))
+}
+
+// GitPanelStageFileCancelable é GitPanelStageFile abortável via CancelOperation(token).
+func (a *App) GitPanelStageFileCancelable(token string, repoPath string, filePath string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+	return a.normalizeGitPanelBindingError(svc.StageFileContext(ctx, repoPath, filePath))
+}
+
+// GitPanelUnstageFile remove arquivo do stage.
+func (a *App) GitPanelUnstageFile(repoPath string, filePath string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	return a.normalizeGitPanelBindingError(svc.UnstageFile(repoPath, filePath))
+}
+
+// GitPanelUnstageFileCancelable é GitPanelUnstageFile abortável via CancelOperation(token).
+func (a *App) GitPanelUnstageFileCancelable(token string, repoPath string, filePath string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+	return a.normalizeGitPanelBindingError(svc.UnstageFileContext(ctx, repoPath, filePath))
+}
+
+// GitPanelDiscardFile descarta alterações locais de um arquivo.
+func (a *App) GitPanelDiscardFile(repoPath string, filePath string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	return a.normalizeGitPanelBindingError(svc.DiscardFile(repoPath, filePath))
+}
+
+// GitPanelDiscardFileCancelable é GitPanelDiscardFile abortável via CancelOperation(token).
+func (a *App) GitPanelDiscardFileCancelable(token string, repoPath string, filePath string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+	return a.normalizeGitPanelBindingError(svc.DiscardFileContext(ctx, repoPath, filePath))
+}
+
+// GitPanelStagePatch aplica patch parcial no stage.
+func (a *App) GitPanelStagePatch(repoPath string, patchText string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	return a.normalizeGitPanelBindingError(svc.StagePatch(repoPath, patchText))
+}
+
+// GitPanelStagePatchCancelable é GitPanelStagePatch abortável via CancelOperation(token).
+func (a *App) GitPanelStagePatchCancelable(token string, repoPath string, patchText string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+	return a.normalizeGitPanelBindingError(svc.StagePatchContext(ctx, repoPath, patchText))
+}
+
+// GitPanelUnstagePatch remove patch parcial do stage.
+func (a *App) GitPanelUnstagePatch(repoPath string, patchText string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	return a.normalizeGitPanelBindingError(svc.UnstagePatch(repoPath, patchText))
+}
+
+// GitPanelUnstagePatchCancelable é GitPanelUnstagePatch abortável via CancelOperation(token).
+func (a *App) GitPanelUnstagePatchCancelable(token string, repoPath string, patchText string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	ctx, done := a.operations.Begin(a.ctx, token)
+	defer done()
+	return a.normalizeGitPanelBindingError(svc.UnstagePatchContext(ctx, repoPath, patchText))
+}
+
+// GitPanelAcceptOurs aplica resolução de conflito com versão local.
+func (a *App) GitPanelAcceptOurs(repoPath string, filePath string, autoStage bool) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	return a.normalizeGitPanelBindingError(svc.AcceptOurs(repoPath, filePath, autoStage))
+}
+
+// GitPanelAcceptTheirs aplica resolução de conflito com versão remota.
+func (a *App) GitPanelAcceptTheirs(repoPath string, filePath string, autoStage bool) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	return a.normalizeGitPanelBindingError(svc.AcceptTheirs(repoPath, filePath, autoStage))
+}
+
+// GitPanelOpenExternalMergeTool abre a ferramenta de merge do repositório (override > padrão > git mergetool).
+func (a *App) GitPanelOpenExternalMergeTool(repoPath string, filePath string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	settings, err := a.GitPanelGetExternalToolSettings(repoPath)
+	if err != nil {
+		return err
+	}
+	return a.normalizeGitPanelBindingError(svc.OpenExternalMergeToolWith(repoPath, filePath, settings.MergeTool))
+}
+
+// GitPanelOpenExternalDiffTool compara HEAD x working tree do arquivo na ferramenta de diff do repositório.
+func (a *App) GitPanelOpenExternalDiffTool(repoPath string, filePath string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	settings, err := a.GitPanelGetExternalToolSettings(repoPath)
+	if err != nil {
+		return err
+	}
+	return a.normalizeGitPanelBindingError(svc.OpenExternalDiffTool(repoPath, filePath, settings.DiffTool))
+}
+
+// GitPanelListExternalTools lista as ferramentas de diff/merge suportadas, marcando as instaladas.
+func (a *App) GitPanelListExternalTools() ([]gp.ExternalToolDTO, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return nil, err
+	}
+	return svc.ListExternalTools(), nil
+}
+
+// GitPanelTestExternalTool abre a ferramenta com arquivos de exemplo para validar a instalação.
+func (a *App) GitPanelTestExternalTool(toolID string) error {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return err
+	}
+	return a.normalizeGitPanelBindingError(svc.TestExternalTool(toolID))
+}
+
+// GitExternalToolSettings resume as ferramentas externas de um repositório (vazio = git mergetool/difftool).
+type GitExternalToolSettings struct {
+	DefaultMergeTool string `json:"defaultMergeTool"`
+	DefaultDiffTool  string `json:"defaultDiffTool"`
+	RepoMergeTool    string `json:"repoMergeTool"` // override do repositório; vazio herda o padrão
+	RepoDiffTool     string `json:"repoDiffTool"`
+	MergeTool        string `json:"mergeTool"` // efetivos
+	DiffTool         string `json:"diffTool"`
+}
+
+// GitPanelGetExternalToolSettings retorna o padrão global, o override do repositório e as ferramentas efetivas.
+func (a *App) GitPanelGetExternalToolSettings(repoPath string) (GitExternalToolSettings, error) {
+	var settings GitExternalToolSettings
+	if a.db == nil {
+		return settings, nil
+	}
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return settings, err
+	}
+	settings.DefaultMergeTool, settings.DefaultDiffTool = cfg.MergeTool, cfg.DiffTool
+	if repoRoot := findGitRepoRoot(strings.TrimSpace(repoPath)); strings.TrimSpace(repoPath) != "" && repoRoot != "" {
+		override, err := a.db.GetGitExternalToolSetting(repoRoot)
+		if err != nil {
+			return settings, err
+		}
+		if override != nil {
+			settings.RepoMergeTool, settings.RepoDiffTool = override.MergeTool, override.DiffTool
+		}
+	}
+	settings.MergeTool = cmp.Or(settings.RepoMergeTool, settings.DefaultMergeTool)
+	settings.DiffTool = cmp.Or(settings.RepoDiffTool, settings.DefaultDiffTool)
+	return settings, nil
+}
+
+// GitPanelSetDefaultExternalTools define as ferramentas de merge/diff padrão (ids de GitPanelListExternalTools).
+func (a *App) GitPanelSetDefaultExternalTools(mergeTool string, diffTool string) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	mergeTool, diffTool, err := normalizeExternalToolIDs(mergeTool, diffTool)
+	if err != nil {
+		return err
+	}
+	return a.db.SetExternalTools(mergeTool, diffTool)
+}
+
+// GitPanelSetRepoExternalTools sobrescreve as ferramentas do repositório; vazio herda o padrão global.
+func (a *App) GitPanelSetRepoExternalTools(repoPath string, mergeTool string, diffTool string) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	repoRoot := findGitRepoRoot(strings.TrimSpace(repoPath))
+	if strings.TrimSpace(repoPath) == "" || repoRoot == "" {
+		return fmt.Errorf("not a git repository: %s", repoPath)
+	}
+	mergeTool, diffTool, err := normalizeExternalToolIDs(mergeTool, diffTool)
+	if err != nil {
+		return err
+	}
+	return a.db.SaveGitExternalToolSetting(repoRoot, mergeTool, diffTool)
+}
+
+func normalizeExternalToolIDs(mergeTool string, diffTool string) (string, string, error) {
+	mergeTool = strings.ToLower(strings.TrimSpace(mergeTool))
+	diffTool = strings.ToLower(strings.TrimSpace(diffTool))
+	for _, id := range []string{mergeTool, diffTool} {
+		if !gp.IsKnownExternalTool(id) {
+			return "", "", fmt.Errorf("unknown external tool: %s", id)
+		}
+	}
+	return mergeTool, diffTool, nil
+}
+
+// === Polling Bindings (expostos ao Frontend) ===
+
+// StartPolling inicia polling inteligente para um repositório
+func (a *App) StartPolling(owner, repo string) {
+	if !a.waitGitHubStartup() || a.poller == nil {
+		return
+	}
+	a.poller.StartPolling(owner, repo)
+}
+
+// StopPolling para o polling
+func (a *App) StopPolling() {
+	if !a.waitGitHubStartup() || a.poller == nil {
+		return
+	}
+	a.poller.StopPolling()
+}
+
+// SetPollingContext atualiza o contexto de polling
+func (a *App) SetPollingContext(context string) {
+	if !a.waitGitHubStartup() || a.poller == nil {
+		return
+	}
+	a.poller.SetContext(gh.PollingContext(context))
+}
+
+// GetRateLimitInfo retorna informações do rate limit do GitHub
+func (a *App) GetRateLimitInfo() gh.RateLimitInfo {
+	if !a.waitGitHubStartup() || a.poller == nil {
+		return gh.RateLimitInfo{Remaining: 5000, Limit: 5000}
+	}
+	return a.poller.GetRateLimitInfo()
+}
+
+// GetGitHubWebhookBridgeStatus retorna o estado do bridge de webhooks (relay SSE).
+func (a *App) GetGitHubWebhookBridgeStatus() gh.WebhookBridgeStatus {
+	if !a.waitGitHubStartup() || a.ghWebhook == nil {
+		return gh.WebhookBridgeStatus{}
+	}
+	return a.ghWebhook.GetStatus()
+}
+
+// ConfigureGitHubWebhookBridge conecta a um relay SSE (smee.io, gosmee) que recebe os webhooks do GitHub.
+// Com o bridge conectado o polling recua; relayURL vazio volta ao polling normal.
+func (a *App) ConfigureGitHubWebhookBridge(relayURL string, secret string) (gh.WebhookBridgeStatus, error) {
+	if !a.waitGitHubStartup() || a.ghWebhook == nil {
+		return gh.WebhookBridgeStatus{}, fmt.Errorf("github service not initialized")
+	}
+	normalized, err := gh.ValidateWebhookRelayURL(relayURL)
+	if err != nil {
+		return gh.WebhookBridgeStatus{}, err
+	}
+	if a.db != nil {
+		if err := a.db.SetGitHubWebhookBridge(normalized, secret); err != nil {
+			return gh.WebhookBridgeStatus{}, err
+		}
+	}
+	if err := a.ghWebhook.Configure(normalized, secret); err != nil {
+		return gh.WebhookBridgeStatus{}, err
+	}
+	return a.ghWebhook.GetStatus(), nil
+}
+
+func (a *App) restoreGitHubWebhookBridge() {
+	if a.db == nil || !a.waitGitHubStartup() || a.ghWebhook == nil {
+		return
+	}
+	cfg, err := a.db.GetConfig()
+	if err != nil || strings.TrimSpace(cfg.GitHubWebhookRelay) == "" {
+		return
+	}
+	if err := a.ghWebhook.Configure(cfg.GitHubWebhookRelay, cfg.GitHubWebhookSecret); err != nil {
+		log.Printf("[ORCH] Could not restore GitHub webhook bridge: %v", err)
+	}
+}
+
+// === GitHub Multi-Account Bindings ===
+
+const gitHubAccountRoutesTTL = time.Minute
+
+// GitHubAccountBindingDTO descreve qual conta GitHub atende um repositório e por quê.
+type GitHubAccountBindingDTO struct {
+	RepoPath  string `json:"repoPath"`
+	Owner     string `json:"owner"`
+	AccountID string `json:"accountId"`         // vazio = conta ativa
+	Source    string `json:"source"`            // "repo" | "workspace" | "owner" | "active"
+	Login     string `json:"login,omitempty"`   // login da conta resolvida
+	Missing   bool   `json:"missing,omitempty"` // vínculo aponta para conta removida
+}
+
+type gitHubAccountRoute struct {
+	accountID string
+	source    string
+}
+
+// ListGitHubAccounts retorna as contas GitHub (primária + extras) com a conta ativa marcada.
+func (a *App) ListGitHubAccounts() []auth.GitHubAccount {
+	if a.auth == nil {
+		return []auth.GitHubAccount{}
+	}
+	return a.auth.ListGitHubAccounts()
+}
+
+// AddGitHubAccount abre o OAuth do GitHub para adicionar outra conta sem trocar a sessão principal.
+func (a *App) AddGitHubAccount() error {
+	if a.auth == nil {
+		return fmt.Errorf("auth service not initialized")
+	}
+
+	callbackHandler := func(result *auth.AuthResult) {
+		if result == nil || !result.Success {
+			message := "Failed to add GitHub account"
+			if result != nil && result.Error != "" {
+				message = result.Error
+			}
+			runtime.EventsEmit(a.ctx, "auth:error", message)
+			return
+		}
+		a.invalidateGitHubAccountRoutes()
+		runtime.EventsEmit(a.ctx, "github:accounts:changed", a.auth.ListGitHubAccounts())
+		runtime.WindowShow(a.ctx)
+	}
+
+	a.auth.BeginAddGitHubAccount()
+	if _, err := a.auth.StartCallbackServer(callbackHandler); err != nil {
+		a.auth.CancelAddGitHubAccount()
+		return fmt.Errorf("failed to start callback server: %w", err)
+	}
+	url, err := a.auth.GetAuthURL("github")
+	if err != nil {
+		a.auth.CancelAddGitHubAccount()
+		a.auth.StopCallbackServer()
+		return err
+	}
+	runtime.BrowserOpenURL(a.ctx, url)
+	return nil
+}
+
+// RemoveGitHubAccount remove uma conta extra; vínculos que apontavam para ela voltam à conta ativa.
+func (a *App) RemoveGitHubAccount(accountID string) ([]auth.GitHubAccount, error) {
+	if a.auth == nil {
+		return nil, fmt.Errorf("auth service not initialized")
+	}
+	if err := a.auth.RemoveGitHubAccount(accountID); err != nil {
+		return nil, err
+	}
+	a.invalidateGitHubAccountRoutes()
+	return a.emitGitHubAccountsChanged(), nil
+}
+
+// SwitchGitHubAccount troca a conta ativa (usada por repositórios sem vínculo); vazio volta à sessão principal.
+func (a *App) SwitchGitHubAccount(accountID string) ([]auth.GitHubAccount, error) {
+	if a.auth == nil {
+		return nil, fmt.Errorf("auth service not initialized")
+	}
+	if err := a.auth.SetActiveGitHubAccount(accountID); err != nil {
+		return nil, err
+	}
+	a.invalidateGitHubAccountRoutes()
+	return a.emitGitHubAccountsChanged(), nil
+}
+
+// SetRepoGitHubAccount vincula o repositório (owner do origin) a uma conta; vazio remove o vínculo.
+func (a *App) SetRepoGitHubAccount(repoPath string, accountID string) (GitHubAccountBindingDTO, error) {
+	if a.db == nil {
+		return GitHubAccountBindingDTO{}, fmt.Errorf("database not initialized")
+	}
+	repoRoot := findGitRepoRoot(strings.TrimSpace(repoPath))
+	if strings.TrimSpace(repoPath) == "" || repoRoot == "" {
+		return GitHubAccountBindingDTO{}, fmt.Errorf("not a git repository: %s", repoPath)
+	}
+	owner, _, ok := a.resolveGitHubOwnerRepo(repoRoot)
+	if !ok {
+		return GitHubAccountBindingDTO{}, fmt.Errorf("origin of %s is not a GitHub repository", repoRoot)
+	}
+	accountID = auth.NormalizeGitHubAccountID(accountID)
+	if accountID != "" && (a.auth == nil || !a.auth.HasGitHubAccount(accountID)) {
+		return GitHubAccountBindingDTO{}, fmt.Errorf("GitHub account not found: %s", accountID)
+	}
+	if err := a.db.SaveGitHubRepoAccount(filepath.Clean(repoRoot), owner, accountID); err != nil {
+		return GitHubAccountBindingDTO{}, err
+	}
+	a.invalidateGitHubAccountRoutes()
+	return a.ResolveGitHubAccount(repoRoot)
+}
+
+// SetWorkspaceGitHubAccount define a conta usada pelos repositórios do workspace; vazio usa a conta ativa.
+func (a *App) SetWorkspaceGitHubAccount(workspaceID uint, accountID string) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	accountID = auth.NormalizeGitHubAccountID(accountID)
+	if accountID != "" && (a.auth == nil || !a.auth.HasGitHubAccount(accountID)) {
+		return fmt.Errorf("GitHub account not found: %s", accountID)
+	}
+	if err := a.db.SetWorkspaceGitHubAccount(workspaceID, accountID); err != nil {
+		return err
+	}
+	a.invalidateGitHubAccountRoutes()
+	return nil
+}
+
+// ResolveGitHubAccount informa qual conta atende o repositório. O roteamento é por owner do origin:
+// vínculo de repositório > vínculo de workspace > conta com o mesmo login do owner > conta ativa.
+func (a *App) ResolveGitHubAccount(repoPath string) (GitHubAccountBindingDTO, error) {
+	repoRoot := findGitRepoRoot(strings.TrimSpace(repoPath))
+	if strings.TrimSpace(repoPath) == "" || repoRoot == "" {
+		return GitHubAccountBindingDTO{}, fmt.Errorf("not a git repository: %s", repoPath)
+	}
+	owner, _, ok := a.resolveGitHubOwnerRepo(repoRoot)
+	if !ok {
+		return GitHubAccountBindingDTO{}, fmt.Errorf("origin of %s is not a GitHub repository", repoRoot)
+	}
+
+	route := a.gitHubAccountForOwner(owner)
+	result := GitHubAccountBindingDTO{
+		RepoPath:  filepath.Clean(repoRoot),
+		Owner:     owner,
+		AccountID: route.accountID,
+		Source:    route.source,
+	}
+	for _, account := range a.ListGitHubAccounts() {
+		if (route.accountID == "" && account.Active) || account.ID == route.accountID {
+			result.Login = account.Login
+		}
+	}
+	result.Missing = route.accountID != "" && result.Login == ""
+	return result, nil
+}
+
+// AddGitHubPersonalAccessToken adiciona uma conta a partir de um PAT (clássico ou fine-grained).
+// O token é validado em GET /user e os escopos (X-OAuth-Scopes) e a expiração ficam registrados.
+func (a *App) AddGitHubPersonalAccessToken(token string) (auth.GitHubAccount, error) {
+	if a.auth == nil || !a.awaitGitHub() {
+		return auth.GitHubAccount{}, fmt.Errorf("github service not initialized")
+	}
+	token = strings.TrimSpace(token)
+	kind := gh.ClassifyToken(token)
+	if kind != gh.CredentialKindClassicPAT && kind != gh.CredentialKindFineGrainedPAT {
+		return auth.GitHubAccount{}, fmt.Errorf("not a personal access token; use AddGitHubAccount or AddGitHubAppInstallation")
+	}
+	info, err := a.github.IntrospectToken(token)
+	if err != nil {
+		return auth.GitHubAccount{}, err
+	}
+	if info.ExpiresAt != nil && time.Now().After(*info.ExpiresAt) {
+		return auth.GitHubAccount{}, fmt.Errorf("token expired on %s", info.ExpiresAt.Format("2006-01-02"))
+	}
+	account, err := a.auth.SaveGitHubCredential(auth.GitHubAccount{
+		Login:     info.Login,
+		Name:      info.Name,
+		AvatarURL: info.AvatarURL,
+		Kind:      info.Kind,
+		Scopes:    info.Scopes,
+		ExpiresAt: info.ExpiresAt,
+	}, token)
+	if err != nil {
+		return auth.GitHubAccount{}, err
+	}
+	a.invalidateGitHubAccountRoutes()
+	a.emitGitHubAccountsChanged()
+	return *account, nil
+}
+
+// AddGitHubAppInstallation adiciona uma instalação de GitHub App como credencial. A chave privada fica
+// no Keychain e tokens de instalação (1h) são emitidos sob demanda.
+func (a *App) AddGitHubAppInstallation(appID int64, installationID int64, privateKeyPEM string) (auth.GitHubAccount, error) {
+	if a.auth == nil || !a.awaitGitHub() {
+		return auth.GitHubAccount{}, fmt.Errorf("github service not initialized")
+	}
+	installation, err := a.github.CreateAppInstallationToken(appID, installationID, privateKeyPEM)
+	if err != nil {
+		return auth.GitHubAccount{}, err
+	}
+	account, err := a.auth.SaveGitHubCredential(auth.GitHubAccount{
+		Login:          installation.AccountLogin,
+		Name:           installation.AppSlug,
+		AvatarURL:      installation.AccountAvatarURL,
+		Kind:           auth.GitHubCredentialApp,
+		Permissions:    installation.Permissions,
+		AppID:          appID,
+		InstallationID: installationID,
+	}, privateKeyPEM)
+	if err != nil {
+		return auth.GitHubAccount{}, err
+	}
+	a.invalidateGitHubAccountRoutes()
+	a.emitGitHubAccountsChanged()
+	return *account, nil
+}
+
+// CheckGitHubCredentialPermissions avisa se a conta que atende o repositório não tem permissão para
+// a operação de PR ("read" | "write" | "merge").
+func (a *App) CheckGitHubCredentialPermissions(repoPath string, operation string) (gh.CredentialPermissionCheck, error) {
+	binding, err := a.ResolveGitHubAccount(repoPath)
+	if err != nil {
+		return gh.CredentialPermissionCheck{}, err
+	}
+
+	method, endpoint := http.MethodGet, "/repos/"+binding.Owner+"/_/pulls"
+	switch strings.ToLower(strings.TrimSpace(operation)) {
+	case "", "read":
+	case "write":
+		method = http.MethodPost
+	case "merge":
+		method, endpoint = http.MethodPut, endpoint+"/0/merge"
+	default:
+		return gh.CredentialPermissionCheck{}, fmt.Errorf("invalid pull request operation: %q", operation)
+	}
+
+	creds, ok := a.gitHubCredentialForOwner(binding.Owner)
+	if !ok {
+		return gh.CredentialPermissionCheck{}, fmt.Errorf("no GitHub credential available for %s", binding.Owner)
+	}
+	return gh.CheckCredentialPermissions(creds, method, endpoint), nil
+}
+
+// gitHubCredentialForOwner informa ao gh.Service o tipo/escopos da credencial roteada para o owner.
+func (a *App) gitHubCredentialForOwner(owner string) (gh.CredentialPermissions, bool) {
+	if a.auth == nil {
+		return gh.CredentialPermissions{}, false
+	}
+	account, ok := a.auth.GitHubCredentialForAccount(a.gitHubAccountForOwner(owner).accountID)
+	if !ok {
+		return gh.CredentialPermissions{}, false
+	}
+	return gh.CredentialPermissions{Kind: account.Kind, Scopes: account.Scopes, Permissions: account.Permissions}, true
+}
+
+func (a *App) mintGitHubAppToken(appID, installationID int64, privateKey string) (*auth.GitHubAppToken, error) {
+	if !a.awaitGitHub() {
+		return nil, fmt.Errorf("github service not initialized")
+	}
+	installation, err := a.github.CreateAppInstallationToken(appID, installationID, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.GitHubAppToken{
+		Token:       installation.Token,
+		ExpiresAt:   installation.ExpiresAt,
+		Permissions: installation.Permissions,
+	}, nil
+}
+
+func (a *App) emitGitHubAccountsChanged() []auth.GitHubAccount {
+	accounts := a.auth.ListGitHubAccounts()
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "github:accounts:changed", accounts)
+	}
+	return accounts
+}
+
+// gitHubTokenForOwner é o roteador de tokens do gh.Service.
+func (a *App) gitHubTokenForOwner(owner string) (string, error) {
+	if a.auth == nil {
+		return "", fmt.Errorf("auth service not initialized")
+	}
+	return a.auth.GitHubTokenForAccount(a.gitHubAccountForOwner(owner).accountID)
+}
+
+// refreshGitHubTokenForOwner renova o token da conta do owner após 401 do GitHub.
+func (a *App) refreshGitHubTokenForOwner(owner string) bool {
+	if a.auth == nil {
+		return false
+	}
+	if strings.TrimSpace(owner) == "" {
+		// Sem owner o GitHub service usa o token da sessão principal.
+		return a.auth.RefreshSessionAfterUnauthorized()
+	}
+	return a.auth.RefreshGitHubAccountToken(a.gitHubAccountForOwner(owner).accountID)
+}
+
+func (a *App) gitHubAccountForOwner(owner string) gitHubAccountRoute {
+	key := strings.ToLower(strings.TrimSpace(owner))
+	if route, ok := a.gitHubAccountRoutes()[key]; ok {
+		return route
+	}
+	if a.auth != nil && key != "" {
+		if accountID := a.auth.GitHubAccountIDForLogin(key); accountID != "" {
+			return gitHubAccountRoute{accountID: accountID, source: "owner"}
+		}
+	}
+	return gitHubAccountRoute{source: "active"}
+}
+
+// gitHubAccountRoutes monta (com cache curto) o mapa owner -> conta a partir dos vínculos persistidos.
+func (a *App) gitHubAccountRoutes() map[string]gitHubAccountRoute {
+	a.ghAccountMu.Lock()
+	if a.ghAccountRoutes != nil && time.Since(a.ghAccountRoutesAt) < gitHubAccountRoutesTTL {
+		routes := a.ghAccountRoutes
+		a.ghAccountMu.Unlock()
+		return routes
+	}
+	a.ghAccountMu.Unlock()
+
+	routes := make(map[string]gitHubAccountRoute)
+	if a.db != nil {
+		if bindings, err := a.db.ListGitHubRepoAccounts(); err == nil {
+			for _, binding := range bindings {
+				if key := strings.ToLower(strings.TrimSpace(binding.Owner)); key != "" {
+					routes[key] = gitHubAccountRoute{accountID: binding.AccountID, source: "repo"}
+				}
+			}
+		}
+		if workspaces, err := a.db.ListWorkspaces(); err == nil {
+			for idx := range workspaces {
+				ws := &workspaces[idx]
+				accountID := auth.NormalizeGitHubAccountID(ws.GitHubAccount)
+				if accountID == "" {
+					continue
+				}
+				for _, path := range a.workspaceRepoPaths(ws) {
+					owner, _, ok := a.resolveGitHubOwnerRepo(findGitRepoRoot(path))
+					key := strings.ToLower(owner)
+					if _, taken := routes[key]; ok && !taken {
+						routes[key] = gitHubAccountRoute{accountID: accountID, source: "workspace"}
+					}
+				}
+			}
+		}
+	}
+
+	a.ghAccountMu.Lock()
+	a.ghAccountRoutes = routes
+	a.ghAccountRoutesAt = time.Now()
+	a.ghAccountMu.Unlock()
+	return routes
+}
+
+func (a *App) invalidateGitHubAccountRoutes() {
+	a.ghAccountMu.Lock()
+	a.ghAccountRoutes = nil
+	a.ghAccountMu.Unlock()
+}
+
+// === Session / P2P Bindings (expostos ao Frontend) ===
+
+type sessionGatewayErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func normalizeSessionListenerAddr(raw, fallback string) string {
+	if candidate := strings.TrimSpace(raw); candidate != "" {
+		return candidate
+	}
+	return fallback
+}
+
+func shouldStartSessionListener(addr string) bool {
+	switch strings.ToLower(strings.TrimSpace(addr)) {
+	case "", "off", "disabled", "none", "false", "0":
+		return false
+	default:
+		return true
+	}
+}
+
+func sessionPublicHostFromListenAddr(addr string) string {
+	trimmed := strings.TrimSpace(addr)
+	if trimmed == "" {
+		return "127.0.0.1"
+	}
+	if strings.HasPrefix(trimmed, ":") {
+		return "127.0.0.1" + trimmed
+	}
+
+	host, port, err := net.SplitHostPort(trimmed)
+	if err != nil {
+		return trimmed
+	}
+
+	switch host {
+	case "", "0.0.0.0", "::", "[::]":
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+func normalizeSessionGatewayBaseURL(raw string, fallbackListenAddr string) string {
+	base := strings.TrimSpace(raw)
+	if base == "" {
+		base = "http://" + sessionPublicHostFromListenAddr(fallbackListenAddr)
+	}
+	if !strings.Contains(base, "://") {
+		base = "http://" + base
+	}
+
+	parsed, err := url.Parse(base)
+	if err != nil || parsed.Host == "" {
+		return defaultSessionGatewayBaseURL
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	if scheme != "http" && scheme != "https" {
+		scheme = "http"
+	}
+	parsed.Scheme = scheme
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return strings.TrimRight(parsed.String(), "/")
+}
+
+func normalizeSessionSignalingURL(raw string, fallbackListenAddr string) string {
+	base := strings.TrimSpace(raw)
+	if base == "" {
+		base = "ws://" + sessionPublicHostFromListenAddr(fallbackListenAddr) + "/ws/signal"
+	}
+	if !strings.Contains(base, "://") {
+		base = "ws://" + base
+	}
+
+	parsed, err := url.Parse(base)
+	if err != nil || parsed.Host == "" {
+		return defaultSessionSignalingBaseURL
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "http":
+		parsed.Scheme = "ws"
+	case "https":
+		parsed.Scheme = "wss"
+	case "ws", "wss":
+		// ok
+	default:
+		parsed.Scheme = "ws"
+	}
+
+	if parsed.Path == "" || parsed.Path == "/" {
+		parsed.Path = "/ws/signal"
+	}
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+func (a *App) configureSessionNetworking() {
+	// config.toml > env vars > padrão (resolvido pelo loader)
+	effective := a.effectiveConfig()
+	a.sessionGatewayAddr = normalizeSessionListenerAddr(effective.Value("session.gateway_listen_addr"), defaultSessionGatewayListenAddr)
+	a.sessionGatewayURL = normalizeSessionGatewayBaseURL(effective.Value("session.gateway_base_url"), a.sessionGatewayAddr)
+	a.signalingAddr = normalizeSessionListenerAddr(effective.Value("session.signaling_listen_addr"), defaultSessionSignalingListenAddr)
+	a.signalingURL = normalizeSessionSignalingURL(effective.Value("session.signaling_base_url"), a.signalingAddr)
+}
+
+func (a *App) resolvedSessionGatewayBaseURL() string {
+	if a != nil {
+		if custom := strings.TrimSpace(a.sessionGatewayURL); custom != "" {
+			return strings.TrimRight(custom, "/")
+		}
+	}
+	return defaultSessionGatewayBaseURL
+}
+
+func (a *App) resolvedSessionSignalingURL() string {
+	if a != nil {
+		if custom := strings.TrimSpace(a.signalingURL); custom != "" {
+			return custom
+		}
+	}
+	return defaultSessionSignalingBaseURL
+}
+
+// SessionGetSignalingURL retorna a URL base do signaling WebSocket.
+func (a *App) SessionGetSignalingURL() string {
+	return a.resolvedSessionSignalingURL()
+}
+
+func (a *App) callSessionGateway(method, path string, requestBody interface{}, responseBody interface{}) error {
+	var bodyReader io.Reader
+	if requestBody != nil {
+		payload, err := json.Marshal(requestBody)
+		if err != nil {
+			return fmt.Errorf("marshal gateway request: %w", err)
+		}
+		bodyReader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, a.resolvedSessionGatewayBaseURL()+path, bodyReader)
+	if err != nil {
+		return fmt.Errorf("create gateway request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := httpclient.NewClient(httpclient.ServiceGateway, 4*time.Second)
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("gateway request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var errPayload sessionGatewayErrorResponse
+		if decodeErr := json.NewDecoder(resp.Body).Decode(&errPayload); decodeErr == nil && errPayload.Error != "" {
+			return errors.New(errPayload.Error)
+		}
+		return fmt.Errorf("gateway request failed with status %d", resp.StatusCode)
+	}
+
+	if responseBody == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(responseBody); err != nil {
+		return fmt.Errorf("decode gateway response: %w", err)
+	}
+	return nil
+}
+
+func (a *App) gatewayJoinSession(code, guestUserID string, guestInfo session.GuestInfo) (*session.JoinResult, error) {
+	var result session.JoinResult
+	err := a.callSessionGateway(http.MethodPost, "/api/session/join", map[string]interface{}{
+		"code":        code,
+		"guestUserID": guestUserID,
+		"guestInfo":   guestInfo,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (a *App) gatewayCreateSession(hostUserID, hostName, hostAvatarURL string, cfg session.SessionConfig) (*session.Session, error) {
+	var result session.Session
+	err := a.callSessionGateway(http.MethodPost, "/api/session/create", map[string]interface{}{
+		"hostUserID":    hostUserID,
+		"hostName":      hostName,
+		"hostAvatarUrl": hostAvatarURL,
+		"config":        cfg,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (a *App) gatewayGetSession(sessionID string) (*session.Session, error) {
+	var result session.Session
+	path := "/api/session/get?sessionID=" + url.QueryEscape(sessionID)
+	if err := a.callSessionGateway(http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (a *App) gatewayGetActiveSession(userID string) (*session.Session, error) {
+	var result session.Session
+	path := "/api/session/active?userID=" + url.QueryEscape(userID)
+	if err := a.callSessionGateway(http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (a *App) gatewayListPendingGuests(sessionID string) ([]session.GuestRequest, error) {
+	var result []session.GuestRequest
+	path := "/api/session/pending?sessionID=" + url.QueryEscape(sessionID)
+	if err := a.callSessionGateway(http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (a *App) gatewayApproveGuest(sessionID, guestUserID string) error {
+	return a.callSessionGateway(http.MethodPost, "/api/session/approve", map[string]interface{}{
+		"sessionID":   sessionID,
+		"guestUserID": guestUserID,
+	}, nil)
+}
+
+func (a *App) gatewayRejectGuest(sessionID, guestUserID string) error {
+	return a.callSessionGateway(http.MethodPost, "/api/session/reject", map[string]interface{}{
+		"sessionID":   sessionID,
+		"guestUserID": guestUserID,
+	}, nil)
+}
+
+func (a *App) gatewayEndSession(sessionID string) error {
+	return a.callSessionGateway(http.MethodPost, "/api/session/end", map[string]interface{}{
+		"sessionID": sessionID,
+	}, nil)
+}
+
+func (a *App) gatewaySetGuestPermission(sessionID, guestUserID, permission string) error {
+	return a.callSessionGateway(http.MethodPost, "/api/session/permission", map[string]interface{}{
+		"sessionID":   sessionID,
+		"guestUserID": guestUserID,
+		"permission":  permission,
+	}, nil)
+}
+
+func (a *App) gatewaySetTimeoutPolicy(sessionID string, policy session.TimeoutPolicy) (*session.Session, error) {
+	var result session.Session
+	err := a.callSessionGateway(http.MethodPost, "/api/session/policy", map[string]interface{}{
+		"sessionID": sessionID,
+		"policy":    policy,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (a *App) gatewayTouchGuestActivity(sessionID, guestUserID string) error {
+	return a.callSessionGateway(http.MethodPost, "/api/session/activity", map[string]interface{}{
+		"sessionID":   sessionID,
+		"guestUserID": guestUserID,
+	}, nil)
+}
+
+func (a *App) gatewaySetCommandApproval(sessionID string, enabled bool) (*session.Session, error) {
+	var result session.Session
+	err := a.callSessionGateway(http.MethodPost, "/api/session/command-approval", map[string]interface{}{
+		"sessionID": sessionID,
+		"enabled":   enabled,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (a *App) gatewayKickGuest(sessionID, guestUserID string) error {
+	return a.callSessionGateway(http.MethodPost, "/api/session/kick", map[string]interface{}{
+		"sessionID":   sessionID,
+		"guestUserID": guestUserID,
+	}, nil)
+}
+
+func (a *App) gatewayRegenerateSessionCode(sessionID string) (*session.Session, error) {
+	var result session.Session
+	err := a.callSessionGateway(http.MethodPost, "/api/session/code/regenerate", map[string]interface{}{
+		"sessionID": sessionID,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (a *App) gatewayRevokeSessionCode(sessionID string) (*session.Session, error) {
+	var result session.Session
+	err := a.callSessionGateway(http.MethodPost, "/api/session/code/revoke", map[string]interface{}{
+		"sessionID": sessionID,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (a *App) gatewaySetAllowNewJoins(sessionID string, allow bool) (*session.Session, error) {
+	var result session.Session
+	err := a.callSessionGateway(http.MethodPost, "/api/session/allow-joins", map[string]interface{}{
+		"sessionID": sessionID,
+		"allow":     allow,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func isSessionNotFoundErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "session not found") || strings.Contains(msg, "session not found for code")
+}
+
+const genericSessionJoinCodeError = "código inválido ou expirado"
+
+func isSessionJoinCodeError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "invalid code format") ||
+		strings.Contains(msg, "session not found for code") ||
+		strings.Contains(msg, "session code has expired") ||
+		strings.Contains(msg, "too many invalid join attempts")
+}
+
+func sanitizeSessionJoinError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if isSessionJoinCodeError(err) {
+		return errors.New(genericSessionJoinCodeError)
+	}
+	return err
+}
+
+func sessionPersistUntil(sess *session.Session, now time.Time) time.Time {
+	if sess == nil {
+		return now.Add(30 * time.Minute)
+	}
+	if sess.Status == session.StatusWaiting {
+		if sess.ExpiresAt.After(now.Add(2 * time.Minute)) {
+			return sess.ExpiresAt
+		}
+		return now.Add(2 * time.Minute)
+	}
+	// Sessões ativas continuam válidas por uma janela curta pós-restart para retomada.
+	return now.Add(12 * time.Hour)
+}
+
+func (a *App) persistSessionState(sessionID string) {
+	if a.db == nil || a.session == nil || strings.TrimSpace(sessionID) == "" {
+		return
+	}
+
+	sess, err := a.session.GetSession(sessionID)
+	if err != nil {
+		return
+	}
+	if sess.Status == session.StatusEnded {
+		a.deletePersistedSessionState(sessionID)
+		return
+	}
+
+	now := time.Now()
+	if sess.Status == session.StatusWaiting && now.After(sess.ExpiresAt) {
+		a.deletePersistedSessionState(sessionID)
+		return
+	}
+
+	payload, err := json.Marshal(sess)
+	if err != nil {
+		log.Printf("[ORCH][SESSION] failed to marshal session state %s: %v", sessionID, err)
+		return
+	}
+
+	state := &database.CollabSessionState{
+		SessionID:    sess.ID,
+		HostUserID:   sess.HostUserID,
+		Code:         sess.Code,
+		Status:       string(sess.Status),
+		ExpiresAt:    sess.ExpiresAt,
+		PersistUntil: sessionPersistUntil(sess, now),
+		Payload:      string(payload),
+	}
+	if err := a.db.UpsertCollabSessionState(state); err != nil {
+		log.Printf("[ORCH][SESSION] failed to persist session state %s: %v", sessionID, err)
+	}
+}
+
+func (a *App) deletePersistedSessionState(sessionID string) {
+	if a.db == nil || strings.TrimSpace(sessionID) == "" {
+		return
+	}
+	if err := a.db.DeleteCollabSessionState(sessionID); err != nil {
+		log.Printf("[ORCH][SESSION] failed to delete persisted session state %s: %v", sessionID, err)
+	}
+}
+
+func (a *App) restorePersistedSessionStates() {
+	if a.db == nil || a.session == nil {
+		return
+	}
+
+	now := time.Now()
+	if err := a.db.CleanupExpiredCollabSessionStates(now); err != nil {
+		log.Printf("[ORCH][SESSION] cleanup persisted states failed: %v", err)
+	}
+
+	states, err := a.db.ListRestorableCollabSessionStates(now, 300)
+	if err != nil {
+		log.Printf("[ORCH][SESSION] failed to load persisted states: %v", err)
+		return
+	}
+
+	restoredCount := 0
+	for _, row := range states {
+		var restored session.Session
+		if err := json.Unmarshal([]byte(row.Payload), &restored); err != nil {
+			log.Printf("[ORCH][SESSION] invalid persisted payload sessionID=%s err=%v", row.SessionID, err)
+			a.deletePersistedSessionState(row.SessionID)
+			continue
+		}
+		if restored.ID == "" || restored.Status == session.StatusEnded {
+			a.deletePersistedSessionState(row.SessionID)
+			continue
+		}
+		if restored.Status == session.StatusWaiting && now.After(restored.ExpiresAt) {
+			a.deletePersistedSessionState(row.SessionID)
+			continue
+		}
+
+		if err := a.session.RestoreSession(&restored); err != nil {
+			log.Printf("[ORCH][SESSION] failed to restore session %s: %v", row.SessionID, err)
+			continue
+		}
+		restoredCount++
+	}
+
+	if restoredCount > 0 {
+		log.Printf("[ORCH][SESSION] restored %d session(s) from persistence", restoredCount)
+	}
+}
+
+// SessionCreate cria uma nova sessão de colaboração limitada a um workspace.
+func (a *App) SessionCreate(maxGuests int, mode string, allowAnonymous bool, workspaceID uint) (*session.Session, error) {
+	return a.SessionCreateWithImage(maxGuests, mode, allowAnonymous, workspaceID, "")
+}
+
+// SessionCreateWithImage cria uma sessão permitindo escolher a imagem Docker (modo docker).
+// Imagem vazia usa a imagem padrão do workspace, depois a stack default e por fim a detecção automática.
+func (a *App) SessionCreateWithImage(maxGuests int, mode string, allowAnonymous bool, workspaceID uint, dockerImage string) (*session.Session, error) {
+	requestedImage, err := normalizeDockerImageRef(dockerImage)
+	if err != nil {
+		return nil, err
+	}
+
+	hostUser, err := a.requireGitHubSessionUser()
+	if err != nil {
+		return nil, err
+	}
+
+	hostUserID := strings.TrimSpace(hostUser.ID)
+	hostName := strings.TrimSpace(hostUser.Name)
+	if hostName == "" {
+		hostName = hostUserID
+	}
+	hostAvatarURL := strings.TrimSpace(hostUser.AvatarURL)
+	allowAnonymous = false
+
+	if a.db == nil {
+		return nil, fmt.Errorf("database service not initialized")
+	}
+
+	var (
+		scopedWorkspace *database.Workspace
+	)
+	if workspaceID == 0 {
+		scopedWorkspace, err = a.db.GetActiveWorkspace()
+	} else {
+		scopedWorkspace, err = a.db.GetWorkspace(workspaceID)
+	}
+	if err != nil || scopedWorkspace == nil {
+		return nil, fmt.Errorf("workspace not found")
+	}
+
+	cfg := session.SessionConfig{
+		MaxGuests:      maxGuests,
+		DefaultPerm:    session.PermReadOnly,
+		AllowAnonymous: false,
+		Mode:           session.SessionMode(mode),
+		WorkspaceID:    scopedWorkspace.ID,
+		WorkspaceName:  strings.TrimSpace(scopedWorkspace.Name),
+		Policy:         a.GetSessionTimeoutPolicyDefaults(),
+	}
+	if cfg.WorkspaceName == "" {
+		cfg.WorkspaceName = fmt.Sprintf("Workspace %d", scopedWorkspace.ID)
+	}
+
+	if cfg.Mode == "" {
+		cfg.Mode = session.ModeLiveShare
+	}
+	if cfg.Mode != session.ModeDocker && cfg.Mode != session.ModeLiveShare {
+		cfg.Mode = session.ModeLiveShare
+	}
+
+	var (
+		containerID  string
+		containerCfg docker.ContainerConfig
+		route        *egressRoute
+	)
+	// Desfaz o que já foi criado para o container se a sessão não chegar a existir.
+	releaseSandbox := func() {
+		if containerID != "" && a.docker != nil {
+			_ = a.docker.StopContainer(containerID)
+			_ = a.docker.RemoveContainer(containerID)
+		}
+		a.closeEgressRoute(route)
+	}
+	if cfg.Mode == session.ModeDocker {
+		if a.docker == nil || !a.docker.IsDockerAvailable() {
+			cfg.Mode = session.ModeLiveShare
+			runtime.EventsEmit(a.ctx, "session:docker_fallback", map[string]string{
+				"reason": "Docker não está disponível. Sessão iniciada em Live Share.",
+			})
+		} else {
+			if scopedWorkspace.Path == "" {
+				return nil, fmt.Errorf("docker mode requires a workspace with a valid path")
+			}
+
+			cfg.ProjectPath = scopedWorkspace.Path
+
+			// Prioridade: imagem escolhida > imagem do workspace > stack default > detecção automática
+			if requestedImage != "" {
+				cfg.DockerImage = requestedImage
+			} else if image := a.resolveWorkspaceDockerImage(scopedWorkspace); image != "" {
+				cfg.DockerImage = image
+			} else {
+				cfg.DockerImage = a.docker.DetectImage(scopedWorkspace.Path)
+			}
+			log.Printf("[ORCH] Using docker image for session: %s", cfg.DockerImage)
+
+			securityProfile := a.GetSessionSecurityProfile()
+			cfg.SecurityProfile = string(securityProfile)
+			cfg.Network = a.GetSessionNetworkPolicyDefaults()
+			networkMode, proxyEnv := "none", []string(nil)
+			switch cfg.Network.Mode {
+			case session.NetworkFull:
+				networkMode = "bridge"
+			case session.NetworkAllowList:
+				route, err = a.openSessionEgress(cfg.Network.AllowedHosts)
+				if err != nil {
+					return nil, err
+				}
+				networkMode, proxyEnv = route.network, docker.ProxyEnv(route.proxyURL)
+			}
+			containerCfg = docker.ContainerConfig{
+				Image:           cfg.DockerImage,
+				ProjectPath:     scopedWorkspace.Path,
+				EnvVars:         proxyEnv,
+				Memory:          "2g",
+				CPUs:            "2",
+				Shell:           "/bin/sh",
+				ReadOnly:        true,
+				NetworkMode:     networkMode,
+				SecurityProfile: securityProfile,
+			}
+
+			createdContainerID, createErr := a.docker.CreateContainer(containerCfg)
+			if createErr != nil {
+				releaseSandbox()
+				return nil, createErr
+			}
+			containerID = createdContainerID
+
+			if err := a.docker.StartContainer(containerID); err != nil {
+				releaseSandbox()
+				return nil, err
+			}
+
+			if err := a.docker.WaitUntilRunning(containerID, 5*time.Second); err != nil {
+				releaseSandbox()
+				return nil, err
+			}
+		}
+	}
+
+	var createdSession *session.Session
+
+	if !a.sessionGatewayOwner {
+		createdSession, err = a.gatewayCreateSession(hostUserID, hostName, hostAvatarURL, cfg)
+		if err != nil {
+			releaseSandbox()
+			return nil, fmt.Errorf("session gateway create failed in client mode: %w", err)
+		}
+	} else {
+		if a.session == nil {
+			releaseSandbox()
+			return nil, fmt.Errorf("session service not initialized")
+		}
+		createdSession, err = a.session.CreateSession(hostUserID, cfg)
+		if err != nil {
+			releaseSandbox()
+			return nil, err
+		}
+	}
+	createdSession.HostUserID = hostUserID
+	createdSession.HostName = hostName
+	createdSession.HostAvatarURL = hostAvatarURL
+
+	if containerID != "" {
+		a.setSessionContainer(createdSession.ID, containerID)
+		a.auditSessionEvent(createdSession.ID, hostUserID, "container_started", fmt.Sprintf("container=%s image=%s", containerID, cfg.DockerImage))
+		// Audita o isolamento efetivo; sem inspect, registra o que foi pedido.
+		if settings, err := a.SessionGetContainerSecurity(createdSession.ID); err == nil {
+			a.auditSessionEvent(createdSession.ID, hostUserID, "container_security", settings.Summary())
+		} else {
+			a.auditSessionEvent(createdSession.ID, hostUserID, "container_security", fmt.Sprintf("requested %s (inspect failed: %v)", docker.ResolveSecuritySettings(containerCfg).Summary(), err))
+		}
+		a.auditSessionEvent(createdSession.ID, hostUserID, "network_policy", networkPolicyAuditDetails(cfg.Network))
+		if route != nil {
+			a.bindSessionEgress(createdSession.ID, route)
+		}
+	}
+	a.auditSessionEvent(
+		createdSession.ID,
+		hostUserID,
+		"session_created",
+		fmt.Sprintf(
+			"mode=%s allowAnonymous=%t workspaceID=%d workspaceName=%s",
+			createdSession.Mode,
+			allowAnonymous,
+			cfg.WorkspaceID,
+			cfg.WorkspaceName,
+		),
+	)
+	if a.sessionGatewayOwner {
+		a.persistSessionState(createdSession.ID)
+	}
+	a.notifyEvent(notify.EventSessionStarted, "Collaboration session started", "", map[string]string{
+		"sessionId": createdSession.ID,
+		"host":      hostName,
+		"mode":      string(createdSession.Mode),
+		"workspace": cfg.WorkspaceName,
+	})
+
+	return createdSession, nil
+}
+
+// SessionJoin entra em uma sessão usando um código
+func (a *App) SessionJoin(code string, name string, email string) (*session.JoinResult, error) {
+	guestUser, err := a.requireGitHubSessionUser()
+	if err != nil {
+		return nil, err
+	}
+
+	guestUserID := strings.TrimSpace(guestUser.ID)
+	if guestUserID == "" {
+		return nil, fmt.Errorf("sessões colaborativas exigem um usuário autenticado válido")
+	}
+	avatarURL := strings.TrimSpace(guestUser.AvatarURL)
+
+	if strings.TrimSpace(name) == "" {
+		name = strings.TrimSpace(guestUser.Name)
+	}
+	if strings.TrimSpace(email) == "" {
+		email = strings.TrimSpace(guestUser.Email)
+	}
+	if strings.TrimSpace(name) == "" {
+		name = guestUserID
+	}
+
+	guestInfo := session.GuestInfo{
+		Name:      name,
+		Login:     strings.TrimSpace(guestUser.Username),
+		Email:     email,
+		AvatarURL: avatarURL,
+	}
+
+	if a.session == nil || !a.sessionGatewayOwner {
+		result, err := a.gatewayJoinSession(code, guestUserID, guestInfo)
+		if err != nil {
+			if !a.sessionGatewayOwner {
+				return nil, sanitizeSessionJoinError(fmt.Errorf("gateway join failed in client mode: %w", err))
+			}
+			return nil, sanitizeSessionJoinError(fmt.Errorf("session service not initialized and gateway join failed: %w", err))
+		}
+		a.auditSessionEvent(result.SessionID, guestUserID, "guest_requested_join", fmt.Sprintf("name=%s", name))
+		return result, nil
+	}
+
+	result, err := a.session.JoinSession(code, guestUserID, guestInfo)
+	if err != nil {
+		// Fallback para gateway compartilhado em outra instância local.
+		if isSessionNotFoundErr(err) {
+			remoteResult, remoteErr := a.gatewayJoinSession(code, guestUserID, guestInfo)
+			if remoteErr != nil {
+				return nil, sanitizeSessionJoinError(fmt.Errorf("%v (gateway fallback failed: %w)", err, remoteErr))
+			}
+			a.auditSessionEvent(remoteResult.SessionID, guestUserID, "guest_requested_join", fmt.Sprintf("name=%s", name))
+			return remoteResult, nil
+		}
+		return nil, sanitizeSessionJoinError(err)
+	}
+
+	a.auditSessionEvent(result.SessionID, guestUserID, "guest_requested_join", fmt.Sprintf("name=%s", name))
+	a.persistSessionState(result.SessionID)
+	return result, nil
+}
+
+// SessionApproveGuest aprova um guest na sessão
+func (a *App) SessionApproveGuest(sessionID, guestUserID string) error {
+	var err error
+	if a.session == nil || !a.sessionGatewayOwner {
+		err = a.gatewayApproveGuest(sessionID, guestUserID)
+	} else {
+		err = a.session.ApproveGuest(sessionID, guestUserID)
+		if isSessionNotFoundErr(err) {
+			err = a.gatewayApproveGuest(sessionID, guestUserID)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	a.auditSessionEvent(sessionID, guestUserID, "guest_approved", "Host approved guest in waiting room")
+	a.auditSessionEvent(sessionID, guestUserID, "guest_entered", "Guest admitted into collaboration session")
+	a.syncGuestPermissionAcrossPTYs(sessionID, guestUserID)
+	if a.sessionGatewayOwner {
+		a.persistSessionState(sessionID)
+	}
+	return nil
+}
+
+// SessionRejectGuest rejeita um guest na sessão
+func (a *App) SessionRejectGuest(sessionID, guestUserID string) error {
+	var err error
+	if a.session == nil || !a.sessionGatewayOwner {
+		err = a.gatewayRejectGuest(sessionID, guestUserID)
+	} else {
+		err = a.session.RejectGuest(sessionID, guestUserID)
+		if isSessionNotFoundErr(err) {
+			err = a.gatewayRejectGuest(sessionID, guestUserID)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	a.auditSessionEvent(sessionID, guestUserID, "guest_rejected", "Host rejected guest in waiting room")
+	if a.sessionGatewayOwner {
+		a.persistSessionState(sessionID)
+	}
+	return nil
+}
+
+// SessionEnd encerra a sessão ativa
+func (a *App) SessionEnd(sessionID string) error {
+	guestsToRevoke := make([]string, 0, 8)
+	if current, getErr := a.SessionGetSession(sessionID); getErr == nil && current != nil {
+		for _, guest := range current.Guests {
+			guestsToRevoke = append(guestsToRevoke, guest.UserID)
+		}
+	}
+
+	if containerID, ok := a.popSessionContainer(sessionID); ok && a.docker != nil {
+		if err := a.docker.StopContainer(containerID); err != nil {
+			log.Printf("[DOCKER] stop failed for %s: %s", containerID, a.sanitizeForLogs(err.Error()))
+		}
+		if err := a.docker.RemoveContainer(containerID); err != nil {
+			log.Printf("[DOCKER] remove failed for %s: %s", containerID, a.sanitizeForLogs(err.Error()))
+		}
+		a.auditSessionEvent(sessionID, "system", "container_stopped", fmt.Sprintf("container=%s", containerID))
+	}
+	a.closeEgressRoute(a.popSessionEgress(sessionID))
+
+	var err error
+	if a.session == nil || !a.sessionGatewayOwner {
+		err = a.gatewayEndSession(sessionID)
+	} else {
+		err = a.session.EndSession(sessionID)
+		if isSessionNotFoundErr(err) {
+			err = a.gatewayEndSession(sessionID)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if a.sessionGatewayOwner && a.signaling != nil {
+		a.signaling.NotifySessionEnded(sessionID, "host")
+	}
+	a.gitShares.Remove(sessionID)
+	a.prReviews.Stop(sessionID)
+	for _, held := range a.commandGuard.Reset(sessionID) {
+		a.discardHeldCommandLine(held.TerminalID)
+	}
+	for _, guestUserID := range guestsToRevoke {
+		a.applyPermissionToAllPTY(guestUserID, terminal.PermissionNone)
+	}
+	a.auditSessionEvent(sessionID, "host", "session_ended", "Host ended the collaboration session")
+	a.deletePersistedSessionState(sessionID)
+	return nil
+}
+
+// SessionListPendingGuests lista pedidos de entrada pendentes
+func (a *App) SessionListPendingGuests(sessionID string) ([]session.GuestRequest, error) {
+	if a.session == nil || !a.sessionGatewayOwner {
+		return a.gatewayListPendingGuests(sessionID)
+	}
+	pending, err := a.session.ListPendingGuests(sessionID)
+	if err == nil {
+		return pending, nil
+	}
+	if isSessionNotFoundErr(err) {
+		return a.gatewayListPendingGuests(sessionID)
+	}
+	return nil, err
+}
+
+// SessionSetGuestPermission altera permissão de um guest
+func (a *App) SessionSetGuestPermission(sessionID, guestUserID, permission string) error {
+	previousPerm := ""
+	if a.sessionGatewayOwner && a.session != nil {
+		if sess, err := a.session.GetSession(sessionID); err == nil && sess != nil {
+			for _, guest := range sess.Guests {
+				if guest.UserID == guestUserID {
+					previousPerm = string(guest.Permission)
+					break
+				}
+			}
+		}
+	} else if sess, err := a.gatewayGetSession(sessionID); err == nil && sess != nil {
+		for _, guest := range sess.Guests {
+			if guest.UserID == guestUserID {
+				previousPerm = string(guest.Permission)
+				break
+			}
+		}
+	}
+
+	var err error
+	if a.session == nil || !a.sessionGatewayOwner {
+		err = a.gatewaySetGuestPermission(sessionID, guestUserID, permission)
+	} else {
+		err = a.session.SetGuestPermission(sessionID, guestUserID, permission)
+		if isSessionNotFoundErr(err) {
+			err = a.gatewaySetGuestPermission(sessionID, guestUserID, permission)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if a.sessionGatewayOwner && a.signaling != nil {
+		a.signaling.NotifyPermissionChange(sessionID, guestUserID, permission)
+	}
+	if permission == string(session.PermReadOnly) {
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "session:permission_revoked", map[string]string{
+				"sessionID":   sessionID,
+				"guestUserID": guestUserID,
+			})
+		}
+		a.auditSessionEvent(sessionID, guestUserID, "permission_revoked", "Write permission revoked by host")
+	}
+	a.syncGuestPermissionAcrossPTYs(sessionID, guestUserID)
+	a.auditSessionEvent(sessionID, guestUserID, "permission_changed", fmt.Sprintf("from=%s to=%s", previousPerm, permission))
+	if a.sessionGatewayOwner {
+		a.persistSessionState(sessionID)
+	}
+	return nil
+}
+
+// SessionKickGuest remove um guest da sessão
+func (a *App) SessionKickGuest(sessionID, guestUserID string) error {
+	var err error
+	if a.session == nil || !a.sessionGatewayOwner {
+		err = a.gatewayKickGuest(sessionID, guestUserID)
+	} else {
+		err = a.session.KickGuest(sessionID, guestUserID)
+		if isSessionNotFoundErr(err) {
+			err = a.gatewayKickGuest(sessionID, guestUserID)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	a.applyPermissionToAllPTY(guestUserID, terminal.PermissionNone)
+	a.auditSessionEvent(sessionID, guestUserID, "guest_kicked", "Guest removed by host")
+	a.auditSessionEvent(sessionID, guestUserID, "guest_left", "Guest left session after host removal")
+	if a.sessionGatewayOwner {
+		a.persistSessionState(sessionID)
+	}
+	return nil
+}
+
+// SessionRegenerateCode gera um novo código de convite para a sessão.
+func (a *App) SessionRegenerateCode(sessionID string) (*session.Session, error) {
+	var (
+		updated *session.Session
+		err     error
+	)
+	if a.session == nil || !a.sessionGatewayOwner {
+		updated, err = a.gatewayRegenerateSessionCode(sessionID)
+	} else {
+		updated, err = a.session.RegenerateCode(sessionID)
+		if isSessionNotFoundErr(err) {
+			updated, err = a.gatewayRegenerateSessionCode(sessionID)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.auditSessionEvent(sessionID, "host", "code_regenerated", "Host generated a new session join code")
+	if a.sessionGatewayOwner {
+		a.persistSessionState(sessionID)
+	}
+	return updated, nil
+}
+
+// SessionRevokeCode invalida o código de convite atual da sessão.
+func (a *App) SessionRevokeCode(sessionID string) (*session.Session, error) {
+	var (
+		updated *session.Session
+		err     error
+	)
+	if a.session == nil || !a.sessionGatewayOwner {
+		updated, err = a.gatewayRevokeSessionCode(sessionID)
+	} else {
+		updated, err = a.session.RevokeCode(sessionID)
+		if isSessionNotFoundErr(err) {
+			updated, err = a.gatewayRevokeSessionCode(sessionID)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.auditSessionEvent(sessionID, "host", "code_revoked", "Host revoked the current session join code")
+	if a.sessionGatewayOwner {
+		a.persistSessionState(sessionID)
+	}
+	return updated, nil
+}
+
+// SessionSetAllowNewJoins habilita/desabilita novos pedidos de entrada.
+func (a *App) SessionSetAllowNewJoins(sessionID string, allow bool) (*session.Session, error) {
+	var (
+		updated *session.Session
+		err     error
+	)
+	if a.session == nil || !a.sessionGatewayOwner {
+		updated, err = a.gatewaySetAllowNewJoins(sessionID, allow)
+	} else {
+		updated, err = a.session.SetAllowNewJoins(sessionID, allow)
+		if isSessionNotFoundErr(err) {
+			updated, err = a.gatewaySetAllowNewJoins(sessionID, allow)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	action := "allow_new_joins_disabled"
+	details := "Host disabled new join requests"
+	if allow {
+		action = "allow_new_joins_enabled"
+		details = "Host enabled new join requests"
+	}
+	a.auditSessionEvent(sessionID, "host", action, details)
+	if a.sessionGatewayOwner {
+		a.persistSessionState(sessionID)
+	}
+	return updated, nil
+}
+
+// sessionPolicyCheckInterval é a frequência de avaliação das políticas de tempo das sessões;
+// sessionActivityTouchInterval limita quantas vezes a atividade de um guest é registrada.
+const (
+	sessionPolicyCheckInterval   = 15 * time.Second
+	sessionActivityTouchInterval = 30 * time.Second
+)
+
+// GetSessionTimeoutPolicyDefaults retorna a política aplicada às novas sessões.
+func (a *App) GetSessionTimeoutPolicyDefaults() session.TimeoutPolicy {
+	if a.db == nil {
+		return session.TimeoutPolicy{}
+	}
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return session.TimeoutPolicy{}
+	}
+	policy, err := session.NormalizeTimeoutPolicy(session.TimeoutPolicy{
+		GuestIdleMinutes:   cfg.SessionIdleMinutes,
+		MaxDurationMinutes: cfg.SessionMaxMinutes,
+		WarnBeforeMinutes:  cfg.SessionWarnMinutes,
+	})
+	if err != nil {
+		return session.TimeoutPolicy{}
+	}
+	return policy
+}
+
+// SetSessionTimeoutPolicyDefaults persiste a política das novas sessões (não altera a sessão ativa).
+func (a *App) SetSessionTimeoutPolicyDefaults(policy session.TimeoutPolicy) (session.TimeoutPolicy, error) {
+	if a.db == nil {
+		return session.TimeoutPolicy{}, fmt.Errorf("database not initialized")
+	}
+	normalized, err := session.NormalizeTimeoutPolicy(policy)
+	if err != nil {
+		return session.TimeoutPolicy{}, err
+	}
+	if err := a.db.SetSessionTimeoutPolicy(normalized.GuestIdleMinutes, normalized.MaxDurationMinutes, normalized.WarnBeforeMinutes); err != nil {
+		return session.TimeoutPolicy{}, err
+	}
+	return normalized, nil
+}
+
+// GetSessionSecurityProfile retorna o perfil de segurança ("strict"/"standard") dos containers
+// das novas sessões Docker.
+func (a *App) GetSessionSecurityProfile() docker.SecurityProfile {
+	if a.db == nil {
+		return docker.ProfileStrict
+	}
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return docker.ProfileStrict
+	}
+	profile, err := docker.NormalizeSecurityProfile(cfg.SessionSandbox)
+	if err != nil {
+		return docker.ProfileStrict
+	}
+	return profile
+}
+
+// SetSessionSecurityProfile persiste o perfil dos containers das novas sessões.
+func (a *App) SetSessionSecurityProfile(profile string) (docker.SecurityProfile, error) {
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	normalized, err := docker.NormalizeSecurityProfile(profile)
+	if err != nil {
+		return "", err
+	}
+	if err := a.db.SetSessionSandbox(string(normalized)); err != nil {
+		return "", err
+	}
+	return normalized, nil
+}
+
+// SessionGetContainerSecurity lê do Docker o isolamento efetivo do container da sessão.
+func (a *App) SessionGetContainerSecurity(sessionID string) (docker.SecuritySettings, error) {
+	if a.docker == nil {
+		return docker.SecuritySettings{}, fmt.Errorf("docker service not initialized")
+	}
+	containerID, ok := a.getSessionContainer(sessionID)
+	if !ok {
+		return docker.SecuritySettings{}, fmt.Errorf("session %s has no container", sessionID)
+	}
+	profile := docker.ProfileStrict
+	if sess, err := a.SessionGetSession(sessionID); err == nil && sess.Config.SecurityProfile != "" {
+		profile = docker.SecurityProfile(sess.Config.SecurityProfile)
+	}
+	return a.docker.InspectSecurity(containerID, profile)
+}
+
+// egressRoute é a saída de uma sessão em allow-list: uma rede Docker interna, sem rota para fora,
+// cujo único caminho é o proxy que o host escuta no gateway dessa rede.
+type egressRoute struct {
+	network  string
+	relay    string // container que repassa ao proxy no Docker Desktop; vazio no Docker nativo
+	proxyURL string
+	proxy    *egress.Proxy
+
+	mu        sync.Mutex
+	sessionID string
+	pending   []egress.Event // requisições feitas antes de a sessão existir
+}
+
+// GetSessionNetworkPolicyDefaults devolve a política de rede aplicada aos containers das novas sessões.
+func (a *App) GetSessionNetworkPolicyDefaults() session.NetworkPolicy {
+	fallback := session.NetworkPolicy{Mode: session.NetworkNone}
+	if a.db == nil {
+		return fallback
+	}
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return fallback
+	}
+	policy, err := session.NormalizeNetworkPolicy(session.NetworkPolicy{
+		Mode:         cfg.SessionNetworkMode,
+		AllowedHosts: strings.Split(cfg.SessionAllowedHosts, "\n"),
+	})
+	if err != nil {
+		return fallback
+	}
+	return policy
+}
+
+// SetSessionNetworkPolicyDefaults persiste a política de rede das novas sessões.
+func (a *App) SetSessionNetworkPolicyDefaults(policy session.NetworkPolicy) (session.NetworkPolicy, error) {
+	if a.db == nil {
+		return session.NetworkPolicy{}, fmt.Errorf("database not initialized")
+	}
+	normalized, err := session.NormalizeNetworkPolicy(policy)
+	if err != nil {
+		return session.NetworkPolicy{}, err
+	}
+	if err := a.db.SetSessionNetworkPolicy(normalized.Mode, strings.Join(normalized.AllowedHosts, "\n")); err != nil {
+		return session.NetworkPolicy{}, err
+	}
+	return normalized, nil
+}
+
+// openSessionEgress cria a rede interna e sobe o proxy da allow-list alcançável por ela.
+func (a *App) openSessionEgress(hosts []string) (*egressRoute, error) {
+	if a.docker == nil {
+		return nil, fmt.Errorf("docker service not initialized")
+	}
+	name := fmt.Sprintf("orch-egress-%d", time.Now().UnixNano())
+	gateway, err := a.docker.CreateInternalNetwork(name)
+	if err != nil {
+		return nil, fmt.Errorf("create session egress network: %w", err)
+	}
+
+	route := &egressRoute{network: name, proxy: egress.NewProxy(hosts)}
+	route.proxy.OnRequest(func(event egress.Event) {
+		a.recordEgressEvent(route, event)
+	})
+	route.proxyURL, route.relay, err = listenSessionEgress(route.proxy, name, gateway, a.docker.StartEgressRelay)
+	if err != nil {
+		_ = a.docker.RemoveNetwork(name)
+		return nil, err
+	}
+	return route, nil
+}
+
+// listenSessionEgress sobe o proxy no gateway da rede interna, que só é uma interface do host no
+// Docker nativo do Linux. No Docker Desktop o bind falha: o proxy escuta no loopback e um relay
+// ligado à rede repassa as conexões. Devolve a URL do proxy para os containers e o relay.
+func listenSessionEgress(proxy *egress.Proxy, network string, gateway string, startRelay func(network string, hostPort string) (string, string, error)) (string, string, error) {
+	if addr, err := proxy.Start(net.JoinHostPort(gateway, "0")); err == nil {
+		return "http://" + addr, "", nil
+	}
+	addr, err := proxy.Start("127.0.0.1:0")
+	if err != nil {
+		return "", "", err
+	}
+	_, port, _ := net.SplitHostPort(addr)
+	relay, proxyURL, err := startRelay(network, port)
+	if err != nil {
+		_ = proxy.Close()
+		return "", "", fmt.Errorf("allowlist network policy: docker gateway %s is not reachable from the host (Docker Desktop) and the egress relay could not start: %w", gateway, err)
+	}
+	return proxyURL, relay, nil
+}
+
+// bindSessionEgress associa o proxy à sessão criada e audita o que chegou antes disso.
+func (a *App) bindSessionEgress(sessionID string, route *egressRoute) {
+	a.mu.Lock()
+	a.sessionEgress[sessionID] = route
+	a.mu.Unlock()
+
+	route.mu.Lock()
+	route.sessionID = sessionID
+	pending := route.pending
+	route.pending = nil
+	route.mu.Unlock()
+
+	for _, event := range pending {
+		a.auditSessionEvent(sessionID, "system", "network_request", egressEventAuditDetails(event))
+	}
+}
+
+func (a *App) recordEgressEvent(route *egressRoute, event egress.Event) {
+	route.mu.Lock()
+	sessionID := route.sessionID
+	if sessionID == "" {
+		route.pending = append(route.pending, event)
+		route.mu.Unlock()
+		return
 	}
+	route.mu.Unlock()
+	a.auditSessionEvent(sessionID, "system", "network_request", egressEventAuditDetails(event))
+}
 
-	return gh.UpdatePRBranchInput{
-		ExpectedHeadSHA: normalizedSHA,
-	}, nil
+func (a *App) popSessionEgress(sessionID string) *egressRoute {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	route := a.sessionEgress[sessionID]
+	delete(a.sessionEgress, sessionID)
+	return route
 }
 
-func (a *App) emitGitPanelPRMutationRefresh(owner, repo string, prNumber int, action string) {
-	if a == nil || a.ctx == nil {
+// closeEgressRoute derruba o proxy e a rede interna; o container já deve ter sido removido.
+func (a *App) closeEgressRoute(route *egressRoute) {
+	if route == nil {
 		return
 	}
-
-	normalizedOwner := strings.TrimSpace(owner)
-	normalizedRepo := strings.TrimSpace(repo)
-	if normalizedOwner == "" || normalizedRepo == "" {
+	_ = route.proxy.Close()
+	if a.docker == nil {
 		return
 	}
-
-	normalizedAction := strings.ToLower(strings.TrimSpace(action))
-	if normalizedAction == "" {
-		normalizedAction = "updated"
+	if route.relay != "" {
+		if err := a.docker.RemoveEgressRelay(route.relay); err != nil {
+			log.Printf("[DOCKER] egress relay remove failed for %s: %s", route.relay, a.sanitizeForLogs(err.Error()))
+		}
+	}
+	if err := a.docker.RemoveNetwork(route.network); err != nil {
+		log.Printf("[DOCKER] network remove failed for %s: %s", route.network, a.sanitizeForLogs(err.Error()))
 	}
-
-	runtime.EventsEmit(a.ctx, "github:prs:updated", map[string]interface{}{
-		"owner":  normalizedOwner,
-		"repo":   normalizedRepo,
-		"count":  1,
-		"source": "mutation",
-		"action": normalizedAction,
-		"changes": []map[string]interface{}{{
-			"number":     prNumber,
-			"changeType": normalizedAction,
-		}},
-	})
 }
 
-// GitPanelPRList retorna PRs do repositorio alvo com filtro e paginacao REST.
-func (a *App) GitPanelPRList(repoPath string, state string, page int, perPage int) ([]gh.PullRequest, error) {
-	normalizedState, stateErr := normalizeGitPanelPRListState(state)
-	if stateErr != nil {
-		return nil, stateErr
+func egressEventAuditDetails(event egress.Event) string {
+	verdict := "denied"
+	if event.Allowed {
+		verdict = "allowed"
 	}
+	return fmt.Sprintf("%s %s %s", event.Method, event.Host, verdict)
+}
 
-	githubService, svcErr := a.requireGitHubServiceForPRs()
-	if svcErr != nil {
-		return nil, svcErr
+func networkPolicyAuditDetails(policy session.NetworkPolicy) string {
+	if policy.Mode != session.NetworkAllowList {
+		return "mode=" + policy.Mode
 	}
+	return fmt.Sprintf("mode=%s hosts=%s", policy.Mode, strings.Join(policy.AllowedHosts, ","))
+}
 
-	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
-	if resolveErr != nil {
-		return nil, resolveErr
+// SessionSetTimeoutPolicy troca a política da sessão em andamento.
+func (a *App) SessionSetTimeoutPolicy(sessionID string, policy session.TimeoutPolicy) (*session.Session, error) {
+	var (
+		updated *session.Session
+		err     error
+	)
+	if a.session == nil || !a.sessionGatewayOwner {
+		updated, err = a.gatewaySetTimeoutPolicy(sessionID, policy)
+	} else {
+		updated, err = a.session.SetTimeoutPolicy(sessionID, policy)
+		if isSessionNotFoundErr(err) {
+			updated, err = a.gatewaySetTimeoutPolicy(sessionID, policy)
+		}
 	}
-
-	items, err := githubService.ListPullRequests(owner, repo, gh.PRFilters{
-		State:   normalizedState,
-		Page:    page,
-		PerPage: perPage,
-	})
 	if err != nil {
-		return nil, a.normalizeGitPanelPRError(err)
-	}
-	if items == nil {
-		return []gh.PullRequest{}, nil
+		return nil, err
 	}
 
-	return items, nil
+	p := updated.Config.Policy
+	a.auditSessionEvent(sessionID, "host", "policy_changed", fmt.Sprintf("idle=%dm max=%dm warn=%dm", p.GuestIdleMinutes, p.MaxDurationMinutes, p.WarnBeforeMinutes))
+	if a.sessionGatewayOwner {
+		a.persistSessionState(sessionID)
+	}
+	return updated, nil
 }
 
-// GitPanelPRCreate cria um Pull Request no repositorio alvo via GitHub REST.
-func (a *App) GitPanelPRCreate(repoPath string, payload GitPanelPRCreatePayloadDTO) (gh.PullRequest, error) {
-	normalizedPayload, payloadErr := normalizeGitPanelPRCreatePayload(payload)
-	if payloadErr != nil {
-		return gh.PullRequest{}, payloadErr
-	}
+// startSessionPolicyMonitor avalia periodicamente as políticas das sessões do gateway.
+func (a *App) startSessionPolicyMonitor() {
+	ticker := time.NewTicker(sessionPolicyCheckInterval)
+	defer ticker.Stop()
 
-	githubService, svcErr := a.requireGitHubServiceForPRs()
-	if svcErr != nil {
-		return gh.PullRequest{}, svcErr
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case now := <-ticker.C:
+			a.enforceSessionPolicies(now)
+		}
 	}
+}
 
-	createInput := normalizedPayload.Input
-	owner := ""
-	repo := ""
-	if normalizedPayload.ManualOwner != "" && normalizedPayload.ManualRepo != "" {
-		target, targetErr := a.GitPanelPRResolveRepository(
-			repoPath,
-			normalizedPayload.ManualOwner,
-			normalizedPayload.ManualRepo,
-			normalizedPayload.AllowTargetOverride,
-		)
-		if targetErr != nil {
-			return gh.PullRequest{}, a.normalizeGitPanelPRError(targetErr)
-		}
+// enforceSessionPolicies aplica avisos, revogações de escrita e encerramentos devidos. Só a
+// instância dona do gateway aplica, para que cada sessão seja avaliada uma única vez.
+func (a *App) enforceSessionPolicies(now time.Time) []session.PolicyAction {
+	if a.session == nil || !a.sessionGatewayOwner {
+		return nil
+	}
 
-		owner = strings.TrimSpace(target.Owner)
-		repo = strings.TrimSpace(target.Repo)
-	} else {
-		var resolveErr error
-		owner, repo, resolveErr = a.resolveGitPanelPROwnerRepo(repoPath)
-		if resolveErr != nil {
-			return gh.PullRequest{}, resolveErr
+	applied := []session.PolicyAction{}
+	for _, action := range a.session.EvaluateTimeoutPolicies(now) {
+		switch action.Type {
+		case session.PolicySessionExpired:
+			// Avisa antes de encerrar: depois do fim o signaling dos guests é fechado.
+			a.recordSessionPolicyAction(action)
+			if err := a.SessionEnd(action.SessionID); err != nil {
+				log.Printf("[SESSION] policy could not end session %s: %v", action.SessionID, err)
+				continue
+			}
+		case session.PolicyGuestWriteRevoked:
+			if err := a.SessionSetGuestPermission(action.SessionID, action.GuestUserID, string(session.PermReadOnly)); err != nil {
+				log.Printf("[SESSION] policy could not revoke write for %s: %v", action.GuestUserID, err)
+				continue
+			}
+			a.recordSessionPolicyAction(action)
+		default:
+			a.recordSessionPolicyAction(action)
 		}
+		applied = append(applied, action)
 	}
+	return applied
+}
 
-	if owner == "" || repo == "" {
-		return gh.PullRequest{}, gpr.NewBindingError(
-			gpr.CodeRepoResolveFailed,
-			"Nao foi possivel resolver owner/repo para criar Pull Request.",
-			"Revise o repositorio alvo e tente novamente.",
-		)
+// recordSessionPolicyAction registra a transição na auditoria e avisa host e guests.
+func (a *App) recordSessionPolicyAction(action session.PolicyAction) {
+	details := map[string]string{
+		session.PolicyGuestIdleWarning:  "Guest write access will be revoked for inactivity",
+		session.PolicyGuestWriteRevoked: "Guest write access revoked after inactivity",
+		session.PolicySessionEndWarning: "Session will end when it reaches the duration cap",
+		session.PolicySessionExpired:    "Session ended after reaching the duration cap",
+	}[action.Type]
+	actor := action.GuestUserID
+	if actor == "" {
+		actor = "system"
 	}
+	a.auditSessionEvent(action.SessionID, actor, "policy_"+action.Type, fmt.Sprintf("%s (deadline=%s)", details, action.DeadlineAt.UTC().Format(time.RFC3339)))
 
-	createInput.Owner = owner
-	createInput.Repo = repo
-
-	created, err := githubService.CreatePullRequest(createInput)
+	eventName, signalType := "session:policy_enforced", "policy_enforced"
+	if action.Type == session.PolicyGuestIdleWarning || action.Type == session.PolicySessionEndWarning {
+		eventName, signalType = "session:policy_warning", "policy_warning"
+	}
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, eventName, action)
+	}
+	if a.signaling == nil {
+		return
+	}
+	payload, err := json.Marshal(action)
 	if err != nil {
-		normalizedErr := a.normalizeGitPanelPRError(err)
-		a.logGitPanelPROperationError("create", owner, repo, 0, normalizedErr)
-		return gh.PullRequest{}, normalizedErr
+		return
 	}
-	if created == nil {
-		return gh.PullRequest{}, nil
+	msg := session.SignalMessage{Type: signalType, Payload: string(payload)}
+	if action.GuestUserID != "" {
+		a.signaling.NotifyGuest(action.SessionID, action.GuestUserID, msg)
+		return
 	}
-
-	a.emitGitPanelPRMutationRefresh(owner, repo, created.Number, "created")
-	return *created, nil
+	a.signaling.NotifyAuthorizedGuests(action.SessionID, msg)
 }
 
-// GitPanelPRCreateLabel cria uma label de repositorio a partir da aba de PR.
-func (a *App) GitPanelPRCreateLabel(repoPath string, payload GitPanelPRCreateLabelPayloadDTO) (gh.Label, error) {
-	normalizedPayload, payloadErr := normalizeGitPanelPRCreateLabelPayload(payload)
-	if payloadErr != nil {
-		return gh.Label{}, payloadErr
+// touchSessionGuestActivity registra input do guest na sessão ativa do host (no máximo uma vez
+// a cada sessionActivityTouchInterval por guest).
+func (a *App) touchSessionGuestActivity(guestUserID string) {
+	now := time.Now()
+	a.sessionActivityMu.Lock()
+	if last, ok := a.sessionActivityAt[guestUserID]; ok && now.Sub(last) < sessionActivityTouchInterval {
+		a.sessionActivityMu.Unlock()
+		return
 	}
+	a.sessionActivityAt[guestUserID] = now
+	a.sessionActivityMu.Unlock()
 
-	githubService, svcErr := a.requireGitHubServiceForPRs()
-	if svcErr != nil {
-		return gh.Label{}, svcErr
+	sess, err := a.resolveActiveCollabSession(a.resolveSessionHostUserID())
+	if err != nil {
+		return
 	}
-
-	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
-	if resolveErr != nil {
-		return gh.Label{}, resolveErr
+	if a.session != nil && a.sessionGatewayOwner {
+		a.session.TouchGuestActivity(sess.ID, guestUserID)
+		return
 	}
+	if err := a.gatewayTouchGuestActivity(sess.ID, guestUserID); err != nil {
+		log.Printf("[SESSION] unable to record guest activity: %v", err)
+	}
+}
 
-	normalizedPayload.Owner = owner
-	normalizedPayload.Repo = repo
-
-	created, err := githubService.CreateLabel(normalizedPayload)
+// SessionSetCommandApproval liga a regra de duas pessoas: comandos perigosos de guests (rm -rf,
+// git push --force, DROP TABLE...) só chegam ao PTY depois da aprovação do host.
+func (a *App) SessionSetCommandApproval(sessionID string, enabled bool) (*session.Session, error) {
+	var (
+		updated *session.Session
+		err     error
+	)
+	if a.session == nil || !a.sessionGatewayOwner {
+		updated, err = a.gatewaySetCommandApproval(sessionID, enabled)
+	} else {
+		updated, err = a.session.SetCommandApproval(sessionID, enabled)
+		if isSessionNotFoundErr(err) {
+			updated, err = a.gatewaySetCommandApproval(sessionID, enabled)
+		}
+	}
 	if err != nil {
-		normalizedErr := a.normalizeGitPanelPRError(err)
-		a.logGitPanelPROperationError("create_label", owner, repo, 0, normalizedErr)
-		return gh.Label{}, normalizedErr
+		return nil, err
 	}
-	if created == nil {
-		return gh.Label{}, nil
+
+	a.auditSessionEvent(sessionID, "host", "command_approval_changed", fmt.Sprintf("enabled=%t", enabled))
+	if !enabled {
+		// Desligar a regra não libera o que já estava retido: a linha é descartada.
+		for _, held := range a.commandGuard.Reset(sessionID) {
+			a.discardHeldCommandLine(held.TerminalID)
+			held.Status = commandguard.StatusDenied
+			held.Reason = "command approval disabled"
+			a.notifyHeldCommand("command_decided", held)
+		}
+	}
+	if a.sessionGatewayOwner {
+		a.persistSessionState(sessionID)
 	}
+	return updated, nil
+}
 
-	return *created, nil
+// SessionListHeldCommands lista os comandos de guests aguardando aprovação neste app.
+func (a *App) SessionListHeldCommands() []commandguard.Request {
+	return a.commandGuard.Pending("")
 }
 
-// GitPanelPRCreateLocalBranch cria e faz checkout de uma branch local sem usar API do GitHub.
-func (a *App) GitPanelPRCreateLocalBranch(repoPath string, branch string, base string) error {
-	repoRoot, repoErr := a.resolveGitPanelPRRepoRoot(repoPath)
-	if repoErr != nil {
-		return repoErr
+// SessionApproveCommand libera o comando retido. O guest precisa continuar na sessão com escrita
+// no terminal; caso contrário a linha é descartada.
+func (a *App) SessionApproveCommand(requestID string) (commandguard.Request, error) {
+	if a.ptyMgr == nil {
+		return commandguard.Request{}, fmt.Errorf("pty manager not initialized")
 	}
-
-	normalizedBranch, branchErr := normalizeGitPanelPRLocalRef(branch, "branch", true)
-	if branchErr != nil {
-		return branchErr
+	request, rest, err := a.commandGuard.Approve(strings.TrimSpace(requestID))
+	if err != nil {
+		return commandguard.Request{}, err
 	}
 
-	normalizedBase, baseErr := normalizeGitPanelPRLocalRef(base, "base", false)
-	if baseErr != nil {
-		return baseErr
+	activeSession, permission, err := a.resolveGuestTerminalAccess(request.TerminalID, request.GuestUserID)
+	if err == nil && activeSession.ID != request.SessionID {
+		err = fmt.Errorf("session %s is no longer active", request.SessionID)
+	}
+	if err == nil && permission != terminal.PermissionReadWrite {
+		err = fmt.Errorf("guest %s no longer has write permission", request.GuestUserID)
+	}
+	if err != nil {
+		a.discardHeldCommandLine(request.TerminalID)
+		request.Status = commandguard.StatusDenied
+		request.Reason = err.Error()
+		a.auditSessionEvent(request.SessionID, "host", "command_denied", heldCommandAuditDetails(request))
+		a.notifyHeldCommand("command_decided", request)
+		return request, err
 	}
 
-	if validationErr := ensureGitPanelPRValidBranchName(repoRoot, normalizedBranch); validationErr != nil {
-		return validationErr
+	if err := a.ptyMgr.Write(request.TerminalID, rest[:1]); err != nil {
+		return request, err
 	}
+	a.auditSessionEvent(request.SessionID, "host", "command_approved", heldCommandAuditDetails(request))
+	a.notifyHeldCommand("command_decided", request)
 
-	checkoutBase := normalizedBase
-	if checkoutBase != "" {
-		resolvedBase, resolveErr := resolveGitPanelPRLocalStartPoint(repoRoot, checkoutBase)
-		if resolveErr != nil {
-			return resolveErr
+	// O que o guest enviou depois do Enter volta a passar pelo guard.
+	if len(rest) > 1 {
+		if err := a.forwardGuestTerminalInput(activeSession, request.TerminalID, request.GuestUserID, rest[1:]); err != nil {
+			log.Printf("[SESSION] unable to forward input after approved command: %v", err)
 		}
-		checkoutBase = resolvedBase
 	}
+	return request, nil
+}
 
-	checkoutArgs := []string{"-C", repoRoot, "checkout", "-b", normalizedBranch}
-	if checkoutBase != "" {
-		checkoutArgs = append(checkoutArgs, checkoutBase)
+// SessionDenyCommand recusa o comando retido e limpa a linha no terminal.
+func (a *App) SessionDenyCommand(requestID string, reason string) (commandguard.Request, error) {
+	request, err := a.commandGuard.Deny(strings.TrimSpace(requestID), strings.TrimSpace(reason))
+	if err != nil {
+		return commandguard.Request{}, err
 	}
+	a.discardHeldCommandLine(request.TerminalID)
+	a.auditSessionEvent(request.SessionID, "host", "command_denied", heldCommandAuditDetails(request))
+	a.notifyHeldCommand("command_decided", request)
+	return request, nil
+}
 
-	output, checkoutErr := runGitPanelPRCommand(checkoutArgs...)
-	if checkoutErr == nil {
-		return nil
+// discardHeldCommandLine cancela (Ctrl-C) a linha digitada pelo guest cujo Enter foi retido.
+func (a *App) discardHeldCommandLine(terminalID string) {
+	if a.ptyMgr == nil {
+		return
 	}
-
-	details := strings.TrimSpace(output)
-	loweredDetails := strings.ToLower(details)
-	if strings.Contains(loweredDetails, "already exists") {
-		switchOutput, switchErr := runGitPanelPRCommand("-C", repoRoot, "checkout", normalizedBranch)
-		if switchErr == nil {
-			return nil
-		}
-		switchDetails := strings.TrimSpace(switchOutput)
-		switch {
-		case details == "" && switchDetails != "":
-			details = switchDetails
-		case details != "" && switchDetails != "":
-			details = details + " | " + switchDetails
-		}
-		checkoutErr = switchErr
+	if err := a.ptyMgr.Write(terminalID, []byte{0x03}); err != nil {
+		log.Printf("[SESSION] unable to discard held command on terminal %s: %v", terminalID, err)
 	}
+}
 
-	if details == "" {
-		details = checkoutErr.Error()
+// notifyHeldCommand avisa o host (evento "session:<signalType>") e o guest autor (signaling).
+func (a *App) notifyHeldCommand(signalType string, request commandguard.Request) {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "session:"+signalType, request)
 	}
-
-	if errors.Is(checkoutErr, context.DeadlineExceeded) {
-		return gpr.NewBindingError(
-			gpr.CodeUnknown,
-			"Tempo limite excedido ao criar branch local.",
-			details,
-		)
+	if a.signaling == nil || !a.sessionGatewayOwner {
+		return
 	}
-
-	return gpr.NewBindingError(
-		gpr.CodeConflict,
-		"Falha ao criar branch local para a Pull Request.",
-		details,
-	)
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return
+	}
+	a.signaling.NotifyGuest(request.SessionID, request.GuestUserID, session.SignalMessage{Type: signalType, Payload: string(payload)})
 }
 
-// GitPanelPRPushLocalBranch publica uma branch local no remote origin com upstream.
-func (a *App) GitPanelPRPushLocalBranch(repoPath string, branch string) error {
-	repoRoot, repoErr := a.resolveGitPanelPRRepoRoot(repoPath)
-	if repoErr != nil {
-		return repoErr
+func heldCommandAuditDetails(request commandguard.Request) string {
+	details := fmt.Sprintf("terminal=%s rule=%s command=%s", request.TerminalID, request.Rule.ID, request.Command)
+	if request.Reason != "" {
+		details += " reason=" + request.Reason
 	}
+	return details
+}
 
-	normalizedBranch, branchErr := normalizeGitPanelPRLocalRef(branch, "branch", true)
-	if branchErr != nil {
-		return branchErr
+// SessionShareGitPanel compartilha (somente leitura) status, histórico e diffs do repositório com
+// os guests aprovados da sessão ativa. redactPatterns ocultam arquivos (ex.: ".env", "secrets/").
+func (a *App) SessionShareGitPanel(repoPath string, redactPatterns []string) (*gitshare.Share, error) {
+	sess, err := a.requireHostedSession("compartilhar o Git Panel")
+	if err != nil {
+		return nil, err
 	}
-
-	if validationErr := ensureGitPanelPRValidBranchName(repoRoot, normalizedBranch); validationErr != nil {
-		return validationErr
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return nil, err
+	}
+	preflight, preflightErr := svc.Preflight(repoPath)
+	if preflightErr != nil {
+		return nil, a.normalizeGitPanelBindingError(preflightErr)
+	}
+	patterns, err := gitshare.NormalizeRedactPatterns(redactPatterns)
+	if err != nil {
+		return nil, err
 	}
 
-	_, localLookupErr := runGitPanelPRCommand(
-		"-C", repoRoot,
-		"rev-parse",
-		"--verify",
-		"--quiet",
-		"refs/heads/"+normalizedBranch,
-	)
-	if localLookupErr != nil {
-		return gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Branch local nao encontrada para publicacao.",
-			fmt.Sprintf(`Crie a branch "%s" localmente antes de publicar no origin.`, normalizedBranch),
-		)
+	share := gitshare.Share{
+		SessionID:      sess.ID,
+		RepoRoot:       filepath.Clean(preflight.RepoRoot),
+		RepoName:       filepath.Base(preflight.RepoRoot),
+		RedactPatterns: patterns,
+		SharedAt:       time.Now(),
 	}
+	a.gitShares.Set(share)
+	a.auditSessionEvent(sess.ID, "host", "gitpanel_shared", fmt.Sprintf("repo=%s redactions=%d", share.RepoName, len(patterns)))
+	a.notifyGitShareGuests(sess.ID, "gitpanel:share_changed", "", "shared")
+	return &share, nil
+}
 
-	pushOutput, pushErr := runGitPanelPRCommand(
-		"-C", repoRoot,
-		"push",
-		"-u",
-		"origin",
-		normalizedBranch,
-	)
-	if pushErr == nil {
+// SessionStopGitPanelShare encerra o compartilhamento do Git Panel na sessão ativa.
+func (a *App) SessionStopGitPanelShare() error {
+	sess, err := a.requireHostedSession("compartilhar o Git Panel")
+	if err != nil {
+		return err
+	}
+	if !a.gitShares.Remove(sess.ID) {
 		return nil
 	}
+	a.auditSessionEvent(sess.ID, "host", "gitpanel_share_stopped", "Host stopped sharing the Git Panel")
+	a.notifyGitShareGuests(sess.ID, "gitpanel:share_changed", "", "stopped")
+	return nil
+}
 
-	details := strings.TrimSpace(pushOutput)
-	if details == "" {
-		details = pushErr.Error()
+// SessionGetGitPanelShare retorna o share da sessão ativa do host (nil quando não há).
+func (a *App) SessionGetGitPanelShare() (*gitshare.Share, error) {
+	sess, err := a.requireHostedSession("compartilhar o Git Panel")
+	if err != nil {
+		return nil, err
 	}
-
-	if errors.Is(pushErr, context.DeadlineExceeded) {
-		return gpr.NewBindingError(
-			gpr.CodeUnknown,
-			"Tempo limite excedido ao publicar branch local no origin.",
-			details,
-		)
+	share, ok := a.gitShares.Get(sess.ID)
+	if !ok {
+		return nil, nil
 	}
-
-	return gpr.NewBindingError(
-		gpr.CodeConflict,
-		"Falha ao publicar branch local no origin.",
-		details,
-	)
+	return &share, nil
 }
 
-// GitPanelPRUpdate atualiza um Pull Request existente no repositorio alvo via GitHub REST.
-func (a *App) GitPanelPRUpdate(repoPath string, prNumber int, payload GitPanelPRUpdatePayloadDTO) (gh.PullRequest, error) {
-	if prNumber <= 0 {
-		return gh.PullRequest{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Numero de Pull Request invalido.",
-			"Informe um numero de PR maior que zero.",
-		)
+// SessionGitPanelGetShare lê, como guest, o repositório que o host compartilhou na sessão.
+func (a *App) SessionGitPanelGetShare(sessionID string) (*gitshare.Share, error) {
+	var share gitshare.Share
+	if err := a.callGitShareGateway(gitshare.RouteInfo, sessionID, nil, &share); err != nil {
+		return nil, err
 	}
+	return &share, nil
+}
 
-	updateInput, payloadErr := normalizeGitPanelPRUpdatePayload(payload)
-	if payloadErr != nil {
-		return gh.PullRequest{}, payloadErr
+// SessionGitPanelGetStatus lê, como guest, o status do repositório compartilhado pelo host.
+func (a *App) SessionGitPanelGetStatus(sessionID string) (gp.StatusDTO, error) {
+	var status gp.StatusDTO
+	if err := a.callGitShareGateway(gitshare.RouteStatus, sessionID, nil, &status); err != nil {
+		return gp.StatusDTO{}, err
 	}
+	return status, nil
+}
 
-	githubService, svcErr := a.requireGitHubServiceForPRs()
-	if svcErr != nil {
-		return gh.PullRequest{}, svcErr
+// SessionGitPanelGetHistory lê, como guest, uma página do histórico do repositório compartilhado.
+func (a *App) SessionGitPanelGetHistory(sessionID string, cursor string, limit int, query string) (gp.HistoryPageDTO, error) {
+	params := url.Values{"cursor": {cursor}, "limit": {strconv.Itoa(limit)}, "query": {query}}
+	var page gp.HistoryPageDTO
+	if err := a.callGitShareGateway(gitshare.RouteHistory, sessionID, params, &page); err != nil {
+		return gp.HistoryPageDTO{}, err
 	}
+	return page, nil
+}
 
-	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
-	if resolveErr != nil {
-		return gh.PullRequest{}, resolveErr
+// SessionGitPanelGetDiff lê, como guest, o diff de um arquivo (ou de todos) do repositório compartilhado.
+func (a *App) SessionGitPanelGetDiff(sessionID string, filePath string, mode string, contextLines int) (gp.DiffDTO, error) {
+	params := url.Values{"filePath": {filePath}, "mode": {mode}, "contextLines": {strconv.Itoa(contextLines)}}
+	var diff gp.DiffDTO
+	if err := a.callGitShareGateway(gitshare.RouteDiff, sessionID, params, &diff); err != nil {
+		return gp.DiffDTO{}, err
 	}
+	return diff, nil
+}
 
-	updateInput.Owner = owner
-	updateInput.Repo = repo
-	updateInput.Number = prNumber
+// requireHostedSession exige a sessão ativa do host neste app: shares e revisões vivem no
+// gateway, então instâncias em client-mode não podem iniciá-los.
+func (a *App) requireHostedSession(feature string) (*session.Session, error) {
+	if a.session == nil || !a.sessionGatewayOwner {
+		return nil, fmt.Errorf("%s exige a instância dona do gateway de sessões", feature)
+	}
+	sess, err := a.session.GetActiveSession(a.resolveSessionHostUserID())
+	if err != nil || sess == nil {
+		return nil, fmt.Errorf("nenhuma sessão colaborativa ativa")
+	}
+	return sess, nil
+}
 
-	updated, err := githubService.UpdatePullRequest(updateInput)
+func (a *App) callGitShareGateway(route string, sessionID string, params url.Values, result interface{}) error {
+	guestUser, err := a.requireGitHubSessionUser()
 	if err != nil {
-		normalizedErr := a.normalizeGitPanelPRError(err)
-		a.logGitPanelPROperationError("update", owner, repo, prNumber, normalizedErr)
-		return gh.PullRequest{}, normalizedErr
+		return err
 	}
-	if updated == nil {
-		return gh.PullRequest{}, nil
+	sessionID = strings.TrimSpace(sessionID)
+	if sessionID == "" {
+		return fmt.Errorf("sessionID is required")
 	}
+	return a.callSessionGateway(http.MethodGet, gitshare.RequestPath(route, sessionID, guestUser.ID, params), nil, result)
+}
 
-	a.emitGitPanelPRMutationRefresh(owner, repo, updated.Number, "updated")
-	return *updated, nil
+// relayGitPanelEventToGuests repassa a invalidação pelo signaling às sessões que compartilham o
+// repositório, para que os guests recarreguem o painel.
+func (a *App) relayGitPanelEventToGuests(repoPath string, eventName string, sourceEvent string, reason string) {
+	if a.gitShares == nil || strings.TrimSpace(repoPath) == "" {
+		return
+	}
+	root := filepath.Clean(strings.TrimSpace(repoPath))
+	if found := findGitRepoRoot(root); found != "" {
+		root = found
+	}
+	for _, sessionID := range a.gitShares.SessionsForRepo(root) {
+		a.notifyGitShareGuests(sessionID, eventName, sourceEvent, reason)
+	}
 }
 
-// GitPanelPRCheckMerged verifica se a PR alvo ja foi mergeada.
-func (a *App) GitPanelPRCheckMerged(repoPath string, prNumber int) (bool, error) {
-	if prNumber <= 0 {
-		return false, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Numero de Pull Request invalido.",
-			"Informe um numero de PR maior que zero.",
-		)
+// notifyGitShareGuests envia o evento sem o caminho local do host; só guests aprovados recebem.
+func (a *App) notifyGitShareGuests(sessionID string, eventName string, sourceEvent string, reason string) {
+	if a.signaling == nil || !a.sessionGatewayOwner {
+		return
 	}
+	payload, err := json.Marshal(map[string]string{
+		"event":       eventName,
+		"sourceEvent": strings.TrimSpace(sourceEvent),
+		"reason":      strings.TrimSpace(reason),
+	})
+	if err != nil {
+		return
+	}
+	a.signaling.NotifyAuthorizedGuests(sessionID, session.SignalMessage{Type: "gitpanel_event", Payload: string(payload)})
+}
 
-	githubService, svcErr := a.requireGitHubServiceForPRs()
-	if svcErr != nil {
-		return false, svcErr
+// SessionStartPRReview abre o PR do host para revisão pelos guests com permissão de escrita. Os
+// comentários sugeridos só são publicados depois da aprovação do host.
+func (a *App) SessionStartPRReview(owner, repo string, prNumber int) (*sessionreview.Target, error) {
+	sess, err := a.requireHostedSession("revisar PRs com os guests")
+	if err != nil {
+		return nil, err
+	}
+	owner, repo = strings.TrimSpace(owner), strings.TrimSpace(repo)
+	if owner == "" || repo == "" || prNumber <= 0 {
+		return nil, fmt.Errorf("owner, repo e número do PR são obrigatórios")
 	}
 
-	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
-	if resolveErr != nil {
-		return false, resolveErr
+	target := sessionreview.Target{SessionID: sess.ID, Owner: owner, Repo: repo, PRNumber: prNumber}
+	target.URL = fmt.Sprintf("https://github.com/%s/%s/pull/%d", owner, repo, prNumber)
+	if a.awaitGitHub() {
+		if pr, prErr := a.github.GetPullRequest(a.requestContext(), owner, repo, prNumber); prErr == nil && pr != nil {
+			target.Title = pr.Title
+		}
 	}
+	target = a.prReviews.SetTarget(target)
+	a.auditSessionEvent(sess.ID, "host", "pr_review_started", fmt.Sprintf("%s/%s#%d", owner, repo, prNumber))
+	a.notifyPRReviewGuests(sess.ID, "started", &target)
+	return &target, nil
+}
 
-	merged, err := githubService.CheckPullRequestMerged(owner, repo, prNumber)
+// SessionStopPRReview encerra o modo de revisão; rascunhos não publicados são descartados.
+func (a *App) SessionStopPRReview() error {
+	sess, err := a.requireHostedSession("revisar PRs com os guests")
 	if err != nil {
-		return false, a.normalizeGitPanelPRError(err)
+		return err
 	}
-
-	return merged, nil
+	if !a.prReviews.Stop(sess.ID) {
+		return nil
+	}
+	a.auditSessionEvent(sess.ID, "host", "pr_review_stopped", "Host stopped the PR review mode")
+	a.notifyPRReviewGuests(sess.ID, "stopped", nil)
+	return nil
 }
 
-// GitPanelPRMerge executa merge de PR no repositorio alvo via GitHub REST.
-func (a *App) GitPanelPRMerge(repoPath string, prNumber int, payload GitPanelPRMergePayloadDTO) (GitPanelPRMergeResultDTO, error) {
-	if prNumber <= 0 {
-		return GitPanelPRMergeResultDTO{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Numero de Pull Request invalido.",
-			"Informe um numero de PR maior que zero.",
-		)
+// SessionListReviewDrafts lista, para o host, os rascunhos enviados pelos guests na sessão ativa.
+func (a *App) SessionListReviewDrafts() ([]sessionreview.Draft, error) {
+	sess, err := a.requireHostedSession("revisar PRs com os guests")
+	if err != nil {
+		return nil, err
 	}
+	return a.prReviews.List(sess.ID, ""), nil
+}
 
-	mergeInput, payloadErr := normalizeGitPanelPRMergePayload(payload)
-	if payloadErr != nil {
-		return GitPanelPRMergeResultDTO{}, payloadErr
+// SessionApproveReviewDraft publica o rascunho no PR com as credenciais do host, creditando o
+// guest no corpo do comentário. Em caso de falha o rascunho pode ser aprovado de novo.
+func (a *App) SessionApproveReviewDraft(draftID string) (*sessionreview.Draft, error) {
+	sess, err := a.requireHostedSession("revisar PRs com os guests")
+	if err != nil {
+		return nil, err
+	}
+	if !a.awaitGitHub() {
+		return nil, fmt.Errorf("GitHub não configurado: faça login para publicar comentários")
+	}
+	draft, err := a.prReviews.BeginPost(sess.ID, strings.TrimSpace(draftID))
+	if err != nil {
+		return nil, err
 	}
 
-	githubService, svcErr := a.requireGitHubServiceForPRs()
-	if svcErr != nil {
-		return GitPanelPRMergeResultDTO{}, svcErr
+	var comment *gh.Comment
+	body := sessionreview.AttributedBody(draft)
+	if draft.Path != "" {
+		comment, err = a.github.CreateInlineComment(a.requestContext(), gh.InlineCommentInput{
+			Owner: draft.Owner, Repo: draft.Repo, PRNumber: draft.PRNumber,
+			Body: body, Path: draft.Path, Line: draft.Line, Side: draft.Side,
+		})
+	} else {
+		comment, err = a.github.CreateComment(a.requestContext(), gh.CreateCommentInput{
+			Owner: draft.Owner, Repo: draft.Repo, PRNumber: draft.PRNumber, Body: body,
+		})
+	}
+	if err != nil {
+		failed, _ := a.prReviews.MarkFailed(sess.ID, draft.ID, err)
+		a.notifyReviewDraftStatus(failed)
+		return nil, err
 	}
 
-	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
-	if resolveErr != nil {
-		return GitPanelPRMergeResultDTO{}, resolveErr
+	commentID := ""
+	if comment != nil {
+		commentID = comment.ID
+	}
+	posted, err := a.prReviews.MarkPosted(sess.ID, draft.ID, commentID)
+	if err != nil {
+		return nil, err
 	}
+	a.auditSessionEvent(sess.ID, draft.Author.UserID, "pr_review_comment_posted", fmt.Sprintf("%s/%s#%d comment=%s", draft.Owner, draft.Repo, draft.PRNumber, commentID))
+	a.notifyReviewDraftStatus(posted)
+	a.emitGitPanelPRMutationRefresh(draft.Owner, draft.Repo, draft.PRNumber, "commented")
+	return &posted, nil
+}
 
-	mergeInput.Owner = owner
-	mergeInput.Repo = repo
-	mergeInput.Number = prNumber
+// SessionRejectReviewDraft descarta o rascunho; o motivo é repassado ao guest autor.
+func (a *App) SessionRejectReviewDraft(draftID string, reason string) (*sessionreview.Draft, error) {
+	sess, err := a.requireHostedSession("revisar PRs com os guests")
+	if err != nil {
+		return nil, err
+	}
+	rejected, err := a.prReviews.MarkRejected(sess.ID, strings.TrimSpace(draftID), reason)
+	if err != nil {
+		return nil, err
+	}
+	a.auditSessionEvent(sess.ID, rejected.Author.UserID, "pr_review_comment_rejected", fmt.Sprintf("%s/%s#%d", rejected.Owner, rejected.Repo, rejected.PRNumber))
+	a.notifyReviewDraftStatus(rejected)
+	return &rejected, nil
+}
 
-	result, err := githubService.MergePullRequestREST(mergeInput)
+// SessionGetPRReviewTarget lê, como guest, o PR aberto para revisão na sessão.
+func (a *App) SessionGetPRReviewTarget(sessionID string) (*sessionreview.Target, error) {
+	guestUser, sessionID, err := a.requirePRReviewGuest(sessionID)
 	if err != nil {
-		normalizedErr := a.normalizeGitPanelPRError(err)
-		a.logGitPanelPROperationError("merge", owner, repo, prNumber, normalizedErr)
-		return GitPanelPRMergeResultDTO{}, normalizedErr
+		return nil, err
 	}
-	if result == nil {
-		return GitPanelPRMergeResultDTO{}, nil
+	var target sessionreview.Target
+	if err := a.callSessionGateway(http.MethodGet, sessionreview.RequestPath(sessionreview.RouteTarget, sessionID, guestUser.ID), nil, &target); err != nil {
+		return nil, err
 	}
+	return &target, nil
+}
 
-	return GitPanelPRMergeResultDTO{
-		SHA:     strings.TrimSpace(result.SHA),
-		Merged:  result.Merged,
-		Message: strings.TrimSpace(result.Message),
-	}, nil
+// SessionSubmitReviewDraft envia, como guest, um comentário para aprovação do host.
+func (a *App) SessionSubmitReviewDraft(sessionID string, input sessionreview.DraftInput) (*sessionreview.Draft, error) {
+	guestUser, sessionID, err := a.requirePRReviewGuest(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sessionreview.NormalizeDraftInput(input); err != nil {
+		return nil, err
+	}
+	req := sessionreview.SubmitRequest{SessionID: sessionID, GuestUserID: guestUser.ID, Draft: input}
+	var draft sessionreview.Draft
+	if err := a.callSessionGateway(http.MethodPost, sessionreview.RouteDrafts, req, &draft); err != nil {
+		return nil, err
+	}
+	return &draft, nil
 }
 
-// GitPanelPRUpdateBranch solicita update da branch da PR alvo via GitHub REST.
-func (a *App) GitPanelPRUpdateBranch(repoPath string, prNumber int, payload GitPanelPRUpdateBranchPayloadDTO) (GitPanelPRUpdateBranchResultDTO, error) {
-	if prNumber <= 0 {
-		return GitPanelPRUpdateBranchResultDTO{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Numero de Pull Request invalido.",
-			"Informe um numero de PR maior que zero.",
-		)
+// SessionListMyReviewDrafts lista, como guest, os próprios rascunhos e a decisão do host.
+func (a *App) SessionListMyReviewDrafts(sessionID string) ([]sessionreview.Draft, error) {
+	guestUser, sessionID, err := a.requirePRReviewGuest(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	drafts := []sessionreview.Draft{}
+	if err := a.callSessionGateway(http.MethodGet, sessionreview.RequestPath(sessionreview.RouteDrafts, sessionID, guestUser.ID), nil, &drafts); err != nil {
+		return nil, err
 	}
+	return drafts, nil
+}
 
-	updateInput, payloadErr := normalizeGitPanelPRUpdateBranchPayload(payload)
-	if payloadErr != nil {
-		return GitPanelPRUpdateBranchResultDTO{}, payloadErr
+func (a *App) requirePRReviewGuest(sessionID string) (*auth.User, string, error) {
+	guestUser, err := a.requireGitHubSessionUser()
+	if err != nil {
+		return nil, "", err
+	}
+	sessionID = strings.TrimSpace(sessionID)
+	if sessionID == "" {
+		return nil, "", fmt.Errorf("sessionID is required")
 	}
+	return guestUser, sessionID, nil
+}
 
-	githubService, svcErr := a.requireGitHubServiceForPRs()
-	if svcErr != nil {
-		return GitPanelPRUpdateBranchResultDTO{}, svcErr
+// notifyPRReviewGuests avisa os guests autorizados que o modo de revisão começou ou terminou.
+func (a *App) notifyPRReviewGuests(sessionID string, state string, target *sessionreview.Target) {
+	if a.signaling == nil || !a.sessionGatewayOwner {
+		return
 	}
+	payload, err := json.Marshal(map[string]interface{}{"state": state, "target": target})
+	if err != nil {
+		return
+	}
+	a.signaling.NotifyAuthorizedGuests(sessionID, session.SignalMessage{Type: "review_mode_changed", Payload: string(payload)})
+}
 
-	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
-	if resolveErr != nil {
-		return GitPanelPRUpdateBranchResultDTO{}, resolveErr
+// notifyReviewDraftStatus informa ao guest autor a decisão do host sobre o rascunho.
+func (a *App) notifyReviewDraftStatus(draft sessionreview.Draft) {
+	if a.signaling == nil || !a.sessionGatewayOwner || draft.ID == "" {
+		return
 	}
+	payload, err := json.Marshal(draft)
+	if err != nil {
+		return
+	}
+	a.signaling.NotifyGuest(draft.SessionID, draft.Author.UserID, session.SignalMessage{Type: "review_draft_status", Payload: string(payload)})
+}
 
-	updateInput.Owner = owner
-	updateInput.Repo = repo
-	updateInput.Number = prNumber
+// SessionGetActive retorna a sessão ativa do host
+func (a *App) SessionGetActive() (*session.Session, error) {
+	hostUserID := a.resolveSessionHostUserID()
 
-	result, err := githubService.UpdatePullRequestBranch(updateInput)
-	if err != nil {
-		normalizedErr := a.normalizeGitPanelPRError(err)
-		a.logGitPanelPROperationError("update_branch", owner, repo, prNumber, normalizedErr)
-		return GitPanelPRUpdateBranchResultDTO{}, normalizedErr
+	// Instâncias em client-mode não devem hidratar sessão ativa como Host.
+	// Isso evita que um guest local assuma role=host por compartilhar o mesmo userID default.
+	if !a.sessionGatewayOwner {
+		return nil, fmt.Errorf("no active session in client mode")
 	}
-	if result == nil {
-		return GitPanelPRUpdateBranchResultDTO{}, nil
+	if a.session == nil {
+		return nil, fmt.Errorf("session service not initialized")
+	}
+	current, err := a.session.GetActiveSession(hostUserID)
+	if err == nil {
+		return current, nil
+	}
+	if strings.Contains(err.Error(), "no active session") {
+		return a.gatewayGetActiveSession(hostUserID)
 	}
-
-	return GitPanelPRUpdateBranchResultDTO{
-		Message: strings.TrimSpace(result.Message),
-	}, nil
+	return nil, err
 }
 
-// GitPanelPRGet retorna detalhes do Pull Request alvo.
-func (a *App) GitPanelPRGet(repoPath string, prNumber int) (gh.PullRequest, error) {
-	if prNumber <= 0 {
-		return gh.PullRequest{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Numero de Pull Request invalido.",
-			"Informe um numero de PR maior que zero.",
-		)
+// SessionGetSession retorna detalhes de uma sessão
+func (a *App) SessionGetSession(sessionID string) (*session.Session, error) {
+	if a.session == nil || !a.sessionGatewayOwner {
+		return a.gatewayGetSession(sessionID)
 	}
-
-	githubService, svcErr := a.requireGitHubServiceForPRs()
-	if svcErr != nil {
-		return gh.PullRequest{}, svcErr
+	current, err := a.session.GetSession(sessionID)
+	if err == nil {
+		return current, nil
+	}
+	if isSessionNotFoundErr(err) {
+		return a.gatewayGetSession(sessionID)
 	}
+	return nil, err
+}
 
-	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
-	if resolveErr != nil {
-		return gh.PullRequest{}, resolveErr
+// SessionGetICEServers retorna a configuração de ICE servers
+func (a *App) SessionGetICEServers() []session.ICEServerConfig {
+	if a.session == nil {
+		return []session.ICEServerConfig{}
 	}
+	return a.session.GetICEServers()
+}
 
-	pr, err := githubService.GetPullRequest(owner, repo, prNumber)
-	if err != nil {
-		return gh.PullRequest{}, a.normalizeGitPanelPRError(err)
+// SessionGetJoinSecurityMetrics retorna métricas agregadas de tentativas inválidas/bloqueios de join.
+func (a *App) SessionGetJoinSecurityMetrics() session.JoinSecurityMetrics {
+	var metrics session.JoinSecurityMetrics
+	if a.session == nil {
+		return metrics
 	}
-	if pr == nil {
-		return gh.PullRequest{}, nil
+	if a.sessionGatewayOwner {
+		return a.session.GetJoinSecurityMetrics()
 	}
-
-	return *pr, nil
+	if err := a.callSessionGateway(http.MethodGet, "/api/session/metrics/join-security", nil, &metrics); err != nil {
+		log.Printf("[SESSION][SECURITY] unable to fetch join metrics from gateway: %v", err)
+	}
+	return metrics
 }
 
-// GitPanelPRGetCommits retorna commits paginados da PR alvo.
-func (a *App) GitPanelPRGetCommits(repoPath string, prNumber int, page int, perPage int) (gh.PRCommitPage, error) {
-	if prNumber <= 0 {
-		return gh.PRCommitPage{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Numero de Pull Request invalido.",
-			"Informe um numero de PR maior que zero.",
-		)
+// SessionGetAuditLogs retorna os eventos de auditoria de uma sessão.
+func (a *App) SessionGetAuditLogs(sessionID string, limit int) ([]database.AuditLog, error) {
+	if a.db == nil {
+		return []database.AuditLog{}, nil
 	}
+	return a.db.ListAuditEvents(sessionID, limit)
+}
 
-	githubService, svcErr := a.requireGitHubServiceForPRs()
-	if svcErr != nil {
-		return gh.PRCommitPage{}, svcErr
-	}
+// maxSessionAnalyticsEvents limita os eventos de auditoria lidos por SessionGetAnalytics.
+const maxSessionAnalyticsEvents = 20000
 
-	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
-	if resolveErr != nil {
-		return gh.PRCommitPage{}, resolveErr
+// SessionGetAnalytics resume uma sessão para retrospectivas: duração, tempo conectado e
+// comandos por guest, mudanças de permissão, reinícios do Docker e sinais de higiene de acesso.
+func (a *App) SessionGetAnalytics(sessionID string) (session.Analytics, error) {
+	sessionID = strings.TrimSpace(sessionID)
+	if sessionID == "" {
+		return session.Analytics{}, fmt.Errorf("session id is required")
 	}
-
-	pageResult, err := githubService.GetPullRequestCommits(owner, repo, prNumber, page, perPage)
+	if a.db == nil {
+		return session.BuildAnalytics(sessionID, nil, false, time.Now()), nil
+	}
+	logs, err := a.db.ListAuditEvents(sessionID, maxSessionAnalyticsEvents)
 	if err != nil {
-		return gh.PRCommitPage{}, a.normalizeGitPanelPRError(err)
+		return session.Analytics{}, err
+	}
+	events := make([]session.AuditEvent, 0, len(logs))
+	for i := len(logs) - 1; i >= 0; i-- {
+		events = append(events, session.AuditEvent{
+			UserID:  logs[i].UserID,
+			Action:  logs[i].Action,
+			Details: logs[i].Details,
+			At:      logs[i].CreatedAt,
+		})
 	}
-	if pageResult == nil {
-		return gh.PRCommitPage{}, nil
+	active := false
+	if a.session != nil && a.sessionGatewayOwner {
+		if current, err := a.session.GetActiveSession(a.resolveSessionHostUserID()); err == nil && current != nil {
+			active = current.ID == sessionID && current.Status != session.StatusEnded
+		}
 	}
-
-	return *pageResult, nil
+	return session.BuildAnalytics(sessionID, events, active, time.Now()), nil
 }
 
-// GitPanelPRGetFiles retorna arquivos paginados da PR alvo.
-func (a *App) GitPanelPRGetFiles(repoPath string, prNumber int, page int, perPage int) (gh.PRFilePage, error) {
-	if prNumber <= 0 {
-		return gh.PRFilePage{}, gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Numero de Pull Request invalido.",
-			"Informe um numero de PR maior que zero.",
-		)
+// SessionSearchAuditLogs busca eventos de auditoria para investigar incidentes de colaboração.
+// actionFilter aceita ações separadas por vírgula (sufixo "*" = prefixo); sessionID vazio busca em todas.
+func (a *App) SessionSearchAuditLogs(
+	sessionID string,
+	actionFilter string,
+	textQuery string,
+	timeRange database.AuditLogTimeRange,
+	pagination database.AuditLogPagination,
+) (*database.AuditLogPage, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
 	}
-
-	githubService, svcErr := a.requireGitHubServiceForPRs()
-	if svcErr != nil {
-		return gh.PRFilePage{}, svcErr
+	if timeRange.From != nil && timeRange.To != nil && timeRange.From.After(*timeRange.To) {
+		return nil, fmt.Errorf("invalid time range: from is after to")
 	}
+	return a.db.SearchAuditLogs(database.AuditLogQuery{
+		SessionID:  sessionID,
+		Actions:    strings.Split(actionFilter, ","),
+		Text:       textQuery,
+		TimeRange:  timeRange,
+		Pagination: pagination,
+	})
+}
 
-	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
-	if resolveErr != nil {
-		return gh.PRFilePage{}, resolveErr
-	}
+// AuditRetentionPolicy define a retenção global de auditoria (0 = sem limite).
+type AuditRetentionPolicy struct {
+	RetentionDays int `json:"retentionDays"`
+	MaxRows       int `json:"maxRows"`
+}
 
-	pageResult, err := githubService.GetPullRequestFiles(owner, repo, prNumber, page, perPage)
-	if err != nil {
-		return gh.PRFilePage{}, a.normalizeGitPanelPRError(err)
+const (
+	maxAuditRetentionDays = 3650
+	minAuditMaxRows       = 100
+)
+
+// GetAuditRetentionPolicy retorna a política de retenção de auditoria.
+func (a *App) GetAuditRetentionPolicy() (AuditRetentionPolicy, error) {
+	if a.db == nil {
+		return AuditRetentionPolicy{}, fmt.Errorf("database not initialized")
 	}
-	if pageResult == nil {
-		return gh.PRFilePage{}, nil
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return AuditRetentionPolicy{}, err
 	}
-
-	return *pageResult, nil
+	return AuditRetentionPolicy{RetentionDays: cfg.AuditRetentionDays, MaxRows: cfg.AuditMaxRows}, nil
 }
 
-// GitPanelPRGetRawDiff retorna diff completo bruto da PR alvo sob demanda.
-func (a *App) GitPanelPRGetRawDiff(repoPath string, prNumber int) (string, error) {
-	if prNumber <= 0 {
-		return "", gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Numero de Pull Request invalido.",
-			"Informe um numero de PR maior que zero.",
-		)
+// SetAuditRetentionPolicy persiste a retenção e já remove o que estiver fora dela.
+func (a *App) SetAuditRetentionPolicy(policy AuditRetentionPolicy) (AuditRetentionPolicy, error) {
+	if a.db == nil {
+		return AuditRetentionPolicy{}, fmt.Errorf("database not initialized")
 	}
-
-	githubService, svcErr := a.requireGitHubServiceForPRs()
-	if svcErr != nil {
-		return "", svcErr
+	if policy.RetentionDays < 0 || policy.RetentionDays > maxAuditRetentionDays {
+		return AuditRetentionPolicy{}, fmt.Errorf("retention days must be between 0 and %d", maxAuditRetentionDays)
 	}
-
-	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
-	if resolveErr != nil {
-		return "", resolveErr
+	if policy.MaxRows < 0 || (policy.MaxRows > 0 && policy.MaxRows < minAuditMaxRows) {
+		return AuditRetentionPolicy{}, fmt.Errorf("max rows must be 0 (unlimited) or at least %d", minAuditMaxRows)
 	}
+	if err := a.db.SetAuditRetention(policy.RetentionDays, policy.MaxRows); err != nil {
+		return AuditRetentionPolicy{}, err
+	}
+	a.pruneAuditLogs()
+	return policy, nil
+}
 
-	rawDiff, err := githubService.GetPullRequestRawDiff(owner, repo, prNumber)
+// pruneAuditLogs aplica a retenção configurada (executado no startup).
+func (a *App) pruneAuditLogs() {
+	if a.db == nil {
+		return
+	}
+	cfg, err := a.db.GetConfig()
 	if err != nil {
-		return "", a.normalizeGitPanelPRError(err)
+		log.Printf("[AUDIT] could not load retention policy: %v", err)
+		return
+	}
+	removed, err := a.db.PruneAuditLogs(cfg.AuditRetentionDays, cfg.AuditMaxRows)
+	if err != nil {
+		log.Printf("[AUDIT] pruning failed: %v", err)
+		return
+	}
+	if removed > 0 {
+		log.Printf("[AUDIT] pruned %d events (retention=%dd max=%d)", removed, cfg.AuditRetentionDays, cfg.AuditMaxRows)
 	}
-
-	return rawDiff, nil
 }
 
-// GitPanelPRGetCommitRawDiff retorna diff bruto de um commit especifico da PR alvo.
-func (a *App) GitPanelPRGetCommitRawDiff(repoPath string, prNumber int, commitSHA string) (string, error) {
-	if prNumber <= 0 {
-		return "", gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Numero de Pull Request invalido.",
-			"Informe um numero de PR maior que zero.",
-		)
+// SessionRestartEnvironment reinicia o container associado à sessão (modo Docker).
+func (a *App) SessionRestartEnvironment(sessionID string) error {
+	if a.docker == nil {
+		return fmt.Errorf("docker service not initialized")
 	}
 
-	normalizedSHA := strings.TrimSpace(commitSHA)
-	if normalizedSHA == "" {
-		return "", gpr.NewBindingError(
-			gpr.CodeValidationFailed,
-			"Commit SHA invalido.",
-			"Informe um commit SHA valido para carregar o diff.",
-		)
+	containerID, ok := a.getSessionContainer(sessionID)
+	if !ok {
+		return fmt.Errorf("no container found for session %s", sessionID)
 	}
 
-	githubService, svcErr := a.requireGitHubServiceForPRs()
-	if svcErr != nil {
-		return "", svcErr
+	if err := a.docker.RestartContainer(containerID); err != nil {
+		return err
 	}
+	a.auditSessionEvent(sessionID, "host", "container_restarted", fmt.Sprintf("container=%s", containerID))
+	return nil
+}
 
-	owner, repo, resolveErr := a.resolveGitPanelPROwnerRepo(repoPath)
-	if resolveErr != nil {
-		return "", resolveErr
+// DockerIsAvailable informa se Docker está disponível e operacional.
+func (a *App) DockerIsAvailable() bool {
+	if a.docker == nil {
+		return false
 	}
+	return a.docker.IsDockerAvailable()
+}
 
-	rawDiff, err := githubService.GetCommitRawDiff(owner, repo, normalizedSHA)
-	if err != nil {
-		return "", a.normalizeGitPanelPRError(err)
+// DockerDetectImage detecta automaticamente a imagem recomendada para um projeto.
+func (a *App) DockerDetectImage(projectPath string) string {
+	if a.docker == nil {
+		return "alpine:latest"
 	}
-
-	return rawDiff, nil
+	return a.docker.DetectImage(projectPath)
 }
 
-// GitPanelPickRepositoryDirectory abre o seletor nativo para escolher diretório do repositório.
-func (a *App) GitPanelPickRepositoryDirectory(defaultPath string) (string, error) {
-	if a.ctx == nil {
-		return "", gp.NewBindingError(
-			gp.CodeServiceUnavailable,
-			"Janela indisponível para seleção de diretório.",
-			"Contexto do runtime ainda não foi inicializado.",
-		)
-	}
+// === Kubernetes Bindings (expostos ao Frontend) ===
 
-	defaultDirectory := resolveExistingDirectory(defaultPath)
-	selectedViaAppleScript, appleScriptErr := pickDirectoryWithAppleScript("Selecionar repositório Git", defaultDirectory)
-	if appleScriptErr == nil {
-		return strings.TrimSpace(selectedViaAppleScript), nil
-	}
+const kubePodAgentType = "kube_pod"
 
-	options := runtime.OpenDialogOptions{
-		Title:                "Selecionar repositório Git",
-		ShowHiddenFiles:      true,
-		CanCreateDirectories: true,
-	}
-	if defaultDirectory != "" {
-		options.DefaultDirectory = defaultDirectory
+func (a *App) requireKubeService() (*kube.Service, error) {
+	if a.kube == nil || !a.kube.IsAvailable() {
+		return nil, fmt.Errorf("kubectl not available")
 	}
+	return a.kube, nil
+}
 
-	selectedPath, err := runtime.OpenDirectoryDialog(a.ctx, options)
+// KubeIsAvailable indica se o kubectl está disponível no host.
+func (a *App) KubeIsAvailable() bool {
+	return a.kube != nil && a.kube.IsAvailable()
+}
+
+// KubeListContexts lista os contextos do kubeconfig.
+func (a *App) KubeListContexts() ([]kube.Context, error) {
+	svc, err := a.requireKubeService()
 	if err != nil {
-		details := strings.TrimSpace(err.Error())
-		if appleScriptErr != nil && !errors.Is(appleScriptErr, exec.ErrNotFound) {
-			details = fmt.Sprintf("apple script: %s | runtime: %s", strings.TrimSpace(appleScriptErr.Error()), details)
-		}
-		return "", gp.NewBindingError(
-			gp.CodeCommandFailed,
-			"Falha ao abrir seletor de diretório.",
-			details,
-		)
+		return nil, err
 	}
-
-	return strings.TrimSpace(selectedPath), nil
+	return svc.ListContexts()
 }
 
-func resolveExistingDirectory(rawPath string) string {
-	trimmed := strings.TrimSpace(rawPath)
-	if trimmed == "" {
-		return ""
+// KubeListNamespaces lista os namespaces de um contexto.
+func (a *App) KubeListNamespaces(kubeContext string) ([]string, error) {
+	svc, err := a.requireKubeService()
+	if err != nil {
+		return nil, err
 	}
+	return svc.ListNamespaces(strings.TrimSpace(kubeContext))
+}
 
-	cleaned := filepath.Clean(trimmed)
-	info, err := os.Stat(cleaned)
-	if err == nil {
-		if info.IsDir() {
-			return cleaned
-		}
-		parent := filepath.Dir(cleaned)
-		if parentInfo, parentErr := os.Stat(parent); parentErr == nil && parentInfo.IsDir() {
-			return parent
-		}
-		return ""
+// KubeListPods lista os pods de um namespace com estado por container.
+func (a *App) KubeListPods(kubeContext string, namespace string) ([]kube.Pod, error) {
+	svc, err := a.requireKubeService()
+	if err != nil {
+		return nil, err
 	}
+	return svc.ListPods(strings.TrimSpace(kubeContext), strings.TrimSpace(namespace))
+}
 
-	parent := filepath.Dir(cleaned)
-	if parent == "" || parent == "." {
-		return ""
+func normalizeKubePodTarget(target kube.PodTarget) (kube.PodTarget, error) {
+	target.Context = strings.TrimSpace(target.Context)
+	target.Namespace = strings.TrimSpace(target.Namespace)
+	target.Pod = strings.TrimSpace(target.Pod)
+	target.Container = strings.TrimSpace(target.Container)
+	target.Shell = strings.TrimSpace(target.Shell)
+	if target.Namespace == "" {
+		target.Namespace = "default"
 	}
-	if parentInfo, parentErr := os.Stat(parent); parentErr == nil && parentInfo.IsDir() {
-		return parent
+	if err := kube.ValidateTarget(target); err != nil {
+		return kube.PodTarget{}, err
 	}
-	return ""
+	return target, nil
 }
 
-func pickDirectoryWithAppleScript(title string, defaultDirectory string) (string, error) {
-	if _, err := exec.LookPath("osascript"); err != nil {
-		return "", err
+func decodeAgentKubeTarget(agent *database.AgentSession) (kube.PodTarget, error) {
+	if agent == nil || agent.Type != kubePodAgentType {
+		return kube.PodTarget{}, fmt.Errorf("agent is not a kube pod terminal")
 	}
-
-	command := "set selectedFolder to choose folder"
-	trimmedTitle := strings.TrimSpace(title)
-	if trimmedTitle != "" {
-		command += " with prompt " + quoteAppleScriptString(trimmedTitle)
+	var target kube.PodTarget
+	if err := json.Unmarshal([]byte(agent.KubeTarget), &target); err != nil {
+		return kube.PodTarget{}, fmt.Errorf("invalid kube target for agent %d: %w", agent.ID, err)
 	}
+	return normalizeKubePodTarget(target)
+}
 
-	trimmedDefault := strings.TrimSpace(defaultDirectory)
-	if trimmedDefault != "" {
-		command += " default location POSIX file " + quoteAppleScriptString(trimmedDefault)
+// CreateKubePodAgent cria um agente do tipo kube_pod vinculado ao pod/container informado.
+func (a *App) CreateKubePodAgent(workspaceID uint, name string, target kube.PodTarget) (*database.AgentSession, error) {
+	normalized, err := normalizeKubePodTarget(target)
+	if err != nil {
+		return nil, err
 	}
 
-	output, err := exec.Command(
-		"osascript",
-		"-e", command,
-		"-e", "POSIX path of selectedFolder",
-	).CombinedOutput()
-	if err != nil {
-		details := strings.TrimSpace(string(output))
-		lowerDetails := strings.ToLower(details)
-		if strings.Contains(lowerDetails, "user canceled") || strings.Contains(lowerDetails, "user cancelled") {
-			return "", nil
-		}
-		if details == "" {
-			details = strings.TrimSpace(err.Error())
+	if strings.TrimSpace(name) == "" {
+		name = normalized.Pod
+		if normalized.Container != "" {
+			name += "/" + normalized.Container
 		}
-		return "", errors.New(details)
 	}
 
-	return strings.TrimSpace(string(output)), nil
+	agent, err := a.CreateAgentSession(workspaceID, name, kubePodAgentType)
+	if err != nil {
+		return nil, err
+	}
+	return a.SetKubePodAgentTarget(agent.ID, normalized)
 }
 
-func quoteAppleScriptString(value string) string {
-	escaped := strings.ReplaceAll(value, "\\", "\\\\")
-	escaped = strings.ReplaceAll(escaped, "\"", "\\\"")
-	return "\"" + escaped + "\""
-}
+// SetKubePodAgentTarget atualiza o alvo (ex: seleção de container) de um agente kube_pod.
+func (a *App) SetKubePodAgentTarget(agentID uint, target kube.PodTarget) (*database.AgentSession, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
 
-// GitPanelGetStatus retorna snapshot de status staged/unstaged/conflicted.
-func (a *App) GitPanelGetStatus(repoPath string) (gp.StatusDTO, error) {
-	svc, err := a.requireGitPanelService()
+	agent, err := a.db.GetAgent(agentID)
 	if err != nil {
-		return gp.StatusDTO{}, err
+		return nil, err
+	}
+	if agent.Type != kubePodAgentType {
+		return nil, fmt.Errorf("agent %d is not a kube pod terminal", agentID)
 	}
 
-	result, statusErr := svc.GetStatus(repoPath)
-	if statusErr != nil {
-		return gp.StatusDTO{}, a.normalizeGitPanelBindingError(statusErr)
+	normalized, err := normalizeKubePodTarget(target)
+	if err != nil {
+		return nil, err
 	}
-	return result, nil
+	encoded, err := json.Marshal(normalized)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.db.UpdateAgentKubeTarget(agentID, string(encoded)); err != nil {
+		return nil, err
+	}
+	return a.db.GetAgent(agentID)
 }
 
-// GitPanelGetHistory retorna página de histórico linear.
-func (a *App) GitPanelGetHistory(repoPath string, cursor string, limit int, query string) (gp.HistoryPageDTO, error) {
-	svc, err := a.requireGitPanelService()
+// CreateTerminalForKubePod abre (ou reutiliza) o terminal exec do pod vinculado ao agente.
+// Pods em crash abrem um terminal de streaming de logs no lugar do exec.
+func (a *App) CreateTerminalForKubePod(agentID uint, cols uint16, rows uint16) (string, error) {
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	svc, err := a.requireKubeService()
 	if err != nil {
-		return gp.HistoryPageDTO{}, err
+		return "", err
 	}
 
-	result, historyErr := svc.GetHistory(repoPath, cursor, limit, query)
-	if historyErr != nil {
-		return gp.HistoryPageDTO{}, a.normalizeGitPanelBindingError(historyErr)
+	agent, err := a.db.GetAgent(agentID)
+	if err != nil {
+		return "", err
 	}
-
-	repoRoot := strings.TrimSpace(repoPath)
-	if preflight, preflightErr := svc.Preflight(repoPath); preflightErr == nil {
-		if normalized := strings.TrimSpace(preflight.RepoRoot); normalized != "" {
-			repoRoot = normalized
-		}
+	if agent.SessionID != "" && a.bridge != nil && a.bridge.IsTerminalAlive(agent.SessionID) {
+		a.bindTerminalToAgent(agent.SessionID, agent.ID)
+		return agent.SessionID, nil
 	}
 
-	a.enrichGitPanelHistoryWithAuthIdentity(repoRoot, &result)
-	return result, nil
-}
+	target, err := decodeAgentKubeTarget(agent)
+	if err != nil {
+		return "", err
+	}
 
-func (a *App) enrichGitPanelHistoryWithAuthIdentity(repoRoot string, page *gp.HistoryPageDTO) {
-	if page == nil || len(page.Items) == 0 {
-		return
+	script := svc.BuildExecScript(target, kube.DefaultReconnectAttempts)
+	if pod, podErr := svc.GetPod(target.Context, target.Namespace, target.Pod); podErr != nil {
+		log.Printf("[ORCH][KUBE] unable to inspect pod %s/%s: %v", target.Namespace, target.Pod, podErr)
+	} else if pod.ContainerCrashed(target.Container) {
+		log.Printf("[ORCH][KUBE] pod %s/%s crashed; opening logs stream", target.Namespace, target.Pod)
+		script = svc.BuildLogsScript(target)
 	}
 
-	a.applyLocalAuthIdentityToHistory(page)
+	sessionID, err := a.createTerminalWithArgs("/bin/sh", []string{"-c", script}, nil, agent.Cwd, false, "", cols, rows)
+	if err != nil {
+		return "", err
+	}
 
-	pendingHashes := a.applyCachedGitHubAuthorsToHistory(repoRoot, page)
-	if len(pendingHashes) == 0 {
-		return
+	effectiveCwd := strings.TrimSpace(agent.Cwd)
+	if effectiveCwd == "" {
+		effectiveCwd, _ = os.UserHomeDir()
+	}
+	if err := a.db.UpdateAgentRuntime(agent.ID, sessionID, "/bin/sh", effectiveCwd, false, "running"); err != nil {
+		a.killTerminalSession(sessionID)
+		return "", err
 	}
 
-	a.queueGitHubAuthorEnrichment(repoRoot, pendingHashes)
+	a.bindTerminalToAgent(sessionID, agent.ID)
+	return sessionID, nil
 }
 
-func (a *App) applyLocalAuthIdentityToHistory(page *gp.HistoryPageDTO) {
-	if page == nil || len(page.Items) == 0 || a.auth == nil {
-		return
+// ReconnectKubePodTerminal encerra o terminal atual do agente e abre um novo exec no pod.
+func (a *App) ReconnectKubePodTerminal(agentID uint, cols uint16, rows uint16) (string, error) {
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
 	}
-
-	user, err := a.auth.GetCurrentUser()
-	if err != nil || user == nil {
-		return
+	agent, err := a.db.GetAgent(agentID)
+	if err != nil {
+		return "", err
 	}
-	if !strings.EqualFold(strings.TrimSpace(user.Provider), "github") {
-		return
+	if agent.SessionID != "" {
+		a.killTerminalSession(agent.SessionID)
 	}
+	return a.CreateTerminalForKubePod(agentID, cols, rows)
+}
 
-	login := strings.TrimSpace(user.Username)
-	if login == "" {
-		login = strings.TrimSpace(user.Name)
+// OpenKubePodLogs abre um terminal avulso com os logs do pod (previous=true para o container anterior ao crash).
+func (a *App) OpenKubePodLogs(target kube.PodTarget, previous bool, cols uint16, rows uint16) (string, error) {
+	svc, err := a.requireKubeService()
+	if err != nil {
+		return "", err
 	}
-	avatarURL := strings.TrimSpace(user.AvatarURL)
-	if login == "" && avatarURL == "" {
-		return
+	normalized, err := normalizeKubePodTarget(target)
+	if err != nil {
+		return "", err
 	}
 
-	emailToken := normalizeGitPanelIdentityToken(user.Email)
-	nameToken := normalizeGitPanelIdentityToken(user.Name)
-	usernameToken := normalizeGitPanelIdentityToken(user.Username)
-
-	for index := range page.Items {
-		item := &page.Items[index]
-		if !historyItemMatchesAuthUser(item, emailToken, nameToken, usernameToken) {
-			continue
-		}
-		item.GitHubLogin = login
-		item.GitHubAvatarURL = avatarURL
-	}
+	args := svc.LogsArgs(normalized, previous, !previous)
+	return a.createTerminalWithArgs("kubectl", args, nil, "", false, "", cols, rows)
 }
 
-func (a *App) applyCachedGitHubAuthorsToHistory(repoRoot string, page *gp.HistoryPageDTO) []string {
-	if page == nil || len(page.Items) == 0 {
-		return nil
-	}
+// === Auth Bindings (expostos ao Frontend) ===
 
-	normalizedRoot := filepath.Clean(strings.TrimSpace(repoRoot))
-	if normalizedRoot == "" {
-		return nil
+// AuthLogin inicia o fluxo de login OAuth
+func (a *App) AuthLogin(provider string) error {
+	if a.auth == nil {
+		return fmt.Errorf("auth service not initialized")
 	}
 
-	now := time.Now()
-	pending := make([]string, 0, gitPanelAuthorLookupPerRequest)
-
-	a.gitPanelAuthorMu.Lock()
-	defer a.gitPanelAuthorMu.Unlock()
-
-	for index := range page.Items {
-		item := &page.Items[index]
-		hash := normalizeGitPanelCommitHash(item.Hash)
-		if hash == "" {
-			continue
-		}
-		if strings.TrimSpace(item.GitHubLogin) != "" && strings.TrimSpace(item.GitHubAvatarURL) != "" {
-			continue
+	// Configurar handler de callback
+	callbackHandler := func(result *auth.AuthResult) {
+		if result == nil {
+			runtime.EventsEmit(a.ctx, "auth:error", "Authentication failed: no result")
+			return
 		}
 
-		cacheKey := buildGitPanelCommitAuthorCacheKey(normalizedRoot, hash)
-		if cached, ok := a.gitPanelAuthorCache[cacheKey]; ok {
-			if now.After(cached.expiresAt) {
-				delete(a.gitPanelAuthorCache, cacheKey)
-			} else {
-				if cached.found {
-					if strings.TrimSpace(item.GitHubLogin) == "" {
-						item.GitHubLogin = cached.login
-					}
-					if strings.TrimSpace(item.GitHubAvatarURL) == "" {
-						item.GitHubAvatarURL = cached.avatarURL
-					}
-				}
-				continue
-			}
+		if result.Success {
+			log.Println("[ORCH] Auth success via local callback!")
+			runtime.EventsEmit(a.ctx, "auth:changed", a.auth.GetAuthState())
+			runtime.WindowShow(a.ctx)
+		} else {
+			log.Printf("[ORCH] Auth failed: %s", result.Error)
+			runtime.EventsEmit(a.ctx, "auth:error", result.Error)
 		}
+	}
 
-		if len(pending) >= gitPanelAuthorLookupPerRequest {
-			continue
-		}
-		if _, inFlight := a.gitPanelAuthorInFlight[cacheKey]; inFlight {
-			continue
-		}
+	// Iniciar servidor de callback
+	_, err := a.auth.StartCallbackServer(callbackHandler)
+	if err != nil {
+		return fmt.Errorf("failed to start callback server: %w", err)
+	}
 
-		a.gitPanelAuthorInFlight[cacheKey] = struct{}{}
-		pending = append(pending, hash)
+	url, err := a.auth.GetAuthURL(provider)
+	if err != nil {
+		a.auth.StopCallbackServer()
+		return err
 	}
 
-	return pending
+	// Abrir URL no navegador padrão
+	runtime.BrowserOpenURL(a.ctx, url)
+	return nil
 }
 
-func (a *App) queueGitHubAuthorEnrichment(repoRoot string, hashes []string) {
-	normalizedRoot := filepath.Clean(strings.TrimSpace(repoRoot))
-	if normalizedRoot == "" || len(hashes) == 0 {
-		a.releaseGitPanelAuthorInFlight(normalizedRoot, hashes)
-		return
+// AuthLogout faz logout do usuário
+func (a *App) AuthLogout() error {
+	if a.auth == nil {
+		return nil
 	}
-	if a.github == nil || a.auth == nil {
-		a.releaseGitPanelAuthorInFlight(normalizedRoot, hashes)
-		return
+
+	if err := a.auth.Logout(); err != nil {
+		return err
 	}
+	a.invalidateGitHubAccountRoutes()
 
-	authState := a.auth.GetAuthState()
-	if authState == nil || !authState.IsAuthenticated || !authState.HasGitHubToken {
-		a.releaseGitPanelAuthorInFlight(normalizedRoot, hashes)
-		return
+	runtime.EventsEmit(a.ctx, "auth:changed", a.auth.GetAuthState())
+	return nil
+}
+
+// AuthReauthorizeForOrg abre o navegador para autorizar o token GitHub via SAML SSO na org.
+// Usa a URL do último desafio detectado (X-GitHub-SSO) ou a página SSO padrão da org.
+func (a *App) AuthReauthorizeForOrg(org string) (string, error) {
+	org = strings.TrimSpace(org)
+	if !gitHubOrgNameRegex.MatchString(org) {
+		return "", fmt.Errorf("invalid GitHub organization: %q", org)
+	}
+
+	authorizationURL := "https://github.com/orgs/" + org + "/sso"
+	if a.awaitGitHub() {
+		if challengeURL, ok := a.github.SSOAuthorizationURL(org); ok && challengeURL != "" {
+			authorizationURL = challengeURL
+		}
+		a.github.ClearSSOChallenge(org)
+	}
+
+	if a.ctx != nil {
+		runtime.BrowserOpenURL(a.ctx, authorizationURL)
+	}
+	return authorizationURL, nil
+}
+
+// GetAuthState retorna o estado atual de autenticação
+func (a *App) GetAuthState() *auth.AuthState {
+	if a.auth == nil {
+		return &auth.AuthState{IsAuthenticated: false}
 	}
+	return a.auth.GetAuthState()
+}
 
-	go a.resolveGitHubAuthorsForHistory(normalizedRoot, hashes)
+// === Deep Link Bindings ===
+
+// deepLinkConfirmTTL limita quanto tempo um link aguardando confirmação continua válido.
+const deepLinkConfirmTTL = 5 * time.Minute
+
+// DeepLinkRequestDTO é um link aguardando confirmação (evento "deeplink:confirm_requested").
+type DeepLinkRequestDTO struct {
+	ID          string        `json:"id"`
+	Summary     string        `json:"summary"`
+	Link        deeplink.Link `json:"link"`
+	RequestedAt time.Time     `json:"requestedAt"`
 }
 
-func (a *App) resolveGitHubAuthorsForHistory(repoRoot string, hashes []string) {
-	defer a.releaseGitPanelAuthorInFlight(repoRoot, hashes)
+// DeepLinkOpenRepoDTO é o payload de "deeplink:open_repo" depois que o workspace foi ativado.
+type DeepLinkOpenRepoDTO struct {
+	WorkspaceID uint   `json:"workspaceId"`
+	Path        string `json:"path"`
+	Created     bool   `json:"created"`
+}
 
-	owner, repo, ok := a.resolveGitHubOwnerRepo(repoRoot)
-	if !ok {
-		a.cacheGitPanelAuthorMisses(repoRoot, hashes)
+// HandleDeepLink processa links orch:// (chamado pelo macOS). Ações que mudam estado ficam
+// pendentes até RespondDeepLink; as demais viram eventos "deeplink:*" para o frontend.
+func (a *App) HandleDeepLink(urlStr string) {
+	log.Printf("[ORCH] Deep Link received: %s", a.sanitizeForLogs(urlStr))
+
+	link, err := deeplink.Parse(urlStr)
+	if err != nil {
+		log.Printf("[ORCH] Ignored invalid deep link: %v", err)
+		a.emitDeepLinkEvent("deeplink:error", err.Error())
 		return
 	}
 
-	resolvedAuthors, err := a.github.ResolveCommitAuthors(owner, repo, hashes)
-	if err != nil {
-		log.Printf("[ORCH][GitPanel] resolve commit authors failed repo=%s remote=%s/%s err=%v", repoRoot, owner, repo, err)
+	if link.RequiresConfirmation() {
+		request := a.queueDeepLink(link)
+		a.emitDeepLinkEvent("deeplink:confirm_requested", request)
+		a.showWindowForDeepLink()
 		return
 	}
+	if err := a.runDeepLink(link); err != nil {
+		log.Printf("[ORCH] Deep link %s failed: %v", link.Action, err)
+		a.emitDeepLinkEvent("deeplink:error", err.Error())
+	}
+}
 
-	a.applyResolvedGitHubAuthors(repoRoot, hashes, resolvedAuthors)
+// GetPendingDeepLinks lista os links aguardando confirmação (links recebidos antes do frontend montar).
+func (a *App) GetPendingDeepLinks() []DeepLinkRequestDTO {
+	a.deepLinksMu.Lock()
+	defer a.deepLinksMu.Unlock()
+	a.pruneDeepLinksLocked(time.Now())
+	pending := make([]DeepLinkRequestDTO, 0, len(a.deepLinks))
+	for _, request := range a.deepLinks {
+		pending = append(pending, request)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].RequestedAt.Before(pending[j].RequestedAt) })
+	return pending
 }
 
-func (a *App) applyResolvedGitHubAuthors(repoRoot string, requestedHashes []string, resolved map[string]gh.User) {
-	normalizedRoot := filepath.Clean(strings.TrimSpace(repoRoot))
-	if normalizedRoot == "" || len(requestedHashes) == 0 {
-		return
+// RespondDeepLink confirma ou descarta um link pendente; confirmado, a ação é executada.
+func (a *App) RespondDeepLink(id string, allow bool) error {
+	a.deepLinksMu.Lock()
+	a.pruneDeepLinksLocked(time.Now())
+	request, ok := a.deepLinks[id]
+	delete(a.deepLinks, id)
+	a.deepLinksMu.Unlock()
+	if !ok {
+		return fmt.Errorf("deep link not found or expired: %s", id)
+	}
+	if !allow {
+		log.Printf("[ORCH] Deep link %s dismissed by the user", request.Link.Action)
+		return nil
 	}
+	return a.runDeepLink(request.Link)
+}
 
-	now := time.Now()
-	updates := make([]map[string]string, 0, len(requestedHashes))
+func (a *App) queueDeepLink(link deeplink.Link) DeepLinkRequestDTO {
+	request := DeepLinkRequestDTO{
+		ID:          uuid.NewString(),
+		Summary:     link.Summary(),
+		Link:        link,
+		RequestedAt: time.Now(),
+	}
+	a.deepLinksMu.Lock()
+	defer a.deepLinksMu.Unlock()
+	a.pruneDeepLinksLocked(request.RequestedAt)
+	if a.deepLinks == nil {
+		a.deepLinks = make(map[string]DeepLinkRequestDTO)
+	}
+	a.deepLinks[request.ID] = request
+	return request
+}
 
-	a.gitPanelAuthorMu.Lock()
-	for _, rawHash := range requestedHashes {
-		hash := normalizeGitPanelCommitHash(rawHash)
-		if hash == "" {
-			continue
+func (a *App) pruneDeepLinksLocked(now time.Time) {
+	for id, request := range a.deepLinks {
+		if now.Sub(request.RequestedAt) > deepLinkConfirmTTL {
+			delete(a.deepLinks, id)
 		}
+	}
+}
 
-		cacheKey := buildGitPanelCommitAuthorCacheKey(normalizedRoot, hash)
-		user, ok := resolved[hash]
-		if !ok {
-			a.gitPanelAuthorCache[cacheKey] = gitPanelCommitAuthorCacheEntry{
-				found:     false,
-				expiresAt: now.Add(gitPanelAuthorMissTTL),
-			}
-			continue
+// runDeepLink executa um link já validado (e confirmado, quando exigido).
+func (a *App) runDeepLink(link deeplink.Link) error {
+	switch link.Action {
+	case deeplink.ActionAuthCallback:
+		return a.handleAuthDeepLink(link)
+	case deeplink.ActionJoinSession:
+		// O nome do convidado vem do diálogo de entrada; o frontend abre o fluxo com o código preenchido.
+		a.emitDeepLinkEvent("deeplink:join_session", map[string]string{"code": link.Code})
+	case deeplink.ActionOpenRepo:
+		opened, err := a.openRepoFromDeepLink(link.Path)
+		if err != nil {
+			return err
 		}
+		a.emitDeepLinkEvent("deeplink:open_repo", opened)
+	case deeplink.ActionOpenPR:
+		a.emitDeepLinkEvent("deeplink:open_pr", link)
+	default:
+		return fmt.Errorf("unsupported deep link action %q", link.Action)
+	}
+	a.showWindowForDeepLink()
+	return nil
+}
 
-		login := strings.TrimSpace(user.Login)
-		if login == "" {
-			a.gitPanelAuthorCache[cacheKey] = gitPanelCommitAuthorCacheEntry{
-				found:     false,
-				expiresAt: now.Add(gitPanelAuthorMissTTL),
+func (a *App) handleAuthDeepLink(link deeplink.Link) error {
+	if a.auth == nil {
+		return nil
+	}
+	result, err := a.auth.HandleCallback(link.Code)
+	if err != nil {
+		log.Printf("[ORCH] Auth callback failed: %v", err)
+		a.emitDeepLinkEvent("auth:error", err.Error())
+		return nil
+	}
+	if result.Success {
+		log.Println("[ORCH] Auth success via Deep Link!")
+		a.emitDeepLinkEvent("auth:changed", a.auth.GetAuthState())
+		a.showWindowForDeepLink()
+	}
+	return nil
+}
+
+// openRepoFromDeepLink ativa o workspace que já contém o repositório ou cria um novo para ele.
+func (a *App) openRepoFromDeepLink(path string) (DeepLinkOpenRepoDTO, error) {
+	if a.db == nil {
+		return DeepLinkOpenRepoDTO{}, fmt.Errorf("database not initialized")
+	}
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return DeepLinkOpenRepoDTO{}, fmt.Errorf("directory not found: %s", path)
+	}
+	repoRoot := findGitRepoRoot(path)
+	if repoRoot == "" {
+		return DeepLinkOpenRepoDTO{}, fmt.Errorf("not a git repository: %s", path)
+	}
+
+	workspaces, err := a.db.ListWorkspaces()
+	if err != nil {
+		return DeepLinkOpenRepoDTO{}, err
+	}
+	for i := range workspaces {
+		if slices.Contains(a.workspaceRepoPaths(&workspaces[i]), filepath.Clean(repoRoot)) {
+			if err := a.SetActiveWorkspace(workspaces[i].ID); err != nil {
+				return DeepLinkOpenRepoDTO{}, err
 			}
-			continue
+			return DeepLinkOpenRepoDTO{WorkspaceID: workspaces[i].ID, Path: repoRoot}, nil
 		}
+	}
 
-		avatarURL := strings.TrimSpace(user.AvatarURL)
-		a.gitPanelAuthorCache[cacheKey] = gitPanelCommitAuthorCacheEntry{
-			login:     login,
-			avatarURL: avatarURL,
-			found:     true,
-			expiresAt: now.Add(gitPanelAuthorCacheTTL),
-		}
+	ws, err := a.CreateWorkspace(filepath.Base(repoRoot))
+	if err != nil {
+		return DeepLinkOpenRepoDTO{}, err
+	}
+	if _, err := a.AddWorkspaceRepository(ws.ID, repoRoot, ""); err != nil {
+		return DeepLinkOpenRepoDTO{}, err
+	}
+	if err := a.SetActiveWorkspace(ws.ID); err != nil {
+		return DeepLinkOpenRepoDTO{}, err
+	}
+	return DeepLinkOpenRepoDTO{WorkspaceID: ws.ID, Path: repoRoot, Created: true}, nil
+}
 
-		updates = append(updates, map[string]string{
-			"hash":            hash,
-			"githubLogin":     login,
-			"githubAvatarUrl": avatarURL,
-		})
+func (a *App) emitDeepLinkEvent(eventName string, data interface{}) {
+	if a.ctx == nil {
+		return
 	}
-	a.gitPanelAuthorMu.Unlock()
+	runtime.EventsEmit(a.ctx, eventName, data)
+}
 
-	if len(updates) == 0 || a.ctx == nil {
+func (a *App) showWindowForDeepLink() {
+	if a.ctx == nil {
 		return
 	}
+	runtime.WindowShow(a.ctx)
+}
 
-	runtime.EventsEmit(a.ctx, "gitpanel:history_authors_enriched", map[string]interface{}{
-		"repoPath": normalizedRoot,
-		"items":    updates,
-	})
+// === Diagnostics / Logs Bindings ===
+
+const (
+	defaultRecentLogsLimit = 200
+	maxRecentLogsLimit     = 2000
+	logFollowBufferSize    = 512
+	logFollowFlushInterval = 250 * time.Millisecond
+)
+
+// LogFollowBatch é o payload do evento "logs:follow" (entradas acumuladas desde o último envio).
+type LogFollowBatch struct {
+	Entries []logging.Entry `json:"entries"`
+	Dropped int             `json:"dropped"` // descartadas por excesso de volume
 }
 
-func (a *App) cacheGitPanelAuthorMisses(repoRoot string, hashes []string) {
-	normalizedRoot := filepath.Clean(strings.TrimSpace(repoRoot))
-	if normalizedRoot == "" || len(hashes) == 0 {
-		return
+// GetRecentLogs retorna as últimas entradas com nível >= level (mais antigas primeiro).
+func (a *App) GetRecentLogs(level string, limit int) ([]logging.Entry, error) {
+	minLevel, err := logging.ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultRecentLogsLimit
+	}
+	if limit > maxRecentLogsLimit {
+		limit = maxRecentLogsLimit
 	}
+	return logging.Default().Recent(minLevel, limit), nil
+}
 
-	now := time.Now()
-	a.gitPanelAuthorMu.Lock()
-	defer a.gitPanelAuthorMu.Unlock()
+// GetLogLevel retorna o nível mínimo de log atual.
+func (a *App) GetLogLevel() string {
+	return logging.Default().Level().String()
+}
 
-	for _, rawHash := range hashes {
-		hash := normalizeGitPanelCommitHash(rawHash)
-		if hash == "" {
-			continue
-		}
-		cacheKey := buildGitPanelCommitAuthorCacheKey(normalizedRoot, hash)
-		a.gitPanelAuthorCache[cacheKey] = gitPanelCommitAuthorCacheEntry{
-			found:     false,
-			expiresAt: now.Add(gitPanelAuthorMissTTL),
-		}
+// SetLogLevel altera o nível mínimo de log em tempo de execução.
+func (a *App) SetLogLevel(level string) error {
+	parsed, err := logging.ParseLevel(level)
+	if err != nil {
+		return err
 	}
+	logging.Default().SetLevel(parsed)
+	logging.Infof("ORCH", "log level set to %s", parsed)
+	return nil
 }
 
-func (a *App) releaseGitPanelAuthorInFlight(repoRoot string, hashes []string) {
-	normalizedRoot := filepath.Clean(strings.TrimSpace(repoRoot))
-	if normalizedRoot == "" || len(hashes) == 0 {
-		return
+// GetLogFilePath retorna o arquivo de log atual (para abrir fora do app).
+func (a *App) GetLogFilePath() string {
+	return logging.Default().FilePath()
+}
+
+// StartLogFollow passa a emitir "logs:follow" com novas entradas de nível >= level.
+func (a *App) StartLogFollow(level string) error {
+	minLevel, err := logging.ParseLevel(level)
+	if err != nil {
+		return err
 	}
 
-	a.gitPanelAuthorMu.Lock()
-	defer a.gitPanelAuthorMu.Unlock()
+	entries := make(chan logging.Entry, logFollowBufferSize)
+	var dropped atomic.Int64
+	unsubscribe := logging.Default().Subscribe(func(entry logging.Entry) {
+		if entry.Level < minLevel {
+			return
+		}
+		select {
+		case entries <- entry:
+		default:
+			dropped.Add(1)
+		}
+	})
 
-	for _, rawHash := range hashes {
-		hash := normalizeGitPanelCommitHash(rawHash)
-		if hash == "" {
-			continue
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(logFollowFlushInterval)
+		defer ticker.Stop()
+		batch := make([]logging.Entry, 0, logFollowBufferSize)
+		for {
+			select {
+			case <-done:
+				return
+			case entry := <-entries:
+				batch = append(batch, entry)
+			case <-ticker.C:
+				lost := int(dropped.Swap(0))
+				if len(batch) == 0 && lost == 0 {
+					continue
+				}
+				if a.ctx != nil {
+					runtime.EventsEmit(a.ctx, "logs:follow", LogFollowBatch{Entries: batch, Dropped: lost})
+				}
+				batch = make([]logging.Entry, 0, logFollowBufferSize)
+			}
 		}
-		cacheKey := buildGitPanelCommitAuthorCacheKey(normalizedRoot, hash)
-		delete(a.gitPanelAuthorInFlight, cacheKey)
+	}()
+
+	a.logFollowMu.Lock()
+	previous := a.logFollowStop
+	a.logFollowStop = func() {
+		unsubscribe()
+		close(done)
+	}
+	a.logFollowMu.Unlock()
+	if previous != nil {
+		previous()
 	}
+	return nil
 }
 
-func (a *App) resolveGitHubOwnerRepo(repoRoot string) (string, string, bool) {
-	normalizedRoot := filepath.Clean(strings.TrimSpace(repoRoot))
-	if normalizedRoot == "" {
-		return "", "", false
+// StopLogFollow encerra o stream "logs:follow".
+func (a *App) StopLogFollow() {
+	a.logFollowMu.Lock()
+	stop := a.logFollowStop
+	a.logFollowStop = nil
+	a.logFollowMu.Unlock()
+	if stop != nil {
+		stop()
 	}
+}
 
-	now := time.Now()
+// === API Inspector Bindings ===
 
-	a.gitPanelAuthorMu.Lock()
-	if cached, ok := a.gitPanelRepoIdentity[normalizedRoot]; ok && now.Before(cached.expiresAt) {
-		a.gitPanelAuthorMu.Unlock()
-		if cached.owner != "" && cached.repo != "" {
-			return cached.owner, cached.repo, true
-		}
-		return "", "", false
-	}
-	a.gitPanelAuthorMu.Unlock()
+const (
+	defaultAPITraceLimit  = 200
+	apiTraceBufferSize    = 256
+	apiTraceFlushInterval = 250 * time.Millisecond
+)
 
-	ctx, cancel := context.WithTimeout(context.Background(), gitPanelAuthorLookupTimeout)
-	defer cancel()
+// APITraceBatch é o payload do evento "api:trace" (Dropped = requests descartados por buffer cheio).
+type APITraceBatch struct {
+	Entries []httpclient.TraceEntry `json:"entries"`
+	Dropped int                     `json:"dropped"`
+}
 
-	originOutput, err := exec.CommandContext(ctx, "git", "-C", normalizedRoot, "remote", "get-url", "origin").Output()
-	if err != nil {
-		a.gitPanelAuthorMu.Lock()
-		a.gitPanelRepoIdentity[normalizedRoot] = gitPanelRepoIdentityCacheEntry{
-			expiresAt: now.Add(gitPanelAuthorMissTTL),
+// SetAPIInspectorEnabled liga/desliga o registro de requests GitHub/IA/gateway e o stream "api:trace".
+func (a *App) SetAPIInspectorEnabled(enabled bool) {
+	a.apiTraceMu.Lock()
+	defer a.apiTraceMu.Unlock()
+
+	httpclient.SetTracingEnabled(enabled)
+	if !enabled {
+		if a.apiTraceStop != nil {
+			a.apiTraceStop()
+			a.apiTraceStop = nil
 		}
-		a.gitPanelAuthorMu.Unlock()
-		return "", "", false
+		return
+	}
+	if a.apiTraceStop != nil {
+		return
 	}
 
-	owner, repo, ok := gpr.ParseGitHubRemoteURL(string(originOutput))
-	a.gitPanelAuthorMu.Lock()
-	if ok {
-		a.gitPanelRepoIdentity[normalizedRoot] = gitPanelRepoIdentityCacheEntry{
-			owner:     owner,
-			repo:      repo,
-			expiresAt: now.Add(gitPanelRepoIdentityCacheTTL),
+	entries := make(chan httpclient.TraceEntry, apiTraceBufferSize)
+	var dropped atomic.Int64
+	unsubscribe := httpclient.SubscribeTraces(func(entry httpclient.TraceEntry) {
+		select {
+		case entries <- entry:
+		default:
+			dropped.Add(1)
 		}
-	} else {
-		a.gitPanelRepoIdentity[normalizedRoot] = gitPanelRepoIdentityCacheEntry{
-			expiresAt: now.Add(gitPanelAuthorMissTTL),
+	})
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(apiTraceFlushInterval)
+		defer ticker.Stop()
+		batch := make([]httpclient.TraceEntry, 0, apiTraceBufferSize)
+		for {
+			select {
+			case <-done:
+				return
+			case entry := <-entries:
+				batch = append(batch, entry)
+			case <-ticker.C:
+				lost := int(dropped.Swap(0))
+				if len(batch) == 0 && lost == 0 {
+					continue
+				}
+				if a.ctx != nil {
+					runtime.EventsEmit(a.ctx, "api:trace", APITraceBatch{Entries: batch, Dropped: lost})
+				}
+				batch = make([]httpclient.TraceEntry, 0, apiTraceBufferSize)
+			}
 		}
+	}()
+	a.apiTraceStop = func() {
+		unsubscribe()
+		close(done)
 	}
-	a.gitPanelAuthorMu.Unlock()
+}
 
-	return owner, repo, ok
+// IsAPIInspectorEnabled indica se o API inspector está registrando requests.
+func (a *App) IsAPIInspectorEnabled() bool {
+	return httpclient.TracingEnabled()
 }
 
-func normalizeGitPanelCommitHash(value string) string {
-	normalized := strings.ToLower(strings.TrimSpace(value))
-	if normalized == "" || !gitPanelCommitHashRegex.MatchString(normalized) {
-		return ""
+// GetAPITrace retorna os últimos requests registrados (mais antigos primeiro).
+func (a *App) GetAPITrace(limit int) []httpclient.TraceEntry {
+	if limit <= 0 {
+		limit = defaultAPITraceLimit
 	}
-	return normalized
+	return httpclient.RecentTraces(limit)
 }
 
-func buildGitPanelCommitAuthorCacheKey(repoRoot string, hash string) string {
-	root := filepath.Clean(strings.TrimSpace(repoRoot))
-	normalizedHash := normalizeGitPanelCommitHash(hash)
-	if root == "" || normalizedHash == "" {
-		return ""
+// ClearAPITrace descarta os requests registrados.
+func (a *App) ClearAPITrace() {
+	httpclient.ClearTraces()
+}
+
+// === System Health Bindings ===
+
+const (
+	healthStatusOK       = "ok"
+	healthStatusDegraded = "degraded"
+	healthStatusDown     = "down"
+	healthStatusDisabled = "disabled"
+
+	healthCheckTimeout  = 5 * time.Second
+	healthCheckInterval = 60 * time.Second
+)
+
+// SubsystemHealth é o estado de um subsistema na última verificação.
+type SubsystemHealth struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"` // "ok", "degraded", "down", "disabled"
+	Message     string     `json:"message,omitempty"`
+	LatencyMs   int64      `json:"latencyMs"`
+	CheckedAt   time.Time  `json:"checkedAt"`
+	LastError   string     `json:"lastError,omitempty"` // último erro observado, mesmo se já recuperado
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty"`
+}
+
+// SystemHealth agrega os subsistemas; Status é o pior estado entre os habilitados.
+type SystemHealth struct {
+	Status     string            `json:"status"`
+	CheckedAt  time.Time         `json:"checkedAt"`
+	Subsystems []SubsystemHealth `json:"subsystems"`
+}
+
+type subsystemErrorRecord struct {
+	message string
+	at      time.Time
+}
+
+type healthCheck struct {
+	name string
+	run  func(ctx context.Context) (status string, message string, err error)
+}
+
+// GetStartupTimings devolve a duração de cada etapa do startup e o que ainda sobe em background.
+func (a *App) GetStartupTimings() startup.Timings {
+	if a.startupTimings == nil {
+		return startup.Timings{Phases: []startup.Phase{}, Pending: []string{}}
+	}
+	return a.startupTimings.Timings()
+}
+
+// GetSystemHealth verifica todos os subsistemas em paralelo (timeout de 5s por verificação).
+func (a *App) GetSystemHealth() SystemHealth {
+	checks := a.healthChecks()
+	results := make([]SubsystemHealth, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check healthCheck) {
+			defer wg.Done()
+			results[i] = a.runHealthCheck(check)
+		}(i, check)
 	}
-	return root + "|" + normalizedHash
+	wg.Wait()
+
+	health := SystemHealth{Status: healthStatusOK, CheckedAt: time.Now(), Subsystems: results}
+	for _, result := range results {
+		health.Status = worseHealthStatus(health.Status, result)
+	}
+	return health
 }
 
-func historyItemMatchesAuthUser(item *gp.HistoryItemDTO, emailToken string, nameToken string, usernameToken string) bool {
-	if item == nil {
-		return false
+func (a *App) healthChecks() []healthCheck {
+	return []healthCheck{
+		{name: "database", run: a.checkDatabaseHealth},
+		{name: "docker", run: a.checkDockerHealth},
+		{name: "signaling", run: a.checkSignalingHealth},
+		{name: "gateway", run: a.checkGatewayHealth},
+		{name: "filewatcher", run: a.checkFileWatcherHealth},
+		{name: "github", run: a.checkGitHubHealth},
+		{name: "ai", run: a.checkAIHealth},
 	}
+}
 
-	authorToken := normalizeGitPanelIdentityToken(item.Author)
-	authorEmailToken := normalizeGitPanelIdentityToken(item.AuthorEmail)
+func (a *App) runHealthCheck(check healthCheck) SubsystemHealth {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
 
-	if emailToken != "" && authorEmailToken != "" && emailToken == authorEmailToken {
-		return true
+	type outcome struct {
+		status  string
+		message string
+		err     error
 	}
-	if nameToken != "" && authorToken != "" && nameToken == authorToken {
-		return true
+	done := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		status, message, err := check.run(ctx)
+		done <- outcome{status, message, err}
+	}()
+
+	var result outcome
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result = outcome{status: healthStatusDown, err: fmt.Errorf("health check timed out after %s", healthCheckTimeout)}
 	}
-	if usernameToken != "" && authorToken != "" && usernameToken == authorToken {
-		return true
+
+	health := SubsystemHealth{
+		Name:      check.name,
+		Status:    result.status,
+		Message:   result.message,
+		LatencyMs: time.Since(start).Milliseconds(),
+		CheckedAt: time.Now(),
 	}
 
-	return false
+	a.healthMu.Lock()
+	if result.err != nil {
+		a.healthLastErrors[check.name] = subsystemErrorRecord{message: a.sanitizeForLogs(result.err.Error()), at: health.CheckedAt}
+	}
+	if record, ok := a.healthLastErrors[check.name]; ok {
+		at := record.at
+		health.LastError = record.message
+		health.LastErrorAt = &at
+	}
+	a.healthMu.Unlock()
+	return health
 }
 
-func normalizeGitPanelIdentityToken(value string) string {
-	return strings.ToLower(strings.TrimSpace(value))
+// worseHealthStatus combina o estado agregado; só o banco derruba o app inteiro.
+func worseHealthStatus(current string, subsystem SubsystemHealth) string {
+	switch subsystem.Status {
+	case healthStatusDown:
+		if subsystem.Name == "database" {
+			return healthStatusDown
+		}
+		if current == healthStatusOK {
+			return healthStatusDegraded
+		}
+	case healthStatusDegraded:
+		if current == healthStatusOK {
+			return healthStatusDegraded
+		}
+	}
+	return current
 }
 
-// GitPanelGetDiff retorna diff textual base para o frontend.
-func (a *App) GitPanelGetDiff(repoPath string, filePath string, mode string, contextLines int) (gp.DiffDTO, error) {
-	svc, err := a.requireGitPanelService()
-	if err != nil {
-		return gp.DiffDTO{}, err
+func (a *App) checkDatabaseHealth(ctx context.Context) (string, string, error) {
+	if a.db == nil {
+		return healthStatusDown, "", fmt.Errorf("database not initialized")
 	}
-
-	result, diffErr := svc.GetDiff(repoPath, filePath, mode, contextLines)
-	if diffErr != nil {
-		return gp.DiffDTO{}, a.normalizeGitPanelBindingError(diffErr)
+	if err := a.db.Ping(ctx); err != nil {
+		return healthStatusDown, "", err
 	}
-	return result, nil
+	return healthStatusOK, fmt.Sprintf("schema v%d", a.db.SchemaVersion()), nil
 }
 
-// GitPanelGetCommitDetails retorna detalhes de um commit específico (ex: lista de arquivos).
-func (a *App) GitPanelGetCommitDetails(repoPath string, commitHash string) (gp.CommitDetailsDTO, error) {
-	svc, err := a.requireGitPanelService()
+func (a *App) checkDockerHealth(ctx context.Context) (string, string, error) {
+	if a.docker == nil {
+		return healthStatusDisabled, "", nil
+	}
+	version, err := a.docker.Ping(ctx)
 	if err != nil {
-		return gp.CommitDetailsDTO{}, err
+		// Docker é opcional: sem ele as sessões usam o fallback Live Share.
+		return healthStatusDegraded, "Live Share fallback", err
 	}
-	result, err := svc.GetCommitDetails(repoPath, commitHash)
+	return healthStatusOK, "Docker " + version, nil
+}
+
+func (a *App) checkSignalingHealth(ctx context.Context) (string, string, error) {
+	if a.signaling == nil || !shouldStartSessionListener(a.signalingAddr) {
+		return healthStatusDisabled, "", nil
+	}
+	addr := sessionPublicHostFromListenAddr(a.signalingAddr)
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
 	if err != nil {
-		return gp.CommitDetailsDTO{}, a.normalizeGitPanelBindingError(err)
+		return healthStatusDown, addr, err
 	}
-	return result, nil
+	_ = conn.Close()
+	return healthStatusOK, addr, nil
 }
 
-// GitPanelGetCommitDiff retorna diff textual de um arquivo em um commit específico.
-func (a *App) GitPanelGetCommitDiff(repoPath string, filePath string, commitHash string, contextLines int) (gp.DiffDTO, error) {
-	svc, err := a.requireGitPanelService()
+func (a *App) checkGatewayHealth(ctx context.Context) (string, string, error) {
+	mode := "client mode"
+	if a.sessionGatewayOwner {
+		mode = "owner"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.resolvedSessionGatewayBaseURL()+"/healthz", nil)
 	if err != nil {
-		return gp.DiffDTO{}, err
+		return healthStatusDown, mode, err
 	}
-	result, err := svc.GetCommitDiff(repoPath, filePath, commitHash, contextLines)
+	resp, err := httpclient.Client(httpclient.ServiceGateway).Do(req)
 	if err != nil {
-		return gp.DiffDTO{}, a.normalizeGitPanelBindingError(err)
+		return healthStatusDown, mode, err
 	}
-	return result, nil
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return healthStatusDown, mode, fmt.Errorf("gateway healthz returned %d", resp.StatusCode)
+	}
+	return healthStatusOK, mode, nil
 }
 
-// GitPanelGetConflicts retorna arquivos em estado de conflito.
-func (a *App) GitPanelGetConflicts(repoPath string) ([]gp.ConflictFileDTO, error) {
-	svc, err := a.requireGitPanelService()
-	if err != nil {
-		return nil, err
+func (a *App) checkFileWatcherHealth(ctx context.Context) (string, string, error) {
+	if a.fileWatcher == nil {
+		return healthStatusDown, "", fmt.Errorf("file watcher not initialized")
 	}
+	return healthStatusOK, fmt.Sprintf("%d repos watched", len(a.fileWatcher.WatchedProjects())), nil
+}
 
-	result, conflictsErr := svc.GetConflicts(repoPath)
-	if conflictsErr != nil {
-		return nil, a.normalizeGitPanelBindingError(conflictsErr)
+func (a *App) checkGitHubHealth(ctx context.Context) (string, string, error) {
+	if !a.awaitGitHub() {
+		return healthStatusDisabled, "", nil
 	}
-	return result, nil
+	if err := a.github.Ping(ctx); err != nil {
+		var ghErr *gh.GitHubError
+		if errors.As(err, &ghErr) && ghErr.Type == "auth" {
+			return healthStatusDegraded, "API reachable, token rejected", err
+		}
+		return healthStatusDown, "", err
+	}
+	return healthStatusOK, "", nil
 }
 
-// GitPanelStageFile adiciona arquivo ao stage.
-func (a *App) GitPanelStageFile(repoPath string, filePath string) error {
-	svc, err := a.requireGitPanelService()
+func (a *App) checkAIHealth(ctx context.Context) (string, string, error) {
+	if a.ai == nil {
+		return healthStatusDisabled, "", nil
+	}
+	provider, err := a.ai.CheckActiveProvider(ctx)
+	if provider.ID == "" {
+		return healthStatusDisabled, "no AI provider configured", nil
+	}
 	if err != nil {
-		return err
+		// IA é opcional; indisponibilidade não bloqueia o restante do app.
+		return healthStatusDegraded, provider.Name, err
 	}
-	return a.normalizeGitPanelBindingError(svc.StageFile(repoPath, filePath))
+	return healthStatusOK, provider.Name, nil
 }
 
-// GitPanelUnstageFile remove arquivo do stage.
-func (a *App) GitPanelUnstageFile(repoPath string, filePath string) error {
-	svc, err := a.requireGitPanelService()
-	if err != nil {
-		return err
+// startHealthMonitor emite "system:health" periodicamente para o indicador da status bar.
+func (a *App) startHealthMonitor() {
+	a.healthMu.Lock()
+	if a.healthStop != nil {
+		a.healthMu.Unlock()
+		return
 	}
-	return a.normalizeGitPanelBindingError(svc.UnstageFile(repoPath, filePath))
+	stop := make(chan struct{})
+	a.healthStop = stop
+	a.healthMu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(healthCheckInterval)
+		defer ticker.Stop()
+		for {
+			a.emitSystemHealth()
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
 }
 
-// GitPanelDiscardFile descarta alterações locais de um arquivo.
-func (a *App) GitPanelDiscardFile(repoPath string, filePath string) error {
-	svc, err := a.requireGitPanelService()
-	if err != nil {
-		return err
+func (a *App) stopHealthMonitor() {
+	a.healthMu.Lock()
+	defer a.healthMu.Unlock()
+	if a.healthStop != nil {
+		close(a.healthStop)
+		a.healthStop = nil
 	}
-	return a.normalizeGitPanelBindingError(svc.DiscardFile(repoPath, filePath))
 }
 
-// GitPanelStagePatch aplica patch parcial no stage.
-func (a *App) GitPanelStagePatch(repoPath string, patchText string) error {
-	svc, err := a.requireGitPanelService()
-	if err != nil {
-		return err
+func (a *App) emitSystemHealth() {
+	health := a.GetSystemHealth()
+	if a.ctx == nil {
+		return
 	}
-	return a.normalizeGitPanelBindingError(svc.StagePatch(repoPath, patchText))
+	runtime.EventsEmit(a.ctx, "system:health", health)
 }
 
-// GitPanelUnstagePatch remove patch parcial do stage.
-func (a *App) GitPanelUnstagePatch(repoPath string, patchText string) error {
-	svc, err := a.requireGitPanelService()
-	if err != nil {
-		return err
+// === Auto-Update Bindings ===
+
+const (
+	startupUpdateCheckDelay = 20 * time.Second
+	updateCheckTimeout      = 30 * time.Second
+)
+
+// CheckForUpdates consulta os releases do canal configurado (stable | beta).
+func (a *App) CheckForUpdates() (*updater.UpdateInfo, error) {
+	if a.updater == nil {
+		return nil, fmt.Errorf("updater not initialized")
 	}
-	return a.normalizeGitPanelBindingError(svc.UnstagePatch(repoPath, patchText))
+	ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+	defer cancel()
+	return a.updater.CheckForUpdates(ctx, a.GetUpdateChannel())
 }
 
-// GitPanelAcceptOurs aplica resolução de conflito com versão local.
-func (a *App) GitPanelAcceptOurs(repoPath string, filePath string, autoStage bool) error {
-	svc, err := a.requireGitPanelService()
+// InstallUpdate baixa, verifica a assinatura e aplica a atualização encontrada em CheckForUpdates.
+// O progresso chega pelo evento "updater:progress"; a nova versão vale após reiniciar o app.
+func (a *App) InstallUpdate() (*updater.InstallResult, error) {
+	if a.updater == nil {
+		return nil, fmt.Errorf("updater not initialized")
+	}
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result, err := a.updater.InstallUpdate(ctx)
 	if err != nil {
-		return err
+		log.Printf("[UPDATER] install failed: %v", err)
+		return nil, err
 	}
-	return a.normalizeGitPanelBindingError(svc.AcceptOurs(repoPath, filePath, autoStage))
+	log.Printf("[UPDATER] installed version %s at %s (restart required)", result.Version, result.InstalledPath)
+	return result, nil
 }
 
-// GitPanelAcceptTheirs aplica resolução de conflito com versão remota.
-func (a *App) GitPanelAcceptTheirs(repoPath string, filePath string, autoStage bool) error {
-	svc, err := a.requireGitPanelService()
+// GetUpdateChannel retorna o canal de atualização configurado.
+func (a *App) GetUpdateChannel() string {
+	if a.db == nil {
+		return updater.ChannelStable
+	}
+	cfg, err := a.db.GetConfig()
 	if err != nil {
-		return err
+		return updater.ChannelStable
 	}
-	return a.normalizeGitPanelBindingError(svc.AcceptTheirs(repoPath, filePath, autoStage))
+	channel, err := updater.NormalizeChannel(cfg.UpdateChannel)
+	if err != nil {
+		return updater.ChannelStable
+	}
+	return channel
 }
 
-// GitPanelOpenExternalMergeTool abre a ferramenta de merge configurada no Git.
-func (a *App) GitPanelOpenExternalMergeTool(repoPath string, filePath string) error {
-	svc, err := a.requireGitPanelService()
+// SetUpdateChannel define o canal de atualização (stable | beta).
+func (a *App) SetUpdateChannel(channel string) error {
+	normalized, err := updater.NormalizeChannel(channel)
 	if err != nil {
 		return err
 	}
-	return a.normalizeGitPanelBindingError(svc.OpenExternalMergeTool(repoPath, filePath))
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return a.db.SetUpdateChannel(normalized)
 }
 
-// === Polling Bindings (expostos ao Frontend) ===
-
-// StartPolling inicia polling inteligente para um repositório
-func (a *App) StartPolling(owner, repo string) {
-	if a.poller == nil {
+// checkForUpdatesInBackground emite "updater:available" quando há versão nova no canal.
+func (a *App) checkForUpdatesInBackground() {
+	info, err := a.CheckForUpdates()
+	if err != nil {
+		log.Printf("[UPDATER] background check failed: %v", err)
 		return
 	}
-	a.poller.StartPolling(owner, repo)
-}
-
-// StopPolling para o polling
-func (a *App) StopPolling() {
-	if a.poller == nil {
+	if !info.Available || a.ctx == nil {
 		return
 	}
-	a.poller.StopPolling()
+	log.Printf("[UPDATER] version %s available on %s channel", info.LatestVersion, info.Channel)
+	runtime.EventsEmit(a.ctx, "updater:available", info)
 }
 
-// SetPollingContext atualiza o contexto de polling
-func (a *App) SetPollingContext(context string) {
-	if a.poller == nil {
-		return
+// === Disk Usage Bindings ===
+
+const (
+	diskUsageScanTimeout       = 60 * time.Second
+	startupDiskUsageCheckDelay = 2 * time.Minute
+)
+
+// ScanDiskUsage mede workspaces, imagens Docker do ORCH, banco, backups, exports, logs e cache.
+// Alertas usam os limites disk.alert_*_mb do config.toml.
+func (a *App) ScanDiskUsage() (*diskusage.Report, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
 	}
-	a.poller.SetContext(gh.PollingContext(context))
+	ctx, cancel := context.WithTimeout(context.Background(), diskUsageScanTimeout)
+	defer cancel()
+
+	report := diskusage.Scan(ctx, a.diskUsageSources(), a.dockerDiskUsage(), a.diskUsageLimits())
+	return &report, nil
 }
 
-// GetRateLimitInfo retorna informações do rate limit do GitHub
-func (a *App) GetRateLimitInfo() gh.RateLimitInfo {
-	if a.poller == nil {
-		return gh.RateLimitInfo{Remaining: 5000, Limit: 5000}
+// PruneDanglingStackImages remove as imagens de stack que ficaram sem tag após rebuilds.
+func (a *App) PruneDanglingStackImages() (diskusage.CleanupResult, error) {
+	if a.docker == nil || !a.docker.IsDockerAvailable() {
+		return diskusage.CleanupResult{}, fmt.Errorf("docker is not available")
 	}
-	return a.poller.GetRateLimitInfo()
+	removed, freed, err := a.docker.PruneDanglingStackImages()
+	if err != nil {
+		return diskusage.CleanupResult{}, err
+	}
+	log.Printf("[DISK] pruned %d dangling stack images (%d bytes)", removed, freed)
+	return diskusage.CleanupResult{Removed: removed, FreedBytes: freed, Paths: []string{}}, nil
 }
 
-// === Session / P2P Bindings (expostos ao Frontend) ===
+// ClearOldDiskArtifacts apaga backups/snapshots do banco, exports de workspace ou logs
+// rotacionados ("backups" | "exports" | "logs") sem modificação há olderThanDays dias.
+func (a *App) ClearOldDiskArtifacts(kind string, olderThanDays int) (diskusage.CleanupResult, error) {
+	if olderThanDays < 1 {
+		return diskusage.CleanupResult{}, fmt.Errorf("olderThanDays must be at least 1")
+	}
+	cutoff := time.Now().AddDate(0, 0, -olderThanDays)
 
-type sessionGatewayErrorResponse struct {
-	Error string `json:"error"`
-}
+	var targets [][2]string // diretório, padrão
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "backups":
+		if a.db == nil {
+			return diskusage.CleanupResult{}, fmt.Errorf("database not initialized")
+		}
+		dbPath := a.db.Path()
+		dbName := strings.TrimSuffix(filepath.Base(dbPath), filepath.Ext(dbPath))
+		targets = append(targets,
+			[2]string{filepath.Join(filepath.Dir(dbPath), "backups"), dbName + "-*-v*.db"},
+			[2]string{a.backupArchiveDir(), "orch-backup-*.orchbak"},
+		)
+	case "exports":
+		targets = append(targets, [2]string{filepath.Join(config.DataDir(), "exports"), "*.orch-workspace.json"})
+	case "logs":
+		// Só os arquivos rotacionados; o orch.log atual continua aberto pelo logger.
+		targets = append(targets, [2]string{config.LogDir(), "orch.log.*"})
+	default:
+		return diskusage.CleanupResult{}, fmt.Errorf("unknown cleanup kind %q (use backups, exports or logs)", kind)
+	}
 
-func normalizeSessionListenerAddr(raw, fallback string) string {
-	if candidate := strings.TrimSpace(raw); candidate != "" {
-		return candidate
+	result := diskusage.CleanupResult{Paths: []string{}}
+	for _, target := range targets {
+		partial, err := diskusage.RemoveOlderThan(target[0], target[1], cutoff)
+		result.Add(partial)
+		if err != nil {
+			return result, err
+		}
 	}
-	return fallback
+	log.Printf("[DISK] cleared %d old %s files (%d bytes)", result.Removed, kind, result.FreedBytes)
+	return result, nil
 }
 
-func shouldStartSessionListener(addr string) bool {
-	switch strings.ToLower(strings.TrimSpace(addr)) {
-	case "", "off", "disabled", "none", "false", "0":
-		return false
-	default:
-		return true
+func (a *App) diskUsageSources() []diskusage.Source {
+	sources := make([]diskusage.Source, 0, 8)
+	seen := make(map[string]bool)
+	if workspaces, err := a.db.ListWorkspaces(); err == nil {
+		for _, ws := range workspaces {
+			path := strings.TrimSpace(ws.Path)
+			if path == "" || seen[filepath.Clean(path)] {
+				continue
+			}
+			seen[filepath.Clean(path)] = true
+			sources = append(sources, diskusage.Source{Category: diskusage.CategoryWorkspace, Name: ws.Name, Paths: []string{path}})
+		}
+	}
+
+	dbPath := a.db.Path()
+	sources = append(sources, diskusage.Source{Category: diskusage.CategoryDatabase, Name: filepath.Base(dbPath), Paths: []string{dbPath, dbPath + "-wal", dbPath + "-shm"}})
+
+	// Os backups de migração ficam ao lado do banco; os arquivos agendados podem estar dentro deles.
+	migrationBackups := filepath.Join(filepath.Dir(dbPath), "backups")
+	sources = append(sources, diskusage.Source{Category: diskusage.CategoryBackups, Name: "database backups", Paths: []string{migrationBackups}})
+	if archives := a.backupArchiveDir(); !strings.HasPrefix(archives, migrationBackups+string(filepath.Separator)) {
+		sources = append(sources, diskusage.Source{Category: diskusage.CategoryBackups, Name: "scheduled backups", Paths: []string{archives}})
 	}
+
+	return append(sources,
+		diskusage.Source{Category: diskusage.CategoryExports, Name: "workspace exports", Paths: []string{filepath.Join(config.DataDir(), "exports")}},
+		diskusage.Source{Category: diskusage.CategoryLogs, Name: "logs", Paths: []string{config.LogDir()}},
+		diskusage.Source{Category: diskusage.CategoryCache, Name: "cache", Paths: []string{config.CacheDir()}},
+	)
 }
 
-func sessionPublicHostFromListenAddr(addr string) string {
-	trimmed := strings.TrimSpace(addr)
-	if trimmed == "" {
-		return "127.0.0.1"
+func (a *App) dockerDiskUsage() []diskusage.Item {
+	if a.docker == nil || !a.docker.IsDockerAvailable() {
+		return nil
 	}
-	if strings.HasPrefix(trimmed, ":") {
-		return "127.0.0.1" + trimmed
+	images, err := a.docker.ListOrchImages()
+	if err != nil {
+		return []diskusage.Item{{Category: diskusage.CategoryDocker, Name: "docker images", Error: err.Error()}}
+	}
+	items := make([]diskusage.Item, 0, len(images))
+	for _, image := range images {
+		name := image.Reference
+		if image.Dangling {
+			name = fmt.Sprintf("%s (dangling %s)", image.Stack, strings.TrimPrefix(image.ID, "sha256:")[:12])
+		}
+		items = append(items, diskusage.Item{Category: diskusage.CategoryDocker, Name: name, Bytes: image.Bytes, Files: 1})
 	}
+	return items
+}
 
-	host, port, err := net.SplitHostPort(trimmed)
-	if err != nil {
-		return trimmed
+func (a *App) diskUsageLimits() map[diskusage.Category]int64 {
+	effective := a.effectiveConfig()
+	mb := func(key string) int64 { return int64(effective.Int(key)) * 1024 * 1024 }
+	return map[diskusage.Category]int64{
+		diskusage.CategoryWorkspace: mb("disk.alert_workspaces_mb"),
+		diskusage.CategoryDocker:    mb("disk.alert_docker_mb"),
+		diskusage.CategoryDatabase:  mb("disk.alert_database_mb"),
+		diskusage.CategoryBackups:   mb("disk.alert_backups_mb"),
 	}
+}
 
-	switch host {
-	case "", "0.0.0.0", "::", "[::]":
-		host = "127.0.0.1"
+func (a *App) backupArchiveDir() string {
+	if a.backup != nil {
+		if dir := strings.TrimSpace(a.backup.ScheduleStatus().Dir); dir != "" {
+			return filepath.Clean(dir)
+		}
 	}
-	return net.JoinHostPort(host, port)
+	return backup.DefaultDir(config.DataDir())
 }
 
-func normalizeSessionGatewayBaseURL(raw string, fallbackListenAddr string) string {
-	base := strings.TrimSpace(raw)
-	if base == "" {
-		base = "http://" + sessionPublicHostFromListenAddr(fallbackListenAddr)
+func (a *App) checkDiskUsageInBackground() {
+	report, err := a.ScanDiskUsage()
+	if err != nil {
+		log.Printf("[DISK] background scan failed: %v", err)
+		return
 	}
-	if !strings.Contains(base, "://") {
-		base = "http://" + base
+	if len(report.Alerts) == 0 || a.ctx == nil {
+		return
 	}
-
-	parsed, err := url.Parse(base)
-	if err != nil || parsed.Host == "" {
-		return defaultSessionGatewayBaseURL
+	for _, alert := range report.Alerts {
+		log.Printf("[DISK] %s uses %d bytes (limit %d)", alert.Category, alert.Bytes, alert.LimitBytes)
+	}
+	runtime.EventsEmit(a.ctx, "disk:alert", report)
+}
+
+// === Config File Bindings (config.toml) ===
+
+const configFileWatchInterval = 2 * time.Second
+
+// configFileSettings lista as chaves aceitas em config.toml com a env var equivalente e o padrão.
+func configFileSettings() []config.Setting {
+	seconds := func(d time.Duration) string { return strconv.FormatInt(int64(d/time.Second), 10) }
+	millis := func(d time.Duration) string { return strconv.FormatInt(int64(d/time.Millisecond), 10) }
+	return []config.Setting{
+		{Key: "session.gateway_listen_addr", Env: "ORCH_SESSION_GATEWAY_LISTEN_ADDR", Default: defaultSessionGatewayListenAddr, RequiresRestart: true, Description: "Endereço de escuta do gateway de sessões"},
+		{Key: "session.gateway_base_url", Env: "ORCH_SESSION_GATEWAY_BASE_URL", RequiresRestart: true, Description: "URL base do gateway (vazio deriva do endereço de escuta)"},
+		{Key: "session.signaling_listen_addr", Env: "ORCH_SESSION_SIGNALING_LISTEN_ADDR", Default: defaultSessionSignalingListenAddr, RequiresRestart: true, Description: "Endereço de escuta do signaling WebSocket"},
+		{Key: "session.signaling_base_url", Env: "ORCH_SESSION_SIGNALING_BASE_URL", RequiresRestart: true, Description: "URL do signaling (vazio deriva do endereço de escuta)"},
+		{Key: "github.poll_pr_detail_seconds", Default: seconds(gh.DefaultPollingInterval(gh.PollingContextPRDetail)), Kind: config.KindSeconds, Description: "Polling com um PR aberto"},
+		{Key: "github.poll_pr_list_seconds", Default: seconds(gh.DefaultPollingInterval(gh.PollingContextPRList)), Kind: config.KindSeconds, Description: "Polling com a lista de PRs visível"},
+		{Key: "github.poll_background_seconds", Default: seconds(gh.DefaultPollingInterval(gh.PollingContextBackground)), Kind: config.KindSeconds, Description: "Polling sem painel GitHub aberto"},
+		{Key: "github.poll_minimized_seconds", Default: seconds(gh.DefaultPollingInterval(gh.PollingContextMinimized)), Kind: config.KindSeconds, Description: "Polling com o app em background"},
+		{Key: "github.poll_collaborate_seconds", Default: seconds(gh.DefaultPollingInterval(gh.PollingContextCollaborate)), Kind: config.KindSeconds, Description: "Polling durante sessão colaborativa"},
+		{Key: "github.cache_ttl_seconds", Default: seconds(gh.DefaultCacheTTL), Kind: config.KindSeconds, Description: "TTL do cache de respostas da API do GitHub"},
+		{Key: "filewatcher.debounce_ms", Default: millis(fw.DefaultDebounceWindow), Kind: config.KindMilliseconds, Description: "Debounce de eventos do file watcher"},
+		{Key: "filewatcher.max_dirs_per_repo", Default: strconv.Itoa(fw.DefaultWatchBudget), Kind: config.KindInteger, Description: "Máximo de diretórios monitorados por repositório (.git + working tree)"},
+		{Key: "events.git_batch_window_ms", Default: millis(eventbatch.DefaultWindow), Kind: config.KindMilliseconds, Description: "Janela de agrupamento dos eventos git/gitpanel enviados ao frontend"},
+		{Key: "gitpanel.event_debounce_ms", Default: millis(gitPanelEventDebounceWindow), Kind: config.KindMilliseconds, Description: "Debounce das invalidações do Git Panel"},
+		{Key: "gitpanel.status_cache_ttl_ms", Default: millis(gp.DefaultStatusCacheTTL), Kind: config.KindMilliseconds, Description: "TTL padrão do cache de status do Git Panel"},
+		{Key: "gitpanel.history_cache_ttl_ms", Default: millis(gp.DefaultHistoryCacheTTL), Kind: config.KindMilliseconds, Description: "TTL padrão do cache de histórico do Git Panel"},
+		{Key: "gitpanel.diff_cache_ttl_ms", Default: millis(gp.DefaultDiffCacheTTL), Kind: config.KindMilliseconds, Description: "TTL padrão do cache de diffs do Git Panel"},
+		{Key: "gitpanel.read_backend", Default: gp.ReadBackendCLI, Description: "Backend de leitura de status/histórico: cli ou gogit (em processo, com fallback para o CLI)"},
+		{Key: "gitpanel.max_concurrent_commands", Default: strconv.Itoa(gp.DefaultExecWorkers), Kind: config.KindInteger, Description: "Processos git simultâneos do Git Panel (leituras e writes)"},
+		{Key: "gitpanel.max_queued_writes", Default: strconv.Itoa(gp.DefaultWriteQueueDepth), Kind: config.KindInteger, Description: "Writes aguardando por repositório antes de recusar com E_BUSY"},
+		{Key: "ai.default_provider", Description: "Provider de IA ativo (vazio mantém a escolha automática)"},
+		{Key: "ai.openai_model", Default: ai.DefaultOpenAIModel, Description: "Modelo OpenAI"},
+		{Key: "ai.gemini_model", Default: ai.DefaultGeminiModel, Description: "Modelo Gemini"},
+		{Key: "ai.ollama_model", Default: ai.DefaultOllamaModel, Description: "Modelo Ollama"},
+		{Key: "ai.ollama_endpoint", Default: ai.DefaultOllamaEndpoint, Description: "Endpoint do Ollama"},
+		{Key: "disk.alert_workspaces_mb", Default: "20480", Kind: config.KindInteger, Description: "Alerta de disco quando os workspaces passam deste tamanho (MB)"},
+		{Key: "disk.alert_docker_mb", Default: "20480", Kind: config.KindInteger, Description: "Alerta de disco para as imagens Docker do ORCH (MB)"},
+		{Key: "disk.alert_database_mb", Default: "512", Kind: config.KindInteger, Description: "Alerta de disco para o banco SQLite (MB)"},
+		{Key: "disk.alert_backups_mb", Default: "2048", Kind: config.KindInteger, Description: "Alerta de disco para backups e snapshots do banco (MB)"},
+	}
+}
+
+// effectiveConfig retorna a configuração resolvida, criando o loader na primeira chamada.
+func (a *App) effectiveConfig() *config.EffectiveConfig {
+	a.mu.Lock()
+	if a.configFile == nil {
+		a.configFile = config.NewFileLoader(config.UserConfigFilePath(), configFileSettings())
 	}
+	loader := a.configFile
+	a.mu.Unlock()
+	return loader.Current()
+}
 
-	scheme := strings.ToLower(parsed.Scheme)
-	if scheme != "http" && scheme != "https" {
-		scheme = "http"
+// applyConfigFile propaga os valores recarregáveis aos serviços em execução.
+// Chaves com RequiresRestart (endereços de sessão) só são lidas em configureSessionNetworking.
+func (a *App) applyConfigFile(effective *config.EffectiveConfig) {
+	if effective == nil {
+		return
+	}
+	if effective.LoadError != "" {
+		log.Printf("[CONFIG] failed to load %s: %s", effective.Path, effective.LoadError)
+	}
+	for _, warning := range effective.Warnings {
+		log.Printf("[CONFIG] %s: %s", effective.Path, warning)
 	}
-	parsed.Scheme = scheme
-	parsed.Path = strings.TrimRight(parsed.Path, "/")
-	parsed.RawQuery = ""
-	parsed.Fragment = ""
-	return strings.TrimRight(parsed.String(), "/")
-}
 
-func normalizeSessionSignalingURL(raw string, fallbackListenAddr string) string {
-	base := strings.TrimSpace(raw)
-	if base == "" {
-		base = "ws://" + sessionPublicHostFromListenAddr(fallbackListenAddr) + "/ws/signal"
+	if a.gitHubStarted() {
+		a.applyGitHubConfig(effective)
+	}
+	if a.fileWatcher != nil {
+		a.fileWatcher.SetDebounceWindow(effective.Duration("filewatcher.debounce_ms"))
+		a.fileWatcher.SetWatchBudget(effective.Int("filewatcher.max_dirs_per_repo"))
 	}
-	if !strings.Contains(base, "://") {
-		base = "ws://" + base
+
+	a.gitEvents.SetWindow(effective.Duration("events.git_batch_window_ms"))
+
+	a.gitPanelEventsMu.Lock()
+	a.gitPanelDebounce = effective.Duration("gitpanel.event_debounce_ms")
+	a.gitPanelEventsMu.Unlock()
+	if a.gitPanel != nil {
+		a.gitPanel.SetDefaultCacheTTLs(gp.CacheTTLsDTO{
+			StatusMs:  int(effective.Duration("gitpanel.status_cache_ttl_ms") / time.Millisecond),
+			HistoryMs: int(effective.Duration("gitpanel.history_cache_ttl_ms") / time.Millisecond),
+			DiffMs:    int(effective.Duration("gitpanel.diff_cache_ttl_ms") / time.Millisecond),
+		})
+		a.gitPanel.SetExecLimits(effective.Int("gitpanel.max_concurrent_commands"), effective.Int("gitpanel.max_queued_writes"))
+		a.gitPanel.SetDefaultReadBackend(effective.Value("gitpanel.read_backend"))
 	}
 
-	parsed, err := url.Parse(base)
-	if err != nil || parsed.Host == "" {
-		return defaultSessionSignalingBaseURL
+	if a.ai != nil {
+		err := a.ai.ApplyDefaults(ai.Defaults{
+			Provider:       effective.Value("ai.default_provider"),
+			OpenAIModel:    effective.Value("ai.openai_model"),
+			GeminiModel:    effective.Value("ai.gemini_model"),
+			OllamaModel:    effective.Value("ai.ollama_model"),
+			OllamaEndpoint: effective.Value("ai.ollama_endpoint"),
+		})
+		if err != nil {
+			log.Printf("[CONFIG] ai defaults not applied: %v", err)
+		}
 	}
+}
 
-	switch strings.ToLower(parsed.Scheme) {
-	case "http":
-		parsed.Scheme = "ws"
-	case "https":
-		parsed.Scheme = "wss"
-	case "ws", "wss":
-		// ok
-	default:
-		parsed.Scheme = "ws"
+// applyGitHubConfig aplica os intervalos do poller e o TTL do cache do GitHub.
+func (a *App) applyGitHubConfig(effective *config.EffectiveConfig) {
+	if effective == nil {
+		return
 	}
+	a.poller.SetIntervals(map[gh.PollingContext]time.Duration{
+		gh.PollingContextPRDetail:    effective.Duration("github.poll_pr_detail_seconds"),
+		gh.PollingContextPRList:      effective.Duration("github.poll_pr_list_seconds"),
+		gh.PollingContextBackground:  effective.Duration("github.poll_background_seconds"),
+		gh.PollingContextMinimized:   effective.Duration("github.poll_minimized_seconds"),
+		gh.PollingContextCollaborate: effective.Duration("github.poll_collaborate_seconds"),
+	})
+	a.github.SetCacheTTL(effective.Duration("github.cache_ttl_seconds"))
+}
 
-	if parsed.Path == "" || parsed.Path == "/" {
-		parsed.Path = "/ws/signal"
+func (a *App) onConfigFileReloaded(effective *config.EffectiveConfig) {
+	log.Printf("[CONFIG] reloaded %s", effective.Path)
+	a.applyConfigFile(effective)
+	if a.ctx == nil {
+		return
 	}
-	parsed.Path = strings.TrimRight(parsed.Path, "/")
-	parsed.RawQuery = ""
-	parsed.Fragment = ""
-	return parsed.String()
+	runtime.EventsEmit(a.ctx, "config:reloaded", effective)
 }
 
-func (a *App) configureSessionNetworking() {
-	a.sessionGatewayAddr = normalizeSessionListenerAddr(os.Getenv("ORCH_SESSION_GATEWAY_LISTEN_ADDR"), defaultSessionGatewayListenAddr)
-	a.sessionGatewayURL = normalizeSessionGatewayBaseURL(os.Getenv("ORCH_SESSION_GATEWAY_BASE_URL"), a.sessionGatewayAddr)
-	a.signalingAddr = normalizeSessionListenerAddr(os.Getenv("ORCH_SESSION_SIGNALING_LISTEN_ADDR"), defaultSessionSignalingListenAddr)
-	a.signalingURL = normalizeSessionSignalingURL(os.Getenv("ORCH_SESSION_SIGNALING_BASE_URL"), a.signalingAddr)
+// GetEffectiveConfig retorna os valores resolvidos de config.toml, env vars e padrões, com a origem de cada um.
+func (a *App) GetEffectiveConfig() *config.EffectiveConfig {
+	return a.effectiveConfig()
 }
 
-func (a *App) resolvedSessionGatewayBaseURL() string {
-	if a != nil {
-		if custom := strings.TrimSpace(a.sessionGatewayURL); custom != "" {
-			return strings.TrimRight(custom, "/")
-		}
+// === Network / Proxy Bindings ===
+
+// GetNetworkSettings retorna o proxy e o bundle de CAs salvos.
+func (a *App) GetNetworkSettings() httpclient.Settings {
+	if a.db == nil {
+		return httpclient.Current()
 	}
-	return defaultSessionGatewayBaseURL
+	cfg, err := a.db.GetConfig()
+	if err != nil {
+		return httpclient.Current()
+	}
+	settings, err := httpclient.Settings{
+		ProxyMode:    cfg.ProxyMode,
+		ProxyURL:     cfg.ProxyURL,
+		NoProxy:      cfg.ProxyNoProxy,
+		CABundlePath: cfg.CABundlePath,
+	}.Normalize()
+	if err != nil {
+		return httpclient.Current()
+	}
+	return settings
 }
 
-func (a *App) resolvedSessionSignalingURL() string {
-	if a != nil {
-		if custom := strings.TrimSpace(a.signalingURL); custom != "" {
-			return custom
-		}
+// SetNetworkSettings valida, aplica imediatamente e persiste proxy/CA.
+// Um bundle de CAs inválido é rejeitado sem alterar a configuração atual.
+func (a *App) SetNetworkSettings(settings httpclient.Settings) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
 	}
-	return defaultSessionSignalingBaseURL
+	previous := httpclient.Current()
+	if err := httpclient.Configure(settings); err != nil {
+		return err
+	}
+	applied := httpclient.Current()
+	if err := a.db.SetNetworkSettings(applied.ProxyMode, applied.ProxyURL, applied.NoProxy, applied.CABundlePath); err != nil {
+		_ = httpclient.Configure(previous)
+		return err
+	}
+	log.Printf("[NETWORK] proxy mode=%s custom_ca=%t", applied.ProxyMode, applied.CABundlePath != "")
+	return nil
 }
 
-// SessionGetSignalingURL retorna a URL base do signaling WebSocket.
-func (a *App) SessionGetSignalingURL() string {
-	return a.resolvedSessionSignalingURL()
+// applyNetworkSettings aplica as configurações salvas no startup; falhas mantêm o proxy do ambiente.
+func (a *App) applyNetworkSettings() {
+	if a.db == nil {
+		return
+	}
+	settings := a.GetNetworkSettings()
+	if err := httpclient.Configure(settings); err != nil {
+		log.Printf("[NETWORK] failed to apply proxy settings: %v", err)
+		return
+	}
+	if settings.ProxyMode != httpclient.ProxyModeSystem || settings.CABundlePath != "" {
+		log.Printf("[NETWORK] proxy mode=%s custom_ca=%t", settings.ProxyMode, settings.CABundlePath != "")
+	}
 }
 
-func (a *App) callSessionGateway(method, path string, requestBody interface{}, responseBody interface{}) error {
-	var bodyReader io.Reader
-	if requestBody != nil {
-		payload, err := json.Marshal(requestBody)
-		if err != nil {
-			return fmt.Errorf("marshal gateway request: %w", err)
-		}
-		bodyReader = bytes.NewReader(payload)
-	}
+// === Issue Tracker Bindings ===
 
-	req, err := http.NewRequest(method, a.resolvedSessionGatewayBaseURL()+path, bodyReader)
+// issueTrackerTokenName identifica o token do issue tracker no Keychain.
+const issueTrackerTokenName = "issue_tracker"
+
+// IssueTrackerSettingsDTO configura o Jira/Linear. Token só é aceito na escrita: vazio mantém o
+// atual e HasToken indica se há um salvo no Keychain.
+type IssueTrackerSettingsDTO struct {
+	Provider string `json:"provider"` // "" | jira | linear
+	BaseURL  string `json:"baseUrl,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Projects string `json:"projects,omitempty"` // PROJ,OPS (vazio = qualquer chave)
+	Token    string `json:"token,omitempty"`
+	HasToken bool   `json:"hasToken"`
+}
+
+// GetIssueTrackerSettings retorna a configuração salva (sem o token).
+func (a *App) GetIssueTrackerSettings() IssueTrackerSettingsDTO {
+	if a.db == nil {
+		return IssueTrackerSettingsDTO{}
+	}
+	cfg, err := a.db.GetConfig()
 	if err != nil {
-		return fmt.Errorf("create gateway request: %w", err)
+		return IssueTrackerSettingsDTO{}
 	}
-	req.Header.Set("Content-Type", "application/json")
+	token, _ := auth.GetIntegrationToken(issueTrackerTokenName)
+	return IssueTrackerSettingsDTO{
+		Provider: cfg.IssueTracker,
+		BaseURL:  cfg.IssueTrackerURL,
+		Email:    cfg.IssueTrackerEmail,
+		Projects: cfg.IssueProjectKeys,
+		HasToken: token != "",
+	}
+}
 
-	client := &http.Client{Timeout: 4 * time.Second}
-	resp, err := client.Do(req)
+// SetIssueTrackerSettings valida, persiste e aplica a configuração. Provider vazio desabilita a
+// integração e remove o token do Keychain.
+func (a *App) SetIssueTrackerSettings(settings IssueTrackerSettingsDTO) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	provider, err := issuetracker.NormalizeProvider(settings.Provider)
 	if err != nil {
-		return fmt.Errorf("gateway request failed: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		var errPayload sessionGatewayErrorResponse
-		if decodeErr := json.NewDecoder(resp.Body).Decode(&errPayload); decodeErr == nil && errPayload.Error != "" {
-			return errors.New(errPayload.Error)
+	projects, err := issuetracker.NormalizeProjects(settings.Projects)
+	if err != nil {
+		return err
+	}
+	baseURL, email := "", ""
+	if provider == issuetracker.ProviderJira {
+		if baseURL, err = issuetracker.NormalizeBaseURL(settings.BaseURL); err != nil {
+			return err
 		}
-		return fmt.Errorf("gateway request failed with status %d", resp.StatusCode)
+		email = strings.TrimSpace(settings.Email)
 	}
 
-	if responseBody == nil {
-		return nil
+	if provider == issuetracker.ProviderNone {
+		if err := auth.DeleteIntegrationToken(issueTrackerTokenName); err != nil {
+			return err
+		}
+	} else if token := strings.TrimSpace(settings.Token); token != "" {
+		if err := auth.SetIntegrationToken(issueTrackerTokenName, token); err != nil {
+			return err
+		}
 	}
-	if err := json.NewDecoder(resp.Body).Decode(responseBody); err != nil {
-		return fmt.Errorf("decode gateway response: %w", err)
+	if err := a.db.SetIssueTrackerSettings(provider, baseURL, email, strings.Join(projects, ",")); err != nil {
+		return err
 	}
+	a.applyIssueTrackerSettings()
 	return nil
 }
 
-func (a *App) gatewayJoinSession(code, guestUserID string, guestInfo session.GuestInfo) (*session.JoinResult, error) {
-	var result session.JoinResult
-	err := a.callSessionGateway(http.MethodPost, "/api/session/join", map[string]interface{}{
-		"code":        code,
-		"guestUserID": guestUserID,
-		"guestInfo":   guestInfo,
-	}, &result)
+// applyIssueTrackerSettings carrega a configuração salva + token do Keychain no serviço.
+func (a *App) applyIssueTrackerSettings() {
+	if a.db == nil {
+		return
+	}
+	cfg, err := a.db.GetConfig()
 	if err != nil {
-		return nil, err
+		return
 	}
-	return &result, nil
+	projects, _ := issuetracker.NormalizeProjects(cfg.IssueProjectKeys)
+	token := ""
+	if cfg.IssueTracker != issuetracker.ProviderNone {
+		if token, err = auth.GetIntegrationToken(issueTrackerTokenName); err != nil {
+			log.Printf("[ISSUES] failed to read token from keychain: %v", err)
+		}
+	}
+	a.issueTracker.Configure(issuetracker.Config{
+		Provider: cfg.IssueTracker,
+		BaseURL:  cfg.IssueTrackerURL,
+		Email:    cfg.IssueTrackerEmail,
+		Token:    token,
+		Projects: projects,
+	})
 }
 
-func (a *App) gatewayCreateSession(hostUserID, hostName, hostAvatarURL string, cfg session.SessionConfig) (*session.Session, error) {
-	var result session.Session
-	err := a.callSessionGateway(http.MethodPost, "/api/session/create", map[string]interface{}{
-		"hostUserID":    hostUserID,
-		"hostName":      hostName,
-		"hostAvatarUrl": hostAvatarURL,
-		"config":        cfg,
-	}, &result)
+// ResolveIssueKeys busca título/status das chaves no provedor configurado (chaves inexistentes
+// são omitidas). Também serve para testar a conexão.
+func (a *App) ResolveIssueKeys(keys []string) ([]issuetracker.Issue, error) {
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	normalized := issuetracker.ExtractKeys(strings.ToUpper(strings.Join(keys, " ")), nil)
+	issues, err := a.issueTracker.Resolve(ctx, normalized)
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return issues, nil
 }
 
-func (a *App) gatewayGetSession(sessionID string) (*session.Session, error) {
-	var result session.Session
-	path := "/api/session/get?sessionID=" + url.QueryEscape(sessionID)
-	if err := a.callSessionGateway(http.MethodGet, path, nil, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+// ExtractIssueKeys detecta as chaves de ticket em um nome de branch ou mensagem de commit.
+func (a *App) ExtractIssueKeys(text string) []string {
+	return issuetracker.ExtractBranchKeys(text, a.issueTracker.Projects())
 }
 
-func (a *App) gatewayGetActiveSession(userID string) (*session.Session, error) {
-	var result session.Session
-	path := "/api/session/active?userID=" + url.QueryEscape(userID)
-	if err := a.callSessionGateway(http.MethodGet, path, nil, &result); err != nil {
-		return nil, err
+// lookupIssueLinks devolve os tickets citados em cada texto com o que já está em cache; o que falta
+// é resolvido em background e anunciado em "issues:resolved" para a UI recarregar.
+func (a *App) lookupIssueLinks(texts []string) ([][]string, map[string]issuetracker.Issue) {
+	keysPerText := make([][]string, len(texts))
+	if !a.issueTracker.Enabled() {
+		return keysPerText, nil
 	}
-	return &result, nil
-}
-
-func (a *App) gatewayListPendingGuests(sessionID string) ([]session.GuestRequest, error) {
-	var result []session.GuestRequest
-	path := "/api/session/pending?sessionID=" + url.QueryEscape(sessionID)
-	if err := a.callSessionGateway(http.MethodGet, path, nil, &result); err != nil {
-		return nil, err
+	projects := a.issueTracker.Projects()
+	all := []string{}
+	for i, text := range texts {
+		keysPerText[i] = issuetracker.ExtractBranchKeys(text, projects)
+		for _, key := range keysPerText[i] {
+			if !slices.Contains(all, key) {
+				all = append(all, key)
+			}
+		}
 	}
-	return result, nil
+	if len(all) == 0 {
+		return keysPerText, nil
+	}
+	found, missing := a.issueTracker.Cached(all)
+	if len(missing) > 0 {
+		a.issueTracker.Prefetch(missing, func(issues []issuetracker.Issue) {
+			if a.ctx != nil {
+				runtime.EventsEmit(a.ctx, "issues:resolved", issues)
+			}
+		})
+	}
+	return keysPerText, found
 }
 
-func (a *App) gatewayApproveGuest(sessionID, guestUserID string) error {
-	return a.callSessionGateway(http.MethodPost, "/api/session/approve", map[string]interface{}{
-		"sessionID":   sessionID,
-		"guestUserID": guestUserID,
-	}, nil)
+func (a *App) attachIssueLinksToHistory(page *gp.HistoryPageDTO) {
+	if page == nil || len(page.Items) == 0 {
+		return
+	}
+	texts := make([]string, len(page.Items))
+	for i, item := range page.Items {
+		texts[i] = item.Subject
+	}
+	keysPerText, found := a.lookupIssueLinks(texts)
+	for i, keys := range keysPerText {
+		for _, key := range keys {
+			issue := found[key]
+			page.Items[i].Issues = append(page.Items[i].Issues, gp.IssueLinkDTO{Key: key, Title: issue.Title, Status: issue.Status, URL: issue.URL})
+		}
+	}
 }
 
-func (a *App) gatewayRejectGuest(sessionID, guestUserID string) error {
-	return a.callSessionGateway(http.MethodPost, "/api/session/reject", map[string]interface{}{
-		"sessionID":   sessionID,
-		"guestUserID": guestUserID,
-	}, nil)
+func (a *App) attachIssueLinksToActivity(events []ga.Event) {
+	texts := make([]string, len(events))
+	for i, event := range events {
+		texts[i] = event.Branch + " " + event.Message
+	}
+	keysPerText, found := a.lookupIssueLinks(texts)
+	for i, keys := range keysPerText {
+		for _, key := range keys {
+			issue := found[key]
+			events[i].Issues = append(events[i].Issues, ga.IssueLink{Key: key, Title: issue.Title, Status: issue.Status, URL: issue.URL})
+		}
+	}
 }
 
-func (a *App) gatewayEndSession(sessionID string) error {
-	return a.callSessionGateway(http.MethodPost, "/api/session/end", map[string]interface{}{
-		"sessionID": sessionID,
-	}, nil)
+// suggestIssuesForPR resolve (com timeout curto) os tickets citados na branch head para o prefill do PR.
+func (a *App) suggestIssuesForPR(head string) []issuetracker.Issue {
+	if !a.issueTracker.Enabled() {
+		return nil
+	}
+	keys := issuetracker.ExtractBranchKeys(head, a.issueTracker.Projects())
+	if len(keys) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	issues, err := a.issueTracker.Resolve(ctx, keys)
+	if err != nil {
+		log.Printf("[ISSUES] failed to resolve %v for PR prefill: %v", keys, err)
+		return nil
+	}
+	return issues
 }
 
-func (a *App) gatewaySetGuestPermission(sessionID, guestUserID, permission string) error {
-	return a.callSessionGateway(http.MethodPost, "/api/session/permission", map[string]interface{}{
-		"sessionID":   sessionID,
-		"guestUserID": guestUserID,
-		"permission":  permission,
-	}, nil)
-}
+// === Lifecycle Hook Bindings ===
 
-func (a *App) gatewayKickGuest(sessionID, guestUserID string) error {
-	return a.callSessionGateway(http.MethodPost, "/api/session/kick", map[string]interface{}{
-		"sessionID":   sessionID,
-		"guestUserID": guestUserID,
-	}, nil)
+// LifecycleHookDTO é um script de usuário disparado por um evento (ver ListLifecycleHookEvents).
+type LifecycleHookDTO struct {
+	ID             uint   `json:"id"`
+	Name           string `json:"name"`
+	Event          string `json:"event"`
+	Script         string `json:"script"`
+	Cwd            string `json:"cwd,omitempty"`
+	TimeoutSeconds int    `json:"timeoutSeconds"`
+	Enabled        bool   `json:"enabled"`
 }
 
-func (a *App) gatewayRegenerateSessionCode(sessionID string) (*session.Session, error) {
-	var result session.Session
-	err := a.callSessionGateway(http.MethodPost, "/api/session/code/regenerate", map[string]interface{}{
-		"sessionID": sessionID,
-	}, &result)
-	if err != nil {
-		return nil, err
+func lifecycleHookToDTO(hook database.LifecycleHook) LifecycleHookDTO {
+	return LifecycleHookDTO{
+		ID:             hook.ID,
+		Name:           hook.Name,
+		Event:          hook.Event,
+		Script:         hook.Script,
+		Cwd:            hook.Cwd,
+		TimeoutSeconds: hook.TimeoutSeconds,
+		Enabled:        hook.Enabled,
 	}
-	return &result, nil
 }
 
-func (a *App) gatewayRevokeSessionCode(sessionID string) (*session.Session, error) {
-	var result session.Session
-	err := a.callSessionGateway(http.MethodPost, "/api/session/code/revoke", map[string]interface{}{
-		"sessionID": sessionID,
-	}, &result)
-	if err != nil {
-		return nil, err
+func lifecycleHookToRunnable(hook database.LifecycleHook) hooks.Hook {
+	return hooks.Hook{
+		ID:      hook.ID,
+		Name:    hook.Name,
+		Event:   hook.Event,
+		Script:  hook.Script,
+		Cwd:     hook.Cwd,
+		Timeout: time.Duration(hook.TimeoutSeconds) * time.Second,
 	}
-	return &result, nil
 }
 
-func (a *App) gatewaySetAllowNewJoins(sessionID string, allow bool) (*session.Session, error) {
-	var result session.Session
-	err := a.callSessionGateway(http.MethodPost, "/api/session/allow-joins", map[string]interface{}{
-		"sessionID": sessionID,
-		"allow":     allow,
-	}, &result)
+// loadLifecycleHooks é a fonte do runner: só os hooks habilitados do evento.
+func (a *App) loadLifecycleHooks(event string) ([]hooks.Hook, error) {
+	if a.db == nil {
+		return nil, nil
+	}
+	stored, err := a.db.ListLifecycleHooks(event)
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	result := make([]hooks.Hook, 0, len(stored))
+	for _, hook := range stored {
+		if hook.Enabled {
+			result = append(result, lifecycleHookToRunnable(hook))
+		}
+	}
+	return result, nil
 }
 
-func isSessionNotFoundErr(err error) bool {
-	if err == nil {
-		return false
+func (a *App) fireTerminalDestroyedHooks(sessionID string, agentID uint) {
+	payload := map[string]string{"sessionId": sessionID}
+	if agentID != 0 {
+		payload["agentId"] = strconv.FormatUint(uint64(agentID), 10)
 	}
-	msg := err.Error()
-	return strings.Contains(msg, "session not found") || strings.Contains(msg, "session not found for code")
+	a.lifecycleHooks.Fire(hooks.EventTerminalDestroyed, payload)
 }
 
-const genericSessionJoinCodeError = "código inválido ou expirado"
-
-func isSessionJoinCodeError(err error) bool {
-	if err == nil {
-		return false
-	}
-	msg := strings.ToLower(err.Error())
-	return strings.Contains(msg, "invalid code format") ||
-		strings.Contains(msg, "session not found for code") ||
-		strings.Contains(msg, "session code has expired") ||
-		strings.Contains(msg, "too many invalid join attempts")
+// ListLifecycleHookEvents lista os eventos que aceitam hooks.
+func (a *App) ListLifecycleHookEvents() []string {
+	return hooks.Events()
 }
 
-func sanitizeSessionJoinError(err error) error {
-	if err == nil {
-		return nil
+// ListLifecycleHooks lista os hooks configurados.
+func (a *App) ListLifecycleHooks() ([]LifecycleHookDTO, error) {
+	if a.db == nil {
+		return []LifecycleHookDTO{}, nil
 	}
-	if isSessionJoinCodeError(err) {
-		return errors.New(genericSessionJoinCodeError)
+	stored, err := a.db.ListLifecycleHooks("")
+	if err != nil {
+		return nil, err
 	}
-	return err
+	result := make([]LifecycleHookDTO, 0, len(stored))
+	for _, hook := range stored {
+		result = append(result, lifecycleHookToDTO(hook))
+	}
+	return result, nil
 }
 
-func sessionPersistUntil(sess *session.Session, now time.Time) time.Time {
-	if sess == nil {
-		return now.Add(30 * time.Minute)
+// SaveLifecycleHook cria (id=0) ou atualiza um hook. O script roda com /bin/sh -c (cmd /C no
+// Windows), só com variáveis seguras do ambiente e o payload do evento como ORCH_*.
+func (a *App) SaveLifecycleHook(dto LifecycleHookDTO) (LifecycleHookDTO, error) {
+	if a.db == nil {
+		return LifecycleHookDTO{}, fmt.Errorf("database not initialized")
 	}
-	if sess.Status == session.StatusWaiting {
-		if sess.ExpiresAt.After(now.Add(2 * time.Minute)) {
-			return sess.ExpiresAt
+	event := strings.TrimSpace(dto.Event)
+	if !hooks.ValidEvent(event) {
+		return LifecycleHookDTO{}, fmt.Errorf("unsupported hook event: %s", dto.Event)
+	}
+	timeoutSeconds := dto.TimeoutSeconds
+	if timeoutSeconds == 0 {
+		timeoutSeconds = int(hooks.DefaultTimeout / time.Second)
+	}
+	if timeoutSeconds < 1 || time.Duration(timeoutSeconds)*time.Second > hooks.MaxTimeout {
+		return LifecycleHookDTO{}, fmt.Errorf("hook timeout must be between 1 and %d seconds", int(hooks.MaxTimeout/time.Second))
+	}
+	cwd := strings.TrimSpace(dto.Cwd)
+	if cwd != "" {
+		cwd = filepath.Clean(cwd)
+		if info, err := os.Stat(cwd); err != nil || !info.IsDir() {
+			return LifecycleHookDTO{}, fmt.Errorf("hook working directory is not a directory: %s", cwd)
 		}
-		return now.Add(2 * time.Minute)
 	}
-	// Sessões ativas continuam válidas por uma janela curta pós-restart para retomada.
-	return now.Add(12 * time.Hour)
+
+	hook := &database.LifecycleHook{}
+	if dto.ID != 0 {
+		existing, err := a.db.GetLifecycleHook(dto.ID)
+		if err != nil {
+			return LifecycleHookDTO{}, err
+		}
+		hook = existing
+	}
+	hook.Name = dto.Name
+	hook.Event = event
+	hook.Script = strings.TrimSpace(dto.Script)
+	hook.Cwd = cwd
+	hook.TimeoutSeconds = timeoutSeconds
+	hook.Enabled = dto.Enabled
+
+	if err := a.db.SaveLifecycleHook(hook); err != nil {
+		return LifecycleHookDTO{}, err
+	}
+	return lifecycleHookToDTO(*hook), nil
 }
 
-func (a *App) persistSessionState(sessionID string) {
-	if a.db == nil || a.session == nil || strings.TrimSpace(sessionID) == "" {
-		return
+// DeleteLifecycleHook remove um hook.
+func (a *App) DeleteLifecycleHook(id uint) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
 	}
+	return a.db.DeleteLifecycleHook(id)
+}
 
-	sess, err := a.session.GetSession(sessionID)
+// TestLifecycleHook roda o hook na hora (mesmo desabilitado) com um payload de exemplo e
+// devolve a execução; o resultado também entra no histórico.
+func (a *App) TestLifecycleHook(id uint) (hooks.Run, error) {
+	if a.db == nil {
+		return hooks.Run{}, fmt.Errorf("database not initialized")
+	}
+	stored, err := a.db.GetLifecycleHook(id)
 	if err != nil {
-		return
+		return hooks.Run{}, err
 	}
-	if sess.Status == session.StatusEnded {
-		a.deletePersistedSessionState(sessionID)
-		return
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
 	}
+	return a.lifecycleHooks.ExecuteAndRecord(ctx, lifecycleHookToRunnable(*stored), stored.Event, map[string]string{"test": "true"}), nil
+}
 
-	now := time.Now()
-	if sess.Status == session.StatusWaiting && now.After(sess.ExpiresAt) {
-		a.deletePersistedSessionState(sessionID)
-		return
+// GetLifecycleHookRuns retorna as últimas execuções de hooks (mais recentes primeiro).
+func (a *App) GetLifecycleHookRuns() []hooks.Run {
+	runs := a.lifecycleHooks.Runs()
+	if runs == nil {
+		return []hooks.Run{}
 	}
+	return runs
+}
 
-	payload, err := json.Marshal(sess)
-	if err != nil {
-		log.Printf("[ORCH][SESSION] failed to marshal session state %s: %v", sessionID, err)
-		return
+// === Outbound Notification Bindings ===
+
+// NotificationTargetDTO é um webhook de saída. A URL nunca volta para o frontend (Slack/Discord
+// embutem o segredo nela): só URLMasked; enviar URL vazia ao atualizar mantém a atual.
+type NotificationTargetDTO struct {
+	ID        uint     `json:"id"`
+	Name      string   `json:"name"`
+	Kind      string   `json:"kind"`
+	URL       string   `json:"url,omitempty"`
+	URLMasked string   `json:"urlMasked"`
+	Events    []string `json:"events"`
+	Template  string   `json:"template,omitempty"`
+	Enabled   bool     `json:"enabled"`
+}
+
+func notificationTargetToDTO(target database.NotificationTarget) NotificationTargetDTO {
+	return NotificationTargetDTO{
+		ID:        target.ID,
+		Name:      target.Name,
+		Kind:      target.Kind,
+		URLMasked: notify.MaskURL(target.URL),
+		Events:    notificationTargetEvents(target),
+		Template:  target.Template,
+		Enabled:   target.Enabled,
 	}
+}
 
-	state := &database.CollabSessionState{
-		SessionID:    sess.ID,
-		HostUserID:   sess.HostUserID,
-		Code:         sess.Code,
-		Status:       string(sess.Status),
-		ExpiresAt:    sess.ExpiresAt,
-		PersistUntil: sessionPersistUntil(sess, now),
-		Payload:      string(payload),
+func notificationTargetEvents(target database.NotificationTarget) []string {
+	events := []string{}
+	if strings.TrimSpace(target.Events) != "" {
+		_ = json.Unmarshal([]byte(target.Events), &events)
 	}
-	if err := a.db.UpsertCollabSessionState(state); err != nil {
-		log.Printf("[ORCH][SESSION] failed to persist session state %s: %v", sessionID, err)
+	return events
+}
+
+func notificationTargetToNotify(target database.NotificationTarget) notify.Target {
+	return notify.Target{
+		ID:       target.ID,
+		Name:     target.Name,
+		Kind:     target.Kind,
+		URL:      target.URL,
+		Events:   notificationTargetEvents(target),
+		Template: target.Template,
 	}
 }
 
-func (a *App) deletePersistedSessionState(sessionID string) {
-	if a.db == nil || strings.TrimSpace(sessionID) == "" {
-		return
+// loadNotificationTargets é a fonte do dispatcher: webhooks habilitados inscritos no evento.
+func (a *App) loadNotificationTargets(event string) ([]notify.Target, error) {
+	if a.db == nil {
+		return nil, nil
 	}
-	if err := a.db.DeleteCollabSessionState(sessionID); err != nil {
-		log.Printf("[ORCH][SESSION] failed to delete persisted session state %s: %v", sessionID, err)
+	stored, err := a.db.ListNotificationTargets()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]notify.Target, 0, len(stored))
+	for _, target := range stored {
+		if target.Enabled && slices.Contains(notificationTargetEvents(target), event) {
+			result = append(result, notificationTargetToNotify(target))
+		}
 	}
+	return result, nil
 }
 
-func (a *App) restorePersistedSessionStates() {
-	if a.db == nil || a.session == nil {
+// notifyEvent dispara o evento para os webhooks inscritos, sem bloquear o chamador.
+func (a *App) notifyEvent(name, title, message string, fields map[string]string) {
+	event := notify.Event{Name: name, Title: title, Message: message, Fields: fields}
+	// Com Não Perturbe, o envio fica retido até o fim do foco.
+	if a.focusTimer != nil && a.focusTimer.Defer(func() { a.notifications.Notify(event) }) {
 		return
 	}
+	a.notifications.Notify(event)
+}
 
-	now := time.Now()
-	if err := a.db.CleanupExpiredCollabSessionStates(now); err != nil {
-		log.Printf("[ORCH][SESSION] cleanup persisted states failed: %v", err)
-	}
+// ListNotificationEvents lista os eventos que podem disparar webhooks.
+func (a *App) ListNotificationEvents() []string {
+	return notify.Events()
+}
 
-	states, err := a.db.ListRestorableCollabSessionStates(now, 300)
+// ListNotificationTargets lista os webhooks configurados.
+func (a *App) ListNotificationTargets() ([]NotificationTargetDTO, error) {
+	if a.db == nil {
+		return []NotificationTargetDTO{}, nil
+	}
+	stored, err := a.db.ListNotificationTargets()
 	if err != nil {
-		log.Printf("[ORCH][SESSION] failed to load persisted states: %v", err)
-		return
+		return nil, err
+	}
+	result := make([]NotificationTargetDTO, 0, len(stored))
+	for _, target := range stored {
+		result = append(result, notificationTargetToDTO(target))
 	}
+	return result, nil
+}
 
-	restoredCount := 0
-	for _, row := range states {
-		var restored session.Session
-		if err := json.Unmarshal([]byte(row.Payload), &restored); err != nil {
-			log.Printf("[ORCH][SESSION] invalid persisted payload sessionID=%s err=%v", row.SessionID, err)
-			a.deletePersistedSessionState(row.SessionID)
-			continue
-		}
-		if restored.ID == "" || restored.Status == session.StatusEnded {
-			a.deletePersistedSessionState(row.SessionID)
-			continue
+// SaveNotificationTarget cria (id=0) ou atualiza um webhook. O template usa text/template sobre o
+// evento ({{.Title}}, {{.Message}}, {{index .Fields "repo"}}, {{json .}}); para generic precisa gerar JSON.
+func (a *App) SaveNotificationTarget(dto NotificationTargetDTO) (NotificationTargetDTO, error) {
+	if a.db == nil {
+		return NotificationTargetDTO{}, fmt.Errorf("database not initialized")
+	}
+	kind, err := notify.NormalizeKind(dto.Kind)
+	if err != nil {
+		return NotificationTargetDTO{}, err
+	}
+	events := make([]string, 0, len(dto.Events))
+	for _, event := range dto.Events {
+		event = strings.TrimSpace(event)
+		if !slices.Contains(notify.Events(), event) {
+			return NotificationTargetDTO{}, fmt.Errorf("unsupported notification event: %s", event)
 		}
-		if restored.Status == session.StatusWaiting && now.After(restored.ExpiresAt) {
-			a.deletePersistedSessionState(row.SessionID)
-			continue
+		if !slices.Contains(events, event) {
+			events = append(events, event)
 		}
+	}
+	if err := notify.ValidateTemplate(kind, dto.Template); err != nil {
+		return NotificationTargetDTO{}, err
+	}
 
-		if err := a.session.RestoreSession(&restored); err != nil {
-			log.Printf("[ORCH][SESSION] failed to restore session %s: %v", row.SessionID, err)
-			continue
+	target := &database.NotificationTarget{}
+	if dto.ID != 0 {
+		existing, err := a.db.GetNotificationTarget(dto.ID)
+		if err != nil {
+			return NotificationTargetDTO{}, err
 		}
-		restoredCount++
+		target = existing
 	}
-
-	if restoredCount > 0 {
-		log.Printf("[ORCH][SESSION] restored %d session(s) from persistence", restoredCount)
+	if webhookURL := strings.TrimSpace(dto.URL); webhookURL != "" || target.ID == 0 {
+		if err := notify.ValidateURL(webhookURL); err != nil {
+			return NotificationTargetDTO{}, err
+		}
+		target.URL = webhookURL
 	}
-}
-
-// SessionCreate cria uma nova sessão de colaboração limitada a um workspace.
-func (a *App) SessionCreate(maxGuests int, mode string, allowAnonymous bool, workspaceID uint) (*session.Session, error) {
-	hostUser, err := a.requireGitHubSessionUser()
+	encodedEvents, err := json.Marshal(events)
 	if err != nil {
-		return nil, err
+		return NotificationTargetDTO{}, err
 	}
+	target.Name = dto.Name
+	target.Kind = kind
+	target.Events = string(encodedEvents)
+	target.Template = strings.TrimSpace(dto.Template)
+	target.Enabled = dto.Enabled
 
-	hostUserID := strings.TrimSpace(hostUser.ID)
-	hostName := strings.TrimSpace(hostUser.Name)
-	if hostName == "" {
-		hostName = hostUserID
+	if err := a.db.SaveNotificationTarget(target); err != nil {
+		return NotificationTargetDTO{}, err
 	}
-	hostAvatarURL := strings.TrimSpace(hostUser.AvatarURL)
-	allowAnonymous = false
+	return notificationTargetToDTO(*target), nil
+}
 
+// DeleteNotificationTarget remove um webhook.
+func (a *App) DeleteNotificationTarget(id uint) error {
 	if a.db == nil {
-		return nil, fmt.Errorf("database service not initialized")
+		return fmt.Errorf("database not initialized")
 	}
+	return a.db.DeleteNotificationTarget(id)
+}
 
-	var (
-		scopedWorkspace *database.Workspace
-	)
-	if workspaceID == 0 {
-		scopedWorkspace, err = a.db.GetActiveWorkspace()
-	} else {
-		scopedWorkspace, err = a.db.GetWorkspace(workspaceID)
+// TestNotificationTarget envia na hora (mesmo desabilitado) um evento de exemplo e devolve a entrega.
+func (a *App) TestNotificationTarget(id uint) (notify.Delivery, error) {
+	if a.db == nil {
+		return notify.Delivery{}, fmt.Errorf("database not initialized")
 	}
-	if err != nil || scopedWorkspace == nil {
-		return nil, fmt.Errorf("workspace not found")
+	stored, err := a.db.GetNotificationTarget(id)
+	if err != nil {
+		return notify.Delivery{}, err
 	}
-
-	cfg := session.SessionConfig{
-		MaxGuests:      maxGuests,
-		DefaultPerm:    session.PermReadOnly,
-		AllowAnonymous: false,
-		Mode:           session.SessionMode(mode),
-		WorkspaceID:    scopedWorkspace.ID,
-		WorkspaceName:  strings.TrimSpace(scopedWorkspace.Name),
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	if cfg.WorkspaceName == "" {
-		cfg.WorkspaceName = fmt.Sprintf("Workspace %d", scopedWorkspace.ID)
+	event := notify.Event{
+		Name:       "test",
+		Title:      "ORCH test notification",
+		Message:    fmt.Sprintf("Webhook %q is configured correctly.", stored.Name),
+		Fields:     map[string]string{"target": stored.Name},
+		OccurredAt: time.Now(),
 	}
+	return a.notifications.Send(ctx, notificationTargetToNotify(*stored), event), nil
+}
 
-	if cfg.Mode == "" {
-		cfg.Mode = session.ModeLiveShare
-	}
-	if cfg.Mode != session.ModeDocker && cfg.Mode != session.ModeLiveShare {
-		cfg.Mode = session.ModeLiveShare
+// GetNotificationDeliveries retorna as últimas entregas de webhooks (mais recentes primeiro).
+func (a *App) GetNotificationDeliveries() []notify.Delivery {
+	deliveries := a.notifications.Deliveries()
+	if deliveries == nil {
+		return []notify.Delivery{}
 	}
+	return deliveries
+}
 
-	var containerID string
-	if cfg.Mode == session.ModeDocker {
-		if a.docker == nil || !a.docker.IsDockerAvailable() {
-			cfg.Mode = session.ModeLiveShare
-			runtime.EventsEmit(a.ctx, "session:docker_fallback", map[string]string{
-				"reason": "Docker não está disponível. Sessão iniciada em Live Share.",
-			})
-		} else {
-			if scopedWorkspace.Path == "" {
-				return nil, fmt.Errorf("docker mode requires a workspace with a valid path")
-			}
-
-			cfg.ProjectPath = scopedWorkspace.Path
-
-			// Verifica se existe imagem customizada criada pelo Stack Builder
-			customImage := "orch-custom-stack:latest"
-			if a.docker.ImageExists(customImage) {
-				cfg.DockerImage = customImage
-				log.Printf("[ORCH] Using custom stack image: %s", customImage)
-			} else {
-				cfg.DockerImage = a.docker.DetectImage(scopedWorkspace.Path)
-			}
+// === Problem Matcher Bindings ===
 
-			containerCfg := docker.ContainerConfig{
-				Image:       cfg.DockerImage,
-				ProjectPath: scopedWorkspace.Path,
-				Memory:      "2g",
-				CPUs:        "2",
-				Shell:       "/bin/sh",
-				ReadOnly:    true,
-				NetworkMode: "none",
-			}
+// ProblemsEvent é o payload de "problems:updated": a lista completa de problemas do workspace.
+type ProblemsEvent struct {
+	WorkspaceID uint               `json:"workspaceId"`
+	Problems    []problems.Problem `json:"problems"`
+}
 
-			createdContainerID, createErr := a.docker.CreateContainer(containerCfg)
-			if createErr != nil {
-				return nil, createErr
-			}
-			containerID = createdContainerID
+// ProblemMatcherDTO é um problem matcher exibido nas configurações. Os embutidos (Builtin)
+// têm ID 0 e não podem ser editados.
+type ProblemMatcherDTO struct {
+	ID            uint   `json:"id"`
+	Name          string `json:"name"`
+	Pattern       string `json:"pattern"`
+	FileGroup     int    `json:"fileGroup"`
+	LineGroup     int    `json:"lineGroup"`
+	ColumnGroup   int    `json:"columnGroup"`
+	SeverityGroup int    `json:"severityGroup"`
+	MessageGroup  int    `json:"messageGroup"`
+	Severity      string `json:"severity"`
+	Enabled       bool   `json:"enabled"`
+	Builtin       bool   `json:"builtin"`
+}
 
-			if err := a.docker.StartContainer(containerID); err != nil {
-				_ = a.docker.RemoveContainer(containerID)
-				return nil, err
-			}
+func problemMatcherToDTO(matcher database.ProblemMatcher) ProblemMatcherDTO {
+	return ProblemMatcherDTO{
+		ID:            matcher.ID,
+		Name:          matcher.Name,
+		Pattern:       matcher.Pattern,
+		FileGroup:     matcher.FileGroup,
+		LineGroup:     matcher.LineGroup,
+		ColumnGroup:   matcher.ColumnGroup,
+		SeverityGroup: matcher.SeverityGroup,
+		MessageGroup:  matcher.MessageGroup,
+		Severity:      matcher.Severity,
+		Enabled:       matcher.Enabled,
+	}
+}
 
-			if err := a.docker.WaitUntilRunning(containerID, 5*time.Second); err != nil {
-				_ = a.docker.StopContainer(containerID)
-				_ = a.docker.RemoveContainer(containerID)
-				return nil, err
-			}
-		}
+func problemMatcherFromDTO(dto ProblemMatcherDTO) problems.Matcher {
+	return problems.Matcher{
+		Name:          dto.Name,
+		Pattern:       dto.Pattern,
+		FileGroup:     dto.FileGroup,
+		LineGroup:     dto.LineGroup,
+		ColumnGroup:   dto.ColumnGroup,
+		SeverityGroup: dto.SeverityGroup,
+		MessageGroup:  dto.MessageGroup,
+		Severity:      dto.Severity,
 	}
+}
 
-	var createdSession *session.Session
+func (a *App) emitProblemsUpdated(workspaceID uint, list []problems.Problem) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, "problems:updated", ProblemsEvent{WorkspaceID: workspaceID, Problems: list})
+}
 
-	if !a.sessionGatewayOwner {
-		createdSession, err = a.gatewayCreateSession(hostUserID, hostName, hostAvatarURL, cfg)
-		if err != nil {
-			if containerID != "" && a.docker != nil {
-				_ = a.docker.StopContainer(containerID)
-				_ = a.docker.RemoveContainer(containerID)
-			}
-			return nil, fmt.Errorf("session gateway create failed in client mode: %w", err)
-		}
-	} else {
-		if a.session == nil {
-			if containerID != "" && a.docker != nil {
-				_ = a.docker.StopContainer(containerID)
-				_ = a.docker.RemoveContainer(containerID)
-			}
-			return nil, fmt.Errorf("session service not initialized")
-		}
-		createdSession, err = a.session.CreateSession(hostUserID, cfg)
-		if err != nil {
-			if containerID != "" && a.docker != nil {
-				_ = a.docker.StopContainer(containerID)
-				_ = a.docker.RemoveContainer(containerID)
-			}
-			return nil, err
+// reloadProblemMatchers aplica ao coletor os matchers habilitados do usuário.
+func (a *App) reloadProblemMatchers() {
+	if a.problemCollector == nil || a.db == nil {
+		return
+	}
+	stored, err := a.db.ListProblemMatchers()
+	if err != nil {
+		log.Printf("[PROBLEMS] failed to load problem matchers: %v", err)
+		return
+	}
+	custom := make([]problems.Matcher, 0, len(stored))
+	for _, matcher := range stored {
+		if matcher.Enabled {
+			custom = append(custom, problemMatcherFromDTO(problemMatcherToDTO(matcher)))
 		}
 	}
-	createdSession.HostUserID = hostUserID
-	createdSession.HostName = hostName
-	createdSession.HostAvatarURL = hostAvatarURL
-
-	if containerID != "" {
-		a.setSessionContainer(createdSession.ID, containerID)
-		a.auditSessionEvent(createdSession.ID, hostUserID, "container_started", fmt.Sprintf("container=%s image=%s", containerID, cfg.DockerImage))
+	for _, err := range a.problemCollector.SetMatchers(custom) {
+		log.Printf("[PROBLEMS] ignoring problem matcher %v", err)
 	}
-	a.auditSessionEvent(
-		createdSession.ID,
-		hostUserID,
-		"session_created",
-		fmt.Sprintf(
-			"mode=%s allowAnonymous=%t workspaceID=%d workspaceName=%s",
-			createdSession.Mode,
-			allowAnonymous,
-			cfg.WorkspaceID,
-			cfg.WorkspaceName,
-		),
-	)
-	if a.sessionGatewayOwner {
-		a.persistSessionState(createdSession.ID)
+}
+
+// GetProblems lista os diagnósticos encontrados nos terminais do workspace (erros primeiro).
+// Atualizações chegam pelo evento problems:updated.
+func (a *App) GetProblems(workspaceID uint) []problems.Problem {
+	if a.problemCollector == nil {
+		return []problems.Problem{}
 	}
+	return a.problemCollector.Problems(workspaceID)
+}
 
-	return createdSession, nil
+// ClearProblems limpa o painel de problemas do workspace.
+func (a *App) ClearProblems(workspaceID uint) {
+	if a.problemCollector != nil {
+		a.problemCollector.Clear(workspaceID)
+	}
 }
 
-// SessionJoin entra em uma sessão usando um código
-func (a *App) SessionJoin(code string, name string, email string) (*session.JoinResult, error) {
-	guestUser, err := a.requireGitHubSessionUser()
+// ListProblemMatchers lista os matchers embutidos seguidos dos configurados pelo usuário.
+func (a *App) ListProblemMatchers() ([]ProblemMatcherDTO, error) {
+	result := make([]ProblemMatcherDTO, 0, len(problems.Builtin))
+	for _, matcher := range problems.Builtin {
+		result = append(result, ProblemMatcherDTO{
+			Name:          matcher.Name,
+			Pattern:       matcher.Pattern,
+			FileGroup:     matcher.FileGroup,
+			LineGroup:     matcher.LineGroup,
+			ColumnGroup:   matcher.ColumnGroup,
+			SeverityGroup: matcher.SeverityGroup,
+			MessageGroup:  matcher.MessageGroup,
+			Severity:      matcher.Severity,
+			Enabled:       true,
+			Builtin:       true,
+		})
+	}
+	if a.db == nil {
+		return result, nil
+	}
+	stored, err := a.db.ListProblemMatchers()
 	if err != nil {
 		return nil, err
 	}
+	for _, matcher := range stored {
+		result = append(result, problemMatcherToDTO(matcher))
+	}
+	return result, nil
+}
 
-	guestUserID := strings.TrimSpace(guestUser.ID)
-	if guestUserID == "" {
-		return nil, fmt.Errorf("sessões colaborativas exigem um usuário autenticado válido")
+// SaveProblemMatcher cria (id=0) ou atualiza um matcher do usuário. A regex é aplicada a cada
+// linha da saída (sem ANSI) e os grupos indicam arquivo, linha, coluna, severidade e mensagem.
+func (a *App) SaveProblemMatcher(dto ProblemMatcherDTO) (ProblemMatcherDTO, error) {
+	if a.db == nil {
+		return ProblemMatcherDTO{}, fmt.Errorf("database not initialized")
+	}
+	if dto.Builtin {
+		return ProblemMatcherDTO{}, fmt.Errorf("built-in problem matchers cannot be changed")
+	}
+	compiled, err := problems.Compile(problemMatcherFromDTO(dto))
+	if err != nil {
+		return ProblemMatcherDTO{}, err
 	}
-	avatarURL := strings.TrimSpace(guestUser.AvatarURL)
 
-	if strings.TrimSpace(name) == "" {
-		name = strings.TrimSpace(guestUser.Name)
+	matcher := &database.ProblemMatcher{}
+	if dto.ID != 0 {
+		existing, err := a.db.GetProblemMatcher(dto.ID)
+		if err != nil {
+			return ProblemMatcherDTO{}, err
+		}
+		matcher = existing
+	}
+	matcher.Name = compiled.Name
+	matcher.Pattern = compiled.Pattern
+	matcher.FileGroup = compiled.FileGroup
+	matcher.LineGroup = compiled.LineGroup
+	matcher.ColumnGroup = compiled.ColumnGroup
+	matcher.SeverityGroup = compiled.SeverityGroup
+	matcher.MessageGroup = compiled.MessageGroup
+	matcher.Severity = compiled.Severity
+	matcher.Enabled = dto.Enabled
+	if err := a.db.SaveProblemMatcher(matcher); err != nil {
+		return ProblemMatcherDTO{}, err
+	}
+	a.reloadProblemMatchers()
+	return problemMatcherToDTO(*matcher), nil
+}
+
+// DeleteProblemMatcher remove um matcher do usuário.
+func (a *App) DeleteProblemMatcher(id uint) error {
+	if a.db == nil {
+		return fmt.Errorf("database not initialized")
 	}
-	if strings.TrimSpace(email) == "" {
-		email = strings.TrimSpace(guestUser.Email)
+	if err := a.db.DeleteProblemMatcher(id); err != nil {
+		return err
 	}
-	if strings.TrimSpace(name) == "" {
-		name = guestUserID
+	a.reloadProblemMatchers()
+	return nil
+}
+
+// === MCP Server Bindings ===
+
+const (
+	// mcpTokenName identifica o token do servidor MCP no Keychain.
+	mcpTokenName       = "mcp_server"
+	mcpDefaultPort     = 7391
+	mcpApprovalTimeout = 2 * time.Minute
+	mcpDefaultOutput   = 4000
+	mcpMaxOutput       = 64 << 10
+)
+
+// Políticas por ferramenta MCP.
+const (
+	MCPPolicyAllow = "allow"
+	MCPPolicyAsk   = "ask" // cada chamada precisa de aprovação na UI
+	MCPPolicyDeny  = "deny"
+)
+
+var terminalEscapeRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]|\x1b\][^\x07]*\x07|\x1b[()][A-Za-z0-9]`)
+
+// MCPToolDTO descreve uma ferramenta exposta e sua política atual.
+type MCPToolDTO struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ReadOnly    bool   `json:"readOnly"`
+	Policy      string `json:"policy"`
+}
+
+// MCPSettingsDTO configura o servidor MCP. O token (Keychain) é mostrado para o usuário colar
+// na configuração do agente externo.
+type MCPSettingsDTO struct {
+	Enabled  bool              `json:"enabled"`
+	Port     int               `json:"port"`
+	Policies map[string]string `json:"policies,omitempty"` // ferramenta -> allow|ask|deny (ausente = padrão)
+	Running  bool              `json:"running"`
+	URL      string            `json:"url,omitempty"`
+	Token    string            `json:"token,omitempty"`
+	Tools    []MCPToolDTO      `json:"tools"`
+}
+
+// MCPApprovalRequestDTO é uma chamada aguardando aprovação (evento "mcp:approval_requested").
+type MCPApprovalRequestDTO struct {
+	ID          string    `json:"id"`
+	Client      string    `json:"client"`
+	Tool        string    `json:"tool"`
+	Arguments   string    `json:"arguments"`
+	RequestedAt time.Time `json:"requestedAt"`
+}
+
+type mcpApproval struct {
+	request  MCPApprovalRequestDTO
+	decision chan bool
+}
+
+// mcpDefaultPolicy: leitura liberada, qualquer ação pede aprovação.
+func mcpDefaultPolicy(tool mcp.Tool) string {
+	if tool.ReadOnly {
+		return MCPPolicyAllow
 	}
+	return MCPPolicyAsk
+}
 
-	guestInfo := session.GuestInfo{
-		Name:      name,
-		Email:     email,
-		AvatarURL: avatarURL,
+func (a *App) loadMCPPolicies() map[string]string {
+	policies := map[string]string{}
+	if a.db == nil {
+		return policies
+	}
+	cfg, err := a.db.GetConfig()
+	if err != nil || strings.TrimSpace(cfg.MCPToolPolicies) == "" {
+		return policies
 	}
+	_ = json.Unmarshal([]byte(cfg.MCPToolPolicies), &policies)
+	return policies
+}
 
-	if a.session == nil || !a.sessionGatewayOwner {
-		result, err := a.gatewayJoinSession(code, guestUserID, guestInfo)
-		if err != nil {
-			if !a.sessionGatewayOwner {
-				return nil, sanitizeSessionJoinError(fmt.Errorf("gateway join failed in client mode: %w", err))
-			}
-			return nil, sanitizeSessionJoinError(fmt.Errorf("session service not initialized and gateway join failed: %w", err))
-		}
-		a.auditSessionEvent(result.SessionID, guestUserID, "guest_requested_join", fmt.Sprintf("name=%s", name))
-		return result, nil
+func (a *App) mcpToolPolicy(tool mcp.Tool, policies map[string]string) string {
+	if policy, ok := policies[tool.Name]; ok {
+		return policy
 	}
+	return mcpDefaultPolicy(tool)
+}
 
-	result, err := a.session.JoinSession(code, guestUserID, guestInfo)
-	if err != nil {
-		// Fallback para gateway compartilhado em outra instância local.
-		if isSessionNotFoundErr(err) {
-			remoteResult, remoteErr := a.gatewayJoinSession(code, guestUserID, guestInfo)
-			if remoteErr != nil {
-				return nil, sanitizeSessionJoinError(fmt.Errorf("%v (gateway fallback failed: %w)", err, remoteErr))
+// GetMCPSettings retorna a configuração do servidor MCP e as ferramentas disponíveis.
+func (a *App) GetMCPSettings() MCPSettingsDTO {
+	settings := MCPSettingsDTO{Port: mcpDefaultPort, Policies: map[string]string{}}
+	if a.db != nil {
+		if cfg, err := a.db.GetConfig(); err == nil {
+			settings.Enabled = cfg.MCPEnabled
+			if cfg.MCPPort > 0 {
+				settings.Port = cfg.MCPPort
 			}
-			a.auditSessionEvent(remoteResult.SessionID, guestUserID, "guest_requested_join", fmt.Sprintf("name=%s", name))
-			return remoteResult, nil
 		}
-		return nil, sanitizeSessionJoinError(err)
 	}
-
-	a.auditSessionEvent(result.SessionID, guestUserID, "guest_requested_join", fmt.Sprintf("name=%s", name))
-	a.persistSessionState(result.SessionID)
-	return result, nil
+	policies := a.loadMCPPolicies()
+	for _, tool := range a.mcpServer.Tools() {
+		policy := a.mcpToolPolicy(tool, policies)
+		settings.Policies[tool.Name] = policy
+		settings.Tools = append(settings.Tools, MCPToolDTO{Name: tool.Name, Description: tool.Description, ReadOnly: tool.ReadOnly, Policy: policy})
+	}
+	if addr := a.mcpServer.Addr(); addr != "" {
+		settings.Running = true
+		settings.URL = "http://" + addr + mcp.Path
+	}
+	settings.Token, _ = auth.GetIntegrationToken(mcpTokenName)
+	return settings
 }
 
-// SessionApproveGuest aprova um guest na sessão
-func (a *App) SessionApproveGuest(sessionID, guestUserID string) error {
-	var err error
-	if a.session == nil || !a.sessionGatewayOwner {
-		err = a.gatewayApproveGuest(sessionID, guestUserID)
-	} else {
-		err = a.session.ApproveGuest(sessionID, guestUserID)
-		if isSessionNotFoundErr(err) {
-			err = a.gatewayApproveGuest(sessionID, guestUserID)
+// SetMCPSettings valida, persiste e (re)inicia o servidor MCP.
+func (a *App) SetMCPSettings(settings MCPSettingsDTO) (MCPSettingsDTO, error) {
+	if a.db == nil {
+		return MCPSettingsDTO{}, fmt.Errorf("database not initialized")
+	}
+	port := settings.Port
+	if port == 0 {
+		port = mcpDefaultPort
+	}
+	if port < 1024 || port > 65535 {
+		return MCPSettingsDTO{}, fmt.Errorf("mcp port must be between 1024 and 65535")
+	}
+	tools := a.mcpServer.Tools()
+	policies := map[string]string{}
+	for name, policy := range settings.Policies {
+		idx := slices.IndexFunc(tools, func(tool mcp.Tool) bool { return tool.Name == name })
+		if idx < 0 {
+			return MCPSettingsDTO{}, fmt.Errorf("unknown mcp tool: %s", name)
+		}
+		policy = strings.ToLower(strings.TrimSpace(policy))
+		if policy != MCPPolicyAllow && policy != MCPPolicyAsk && policy != MCPPolicyDeny {
+			return MCPSettingsDTO{}, fmt.Errorf("invalid policy %q for %s (use allow, ask or deny)", policy, name)
+		}
+		if policy != mcpDefaultPolicy(tools[idx]) {
+			policies[name] = policy
 		}
 	}
+	encoded, err := json.Marshal(policies)
 	if err != nil {
-		return err
+		return MCPSettingsDTO{}, err
 	}
-	a.auditSessionEvent(sessionID, guestUserID, "guest_approved", "Host approved guest in waiting room")
-	a.auditSessionEvent(sessionID, guestUserID, "guest_entered", "Guest admitted into collaboration session")
-	a.syncGuestPermissionAcrossPTYs(sessionID, guestUserID)
-	if a.sessionGatewayOwner {
-		a.persistSessionState(sessionID)
+	if err := a.db.SetMCPSettings(settings.Enabled, port, string(encoded)); err != nil {
+		return MCPSettingsDTO{}, err
 	}
-	return nil
+	if err := a.applyMCPSettings(); err != nil {
+		return a.GetMCPSettings(), err
+	}
+	return a.GetMCPSettings(), nil
 }
 
-// SessionRejectGuest rejeita um guest na sessão
-func (a *App) SessionRejectGuest(sessionID, guestUserID string) error {
-	var err error
-	if a.session == nil || !a.sessionGatewayOwner {
-		err = a.gatewayRejectGuest(sessionID, guestUserID)
-	} else {
-		err = a.session.RejectGuest(sessionID, guestUserID)
-		if isSessionNotFoundErr(err) {
-			err = a.gatewayRejectGuest(sessionID, guestUserID)
-		}
+// RegenerateMCPToken troca o token exigido pelo servidor MCP (clientes antigos perdem acesso).
+func (a *App) RegenerateMCPToken() (MCPSettingsDTO, error) {
+	if err := auth.SetIntegrationToken(mcpTokenName, mcp.NewToken()); err != nil {
+		return MCPSettingsDTO{}, err
+	}
+	if err := a.applyMCPSettings(); err != nil {
+		return a.GetMCPSettings(), err
+	}
+	return a.GetMCPSettings(), nil
+}
+
+// applyMCPSettings para o servidor e o inicia de novo em 127.0.0.1 se estiver habilitado.
+func (a *App) applyMCPSettings() error {
+	a.mcpServer.Stop()
+	if a.db == nil {
+		return nil
+	}
+	cfg, err := a.db.GetConfig()
+	if err != nil || !cfg.MCPEnabled {
+		return err
 	}
+	token, err := auth.GetIntegrationToken(mcpTokenName)
 	if err != nil {
 		return err
 	}
-	a.auditSessionEvent(sessionID, guestUserID, "guest_rejected", "Host rejected guest in waiting room")
-	if a.sessionGatewayOwner {
-		a.persistSessionState(sessionID)
+	if token == "" {
+		token = mcp.NewToken()
+		if err := auth.SetIntegrationToken(mcpTokenName, token); err != nil {
+			return err
+		}
+	}
+	port := cfg.MCPPort
+	if port == 0 {
+		port = mcpDefaultPort
+	}
+	return a.mcpServer.Start(fmt.Sprintf("127.0.0.1:%d", port), token)
+}
+
+// GetPendingMCPApprovals lista as chamadas aguardando aprovação.
+func (a *App) GetPendingMCPApprovals() []MCPApprovalRequestDTO {
+	a.mcpApprovalsMu.Lock()
+	defer a.mcpApprovalsMu.Unlock()
+	pending := make([]MCPApprovalRequestDTO, 0, len(a.mcpApprovals))
+	for _, approval := range a.mcpApprovals {
+		pending = append(pending, approval.request)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].RequestedAt.Before(pending[j].RequestedAt) })
+	return pending
+}
+
+// RespondMCPApproval aprova ou nega uma chamada pendente.
+func (a *App) RespondMCPApproval(id string, allow bool) error {
+	a.mcpApprovalsMu.Lock()
+	approval, ok := a.mcpApprovals[id]
+	delete(a.mcpApprovals, id)
+	a.mcpApprovalsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("mcp approval not found or expired: %s", id)
 	}
+	approval.decision <- allow
 	return nil
 }
 
-// SessionEnd encerra a sessão ativa
-func (a *App) SessionEnd(sessionID string) error {
-	guestsToRevoke := make([]string, 0, 8)
-	if current, getErr := a.SessionGetSession(sessionID); getErr == nil && current != nil {
-		for _, guest := range current.Guests {
-			guestsToRevoke = append(guestsToRevoke, guest.UserID)
-		}
+// authorizeMCPCall aplica a política da ferramenta; "ask" espera a resposta do usuário.
+func (a *App) authorizeMCPCall(ctx context.Context, call mcp.Call) error {
+	tools := a.mcpServer.Tools()
+	idx := slices.IndexFunc(tools, func(tool mcp.Tool) bool { return tool.Name == call.Tool })
+	if idx < 0 {
+		return fmt.Errorf("unknown tool")
+	}
+	switch a.mcpToolPolicy(tools[idx], a.loadMCPPolicies()) {
+	case MCPPolicyAllow:
+		return nil
+	case MCPPolicyAsk:
+		return a.requestMCPApproval(ctx, call)
+	default:
+		return fmt.Errorf("%s is disabled in ORCH settings", call.Tool)
+	}
+}
+
+func (a *App) requestMCPApproval(ctx context.Context, call mcp.Call) error {
+	approval := &mcpApproval{
+		request: MCPApprovalRequestDTO{
+			ID:          mcp.NewToken()[:16],
+			Client:      call.Client,
+			Tool:        call.Tool,
+			Arguments:   a.sanitizeForLogs(string(call.Arguments)),
+			RequestedAt: time.Now(),
+		},
+		decision: make(chan bool, 1),
+	}
+	a.mcpApprovalsMu.Lock()
+	a.mcpApprovals[approval.request.ID] = approval
+	a.mcpApprovalsMu.Unlock()
+	defer func() {
+		a.mcpApprovalsMu.Lock()
+		delete(a.mcpApprovals, approval.request.ID)
+		a.mcpApprovalsMu.Unlock()
+	}()
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "mcp:approval_requested", approval.request)
 	}
 
-	if containerID, ok := a.popSessionContainer(sessionID); ok && a.docker != nil {
-		if err := a.docker.StopContainer(containerID); err != nil {
-			log.Printf("[DOCKER] stop failed for %s: %s", containerID, a.sanitizeForLogs(err.Error()))
+	timer := time.NewTimer(mcpApprovalTimeout)
+	defer timer.Stop()
+	select {
+	case allowed := <-approval.decision:
+		if !allowed {
+			return fmt.Errorf("rejected by the user")
 		}
-		if err := a.docker.RemoveContainer(containerID); err != nil {
-			log.Printf("[DOCKER] remove failed for %s: %s", containerID, a.sanitizeForLogs(err.Error()))
-		}
-		a.auditSessionEvent(sessionID, "system", "container_stopped", fmt.Sprintf("container=%s", containerID))
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("approval timed out")
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
-	var err error
-	if a.session == nil || !a.sessionGatewayOwner {
-		err = a.gatewayEndSession(sessionID)
-	} else {
-		err = a.session.EndSession(sessionID)
-		if isSessionNotFoundErr(err) {
-			err = a.gatewayEndSession(sessionID)
-		}
+// auditMCPCall registra cada chamada no audit log (sessão "mcp:<cliente>").
+func (a *App) auditMCPCall(record mcp.CallRecord) {
+	arguments := record.Arguments
+	if len(arguments) > 500 {
+		arguments = arguments[:500] + "…"
 	}
-	if err != nil {
-		return err
+	details := fmt.Sprintf("tool=%s allowed=%t duration=%dms args=%s", record.Tool, record.Allowed, record.DurationMs, arguments)
+	if record.Error != "" {
+		details += " error=" + record.Error
 	}
-	if a.sessionGatewayOwner && a.signaling != nil {
-		a.signaling.NotifySessionEnded(sessionID, "host")
+	a.auditSessionEvent("mcp:"+record.Client, "", "mcp_tool_call", details)
+}
+
+// registerMCPTools registra as ferramentas expostas; todas passam por authorizeMCPCall e auditMCPCall.
+func (a *App) registerMCPTools() {
+	a.mcpServer.SetAuthorizer(a.authorizeMCPCall)
+	a.mcpServer.SetCallObserver(a.auditMCPCall)
+
+	intProp := func(description string) map[string]interface{} {
+		return map[string]interface{}{"type": "integer", "description": description}
 	}
-	for _, guestUserID := range guestsToRevoke {
-		a.applyPermissionToAllPTY(guestUserID, terminal.PermissionNone)
+	schema := func(required []string, properties map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"type": "object", "properties": properties, "required": required}
 	}
-	a.auditSessionEvent(sessionID, "host", "session_ended", "Host ended the collaboration session")
-	a.deletePersistedSessionState(sessionID)
-	return nil
+
+	a.mcpServer.Register(mcp.Tool{
+		Name:        "list_workspaces",
+		Description: "List ORCH workspaces with their agents (terminals) and whether each terminal is running.",
+		ReadOnly:    true,
+		InputSchema: schema([]string{}, map[string]interface{}{}),
+		Handler:     a.mcpListWorkspaces,
+	})
+	a.mcpServer.Register(mcp.Tool{
+		Name:        "read_terminal_output",
+		Description: "Read the most recent output of an agent's terminal (ANSI escapes removed, secrets masked).",
+		ReadOnly:    true,
+		InputSchema: schema([]string{"agent_id"}, map[string]interface{}{
+			"agent_id":  intProp("Agent ID from list_workspaces."),
+			"max_chars": intProp(fmt.Sprintf("Maximum characters from the end of the output (default %d, max %d).", mcpDefaultOutput, mcpMaxOutput)),
+		}),
+		Handler: a.mcpReadTerminalOutput,
+	})
+	a.mcpServer.Register(mcp.Tool{
+		Name:        "run_command",
+		Description: "Type a single-line command into an agent's running terminal. Requires the user's approval unless allowed in ORCH settings; use read_terminal_output to see the result.",
+		InputSchema: schema([]string{"agent_id", "command"}, map[string]interface{}{
+			"agent_id": intProp("Agent ID from list_workspaces."),
+			"command":  map[string]interface{}{"type": "string", "description": "Command line to run."},
+		}),
+		Handler: a.mcpRunCommand,
+	})
+	a.mcpServer.Register(mcp.Tool{
+		Name:        "get_git_status",
+		Description: "Get the Git status (branch, ahead/behind, staged, unstaged and conflicted files) of a workspace repository.",
+		ReadOnly:    true,
+		InputSchema: schema([]string{"workspace_id"}, map[string]interface{}{
+			"workspace_id": intProp("Workspace ID from list_workspaces."),
+		}),
+		Handler: a.mcpGetGitStatus,
+	})
+	a.mcpServer.Register(mcp.Tool{
+		Name:        "list_prs",
+		Description: "List GitHub pull requests of a workspace repository.",
+		ReadOnly:    true,
+		InputSchema: schema([]string{"workspace_id"}, map[string]interface{}{
+			"workspace_id": intProp("Workspace ID from list_workspaces."),
+			"state":        map[string]interface{}{"type": "string", "enum": []string{"open", "closed", "all"}, "description": "Default: open."},
+		}),
+		Handler: a.mcpListPRs,
+	})
 }
 
-// SessionListPendingGuests lista pedidos de entrada pendentes
-func (a *App) SessionListPendingGuests(sessionID string) ([]session.GuestRequest, error) {
-	if a.session == nil || !a.sessionGatewayOwner {
-		return a.gatewayListPendingGuests(sessionID)
+func mcpJSON(value interface{}) (string, error) {
+	encoded, err := json.MarshalIndent(value, "", "  ")
+	return string(encoded), err
+}
+
+func (a *App) mcpAgentTerminalAlive(agent database.AgentSession) bool {
+	return agent.SessionID != "" && a.bridge != nil && a.bridge.IsTerminalAlive(agent.SessionID)
+}
+
+func (a *App) mcpListWorkspaces(_ context.Context, _ mcp.Call) (string, error) {
+	items, err := a.listWorkspaceAgents()
+	if err != nil {
+		return "", err
 	}
-	pending, err := a.session.ListPendingGuests(sessionID)
-	if err == nil {
-		return pending, nil
+	return mcpJSON(items)
+}
+
+// listWorkspaceAgents resume workspaces e agentes para clientes externos (MCP e orchctl).
+func (a *App) listWorkspaceAgents() ([]cliapi.Workspace, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
 	}
-	if isSessionNotFoundErr(err) {
-		return a.gatewayListPendingGuests(sessionID)
+	workspaces, err := a.GetWorkspacesWithAgents()
+	if err != nil {
+		return nil, err
 	}
-	return nil, err
+	items := make([]cliapi.Workspace, 0, len(workspaces))
+	for _, ws := range workspaces {
+		item := cliapi.Workspace{ID: ws.ID, Name: ws.Name, Path: ws.Path, Agents: []cliapi.Agent{}}
+		for _, agent := range ws.Agents {
+			item.Agents = append(item.Agents, cliapi.Agent{ID: agent.ID, Name: agent.Name, Type: agent.Type, Running: a.mcpAgentTerminalAlive(agent)})
+		}
+		items = append(items, item)
+	}
+	return items, nil
 }
 
-// SessionSetGuestPermission altera permissão de um guest
-func (a *App) SessionSetGuestPermission(sessionID, guestUserID, permission string) error {
-	previousPerm := ""
-	if a.sessionGatewayOwner && a.session != nil {
-		if sess, err := a.session.GetSession(sessionID); err == nil && sess != nil {
-			for _, guest := range sess.Guests {
-				if guest.UserID == guestUserID {
-					previousPerm = string(guest.Permission)
-					break
-				}
-			}
-		}
-	} else if sess, err := a.gatewayGetSession(sessionID); err == nil && sess != nil {
-		for _, guest := range sess.Guests {
-			if guest.UserID == guestUserID {
-				previousPerm = string(guest.Permission)
-				break
-			}
-		}
+func (a *App) mcpReadTerminalOutput(_ context.Context, call mcp.Call) (string, error) {
+	var args struct {
+		AgentID  uint `json:"agent_id"`
+		MaxChars int  `json:"max_chars"`
+	}
+	if err := call.Bind(&args); err != nil {
+		return "", err
+	}
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	agent, err := a.db.GetAgent(args.AgentID)
+	if err != nil {
+		return "", fmt.Errorf("agent %d not found", args.AgentID)
+	}
+	if agent.SessionID == "" {
+		return "", fmt.Errorf("agent %q has no terminal", agent.Name)
 	}
+	maxChars := args.MaxChars
+	if maxChars <= 0 {
+		maxChars = mcpDefaultOutput
+	}
+	maxChars = min(maxChars, mcpMaxOutput)
 
-	var err error
-	if a.session == nil || !a.sessionGatewayOwner {
-		err = a.gatewaySetGuestPermission(sessionID, guestUserID, permission)
-	} else {
-		err = a.session.SetGuestPermission(sessionID, guestUserID, permission)
-		if isSessionNotFoundErr(err) {
-			err = a.gatewaySetGuestPermission(sessionID, guestUserID, permission)
-		}
+	output := terminalEscapeRegex.ReplaceAllString(a.getTerminalHistory(agent.SessionID), "")
+	output = strings.ReplaceAll(output, "\r\n", "\n")
+	if runes := []rune(output); len(runes) > maxChars {
+		output = string(runes[len(runes)-maxChars:])
+	}
+	return a.sanitizeForLogs(output), nil
+}
+
+func (a *App) mcpRunCommand(_ context.Context, call mcp.Call) (string, error) {
+	var args struct {
+		AgentID uint   `json:"agent_id"`
+		Command string `json:"command"`
+	}
+	if err := call.Bind(&args); err != nil {
+		return "", err
 	}
+	command := strings.TrimSpace(args.Command)
+	if command == "" || strings.ContainsAny(command, "\r\n") {
+		return "", fmt.Errorf("command must be a single non-empty line")
+	}
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+	agent, err := a.db.GetAgent(args.AgentID)
 	if err != nil {
-		return err
+		return "", fmt.Errorf("agent %d not found", args.AgentID)
 	}
-	if a.sessionGatewayOwner && a.signaling != nil {
-		a.signaling.NotifyPermissionChange(sessionID, guestUserID, permission)
+	if !a.mcpAgentTerminalAlive(*agent) {
+		return "", fmt.Errorf("agent %q terminal is not running", agent.Name)
 	}
-	if permission == string(session.PermReadOnly) {
-		runtime.EventsEmit(a.ctx, "session:permission_revoked", map[string]string{
-			"sessionID":   sessionID,
-			"guestUserID": guestUserID,
-		})
-		a.auditSessionEvent(sessionID, guestUserID, "permission_revoked", "Write permission revoked by host")
+	a.writeTerminalCommands(agent.SessionID, agent.ID, []string{command})
+	return fmt.Sprintf("Command sent to agent %q. Use read_terminal_output to see the result.", agent.Name), nil
+}
+
+func (a *App) mcpWorkspacePath(call mcp.Call) (string, error) {
+	var args struct {
+		WorkspaceID uint `json:"workspace_id"`
 	}
-	a.syncGuestPermissionAcrossPTYs(sessionID, guestUserID)
-	a.auditSessionEvent(sessionID, guestUserID, "permission_changed", fmt.Sprintf("from=%s to=%s", previousPerm, permission))
-	if a.sessionGatewayOwner {
-		a.persistSessionState(sessionID)
+	if err := call.Bind(&args); err != nil {
+		return "", err
 	}
-	return nil
+	return a.workspaceRepoPath(args.WorkspaceID)
 }
 
-// SessionKickGuest remove um guest da sessão
-func (a *App) SessionKickGuest(sessionID, guestUserID string) error {
-	var err error
-	if a.session == nil || !a.sessionGatewayOwner {
-		err = a.gatewayKickGuest(sessionID, guestUserID)
-	} else {
-		err = a.session.KickGuest(sessionID, guestUserID)
-		if isSessionNotFoundErr(err) {
-			err = a.gatewayKickGuest(sessionID, guestUserID)
-		}
+func (a *App) workspaceRepoPath(workspaceID uint) (string, error) {
+	if a.db == nil {
+		return "", fmt.Errorf("database not initialized")
 	}
+	ws, err := a.db.GetWorkspace(workspaceID)
+	if err != nil || strings.TrimSpace(ws.Path) == "" {
+		return "", fmt.Errorf("workspace %d not found", workspaceID)
+	}
+	return ws.Path, nil
+}
+
+func (a *App) mcpGetGitStatus(_ context.Context, call mcp.Call) (string, error) {
+	repoPath, err := a.mcpWorkspacePath(call)
 	if err != nil {
-		return err
+		return "", err
 	}
-	a.applyPermissionToAllPTY(guestUserID, terminal.PermissionNone)
-	a.auditSessionEvent(sessionID, guestUserID, "guest_kicked", "Guest removed by host")
-	a.auditSessionEvent(sessionID, guestUserID, "guest_left", "Guest left session after host removal")
-	if a.sessionGatewayOwner {
-		a.persistSessionState(sessionID)
+	status, err := a.GitPanelGetStatus(repoPath)
+	if err != nil {
+		return "", err
 	}
-	return nil
+	return mcpJSON(status)
 }
 
-// SessionRegenerateCode gera um novo código de convite para a sessão.
-func (a *App) SessionRegenerateCode(sessionID string) (*session.Session, error) {
-	var (
-		updated *session.Session
-		err     error
-	)
-	if a.session == nil || !a.sessionGatewayOwner {
-		updated, err = a.gatewayRegenerateSessionCode(sessionID)
-	} else {
-		updated, err = a.session.RegenerateCode(sessionID)
-		if isSessionNotFoundErr(err) {
-			updated, err = a.gatewayRegenerateSessionCode(sessionID)
-		}
+func (a *App) mcpListPRs(_ context.Context, call mcp.Call) (string, error) {
+	repoPath, err := a.mcpWorkspacePath(call)
+	if err != nil {
+		return "", err
+	}
+	var args struct {
+		State string `json:"state"`
+	}
+	if err := call.Bind(&args); err != nil {
+		return "", err
 	}
+	prs, err := a.GitPanelPRList(repoPath, cmp.Or(strings.TrimSpace(args.State), "open"), 1, 30)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-
-	a.auditSessionEvent(sessionID, "host", "code_regenerated", "Host generated a new session join code")
-	if a.sessionGatewayOwner {
-		a.persistSessionState(sessionID)
+	type prItem struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		State  string `json:"state"`
+		Author string `json:"author"`
+		Head   string `json:"head"`
+		Base   string `json:"base"`
+		Draft  bool   `json:"draft,omitempty"`
 	}
-	return updated, nil
+	items := make([]prItem, 0, len(prs))
+	for _, pr := range prs {
+		items = append(items, prItem{Number: pr.Number, Title: pr.Title, State: pr.State, Author: pr.Author.Login, Head: pr.HeadBranch, Base: pr.BaseBranch, Draft: pr.IsDraft})
+	}
+	return mcpJSON(items)
 }
 
-// SessionRevokeCode invalida o código de convite atual da sessão.
-func (a *App) SessionRevokeCode(sessionID string) (*session.Session, error) {
-	var (
-		updated *session.Session
-		err     error
-	)
-	if a.session == nil || !a.sessionGatewayOwner {
-		updated, err = a.gatewayRevokeSessionCode(sessionID)
-	} else {
-		updated, err = a.session.RevokeCode(sessionID)
-		if isSessionNotFoundErr(err) {
-			updated, err = a.gatewayRevokeSessionCode(sessionID)
+// === REST API Bindings ===
+
+const (
+	// restAPITokenName identifica o token da API REST no Keychain.
+	restAPITokenName   = "rest_api"
+	restAPIDefaultPort = 7392
+)
+
+// RESTAPISettingsDTO configura a API REST local. O token (Keychain) é mostrado para o usuário
+// usar em scripts e extensões (Raycast/Alfred).
+type RESTAPISettingsDTO struct {
+	Enabled    bool                `json:"enabled"`
+	Port       int                 `json:"port"`
+	AllowWrite bool                `json:"allowWrite"` // sem isso só as rotas de leitura respondem
+	Running    bool                `json:"running"`
+	URL        string              `json:"url,omitempty"`
+	Token      string              `json:"token,omitempty"`
+	Routes     []restapi.RouteInfo `json:"routes"`
+}
+
+// GetRESTAPISettings retorna a configuração da API REST e as rotas expostas.
+func (a *App) GetRESTAPISettings() RESTAPISettingsDTO {
+	settings := RESTAPISettingsDTO{Port: restAPIDefaultPort, Routes: a.restAPI.Routes()}
+	if a.db != nil {
+		if cfg, err := a.db.GetConfig(); err == nil {
+			settings.Enabled = cfg.RESTAPIEnabled
+			settings.AllowWrite = cfg.RESTAPIAllowWrite
+			if cfg.RESTAPIPort > 0 {
+				settings.Port = cfg.RESTAPIPort
+			}
 		}
 	}
-	if err != nil {
-		return nil, err
+	if addr := a.restAPI.Addr(); addr != "" {
+		settings.Running = true
+		settings.URL = "http://" + addr + restapi.Prefix
 	}
+	settings.Token, _ = auth.GetIntegrationToken(restAPITokenName)
+	return settings
+}
 
-	a.auditSessionEvent(sessionID, "host", "code_revoked", "Host revoked the current session join code")
-	if a.sessionGatewayOwner {
-		a.persistSessionState(sessionID)
+// SetRESTAPISettings valida, persiste e (re)inicia a API REST.
+func (a *App) SetRESTAPISettings(settings RESTAPISettingsDTO) (RESTAPISettingsDTO, error) {
+	if a.db == nil {
+		return RESTAPISettingsDTO{}, fmt.Errorf("database not initialized")
 	}
-	return updated, nil
+	port := settings.Port
+	if port == 0 {
+		port = restAPIDefaultPort
+	}
+	if port < 1024 || port > 65535 {
+		return RESTAPISettingsDTO{}, fmt.Errorf("rest api port must be between 1024 and 65535")
+	}
+	if err := a.db.SetRESTAPISettings(settings.Enabled, port, settings.AllowWrite); err != nil {
+		return RESTAPISettingsDTO{}, err
+	}
+	if err := a.applyRESTAPISettings(); err != nil {
+		return a.GetRESTAPISettings(), err
+	}
+	return a.GetRESTAPISettings(), nil
 }
 
-// SessionSetAllowNewJoins habilita/desabilita novos pedidos de entrada.
-func (a *App) SessionSetAllowNewJoins(sessionID string, allow bool) (*session.Session, error) {
-	var (
-		updated *session.Session
-		err     error
-	)
-	if a.session == nil || !a.sessionGatewayOwner {
-		updated, err = a.gatewaySetAllowNewJoins(sessionID, allow)
-	} else {
-		updated, err = a.session.SetAllowNewJoins(sessionID, allow)
-		if isSessionNotFoundErr(err) {
-			updated, err = a.gatewaySetAllowNewJoins(sessionID, allow)
-		}
+// RegenerateRESTAPIToken troca o token exigido pela API REST (clientes antigos perdem acesso).
+func (a *App) RegenerateRESTAPIToken() (RESTAPISettingsDTO, error) {
+	if err := auth.SetIntegrationToken(restAPITokenName, restapi.NewToken()); err != nil {
+		return RESTAPISettingsDTO{}, err
 	}
-	if err != nil {
-		return nil, err
+	if err := a.applyRESTAPISettings(); err != nil {
+		return a.GetRESTAPISettings(), err
 	}
+	return a.GetRESTAPISettings(), nil
+}
 
-	action := "allow_new_joins_disabled"
-	details := "Host disabled new join requests"
-	if allow {
-		action = "allow_new_joins_enabled"
-		details = "Host enabled new join requests"
+// applyRESTAPISettings para a API e a inicia de novo em 127.0.0.1 se estiver habilitada.
+func (a *App) applyRESTAPISettings() error {
+	a.restAPI.Stop()
+	if a.db == nil {
+		return nil
 	}
-	a.auditSessionEvent(sessionID, "host", action, details)
-	if a.sessionGatewayOwner {
-		a.persistSessionState(sessionID)
+	cfg, err := a.db.GetConfig()
+	if err != nil || !cfg.RESTAPIEnabled {
+		return err
 	}
-	return updated, nil
+	token, err := auth.GetIntegrationToken(restAPITokenName)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		token = restapi.NewToken()
+		if err := auth.SetIntegrationToken(restAPITokenName, token); err != nil {
+			return err
+		}
+	}
+	port := cfg.RESTAPIPort
+	if port == 0 {
+		port = restAPIDefaultPort
+	}
+	return a.restAPI.Start(fmt.Sprintf("127.0.0.1:%d", port), token, cfg.RESTAPIAllowWrite)
 }
 
-// SessionGetActive retorna a sessão ativa do host
-func (a *App) SessionGetActive() (*session.Session, error) {
-	hostUserID := a.resolveSessionHostUserID()
-
-	// Instâncias em client-mode não devem hidratar sessão ativa como Host.
-	// Isso evita que um guest local assuma role=host por compartilhar o mesmo userID default.
-	if !a.sessionGatewayOwner {
-		return nil, fmt.Errorf("no active session in client mode")
+// auditRESTAPICall registra no audit log as chamadas que alteram estado (sessão "rest:api").
+func (a *App) auditRESTAPICall(record restapi.CallRecord) {
+	if !record.Write {
+		return
 	}
-	if a.session == nil {
-		return nil, fmt.Errorf("session service not initialized")
+	details := fmt.Sprintf("route=%s %s path=%s status=%d duration=%dms", record.Method, record.Route, record.Path, record.Status, record.DurationMs)
+	if record.Error != "" {
+		details += " error=" + record.Error
 	}
-	current, err := a.session.GetActiveSession(hostUserID)
-	if err == nil {
-		return current, nil
+	a.auditSessionEvent("rest:api", "", "rest_api_call", details)
+}
+
+// registerRESTRoutes define o subconjunto de bindings exposto pela API REST.
+func (a *App) registerRESTRoutes() {
+	a.restAPI.SetCallObserver(a.auditRESTAPICall)
+	route := func(method, path, description string, write bool, handler func(req restapi.Request) (interface{}, error)) {
+		a.restAPI.Register(restapi.Route{Method: method, Path: path, Description: description, Write: write, Handler: handler})
 	}
-	if strings.Contains(err.Error(), "no active session") {
-		return a.gatewayGetActiveSession(hostUserID)
+	sessionIDParam := func(req restapi.Request) string { return req.PathValue("sessionId") }
+
+	// Workspaces e Git
+	route("GET", "/workspaces", "List workspaces with their agents", false, func(restapi.Request) (interface{}, error) {
+		return a.listWorkspaceAgents()
+	})
+	route("POST", "/workspaces/{id}/activate", "Switch the active workspace", true, func(req restapi.Request) (interface{}, error) {
+		id, err := req.PathUint("id")
+		if err != nil {
+			return nil, err
+		}
+		return nil, a.SetActiveWorkspace(id)
+	})
+	route("GET", "/workspaces/{id}/git/status", "Git status of the workspace repository", false, func(req restapi.Request) (interface{}, error) {
+		id, err := req.PathUint("id")
+		if err != nil {
+			return nil, err
+		}
+		repoPath, err := a.workspaceRepoPath(id)
+		if err != nil {
+			return nil, restapi.Errorf(http.StatusNotFound, "%v", err)
+		}
+		return a.GitPanelGetStatus(repoPath)
+	})
+
+	// Terminais
+	route("GET", "/terminals", "List terminal sessions", false, func(restapi.Request) (interface{}, error) {
+		if a.bridge == nil {
+			return []terminal.SessionInfo{}, nil
+		}
+		return a.GetTerminals(), nil
+	})
+	route("GET", "/terminals/{sessionId}/output", "Terminal output after ?cursor= (bytes already read)", false, func(req restapi.Request) (interface{}, error) {
+		sessionID := sessionIDParam(req)
+		if !a.restTerminalExists(sessionID) {
+			return nil, restapi.Errorf(http.StatusNotFound, "terminal %s not found", sessionID)
+		}
+		cursor, _ := strconv.ParseInt(req.URL.Query().Get("cursor"), 10, 64)
+		chunk := a.terminalOutputSince(sessionID, max(cursor, 0))
+		chunk.Output = a.sanitizeForLogs(chunk.Output)
+		chunk.Running = a.bridge != nil && a.bridge.IsTerminalAlive(sessionID)
+		return chunk, nil
+	})
+	route("POST", "/terminals/{sessionId}/input", `Write to a terminal: {"command": "..."} runs a line, {"data": "..."} sends raw input`, true, func(req restapi.Request) (interface{}, error) {
+		var body struct {
+			Command string `json:"command"`
+			Data    string `json:"data"`
+		}
+		if err := req.Bind(&body); err != nil {
+			return nil, err
+		}
+		sessionID := sessionIDParam(req)
+		if !a.restTerminalExists(sessionID) {
+			return nil, restapi.Errorf(http.StatusNotFound, "terminal %s not found", sessionID)
+		}
+		switch {
+		case body.Command != "" && body.Data != "":
+			return nil, fmt.Errorf("use either command or data")
+		case body.Command != "":
+			if strings.ContainsAny(body.Command, "\r\n") {
+				return nil, fmt.Errorf("command must be a single line")
+			}
+			return nil, a.WriteTerminal(sessionID, body.Command+"\r")
+		case body.Data != "":
+			return nil, a.WriteTerminal(sessionID, body.Data)
+		}
+		return nil, fmt.Errorf("command or data is required")
+	})
+	route("DELETE", "/terminals/{sessionId}", "Close a terminal session", true, func(req restapi.Request) (interface{}, error) {
+		sessionID := sessionIDParam(req)
+		if !a.restTerminalExists(sessionID) {
+			return nil, restapi.Errorf(http.StatusNotFound, "terminal %s not found", sessionID)
+		}
+		return nil, a.DestroyTerminal(sessionID)
+	})
+
+	// Sessões de colaboração
+	route("GET", "/sessions/active", "Active collaboration session hosted or joined by this app", false, func(restapi.Request) (interface{}, error) {
+		return a.SessionGetActive()
+	})
+	route("POST", "/sessions", "Create a collaboration session", true, func(req restapi.Request) (interface{}, error) {
+		var body struct {
+			MaxGuests      int    `json:"maxGuests"`
+			Mode           string `json:"mode"`
+			AllowAnonymous bool   `json:"allowAnonymous"`
+			WorkspaceID    uint   `json:"workspaceId"`
+		}
+		if err := req.Bind(&body); err != nil {
+			return nil, err
+		}
+		return a.SessionCreate(body.MaxGuests, body.Mode, body.AllowAnonymous, body.WorkspaceID)
+	})
+	route("GET", "/sessions/{sessionId}", "Get a collaboration session", false, func(req restapi.Request) (interface{}, error) {
+		sess, err := a.SessionGetSession(sessionIDParam(req))
+		if err != nil {
+			return nil, restapi.Errorf(http.StatusNotFound, "%v", err)
+		}
+		return sess, nil
+	})
+	route("GET", "/sessions/{sessionId}/pending", "Guests waiting for approval", false, func(req restapi.Request) (interface{}, error) {
+		return a.SessionListPendingGuests(sessionIDParam(req))
+	})
+	route("POST", "/sessions/{sessionId}/guests/{guestId}/approve", "Approve a waiting guest", true, func(req restapi.Request) (interface{}, error) {
+		return nil, a.SessionApproveGuest(sessionIDParam(req), req.PathValue("guestId"))
+	})
+	route("POST", "/sessions/{sessionId}/guests/{guestId}/reject", "Reject a waiting guest", true, func(req restapi.Request) (interface{}, error) {
+		return nil, a.SessionRejectGuest(sessionIDParam(req), req.PathValue("guestId"))
+	})
+	route("POST", "/sessions/{sessionId}/end", "End a collaboration session", true, func(req restapi.Request) (interface{}, error) {
+		return nil, a.SessionEnd(sessionIDParam(req))
+	})
+}
+
+func (a *App) restTerminalExists(sessionID string) bool {
+	return a.bridge != nil && slices.ContainsFunc(a.GetTerminals(), func(info terminal.SessionInfo) bool { return info.ID == sessionID })
+}
+
+// === Clipboard History Bindings ===
+
+// clipboardPollInterval é o intervalo de leitura do clipboard enquanto o histórico está ligado.
+const clipboardPollInterval = time.Second
+
+// ClipboardHistorySettingsDTO liga/desliga o histórico de clipboard.
+type ClipboardHistorySettingsDTO struct {
+	Enabled    bool `json:"enabled"`
+	Watching   bool `json:"watching"`
+	MaxEntries int  `json:"maxEntries"`
+}
+
+// GetClipboardHistorySettings retorna se o histórico está habilitado e se o watcher está rodando.
+func (a *App) GetClipboardHistorySettings() ClipboardHistorySettingsDTO {
+	settings := ClipboardHistorySettingsDTO{Watching: a.clipboardHistory.Watching(), MaxEntries: clipboard.DefaultMaxEntries}
+	if a.db != nil {
+		if cfg, err := a.db.GetConfig(); err == nil {
+			settings.Enabled = cfg.ClipboardHistory
+		}
 	}
-	return nil, err
+	return settings
 }
 
-// SessionGetSession retorna detalhes de uma sessão
-func (a *App) SessionGetSession(sessionID string) (*session.Session, error) {
-	if a.session == nil || !a.sessionGatewayOwner {
-		return a.gatewayGetSession(sessionID)
+// SetClipboardHistoryEnabled persiste o opt-in e liga/desliga o watcher. Desligar não apaga o histórico.
+func (a *App) SetClipboardHistoryEnabled(enabled bool) (ClipboardHistorySettingsDTO, error) {
+	if a.db == nil {
+		return ClipboardHistorySettingsDTO{}, fmt.Errorf("database not initialized")
 	}
-	current, err := a.session.GetSession(sessionID)
-	if err == nil {
-		return current, nil
+	if err := a.db.SetClipboardHistoryEnabled(enabled); err != nil {
+		return ClipboardHistorySettingsDTO{}, err
 	}
-	if isSessionNotFoundErr(err) {
-		return a.gatewayGetSession(sessionID)
+	a.applyClipboardHistorySettings()
+	return a.GetClipboardHistorySettings(), nil
+}
+
+// ClipboardHistoryList retorna os textos copiados (já com segredos redigidos), mais recentes primeiro.
+func (a *App) ClipboardHistoryList() ([]clipboard.Entry, error) {
+	return a.clipboardHistory.List()
+}
+
+// ClipboardHistoryPin fixa ou solta uma entrada; fixadas não saem pelo limite nem pelo Clear comum.
+func (a *App) ClipboardHistoryPin(id string, pinned bool) (clipboard.Entry, error) {
+	entry, err := a.clipboardHistory.Pin(strings.TrimSpace(id), pinned)
+	if err != nil {
+		return clipboard.Entry{}, err
 	}
-	return nil, err
+	a.emitClipboardHistoryChanged()
+	return entry, nil
 }
 
-// SessionGetICEServers retorna a configuração de ICE servers
-func (a *App) SessionGetICEServers() []session.ICEServerConfig {
-	if a.session == nil {
-		return []session.ICEServerConfig{}
+// ClipboardHistoryClear apaga o histórico; includePinned também remove as fixadas.
+func (a *App) ClipboardHistoryClear(includePinned bool) error {
+	if err := a.clipboardHistory.Clear(includePinned); err != nil {
+		return err
 	}
-	return a.session.GetICEServers()
+	a.emitClipboardHistoryChanged()
+	return nil
 }
 
-// SessionGetJoinSecurityMetrics retorna métricas agregadas de tentativas inválidas/bloqueios de join.
-func (a *App) SessionGetJoinSecurityMetrics() session.JoinSecurityMetrics {
-	var metrics session.JoinSecurityMetrics
-	if a.session == nil {
-		return metrics
+// applyClipboardHistorySettings inicia ou para o watcher conforme o opt-in salvo.
+func (a *App) applyClipboardHistorySettings() {
+	enabled := false
+	if a.db != nil {
+		if cfg, err := a.db.GetConfig(); err == nil {
+			enabled = cfg.ClipboardHistory
+		}
 	}
-	if a.sessionGatewayOwner {
-		return a.session.GetJoinSecurityMetrics()
+	if !enabled || a.ctx == nil {
+		a.clipboardHistory.StopWatching()
+		return
 	}
-	if err := a.callSessionGateway(http.MethodGet, "/api/session/metrics/join-security", nil, &metrics); err != nil {
-		log.Printf("[SESSION][SECURITY] unable to fetch join metrics from gateway: %v", err)
+	a.clipboardHistory.Watch(clipboardPollInterval, func() (string, error) {
+		return runtime.ClipboardGetText(a.ctx)
+	}, func(clipboard.Entry) {
+		a.emitClipboardHistoryChanged()
+	})
+}
+
+func (a *App) emitClipboardHistoryChanged() {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, "clipboard:history_changed")
+}
+
+// === orchctl Gateway ===
+
+// cliBackend atende as rotas /api/cli/ do gateway local usadas pelo orchctl.
+type cliBackend struct {
+	a *App
+}
+
+func (b cliBackend) Workspaces() ([]cliapi.Workspace, error) {
+	return b.a.listWorkspaceAgents()
+}
+
+func (b cliBackend) PullRequests(workspaceID uint, state string) ([]gh.PullRequest, error) {
+	repoPath, err := b.a.workspaceRepoPath(workspaceID)
+	if err != nil {
+		return nil, err
 	}
-	return metrics
+	return b.a.GitPanelPRList(repoPath, cmp.Or(strings.TrimSpace(state), "open"), 1, 50)
 }
 
-// SessionGetAuditLogs retorna os eventos de auditoria de uma sessão.
-func (a *App) SessionGetAuditLogs(sessionID string, limit int) ([]database.AuditLog, error) {
-	if a.db == nil {
-		return []database.AuditLog{}, nil
+func (b cliBackend) GitStatus(workspaceID uint) (gp.StatusDTO, error) {
+	repoPath, err := b.a.workspaceRepoPath(workspaceID)
+	if err != nil {
+		return gp.StatusDTO{}, err
 	}
-	return a.db.ListAuditEvents(sessionID, limit)
+	return b.a.GitPanelGetStatus(repoPath)
 }
 
-// SessionRestartEnvironment reinicia o container associado à sessão (modo Docker).
-func (a *App) SessionRestartEnvironment(sessionID string) error {
-	if a.docker == nil {
-		return fmt.Errorf("docker service not initialized")
+func (b cliBackend) TerminalOutput(agentID uint, cursor int64) (cliapi.TerminalChunk, error) {
+	if b.a.db == nil {
+		return cliapi.TerminalChunk{}, fmt.Errorf("database not initialized")
 	}
-
-	containerID, ok := a.getSessionContainer(sessionID)
-	if !ok {
-		return fmt.Errorf("no container found for session %s", sessionID)
+	agent, err := b.a.db.GetAgent(agentID)
+	if err != nil {
+		return cliapi.TerminalChunk{}, fmt.Errorf("agent %d not found", agentID)
+	}
+	if agent.SessionID == "" {
+		return cliapi.TerminalChunk{}, fmt.Errorf("agent %q has no terminal", agent.Name)
 	}
+	chunk := b.a.terminalOutputSince(agent.SessionID, cursor)
+	chunk.Output = b.a.sanitizeForLogs(chunk.Output)
+	chunk.Running = b.a.mcpAgentTerminalAlive(*agent)
+	return chunk, nil
+}
 
-	if err := a.docker.RestartContainer(containerID); err != nil {
+func (b cliBackend) StartStackBuild(req cliapi.StackBuildRequest) error {
+	name := cmp.Or(strings.TrimSpace(req.Name), docker.DefaultStackName)
+	tools, err := b.a.GetNamedStackTools(name)
+	if err != nil {
 		return err
 	}
-	a.auditSessionEvent(sessionID, "host", "container_restarted", fmt.Sprintf("container=%s", containerID))
-	return nil
+	if len(tools) == 0 {
+		return fmt.Errorf("stack %q has no saved tools; configure it in ORCH first", name)
+	}
+	return b.a.BuildNamedStack(name, tools)
 }
 
-// DockerIsAvailable informa se Docker está disponível e operacional.
-func (a *App) DockerIsAvailable() bool {
-	if a.docker == nil {
-		return false
-	}
-	return a.docker.IsDockerAvailable()
+func (b cliBackend) StackBuildState() (cliapi.StackBuildState, error) {
+	state := b.a.GetStackBuildState()
+	return cliapi.StackBuildState(state), nil
 }
 
-// DockerDetectImage detecta automaticamente a imagem recomendada para um projeto.
-func (a *App) DockerDetectImage(projectPath string) string {
-	if a.docker == nil {
-		return "alpine:latest"
+// gitShareBackend atende as rotas do Git Panel compartilhado no gateway do host.
+type gitShareBackend struct {
+	a *App
+}
+
+func (b gitShareBackend) AuthorizeGuest(sessionID, guestUserID string) error {
+	if b.a.session == nil {
+		return fmt.Errorf("session service not initialized")
 	}
-	return a.docker.DetectImage(projectPath)
+	_, err := b.a.session.AuthorizedGuest(sessionID, guestUserID)
+	return err
 }
 
-// === Auth Bindings (expostos ao Frontend) ===
+func (b gitShareBackend) Status(repoRoot string) (gp.StatusDTO, error) {
+	return b.a.GitPanelGetStatus(repoRoot)
+}
 
-// AuthLogin inicia o fluxo de login OAuth
-func (a *App) AuthLogin(provider string) error {
-	if a.auth == nil {
-		return fmt.Errorf("auth service not initialized")
-	}
+func (b gitShareBackend) History(repoRoot string, cursor string, limit int, query string) (gp.HistoryPageDTO, error) {
+	return b.a.GitPanelGetHistory(repoRoot, cursor, limit, query)
+}
 
-	// Configurar handler de callback
-	callbackHandler := func(result *auth.AuthResult) {
-		if result == nil {
-			runtime.EventsEmit(a.ctx, "auth:error", "Authentication failed: no result")
-			return
-		}
+func (b gitShareBackend) Diff(repoRoot string, filePath string, mode string, contextLines int) (gp.DiffDTO, error) {
+	return b.a.GitPanelGetDiff(repoRoot, filePath, mode, contextLines)
+}
 
-		if result.Success {
-			log.Println("[ORCH] Auth success via local callback!")
-			runtime.EventsEmit(a.ctx, "auth:changed", a.auth.GetAuthState())
-			runtime.WindowShow(a.ctx)
-		} else {
-			log.Printf("[ORCH] Auth failed: %s", result.Error)
-			runtime.EventsEmit(a.ctx, "auth:error", result.Error)
-		}
-	}
+// sessionReviewBackend atende as rotas do modo de revisão de PR no gateway do host.
+type sessionReviewBackend struct {
+	a *App
+}
 
-	// Iniciar servidor de callback
-	_, err := a.auth.StartCallbackServer(callbackHandler)
+func (b sessionReviewBackend) AuthorizeReviewer(sessionID, guestUserID string) (sessionreview.Reviewer, error) {
+	if b.a.session == nil {
+		return sessionreview.Reviewer{}, fmt.Errorf("session service not initialized")
+	}
+	guest, err := b.a.session.AuthorizedGuest(sessionID, guestUserID)
 	if err != nil {
-		return fmt.Errorf("failed to start callback server: %w", err)
+		return sessionreview.Reviewer{}, err
+	}
+	if guest.Permission != session.PermReadWrite {
+		return sessionreview.Reviewer{}, fmt.Errorf("PR review requires read-write permission")
 	}
+	return sessionreview.Reviewer{UserID: guest.UserID, Name: guest.Name, Login: guest.Login}, nil
+}
 
-	url, err := a.auth.GetAuthURL(provider)
-	if err != nil {
-		a.auth.StopCallbackServer()
-		return err
+func (b sessionReviewBackend) DraftSubmitted(draft sessionreview.Draft) {
+	if b.a.session != nil {
+		b.a.session.TouchGuestActivity(draft.SessionID, draft.Author.UserID)
+	}
+	b.a.auditSessionEvent(draft.SessionID, draft.Author.UserID, "pr_review_comment_submitted", fmt.Sprintf("%s/%s#%d", draft.Owner, draft.Repo, draft.PRNumber))
+	if b.a.ctx == nil {
+		return
 	}
+	runtime.EventsEmit(b.a.ctx, "session:review_draft_submitted", draft)
+}
 
-	// Abrir URL no navegador padrão
-	runtime.BrowserOpenURL(a.ctx, url)
-	return nil
+// terminalOutputSince devolve a saída emitida depois do cursor (total de bytes já lidos pelo cliente).
+// Se o ring buffer já descartou parte desse trecho, devolve o buffer inteiro marcado como truncado.
+func (a *App) terminalOutputSince(sessionID string, cursor int64) cliapi.TerminalChunk {
+	a.terminalStateMu.RLock()
+	defer a.terminalStateMu.RUnlock()
+
+	history := a.terminalHistory[sessionID]
+	total := a.terminalBytes[sessionID]
+	start := total - int64(len(history))
+	if cursor < start || cursor > total {
+		return cliapi.TerminalChunk{Output: history, Cursor: total, Truncated: true}
+	}
+	return cliapi.TerminalChunk{Output: history[cursor-start:], Cursor: total}
 }
 
-// AuthLogout faz logout do usuário
-func (a *App) AuthLogout() error {
-	if a.auth == nil {
-		return nil
+// === Instance Coordination ===
+
+const (
+	terminalSnapshotsLease = "db.terminal_snapshots"
+	// dbWriteLeaseTimeout limita a espera por outra instância gravando o mesmo dado.
+	dbWriteLeaseTimeout = 5 * time.Second
+)
+
+// CoordinationStatus descreve os leases entre instâncias vistos por esta instância.
+type CoordinationStatus struct {
+	InstanceID   string               `json:"instanceId"`
+	GatewayOwner bool                 `json:"gatewayOwner"`
+	Held         []string             `json:"held"`
+	Leases       []coordination.Lease `json:"leases"`
+	Error        string               `json:"error,omitempty"`
+}
+
+// startCoordination liga poller, file watcher (monitoramento e auto-fetch por repo) e backup agendado aos leases do gateway local.
+// O dono do gateway usa a tabela em memória; as demais instâncias a consultam por HTTP.
+func (a *App) startCoordination(leaseTable *coordination.Table) {
+	var backend coordination.Backend = leaseTable
+	if !a.sessionGatewayOwner {
+		backend = coordination.NewHTTPBackend(a.resolvedSessionGatewayBaseURL(), httpclient.NewClient(httpclient.ServiceGateway, 4*time.Second))
 	}
+	a.coordinator = coordination.NewCoordinator(backend, coordination.NewInstanceID(), coordination.DefaultLeaseTTL)
 
-	if err := a.auth.Logout(); err != nil {
-		return err
+	if a.gitHubStarted() {
+		a.poller.SetLeaseGate(a.coordinator.Acquire, a.coordinator.Release)
 	}
+	if a.fileWatcher != nil {
+		a.fileWatcher.SetLeaseGate(a.coordinator.Acquire, a.coordinator.Release)
+	}
+	if a.backup != nil {
+		a.backup.SetLeaseGate(a.coordinator.Acquire, a.coordinator.Release)
+	}
+	log.Printf("[ORCH] Instance coordination started (instance=%s, gatewayOwner=%v)", a.coordinator.Holder(), a.sessionGatewayOwner)
+}
 
-	runtime.EventsEmit(a.ctx, "auth:changed", a.auth.GetAuthState())
-	return nil
+// withDBWriteLease serializa entre instâncias uma gravação no banco compartilhado.
+func (a *App) withDBWriteLease(resource string, write func() error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbWriteLeaseTimeout)
+	defer cancel()
+	return a.coordinator.Do(ctx, resource, write)
 }
 
-// GetAuthState retorna o estado atual de autenticação
-func (a *App) GetAuthState() *auth.AuthState {
-	if a.auth == nil {
-		return &auth.AuthState{IsAuthenticated: false}
+// GetCoordinationStatus retorna os leases desta instância e os ativos no gateway.
+func (a *App) GetCoordinationStatus() CoordinationStatus {
+	status := CoordinationStatus{
+		InstanceID:   a.coordinator.Holder(),
+		GatewayOwner: a.sessionGatewayOwner,
+		Held:         a.coordinator.Held(),
+		Leases:       []coordination.Lease{},
 	}
-	return a.auth.GetAuthState()
+	if status.Held == nil {
+		status.Held = []string{}
+	}
+	leases, err := a.coordinator.Leases()
+	if err != nil {
+		status.Error = err.Error()
+	} else if leases != nil {
+		status.Leases = leases
+	}
+	return status
 }
 
-// HandleDeepLink processa links orch:// (chamado pelo macOS)
-func (a *App) HandleDeepLink(urlStr string) {
-	log.Printf("[ORCH] Deep Link received: %s", urlStr)
+// === Background Jobs ===
 
-	// Validar scheme
-	if !strings.HasPrefix(urlStr, "orch://auth/callback") {
-		log.Printf("[ORCH] Ignored unknown deep link: %s", urlStr)
-		return
-	}
+// JobsList retorna os jobs ativos e os finalizados recentemente (sem logs; use JobGet).
+func (a *App) JobsList() []jobs.Job {
+	return a.jobs.List()
+}
 
-	// Parse manual simples para evitar dependencias complexas com net/url e custom schemes
-	// orch://auth/callback?code=xxx&state=yyy
-	parts := strings.Split(urlStr, "?")
-	if len(parts) < 2 {
-		log.Println("[ORCH] Deep link missing query params")
-		return
-	}
+// JobGet retorna um job com o log acumulado.
+func (a *App) JobGet(jobID string) (jobs.Job, error) {
+	return a.jobs.Get(jobID)
+}
 
-	queryParams := parts[1]
-	values := make(map[string]string)
-	for _, pair := range strings.Split(queryParams, "&") {
-		kv := strings.SplitN(pair, "=", 2)
-		if len(kv) == 2 {
-			values[kv[0]] = kv[1]
-		}
-	}
+// JobCancel pede o cancelamento do job; o estado final chega pelo evento jobs:updated.
+func (a *App) JobCancel(jobID string) error {
+	return a.jobs.Cancel(jobID)
+}
 
-	code := values["code"]
+// requestContext é o contexto das chamadas (GitHub e leituras git) de bindings sem token de
+// cancelamento: são abortadas quando o app encerra.
+func (a *App) requestContext() context.Context {
+	if a.ctx == nil {
+		return context.Background()
+	}
+	return a.ctx
+}
 
-	if code == "" {
-		log.Println("[ORCH] Deep link missing code")
-		return
+// CancelOperation aborta a chamada *Cancelable que recebeu o token (requests HTTP e processos git);
+// IDs de job também são aceitos. Retorna false se nada estava em andamento com esse token.
+// Cobertura: no Git Panel, status, diff, stage/unstage/discard (arquivo e patch), commit e
+// push; fetch roda como job e é cancelado pelo ID. No GitHub, as leituras de PR (commits,
+// arquivos e diffs); as demais chamadas REST/GraphQL são abortadas quando o app encerra.
+func (a *App) CancelOperation(token string) bool {
+	if a.operations.Cancel(token) {
+		return true
+	}
+	job, err := a.jobs.Get(token)
+	if err != nil || job.Done() {
+		return false
 	}
+	return a.jobs.Cancel(token) == nil
+}
 
-	// Processar callback
-	if a.auth != nil {
-		result, err := a.auth.HandleCallback(code)
-		if err != nil {
-			log.Printf("[ORCH] Auth callback failed: %v", err)
-			runtime.EventsEmit(a.ctx, "auth:error", err.Error())
-			return
+// GitPanelPushAsync executa GitPanelPush como job cancelável; o resultado (PushResultDTO) fica em Job.Result.
+func (a *App) GitPanelPushAsync(repoPath string, options gp.PushOptionsDTO) (jobs.Job, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	return a.submitJob(jobs.Spec{
+		Kind:         "git.push",
+		Module:       "gitpanel",
+		Title:        "Push",
+		Target:       repoPath,
+		ExclusiveKey: "git.push:" + filepath.Clean(repoPath),
+	}, func(ctx context.Context, report *jobs.Reporter) (interface{}, error) {
+		report.Progress(-1, "Verificando proteção de branches")
+		options.ProtectedBranches = a.gitHubProtectedBranches(repoPath)
+
+		report.Progress(-1, "Enviando commits")
+		result, pushErr := svc.PushContext(ctx, repoPath, options)
+		if pushErr != nil {
+			return nil, a.normalizeGitPanelBindingError(pushErr)
+		}
+		if result.Output != "" {
+			report.Log(result.Output)
 		}
+		if !result.Pushed {
+			report.Progress(1, "Push exige confirmação")
+		}
+		return result, nil
+	})
+}
 
-		if result.Success {
-			log.Println("[ORCH] Auth success via Deep Link!")
-			runtime.EventsEmit(a.ctx, "auth:changed", a.auth.GetAuthState())
+// GitPanelFetchAsync busca atualizações do remoto (vazio = todos) como job cancelável.
+func (a *App) GitPanelFetchAsync(repoPath string, options gp.FetchOptionsDTO) (jobs.Job, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	return a.submitJob(jobs.Spec{
+		Kind:         "git.fetch",
+		Module:       "gitpanel",
+		Title:        "Fetch",
+		Target:       repoPath,
+		ExclusiveKey: "git.fetch:" + filepath.Clean(repoPath),
+	}, func(ctx context.Context, report *jobs.Reporter) (interface{}, error) {
+		result, fetchErr := svc.FetchContext(ctx, repoPath, options)
+		if fetchErr != nil {
+			return nil, a.normalizeGitPanelBindingError(fetchErr)
+		}
+		if result.Output != "" {
+			report.Log(result.Output)
+		}
+		return result, nil
+	})
+}
 
-			// Focar janela do app
-			runtime.WindowShow(a.ctx)
+// GitPanelStreamHistoryAsync transmite o histórico em lotes (evento gitpanel:history:batch)
+// a partir do cursor, como job cancelável; útil para rolagem profunda em repositórios grandes.
+func (a *App) GitPanelStreamHistoryAsync(repoPath string, options gp.HistoryStreamOptionsDTO) (jobs.Job, error) {
+	svc, err := a.requireGitPanelService()
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	if strings.TrimSpace(options.Scope) == "" {
+		options.Scope = a.resolveGitPathScope(svc, repoPath)
+	}
+	return a.submitJob(jobs.Spec{
+		Kind:         "git.history_stream",
+		Module:       "gitpanel",
+		Title:        "Histórico",
+		Target:       repoPath,
+		ExclusiveKey: "git.history_stream:" + filepath.Clean(repoPath),
+	}, func(ctx context.Context, report *jobs.Reporter) (interface{}, error) {
+		final, streamErr := svc.StreamHistory(ctx, repoPath, options, func(batch gp.HistoryStreamBatchDTO) {
+			batch.StreamID = report.JobID()
+			page := gp.HistoryPageDTO{Items: batch.Items}
+			a.enrichGitPanelHistoryWithAuthIdentity(batch.RepoRoot, &page)
+			batch.Items = page.Items
+			if batch.EstimatedTotal > 0 {
+				report.Progress(float64(batch.Loaded)/float64(batch.EstimatedTotal), fmt.Sprintf("%d commits", batch.Loaded))
+			} else {
+				report.Progress(-1, fmt.Sprintf("%d commits", batch.Loaded))
+			}
+			if a.ctx != nil {
+				a.emitGitPanelRuntimeEvent("gitpanel:history:batch", batch)
+			}
+		})
+		if streamErr != nil {
+			return nil, a.normalizeGitPanelBindingError(streamErr)
 		}
+		final.Items = nil
+		final.StreamID = report.JobID()
+		return final, nil
+	})
+}
+
+// GitPanelPRPushLocalBranchAsync publica a branch no origin (fluxo de PR) como job cancelável.
+func (a *App) GitPanelPRPushLocalBranchAsync(repoPath string, branch string, confirm gp.PushConfirmationDTO) (jobs.Job, error) {
+	repoRoot, normalizedBranch, err := a.prepareGitPanelPRLocalBranchPush(repoPath, branch)
+	if err != nil {
+		return jobs.Job{}, err
+	}
+	return a.submitJob(jobs.Spec{
+		Kind:         "github.pr_push_branch",
+		Module:       "github",
+		Title:        "Publicar " + normalizedBranch + " no origin",
+		Target:       repoRoot,
+		ExclusiveKey: "git.push:" + filepath.Clean(repoRoot),
+	}, func(ctx context.Context, _ *jobs.Reporter) (interface{}, error) {
+		return nil, a.pushGitPanelPRLocalBranch(ctx, repoRoot, normalizedBranch, confirm)
+	})
+}
+
+// submitJob traduz o conflito de chave exclusiva para a mensagem exibida ao usuário.
+func (a *App) submitJob(spec jobs.Spec, fn jobs.Func) (jobs.Job, error) {
+	job, err := a.jobs.Submit(spec, fn)
+	if errors.Is(err, jobs.ErrConflict) {
+		return jobs.Job{}, fmt.Errorf("já existe uma operação %s em andamento para %s", spec.Kind, spec.Target)
 	}
+	return job, err
 }
 
 // === Docker Stack Builder Bindings ===
@@ -5863,10 +16387,21 @@ type StackBuildState struct {
 	Logs       []string `json:"logs"`
 	StartTime  int64    `json:"startTime"` // unix seconds, 0 se não está construindo
 	Result     string   `json:"result"`    // "", "success", "error"
+	JobID      string   `json:"jobId,omitempty"`
 }
 
 // BuildCustomStack inicia o processo de build da imagem customizada
 func (a *App) BuildCustomStack(tools map[string]string) error {
+	return a.BuildNamedStack(docker.DefaultStackName, tools)
+}
+
+// BuildNamedStack inicia o build de uma stack nomeada (orch-stack-<name>:latest).
+func (a *App) BuildNamedStack(name string, tools map[string]string) error {
+	stackName, err := docker.NormalizeStackName(name)
+	if err != nil {
+		return err
+	}
+
 	if a.docker == nil {
 		return fmt.Errorf("docker service not initialized")
 	}
@@ -5885,42 +16420,67 @@ func (a *App) BuildCustomStack(tools map[string]string) error {
 	a.stackBuildLogs = []string{"Starting environment build..."}
 	a.stackBuildStart = time.Now().Unix()
 	a.stackBuildResult = ""
+	a.stackBuildJobID = ""
 	a.stackBuildMu.Unlock()
 
-	// Emitir estado inicial para o frontend
-	runtime.EventsEmit(a.ctx, "docker:build:started", a.GetStackBuildState())
-
-	go func() {
-		cfg := docker.StackConfig{
-			ImageName: "orch-custom-stack:latest",
-			Tools:     tools,
-		}
+	cfg := docker.StackConfig{
+		Name:      stackName,
+		ImageName: docker.StackImageTag(stackName),
+		Tools:     tools,
+	}
+	// O build roda como job (JobCancel interrompe o docker build); os eventos docker:build:*
+	// continuam sendo emitidos para a tela de stacks.
+	_, submitErr := a.jobs.Submit(jobs.Spec{
+		Kind:         "docker.stack_build",
+		Module:       "docker",
+		Title:        "Build da stack " + stackName,
+		Target:       stackName,
+		ExclusiveKey: "docker:stack_build",
+	}, func(ctx context.Context, report *jobs.Reporter) (interface{}, error) {
+		a.stackBuildMu.Lock()
+		a.stackBuildJobID = report.JobID()
+		a.stackBuildMu.Unlock()
+		// Emitir estado inicial para o frontend
+		runtime.EventsEmit(a.ctx, "docker:build:started", a.GetStackBuildState())
 
 		logFn := func(line string) {
 			a.stackBuildMu.Lock()
 			a.stackBuildLogs = append(a.stackBuildLogs, line)
 			a.stackBuildMu.Unlock()
+			report.Log(line)
 			runtime.EventsEmit(a.ctx, "docker:build:log", line)
 		}
 
-		if err := a.docker.BuildStackImage(a.ctx, cfg, logFn); err != nil {
-			a.stackBuildMu.Lock()
+		if err := a.docker.BuildStackImage(ctx, cfg, logFn); err != nil {
 			errorMsg := err.Error()
+			if ctx.Err() != nil {
+				errorMsg = "build canceled"
+			}
+			a.stackBuildMu.Lock()
 			a.stackBuildLogs = append(a.stackBuildLogs, "❌ Error: "+errorMsg)
 			a.stackBuildRunning = false
 			a.stackBuildResult = "error"
 			a.stackBuildMu.Unlock()
 			runtime.EventsEmit(a.ctx, "docker:build:error", errorMsg)
-		} else {
-			a.stackBuildMu.Lock()
-			a.stackBuildLogs = append(a.stackBuildLogs, "✅ Image built successfully")
-			a.stackBuildRunning = false
-			a.stackBuildResult = "success"
-			a.stackBuildMu.Unlock()
-			runtime.EventsEmit(a.ctx, "docker:build:success", "Image built successfully")
+			if ctx.Err() == nil {
+				a.notifyEvent(notify.EventBuildFailed, "Stack image build failed", errorMsg, map[string]string{"image": cfg.ImageName})
+			}
+			return nil, err
 		}
-	}()
-
+		a.stackBuildMu.Lock()
+		a.stackBuildLogs = append(a.stackBuildLogs, "✅ Image built successfully")
+		a.stackBuildRunning = false
+		a.stackBuildResult = "success"
+		a.stackBuildMu.Unlock()
+		runtime.EventsEmit(a.ctx, "docker:build:success", "Image built successfully")
+		return cfg.ImageName, nil
+	})
+	if submitErr != nil {
+		a.stackBuildMu.Lock()
+		a.stackBuildRunning = false
+		a.stackBuildMu.Unlock()
+		return submitErr
+	}
 	return nil
 }
 
@@ -5937,6 +16497,7 @@ func (a *App) GetStackBuildState() StackBuildState {
 		Logs:       logsCopy,
 		StartTime:  a.stackBuildStart,
 		Result:     a.stackBuildResult,
+		JobID:      a.stackBuildJobID,
 	}
 }
 
@@ -5952,6 +16513,95 @@ func (a *App) GetCustomStackTools() (map[string]string, error) {
 	return cfg.Tools, nil
 }
 
+// ListStackImages lista as stacks nomeadas e se a imagem correspondente já foi construída.
+func (a *App) ListStackImages() ([]docker.StackImageInfo, error) {
+	if a.docker == nil {
+		return []docker.StackImageInfo{}, nil
+	}
+	return a.docker.ListStackImages()
+}
+
+// GetNamedStackTools retorna as ferramentas configuradas de uma stack nomeada.
+func (a *App) GetNamedStackTools(name string) (map[string]string, error) {
+	if a.docker == nil {
+		return map[string]string{}, nil
+	}
+	cfg, err := a.docker.LoadNamedStackConfig(name)
+	if err != nil {
+		return map[string]string{}, err
+	}
+	if cfg.Tools == nil {
+		return map[string]string{}, nil
+	}
+	return cfg.Tools, nil
+}
+
+// DeleteStackImage remove uma stack nomeada e limpa workspaces que a usavam como padrão.
+func (a *App) DeleteStackImage(name string) error {
+	if a.docker == nil {
+		return fmt.Errorf("docker service not initialized")
+	}
+
+	stackName, err := docker.NormalizeStackName(name)
+	if err != nil {
+		return err
+	}
+
+	a.stackBuildMu.RLock()
+	building := a.stackBuildRunning
+	a.stackBuildMu.RUnlock()
+	if building {
+		return fmt.Errorf("cannot delete stack while a build is in progress")
+	}
+
+	image := docker.StackImageTag(stackName)
+	if cfg, loadErr := a.docker.LoadNamedStackConfig(stackName); loadErr == nil && cfg.ImageName != "" {
+		image = cfg.ImageName
+	}
+
+	if err := a.docker.DeleteStackImage(stackName); err != nil {
+		return err
+	}
+
+	if a.db != nil {
+		if err := a.db.ClearWorkspaceDockerImageRefs(image); err != nil {
+			log.Printf("[ORCH] failed to clear workspace docker image refs image=%s: %v", image, err)
+		}
+	}
+	return nil
+}
+
+// SetWorkspaceDockerImage define a imagem Docker padrão do workspace (vazio remove a associação).
+func (a *App) SetWorkspaceDockerImage(workspaceID uint, image string) (*database.Workspace, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	normalized, err := normalizeDockerImageRef(image)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.db.SetWorkspaceDockerImage(workspaceID, normalized); err != nil {
+		return nil, err
+	}
+	return a.db.GetWorkspace(workspaceID)
+}
+
+var dockerImageRefRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._/:@-]{0,254}$`)
+
+// normalizeDockerImageRef valida uma referência de imagem Docker (vazio é permitido).
+func normalizeDockerImageRef(image string) (string, error) {
+	trimmed := strings.TrimSpace(image)
+	if trimmed == "" {
+		return "", nil
+	}
+	if !dockerImageRefRegex.MatchString(trimmed) || strings.Contains(trimmed, "..") {
+		return "", fmt.Errorf("invalid docker image reference: %q", image)
+	}
+	return trimmed, nil
+}
+
 // === Terminal Snapshot Bindings (Session Persistence) ===
 
 // TerminalSnapshotDTO é o DTO para comunicação frontend↔backend.
@@ -5965,6 +16615,8 @@ type TerminalSnapshotDTO struct {
 	UseDocker bool   `json:"useDocker"`
 	Config    string `json:"config,omitempty"`
 	CLIType   string `json:"cliType,omitempty"`
+	// ReplayCommands são reexecutados na restauração; vazio usa os marcados no agente da sessão.
+	ReplayCommands []string `json:"replayCommands,omitempty"`
 }
 
 // SaveTerminalSnapshots recebe dados dos panes do frontend,
@@ -6002,10 +16654,12 @@ func (a *App) SaveTerminalSnapshots(dtos []TerminalSnapshotDTO) error {
 			PaneTitle: dto.PaneTitle,
 			PaneType:  dto.PaneType,
 			Config:    dto.Config,
+
+			ReplayCmds: a.snapshotReplayCommands(dto.SessionID, dto.ReplayCommands),
 		})
 	}
 
-	if err := a.db.SaveTerminalSnapshots(snapshots); err != nil {
+	if err := a.withDBWriteLease(terminalSnapshotsLease, func() error { return a.db.SaveTerminalSnapshots(snapshots) }); err != nil {
 		log.Printf("[ORCH] Error saving terminal snapshots: %v", err)
 		return err
 	}
@@ -6014,6 +16668,25 @@ func (a *App) SaveTerminalSnapshots(dtos []TerminalSnapshotDTO) error {
 	return nil
 }
 
+// snapshotReplayCommands resolve os comandos de replay gravados no snapshot: os enviados pelo
+// frontend ou, na falta deles, os marcados no agente vinculado à sessão.
+func (a *App) snapshotReplayCommands(sessionID string, commands []string) string {
+	if normalized, err := normalizeStartupCommands(commands); err == nil && len(normalized) > 0 {
+		return encodeStringList(normalized)
+	}
+	a.terminalStateMu.RLock()
+	agentID := a.sessionAgents[strings.TrimSpace(sessionID)]
+	a.terminalStateMu.RUnlock()
+	if agentID == 0 || a.db == nil {
+		return ""
+	}
+	agent, err := a.db.GetAgent(agentID)
+	if err != nil || agent == nil {
+		return ""
+	}
+	return agent.ReplayCmds
+}
+
 // GetTerminalSnapshots retorna os snapshots salvos para restauração.
 func (a *App) GetTerminalSnapshots() ([]TerminalSnapshotDTO, error) {
 	if a.db == nil {
@@ -6036,6 +16709,8 @@ func (a *App) GetTerminalSnapshots() ([]TerminalSnapshotDTO, error) {
 			UseDocker: s.UseDocker,
 			Config:    s.Config,
 			CLIType:   s.CLIType,
+
+			ReplayCommands: decodeAgentStringList(s.ReplayCmds),
 		})
 	}
 
@@ -6048,7 +16723,7 @@ func (a *App) ClearTerminalSnapshots() error {
 		return nil
 	}
 	log.Println("[ORCH] Clearing terminal snapshots")
-	return a.db.ClearTerminalSnapshots()
+	return a.withDBWriteLease(terminalSnapshotsLease, a.db.ClearTerminalSnapshots)
 }
 
 // snapshotTerminalsOnShutdown captura o estado dos terminais ativos
@@ -6094,15 +16769,16 @@ func (a *App) snapshotTerminalsOnShutdown() {
 		}
 
 		snapshots = append(snapshots, database.TerminalSnapshot{
-			PaneID:  paneID,
-			CLIType: string(cliType),
-			Shell:   sess.Shell,
-			Cwd:     cwd,
+			PaneID:     paneID,
+			CLIType:    string(cliType),
+			Shell:      sess.Shell,
+			Cwd:        cwd,
+			ReplayCmds: a.snapshotReplayCommands(sess.ID, nil),
 		})
 	}
 
 	if len(snapshots) > 0 {
-		if err := a.db.SaveTerminalSnapshots(snapshots); err != nil {
+		if err := a.withDBWriteLease(terminalSnapshotsLease, func() error { return a.db.SaveTerminalSnapshots(snapshots) }); err != nil {
 			log.Printf("[ORCH] Error saving terminal snapshots (fallback): %v", err)
 		} else {
 			log.Printf("[ORCH] Saved %d terminal snapshots (backend fallback)", len(snapshots))
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"orch/internal/database"
+	ga "orch/internal/gitactivity"
+	gp "orch/internal/gitpanel"
+	"orch/internal/terminal"
+)
+
+func TestGenerateActivityDigestCombinesLocalSources(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	repoRoot := mustInitPRResolveTestRepo(t, "")
+	runGitOrFailForPRResolve(t, repoRoot, "commit", "--allow-empty", "-m", "wire digest binding")
+
+	ws := &database.Workspace{Name: "Digest", Path: repoRoot}
+	if err := app.db.CreateWorkspace(ws); err != nil {
+		t.Fatalf("CreateWorkspace returned error: %v", err)
+	}
+
+	app.gitPanel = gp.NewService(nil)
+	app.gitActivity = ga.NewService(50, time.Millisecond)
+	app.gitActivity.AppendEvent(ga.Event{Type: ga.EventTypeBranchCreated, RepoPath: repoRoot, RepoName: "digest", Branch: "feature/digest", Message: "Branch feature/digest criado", Timestamp: time.Now()})
+	app.gitActivity.AppendEvent(ga.Event{Type: ga.EventTypeIndexUpdated, RepoPath: repoRoot, RepoName: "digest", Message: "index atualizado", Timestamp: time.Now()})
+	app.gitActivity.AppendEvent(ga.Event{Type: ga.EventTypeMerge, RepoPath: repoRoot, RepoName: "digest", Message: "merge antigo", Timestamp: time.Now().Add(-48 * time.Hour)})
+
+	app.testRuns = terminal.NewTestRunTracker(10, func(string) uint { return ws.ID })
+	app.testRuns.ObserveInput("term-1", []byte("go test ./...\r"))
+	app.testRuns.ObserveOutput("term-1", []byte("FAIL\torch/internal/digest\t0.01s\n"))
+
+	result, err := app.GenerateActivityDigest(ws.ID, 0, true)
+	if err != nil {
+		t.Fatalf("GenerateActivityDigest returned error: %v", err)
+	}
+
+	if result.WorkspaceName != "Digest" || time.Since(result.Since) < 23*time.Hour {
+		t.Fatalf("unexpected header: name=%q since=%v", result.WorkspaceName, result.Since)
+	}
+	if result.Stats.Commits != 2 || result.Commits[0].Repo != filepath.Base(repoRoot) || !strings.Contains(result.Markdown, "wire digest binding") {
+		t.Fatalf("unexpected commits: %+v", result.Commits)
+	}
+	if len(result.Activity) != 1 || result.Activity[0].Branch != "feature/digest" {
+		t.Fatalf("expected only the recent branch event, got %+v", result.Activity)
+	}
+	if result.Stats.TestRuns != 1 || result.Stats.TestRunsFailed != 1 || result.TestRuns[0].Command != "go test ./..." {
+		t.Fatalf("unexpected test runs: %+v", result.TestRuns)
+	}
+	if len(result.PullRequestsOpened) != 0 || len(result.PullRequestsReviewed) != 0 {
+		t.Fatalf("expected no PRs without GitHub service, got %+v / %+v", result.PullRequestsOpened, result.PullRequestsReviewed)
+	}
+	if result.Summary != "" || len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "IA indisponível") {
+		t.Fatalf("expected AI warning without summary, got summary=%q warnings=%v", result.Summary, result.Warnings)
+	}
+
+	if _, err := app.GenerateActivityDigest(0, 24, false); err == nil {
+		t.Fatalf("expected missing workspace to fail")
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestSetAgentAutoStartPersistsPolicy(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+
+	agent, err := app.CreateAgentSession(0, "", "")
+	if err != nil {
+		t.Fatalf("CreateAgentSession returned error: %v", err)
+	}
+	if agent.AutoStart != agentAutoStartManual {
+		t.Fatalf("expected new agents to be manual, got %q", agent.AutoStart)
+	}
+	if _, err := app.SetAgentAutoStart(agent.ID, "on_boot"); err == nil {
+		t.Fatalf("expected unknown policy to be rejected")
+	}
+	updated, err := app.SetAgentAutoStart(agent.ID, " Workspace_Activated ")
+	if err != nil {
+		t.Fatalf("SetAgentAutoStart returned error: %v", err)
+	}
+	if updated.AutoStart != agentAutoStartWorkspaceActivated {
+		t.Fatalf("unexpected policy: %q", updated.AutoStart)
+	}
+
+	scoped, err := app.db.ListAgentsByAutoStart(agentAutoStartWorkspaceActivated, agent.WorkspaceID)
+	if err != nil || len(scoped) != 1 || scoped[0].ID != agent.ID {
+		t.Fatalf("expected agent listed for its workspace, got %+v (%v)", scoped, err)
+	}
+	if other, _ := app.db.ListAgentsByAutoStart(agentAutoStartWorkspaceActivated, agent.WorkspaceID+1); len(other) != 0 {
+		t.Fatalf("expected no agents for other workspace, got %+v", other)
+	}
+	if launch, _ := app.db.ListAgentsByAutoStart(agentAutoStartAppLaunch, 0); len(launch) != 0 {
+		t.Fatalf("expected no app_launch agents, got %+v", launch)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"orch/internal/agentgraph"
+)
+
+func TestSetAgentDependenciesValidatesGraph(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+
+	db, err := app.CreateAgentSession(0, "db", "")
+	if err != nil {
+		t.Fatalf("CreateAgentSession returned error: %v", err)
+	}
+	api, err := app.CreateAgentSession(0, "api", "")
+	if err != nil {
+		t.Fatalf("CreateAgentSession returned error: %v", err)
+	}
+
+	if _, err := app.SetAgentDependencies(db.ID, AgentDependenciesDTO{ReadyMode: agentgraph.ReadyOnPattern, ReadyRegex: "("}); err == nil {
+		t.Fatalf("expected invalid regex to be rejected")
+	}
+	updated, err := app.SetAgentDependencies(db.ID, AgentDependenciesDTO{ReadyMode: "Pattern", ReadyRegex: "ready to accept connections"})
+	if err != nil {
+		t.Fatalf("SetAgentDependencies returned error: %v", err)
+	}
+	if updated.ReadyMode != agentgraph.ReadyOnPattern || updated.ReadyRegex != "ready to accept connections" {
+		t.Fatalf("unexpected readiness config: %+v", updated)
+	}
+
+	updated, err = app.SetAgentDependencies(api.ID, AgentDependenciesDTO{DependsOn: []uint{db.ID, db.ID}, ReadyMode: "exit", ReadyRegex: "ignored"})
+	if err != nil {
+		t.Fatalf("SetAgentDependencies returned error: %v", err)
+	}
+	if got := decodeAgentIDList(updated.DependsOn); len(got) != 1 || got[0] != db.ID || updated.ReadyRegex != "" {
+		t.Fatalf("unexpected dependencies: %+v", updated)
+	}
+
+	if _, err := app.SetAgentDependencies(db.ID, AgentDependenciesDTO{DependsOn: []uint{api.ID}}); err == nil {
+		t.Fatalf("expected dependency cycle to be rejected")
+	}
+	if _, err := app.SetAgentDependencies(db.ID, AgentDependenciesDTO{DependsOn: []uint{api.ID + 100}}); err == nil {
+		t.Fatalf("expected agent outside the workspace to be rejected")
+	}
+
+	if _, err := app.StartWorkspaceAgents(db.WorkspaceID + 100); err == nil {
+		t.Fatalf("expected unknown workspace error")
+	}
+}
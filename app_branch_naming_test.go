@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	gp "orch/internal/gitpanel"
+	gpr "orch/internal/gitprs"
+)
+
+func TestGitPanelCreateLocalBranchEnforcesBranchNamingPolicy(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	app.gitPanel = gp.NewService(nil)
+	repoRoot := mustInitPRResolveTestRepo(t, "")
+
+	if _, err := app.GitPanelSetBranchNamingPolicy(repoRoot, gp.BranchNamingPolicyDTO{Templates: []string{"feature/{oops}"}}); err == nil {
+		t.Fatalf("expected invalid template to be rejected")
+	}
+	saved, err := app.GitPanelSetBranchNamingPolicy(repoRoot, gp.BranchNamingPolicyDTO{Templates: []string{"feature/{ticket}-{slug}"}, Mode: "block"})
+	if err != nil || saved.Mode != gp.BranchNamingModeBlock {
+		t.Fatalf("GitPanelSetBranchNamingPolicy returned (%+v, %v)", saved, err)
+	}
+	if policy, _ := app.GitPanelGetBranchNamingPolicy(repoRoot); len(policy.Templates) != 1 || policy.Mode != gp.BranchNamingModeBlock {
+		t.Fatalf("unexpected stored policy: %+v", policy)
+	}
+
+	err = app.GitPanelPRCreateLocalBranch(repoRoot, "feature/add-search", "")
+	bindingErr := gpr.AsBindingError(err)
+	if bindingErr == nil || bindingErr.Code != gpr.CodeValidationFailed || !strings.Contains(bindingErr.Details, "falta o ticket") {
+		t.Fatalf("expected policy violation, got %v", err)
+	}
+
+	suggestion, err := app.GitPanelSuggestBranchName(repoRoot, "Add search", "ORCH-7", true)
+	if err != nil || suggestion.Name != "feature/ORCH-7-add-search" || suggestion.Warning == "" {
+		t.Fatalf("unexpected suggestion: %+v (%v)", suggestion, err)
+	}
+	if err := app.GitPanelPRCreateLocalBranch(repoRoot, suggestion.Name, ""); err != nil {
+		t.Fatalf("expected suggested branch to be accepted: %v", err)
+	}
+
+	if _, err := app.GitPanelSetBranchNamingPolicy(repoRoot, gp.BranchNamingPolicyDTO{}); err != nil {
+		t.Fatalf("clearing policy returned error: %v", err)
+	}
+	if result, _ := app.GitPanelValidateBranchName(repoRoot, "whatever"); !result.Valid {
+		t.Fatalf("expected any name to be valid without policy: %+v", result)
+	}
+}
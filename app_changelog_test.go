@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gp "orch/internal/gitpanel"
+)
+
+func TestGitPanelChangelogLinksPRsAndWritesFile(t *testing.T) {
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+	repoRoot := mustInitPRResolveTestRepo(t, "https://github.com/orch-labs/orch.git")
+	runGitOrFailForPRResolve(t, repoRoot, "tag", "v0.1.0")
+	runGitOrFailForPRResolve(t, repoRoot, "commit", "--allow-empty", "-m", "feat(ui): add changelog panel (#42)")
+
+	changelog, err := app.GitPanelGenerateChangelog(repoRoot, "", "", "conventional")
+	if err != nil {
+		t.Fatalf("GitPanelGenerateChangelog returned error: %v", err)
+	}
+	if !strings.Contains(changelog.Markdown, "- **ui:** add changelog panel ([#42](https://github.com/orch-labs/orch/pull/42)) (") {
+		t.Fatalf("expected PR link in markdown:\n%s", changelog.Markdown)
+	}
+
+	if _, err := app.GitPanelPublishChangelog(repoRoot, "", "", "", GitPanelChangelogPublishDTO{}); err == nil {
+		t.Fatalf("expected publish without targets to fail")
+	}
+	if _, err := app.GitPanelPublishChangelog(repoRoot, "", "", "", GitPanelChangelogPublishDTO{ReleaseDraft: true}); err == nil {
+		t.Fatalf("expected release draft of an untagged ref without tag name to fail")
+	}
+
+	result, err := app.GitPanelPublishChangelog(repoRoot, "", "", "", GitPanelChangelogPublishDTO{WriteFile: true})
+	if err != nil || result.File == nil || !result.File.Created || result.Release != nil {
+		t.Fatalf("unexpected publish result: %+v (%v)", result, err)
+	}
+	content, err := os.ReadFile(filepath.Join(repoRoot, gp.ChangelogFileName))
+	if err != nil || !strings.HasPrefix(string(content), "# Changelog\n\n## Unreleased\n\n### Features\n") {
+		t.Fatalf("unexpected CHANGELOG.md: %q (%v)", content, err)
+	}
+}
+
+func TestChangelogReleaseBodyDropsVersionHeading(t *testing.T) {
+	body := changelogReleaseBody("## v1.0.0 (2026-01-01)\n\n### Features\n\n- x (abc)\n")
+	if body != "### Features\n\n- x (abc)\n" {
+		t.Fatalf("unexpected release body: %q", body)
+	}
+}
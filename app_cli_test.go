@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"orch/internal/config"
+)
+
+func TestTerminalOutputSinceFollowsCursor(t *testing.T) {
+	app := NewApp()
+	app.observeTerminalHistory("s1", []byte("hello "))
+	first := app.terminalOutputSince("s1", 0)
+	if first.Output != "hello " || first.Cursor != 6 || first.Truncated {
+		t.Fatalf("unexpected first chunk: %+v", first)
+	}
+
+	app.observeTerminalHistory("s1", []byte("world\n"))
+	if next := app.terminalOutputSince("s1", first.Cursor); next.Output != "world\n" || next.Cursor != 12 {
+		t.Fatalf("unexpected next chunk: %+v", next)
+	}
+	if idle := app.terminalOutputSince("s1", 12); idle.Output != "" || idle.Cursor != 12 {
+		t.Fatalf("expected empty chunk at the end, got %+v", idle)
+	}
+
+	// Depois de estourar o ring buffer, um cursor antigo recebe o buffer inteiro marcado como truncado.
+	app.observeTerminalHistory("s1", []byte(strings.Repeat("x", config.TerminalRingBufferSize+1)))
+	stale := app.terminalOutputSince("s1", 12)
+	if !stale.Truncated || len(stale.Output) != config.TerminalRingBufferSize || stale.Cursor != int64(13+config.TerminalRingBufferSize) {
+		t.Fatalf("unexpected stale chunk: truncated=%v len=%d cursor=%d", stale.Truncated, len(stale.Output), stale.Cursor)
+	}
+	if restarted := app.terminalOutputSince("s1", stale.Cursor+100); !restarted.Truncated {
+		t.Fatalf("expected cursor beyond the end to be treated as a restart")
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestClipboardHistoryBindings(t *testing.T) {
+	keyring.MockInit()
+	app := newTestAppWithDatabase(t)
+
+	if settings := app.GetClipboardHistorySettings(); settings.Enabled || settings.Watching {
+		t.Fatalf("clipboard history must be opt-in, got %+v", settings)
+	}
+	settings, err := app.SetClipboardHistoryEnabled(true)
+	if err != nil || !settings.Enabled {
+		t.Fatalf("SetClipboardHistoryEnabled returned (%+v, %v)", settings, err)
+	}
+
+	entry, _, err := app.clipboardHistory.Add("password=hunter2 make deploy")
+	if err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	app.clipboardHistory.Add("npm run dev")
+	if _, err := app.ClipboardHistoryPin(entry.ID, true); err != nil {
+		t.Fatalf("ClipboardHistoryPin returned error: %v", err)
+	}
+	if _, err := app.ClipboardHistoryPin("missing", true); err == nil {
+		t.Fatalf("expected unknown entry to be rejected")
+	}
+
+	if err := app.ClipboardHistoryClear(false); err != nil {
+		t.Fatalf("ClipboardHistoryClear returned error: %v", err)
+	}
+	entries, err := app.ClipboardHistoryList()
+	if err != nil || len(entries) != 1 || !entries[0].Pinned {
+		t.Fatalf("expected only the pinned entry to remain, got %+v (%v)", entries, err)
+	}
+	if strings.Contains(entries[0].Text, "hunter2") || !entries[0].Redacted {
+		t.Fatalf("expected password to be redacted, got %+v", entries[0])
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"orch/internal/config"
+)
+
+func TestConfigFileOverridesSessionEnvVars(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := "[session]\ngateway_listen_addr = \"127.0.0.1:19888\"\n\n[gitpanel]\nevent_debounce_ms = 300\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("ORCH_CONFIG_FILE", path)
+	t.Setenv("ORCH_SESSION_GATEWAY_LISTEN_ADDR", "127.0.0.1:29888")
+	t.Setenv("ORCH_SESSION_SIGNALING_LISTEN_ADDR", "127.0.0.1:29876")
+
+	app := NewApp()
+	app.configureSessionNetworking()
+	if app.sessionGatewayAddr != "127.0.0.1:19888" {
+		t.Fatalf("expected config file to win over env, got %q", app.sessionGatewayAddr)
+	}
+	if app.signalingAddr != "127.0.0.1:29876" {
+		t.Fatalf("expected env to win over default, got %q", app.signalingAddr)
+	}
+
+	effective := app.GetEffectiveConfig()
+	if !effective.FileLoaded || effective.Path != path {
+		t.Fatalf("unexpected effective config: %#v", effective)
+	}
+	sources := make(map[string]string)
+	for _, value := range effective.Values {
+		sources[value.Key] = value.Source
+	}
+	if sources["session.gateway_listen_addr"] != config.SourceFile ||
+		sources["session.signaling_listen_addr"] != config.SourceEnv ||
+		sources["github.cache_ttl_seconds"] != config.SourceDefault {
+		t.Fatalf("unexpected sources: %#v", sources)
+	}
+
+	app.applyConfigFile(effective)
+	if app.gitPanelDebounce != 300*time.Millisecond {
+		t.Fatalf("expected git panel debounce from config file, got %s", app.gitPanelDebounce)
+	}
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"orch/internal/database"
 )
@@ -11,6 +14,8 @@ func newTestAppWithDatabase(t *testing.T) *App {
 	t.Helper()
 
 	t.Setenv("HOME", t.TempDir())
+	// Sem ORCH_DB_PATH o banco cai no .orch/ do diretório atual (o do repositório).
+	t.Setenv("ORCH_DB_PATH", filepath.Join(t.TempDir(), "orch.db"))
 
 	db, err := database.NewService()
 	if err != nil {
@@ -148,3 +153,49 @@ func TestGetWorkspacesWithAgentsMigratesLegacyGitHubAgents(t *testing.T) {
 		}
 	}
 }
+
+func TestGetDatabaseInfoReportsLatestSchema(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+
+	info, err := app.GetDatabaseInfo()
+	if err != nil {
+		t.Fatalf("GetDatabaseInfo returned error: %v", err)
+	}
+	if info.SchemaVersion != database.LatestSchemaVersion() || info.PendingMigrations != 0 || info.SizeBytes <= 0 || info.Path == "" {
+		t.Fatalf("unexpected database info: %+v", info)
+	}
+}
+
+func TestSessionSearchAuditLogsFiltersByActionList(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	sessionID := fmt.Sprintf("audit-search-%d", time.Now().UnixNano())
+	for _, action := range []string{"join_approved", "join_rejected", "terminal_input"} {
+		if err := app.db.SaveAuditEvent(&database.AuditLog{SessionID: sessionID, UserID: "host", Action: action}); err != nil {
+			t.Fatalf("SaveAuditEvent returned error: %v", err)
+		}
+	}
+
+	page, err := app.SessionSearchAuditLogs(sessionID, "join_approved, terminal_*", "", database.AuditLogTimeRange{}, database.AuditLogPagination{})
+	if err != nil {
+		t.Fatalf("SessionSearchAuditLogs returned error: %v", err)
+	}
+	if page.Total != 2 {
+		t.Fatalf("expected 2 matching events, got %+v", page)
+	}
+
+	from := time.Now()
+	to := from.Add(-time.Hour)
+	if _, err := app.SessionSearchAuditLogs(sessionID, "", "", database.AuditLogTimeRange{From: &from, To: &to}, database.AuditLogPagination{}); err == nil {
+		t.Fatalf("expected inverted time range to be rejected")
+	}
+}
+
+func TestSetAuditRetentionPolicyRejectsInvalidValues(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+
+	for _, policy := range []AuditRetentionPolicy{{RetentionDays: -1}, {MaxRows: 10}, {RetentionDays: maxAuditRetentionDays + 1}} {
+		if _, err := app.SetAuditRetentionPolicy(policy); err == nil {
+			t.Fatalf("expected policy %+v to be rejected", policy)
+		}
+	}
+}
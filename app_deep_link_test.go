@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"orch/internal/deeplink"
+)
+
+func TestHandleDeepLinkQueuesConfirmationAndOpensRepo(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	repo := newFakeGitRepo(t)
+
+	app.HandleDeepLink("orch://open?path=" + repo)
+	app.HandleDeepLink("orch://join/abcd-efg")
+	app.HandleDeepLink("orch://join/bogus")
+	app.HandleDeepLink("orch://pr/octo/hello/7")
+
+	pending := app.GetPendingDeepLinks()
+	if len(pending) != 2 {
+		t.Fatalf("expected open and join links pending confirmation, got %+v", pending)
+	}
+	if pending[0].Link.Action != deeplink.ActionOpenRepo || pending[1].Link.Code != "ABCD-EFG" {
+		t.Fatalf("unexpected pending links: %+v", pending)
+	}
+
+	if err := app.RespondDeepLink(pending[1].ID, false); err != nil {
+		t.Fatalf("RespondDeepLink(dismiss) returned error: %v", err)
+	}
+	if err := app.RespondDeepLink(pending[1].ID, true); err == nil {
+		t.Fatalf("expected dismissed link to be gone")
+	}
+
+	if err := app.RespondDeepLink(pending[0].ID, true); err != nil {
+		t.Fatalf("RespondDeepLink(open) returned error: %v", err)
+	}
+	active, err := app.db.GetActiveWorkspace()
+	if err != nil {
+		t.Fatalf("GetActiveWorkspace returned error: %v", err)
+	}
+	repos, _ := app.ListWorkspaceRepositories(active.ID)
+	if len(repos) != 1 || repos[0].Path != repo {
+		t.Fatalf("expected active workspace to hold %s, got %+v", repo, repos)
+	}
+
+	// Um segundo link para o mesmo repositório reaproveita o workspace.
+	opened, err := app.openRepoFromDeepLink(repo)
+	if err != nil || opened.Created || opened.WorkspaceID != active.ID {
+		t.Fatalf("expected existing workspace to be reused, got %+v (%v)", opened, err)
+	}
+	if _, err := app.openRepoFromDeepLink(t.TempDir()); err == nil {
+		t.Fatalf("expected non-repository path to be rejected")
+	}
+}
+
+func TestPendingDeepLinksExpire(t *testing.T) {
+	app := NewApp()
+	request := app.queueDeepLink(deeplink.Link{Action: deeplink.ActionJoinSession, Code: "ABCD-EFG"})
+
+	app.deepLinksMu.Lock()
+	request.RequestedAt = time.Now().Add(-deepLinkConfirmTTL - time.Second)
+	app.deepLinks[request.ID] = request
+	app.deepLinksMu.Unlock()
+
+	if pending := app.GetPendingDeepLinks(); len(pending) != 0 {
+		t.Fatalf("expected expired link to be pruned, got %+v", pending)
+	}
+	if err := app.RespondDeepLink(request.ID, true); err == nil {
+		t.Fatalf("expected expired link to be rejected")
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"orch/internal/config"
+	"orch/internal/database"
+	"orch/internal/diskusage"
+)
+
+func TestScanDiskUsageReportsWorkspacesAndDatabase(t *testing.T) {
+	app, db := newAppWithIsolatedDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	wsPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(wsPath, "big.bin"), make([]byte, 4096), 0o600); err != nil {
+		t.Fatalf("write workspace file: %v", err)
+	}
+	if err := db.CreateWorkspace(&database.Workspace{Name: "disk-ws", Path: wsPath}); err != nil {
+		t.Fatalf("CreateWorkspace() error: %v", err)
+	}
+
+	report, err := app.ScanDiskUsage()
+	if err != nil {
+		t.Fatalf("ScanDiskUsage() error: %v", err)
+	}
+	var workspace *diskusage.Item
+	for i := range report.Items {
+		if report.Items[i].Category == diskusage.CategoryWorkspace && report.Items[i].Name == "disk-ws" {
+			workspace = &report.Items[i]
+		}
+	}
+	if workspace == nil || workspace.Bytes != 4096 {
+		t.Fatalf("expected disk-ws to be measured, got %+v", report.Items)
+	}
+	if report.Totals[diskusage.CategoryDatabase] == 0 {
+		t.Fatalf("expected SQLite database to be measured, totals=%+v", report.Totals)
+	}
+	if len(report.Alerts) != 0 {
+		t.Fatalf("default limits should not alert on a tiny tree: %+v", report.Alerts)
+	}
+}
+
+func TestClearOldDiskArtifactsRemovesOnlyStaleRotatedLogs(t *testing.T) {
+	app, db := newAppWithIsolatedDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	logDir := config.LogDir()
+	if err := os.MkdirAll(logDir, 0o700); err != nil {
+		t.Fatalf("mkdir logs: %v", err)
+	}
+	old := time.Now().AddDate(0, 0, -10)
+	for _, name := range []string{"orch.log", "orch.log.1"} {
+		path := filepath.Join(logDir, name)
+		if err := os.WriteFile(path, []byte("entry\n"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+
+	result, err := app.ClearOldDiskArtifacts("logs", 7)
+	if err != nil {
+		t.Fatalf("ClearOldDiskArtifacts() error: %v", err)
+	}
+	if result.Removed != 1 || result.FreedBytes != 6 {
+		t.Fatalf("unexpected cleanup result: %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(logDir, "orch.log")); err != nil {
+		t.Fatalf("active log must be kept: %v", err)
+	}
+
+	if _, err := app.ClearOldDiskArtifacts("logs", 0); err == nil {
+		t.Fatalf("expected olderThanDays=0 to be rejected")
+	}
+	if _, err := app.ClearOldDiskArtifacts("everything", 7); err == nil {
+		t.Fatalf("expected unknown kind to be rejected")
+	}
+}
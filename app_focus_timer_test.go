@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"orch/internal/database"
+	"orch/internal/focus"
+)
+
+func TestFocusTimerBindingsHoldNotificationsDuringFocus(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	t.Cleanup(app.focusTimer.Close)
+
+	ws := &database.Workspace{Name: "Focus", Path: t.TempDir()}
+	if err := app.db.CreateWorkspace(ws); err != nil {
+		t.Fatalf("CreateWorkspace returned error: %v", err)
+	}
+	if _, err := app.FocusStart(0, focus.Options{}); err == nil {
+		t.Fatalf("expected missing workspace to fail")
+	}
+	if _, err := app.FocusStart(ws.ID+100, focus.Options{}); err == nil {
+		t.Fatalf("expected unknown workspace to fail")
+	}
+
+	state, err := app.FocusStart(ws.ID, focus.Options{DoNotDisturb: true})
+	if err != nil {
+		t.Fatalf("FocusStart returned error: %v", err)
+	}
+	if state.Phase != focus.PhaseFocus || !state.DoNotDisturb || state.RemainingSeconds != 25*60 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+
+	app.notifyEvent("pr.merged", "PR merged", "orch#1", nil)
+	if held := app.GetFocusState().HeldNotifications; held != 1 {
+		t.Fatalf("expected notification to be held during focus, got %d", held)
+	}
+
+	if state, err := app.FocusPause(); err != nil || !state.Paused || state.HeldNotifications != 0 {
+		t.Fatalf("FocusPause returned (%+v, %v)", state, err)
+	}
+	if _, err := app.FocusResume(); err != nil {
+		t.Fatalf("FocusResume returned error: %v", err)
+	}
+	if state := app.FocusStop(); state.Phase != focus.PhaseIdle {
+		t.Fatalf("unexpected stopped state: %+v", state)
+	}
+	if _, err := app.FocusPause(); err == nil {
+		t.Fatalf("expected FocusPause to fail when idle")
+	}
+}
@@ -65,6 +65,7 @@ func TestMapLegacyGitEventToGitPanelInvalidation(t *testing.T) {
 		{eventName: "git:branch_changed", wantStatus: true, wantHistory: true},
 		{eventName: "git:commit", wantStatus: true, wantHistory: true},
 		{eventName: "git:fetch", wantStatus: true},
+		{eventName: "git:worktree", wantStatus: true},
 		{eventName: "git:commit_preparing"},
 		{eventName: "unknown:event"},
 	}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"orch/internal/auth"
+	gh "orch/internal/github"
+	gp "orch/internal/gitpanel"
+	"orch/internal/session"
+)
+
+func TestGitPanelCommitAddsSessionGuestsAsCoAuthors(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available in test environment")
+	}
+	app := newTestAppWithDatabase(t)
+	app.gitPanel = gp.NewService(nil)
+	app.auth = auth.NewService(app.db)
+	app.auth.SetCurrentUserForTesting(&auth.User{ID: "host-1", Name: "Host", Provider: "github"})
+	app.session = session.NewService(nil)
+	app.sessionGatewayOwner = true
+	app.github = gh.NewService(func() (string, error) { return "token", nil })
+	// Identidades já em cache: o teste não acessa a API do GitHub.
+	app.gitPanelCoAuthors["ana-dev"] = gitPanelCoAuthorCacheEntry{
+		identity:  &gh.UserIdentity{ID: 42, Login: "ana-dev", NoReplyEmail: "42+ana-dev@users.noreply.github.com"},
+		expiresAt: time.Now().Add(time.Hour),
+	}
+	app.gitPanelCoAuthors["bob"] = gitPanelCoAuthorCacheEntry{expiresAt: time.Now().Add(time.Hour)}
+
+	repoRoot := t.TempDir()
+	runGitForCommitTest(t, repoRoot, "init")
+	runGitForCommitTest(t, repoRoot, "config", "user.email", "tests@orch.local")
+	runGitForCommitTest(t, repoRoot, "config", "user.name", "ORCH Tests")
+	ws, err := app.CreateWorkspace("Pairing")
+	if err != nil {
+		t.Fatalf("CreateWorkspace returned error: %v", err)
+	}
+	if _, err := app.AddWorkspaceRepository(ws.ID, repoRoot, "app"); err != nil {
+		t.Fatalf("AddWorkspaceRepository returned error: %v", err)
+	}
+
+	suggestions, err := app.GitPanelSuggestCoAuthors(repoRoot)
+	if err != nil || len(suggestions) != 0 {
+		t.Fatalf("expected no suggestions without session, got (%+v, %v)", suggestions, err)
+	}
+
+	sess, err := app.session.CreateSession("host-1", session.SessionConfig{WorkspaceID: ws.ID})
+	if err != nil {
+		t.Fatalf("CreateSession returned error: %v", err)
+	}
+	guests := []struct{ id, name, login string }{{"g-ana", "Ana Souza", "ana-dev"}, {"g-bob", "Bob", "bob"}, {"g-cli", "cli", ""}}
+	// O código deixa de valer quando o primeiro convidado conecta; todos pedem entrada antes.
+	for _, guest := range guests {
+		if _, err := app.session.JoinSession(sess.Code, guest.id, session.GuestInfo{Name: guest.name, Login: guest.login}); err != nil {
+			t.Fatalf("JoinSession(%s) returned error: %v", guest.id, err)
+		}
+	}
+	for _, guest := range guests {
+		if err := app.session.ApproveGuest(sess.ID, guest.id); err != nil {
+			t.Fatalf("ApproveGuest(%s) returned error: %v", guest.id, err)
+		}
+		if err := app.session.MarkGuestConnected(sess.ID, guest.id); err != nil {
+			t.Fatalf("MarkGuestConnected(%s) returned error: %v", guest.id, err)
+		}
+	}
+
+	suggestions, err = app.GitPanelSuggestCoAuthors(repoRoot)
+	if err != nil {
+		t.Fatalf("GitPanelSuggestCoAuthors returned error: %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0].Trailer != "Ana Souza <42+ana-dev@users.noreply.github.com>" {
+		t.Fatalf("unexpected suggestions: %+v", suggestions)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoRoot, "a.txt"), []byte("a\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGitForCommitTest(t, repoRoot, "add", "a.txt")
+	result, err := app.GitPanelCommit(repoRoot, "feat: pairing", gp.CommitOptionsDTO{})
+	if err != nil || !result.Committed || len(result.CoAuthors) != 0 {
+		t.Fatalf("expected commit without co-authors before opt-in, got (%+v, %v)", result, err)
+	}
+
+	if err := app.GitPanelSetCoAuthorGuests(ws.ID, true); err != nil {
+		t.Fatalf("GitPanelSetCoAuthorGuests returned error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoRoot, "b.txt"), []byte("b\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGitForCommitTest(t, repoRoot, "add", "b.txt")
+	result, err = app.GitPanelCommit(repoRoot, "feat: more pairing", gp.CommitOptionsDTO{CoAuthors: []string{suggestions[0].Trailer}})
+	if err != nil || !result.Committed || len(result.CoAuthors) != 1 {
+		t.Fatalf("expected one deduplicated co-author, got (%+v, %v)", result, err)
+	}
+	out, err := exec.Command("git", "-C", repoRoot, "log", "-1", "--format=%B").Output()
+	if err != nil {
+		t.Fatalf("git log failed: %v", err)
+	}
+	if strings.Count(string(out), "Co-authored-by:") != 1 || !strings.Contains(string(out), suggestions[0].Trailer) {
+		t.Fatalf("unexpected commit message: %q", out)
+	}
+
+	if err := app.GitPanelSetCoAuthorGuests(9999, true); err == nil {
+		t.Fatalf("expected unknown workspace to fail")
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"orch/internal/auth"
+	gp "orch/internal/gitpanel"
+)
+
+func TestGitPanelConfigWarnsWhenEmailDoesNotMatchGitHubAccount(t *testing.T) {
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+	app := newTestAppWithDatabase(t)
+	app.gitPanel = gp.NewService(nil)
+	app.auth = auth.NewService(nil)
+	app.auth.SetCurrentUserForTesting(&auth.User{ID: "u1", Email: "dev@orch.dev", Username: "orch-dev", Provider: "github"})
+	repoRoot := mustInitPRResolveTestRepo(t, "")
+
+	config, err := app.GitPanelSetConfig(repoRoot, "local", "user.email", "someone@else.dev")
+	if err != nil {
+		t.Fatalf("GitPanelSetConfig returned error: %v", err)
+	}
+	if len(config.Warnings) != 1 || !strings.Contains(config.Warnings[0], "orch-dev") {
+		t.Fatalf("expected identity mismatch warning, got %+v", config.Warnings)
+	}
+
+	for _, email := range []string{"DEV@orch.dev", "12345+orch-dev@users.noreply.github.com", "orch-dev@users.noreply.github.com"} {
+		if config, err = app.GitPanelSetConfig(repoRoot, "local", "user.email", email); err != nil || len(config.Warnings) != 0 {
+			t.Fatalf("expected %s to match the GitHub account, got (%+v, %v)", email, config.Warnings, err)
+		}
+	}
+
+	if _, err := app.GitPanelSetConfig(repoRoot, "local", "core.hooksPath", "/tmp/hooks"); err == nil {
+		t.Fatalf("expected non-whitelisted key to be rejected")
+	}
+	if config, err = app.GitPanelGetConfig(repoRoot, "global"); err != nil || config.Scope != gp.GitConfigScopeGlobal {
+		t.Fatalf("GitPanelGetConfig(global) returned (%+v, %v)", config, err)
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+
+	fw "orch/internal/filewatcher"
+)
+
+func TestGitEventBypassesBatch(t *testing.T) {
+	batched := []string{"git:index", "git:worktree", "git:branch_changed", "gitpanel:status_changed", "gitpanel:history_invalidated", fw.WorkspaceFileChangedEvent}
+	for _, eventName := range batched {
+		if gitEventBypassesBatch(eventName) {
+			t.Fatalf("%s should be coalesced during bursts", eventName)
+		}
+	}
+	immediate := []string{"git:merge", "gitpanel:conflicts_changed", "gitpanel:command_result", "gitpanel:secrets_detected"}
+	for _, eventName := range immediate {
+		if !gitEventBypassesBatch(eventName) {
+			t.Fatalf("%s must bypass batching", eventName)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	gp "orch/internal/gitpanel"
+)
+
+func TestGitPanelGetForkSyncStatusUsesUpstreamAndOrigin(t *testing.T) {
+	repoRoot := mustInitPRResolveTestRepo(t, "git@github.com:ana/orch.git")
+	runGitOrFailForPRResolve(t, repoRoot, "remote", "add", "upstream", "https://github.com/orch-labs/orch.git")
+	out, err := exec.Command("git", "-C", repoRoot, "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("failed to read current branch: %v", err)
+	}
+	branch := strings.TrimSpace(string(out))
+	// Simula refs já buscadas: upstream e fork no commit inicial, branch local um commit à frente.
+	runGitOrFailForPRResolve(t, repoRoot, "update-ref", "refs/remotes/upstream/"+branch, "HEAD")
+	runGitOrFailForPRResolve(t, repoRoot, "update-ref", "refs/remotes/origin/"+branch, "HEAD")
+	runGitOrFailForPRResolve(t, repoRoot, "commit", "--allow-empty", "-m", "local work")
+
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+
+	status, err := app.GitPanelGetForkSyncStatus(repoRoot)
+	if err != nil {
+		t.Fatalf("GitPanelGetForkSyncStatus() error: %v", err)
+	}
+	if status.UpstreamRemote != "upstream" || status.ForkRemote != "origin" || status.DefaultBranch != branch || status.LocalAhead != 1 || status.Strategy != gp.ForkSyncUpToDate {
+		t.Fatalf("unexpected fork status: %+v", status)
+	}
+}
+
+func TestGitPanelSyncForkWithUpstreamRequiresUpstreamRemote(t *testing.T) {
+	repoRoot := mustInitPRResolveTestRepo(t, "git@github.com:ana/orch.git")
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+
+	_, err := app.GitPanelSyncForkWithUpstream(repoRoot)
+	if bindingErr := gp.AsBindingError(err); bindingErr == nil || bindingErr.Code != gp.CodeCommandFailed {
+		t.Fatalf("expected binding error without upstream remote, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	gp "orch/internal/gitpanel"
+	gpr "orch/internal/gitprs"
+)
+
+func TestGitPanelPRGetCodeOwnersSuggestsReviewersForBranch(t *testing.T) {
+	repoRoot := mustInitPRResolveTestRepo(t, "")
+	base := runGitOutputOrFailForPRLocalBranch(t, repoRoot, "branch", "--show-current")
+	writeCodeOwnersTestFile(t, repoRoot, ".github/CODEOWNERS", "* @orch-labs/core\n/internal/ @ana\n/scripts/\n")
+	runGitOrFailForPRResolve(t, repoRoot, "add", "-A")
+	runGitOrFailForPRResolve(t, repoRoot, "commit", "-m", "add codeowners")
+
+	runGitOrFailForPRResolve(t, repoRoot, "checkout", "-b", "feature/owners")
+	writeCodeOwnersTestFile(t, repoRoot, "internal/app.go", "package internal\n")
+	writeCodeOwnersTestFile(t, repoRoot, "scripts/release.sh", "#!/bin/sh\n")
+	runGitOrFailForPRResolve(t, repoRoot, "add", "-A")
+	runGitOrFailForPRResolve(t, repoRoot, "commit", "-m", "feature")
+
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+
+	if _, err := app.GitPanelPRGetCodeOwners(repoRoot, "", ""); gpr.AsBindingError(err) == nil {
+		t.Fatalf("expected validation error without base, got=%v", err)
+	}
+
+	report, err := app.GitPanelPRGetCodeOwners(repoRoot, base, "")
+	if err != nil {
+		t.Fatalf("GitPanelPRGetCodeOwners() error: %v", err)
+	}
+	if report.Source != ".github/CODEOWNERS" || len(report.Files) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if !reflect.DeepEqual(report.SuggestedReviewers, []string{"ana"}) || !reflect.DeepEqual(report.UnownedPaths, []string{"scripts/release.sh"}) {
+		t.Fatalf("unexpected suggestions: reviewers=%v unowned=%v", report.SuggestedReviewers, report.UnownedPaths)
+	}
+
+	// Com origin/HEAD conhecido, o prefill já traz base e reviewers sugeridos.
+	runGitOrFailForPRResolve(t, repoRoot, "update-ref", "refs/remotes/origin/"+base, base)
+	runGitOrFailForPRResolve(t, repoRoot, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/"+base)
+	payload, err := app.GitPanelPRPrefillCreate(repoRoot, "", "")
+	if err != nil {
+		t.Fatalf("GitPanelPRPrefillCreate() error: %v", err)
+	}
+	if payload.Base != base || !reflect.DeepEqual(payload.Reviewers, []string{"ana"}) || len(payload.TeamReviewers) != 0 {
+		t.Fatalf("unexpected prefill payload: %+v", payload)
+	}
+}
+
+func TestNormalizeGitPanelPRReviewers(t *testing.T) {
+	got := normalizeGitPanelPRReviewers([]string{" @ana ", "Ana", "@orch-labs/core", "", "bruno"})
+	if want := []string{"ana", "core", "bruno"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("normalizeGitPanelPRReviewers() = %v, want %v", got, want)
+	}
+}
+
+func writeCodeOwnersTestFile(t *testing.T, repoRoot, relPath, content string) {
+	t.Helper()
+	fullPath := filepath.Join(repoRoot, filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(fullPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", relPath, err)
+	}
+}
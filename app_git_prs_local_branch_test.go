@@ -1,7 +1,9 @@
 package main
 
 import (
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -63,7 +65,7 @@ func TestGitPanelPRCreateLocalBranchCreatesAndChecksOutBranch(t *testing.T) {
 func TestGitPanelPRPushLocalBranchRequiresGitPanelService(t *testing.T) {
 	app := NewApp()
 
-	err := app.GitPanelPRPushLocalBranch("/tmp/repo", "feature/local-head")
+	err := app.GitPanelPRPushLocalBranch("/tmp/repo", "feature/local-head", gp.PushConfirmationDTO{})
 	if err == nil {
 		t.Fatalf("expected service error when git panel service is not initialized")
 	}
@@ -89,7 +91,7 @@ func TestGitPanelPRPushLocalBranchPublishesToOrigin(t *testing.T) {
 	if err := app.GitPanelPRCreateLocalBranch(repoRoot, "feature/publish", ""); err != nil {
 		t.Fatalf("GitPanelPRCreateLocalBranch() error: %v", err)
 	}
-	if err := app.GitPanelPRPushLocalBranch(repoRoot, "feature/publish"); err != nil {
+	if err := app.GitPanelPRPushLocalBranch(repoRoot, "feature/publish", gp.PushConfirmationDTO{}); err != nil {
 		t.Fatalf("GitPanelPRPushLocalBranch() error: %v", err)
 	}
 
@@ -106,6 +108,33 @@ func TestGitPanelPRPushLocalBranchPublishesToOrigin(t *testing.T) {
 	}
 }
 
+func TestGitPanelPRPushLocalBranchRequiresConfirmationForProtectedBranch(t *testing.T) {
+	repoRoot := mustInitPRResolveTestRepo(t, "")
+	bareRemote := t.TempDir()
+	runGitOrFailForPRResolve(t, bareRemote, "init", "--bare")
+	runGitOrFailForPRResolve(t, repoRoot, "remote", "add", "origin", bareRemote)
+	runGitOrFailForPRResolve(t, repoRoot, "config", "orch.protectedBranches", "release/*")
+
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+
+	if err := app.GitPanelPRCreateLocalBranch(repoRoot, "release/1.0", ""); err != nil {
+		t.Fatalf("GitPanelPRCreateLocalBranch() error: %v", err)
+	}
+
+	err := app.GitPanelPRPushLocalBranch(repoRoot, "release/1.0", gp.PushConfirmationDTO{})
+	if bindingErr := gpr.AsBindingError(err); bindingErr == nil || bindingErr.Code != gpr.CodePushNotConfirmed {
+		t.Fatalf("expected %s, got=%v", gpr.CodePushNotConfirmed, err)
+	}
+	if remoteBranch := runGitOutputOrFailForPRLocalBranch(t, repoRoot, "ls-remote", "--heads", "origin"); remoteBranch != "" {
+		t.Fatalf("expected nothing published without confirmation, got=%q", remoteBranch)
+	}
+
+	if err := app.GitPanelPRPushLocalBranch(repoRoot, "release/1.0", gp.PushConfirmationDTO{ProtectedBranch: true}); err != nil {
+		t.Fatalf("GitPanelPRPushLocalBranch(confirmed) error: %v", err)
+	}
+}
+
 func runGitOutputOrFailForPRLocalBranch(t *testing.T, repoRoot string, args ...string) string {
 	t.Helper()
 
@@ -117,3 +146,36 @@ func runGitOutputOrFailForPRLocalBranch(t *testing.T, repoRoot string, args ...s
 	}
 	return strings.TrimSpace(string(output))
 }
+
+func TestGitPanelPRPrefillCreateUsesLocalTemplateAndBranchIssue(t *testing.T) {
+	repoRoot := mustInitPRResolveTestRepo(t, "")
+	templateDir := filepath.Join(repoRoot, ".github")
+	if err := os.MkdirAll(templateDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(templateDir, "pull_request_template.md"), []byte("## Resumo\n"), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	runGitOrFailForPRResolve(t, repoRoot, "checkout", "-b", "fix/42-login")
+
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+
+	templates, err := app.GitPanelPRListTemplates(repoRoot)
+	if err != nil || len(templates) != 1 || templates[0].Path != ".github/pull_request_template.md" {
+		t.Fatalf("unexpected templates: %+v err=%v", templates, err)
+	}
+
+	payload, err := app.GitPanelPRPrefillCreate(repoRoot, "", "")
+	if err != nil {
+		t.Fatalf("GitPanelPRPrefillCreate() error: %v", err)
+	}
+	if payload.Head != "fix/42-login" || payload.Body != "## Resumo\n\nFixes #42" {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+
+	_, err = app.GitPanelPRPrefillCreate(repoRoot, "docs/missing.md", "")
+	if bindingErr := gpr.AsBindingError(err); bindingErr == nil || bindingErr.Code != gpr.CodeValidationFailed {
+		t.Fatalf("expected validation error for unknown template, got=%v", err)
+	}
+}
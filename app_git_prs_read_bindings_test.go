@@ -28,6 +28,9 @@ func TestGitPanelPRReadBindingsRequireGitHubService(t *testing.T) {
 		Title: &updateTitle,
 	})
 	_, checkMergedErr := app.GitPanelPRCheckMerged("/tmp/repo", 1)
+	_, mergeRequirementsErr := app.GitPanelPRGetMergeRequirements("/tmp/repo", 1)
+	_, linkedIssuesErr := app.GitPanelPRGetLinkedIssues("/tmp/repo", 1)
+	_, issueDevelopmentErr := app.GitPanelPRGetIssueDevelopment("/tmp/repo", 1)
 	_, mergeErr := app.GitPanelPRMerge("/tmp/repo", 1, GitPanelPRMergePayloadDTO{MergeMethod: "merge"})
 	_, updateBranchErr := app.GitPanelPRUpdateBranch("/tmp/repo", 1, GitPanelPRUpdateBranchPayloadDTO{})
 	_, getErr := app.GitPanelPRGet("/tmp/repo", 1)
@@ -39,6 +42,9 @@ func TestGitPanelPRReadBindingsRequireGitHubService(t *testing.T) {
 		createLabelErr,
 		updateErr,
 		checkMergedErr,
+		mergeRequirementsErr,
+		linkedIssuesErr,
+		issueDevelopmentErr,
 		mergeErr,
 		updateBranchErr,
 		getErr,
@@ -181,6 +187,21 @@ func TestGitPanelPRReadBindingsValidateInput(t *testing.T) {
 		t.Fatalf("expected validation error for invalid PR number on merge check")
 	}
 
+	_, mergeRequirementsErr := app.GitPanelPRGetMergeRequirements("/tmp/repo", 0)
+	if mergeRequirementsErr == nil {
+		t.Fatalf("expected validation error for invalid PR number on merge requirements")
+	}
+
+	_, linkedIssuesErr := app.GitPanelPRGetLinkedIssues("/tmp/repo", 0)
+	if linkedIssuesErr == nil {
+		t.Fatalf("expected validation error for invalid PR number on linked issues")
+	}
+
+	_, issueDevelopmentErr := app.GitPanelPRGetIssueDevelopment("/tmp/repo", -3)
+	if issueDevelopmentErr == nil {
+		t.Fatalf("expected validation error for invalid issue number on development lookup")
+	}
+
 	invalidMergeMethod := "fast-forward"
 	_, mergeMethodErr := app.GitPanelPRMerge("/tmp/repo", 1, GitPanelPRMergePayloadDTO{
 		MergeMethod: invalidMergeMethod,
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"testing"
+
+	gh "orch/internal/github"
+	gp "orch/internal/gitpanel"
+	gpr "orch/internal/gitprs"
+)
+
+func TestGitPanelPRResolveRepositoryPrefersUpstreamRemote(t *testing.T) {
+	repoRoot := mustInitPRResolveTestRepo(t, "git@github.com:ana/orch.git")
+	runGitOrFailForPRResolve(t, repoRoot, "remote", "add", "upstream", "https://github.com/orch-labs/orch.git")
+	runGitOrFailForPRResolve(t, repoRoot, "remote", "add", "mirror", "https://gitlab.com/orch-labs/orch.git")
+	app := newTestAppWithDatabase(t)
+	app.gitPanel = gp.NewService(nil)
+
+	target, err := app.GitPanelPRResolveRepository(repoRoot, "", "", false)
+	if err != nil {
+		t.Fatalf("GitPanelPRResolveRepository() error: %v", err)
+	}
+	if target.Owner != "orch-labs" || target.BaseRemote != "upstream" || target.Source != "remote" {
+		t.Fatalf("expected upstream as base, got %+v", target)
+	}
+	if target.OriginOwner != "ana" || len(target.Remotes) != 2 {
+		t.Fatalf("expected only GitHub remotes listed, got %+v", target.Remotes)
+	}
+
+	// Manual apontando para o fork local nao exige override.
+	manual, err := app.GitPanelPRResolveRepository(repoRoot, "ana", "orch", false)
+	if err != nil || manual.BaseRemote != "origin" || manual.OverrideConfirmed {
+		t.Fatalf("expected fork remote accepted as manual target, got (%+v, %v)", manual, err)
+	}
+
+	target, err = app.GitPanelPRSetBaseRemote(repoRoot, "origin")
+	if err != nil {
+		t.Fatalf("GitPanelPRSetBaseRemote() error: %v", err)
+	}
+	if target.Owner != "ana" || target.Source != "origin" {
+		t.Fatalf("expected preferred origin as base, got %+v", target)
+	}
+	if _, err := app.GitPanelPRSetBaseRemote(repoRoot, "mirror"); err == nil {
+		t.Fatalf("expected non-GitHub remote to be rejected")
+	}
+
+	if target, err = app.GitPanelPRSetBaseRemote(repoRoot, ""); err != nil || target.BaseRemote != "upstream" {
+		t.Fatalf("expected preference cleared, got (%+v, %v)", target, err)
+	}
+}
+
+func TestResolveGitPanelPRCreateHeadQualifiesForkBranch(t *testing.T) {
+	repoRoot := mustInitPRResolveTestRepo(t, "git@github.com:ana/orch.git")
+	runGitOrFailForPRResolve(t, repoRoot, "remote", "add", "upstream", "https://github.com/orch-labs/orch.git")
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+
+	target, err := app.GitPanelPRResolveRepository(repoRoot, "", "", false)
+	if err != nil {
+		t.Fatalf("GitPanelPRResolveRepository() error: %v", err)
+	}
+
+	if head, err := resolveGitPanelPRCreateHead(target, "fix/login", ""); err != nil || head != "ana:fix/login" {
+		t.Fatalf("expected fork-qualified head, got (%q, %v)", head, err)
+	}
+	if head, err := resolveGitPanelPRCreateHead(target, "fix/login", "upstream"); err != nil || head != "fix/login" {
+		t.Fatalf("expected plain head on base remote, got (%q, %v)", head, err)
+	}
+	if head, err := resolveGitPanelPRCreateHead(target, "bob:fix/login", ""); err != nil || head != "bob:fix/login" {
+		t.Fatalf("expected qualified head untouched, got (%q, %v)", head, err)
+	}
+
+	runGitOrFailForPRResolve(t, repoRoot, "config", "branch.fix/login.pushRemote", "upstream")
+	if head, err := resolveGitPanelPRCreateHead(target, "fix/login", ""); err != nil || head != "fix/login" {
+		t.Fatalf("expected branch pushRemote honored, got (%q, %v)", head, err)
+	}
+
+	_, err = resolveGitPanelPRCreateHead(target, "fix/login", "missing")
+	if bindingErr := gpr.AsBindingError(err); bindingErr == nil || bindingErr.Code != gpr.CodeValidationFailed {
+		t.Fatalf("expected validation error for unknown head remote, got %v", err)
+	}
+}
+
+func TestClassifyGitPanelPRRemotesUsesParentLookup(t *testing.T) {
+	remotes := []GitPanelPRRemoteDTO{
+		{Name: "origin", Owner: "ana", Repo: "orch", Role: "unknown"},
+		{Name: "main-repo", Owner: "orch-labs", Repo: "orch", Role: "unknown"},
+	}
+	classifyGitPanelPRRemotes(remotes, func(owner, repo string) *gh.RepositoryForkInfo {
+		if owner == "ana" {
+			return &gh.RepositoryForkInfo{Owner: owner, Repo: repo, Fork: true, ParentOwner: "orch-labs", ParentRepo: "orch"}
+		}
+		return nil // falha da API para o upstream
+	})
+	if remotes[0].Role != "fork" || remotes[0].ParentOwner != "orch-labs" || remotes[1].Role != "upstream" {
+		t.Fatalf("unexpected classification: %+v", remotes)
+	}
+
+	base, ok := selectGitPanelPRBaseRemote(remotes)
+	if !ok || base.Name != "main-repo" {
+		t.Fatalf("expected parent of origin as base, got (%+v, %v)", base, ok)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"orch/internal/auth"
+	gp "orch/internal/gitpanel"
+	"orch/internal/gitshare"
+	"orch/internal/session"
+)
+
+func TestSessionShareGitPanelServesApprovedGuests(t *testing.T) {
+	tempRoot := t.TempDir()
+	t.Setenv("HOME", tempRoot)
+	t.Setenv("ORCH_DB_PATH", fmt.Sprintf("%s/orch-gitshare.db", tempRoot))
+
+	host, _ := newSessionHostAppWithDB(t)
+	host.gitPanel = gp.NewService(nil)
+	gatewayAddr, gatewayURL := reserveGatewayAddress(t)
+	gateway := session.NewGatewayServer(host.session, gatewayAddr)
+	gateway.Handle(gitshare.RoutePrefix, gitshare.NewHandler(host.gitShares, gitShareBackend{host}))
+	if err := gateway.Start(); err != nil {
+		t.Fatalf("failed to start gateway: %v", err)
+	}
+	t.Cleanup(func() { _ = gateway.Stop(t.Context()) })
+
+	repoRoot := mustInitPRResolveTestRepo(t, "git@github.com:orch-labs/orch.git")
+	for name, content := range map[string]string{"app.go": "package main\n", ".env": "TOKEN=secret\n"} {
+		if err := os.WriteFile(filepath.Join(repoRoot, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	if _, err := host.SessionShareGitPanel(repoRoot, nil); err == nil {
+		t.Fatalf("expected share without active session to fail")
+	}
+	created, err := host.SessionCreate(2, string(session.ModeLiveShare), true, 1)
+	if err != nil {
+		t.Fatalf("SessionCreate() error: %v", err)
+	}
+
+	newGuest := func(id string) *App {
+		guest := NewApp()
+		guest.auth = auth.NewService(nil)
+		guest.auth.SetCurrentUserForTesting(&auth.User{ID: id, Name: id, Provider: "github"})
+		guest.sessionGatewayURL = gatewayURL
+		if _, err := guest.SessionJoin(created.Code, id, ""); err != nil {
+			t.Fatalf("SessionJoin(%s) error: %v", id, err)
+		}
+		return guest
+	}
+	approved, pending := newGuest("guest-approved"), newGuest("guest-pending")
+	if err := host.SessionApproveGuest(created.ID, "guest-approved"); err != nil {
+		t.Fatalf("SessionApproveGuest() error: %v", err)
+	}
+
+	if _, err := approved.SessionGitPanelGetStatus(created.ID); err == nil || !strings.Contains(err.Error(), "not shared") {
+		t.Fatalf("expected not shared error, got %v", err)
+	}
+	share, err := host.SessionShareGitPanel(repoRoot, []string{".env"})
+	if err != nil {
+		t.Fatalf("SessionShareGitPanel() error: %v", err)
+	}
+	if share.RepoName != filepath.Base(repoRoot) || len(share.RedactPatterns) != 1 {
+		t.Fatalf("unexpected share: %+v", share)
+	}
+
+	if _, err := pending.SessionGitPanelGetStatus(created.ID); err == nil || !strings.Contains(err.Error(), "not approved") {
+		t.Fatalf("expected pending guest to be refused, got %v", err)
+	}
+	info, err := approved.SessionGitPanelGetShare(created.ID)
+	if err != nil || info.RepoRoot != "" || info.RepoName != share.RepoName {
+		t.Fatalf("unexpected guest share info (%+v, %v)", info, err)
+	}
+	status, err := approved.SessionGitPanelGetStatus(created.ID)
+	if err != nil {
+		t.Fatalf("SessionGitPanelGetStatus() error: %v", err)
+	}
+	if len(status.Unstaged) != 1 || status.Unstaged[0].Path != "app.go" {
+		t.Fatalf("expected only app.go visible to the guest, got %+v", status.Unstaged)
+	}
+	page, err := approved.SessionGitPanelGetHistory(created.ID, "", 10, "")
+	if err != nil || len(page.Items) == 0 {
+		t.Fatalf("unexpected guest history (%+v, %v)", page, err)
+	}
+	if _, err := approved.SessionGitPanelGetDiff(created.ID, ".env", "unified", 3); err == nil {
+		t.Fatalf("expected redacted diff to be refused")
+	}
+
+	if err := host.SessionStopGitPanelShare(); err != nil {
+		t.Fatalf("SessionStopGitPanelShare() error: %v", err)
+	}
+	if current, err := host.SessionGetGitPanelShare(); err != nil || current != nil {
+		t.Fatalf("expected no share after stop, got (%+v, %v)", current, err)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"orch/internal/auth"
+	gpr "orch/internal/gitprs"
+	gh "orch/internal/github"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestGitHubAccountRoutingByRepoBindingAndOwner(t *testing.T) {
+	keyring.MockInit()
+	app := newTestAppWithDatabase(t)
+	app.auth = auth.NewService(app.db)
+	for login, token := range map[string]string{"personal": "personal-token", "work": "work-token"} {
+		if _, err := app.auth.AddGitHubAccount(login, "", "", token); err != nil {
+			t.Fatalf("AddGitHubAccount(%s) error: %v", login, err)
+		}
+	}
+	repoRoot := mustInitPRResolveTestRepo(t, "git@github.com:acme/api.git")
+
+	if _, err := app.SetRepoGitHubAccount(repoRoot, "ghost"); err == nil {
+		t.Fatalf("expected unknown account to be rejected")
+	}
+	binding, err := app.SetRepoGitHubAccount(repoRoot, "Work")
+	if err != nil {
+		t.Fatalf("SetRepoGitHubAccount returned error: %v", err)
+	}
+	if binding.Owner != "acme" || binding.AccountID != "work" || binding.Source != "repo" || binding.Login != "work" {
+		t.Fatalf("unexpected binding: %+v", binding)
+	}
+	if token, err := app.gitHubTokenForOwner("ACME"); err != nil || token != "work-token" {
+		t.Fatalf("expected acme routed to work account, got %q err=%v", token, err)
+	}
+	if token, err := app.gitHubTokenForOwner("personal"); err != nil || token != "personal-token" {
+		t.Fatalf("expected owner matching account login, got %q err=%v", token, err)
+	}
+
+	if _, err := app.SwitchGitHubAccount("personal"); err != nil {
+		t.Fatalf("SwitchGitHubAccount returned error: %v", err)
+	}
+	binding, err = app.SetRepoGitHubAccount(repoRoot, "")
+	if err != nil {
+		t.Fatalf("clearing binding returned error: %v", err)
+	}
+	if binding.Source != "active" || binding.AccountID != "" || binding.Login != "personal" {
+		t.Fatalf("expected repo to fall back to active account, got %+v", binding)
+	}
+	if token, _ := app.gitHubTokenForOwner("acme"); token != "personal-token" {
+		t.Fatalf("expected active account token after clearing binding, got %q", token)
+	}
+}
+
+func TestAuthReauthorizeForOrgUsesSSOChallengeURL(t *testing.T) {
+	app := &App{github: gh.NewService(func() (string, error) { return "token", nil })}
+
+	if _, err := app.AuthReauthorizeForOrg("acme/evil"); err == nil {
+		t.Fatalf("expected invalid org to be rejected")
+	}
+	url, err := app.AuthReauthorizeForOrg("acme")
+	if err != nil || url != "https://github.com/orgs/acme/sso" {
+		t.Fatalf("expected default sso url, got %q err=%v", url, err)
+	}
+
+	bindingErr := gpr.AsBindingError(app.normalizeGitPanelPRError(&gh.GitHubError{
+		StatusCode: 403,
+		Type:       "sso",
+		SSOOrg:     "acme",
+		SSOURL:     "https://github.com/orgs/acme/sso?authorization_request=abc",
+	}))
+	if bindingErr == nil || bindingErr.Code != gpr.CodeSSORequired || !strings.Contains(bindingErr.Details, "authorization_request=abc") {
+		t.Fatalf("unexpected normalized sso error: %+v", bindingErr)
+	}
+}
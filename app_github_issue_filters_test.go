@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGHSaveIssueFilterNormalizesAndPersistsPerRepo(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+
+	saved, err := app.GHSaveIssueFilter(GitHubIssueFilterDTO{
+		Owner:    "orch-labs",
+		Repo:     "orch.git",
+		Name:     " Bugs sem dono ",
+		Labels:   []string{"bug", " bug", "", "needs triage"},
+		Assignee: "none",
+		Query:    " crash ",
+	})
+	if err != nil {
+		t.Fatalf("GHSaveIssueFilter returned error: %v", err)
+	}
+	if saved.ID == 0 || saved.Repo != "orch" || saved.Name != "Bugs sem dono" || saved.State != "open" || saved.Query != "crash" {
+		t.Fatalf("unexpected saved filter: %+v", saved)
+	}
+	if !reflect.DeepEqual(saved.Labels, []string{"bug", "needs triage"}) {
+		t.Fatalf("unexpected labels: %q", saved.Labels)
+	}
+
+	if _, err := app.GHSaveIssueFilter(GitHubIssueFilterDTO{Owner: "orch-labs", Repo: "docs", Name: "Minhas", Assignee: "@ana"}); err != nil {
+		t.Fatalf("GHSaveIssueFilter returned error: %v", err)
+	}
+	if _, err := app.GHSaveIssueFilter(GitHubIssueFilterDTO{Owner: "orch-labs", Repo: "orch", Name: "x", State: "merged"}); err == nil {
+		t.Fatalf("expected invalid state to be rejected")
+	}
+	if _, err := app.GHSaveIssueFilter(GitHubIssueFilterDTO{Owner: "orch-labs", Repo: "orch", Name: "x", Query: "a\nb"}); err == nil {
+		t.Fatalf("expected multi-line query to be rejected")
+	}
+
+	saved.State = "closed"
+	if _, err := app.GHSaveIssueFilter(saved); err != nil {
+		t.Fatalf("update returned error: %v", err)
+	}
+	filters, err := app.GHListIssueFilters("orch-labs", "orch")
+	if err != nil || len(filters) != 1 || filters[0].State != "closed" {
+		t.Fatalf("expected only the updated orch filter, got %+v err=%v", filters, err)
+	}
+	if other, _ := app.GHListIssueFilters("orch-labs", "docs"); len(other) != 1 || other[0].Assignee != "ana" {
+		t.Fatalf("unexpected docs filters: %+v", other)
+	}
+
+	if err := app.GHDeleteIssueFilter(saved.ID); err != nil {
+		t.Fatalf("GHDeleteIssueFilter returned error: %v", err)
+	}
+	if filters, _ := app.GHListIssueFilters("orch-labs", "orch"); len(filters) != 0 {
+		t.Fatalf("expected filter to be deleted, got %+v", filters)
+	}
+	if counts, err := app.GHGetIssueFilterCounts("orch-labs", "orch"); err != nil || len(counts) != 0 {
+		t.Fatalf("expected no counts without GitHub service, got %+v err=%v", counts, err)
+	}
+}
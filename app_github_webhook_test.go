@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gh "orch/internal/github"
+)
+
+func TestConfigureGitHubWebhookBridgePersistsAndRestores(t *testing.T) {
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer relay.Close()
+
+	app := newTestAppWithDatabase(t)
+	app.ghWebhook = gh.NewWebhookBridge(nil, nil)
+	defer app.ghWebhook.Stop()
+
+	if _, err := app.ConfigureGitHubWebhookBridge("http://relay.example.com/events", ""); err == nil {
+		t.Fatalf("expected plain http relay outside localhost to be rejected")
+	}
+
+	status, err := app.ConfigureGitHubWebhookBridge(relay.URL, "s3cret")
+	if err != nil {
+		t.Fatalf("ConfigureGitHubWebhookBridge returned error: %v", err)
+	}
+	if !status.Enabled || !status.HasSecret || status.RelayURL != relay.URL {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	cfg, err := app.db.GetConfig()
+	if err != nil {
+		t.Fatalf("GetConfig returned error: %v", err)
+	}
+	if cfg.GitHubWebhookRelay != relay.URL || cfg.GitHubWebhookSecret != "s3cret" {
+		t.Fatalf("bridge config not persisted: relay=%q", cfg.GitHubWebhookRelay)
+	}
+
+	restored := gh.NewWebhookBridge(nil, nil)
+	defer restored.Stop()
+	app.ghWebhook = restored
+	app.restoreGitHubWebhookBridge()
+	if got := restored.GetStatus(); !got.Enabled || got.RelayURL != relay.URL {
+		t.Fatalf("expected bridge restored from config, got %+v", got)
+	}
+
+	if status, err := app.ConfigureGitHubWebhookBridge("", ""); err != nil || status.Enabled {
+		t.Fatalf("expected bridge disabled, got %+v err=%v", status, err)
+	}
+}
@@ -1,9 +1,15 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
 	"testing"
+	"time"
 
 	gp "orch/internal/gitpanel"
+	"orch/internal/jobs"
 )
 
 func TestGitPanelBindingsRequireService(t *testing.T) {
@@ -74,3 +80,199 @@ func TestGitPanelPickRepositoryDirectoryRequiresRuntimeContext(t *testing.T) {
 		t.Fatalf("unexpected error code: got=%s want=%s", bindingErr.Code, gp.CodeServiceUnavailable)
 	}
 }
+
+func TestGitPanelExternalToolSettingsPreferRepoOverride(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	repo := newFakeGitRepo(t)
+	other := newFakeGitRepo(t)
+
+	if err := app.GitPanelSetDefaultExternalTools("KDiff3", "meld"); err != nil {
+		t.Fatalf("GitPanelSetDefaultExternalTools returned error: %v", err)
+	}
+	if err := app.GitPanelSetRepoExternalTools(repo, "vscode", ""); err != nil {
+		t.Fatalf("GitPanelSetRepoExternalTools returned error: %v", err)
+	}
+	if err := app.GitPanelSetRepoExternalTools(repo, "notepad", ""); err == nil {
+		t.Fatalf("expected unknown tool to be rejected")
+	}
+
+	settings, err := app.GitPanelGetExternalToolSettings(repo)
+	if err != nil {
+		t.Fatalf("GitPanelGetExternalToolSettings returned error: %v", err)
+	}
+	want := GitExternalToolSettings{
+		DefaultMergeTool: "kdiff3",
+		DefaultDiffTool:  "meld",
+		RepoMergeTool:    "vscode",
+		MergeTool:        "vscode",
+		DiffTool:         "meld",
+	}
+	if settings != want {
+		t.Fatalf("unexpected settings: got=%+v want=%+v", settings, want)
+	}
+	if settings, _ := app.GitPanelGetExternalToolSettings(other); settings.MergeTool != "kdiff3" || settings.RepoMergeTool != "" {
+		t.Fatalf("expected other repos to inherit the default, got %+v", settings)
+	}
+
+	if err := app.GitPanelSetRepoExternalTools(repo, "", ""); err != nil {
+		t.Fatalf("clearing the override returned error: %v", err)
+	}
+	if settings, _ := app.GitPanelGetExternalToolSettings(repo); settings.MergeTool != "kdiff3" {
+		t.Fatalf("expected cleared override to inherit the default, got %+v", settings)
+	}
+}
+
+func TestGitPanelGetContributorStatsUsesCachedGitHubAuthors(t *testing.T) {
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+	repoRoot := mustInitPRResolveTestRepo(t, "")
+	runGitOrFailForPRResolve(t, repoRoot, "commit", "--allow-empty", "--author", "Bia <bia@orch.local>", "-m", "bia")
+	headOut, err := exec.Command("git", "-C", repoRoot, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("failed to resolve HEAD: %v", err)
+	}
+	biaHash := strings.TrimSpace(string(headOut))
+
+	preflight, err := app.GitPanelPreflight(repoRoot)
+	if err != nil {
+		t.Fatalf("GitPanelPreflight returned error: %v", err)
+	}
+	app.gitPanelAuthorCache[buildGitPanelCommitAuthorCacheKey(preflight.RepoRoot, biaHash)] = gitPanelCommitAuthorCacheEntry{
+		login:     "bia-dev",
+		avatarURL: "https://avatars.example/bia",
+		found:     true,
+		expiresAt: time.Now().Add(time.Hour),
+	}
+
+	page, err := app.GitPanelGetContributorStats(repoRoot, 30, "", 10)
+	if err != nil {
+		t.Fatalf("GitPanelGetContributorStats returned error: %v", err)
+	}
+	if page.TotalContributors != 2 || len(page.Items) != 2 {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+	for _, item := range page.Items {
+		switch item.Email {
+		case "bia@orch.local":
+			if item.LastCommitHash != biaHash || item.GitHubLogin != "bia-dev" || item.GitHubAvatarURL != "https://avatars.example/bia" {
+				t.Fatalf("expected cached GitHub identity for bia, got %+v", item)
+			}
+		case "tests@orch.local":
+			if item.GitHubLogin != "" {
+				t.Fatalf("expected no GitHub identity without auth/cache, got %+v", item)
+			}
+		default:
+			t.Fatalf("unexpected contributor: %+v", item)
+		}
+	}
+}
+
+func TestGitPanelWarmCacheRunsInBackgroundWithRepoTTLs(t *testing.T) {
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+	repoRoot := mustInitPRResolveTestRepo(t, "")
+
+	ttls, err := app.GitPanelSetCacheTTLs(repoRoot, gp.CacheTTLsDTO{StatusMs: 45000})
+	if err != nil {
+		t.Fatalf("GitPanelSetCacheTTLs returned error: %v", err)
+	}
+	if ttls.StatusMs != 45000 || ttls.HistoryMs != int(gp.DefaultHistoryCacheTTL/time.Millisecond) {
+		t.Fatalf("unexpected effective TTLs: %+v", ttls)
+	}
+	if err := app.GitPanelResetCacheMetrics(); err != nil {
+		t.Fatalf("GitPanelResetCacheMetrics returned error: %v", err)
+	}
+
+	if err := app.GitPanelWarmCache(repoRoot); err != nil {
+		t.Fatalf("GitPanelWarmCache returned error: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		metrics, err := app.GitPanelGetCacheMetrics()
+		if err != nil {
+			t.Fatalf("GitPanelGetCacheMetrics returned error: %v", err)
+		}
+		if metrics.Warmups == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("warm-up did not finish: %+v", metrics)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := app.GitPanelWarmCache(t.TempDir()); err == nil {
+		t.Fatalf("expected non-git directory to fail")
+	}
+}
+
+func TestGitPanelFetchAsyncRunsAsJob(t *testing.T) {
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+	t.Cleanup(app.jobs.Shutdown)
+	repoRoot := mustInitPRResolveTestRepo(t, "")
+	remoteRoot := t.TempDir()
+	runGitOrFailForPRResolve(t, remoteRoot, "init", "--bare")
+	runGitOrFailForPRResolve(t, repoRoot, "remote", "add", "backup", remoteRoot)
+
+	job, err := app.GitPanelFetchAsync(repoRoot, gp.FetchOptionsDTO{Remote: "backup", Prune: true})
+	if err != nil {
+		t.Fatalf("GitPanelFetchAsync returned error: %v", err)
+	}
+	if job.Kind != "git.fetch" || job.Module != "gitpanel" || job.Status != jobs.StatusRunning {
+		t.Fatalf("unexpected submitted job: %+v", job)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	done, err := app.jobs.Wait(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if done.Status != jobs.StatusSucceeded {
+		t.Fatalf("expected fetch job to succeed, got %+v", done)
+	}
+	if result, ok := done.Result.(gp.FetchResultDTO); !ok || result.Remote != "backup" {
+		t.Fatalf("unexpected job result: %#v", done.Result)
+	}
+
+	if listed := app.JobsList(); len(listed) != 1 || listed[0].ID != job.ID {
+		t.Fatalf("unexpected jobs list: %+v", listed)
+	}
+	if err := app.JobCancel("missing"); !errors.Is(err, jobs.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown job, got %v", err)
+	}
+}
+
+func TestCancelOperationAbortsTokensAndJobs(t *testing.T) {
+	app := NewApp()
+	t.Cleanup(app.jobs.Shutdown)
+
+	ctx, done := app.operations.Begin(context.Background(), "pr-diff-42")
+	if !app.CancelOperation("pr-diff-42") || ctx.Err() == nil {
+		t.Fatalf("expected token operation to be canceled")
+	}
+	done()
+	if app.CancelOperation("pr-diff-42") {
+		t.Fatalf("expected finished token to report false")
+	}
+
+	job, err := app.jobs.Submit(jobs.Spec{Kind: "git.fetch"}, func(ctx context.Context, _ *jobs.Reporter) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	if !app.CancelOperation(job.ID) {
+		t.Fatalf("expected running job to be canceled by id")
+	}
+	waitCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if finished, err := app.jobs.Wait(waitCtx, job.ID); err != nil || finished.Status != jobs.StatusCanceled {
+		t.Fatalf("expected canceled job, got %+v err=%v", finished, err)
+	}
+	if app.CancelOperation(job.ID) {
+		t.Fatalf("expected finished job to report false")
+	}
+}
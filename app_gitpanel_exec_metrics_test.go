@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	gp "orch/internal/gitpanel"
+)
+
+func TestGitPanelExecMetricsRequireService(t *testing.T) {
+	app := NewApp()
+	_, err := app.GitPanelGetExecMetrics()
+	if bindingErr := gp.AsBindingError(err); bindingErr == nil || bindingErr.Code != gp.CodeServiceUnavailable {
+		t.Fatalf("expected %s, got %v", gp.CodeServiceUnavailable, err)
+	}
+}
+
+func TestConfigFileSetsGitPanelExecLimits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := "[gitpanel]\nmax_concurrent_commands = 3\nmax_queued_writes = 5\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("ORCH_CONFIG_FILE", path)
+
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+	app.applyConfigFile(app.effectiveConfig())
+
+	metrics, err := app.GitPanelGetExecMetrics()
+	if err != nil {
+		t.Fatalf("GitPanelGetExecMetrics() error: %v", err)
+	}
+	if metrics.Workers != 3 || metrics.WriteQueueDepth != 5 || metrics.Repos == nil {
+		t.Fatalf("expected limits from config file, got %+v", metrics)
+	}
+	if err := app.GitPanelResetExecMetrics(); err != nil {
+		t.Fatalf("GitPanelResetExecMetrics() error: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	gp "orch/internal/gitpanel"
+	"orch/internal/httpclient"
+)
+
+func TestGitPanelReadBackendFromConfigAndInspector(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available in test environment")
+	}
+	repoRoot := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "tests@orch.local"},
+		{"config", "user.name", "ORCH Tests"},
+		{"commit", "-q", "--allow-empty", "-m", "initial"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", repoRoot}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v %s", args, err, out)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("[gitpanel]\nread_backend = \"gogit\"\n"), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("ORCH_CONFIG_FILE", path)
+
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+	app.gitPanel.SetReadObserver(recordGitPanelRead)
+	app.applyConfigFile(app.effectiveConfig())
+
+	backend, err := app.GitPanelGetReadBackend(repoRoot)
+	if err != nil || backend.Backend != gp.ReadBackendGoGit || backend.Override {
+		t.Fatalf("expected go-git default from config file, got %+v (%v)", backend, err)
+	}
+
+	httpclient.ClearTraces()
+	app.SetAPIInspectorEnabled(true)
+	t.Cleanup(func() {
+		app.SetAPIInspectorEnabled(false)
+		httpclient.ClearTraces()
+	})
+	if _, err := app.GitPanelGetStatus(repoRoot); err != nil {
+		t.Fatalf("GitPanelGetStatus() error: %v", err)
+	}
+	traces := httpclient.RecentTraces(0)
+	if len(traces) != 1 || traces[0].Service != httpclient.ServiceGit || traces[0].Host != gp.ReadBackendGoGit || traces[0].Method != "STATUS" {
+		t.Fatalf("expected the status read in the API inspector, got %#v", traces)
+	}
+
+	if backend, err := app.GitPanelSetReadBackend(repoRoot, "cli"); err != nil || backend.Backend != gp.ReadBackendCLI || !backend.Override {
+		t.Fatalf("GitPanelSetReadBackend() = %+v, %v", backend, err)
+	}
+	if _, err := app.GitPanelSetReadBackend(repoRoot, "libgit2"); err == nil {
+		t.Fatalf("expected unknown backend to be rejected")
+	}
+}
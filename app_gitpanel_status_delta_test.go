@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	gp "orch/internal/gitpanel"
+)
+
+func TestGitPanelStatusChangedPayloadCarriesDelta(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available in test environment")
+	}
+	repoRoot := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "tests@orch.local"},
+		{"config", "user.name", "ORCH Tests"},
+		{"commit", "-q", "--allow-empty", "-m", "initial"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", repoRoot}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v %s", args, err, out)
+		}
+	}
+
+	app := NewApp()
+	app.gitPanel = gp.NewService(nil)
+	base := map[string]string{"repoPath": repoRoot, "reason": "watcher"}
+
+	first, ok := app.gitPanelStatusChangedPayload(repoRoot, base).(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected payload with delta")
+	}
+	if first["reason"] != "watcher" || !first["delta"].(gp.StatusDeltaDTO).Full {
+		t.Fatalf("unexpected first payload: %+v", first)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoRoot, "new.txt"), []byte("x\n"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	app.gitPanel.InvalidateRepoCache(repoRoot)
+	next := app.gitPanelStatusChangedPayload(repoRoot, base).(map[string]interface{})
+	delta := next["delta"].(gp.StatusDeltaDTO)
+	if delta.Full || delta.BaseSeq != first["delta"].(gp.StatusDeltaDTO).Seq || len(delta.Unstaged.Upserted) != 1 {
+		t.Fatalf("unexpected delta: %+v", delta)
+	}
+
+	if got, ok := app.gitPanelStatusChangedPayload(t.TempDir(), base).(map[string]string); !ok || got["reason"] != "watcher" {
+		t.Fatalf("expected plain payload outside a repository, got %+v", got)
+	}
+}
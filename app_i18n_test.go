@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	ga "orch/internal/gitactivity"
+	gp "orch/internal/gitpanel"
+	"orch/internal/i18n"
+)
+
+func TestFormatGitActivityMessageReturnsCatalogReference(t *testing.T) {
+	message, ref := formatGitActivityMessage(i18n.LocaleENUS, "ana", ga.EventTypeCommitCreated, "", "", "refs/heads/main")
+	if message != "ana updated ref refs/heads/main in repository repository" {
+		t.Fatalf("unexpected message %q", message)
+	}
+	if ref.ID != i18n.GitActivityRefUpdated || ref.Params["ref"] != "refs/heads/main" {
+		t.Fatalf("unexpected reference %+v", ref)
+	}
+	if again := i18n.Localize(i18n.LocalePTBR, ref.ID, ref.Params); !strings.HasPrefix(again, "ana atualizou a ref") {
+		t.Fatalf("re-render in pt-BR = %q", again)
+	}
+}
+
+func TestMessageCatalogFollowsSelectedLanguage(t *testing.T) {
+	app := NewApp()
+	if got := app.GetMessageCatalog("")[i18n.GitActivityMerge]; !strings.Contains(got, "executou merge") {
+		t.Fatalf("default catalog should be pt-BR, got %q", got)
+	}
+	app.translator.SetLocale(normalizeLanguage("en"))
+	if got := app.GetMessageCatalog("")[i18n.GitActivityMerge]; got != "{actor} ran a merge in repository {repo}" {
+		t.Fatalf("expected en-US catalog, got %q", got)
+	}
+
+	err := gp.NewBindingError(gp.CodeBusy, "Fila cheia.", "")
+	if err.MessageID != "error.busy" || !strings.Contains(err.Error(), `"messageId":"error.busy"`) {
+		t.Fatalf("binding error should carry its catalog id: %s", err.Error())
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"orch/internal/auth"
+	gp "orch/internal/gitpanel"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestIssueTrackerSettingsAndPRPrefill(t *testing.T) {
+	keyring.MockInit()
+	app := newTestAppWithDatabase(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer jira-pat" || r.URL.Path != "/rest/api/2/issue/PROJ-7" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"key":"PROJ-7","fields":{"summary":"Login timeout","status":{"name":"In Review"}}}`))
+	}))
+	defer server.Close()
+
+	if err := app.SetIssueTrackerSettings(IssueTrackerSettingsDTO{Provider: "jira", BaseURL: "not a url", Token: "x"}); err == nil {
+		t.Fatalf("expected invalid Jira URL to be rejected")
+	}
+	if err := app.SetIssueTrackerSettings(IssueTrackerSettingsDTO{Provider: "jira", BaseURL: server.URL + "/", Projects: "proj", Token: "jira-pat"}); err != nil {
+		t.Fatalf("SetIssueTrackerSettings returned error: %v", err)
+	}
+	settings := app.GetIssueTrackerSettings()
+	if settings.Provider != "jira" || settings.BaseURL != server.URL || settings.Projects != "PROJ" || !settings.HasToken || settings.Token != "" {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+	if token, _ := auth.GetIntegrationToken(issueTrackerTokenName); token != "jira-pat" {
+		t.Fatalf("expected token in keychain, got %q", token)
+	}
+
+	app.gitPanel = gp.NewService(nil)
+	repoRoot := mustInitPRResolveTestRepo(t, "git@github.com:acme/api.git")
+	payload, err := app.GitPanelPRPrefillCreate(repoRoot, "", "feature/proj-7-login")
+	if err != nil {
+		t.Fatalf("GitPanelPRPrefillCreate returned error: %v", err)
+	}
+	if payload.Title != "PROJ-7: Login timeout" || payload.Body != "- [PROJ-7]("+server.URL+"/browse/PROJ-7) Login timeout" {
+		t.Fatalf("unexpected prefill: %+v", payload)
+	}
+
+	// Já em cache: o histórico recebe título/status sem novo request.
+	page := gp.HistoryPageDTO{Items: []gp.HistoryItemDTO{{Subject: "PROJ-7 fix timeout"}, {Subject: "chore: bump"}}}
+	app.attachIssueLinksToHistory(&page)
+	if len(page.Items[0].Issues) != 1 || page.Items[0].Issues[0].Status != "In Review" || len(page.Items[1].Issues) != 0 {
+		t.Fatalf("unexpected history issues: %+v", page.Items)
+	}
+
+	if err := app.SetIssueTrackerSettings(IssueTrackerSettingsDTO{}); err != nil {
+		t.Fatalf("disabling tracker returned error: %v", err)
+	}
+	if app.GetIssueTrackerSettings().HasToken || app.issueTracker.Enabled() {
+		t.Fatalf("expected tracker disabled and token removed")
+	}
+}
@@ -0,0 +1,55 @@
+//go:build !windows
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLifecycleHookBindingsValidateAndRun(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	t.Cleanup(app.lifecycleHooks.Close)
+
+	if _, err := app.SaveLifecycleHook(LifecycleHookDTO{Name: "x", Event: "app.crashed", Script: "true"}); err == nil {
+		t.Fatalf("expected unknown event to be rejected")
+	}
+	if _, err := app.SaveLifecycleHook(LifecycleHookDTO{Name: "x", Event: "app.startup", Script: "true", TimeoutSeconds: 3600}); err == nil {
+		t.Fatalf("expected timeout above the limit to be rejected")
+	}
+
+	saved, err := app.SaveLifecycleHook(LifecycleHookDTO{
+		Name:   "announce",
+		Event:  "terminal.created",
+		Script: `echo "$ORCH_EVENT test=$ORCH_TEST"`,
+		Cwd:    t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("SaveLifecycleHook returned error: %v", err)
+	}
+	if saved.TimeoutSeconds != 30 || saved.Enabled {
+		t.Fatalf("expected default timeout and disabled hook, got %+v", saved)
+	}
+	if loaded, _ := app.loadLifecycleHooks("terminal.created"); len(loaded) != 0 {
+		t.Fatalf("disabled hooks must not be loaded for events, got %+v", loaded)
+	}
+
+	run, err := app.TestLifecycleHook(saved.ID)
+	if err != nil {
+		t.Fatalf("TestLifecycleHook returned error: %v", err)
+	}
+	if run.ExitCode != 0 || strings.TrimSpace(run.Output) != "terminal.created test=true" {
+		t.Fatalf("unexpected test run: %+v", run)
+	}
+	if runs := app.GetLifecycleHookRuns(); len(runs) != 1 || runs[0].HookID != saved.ID {
+		t.Fatalf("expected test run in history, got %+v", runs)
+	}
+
+	saved.Enabled = true
+	if _, err := app.SaveLifecycleHook(saved); err != nil {
+		t.Fatalf("SaveLifecycleHook returned error: %v", err)
+	}
+	if loaded, _ := app.loadLifecycleHooks("terminal.created"); len(loaded) != 1 {
+		t.Fatalf("expected enabled hook to be loaded, got %+v", loaded)
+	}
+}
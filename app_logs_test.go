@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"orch/internal/logging"
+)
+
+func TestSetLogLevelControlsRecentLogs(t *testing.T) {
+	app := NewApp()
+	previous := logging.Default().Level()
+	t.Cleanup(func() { logging.Default().SetLevel(previous) })
+
+	if err := app.SetLogLevel("verbose"); err == nil {
+		t.Fatalf("expected unknown level to be rejected")
+	}
+	if err := app.SetLogLevel("debug"); err != nil {
+		t.Fatalf("SetLogLevel returned error: %v", err)
+	}
+	if got := app.GetLogLevel(); got != "debug" {
+		t.Fatalf("expected debug level, got %q", got)
+	}
+
+	logging.Debugf("TEST", "diagnostics probe")
+	entries, err := app.GetRecentLogs("debug", 1)
+	if err != nil {
+		t.Fatalf("GetRecentLogs returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Message != "diagnostics probe" || entries[0].Component != "TEST" {
+		t.Fatalf("unexpected recent logs %+v", entries)
+	}
+	if _, err := app.GetRecentLogs("nope", 10); err == nil {
+		t.Fatalf("expected unknown level to be rejected")
+	}
+}
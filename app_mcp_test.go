@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zalando/go-keyring"
+)
+
+func freeTCPPort(t *testing.T) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve port: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func callMCPTool(t *testing.T, settings MCPSettingsDTO, tool string, arguments string) (string, bool) {
+	t.Helper()
+	body := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"` + tool + `","arguments":` + arguments + `}}`
+	req, _ := http.NewRequest(http.MethodPost, settings.URL, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+settings.Token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("tools/call %s failed: %v", tool, err)
+	}
+	defer resp.Body.Close()
+	var decoded struct {
+		Result struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+			IsError bool `json:"isError"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil || len(decoded.Result.Content) == 0 {
+		t.Fatalf("unexpected tools/call response for %s: %v", tool, err)
+	}
+	return decoded.Result.Content[0].Text, decoded.Result.IsError
+}
+
+func TestMCPServerPoliciesApprovalAndAudit(t *testing.T) {
+	keyring.MockInit()
+	app := newTestAppWithDatabase(t)
+	t.Cleanup(app.mcpServer.Stop)
+
+	if _, err := app.SetMCPSettings(MCPSettingsDTO{Enabled: true, Port: 8000, Policies: map[string]string{"run_command": "always"}}); err == nil {
+		t.Fatalf("expected invalid policy to be rejected")
+	}
+	settings, err := app.SetMCPSettings(MCPSettingsDTO{Enabled: true, Port: freeTCPPort(t), Policies: map[string]string{"get_git_status": "deny"}})
+	if err != nil {
+		t.Fatalf("SetMCPSettings returned error: %v", err)
+	}
+	if !settings.Running || settings.Token == "" || settings.Policies["run_command"] != MCPPolicyAsk || settings.Policies["get_git_status"] != MCPPolicyDeny {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+
+	agent, err := app.CreateAgentSession(0, "", "")
+	if err != nil {
+		t.Fatalf("CreateAgentSession returned error: %v", err)
+	}
+	if text, isErr := callMCPTool(t, settings, "list_workspaces", `{}`); isErr || !strings.Contains(text, `"name": "`+agent.Name+`"`) {
+		t.Fatalf("unexpected list_workspaces result: %s", text)
+	}
+	if text, isErr := callMCPTool(t, settings, "get_git_status", `{"workspace_id":1}`); !isErr || !strings.Contains(text, "disabled in ORCH settings") {
+		t.Fatalf("expected denied git status, got %s", text)
+	}
+
+	done := make(chan string, 1)
+	go func() {
+		text, _ := callMCPTool(t, settings, "run_command", `{"agent_id":`+strconv.FormatUint(uint64(agent.ID), 10)+`,"command":"rm -rf /"}`)
+		done <- text
+	}()
+	deadline := time.Now().Add(3 * time.Second)
+	var pending []MCPApprovalRequestDTO
+	for len(pending) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		pending = app.GetPendingMCPApprovals()
+	}
+	if len(pending) != 1 || pending[0].Tool != "run_command" {
+		t.Fatalf("expected pending run_command approval, got %+v", pending)
+	}
+	if err := app.RespondMCPApproval(pending[0].ID, false); err != nil {
+		t.Fatalf("RespondMCPApproval returned error: %v", err)
+	}
+	if text := <-done; !strings.Contains(text, "rejected by the user") {
+		t.Fatalf("expected rejected command, got %s", text)
+	}
+
+	events, err := app.db.ListAuditEvents("mcp:unknown", 10)
+	if err != nil || len(events) != 3 {
+		t.Fatalf("expected 3 audited MCP calls, got %d (%v)", len(events), err)
+	}
+
+	if settings, _ = app.SetMCPSettings(MCPSettingsDTO{Enabled: false}); settings.Running {
+		t.Fatalf("expected server to stop when disabled")
+	}
+}
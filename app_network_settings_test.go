@@ -0,0 +1,69 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"orch/internal/httpclient"
+)
+
+func TestSetNetworkSettingsAppliesAndPersists(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	t.Cleanup(func() {
+		_ = app.SetNetworkSettings(httpclient.Settings{ProxyMode: httpclient.ProxyModeSystem})
+	})
+
+	err := app.SetNetworkSettings(httpclient.Settings{ProxyMode: "manual", ProxyURL: " http://proxy.corp:3128 ", NoProxy: "localhost"})
+	if err != nil {
+		t.Fatalf("SetNetworkSettings returned error: %v", err)
+	}
+	saved := app.GetNetworkSettings()
+	if saved.ProxyMode != httpclient.ProxyModeManual || saved.ProxyURL != "http://proxy.corp:3128" || saved.NoProxy != "localhost" {
+		t.Fatalf("unexpected saved settings: %#v", saved)
+	}
+	if applied := httpclient.Current(); applied != saved {
+		t.Fatalf("expected settings to be applied, got %#v", applied)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.pem")
+	if err := app.SetNetworkSettings(httpclient.Settings{CABundlePath: missing}); err == nil {
+		t.Fatalf("expected missing CA bundle to be rejected")
+	}
+	if got := app.GetNetworkSettings(); got != saved {
+		t.Fatalf("rejected settings must not be persisted, got %#v", got)
+	}
+}
+
+func TestAPIInspectorToggleAndTrace(t *testing.T) {
+	app := NewApp()
+	httpclient.ClearTraces()
+	t.Cleanup(func() {
+		app.SetAPIInspectorEnabled(false)
+		httpclient.ClearTraces()
+	})
+
+	httpclient.RecordCacheHit(httpclient.ServiceGitHub, "GET", "/ignored")
+	app.SetAPIInspectorEnabled(true)
+	app.SetAPIInspectorEnabled(true)
+	if !app.IsAPIInspectorEnabled() {
+		t.Fatalf("expected inspector to be enabled")
+	}
+	for i := 0; i < 3; i++ {
+		httpclient.RecordCacheHit(httpclient.ServiceGitHub, "GET", "/repos/o/r/pulls")
+	}
+	if traces := app.GetAPITrace(2); len(traces) != 2 || traces[1].Path != "/repos/o/r/pulls" {
+		t.Fatalf("unexpected trace: %#v", traces)
+	}
+	if traces := app.GetAPITrace(0); len(traces) != 3 {
+		t.Fatalf("expected 3 traces, got %d", len(traces))
+	}
+
+	app.SetAPIInspectorEnabled(false)
+	if app.IsAPIInspectorEnabled() || app.apiTraceStop != nil {
+		t.Fatalf("expected inspector and stream to be stopped")
+	}
+	app.ClearAPITrace()
+	if traces := app.GetAPITrace(0); len(traces) != 0 {
+		t.Fatalf("expected trace to be cleared, got %d", len(traces))
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNotificationTargetBindingsMaskURLAndTestFire(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	t.Cleanup(app.notifications.Close)
+
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if _, err := app.SaveNotificationTarget(NotificationTargetDTO{Name: "x", URL: server.URL, Events: []string{"pr.closed"}}); err == nil {
+		t.Fatalf("expected unknown event to be rejected")
+	}
+	if _, err := app.SaveNotificationTarget(NotificationTargetDTO{Name: "x", URL: "ftp://example.com/hook"}); err == nil {
+		t.Fatalf("expected non-http URL to be rejected")
+	}
+
+	saved, err := app.SaveNotificationTarget(NotificationTargetDTO{
+		Name:     "ops",
+		Kind:     "Slack",
+		URL:      server.URL + "/services/T0/B0/secret",
+		Events:   []string{"build.failed", "build.failed"},
+		Template: "{{.Title}} ({{index .Fields \"target\"}})",
+		Enabled:  true,
+	})
+	if err != nil {
+		t.Fatalf("SaveNotificationTarget returned error: %v", err)
+	}
+	if saved.Kind != "slack" || saved.URL != "" || saved.URLMasked != server.URL+"/…" || len(saved.Events) != 1 {
+		t.Fatalf("unexpected saved target: %+v", saved)
+	}
+
+	// Atualizar sem URL mantém a URL salva.
+	saved.Name = "ops-alerts"
+	if _, err := app.SaveNotificationTarget(saved); err != nil {
+		t.Fatalf("SaveNotificationTarget update returned error: %v", err)
+	}
+	if loaded, _ := app.loadNotificationTargets("build.failed"); len(loaded) != 1 || loaded[0].URL != server.URL+"/services/T0/B0/secret" {
+		t.Fatalf("expected stored URL to be kept, got %+v", loaded)
+	}
+	if loaded, _ := app.loadNotificationTargets("pr.merged"); len(loaded) != 0 {
+		t.Fatalf("target must only load for subscribed events, got %+v", loaded)
+	}
+
+	delivery, err := app.TestNotificationTarget(saved.ID)
+	if err != nil || !delivery.OK || delivery.Attempts != 1 {
+		t.Fatalf("unexpected test delivery: %+v (%v)", delivery, err)
+	}
+	var payload map[string]string
+	if err := json.Unmarshal(<-received, &payload); err != nil || payload["text"] != "ORCH test notification (ops-alerts)" {
+		t.Fatalf("unexpected slack payload: %+v (%v)", payload, err)
+	}
+	if deliveries := app.GetNotificationDeliveries(); len(deliveries) != 1 || deliveries[0].TargetID != saved.ID {
+		t.Fatalf("expected test delivery in history, got %+v", deliveries)
+	}
+}
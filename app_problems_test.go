@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	"orch/internal/problems"
+)
+
+func TestProblemMatcherBindings(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	app.problemCollector = problems.NewCollector(func(string) uint { return 3 }, nil)
+
+	matchers, err := app.ListProblemMatchers()
+	if err != nil || len(matchers) != len(problems.Builtin) || !matchers[0].Builtin {
+		t.Fatalf("expected only built-in matchers, got %#v (%v)", matchers, err)
+	}
+	if _, err := app.SaveProblemMatcher(ProblemMatcherDTO{Name: "bad", Pattern: `(\S+)`, FileGroup: 1, LineGroup: 2, MessageGroup: 1, Enabled: true}); err == nil {
+		t.Fatalf("expected out-of-range group to be rejected")
+	}
+	saved, err := app.SaveProblemMatcher(ProblemMatcherDTO{
+		Name:         "make",
+		Pattern:      `^ERR (\S+) line (\d+): (.+)$`,
+		FileGroup:    1,
+		LineGroup:    2,
+		MessageGroup: 3,
+		Severity:     "warning",
+		Enabled:      true,
+	})
+	if err != nil || saved.ID == 0 || saved.Severity != "warning" {
+		t.Fatalf("SaveProblemMatcher returned (%#v, %v)", saved, err)
+	}
+
+	app.problemCollector.ObserveOutput("term-1", []byte("ERR Makefile line 4: missing separator\nmain.go:2:1: expected 'package'\n"))
+	list := app.GetProblems(3)
+	if len(list) != 2 || list[0].Source != "$go" || list[1].Source != "make" || list[1].Severity != problems.SeverityWarning {
+		t.Fatalf("unexpected problems: %#v", list)
+	}
+
+	if err := app.DeleteProblemMatcher(saved.ID); err != nil {
+		t.Fatalf("DeleteProblemMatcher returned error: %v", err)
+	}
+	app.ClearProblems(3)
+	app.problemCollector.ObserveOutput("term-1", []byte("ERR Makefile line 4: missing separator\n"))
+	if list := app.GetProblems(3); len(list) != 0 {
+		t.Fatalf("deleted matcher should stop matching, got %#v", list)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"orch/internal/config"
+)
+
+func TestSwitchProfileRequiresRestartAndRespectsEnvPin(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(config.ProfileEnv, "")
+	app := NewApp()
+
+	result, err := app.SwitchProfile("lab")
+	if err != nil {
+		t.Fatalf("SwitchProfile error: %v", err)
+	}
+	if !result.RestartRequired || result.Profile.Name != "lab" || result.Profile.Active {
+		t.Fatalf("unexpected switch result: %+v", result)
+	}
+	found := false
+	for _, profile := range app.GetProfiles() {
+		found = found || profile.Name == "lab"
+	}
+	if !found {
+		t.Fatalf("created profile should be listed: %+v", app.GetProfiles())
+	}
+
+	current, err := app.SwitchProfile(config.ActiveProfile())
+	if err != nil || current.RestartRequired {
+		t.Fatalf("switching to the running profile needs no restart: %+v (%v)", current, err)
+	}
+	if app.GetAppInfo()["profile"] != config.ActiveProfile() {
+		t.Fatalf("app info should expose the running profile")
+	}
+
+	t.Setenv(config.ProfileEnv, "ci")
+	if _, err := app.SwitchProfile("lab"); err == nil {
+		t.Fatalf("expected pinned profile to reject switching")
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	gp "orch/internal/gitpanel"
+)
+
+func TestGitPanelUndoLastPreviewsAndUndoesCommit(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	app.gitPanel = gp.NewService(nil)
+	repoRoot := mustInitPRResolveTestRepo(t, "")
+
+	if err := os.WriteFile(filepath.Join(repoRoot, "notes.txt"), []byte("notes\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGitOrFailForPRResolve(t, repoRoot, "add", "notes.txt")
+	runGitOrFailForPRResolve(t, repoRoot, "commit", "-m", "add notes")
+
+	entries, err := app.GitPanelGetReflog(repoRoot, 5)
+	if err != nil || len(entries) == 0 || entries[0].Action != gp.ReflogActionCommit {
+		t.Fatalf("GitPanelGetReflog returned (%+v, %v)", entries, err)
+	}
+
+	preview, err := app.GitPanelUndoLast(repoRoot, true)
+	if err != nil || !preview.DryRun || !preview.Supported || preview.Applied {
+		t.Fatalf("unexpected preview: (%+v, %v)", preview, err)
+	}
+	applied, err := app.GitPanelUndoLast(repoRoot, false)
+	if err != nil || !applied.Applied || applied.TargetHash != preview.TargetHash {
+		t.Fatalf("unexpected undo result: (%+v, %v)", applied, err)
+	}
+
+	entries, err = app.GitPanelGetReflog(repoRoot, 1)
+	if err != nil || len(entries) != 1 || entries[0].Action != gp.ReflogActionReset || entries[0].Hash != preview.TargetHash {
+		t.Fatalf("expected undo recorded as reset, got (%+v, %v)", entries, err)
+	}
+}
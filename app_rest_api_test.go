@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func restAPIRequest(t *testing.T, settings RESTAPISettingsDTO, method, path, body string) (int, string) {
+	t.Helper()
+	req, _ := http.NewRequest(method, settings.URL+path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+settings.Token)
+	req.Close = true // o servidor é reiniciado na mesma porta durante o teste
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	var raw json.RawMessage
+	_ = json.NewDecoder(resp.Body).Decode(&raw)
+	return resp.StatusCode, string(raw)
+}
+
+func TestRESTAPISettingsAndRoutes(t *testing.T) {
+	keyring.MockInit()
+	app := newTestAppWithDatabase(t)
+	t.Cleanup(app.restAPI.Stop)
+
+	if _, err := app.SetRESTAPISettings(RESTAPISettingsDTO{Enabled: true, Port: 80}); err == nil {
+		t.Fatalf("expected privileged port to be rejected")
+	}
+	settings, err := app.SetRESTAPISettings(RESTAPISettingsDTO{Enabled: true, Port: freeTCPPort(t)})
+	if err != nil {
+		t.Fatalf("SetRESTAPISettings returned error: %v", err)
+	}
+	if !settings.Running || settings.Token == "" || settings.AllowWrite || len(settings.Routes) == 0 {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+
+	agent, err := app.CreateAgentSession(0, "", "")
+	if err != nil {
+		t.Fatalf("CreateAgentSession returned error: %v", err)
+	}
+	if status, body := restAPIRequest(t, settings, "GET", "/workspaces", ""); status != http.StatusOK || !strings.Contains(body, `"name":"`+agent.Name+`"`) {
+		t.Fatalf("unexpected workspaces: %d %s", status, body)
+	}
+	if status, _ := restAPIRequest(t, settings, "GET", "/terminals/missing/output", ""); status != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown terminal, got %d", status)
+	}
+	if status, _ := restAPIRequest(t, settings, "POST", "/sessions/abc/end", ""); status != http.StatusForbidden {
+		t.Fatalf("expected write route to be blocked by default, got %d", status)
+	}
+
+	settings, err = app.SetRESTAPISettings(RESTAPISettingsDTO{Enabled: true, Port: settings.Port, AllowWrite: true})
+	if err != nil || !settings.AllowWrite {
+		t.Fatalf("failed to enable write access: %+v (%v)", settings, err)
+	}
+	if status, _ := restAPIRequest(t, settings, "POST", "/terminals/missing/input", `{"command":"ls"}`); status != http.StatusNotFound {
+		t.Fatalf("expected 404 when writing to unknown terminal, got %d", status)
+	}
+	events, err := app.db.ListAuditEvents("rest:api", 10)
+	if err != nil || len(events) != 2 {
+		t.Fatalf("expected 2 audited write calls, got %d (%v)", len(events), err)
+	}
+
+	oldToken := settings.Token
+	if settings, err = app.RegenerateRESTAPIToken(); err != nil || settings.Token == oldToken {
+		t.Fatalf("expected a new token, got %+v (%v)", settings, err)
+	}
+	if settings, _ = app.SetRESTAPISettings(RESTAPISettingsDTO{Enabled: false}); settings.Running {
+		t.Fatalf("expected server to stop when disabled")
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"orch/internal/session"
+)
+
+func TestSessionGetAnalyticsSummarizesAuditEvents(t *testing.T) {
+	tempRoot := t.TempDir()
+	t.Setenv("HOME", tempRoot)
+	t.Setenv("ORCH_DB_PATH", fmt.Sprintf("%s/orch-analytics.db", tempRoot))
+
+	host, _ := newSessionHostAppWithDB(t)
+	if _, err := host.SessionGetAnalytics(" "); err == nil {
+		t.Fatalf("expected empty session id to be rejected")
+	}
+
+	created, err := host.SessionCreate(2, string(session.ModeLiveShare), true, 1)
+	if err != nil {
+		t.Fatalf("SessionCreate() error: %v", err)
+	}
+	if _, err := host.session.JoinSession(created.Code, "guest-writer", session.GuestInfo{Name: "Writer"}); err != nil {
+		t.Fatalf("JoinSession() error: %v", err)
+	}
+	if err := host.SessionApproveGuest(created.ID, "guest-writer"); err != nil {
+		t.Fatalf("SessionApproveGuest() error: %v", err)
+	}
+	if err := host.SessionSetGuestPermission(created.ID, "guest-writer", string(session.PermReadWrite)); err != nil {
+		t.Fatalf("SessionSetGuestPermission() error: %v", err)
+	}
+
+	analytics, err := host.SessionGetAnalytics(created.ID)
+	if err != nil {
+		t.Fatalf("SessionGetAnalytics() error: %v", err)
+	}
+	if analytics.Ended || analytics.PermissionChanges != 1 || len(analytics.Guests) != 1 {
+		t.Fatalf("unexpected analytics for active session: %+v", analytics)
+	}
+	if guest := analytics.Guests[0]; guest.UserID != "guest-writer" || guest.Connections != 1 || guest.FinalPermission != string(session.PermReadWrite) {
+		t.Fatalf("unexpected guest analytics: %+v", guest)
+	}
+	if len(analytics.Timeline) == 0 || analytics.Timeline[0].Action != "session_created" {
+		t.Fatalf("expected ascending timeline, got %+v", analytics.Timeline)
+	}
+
+	if err := host.SessionEnd(created.ID); err != nil {
+		t.Fatalf("SessionEnd() error: %v", err)
+	}
+	ended, err := host.SessionGetAnalytics(created.ID)
+	if err != nil || !ended.Ended {
+		t.Fatalf("expected ended session analytics, got (%+v, %v)", ended, err)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"orch/internal/commandguard"
+	"orch/internal/session"
+	"orch/internal/terminal"
+)
+
+func TestGuestDangerousCommandWaitsForHostApproval(t *testing.T) {
+	app, db, scopedWorkspace, _ := newScopedPermissionTestApp(t)
+	app.ptyMgr = terminal.NewPTYManager()
+	t.Cleanup(app.ptyMgr.DestroyAll)
+
+	terminalID, err := app.ptyMgr.Create(terminal.PTYConfig{Shell: "/bin/cat", Cwd: t.TempDir()})
+	if err != nil {
+		t.Skipf("pty unavailable: %v", err)
+	}
+	var mu sync.Mutex
+	var output strings.Builder
+	app.ptyMgr.OnOutput(terminalID, func(data []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		output.Write(data)
+	})
+	occurrences := func(text string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return strings.Count(output.String(), text)
+	}
+	waitFor := func(text string, count int) bool {
+		deadline := time.Now().Add(3 * time.Second)
+		for time.Now().Before(deadline) && occurrences(text) < count {
+			time.Sleep(20 * time.Millisecond)
+		}
+		return occurrences(text) >= count
+	}
+
+	created := createApprovedGuestInScopedSession(t, app, scopedWorkspace.ID, session.PermReadWrite, "guest-rw")
+	bindTerminalToWorkspace(t, app, db, scopedWorkspace.ID, terminalID)
+
+	// Com a regra desligada o comando passa direto (eco do PTY + saída do cat).
+	if err := app.WriteTerminalAsGuest(terminalID, "guest-rw", "git push -f\r"); err != nil {
+		t.Fatalf("WriteTerminalAsGuest() error: %v", err)
+	}
+	if !waitFor("git push -f", 2) {
+		t.Fatalf("expected command to run without approval, got %q", output.String())
+	}
+
+	if _, err := app.SessionSetCommandApproval(created.ID, true); err != nil {
+		t.Fatalf("SessionSetCommandApproval() error: %v", err)
+	}
+	for _, key := range []string{"rm -rf build", "\r"} {
+		if err := app.WriteTerminalAsGuest(terminalID, "guest-rw", key); err != nil {
+			t.Fatalf("WriteTerminalAsGuest(%q) error: %v", key, err)
+		}
+	}
+	held := app.SessionListHeldCommands()
+	if len(held) != 1 || held[0].Command != "rm -rf build" || held[0].Rule.ID != commandguard.RuleRecursiveDelete.ID {
+		t.Fatalf("unexpected held commands: %+v", held)
+	}
+	if err := app.WriteTerminalAsGuest(terminalID, "guest-rw", "ls\r"); !errors.Is(err, commandguard.ErrAwaitingApproval) {
+		t.Fatalf("expected guest input to wait for approval, got %v", err)
+	}
+	waitFor("rm -rf build", 1)
+	time.Sleep(100 * time.Millisecond)
+	if got := occurrences("rm -rf build"); got != 1 {
+		t.Fatalf("expected only the echo before approval, got %d occurrences", got)
+	}
+
+	approved, err := app.SessionApproveCommand(held[0].ID)
+	if err != nil || approved.Status != commandguard.StatusApproved {
+		t.Fatalf("SessionApproveCommand() = (%+v, %v)", approved, err)
+	}
+	if !waitFor("rm -rf build", 2) {
+		t.Fatalf("expected approved command to reach the PTY, got %q", output.String())
+	}
+
+	if err := app.WriteTerminalAsGuest(terminalID, "guest-rw", "git reset --hard\r"); err != nil {
+		t.Fatalf("WriteTerminalAsGuest() error: %v", err)
+	}
+	held = app.SessionListHeldCommands()
+	if len(held) != 1 {
+		t.Fatalf("expected second command to be held, got %+v", held)
+	}
+	denied, err := app.SessionDenyCommand(held[0].ID, "not now")
+	if err != nil || denied.Status != commandguard.StatusDenied || denied.Reason != "not now" {
+		t.Fatalf("SessionDenyCommand() = (%+v, %v)", denied, err)
+	}
+
+	events, err := db.ListAuditEvents(created.ID, 100)
+	if err != nil {
+		t.Fatalf("ListAuditEvents() error: %v", err)
+	}
+	recorded := map[string]int{}
+	for _, event := range events {
+		recorded[event.Action]++
+	}
+	if recorded["command_approval_changed"] != 1 || recorded["command_held"] != 2 || recorded["command_approved"] != 1 || recorded["command_denied"] != 1 {
+		t.Fatalf("unexpected audit trail: %v", recorded)
+	}
+}
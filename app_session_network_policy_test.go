@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"orch/internal/egress"
+	"orch/internal/session"
+)
+
+func TestSessionNetworkPolicyDefaultsPersist(t *testing.T) {
+	app, db := newAppWithIsolatedDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	if got := app.GetSessionNetworkPolicyDefaults(); got.Mode != session.NetworkNone {
+		t.Fatalf("default network mode = %q, want none", got.Mode)
+	}
+	if _, err := app.SetSessionNetworkPolicyDefaults(session.NetworkPolicy{Mode: session.NetworkAllowList}); err == nil {
+		t.Fatalf("expected empty allow-list to be rejected")
+	}
+	if _, err := app.SetSessionNetworkPolicyDefaults(session.NetworkPolicy{Mode: "host"}); err == nil {
+		t.Fatalf("expected unknown mode to be rejected")
+	}
+
+	got, err := app.SetSessionNetworkPolicyDefaults(session.NetworkPolicy{
+		Mode:         "AllowList",
+		AllowedHosts: []string{"https://registry.npmjs.org", "github.com", "github.com"},
+	})
+	if err != nil {
+		t.Fatalf("SetSessionNetworkPolicyDefaults() error: %v", err)
+	}
+	if got.Mode != session.NetworkAllowList || strings.Join(got.AllowedHosts, ",") != "github.com,registry.npmjs.org" {
+		t.Fatalf("unexpected normalized policy: %+v", got)
+	}
+	persisted := app.GetSessionNetworkPolicyDefaults()
+	if persisted.Mode != session.NetworkAllowList || strings.Join(persisted.AllowedHosts, ",") != "github.com,registry.npmjs.org" {
+		t.Fatalf("unexpected persisted policy: %+v", persisted)
+	}
+}
+
+func TestSessionEgressRequestsAreAudited(t *testing.T) {
+	app, db := newAppWithIsolatedDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	route := &egressRoute{network: "orch-egress-test", proxy: egress.NewProxy([]string{"github.com"})}
+	// Requisição feita entre o start do container e a criação da sessão fica pendente.
+	app.recordEgressEvent(route, egress.Event{Method: "CONNECT", Host: "github.com:443", Allowed: true})
+	app.bindSessionEgress("sess-egress", route)
+	app.recordEgressEvent(route, egress.Event{Method: "GET", Host: "evil.test:80", Allowed: false})
+
+	events, err := db.ListAuditEvents("sess-egress", 10)
+	if err != nil {
+		t.Fatalf("ListAuditEvents() error: %v", err)
+	}
+	details := []string{}
+	for _, event := range events {
+		if event.Action == "network_request" {
+			details = append(details, event.Details)
+		}
+	}
+	joined := strings.Join(details, "|")
+	if len(details) != 2 || !strings.Contains(joined, "CONNECT github.com:443 allowed") || !strings.Contains(joined, "GET evil.test:80 denied") {
+		t.Fatalf("unexpected network audit trail: %v", details)
+	}
+
+	if popped := app.popSessionEgress("sess-egress"); popped != route {
+		t.Fatalf("expected bound route to be returned on session end")
+	}
+	app.closeEgressRoute(route)
+	if app.popSessionEgress("sess-egress") != nil {
+		t.Fatalf("route should be released after session end")
+	}
+}
+
+func TestSessionEgressFallsBackToRelayOnDockerDesktop(t *testing.T) {
+	// 192.0.2.1 (TEST-NET-1) não é interface do host, como o gateway dentro da VM do Docker Desktop.
+	proxy := egress.NewProxy([]string{"github.com"})
+	t.Cleanup(func() { _ = proxy.Close() })
+	relayedPort := ""
+	proxyURL, relay, err := listenSessionEgress(proxy, "orch-egress-test", "192.0.2.1", func(network string, hostPort string) (string, string, error) {
+		relayedPort = hostPort
+		return network + "-relay", "http://" + network + "-relay:3128", nil
+	})
+	if err != nil {
+		t.Fatalf("listenSessionEgress() error: %v", err)
+	}
+	if proxyURL != "http://orch-egress-test-relay:3128" || relay != "orch-egress-test-relay" {
+		t.Fatalf("unexpected relay route: url=%q relay=%q", proxyURL, relay)
+	}
+	conn, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", relayedPort))
+	if err != nil {
+		t.Fatalf("expected proxy listening on the loopback port handed to the relay: %v", err)
+	}
+	_ = conn.Close()
+
+	failing := egress.NewProxy([]string{"github.com"})
+	_, _, err = listenSessionEgress(failing, "orch-egress-test", "192.0.2.1", func(string, string) (string, string, error) {
+		return "", "", errors.New("pull access denied for alpine/socat")
+	})
+	if err == nil || !strings.Contains(err.Error(), "Docker Desktop") || !strings.Contains(err.Error(), "alpine/socat") {
+		t.Fatalf("expected a clear Docker Desktop error, got %v", err)
+	}
+
+	direct := egress.NewProxy([]string{"github.com"})
+	t.Cleanup(func() { _ = direct.Close() })
+	proxyURL, relay, err = listenSessionEgress(direct, "orch-egress-test", "127.0.0.1", func(string, string) (string, string, error) {
+		t.Fatalf("relay must not start when the gateway is a host interface")
+		return "", "", nil
+	})
+	if err != nil || relay != "" || !strings.HasPrefix(proxyURL, "http://127.0.0.1:") {
+		t.Fatalf("expected direct gateway listener, got url=%q relay=%q err=%v", proxyURL, relay, err)
+	}
+}
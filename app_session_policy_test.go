@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"orch/internal/session"
+)
+
+func TestSessionPoliciesRevokeIdleWritersAndEndCappedSessions(t *testing.T) {
+	tempRoot := t.TempDir()
+	t.Setenv("HOME", tempRoot)
+	t.Setenv("ORCH_DB_PATH", fmt.Sprintf("%s/orch-policy.db", tempRoot))
+
+	host, db := newSessionHostAppWithDB(t)
+	if _, err := host.SetSessionTimeoutPolicyDefaults(session.TimeoutPolicy{GuestIdleMinutes: -1}); err == nil {
+		t.Fatalf("expected invalid default policy to be rejected")
+	}
+	defaults, err := host.SetSessionTimeoutPolicyDefaults(session.TimeoutPolicy{GuestIdleMinutes: 15})
+	if err != nil || defaults.WarnBeforeMinutes != 2 {
+		t.Fatalf("unexpected defaults (%+v, %v)", defaults, err)
+	}
+
+	created, err := host.SessionCreate(2, string(session.ModeLiveShare), true, 1)
+	if err != nil {
+		t.Fatalf("SessionCreate() error: %v", err)
+	}
+	if created.Config.Policy != defaults {
+		t.Fatalf("expected new session to use default policy, got %+v", created.Config.Policy)
+	}
+	if _, err := host.session.JoinSession(created.Code, "guest-writer", session.GuestInfo{Name: "Writer"}); err != nil {
+		t.Fatalf("JoinSession() error: %v", err)
+	}
+	if err := host.SessionApproveGuest(created.ID, "guest-writer"); err != nil {
+		t.Fatalf("SessionApproveGuest() error: %v", err)
+	}
+	if err := host.SessionSetGuestPermission(created.ID, "guest-writer", string(session.PermReadWrite)); err != nil {
+		t.Fatalf("SessionSetGuestPermission() error: %v", err)
+	}
+	if _, err := host.SessionSetTimeoutPolicy(created.ID, session.TimeoutPolicy{GuestIdleMinutes: 10, MaxDurationMinutes: 60}); err != nil {
+		t.Fatalf("SessionSetTimeoutPolicy() error: %v", err)
+	}
+
+	start := time.Now()
+	applied := func(now time.Time) []string {
+		result := []string{}
+		for _, action := range host.enforceSessionPolicies(now) {
+			result = append(result, action.Type)
+		}
+		return result
+	}
+	if got := applied(start.Add(9 * time.Minute)); len(got) != 1 || got[0] != session.PolicyGuestIdleWarning {
+		t.Fatalf("expected idle warning, got %v", got)
+	}
+	if got := applied(start.Add(11 * time.Minute)); len(got) != 1 || got[0] != session.PolicyGuestWriteRevoked {
+		t.Fatalf("expected write revocation, got %v", got)
+	}
+	current, err := host.SessionGetSession(created.ID)
+	if err != nil || current.Guests[0].Permission != session.PermReadOnly {
+		t.Fatalf("expected guest to be read-only after revocation, got (%+v, %v)", current, err)
+	}
+
+	if got := applied(start.Add(59 * time.Minute)); len(got) != 1 || got[0] != session.PolicySessionEndWarning {
+		t.Fatalf("expected session end warning, got %v", got)
+	}
+	if got := applied(start.Add(61 * time.Minute)); len(got) != 1 || got[0] != session.PolicySessionExpired {
+		t.Fatalf("expected session expiration, got %v", got)
+	}
+	if active, _ := host.session.GetActiveSession("host-regression"); active != nil {
+		t.Fatalf("expected capped session to be ended, got %+v", active)
+	}
+
+	events, err := db.ListAuditEvents(created.ID, 100)
+	if err != nil {
+		t.Fatalf("ListAuditEvents() error: %v", err)
+	}
+	recorded := map[string]bool{}
+	for _, event := range events {
+		recorded[event.Action] = true
+	}
+	for _, action := range []string{"policy_changed", "policy_guest_idle_warning", "policy_guest_write_revoked", "policy_session_end_warning", "policy_session_expired", "session_ended"} {
+		if !recorded[action] {
+			t.Fatalf("expected audit action %q, got %v", action, recorded)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"orch/internal/auth"
+	"orch/internal/session"
+	"orch/internal/sessionreview"
+)
+
+func TestSessionPRReviewRequiresWriteGuestsAndHostApproval(t *testing.T) {
+	tempRoot := t.TempDir()
+	t.Setenv("HOME", tempRoot)
+	t.Setenv("ORCH_DB_PATH", fmt.Sprintf("%s/orch-prreview.db", tempRoot))
+
+	host, _ := newSessionHostAppWithDB(t)
+	gatewayAddr, gatewayURL := reserveGatewayAddress(t)
+	gateway := session.NewGatewayServer(host.session, gatewayAddr)
+	gateway.Handle(sessionreview.RoutePrefix, sessionreview.NewHandler(host.prReviews, sessionReviewBackend{host}))
+	if err := gateway.Start(); err != nil {
+		t.Fatalf("failed to start gateway: %v", err)
+	}
+	t.Cleanup(func() { _ = gateway.Stop(t.Context()) })
+
+	if _, err := host.SessionStartPRReview("orch-labs", "orch", 42); err == nil {
+		t.Fatalf("expected review without active session to fail")
+	}
+	created, err := host.SessionCreate(2, string(session.ModeLiveShare), true, 1)
+	if err != nil {
+		t.Fatalf("SessionCreate() error: %v", err)
+	}
+
+	newGuest := func(id string) *App {
+		guest := NewApp()
+		guest.auth = auth.NewService(nil)
+		guest.auth.SetCurrentUserForTesting(&auth.User{ID: id, Name: id, Username: id, Provider: "github"})
+		guest.sessionGatewayURL = gatewayURL
+		if _, err := guest.SessionJoin(created.Code, id, ""); err != nil {
+			t.Fatalf("SessionJoin(%s) error: %v", id, err)
+		}
+		return guest
+	}
+	writer, reader := newGuest("guest-writer"), newGuest("guest-reader")
+	for _, id := range []string{"guest-writer", "guest-reader"} {
+		if err := host.SessionApproveGuest(created.ID, id); err != nil {
+			t.Fatalf("SessionApproveGuest(%s) error: %v", id, err)
+		}
+	}
+	if err := host.SessionSetGuestPermission(created.ID, "guest-writer", string(session.PermReadWrite)); err != nil {
+		t.Fatalf("SessionSetGuestPermission() error: %v", err)
+	}
+
+	if _, err := writer.SessionGetPRReviewTarget(created.ID); err == nil || !strings.Contains(err.Error(), "not active") {
+		t.Fatalf("expected inactive review error, got %v", err)
+	}
+	target, err := host.SessionStartPRReview("orch-labs", "orch", 42)
+	if err != nil {
+		t.Fatalf("SessionStartPRReview() error: %v", err)
+	}
+	if target.SessionID != created.ID || target.URL != "https://github.com/orch-labs/orch/pull/42" {
+		t.Fatalf("unexpected target: %+v", target)
+	}
+
+	if _, err := reader.SessionSubmitReviewDraft(created.ID, sessionreview.DraftInput{Body: "nit"}); err == nil || !strings.Contains(err.Error(), "read-write") {
+		t.Fatalf("expected read-only guest to be refused, got %v", err)
+	}
+	seen, err := writer.SessionGetPRReviewTarget(created.ID)
+	if err != nil || seen.PRNumber != 42 {
+		t.Fatalf("unexpected guest target (%+v, %v)", seen, err)
+	}
+	inline, err := writer.SessionSubmitReviewDraft(created.ID, sessionreview.DraftInput{Body: "Extract this helper.", Path: "app.go", Line: 10})
+	if err != nil {
+		t.Fatalf("SessionSubmitReviewDraft(inline) error: %v", err)
+	}
+	general, err := writer.SessionSubmitReviewDraft(created.ID, sessionreview.DraftInput{Body: "LGTM overall"})
+	if err != nil {
+		t.Fatalf("SessionSubmitReviewDraft(general) error: %v", err)
+	}
+	if inline.Author.Login != "guest-writer" || inline.Side != "RIGHT" {
+		t.Fatalf("unexpected inline draft: %+v", inline)
+	}
+
+	drafts, err := host.SessionListReviewDrafts()
+	if err != nil || len(drafts) != 2 {
+		t.Fatalf("expected host to see 2 drafts, got (%+v, %v)", drafts, err)
+	}
+	// Sem GitHub configurado nada é publicado e o rascunho continua pendente.
+	host.github = nil
+	if _, err := host.SessionApproveReviewDraft(inline.ID); err == nil {
+		t.Fatalf("expected approval without GitHub to fail")
+	}
+	rejected, err := host.SessionRejectReviewDraft(general.ID, "covered by another comment")
+	if err != nil || rejected.Status != sessionreview.DraftRejected {
+		t.Fatalf("unexpected rejection (%+v, %v)", rejected, err)
+	}
+
+	mine, err := writer.SessionListMyReviewDrafts(created.ID)
+	if err != nil || len(mine) != 2 {
+		t.Fatalf("unexpected guest drafts (%+v, %v)", mine, err)
+	}
+	statuses := map[string]string{}
+	for _, draft := range mine {
+		statuses[draft.ID] = draft.Status
+	}
+	if statuses[inline.ID] != sessionreview.DraftPending || statuses[general.ID] != sessionreview.DraftRejected {
+		t.Fatalf("unexpected draft statuses: %+v", statuses)
+	}
+
+	if err := host.SessionStopPRReview(); err != nil {
+		t.Fatalf("SessionStopPRReview() error: %v", err)
+	}
+	if drafts, _ := host.SessionListReviewDrafts(); len(drafts) != 0 {
+		t.Fatalf("expected drafts to be cleared after stop, got %+v", drafts)
+	}
+}
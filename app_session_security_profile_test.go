@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"orch/internal/docker"
+)
+
+func TestSessionSecurityProfileDefaultsPersist(t *testing.T) {
+	app, db := newAppWithIsolatedDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+	app.docker = docker.NewService()
+
+	if got := app.GetSessionSecurityProfile(); got != docker.ProfileStrict {
+		t.Fatalf("default profile = %q, want strict", got)
+	}
+	if _, err := app.SetSessionSecurityProfile("privileged"); err == nil {
+		t.Fatalf("expected unknown profile to be rejected")
+	}
+	if got, err := app.SetSessionSecurityProfile("standard"); err != nil || got != docker.ProfileStandard {
+		t.Fatalf("SetSessionSecurityProfile() = (%q, %v)", got, err)
+	}
+	if got := app.GetSessionSecurityProfile(); got != docker.ProfileStandard {
+		t.Fatalf("persisted profile = %q, want standard", got)
+	}
+
+	if _, err := app.SessionGetContainerSecurity("no-container"); err == nil {
+		t.Fatalf("expected session without container to fail")
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	gp "orch/internal/gitpanel"
+)
+
+func TestGitPanelConfigureSigningWritesLocalConfig(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	app.gitPanel = gp.NewService(nil)
+	repoRoot := mustInitPRResolveTestRepo(t, "")
+
+	if _, err := app.GitPanelConfigureSigning(repoRoot, gp.SigningSetupDTO{Format: "pkcs11"}); err == nil {
+		t.Fatalf("expected unknown signing format to be rejected")
+	}
+
+	status, err := app.GitPanelConfigureSigning(repoRoot, gp.SigningSetupDTO{Format: "openpgp", SigningKey: "0xDEADBEEF", SignCommits: true, SignTags: true})
+	if err != nil {
+		t.Fatalf("GitPanelConfigureSigning returned error: %v", err)
+	}
+	if status.Format != gp.SigningFormatOpenPGP || status.SigningKey != "0xDEADBEEF" || !status.SignCommits || !status.SignTags {
+		t.Fatalf("unexpected status after setup: %+v", status)
+	}
+	if out, err := exec.Command("git", "-C", repoRoot, "config", "--local", "--get", "commit.gpgsign").Output(); err != nil || strings.TrimSpace(string(out)) != "true" {
+		t.Fatalf("expected commit.gpgsign=true in local config, got %q (%v)", out, err)
+	}
+
+	// Desligar a assinatura e limpar a chave volta ao default do git.
+	status, err = app.GitPanelConfigureSigning(repoRoot, gp.SigningSetupDTO{Format: "openpgp"})
+	if err != nil {
+		t.Fatalf("GitPanelConfigureSigning(disable) returned error: %v", err)
+	}
+	if status.SigningKey != "" || status.SignCommits || status.Configured {
+		t.Fatalf("expected signing disabled, got %+v", status)
+	}
+	if reread, err := app.GitPanelGetSigningStatus(repoRoot); err != nil || reread.SignCommits {
+		t.Fatalf("GitPanelGetSigningStatus returned (%+v, %v)", reread, err)
+	}
+}
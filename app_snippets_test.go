@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnippetsScopeAndRunValidation(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+
+	agent, err := app.CreateAgentSession(0, "api", "")
+	if err != nil {
+		t.Fatalf("CreateAgentSession returned error: %v", err)
+	}
+	other, err := app.CreateWorkspace("other")
+	if err != nil {
+		t.Fatalf("CreateWorkspace returned error: %v", err)
+	}
+
+	global, err := app.SaveSnippet(SnippetDTO{Name: " logs ", Command: "kubectl logs {{pod}} --tail={{lines:100}}"})
+	if err != nil {
+		t.Fatalf("SaveSnippet returned error: %v", err)
+	}
+	if global.Name != "logs" || len(global.Placeholders) != 2 || !global.Placeholders[0].Required {
+		t.Fatalf("unexpected saved snippet: %+v", global)
+	}
+	if _, err := app.SaveSnippet(SnippetDTO{Name: "logs", Command: "echo dup"}); err == nil {
+		t.Fatalf("expected duplicate name in the same scope to be rejected")
+	}
+	local, err := app.SaveSnippet(SnippetDTO{WorkspaceID: agent.WorkspaceID, Name: "logs", Command: "tail -f log/dev.log"})
+	if err != nil {
+		t.Fatalf("expected same name in workspace scope, got %v", err)
+	}
+	foreign, err := app.SaveSnippet(SnippetDTO{WorkspaceID: other.ID, Name: "seed", Command: "make seed"})
+	if err != nil {
+		t.Fatalf("SaveSnippet returned error: %v", err)
+	}
+
+	listed, err := app.ListSnippets(agent.WorkspaceID)
+	if err != nil || len(listed) != 2 || listed[0].ID != local.ID || listed[1].ID != global.ID {
+		t.Fatalf("expected workspace snippet before global one, got %+v (%v)", listed, err)
+	}
+	if globals, _ := app.ListSnippets(0); len(globals) != 1 {
+		t.Fatalf("expected only global snippets, got %+v", globals)
+	}
+
+	if _, err := app.RunSnippet(agent.ID, foreign.ID, nil); err == nil || !strings.Contains(err.Error(), "another workspace") {
+		t.Fatalf("expected scope error, got %v", err)
+	}
+	if _, err := app.RunSnippet(agent.ID, global.ID, nil); err == nil || !strings.Contains(err.Error(), "missing value for pod") {
+		t.Fatalf("expected missing placeholder error, got %v", err)
+	}
+	if _, err := app.RunSnippet(agent.ID, global.ID, map[string]string{"pod": "api-0"}); err == nil || !strings.Contains(err.Error(), "not running") {
+		t.Fatalf("expected terminal not running error, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gp "orch/internal/gitpanel"
+)
+
+func TestGitPanelSparseCheckoutAndPartialCloneBindings(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	app.gitPanel = gp.NewService(nil)
+	repoRoot := mustInitPRResolveTestRepo(t, "https://github.com/orch-labs/orch.git")
+
+	for _, dir := range []string{"services/api", "services/worker"} {
+		if err := os.MkdirAll(filepath.Join(repoRoot, dir), 0o755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(repoRoot, dir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	runGitOrFailForPRResolve(t, repoRoot, "add", ".")
+	runGitOrFailForPRResolve(t, repoRoot, "commit", "-m", "add services")
+
+	sparse, err := app.GitPanelSetSparseCheckout(repoRoot, []string{"services/api"})
+	if err != nil || strings.Join(sparse.Directories, ",") != "services/api" {
+		t.Fatalf("GitPanelSetSparseCheckout returned (%+v, %v)", sparse, err)
+	}
+	if current, err := app.GitPanelGetSparseCheckout(repoRoot); err != nil || !current.Enabled {
+		t.Fatalf("GitPanelGetSparseCheckout returned (%+v, %v)", current, err)
+	}
+	if sparse, err = app.GitPanelDisableSparseCheckout(repoRoot); err != nil || sparse.Enabled {
+		t.Fatalf("GitPanelDisableSparseCheckout returned (%+v, %v)", sparse, err)
+	}
+
+	state, err := app.GitPanelSetPartialClone(repoRoot, "origin", gp.PartialCloneFilterBlobNone)
+	if err != nil || !state.Enabled || state.Filter != gp.PartialCloneFilterBlobNone {
+		t.Fatalf("GitPanelSetPartialClone returned (%+v, %v)", state, err)
+	}
+	if current, err := app.GitPanelGetPartialClone(repoRoot); err != nil || current.Remote != "origin" || len(current.Warnings) == 0 {
+		t.Fatalf("GitPanelGetPartialClone returned (%+v, %v)", current, err)
+	}
+}
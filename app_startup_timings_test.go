@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	gh "orch/internal/github"
+	"orch/internal/startup"
+)
+
+func TestGetStartupTimingsReportsPendingSubsystems(t *testing.T) {
+	app := NewApp()
+	if timings := app.GetStartupTimings(); len(timings.Phases) != 0 || timings.Pending == nil {
+		t.Fatalf("expected empty timings before startup, got %+v", timings)
+	}
+
+	app.startupTimings = startup.NewTracker(nil)
+	app.startupTimings.Begin("database")(nil)
+	release := make(chan struct{})
+	app.startupTimings.Go("docker", func() error {
+		<-release
+		return nil
+	})
+	app.startupTimings.MarkWindowReady()
+
+	timings := app.GetStartupTimings()
+	if len(timings.Pending) != 1 || timings.Pending[0] != "docker" {
+		t.Fatalf("docker probe should not block the window, got %+v", timings)
+	}
+
+	close(release)
+	if !app.startupTimings.Wait(time.Second) {
+		t.Fatalf("docker phase did not finish")
+	}
+	if timings := app.GetStartupTimings(); len(timings.Pending) != 0 {
+		t.Fatalf("expected no pending subsystems, got %+v", timings.Pending)
+	}
+}
+
+func TestGitHubBindingsWaitForGitHubStartupPhase(t *testing.T) {
+	app := NewApp()
+	app.githubReady = make(chan struct{})
+	if app.gitHubStarted() {
+		t.Fatalf("github should not report started before its phase finishes")
+	}
+	if _, ok := (gitHubCacheReader{app: app}).GetCachedPullRequest("acme", "orch", 1); ok {
+		t.Fatalf("cache reader should be empty before the github phase")
+	}
+
+	ready := make(chan bool, 1)
+	go func() { ready <- app.awaitGitHub() }()
+	select {
+	case <-ready:
+		t.Fatalf("awaitGitHub returned before the github phase finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	app.github = gh.NewService(func() (string, error) { return "", nil })
+	close(app.githubReady)
+	select {
+	case ok := <-ready:
+		if !ok {
+			t.Fatalf("expected github available after its phase")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("awaitGitHub did not return after the github phase")
+	}
+	if !app.gitHubStarted() {
+		t.Fatalf("expected github started after its phase")
+	}
+}
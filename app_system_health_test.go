@@ -0,0 +1,70 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func findSubsystemHealth(t *testing.T, health SystemHealth, name string) SubsystemHealth {
+	t.Helper()
+	for _, subsystem := range health.Subsystems {
+		if subsystem.Name == name {
+			return subsystem
+		}
+	}
+	t.Fatalf("subsystem %q missing from %+v", name, health.Subsystems)
+	return SubsystemHealth{}
+}
+
+func TestGetSystemHealthReportsDatabaseDownWithoutServices(t *testing.T) {
+	app := NewApp()
+	app.sessionGatewayURL = "http://127.0.0.1:1"
+
+	health := app.GetSystemHealth()
+	if health.Status != healthStatusDown {
+		t.Fatalf("expected overall down without database, got %q", health.Status)
+	}
+	if db := findSubsystemHealth(t, health, "database"); db.Status != healthStatusDown || db.LastError == "" || db.LastErrorAt == nil {
+		t.Fatalf("unexpected database health %+v", db)
+	}
+	for _, name := range []string{"docker", "signaling", "github", "ai"} {
+		if subsystem := findSubsystemHealth(t, health, name); subsystem.Status != healthStatusDisabled {
+			t.Fatalf("expected %s disabled, got %+v", name, subsystem)
+		}
+	}
+}
+
+func TestGetSystemHealthKeepsLastErrorAfterRecovery(t *testing.T) {
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	app := newTestAppWithDatabase(t)
+	app.sessionGatewayURL = server.URL
+
+	health := app.GetSystemHealth()
+	if gateway := findSubsystemHealth(t, health, "gateway"); gateway.Status != healthStatusDown {
+		t.Fatalf("expected gateway down, got %+v", gateway)
+	}
+	if db := findSubsystemHealth(t, health, "database"); db.Status != healthStatusOK {
+		t.Fatalf("expected database ok, got %+v", db)
+	}
+
+	healthy.Store(true)
+	health = app.GetSystemHealth()
+	gateway := findSubsystemHealth(t, health, "gateway")
+	if gateway.Status != healthStatusOK || gateway.LastError == "" || gateway.LastErrorAt == nil {
+		t.Fatalf("expected recovered gateway with last error kept, got %+v", gateway)
+	}
+}
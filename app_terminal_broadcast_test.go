@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"orch/internal/terminal"
+)
+
+func TestWriteTerminalFansOutToBroadcastGroup(t *testing.T) {
+	app := NewApp()
+	app.ptyMgr = terminal.NewPTYManager()
+	t.Cleanup(app.ptyMgr.DestroyAll)
+
+	var mu sync.Mutex
+	outputs := map[string]*strings.Builder{}
+	newCat := func() string {
+		id, err := app.ptyMgr.Create(terminal.PTYConfig{Shell: "/bin/cat", Cwd: t.TempDir()})
+		if err != nil {
+			t.Skipf("pty unavailable: %v", err)
+		}
+		mu.Lock()
+		outputs[id] = &strings.Builder{}
+		mu.Unlock()
+		app.ptyMgr.OnOutput(id, func(data []byte) {
+			mu.Lock()
+			defer mu.Unlock()
+			outputs[id].Write(data)
+		})
+		return id
+	}
+	source, peer, muted, outside := newCat(), newCat(), newCat(), newCat()
+
+	if _, err := app.CreateTerminalBroadcastGroup("services", []string{source, peer, muted}); err != nil {
+		t.Fatalf("CreateTerminalBroadcastGroup() error: %v", err)
+	}
+	if _, err := app.SetTerminalBroadcastEnabled(muted, false); err != nil {
+		t.Fatalf("SetTerminalBroadcastEnabled() error: %v", err)
+	}
+	if err := app.WriteTerminal(source, "echo-broadcast\n"); err != nil {
+		t.Fatalf("WriteTerminal() error: %v", err)
+	}
+
+	received := func(id string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return strings.Contains(outputs[id].String(), "echo-broadcast")
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) && !(received(source) && received(peer)) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if !received(source) || !received(peer) {
+		t.Fatalf("expected source and enabled peer to receive the input")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if received(muted) || received(outside) {
+		t.Fatalf("disabled member and non-member must not receive broadcast input")
+	}
+
+	app.RemoveTerminalFromBroadcastGroup(peer)
+	groups := app.GetTerminalBroadcastGroups()
+	if len(groups) != 1 || len(groups[0].Members) != 2 {
+		t.Fatalf("expected removed terminal to leave the group, got %+v", groups)
+	}
+}
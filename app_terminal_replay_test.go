@@ -0,0 +1,67 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResumeBootstrapScriptWrapsReplayCommands(t *testing.T) {
+	if got := resumeBootstrapScript(nil, "claude --continue", "/bin/zsh"); got != "claude --continue; exec '/bin/zsh' -l" {
+		t.Fatalf("unexpected bootstrap without replay: %q", got)
+	}
+	got := resumeBootstrapScript([]string{"source .env", "nvm use"}, "claude --continue", "/bin/zsh")
+	want := "set -a; source .env; nvm use; set +a; claude --continue; exec '/bin/zsh' -l"
+	if got != want {
+		t.Fatalf("unexpected bootstrap with replay:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestTerminalSnapshotsCarryAgentReplayCommands(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+
+	agent, err := app.CreateAgentSession(0, "", "")
+	if err != nil {
+		t.Fatalf("CreateAgentSession returned error: %v", err)
+	}
+	if _, err := app.SetAgentReplayCommands(agent.ID, []string{"source .env\nrm -rf /"}); err == nil {
+		t.Fatalf("expected multi-line replay command to be rejected")
+	}
+	saved, err := app.SetAgentReplayCommands(agent.ID, []string{" source .env ", "", "nvm use"})
+	if err != nil {
+		t.Fatalf("SetAgentReplayCommands returned error: %v", err)
+	}
+	want := []string{"source .env", "nvm use"}
+	if !reflect.DeepEqual(saved, want) {
+		t.Fatalf("unexpected normalized commands: %v", saved)
+	}
+	if got, _ := app.GetAgentReplayCommands(agent.ID); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected stored commands: %v", got)
+	}
+
+	app.bindTerminalToAgent("session-1", agent.ID)
+	err = app.SaveTerminalSnapshots([]TerminalSnapshotDTO{
+		{PaneID: "pane-agent", SessionID: "session-1", PaneType: "terminal", Shell: "/bin/bash"},
+		{PaneID: "pane-explicit", SessionID: "session-2", PaneType: "terminal", Shell: "/bin/bash", ReplayCommands: []string{"export FOO=1"}},
+		{PaneID: "pane-plain", SessionID: "session-3", PaneType: "terminal", Shell: "/bin/bash"},
+	})
+	if err != nil {
+		t.Fatalf("SaveTerminalSnapshots returned error: %v", err)
+	}
+	snapshots, err := app.GetTerminalSnapshots()
+	if err != nil {
+		t.Fatalf("GetTerminalSnapshots returned error: %v", err)
+	}
+	byPane := make(map[string][]string, len(snapshots))
+	for _, snapshot := range snapshots {
+		byPane[snapshot.PaneID] = snapshot.ReplayCommands
+	}
+	if !reflect.DeepEqual(byPane["pane-agent"], want) {
+		t.Fatalf("expected agent replay commands in snapshot, got %v", byPane["pane-agent"])
+	}
+	if !reflect.DeepEqual(byPane["pane-explicit"], []string{"export FOO=1"}) {
+		t.Fatalf("expected explicit replay commands in snapshot, got %v", byPane["pane-explicit"])
+	}
+	if len(byPane["pane-plain"]) != 0 {
+		t.Fatalf("expected no replay commands for unbound pane, got %v", byPane["pane-plain"])
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+
+	"orch/internal/ai"
+)
+
+func TestAISummarizeTerminalOutputValidatesInput(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	if _, err := app.AISummarizeTerminalOutput("term-1", 100); err == nil {
+		t.Fatalf("expected error without AI service")
+	}
+
+	app.ai = ai.NewService(ai.ServiceDeps{})
+	if _, err := app.AISummarizeTerminalOutput("", 100); err == nil {
+		t.Fatalf("expected missing session to fail")
+	}
+	if _, err := app.AISummarizeTerminalOutput("term-1", 100); err == nil {
+		t.Fatalf("expected terminal without output to fail")
+	}
+}
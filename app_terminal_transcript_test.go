@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"orch/internal/i18n"
+	"orch/internal/terminal"
+)
+
+func TestGetTerminalTranscriptUsesInputObserverAndLanguage(t *testing.T) {
+	app := NewApp()
+	app.transcripts = terminal.NewTranscriptRecorder()
+	app.translator.SetLocale(i18n.LocaleENUS)
+
+	app.observeTerminalInput("term-1", []byte("echo hi\r"))
+	app.transcripts.ObserveOutput("term-1", []byte("echo hi\r\n\x1b[1mhi\x1b[0m\r\n"))
+
+	transcript, err := app.GetTerminalTranscript("term-1", true)
+	if err != nil {
+		t.Fatalf("GetTerminalTranscript error: %v", err)
+	}
+	if len(transcript.Entries) != 1 || transcript.Entries[0].Command != "echo hi" || transcript.Entries[0].Output != "hi" {
+		t.Fatalf("unexpected entries: %+v", transcript.Entries)
+	}
+	if !strings.Contains(transcript.Text, "Command 1, at ") || !strings.Contains(transcript.Text, "End of command 1.") {
+		t.Fatalf("expected English annotations, got:\n%s", transcript.Text)
+	}
+
+	if _, err := app.GetTerminalTranscript("missing", true); err == nil {
+		t.Fatalf("expected error for unknown session")
+	}
+	if _, err := app.GetTerminalTranscript(" ", false); err == nil {
+		t.Fatalf("expected error for empty session id")
+	}
+}
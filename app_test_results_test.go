@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"orch/internal/terminal"
+)
+
+func TestGetLastTestResultsReturnsRunForAgent(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	if _, err := app.GetLastTestResults(0); err == nil {
+		t.Fatalf("expected missing agentID to fail")
+	}
+	if run, err := app.GetLastTestResults(5); err != nil || run != nil {
+		t.Fatalf("expected no results without tracker, got %#v (%v)", run, err)
+	}
+
+	app.testRuns = terminal.NewTestRunTracker(10, nil)
+	app.testRuns.SetAgentResolver(func(string) uint { return 5 })
+	app.testRuns.ObserveInput("term-1", []byte("go test -v ./...\r"))
+	app.testRuns.ObserveOutput("term-1", []byte("--- FAIL: TestLogin (0.01s)\nFAIL\n"))
+	app.testRuns.ObserveInput("term-1", []byte("git status\r"))
+
+	run, err := app.GetLastTestResults(5)
+	if err != nil || run == nil {
+		t.Fatalf("GetLastTestResults returned (%#v, %v)", run, err)
+	}
+	if run.Status != terminal.TestRunFailed || run.Failed != 1 || len(run.FailingTests) != 1 || run.FailingTests[0] != "TestLogin" {
+		t.Fatalf("unexpected run: %#v", run)
+	}
+}
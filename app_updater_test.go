@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestSetUpdateChannelPersistsNormalizedChannel(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	t.Cleanup(func() { _ = app.SetUpdateChannel("stable") })
+
+	if err := app.SetUpdateChannel("nightly"); err == nil {
+		t.Fatalf("expected unknown channel to be rejected")
+	}
+	if err := app.SetUpdateChannel(" Beta "); err != nil {
+		t.Fatalf("SetUpdateChannel returned error: %v", err)
+	}
+	if got := app.GetUpdateChannel(); got != "beta" {
+		t.Fatalf("expected beta channel, got %q", got)
+	}
+	if _, err := app.CheckForUpdates(); err == nil {
+		t.Fatalf("expected CheckForUpdates to fail without updater")
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"orch/internal/database"
+)
+
+func TestExportImportWorkspaceRoundTripRedactsSecrets(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	projectDir := t.TempDir()
+
+	source := &database.Workspace{Name: "Payments", Path: projectDir, Color: "#ff0000"}
+	agents := []database.AgentSession{
+		{
+			Name:        "api",
+			Type:        "terminal",
+			Shell:       "/bin/zsh",
+			Cwd:         filepath.Join(projectDir, "api"),
+			Env:         encodeStringList([]string{"PORT=8080", "STRIPE_API_KEY=sk_live_123", "GITHUB_TOKEN=ghp_abc"}),
+			StartupCmds: encodeStringList([]string{"make dev"}),
+			LayoutJSON:  `{"x":1}`,
+		},
+	}
+	if err := app.db.CreateWorkspaceWithAgents(source, agents); err != nil {
+		t.Fatalf("CreateWorkspaceWithAgents returned error: %v", err)
+	}
+
+	exportPath, err := app.ExportWorkspace(source.ID)
+	if err != nil {
+		t.Fatalf("ExportWorkspace returned error: %v", err)
+	}
+	raw, err := os.ReadFile(exportPath)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	if strings.Contains(string(raw), "sk_live_123") || strings.Contains(string(raw), "ghp_abc") {
+		t.Fatalf("export leaked secret values: %s", raw)
+	}
+	if !strings.Contains(string(raw), `"cwd": "api"`) {
+		t.Fatalf("expected agent cwd to be relative to workspace path: %s", raw)
+	}
+
+	imported, err := app.ImportWorkspace(exportPath)
+	if err != nil {
+		t.Fatalf("ImportWorkspace returned error: %v", err)
+	}
+	if imported.Name != "Payments (2)" || imported.Path != projectDir || !imported.IsActive {
+		t.Fatalf("unexpected imported workspace: %+v", imported)
+	}
+
+	importedAgents, err := app.db.ListAgents(imported.ID)
+	if err != nil || len(importedAgents) != 1 {
+		t.Fatalf("unexpected imported agents: %v %+v", err, importedAgents)
+	}
+	agent := importedAgents[0]
+	if agent.Cwd != filepath.Join(projectDir, "api") || agent.LayoutJSON != `{"x":1}` {
+		t.Fatalf("unexpected imported agent: %+v", agent)
+	}
+	env := strings.Join(decodeAgentStringList(agent.Env), ",")
+	if env != "PORT=8080,STRIPE_API_KEY={{secret}},GITHUB_TOKEN={{secret}}" {
+		t.Fatalf("unexpected imported env: %s", env)
+	}
+}
+
+func TestParseWorkspaceBundleRejectsUnknownFormat(t *testing.T) {
+	if _, err := parseWorkspaceBundle([]byte(`{"format":"other","version":1}`)); err == nil {
+		t.Fatalf("expected unknown format to be rejected")
+	}
+	if _, err := parseWorkspaceBundle([]byte(`{"format":"orch.workspace","version":99}`)); err == nil {
+		t.Fatalf("expected future version to be rejected")
+	}
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"orch/internal/editor"
+	"orch/internal/filesearch"
+	fw "orch/internal/filewatcher"
+	"orch/internal/workspacefs"
+)
+
+func newFakeGitRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatalf("failed to create fake .git dir: %v", err)
+	}
+	return dir
+}
+
+func TestWorkspaceRepositoriesScopeAgentsAndEvents(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	// O primeiro terminal sempre nasce no workspace "Default"; consome essa regra antes.
+	if _, err := app.CreateAgentSession(0, "bootstrap", "terminal"); err != nil {
+		t.Fatalf("CreateAgentSession(bootstrap) returned error: %v", err)
+	}
+
+	ws, err := app.CreateWorkspace("Platform")
+	if err != nil {
+		t.Fatalf("CreateWorkspace returned error: %v", err)
+	}
+	frontend := newFakeGitRepo(t)
+	backend := newFakeGitRepo(t)
+
+	first, err := app.AddWorkspaceRepository(ws.ID, filepath.Join(frontend, "."), "")
+	if err != nil {
+		t.Fatalf("AddWorkspaceRepository returned error: %v", err)
+	}
+	if !first.IsPrimary || first.Name != filepath.Base(frontend) {
+		t.Fatalf("expected first repo to be primary: %+v", first)
+	}
+	if _, err := app.AddWorkspaceRepository(ws.ID, backend, "backend"); err != nil {
+		t.Fatalf("AddWorkspaceRepository(backend) returned error: %v", err)
+	}
+	if _, err := app.AddWorkspaceRepository(ws.ID, backend, "dup"); err == nil {
+		t.Fatalf("expected duplicated repo to be rejected")
+	}
+	if _, err := app.AddWorkspaceRepository(ws.ID, t.TempDir(), ""); err == nil {
+		t.Fatalf("expected non-git directory to be rejected")
+	}
+
+	agent, err := app.CreateAgentSession(ws.ID, "api", "terminal")
+	if err != nil {
+		t.Fatalf("CreateAgentSession returned error: %v", err)
+	}
+
+	repoPath, err := app.GitPanelResolveAgentRepository(agent.ID)
+	if err != nil || repoPath != frontend {
+		t.Fatalf("expected primary repo for unbound agent, got %q err=%v", repoPath, err)
+	}
+
+	if _, err := app.BindAgentToRepository(agent.ID, t.TempDir()); err == nil {
+		t.Fatalf("expected binding to a foreign repo to fail")
+	}
+	bound, err := app.BindAgentToRepository(agent.ID, backend)
+	if err != nil {
+		t.Fatalf("BindAgentToRepository returned error: %v", err)
+	}
+	if bound.RepoPath != backend {
+		t.Fatalf("unexpected bound repo: %q", bound.RepoPath)
+	}
+
+	workspaceIDs, agentIDs := app.resolveRepoScope(backend)
+	if joinUintIDs(workspaceIDs) != joinUintIDs([]uint{ws.ID}) || joinUintIDs(agentIDs) != joinUintIDs([]uint{agent.ID}) {
+		t.Fatalf("unexpected repo scope: workspaces=%v agents=%v", workspaceIDs, agentIDs)
+	}
+
+	repos, err := app.ListWorkspaceRepositories(ws.ID)
+	if err != nil || len(repos) != 2 {
+		t.Fatalf("unexpected repos: %v %+v", err, repos)
+	}
+	if err := app.RemoveWorkspaceRepository(repos[1].ID); err != nil {
+		t.Fatalf("RemoveWorkspaceRepository returned error: %v", err)
+	}
+	reloaded, err := app.db.GetAgent(agent.ID)
+	if err != nil || reloaded.RepoPath != "" {
+		t.Fatalf("expected agent binding to be cleared, got %q err=%v", reloaded.RepoPath, err)
+	}
+}
+
+func TestSetWorkspaceFileWatchingPersistsAndWatchesPrimaryRepo(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	watcher, err := fw.NewService(nil)
+	if err != nil {
+		t.Fatalf("fw.NewService returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = watcher.Close() })
+	app.fileWatcher = watcher
+
+	ws, err := app.CreateWorkspace("Platform")
+	if err != nil {
+		t.Fatalf("CreateWorkspace returned error: %v", err)
+	}
+	if err := app.SetWorkspaceFileWatching(ws.ID, true); err == nil {
+		t.Fatalf("expected workspace without path or repos to be rejected")
+	}
+
+	repo := newFakeGitRepo(t)
+	if _, err := app.AddWorkspaceRepository(ws.ID, repo, ""); err != nil {
+		t.Fatalf("AddWorkspaceRepository returned error: %v", err)
+	}
+	if err := app.SetWorkspaceFileWatching(ws.ID, true); err != nil {
+		t.Fatalf("SetWorkspaceFileWatching returned error: %v", err)
+	}
+	if saved, _ := app.db.GetWorkspace(ws.ID); !saved.WatchFiles {
+		t.Fatalf("expected WatchFiles to be persisted")
+	}
+	if roots := watcher.WatchedWorkspaces(); roots[ws.ID] != repo {
+		t.Fatalf("expected primary repo to be watched, got %#v", roots)
+	}
+
+	// Restore sincroniza com o banco: workspaces habilitados voltam a ser monitorados.
+	watcher.UnwatchWorkspaceFiles(ws.ID)
+	app.restoreWorkspaceFileWatching()
+	if roots := watcher.WatchedWorkspaces(); roots[ws.ID] != repo {
+		t.Fatalf("expected restore to watch enabled workspace, got %#v", roots)
+	}
+
+	other, err := app.CreateWorkspace("Other")
+	if err != nil {
+		t.Fatalf("CreateWorkspace returned error: %v", err)
+	}
+	if err := app.DeleteWorkspace(ws.ID); err != nil {
+		t.Fatalf("DeleteWorkspace returned error: %v", err)
+	}
+	if roots := watcher.WatchedWorkspaces(); len(roots) != 0 {
+		t.Fatalf("expected deleted workspace to be unwatched, got %#v", roots)
+	}
+	if err := app.SetWorkspaceFileWatching(other.ID, false); err != nil {
+		t.Fatalf("disabling file watching returned error: %v", err)
+	}
+}
+
+func TestFuzzyFindFilesSearchesWorkspaceRoot(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	app.fileSearch = filesearch.NewService()
+
+	ws, err := app.CreateWorkspace("Search")
+	if err != nil {
+		t.Fatalf("CreateWorkspace returned error: %v", err)
+	}
+	if _, err := app.FuzzyFindFiles(ws.ID, "main", 10); err == nil {
+		t.Fatalf("expected workspace without path or repos to be rejected")
+	}
+
+	repo := newFakeGitRepo(t)
+	for _, file := range []string{"cmd/main.go", "README.md"} {
+		path := filepath.Join(repo, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	if _, err := app.AddWorkspaceRepository(ws.ID, repo, ""); err != nil {
+		t.Fatalf("AddWorkspaceRepository returned error: %v", err)
+	}
+
+	matches, err := app.FuzzyFindFiles(ws.ID, "cmdmain", 10)
+	if err != nil {
+		t.Fatalf("FuzzyFindFiles returned error: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Path != "cmd/main.go" {
+		t.Fatalf("unexpected matches: %#v", matches)
+	}
+}
+
+func TestSearchInWorkspaceRunsAndCancels(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	app.fileSearch = filesearch.NewService()
+
+	ws, err := app.CreateWorkspace("Search")
+	if err != nil {
+		t.Fatalf("CreateWorkspace returned error: %v", err)
+	}
+	repo := newFakeGitRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := app.AddWorkspaceRepository(ws.ID, repo, ""); err != nil {
+		t.Fatalf("AddWorkspaceRepository returned error: %v", err)
+	}
+
+	if _, err := app.SearchInWorkspace(ws.ID, "(", true, nil); err == nil {
+		t.Fatalf("expected invalid regex to be rejected synchronously")
+	}
+	first, err := app.SearchInWorkspace(ws.ID, "package", false, []string{"*.go"})
+	if err != nil {
+		t.Fatalf("SearchInWorkspace returned error: %v", err)
+	}
+	second, err := app.SearchInWorkspace(ws.ID, "main", false, nil)
+	if err != nil || second == first {
+		t.Fatalf("expected a new search id, got %q (first %q) err=%v", second, first, err)
+	}
+	app.CancelWorkspaceSearch(second)
+	app.CancelWorkspaceSearch("unknown")
+
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		app.searchMu.Lock()
+		running := len(app.searches)
+		app.searchMu.Unlock()
+		if running == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("searches did not finish: %d running", running)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReadWriteWorkspaceFileRoundTrip(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+
+	ws, err := app.CreateWorkspace("Editor")
+	if err != nil {
+		t.Fatalf("CreateWorkspace returned error: %v", err)
+	}
+	repo := newFakeGitRepo(t)
+	if _, err := app.AddWorkspaceRepository(ws.ID, repo, ""); err != nil {
+		t.Fatalf("AddWorkspaceRepository returned error: %v", err)
+	}
+
+	written, err := app.WriteWorkspaceFile(ws.ID, "conflict.txt", "ours\ntheirs\n", "")
+	if err != nil {
+		t.Fatalf("WriteWorkspaceFile returned error: %v", err)
+	}
+	read, err := app.ReadWorkspaceFile(ws.ID, "conflict.txt", workspacefs.ReadRange{StartLine: 2})
+	if err != nil || read.Content != "theirs\n" || read.Hash != written.Hash {
+		t.Fatalf("unexpected read: %#v err=%v", read, err)
+	}
+	if _, err := app.WriteWorkspaceFile(ws.ID, "conflict.txt", "resolved\n", "stale"); !errors.Is(err, workspacefs.ErrHashMismatch) {
+		t.Fatalf("expected stale hash to be rejected, got %v", err)
+	}
+	if _, err := app.ReadWorkspaceFile(ws.ID, "../outside.txt", workspacefs.ReadRange{}); !errors.Is(err, workspacefs.ErrOutsideRoot) {
+		t.Fatalf("expected sandbox violation, got %v", err)
+	}
+}
+
+func TestOpenInEditorResolvesWorkspaceRepositoriesAndPreference(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+
+	ws, err := app.CreateWorkspace("Editors")
+	if err != nil {
+		t.Fatalf("CreateWorkspace returned error: %v", err)
+	}
+	primary, secondary := newFakeGitRepo(t), newFakeGitRepo(t)
+	for _, repo := range []string{primary, secondary} {
+		if _, err := app.AddWorkspaceRepository(ws.ID, repo, ""); err != nil {
+			t.Fatalf("AddWorkspaceRepository returned error: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	if err := app.SetWorkspaceEditor(ws.ID, "vscode-ish"); !errors.Is(err, editor.ErrUnknownEditor) {
+		t.Fatalf("expected unknown editor to be rejected, got %v", err)
+	}
+	if err := app.SetWorkspaceEditor(ws.ID, " GoLand "); err != nil {
+		t.Fatalf("SetWorkspaceEditor returned error: %v", err)
+	}
+	stored, err := app.db.GetWorkspace(ws.ID)
+	if err != nil || stored.Editor != "goland" {
+		t.Fatalf("expected editor preference to be persisted, got %#v err=%v", stored, err)
+	}
+
+	target, dir, err := app.resolveEditorTarget(stored, "main.go")
+	if err != nil || target != filepath.Join(primary, "main.go") || dir != primary {
+		t.Fatalf("expected relative path inside the primary repo, got %q dir=%q err=%v", target, dir, err)
+	}
+	target, dir, err = app.resolveEditorTarget(stored, filepath.Join(secondary, "main.go"))
+	if err != nil || target != filepath.Join(secondary, "main.go") || dir != secondary {
+		t.Fatalf("expected absolute path inside a secondary repo, got %q dir=%q err=%v", target, dir, err)
+	}
+	if _, _, err := app.resolveEditorTarget(stored, filepath.Join(t.TempDir(), "x.go")); !errors.Is(err, workspacefs.ErrOutsideRoot) {
+		t.Fatalf("expected path outside the workspace to be rejected, got %v", err)
+	}
+	if _, _, err := app.resolveEditorTarget(stored, "missing.go"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected missing file error, got %v", err)
+	}
+}
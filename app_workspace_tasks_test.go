@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"orch/internal/filesearch"
+	"orch/internal/tasks"
+)
+
+func TestWorkspaceTasksMergeManualAndCommentTasks(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	app.fileSearch = filesearch.NewService()
+	app.taskComments = tasks.NewIndex(app.fileSearch.Files)
+
+	ws, err := app.CreateWorkspace("Tasks")
+	if err != nil {
+		t.Fatalf("CreateWorkspace returned error: %v", err)
+	}
+	repo := newFakeGitRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n\n// TODO: wire the config\n"), 0o644); err != nil {
+		t.Fatalf("write main.go: %v", err)
+	}
+	if _, err := app.AddWorkspaceRepository(ws.ID, repo, ""); err != nil {
+		t.Fatalf("AddWorkspaceRepository returned error: %v", err)
+	}
+
+	if _, err := app.CreateWorkspaceTask(ws.ID, "escape", "../outside.go", 1); err == nil {
+		t.Fatalf("expected path outside the workspace to be rejected")
+	}
+	manual, err := app.CreateWorkspaceTask(ws.ID, "  Write release notes ", "docs/notes.md", 3)
+	if err != nil || manual.Title != "Write release notes" || manual.Source != "manual" {
+		t.Fatalf("CreateWorkspaceTask returned (%+v, %v)", manual, err)
+	}
+
+	list, err := app.ListWorkspaceTasks(ws.ID, false)
+	if err != nil {
+		t.Fatalf("ListWorkspaceTasks returned error: %v", err)
+	}
+	if len(list.Tasks) != 2 || list.Open != 2 || list.Tasks[1].Kind != "TODO" || list.Tasks[1].Path != "main.go" || list.Tasks[1].Line != 3 {
+		t.Fatalf("unexpected task list: %+v", list)
+	}
+
+	if _, err := app.SetWorkspaceTaskDone(ws.ID, list.Tasks[1].ID, true); err != nil {
+		t.Fatalf("SetWorkspaceTaskDone(comment) returned error: %v", err)
+	}
+	if _, err := app.SetWorkspaceTaskDone(ws.ID, manual.ID, true); err != nil {
+		t.Fatalf("SetWorkspaceTaskDone(manual) returned error: %v", err)
+	}
+	list, _ = app.ListWorkspaceTasks(ws.ID, false)
+	if len(list.Tasks) != 0 || list.Done != 2 || list.Open != 0 {
+		t.Fatalf("expected completed tasks to be hidden, got %+v", list)
+	}
+	list, _ = app.ListWorkspaceTasks(ws.ID, true)
+	if len(list.Tasks) != 2 || !list.Tasks[0].Done || !list.Tasks[1].Done || list.Tasks[1].DoneAt == nil {
+		t.Fatalf("expected completed tasks when includeDone, got %+v", list)
+	}
+
+	if _, err := app.SetWorkspaceTaskDone(ws.ID, list.Tasks[1].ID, false); err != nil {
+		t.Fatalf("reopening comment task returned error: %v", err)
+	}
+	if list, _ := app.ListWorkspaceTasks(ws.ID, false); list.Open != 1 || list.Done != 1 {
+		t.Fatalf("expected reopened TODO to count as open, got %+v", list)
+	}
+	if err := app.DeleteWorkspaceTask(1 << 20); err == nil {
+		t.Fatalf("expected unknown task to be rejected")
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeWorkspaceTemplateRejectsInvalidEnv(t *testing.T) {
+	_, err := normalizeWorkspaceTemplate(WorkspaceTemplateDTO{
+		Name: "api",
+		Env:  []string{"NOT VALID"},
+	})
+	if err == nil {
+		t.Fatalf("expected invalid env entry to be rejected")
+	}
+
+	_, err = normalizeWorkspaceTemplate(WorkspaceTemplateDTO{
+		Name: "api",
+		Agents: []WorkspaceTemplateAgentDTO{
+			{Name: "dev", StartupCommands: []string{"npm ci\nrm -rf /"}},
+		},
+	})
+	if err == nil {
+		t.Fatalf("expected multi-line startup command to be rejected")
+	}
+}
+
+func TestCreateWorkspaceFromTemplateProvisionsAgents(t *testing.T) {
+	app := newTestAppWithDatabase(t)
+	projectDir := t.TempDir()
+
+	saved, err := app.SaveWorkspaceTemplate(WorkspaceTemplateDTO{
+		Name:        " Node API ",
+		DockerImage: "node:20-alpine",
+		Env:         []string{"NODE_ENV=development"},
+		Agents: []WorkspaceTemplateAgentDTO{
+			{Name: "server", Cwd: "services/api", Env: []string{"PORT=3000"}, StartupCommands: []string{"npm run dev", " "}},
+			{Type: "ai_agent", Shell: "/bin/bash"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SaveWorkspaceTemplate returned error: %v", err)
+	}
+	if saved.ID == 0 || saved.Name != "Node API" {
+		t.Fatalf("unexpected saved template: %+v", saved)
+	}
+
+	ws, err := app.CreateWorkspaceFromTemplate(saved.ID, "", projectDir)
+	if err != nil {
+		t.Fatalf("CreateWorkspaceFromTemplate returned error: %v", err)
+	}
+	if ws.Name != "Node API" || ws.Path != projectDir || ws.DockerImage != "node:20-alpine" || !ws.IsActive {
+		t.Fatalf("unexpected workspace: %+v", ws)
+	}
+
+	agents, err := app.db.ListAgents(ws.ID)
+	if err != nil {
+		t.Fatalf("ListAgents returned error: %v", err)
+	}
+	if len(agents) != 2 {
+		t.Fatalf("expected 2 agents, got %d", len(agents))
+	}
+
+	server := agents[0]
+	if server.Cwd != filepath.Join(projectDir, "services/api") {
+		t.Fatalf("unexpected server cwd: %q", server.Cwd)
+	}
+	env := decodeAgentStringList(server.Env)
+	if strings.Join(env, ",") != "NODE_ENV=development,PORT=3000" {
+		t.Fatalf("unexpected server env: %v", env)
+	}
+	if cmds := decodeAgentStringList(server.StartupCmds); len(cmds) != 1 || cmds[0] != "npm run dev" {
+		t.Fatalf("unexpected startup commands: %v", cmds)
+	}
+
+	second := agents[1]
+	if second.Type != "ai_agent" || second.Shell != "/bin/bash" || second.Name != "Terminal 2" || second.Cwd != projectDir {
+		t.Fatalf("unexpected second agent: %+v", second)
+	}
+}
@@ -0,0 +1,351 @@
+// Command orchctl controla o ORCH em execução a partir de scripts e CI, usando o gateway local
+// do app: entra em sessões de colaboração, lista PRs, acompanha terminais e dispara builds de stack.
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"orch/internal/cliapi"
+	"orch/internal/session"
+)
+
+const defaultGatewayURL = "http://127.0.0.1:9888"
+
+const usage = `usage: orchctl [--gateway URL] [--token-file PATH] <command> [flags]
+
+commands:
+  workspaces                         list workspaces and agent IDs
+  prs --workspace ID [--state S]     list pull requests (open, closed, all)
+  status --workspace ID              show the Git status of a workspace
+  tail --agent ID [-f] [-n LINES]    print (and follow) an agent's terminal output
+  build [--name STACK] [--wait]      build a stack image with its saved tools
+  session join CODE [--wait]         ask to join a collaboration session
+
+The gateway URL defaults to $ORCH_SESSION_GATEWAY_BASE_URL or ` + defaultGatewayURL + `.
+The token is read from $ORCH_CLI_TOKEN or from the file written by the app.
+`
+
+// errUsage sinaliza argumentos inválidos (exit code 2).
+var errUsage = errors.New("invalid usage")
+
+type cli struct {
+	client *cliapi.Client
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	global := flag.NewFlagSet("orchctl", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.Usage = func() { fmt.Fprint(stderr, usage) }
+	gateway := global.String("gateway", cmp.Or(os.Getenv("ORCH_SESSION_GATEWAY_BASE_URL"), defaultGatewayURL), "gateway base URL")
+	tokenFile := global.String("token-file", cliapi.TokenPath(), "file with the CLI token")
+	if err := global.Parse(args); err != nil {
+		return 2
+	}
+	if global.NArg() == 0 {
+		global.Usage()
+		return 2
+	}
+
+	token := strings.TrimSpace(os.Getenv("ORCH_CLI_TOKEN"))
+	if token == "" {
+		// Sem token ainda dá para entrar em sessões; as rotas do CLI respondem 401.
+		token, _ = cliapi.ReadToken(*tokenFile)
+	}
+	c := &cli{client: cliapi.NewClient(*gateway, token, nil), stdout: stdout, stderr: stderr}
+
+	var err error
+	command, rest := global.Arg(0), global.Args()[1:]
+	switch command {
+	case "workspaces":
+		err = c.workspaces(rest)
+	case "prs":
+		err = c.prs(rest)
+	case "status":
+		err = c.status(rest)
+	case "tail":
+		err = c.tail(rest)
+	case "build":
+		err = c.build(rest)
+	case "session":
+		err = c.session(rest)
+	default:
+		fmt.Fprintf(stderr, "orchctl: unknown command %q\n\n", command)
+		global.Usage()
+		return 2
+	}
+	if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "orchctl:", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *cli) newFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("orchctl "+name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	return fs
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
+
+func (c *cli) printJSON(value interface{}) error {
+	encoder := json.NewEncoder(c.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func (c *cli) workspaces(args []string) error {
+	fs := c.newFlags("workspaces")
+	asJSON := fs.Bool("json", false, "print JSON")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	workspaces, err := c.client.Workspaces()
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return c.printJSON(workspaces)
+	}
+	tw := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "WORKSPACE\tAGENT\tNAME\tTYPE\tRUNNING")
+	for _, ws := range workspaces {
+		fmt.Fprintf(tw, "%d\t\t%s\t\t\n", ws.ID, ws.Name)
+		for _, agent := range ws.Agents {
+			fmt.Fprintf(tw, "\t%d\t%s\t%s\t%t\n", agent.ID, agent.Name, agent.Type, agent.Running)
+		}
+	}
+	return tw.Flush()
+}
+
+func (c *cli) prs(args []string) error {
+	fs := c.newFlags("prs")
+	workspaceID := fs.Uint("workspace", 0, "workspace ID (see orchctl workspaces)")
+	state := fs.String("state", "open", "open, closed or all")
+	asJSON := fs.Bool("json", false, "print JSON")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *workspaceID == 0 {
+		return fmt.Errorf("--workspace is required")
+	}
+	prs, err := c.client.PullRequests(*workspaceID, *state)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return c.printJSON(prs)
+	}
+	tw := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tSTATE\tAUTHOR\tBRANCH\tTITLE")
+	for _, pr := range prs {
+		state := pr.State
+		if pr.IsDraft {
+			state += " (draft)"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s -> %s\t%s\n", pr.Number, state, pr.Author.Login, pr.HeadBranch, pr.BaseBranch, pr.Title)
+	}
+	return tw.Flush()
+}
+
+func (c *cli) status(args []string) error {
+	fs := c.newFlags("status")
+	workspaceID := fs.Uint("workspace", 0, "workspace ID (see orchctl workspaces)")
+	asJSON := fs.Bool("json", false, "print JSON")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *workspaceID == 0 {
+		return fmt.Errorf("--workspace is required")
+	}
+	status, err := c.client.GitStatus(*workspaceID)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return c.printJSON(status)
+	}
+	branch := status.Branch
+	if status.Upstream != "" {
+		branch += " -> " + status.Upstream
+	}
+	fmt.Fprintf(c.stdout, "branch %s (ahead %d, behind %d)\n", branch, status.Ahead, status.Behind)
+	if status.Operation != "" {
+		fmt.Fprintf(c.stdout, "in progress: %s\n", status.Operation)
+	}
+	for _, file := range status.Staged {
+		fmt.Fprintf(c.stdout, "  staged     %s\n", file.Path)
+	}
+	for _, file := range status.Unstaged {
+		fmt.Fprintf(c.stdout, "  unstaged   %s\n", file.Path)
+	}
+	for _, file := range status.Conflicted {
+		fmt.Fprintf(c.stdout, "  conflicted %s\n", file.Path)
+	}
+	return nil
+}
+
+func (c *cli) tail(args []string) error {
+	fs := c.newFlags("tail")
+	agentID := fs.Uint("agent", 0, "agent ID (see orchctl workspaces)")
+	follow := fs.Bool("f", false, "keep printing new output until the terminal exits")
+	lines := fs.Int("n", 0, "only print the last N lines of the current output")
+	interval := fs.Duration("interval", time.Second, "poll interval with -f")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *agentID == 0 {
+		return fmt.Errorf("--agent is required")
+	}
+
+	chunk, err := c.client.TerminalOutput(*agentID, 0)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(c.stdout, lastLines(chunk.Output, *lines))
+	for *follow && chunk.Running {
+		time.Sleep(*interval)
+		if chunk, err = c.client.TerminalOutput(*agentID, chunk.Cursor); err != nil {
+			return err
+		}
+		if chunk.Truncated {
+			fmt.Fprintln(c.stdout, "\n[orchctl: output skipped]")
+		}
+		fmt.Fprint(c.stdout, chunk.Output)
+	}
+	return nil
+}
+
+func lastLines(output string, n int) string {
+	if n <= 0 {
+		return output
+	}
+	lines := strings.SplitAfter(output, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines[max(len(lines)-n, 0):], "")
+}
+
+func (c *cli) build(args []string) error {
+	fs := c.newFlags("build")
+	name := fs.String("name", "", "stack name (default stack when empty)")
+	wait := fs.Bool("wait", false, "stream the build log and exit non-zero if it fails")
+	interval := fs.Duration("interval", time.Second, "poll interval with --wait")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := c.client.StartStackBuild(cliapi.StackBuildRequest{Name: *name}); err != nil {
+		return err
+	}
+	if !*wait {
+		fmt.Fprintln(c.stdout, "build started")
+		return nil
+	}
+
+	printed := 0
+	for {
+		state, err := c.client.StackBuildState()
+		if err != nil {
+			return err
+		}
+		for ; printed < len(state.Logs); printed++ {
+			fmt.Fprintln(c.stdout, state.Logs[printed])
+		}
+		if !state.IsBuilding {
+			if state.Result != "success" {
+				return fmt.Errorf("stack build failed")
+			}
+			return nil
+		}
+		time.Sleep(*interval)
+	}
+}
+
+func (c *cli) session(args []string) error {
+	if len(args) == 0 || args[0] != "join" {
+		fmt.Fprintln(c.stderr, "usage: orchctl session join CODE [--user ID] [--name NAME] [--email EMAIL] [--wait]")
+		return errUsage
+	}
+	fs := c.newFlags("session join")
+	guestUserID := fs.String("user", "", "guest user ID (default cli:<login>)")
+	name := fs.String("name", "", "name shown to the host")
+	email := fs.String("email", "", "email shown to the host")
+	wait := fs.Bool("wait", false, "wait until the host approves or rejects the request")
+	timeout := fs.Duration("timeout", 5*time.Minute, "how long to wait with --wait")
+	interval := fs.Duration("interval", 2*time.Second, "poll interval with --wait")
+	// O código vem antes das flags: orchctl session join ABC-123 --wait
+	if len(args) < 2 || strings.HasPrefix(args[1], "-") {
+		fmt.Fprintln(c.stderr, "usage: orchctl session join CODE [flags]")
+		return errUsage
+	}
+	code := args[1]
+	if err := parseFlags(fs, args[2:]); err != nil {
+		return err
+	}
+
+	login := "orchctl"
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		login = current.Username
+	}
+	userID := cmp.Or(strings.TrimSpace(*guestUserID), "cli:"+login)
+	info := session.GuestInfo{Name: cmp.Or(strings.TrimSpace(*name), login), Email: strings.TrimSpace(*email)}
+
+	result, err := c.client.JoinSession(code, userID, info)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stdout, "join requested: session %s (host %s), status %s\n", result.SessionID, result.HostName, result.Status)
+	if !*wait {
+		return nil
+	}
+
+	deadline := time.Now().Add(*timeout)
+	for time.Now().Before(deadline) {
+		sess, err := c.client.GetSession(result.SessionID)
+		if err != nil {
+			return err
+		}
+		for _, guest := range sess.Guests {
+			if guest.UserID != userID {
+				continue
+			}
+			switch guest.Status {
+			case session.GuestApproved, session.GuestConnected:
+				fmt.Fprintln(c.stdout, "approved")
+				return nil
+			case session.GuestRejected, session.GuestExpired:
+				return fmt.Errorf("join request %s", guest.Status)
+			}
+		}
+		time.Sleep(*interval)
+	}
+	return fmt.Errorf("timed out after %s waiting for approval", *timeout)
+}
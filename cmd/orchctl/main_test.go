@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"orch/internal/cliapi"
+	gh "orch/internal/github"
+	gp "orch/internal/gitpanel"
+)
+
+// scriptedBackend simula um build que termina após algumas consultas e um terminal que encerra.
+type scriptedBackend struct {
+	mu        sync.Mutex
+	polls     int
+	buildFail bool
+}
+
+func (b *scriptedBackend) Workspaces() ([]cliapi.Workspace, error) { return nil, nil }
+
+func (b *scriptedBackend) PullRequests(uint, string) ([]gh.PullRequest, error) {
+	return []gh.PullRequest{{Number: 3, Title: "Add CLI", State: "OPEN", HeadBranch: "feat/cli", BaseBranch: "main"}}, nil
+}
+
+func (b *scriptedBackend) GitStatus(uint) (gp.StatusDTO, error) { return gp.StatusDTO{}, nil }
+
+func (b *scriptedBackend) TerminalOutput(_ uint, cursor int64) (cliapi.TerminalChunk, error) {
+	if cursor == 0 {
+		return cliapi.TerminalChunk{Output: "one\ntwo\nthree\n", Cursor: 14, Running: true}, nil
+	}
+	return cliapi.TerminalChunk{Output: "done\n", Cursor: cursor + 5}, nil
+}
+
+func (b *scriptedBackend) StartStackBuild(cliapi.StackBuildRequest) error { return nil }
+
+func (b *scriptedBackend) StackBuildState() (cliapi.StackBuildState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.polls++
+	if b.polls < 2 {
+		return cliapi.StackBuildState{IsBuilding: true, Logs: []string{"start"}}, nil
+	}
+	if b.buildFail {
+		return cliapi.StackBuildState{Logs: []string{"start", "❌ Error: boom"}, Result: "error"}, nil
+	}
+	return cliapi.StackBuildState{Logs: []string{"start", "✅ Image built successfully"}, Result: "success"}, nil
+}
+
+func runAgainst(t *testing.T, backend cliapi.Backend, args ...string) (int, string, string) {
+	t.Helper()
+	server := httptest.NewServer(cliapi.NewHandler(backend, "tok"))
+	defer server.Close()
+	t.Setenv("ORCH_CLI_TOKEN", "tok")
+	var stdout, stderr bytes.Buffer
+	code := run(append([]string{"--gateway", server.URL}, args...), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestBuildWaitExitCode(t *testing.T) {
+	code, out, _ := runAgainst(t, &scriptedBackend{}, "build", "--wait", "--interval", "1ms")
+	if code != 0 || out != "start\n✅ Image built successfully\n" {
+		t.Fatalf("unexpected successful build: code=%d out=%q", code, out)
+	}
+	code, _, errOut := runAgainst(t, &scriptedBackend{buildFail: true}, "build", "--wait", "--interval", "1ms")
+	if code != 1 || !strings.Contains(errOut, "stack build failed") {
+		t.Fatalf("expected failing build to exit 1, got code=%d err=%q", code, errOut)
+	}
+}
+
+func TestTailAndPRs(t *testing.T) {
+	code, out, _ := runAgainst(t, &scriptedBackend{}, "tail", "--agent", "1", "-n", "2", "-f", "--interval", "1ms")
+	if code != 0 || out != "two\nthree\ndone\n" {
+		t.Fatalf("unexpected tail output: code=%d out=%q", code, out)
+	}
+	code, out, _ = runAgainst(t, &scriptedBackend{}, "prs", "--workspace", "1")
+	if code != 0 || !strings.Contains(out, "feat/cli -> main") {
+		t.Fatalf("unexpected prs output: code=%d out=%q", code, out)
+	}
+	if code, _, _ := runAgainst(t, &scriptedBackend{}, "nope"); code != 2 {
+		t.Fatalf("expected usage exit code for unknown command, got %d", code)
+	}
+}
+
+func TestRunReportsUnreachableGateway(t *testing.T) {
+	t.Setenv("ORCH_CLI_TOKEN", "tok")
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--gateway", "http://127.0.0.1:1", "workspaces"}, &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), "is ORCH running?") {
+		t.Fatalf("unexpected result: code=%d err=%q", code, stderr.String())
+	}
+}
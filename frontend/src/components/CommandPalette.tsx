@@ -1,7 +1,8 @@
 import { useState, useEffect, useCallback, useMemo, useRef } from 'react'
-import { Search, Terminal, GitBranch, Bot, Palette, Keyboard, Trash2, Maximize2, Settings2, Users, UserPlus } from 'lucide-react'
+import { Search, Terminal, GitBranch, Bot, Palette, Keyboard, Trash2, Maximize2, Settings2, Users, UserPlus, Code2 } from 'lucide-react'
 import { useLayoutStore } from '../features/command-center/stores/layoutStore'
 import { useAppStore } from '../stores/appStore'
+import { useWorkspaceStore } from '../stores/workspaceStore'
 import { formatShortcutBinding, resolveShortcutBindings } from '../features/shortcuts/shortcuts'
 import './CommandPalette.css'
 
@@ -15,6 +16,15 @@ interface Command {
   action: () => void
 }
 
+interface Snippet {
+  id: number
+  workspaceId: number
+  name: string
+  description?: string
+  command: string
+  placeholders: { name: string; default?: string; required: boolean }[]
+}
+
 /**
  * CommandPalette — overlay de busca fuzzy para ações rápidas.
  * Ativado com Cmd+K.
@@ -32,6 +42,9 @@ export function CommandPalette() {
   const toggleZenMode = useLayoutStore((s) => s.toggleZenMode)
   const setTheme = useAppStore((s) => s.setTheme)
   const shortcutOverrides = useAppStore((s) => s.shortcutBindings)
+  const activeAgentDBID = useLayoutStore((s) => (s.activePaneId ? s.panes[s.activePaneId]?.agentDBID : undefined))
+  const activeWorkspaceId = useWorkspaceStore((s) => s.activeWorkspaceId)
+  const [snippets, setSnippets] = useState<Snippet[]>([])
 
   const shortcutLabels = useMemo(() => {
     const bindings = resolveShortcutBindings(shortcutOverrides)
@@ -50,8 +63,47 @@ export function CommandPalette() {
     return () => window.removeEventListener('command-palette:toggle', handleToggle)
   }, [])
 
+  /** Snippets do workspace ativo (e globais), recarregados a cada abertura */
+  useEffect(() => {
+    if (!isOpen || !window.go?.main?.App?.ListSnippets) return
+    let cancelled = false
+    window.go.main.App.ListSnippets(activeWorkspaceId ?? 0)
+      .then((list: Snippet[]) => { if (!cancelled) setSnippets(list || []) })
+      .catch(() => { if (!cancelled) setSnippets([]) })
+    return () => { cancelled = true }
+  }, [isOpen, activeWorkspaceId])
+
+  /** Preenche os placeholders e digita o snippet no terminal do painel ativo */
+  const runSnippet = useCallback(async (snippet: Snippet) => {
+    if (!activeAgentDBID || !window.go?.main?.App?.RunSnippet) return
+    const vars: Record<string, string> = {}
+    for (const placeholder of snippet.placeholders) {
+      const value = window.prompt(`${snippet.name}: ${placeholder.name}`, placeholder.default ?? '')
+      if (value === null) return
+      vars[placeholder.name] = value
+    }
+    try {
+      await window.go.main.App.RunSnippet(activeAgentDBID, snippet.id, vars)
+    } catch (err) {
+      console.error('[CommandPalette] RunSnippet failed:', err)
+    }
+  }, [activeAgentDBID])
+
+  const snippetCommands = useMemo((): Command[] => (
+    activeAgentDBID
+      ? snippets.map((snippet) => ({
+        id: `snippet-${snippet.id}`,
+        label: snippet.name,
+        description: snippet.description || snippet.command,
+        icon: <Code2 size={16} />,
+        category: snippet.workspaceId ? 'Snippets do Workspace' : 'Snippets',
+        action: () => { void runSnippet(snippet) },
+      }))
+      : []
+  ), [activeAgentDBID, runSnippet, snippets])
+
   /** Todos os comandos disponíveis */
-  const commands = useMemo((): Command[] => [
+  const baseCommands = useMemo((): Command[] => [
     {
       id: 'new-terminal',
       label: 'Novo Terminal',
@@ -164,6 +216,8 @@ export function CommandPalette() {
     },
   ], [addPane, activePaneId, removePane, setTheme, shortcutLabels, toggleZenMode])
 
+  const commands = useMemo(() => [...baseCommands, ...snippetCommands], [baseCommands, snippetCommands])
+
   /** Filtrar comandos baseado na query (fuzzy) */
   const filteredCommands = useMemo(() => {
     if (!query.trim()) return commands
@@ -491,8 +491,17 @@ export function TerminalPane({ paneId, isActive }: TerminalPaneProps) {
         window.go?.main?.App?.CreateTerminalForAgentResume,
       )
 
+      const hasReplay = Boolean(snapshot?.replayCommands?.length)
+      const canRestoreWithReplay = Boolean(
+        hasReplay &&
+        agentDBID &&
+        window.go?.main?.App?.RestoreTerminalForAgent,
+      )
+
       // Criar ou reutilizar terminal no backend
-      const sessionID = canResumeViaBackend
+      const sessionID = canRestoreWithReplay
+        ? await window.go.main.App.RestoreTerminalForAgent(agentDBID as number, snapshot, cols || 80, rows || 24)
+        : canResumeViaBackend
         ? await window.go.main.App.CreateTerminalForAgentResume(
           agentDBID as number,
           resumeCLIType,
@@ -287,7 +287,11 @@ describe('GitPanelPRView', () => {
     fireEvent.click(screen.getByRole('button', { name: 'Criar PR' }))
 
     await waitFor(() => {
-      expect(AppBindings.GitPanelPRPushLocalBranch).toHaveBeenCalledWith('/tmp/repo', 'feature/principal')
+      expect(AppBindings.GitPanelPRPushLocalBranch).toHaveBeenCalledWith('/tmp/repo', 'feature/principal', {
+        protectedBranch: false,
+        forcePush: false,
+        largeFiles: false,
+      })
     })
     expect(AppBindings.GitPanelPRCreate).toHaveBeenCalledWith('/tmp/repo', expect.objectContaining({
       head: 'feature/principal',
@@ -941,6 +941,7 @@ export function GitPanelPRView({ repoPath }: GitPanelPRViewProps) {
         await GitPanelPRPushLocalBranch(
           normalizedRepoPath,
           parsedHead.branch,
+          { protectedBranch: false, forcePush: false, largeFiles: false },
         )
       }
 
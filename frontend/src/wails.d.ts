@@ -58,6 +58,87 @@ declare global {
                         cols: number,
                         rows: number,
                     ) => Promise<string>;
+                    RestoreTerminalForAgent: (
+                        agentID: number,
+                        snapshot: TerminalSnapshotDTO,
+                        cols: number,
+                        rows: number,
+                    ) => Promise<string>;
+                    GetAgentReplayCommands: (agentID: number) => Promise<string[]>;
+                    SetAgentReplayCommands: (
+                        agentID: number,
+                        commands: string[],
+                    ) => Promise<string[]>;
+                    SetAgentAutoStart: (
+                        agentID: number,
+                        policy: string,
+                    ) => Promise<AgentSessionDTO>;
+                    SetAgentDependencies: (
+                        agentID: number,
+                        deps: { dependsOn: number[]; readyMode: string; readyRegex: string },
+                    ) => Promise<AgentSessionDTO>;
+                    StartWorkspaceAgents: (workspaceID: number) => Promise<any>;
+                    ListSnippets: (workspaceID: number) => Promise<any[]>;
+                    SaveSnippet: (snippet: any) => Promise<any>;
+                    DeleteSnippet: (id: number) => Promise<void>;
+                    ListWorkspaceTasks: (workspaceID: number, includeDone: boolean) => Promise<any>;
+                    CreateWorkspaceTask: (
+                        workspaceID: number,
+                        title: string,
+                        path: string,
+                        line: number,
+                    ) => Promise<any>;
+                    UpdateWorkspaceTask: (id: number, title: string, path: string, line: number) => Promise<any>;
+                    DeleteWorkspaceTask: (id: number) => Promise<void>;
+                    SetWorkspaceTaskDone: (workspaceID: number, taskID: string, done: boolean) => Promise<any>;
+                    RunSnippet: (
+                        agentID: number,
+                        snippetID: number,
+                        vars: Record<string, string>,
+                    ) => Promise<string>;
+                    ListLifecycleHookEvents: () => Promise<string[]>;
+                    ListLifecycleHooks: () => Promise<any[]>;
+                    SaveLifecycleHook: (hook: any) => Promise<any>;
+                    DeleteLifecycleHook: (id: number) => Promise<void>;
+                    TestLifecycleHook: (id: number) => Promise<any>;
+                    GetLifecycleHookRuns: () => Promise<any[]>;
+                    ListNotificationEvents: () => Promise<string[]>;
+                    ListNotificationTargets: () => Promise<any[]>;
+                    SaveNotificationTarget: (target: any) => Promise<any>;
+                    DeleteNotificationTarget: (id: number) => Promise<void>;
+                    TestNotificationTarget: (id: number) => Promise<any>;
+                    GetNotificationDeliveries: () => Promise<any[]>;
+                    GetIssueTrackerSettings: () => Promise<any>;
+                    SetIssueTrackerSettings: (settings: any) => Promise<void>;
+                    ResolveIssueKeys: (keys: string[]) => Promise<any[]>;
+                    ExtractIssueKeys: (text: string) => Promise<string[]>;
+                    GetMCPSettings: () => Promise<any>;
+                    SetMCPSettings: (settings: any) => Promise<any>;
+                    RegenerateMCPToken: () => Promise<any>;
+                    GetPendingMCPApprovals: () => Promise<any[]>;
+                    RespondMCPApproval: (id: string, allow: boolean) => Promise<void>;
+                    GetPendingDeepLinks: () => Promise<any[]>;
+                    RespondDeepLink: (id: string, allow: boolean) => Promise<void>;
+                    GetRESTAPISettings: () => Promise<any>;
+                    SetRESTAPISettings: (settings: any) => Promise<any>;
+                    RegenerateRESTAPIToken: () => Promise<any>;
+                    GetClipboardHistorySettings: () => Promise<any>;
+                    SetClipboardHistoryEnabled: (enabled: boolean) => Promise<any>;
+                    ClipboardHistoryList: () => Promise<any[]>;
+                    ClipboardHistoryPin: (id: string, pinned: boolean) => Promise<any>;
+                    ClipboardHistoryClear: (includePinned: boolean) => Promise<void>;
+                    FocusStart: (workspaceID: number, opts: any) => Promise<any>;
+                    FocusPause: () => Promise<any>;
+                    FocusResume: () => Promise<any>;
+                    FocusStop: () => Promise<any>;
+                    GetFocusState: () => Promise<any>;
+                    AISummarizeTerminalOutput: (sessionID: string, lastNLines: number) => Promise<any>;
+                    GetLastTestResults: (agentID: number) => Promise<any>;
+                    GetProblems: (workspaceID: number) => Promise<any[]>;
+                    ClearProblems: (workspaceID: number) => Promise<void>;
+                    ListProblemMatchers: () => Promise<any[]>;
+                    SaveProblemMatcher: (matcher: any) => Promise<any>;
+                    DeleteProblemMatcher: (id: number) => Promise<void>;
                     GetTerminals: () => Promise<any[]>;
                     IsTerminalAlive: (sessionID: string) => Promise<boolean>;
                     GetWorkspaceHistoryBuffer: (
@@ -211,6 +292,20 @@ declare global {
                         name: string,
                         sourceBranch: string,
                     ) => Promise<Branch>;
+                    GHForkRepository: (
+                        owner: string,
+                        repo: string,
+                    ) => Promise<{
+                        owner: string;
+                        repo: string;
+                        fork: boolean;
+                        parentOwner?: string;
+                        parentRepo?: string;
+                        defaultBranch?: string;
+                        cloneUrl?: string;
+                        sshUrl?: string;
+                        htmlUrl?: string;
+                    } | null>;
                     GHInvalidateCache: (
                         owner: string,
                         repo: string,
@@ -320,6 +415,7 @@ declare global {
         useDocker: boolean;
         config?: string;
         cliType?: string;
+        replayCommands?: string[];
     }
     interface AgentSessionDTO {
         id: number;
@@ -333,6 +429,10 @@ declare global {
         status: string;
         sortOrder: number;
         isMinimized: boolean;
+        autoStart?: 'manual' | 'app_launch' | 'workspace_activated';
+        dependsOn?: string;
+        readyMode?: 'started' | 'pattern' | 'exit';
+        readyRegex?: string;
         createdAt?: string;
         updatedAt?: string;
     }
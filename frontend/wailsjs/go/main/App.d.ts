@@ -1,15 +1,39 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 import {ai} from '../models';
+import {auth} from '../models';
 import {database} from '../models';
+import {updater} from '../models';
 import {github} from '../models';
-import {auth} from '../models';
+import {kube} from '../models';
+import {backup} from '../models';
+import {filesearch} from '../models';
 import {main} from '../models';
+import {digest} from '../models';
+import {httpclient} from '../models';
+import {config} from '../models';
 import {filewatcher} from '../models';
+import {hooks} from '../models';
+import {notify} from '../models';
+import {logging} from '../models';
 import {terminal} from '../models';
 import {gitactivity} from '../models';
 import {gitpanel} from '../models';
+import {jobs} from '../models';
+import {gitprs} from '../models';
+import {editor} from '../models';
+import {docker} from '../models';
+import {workspacefs} from '../models';
+import {issuetracker} from '../models';
 import {session} from '../models';
+import {clipboard} from '../models';
+import {focus} from '../models';
+import {problems} from '../models';
+import {gitshare} from '../models';
+import {sessionreview} from '../models';
+import {commandguard} from '../models';
+import {diskusage} from '../models';
+import {startup} from '../models';
 
 export function AICancel(arg1:string):Promise<void>;
 
@@ -19,40 +43,128 @@ export function AISetProvider(arg1:ai.AIProvider):Promise<void>;
 
 export function AISetSessionState(arg1:string,arg2:ai.SessionState):Promise<void>;
 
+export function AISummarizeTerminalOutput(arg1:string,arg2:number):Promise<ai.TerminalSummary>;
+
+export function AddGitHubAccount():Promise<void>;
+
+export function AddGitHubAppInstallation(arg1:number,arg2:number,arg3:string):Promise<auth.GitHubAccount>;
+
+export function AddGitHubPersonalAccessToken(arg1:string):Promise<auth.GitHubAccount>;
+
+export function AddTerminalToBroadcastGroup(arg1:string,arg2:string):Promise<terminal.BroadcastGroup>;
+
+export function AddWorkspaceRepository(arg1:number,arg2:string,arg3:string):Promise<database.WorkspaceRepo>;
+
 export function AuthLogin(arg1:string):Promise<void>;
 
 export function AuthLogout():Promise<void>;
 
+export function AuthReauthorizeForOrg(arg1:string):Promise<string>;
+
+export function BindAgentToRepository(arg1:number,arg2:string):Promise<database.AgentSession>;
+
 export function BuildCustomStack(arg1:Record<string, string>):Promise<void>;
 
+export function BuildNamedStack(arg1:string,arg2:Record<string, string>):Promise<void>;
+
+export function CancelOperation(arg1:string):Promise<boolean>;
+
+export function CancelWorkspaceSearch(arg1:string):Promise<void>;
+
+export function CheckForUpdates():Promise<updater.UpdateInfo>;
+
+export function CheckGitHubCredentialPermissions(arg1:string,arg2:string):Promise<github.CredentialPermissionCheck>;
+
+export function ClearAPITrace():Promise<void>;
+
+export function ClearOldDiskArtifacts(arg1:string,arg2:number):Promise<diskusage.CleanupResult>;
+
+export function ClearProblems(arg1:number):Promise<void>;
+
 export function ClearTerminalSnapshots():Promise<void>;
 
+export function ClipboardHistoryClear(arg1:boolean):Promise<void>;
+
+export function ClipboardHistoryList():Promise<Array<clipboard.Entry>>;
+
+export function ClipboardHistoryPin(arg1:string,arg2:boolean):Promise<clipboard.Entry>;
+
 export function CompleteOnboarding():Promise<void>;
 
+export function ConfigureGitHubWebhookBridge(arg1:string,arg2:string):Promise<github.WebhookBridgeStatus>;
+
 export function CreateAgent(arg1:string,arg2:string):Promise<database.AgentSession>;
 
 export function CreateAgentSession(arg1:number,arg2:string,arg3:string):Promise<database.AgentSession>;
 
+export function CreateDockerTerminalWithImage(arg1:string,arg2:string,arg3:string,arg4:number,arg5:number):Promise<string>;
+
+export function CreateKubePodAgent(arg1:number,arg2:string,arg3:kube.PodTarget):Promise<database.AgentSession>;
+
+export function CreateProfile(arg1:string):Promise<config.Profile>;
+
 export function CreateTerminal(arg1:string,arg2:string,arg3:boolean,arg4:number,arg5:number):Promise<string>;
 
+export function CreateTerminalBroadcastGroup(arg1:string,arg2:Array<string>):Promise<terminal.BroadcastGroup>;
+
 export function CreateTerminalForAgent(arg1:number,arg2:string,arg3:string,arg4:boolean,arg5:number,arg6:number):Promise<string>;
 
 export function CreateTerminalForAgentResume(arg1:number,arg2:string,arg3:string,arg4:string,arg5:boolean,arg6:number,arg7:number):Promise<string>;
 
+export function CreateTerminalForKubePod(arg1:number,arg2:number,arg3:number):Promise<string>;
+
 export function CreateWorkspace(arg1:string):Promise<database.Workspace>;
 
+export function CreateWorkspaceFromTemplate(arg1:number,arg2:string,arg3:string):Promise<database.Workspace>;
+
+export function CreateWorkspaceTask(arg1:number,arg2:string,arg3:string,arg4:number):Promise<main.WorkspaceTaskDTO>;
+
+export function DatabaseBackup(arg1:string):Promise<backup.Manifest>;
+
+export function DatabaseRestore(arg1:string):Promise<backup.Manifest>;
+
 export function DeleteAgent(arg1:number):Promise<void>;
 
 export function DeleteAgentSession(arg1:number):Promise<void>;
 
+export function DeleteLifecycleHook(arg1:number):Promise<void>;
+
+export function DeleteNotificationTarget(arg1:number):Promise<void>;
+
+export function DeleteProblemMatcher(arg1:number):Promise<void>;
+
+export function DeleteSnippet(arg1:number):Promise<void>;
+
+export function DeleteStackImage(arg1:string):Promise<void>;
+
+export function DeleteTerminalBroadcastGroup(arg1:string):Promise<void>;
+
 export function DeleteWorkspace(arg1:number):Promise<void>;
 
+export function DeleteWorkspaceTask(arg1:number):Promise<void>;
+
+export function DeleteWorkspaceTemplate(arg1:number):Promise<void>;
+
 export function DestroyTerminal(arg1:string):Promise<void>;
 
 export function DockerDetectImage(arg1:string):Promise<string>;
 
 export function DockerIsAvailable():Promise<boolean>;
 
+export function ExportWorkspace(arg1:number):Promise<string>;
+
+export function ExtractIssueKeys(arg1:string):Promise<Array<string>>;
+
+export function FocusPause():Promise<focus.State>;
+
+export function FocusResume():Promise<focus.State>;
+
+export function FocusStart(arg1:number,arg2:focus.Options):Promise<focus.State>;
+
+export function FocusStop():Promise<focus.State>;
+
+export function FuzzyFindFiles(arg1:number,arg2:string,arg3:number):Promise<Array<filesearch.FileMatch>>;
+
 export function GHClosePullRequest(arg1:string,arg2:string,arg3:number):Promise<void>;
 
 export function GHCreateBranch(arg1:string,arg2:string,arg3:string,arg4:string):Promise<github.Branch>;
@@ -67,6 +179,16 @@ export function GHCreatePullRequest(arg1:string,arg2:string,arg3:string,arg4:str
 
 export function GHCreateReview(arg1:string,arg2:string,arg3:number,arg4:string,arg5:string):Promise<github.Review>;
 
+export function GHDeleteIssueFilter(arg1:number):Promise<void>;
+
+export function GHDispatchWorkflow(arg1:string,arg2:string,arg3:string,arg4:string,arg5:Record<string, string>):Promise<void>;
+
+export function GHForkRepository(arg1:string,arg2:string):Promise<github.RepositoryForkInfo>;
+
+export function GHGetIssueFilterCounts(arg1:string,arg2:string):Promise<Array<main.GitHubIssueFilterCountDTO>>;
+
+export function GHGetMyPullRequestDashboard():Promise<github.PullRequestDashboard>;
+
 export function GHGetPullRequest(arg1:string,arg2:string,arg3:number):Promise<github.PullRequest>;
 
 export function GHGetPullRequestDiff(arg1:string,arg2:string,arg3:number,arg4:number,arg5:string):Promise<github.Diff>;
@@ -77,8 +199,12 @@ export function GHListBranches(arg1:string,arg2:string):Promise<Array<github.Bra
 
 export function GHListComments(arg1:string,arg2:string,arg3:number):Promise<Array<github.Comment>>;
 
+export function GHListIssueFilters(arg1:string,arg2:string):Promise<Array<main.GitHubIssueFilterDTO>>;
+
 export function GHListIssues(arg1:string,arg2:string,arg3:string,arg4:number):Promise<Array<github.Issue>>;
 
+export function GHListIssuesWithFilter(arg1:number,arg2:string):Promise<github.IssueSearchPage>;
+
 export function GHListPullRequests(arg1:string,arg2:string,arg3:string,arg4:number):Promise<Array<github.PullRequest>>;
 
 export function GHListRepositories():Promise<Array<github.Repository>>;
@@ -87,34 +213,112 @@ export function GHListReviews(arg1:string,arg2:string,arg3:number):Promise<Array
 
 export function GHMergePullRequest(arg1:string,arg2:string,arg3:number,arg4:string):Promise<void>;
 
+export function GHRerunCheckRun(arg1:string,arg2:string,arg3:number):Promise<void>;
+
+export function GHRerunFailedJobs(arg1:string,arg2:string,arg3:number):Promise<void>;
+
+export function GHSaveIssueFilter(arg1:main.GitHubIssueFilterDTO):Promise<main.GitHubIssueFilterDTO>;
+
 export function GHUpdateIssue(arg1:string,arg2:string,arg3:number,arg4:any,arg5:any,arg6:any):Promise<void>;
 
+export function GenerateActivityDigest(arg1:number,arg2:number,arg3:boolean):Promise<digest.Digest>;
+
+export function GetAPITrace(arg1:number):Promise<Array<httpclient.TraceEntry>>;
+
+export function GetAgentReplayCommands(arg1:number):Promise<Array<string>>;
+
 export function GetAppInfo():Promise<Record<string, string>>;
 
+export function GetAuditRetentionPolicy():Promise<main.AuditRetentionPolicy>;
+
 export function GetAuthState():Promise<auth.AuthState>;
 
 export function GetAvailableShells():Promise<Array<string>>;
 
 export function GetAvailableTerminalFonts():Promise<Array<string>>;
 
+export function GetBackupSchedule():Promise<backup.ScheduleStatus>;
+
+export function GetClipboardHistorySettings():Promise<main.ClipboardHistorySettingsDTO>;
+
+export function GetCoordinationStatus():Promise<main.CoordinationStatus>;
+
 export function GetCurrentBranch(arg1:string):Promise<string>;
 
 export function GetCustomStackTools():Promise<Record<string, string>>;
 
+export function GetDatabaseInfo():Promise<database.DatabaseInfo>;
+
+export function GetEffectiveConfig():Promise<config.EffectiveConfig>;
+
+export function GetFileWatcherMetrics():Promise<filewatcher.WatcherMetrics>;
+
+export function GetFocusState():Promise<focus.State>;
+
+export function GetGitHubWebhookBridgeStatus():Promise<github.WebhookBridgeStatus>;
+
 export function GetHydrationData():Promise<main.HydrationPayload>;
 
+export function GetIssueTrackerSettings():Promise<main.IssueTrackerSettingsDTO>;
+
 export function GetLastCommit(arg1:string):Promise<filewatcher.CommitInfo>;
 
+export function GetLastTestResults(arg1:number):Promise<terminal.TestRun>;
+
 export function GetLayoutState():Promise<string>;
 
+export function GetLifecycleHookRuns():Promise<Array<hooks.Run>>;
+
+export function GetLogFilePath():Promise<string>;
+
+export function GetLogLevel():Promise<string>;
+
+export function GetMCPSettings():Promise<main.MCPSettingsDTO>;
+
+export function GetMessageCatalog(arg1:string):Promise<Record<string, string>>;
+
+export function GetNamedStackTools(arg1:string):Promise<Record<string, string>>;
+
+export function GetNetworkSettings():Promise<httpclient.Settings>;
+
+export function GetNotificationDeliveries():Promise<Array<notify.Delivery>>;
+
+export function GetPendingDeepLinks():Promise<Array<main.DeepLinkRequestDTO>>;
+
+export function GetPendingMCPApprovals():Promise<Array<main.MCPApprovalRequestDTO>>;
+
+export function GetProblems(arg1:number):Promise<Array<problems.Problem>>;
+
+export function GetProfiles():Promise<Array<config.Profile>>;
+
+export function GetRESTAPISettings():Promise<main.RESTAPISettingsDTO>;
+
 export function GetRateLimitInfo():Promise<github.RateLimitInfo>;
 
+export function GetRecentLogs(arg1:string,arg2:number):Promise<Array<logging.Entry>>;
+
+export function GetSessionNetworkPolicyDefaults():Promise<session.NetworkPolicy>;
+
+export function GetSessionSecurityProfile():Promise<string>;
+
+export function GetSessionTimeoutPolicyDefaults():Promise<session.TimeoutPolicy>;
+
 export function GetStackBuildState():Promise<main.StackBuildState>;
 
+export function GetStartupTimings():Promise<startup.Timings>;
+
+export function GetSystemHealth():Promise<main.SystemHealth>;
+
+export function GetTerminalBroadcastGroups():Promise<Array<terminal.BroadcastGroup>>;
+
 export function GetTerminalSnapshots():Promise<Array<main.TerminalSnapshotDTO>>;
 
+export function GetTerminalTranscript(arg1:string,arg2:boolean):Promise<terminal.Transcript>;
+
 export function GetTerminals():Promise<Array<terminal.SessionInfo>>;
 
+export function GetUpdateChannel():Promise<string>;
+
 export function GetWorkspaceHistoryBuffer(arg1:number):Promise<Record<string, string>>;
 
 export function GetWorkspacesWithAgents():Promise<Array<database.Workspace>>;
@@ -135,24 +339,118 @@ export function GitActivityList(arg1:number,arg2:string,arg3:string):Promise<Arr
 
 export function GitActivityUnstageFile(arg1:string,arg2:string):Promise<void>;
 
+export function GitAutoFetchConfigure(arg1:string,arg2:boolean,arg3:number):Promise<database.GitAutoFetchSetting>;
+
+export function GitAutoFetchLastResult(arg1:string):Promise<filewatcher.AutoFetchResult>;
+
+export function GitAutoFetchListSettings():Promise<Array<database.GitAutoFetchSetting>>;
+
+export function GitAutoFetchNow(arg1:string):Promise<filewatcher.AutoFetchResult>;
+
 export function GitPanelAcceptOurs(arg1:string,arg2:string,arg3:boolean):Promise<void>;
 
 export function GitPanelAcceptTheirs(arg1:string,arg2:string,arg3:boolean):Promise<void>;
 
+export function GitPanelAddIgnorePattern(arg1:string,arg2:string):Promise<gitpanel.IgnoreRulesDTO>;
+
+export function GitPanelBenchmarkReadBackends(arg1:string,arg2:number):Promise<gitpanel.ReadBenchmarkDTO>;
+
+export function GitPanelCheckIgnored(arg1:string,arg2:string):Promise<gitpanel.IgnoreCheckDTO>;
+
+export function GitPanelCheckPushSafety(arg1:string,arg2:gitpanel.PushOptionsDTO):Promise<gitpanel.PushSafetyReportDTO>;
+
+export function GitPanelCommit(arg1:string,arg2:string,arg3:gitpanel.CommitOptionsDTO):Promise<gitpanel.CommitResultDTO>;
+
+export function GitPanelCommitCancelable(arg1:string,arg2:string,arg3:string,arg4:gitpanel.CommitOptionsDTO):Promise<gitpanel.CommitResultDTO>;
+
+export function GitPanelCompareBranches(arg1:string,arg2:string,arg3:string):Promise<gitpanel.BranchComparisonDTO>;
+
+export function GitPanelConfigureSigning(arg1:string,arg2:gitpanel.SigningSetupDTO):Promise<gitpanel.SigningStatusDTO>;
+
+export function GitPanelDisableSparseCheckout(arg1:string):Promise<gitpanel.SparseCheckoutDTO>;
+
 export function GitPanelDiscardFile(arg1:string,arg2:string):Promise<void>;
 
+export function GitPanelDiscardFileCancelable(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function GitPanelFetchAsync(arg1:string,arg2:gitpanel.FetchOptionsDTO):Promise<jobs.Job>;
+
+export function GitPanelGenerateChangelog(arg1:string,arg2:string,arg3:string,arg4:string):Promise<gitpanel.ChangelogDTO>;
+
+export function GitPanelGetBranchNamingPolicy(arg1:string):Promise<gitpanel.BranchNamingPolicyDTO>;
+
+export function GitPanelGetCacheMetrics():Promise<gitpanel.CacheMetricsDTO>;
+
+export function GitPanelGetCacheTTLs(arg1:string):Promise<gitpanel.CacheTTLsDTO>;
+
 export function GitPanelGetCommitDetails(arg1:string,arg2:string):Promise<gitpanel.CommitDetailsDTO>;
 
 export function GitPanelGetCommitDiff(arg1:string,arg2:string,arg3:string,arg4:number):Promise<gitpanel.DiffDTO>;
 
+export function GitPanelGetCommitDiffWithOptions(arg1:string,arg2:string,arg3:string,arg4:number,arg5:gitpanel.DiffOptionsDTO):Promise<gitpanel.DiffDTO>;
+
+export function GitPanelGetConfig(arg1:string,arg2:string):Promise<gitpanel.GitConfigDTO>;
+
 export function GitPanelGetConflicts(arg1:string):Promise<Array<gitpanel.ConflictFileDTO>>;
 
+export function GitPanelGetContributorStats(arg1:string,arg2:number,arg3:string,arg4:number):Promise<gitpanel.ContributorStatsPageDTO>;
+
 export function GitPanelGetDiff(arg1:string,arg2:string,arg3:string,arg4:number):Promise<gitpanel.DiffDTO>;
 
+export function GitPanelGetDiffCancelable(arg1:string,arg2:string,arg3:string,arg4:string,arg5:number,arg6:gitpanel.DiffOptionsDTO):Promise<gitpanel.DiffDTO>;
+
+export function GitPanelGetDiffWithOptions(arg1:string,arg2:string,arg3:string,arg4:number,arg5:gitpanel.DiffOptionsDTO):Promise<gitpanel.DiffDTO>;
+
+export function GitPanelGetExecMetrics():Promise<gitpanel.ExecMetricsDTO>;
+
+export function GitPanelGetExternalToolSettings(arg1:string):Promise<main.GitExternalToolSettings>;
+
+export function GitPanelGetFileHistory(arg1:string,arg2:string,arg3:string,arg4:number):Promise<gitpanel.FileHistoryPageDTO>;
+
+export function GitPanelGetForkSyncStatus(arg1:string):Promise<gitpanel.ForkSyncStatusDTO>;
+
 export function GitPanelGetHistory(arg1:string,arg2:string,arg3:number,arg4:string):Promise<gitpanel.HistoryPageDTO>;
 
+export function GitPanelGetHistoryCountEstimate(arg1:string):Promise<gitpanel.HistoryCountEstimateDTO>;
+
+export function GitPanelGetHistoryDetail(arg1:string,arg2:string,arg3:number,arg4:string,arg5:string):Promise<gitpanel.HistoryPageDTO>;
+
+export function GitPanelGetHookSetup(arg1:string):Promise<gitpanel.HookSetupDTO>;
+
+export function GitPanelGetIgnoreRules(arg1:string):Promise<gitpanel.IgnoreRulesDTO>;
+
+export function GitPanelGetPartialClone(arg1:string):Promise<gitpanel.PartialCloneDTO>;
+
+export function GitPanelGetPathScope(arg1:number):Promise<main.GitPanelPathScopeDTO>;
+
+export function GitPanelGetRangeDiff(arg1:string,arg2:string,arg3:string,arg4:string):Promise<gitpanel.DiffDTO>;
+
+export function GitPanelGetReadBackend(arg1:string):Promise<gitpanel.ReadBackendDTO>;
+
+export function GitPanelGetReflog(arg1:string,arg2:number):Promise<Array<gitpanel.ReflogEntryDTO>>;
+
+export function GitPanelGetRepoStats(arg1:string):Promise<gitpanel.RepoStatsDTO>;
+
+export function GitPanelGetSigningStatus(arg1:string):Promise<gitpanel.SigningStatusDTO>;
+
+export function GitPanelGetSparseCheckout(arg1:string):Promise<gitpanel.SparseCheckoutDTO>;
+
 export function GitPanelGetStatus(arg1:string):Promise<gitpanel.StatusDTO>;
 
+export function GitPanelGetStatusCancelable(arg1:string,arg2:string,arg3:string):Promise<gitpanel.StatusDTO>;
+
+export function GitPanelGetStatusDetail(arg1:string,arg2:string):Promise<gitpanel.StatusDTO>;
+
+export function GitPanelGetStatusForAgent(arg1:number):Promise<gitpanel.StatusDTO>;
+
+export function GitPanelLintCommitMessage(arg1:string,arg2:string):Promise<gitpanel.CommitLintResultDTO>;
+
+export function GitPanelListExternalTools():Promise<Array<gitpanel.ExternalToolDTO>>;
+
+export function GitPanelListSecretOverrides(arg1:string,arg2:number):Promise<Array<database.AuditLog>>;
+
+export function GitPanelOpenExternalDiffTool(arg1:string,arg2:string):Promise<void>;
+
 export function GitPanelOpenExternalMergeTool(arg1:string,arg2:string):Promise<void>;
 
 export function GitPanelPRCheckMerged(arg1:string,arg2:number):Promise<boolean>;
@@ -165,22 +463,50 @@ export function GitPanelPRCreateLocalBranch(arg1:string,arg2:string,arg3:string)
 
 export function GitPanelPRGet(arg1:string,arg2:number):Promise<github.PullRequest>;
 
+export function GitPanelPRGetCodeOwners(arg1:string,arg2:string,arg3:string):Promise<gitprs.CodeOwnersReport>;
+
 export function GitPanelPRGetCommitRawDiff(arg1:string,arg2:number,arg3:string):Promise<string>;
 
+export function GitPanelPRGetCommitRawDiffCancelable(arg1:string,arg2:string,arg3:number,arg4:string):Promise<string>;
+
 export function GitPanelPRGetCommits(arg1:string,arg2:number,arg3:number,arg4:number):Promise<github.PRCommitPage>;
 
+export function GitPanelPRGetCommitsCancelable(arg1:string,arg2:string,arg3:number,arg4:number,arg5:number):Promise<github.PRCommitPage>;
+
 export function GitPanelPRGetFiles(arg1:string,arg2:number,arg3:number,arg4:number):Promise<github.PRFilePage>;
 
+export function GitPanelPRGetFilesCancelable(arg1:string,arg2:string,arg3:number,arg4:number,arg5:number):Promise<github.PRFilePage>;
+
+export function GitPanelPRGetIssueDevelopment(arg1:string,arg2:number):Promise<github.IssueDevelopment>;
+
+export function GitPanelPRGetLinkedIssues(arg1:string,arg2:number):Promise<Array<github.LinkedIssue>>;
+
+export function GitPanelPRGetMergeRequirements(arg1:string,arg2:number):Promise<github.MergeRequirements>;
+
+export function GitPanelPRGetPullRequestCodeOwners(arg1:string,arg2:number):Promise<gitprs.CodeOwnersReport>;
+
 export function GitPanelPRGetRawDiff(arg1:string,arg2:number):Promise<string>;
 
+export function GitPanelPRGetRawDiffCancelable(arg1:string,arg2:string,arg3:number):Promise<string>;
+
 export function GitPanelPRList(arg1:string,arg2:string,arg3:number,arg4:number):Promise<Array<github.PullRequest>>;
 
+export function GitPanelPRListTemplates(arg1:string):Promise<Array<gitprs.Template>>;
+
 export function GitPanelPRMerge(arg1:string,arg2:number,arg3:main.GitPanelPRMergePayloadDTO):Promise<main.GitPanelPRMergeResultDTO>;
 
-export function GitPanelPRPushLocalBranch(arg1:string,arg2:string):Promise<void>;
+export function GitPanelPRPrefillCreate(arg1:string,arg2:string,arg3:string):Promise<main.GitPanelPRCreatePayloadDTO>;
+
+export function GitPanelPRPushLocalBranch(arg1:string,arg2:string,arg3:gitpanel.PushConfirmationDTO):Promise<void>;
+
+export function GitPanelPRPushLocalBranchAsync(arg1:string,arg2:string,arg3:gitpanel.PushConfirmationDTO):Promise<jobs.Job>;
+
+export function GitPanelPRPushLocalBranchCancelable(arg1:string,arg2:string,arg3:string,arg4:gitpanel.PushConfirmationDTO):Promise<void>;
 
 export function GitPanelPRResolveRepository(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<main.GitPanelPRRepositoryTargetDTO>;
 
+export function GitPanelPRSetBaseRemote(arg1:string,arg2:string):Promise<main.GitPanelPRRepositoryTargetDTO>;
+
 export function GitPanelPRUpdate(arg1:string,arg2:number,arg3:main.GitPanelPRUpdatePayloadDTO):Promise<github.PullRequest>;
 
 export function GitPanelPRUpdateBranch(arg1:string,arg2:number,arg3:main.GitPanelPRUpdateBranchPayloadDTO):Promise<main.GitPanelPRUpdateBranchResultDTO>;
@@ -189,26 +515,172 @@ export function GitPanelPickRepositoryDirectory(arg1:string):Promise<string>;
 
 export function GitPanelPreflight(arg1:string):Promise<gitpanel.PreflightResult>;
 
+export function GitPanelPublishChangelog(arg1:string,arg2:string,arg3:string,arg4:string,arg5:main.GitPanelChangelogPublishDTO):Promise<main.GitPanelChangelogPublishResultDTO>;
+
+export function GitPanelPush(arg1:string,arg2:gitpanel.PushOptionsDTO):Promise<gitpanel.PushResultDTO>;
+
+export function GitPanelPushAsync(arg1:string,arg2:gitpanel.PushOptionsDTO):Promise<jobs.Job>;
+
+export function GitPanelRefreshContributorStats(arg1:string,arg2:number,arg3:number):Promise<gitpanel.ContributorStatsPageDTO>;
+
+export function GitPanelRefreshRepoStats(arg1:string):Promise<gitpanel.RepoStatsDTO>;
+
+export function GitPanelResetCacheMetrics():Promise<void>;
+
+export function GitPanelResetExecMetrics():Promise<void>;
+
+export function GitPanelResolveAgentRepository(arg1:number):Promise<string>;
+
+export function GitPanelScanStagedSecrets(arg1:string,arg2:string):Promise<gitpanel.SecretScanResultDTO>;
+
+export function GitPanelSetAllowNoVerify(arg1:number,arg2:boolean):Promise<void>;
+
+export function GitPanelSetBranchNamingPolicy(arg1:string,arg2:gitpanel.BranchNamingPolicyDTO):Promise<gitpanel.BranchNamingPolicyDTO>;
+
+export function GitPanelSetCacheTTLs(arg1:string,arg2:gitpanel.CacheTTLsDTO):Promise<gitpanel.CacheTTLsDTO>;
+
+export function GitPanelSetCoAuthorGuests(arg1:number,arg2:boolean):Promise<void>;
+
+export function GitPanelSetCommitLintMode(arg1:number,arg2:string):Promise<string>;
+
+export function GitPanelSetConfig(arg1:string,arg2:string,arg3:string,arg4:string):Promise<gitpanel.GitConfigDTO>;
+
+export function GitPanelSetDefaultExternalTools(arg1:string,arg2:string):Promise<void>;
+
+export function GitPanelSetPartialClone(arg1:string,arg2:string,arg3:string):Promise<gitpanel.PartialCloneDTO>;
+
+export function GitPanelSetPathScope(arg1:number,arg2:string):Promise<main.GitPanelPathScopeDTO>;
+
+export function GitPanelSetReadBackend(arg1:string,arg2:string):Promise<gitpanel.ReadBackendDTO>;
+
+export function GitPanelSetRepoExternalTools(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function GitPanelSetSparseCheckout(arg1:string,arg2:Array<string>):Promise<gitpanel.SparseCheckoutDTO>;
+
 export function GitPanelStageFile(arg1:string,arg2:string):Promise<void>;
 
+export function GitPanelStageFileCancelable(arg1:string,arg2:string,arg3:string):Promise<void>;
+
 export function GitPanelStagePatch(arg1:string,arg2:string):Promise<void>;
 
+export function GitPanelStagePatchCancelable(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function GitPanelStreamHistoryAsync(arg1:string,arg2:gitpanel.HistoryStreamOptionsDTO):Promise<jobs.Job>;
+
+export function GitPanelSuggestBranchName(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<gitpanel.BranchNameSuggestionDTO>;
+
+export function GitPanelSuggestCoAuthors(arg1:string):Promise<Array<main.GitPanelCoAuthorDTO>>;
+
+export function GitPanelSuggestCommitScopes(arg1:string):Promise<gitpanel.CommitSuggestionsDTO>;
+
+export function GitPanelSuggestIgnorePatterns(arg1:string):Promise<Array<gitpanel.IgnoreSuggestionDTO>>;
+
+export function GitPanelSyncForkWithUpstream(arg1:string):Promise<gitpanel.ForkSyncStatusDTO>;
+
+export function GitPanelTestExternalTool(arg1:string):Promise<void>;
+
+export function GitPanelUndoLast(arg1:string,arg2:boolean):Promise<gitpanel.UndoPlanDTO>;
+
 export function GitPanelUnstageFile(arg1:string,arg2:string):Promise<void>;
 
+export function GitPanelUnstageFileCancelable(arg1:string,arg2:string,arg3:string):Promise<void>;
+
 export function GitPanelUnstagePatch(arg1:string,arg2:string):Promise<void>;
 
+export function GitPanelUnstagePatchCancelable(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function GitPanelValidateBranchName(arg1:string,arg2:string):Promise<gitpanel.BranchNamingValidationDTO>;
+
+export function GitPanelWarmCache(arg1:string):Promise<void>;
+
 export function HandleDeepLink(arg1:string):Promise<void>;
 
+export function ImportWorkspace(arg1:string):Promise<database.Workspace>;
+
+export function InstallUpdate():Promise<updater.InstallResult>;
+
+export function IsAPIInspectorEnabled():Promise<boolean>;
+
 export function IsTerminalAlive(arg1:string):Promise<boolean>;
 
+export function JobCancel(arg1:string):Promise<void>;
+
+export function JobGet(arg1:string):Promise<jobs.Job>;
+
+export function JobsList():Promise<Array<jobs.Job>>;
+
+export function KubeIsAvailable():Promise<boolean>;
+
+export function KubeListContexts():Promise<Array<kube.Context>>;
+
+export function KubeListNamespaces(arg1:string):Promise<Array<string>>;
+
+export function KubeListPods(arg1:string,arg2:string):Promise<Array<kube.Pod>>;
+
 export function ListAgents():Promise<Array<database.AgentSession>>;
 
+export function ListEditors():Promise<Array<editor.Editor>>;
+
+export function ListGitHubAccounts():Promise<Array<auth.GitHubAccount>>;
+
+export function ListLifecycleHookEvents():Promise<Array<string>>;
+
+export function ListLifecycleHooks():Promise<Array<main.LifecycleHookDTO>>;
+
+export function ListNotificationEvents():Promise<Array<string>>;
+
+export function ListNotificationTargets():Promise<Array<main.NotificationTargetDTO>>;
+
+export function ListProblemMatchers():Promise<Array<main.ProblemMatcherDTO>>;
+
+export function ListSnippets(arg1:number):Promise<Array<main.SnippetDTO>>;
+
+export function ListStackImages():Promise<Array<docker.StackImageInfo>>;
+
+export function ListWorkspaceRepositories(arg1:number):Promise<Array<database.WorkspaceRepo>>;
+
+export function ListWorkspaceTasks(arg1:number,arg2:boolean):Promise<main.WorkspaceTaskListDTO>;
+
+export function ListWorkspaceTemplates():Promise<Array<main.WorkspaceTemplateDTO>>;
+
 export function MoveAgentSessionToWorkspace(arg1:number,arg2:number):Promise<database.AgentSession>;
 
+export function OpenInEditor(arg1:number,arg2:string,arg3:number,arg4:number):Promise<string>;
+
+export function OpenKubePodLogs(arg1:kube.PodTarget,arg2:boolean,arg3:number,arg4:number):Promise<string>;
+
+export function PruneDanglingStackImages():Promise<diskusage.CleanupResult>;
+
+export function ReadWorkspaceFile(arg1:number,arg2:string,arg3:workspacefs.ReadRange):Promise<workspacefs.FileContent>;
+
+export function ReconnectKubePodTerminal(arg1:number,arg2:number,arg3:number):Promise<string>;
+
+export function RegenerateMCPToken():Promise<main.MCPSettingsDTO>;
+
+export function RegenerateRESTAPIToken():Promise<main.RESTAPISettingsDTO>;
+
+export function RemoveGitHubAccount(arg1:string):Promise<Array<auth.GitHubAccount>>;
+
+export function RemoveTerminalFromBroadcastGroup(arg1:string):Promise<void>;
+
+export function RemoveWorkspaceRepository(arg1:number):Promise<void>;
+
 export function RenameWorkspace(arg1:number,arg2:string):Promise<database.Workspace>;
 
 export function ResizeTerminal(arg1:string,arg2:number,arg3:number):Promise<void>;
 
+export function ResolveGitHubAccount(arg1:string):Promise<main.GitHubAccountBindingDTO>;
+
+export function ResolveIssueKeys(arg1:Array<string>):Promise<Array<issuetracker.Issue>>;
+
+export function RespondDeepLink(arg1:string,arg2:boolean):Promise<void>;
+
+export function RespondMCPApproval(arg1:string,arg2:boolean):Promise<void>;
+
+export function RestoreTerminalForAgent(arg1:number,arg2:main.TerminalSnapshotDTO,arg3:number,arg4:number):Promise<string>;
+
+export function RunSnippet(arg1:number,arg2:number,arg3:Record<string, string>):Promise<string>;
+
 export function SaveAgentLayout(arg1:number,arg2:string):Promise<void>;
 
 export function SaveDefaultShell(arg1:string):Promise<void>;
@@ -217,8 +689,16 @@ export function SaveLanguage(arg1:string):Promise<void>;
 
 export function SaveLayoutState(arg1:string):Promise<void>;
 
+export function SaveLifecycleHook(arg1:main.LifecycleHookDTO):Promise<main.LifecycleHookDTO>;
+
+export function SaveNotificationTarget(arg1:main.NotificationTargetDTO):Promise<main.NotificationTargetDTO>;
+
+export function SaveProblemMatcher(arg1:main.ProblemMatcherDTO):Promise<main.ProblemMatcherDTO>;
+
 export function SaveShortcutBindings(arg1:string):Promise<void>;
 
+export function SaveSnippet(arg1:main.SnippetDTO):Promise<main.SnippetDTO>;
+
 export function SaveTerminalCursorStyle(arg1:string):Promise<void>;
 
 export function SaveTerminalFontFamily(arg1:string):Promise<void>;
@@ -229,58 +709,178 @@ export function SaveTerminalSnapshots(arg1:Array<main.TerminalSnapshotDTO>):Prom
 
 export function SaveTheme(arg1:string):Promise<void>;
 
+export function SaveWorkspaceTemplate(arg1:main.WorkspaceTemplateDTO):Promise<main.WorkspaceTemplateDTO>;
+
+export function ScanDiskUsage():Promise<diskusage.Report>;
+
+export function SearchInWorkspace(arg1:number,arg2:string,arg3:boolean,arg4:Array<string>):Promise<string>;
+
+export function SessionApproveCommand(arg1:string):Promise<commandguard.Request>;
+
 export function SessionApproveGuest(arg1:string,arg2:string):Promise<void>;
 
+export function SessionApproveReviewDraft(arg1:string):Promise<sessionreview.Draft>;
+
 export function SessionCreate(arg1:number,arg2:string,arg3:boolean,arg4:number):Promise<session.Session>;
 
+export function SessionCreateWithImage(arg1:number,arg2:string,arg3:boolean,arg4:number,arg5:string):Promise<session.Session>;
+
+export function SessionDenyCommand(arg1:string,arg2:string):Promise<commandguard.Request>;
+
 export function SessionEnd(arg1:string):Promise<void>;
 
 export function SessionGetActive():Promise<session.Session>;
 
+export function SessionGetAnalytics(arg1:string):Promise<session.Analytics>;
+
 export function SessionGetAuditLogs(arg1:string,arg2:number):Promise<Array<database.AuditLog>>;
 
+export function SessionGetContainerSecurity(arg1:string):Promise<docker.SecuritySettings>;
+
+export function SessionGetGitPanelShare():Promise<gitshare.Share>;
+
 export function SessionGetICEServers():Promise<Array<session.ICEServerConfig>>;
 
 export function SessionGetJoinSecurityMetrics():Promise<session.JoinSecurityMetrics>;
 
+export function SessionGetPRReviewTarget(arg1:string):Promise<sessionreview.Target>;
+
 export function SessionGetSession(arg1:string):Promise<session.Session>;
 
 export function SessionGetSignalingURL():Promise<string>;
 
+export function SessionGitPanelGetDiff(arg1:string,arg2:string,arg3:string,arg4:number):Promise<gitpanel.DiffDTO>;
+
+export function SessionGitPanelGetHistory(arg1:string,arg2:string,arg3:number,arg4:string):Promise<gitpanel.HistoryPageDTO>;
+
+export function SessionGitPanelGetShare(arg1:string):Promise<gitshare.Share>;
+
+export function SessionGitPanelGetStatus(arg1:string):Promise<gitpanel.StatusDTO>;
+
 export function SessionJoin(arg1:string,arg2:string,arg3:string):Promise<session.JoinResult>;
 
 export function SessionKickGuest(arg1:string,arg2:string):Promise<void>;
 
+export function SessionListHeldCommands():Promise<Array<commandguard.Request>>;
+
+export function SessionListMyReviewDrafts(arg1:string):Promise<Array<sessionreview.Draft>>;
+
 export function SessionListPendingGuests(arg1:string):Promise<Array<session.GuestRequest>>;
 
+export function SessionListReviewDrafts():Promise<Array<sessionreview.Draft>>;
+
 export function SessionRegenerateCode(arg1:string):Promise<session.Session>;
 
 export function SessionRejectGuest(arg1:string,arg2:string):Promise<void>;
 
+export function SessionRejectReviewDraft(arg1:string,arg2:string):Promise<sessionreview.Draft>;
+
 export function SessionRestartEnvironment(arg1:string):Promise<void>;
 
 export function SessionRevokeCode(arg1:string):Promise<session.Session>;
 
+export function SessionSearchAuditLogs(arg1:string,arg2:string,arg3:string,arg4:database.AuditLogTimeRange,arg5:database.AuditLogPagination):Promise<database.AuditLogPage>;
+
 export function SessionSetAllowNewJoins(arg1:string,arg2:boolean):Promise<session.Session>;
 
+export function SessionSetCommandApproval(arg1:string,arg2:boolean):Promise<session.Session>;
+
 export function SessionSetGuestPermission(arg1:string,arg2:string,arg3:string):Promise<void>;
 
+export function SessionSetTimeoutPolicy(arg1:string,arg2:session.TimeoutPolicy):Promise<session.Session>;
+
+export function SessionShareGitPanel(arg1:string,arg2:Array<string>):Promise<gitshare.Share>;
+
+export function SessionStartPRReview(arg1:string,arg2:string,arg3:number):Promise<sessionreview.Target>;
+
+export function SessionStopGitPanelShare():Promise<void>;
+
+export function SessionStopPRReview():Promise<void>;
+
+export function SessionSubmitReviewDraft(arg1:string,arg2:sessionreview.DraftInput):Promise<sessionreview.Draft>;
+
+export function SetAPIInspectorEnabled(arg1:boolean):Promise<void>;
+
 export function SetActiveWorkspace(arg1:number):Promise<void>;
 
+export function SetAgentAutoStart(arg1:number,arg2:string):Promise<database.AgentSession>;
+
+export function SetAgentDependencies(arg1:number,arg2:main.AgentDependenciesDTO):Promise<database.AgentSession>;
+
+export function SetAgentReplayCommands(arg1:number,arg2:Array<string>):Promise<Array<string>>;
+
+export function SetAuditRetentionPolicy(arg1:main.AuditRetentionPolicy):Promise<main.AuditRetentionPolicy>;
+
+export function SetBackupSchedule(arg1:backup.Schedule):Promise<backup.ScheduleStatus>;
+
+export function SetClipboardHistoryEnabled(arg1:boolean):Promise<main.ClipboardHistorySettingsDTO>;
+
+export function SetIssueTrackerSettings(arg1:main.IssueTrackerSettingsDTO):Promise<void>;
+
+export function SetKubePodAgentTarget(arg1:number,arg2:kube.PodTarget):Promise<database.AgentSession>;
+
+export function SetLogLevel(arg1:string):Promise<void>;
+
+export function SetMCPSettings(arg1:main.MCPSettingsDTO):Promise<main.MCPSettingsDTO>;
+
+export function SetNetworkSettings(arg1:httpclient.Settings):Promise<void>;
+
 export function SetPollingContext(arg1:string):Promise<void>;
 
+export function SetRESTAPISettings(arg1:main.RESTAPISettingsDTO):Promise<main.RESTAPISettingsDTO>;
+
+export function SetRepoGitHubAccount(arg1:string,arg2:string):Promise<main.GitHubAccountBindingDTO>;
+
+export function SetSessionNetworkPolicyDefaults(arg1:session.NetworkPolicy):Promise<session.NetworkPolicy>;
+
+export function SetSessionSecurityProfile(arg1:string):Promise<string>;
+
+export function SetSessionTimeoutPolicyDefaults(arg1:session.TimeoutPolicy):Promise<session.TimeoutPolicy>;
+
+export function SetTerminalBroadcastEnabled(arg1:string,arg2:boolean):Promise<terminal.BroadcastGroup>;
+
+export function SetUpdateChannel(arg1:string):Promise<void>;
+
 export function SetWorkspaceColor(arg1:number,arg2:string):Promise<database.Workspace>;
 
+export function SetWorkspaceDockerImage(arg1:number,arg2:string):Promise<database.Workspace>;
+
+export function SetWorkspaceEditor(arg1:number,arg2:string):Promise<void>;
+
+export function SetWorkspaceFileWatching(arg1:number,arg2:boolean):Promise<void>;
+
+export function SetWorkspaceGitHubAccount(arg1:number,arg2:string):Promise<void>;
+
+export function SetWorkspaceTaskDone(arg1:number,arg2:string,arg3:boolean):Promise<main.WorkspaceTaskDTO>;
+
+export function StartLogFollow(arg1:string):Promise<void>;
+
 export function StartPolling(arg1:string,arg2:string):Promise<void>;
 
+export function StartWorkspaceAgents(arg1:number):Promise<jobs.Job>;
+
+export function StopLogFollow():Promise<void>;
+
 export function StopPolling():Promise<void>;
 
+export function SwitchGitHubAccount(arg1:string):Promise<Array<auth.GitHubAccount>>;
+
+export function SwitchProfile(arg1:string):Promise<main.ProfileSwitchDTO>;
+
 export function SyncGuestWorkspace(arg1:string):Promise<database.Workspace>;
 
+export function TestLifecycleHook(arg1:number):Promise<hooks.Run>;
+
+export function TestNotificationTarget(arg1:number):Promise<notify.Delivery>;
+
 export function UnwatchProject(arg1:string):Promise<void>;
 
+export function UpdateWorkspaceTask(arg1:number,arg2:string,arg3:string,arg4:number):Promise<main.WorkspaceTaskDTO>;
+
 export function WatchProject(arg1:string):Promise<void>;
 
 export function WriteTerminal(arg1:string,arg2:string):Promise<void>;
 
 export function WriteTerminalAsGuest(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function WriteWorkspaceFile(arg1:number,arg2:string,arg3:string,arg4:string):Promise<workspacefs.WriteResult>;
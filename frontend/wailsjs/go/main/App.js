@@ -18,6 +18,30 @@ export function AISetSessionState(arg1, arg2) {
   return window['go']['main']['App']['AISetSessionState'](arg1, arg2);
 }
 
+export function AISummarizeTerminalOutput(arg1, arg2) {
+  return window['go']['main']['App']['AISummarizeTerminalOutput'](arg1, arg2);
+}
+
+export function AddGitHubAccount() {
+  return window['go']['main']['App']['AddGitHubAccount']();
+}
+
+export function AddGitHubAppInstallation(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AddGitHubAppInstallation'](arg1, arg2, arg3);
+}
+
+export function AddGitHubPersonalAccessToken(arg1) {
+  return window['go']['main']['App']['AddGitHubPersonalAccessToken'](arg1);
+}
+
+export function AddTerminalToBroadcastGroup(arg1, arg2) {
+  return window['go']['main']['App']['AddTerminalToBroadcastGroup'](arg1, arg2);
+}
+
+export function AddWorkspaceRepository(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AddWorkspaceRepository'](arg1, arg2, arg3);
+}
+
 export function AuthLogin(arg1) {
   return window['go']['main']['App']['AuthLogin'](arg1);
 }
@@ -26,18 +50,74 @@ export function AuthLogout() {
   return window['go']['main']['App']['AuthLogout']();
 }
 
+export function AuthReauthorizeForOrg(arg1) {
+  return window['go']['main']['App']['AuthReauthorizeForOrg'](arg1);
+}
+
+export function BindAgentToRepository(arg1, arg2) {
+  return window['go']['main']['App']['BindAgentToRepository'](arg1, arg2);
+}
+
 export function BuildCustomStack(arg1) {
   return window['go']['main']['App']['BuildCustomStack'](arg1);
 }
 
+export function BuildNamedStack(arg1, arg2) {
+  return window['go']['main']['App']['BuildNamedStack'](arg1, arg2);
+}
+
+export function CancelOperation(arg1) {
+  return window['go']['main']['App']['CancelOperation'](arg1);
+}
+
+export function CancelWorkspaceSearch(arg1) {
+  return window['go']['main']['App']['CancelWorkspaceSearch'](arg1);
+}
+
+export function CheckForUpdates() {
+  return window['go']['main']['App']['CheckForUpdates']();
+}
+
+export function CheckGitHubCredentialPermissions(arg1, arg2) {
+  return window['go']['main']['App']['CheckGitHubCredentialPermissions'](arg1, arg2);
+}
+
+export function ClearAPITrace() {
+  return window['go']['main']['App']['ClearAPITrace']();
+}
+
+export function ClearOldDiskArtifacts(arg1, arg2) {
+  return window['go']['main']['App']['ClearOldDiskArtifacts'](arg1, arg2);
+}
+
+export function ClearProblems(arg1) {
+  return window['go']['main']['App']['ClearProblems'](arg1);
+}
+
 export function ClearTerminalSnapshots() {
   return window['go']['main']['App']['ClearTerminalSnapshots']();
 }
 
+export function ClipboardHistoryClear(arg1) {
+  return window['go']['main']['App']['ClipboardHistoryClear'](arg1);
+}
+
+export function ClipboardHistoryList() {
+  return window['go']['main']['App']['ClipboardHistoryList']();
+}
+
+export function ClipboardHistoryPin(arg1, arg2) {
+  return window['go']['main']['App']['ClipboardHistoryPin'](arg1, arg2);
+}
+
 export function CompleteOnboarding() {
   return window['go']['main']['App']['CompleteOnboarding']();
 }
 
+export function ConfigureGitHubWebhookBridge(arg1, arg2) {
+  return window['go']['main']['App']['ConfigureGitHubWebhookBridge'](arg1, arg2);
+}
+
 export function CreateAgent(arg1, arg2) {
   return window['go']['main']['App']['CreateAgent'](arg1, arg2);
 }
@@ -46,10 +126,26 @@ export function CreateAgentSession(arg1, arg2, arg3) {
   return window['go']['main']['App']['CreateAgentSession'](arg1, arg2, arg3);
 }
 
+export function CreateDockerTerminalWithImage(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['CreateDockerTerminalWithImage'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function CreateKubePodAgent(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CreateKubePodAgent'](arg1, arg2, arg3);
+}
+
+export function CreateProfile(arg1) {
+  return window['go']['main']['App']['CreateProfile'](arg1);
+}
+
 export function CreateTerminal(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['main']['App']['CreateTerminal'](arg1, arg2, arg3, arg4, arg5);
 }
 
+export function CreateTerminalBroadcastGroup(arg1, arg2) {
+  return window['go']['main']['App']['CreateTerminalBroadcastGroup'](arg1, arg2);
+}
+
 export function CreateTerminalForAgent(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['main']['App']['CreateTerminalForAgent'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
@@ -58,10 +154,30 @@ export function CreateTerminalForAgentResume(arg1, arg2, arg3, arg4, arg5, arg6,
   return window['go']['main']['App']['CreateTerminalForAgentResume'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
 }
 
+export function CreateTerminalForKubePod(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CreateTerminalForKubePod'](arg1, arg2, arg3);
+}
+
 export function CreateWorkspace(arg1) {
   return window['go']['main']['App']['CreateWorkspace'](arg1);
 }
 
+export function CreateWorkspaceFromTemplate(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CreateWorkspaceFromTemplate'](arg1, arg2, arg3);
+}
+
+export function CreateWorkspaceTask(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['CreateWorkspaceTask'](arg1, arg2, arg3, arg4);
+}
+
+export function DatabaseBackup(arg1) {
+  return window['go']['main']['App']['DatabaseBackup'](arg1);
+}
+
+export function DatabaseRestore(arg1) {
+  return window['go']['main']['App']['DatabaseRestore'](arg1);
+}
+
 export function DeleteAgent(arg1) {
   return window['go']['main']['App']['DeleteAgent'](arg1);
 }
@@ -70,10 +186,42 @@ export function DeleteAgentSession(arg1) {
   return window['go']['main']['App']['DeleteAgentSession'](arg1);
 }
 
+export function DeleteLifecycleHook(arg1) {
+  return window['go']['main']['App']['DeleteLifecycleHook'](arg1);
+}
+
+export function DeleteNotificationTarget(arg1) {
+  return window['go']['main']['App']['DeleteNotificationTarget'](arg1);
+}
+
+export function DeleteProblemMatcher(arg1) {
+  return window['go']['main']['App']['DeleteProblemMatcher'](arg1);
+}
+
+export function DeleteSnippet(arg1) {
+  return window['go']['main']['App']['DeleteSnippet'](arg1);
+}
+
+export function DeleteStackImage(arg1) {
+  return window['go']['main']['App']['DeleteStackImage'](arg1);
+}
+
+export function DeleteTerminalBroadcastGroup(arg1) {
+  return window['go']['main']['App']['DeleteTerminalBroadcastGroup'](arg1);
+}
+
 export function DeleteWorkspace(arg1) {
   return window['go']['main']['App']['DeleteWorkspace'](arg1);
 }
 
+export function DeleteWorkspaceTask(arg1) {
+  return window['go']['main']['App']['DeleteWorkspaceTask'](arg1);
+}
+
+export function DeleteWorkspaceTemplate(arg1) {
+  return window['go']['main']['App']['DeleteWorkspaceTemplate'](arg1);
+}
+
 export function DestroyTerminal(arg1) {
   return window['go']['main']['App']['DestroyTerminal'](arg1);
 }
@@ -86,6 +234,34 @@ export function DockerIsAvailable() {
   return window['go']['main']['App']['DockerIsAvailable']();
 }
 
+export function ExportWorkspace(arg1) {
+  return window['go']['main']['App']['ExportWorkspace'](arg1);
+}
+
+export function ExtractIssueKeys(arg1) {
+  return window['go']['main']['App']['ExtractIssueKeys'](arg1);
+}
+
+export function FocusPause() {
+  return window['go']['main']['App']['FocusPause']();
+}
+
+export function FocusResume() {
+  return window['go']['main']['App']['FocusResume']();
+}
+
+export function FocusStart(arg1, arg2) {
+  return window['go']['main']['App']['FocusStart'](arg1, arg2);
+}
+
+export function FocusStop() {
+  return window['go']['main']['App']['FocusStop']();
+}
+
+export function FuzzyFindFiles(arg1, arg2, arg3) {
+  return window['go']['main']['App']['FuzzyFindFiles'](arg1, arg2, arg3);
+}
+
 export function GHClosePullRequest(arg1, arg2, arg3) {
   return window['go']['main']['App']['GHClosePullRequest'](arg1, arg2, arg3);
 }
@@ -114,6 +290,26 @@ export function GHCreateReview(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['main']['App']['GHCreateReview'](arg1, arg2, arg3, arg4, arg5);
 }
 
+export function GHDeleteIssueFilter(arg1) {
+  return window['go']['main']['App']['GHDeleteIssueFilter'](arg1);
+}
+
+export function GHDispatchWorkflow(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['GHDispatchWorkflow'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function GHForkRepository(arg1, arg2) {
+  return window['go']['main']['App']['GHForkRepository'](arg1, arg2);
+}
+
+export function GHGetIssueFilterCounts(arg1, arg2) {
+  return window['go']['main']['App']['GHGetIssueFilterCounts'](arg1, arg2);
+}
+
+export function GHGetMyPullRequestDashboard() {
+  return window['go']['main']['App']['GHGetMyPullRequestDashboard']();
+}
+
 export function GHGetPullRequest(arg1, arg2, arg3) {
   return window['go']['main']['App']['GHGetPullRequest'](arg1, arg2, arg3);
 }
@@ -134,10 +330,18 @@ export function GHListComments(arg1, arg2, arg3) {
   return window['go']['main']['App']['GHListComments'](arg1, arg2, arg3);
 }
 
+export function GHListIssueFilters(arg1, arg2) {
+  return window['go']['main']['App']['GHListIssueFilters'](arg1, arg2);
+}
+
 export function GHListIssues(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['GHListIssues'](arg1, arg2, arg3, arg4);
 }
 
+export function GHListIssuesWithFilter(arg1, arg2) {
+  return window['go']['main']['App']['GHListIssuesWithFilter'](arg1, arg2);
+}
+
 export function GHListPullRequests(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['GHListPullRequests'](arg1, arg2, arg3, arg4);
 }
@@ -154,14 +358,42 @@ export function GHMergePullRequest(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['GHMergePullRequest'](arg1, arg2, arg3, arg4);
 }
 
+export function GHRerunCheckRun(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GHRerunCheckRun'](arg1, arg2, arg3);
+}
+
+export function GHRerunFailedJobs(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GHRerunFailedJobs'](arg1, arg2, arg3);
+}
+
+export function GHSaveIssueFilter(arg1) {
+  return window['go']['main']['App']['GHSaveIssueFilter'](arg1);
+}
+
 export function GHUpdateIssue(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['main']['App']['GHUpdateIssue'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
+export function GenerateActivityDigest(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GenerateActivityDigest'](arg1, arg2, arg3);
+}
+
+export function GetAPITrace(arg1) {
+  return window['go']['main']['App']['GetAPITrace'](arg1);
+}
+
+export function GetAgentReplayCommands(arg1) {
+  return window['go']['main']['App']['GetAgentReplayCommands'](arg1);
+}
+
 export function GetAppInfo() {
   return window['go']['main']['App']['GetAppInfo']();
 }
 
+export function GetAuditRetentionPolicy() {
+  return window['go']['main']['App']['GetAuditRetentionPolicy']();
+}
+
 export function GetAuthState() {
   return window['go']['main']['App']['GetAuthState']();
 }
@@ -174,6 +406,18 @@ export function GetAvailableTerminalFonts() {
   return window['go']['main']['App']['GetAvailableTerminalFonts']();
 }
 
+export function GetBackupSchedule() {
+  return window['go']['main']['App']['GetBackupSchedule']();
+}
+
+export function GetClipboardHistorySettings() {
+  return window['go']['main']['App']['GetClipboardHistorySettings']();
+}
+
+export function GetCoordinationStatus() {
+  return window['go']['main']['App']['GetCoordinationStatus']();
+}
+
 export function GetCurrentBranch(arg1) {
   return window['go']['main']['App']['GetCurrentBranch'](arg1);
 }
@@ -182,34 +426,150 @@ export function GetCustomStackTools() {
   return window['go']['main']['App']['GetCustomStackTools']();
 }
 
+export function GetDatabaseInfo() {
+  return window['go']['main']['App']['GetDatabaseInfo']();
+}
+
+export function GetEffectiveConfig() {
+  return window['go']['main']['App']['GetEffectiveConfig']();
+}
+
+export function GetFileWatcherMetrics() {
+  return window['go']['main']['App']['GetFileWatcherMetrics']();
+}
+
+export function GetFocusState() {
+  return window['go']['main']['App']['GetFocusState']();
+}
+
+export function GetGitHubWebhookBridgeStatus() {
+  return window['go']['main']['App']['GetGitHubWebhookBridgeStatus']();
+}
+
 export function GetHydrationData() {
   return window['go']['main']['App']['GetHydrationData']();
 }
 
+export function GetIssueTrackerSettings() {
+  return window['go']['main']['App']['GetIssueTrackerSettings']();
+}
+
 export function GetLastCommit(arg1) {
   return window['go']['main']['App']['GetLastCommit'](arg1);
 }
 
+export function GetLastTestResults(arg1) {
+  return window['go']['main']['App']['GetLastTestResults'](arg1);
+}
+
 export function GetLayoutState() {
   return window['go']['main']['App']['GetLayoutState']();
 }
 
+export function GetLifecycleHookRuns() {
+  return window['go']['main']['App']['GetLifecycleHookRuns']();
+}
+
+export function GetLogFilePath() {
+  return window['go']['main']['App']['GetLogFilePath']();
+}
+
+export function GetLogLevel() {
+  return window['go']['main']['App']['GetLogLevel']();
+}
+
+export function GetMCPSettings() {
+  return window['go']['main']['App']['GetMCPSettings']();
+}
+
+export function GetMessageCatalog(arg1) {
+  return window['go']['main']['App']['GetMessageCatalog'](arg1);
+}
+
+export function GetNamedStackTools(arg1) {
+  return window['go']['main']['App']['GetNamedStackTools'](arg1);
+}
+
+export function GetNetworkSettings() {
+  return window['go']['main']['App']['GetNetworkSettings']();
+}
+
+export function GetNotificationDeliveries() {
+  return window['go']['main']['App']['GetNotificationDeliveries']();
+}
+
+export function GetPendingDeepLinks() {
+  return window['go']['main']['App']['GetPendingDeepLinks']();
+}
+
+export function GetPendingMCPApprovals() {
+  return window['go']['main']['App']['GetPendingMCPApprovals']();
+}
+
+export function GetProblems(arg1) {
+  return window['go']['main']['App']['GetProblems'](arg1);
+}
+
+export function GetProfiles() {
+  return window['go']['main']['App']['GetProfiles']();
+}
+
+export function GetRESTAPISettings() {
+  return window['go']['main']['App']['GetRESTAPISettings']();
+}
+
 export function GetRateLimitInfo() {
   return window['go']['main']['App']['GetRateLimitInfo']();
 }
 
+export function GetRecentLogs(arg1, arg2) {
+  return window['go']['main']['App']['GetRecentLogs'](arg1, arg2);
+}
+
+export function GetSessionNetworkPolicyDefaults() {
+  return window['go']['main']['App']['GetSessionNetworkPolicyDefaults']();
+}
+
+export function GetSessionSecurityProfile() {
+  return window['go']['main']['App']['GetSessionSecurityProfile']();
+}
+
+export function GetSessionTimeoutPolicyDefaults() {
+  return window['go']['main']['App']['GetSessionTimeoutPolicyDefaults']();
+}
+
 export function GetStackBuildState() {
   return window['go']['main']['App']['GetStackBuildState']();
 }
 
+export function GetStartupTimings() {
+  return window['go']['main']['App']['GetStartupTimings']();
+}
+
+export function GetSystemHealth() {
+  return window['go']['main']['App']['GetSystemHealth']();
+}
+
+export function GetTerminalBroadcastGroups() {
+  return window['go']['main']['App']['GetTerminalBroadcastGroups']();
+}
+
 export function GetTerminalSnapshots() {
   return window['go']['main']['App']['GetTerminalSnapshots']();
 }
 
+export function GetTerminalTranscript(arg1, arg2) {
+  return window['go']['main']['App']['GetTerminalTranscript'](arg1, arg2);
+}
+
 export function GetTerminals() {
   return window['go']['main']['App']['GetTerminals']();
 }
 
+export function GetUpdateChannel() {
+  return window['go']['main']['App']['GetUpdateChannel']();
+}
+
 export function GetWorkspaceHistoryBuffer(arg1) {
   return window['go']['main']['App']['GetWorkspaceHistoryBuffer'](arg1);
 }
@@ -250,6 +610,22 @@ export function GitActivityUnstageFile(arg1, arg2) {
   return window['go']['main']['App']['GitActivityUnstageFile'](arg1, arg2);
 }
 
+export function GitAutoFetchConfigure(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitAutoFetchConfigure'](arg1, arg2, arg3);
+}
+
+export function GitAutoFetchLastResult(arg1) {
+  return window['go']['main']['App']['GitAutoFetchLastResult'](arg1);
+}
+
+export function GitAutoFetchListSettings() {
+  return window['go']['main']['App']['GitAutoFetchListSettings']();
+}
+
+export function GitAutoFetchNow(arg1) {
+  return window['go']['main']['App']['GitAutoFetchNow'](arg1);
+}
+
 export function GitPanelAcceptOurs(arg1, arg2, arg3) {
   return window['go']['main']['App']['GitPanelAcceptOurs'](arg1, arg2, arg3);
 }
@@ -258,138 +634,634 @@ export function GitPanelAcceptTheirs(arg1, arg2, arg3) {
   return window['go']['main']['App']['GitPanelAcceptTheirs'](arg1, arg2, arg3);
 }
 
-export function GitPanelDiscardFile(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelDiscardFile'](arg1, arg2);
+export function GitPanelAddIgnorePattern(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelAddIgnorePattern'](arg1, arg2);
 }
 
-export function GitPanelGetCommitDetails(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelGetCommitDetails'](arg1, arg2);
+export function GitPanelBenchmarkReadBackends(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelBenchmarkReadBackends'](arg1, arg2);
 }
 
-export function GitPanelGetCommitDiff(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['GitPanelGetCommitDiff'](arg1, arg2, arg3, arg4);
+export function GitPanelCheckIgnored(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelCheckIgnored'](arg1, arg2);
 }
 
-export function GitPanelGetConflicts(arg1) {
-  return window['go']['main']['App']['GitPanelGetConflicts'](arg1);
+export function GitPanelCheckPushSafety(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelCheckPushSafety'](arg1, arg2);
 }
 
-export function GitPanelGetDiff(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['GitPanelGetDiff'](arg1, arg2, arg3, arg4);
+export function GitPanelCommit(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelCommit'](arg1, arg2, arg3);
 }
 
-export function GitPanelGetHistory(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['GitPanelGetHistory'](arg1, arg2, arg3, arg4);
+export function GitPanelCommitCancelable(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelCommitCancelable'](arg1, arg2, arg3, arg4);
 }
 
-export function GitPanelGetStatus(arg1) {
-  return window['go']['main']['App']['GitPanelGetStatus'](arg1);
+export function GitPanelCompareBranches(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelCompareBranches'](arg1, arg2, arg3);
 }
 
-export function GitPanelOpenExternalMergeTool(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelOpenExternalMergeTool'](arg1, arg2);
+export function GitPanelConfigureSigning(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelConfigureSigning'](arg1, arg2);
 }
 
-export function GitPanelPRCheckMerged(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelPRCheckMerged'](arg1, arg2);
+export function GitPanelDisableSparseCheckout(arg1) {
+  return window['go']['main']['App']['GitPanelDisableSparseCheckout'](arg1);
 }
 
-export function GitPanelPRCreate(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelPRCreate'](arg1, arg2);
+export function GitPanelDiscardFile(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelDiscardFile'](arg1, arg2);
 }
 
-export function GitPanelPRCreateLabel(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelPRCreateLabel'](arg1, arg2);
+export function GitPanelDiscardFileCancelable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelDiscardFileCancelable'](arg1, arg2, arg3);
 }
 
-export function GitPanelPRCreateLocalBranch(arg1, arg2, arg3) {
-  return window['go']['main']['App']['GitPanelPRCreateLocalBranch'](arg1, arg2, arg3);
+export function GitPanelFetchAsync(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelFetchAsync'](arg1, arg2);
 }
 
-export function GitPanelPRGet(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelPRGet'](arg1, arg2);
+export function GitPanelGenerateChangelog(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelGenerateChangelog'](arg1, arg2, arg3, arg4);
 }
 
-export function GitPanelPRGetCommitRawDiff(arg1, arg2, arg3) {
-  return window['go']['main']['App']['GitPanelPRGetCommitRawDiff'](arg1, arg2, arg3);
+export function GitPanelGetBranchNamingPolicy(arg1) {
+  return window['go']['main']['App']['GitPanelGetBranchNamingPolicy'](arg1);
 }
 
-export function GitPanelPRGetCommits(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['GitPanelPRGetCommits'](arg1, arg2, arg3, arg4);
+export function GitPanelGetCacheMetrics() {
+  return window['go']['main']['App']['GitPanelGetCacheMetrics']();
 }
 
-export function GitPanelPRGetFiles(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['GitPanelPRGetFiles'](arg1, arg2, arg3, arg4);
+export function GitPanelGetCacheTTLs(arg1) {
+  return window['go']['main']['App']['GitPanelGetCacheTTLs'](arg1);
 }
 
-export function GitPanelPRGetRawDiff(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelPRGetRawDiff'](arg1, arg2);
+export function GitPanelGetCommitDetails(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelGetCommitDetails'](arg1, arg2);
 }
 
-export function GitPanelPRList(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['GitPanelPRList'](arg1, arg2, arg3, arg4);
+export function GitPanelGetCommitDiff(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelGetCommitDiff'](arg1, arg2, arg3, arg4);
 }
 
-export function GitPanelPRMerge(arg1, arg2, arg3) {
-  return window['go']['main']['App']['GitPanelPRMerge'](arg1, arg2, arg3);
+export function GitPanelGetCommitDiffWithOptions(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['GitPanelGetCommitDiffWithOptions'](arg1, arg2, arg3, arg4, arg5);
 }
 
-export function GitPanelPRPushLocalBranch(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelPRPushLocalBranch'](arg1, arg2);
+export function GitPanelGetConfig(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelGetConfig'](arg1, arg2);
 }
 
-export function GitPanelPRResolveRepository(arg1, arg2, arg3, arg4) {
-  return window['go']['main']['App']['GitPanelPRResolveRepository'](arg1, arg2, arg3, arg4);
+export function GitPanelGetConflicts(arg1) {
+  return window['go']['main']['App']['GitPanelGetConflicts'](arg1);
 }
 
-export function GitPanelPRUpdate(arg1, arg2, arg3) {
-  return window['go']['main']['App']['GitPanelPRUpdate'](arg1, arg2, arg3);
+export function GitPanelGetContributorStats(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelGetContributorStats'](arg1, arg2, arg3, arg4);
 }
 
-export function GitPanelPRUpdateBranch(arg1, arg2, arg3) {
-  return window['go']['main']['App']['GitPanelPRUpdateBranch'](arg1, arg2, arg3);
+export function GitPanelGetDiff(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelGetDiff'](arg1, arg2, arg3, arg4);
 }
 
-export function GitPanelPickRepositoryDirectory(arg1) {
-  return window['go']['main']['App']['GitPanelPickRepositoryDirectory'](arg1);
+export function GitPanelGetDiffCancelable(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['GitPanelGetDiffCancelable'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
-export function GitPanelPreflight(arg1) {
-  return window['go']['main']['App']['GitPanelPreflight'](arg1);
+export function GitPanelGetDiffWithOptions(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['GitPanelGetDiffWithOptions'](arg1, arg2, arg3, arg4, arg5);
 }
 
-export function GitPanelStageFile(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelStageFile'](arg1, arg2);
+export function GitPanelGetExecMetrics() {
+  return window['go']['main']['App']['GitPanelGetExecMetrics']();
 }
 
-export function GitPanelStagePatch(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelStagePatch'](arg1, arg2);
+export function GitPanelGetExternalToolSettings(arg1) {
+  return window['go']['main']['App']['GitPanelGetExternalToolSettings'](arg1);
 }
 
-export function GitPanelUnstageFile(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelUnstageFile'](arg1, arg2);
+export function GitPanelGetFileHistory(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelGetFileHistory'](arg1, arg2, arg3, arg4);
 }
 
-export function GitPanelUnstagePatch(arg1, arg2) {
-  return window['go']['main']['App']['GitPanelUnstagePatch'](arg1, arg2);
+export function GitPanelGetForkSyncStatus(arg1) {
+  return window['go']['main']['App']['GitPanelGetForkSyncStatus'](arg1);
 }
 
-export function HandleDeepLink(arg1) {
-  return window['go']['main']['App']['HandleDeepLink'](arg1);
+export function GitPanelGetHistory(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelGetHistory'](arg1, arg2, arg3, arg4);
+}
+
+export function GitPanelGetHistoryCountEstimate(arg1) {
+  return window['go']['main']['App']['GitPanelGetHistoryCountEstimate'](arg1);
+}
+
+export function GitPanelGetHistoryDetail(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['GitPanelGetHistoryDetail'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function GitPanelGetHookSetup(arg1) {
+  return window['go']['main']['App']['GitPanelGetHookSetup'](arg1);
+}
+
+export function GitPanelGetIgnoreRules(arg1) {
+  return window['go']['main']['App']['GitPanelGetIgnoreRules'](arg1);
+}
+
+export function GitPanelGetPartialClone(arg1) {
+  return window['go']['main']['App']['GitPanelGetPartialClone'](arg1);
+}
+
+export function GitPanelGetPathScope(arg1) {
+  return window['go']['main']['App']['GitPanelGetPathScope'](arg1);
+}
+
+export function GitPanelGetRangeDiff(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelGetRangeDiff'](arg1, arg2, arg3, arg4);
+}
+
+export function GitPanelGetReadBackend(arg1) {
+  return window['go']['main']['App']['GitPanelGetReadBackend'](arg1);
+}
+
+export function GitPanelGetReflog(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelGetReflog'](arg1, arg2);
+}
+
+export function GitPanelGetRepoStats(arg1) {
+  return window['go']['main']['App']['GitPanelGetRepoStats'](arg1);
+}
+
+export function GitPanelGetSigningStatus(arg1) {
+  return window['go']['main']['App']['GitPanelGetSigningStatus'](arg1);
+}
+
+export function GitPanelGetSparseCheckout(arg1) {
+  return window['go']['main']['App']['GitPanelGetSparseCheckout'](arg1);
+}
+
+export function GitPanelGetStatus(arg1) {
+  return window['go']['main']['App']['GitPanelGetStatus'](arg1);
+}
+
+export function GitPanelGetStatusCancelable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelGetStatusCancelable'](arg1, arg2, arg3);
+}
+
+export function GitPanelGetStatusDetail(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelGetStatusDetail'](arg1, arg2);
+}
+
+export function GitPanelGetStatusForAgent(arg1) {
+  return window['go']['main']['App']['GitPanelGetStatusForAgent'](arg1);
+}
+
+export function GitPanelLintCommitMessage(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelLintCommitMessage'](arg1, arg2);
+}
+
+export function GitPanelListExternalTools() {
+  return window['go']['main']['App']['GitPanelListExternalTools']();
+}
+
+export function GitPanelListSecretOverrides(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelListSecretOverrides'](arg1, arg2);
+}
+
+export function GitPanelOpenExternalDiffTool(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelOpenExternalDiffTool'](arg1, arg2);
+}
+
+export function GitPanelOpenExternalMergeTool(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelOpenExternalMergeTool'](arg1, arg2);
+}
+
+export function GitPanelPRCheckMerged(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelPRCheckMerged'](arg1, arg2);
+}
+
+export function GitPanelPRCreate(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelPRCreate'](arg1, arg2);
+}
+
+export function GitPanelPRCreateLabel(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelPRCreateLabel'](arg1, arg2);
+}
+
+export function GitPanelPRCreateLocalBranch(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelPRCreateLocalBranch'](arg1, arg2, arg3);
+}
+
+export function GitPanelPRGet(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelPRGet'](arg1, arg2);
+}
+
+export function GitPanelPRGetCodeOwners(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelPRGetCodeOwners'](arg1, arg2, arg3);
+}
+
+export function GitPanelPRGetCommitRawDiff(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelPRGetCommitRawDiff'](arg1, arg2, arg3);
+}
+
+export function GitPanelPRGetCommitRawDiffCancelable(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelPRGetCommitRawDiffCancelable'](arg1, arg2, arg3, arg4);
+}
+
+export function GitPanelPRGetCommits(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelPRGetCommits'](arg1, arg2, arg3, arg4);
+}
+
+export function GitPanelPRGetCommitsCancelable(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['GitPanelPRGetCommitsCancelable'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function GitPanelPRGetFiles(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelPRGetFiles'](arg1, arg2, arg3, arg4);
+}
+
+export function GitPanelPRGetFilesCancelable(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['GitPanelPRGetFilesCancelable'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function GitPanelPRGetIssueDevelopment(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelPRGetIssueDevelopment'](arg1, arg2);
+}
+
+export function GitPanelPRGetLinkedIssues(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelPRGetLinkedIssues'](arg1, arg2);
+}
+
+export function GitPanelPRGetMergeRequirements(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelPRGetMergeRequirements'](arg1, arg2);
+}
+
+export function GitPanelPRGetPullRequestCodeOwners(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelPRGetPullRequestCodeOwners'](arg1, arg2);
+}
+
+export function GitPanelPRGetRawDiff(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelPRGetRawDiff'](arg1, arg2);
+}
+
+export function GitPanelPRGetRawDiffCancelable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelPRGetRawDiffCancelable'](arg1, arg2, arg3);
+}
+
+export function GitPanelPRList(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelPRList'](arg1, arg2, arg3, arg4);
+}
+
+export function GitPanelPRListTemplates(arg1) {
+  return window['go']['main']['App']['GitPanelPRListTemplates'](arg1);
+}
+
+export function GitPanelPRMerge(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelPRMerge'](arg1, arg2, arg3);
+}
+
+export function GitPanelPRPrefillCreate(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelPRPrefillCreate'](arg1, arg2, arg3);
+}
+
+export function GitPanelPRPushLocalBranch(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelPRPushLocalBranch'](arg1, arg2, arg3);
+}
+
+export function GitPanelPRPushLocalBranchAsync(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelPRPushLocalBranchAsync'](arg1, arg2, arg3);
+}
+
+export function GitPanelPRPushLocalBranchCancelable(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelPRPushLocalBranchCancelable'](arg1, arg2, arg3, arg4);
+}
+
+export function GitPanelPRResolveRepository(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelPRResolveRepository'](arg1, arg2, arg3, arg4);
+}
+
+export function GitPanelPRSetBaseRemote(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelPRSetBaseRemote'](arg1, arg2);
+}
+
+export function GitPanelPRUpdate(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelPRUpdate'](arg1, arg2, arg3);
+}
+
+export function GitPanelPRUpdateBranch(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelPRUpdateBranch'](arg1, arg2, arg3);
+}
+
+export function GitPanelPickRepositoryDirectory(arg1) {
+  return window['go']['main']['App']['GitPanelPickRepositoryDirectory'](arg1);
+}
+
+export function GitPanelPreflight(arg1) {
+  return window['go']['main']['App']['GitPanelPreflight'](arg1);
+}
+
+export function GitPanelPublishChangelog(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['GitPanelPublishChangelog'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function GitPanelPush(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelPush'](arg1, arg2);
+}
+
+export function GitPanelPushAsync(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelPushAsync'](arg1, arg2);
+}
+
+export function GitPanelRefreshContributorStats(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelRefreshContributorStats'](arg1, arg2, arg3);
+}
+
+export function GitPanelRefreshRepoStats(arg1) {
+  return window['go']['main']['App']['GitPanelRefreshRepoStats'](arg1);
+}
+
+export function GitPanelResetCacheMetrics() {
+  return window['go']['main']['App']['GitPanelResetCacheMetrics']();
+}
+
+export function GitPanelResetExecMetrics() {
+  return window['go']['main']['App']['GitPanelResetExecMetrics']();
+}
+
+export function GitPanelResolveAgentRepository(arg1) {
+  return window['go']['main']['App']['GitPanelResolveAgentRepository'](arg1);
+}
+
+export function GitPanelScanStagedSecrets(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelScanStagedSecrets'](arg1, arg2);
+}
+
+export function GitPanelSetAllowNoVerify(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelSetAllowNoVerify'](arg1, arg2);
+}
+
+export function GitPanelSetBranchNamingPolicy(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelSetBranchNamingPolicy'](arg1, arg2);
+}
+
+export function GitPanelSetCacheTTLs(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelSetCacheTTLs'](arg1, arg2);
+}
+
+export function GitPanelSetCoAuthorGuests(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelSetCoAuthorGuests'](arg1, arg2);
+}
+
+export function GitPanelSetCommitLintMode(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelSetCommitLintMode'](arg1, arg2);
+}
+
+export function GitPanelSetConfig(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelSetConfig'](arg1, arg2, arg3, arg4);
+}
+
+export function GitPanelSetDefaultExternalTools(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelSetDefaultExternalTools'](arg1, arg2);
+}
+
+export function GitPanelSetPartialClone(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelSetPartialClone'](arg1, arg2, arg3);
+}
+
+export function GitPanelSetPathScope(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelSetPathScope'](arg1, arg2);
+}
+
+export function GitPanelSetReadBackend(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelSetReadBackend'](arg1, arg2);
+}
+
+export function GitPanelSetRepoExternalTools(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelSetRepoExternalTools'](arg1, arg2, arg3);
+}
+
+export function GitPanelSetSparseCheckout(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelSetSparseCheckout'](arg1, arg2);
+}
+
+export function GitPanelStageFile(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelStageFile'](arg1, arg2);
+}
+
+export function GitPanelStageFileCancelable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelStageFileCancelable'](arg1, arg2, arg3);
+}
+
+export function GitPanelStagePatch(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelStagePatch'](arg1, arg2);
+}
+
+export function GitPanelStagePatchCancelable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelStagePatchCancelable'](arg1, arg2, arg3);
+}
+
+export function GitPanelStreamHistoryAsync(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelStreamHistoryAsync'](arg1, arg2);
+}
+
+export function GitPanelSuggestBranchName(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GitPanelSuggestBranchName'](arg1, arg2, arg3, arg4);
+}
+
+export function GitPanelSuggestCoAuthors(arg1) {
+  return window['go']['main']['App']['GitPanelSuggestCoAuthors'](arg1);
+}
+
+export function GitPanelSuggestCommitScopes(arg1) {
+  return window['go']['main']['App']['GitPanelSuggestCommitScopes'](arg1);
+}
+
+export function GitPanelSuggestIgnorePatterns(arg1) {
+  return window['go']['main']['App']['GitPanelSuggestIgnorePatterns'](arg1);
+}
+
+export function GitPanelSyncForkWithUpstream(arg1) {
+  return window['go']['main']['App']['GitPanelSyncForkWithUpstream'](arg1);
+}
+
+export function GitPanelTestExternalTool(arg1) {
+  return window['go']['main']['App']['GitPanelTestExternalTool'](arg1);
+}
+
+export function GitPanelUndoLast(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelUndoLast'](arg1, arg2);
+}
+
+export function GitPanelUnstageFile(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelUnstageFile'](arg1, arg2);
+}
+
+export function GitPanelUnstageFileCancelable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelUnstageFileCancelable'](arg1, arg2, arg3);
+}
+
+export function GitPanelUnstagePatch(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelUnstagePatch'](arg1, arg2);
+}
+
+export function GitPanelUnstagePatchCancelable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GitPanelUnstagePatchCancelable'](arg1, arg2, arg3);
+}
+
+export function GitPanelValidateBranchName(arg1, arg2) {
+  return window['go']['main']['App']['GitPanelValidateBranchName'](arg1, arg2);
+}
+
+export function GitPanelWarmCache(arg1) {
+  return window['go']['main']['App']['GitPanelWarmCache'](arg1);
+}
+
+export function HandleDeepLink(arg1) {
+  return window['go']['main']['App']['HandleDeepLink'](arg1);
+}
+
+export function ImportWorkspace(arg1) {
+  return window['go']['main']['App']['ImportWorkspace'](arg1);
+}
+
+export function InstallUpdate() {
+  return window['go']['main']['App']['InstallUpdate']();
+}
+
+export function IsAPIInspectorEnabled() {
+  return window['go']['main']['App']['IsAPIInspectorEnabled']();
 }
 
 export function IsTerminalAlive(arg1) {
   return window['go']['main']['App']['IsTerminalAlive'](arg1);
 }
 
+export function JobCancel(arg1) {
+  return window['go']['main']['App']['JobCancel'](arg1);
+}
+
+export function JobGet(arg1) {
+  return window['go']['main']['App']['JobGet'](arg1);
+}
+
+export function JobsList() {
+  return window['go']['main']['App']['JobsList']();
+}
+
+export function KubeIsAvailable() {
+  return window['go']['main']['App']['KubeIsAvailable']();
+}
+
+export function KubeListContexts() {
+  return window['go']['main']['App']['KubeListContexts']();
+}
+
+export function KubeListNamespaces(arg1) {
+  return window['go']['main']['App']['KubeListNamespaces'](arg1);
+}
+
+export function KubeListPods(arg1, arg2) {
+  return window['go']['main']['App']['KubeListPods'](arg1, arg2);
+}
+
 export function ListAgents() {
   return window['go']['main']['App']['ListAgents']();
 }
 
+export function ListEditors() {
+  return window['go']['main']['App']['ListEditors']();
+}
+
+export function ListGitHubAccounts() {
+  return window['go']['main']['App']['ListGitHubAccounts']();
+}
+
+export function ListLifecycleHookEvents() {
+  return window['go']['main']['App']['ListLifecycleHookEvents']();
+}
+
+export function ListLifecycleHooks() {
+  return window['go']['main']['App']['ListLifecycleHooks']();
+}
+
+export function ListNotificationEvents() {
+  return window['go']['main']['App']['ListNotificationEvents']();
+}
+
+export function ListNotificationTargets() {
+  return window['go']['main']['App']['ListNotificationTargets']();
+}
+
+export function ListProblemMatchers() {
+  return window['go']['main']['App']['ListProblemMatchers']();
+}
+
+export function ListSnippets(arg1) {
+  return window['go']['main']['App']['ListSnippets'](arg1);
+}
+
+export function ListStackImages() {
+  return window['go']['main']['App']['ListStackImages']();
+}
+
+export function ListWorkspaceRepositories(arg1) {
+  return window['go']['main']['App']['ListWorkspaceRepositories'](arg1);
+}
+
+export function ListWorkspaceTasks(arg1, arg2) {
+  return window['go']['main']['App']['ListWorkspaceTasks'](arg1, arg2);
+}
+
+export function ListWorkspaceTemplates() {
+  return window['go']['main']['App']['ListWorkspaceTemplates']();
+}
+
 export function MoveAgentSessionToWorkspace(arg1, arg2) {
   return window['go']['main']['App']['MoveAgentSessionToWorkspace'](arg1, arg2);
 }
 
+export function OpenInEditor(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['OpenInEditor'](arg1, arg2, arg3, arg4);
+}
+
+export function OpenKubePodLogs(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['OpenKubePodLogs'](arg1, arg2, arg3, arg4);
+}
+
+export function PruneDanglingStackImages() {
+  return window['go']['main']['App']['PruneDanglingStackImages']();
+}
+
+export function ReadWorkspaceFile(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ReadWorkspaceFile'](arg1, arg2, arg3);
+}
+
+export function ReconnectKubePodTerminal(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ReconnectKubePodTerminal'](arg1, arg2, arg3);
+}
+
+export function RegenerateMCPToken() {
+  return window['go']['main']['App']['RegenerateMCPToken']();
+}
+
+export function RegenerateRESTAPIToken() {
+  return window['go']['main']['App']['RegenerateRESTAPIToken']();
+}
+
+export function RemoveGitHubAccount(arg1) {
+  return window['go']['main']['App']['RemoveGitHubAccount'](arg1);
+}
+
+export function RemoveTerminalFromBroadcastGroup(arg1) {
+  return window['go']['main']['App']['RemoveTerminalFromBroadcastGroup'](arg1);
+}
+
+export function RemoveWorkspaceRepository(arg1) {
+  return window['go']['main']['App']['RemoveWorkspaceRepository'](arg1);
+}
+
 export function RenameWorkspace(arg1, arg2) {
   return window['go']['main']['App']['RenameWorkspace'](arg1, arg2);
 }
@@ -398,6 +1270,30 @@ export function ResizeTerminal(arg1, arg2, arg3) {
   return window['go']['main']['App']['ResizeTerminal'](arg1, arg2, arg3);
 }
 
+export function ResolveGitHubAccount(arg1) {
+  return window['go']['main']['App']['ResolveGitHubAccount'](arg1);
+}
+
+export function ResolveIssueKeys(arg1) {
+  return window['go']['main']['App']['ResolveIssueKeys'](arg1);
+}
+
+export function RespondDeepLink(arg1, arg2) {
+  return window['go']['main']['App']['RespondDeepLink'](arg1, arg2);
+}
+
+export function RespondMCPApproval(arg1, arg2) {
+  return window['go']['main']['App']['RespondMCPApproval'](arg1, arg2);
+}
+
+export function RestoreTerminalForAgent(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['RestoreTerminalForAgent'](arg1, arg2, arg3, arg4);
+}
+
+export function RunSnippet(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RunSnippet'](arg1, arg2, arg3);
+}
+
 export function SaveAgentLayout(arg1, arg2) {
   return window['go']['main']['App']['SaveAgentLayout'](arg1, arg2);
 }
@@ -414,10 +1310,26 @@ export function SaveLayoutState(arg1) {
   return window['go']['main']['App']['SaveLayoutState'](arg1);
 }
 
+export function SaveLifecycleHook(arg1) {
+  return window['go']['main']['App']['SaveLifecycleHook'](arg1);
+}
+
+export function SaveNotificationTarget(arg1) {
+  return window['go']['main']['App']['SaveNotificationTarget'](arg1);
+}
+
+export function SaveProblemMatcher(arg1) {
+  return window['go']['main']['App']['SaveProblemMatcher'](arg1);
+}
+
 export function SaveShortcutBindings(arg1) {
   return window['go']['main']['App']['SaveShortcutBindings'](arg1);
 }
 
+export function SaveSnippet(arg1) {
+  return window['go']['main']['App']['SaveSnippet'](arg1);
+}
+
 export function SaveTerminalCursorStyle(arg1) {
   return window['go']['main']['App']['SaveTerminalCursorStyle'](arg1);
 }
@@ -438,14 +1350,42 @@ export function SaveTheme(arg1) {
   return window['go']['main']['App']['SaveTheme'](arg1);
 }
 
+export function SaveWorkspaceTemplate(arg1) {
+  return window['go']['main']['App']['SaveWorkspaceTemplate'](arg1);
+}
+
+export function ScanDiskUsage() {
+  return window['go']['main']['App']['ScanDiskUsage']();
+}
+
+export function SearchInWorkspace(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SearchInWorkspace'](arg1, arg2, arg3, arg4);
+}
+
+export function SessionApproveCommand(arg1) {
+  return window['go']['main']['App']['SessionApproveCommand'](arg1);
+}
+
 export function SessionApproveGuest(arg1, arg2) {
   return window['go']['main']['App']['SessionApproveGuest'](arg1, arg2);
 }
 
+export function SessionApproveReviewDraft(arg1) {
+  return window['go']['main']['App']['SessionApproveReviewDraft'](arg1);
+}
+
 export function SessionCreate(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['SessionCreate'](arg1, arg2, arg3, arg4);
 }
 
+export function SessionCreateWithImage(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['SessionCreateWithImage'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SessionDenyCommand(arg1, arg2) {
+  return window['go']['main']['App']['SessionDenyCommand'](arg1, arg2);
+}
+
 export function SessionEnd(arg1) {
   return window['go']['main']['App']['SessionEnd'](arg1);
 }
@@ -454,10 +1394,22 @@ export function SessionGetActive() {
   return window['go']['main']['App']['SessionGetActive']();
 }
 
+export function SessionGetAnalytics(arg1) {
+  return window['go']['main']['App']['SessionGetAnalytics'](arg1);
+}
+
 export function SessionGetAuditLogs(arg1, arg2) {
   return window['go']['main']['App']['SessionGetAuditLogs'](arg1, arg2);
 }
 
+export function SessionGetContainerSecurity(arg1) {
+  return window['go']['main']['App']['SessionGetContainerSecurity'](arg1);
+}
+
+export function SessionGetGitPanelShare() {
+  return window['go']['main']['App']['SessionGetGitPanelShare']();
+}
+
 export function SessionGetICEServers() {
   return window['go']['main']['App']['SessionGetICEServers']();
 }
@@ -466,6 +1418,10 @@ export function SessionGetJoinSecurityMetrics() {
   return window['go']['main']['App']['SessionGetJoinSecurityMetrics']();
 }
 
+export function SessionGetPRReviewTarget(arg1) {
+  return window['go']['main']['App']['SessionGetPRReviewTarget'](arg1);
+}
+
 export function SessionGetSession(arg1) {
   return window['go']['main']['App']['SessionGetSession'](arg1);
 }
@@ -474,6 +1430,22 @@ export function SessionGetSignalingURL() {
   return window['go']['main']['App']['SessionGetSignalingURL']();
 }
 
+export function SessionGitPanelGetDiff(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SessionGitPanelGetDiff'](arg1, arg2, arg3, arg4);
+}
+
+export function SessionGitPanelGetHistory(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SessionGitPanelGetHistory'](arg1, arg2, arg3, arg4);
+}
+
+export function SessionGitPanelGetShare(arg1) {
+  return window['go']['main']['App']['SessionGitPanelGetShare'](arg1);
+}
+
+export function SessionGitPanelGetStatus(arg1) {
+  return window['go']['main']['App']['SessionGitPanelGetStatus'](arg1);
+}
+
 export function SessionJoin(arg1, arg2, arg3) {
   return window['go']['main']['App']['SessionJoin'](arg1, arg2, arg3);
 }
@@ -482,10 +1454,22 @@ export function SessionKickGuest(arg1, arg2) {
   return window['go']['main']['App']['SessionKickGuest'](arg1, arg2);
 }
 
+export function SessionListHeldCommands() {
+  return window['go']['main']['App']['SessionListHeldCommands']();
+}
+
+export function SessionListMyReviewDrafts(arg1) {
+  return window['go']['main']['App']['SessionListMyReviewDrafts'](arg1);
+}
+
 export function SessionListPendingGuests(arg1) {
   return window['go']['main']['App']['SessionListPendingGuests'](arg1);
 }
 
+export function SessionListReviewDrafts() {
+  return window['go']['main']['App']['SessionListReviewDrafts']();
+}
+
 export function SessionRegenerateCode(arg1) {
   return window['go']['main']['App']['SessionRegenerateCode'](arg1);
 }
@@ -494,6 +1478,10 @@ export function SessionRejectGuest(arg1, arg2) {
   return window['go']['main']['App']['SessionRejectGuest'](arg1, arg2);
 }
 
+export function SessionRejectReviewDraft(arg1, arg2) {
+  return window['go']['main']['App']['SessionRejectReviewDraft'](arg1, arg2);
+}
+
 export function SessionRestartEnvironment(arg1) {
   return window['go']['main']['App']['SessionRestartEnvironment'](arg1);
 }
@@ -502,42 +1490,202 @@ export function SessionRevokeCode(arg1) {
   return window['go']['main']['App']['SessionRevokeCode'](arg1);
 }
 
+export function SessionSearchAuditLogs(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['SessionSearchAuditLogs'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function SessionSetAllowNewJoins(arg1, arg2) {
   return window['go']['main']['App']['SessionSetAllowNewJoins'](arg1, arg2);
 }
 
+export function SessionSetCommandApproval(arg1, arg2) {
+  return window['go']['main']['App']['SessionSetCommandApproval'](arg1, arg2);
+}
+
 export function SessionSetGuestPermission(arg1, arg2, arg3) {
   return window['go']['main']['App']['SessionSetGuestPermission'](arg1, arg2, arg3);
 }
 
+export function SessionSetTimeoutPolicy(arg1, arg2) {
+  return window['go']['main']['App']['SessionSetTimeoutPolicy'](arg1, arg2);
+}
+
+export function SessionShareGitPanel(arg1, arg2) {
+  return window['go']['main']['App']['SessionShareGitPanel'](arg1, arg2);
+}
+
+export function SessionStartPRReview(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SessionStartPRReview'](arg1, arg2, arg3);
+}
+
+export function SessionStopGitPanelShare() {
+  return window['go']['main']['App']['SessionStopGitPanelShare']();
+}
+
+export function SessionStopPRReview() {
+  return window['go']['main']['App']['SessionStopPRReview']();
+}
+
+export function SessionSubmitReviewDraft(arg1, arg2) {
+  return window['go']['main']['App']['SessionSubmitReviewDraft'](arg1, arg2);
+}
+
+export function SetAPIInspectorEnabled(arg1) {
+  return window['go']['main']['App']['SetAPIInspectorEnabled'](arg1);
+}
+
 export function SetActiveWorkspace(arg1) {
   return window['go']['main']['App']['SetActiveWorkspace'](arg1);
 }
 
+export function SetAgentAutoStart(arg1, arg2) {
+  return window['go']['main']['App']['SetAgentAutoStart'](arg1, arg2);
+}
+
+export function SetAgentDependencies(arg1, arg2) {
+  return window['go']['main']['App']['SetAgentDependencies'](arg1, arg2);
+}
+
+export function SetAgentReplayCommands(arg1, arg2) {
+  return window['go']['main']['App']['SetAgentReplayCommands'](arg1, arg2);
+}
+
+export function SetAuditRetentionPolicy(arg1) {
+  return window['go']['main']['App']['SetAuditRetentionPolicy'](arg1);
+}
+
+export function SetBackupSchedule(arg1) {
+  return window['go']['main']['App']['SetBackupSchedule'](arg1);
+}
+
+export function SetClipboardHistoryEnabled(arg1) {
+  return window['go']['main']['App']['SetClipboardHistoryEnabled'](arg1);
+}
+
+export function SetIssueTrackerSettings(arg1) {
+  return window['go']['main']['App']['SetIssueTrackerSettings'](arg1);
+}
+
+export function SetKubePodAgentTarget(arg1, arg2) {
+  return window['go']['main']['App']['SetKubePodAgentTarget'](arg1, arg2);
+}
+
+export function SetLogLevel(arg1) {
+  return window['go']['main']['App']['SetLogLevel'](arg1);
+}
+
+export function SetMCPSettings(arg1) {
+  return window['go']['main']['App']['SetMCPSettings'](arg1);
+}
+
+export function SetNetworkSettings(arg1) {
+  return window['go']['main']['App']['SetNetworkSettings'](arg1);
+}
+
 export function SetPollingContext(arg1) {
   return window['go']['main']['App']['SetPollingContext'](arg1);
 }
 
+export function SetRESTAPISettings(arg1) {
+  return window['go']['main']['App']['SetRESTAPISettings'](arg1);
+}
+
+export function SetRepoGitHubAccount(arg1, arg2) {
+  return window['go']['main']['App']['SetRepoGitHubAccount'](arg1, arg2);
+}
+
+export function SetSessionNetworkPolicyDefaults(arg1) {
+  return window['go']['main']['App']['SetSessionNetworkPolicyDefaults'](arg1);
+}
+
+export function SetSessionSecurityProfile(arg1) {
+  return window['go']['main']['App']['SetSessionSecurityProfile'](arg1);
+}
+
+export function SetSessionTimeoutPolicyDefaults(arg1) {
+  return window['go']['main']['App']['SetSessionTimeoutPolicyDefaults'](arg1);
+}
+
+export function SetTerminalBroadcastEnabled(arg1, arg2) {
+  return window['go']['main']['App']['SetTerminalBroadcastEnabled'](arg1, arg2);
+}
+
+export function SetUpdateChannel(arg1) {
+  return window['go']['main']['App']['SetUpdateChannel'](arg1);
+}
+
 export function SetWorkspaceColor(arg1, arg2) {
   return window['go']['main']['App']['SetWorkspaceColor'](arg1, arg2);
 }
 
+export function SetWorkspaceDockerImage(arg1, arg2) {
+  return window['go']['main']['App']['SetWorkspaceDockerImage'](arg1, arg2);
+}
+
+export function SetWorkspaceEditor(arg1, arg2) {
+  return window['go']['main']['App']['SetWorkspaceEditor'](arg1, arg2);
+}
+
+export function SetWorkspaceFileWatching(arg1, arg2) {
+  return window['go']['main']['App']['SetWorkspaceFileWatching'](arg1, arg2);
+}
+
+export function SetWorkspaceGitHubAccount(arg1, arg2) {
+  return window['go']['main']['App']['SetWorkspaceGitHubAccount'](arg1, arg2);
+}
+
+export function SetWorkspaceTaskDone(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetWorkspaceTaskDone'](arg1, arg2, arg3);
+}
+
+export function StartLogFollow(arg1) {
+  return window['go']['main']['App']['StartLogFollow'](arg1);
+}
+
 export function StartPolling(arg1, arg2) {
   return window['go']['main']['App']['StartPolling'](arg1, arg2);
 }
 
+export function StartWorkspaceAgents(arg1) {
+  return window['go']['main']['App']['StartWorkspaceAgents'](arg1);
+}
+
+export function StopLogFollow() {
+  return window['go']['main']['App']['StopLogFollow']();
+}
+
 export function StopPolling() {
   return window['go']['main']['App']['StopPolling']();
 }
 
+export function SwitchGitHubAccount(arg1) {
+  return window['go']['main']['App']['SwitchGitHubAccount'](arg1);
+}
+
+export function SwitchProfile(arg1) {
+  return window['go']['main']['App']['SwitchProfile'](arg1);
+}
+
 export function SyncGuestWorkspace(arg1) {
   return window['go']['main']['App']['SyncGuestWorkspace'](arg1);
 }
 
+export function TestLifecycleHook(arg1) {
+  return window['go']['main']['App']['TestLifecycleHook'](arg1);
+}
+
+export function TestNotificationTarget(arg1) {
+  return window['go']['main']['App']['TestNotificationTarget'](arg1);
+}
+
 export function UnwatchProject(arg1) {
   return window['go']['main']['App']['UnwatchProject'](arg1);
 }
 
+export function UpdateWorkspaceTask(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['UpdateWorkspaceTask'](arg1, arg2, arg3, arg4);
+}
+
 export function WatchProject(arg1) {
   return window['go']['main']['App']['WatchProject'](arg1);
 }
@@ -549,3 +1697,7 @@ export function WriteTerminal(arg1, arg2) {
 export function WriteTerminalAsGuest(arg1, arg2, arg3) {
   return window['go']['main']['App']['WriteTerminalAsGuest'](arg1, arg2, arg3);
 }
+
+export function WriteWorkspaceFile(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['WriteWorkspaceFile'](arg1, arg2, arg3, arg4);
+}
@@ -119,6 +119,32 @@ export namespace ai {
 		}
 	}
 
+	export class TerminalSummary {
+	    sessionId: string;
+	    command?: string;
+	    status: string;
+	    errors: string[];
+	    warnings: string[];
+	    totalLines: number;
+	    selectedLines: number;
+	    summary: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TerminalSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.command = source["command"];
+	        this.status = source["status"];
+	        this.errors = source["errors"];
+	        this.warnings = source["warnings"];
+	        this.totalLines = source["totalLines"];
+	        this.selectedLines = source["selectedLines"];
+	        this.summary = source["summary"];
+	    }
+	}
 }
 
 export namespace auth {
@@ -181,49 +207,42 @@ export namespace auth {
 		    return a;
 		}
 	}
-
-}
-
-export namespace database {
-	
-	export class AgentSession {
-	    id: number;
-	    workspaceId: number;
-	    name: string;
-	    type: string;
-	    shell: string;
-	    cwd: string;
-	    useDocker: boolean;
-	    sessionId?: string;
-	    status: string;
-	    layoutJson?: string;
-	    sortOrder: number;
-	    isMinimized: boolean;
+	export class GitHubAccount {
+	    id: string;
+	    login: string;
+	    name?: string;
+	    avatarUrl?: string;
+	    kind: string;
+	    scopes?: string[];
+	    permissions?: Record<string, string>;
 	    // Go type: time
-	    createdAt: any;
+	    expiresAt?: any;
+	    appId?: number;
+	    installationId?: number;
+	    primary: boolean;
+	    active: boolean;
 	    // Go type: time
-	    updatedAt: any;
+	    addedAt?: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new AgentSession(source);
+	        return new GitHubAccount(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.workspaceId = source["workspaceId"];
+	        this.login = source["login"];
 	        this.name = source["name"];
-	        this.type = source["type"];
-	        this.shell = source["shell"];
-	        this.cwd = source["cwd"];
-	        this.useDocker = source["useDocker"];
-	        this.sessionId = source["sessionId"];
-	        this.status = source["status"];
-	        this.layoutJson = source["layoutJson"];
-	        this.sortOrder = source["sortOrder"];
-	        this.isMinimized = source["isMinimized"];
-	        this.createdAt = this.convertValues(source["createdAt"], null);
-	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	        this.avatarUrl = source["avatarUrl"];
+	        this.kind = source["kind"];
+	        this.scopes = source["scopes"];
+	        this.permissions = source["permissions"];
+	        this.expiresAt = this.convertValues(source["expiresAt"], null);
+	        this.appId = source["appId"];
+	        this.installationId = source["installationId"];
+	        this.primary = source["primary"];
+	        this.active = source["active"];
+	        this.addedAt = this.convertValues(source["addedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -244,27 +263,50 @@ export namespace database {
 		    return a;
 		}
 	}
-	export class AuditLog {
-	    id: number;
-	    sessionID: string;
-	    userID: string;
-	    action: string;
-	    details: string;
+
+}
+
+export namespace backup {
+	
+	export class FileEntry {
+	    path: string;
+	    size: number;
+	    sha256: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.sha256 = source["sha256"];
+	    }
+	}
+	export class Manifest {
+	    formatVersion: number;
+	    appVersion: string;
+	    schemaVersion: number;
 	    // Go type: time
 	    createdAt: any;
+	    files: FileEntry[];
+	    archivePath?: string;
+	    archiveSize?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new AuditLog(source);
+	        return new Manifest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.sessionID = source["sessionID"];
-	        this.userID = source["userID"];
-	        this.action = source["action"];
-	        this.details = source["details"];
+	        this.formatVersion = source["formatVersion"];
+	        this.appVersion = source["appVersion"];
+	        this.schemaVersion = source["schemaVersion"];
 	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.files = this.convertValues(source["files"], FileEntry);
+	        this.archivePath = source["archivePath"];
+	        this.archiveSize = source["archiveSize"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -285,43 +327,50 @@ export namespace database {
 		    return a;
 		}
 	}
-	export class Workspace {
-	    id: number;
-	    userId: string;
-	    name: string;
-	    path: string;
-	    gitRemote?: string;
-	    owner?: string;
-	    repo?: string;
-	    color?: string;
-	    isActive: boolean;
-	    agents?: AgentSession[];
-	    // Go type: time
-	    lastOpenedAt?: any;
+	export class Schedule {
+	    enabled: boolean;
+	    intervalHours: number;
+	    retention: number;
+	    dir: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Schedule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.intervalHours = source["intervalHours"];
+	        this.retention = source["retention"];
+	        this.dir = source["dir"];
+	    }
+	}
+	export class ScheduleStatus {
+	    enabled: boolean;
+	    intervalHours: number;
+	    retention: number;
+	    dir: string;
 	    // Go type: time
-	    createdAt: any;
+	    lastBackupAt?: any;
+	    lastBackupPath?: string;
 	    // Go type: time
-	    updatedAt: any;
+	    nextBackupAt?: any;
+	    lastError?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new Workspace(source);
+	        return new ScheduleStatus(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.userId = source["userId"];
-	        this.name = source["name"];
-	        this.path = source["path"];
-	        this.gitRemote = source["gitRemote"];
-	        this.owner = source["owner"];
-	        this.repo = source["repo"];
-	        this.color = source["color"];
-	        this.isActive = source["isActive"];
-	        this.agents = this.convertValues(source["agents"], AgentSession);
-	        this.lastOpenedAt = this.convertValues(source["lastOpenedAt"], null);
-	        this.createdAt = this.convertValues(source["createdAt"], null);
-	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	        this.enabled = source["enabled"];
+	        this.intervalHours = source["intervalHours"];
+	        this.retention = source["retention"];
+	        this.dir = source["dir"];
+	        this.lastBackupAt = this.convertValues(source["lastBackupAt"], null);
+	        this.lastBackupPath = source["lastBackupPath"];
+	        this.nextBackupAt = this.convertValues(source["nextBackupAt"], null);
+	        this.lastError = source["lastError"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -345,25 +394,27 @@ export namespace database {
 
 }
 
-export namespace filewatcher {
+export namespace clipboard {
 	
-	export class CommitInfo {
-	    hash: string;
-	    message: string;
-	    author: string;
+	export class Entry {
+	    id: string;
+	    text: string;
+	    redacted: boolean;
+	    pinned: boolean;
 	    // Go type: time
-	    date: any;
+	    copiedAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new CommitInfo(source);
+	        return new Entry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.hash = source["hash"];
-	        this.message = source["message"];
-	        this.author = source["author"];
-	        this.date = this.convertValues(source["date"], null);
+	        this.id = source["id"];
+	        this.text = source["text"];
+	        this.redacted = source["redacted"];
+	        this.pinned = source["pinned"];
+	        this.copiedAt = this.convertValues(source["copiedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -387,44 +438,38 @@ export namespace filewatcher {
 
 }
 
-export namespace gitactivity {
+export namespace commandguard {
 	
-	export class EventFile {
-	    path: string;
-	    status?: string;
-	    added?: number;
-	    removed?: number;
+	export class Request {
+	    id: string;
+	    sessionID: string;
+	    terminalID: string;
+	    guestUserID: string;
+	    command: string;
+	    rule: Rule;
+	    status: string;
+	    reason?: string;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    decidedAt?: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new EventFile(source);
+	        return new Request(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
+	        this.id = source["id"];
+	        this.sessionID = source["sessionID"];
+	        this.terminalID = source["terminalID"];
+	        this.guestUserID = source["guestUserID"];
+	        this.command = source["command"];
+	        this.rule = this.convertValues(source["rule"], Rule);
 	        this.status = source["status"];
-	        this.added = source["added"];
-	        this.removed = source["removed"];
-	    }
-	}
-	export class EventDetails {
-	    ref?: string;
-	    commitHash?: string;
-	    diffPreview?: string;
-	    files?: EventFile[];
-	    extra?: Record<string, string>;
-	
-	    static createFrom(source: any = {}) {
-	        return new EventDetails(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.ref = source["ref"];
-	        this.commitHash = source["commitHash"];
-	        this.diffPreview = source["diffPreview"];
-	        this.files = this.convertValues(source["files"], EventFile);
-	        this.extra = source["extra"];
+	        this.reason = source["reason"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.decidedAt = this.convertValues(source["decidedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -445,119 +490,86 @@ export namespace gitactivity {
 		    return a;
 		}
 	}
-	export class Event {
+	export class Rule {
 	    id: string;
-	    type: string;
-	    actorName: string;
-	    actorId?: string;
-	    repoPath: string;
-	    repoName: string;
-	    branch?: string;
-	    message: string;
-	    // Go type: time
-	    timestamp: any;
-	    source?: string;
-	    dedupeKey?: string;
-	    details?: EventDetails;
+	    description: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new Event(source);
+	        return new Rule(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.type = source["type"];
-	        this.actorName = source["actorName"];
-	        this.actorId = source["actorId"];
-	        this.repoPath = source["repoPath"];
-	        this.repoName = source["repoName"];
-	        this.branch = source["branch"];
-	        this.message = source["message"];
-	        this.timestamp = this.convertValues(source["timestamp"], null);
-	        this.source = source["source"];
-	        this.dedupeKey = source["dedupeKey"];
-	        this.details = this.convertValues(source["details"], EventDetails);
+	        this.description = source["description"];
 	    }
-	
-		convertValues(a: any, classs: any, asMap: boolean = false): any {
-		    if (!a) {
-		        return a;
-		    }
-		    if (a.slice && a.map) {
-		        return (a as any[]).map(elem => this.convertValues(elem, classs));
-		    } else if ("object" === typeof a) {
-		        if (asMap) {
-		            for (const key of Object.keys(a)) {
-		                a[key] = new classs(a[key]);
-		            }
-		            return a;
-		        }
-		        return new classs(a);
-		    }
-		    return a;
-		}
 	}
-	
 
 }
 
-export namespace github {
+export namespace config {
 	
-	export class Branch {
+	export class Profile {
 	    name: string;
-	    prefix: string;
-	    commit: string;
+	    dataDir: string;
+	    active: boolean;
+	    isDefault: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Branch(source);
+	        return new Profile(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.prefix = source["prefix"];
-	        this.commit = source["commit"];
+	        this.dataDir = source["dataDir"];
+	        this.active = source["active"];
+	        this.isDefault = source["isDefault"];
 	    }
 	}
-	export class User {
-	    login: string;
-	    avatarUrl: string;
+	export class SettingValue {
+	    key: string;
+	    value: string;
+	    source: string;
+	    env?: string;
+	    requiresRestart: boolean;
+	    description?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new User(source);
+	        return new SettingValue(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.login = source["login"];
-	        this.avatarUrl = source["avatarUrl"];
+	        this.key = source["key"];
+	        this.value = source["value"];
+	        this.source = source["source"];
+	        this.env = source["env"];
+	        this.requiresRestart = source["requiresRestart"];
+	        this.description = source["description"];
 	    }
 	}
-	export class Comment {
-	    id: string;
-	    author: User;
-	    body: string;
-	    path?: string;
-	    line?: number;
-	    // Go type: time
-	    createdAt: any;
+	export class EffectiveConfig {
+	    path: string;
+	    fileLoaded: boolean;
+	    loadError?: string;
 	    // Go type: time
-	    updatedAt: any;
+	    loadedAt: any;
+	    warnings: string[];
+	    values: SettingValue[];
 	
 	    static createFrom(source: any = {}) {
-	        return new Comment(source);
+	        return new EffectiveConfig(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.author = this.convertValues(source["author"], User);
-	        this.body = source["body"];
 	        this.path = source["path"];
-	        this.line = source["line"];
-	        this.createdAt = this.convertValues(source["createdAt"], null);
-	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	        this.fileLoaded = source["fileLoaded"];
+	        this.loadError = source["loadError"];
+	        this.loadedAt = this.convertValues(source["loadedAt"], null);
+	        this.warnings = source["warnings"];
+	        this.values = this.convertValues(source["values"], SettingValue);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -578,62 +590,110 @@ export namespace github {
 		    return a;
 		}
 	}
-	export class DiffPagination {
-	    first: number;
-	    after?: string;
-	    hasNextPage: boolean;
-	    endCursor?: string;
+
+}
+
+export namespace coordination {
+	
+	export class Lease {
+	    name: string;
+	    holder: string;
+	    // Go type: time
+	    acquiredAt: any;
+	    // Go type: time
+	    expiresAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new DiffPagination(source);
+	        return new Lease(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.first = source["first"];
-	        this.after = source["after"];
-	        this.hasNextPage = source["hasNextPage"];
-	        this.endCursor = source["endCursor"];
+	        this.name = source["name"];
+	        this.holder = source["holder"];
+	        this.acquiredAt = this.convertValues(source["acquiredAt"], null);
+	        this.expiresAt = this.convertValues(source["expiresAt"], null);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class DiffLine {
+
+}
+
+export namespace database {
+	
+	export class AgentSession {
+	    id: number;
+	    workspaceId: number;
+	    name: string;
 	    type: string;
-	    content: string;
-	    oldLine?: number;
-	    newLine?: number;
+	    shell: string;
+	    cwd: string;
+	    useDocker: boolean;
+	    sessionId?: string;
+	    status: string;
+	    layoutJson?: string;
+	    sortOrder: number;
+	    isMinimized: boolean;
+	    kubeTarget?: string;
+	    env?: string;
+	    startupCmds?: string;
+	    replayCmds?: string;
+	    repoPath?: string;
+	    autoStart: string;
+	    dependsOn?: string;
+	    readyMode?: string;
+	    readyRegex?: string;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new DiffLine(source);
+	        return new AgentSession(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.workspaceId = source["workspaceId"];
+	        this.name = source["name"];
 	        this.type = source["type"];
-	        this.content = source["content"];
-	        this.oldLine = source["oldLine"];
-	        this.newLine = source["newLine"];
-	    }
-	}
-	export class DiffHunk {
-	    oldStart: number;
-	    oldLines: number;
-	    newStart: number;
-	    newLines: number;
-	    header: string;
-	    lines: DiffLine[];
-	
-	    static createFrom(source: any = {}) {
-	        return new DiffHunk(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.oldStart = source["oldStart"];
-	        this.oldLines = source["oldLines"];
-	        this.newStart = source["newStart"];
-	        this.newLines = source["newLines"];
-	        this.header = source["header"];
-	        this.lines = this.convertValues(source["lines"], DiffLine);
+	        this.shell = source["shell"];
+	        this.cwd = source["cwd"];
+	        this.useDocker = source["useDocker"];
+	        this.sessionId = source["sessionId"];
+	        this.status = source["status"];
+	        this.layoutJson = source["layoutJson"];
+	        this.sortOrder = source["sortOrder"];
+	        this.isMinimized = source["isMinimized"];
+	        this.kubeTarget = source["kubeTarget"];
+	        this.env = source["env"];
+	        this.startupCmds = source["startupCmds"];
+	        this.replayCmds = source["replayCmds"];
+	        this.repoPath = source["repoPath"];
+	        this.autoStart = source["autoStart"];
+	        this.dependsOn = source["dependsOn"];
+	        this.readyMode = source["readyMode"];
+	        this.readyRegex = source["readyRegex"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -654,26 +714,27 @@ export namespace github {
 		    return a;
 		}
 	}
-	export class DiffFile {
-	    filename: string;
-	    status: string;
-	    additions: number;
-	    deletions: number;
-	    patch?: string;
-	    hunks: DiffHunk[];
+	export class AuditLog {
+	    id: number;
+	    sessionID: string;
+	    userID: string;
+	    action: string;
+	    details: string;
+	    // Go type: time
+	    createdAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new DiffFile(source);
+	        return new AuditLog(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.filename = source["filename"];
-	        this.status = source["status"];
-	        this.additions = source["additions"];
-	        this.deletions = source["deletions"];
-	        this.patch = source["patch"];
-	        this.hunks = this.convertValues(source["hunks"], DiffHunk);
+	        this.id = source["id"];
+	        this.sessionID = source["sessionID"];
+	        this.userID = source["userID"];
+	        this.action = source["action"];
+	        this.details = source["details"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -694,20 +755,26 @@ export namespace github {
 		    return a;
 		}
 	}
-	export class Diff {
-	    files: DiffFile[];
-	    totalFiles: number;
-	    pagination: DiffPagination;
+	export class AuditLogPage {
+	    items: AuditLog[];
+	    total: number;
+	    page: number;
+	    perPage: number;
+	    hasNextPage: boolean;
+	    nextPage?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Diff(source);
+	        return new AuditLogPage(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.files = this.convertValues(source["files"], DiffFile);
-	        this.totalFiles = source["totalFiles"];
-	        this.pagination = this.convertValues(source["pagination"], DiffPagination);
+	        this.items = this.convertValues(source["items"], AuditLog);
+	        this.total = source["total"];
+	        this.page = source["page"];
+	        this.perPage = source["perPage"];
+	        this.hasNextPage = source["hasNextPage"];
+	        this.nextPage = source["nextPage"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -728,56 +795,34 @@ export namespace github {
 		    return a;
 		}
 	}
-	
-	
-	
-	
-	export class Label {
-	    name: string;
-	    color: string;
-	    description?: string;
+	export class AuditLogPagination {
+	    page: number;
+	    perPage: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Label(source);
+	        return new AuditLogPagination(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.color = source["color"];
-	        this.description = source["description"];
+	        this.page = source["page"];
+	        this.perPage = source["perPage"];
 	    }
 	}
-	export class Issue {
-	    id: string;
-	    number: number;
-	    title: string;
-	    body: string;
-	    state: string;
-	    author: User;
-	    assignees: User[];
-	    labels: Label[];
+	export class AuditLogTimeRange {
 	    // Go type: time
-	    createdAt: any;
+	    from?: any;
 	    // Go type: time
-	    updatedAt: any;
+	    to?: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new Issue(source);
+	        return new AuditLogTimeRange(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.number = source["number"];
-	        this.title = source["title"];
-	        this.body = source["body"];
-	        this.state = source["state"];
-	        this.author = this.convertValues(source["author"], User);
-	        this.assignees = this.convertValues(source["assignees"], User);
-	        this.labels = this.convertValues(source["labels"], Label);
-	        this.createdAt = this.convertValues(source["createdAt"], null);
-	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -798,41 +843,21 @@ export namespace github {
 		    return a;
 		}
 	}
-	
-	export class PRCommit {
-	    sha: string;
-	    message: string;
-	    htmlUrl?: string;
-	    authorName?: string;
-	    authorEmail?: string;
-	    // Go type: time
-	    authoredAt?: any;
-	    committerName?: string;
-	    committerEmail?: string;
+	export class SchemaVersion {
+	    version: number;
+	    description: string;
 	    // Go type: time
-	    committedAt?: any;
-	    author?: User;
-	    committer?: User;
-	    parentShas?: string[];
+	    appliedAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new PRCommit(source);
+	        return new SchemaVersion(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sha = source["sha"];
-	        this.message = source["message"];
-	        this.htmlUrl = source["htmlUrl"];
-	        this.authorName = source["authorName"];
-	        this.authorEmail = source["authorEmail"];
-	        this.authoredAt = this.convertValues(source["authoredAt"], null);
-	        this.committerName = source["committerName"];
-	        this.committerEmail = source["committerEmail"];
-	        this.committedAt = this.convertValues(source["committedAt"], null);
-	        this.author = this.convertValues(source["author"], User);
-	        this.committer = this.convertValues(source["committer"], User);
-	        this.parentShas = source["parentShas"];
+	        this.version = source["version"];
+	        this.description = source["description"];
+	        this.appliedAt = this.convertValues(source["appliedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -853,24 +878,30 @@ export namespace github {
 		    return a;
 		}
 	}
-	export class PRCommitPage {
-	    items: PRCommit[];
-	    page: number;
-	    perPage: number;
-	    hasNextPage: boolean;
-	    nextPage?: number;
+	export class DatabaseInfo {
+	    path: string;
+	    sizeBytes: number;
+	    walSizeBytes: number;
+	    schemaVersion: number;
+	    latestVersion: number;
+	    pendingMigrations: number;
+	    migrations: SchemaVersion[];
+	    lastBackupPath?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new PRCommitPage(source);
+	        return new DatabaseInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.items = this.convertValues(source["items"], PRCommit);
-	        this.page = source["page"];
-	        this.perPage = source["perPage"];
-	        this.hasNextPage = source["hasNextPage"];
-	        this.nextPage = source["nextPage"];
+	        this.path = source["path"];
+	        this.sizeBytes = source["sizeBytes"];
+	        this.walSizeBytes = source["walSizeBytes"];
+	        this.schemaVersion = source["schemaVersion"];
+	        this.latestVersion = source["latestVersion"];
+	        this.pendingMigrations = source["pendingMigrations"];
+	        this.migrations = this.convertValues(source["migrations"], SchemaVersion);
+	        this.lastBackupPath = source["lastBackupPath"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -891,62 +922,75 @@ export namespace github {
 		    return a;
 		}
 	}
-	export class PRFile {
-	    filename: string;
-	    previousFilename?: string;
-	    status: string;
-	    additions: number;
-	    deletions: number;
-	    changes: number;
-	    blobUrl?: string;
-	    rawUrl?: string;
-	    contentsUrl?: string;
-	    patch?: string;
-	    hasPatch: boolean;
-	    patchState: string;
-	    isBinary: boolean;
-	    isPatchTruncated: boolean;
+	export class GitAutoFetchSetting {
+	    id: number;
+	    repoPath: string;
+	    enabled: boolean;
+	    intervalSeconds: number;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new PRFile(source);
+	        return new GitAutoFetchSetting(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.filename = source["filename"];
-	        this.previousFilename = source["previousFilename"];
-	        this.status = source["status"];
-	        this.additions = source["additions"];
-	        this.deletions = source["deletions"];
-	        this.changes = source["changes"];
-	        this.blobUrl = source["blobUrl"];
-	        this.rawUrl = source["rawUrl"];
-	        this.contentsUrl = source["contentsUrl"];
-	        this.patch = source["patch"];
-	        this.hasPatch = source["hasPatch"];
-	        this.patchState = source["patchState"];
-	        this.isBinary = source["isBinary"];
-	        this.isPatchTruncated = source["isPatchTruncated"];
+	        this.id = source["id"];
+	        this.repoPath = source["repoPath"];
+	        this.enabled = source["enabled"];
+	        this.intervalSeconds = source["intervalSeconds"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class PRFilePage {
-	    items: PRFile[];
-	    page: number;
-	    perPage: number;
-	    hasNextPage: boolean;
-	    nextPage?: number;
+	
+	export class WorkspaceRepo {
+	    id: number;
+	    workspaceId: number;
+	    name: string;
+	    path: string;
+	    isPrimary: boolean;
+	    sortOrder: number;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new PRFilePage(source);
+	        return new WorkspaceRepo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.items = this.convertValues(source["items"], PRFile);
-	        this.page = source["page"];
-	        this.perPage = source["perPage"];
-	        this.hasNextPage = source["hasNextPage"];
-	        this.nextPage = source["nextPage"];
+	        this.id = source["id"];
+	        this.workspaceId = source["workspaceId"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.isPrimary = source["isPrimary"];
+	        this.sortOrder = source["sortOrder"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -967,52 +1011,61 @@ export namespace github {
 		    return a;
 		}
 	}
-	export class PullRequest {
-	    id: string;
-	    number: number;
-	    title: string;
-	    body: string;
-	    state: string;
-	    author: User;
-	    reviewers: User[];
-	    labels: Label[];
+	export class Workspace {
+	    id: number;
+	    userId: string;
+	    name: string;
+	    path: string;
+	    gitRemote?: string;
+	    owner?: string;
+	    repo?: string;
+	    color?: string;
+	    dockerImage?: string;
+	    gitPathScope?: string;
+	    gitAllowNoVerify: boolean;
+	    gitCommitLintMode: string;
+	    githubAccount?: string;
+	    watchFiles: boolean;
+	    editor?: string;
+	    gitCoAuthorGuests: boolean;
+	    isActive: boolean;
+	    agents?: AgentSession[];
+	    repos?: WorkspaceRepo[];
+	    // Go type: time
+	    lastOpenedAt?: any;
 	    // Go type: time
 	    createdAt: any;
 	    // Go type: time
 	    updatedAt: any;
-	    mergeCommit?: string;
-	    headBranch: string;
-	    baseBranch: string;
-	    additions: number;
-	    deletions: number;
-	    changedFiles: number;
-	    isDraft: boolean;
-	    maintainerCanModify?: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new PullRequest(source);
+	        return new Workspace(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.number = source["number"];
-	        this.title = source["title"];
-	        this.body = source["body"];
-	        this.state = source["state"];
-	        this.author = this.convertValues(source["author"], User);
-	        this.reviewers = this.convertValues(source["reviewers"], User);
-	        this.labels = this.convertValues(source["labels"], Label);
+	        this.userId = source["userId"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.gitRemote = source["gitRemote"];
+	        this.owner = source["owner"];
+	        this.repo = source["repo"];
+	        this.color = source["color"];
+	        this.dockerImage = source["dockerImage"];
+	        this.gitPathScope = source["gitPathScope"];
+	        this.gitAllowNoVerify = source["gitAllowNoVerify"];
+	        this.gitCommitLintMode = source["gitCommitLintMode"];
+	        this.githubAccount = source["githubAccount"];
+	        this.watchFiles = source["watchFiles"];
+	        this.editor = source["editor"];
+	        this.gitCoAuthorGuests = source["gitCoAuthorGuests"];
+	        this.isActive = source["isActive"];
+	        this.agents = this.convertValues(source["agents"], AgentSession);
+	        this.repos = this.convertValues(source["repos"], WorkspaceRepo);
+	        this.lastOpenedAt = this.convertValues(source["lastOpenedAt"], null);
 	        this.createdAt = this.convertValues(source["createdAt"], null);
 	        this.updatedAt = this.convertValues(source["updatedAt"], null);
-	        this.mergeCommit = source["mergeCommit"];
-	        this.headBranch = source["headBranch"];
-	        this.baseBranch = source["baseBranch"];
-	        this.additions = source["additions"];
-	        this.deletions = source["deletions"];
-	        this.changedFiles = source["changedFiles"];
-	        this.isDraft = source["isDraft"];
-	        this.maintainerCanModify = source["maintainerCanModify"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1033,21 +1086,57 @@ export namespace github {
 		    return a;
 		}
 	}
-	export class RateLimitInfo {
-	    remaining: number;
-	    limit: number;
+
+}
+
+export namespace deeplink {
+	
+	export class Link {
+	    action: string;
+	    code?: string;
+	    path?: string;
+	    owner?: string;
+	    repo?: string;
+	    number?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Link(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.action = source["action"];
+	        this.code = source["code"];
+	        this.path = source["path"];
+	        this.owner = source["owner"];
+	        this.repo = source["repo"];
+	        this.number = source["number"];
+	    }
+	}
+
+}
+
+export namespace digest {
+	
+	export class Activity {
+	    type: string;
+	    repo: string;
+	    branch?: string;
+	    message: string;
 	    // Go type: time
-	    resetAt: any;
+	    timestamp: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new RateLimitInfo(source);
+	        return new Activity(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.remaining = source["remaining"];
-	        this.limit = source["limit"];
-	        this.resetAt = this.convertValues(source["resetAt"], null);
+	        this.type = source["type"];
+	        this.repo = source["repo"];
+	        this.branch = source["branch"];
+	        this.message = source["message"];
+	        this.timestamp = this.convertValues(source["timestamp"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1068,31 +1157,29 @@ export namespace github {
 		    return a;
 		}
 	}
-	export class Repository {
-	    id: string;
-	    name: string;
-	    fullName: string;
-	    owner: string;
-	    description: string;
-	    isPrivate: boolean;
-	    defaultBranch: string;
+	export class Commit {
+	    repo: string;
+	    hash: string;
+	    shortHash: string;
+	    subject: string;
 	    // Go type: time
-	    updatedAt: any;
+	    authoredAt: any;
+	    additions: number;
+	    deletions: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Repository(source);
+	        return new Commit(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.fullName = source["fullName"];
-	        this.owner = source["owner"];
-	        this.description = source["description"];
-	        this.isPrivate = source["isPrivate"];
-	        this.defaultBranch = source["defaultBranch"];
-	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	        this.repo = source["repo"];
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.subject = source["subject"];
+	        this.authoredAt = this.convertValues(source["authoredAt"], null);
+	        this.additions = source["additions"];
+	        this.deletions = source["deletions"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1113,25 +1200,24 @@ export namespace github {
 		    return a;
 		}
 	}
-	export class Review {
-	    id: string;
-	    author: User;
-	    state: string;
-	    body: string;
+	export class FocusSession {
 	    // Go type: time
-	    createdAt: any;
+	    startedAt: any;
+	    // Go type: time
+	    endedAt: any;
+	    minutes: number;
+	    completed: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new Review(source);
+	        return new FocusSession(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.author = this.convertValues(source["author"], User);
-	        this.state = source["state"];
-	        this.body = source["body"];
-	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.startedAt = this.convertValues(source["startedAt"], null);
+	        this.endedAt = this.convertValues(source["endedAt"], null);
+	        this.minutes = source["minutes"];
+	        this.completed = source["completed"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1152,37 +1238,58 @@ export namespace github {
 		    return a;
 		}
 	}
-
-}
-
-export namespace gitpanel {
-	
-	export class CommitFileDTO {
-	    path: string;
-	    status: string;
+	export class Stats {
+	    commits: number;
+	    additions: number;
+	    deletions: number;
+	    prsOpened: number;
+	    prsReviewed: number;
+	    testRuns: number;
+	    testRunsFailed: number;
+	    testRunsPassing: number;
+	    focusSessions: number;
+	    focusMinutes: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new CommitFileDTO(source);
+	        return new Stats(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.status = source["status"];
+	        this.commits = source["commits"];
+	        this.additions = source["additions"];
+	        this.deletions = source["deletions"];
+	        this.prsOpened = source["prsOpened"];
+	        this.prsReviewed = source["prsReviewed"];
+	        this.testRuns = source["testRuns"];
+	        this.testRunsFailed = source["testRunsFailed"];
+	        this.testRunsPassing = source["testRunsPassing"];
+	        this.focusSessions = source["focusSessions"];
+	        this.focusMinutes = source["focusMinutes"];
 	    }
 	}
-	export class CommitDetailsDTO {
-	    hash: string;
-	    files: CommitFileDTO[];
+	export class TestRun {
+	    command: string;
+	    framework: string;
+	    status: string;
+	    summary?: string;
+	    // Go type: time
+	    startedAt: any;
+	    // Go type: time
+	    finishedAt?: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new CommitDetailsDTO(source);
+	        return new TestRun(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.hash = source["hash"];
-	        this.files = this.convertValues(source["files"], CommitFileDTO);
+	        this.command = source["command"];
+	        this.framework = source["framework"];
+	        this.status = source["status"];
+	        this.summary = source["summary"];
+	        this.startedAt = this.convertValues(source["startedAt"], null);
+	        this.finishedAt = this.convertValues(source["finishedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1203,59 +1310,29 @@ export namespace gitpanel {
 		    return a;
 		}
 	}
-	
-	export class ConflictFileDTO {
-	    path: string;
-	    status: string;
+	export class PullRequest {
+	    repo: string;
+	    number: number;
+	    title: string;
+	    url: string;
+	    state: string;
+	    isDraft: boolean;
+	    // Go type: time
+	    updatedAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new ConflictFileDTO(source);
+	        return new PullRequest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.status = source["status"];
-	    }
-	}
-	export class DiffLineDTO {
-	    type: string;
-	    content: string;
-	    oldLine?: number;
-	    newLine?: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new DiffLineDTO(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.type = source["type"];
-	        this.content = source["content"];
-	        this.oldLine = source["oldLine"];
-	        this.newLine = source["newLine"];
-	    }
-	}
-	export class DiffHunkDTO {
-	    header: string;
-	    oldStart: number;
-	    oldLines: number;
-	    newStart: number;
-	    newLines: number;
-	    lines: DiffLineDTO[];
-	
-	    static createFrom(source: any = {}) {
-	        return new DiffHunkDTO(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.header = source["header"];
-	        this.oldStart = source["oldStart"];
-	        this.oldLines = source["oldLines"];
-	        this.newStart = source["newStart"];
-	        this.newLines = source["newLines"];
-	        this.lines = this.convertValues(source["lines"], DiffLineDTO);
+	        this.repo = source["repo"];
+	        this.number = source["number"];
+	        this.title = source["title"];
+	        this.url = source["url"];
+	        this.state = source["state"];
+	        this.isDraft = source["isDraft"];
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1276,28 +1353,44 @@ export namespace gitpanel {
 		    return a;
 		}
 	}
-	export class DiffFileDTO {
-	    path: string;
-	    oldPath?: string;
-	    status: string;
-	    additions: number;
-	    deletions: number;
-	    isBinary: boolean;
-	    hunks: DiffHunkDTO[];
+	export class Digest {
+	    workspaceId: number;
+	    workspaceName: string;
+	    // Go type: time
+	    since: any;
+	    // Go type: time
+	    generatedAt: any;
+	    commits: Commit[];
+	    activity: Activity[];
+	    pullRequestsOpened: PullRequest[];
+	    pullRequestsReviewed: PullRequest[];
+	    testRuns: TestRun[];
+	    focusSessions: FocusSession[];
+	    stats: Stats;
+	    markdown: string;
+	    summary?: string;
+	    warnings: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new DiffFileDTO(source);
+	        return new Digest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.oldPath = source["oldPath"];
-	        this.status = source["status"];
-	        this.additions = source["additions"];
-	        this.deletions = source["deletions"];
-	        this.isBinary = source["isBinary"];
-	        this.hunks = this.convertValues(source["hunks"], DiffHunkDTO);
+	        this.workspaceId = source["workspaceId"];
+	        this.workspaceName = source["workspaceName"];
+	        this.since = this.convertValues(source["since"], null);
+	        this.generatedAt = this.convertValues(source["generatedAt"], null);
+	        this.commits = this.convertValues(source["commits"], Commit);
+	        this.activity = this.convertValues(source["activity"], Activity);
+	        this.pullRequestsOpened = this.convertValues(source["pullRequestsOpened"], PullRequest);
+	        this.pullRequestsReviewed = this.convertValues(source["pullRequestsReviewed"], PullRequest);
+	        this.testRuns = this.convertValues(source["testRuns"], TestRun);
+	        this.focusSessions = this.convertValues(source["focusSessions"], FocusSession);
+	        this.stats = this.convertValues(source["stats"], Stats);
+	        this.markdown = source["markdown"];
+	        this.summary = source["summary"];
+	        this.warnings = source["warnings"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1318,26 +1411,89 @@ export namespace gitpanel {
 		    return a;
 		}
 	}
-	export class DiffDTO {
-	    mode: string;
-	    filePath?: string;
-	    raw: string;
-	    files: DiffFileDTO[];
-	    isBinary: boolean;
-	    isTruncated: boolean;
+	
+	
+
+}
+
+export namespace diskusage {
+	
+	export class Alert {
+	    category: string;
+	    bytes: number;
+	    limitBytes: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new DiffDTO(source);
+	        return new Alert(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.mode = source["mode"];
-	        this.filePath = source["filePath"];
-	        this.raw = source["raw"];
-	        this.files = this.convertValues(source["files"], DiffFileDTO);
-	        this.isBinary = source["isBinary"];
-	        this.isTruncated = source["isTruncated"];
+	        this.category = source["category"];
+	        this.bytes = source["bytes"];
+	        this.limitBytes = source["limitBytes"];
+	    }
+	}
+
+	export class CleanupResult {
+	    removed: number;
+	    freedBytes: number;
+	    paths: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CleanupResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.removed = source["removed"];
+	        this.freedBytes = source["freedBytes"];
+	        this.paths = source["paths"];
+	    }
+	}
+	export class Item {
+	    category: string;
+	    name: string;
+	    path?: string;
+	    bytes: number;
+	    files: number;
+	    partial?: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Item(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.category = source["category"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.bytes = source["bytes"];
+	        this.files = source["files"];
+	        this.partial = source["partial"];
+	        this.error = source["error"];
+	    }
+	}
+	export class Report {
+	    items: Item[];
+	    totals: Record<string, number>;
+	    totalBytes: number;
+	    alerts: Alert[];
+	    // Go type: time
+	    scannedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], Item);
+	        this.totals = source["totals"];
+	        this.totalBytes = source["totalBytes"];
+	        this.alerts = this.convertValues(source["alerts"], Alert);
+	        this.scannedAt = this.convertValues(source["scannedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1358,73 +1514,136 @@ export namespace gitpanel {
 		    return a;
 		}
 	}
+}
+
+export namespace docker {
+	
+	export class SecuritySettings {
+	    profile: string;
+	    seccomp: string;
+	    appArmor: string;
+	    capDrop: string[];
+	    capAdd: string[];
+	    noNewPrivileges: boolean;
+	    readOnlyRootfs: boolean;
+	    writableMounts: string[];
+	    pidsLimit: number;
+	    networkMode: string;
 	
+	    static createFrom(source: any = {}) {
+	        return new SecuritySettings(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profile = source["profile"];
+	        this.seccomp = source["seccomp"];
+	        this.appArmor = source["appArmor"];
+	        this.capDrop = source["capDrop"];
+	        this.capAdd = source["capAdd"];
+	        this.noNewPrivileges = source["noNewPrivileges"];
+	        this.readOnlyRootfs = source["readOnlyRootfs"];
+	        this.writableMounts = source["writableMounts"];
+	        this.pidsLimit = source["pidsLimit"];
+	        this.networkMode = source["networkMode"];
+	    }
+	}
+	export class StackImageInfo {
+	    name: string;
+	    imageName: string;
+	    tools: Record<string, string>;
+	    built: boolean;
+	    updatedAt: number;
 	
-	export class FileChangeDTO {
-	    path: string;
-	    originalPath?: string;
-	    status: string;
-	    added: number;
-	    removed: number;
+	    static createFrom(source: any = {}) {
+	        return new StackImageInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.imageName = source["imageName"];
+	        this.tools = source["tools"];
+	        this.built = source["built"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+
+}
+
+export namespace editor {
+	
+	export class Editor {
+	    id: string;
+	    name: string;
+	    kind: string;
+	    command?: string;
+	    terminal: boolean;
+	    available: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new FileChangeDTO(source);
+	        return new Editor(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.originalPath = source["originalPath"];
-	        this.status = source["status"];
-	        this.added = source["added"];
-	        this.removed = source["removed"];
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.command = source["command"];
+	        this.terminal = source["terminal"];
+	        this.available = source["available"];
 	    }
 	}
-	export class HistoryItemDTO {
-	    hash: string;
-	    shortHash: string;
-	    author: string;
-	    authoredAt: string;
-	    subject: string;
-	    additions: number;
-	    deletions: number;
-	    changedFiles: number;
-	    githubLogin?: string;
-	    githubAvatarUrl?: string;
+
+}
+
+export namespace filesearch {
+	
+	export class FileMatch {
+	    path: string;
+	    name: string;
+	    score: number;
+	    positions: number[];
 	
 	    static createFrom(source: any = {}) {
-	        return new HistoryItemDTO(source);
+	        return new FileMatch(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.hash = source["hash"];
-	        this.shortHash = source["shortHash"];
-	        this.author = source["author"];
-	        this.authoredAt = source["authoredAt"];
-	        this.subject = source["subject"];
-	        this.additions = source["additions"];
-	        this.deletions = source["deletions"];
-	        this.changedFiles = source["changedFiles"];
-	        this.githubLogin = source["githubLogin"];
-	        this.githubAvatarUrl = source["githubAvatarUrl"];
+	        this.path = source["path"];
+	        this.name = source["name"];
+	        this.score = source["score"];
+	        this.positions = source["positions"];
 	    }
 	}
-	export class HistoryPageDTO {
-	    items: HistoryItemDTO[];
-	    nextCursor: string;
-	    hasMore: boolean;
+
+}
+
+export namespace filewatcher {
+	
+	export class AutoFetchResult {
+	    repoPath: string;
+	    upstream?: string;
+	    ahead: number;
+	    behind: number;
+	    // Go type: time
+	    fetchedAt: any;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new HistoryPageDTO(source);
+	        return new AutoFetchResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.items = this.convertValues(source["items"], HistoryItemDTO);
-	        this.nextCursor = source["nextCursor"];
-	        this.hasMore = source["hasMore"];
+	        this.repoPath = source["repoPath"];
+	        this.upstream = source["upstream"];
+	        this.ahead = source["ahead"];
+	        this.behind = source["behind"];
+	        this.fetchedAt = this.convertValues(source["fetchedAt"], null);
+	        this.error = source["error"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1445,46 +1664,23 @@ export namespace gitpanel {
 		    return a;
 		}
 	}
-	export class PreflightResult {
-	    gitAvailable: boolean;
-	    repoPath: string;
-	    repoRoot: string;
-	    branch?: string;
-	    mergeActive: boolean;
-	
-	    static createFrom(source: any = {}) {
-	        return new PreflightResult(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.gitAvailable = source["gitAvailable"];
-	        this.repoPath = source["repoPath"];
-	        this.repoRoot = source["repoRoot"];
-	        this.branch = source["branch"];
-	        this.mergeActive = source["mergeActive"];
-	    }
-	}
-	export class StatusDTO {
-	    branch: string;
-	    ahead: number;
-	    behind: number;
-	    staged: FileChangeDTO[];
-	    unstaged: FileChangeDTO[];
-	    conflicted: ConflictFileDTO[];
+	export class CommitInfo {
+	    hash: string;
+	    message: string;
+	    author: string;
+	    // Go type: time
+	    date: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new StatusDTO(source);
+	        return new CommitInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.branch = source["branch"];
-	        this.ahead = source["ahead"];
-	        this.behind = source["behind"];
-	        this.staged = this.convertValues(source["staged"], FileChangeDTO);
-	        this.unstaged = this.convertValues(source["unstaged"], FileChangeDTO);
-	        this.conflicted = this.convertValues(source["conflicted"], ConflictFileDTO);
+	        this.hash = source["hash"];
+	        this.message = source["message"];
+	        this.author = source["author"];
+	        this.date = this.convertValues(source["date"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1505,191 +1701,6415 @@ export namespace gitpanel {
 		    return a;
 		}
 	}
-
-}
-
-export namespace main {
-	
-	export class GitPanelPRCreateLabelPayloadDTO {
-	    name: string;
-	    color: string;
-	    description?: string;
+	export class RepoWatchMetrics {
+	    repoPath: string;
+	    gitDirs: number;
+	    worktreeDirs: number;
+	    ignoredDirs: number;
+	    budgetExceeded: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new GitPanelPRCreateLabelPayloadDTO(source);
+	        return new RepoWatchMetrics(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
+	        this.repoPath = source["repoPath"];
+	        this.gitDirs = source["gitDirs"];
+	        this.worktreeDirs = source["worktreeDirs"];
+	        this.ignoredDirs = source["ignoredDirs"];
+	        this.budgetExceeded = source["budgetExceeded"];
+	    }
+	}
+	export class WorkspaceWatchMetrics {
+	    workspaceId: number;
+	    root: string;
+	    dirs: number;
+	    ignoredDirs: number;
+	    budgetExceeded: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkspaceWatchMetrics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.workspaceId = source["workspaceId"];
+	        this.root = source["root"];
+	        this.dirs = source["dirs"];
+	        this.ignoredDirs = source["ignoredDirs"];
+	        this.budgetExceeded = source["budgetExceeded"];
+	    }
+	}
+	export class WatcherMetrics {
+	    watchedDirs: number;
+	    budget: number;
+	    rawEvents: number;
+	    droppedEvents: number;
+	    batches: number;
+	    emittedEvents: number;
+	    pendingEvents: number;
+	    repos: RepoWatchMetrics[];
+	    workspaces: WorkspaceWatchMetrics[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WatcherMetrics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.watchedDirs = source["watchedDirs"];
+	        this.budget = source["budget"];
+	        this.rawEvents = source["rawEvents"];
+	        this.droppedEvents = source["droppedEvents"];
+	        this.batches = source["batches"];
+	        this.emittedEvents = source["emittedEvents"];
+	        this.pendingEvents = source["pendingEvents"];
+	        this.repos = this.convertValues(source["repos"], RepoWatchMetrics);
+	        this.workspaces = this.convertValues(source["workspaces"], WorkspaceWatchMetrics);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace focus {
+	
+	export class Options {
+	    focusMinutes: number;
+	    shortBreakMinutes: number;
+	    longBreakMinutes: number;
+	    cyclesBeforeLongBreak: number;
+	    doNotDisturb: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Options(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.focusMinutes = source["focusMinutes"];
+	        this.shortBreakMinutes = source["shortBreakMinutes"];
+	        this.longBreakMinutes = source["longBreakMinutes"];
+	        this.cyclesBeforeLongBreak = source["cyclesBeforeLongBreak"];
+	        this.doNotDisturb = source["doNotDisturb"];
+	    }
+	}
+	export class State {
+	    phase: string;
+	    previousPhase?: string;
+	    workspaceId?: number;
+	    paused: boolean;
+	    remainingSeconds: number;
+	    durationSeconds: number;
+	    completedCycles: number;
+	    doNotDisturb: boolean;
+	    heldNotifications: number;
+	    // Go type: time
+	    phaseStartedAt?: any;
+	    options: Options;
+	
+	    static createFrom(source: any = {}) {
+	        return new State(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.phase = source["phase"];
+	        this.previousPhase = source["previousPhase"];
+	        this.workspaceId = source["workspaceId"];
+	        this.paused = source["paused"];
+	        this.remainingSeconds = source["remainingSeconds"];
+	        this.durationSeconds = source["durationSeconds"];
+	        this.completedCycles = source["completedCycles"];
+	        this.doNotDisturb = source["doNotDisturb"];
+	        this.heldNotifications = source["heldNotifications"];
+	        this.phaseStartedAt = this.convertValues(source["phaseStartedAt"], null);
+	        this.options = this.convertValues(source["options"], Options);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+}
+
+export namespace gitactivity {
+	
+	export class IssueLink {
+	    key: string;
+	    title?: string;
+	    status?: string;
+	    url?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new IssueLink(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.title = source["title"];
+	        this.status = source["status"];
+	        this.url = source["url"];
+	    }
+	}
+	export class EventFile {
+	    path: string;
+	    status?: string;
+	    added?: number;
+	    removed?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new EventFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.status = source["status"];
+	        this.added = source["added"];
+	        this.removed = source["removed"];
+	    }
+	}
+	export class EventDetails {
+	    ref?: string;
+	    commitHash?: string;
+	    diffPreview?: string;
+	    files?: EventFile[];
+	    extra?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new EventDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ref = source["ref"];
+	        this.commitHash = source["commitHash"];
+	        this.diffPreview = source["diffPreview"];
+	        this.files = this.convertValues(source["files"], EventFile);
+	        this.extra = source["extra"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Event {
+	    id: string;
+	    type: string;
+	    actorName: string;
+	    actorId?: string;
+	    repoPath: string;
+	    repoName: string;
+	    branch?: string;
+	    message: string;
+	    // Go type: time
+	    timestamp: any;
+	    source?: string;
+	    dedupeKey?: string;
+	    details?: EventDetails;
+	    issues?: IssueLink[];
+	    messageId?: string;
+	    messageParams?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new Event(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.type = source["type"];
+	        this.actorName = source["actorName"];
+	        this.actorId = source["actorId"];
+	        this.repoPath = source["repoPath"];
+	        this.repoName = source["repoName"];
+	        this.branch = source["branch"];
+	        this.message = source["message"];
+	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.source = source["source"];
+	        this.dedupeKey = source["dedupeKey"];
+	        this.details = this.convertValues(source["details"], EventDetails);
+	        this.issues = this.convertValues(source["issues"], IssueLink);
+	        this.messageId = source["messageId"];
+	        this.messageParams = source["messageParams"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+
+}
+
+export namespace github {
+	
+	export class Branch {
+	    name: string;
+	    prefix: string;
+	    commit: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Branch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.prefix = source["prefix"];
+	        this.commit = source["commit"];
+	    }
+	}
+	export class BranchProtectionRules {
+	    branch: string;
+	    requiredApprovingReviews: number;
+	    requireCodeOwnerReviews: boolean;
+	    dismissStaleReviews: boolean;
+	    requiredStatusChecks: string[];
+	    requireUpToDate: boolean;
+	    enforceAdmins: boolean;
+	    requireLinearHistory: boolean;
+	    requireConversationResolution: boolean;
+	    partial: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BranchProtectionRules(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.branch = source["branch"];
+	        this.requiredApprovingReviews = source["requiredApprovingReviews"];
+	        this.requireCodeOwnerReviews = source["requireCodeOwnerReviews"];
+	        this.dismissStaleReviews = source["dismissStaleReviews"];
+	        this.requiredStatusChecks = source["requiredStatusChecks"];
+	        this.requireUpToDate = source["requireUpToDate"];
+	        this.enforceAdmins = source["enforceAdmins"];
+	        this.requireLinearHistory = source["requireLinearHistory"];
+	        this.requireConversationResolution = source["requireConversationResolution"];
+	        this.partial = source["partial"];
+	    }
+	}
+	export class Release {
+	    id: number;
+	    tagName: string;
+	    targetCommitish?: string;
+	    name: string;
+	    body: string;
+	    draft: boolean;
+	    prerelease: boolean;
+	    htmlUrl: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Release(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.tagName = source["tagName"];
+	        this.targetCommitish = source["targetCommitish"];
+	        this.name = source["name"];
+	        this.body = source["body"];
+	        this.draft = source["draft"];
+	        this.prerelease = source["prerelease"];
+	        this.htmlUrl = source["htmlUrl"];
+	    }
+	}
+	export class RepositoryForkInfo {
+	    owner: string;
+	    repo: string;
+	    fork: boolean;
+	    parentOwner?: string;
+	    parentRepo?: string;
+	    defaultBranch?: string;
+	    cloneUrl?: string;
+	    sshUrl?: string;
+	    htmlUrl?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RepositoryForkInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.owner = source["owner"];
+	        this.repo = source["repo"];
+	        this.fork = source["fork"];
+	        this.parentOwner = source["parentOwner"];
+	        this.parentRepo = source["parentRepo"];
+	        this.defaultBranch = source["defaultBranch"];
+	        this.cloneUrl = source["cloneUrl"];
+	        this.sshUrl = source["sshUrl"];
+	        this.htmlUrl = source["htmlUrl"];
+	    }
+	}
+	export class User {
+	    login: string;
+	    avatarUrl: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new User(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.login = source["login"];
+	        this.avatarUrl = source["avatarUrl"];
+	    }
+	}
+	export class Comment {
+	    id: string;
+	    author: User;
+	    body: string;
+	    path?: string;
+	    line?: number;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Comment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.author = this.convertValues(source["author"], User);
+	        this.body = source["body"];
+	        this.path = source["path"];
+	        this.line = source["line"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CredentialPermissionCheck {
+	    kind: string;
+	    required: string[];
+	    missing?: string[];
+	    verifiable: boolean;
+	    warning?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CredentialPermissionCheck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.required = source["required"];
+	        this.missing = source["missing"];
+	        this.verifiable = source["verifiable"];
+	        this.warning = source["warning"];
+	    }
+	}
+	export class DashboardPullRequest {
+	    owner: string;
+	    repo: string;
+	    number: number;
+	    title: string;
+	    url: string;
+	    state: string;
+	    isDraft: boolean;
+	    author: User;
+	    headBranch: string;
+	    baseBranch: string;
+	    reviewDecision?: string;
+	    checksState?: string;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new DashboardPullRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.owner = source["owner"];
+	        this.repo = source["repo"];
+	        this.number = source["number"];
+	        this.title = source["title"];
+	        this.url = source["url"];
+	        this.state = source["state"];
+	        this.isDraft = source["isDraft"];
+	        this.author = this.convertValues(source["author"], User);
+	        this.headBranch = source["headBranch"];
+	        this.baseBranch = source["baseBranch"];
+	        this.reviewDecision = source["reviewDecision"];
+	        this.checksState = source["checksState"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DashboardSection {
+	    items: DashboardPullRequest[];
+	    total: number;
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DashboardSection(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], DashboardPullRequest);
+	        this.total = source["total"];
+	        this.truncated = source["truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DiffPagination {
+	    first: number;
+	    after?: string;
+	    hasNextPage: boolean;
+	    endCursor?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffPagination(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.first = source["first"];
+	        this.after = source["after"];
+	        this.hasNextPage = source["hasNextPage"];
+	        this.endCursor = source["endCursor"];
+	    }
+	}
+	export class DiffLine {
+	    type: string;
+	    content: string;
+	    oldLine?: number;
+	    newLine?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffLine(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.content = source["content"];
+	        this.oldLine = source["oldLine"];
+	        this.newLine = source["newLine"];
+	    }
+	}
+	export class DiffHunk {
+	    oldStart: number;
+	    oldLines: number;
+	    newStart: number;
+	    newLines: number;
+	    header: string;
+	    lines: DiffLine[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffHunk(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oldStart = source["oldStart"];
+	        this.oldLines = source["oldLines"];
+	        this.newStart = source["newStart"];
+	        this.newLines = source["newLines"];
+	        this.header = source["header"];
+	        this.lines = this.convertValues(source["lines"], DiffLine);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DiffFile {
+	    filename: string;
+	    status: string;
+	    additions: number;
+	    deletions: number;
+	    patch?: string;
+	    hunks: DiffHunk[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filename = source["filename"];
+	        this.status = source["status"];
+	        this.additions = source["additions"];
+	        this.deletions = source["deletions"];
+	        this.patch = source["patch"];
+	        this.hunks = this.convertValues(source["hunks"], DiffHunk);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Diff {
+	    files: DiffFile[];
+	    totalFiles: number;
+	    pagination: DiffPagination;
+	
+	    static createFrom(source: any = {}) {
+	        return new Diff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.files = this.convertValues(source["files"], DiffFile);
+	        this.totalFiles = source["totalFiles"];
+	        this.pagination = this.convertValues(source["pagination"], DiffPagination);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	export class Label {
+	    name: string;
+	    color: string;
+	    description?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Label(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.color = source["color"];
+	        this.description = source["description"];
+	    }
+	}
+	export class Issue {
+	    id: string;
+	    number: number;
+	    title: string;
+	    body: string;
+	    state: string;
+	    author: User;
+	    assignees: User[];
+	    labels: Label[];
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Issue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.number = source["number"];
+	        this.title = source["title"];
+	        this.body = source["body"];
+	        this.state = source["state"];
+	        this.author = this.convertValues(source["author"], User);
+	        this.assignees = this.convertValues(source["assignees"], User);
+	        this.labels = this.convertValues(source["labels"], Label);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LinkedBranch {
+	    name: string;
+	    repository: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LinkedBranch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.repository = source["repository"];
+	    }
+	}
+	export class LinkedPullRequest {
+	    number: number;
+	    title: string;
+	    state: string;
+	    isDraft: boolean;
+	    url: string;
+	    headBranch: string;
+	    repository: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LinkedPullRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.number = source["number"];
+	        this.title = source["title"];
+	        this.state = source["state"];
+	        this.isDraft = source["isDraft"];
+	        this.url = source["url"];
+	        this.headBranch = source["headBranch"];
+	        this.repository = source["repository"];
+	    }
+	}
+	export class IssueDevelopment {
+	    number: number;
+	    pullRequests: LinkedPullRequest[];
+	    branches: LinkedBranch[];
+	
+	    static createFrom(source: any = {}) {
+	        return new IssueDevelopment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.number = source["number"];
+	        this.pullRequests = this.convertValues(source["pullRequests"], LinkedPullRequest);
+	        this.branches = this.convertValues(source["branches"], LinkedBranch);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class IssueSearchPage {
+	    items: Issue[];
+	    total: number;
+	    hasNextPage: boolean;
+	    endCursor?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new IssueSearchPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], Issue);
+	        this.total = source["total"];
+	        this.hasNextPage = source["hasNextPage"];
+	        this.endCursor = source["endCursor"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class LinkedIssue {
+	    number: number;
+	    title: string;
+	    state: string;
+	    url: string;
+	    repository: string;
+	    assignees: User[];
+	    labels: Label[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LinkedIssue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.number = source["number"];
+	        this.title = source["title"];
+	        this.state = source["state"];
+	        this.url = source["url"];
+	        this.repository = source["repository"];
+	        this.assignees = this.convertValues(source["assignees"], User);
+	        this.labels = this.convertValues(source["labels"], Label);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class MergeBlocker {
+	    code: string;
+	    message: string;
+	    detail?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MergeBlocker(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.message = source["message"];
+	        this.detail = source["detail"];
+	    }
+	}
+	export class ReviewRequirement {
+	    required: number;
+	    approvals: number;
+	    approvedBy: string[];
+	    changesRequested: string[];
+	    codeOwnerReview: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewRequirement(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.required = source["required"];
+	        this.approvals = source["approvals"];
+	        this.approvedBy = source["approvedBy"];
+	        this.changesRequested = source["changesRequested"];
+	        this.codeOwnerReview = source["codeOwnerReview"];
+	    }
+	}
+	export class RequiredCheckStatus {
+	    name: string;
+	    state: string;
+	    conclusion?: string;
+	    detailsUrl?: string;
+	    checkRunId?: number;
+	    workflowRunId?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RequiredCheckStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.state = source["state"];
+	        this.conclusion = source["conclusion"];
+	        this.detailsUrl = source["detailsUrl"];
+	        this.checkRunId = source["checkRunId"];
+	        this.workflowRunId = source["workflowRunId"];
+	    }
+	}
+	export class MergeRequirements {
+	    number: number;
+	    state: string;
+	    isDraft: boolean;
+	    baseBranch: string;
+	    headSha: string;
+	    mergeable?: boolean;
+	    mergeableState: string;
+	    protection?: BranchProtectionRules;
+	    requiredChecks: RequiredCheckStatus[];
+	    reviews: ReviewRequirement;
+	    ready: boolean;
+	    blockers: MergeBlocker[];
+	    warnings: MergeBlocker[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MergeRequirements(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.number = source["number"];
+	        this.state = source["state"];
+	        this.isDraft = source["isDraft"];
+	        this.baseBranch = source["baseBranch"];
+	        this.headSha = source["headSha"];
+	        this.mergeable = source["mergeable"];
+	        this.mergeableState = source["mergeableState"];
+	        this.protection = this.convertValues(source["protection"], BranchProtectionRules);
+	        this.requiredChecks = this.convertValues(source["requiredChecks"], RequiredCheckStatus);
+	        this.reviews = this.convertValues(source["reviews"], ReviewRequirement);
+	        this.ready = source["ready"];
+	        this.blockers = this.convertValues(source["blockers"], MergeBlocker);
+	        this.warnings = this.convertValues(source["warnings"], MergeBlocker);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PRCommit {
+	    sha: string;
+	    message: string;
+	    htmlUrl?: string;
+	    authorName?: string;
+	    authorEmail?: string;
+	    // Go type: time
+	    authoredAt?: any;
+	    committerName?: string;
+	    committerEmail?: string;
+	    // Go type: time
+	    committedAt?: any;
+	    author?: User;
+	    committer?: User;
+	    parentShas?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PRCommit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sha = source["sha"];
+	        this.message = source["message"];
+	        this.htmlUrl = source["htmlUrl"];
+	        this.authorName = source["authorName"];
+	        this.authorEmail = source["authorEmail"];
+	        this.authoredAt = this.convertValues(source["authoredAt"], null);
+	        this.committerName = source["committerName"];
+	        this.committerEmail = source["committerEmail"];
+	        this.committedAt = this.convertValues(source["committedAt"], null);
+	        this.author = this.convertValues(source["author"], User);
+	        this.committer = this.convertValues(source["committer"], User);
+	        this.parentShas = source["parentShas"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PRCommitPage {
+	    items: PRCommit[];
+	    page: number;
+	    perPage: number;
+	    hasNextPage: boolean;
+	    nextPage?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PRCommitPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], PRCommit);
+	        this.page = source["page"];
+	        this.perPage = source["perPage"];
+	        this.hasNextPage = source["hasNextPage"];
+	        this.nextPage = source["nextPage"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PRFile {
+	    filename: string;
+	    previousFilename?: string;
+	    status: string;
+	    additions: number;
+	    deletions: number;
+	    changes: number;
+	    blobUrl?: string;
+	    rawUrl?: string;
+	    contentsUrl?: string;
+	    patch?: string;
+	    hasPatch: boolean;
+	    patchState: string;
+	    isBinary: boolean;
+	    isPatchTruncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PRFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filename = source["filename"];
+	        this.previousFilename = source["previousFilename"];
+	        this.status = source["status"];
+	        this.additions = source["additions"];
+	        this.deletions = source["deletions"];
+	        this.changes = source["changes"];
+	        this.blobUrl = source["blobUrl"];
+	        this.rawUrl = source["rawUrl"];
+	        this.contentsUrl = source["contentsUrl"];
+	        this.patch = source["patch"];
+	        this.hasPatch = source["hasPatch"];
+	        this.patchState = source["patchState"];
+	        this.isBinary = source["isBinary"];
+	        this.isPatchTruncated = source["isPatchTruncated"];
+	    }
+	}
+	export class PRFilePage {
+	    items: PRFile[];
+	    page: number;
+	    perPage: number;
+	    hasNextPage: boolean;
+	    nextPage?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PRFilePage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], PRFile);
+	        this.page = source["page"];
+	        this.perPage = source["perPage"];
+	        this.hasNextPage = source["hasNextPage"];
+	        this.nextPage = source["nextPage"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PullRequest {
+	    id: string;
+	    number: number;
+	    title: string;
+	    body: string;
+	    state: string;
+	    author: User;
+	    reviewers: User[];
+	    labels: Label[];
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	    mergeCommit?: string;
+	    headBranch: string;
+	    baseBranch: string;
+	    additions: number;
+	    deletions: number;
+	    changedFiles: number;
+	    isDraft: boolean;
+	    maintainerCanModify?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PullRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.number = source["number"];
+	        this.title = source["title"];
+	        this.body = source["body"];
+	        this.state = source["state"];
+	        this.author = this.convertValues(source["author"], User);
+	        this.reviewers = this.convertValues(source["reviewers"], User);
+	        this.labels = this.convertValues(source["labels"], Label);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	        this.mergeCommit = source["mergeCommit"];
+	        this.headBranch = source["headBranch"];
+	        this.baseBranch = source["baseBranch"];
+	        this.additions = source["additions"];
+	        this.deletions = source["deletions"];
+	        this.changedFiles = source["changedFiles"];
+	        this.isDraft = source["isDraft"];
+	        this.maintainerCanModify = source["maintainerCanModify"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PullRequestDashboard {
+	    authored: DashboardSection;
+	    reviewRequested: DashboardSection;
+	    failingChecks: DashboardSection;
+	    // Go type: time
+	    fetchedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new PullRequestDashboard(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.authored = this.convertValues(source["authored"], DashboardSection);
+	        this.reviewRequested = this.convertValues(source["reviewRequested"], DashboardSection);
+	        this.failingChecks = this.convertValues(source["failingChecks"], DashboardSection);
+	        this.fetchedAt = this.convertValues(source["fetchedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RateCategoryUsage {
+	    category: string;
+	    resource: string;
+	    requests: number;
+	    points: number;
+	    batched: number;
+	    deferred: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RateCategoryUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.category = source["category"];
+	        this.resource = source["resource"];
+	        this.requests = source["requests"];
+	        this.points = source["points"];
+	        this.batched = source["batched"];
+	        this.deferred = source["deferred"];
+	    }
+	}
+	export class RateResourceInfo {
+	    resource: string;
+	    remaining: number;
+	    limit: number;
+	    used: number;
+	    // Go type: time
+	    resetAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new RateResourceInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.resource = source["resource"];
+	        this.remaining = source["remaining"];
+	        this.limit = source["limit"];
+	        this.used = source["used"];
+	        this.resetAt = this.convertValues(source["resetAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RateBudgetStatus {
+	    mode: string;
+	    inFlight: number;
+	    queued: number;
+	    resources: RateResourceInfo[];
+	    categories: RateCategoryUsage[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RateBudgetStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.inFlight = source["inFlight"];
+	        this.queued = source["queued"];
+	        this.resources = this.convertValues(source["resources"], RateResourceInfo);
+	        this.categories = this.convertValues(source["categories"], RateCategoryUsage);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class RateLimitInfo {
+	    remaining: number;
+	    limit: number;
+	    // Go type: time
+	    resetAt: any;
+	    budget?: RateBudgetStatus;
+	
+	    static createFrom(source: any = {}) {
+	        return new RateLimitInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.remaining = source["remaining"];
+	        this.limit = source["limit"];
+	        this.resetAt = this.convertValues(source["resetAt"], null);
+	        this.budget = this.convertValues(source["budget"], RateBudgetStatus);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Repository {
+	    id: string;
+	    name: string;
+	    fullName: string;
+	    owner: string;
+	    description: string;
+	    isPrivate: boolean;
+	    defaultBranch: string;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Repository(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.fullName = source["fullName"];
+	        this.owner = source["owner"];
+	        this.description = source["description"];
+	        this.isPrivate = source["isPrivate"];
+	        this.defaultBranch = source["defaultBranch"];
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Review {
+	    id: string;
+	    author: User;
+	    state: string;
+	    body: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Review(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.author = this.convertValues(source["author"], User);
+	        this.state = source["state"];
+	        this.body = source["body"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class WebhookBridgeStatus {
+	    enabled: boolean;
+	    connected: boolean;
+	    relayUrl?: string;
+	    hasSecret: boolean;
+	    eventsReceived: number;
+	    // Go type: time
+	    lastEventAt?: any;
+	    lastError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WebhookBridgeStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.connected = source["connected"];
+	        this.relayUrl = source["relayUrl"];
+	        this.hasSecret = source["hasSecret"];
+	        this.eventsReceived = source["eventsReceived"];
+	        this.lastEventAt = this.convertValues(source["lastEventAt"], null);
+	        this.lastError = source["lastError"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace gitpanel {
+	
+	export class BinaryBlobDTO {
+	    exists: boolean;
+	    size: number;
+	    hash?: string;
+	    mimeType?: string;
+	    width?: number;
+	    height?: number;
+	    dataUrl?: string;
+	    previewOmitted?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BinaryBlobDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.exists = source["exists"];
+	        this.size = source["size"];
+	        this.hash = source["hash"];
+	        this.mimeType = source["mimeType"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.dataUrl = source["dataUrl"];
+	        this.previewOmitted = source["previewOmitted"];
+	    }
+	}
+	export class BinaryDiffDTO {
+	    isImage: boolean;
+	    old: BinaryBlobDTO;
+	    new: BinaryBlobDTO;
+	    sizeDelta: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BinaryDiffDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.isImage = source["isImage"];
+	        this.old = this.convertValues(source["old"], BinaryBlobDTO);
+	        this.new = this.convertValues(source["new"], BinaryBlobDTO);
+	        this.sizeDelta = source["sizeDelta"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BranchNameSuggestionDTO {
+	    name: string;
+	    template: string;
+	    type: string;
+	    slug: string;
+	    source: string;
+	    candidates: string[];
+	    warning?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BranchNameSuggestionDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.template = source["template"];
+	        this.type = source["type"];
+	        this.slug = source["slug"];
+	        this.source = source["source"];
+	        this.candidates = source["candidates"];
+	        this.warning = source["warning"];
+	    }
+	}
+	export class BranchNamingPolicyDTO {
+	    templates: string[];
+	    mode: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BranchNamingPolicyDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.templates = source["templates"];
+	        this.mode = source["mode"];
+	    }
+	}
+	export class BranchNamingValidationDTO {
+	    branch: string;
+	    mode: string;
+	    valid: boolean;
+	    blocking: boolean;
+	    matchedTemplate?: string;
+	    templates: string[];
+	    violations: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BranchNamingValidationDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.branch = source["branch"];
+	        this.mode = source["mode"];
+	        this.valid = source["valid"];
+	        this.blocking = source["blocking"];
+	        this.matchedTemplate = source["matchedTemplate"];
+	        this.templates = source["templates"];
+	        this.violations = source["violations"];
+	    }
+	}
+	export class ChangelogDTO {
+	    fromRef: string;
+	    toRef: string;
+	    fromHash?: string;
+	    toHash: string;
+	    version: string;
+	    style: string;
+	    date: string;
+	    sections: ChangelogSectionDTO[];
+	    commitCount: number;
+	    truncated: boolean;
+	    markdown: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangelogDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fromRef = source["fromRef"];
+	        this.toRef = source["toRef"];
+	        this.fromHash = source["fromHash"];
+	        this.toHash = source["toHash"];
+	        this.version = source["version"];
+	        this.style = source["style"];
+	        this.date = source["date"];
+	        this.sections = this.convertValues(source["sections"], ChangelogSectionDTO);
+	        this.commitCount = source["commitCount"];
+	        this.truncated = source["truncated"];
+	        this.markdown = source["markdown"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ChangelogEntryDTO {
+	    hash: string;
+	    shortHash: string;
+	    type: string;
+	    scope?: string;
+	    description: string;
+	    breaking: boolean;
+	    author: string;
+	    pr?: ChangelogPRDTO;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangelogEntryDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.type = source["type"];
+	        this.scope = source["scope"];
+	        this.description = source["description"];
+	        this.breaking = source["breaking"];
+	        this.author = source["author"];
+	        this.pr = this.convertValues(source["pr"], ChangelogPRDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ChangelogFileResultDTO {
+	    path: string;
+	    created: boolean;
+	    replaced: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangelogFileResultDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.created = source["created"];
+	        this.replaced = source["replaced"];
+	    }
+	}
+	export class ChangelogPRDTO {
+	    number: number;
+	    url?: string;
+	    title?: string;
+	    author?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangelogPRDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.number = source["number"];
+	        this.url = source["url"];
+	        this.title = source["title"];
+	        this.author = source["author"];
+	    }
+	}
+	export class ChangelogSectionDTO {
+	    key: string;
+	    title: string;
+	    entries: ChangelogEntryDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangelogSectionDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.title = source["title"];
+	        this.entries = this.convertValues(source["entries"], ChangelogEntryDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExecMetricsDTO {
+	    since: string;
+	    workers: number;
+	    writeQueueDepth: number;
+	    running: number;
+	    waiting: number;
+	    commands: number;
+	    rejectedWrites: number;
+	    avgQueueWaitMs: number;
+	    avgExecMs: number;
+	    repos: ExecRepoMetricsDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ExecMetricsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.since = source["since"];
+	        this.workers = source["workers"];
+	        this.writeQueueDepth = source["writeQueueDepth"];
+	        this.running = source["running"];
+	        this.waiting = source["waiting"];
+	        this.commands = source["commands"];
+	        this.rejectedWrites = source["rejectedWrites"];
+	        this.avgQueueWaitMs = source["avgQueueWaitMs"];
+	        this.avgExecMs = source["avgExecMs"];
+	        this.repos = this.convertValues(source["repos"], ExecRepoMetricsDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExecRepoMetricsDTO {
+	    repoRoot: string;
+	    commands: number;
+	    avgQueueWaitMs: number;
+	    maxQueueWaitMs: number;
+	    avgExecMs: number;
+	    maxExecMs: number;
+	    writes: number;
+	    avgWriteQueueWaitMs: number;
+	    maxWriteQueueWaitMs: number;
+	    rejectedWrites: number;
+	    queuedWrites: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExecRepoMetricsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoRoot = source["repoRoot"];
+	        this.commands = source["commands"];
+	        this.avgQueueWaitMs = source["avgQueueWaitMs"];
+	        this.maxQueueWaitMs = source["maxQueueWaitMs"];
+	        this.avgExecMs = source["avgExecMs"];
+	        this.maxExecMs = source["maxExecMs"];
+	        this.writes = source["writes"];
+	        this.avgWriteQueueWaitMs = source["avgWriteQueueWaitMs"];
+	        this.maxWriteQueueWaitMs = source["maxWriteQueueWaitMs"];
+	        this.rejectedWrites = source["rejectedWrites"];
+	        this.queuedWrites = source["queuedWrites"];
+	    }
+	}
+	export class ForkSyncStatusDTO {
+	    upstreamRemote: string;
+	    forkRemote?: string;
+	    defaultBranch: string;
+	    currentBranch?: string;
+	    localExists: boolean;
+	    localAhead: number;
+	    localBehind: number;
+	    forkAhead: number;
+	    forkBehind: number;
+	    strategy: string;
+	    synced: boolean;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ForkSyncStatusDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.upstreamRemote = source["upstreamRemote"];
+	        this.forkRemote = source["forkRemote"];
+	        this.defaultBranch = source["defaultBranch"];
+	        this.currentBranch = source["currentBranch"];
+	        this.localExists = source["localExists"];
+	        this.localAhead = source["localAhead"];
+	        this.localBehind = source["localBehind"];
+	        this.forkAhead = source["forkAhead"];
+	        this.forkBehind = source["forkBehind"];
+	        this.strategy = source["strategy"];
+	        this.synced = source["synced"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class GitConfigDTO {
+	    scope: string;
+	    repoRoot: string;
+	    entries: GitConfigEntryDTO[];
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GitConfigDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scope = source["scope"];
+	        this.repoRoot = source["repoRoot"];
+	        this.entries = this.convertValues(source["entries"], GitConfigEntryDTO);
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GitConfigEntryDTO {
+	    key: string;
+	    description: string;
+	    kind: string;
+	    options: string[];
+	    value: string;
+	    set: boolean;
+	    effectiveValue: string;
+	    effectiveScope?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitConfigEntryDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.description = source["description"];
+	        this.kind = source["kind"];
+	        this.options = source["options"];
+	        this.value = source["value"];
+	        this.set = source["set"];
+	        this.effectiveValue = source["effectiveValue"];
+	        this.effectiveScope = source["effectiveScope"];
+	    }
+	}
+	export class PartialCloneDTO {
+	    enabled: boolean;
+	    remote?: string;
+	    filter?: string;
+	    promisor: boolean;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PartialCloneDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.remote = source["remote"];
+	        this.filter = source["filter"];
+	        this.promisor = source["promisor"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class ReadBackendDTO {
+	    repoRoot: string;
+	    backend: string;
+	    override: boolean;
+	    lastFallback?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReadBackendDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoRoot = source["repoRoot"];
+	        this.backend = source["backend"];
+	        this.override = source["override"];
+	        this.lastFallback = source["lastFallback"];
+	    }
+	}
+	export class ReadBenchmarkDTO {
+	    repoRoot: string;
+	    iterations: number;
+	    results: ReadBenchmarkResultDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ReadBenchmarkDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoRoot = source["repoRoot"];
+	        this.iterations = source["iterations"];
+	        this.results = this.convertValues(source["results"], ReadBenchmarkResultDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ReadBenchmarkResultDTO {
+	    backend: string;
+	    operation: string;
+	    avgMs: number;
+	    minMs: number;
+	    maxMs: number;
+	    fallback?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReadBenchmarkResultDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.backend = source["backend"];
+	        this.operation = source["operation"];
+	        this.avgMs = source["avgMs"];
+	        this.minMs = source["minMs"];
+	        this.maxMs = source["maxMs"];
+	        this.fallback = source["fallback"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ReflogEntryDTO {
+	    selector: string;
+	    hash: string;
+	    shortHash: string;
+	    date?: string;
+	    action: string;
+	    detail?: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReflogEntryDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.selector = source["selector"];
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.date = source["date"];
+	        this.action = source["action"];
+	        this.detail = source["detail"];
+	        this.message = source["message"];
+	    }
+	}
+	export class SigningKeyDTO {
+	    format: string;
+	    id: string;
+	    fingerprint?: string;
+	    label?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SigningKeyDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.format = source["format"];
+	        this.id = source["id"];
+	        this.fingerprint = source["fingerprint"];
+	        this.label = source["label"];
+	    }
+	}
+	export class SigningSetupDTO {
+	    format: string;
+	    signingKey: string;
+	    signCommits: boolean;
+	    signTags: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SigningSetupDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.format = source["format"];
+	        this.signingKey = source["signingKey"];
+	        this.signCommits = source["signCommits"];
+	        this.signTags = source["signTags"];
+	    }
+	}
+	export class SigningStatusDTO {
+	    format: string;
+	    signingKey?: string;
+	    signCommits: boolean;
+	    signTags: boolean;
+	    program: string;
+	    programAvailable: boolean;
+	    keyAvailable: boolean;
+	    allowedSignersFile?: string;
+	    configured: boolean;
+	    availableKeys: SigningKeyDTO[];
+	    issues: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SigningStatusDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.format = source["format"];
+	        this.signingKey = source["signingKey"];
+	        this.signCommits = source["signCommits"];
+	        this.signTags = source["signTags"];
+	        this.program = source["program"];
+	        this.programAvailable = source["programAvailable"];
+	        this.keyAvailable = source["keyAvailable"];
+	        this.allowedSignersFile = source["allowedSignersFile"];
+	        this.configured = source["configured"];
+	        this.availableKeys = this.convertValues(source["availableKeys"], SigningKeyDTO);
+	        this.issues = source["issues"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UndoPlanDTO {
+	    operation: string;
+	    entry: ReflogEntryDTO;
+	    supported: boolean;
+	    reason?: string;
+	    description?: string;
+	    command: string[];
+	    currentHash?: string;
+	    targetHash?: string;
+	    warnings: string[];
+	    dryRun: boolean;
+	    applied: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new UndoPlanDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.operation = source["operation"];
+	        this.entry = this.convertValues(source["entry"], ReflogEntryDTO);
+	        this.supported = source["supported"];
+	        this.reason = source["reason"];
+	        this.description = source["description"];
+	        this.command = source["command"];
+	        this.currentHash = source["currentHash"];
+	        this.targetHash = source["targetHash"];
+	        this.warnings = source["warnings"];
+	        this.dryRun = source["dryRun"];
+	        this.applied = source["applied"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WordRangeDTO {
+	    start: number;
+	    end: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WordRangeDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = source["start"];
+	        this.end = source["end"];
+	    }
+	}
+	export class DiffLineDTO {
+	    type: string;
+	    content: string;
+	    oldLine?: number;
+	    newLine?: number;
+	    changes?: WordRangeDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffLineDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.content = source["content"];
+	        this.oldLine = source["oldLine"];
+	        this.newLine = source["newLine"];
+	        this.changes = this.convertValues(source["changes"], WordRangeDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DiffHunkDTO {
+	    header: string;
+	    oldStart: number;
+	    oldLines: number;
+	    newStart: number;
+	    newLines: number;
+	    lines: DiffLineDTO[];
+	    context?: string;
+	    contextKind?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffHunkDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.header = source["header"];
+	        this.oldStart = source["oldStart"];
+	        this.oldLines = source["oldLines"];
+	        this.newStart = source["newStart"];
+	        this.newLines = source["newLines"];
+	        this.lines = this.convertValues(source["lines"], DiffLineDTO);
+	        this.context = source["context"];
+	        this.contextKind = source["contextKind"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DiffFileDTO {
+	    path: string;
+	    oldPath?: string;
+	    status: string;
+	    additions: number;
+	    deletions: number;
+	    isBinary: boolean;
+	    hunks: DiffHunkDTO[];
+	    binary?: BinaryDiffDTO;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffFileDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.oldPath = source["oldPath"];
+	        this.status = source["status"];
+	        this.additions = source["additions"];
+	        this.deletions = source["deletions"];
+	        this.isBinary = source["isBinary"];
+	        this.hunks = this.convertValues(source["hunks"], DiffHunkDTO);
+	        this.binary = this.convertValues(source["binary"], BinaryDiffDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DiffDTO {
+	    mode: string;
+	    filePath?: string;
+	    raw: string;
+	    files: DiffFileDTO[];
+	    isBinary: boolean;
+	    isTruncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.filePath = source["filePath"];
+	        this.raw = source["raw"];
+	        this.files = this.convertValues(source["files"], DiffFileDTO);
+	        this.isBinary = source["isBinary"];
+	        this.isTruncated = source["isTruncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ComparisonFileDTO {
+	    path: string;
+	    oldPath?: string;
+	    status: string;
+	    additions: number;
+	    deletions: number;
+	    isBinary: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ComparisonFileDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.oldPath = source["oldPath"];
+	        this.status = source["status"];
+	        this.additions = source["additions"];
+	        this.deletions = source["deletions"];
+	        this.isBinary = source["isBinary"];
+	    }
+	}
+	export class IssueLinkDTO {
+	    key: string;
+	    title?: string;
+	    status?: string;
+	    url?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new IssueLinkDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.title = source["title"];
+	        this.status = source["status"];
+	        this.url = source["url"];
+	    }
+	}
+	export class HistoryItemDTO {
+	    hash: string;
+	    shortHash: string;
+	    author: string;
+	    authoredAt: string;
+	    subject: string;
+	    additions: number;
+	    deletions: number;
+	    changedFiles: number;
+	    githubLogin?: string;
+	    githubAvatarUrl?: string;
+	    signature?: string;
+	    issues?: IssueLinkDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryItemDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.author = source["author"];
+	        this.authoredAt = source["authoredAt"];
+	        this.subject = source["subject"];
+	        this.additions = source["additions"];
+	        this.deletions = source["deletions"];
+	        this.changedFiles = source["changedFiles"];
+	        this.githubLogin = source["githubLogin"];
+	        this.githubAvatarUrl = source["githubAvatarUrl"];
+	        this.signature = source["signature"];
+	        this.issues = this.convertValues(source["issues"], IssueLinkDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BranchComparisonDTO {
+	    base: string;
+	    head: string;
+	    baseHash: string;
+	    headHash: string;
+	    mergeBase: string;
+	    ahead: number;
+	    behind: number;
+	    aheadCommits: HistoryItemDTO[];
+	    behindCommits: HistoryItemDTO[];
+	    commitsTruncated: boolean;
+	    files: ComparisonFileDTO[];
+	    additions: number;
+	    deletions: number;
+	    diff: DiffDTO;
+	
+	    static createFrom(source: any = {}) {
+	        return new BranchComparisonDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.base = source["base"];
+	        this.head = source["head"];
+	        this.baseHash = source["baseHash"];
+	        this.headHash = source["headHash"];
+	        this.mergeBase = source["mergeBase"];
+	        this.ahead = source["ahead"];
+	        this.behind = source["behind"];
+	        this.aheadCommits = this.convertValues(source["aheadCommits"], HistoryItemDTO);
+	        this.behindCommits = this.convertValues(source["behindCommits"], HistoryItemDTO);
+	        this.commitsTruncated = source["commitsTruncated"];
+	        this.files = this.convertValues(source["files"], ComparisonFileDTO);
+	        this.additions = source["additions"];
+	        this.deletions = source["deletions"];
+	        this.diff = this.convertValues(source["diff"], DiffDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CacheKindMetricsDTO {
+	    kind: string;
+	    hits: number;
+	    misses: number;
+	    hitRate: number;
+	    computes: number;
+	    avgComputeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CacheKindMetricsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.hits = source["hits"];
+	        this.misses = source["misses"];
+	        this.hitRate = source["hitRate"];
+	        this.computes = source["computes"];
+	        this.avgComputeMs = source["avgComputeMs"];
+	    }
+	}
+	export class CacheMetricsDTO {
+	    since: string;
+	    kinds: CacheKindMetricsDTO[];
+	    warmups: number;
+	    lastWarmupMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CacheMetricsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.since = source["since"];
+	        this.kinds = this.convertValues(source["kinds"], CacheKindMetricsDTO);
+	        this.warmups = source["warmups"];
+	        this.lastWarmupMs = source["lastWarmupMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CacheTTLsDTO {
+	    preflightMs: number;
+	    statusMs: number;
+	    historyMs: number;
+	    diffMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CacheTTLsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.preflightMs = source["preflightMs"];
+	        this.statusMs = source["statusMs"];
+	        this.historyMs = source["historyMs"];
+	        this.diffMs = source["diffMs"];
+	    }
+	}
+	export class CommitBucketDTO {
+	    start: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitBucketDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = source["start"];
+	        this.count = source["count"];
+	    }
+	}
+	export class CommitFileDTO {
+	    path: string;
+	    status: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitFileDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.status = source["status"];
+	    }
+	}
+	export class CommitDetailsDTO {
+	    hash: string;
+	    files: CommitFileDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitDetailsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.files = this.convertValues(source["files"], CommitFileDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CommitLintConfigDTO {
+	    source: string;
+	    types: string[];
+	    scopes?: string[];
+	    scopeRequired: boolean;
+	    headerMaxLength: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitLintConfigDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.types = source["types"];
+	        this.scopes = source["scopes"];
+	        this.scopeRequired = source["scopeRequired"];
+	        this.headerMaxLength = source["headerMaxLength"];
+	    }
+	}
+	export class CommitLintIssueDTO {
+	    rule: string;
+	    severity: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitLintIssueDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rule = source["rule"];
+	        this.severity = source["severity"];
+	        this.message = source["message"];
+	    }
+	}
+	export class CommitLintResultDTO {
+	    valid: boolean;
+	    blocking: boolean;
+	    mode: string;
+	    type?: string;
+	    scope?: string;
+	    breaking: boolean;
+	    subject?: string;
+	    issues: CommitLintIssueDTO[];
+	    config: CommitLintConfigDTO;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitLintResultDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.valid = source["valid"];
+	        this.blocking = source["blocking"];
+	        this.mode = source["mode"];
+	        this.type = source["type"];
+	        this.scope = source["scope"];
+	        this.breaking = source["breaking"];
+	        this.subject = source["subject"];
+	        this.issues = this.convertValues(source["issues"], CommitLintIssueDTO);
+	        this.config = this.convertValues(source["config"], CommitLintConfigDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CommitOptionsDTO {
+	    noVerify: boolean;
+	    secretOverrides?: string[];
+	    coAuthors?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitOptionsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.noVerify = source["noVerify"];
+	        this.secretOverrides = source["secretOverrides"];
+	        this.coAuthors = source["coAuthors"];
+	    }
+	}
+	export class SecretFindingDTO {
+	    id: string;
+	    ruleId: string;
+	    description: string;
+	    filePath: string;
+	    line: number;
+	    column: number;
+	    preview: string;
+	    overridden: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SecretFindingDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.ruleId = source["ruleId"];
+	        this.description = source["description"];
+	        this.filePath = source["filePath"];
+	        this.line = source["line"];
+	        this.column = source["column"];
+	        this.preview = source["preview"];
+	        this.overridden = source["overridden"];
+	    }
+	}
+	export class SecretScanResultDTO {
+	    repoPath: string;
+	    scannedFiles: number;
+	    findings: SecretFindingDTO[];
+	    blocking: number;
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SecretScanResultDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoPath = source["repoPath"];
+	        this.scannedFiles = source["scannedFiles"];
+	        this.findings = this.convertValues(source["findings"], SecretFindingDTO);
+	        this.blocking = source["blocking"];
+	        this.truncated = source["truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HookRunDTO {
+	    name: string;
+	    command?: string;
+	    exitCode: number;
+	    failed: boolean;
+	    durationMs: number;
+	    output?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HookRunDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.command = source["command"];
+	        this.exitCode = source["exitCode"];
+	        this.failed = source["failed"];
+	        this.durationMs = source["durationMs"];
+	        this.output = source["output"];
+	    }
+	}
+	export class CommitResultDTO {
+	    committed: boolean;
+	    commitHash?: string;
+	    noVerify: boolean;
+	    failedHook?: string;
+	    hooks: HookRunDTO[];
+	    output?: string;
+	    lint?: CommitLintResultDTO;
+	    secrets?: SecretScanResultDTO;
+	    coAuthors?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitResultDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.committed = source["committed"];
+	        this.commitHash = source["commitHash"];
+	        this.noVerify = source["noVerify"];
+	        this.failedHook = source["failedHook"];
+	        this.hooks = this.convertValues(source["hooks"], HookRunDTO);
+	        this.output = source["output"];
+	        this.lint = this.convertValues(source["lint"], CommitLintResultDTO);
+	        this.secrets = this.convertValues(source["secrets"], SecretScanResultDTO);
+	        this.coAuthors = source["coAuthors"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CommitSuggestionsDTO {
+	    stagedCount: number;
+	    types: string[];
+	    scopes: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitSuggestionsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.stagedCount = source["stagedCount"];
+	        this.types = source["types"];
+	        this.scopes = source["scopes"];
+	    }
+	}
+	
+	export class ConflictFileDTO {
+	    path: string;
+	    status: string;
+	    line?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConflictFileDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.status = source["status"];
+	        this.line = source["line"];
+	    }
+	}
+	export class ContributorDTO {
+	    name: string;
+	    email?: string;
+	    commits: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContributorDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.email = source["email"];
+	        this.commits = source["commits"];
+	    }
+	}
+	export class ContributorStatsDTO {
+	    name: string;
+	    email?: string;
+	    commits: number;
+	    additions: number;
+	    deletions: number;
+	    activeDays: number;
+	    firstCommitAt: string;
+	    lastCommitAt: string;
+	    lastCommitHash: string;
+	    githubLogin?: string;
+	    githubAvatarUrl?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContributorStatsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.email = source["email"];
+	        this.commits = source["commits"];
+	        this.additions = source["additions"];
+	        this.deletions = source["deletions"];
+	        this.activeDays = source["activeDays"];
+	        this.firstCommitAt = source["firstCommitAt"];
+	        this.lastCommitAt = source["lastCommitAt"];
+	        this.lastCommitHash = source["lastCommitHash"];
+	        this.githubLogin = source["githubLogin"];
+	        this.githubAvatarUrl = source["githubAvatarUrl"];
+	    }
+	}
+	export class ContributorStatsPageDTO {
+	    repoRoot: string;
+	    rangeDays: number;
+	    items: ContributorStatsDTO[];
+	    totalContributors: number;
+	    totalCommits: number;
+	    nextCursor: string;
+	    hasMore: boolean;
+	    computedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContributorStatsPageDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoRoot = source["repoRoot"];
+	        this.rangeDays = source["rangeDays"];
+	        this.items = this.convertValues(source["items"], ContributorStatsDTO);
+	        this.totalContributors = source["totalContributors"];
+	        this.totalCommits = source["totalCommits"];
+	        this.nextCursor = source["nextCursor"];
+	        this.hasMore = source["hasMore"];
+	        this.computedAt = source["computedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	export class DiffOptionsDTO {
+	    wordDiff: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffOptionsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.wordDiff = source["wordDiff"];
+	    }
+	}
+	export class ExternalToolDTO {
+	    id: string;
+	    name: string;
+	    command?: string;
+	    available: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExternalToolDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.command = source["command"];
+	        this.available = source["available"];
+	    }
+	}
+	export class FetchOptionsDTO {
+	    remote?: string;
+	    prune: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FetchOptionsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.remote = source["remote"];
+	        this.prune = source["prune"];
+	    }
+	}
+	export class FileChangeDTO {
+	    path: string;
+	    originalPath?: string;
+	    status: string;
+	    added: number;
+	    removed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileChangeDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.originalPath = source["originalPath"];
+	        this.status = source["status"];
+	        this.added = source["added"];
+	        this.removed = source["removed"];
+	    }
+	}
+	export class FileHistoryItemDTO {
+	    hash: string;
+	    shortHash: string;
+	    author: string;
+	    authoredAt: string;
+	    subject: string;
+	    status: string;
+	    path: string;
+	    oldPath?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileHistoryItemDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.author = source["author"];
+	        this.authoredAt = source["authoredAt"];
+	        this.subject = source["subject"];
+	        this.status = source["status"];
+	        this.path = source["path"];
+	        this.oldPath = source["oldPath"];
+	    }
+	}
+	export class FileHistoryPageDTO {
+	    filePath: string;
+	    items: FileHistoryItemDTO[];
+	    nextCursor: string;
+	    hasMore: boolean;
+	    warning?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileHistoryPageDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filePath = source["filePath"];
+	        this.items = this.convertValues(source["items"], FileHistoryItemDTO);
+	        this.nextCursor = source["nextCursor"];
+	        this.hasMore = source["hasMore"];
+	        this.warning = source["warning"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HistoryCountEstimateDTO {
+	    repoRoot: string;
+	    pathScope?: string;
+	    total: number;
+	    head?: string;
+	    exact: boolean;
+	    computedAt?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryCountEstimateDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoRoot = source["repoRoot"];
+	        this.pathScope = source["pathScope"];
+	        this.total = source["total"];
+	        this.head = source["head"];
+	        this.exact = source["exact"];
+	        this.computedAt = source["computedAt"];
+	    }
+	}
+	
+	export class HistoryPageDTO {
+	    items: HistoryItemDTO[];
+	    nextCursor: string;
+	    hasMore: boolean;
+	    pathScope?: string;
+	    estimatedTotal?: number;
+	    detail?: string;
+	    warning?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryPageDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], HistoryItemDTO);
+	        this.nextCursor = source["nextCursor"];
+	        this.hasMore = source["hasMore"];
+	        this.pathScope = source["pathScope"];
+	        this.estimatedTotal = source["estimatedTotal"];
+	        this.detail = source["detail"];
+	        this.warning = source["warning"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HistoryStreamOptionsDTO {
+	    cursor?: string;
+	    search?: string;
+	    scope?: string;
+	    batchSize?: number;
+	    maxItems?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryStreamOptionsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.cursor = source["cursor"];
+	        this.search = source["search"];
+	        this.scope = source["scope"];
+	        this.batchSize = source["batchSize"];
+	        this.maxItems = source["maxItems"];
+	    }
+	}
+	export class HookFrameworkDTO {
+	    name: string;
+	    configPath?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HookFrameworkDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.configPath = source["configPath"];
+	    }
+	}
+	
+	export class HookSetupDTO {
+	    hooksPath: string;
+	    installed: string[];
+	    frameworks: HookFrameworkDTO[];
+	    allowNoVerify: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new HookSetupDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hooksPath = source["hooksPath"];
+	        this.installed = source["installed"];
+	        this.frameworks = this.convertValues(source["frameworks"], HookFrameworkDTO);
+	        this.allowNoVerify = source["allowNoVerify"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class IgnoreCheckDTO {
+	    path: string;
+	    ignored: boolean;
+	    source?: string;
+	    line?: number;
+	    pattern?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new IgnoreCheckDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.ignored = source["ignored"];
+	        this.source = source["source"];
+	        this.line = source["line"];
+	        this.pattern = source["pattern"];
+	    }
+	}
+	export class IgnoreRulesDTO {
+	    path: string;
+	    exists: boolean;
+	    content: string;
+	    patterns: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new IgnoreRulesDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.exists = source["exists"];
+	        this.content = source["content"];
+	        this.patterns = source["patterns"];
+	    }
+	}
+	export class IgnoreSuggestionDTO {
+	    pattern: string;
+	    reason: string;
+	    matches: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new IgnoreSuggestionDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pattern = source["pattern"];
+	        this.reason = source["reason"];
+	        this.matches = source["matches"];
+	    }
+	}
+	
+	export class PreflightResult {
+	    gitAvailable: boolean;
+	    repoPath: string;
+	    repoRoot: string;
+	    branch?: string;
+	    mergeActive: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PreflightResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.gitAvailable = source["gitAvailable"];
+	        this.repoPath = source["repoPath"];
+	        this.repoRoot = source["repoRoot"];
+	        this.branch = source["branch"];
+	        this.mergeActive = source["mergeActive"];
+	    }
+	}
+	export class PushConfirmationDTO {
+	    protectedBranch: boolean;
+	    forcePush: boolean;
+	    largeFiles: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PushConfirmationDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.protectedBranch = source["protectedBranch"];
+	        this.forcePush = source["forcePush"];
+	        this.largeFiles = source["largeFiles"];
+	    }
+	}
+	export class PushOptionsDTO {
+	    remote?: string;
+	    branch?: string;
+	    force: boolean;
+	    setUpstream: boolean;
+	    confirm: PushConfirmationDTO;
+	
+	    static createFrom(source: any = {}) {
+	        return new PushOptionsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.remote = source["remote"];
+	        this.branch = source["branch"];
+	        this.force = source["force"];
+	        this.setUpstream = source["setUpstream"];
+	        this.confirm = this.convertValues(source["confirm"], PushConfirmationDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PushViolationDTO {
+	    kind: string;
+	    message: string;
+	    confirmed: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PushViolationDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.message = source["message"];
+	        this.confirmed = source["confirmed"];
+	    }
+	}
+	export class RepoFileSizeDTO {
+	    path: string;
+	    sizeBytes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RepoFileSizeDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.sizeBytes = source["sizeBytes"];
+	    }
+	}
+	export class PushSafetyReportDTO {
+	    remote: string;
+	    branch: string;
+	    defaultBranch?: string;
+	    protectedBranch: boolean;
+	    protectedSource?: string;
+	    force: boolean;
+	    largeFiles: RepoFileSizeDTO[];
+	    largeFileThresholdBytes: number;
+	    violations: PushViolationDTO[];
+	    requiresConfirmation: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PushSafetyReportDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.remote = source["remote"];
+	        this.branch = source["branch"];
+	        this.defaultBranch = source["defaultBranch"];
+	        this.protectedBranch = source["protectedBranch"];
+	        this.protectedSource = source["protectedSource"];
+	        this.force = source["force"];
+	        this.largeFiles = this.convertValues(source["largeFiles"], RepoFileSizeDTO);
+	        this.largeFileThresholdBytes = source["largeFileThresholdBytes"];
+	        this.violations = this.convertValues(source["violations"], PushViolationDTO);
+	        this.requiresConfirmation = source["requiresConfirmation"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PushResultDTO {
+	    pushed: boolean;
+	    safety: PushSafetyReportDTO;
+	    output?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PushResultDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pushed = source["pushed"];
+	        this.safety = this.convertValues(source["safety"], PushSafetyReportDTO);
+	        this.output = source["output"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	export class StaleBranchDTO {
+	    name: string;
+	    remote: boolean;
+	    lastAuthor?: string;
+	    lastCommitAt: string;
+	    daysInactive: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StaleBranchDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.remote = source["remote"];
+	        this.lastAuthor = source["lastAuthor"];
+	        this.lastCommitAt = source["lastCommitAt"];
+	        this.daysInactive = source["daysInactive"];
+	    }
+	}
+	export class RepoStatsDTO {
+	    repoRoot: string;
+	    sizeBytes: number;
+	    objectCount: number;
+	    trackedFiles: number;
+	    largestFiles: RepoFileSizeDTO[];
+	    branchCount: number;
+	    remoteBranchCount: number;
+	    tagCount: number;
+	    staleDays: number;
+	    staleBranches: StaleBranchDTO[];
+	    totalCommits: number;
+	    contributorCount: number;
+	    contributors: ContributorDTO[];
+	    commitsByWeek: CommitBucketDTO[];
+	    commitsByWeekday: number[];
+	    commitsByHour: number[];
+	    partialClone: PartialCloneDTO;
+	    sparseCheckout: SparseCheckoutDTO;
+	    computedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RepoStatsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoRoot = source["repoRoot"];
+	        this.sizeBytes = source["sizeBytes"];
+	        this.objectCount = source["objectCount"];
+	        this.trackedFiles = source["trackedFiles"];
+	        this.largestFiles = this.convertValues(source["largestFiles"], RepoFileSizeDTO);
+	        this.branchCount = source["branchCount"];
+	        this.remoteBranchCount = source["remoteBranchCount"];
+	        this.tagCount = source["tagCount"];
+	        this.staleDays = source["staleDays"];
+	        this.staleBranches = this.convertValues(source["staleBranches"], StaleBranchDTO);
+	        this.totalCommits = source["totalCommits"];
+	        this.contributorCount = source["contributorCount"];
+	        this.contributors = this.convertValues(source["contributors"], ContributorDTO);
+	        this.commitsByWeek = this.convertValues(source["commitsByWeek"], CommitBucketDTO);
+	        this.commitsByWeekday = source["commitsByWeekday"];
+	        this.commitsByHour = source["commitsByHour"];
+	        this.partialClone = this.convertValues(source["partialClone"], PartialCloneDTO);
+	        this.sparseCheckout = this.convertValues(source["sparseCheckout"], SparseCheckoutDTO);
+	        this.computedAt = source["computedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class SparseCheckoutDTO {
+	    enabled: boolean;
+	    cone: boolean;
+	    directories?: string[];
+	    patterns?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SparseCheckoutDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.cone = source["cone"];
+	        this.directories = source["directories"];
+	        this.patterns = source["patterns"];
+	    }
+	}
+	
+	export class StatusDTO {
+	    branch: string;
+	    upstream?: string;
+	    upstreamGone: boolean;
+	    ahead: number;
+	    behind: number;
+	    detached: boolean;
+	    headCommit?: string;
+	    operation?: string;
+	    staged: FileChangeDTO[];
+	    unstaged: FileChangeDTO[];
+	    conflicted: ConflictFileDTO[];
+	    pathScope?: string;
+	    stagedCount: number;
+	    unstagedCount: number;
+	    conflictedCount: number;
+	    detail?: string;
+	    seq?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatusDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.branch = source["branch"];
+	        this.upstream = source["upstream"];
+	        this.upstreamGone = source["upstreamGone"];
+	        this.ahead = source["ahead"];
+	        this.behind = source["behind"];
+	        this.detached = source["detached"];
+	        this.headCommit = source["headCommit"];
+	        this.operation = source["operation"];
+	        this.staged = this.convertValues(source["staged"], FileChangeDTO);
+	        this.unstaged = this.convertValues(source["unstaged"], FileChangeDTO);
+	        this.conflicted = this.convertValues(source["conflicted"], ConflictFileDTO);
+	        this.pathScope = source["pathScope"];
+	        this.stagedCount = source["stagedCount"];
+	        this.unstagedCount = source["unstagedCount"];
+	        this.conflictedCount = source["conflictedCount"];
+	        this.detail = source["detail"];
+	        this.seq = source["seq"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace gitprs {
+	
+	export class CodeOwnersReport {
+	    source: string;
+	    files: FileOwners[];
+	    owners: string[];
+	    suggestedReviewers: string[];
+	    suggestedTeams: string[];
+	    unownedPaths: string[];
+	    invalidLines: number[];
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CodeOwnersReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.files = this.convertValues(source["files"], FileOwners);
+	        this.owners = source["owners"];
+	        this.suggestedReviewers = source["suggestedReviewers"];
+	        this.suggestedTeams = source["suggestedTeams"];
+	        this.unownedPaths = source["unownedPaths"];
+	        this.invalidLines = source["invalidLines"];
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FileOwners {
+	    path: string;
+	    owners: string[];
+	    line?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileOwners(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.owners = source["owners"];
+	        this.line = source["line"];
+	    }
+	}
+	export class Template {
+	    name: string;
+	    path: string;
+	    body: string;
+	    source: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Template(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.body = source["body"];
+	        this.source = source["source"];
+	    }
+	}
+
+}
+
+export namespace gitshare {
+	
+	export class Share {
+	    sessionId: string;
+	    repoRoot?: string;
+	    repoName: string;
+	    redactPatterns?: string[];
+	    // Go type: time
+	    sharedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Share(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.repoRoot = source["repoRoot"];
+	        this.repoName = source["repoName"];
+	        this.redactPatterns = source["redactPatterns"];
+	        this.sharedAt = this.convertValues(source["sharedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace hooks {
+	
+	export class Run {
+	    hookId: number;
+	    hookName: string;
+	    event: string;
+	    // Go type: time
+	    startedAt: any;
+	    durationMs: number;
+	    exitCode: number;
+	    timedOut?: boolean;
+	    output?: string;
+	    truncated?: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Run(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hookId = source["hookId"];
+	        this.hookName = source["hookName"];
+	        this.event = source["event"];
+	        this.startedAt = this.convertValues(source["startedAt"], null);
+	        this.durationMs = source["durationMs"];
+	        this.exitCode = source["exitCode"];
+	        this.timedOut = source["timedOut"];
+	        this.output = source["output"];
+	        this.truncated = source["truncated"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace httpclient {
+	
+	export class Settings {
+	    proxyMode: string;
+	    proxyURL?: string;
+	    noProxy?: string;
+	    caBundlePath?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Settings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.proxyMode = source["proxyMode"];
+	        this.proxyURL = source["proxyURL"];
+	        this.noProxy = source["noProxy"];
+	        this.caBundlePath = source["caBundlePath"];
+	    }
+	}
+	export class TraceEntry {
+	    seq: number;
+	    // Go type: time
+	    time: any;
+	    service: string;
+	    method: string;
+	    host?: string;
+	    path: string;
+	    status: number;
+	    durationMs: number;
+	    rateLimitCost: number;
+	    rateRemaining: number;
+	    cache?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seq = source["seq"];
+	        this.time = this.convertValues(source["time"], null);
+	        this.service = source["service"];
+	        this.method = source["method"];
+	        this.host = source["host"];
+	        this.path = source["path"];
+	        this.status = source["status"];
+	        this.durationMs = source["durationMs"];
+	        this.rateLimitCost = source["rateLimitCost"];
+	        this.rateRemaining = source["rateRemaining"];
+	        this.cache = source["cache"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace issuetracker {
+	
+	export class Issue {
+	    key: string;
+	    title: string;
+	    status: string;
+	    url: string;
+	    provider: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Issue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.title = source["title"];
+	        this.status = source["status"];
+	        this.url = source["url"];
+	        this.provider = source["provider"];
+	    }
+	}
+
+}
+
+export namespace jobs {
+	
+	export class Job {
+	    id: string;
+	    kind: string;
+	    module: string;
+	    title: string;
+	    target?: string;
+	    status: string;
+	    progress: number;
+	    message?: string;
+	    error?: string;
+	    result?: any;
+	    logs?: string[];
+	    logsTruncated?: boolean;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    finishedAt?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Job(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.module = source["module"];
+	        this.title = source["title"];
+	        this.target = source["target"];
+	        this.status = source["status"];
+	        this.progress = source["progress"];
+	        this.message = source["message"];
+	        this.error = source["error"];
+	        this.result = source["result"];
+	        this.logs = source["logs"];
+	        this.logsTruncated = source["logsTruncated"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.finishedAt = this.convertValues(source["finishedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace kube {
+	
+	export class ContainerStatus {
+	    name: string;
+	    ready: boolean;
+	    restartCount: number;
+	    state: string;
+	    reason?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.ready = source["ready"];
+	        this.restartCount = source["restartCount"];
+	        this.state = source["state"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class Context {
+	    name: string;
+	    cluster: string;
+	    user?: string;
+	    namespace?: string;
+	    isCurrent: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Context(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.cluster = source["cluster"];
+	        this.user = source["user"];
+	        this.namespace = source["namespace"];
+	        this.isCurrent = source["isCurrent"];
+	    }
+	}
+	export class Pod {
+	    name: string;
+	    namespace: string;
+	    phase: string;
+	    node?: string;
+	    containers: ContainerStatus[];
+	    crashed: boolean;
+	    createdAt?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Pod(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.namespace = source["namespace"];
+	        this.phase = source["phase"];
+	        this.node = source["node"];
+	        this.containers = this.convertValues(source["containers"], ContainerStatus);
+	        this.crashed = source["crashed"];
+	        this.createdAt = source["createdAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PodTarget {
+	    context: string;
+	    namespace: string;
+	    pod: string;
+	    container?: string;
+	    shell?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PodTarget(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.context = source["context"];
+	        this.namespace = source["namespace"];
+	        this.pod = source["pod"];
+	        this.container = source["container"];
+	        this.shell = source["shell"];
+	    }
+	}
+
+}
+
+export namespace logging {
+	
+	export class Entry {
+	    seq: number;
+	    // Go type: time
+	    time: any;
+	    level: string;
+	    component?: string;
+	    message: string;
+	    fields?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new Entry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seq = source["seq"];
+	        this.time = this.convertValues(source["time"], null);
+	        this.level = source["level"];
+	        this.component = source["component"];
+	        this.message = source["message"];
+	        this.fields = source["fields"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace main {
+	
+	export class AgentDependenciesDTO {
+	    dependsOn: number[];
+	    readyMode: string;
+	    readyRegex: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AgentDependenciesDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dependsOn = source["dependsOn"];
+	        this.readyMode = source["readyMode"];
+	        this.readyRegex = source["readyRegex"];
+	    }
+	}
+	export class AuditRetentionPolicy {
+	    retentionDays: number;
+	    maxRows: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuditRetentionPolicy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.retentionDays = source["retentionDays"];
+	        this.maxRows = source["maxRows"];
+	    }
+	}
+	export class ClipboardHistorySettingsDTO {
+	    enabled: boolean;
+	    watching: boolean;
+	    maxEntries: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClipboardHistorySettingsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.watching = source["watching"];
+	        this.maxEntries = source["maxEntries"];
+	    }
+	}
+	export class CoordinationStatus {
+	    instanceId: string;
+	    gatewayOwner: boolean;
+	    held: string[];
+	    leases: coordination.Lease[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CoordinationStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instanceId = source["instanceId"];
+	        this.gatewayOwner = source["gatewayOwner"];
+	        this.held = source["held"];
+	        this.leases = this.convertValues(source["leases"], coordination.Lease);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DeepLinkRequestDTO {
+	    id: string;
+	    summary: string;
+	    link: deeplink.Link;
+	    // Go type: time
+	    requestedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeepLinkRequestDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.summary = source["summary"];
+	        this.link = this.convertValues(source["link"], deeplink.Link);
+	        this.requestedAt = this.convertValues(source["requestedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GitExternalToolSettings {
+	    defaultMergeTool: string;
+	    defaultDiffTool: string;
+	    repoMergeTool: string;
+	    repoDiffTool: string;
+	    mergeTool: string;
+	    diffTool: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitExternalToolSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.defaultMergeTool = source["defaultMergeTool"];
+	        this.defaultDiffTool = source["defaultDiffTool"];
+	        this.repoMergeTool = source["repoMergeTool"];
+	        this.repoDiffTool = source["repoDiffTool"];
+	        this.mergeTool = source["mergeTool"];
+	        this.diffTool = source["diffTool"];
+	    }
+	}
+	export class GitHubAccountBindingDTO {
+	    repoPath: string;
+	    owner: string;
+	    accountId: string;
+	    source: string;
+	    login?: string;
+	    missing?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitHubAccountBindingDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoPath = source["repoPath"];
+	        this.owner = source["owner"];
+	        this.accountId = source["accountId"];
+	        this.source = source["source"];
+	        this.login = source["login"];
+	        this.missing = source["missing"];
+	    }
+	}
+	export class GitHubIssueFilterCountDTO {
+	    filterId: number;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitHubIssueFilterCountDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filterId = source["filterId"];
+	        this.count = source["count"];
+	    }
+	}
+	export class GitHubIssueFilterDTO {
+	    id: number;
+	    owner: string;
+	    repo: string;
+	    name: string;
+	    labels: string[];
+	    assignee?: string;
+	    state: string;
+	    query?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitHubIssueFilterDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.owner = source["owner"];
+	        this.repo = source["repo"];
+	        this.name = source["name"];
+	        this.labels = source["labels"];
+	        this.assignee = source["assignee"];
+	        this.state = source["state"];
+	        this.query = source["query"];
+	    }
+	}
+	export class GitPanelChangelogPublishDTO {
+	    writeFile: boolean;
+	    releaseDraft: boolean;
+	    tagName?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelChangelogPublishDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.writeFile = source["writeFile"];
+	        this.releaseDraft = source["releaseDraft"];
+	        this.tagName = source["tagName"];
+	    }
+	}
+	export class GitPanelChangelogPublishResultDTO {
+	    changelog: gitpanel.ChangelogDTO;
+	    file?: gitpanel.ChangelogFileResultDTO;
+	    release?: github.Release;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelChangelogPublishResultDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.changelog = this.convertValues(source["changelog"], gitpanel.ChangelogDTO);
+	        this.file = this.convertValues(source["file"], gitpanel.ChangelogFileResultDTO);
+	        this.release = this.convertValues(source["release"], github.Release);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GitPanelCoAuthorDTO {
+	    userId: string;
+	    name: string;
+	    login: string;
+	    email: string;
+	    trailer: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelCoAuthorDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.userId = source["userId"];
+	        this.name = source["name"];
+	        this.login = source["login"];
+	        this.email = source["email"];
+	        this.trailer = source["trailer"];
+	    }
+	}
+	export class GitPanelPRCreateLabelPayloadDTO {
+	    name: string;
+	    color: string;
+	    description?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelPRCreateLabelPayloadDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
 	        this.color = source["color"];
 	        this.description = source["description"];
 	    }
 	}
-	export class GitPanelPRCreatePayloadDTO {
-	    title: string;
-	    head: string;
-	    base: string;
-	    body?: string;
-	    draft?: boolean;
-	    maintainerCanModify?: boolean;
-	    manualOwner?: string;
-	    manualRepo?: string;
-	    allowTargetOverride?: boolean;
+	export class GitPanelPRCreatePayloadDTO {
+	    title: string;
+	    head: string;
+	    base: string;
+	    body?: string;
+	    draft?: boolean;
+	    maintainerCanModify?: boolean;
+	    manualOwner?: string;
+	    manualRepo?: string;
+	    allowTargetOverride?: boolean;
+	    headRemote?: string;
+	    reviewers?: string[];
+	    teamReviewers?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelPRCreatePayloadDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.head = source["head"];
+	        this.base = source["base"];
+	        this.body = source["body"];
+	        this.draft = source["draft"];
+	        this.maintainerCanModify = source["maintainerCanModify"];
+	        this.manualOwner = source["manualOwner"];
+	        this.manualRepo = source["manualRepo"];
+	        this.allowTargetOverride = source["allowTargetOverride"];
+	        this.headRemote = source["headRemote"];
+	        this.reviewers = source["reviewers"];
+	        this.teamReviewers = source["teamReviewers"];
+	    }
+	}
+	export class GitPanelPRMergePayloadDTO {
+	    mergeMethod?: string;
+	    sha?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelPRMergePayloadDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mergeMethod = source["mergeMethod"];
+	        this.sha = source["sha"];
+	    }
+	}
+	export class GitPanelPRMergeResultDTO {
+	    sha?: string;
+	    merged: boolean;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelPRMergeResultDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sha = source["sha"];
+	        this.merged = source["merged"];
+	        this.message = source["message"];
+	    }
+	}
+	export class GitPanelPRRemoteDTO {
+	    name: string;
+	    url: string;
+	    owner: string;
+	    repo: string;
+	    role: string;
+	    parentOwner?: string;
+	    parentRepo?: string;
+	    preferred?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelPRRemoteDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.url = source["url"];
+	        this.owner = source["owner"];
+	        this.repo = source["repo"];
+	        this.role = source["role"];
+	        this.parentOwner = source["parentOwner"];
+	        this.parentRepo = source["parentRepo"];
+	        this.preferred = source["preferred"];
+	    }
+	}
+	export class GitPanelPRRepositoryTargetDTO {
+	    repoPath: string;
+	    repoRoot: string;
+	    owner: string;
+	    repo: string;
+	    source: string;
+	    originOwner?: string;
+	    originRepo?: string;
+	    manualOwner?: string;
+	    manualRepo?: string;
+	    overrideConfirmed?: boolean;
+	    baseRemote?: string;
+	    remotes?: GitPanelPRRemoteDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelPRRepositoryTargetDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repoPath = source["repoPath"];
+	        this.repoRoot = source["repoRoot"];
+	        this.owner = source["owner"];
+	        this.repo = source["repo"];
+	        this.source = source["source"];
+	        this.originOwner = source["originOwner"];
+	        this.originRepo = source["originRepo"];
+	        this.manualOwner = source["manualOwner"];
+	        this.manualRepo = source["manualRepo"];
+	        this.overrideConfirmed = source["overrideConfirmed"];
+	        this.baseRemote = source["baseRemote"];
+	        this.remotes = this.convertValues(source["remotes"], GitPanelPRRemoteDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GitPanelPRUpdateBranchPayloadDTO {
+	    expectedHeadSha?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelPRUpdateBranchPayloadDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.expectedHeadSha = source["expectedHeadSha"];
+	    }
+	}
+	export class GitPanelPRUpdateBranchResultDTO {
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelPRUpdateBranchResultDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.message = source["message"];
+	    }
+	}
+	export class GitPanelPRUpdatePayloadDTO {
+	    title?: string;
+	    body?: string;
+	    state?: string;
+	    base?: string;
+	    maintainerCanModify?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelPRUpdatePayloadDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.body = source["body"];
+	        this.state = source["state"];
+	        this.base = source["base"];
+	        this.maintainerCanModify = source["maintainerCanModify"];
+	    }
+	}
+	export class GitPanelPathScopeDTO {
+	    workspaceId: number;
+	    repoRoot: string;
+	    pathScope: string;
+	    sparseCheckout: gitpanel.SparseCheckoutDTO;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitPanelPathScopeDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.workspaceId = source["workspaceId"];
+	        this.repoRoot = source["repoRoot"];
+	        this.pathScope = source["pathScope"];
+	        this.sparseCheckout = this.convertValues(source["sparseCheckout"], gitpanel.SparseCheckoutDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HydrationPayload {
+	    isAuthenticated: boolean;
+	    user?: auth.User;
+	    theme: string;
+	    language: string;
+	    defaultShell: string;
+	    terminalFontSize: number;
+	    terminalFontFamily: string;
+	    terminalCursorStyle: string;
+	    onboardingCompleted: boolean;
+	    shortcutBindings?: string;
+	    version: string;
+	    workspaces?: database.Workspace[];
+	
+	    static createFrom(source: any = {}) {
+	        return new HydrationPayload(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.isAuthenticated = source["isAuthenticated"];
+	        this.user = this.convertValues(source["user"], auth.User);
+	        this.theme = source["theme"];
+	        this.language = source["language"];
+	        this.defaultShell = source["defaultShell"];
+	        this.terminalFontSize = source["terminalFontSize"];
+	        this.terminalFontFamily = source["terminalFontFamily"];
+	        this.terminalCursorStyle = source["terminalCursorStyle"];
+	        this.onboardingCompleted = source["onboardingCompleted"];
+	        this.shortcutBindings = source["shortcutBindings"];
+	        this.version = source["version"];
+	        this.workspaces = this.convertValues(source["workspaces"], database.Workspace);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class IssueTrackerSettingsDTO {
+	    provider: string;
+	    baseUrl?: string;
+	    email?: string;
+	    projects?: string;
+	    token?: string;
+	    hasToken: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new IssueTrackerSettingsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.provider = source["provider"];
+	        this.baseUrl = source["baseUrl"];
+	        this.email = source["email"];
+	        this.projects = source["projects"];
+	        this.token = source["token"];
+	        this.hasToken = source["hasToken"];
+	    }
+	}
+	export class LifecycleHookDTO {
+	    id: number;
+	    name: string;
+	    event: string;
+	    script: string;
+	    cwd?: string;
+	    timeoutSeconds: number;
+	    enabled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LifecycleHookDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.event = source["event"];
+	        this.script = source["script"];
+	        this.cwd = source["cwd"];
+	        this.timeoutSeconds = source["timeoutSeconds"];
+	        this.enabled = source["enabled"];
+	    }
+	}
+	export class MCPApprovalRequestDTO {
+	    id: string;
+	    client: string;
+	    tool: string;
+	    arguments: string;
+	    // Go type: time
+	    requestedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new MCPApprovalRequestDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.client = source["client"];
+	        this.tool = source["tool"];
+	        this.arguments = source["arguments"];
+	        this.requestedAt = this.convertValues(source["requestedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MCPToolDTO {
+	    name: string;
+	    description: string;
+	    readOnly: boolean;
+	    policy: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MCPToolDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.readOnly = source["readOnly"];
+	        this.policy = source["policy"];
+	    }
+	}
+	export class MCPSettingsDTO {
+	    enabled: boolean;
+	    port: number;
+	    policies?: Record<string, string>;
+	    running: boolean;
+	    url?: string;
+	    token?: string;
+	    tools: MCPToolDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MCPSettingsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.port = source["port"];
+	        this.policies = source["policies"];
+	        this.running = source["running"];
+	        this.url = source["url"];
+	        this.token = source["token"];
+	        this.tools = this.convertValues(source["tools"], MCPToolDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class NotificationTargetDTO {
+	    id: number;
+	    name: string;
+	    kind: string;
+	    url?: string;
+	    urlMasked: string;
+	    events: string[];
+	    template?: string;
+	    enabled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new NotificationTargetDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.url = source["url"];
+	        this.urlMasked = source["urlMasked"];
+	        this.events = source["events"];
+	        this.template = source["template"];
+	        this.enabled = source["enabled"];
+	    }
+	}
+	export class ProblemMatcherDTO {
+	    id: number;
+	    name: string;
+	    pattern: string;
+	    fileGroup: number;
+	    lineGroup: number;
+	    columnGroup: number;
+	    severityGroup: number;
+	    messageGroup: number;
+	    severity: string;
+	    enabled: boolean;
+	    builtin: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProblemMatcherDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.pattern = source["pattern"];
+	        this.fileGroup = source["fileGroup"];
+	        this.lineGroup = source["lineGroup"];
+	        this.columnGroup = source["columnGroup"];
+	        this.severityGroup = source["severityGroup"];
+	        this.messageGroup = source["messageGroup"];
+	        this.severity = source["severity"];
+	        this.enabled = source["enabled"];
+	        this.builtin = source["builtin"];
+	    }
+	}
+	export class ProfileSwitchDTO {
+	    profile: config.Profile;
+	    restartRequired: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileSwitchDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profile = this.convertValues(source["profile"], config.Profile);
+	        this.restartRequired = source["restartRequired"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RESTAPISettingsDTO {
+	    enabled: boolean;
+	    port: number;
+	    allowWrite: boolean;
+	    running: boolean;
+	    url?: string;
+	    token?: string;
+	    routes: restapi.RouteInfo[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RESTAPISettingsDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.port = source["port"];
+	        this.allowWrite = source["allowWrite"];
+	        this.running = source["running"];
+	        this.url = source["url"];
+	        this.token = source["token"];
+	        this.routes = this.convertValues(source["routes"], restapi.RouteInfo);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SnippetDTO {
+	    id: number;
+	    workspaceId: number;
+	    name: string;
+	    description?: string;
+	    command: string;
+	    placeholders: snippets.Placeholder[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SnippetDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.workspaceId = source["workspaceId"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.command = source["command"];
+	        this.placeholders = this.convertValues(source["placeholders"], snippets.Placeholder);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StackBuildState {
+	    isBuilding: boolean;
+	    logs: string[];
+	    startTime: number;
+	    result: string;
+	    jobId?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StackBuildState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.isBuilding = source["isBuilding"];
+	        this.logs = source["logs"];
+	        this.startTime = source["startTime"];
+	        this.result = source["result"];
+	        this.jobId = source["jobId"];
+	    }
+	}
+	export class SubsystemHealth {
+	    name: string;
+	    status: string;
+	    message?: string;
+	    latencyMs: number;
+	    // Go type: time
+	    checkedAt: any;
+	    lastError?: string;
+	    // Go type: time
+	    lastErrorAt?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new SubsystemHealth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.message = source["message"];
+	        this.latencyMs = source["latencyMs"];
+	        this.checkedAt = this.convertValues(source["checkedAt"], null);
+	        this.lastError = source["lastError"];
+	        this.lastErrorAt = this.convertValues(source["lastErrorAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SystemHealth {
+	    status: string;
+	    // Go type: time
+	    checkedAt: any;
+	    subsystems: SubsystemHealth[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SystemHealth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.status = source["status"];
+	        this.checkedAt = this.convertValues(source["checkedAt"], null);
+	        this.subsystems = this.convertValues(source["subsystems"], SubsystemHealth);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TerminalSnapshotDTO {
+	    paneId: string;
+	    sessionId: string;
+	    paneTitle: string;
+	    paneType: string;
+	    shell: string;
+	    cwd: string;
+	    useDocker: boolean;
+	    config?: string;
+	    cliType?: string;
+	    replayCommands?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TerminalSnapshotDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.paneId = source["paneId"];
+	        this.sessionId = source["sessionId"];
+	        this.paneTitle = source["paneTitle"];
+	        this.paneType = source["paneType"];
+	        this.shell = source["shell"];
+	        this.cwd = source["cwd"];
+	        this.useDocker = source["useDocker"];
+	        this.config = source["config"];
+	        this.cliType = source["cliType"];
+	        this.replayCommands = source["replayCommands"];
+	    }
+	}
+	export class WorkspaceTaskDTO {
+	    id: string;
+	    source: string;
+	    kind?: string;
+	    title: string;
+	    author?: string;
+	    path?: string;
+	    line?: number;
+	    column?: number;
+	    done: boolean;
+	    // Go type: time
+	    doneAt?: any;
+	    // Go type: time
+	    createdAt?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkspaceTaskDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.source = source["source"];
+	        this.kind = source["kind"];
+	        this.title = source["title"];
+	        this.author = source["author"];
+	        this.path = source["path"];
+	        this.line = source["line"];
+	        this.column = source["column"];
+	        this.done = source["done"];
+	        this.doneAt = this.convertValues(source["doneAt"], null);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WorkspaceTaskListDTO {
+	    tasks: WorkspaceTaskDTO[];
+	    open: number;
+	    done: number;
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkspaceTaskListDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tasks = this.convertValues(source["tasks"], WorkspaceTaskDTO);
+	        this.open = source["open"];
+	        this.done = source["done"];
+	        this.truncated = source["truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WorkspaceTemplateAgentDTO {
+	    name: string;
+	    type?: string;
+	    shell?: string;
+	    cwd?: string;
+	    useDocker?: boolean;
+	    env?: string[];
+	    startupCommands?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkspaceTemplateAgentDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.shell = source["shell"];
+	        this.cwd = source["cwd"];
+	        this.useDocker = source["useDocker"];
+	        this.env = source["env"];
+	        this.startupCommands = source["startupCommands"];
+	    }
+	}
+	export class WorkspaceTemplateDTO {
+	    id: number;
+	    name: string;
+	    description?: string;
+	    path?: string;
+	    dockerImage?: string;
+	    env?: string[];
+	    agents: WorkspaceTemplateAgentDTO[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkspaceTemplateDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.path = source["path"];
+	        this.dockerImage = source["dockerImage"];
+	        this.env = source["env"];
+	        this.agents = this.convertValues(source["agents"], WorkspaceTemplateAgentDTO);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace notify {
+	
+	export class Delivery {
+	    targetId: number;
+	    targetName: string;
+	    event: string;
+	    // Go type: time
+	    sentAt: any;
+	    attempts: number;
+	    statusCode?: number;
+	    ok: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Delivery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.targetId = source["targetId"];
+	        this.targetName = source["targetName"];
+	        this.event = source["event"];
+	        this.sentAt = this.convertValues(source["sentAt"], null);
+	        this.attempts = source["attempts"];
+	        this.statusCode = source["statusCode"];
+	        this.ok = source["ok"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace problems {
+	
+	export class Problem {
+	    key: string;
+	    workspaceId: number;
+	    sessionId: string;
+	    source: string;
+	    file: string;
+	    line: number;
+	    column?: number;
+	    severity: string;
+	    message: string;
+	    // Go type: time
+	    seenAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Problem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.workspaceId = source["workspaceId"];
+	        this.sessionId = source["sessionId"];
+	        this.source = source["source"];
+	        this.file = source["file"];
+	        this.line = source["line"];
+	        this.column = source["column"];
+	        this.severity = source["severity"];
+	        this.message = source["message"];
+	        this.seenAt = this.convertValues(source["seenAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace restapi {
+	
+	export class RouteInfo {
+	    method: string;
+	    path: string;
+	    description: string;
+	    write: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RouteInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.method = source["method"];
+	        this.path = source["path"];
+	        this.description = source["description"];
+	        this.write = source["write"];
+	    }
+	}
+
+}
+
+export namespace session {
+	
+	export class Analytics {
+	    sessionId: string;
+	    // Go type: time
+	    startedAt: any;
+	    // Go type: time
+	    endedAt: any;
+	    ended: boolean;
+	    durationSeconds: number;
+	    guests: GuestAnalytics[];
+	    commandsExecuted: number;
+	    permissionChanges: number;
+	    dockerRestarts: number;
+	    failedJoins: number;
+	    networkRequests: number;
+	    timeline: TimelineEntry[];
+	    timelineTruncated: boolean;
+	    hygieneFlags: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Analytics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.startedAt = this.convertValues(source["startedAt"], null);
+	        this.endedAt = this.convertValues(source["endedAt"], null);
+	        this.ended = source["ended"];
+	        this.durationSeconds = source["durationSeconds"];
+	        this.guests = this.convertValues(source["guests"], GuestAnalytics);
+	        this.commandsExecuted = source["commandsExecuted"];
+	        this.permissionChanges = source["permissionChanges"];
+	        this.dockerRestarts = source["dockerRestarts"];
+	        this.failedJoins = source["failedJoins"];
+	        this.networkRequests = source["networkRequests"];
+	        this.timeline = this.convertValues(source["timeline"], TimelineEntry);
+	        this.timelineTruncated = source["timelineTruncated"];
+	        this.hygieneFlags = source["hygieneFlags"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GuestAnalytics {
+	    userId: string;
+	    name?: string;
+	    // Go type: time
+	    firstSeenAt: any;
+	    connectedSeconds: number;
+	    connections: number;
+	    commandsExecuted: number;
+	    commandsHeld: number;
+	    permissionChanges: number;
+	    finalPermission?: string;
+	    kicked: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GuestAnalytics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.userId = source["userId"];
+	        this.name = source["name"];
+	        this.firstSeenAt = this.convertValues(source["firstSeenAt"], null);
+	        this.connectedSeconds = source["connectedSeconds"];
+	        this.connections = source["connections"];
+	        this.commandsExecuted = source["commandsExecuted"];
+	        this.commandsHeld = source["commandsHeld"];
+	        this.permissionChanges = source["permissionChanges"];
+	        this.finalPermission = source["finalPermission"];
+	        this.kicked = source["kicked"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GuestRequest {
+	    userID: string;
+	    name: string;
+	    email?: string;
+	    avatarUrl?: string;
+	    // Go type: time
+	    requestAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new GuestRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.userID = source["userID"];
+	        this.name = source["name"];
+	        this.email = source["email"];
+	        this.avatarUrl = source["avatarUrl"];
+	        this.requestAt = this.convertValues(source["requestAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ICEServerConfig {
+	    urls: string[];
+	    username?: string;
+	    credential?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ICEServerConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.urls = source["urls"];
+	        this.username = source["username"];
+	        this.credential = source["credential"];
+	    }
+	}
+	export class JoinResult {
+	    sessionID: string;
+	    sessionCode: string;
+	    hostName: string;
+	    status: string;
+	    guestUserID: string;
+	    // Go type: time
+	    approvalExpiresAt?: any;
+	    workspaceName?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JoinResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionID = source["sessionID"];
+	        this.sessionCode = source["sessionCode"];
+	        this.hostName = source["hostName"];
+	        this.status = source["status"];
+	        this.guestUserID = source["guestUserID"];
+	        this.approvalExpiresAt = this.convertValues(source["approvalExpiresAt"], null);
+	        this.workspaceName = source["workspaceName"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JoinSecurityMetrics {
+	    invalidAttemptsTotal: number;
+	    invalidFormatAttemptsTotal: number;
+	    unknownCodeAttemptsTotal: number;
+	    missingSessionAttemptsTotal: number;
+	    blockedAttemptsTotal: number;
+	    lockoutsTotal: number;
+	    activeLocks: number;
+	    // Go type: time
+	    lastInvalidAttemptAt?: any;
+	    // Go type: time
+	    lastBlockedAt?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new JoinSecurityMetrics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.invalidAttemptsTotal = source["invalidAttemptsTotal"];
+	        this.invalidFormatAttemptsTotal = source["invalidFormatAttemptsTotal"];
+	        this.unknownCodeAttemptsTotal = source["unknownCodeAttemptsTotal"];
+	        this.missingSessionAttemptsTotal = source["missingSessionAttemptsTotal"];
+	        this.blockedAttemptsTotal = source["blockedAttemptsTotal"];
+	        this.lockoutsTotal = source["lockoutsTotal"];
+	        this.activeLocks = source["activeLocks"];
+	        this.lastInvalidAttemptAt = this.convertValues(source["lastInvalidAttemptAt"], null);
+	        this.lastBlockedAt = this.convertValues(source["lastBlockedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NetworkPolicy {
+	    mode: string;
+	    allowedHosts?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkPolicy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.allowedHosts = source["allowedHosts"];
+	    }
+	}
+	export class PolicyAction {
+	    type: string;
+	    sessionID: string;
+	    hostUserID: string;
+	    guestUserID?: string;
+	    // Go type: time
+	    deadlineAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new PolicyAction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.sessionID = source["sessionID"];
+	        this.hostUserID = source["hostUserID"];
+	        this.guestUserID = source["guestUserID"];
+	        this.deadlineAt = this.convertValues(source["deadlineAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SessionConfig {
+	    maxGuests: number;
+	    defaultPerm: string;
+	    allowAnonymous: boolean;
+	    mode: string;
+	    workspaceID: number;
+	    workspaceName?: string;
+	    dockerImage?: string;
+	    projectPath?: string;
+	    codeTTLMinutes: number;
+	    policy: TimeoutPolicy;
+	    securityProfile?: string;
+	    network: NetworkPolicy;
+	    requireCommandApproval: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.maxGuests = source["maxGuests"];
+	        this.defaultPerm = source["defaultPerm"];
+	        this.allowAnonymous = source["allowAnonymous"];
+	        this.mode = source["mode"];
+	        this.workspaceID = source["workspaceID"];
+	        this.workspaceName = source["workspaceName"];
+	        this.dockerImage = source["dockerImage"];
+	        this.projectPath = source["projectPath"];
+	        this.codeTTLMinutes = source["codeTTLMinutes"];
+	        this.policy = this.convertValues(source["policy"], TimeoutPolicy);
+	        this.securityProfile = source["securityProfile"];
+	        this.network = this.convertValues(source["network"], NetworkPolicy);
+	        this.requireCommandApproval = source["requireCommandApproval"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SessionGuest {
+	    userID: string;
+	    name: string;
+	    login?: string;
+	    avatarUrl?: string;
+	    permission: string;
+	    // Go type: time
+	    joinedAt: any;
+	    status: string;
+	    // Go type: time
+	    lastActivityAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionGuest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.userID = source["userID"];
+	        this.name = source["name"];
+	        this.login = source["login"];
+	        this.avatarUrl = source["avatarUrl"];
+	        this.permission = source["permission"];
+	        this.joinedAt = this.convertValues(source["joinedAt"], null);
+	        this.status = source["status"];
+	        this.lastActivityAt = this.convertValues(source["lastActivityAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Session {
+	    id: string;
+	    code: string;
+	    allowNewJoins: boolean;
+	    hostUserID: string;
+	    hostName: string;
+	    hostAvatarUrl?: string;
+	    status: string;
+	    mode: string;
+	    guests: SessionGuest[];
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    expiresAt: any;
+	    config: SessionConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new Session(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.code = source["code"];
+	        this.allowNewJoins = source["allowNewJoins"];
+	        this.hostUserID = source["hostUserID"];
+	        this.hostName = source["hostName"];
+	        this.hostAvatarUrl = source["hostAvatarUrl"];
+	        this.status = source["status"];
+	        this.mode = source["mode"];
+	        this.guests = this.convertValues(source["guests"], SessionGuest);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.expiresAt = this.convertValues(source["expiresAt"], null);
+	        this.config = this.convertValues(source["config"], SessionConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+
+	export class TimelineEntry {
+	    // Go type: time
+	    at: any;
+	    action: string;
+	    userId?: string;
+	    details?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimelineEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.at = this.convertValues(source["at"], null);
+	        this.action = source["action"];
+	        this.userId = source["userId"];
+	        this.details = source["details"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TimeoutPolicy {
+	    guestIdleMinutes: number;
+	    maxDurationMinutes: number;
+	    warnBeforeMinutes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeoutPolicy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.guestIdleMinutes = source["guestIdleMinutes"];
+	        this.maxDurationMinutes = source["maxDurationMinutes"];
+	        this.warnBeforeMinutes = source["warnBeforeMinutes"];
+	    }
+	}
+}
+
+export namespace sessionreview {
+	
+	export class Draft {
+	    id: string;
+	    sessionId: string;
+	    owner: string;
+	    repo: string;
+	    prNumber: number;
+	    author: Reviewer;
+	    body: string;
+	    path?: string;
+	    line?: number;
+	    side?: string;
+	    status: string;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    decidedAt?: any;
+	    rejectReason?: string;
+	    commentId?: string;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new GitPanelPRCreatePayloadDTO(source);
+	        return new Draft(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.title = source["title"];
-	        this.head = source["head"];
-	        this.base = source["base"];
+	        this.id = source["id"];
+	        this.sessionId = source["sessionId"];
+	        this.owner = source["owner"];
+	        this.repo = source["repo"];
+	        this.prNumber = source["prNumber"];
+	        this.author = this.convertValues(source["author"], Reviewer);
 	        this.body = source["body"];
-	        this.draft = source["draft"];
-	        this.maintainerCanModify = source["maintainerCanModify"];
-	        this.manualOwner = source["manualOwner"];
-	        this.manualRepo = source["manualRepo"];
-	        this.allowTargetOverride = source["allowTargetOverride"];
+	        this.path = source["path"];
+	        this.line = source["line"];
+	        this.side = source["side"];
+	        this.status = source["status"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.decidedAt = this.convertValues(source["decidedAt"], null);
+	        this.rejectReason = source["rejectReason"];
+	        this.commentId = source["commentId"];
+	        this.error = source["error"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class GitPanelPRMergePayloadDTO {
-	    mergeMethod?: string;
-	    sha?: string;
+	export class DraftInput {
+	    body: string;
+	    path?: string;
+	    line?: number;
+	    side?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new GitPanelPRMergePayloadDTO(source);
+	        return new DraftInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.mergeMethod = source["mergeMethod"];
-	        this.sha = source["sha"];
+	        this.body = source["body"];
+	        this.path = source["path"];
+	        this.line = source["line"];
+	        this.side = source["side"];
 	    }
 	}
-	export class GitPanelPRMergeResultDTO {
-	    sha?: string;
-	    merged: boolean;
-	    message: string;
+
+	export class Reviewer {
+	    userId: string;
+	    name: string;
+	    login?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new GitPanelPRMergeResultDTO(source);
+	        return new Reviewer(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sha = source["sha"];
-	        this.merged = source["merged"];
-	        this.message = source["message"];
+	        this.userId = source["userId"];
+	        this.name = source["name"];
+	        this.login = source["login"];
 	    }
 	}
-	export class GitPanelPRRepositoryTargetDTO {
-	    repoPath: string;
-	    repoRoot: string;
+	export class Target {
+	    sessionId: string;
 	    owner: string;
 	    repo: string;
-	    source: string;
-	    originOwner?: string;
-	    originRepo?: string;
-	    manualOwner?: string;
-	    manualRepo?: string;
-	    overrideConfirmed?: boolean;
+	    prNumber: number;
+	    title?: string;
+	    url?: string;
+	    // Go type: time
+	    startedAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new GitPanelPRRepositoryTargetDTO(source);
+	        return new Target(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.repoPath = source["repoPath"];
-	        this.repoRoot = source["repoRoot"];
+	        this.sessionId = source["sessionId"];
 	        this.owner = source["owner"];
 	        this.repo = source["repo"];
-	        this.source = source["source"];
-	        this.originOwner = source["originOwner"];
-	        this.originRepo = source["originRepo"];
-	        this.manualOwner = source["manualOwner"];
-	        this.manualRepo = source["manualRepo"];
-	        this.overrideConfirmed = source["overrideConfirmed"];
+	        this.prNumber = source["prNumber"];
+	        this.title = source["title"];
+	        this.url = source["url"];
+	        this.startedAt = this.convertValues(source["startedAt"], null);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class GitPanelPRUpdateBranchPayloadDTO {
-	    expectedHeadSha?: string;
+}
+
+export namespace snippets {
+	
+	export class Placeholder {
+	    name: string;
+	    default?: string;
+	    required: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new GitPanelPRUpdateBranchPayloadDTO(source);
+	        return new Placeholder(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.expectedHeadSha = source["expectedHeadSha"];
+	        this.name = source["name"];
+	        this.default = source["default"];
+	        this.required = source["required"];
 	    }
 	}
-	export class GitPanelPRUpdateBranchResultDTO {
-	    message: string;
+
+}
+
+export namespace startup {
+	
+	export class Phase {
+	    name: string;
+	    async: boolean;
+	    status: string;
+	    error?: string;
+	    // Go type: time
+	    startedAt: any;
+	    durationMs: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new GitPanelPRUpdateBranchResultDTO(source);
+	        return new Phase(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.message = source["message"];
+	        this.name = source["name"];
+	        this.async = source["async"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	        this.startedAt = this.convertValues(source["startedAt"], null);
+	        this.durationMs = source["durationMs"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class GitPanelPRUpdatePayloadDTO {
-	    title?: string;
-	    body?: string;
-	    state?: string;
-	    base?: string;
-	    maintainerCanModify?: boolean;
+
+	export class Timings {
+	    // Go type: time
+	    startedAt: any;
+	    windowReadyMs: number;
+	    phases: Phase[];
+	    pending: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new GitPanelPRUpdatePayloadDTO(source);
+	        return new Timings(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.title = source["title"];
-	        this.body = source["body"];
-	        this.state = source["state"];
-	        this.base = source["base"];
-	        this.maintainerCanModify = source["maintainerCanModify"];
+	        this.startedAt = this.convertValues(source["startedAt"], null);
+	        this.windowReadyMs = source["windowReadyMs"];
+	        this.phases = this.convertValues(source["phases"], Phase);
+	        this.pending = source["pending"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class HydrationPayload {
-	    isAuthenticated: boolean;
-	    user?: auth.User;
-	    theme: string;
-	    language: string;
-	    defaultShell: string;
-	    terminalFontSize: number;
-	    terminalFontFamily: string;
-	    terminalCursorStyle: string;
-	    onboardingCompleted: boolean;
-	    shortcutBindings?: string;
-	    version: string;
-	    workspaces?: database.Workspace[];
+}
+
+export namespace terminal {
+	
+	export class BroadcastGroup {
+	    id: string;
+	    name: string;
+	    members: BroadcastMember[];
+	    // Go type: time
+	    createdAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new HydrationPayload(source);
+	        return new BroadcastGroup(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.isAuthenticated = source["isAuthenticated"];
-	        this.user = this.convertValues(source["user"], auth.User);
-	        this.theme = source["theme"];
-	        this.language = source["language"];
-	        this.defaultShell = source["defaultShell"];
-	        this.terminalFontSize = source["terminalFontSize"];
-	        this.terminalFontFamily = source["terminalFontFamily"];
-	        this.terminalCursorStyle = source["terminalCursorStyle"];
-	        this.onboardingCompleted = source["onboardingCompleted"];
-	        this.shortcutBindings = source["shortcutBindings"];
-	        this.version = source["version"];
-	        this.workspaces = this.convertValues(source["workspaces"], database.Workspace);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.members = this.convertValues(source["members"], BroadcastMember);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1710,76 +8130,43 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class StackBuildState {
-	    isBuilding: boolean;
-	    logs: string[];
-	    startTime: number;
-	    result: string;
+	export class BroadcastMember {
+	    sessionID: string;
+	    enabled: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new StackBuildState(source);
+	        return new BroadcastMember(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.isBuilding = source["isBuilding"];
-	        this.logs = source["logs"];
-	        this.startTime = source["startTime"];
-	        this.result = source["result"];
+	        this.sessionID = source["sessionID"];
+	        this.enabled = source["enabled"];
 	    }
 	}
-	export class TerminalSnapshotDTO {
-	    paneId: string;
-	    sessionId: string;
-	    paneTitle: string;
-	    paneType: string;
+	export class SessionInfo {
+	    id: string;
 	    shell: string;
 	    cwd: string;
-	    useDocker: boolean;
-	    config?: string;
-	    cliType?: string;
+	    cols: number;
+	    rows: number;
+	    isAlive: boolean;
+	    // Go type: time
+	    createdAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new TerminalSnapshotDTO(source);
+	        return new SessionInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.paneId = source["paneId"];
-	        this.sessionId = source["sessionId"];
-	        this.paneTitle = source["paneTitle"];
-	        this.paneType = source["paneType"];
+	        this.id = source["id"];
 	        this.shell = source["shell"];
 	        this.cwd = source["cwd"];
-	        this.useDocker = source["useDocker"];
-	        this.config = source["config"];
-	        this.cliType = source["cliType"];
-	    }
-	}
-
-}
-
-export namespace session {
-	
-	export class GuestRequest {
-	    userID: string;
-	    name: string;
-	    email?: string;
-	    avatarUrl?: string;
-	    // Go type: time
-	    requestAt: any;
-	
-	    static createFrom(source: any = {}) {
-	        return new GuestRequest(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.userID = source["userID"];
-	        this.name = source["name"];
-	        this.email = source["email"];
-	        this.avatarUrl = source["avatarUrl"];
-	        this.requestAt = this.convertValues(source["requestAt"], null);
+	        this.cols = source["cols"];
+	        this.rows = source["rows"];
+	        this.isAlive = source["isAlive"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1800,45 +8187,43 @@ export namespace session {
 		    return a;
 		}
 	}
-	export class ICEServerConfig {
-	    urls: string[];
-	    username?: string;
-	    credential?: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new ICEServerConfig(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.urls = source["urls"];
-	        this.username = source["username"];
-	        this.credential = source["credential"];
-	    }
-	}
-	export class JoinResult {
-	    sessionID: string;
-	    sessionCode: string;
-	    hostName: string;
+
+	export class TestRun {
+	    sessionId: string;
+	    workspaceId?: number;
+	    agentId?: number;
+	    command: string;
+	    framework: string;
 	    status: string;
-	    guestUserID: string;
+	    summary?: string;
+	    passed: number;
+	    failed: number;
+	    skipped: number;
+	    failingTests?: string[];
 	    // Go type: time
-	    approvalExpiresAt?: any;
-	    workspaceName?: string;
+	    startedAt: any;
+	    // Go type: time
+	    finishedAt?: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new JoinResult(source);
+	        return new TestRun(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sessionID = source["sessionID"];
-	        this.sessionCode = source["sessionCode"];
-	        this.hostName = source["hostName"];
+	        this.sessionId = source["sessionId"];
+	        this.workspaceId = source["workspaceId"];
+	        this.agentId = source["agentId"];
+	        this.command = source["command"];
+	        this.framework = source["framework"];
 	        this.status = source["status"];
-	        this.guestUserID = source["guestUserID"];
-	        this.approvalExpiresAt = this.convertValues(source["approvalExpiresAt"], null);
-	        this.workspaceName = source["workspaceName"];
+	        this.summary = source["summary"];
+	        this.passed = source["passed"];
+	        this.failed = source["failed"];
+	        this.skipped = source["skipped"];
+	        this.failingTests = source["failingTests"];
+	        this.startedAt = this.convertValues(source["startedAt"], null);
+	        this.finishedAt = this.convertValues(source["finishedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1859,34 +8244,24 @@ export namespace session {
 		    return a;
 		}
 	}
-	export class JoinSecurityMetrics {
-	    invalidAttemptsTotal: number;
-	    invalidFormatAttemptsTotal: number;
-	    unknownCodeAttemptsTotal: number;
-	    missingSessionAttemptsTotal: number;
-	    blockedAttemptsTotal: number;
-	    lockoutsTotal: number;
-	    activeLocks: number;
-	    // Go type: time
-	    lastInvalidAttemptAt?: any;
-	    // Go type: time
-	    lastBlockedAt?: any;
+	export class Transcript {
+	    sessionId: string;
+	    format: string;
+	    text: string;
+	    entries: TranscriptEntry[];
+	    droppedEntries: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new JoinSecurityMetrics(source);
+	        return new Transcript(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.invalidAttemptsTotal = source["invalidAttemptsTotal"];
-	        this.invalidFormatAttemptsTotal = source["invalidFormatAttemptsTotal"];
-	        this.unknownCodeAttemptsTotal = source["unknownCodeAttemptsTotal"];
-	        this.missingSessionAttemptsTotal = source["missingSessionAttemptsTotal"];
-	        this.blockedAttemptsTotal = source["blockedAttemptsTotal"];
-	        this.lockoutsTotal = source["lockoutsTotal"];
-	        this.activeLocks = source["activeLocks"];
-	        this.lastInvalidAttemptAt = this.convertValues(source["lastInvalidAttemptAt"], null);
-	        this.lastBlockedAt = this.convertValues(source["lastBlockedAt"], null);
+	        this.sessionId = source["sessionId"];
+	        this.format = source["format"];
+	        this.text = source["text"];
+	        this.entries = this.convertValues(source["entries"], TranscriptEntry);
+	        this.droppedEntries = source["droppedEntries"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1907,55 +8282,25 @@ export namespace session {
 		    return a;
 		}
 	}
-	export class SessionConfig {
-	    maxGuests: number;
-	    defaultPerm: string;
-	    allowAnonymous: boolean;
-	    mode: string;
-	    workspaceID: number;
-	    workspaceName?: string;
-	    dockerImage?: string;
-	    projectPath?: string;
-	    codeTTLMinutes: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new SessionConfig(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.maxGuests = source["maxGuests"];
-	        this.defaultPerm = source["defaultPerm"];
-	        this.allowAnonymous = source["allowAnonymous"];
-	        this.mode = source["mode"];
-	        this.workspaceID = source["workspaceID"];
-	        this.workspaceName = source["workspaceName"];
-	        this.dockerImage = source["dockerImage"];
-	        this.projectPath = source["projectPath"];
-	        this.codeTTLMinutes = source["codeTTLMinutes"];
-	    }
-	}
-	export class SessionGuest {
-	    userID: string;
-	    name: string;
-	    avatarUrl?: string;
-	    permission: string;
+	export class TranscriptEntry {
+	    index: number;
+	    command?: string;
 	    // Go type: time
-	    joinedAt: any;
-	    status: string;
+	    startedAt: any;
+	    output: string;
+	    truncated: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new SessionGuest(source);
+	        return new TranscriptEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.userID = source["userID"];
-	        this.name = source["name"];
-	        this.avatarUrl = source["avatarUrl"];
-	        this.permission = source["permission"];
-	        this.joinedAt = this.convertValues(source["joinedAt"], null);
-	        this.status = source["status"];
+	        this.index = source["index"];
+	        this.command = source["command"];
+	        this.startedAt = this.convertValues(source["startedAt"], null);
+	        this.output = source["output"];
+	        this.truncated = source["truncated"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1976,40 +8321,60 @@ export namespace session {
 		    return a;
 		}
 	}
-	export class Session {
-	    id: string;
-	    code: string;
-	    allowNewJoins: boolean;
-	    hostUserID: string;
-	    hostName: string;
-	    hostAvatarUrl?: string;
-	    status: string;
-	    mode: string;
-	    guests: SessionGuest[];
+}
+
+export namespace updater {
+	
+	export class InstallResult {
+	    version: string;
+	    installedPath: string;
+	    restartRequired: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new InstallResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.installedPath = source["installedPath"];
+	        this.restartRequired = source["restartRequired"];
+	    }
+	}
+	export class UpdateInfo {
+	    currentVersion: string;
+	    latestVersion?: string;
+	    channel: string;
+	    available: boolean;
+	    releaseName?: string;
+	    releaseNotes?: string;
+	    releaseUrl?: string;
+	    prerelease: boolean;
 	    // Go type: time
-	    createdAt: any;
+	    publishedAt?: any;
+	    assetName?: string;
+	    assetSize?: number;
 	    // Go type: time
-	    expiresAt: any;
-	    config: SessionConfig;
+	    checkedAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new Session(source);
+	        return new UpdateInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.code = source["code"];
-	        this.allowNewJoins = source["allowNewJoins"];
-	        this.hostUserID = source["hostUserID"];
-	        this.hostName = source["hostName"];
-	        this.hostAvatarUrl = source["hostAvatarUrl"];
-	        this.status = source["status"];
-	        this.mode = source["mode"];
-	        this.guests = this.convertValues(source["guests"], SessionGuest);
-	        this.createdAt = this.convertValues(source["createdAt"], null);
-	        this.expiresAt = this.convertValues(source["expiresAt"], null);
-	        this.config = this.convertValues(source["config"], SessionConfig);
+	        this.currentVersion = source["currentVersion"];
+	        this.latestVersion = source["latestVersion"];
+	        this.channel = source["channel"];
+	        this.available = source["available"];
+	        this.releaseName = source["releaseName"];
+	        this.releaseNotes = source["releaseNotes"];
+	        this.releaseUrl = source["releaseUrl"];
+	        this.prerelease = source["prerelease"];
+	        this.publishedAt = this.convertValues(source["publishedAt"], null);
+	        this.assetName = source["assetName"];
+	        this.assetSize = source["assetSize"];
+	        this.checkedAt = this.convertValues(source["checkedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -2030,35 +8395,40 @@ export namespace session {
 		    return a;
 		}
 	}
-	
 
 }
 
-export namespace terminal {
+export namespace workspacefs {
 	
-	export class SessionInfo {
-	    id: string;
-	    shell: string;
-	    cwd: string;
-	    cols: number;
-	    rows: number;
-	    isAlive: boolean;
+	export class FileContent {
+	    path: string;
+	    content: string;
+	    hash: string;
+	    size: number;
+	    binary: boolean;
+	    startLine: number;
+	    endLine: number;
+	    totalLines: number;
+	    lineEnding: string;
 	    // Go type: time
-	    createdAt: any;
+	    modTime: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new SessionInfo(source);
+	        return new FileContent(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.shell = source["shell"];
-	        this.cwd = source["cwd"];
-	        this.cols = source["cols"];
-	        this.rows = source["rows"];
-	        this.isAlive = source["isAlive"];
-	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.path = source["path"];
+	        this.content = source["content"];
+	        this.hash = source["hash"];
+	        this.size = source["size"];
+	        this.binary = source["binary"];
+	        this.startLine = source["startLine"];
+	        this.endLine = source["endLine"];
+	        this.totalLines = source["totalLines"];
+	        this.lineEnding = source["lineEnding"];
+	        this.modTime = this.convertValues(source["modTime"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -2079,6 +8449,38 @@ export namespace terminal {
 		    return a;
 		}
 	}
+	export class ReadRange {
+	    startLine: number;
+	    endLine: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReadRange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.startLine = source["startLine"];
+	        this.endLine = source["endLine"];
+	    }
+	}
+	export class WriteResult {
+	    path: string;
+	    hash: string;
+	    size: number;
+	    created: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WriteResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.hash = source["hash"];
+	        this.size = source["size"];
+	        this.created = source["created"];
+	    }
+	}
 
 }
 
@@ -0,0 +1,116 @@
+package agentgraph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Modos de prontidão de um agente.
+const (
+	ReadyOnStart   = "started" // pronto assim que o terminal sobe
+	ReadyOnPattern = "pattern" // pronto quando a saída casa com o regex
+	ReadyOnExit    = "exit"    // pronto quando os startup commands terminam com código 0
+)
+
+// Node é um agente no grafo de inicialização do workspace.
+type Node struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	DependsOn []uint `json:"dependsOn,omitempty"`
+	ReadyMode string `json:"readyMode,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+}
+
+// NormalizeReadyMode valida o modo; vazio equivale a ReadyOnStart.
+func NormalizeReadyMode(mode string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", ReadyOnStart:
+		return ReadyOnStart, nil
+	case ReadyOnPattern:
+		return ReadyOnPattern, nil
+	case ReadyOnExit:
+		return ReadyOnExit, nil
+	default:
+		return "", fmt.Errorf("unsupported readiness mode: %s", mode)
+	}
+}
+
+// Levels valida o grafo e o agrupa em níveis: cada nível depende só dos anteriores.
+// Dependências fora do conjunto de nós e ciclos são erro.
+func Levels(nodes []Node) ([][]Node, error) {
+	byID := make(map[uint]Node, len(nodes))
+	for _, node := range nodes {
+		if _, dup := byID[node.ID]; dup {
+			return nil, fmt.Errorf("duplicate agent %d in startup graph", node.ID)
+		}
+		byID[node.ID] = node
+	}
+
+	pending := make(map[uint]int, len(nodes)) // id -> dependências ainda não resolvidas
+	dependents := make(map[uint][]uint, len(nodes))
+	for _, node := range nodes {
+		for _, dep := range uniqueIDs(node.DependsOn) {
+			if dep == node.ID {
+				return nil, fmt.Errorf("agent %d cannot depend on itself", node.ID)
+			}
+			if _, ok := byID[dep]; !ok {
+				return nil, fmt.Errorf("agent %d depends on unknown agent %d", node.ID, dep)
+			}
+			pending[node.ID]++
+			dependents[dep] = append(dependents[dep], node.ID)
+		}
+	}
+
+	var levels [][]Node
+	current := make([]uint, 0, len(nodes))
+	for _, node := range nodes {
+		if pending[node.ID] == 0 {
+			current = append(current, node.ID)
+		}
+	}
+	placed := 0
+	for len(current) > 0 {
+		sort.Slice(current, func(i, j int) bool { return current[i] < current[j] })
+		level := make([]Node, 0, len(current))
+		next := make([]uint, 0)
+		for _, id := range current {
+			level = append(level, byID[id])
+			for _, dependent := range dependents[id] {
+				pending[dependent]--
+				if pending[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		levels = append(levels, level)
+		placed += len(level)
+		current = next
+	}
+	if placed != len(nodes) {
+		cycle := make([]string, 0)
+		for _, node := range nodes {
+			if pending[node.ID] > 0 {
+				cycle = append(cycle, fmt.Sprintf("%d", node.ID))
+			}
+		}
+		return nil, fmt.Errorf("dependency cycle between agents %s", strings.Join(cycle, ", "))
+	}
+	return levels, nil
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]struct{}, len(ids))
+	out := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
@@ -0,0 +1,137 @@
+package agentgraph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Estados reportados por agente durante a inicialização ordenada.
+const (
+	StatePending  = "pending"
+	StateStarting = "starting"
+	StateWaiting  = "waiting" // terminal no ar, aguardando prontidão
+	StateReady    = "ready"
+	StateFailed   = "failed"
+	StateSkipped  = "skipped" // alguma dependência não ficou pronta
+)
+
+// DefaultReadyTimeout é quanto um agente tem para ficar pronto depois de subir.
+const DefaultReadyTimeout = 5 * time.Minute
+
+// Event descreve a mudança de estado de um agente.
+type Event struct {
+	AgentID   uint   `json:"agentId"`
+	Name      string `json:"name,omitempty"`
+	State     string `json:"state"`
+	SessionID string `json:"sessionId,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// StartFunc sobe o terminal do agente e devolve o probe que decide a prontidão.
+type StartFunc func(ctx context.Context, node Node) (sessionID string, probe *Probe, err error)
+
+// Run sobe os agentes respeitando as dependências: cada um só inicia depois que todas as
+// dependências ficarem prontas; independentes sobem em paralelo. Retorna o estado final de
+// cada agente, na ordem dos níveis.
+func Run(ctx context.Context, nodes []Node, start StartFunc, emit func(Event), readyTimeout time.Duration) ([]Event, error) {
+	levels, err := Levels(nodes)
+	if err != nil {
+		return nil, err
+	}
+	if readyTimeout <= 0 {
+		readyTimeout = DefaultReadyTimeout
+	}
+	if emit == nil {
+		emit = func(Event) {}
+	}
+
+	type outcome struct {
+		done  chan struct{}
+		event Event
+	}
+	outcomes := make(map[uint]*outcome, len(nodes))
+	for _, level := range levels {
+		for _, node := range level {
+			outcomes[node.ID] = &outcome{done: make(chan struct{})}
+			emit(Event{AgentID: node.ID, Name: node.Name, State: StatePending})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, level := range levels {
+		for _, node := range level {
+			wg.Add(1)
+			go func(node Node) {
+				defer wg.Done()
+				result := &outcomes[node.ID].event
+				defer close(outcomes[node.ID].done)
+
+				*result = runNode(ctx, node, start, emit, readyTimeout, func(dep uint) (Event, bool) {
+					select {
+					case <-outcomes[dep].done:
+						return outcomes[dep].event, true
+					case <-ctx.Done():
+						return Event{}, false
+					}
+				})
+				emit(*result)
+			}(node)
+		}
+	}
+	wg.Wait()
+
+	final := make([]Event, 0, len(nodes))
+	for _, level := range levels {
+		for _, node := range level {
+			final = append(final, outcomes[node.ID].event)
+		}
+	}
+	return final, ctx.Err()
+}
+
+func runNode(ctx context.Context, node Node, start StartFunc, emit func(Event), readyTimeout time.Duration, waitDep func(uint) (Event, bool)) Event {
+	base := Event{AgentID: node.ID, Name: node.Name}
+	for _, dep := range uniqueIDs(node.DependsOn) {
+		depEvent, ok := waitDep(dep)
+		if !ok {
+			return withState(base, StateSkipped, "cancelled")
+		}
+		if depEvent.State != StateReady {
+			return withState(base, StateSkipped, fmt.Sprintf("dependency %d is %s", dep, depEvent.State))
+		}
+	}
+	if ctx.Err() != nil {
+		return withState(base, StateSkipped, "cancelled")
+	}
+
+	emit(withState(base, StateStarting, ""))
+	sessionID, probe, err := start(ctx, node)
+	if err != nil {
+		return withState(base, StateFailed, err.Error())
+	}
+	base.SessionID = sessionID
+	emit(withState(base, StateWaiting, ""))
+
+	timer := time.NewTimer(readyTimeout)
+	defer timer.Stop()
+	select {
+	case <-probe.Done():
+	case <-timer.C:
+		probe.Fail(fmt.Sprintf("not ready after %s", readyTimeout))
+	case <-ctx.Done():
+		probe.Fail("cancelled")
+	}
+	ready, detail := probe.Result()
+	if !ready {
+		return withState(base, StateFailed, detail)
+	}
+	return withState(base, StateReady, detail)
+}
+
+func withState(event Event, state string, detail string) Event {
+	event.State = state
+	event.Detail = detail
+	return event
+}
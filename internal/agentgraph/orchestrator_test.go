@@ -0,0 +1,140 @@
+package agentgraph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLevelsOrdersDependenciesAndRejectsCycles(t *testing.T) {
+	levels, err := Levels([]Node{
+		{ID: 3, Name: "frontend", DependsOn: []uint{2}},
+		{ID: 2, Name: "api", DependsOn: []uint{1, 1}},
+		{ID: 1, Name: "db"},
+		{ID: 4, Name: "docs"},
+	})
+	if err != nil {
+		t.Fatalf("Levels returned error: %v", err)
+	}
+	got := make([][]uint, 0, len(levels))
+	for _, level := range levels {
+		ids := make([]uint, 0, len(level))
+		for _, node := range level {
+			ids = append(ids, node.ID)
+		}
+		got = append(got, ids)
+	}
+	if fmt.Sprint(got) != "[[1 4] [2] [3]]" {
+		t.Fatalf("unexpected levels: %v", got)
+	}
+
+	if _, err := Levels([]Node{{ID: 1, DependsOn: []uint{2}}, {ID: 2, DependsOn: []uint{1}}}); err == nil {
+		t.Fatalf("expected cycle error")
+	}
+	if _, err := Levels([]Node{{ID: 1, DependsOn: []uint{9}}}); err == nil {
+		t.Fatalf("expected unknown dependency error")
+	}
+}
+
+func TestProbeDetectsPatternAndExitMarker(t *testing.T) {
+	pattern, err := NewProbe(ReadyOnPattern, `listening on :\d+`)
+	if err != nil {
+		t.Fatalf("NewProbe returned error: %v", err)
+	}
+	pattern.Feed([]byte("booting...\r\n\x1b[32mlistening on"))
+	pattern.Feed([]byte(" :5432\r\n"))
+	<-pattern.Done()
+	if ready, detail := pattern.Result(); !ready || detail != `matched "listening on :5432"` {
+		t.Fatalf("unexpected pattern result: %v %q", ready, detail)
+	}
+
+	exit, _ := NewProbe(ReadyOnExit, "")
+	exit.Feed([]byte(ExitMarkerCommand + "\r\n"))
+	select {
+	case <-exit.Done():
+		t.Fatalf("echo of the marker command must not decide readiness")
+	default:
+	}
+	exit.Feed([]byte("\r\n__ORCH_EXIT_2__\r\n"))
+	<-exit.Done()
+	if ready, detail := exit.Result(); ready || detail != "exited 2" {
+		t.Fatalf("unexpected exit result: %v %q", ready, detail)
+	}
+
+	if _, err := NewProbe(ReadyOnPattern, "("); err == nil {
+		t.Fatalf("expected invalid regex error")
+	}
+}
+
+func TestRunStartsAgentsAfterDependenciesAreReady(t *testing.T) {
+	var mu sync.Mutex
+	var started []uint
+	probes := make(map[uint]*Probe)
+
+	start := func(_ context.Context, node Node) (string, *Probe, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		started = append(started, node.ID)
+		if node.ID == 4 {
+			return "", nil, fmt.Errorf("boom")
+		}
+		probe, err := NewProbe(node.ReadyMode, node.Pattern)
+		probes[node.ID] = probe
+		return fmt.Sprintf("session-%d", node.ID), probe, err
+	}
+	// db fica pronto pela saída, um pouco depois de subir.
+	go func() {
+		for {
+			mu.Lock()
+			probe := probes[1]
+			mu.Unlock()
+			if probe != nil {
+				probe.Feed([]byte("database system is ready to accept connections\n"))
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	var events []Event
+	var eventsMu sync.Mutex
+	final, err := Run(context.Background(), []Node{
+		{ID: 1, Name: "db", ReadyMode: ReadyOnPattern, Pattern: "ready to accept connections"},
+		{ID: 2, Name: "api", DependsOn: []uint{1}},
+		{ID: 3, Name: "frontend", DependsOn: []uint{2}},
+		{ID: 4, Name: "worker"},
+		{ID: 5, Name: "jobs", DependsOn: []uint{4}},
+	}, start, func(event Event) {
+		eventsMu.Lock()
+		events = append(events, event)
+		eventsMu.Unlock()
+	}, time.Second)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	states := make(map[uint]string, len(final))
+	for _, event := range final {
+		states[event.AgentID] = event.State
+	}
+	want := map[uint]string{1: StateReady, 2: StateReady, 3: StateReady, 4: StateFailed, 5: StateSkipped}
+	if fmt.Sprint(states) != fmt.Sprint(want) {
+		t.Fatalf("unexpected final states: %v", states)
+	}
+
+	order := make(map[uint]int, len(started))
+	for i, id := range started {
+		order[id] = i
+	}
+	if _, ok := order[5]; ok {
+		t.Fatalf("agent with failed dependency must not start")
+	}
+	if order[1] > order[2] || order[2] > order[3] {
+		t.Fatalf("agents started out of dependency order: %v", started)
+	}
+	if len(events) < len(final)*2 {
+		t.Fatalf("expected pending and final events for each agent, got %d", len(events))
+	}
+}
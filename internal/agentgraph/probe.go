@@ -0,0 +1,123 @@
+package agentgraph
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ExitMarkerCommand é digitado após os startup commands no modo ReadyOnExit; imprime o
+// código de saída do último comando. O eco do próprio comando não casa com exitMarkerRegex
+// porque contém %s no lugar dos dígitos. Requer shell POSIX ($?).
+const ExitMarkerCommand = `printf '\n__ORCH_EXIT_%s__\n' "$?"`
+
+// probeTailSize limita o texto guardado entre chunks (padrões que cruzam a fronteira).
+const probeTailSize = 4096
+
+var (
+	exitMarkerRegex = regexp.MustCompile(`__ORCH_EXIT_(\d+)__`)
+	ansiRegex       = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]|\x1b\][^\x07]*\x07`)
+)
+
+// Probe observa a saída de um terminal até decidir se o agente ficou pronto.
+type Probe struct {
+	mode    string
+	pattern *regexp.Regexp
+
+	mu     sync.Mutex
+	tail   string
+	done   chan struct{}
+	closed bool
+	ready  bool
+	detail string
+}
+
+// NewProbe cria o probe do modo informado. ReadyOnStart já nasce pronto.
+func NewProbe(mode string, pattern string) (*Probe, error) {
+	normalized, err := NormalizeReadyMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	p := &Probe{mode: normalized, done: make(chan struct{})}
+	switch normalized {
+	case ReadyOnPattern:
+		if strings.TrimSpace(pattern) == "" {
+			return nil, fmt.Errorf("readiness pattern cannot be empty")
+		}
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid readiness pattern: %w", err)
+		}
+		p.pattern = compiled
+	case ReadyOnStart:
+		p.finish(true, "terminal started")
+	}
+	return p, nil
+}
+
+// Feed entrega um chunk de saída do terminal.
+func (p *Probe) Feed(data []byte) {
+	if p == nil || len(data) == 0 {
+		return
+	}
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	text := p.tail + ansiRegex.ReplaceAllString(string(data), "")
+	if len(text) > probeTailSize {
+		text = text[len(text)-probeTailSize:]
+	}
+	p.tail = text
+	p.mu.Unlock()
+
+	switch p.mode {
+	case ReadyOnPattern:
+		if match := p.pattern.FindString(text); match != "" {
+			p.finish(true, "matched "+strconv.Quote(strings.TrimSpace(match)))
+		}
+	case ReadyOnExit:
+		if groups := exitMarkerRegex.FindStringSubmatch(text); groups != nil {
+			if groups[1] == "0" {
+				p.finish(true, "exited 0")
+			} else {
+				p.finish(false, "exited "+groups[1])
+			}
+		}
+	}
+}
+
+// Fail encerra o probe sem prontidão (terminal morreu, timeout, cancelamento).
+func (p *Probe) Fail(detail string) {
+	if p != nil {
+		p.finish(false, detail)
+	}
+}
+
+// Done fecha quando o probe decide.
+func (p *Probe) Done() <-chan struct{} {
+	return p.done
+}
+
+// Result retorna a decisão e o motivo; válido após Done.
+func (p *Probe) Result() (bool, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ready, p.detail
+}
+
+func (p *Probe) finish(ready bool, detail string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	p.ready = ready
+	p.detail = detail
+	p.tail = ""
+	close(p.done)
+}
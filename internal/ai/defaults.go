@@ -0,0 +1,70 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Defaults são os padrões de IA vindos do arquivo de configuração (campos vazios mantêm o atual).
+type Defaults struct {
+	Provider       string // provider ativo: openai | gemini | ollama
+	OpenAIModel    string
+	GeminiModel    string
+	OllamaModel    string
+	OllamaEndpoint string
+}
+
+// ApplyDefaults atualiza modelos/endpoint dos providers e o provider ativo.
+// Providers sem chave de API continuam desabilitados; só o modelo registrado muda.
+func (s *Service) ApplyDefaults(defaults Defaults) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	overrides := map[string]AIProvider{
+		"openai": {Model: defaults.OpenAIModel},
+		"gemini": {Model: defaults.GeminiModel},
+		"ollama": {Model: defaults.OllamaModel, Endpoint: defaults.OllamaEndpoint},
+	}
+	for id, override := range overrides {
+		reg, ok := s.providers[id]
+		if !ok {
+			continue
+		}
+		meta := reg.meta
+		changed := false
+		if model := strings.TrimSpace(override.Model); model != "" && model != meta.Model {
+			meta.Model = model
+			changed = true
+		}
+		if endpoint := strings.TrimSpace(override.Endpoint); endpoint != "" && endpoint != meta.Endpoint {
+			meta.Endpoint = endpoint
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		client := reg.client
+		if client != nil {
+			rebuilt, err := newProviderClient(id, meta)
+			if err != nil {
+				return fmt.Errorf("provider %q: %w", id, err)
+			}
+			client = rebuilt
+		}
+		s.providers[id] = providerRegistration{meta: meta, client: client}
+	}
+
+	provider := strings.ToLower(strings.TrimSpace(defaults.Provider))
+	if provider == "" {
+		return nil
+	}
+	reg, ok := s.providers[provider]
+	if !ok {
+		return fmt.Errorf("provider %q não suportado", provider)
+	}
+	if reg.client == nil {
+		return fmt.Errorf("provider %q não está configurado (chave de API ausente)", provider)
+	}
+	s.activeProvider = provider
+	return nil
+}
@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// providerPinger é implementado pelos providers que conseguem validar conectividade sem gerar texto.
+type providerPinger interface {
+	Ping(ctx context.Context) error
+}
+
+// CheckActiveProvider verifica se o provider ativo responde. Retorna o provider sem a chave de API.
+func (s *Service) CheckActiveProvider(ctx context.Context) (AIProvider, error) {
+	meta, client, err := s.getActiveProvider()
+	meta.APIKey = ""
+	if err != nil {
+		return meta, err
+	}
+	pinger, ok := client.(providerPinger)
+	if !ok {
+		return meta, nil
+	}
+	return meta, pinger.Ping(ctx)
+}
+
+func (p *openAIProvider) Ping(ctx context.Context) error {
+	_, err := p.client.GetModel(ctx, p.model)
+	return err
+}
+
+func (p *ollamaProvider) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"/api/tags", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("ollama returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (p *geminiProvider) Ping(ctx context.Context) error {
+	if p.client == nil {
+		return fmt.Errorf("gemini client is nil")
+	}
+	_, err := p.client.Models.Get(ctx, p.model, nil)
+	return err
+}
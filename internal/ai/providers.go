@@ -11,6 +11,8 @@ import (
 	"net/http"
 	"strings"
 
+	"orch/internal/httpclient"
+
 	openai "github.com/sashabaranov/go-openai"
 	"google.golang.org/genai"
 )
@@ -32,11 +34,18 @@ func newOpenAIProvider(apiKey, model string) (providerClient, error) {
 		model = "gpt-4.1-mini"
 	}
 	return &openAIProvider{
-		client: openai.NewClient(apiKey),
+		client: openai.NewClientWithConfig(openAIConfig(apiKey)),
 		model:  model,
 	}, nil
 }
 
+// openAIConfig usa o client HTTP compartilhado (proxy/CA das configurações).
+func openAIConfig(apiKey string) openai.ClientConfig {
+	config := openai.DefaultConfig(apiKey)
+	config.HTTPClient = httpclient.Client(httpclient.ServiceAI)
+	return config
+}
+
 func (p *openAIProvider) Stream(ctx context.Context, prompt string, out chan<- string) error {
 	req := openai.ChatCompletionRequest{
 		Model: p.model,
@@ -91,9 +100,7 @@ func newOllamaProvider(endpoint, model string) providerClient {
 		model = "llama3"
 	}
 	return &ollamaProvider{
-		client: &http.Client{
-			Timeout: 0, // stream contínuo
-		},
+		client:   httpclient.Client(httpclient.ServiceAI), // stream contínuo
 		endpoint: strings.TrimRight(endpoint, "/"),
 		model:    model,
 	}
@@ -175,8 +182,9 @@ func newGeminiProvider(apiKey, model string) (providerClient, error) {
 	}
 
 	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
-		APIKey:  apiKey,
-		Backend: genai.BackendGeminiAPI,
+		APIKey:     apiKey,
+		Backend:    genai.BackendGeminiAPI,
+		HTTPClient: httpclient.Client(httpclient.ServiceAI),
 	})
 	if err != nil {
 		return nil, err
@@ -14,6 +14,11 @@ import (
 const (
 	defaultTokenBudget = 4000
 	maxHistoryLines    = 50
+
+	DefaultOpenAIModel    = "gpt-4.1-mini"
+	DefaultGeminiModel    = "gemini-2.5-flash"
+	DefaultOllamaModel    = "llama3"
+	DefaultOllamaEndpoint = "http://localhost:11434"
 )
 
 var commandPrefixes = []string{
@@ -90,8 +95,8 @@ func (s *Service) bootstrapProviders() {
 	ollama := AIProvider{
 		ID:       "ollama",
 		Name:     "Ollama (Local)",
-		Model:    "llama3",
-		Endpoint: "http://localhost:11434",
+		Model:    DefaultOllamaModel,
+		Endpoint: DefaultOllamaEndpoint,
 		Enabled:  true,
 	}
 	s.providers[ollama.ID] = providerRegistration{
@@ -110,7 +115,7 @@ func (s *Service) bootstrapProviders() {
 	gemini := AIProvider{
 		ID:      "gemini",
 		Name:    "Gemini",
-		Model:   "gemini-2.5-flash",
+		Model:   DefaultGeminiModel,
 		APIKey:  geminiKey,
 		Enabled: geminiKey != "",
 	}
@@ -134,7 +139,7 @@ func (s *Service) bootstrapProviders() {
 	openAI := AIProvider{
 		ID:      "openai",
 		Name:    "OpenAI",
-		Model:   "gpt-4.1-mini",
+		Model:   DefaultOpenAIModel,
 		APIKey:  openAIKey,
 		Enabled: openAIKey != "",
 	}
@@ -201,6 +206,37 @@ func (s *Service) GenerateResponse(ctx context.Context, userMessage string, sess
 	return stream, nil
 }
 
+// Complete envia um prompt avulso (sem contexto de terminal) e devolve a resposta inteira.
+func (s *Service) Complete(ctx context.Context, prompt string) (string, error) {
+	prompt = strings.TrimSpace(prompt)
+	if prompt == "" {
+		return "", fmt.Errorf("mensagem vazia")
+	}
+	prompt = s.sanitizer.Clean(prompt)
+	prompt = s.truncateToFit(prompt, s.tokenBudget)
+
+	_, client, err := s.getActiveProvider()
+	if err != nil {
+		return "", err
+	}
+
+	stream := make(chan string, 128)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(stream)
+		errCh <- client.Stream(ctx, prompt, stream)
+	}()
+
+	var sb strings.Builder
+	for chunk := range stream {
+		sb.WriteString(chunk)
+	}
+	if err := <-errCh; err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
 // SetProvider configura/ativa o provedor escolhido.
 func (s *Service) SetProvider(provider AIProvider) error {
 	s.mu.Lock()
@@ -229,28 +265,11 @@ func (s *Service) SetProvider(provider AIProvider) error {
 		provider.APIKey = current.meta.APIKey
 	}
 
-	var client providerClient
-	switch id {
-	case "openai":
-		c, err := newOpenAIProvider(provider.APIKey, provider.Model)
-		if err != nil {
-			return err
-		}
-		client = c
-		provider.Enabled = true
-	case "ollama":
-		client = newOllamaProvider(provider.Endpoint, provider.Model)
-		provider.Enabled = true
-	case "gemini":
-		c, err := newGeminiProvider(provider.APIKey, provider.Model)
-		if err != nil {
-			return err
-		}
-		client = c
-		provider.Enabled = true
-	default:
-		return fmt.Errorf("provider %q não suportado", id)
+	client, err := newProviderClient(id, provider)
+	if err != nil {
+		return err
 	}
+	provider.Enabled = true
 
 	s.providers[id] = providerRegistration{
 		meta:   provider,
@@ -260,6 +279,18 @@ func (s *Service) SetProvider(provider AIProvider) error {
 	return nil
 }
 
+func newProviderClient(id string, provider AIProvider) (providerClient, error) {
+	switch id {
+	case "openai":
+		return newOpenAIProvider(provider.APIKey, provider.Model)
+	case "ollama":
+		return newOllamaProvider(provider.Endpoint, provider.Model), nil
+	case "gemini":
+		return newGeminiProvider(provider.APIKey, provider.Model)
+	}
+	return nil, fmt.Errorf("provider %q não suportado", id)
+}
+
 // ListProviders lista provedores disponíveis.
 func (s *Service) ListProviders() []AIProvider {
 	s.mu.RLock()
@@ -0,0 +1,266 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	DefaultSummaryLines = 500
+	MaxSummaryLines     = 5000
+
+	// Reserva do budget para instruções e resposta; o restante vai para o trecho do log.
+	summaryPromptReserveTokens = 600
+	summaryTailLines           = 20 // o fim do log quase sempre traz o status final
+	summaryErrorContextLines   = 2  // linhas após um erro (stack, arquivo:linha)
+	maxSummaryFindings         = 20
+	maxSummaryFindingLength    = 300
+)
+
+// Status final detectado no log.
+const (
+	LogStatusFailed  = "failed"
+	LogStatusPassed  = "passed"
+	LogStatusUnknown = "unknown"
+)
+
+var (
+	summaryANSIRegex    = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]|\x1b\][^\x07]*\x07`)
+	summaryErrorRegex   = regexp.MustCompile(`(?i)\b(error|errors|err!|fatal|panic|exception|traceback|failed|failure|undefined|cannot find|not found)\b|^FAIL\b|--- FAIL`)
+	summaryWarningRegex = regexp.MustCompile(`(?i)\b(warn|warning|warnings|deprecated|deprecation)\b`)
+	summaryFailRegex    = regexp.MustCompile(`(?i)^(FAIL\b|--- FAIL|BUILD FAILURE|BUILD FAILED|test result: FAILED|npm ERR!|error: could not compile)|\b[1-9]\d* (failed|failing)\b|exit (code|status) [1-9]`)
+	summaryPassRegex    = regexp.MustCompile(`(?i)^(ok\s|PASS$|BUILD SUCCESS|BUILD SUCCESSFUL|test result: ok|Done in\b|✓ built in\b)|\b\d+ passed\b|\b0 failed\b|compiled successfully`)
+)
+
+// LogExcerpt é o log pré-filtrado por heurística antes de ir para a IA.
+type LogExcerpt struct {
+	Text          string   // linhas escolhidas, na ordem original, com marcadores de trechos omitidos
+	Errors        []string // linhas de erro (sem repetição)
+	Warnings      []string // linhas de aviso (sem repetição)
+	Status        string   // failed | passed | unknown
+	TotalLines    int
+	SelectedLines int
+}
+
+// TerminalSummary é o resumo de uma saída longa do terminal.
+type TerminalSummary struct {
+	SessionID     string   `json:"sessionId"`
+	Command       string   `json:"command,omitempty"`
+	Status        string   `json:"status"`
+	Errors        []string `json:"errors"`
+	Warnings      []string `json:"warnings"`
+	TotalLines    int      `json:"totalLines"`
+	SelectedLines int      `json:"selectedLines"` // linhas enviadas ao provedor após o pré-filtro
+	Summary       string   `json:"summary"`
+}
+
+// PrefilterLog limpa o log (ANSI, linhas vazias, repetições) e escolhe as linhas que cabem em
+// maxTokens: erros com um pouco de contexto primeiro, depois o final do log e os avisos, e por
+// fim o restante de trás para frente.
+func PrefilterLog(output string, maxTokens int) LogExcerpt {
+	lines := cleanLogLines(output)
+	excerpt := LogExcerpt{Status: LogStatusUnknown, TotalLines: len(lines), Errors: []string{}, Warnings: []string{}}
+	if len(lines) == 0 {
+		return excerpt
+	}
+
+	errorLines := make([]int, 0)
+	warningLines := make([]int, 0)
+	seenFinding := make(map[string]bool)
+	for i, line := range lines {
+		switch {
+		case summaryPassRegex.MatchString(line) && !summaryFailRegex.MatchString(line):
+			// "12 passed, 0 failed" não é erro.
+		case summaryErrorRegex.MatchString(line):
+			errorLines = append(errorLines, i)
+			excerpt.Errors = appendFinding(excerpt.Errors, seenFinding, line)
+		case summaryWarningRegex.MatchString(line):
+			warningLines = append(warningLines, i)
+			excerpt.Warnings = appendFinding(excerpt.Warnings, seenFinding, line)
+		}
+	}
+	for i := len(lines) - 1; i >= 0 && excerpt.Status == LogStatusUnknown; i-- {
+		if summaryFailRegex.MatchString(lines[i]) {
+			excerpt.Status = LogStatusFailed
+		} else if summaryPassRegex.MatchString(lines[i]) {
+			excerpt.Status = LogStatusPassed
+		}
+	}
+
+	selected := make([]bool, len(lines))
+	budget := maxTokens
+	take := func(i int) bool {
+		if i < 0 || i >= len(lines) || selected[i] {
+			return true
+		}
+		cost := estimateTokens(lines[i]) + 1
+		if cost > budget {
+			return false
+		}
+		selected[i] = true
+		budget -= cost
+		excerpt.SelectedLines++
+		return true
+	}
+
+	// Erros mais recentes primeiro: num log de build o último erro costuma ser o que importa.
+	for k := len(errorLines) - 1; k >= 0; k-- {
+		i := errorLines[k]
+		if !take(i) {
+			break
+		}
+		for c := 1; c <= summaryErrorContextLines; c++ {
+			take(i + c)
+		}
+	}
+	for i := len(lines) - 1; i >= max(0, len(lines)-summaryTailLines); i-- {
+		if !take(i) {
+			break
+		}
+	}
+	for k := len(warningLines) - 1; k >= 0; k-- {
+		if !take(warningLines[k]) {
+			break
+		}
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		if !take(i) {
+			break
+		}
+	}
+
+	var sb strings.Builder
+	omitted := 0
+	for i, line := range lines {
+		if !selected[i] {
+			omitted++
+			continue
+		}
+		if omitted > 0 {
+			fmt.Fprintf(&sb, "[... %d linhas omitidas ...]\n", omitted)
+			omitted = 0
+		}
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+	if omitted > 0 {
+		fmt.Fprintf(&sb, "[... %d linhas omitidas ...]\n", omitted)
+	}
+	excerpt.Text = strings.TrimRight(sb.String(), "\n")
+	return excerpt
+}
+
+// TerminalSummaryPrompt pede à IA os pontos principais de um log de build/teste.
+func TerminalSummaryPrompt(command string, excerpt LogExcerpt) string {
+	var sb strings.Builder
+	sb.WriteString("Resuma a saída de terminal abaixo (build, testes ou deploy) em até 6 tópicos curtos: " +
+		"status final, erros principais (com arquivo:linha quando houver), avisos relevantes e o próximo passo sugerido. " +
+		"Não invente nada que não esteja na saída; trechos marcados como omitidos foram cortados por tamanho.\n\n")
+	if command != "" {
+		fmt.Fprintf(&sb, "Comando: %s\n", command)
+	}
+	fmt.Fprintf(&sb, "Status detectado: %s · %d linhas no total, %d enviadas\n\n", excerpt.Status, excerpt.TotalLines, excerpt.SelectedLines)
+	sb.WriteString(excerpt.Text)
+	return sb.String()
+}
+
+// SummarizeTerminalOutput condensa as últimas lastNLines linhas da saída com o provedor ativo,
+// pré-filtrando o log para caber no budget de tokens.
+func (s *Service) SummarizeTerminalOutput(ctx context.Context, sessionID, output string, lastNLines int) (TerminalSummary, error) {
+	if lastNLines <= 0 {
+		lastNLines = DefaultSummaryLines
+	}
+	lastNLines = min(lastNLines, MaxSummaryLines)
+	output = lastLines(output, lastNLines)
+	if strings.TrimSpace(summaryANSIRegex.ReplaceAllString(output, "")) == "" {
+		return TerminalSummary{}, fmt.Errorf("terminal has no output to summarize")
+	}
+
+	s.mu.RLock()
+	command := ""
+	if term := s.terminalState[sessionID]; term != nil {
+		command = term.lastCommand
+	}
+	s.mu.RUnlock()
+
+	excerpt := PrefilterLog(output, max(s.tokenBudget-summaryPromptReserveTokens, s.tokenBudget/2))
+	summary := TerminalSummary{
+		SessionID:     sessionID,
+		Command:       command,
+		Status:        excerpt.Status,
+		Errors:        excerpt.Errors,
+		Warnings:      excerpt.Warnings,
+		TotalLines:    excerpt.TotalLines,
+		SelectedLines: excerpt.SelectedLines,
+	}
+	text, err := s.Complete(ctx, TerminalSummaryPrompt(command, excerpt))
+	if err != nil {
+		return summary, err
+	}
+	summary.Summary = text
+	return summary, nil
+}
+
+// cleanLogLines remove ANSI, \r de barras de progresso, linhas vazias e repetições seguidas.
+func cleanLogLines(output string) []string {
+	output = summaryANSIRegex.ReplaceAllString(output, "")
+	raw := strings.Split(output, "\n")
+	lines := make([]string, 0, len(raw))
+	repeats := 0
+	for _, line := range raw {
+		// Barras de progresso reescrevem a linha com \r; só o último estado interessa.
+		if idx := strings.LastIndex(strings.TrimRight(line, "\r"), "\r"); idx >= 0 {
+			line = line[idx+1:]
+		}
+		line = strings.TrimRight(line, "\r \t")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if len(lines) > 0 && lines[len(lines)-1] == line {
+			repeats++
+			continue
+		}
+		if repeats > 0 {
+			lines = append(lines, fmt.Sprintf("[linha anterior repetida %d vezes]", repeats))
+			repeats = 0
+		}
+		lines = append(lines, line)
+	}
+	if repeats > 0 {
+		lines = append(lines, fmt.Sprintf("[linha anterior repetida %d vezes]", repeats))
+	}
+	return lines
+}
+
+func lastLines(output string, n int) string {
+	idx := len(output)
+	for count := 0; idx > 0; {
+		next := strings.LastIndexByte(output[:idx], '\n')
+		if next < 0 {
+			return output
+		}
+		// Uma quebra no fim não conta como linha.
+		if next != len(output)-1 {
+			count++
+			if count == n {
+				return output[next+1:]
+			}
+		}
+		idx = next
+	}
+	return output
+}
+
+func appendFinding(findings []string, seen map[string]bool, line string) []string {
+	line = strings.TrimSpace(line)
+	if len(findings) >= maxSummaryFindings || seen[line] {
+		return findings
+	}
+	seen[line] = true
+	if len([]rune(line)) > maxSummaryFindingLength {
+		line = string([]rune(line)[:maxSummaryFindingLength]) + "…"
+	}
+	return append(findings, line)
+}
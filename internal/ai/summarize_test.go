@@ -0,0 +1,85 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrefilterLogKeepsErrorsAndTailWithinBudget(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("\x1b[32mCompiling 120 crates\x1b[0m\n")
+	for i := 0; i < 400; i++ {
+		fmt.Fprintf(&sb, "   Compiling crate-%03d v0.1.0\n", i)
+	}
+	sb.WriteString("warning: unused variable `x`\n")
+	sb.WriteString("warning: unused variable `x`\n")
+	sb.WriteString("error[E0425]: cannot find value `y` in this scope\n")
+	sb.WriteString("  --> src/main.rs:12:5\n")
+	sb.WriteString("Downloading 10%\rDownloading 50%\rDownloading 100%\r\n")
+	sb.WriteString("error: could not compile `app` due to previous error\n")
+
+	excerpt := PrefilterLog(sb.String(), 200)
+	if excerpt.Status != LogStatusFailed {
+		t.Fatalf("expected failed status, got %q", excerpt.Status)
+	}
+	if excerpt.TotalLines != 407 || excerpt.SelectedLines >= excerpt.TotalLines {
+		t.Fatalf("unexpected line counts: total=%d selected=%d", excerpt.TotalLines, excerpt.SelectedLines)
+	}
+	if estimateTokens(excerpt.Text) > 260 {
+		t.Fatalf("excerpt exceeds budget: %d tokens", estimateTokens(excerpt.Text))
+	}
+	for _, fragment := range []string{
+		"error[E0425]: cannot find value `y` in this scope\n  --> src/main.rs:12:5",
+		"[linha anterior repetida 1 vezes]",
+		"Downloading 100%",
+		"[... ",
+	} {
+		if !strings.Contains(excerpt.Text, fragment) {
+			t.Fatalf("excerpt missing %q:\n%s", fragment, excerpt.Text)
+		}
+	}
+	if strings.Contains(excerpt.Text, "\x1b[") || strings.Contains(excerpt.Text, "Downloading 10%") {
+		t.Fatalf("excerpt should not keep ANSI codes or overwritten progress:\n%s", excerpt.Text)
+	}
+	if len(excerpt.Errors) != 2 || len(excerpt.Warnings) != 1 {
+		t.Fatalf("unexpected findings: errors=%v warnings=%v", excerpt.Errors, excerpt.Warnings)
+	}
+}
+
+func TestPrefilterLogDetectsPassingRun(t *testing.T) {
+	excerpt := PrefilterLog("running 3 tests\ntest a ... ok\ntest result: ok. 3 passed; 0 failed\n", 1000)
+	if excerpt.Status != LogStatusPassed || len(excerpt.Errors) != 0 {
+		t.Fatalf("unexpected excerpt: %+v", excerpt)
+	}
+	if excerpt.SelectedLines != 3 || strings.Contains(excerpt.Text, "omitidas") {
+		t.Fatalf("short logs should be sent whole: %+v", excerpt)
+	}
+}
+
+func TestSummarizeTerminalOutputRequiresOutputAndProvider(t *testing.T) {
+	svc := NewService(ServiceDeps{})
+	if _, err := svc.SummarizeTerminalOutput(context.Background(), "term-1", "\x1b[0m\n\n", 50); err == nil {
+		t.Fatalf("expected empty output to fail")
+	}
+
+	// O resultado heurístico volta mesmo quando o provedor falha.
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	svc.ObserveTerminalInput("term-1", []byte("go test ./...\r"))
+	summary, err := svc.SummarizeTerminalOutput(ctx, "term-1", "one\ntwo\nFAIL\torch/internal/ai\n", 2)
+	if summary.Command != "go test ./..." || summary.TotalLines != 2 || summary.Status != LogStatusFailed {
+		t.Fatalf("unexpected heuristic summary: %+v (err=%v)", summary, err)
+	}
+}
+
+func TestLastLines(t *testing.T) {
+	if got := lastLines("a\nb\nc\n", 2); got != "b\nc\n" {
+		t.Fatalf("unexpected last lines: %q", got)
+	}
+	if got := lastLines("a\nb", 5); got != "a\nb" {
+		t.Fatalf("unexpected last lines: %q", got)
+	}
+}
@@ -0,0 +1,490 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"orch/internal/config"
+
+	"github.com/zalando/go-keyring"
+)
+
+// === Contas GitHub adicionais ===
+//
+// A sessão principal (login OAuth) continua sendo a conta "primária". Contas extras (ex.: pessoal +
+// trabalho) guardam o segredo no Keychain (token OAuth/PAT ou chave privada do GitHub App); os
+// metadados ficam em JSON no mesmo serviço do Keychain. A conta ativa é usada para repositórios
+// sem vínculo explícito.
+
+const (
+	keychainGitHubAccounts           = "github_accounts"
+	keychainGitHubActiveAccount      = "github_active_account"
+	keychainGitHubAccountTokenPrefix = "github_account_token:"
+
+	// Renova o token de instalação do GitHub App antes de expirar (validade de 1h).
+	githubAppTokenRefreshMargin = 5 * time.Minute
+)
+
+// Tipos de credencial (mesmos valores de github.CredentialKind*).
+const (
+	GitHubCredentialOAuth          = "oauth"
+	GitHubCredentialClassicPAT     = "pat_classic"
+	GitHubCredentialFineGrainedPAT = "pat_fine_grained"
+	GitHubCredentialApp            = "app"
+)
+
+// GitHubAppToken é um token de instalação emitido a partir da chave privada do App.
+type GitHubAppToken struct {
+	Token       string
+	ExpiresAt   time.Time
+	Permissions map[string]string
+}
+
+// GitHubAppTokenMinter emite tokens de instalação (implementado pelo gh.Service).
+type GitHubAppTokenMinter func(appID, installationID int64, privateKey string) (*GitHubAppToken, error)
+
+// storedGitHubAccount são os metadados persistidos de uma conta extra (sem segredo).
+type storedGitHubAccount struct {
+	Login          string            `json:"login"`
+	Name           string            `json:"name,omitempty"`
+	AvatarURL      string            `json:"avatarUrl,omitempty"`
+	Kind           string            `json:"kind,omitempty"`
+	Scopes         []string          `json:"scopes,omitempty"`
+	Permissions    map[string]string `json:"permissions,omitempty"`
+	ExpiresAt      *time.Time        `json:"expiresAt,omitempty"`
+	AppID          int64             `json:"appId,omitempty"`
+	InstallationID int64             `json:"installationId,omitempty"`
+	AddedAt        time.Time         `json:"addedAt"`
+}
+
+func (entry storedGitHubAccount) toAccount(id string) GitHubAccount {
+	kind := entry.Kind
+	if kind == "" {
+		kind = GitHubCredentialOAuth
+	}
+	return GitHubAccount{
+		ID:             id,
+		Login:          entry.Login,
+		Name:           entry.Name,
+		AvatarURL:      entry.AvatarURL,
+		Kind:           kind,
+		Scopes:         entry.Scopes,
+		Permissions:    entry.Permissions,
+		ExpiresAt:      entry.ExpiresAt,
+		AppID:          entry.AppID,
+		InstallationID: entry.InstallationID,
+		AddedAt:        entry.AddedAt,
+	}
+}
+
+// GitHubAppAccountID é o ID de conta de uma instalação de GitHub App.
+func GitHubAppAccountID(installationID int64) string {
+	return fmt.Sprintf("app-%d", installationID)
+}
+
+// SetGitHubAppTokenMinter registra quem emite tokens de instalação de GitHub App.
+func (s *Service) SetGitHubAppTokenMinter(minter GitHubAppTokenMinter) {
+	s.accountsMu.Lock()
+	s.appTokenMinter = minter
+	s.accountsMu.Unlock()
+}
+
+// NormalizeGitHubAccountID normaliza o ID de conta (login GitHub em minúsculas).
+func NormalizeGitHubAccountID(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
+
+// BeginAddGitHubAccount faz o próximo callback OAuth adicionar uma conta em vez de trocar a sessão.
+func (s *Service) BeginAddGitHubAccount() {
+	s.accountsMu.Lock()
+	s.addingAccount = true
+	s.accountsMu.Unlock()
+}
+
+// CancelAddGitHubAccount descarta um fluxo de adição pendente.
+func (s *Service) CancelAddGitHubAccount() {
+	s.accountsMu.Lock()
+	s.addingAccount = false
+	s.accountsMu.Unlock()
+}
+
+func (s *Service) takeAddingAccount() bool {
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+	adding := s.addingAccount
+	s.addingAccount = false
+	return adding
+}
+
+// completeAddGitHubAccount guarda o token do provider como conta extra; a sessão principal não muda.
+func (s *Service) completeAddGitHubAccount(pair *TokenPair) (*AuthResult, error) {
+	if !strings.EqualFold(strings.TrimSpace(pair.Provider), "github") || strings.TrimSpace(pair.ProviderAccessToken) == "" {
+		return &AuthResult{Success: false, Error: "GitHub did not return an access token for the new account"}, nil
+	}
+	profile, err := s.fetchUserProfile(pair.AccessToken)
+	if err != nil {
+		return &AuthResult{Success: false, Error: "Failed to fetch user profile"}, nil
+	}
+	if _, err := s.AddGitHubAccount(profile.Username, profile.Name, profile.AvatarURL, pair.ProviderAccessToken); err != nil {
+		return &AuthResult{Success: false, Error: err.Error()}, nil
+	}
+	return &AuthResult{Success: true, User: profile}, nil
+}
+
+// AddGitHubAccount registra (ou atualiza) uma conta OAuth extra com seu token.
+func (s *Service) AddGitHubAccount(login, name, avatarURL, token string) (*GitHubAccount, error) {
+	return s.SaveGitHubCredential(GitHubAccount{
+		Login:     login,
+		Name:      name,
+		AvatarURL: avatarURL,
+		Kind:      GitHubCredentialOAuth,
+		Scopes:    strings.Fields(githubOAuthScopes),
+	}, token)
+}
+
+// SaveGitHubCredential registra (ou substitui) uma conta extra. secret é o token (OAuth/PAT) ou a
+// chave privada PEM (GitHub App). Instalações de App usam o ID "app-<installationId>".
+func (s *Service) SaveGitHubCredential(account GitHubAccount, secret string) (*GitHubAccount, error) {
+	secret = strings.TrimSpace(secret)
+	kind := strings.TrimSpace(account.Kind)
+	if kind == "" {
+		kind = GitHubCredentialOAuth
+	}
+	id := NormalizeGitHubAccountID(account.Login)
+	if kind == GitHubCredentialApp {
+		if account.AppID <= 0 || account.InstallationID <= 0 {
+			return nil, fmt.Errorf("GitHub App ID and installation ID are required")
+		}
+		id = GitHubAppAccountID(account.InstallationID)
+	}
+	if strings.TrimSpace(account.Login) == "" {
+		return nil, fmt.Errorf("GitHub login is required")
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("GitHub credential secret is required")
+	}
+	if primary := s.primaryGitHubAccount(); primary != nil && primary.ID == id {
+		return nil, fmt.Errorf("account %s is already the primary session", primary.Login)
+	}
+
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+
+	accounts := s.loadStoredAccountsLocked()
+	entry := storedGitHubAccount{
+		Login:          strings.TrimSpace(account.Login),
+		Name:           strings.TrimSpace(account.Name),
+		AvatarURL:      strings.TrimSpace(account.AvatarURL),
+		Kind:           kind,
+		Scopes:         account.Scopes,
+		Permissions:    account.Permissions,
+		ExpiresAt:      account.ExpiresAt,
+		AppID:          account.AppID,
+		InstallationID: account.InstallationID,
+		AddedAt:        time.Now(),
+	}
+	if existing, ok := accounts[id]; ok {
+		entry.AddedAt = existing.AddedAt
+	}
+	if err := keyring.Set(config.KeychainService(), keychainGitHubAccountTokenPrefix+id, secret); err != nil {
+		return nil, fmt.Errorf("failed to store account credential: %w", err)
+	}
+	accounts[id] = entry
+	if err := s.saveStoredAccountsLocked(accounts); err != nil {
+		return nil, err
+	}
+	delete(s.appTokens, id)
+	saved := entry.toAccount(id)
+	return &saved, nil
+}
+
+// RemoveGitHubAccount remove uma conta extra e seu token. A conta primária sai via Logout.
+func (s *Service) RemoveGitHubAccount(id string) error {
+	id = NormalizeGitHubAccountID(id)
+	if primary := s.primaryGitHubAccount(); primary != nil && primary.ID == id {
+		return fmt.Errorf("the primary account can only be removed by logging out")
+	}
+
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+
+	accounts := s.loadStoredAccountsLocked()
+	if _, ok := accounts[id]; !ok {
+		return fmt.Errorf("GitHub account not found: %s", id)
+	}
+	delete(accounts, id)
+	if err := s.saveStoredAccountsLocked(accounts); err != nil {
+		return err
+	}
+	_ = keyring.Delete(config.KeychainService(), keychainGitHubAccountTokenPrefix+id)
+	delete(s.appTokens, id)
+	if active, _ := keyring.Get(config.KeychainService(), keychainGitHubActiveAccount); NormalizeGitHubAccountID(active) == id {
+		_ = keyring.Delete(config.KeychainService(), keychainGitHubActiveAccount)
+	}
+	return nil
+}
+
+// SetActiveGitHubAccount define a conta padrão (vazio ou primária = sessão principal).
+func (s *Service) SetActiveGitHubAccount(id string) error {
+	id = NormalizeGitHubAccountID(id)
+	if primary := s.primaryGitHubAccount(); id == "" || (primary != nil && primary.ID == id) {
+		_ = keyring.Delete(config.KeychainService(), keychainGitHubActiveAccount)
+		return nil
+	}
+
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+
+	if _, ok := s.loadStoredAccountsLocked()[id]; !ok {
+		return fmt.Errorf("GitHub account not found: %s", id)
+	}
+	if err := keyring.Set(config.KeychainService(), keychainGitHubActiveAccount, id); err != nil {
+		return fmt.Errorf("failed to store active account: %w", err)
+	}
+	return nil
+}
+
+// ListGitHubAccounts retorna a conta primária (se logada com GitHub) seguida das contas extras.
+func (s *Service) ListGitHubAccounts() []GitHubAccount {
+	activeID := s.activeStoredAccountID()
+
+	var result []GitHubAccount
+	if primary := s.primaryGitHubAccount(); primary != nil {
+		primary.Active = activeID == ""
+		result = append(result, *primary)
+	}
+
+	s.accountsMu.Lock()
+	accounts := s.loadStoredAccountsLocked()
+	s.accountsMu.Unlock()
+
+	extras := make([]GitHubAccount, 0, len(accounts))
+	for id, entry := range accounts {
+		account := entry.toAccount(id)
+		account.Active = id == activeID
+		extras = append(extras, account)
+	}
+	sort.Slice(extras, func(i, j int) bool { return extras[i].ID < extras[j].ID })
+	return append(result, extras...)
+}
+
+// HasGitHubAccount indica se o ID corresponde à conta primária ou a uma conta extra.
+func (s *Service) HasGitHubAccount(id string) bool {
+	id = NormalizeGitHubAccountID(id)
+	if id == "" {
+		return false
+	}
+	if primary := s.primaryGitHubAccount(); primary != nil && primary.ID == id {
+		return true
+	}
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+	_, ok := s.loadStoredAccountsLocked()[id]
+	return ok
+}
+
+// GitHubAccountIDForLogin retorna a conta cujo login é o informado (ID exato tem prioridade
+// sobre instalações de App no mesmo owner); vazio quando não há.
+func (s *Service) GitHubAccountIDForLogin(login string) string {
+	login = NormalizeGitHubAccountID(login)
+	if login == "" {
+		return ""
+	}
+	if s.HasGitHubAccount(login) {
+		return login
+	}
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+	ids := make([]string, 0)
+	for id, entry := range s.loadStoredAccountsLocked() {
+		if NormalizeGitHubAccountID(entry.Login) == login {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+	sort.Strings(ids)
+	return ids[0]
+}
+
+// GitHubCredentialForAccount retorna os metadados (tipo, escopos, permissões) da conta; vazio usa a ativa.
+func (s *Service) GitHubCredentialForAccount(id string) (GitHubAccount, bool) {
+	id = NormalizeGitHubAccountID(id)
+	if id == "" {
+		id = s.activeStoredAccountID()
+	}
+	if id != "" {
+		s.accountsMu.Lock()
+		entry, ok := s.loadStoredAccountsLocked()[id]
+		s.accountsMu.Unlock()
+		if ok {
+			return entry.toAccount(id), true
+		}
+	}
+	primary := s.primaryGitHubAccount()
+	if primary == nil || (id != "" && primary.ID != id) {
+		return GitHubAccount{}, false
+	}
+	return *primary, true
+}
+
+// GitHubTokenForAccount retorna o token da conta; vazio usa a conta ativa.
+func (s *Service) GitHubTokenForAccount(id string) (string, error) {
+	id = NormalizeGitHubAccountID(id)
+	if id == "" {
+		id = s.activeStoredAccountID()
+	}
+	if id == "" {
+		return s.GetGitHubToken()
+	}
+	s.accountsMu.Lock()
+	entry, stored := s.loadStoredAccountsLocked()[id]
+	s.accountsMu.Unlock()
+	if stored {
+		if entry.ExpiresAt != nil && time.Now().After(*entry.ExpiresAt) {
+			return "", fmt.Errorf("token for GitHub account %s expired on %s; add a new token", id, entry.ExpiresAt.Format("2006-01-02"))
+		}
+		if entry.Kind == GitHubCredentialApp {
+			return s.gitHubAppToken(id, entry)
+		}
+	}
+	if token, err := keyring.Get(config.KeychainService(), keychainGitHubAccountTokenPrefix+id); err == nil && strings.TrimSpace(token) != "" {
+		return strings.TrimSpace(token), nil
+	}
+	if primary := s.primaryGitHubAccount(); primary != nil && primary.ID == id {
+		return s.GetGitHubToken()
+	}
+	return "", fmt.Errorf("missing token for GitHub account %s; add the account again", id)
+}
+
+// gitHubAppToken retorna o token de instalação em cache ou emite outro com a chave privada.
+func (s *Service) gitHubAppToken(id string, entry storedGitHubAccount) (string, error) {
+	s.accountsMu.Lock()
+	cached, ok := s.appTokens[id]
+	minter := s.appTokenMinter
+	s.accountsMu.Unlock()
+	if ok && time.Now().Add(githubAppTokenRefreshMargin).Before(cached.ExpiresAt) {
+		return cached.Token, nil
+	}
+	if minter == nil {
+		return "", fmt.Errorf("GitHub App token minting is not configured")
+	}
+	privateKey, err := keyring.Get(config.KeychainService(), keychainGitHubAccountTokenPrefix+id)
+	if err != nil || strings.TrimSpace(privateKey) == "" {
+		return "", fmt.Errorf("missing private key for GitHub App account %s; add the installation again", id)
+	}
+	minted, err := minter(entry.AppID, entry.InstallationID, privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitHub App installation token: %w", err)
+	}
+
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+	if s.appTokens == nil {
+		s.appTokens = make(map[string]GitHubAppToken)
+	}
+	s.appTokens[id] = *minted
+	// Permissões da instalação podem mudar (aprovação no GitHub); mantém os metadados atualizados.
+	accounts := s.loadStoredAccountsLocked()
+	if current, ok := accounts[id]; ok && minted.Permissions != nil {
+		current.Permissions = minted.Permissions
+		accounts[id] = current
+		if err := s.saveStoredAccountsLocked(accounts); err != nil {
+			log.Printf("[AUTH] warning: failed to update GitHub App permissions: %v", err)
+		}
+	}
+	return minted.Token, nil
+}
+
+// activeStoredAccountID retorna a conta extra ativa ("" = sessão principal).
+func (s *Service) activeStoredAccountID() string {
+	active, err := keyring.Get(config.KeychainService(), keychainGitHubActiveAccount)
+	if err != nil {
+		return ""
+	}
+	active = NormalizeGitHubAccountID(active)
+	if active == "" {
+		return ""
+	}
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+	if _, ok := s.loadStoredAccountsLocked()[active]; !ok {
+		return ""
+	}
+	return active
+}
+
+// primaryGitHubAccount descreve a sessão principal quando ela é GitHub e tem token do provider.
+func (s *Service) primaryGitHubAccount() *GitHubAccount {
+	if s.getProvider() != "github" {
+		return nil
+	}
+	if token, err := s.getProviderAccessToken(); err != nil || strings.TrimSpace(token) == "" {
+		return nil
+	}
+	user, err := s.GetCurrentUser()
+	if err != nil || user == nil || strings.TrimSpace(user.Username) == "" {
+		return nil
+	}
+	return &GitHubAccount{
+		ID:        NormalizeGitHubAccountID(user.Username),
+		Login:     strings.TrimSpace(user.Username),
+		Name:      user.Name,
+		AvatarURL: user.AvatarURL,
+		Kind:      GitHubCredentialOAuth,
+		Scopes:    strings.Fields(githubOAuthScopes),
+		Primary:   true,
+	}
+}
+
+// loadStoredAccountsLocked retorna uma cópia das contas extras (carregadas do Keychain uma vez).
+func (s *Service) loadStoredAccountsLocked() map[string]storedGitHubAccount {
+	if s.accounts != nil {
+		clone := make(map[string]storedGitHubAccount, len(s.accounts))
+		for id, entry := range s.accounts {
+			clone[id] = entry
+		}
+		return clone
+	}
+	accounts := make(map[string]storedGitHubAccount)
+	if raw, err := keyring.Get(config.KeychainService(), keychainGitHubAccounts); err == nil && strings.TrimSpace(raw) != "" {
+		if err := json.Unmarshal([]byte(raw), &accounts); err != nil {
+			log.Printf("[AUTH] warning: ignoring unreadable GitHub account list: %v", err)
+			accounts = make(map[string]storedGitHubAccount)
+		}
+	}
+	s.accounts = accounts
+	return s.loadStoredAccountsLocked()
+}
+
+func (s *Service) saveStoredAccountsLocked(accounts map[string]storedGitHubAccount) error {
+	raw, err := json.Marshal(accounts)
+	if err != nil {
+		return fmt.Errorf("failed to encode GitHub accounts: %w", err)
+	}
+	if err := keyring.Set(config.KeychainService(), keychainGitHubAccounts, string(raw)); err != nil {
+		return fmt.Errorf("failed to store GitHub accounts: %w", err)
+	}
+	s.accounts = accounts
+	return nil
+}
+
+// clearGitHubAccounts remove contas extras e seus tokens (logout).
+func (s *Service) clearGitHubAccounts() {
+	s.accountsMu.Lock()
+	defer s.accountsMu.Unlock()
+
+	for id := range s.loadStoredAccountsLocked() {
+		_ = keyring.Delete(config.KeychainService(), keychainGitHubAccountTokenPrefix+id)
+	}
+	_ = keyring.Delete(config.KeychainService(), keychainGitHubAccounts)
+	_ = keyring.Delete(config.KeychainService(), keychainGitHubActiveAccount)
+	s.accounts = nil
+	s.appTokens = nil
+	s.addingAccount = false
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"orch/internal/config"
+
+	"github.com/zalando/go-keyring"
+)
+
+func newAccountsTestService(t *testing.T) *Service {
+	t.Helper()
+	keyring.MockInit()
+
+	s := NewService(nil)
+	if err := keyring.Set(config.KeychainService(), keychainProvider, "github"); err != nil {
+		t.Fatalf("failed to seed provider: %v", err)
+	}
+	if err := keyring.Set(config.KeychainService(), keychainProviderAccessToken, "primary-token"); err != nil {
+		t.Fatalf("failed to seed provider token: %v", err)
+	}
+	if err := keyring.Set(config.KeychainService(), keychainExpiresAt, "2999-01-01T00:00:00Z"); err != nil {
+		t.Fatalf("failed to seed expiration: %v", err)
+	}
+	s.SetCurrentUserForTesting(&User{ID: "u1", Username: "Dev", Name: "Dev", Provider: "github"})
+	return s
+}
+
+func TestGitHubAccountsAddSwitchAndRoute(t *testing.T) {
+	s := newAccountsTestService(t)
+
+	if _, err := s.AddGitHubAccount("dev", "", "", "x"); err == nil {
+		t.Fatalf("expected primary login to be rejected as extra account")
+	}
+	if _, err := s.AddGitHubAccount("Dev-Work", "Dev at Work", "", "work-token"); err != nil {
+		t.Fatalf("AddGitHubAccount() error: %v", err)
+	}
+
+	accounts := s.ListGitHubAccounts()
+	if len(accounts) != 2 || !accounts[0].Primary || !accounts[0].Active || accounts[1].ID != "dev-work" || accounts[1].Active {
+		t.Fatalf("unexpected accounts: %+v", accounts)
+	}
+	if token, err := s.GitHubTokenForAccount(""); err != nil || token != "primary-token" {
+		t.Fatalf("expected primary token by default, got %q err=%v", token, err)
+	}
+	if token, err := s.GitHubTokenForAccount("DEV-WORK"); err != nil || token != "work-token" {
+		t.Fatalf("expected work token, got %q err=%v", token, err)
+	}
+
+	if err := s.SetActiveGitHubAccount("dev-work"); err != nil {
+		t.Fatalf("SetActiveGitHubAccount() error: %v", err)
+	}
+	if token, _ := s.GitHubTokenForAccount(""); token != "work-token" {
+		t.Fatalf("expected active account token, got %q", token)
+	}
+	if token, _ := s.GitHubTokenForAccount("dev"); token != "primary-token" {
+		t.Fatalf("expected explicit primary token, got %q", token)
+	}
+
+	// Recarrega do Keychain em uma nova instância.
+	reloaded := NewService(nil)
+	reloaded.SetCurrentUserForTesting(&User{Username: "dev", Provider: "github"})
+	if accounts := reloaded.ListGitHubAccounts(); len(accounts) != 2 || !accounts[1].Active || accounts[1].Name != "Dev at Work" {
+		t.Fatalf("unexpected persisted accounts: %+v", accounts)
+	}
+
+	if err := s.RemoveGitHubAccount("dev"); err == nil {
+		t.Fatalf("expected primary account removal to be rejected")
+	}
+	if err := s.RemoveGitHubAccount("dev-work"); err != nil {
+		t.Fatalf("RemoveGitHubAccount() error: %v", err)
+	}
+	if token, _ := s.GitHubTokenForAccount(""); token != "primary-token" {
+		t.Fatalf("expected fallback to primary after removing active account, got %q", token)
+	}
+	if _, err := s.GitHubTokenForAccount("dev-work"); err == nil {
+		t.Fatalf("expected error for removed account")
+	}
+}
+
+func TestLogoutClearsExtraGitHubAccounts(t *testing.T) {
+	s := newAccountsTestService(t)
+	if _, err := s.AddGitHubAccount("work", "", "", "work-token"); err != nil {
+		t.Fatalf("AddGitHubAccount() error: %v", err)
+	}
+	if err := s.Logout(); err != nil {
+		t.Fatalf("Logout() error: %v", err)
+	}
+	if _, err := keyring.Get(config.KeychainService(), keychainGitHubAccountTokenPrefix+"work"); err == nil {
+		t.Fatalf("expected extra account token removed on logout")
+	}
+	if accounts := s.ListGitHubAccounts(); len(accounts) != 0 {
+		t.Fatalf("expected no accounts after logout, got %+v", accounts)
+	}
+}
+
+func TestGitHubAppAccountMintsAndCachesInstallationTokens(t *testing.T) {
+	s := newAccountsTestService(t)
+	mints := 0
+	s.SetGitHubAppTokenMinter(func(appID, installationID int64, privateKey string) (*GitHubAppToken, error) {
+		mints++
+		if appID != 42 || installationID != 7 || privateKey != "PEM" {
+			t.Fatalf("unexpected mint args: %d %d %q", appID, installationID, privateKey)
+		}
+		return &GitHubAppToken{Token: "ghs_minted", ExpiresAt: time.Now().Add(time.Hour), Permissions: map[string]string{"pull_requests": "write"}}, nil
+	})
+
+	account, err := s.SaveGitHubCredential(GitHubAccount{Login: "acme", Kind: GitHubCredentialApp, AppID: 42, InstallationID: 7}, "PEM")
+	if err != nil {
+		t.Fatalf("SaveGitHubCredential() error: %v", err)
+	}
+	if account.ID != "app-7" {
+		t.Fatalf("unexpected app account id: %s", account.ID)
+	}
+	if id := s.GitHubAccountIDForLogin("ACME"); id != "app-7" {
+		t.Fatalf("expected owner lookup to find app installation, got %q", id)
+	}
+
+	for i := 0; i < 2; i++ {
+		if token, err := s.GitHubTokenForAccount("app-7"); err != nil || token != "ghs_minted" {
+			t.Fatalf("unexpected app token %q err=%v", token, err)
+		}
+	}
+	if mints != 1 {
+		t.Fatalf("expected installation token cached, minted %d times", mints)
+	}
+	if creds, ok := s.GitHubCredentialForAccount("app-7"); !ok || creds.Permissions["pull_requests"] != "write" {
+		t.Fatalf("expected permissions refreshed from mint, got %+v", creds)
+	}
+}
+
+func TestExpiredPersonalAccessTokenIsRejected(t *testing.T) {
+	s := newAccountsTestService(t)
+	expired := time.Now().Add(-time.Hour)
+	if _, err := s.SaveGitHubCredential(GitHubAccount{Login: "ci", Kind: GitHubCredentialFineGrainedPAT, ExpiresAt: &expired}, "github_pat_x"); err != nil {
+		t.Fatalf("SaveGitHubCredential() error: %v", err)
+	}
+	if _, err := s.GitHubTokenForAccount("ci"); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("expected expired token error, got %v", err)
+	}
+	if creds, ok := s.GitHubCredentialForAccount("ci"); !ok || creds.Kind != GitHubCredentialFineGrainedPAT {
+		t.Fatalf("unexpected credential: %+v", creds)
+	}
+}
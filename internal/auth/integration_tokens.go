@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"orch/internal/config"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keychainIntegrationTokenPrefix guarda tokens de integrações externas (Jira, Linear...) no Keychain.
+const keychainIntegrationTokenPrefix = "integration_token:"
+
+// SetIntegrationToken grava o token de uma integração no Keychain; token vazio remove.
+func SetIntegrationToken(integration string, token string) error {
+	integration = strings.TrimSpace(integration)
+	if integration == "" {
+		return fmt.Errorf("integration name cannot be empty")
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return DeleteIntegrationToken(integration)
+	}
+	if err := keyring.Set(config.KeychainService(), keychainIntegrationTokenPrefix+integration, token); err != nil {
+		return fmt.Errorf("failed to store integration token: %w", err)
+	}
+	return nil
+}
+
+// GetIntegrationToken lê o token de uma integração; ausente retorna "" sem erro.
+func GetIntegrationToken(integration string) (string, error) {
+	token, err := keyring.Get(config.KeychainService(), keychainIntegrationTokenPrefix+strings.TrimSpace(integration))
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", nil
+	}
+	return token, err
+}
+
+// DeleteIntegrationToken remove o token de uma integração (ausente não é erro).
+func DeleteIntegrationToken(integration string) error {
+	err := keyring.Delete(config.KeychainService(), keychainIntegrationTokenPrefix+strings.TrimSpace(integration))
+	if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"orch/internal/config"
+	"orch/internal/httpclient"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	// Chave AES-256 dos refresh tokens, separada do valor cifrado no Keychain.
+	keychainTokenEncryptionKey = "token_encryption_key"
+	encryptedTokenPrefix       = "enc:v1:"
+
+	tokenRefreshLeadTime     = 5 * time.Minute  // renova antes de expirar
+	tokenRefreshMinInterval  = 30 * time.Second // intervalo mínimo entre tentativas
+	tokenRefreshIdleInterval = time.Minute      // sem sessão: checa de novo depois
+	tokenRefreshDedupWindow  = 10 * time.Second // refresh recente atende chamadas concorrentes
+)
+
+// Motivos do refresh emitidos em TokenRefreshedEvent.
+const (
+	TokenRefreshProactive    = "proactive"
+	TokenRefreshExpired      = "expired"
+	TokenRefreshUnauthorized = "unauthorized"
+)
+
+// TokenRefreshedHandler recebe o evento após cada refresh bem-sucedido.
+type TokenRefreshedHandler func(event TokenRefreshedEvent)
+
+// SetTokenRefreshedHandler define o callback de refresh (App emite auth:token_refreshed).
+func (s *Service) SetTokenRefreshedHandler(handler TokenRefreshedHandler) {
+	s.refreshMu.Lock()
+	s.onRefreshed = handler
+	s.refreshMu.Unlock()
+}
+
+// refreshSession renova a sessão usando o refresh token, serializando chamadas concorrentes.
+// Refresh tokens do Supabase são de uso único: dois refreshes paralelos invalidariam a sessão.
+func (s *Service) refreshSession(reason string) error {
+	s.refreshMu.Lock()
+	if !s.lastRefreshAt.IsZero() && time.Since(s.lastRefreshAt) < tokenRefreshDedupWindow {
+		s.refreshMu.Unlock()
+		return nil
+	}
+	pair, err := s.requestTokenRefresh()
+	if err == nil {
+		err = s.storeTokens(pair)
+	}
+	if err != nil {
+		s.refreshMu.Unlock()
+		return err
+	}
+	s.lastRefreshAt = time.Now()
+	handler := s.onRefreshed
+	s.refreshMu.Unlock()
+
+	if handler != nil {
+		handler(TokenRefreshedEvent{Provider: pair.Provider, ExpiresAt: pair.ExpiresAt, Reason: reason})
+	}
+	return nil
+}
+
+// RefreshGitHubAccountToken renova o token da conta após 401 do GitHub ("" = conta ativa).
+// Retorna true quando há um token novo para repetir o request.
+func (s *Service) RefreshGitHubAccountToken(accountID string) bool {
+	id := NormalizeGitHubAccountID(accountID)
+	if id == "" {
+		id = s.activeStoredAccountID()
+	}
+	if id != "" {
+		s.accountsMu.Lock()
+		_, stored := s.loadStoredAccountsLocked()[id]
+		// Token de instalação em cache pode ter sido revogado: força novo mint.
+		_, minted := s.appTokens[id]
+		delete(s.appTokens, id)
+		s.accountsMu.Unlock()
+		if stored {
+			return minted
+		}
+		if primary := s.primaryGitHubAccount(); primary == nil || primary.ID != id {
+			return false
+		}
+	}
+
+	return s.RefreshSessionAfterUnauthorized()
+}
+
+// RefreshSessionAfterUnauthorized renova a sessão principal após 401 do GitHub.
+func (s *Service) RefreshSessionAfterUnauthorized() bool {
+	if err := s.refreshSession(TokenRefreshUnauthorized); err != nil {
+		log.Printf("[AUTH] GitHub token refresh after 401 failed: %v", err)
+		return false
+	}
+	return true
+}
+
+// StartTokenRefresher renova a sessão em background antes da expiração.
+func (s *Service) StartTokenRefresher() {
+	s.refreshMu.Lock()
+	if s.refreshStop != nil {
+		s.refreshMu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	s.refreshStop = stop
+	s.refreshMu.Unlock()
+
+	go func() {
+		for {
+			timer := time.NewTimer(s.nextProactiveRefreshIn())
+			select {
+			case <-stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			if refreshToken, err := s.getRefreshToken(); err != nil || refreshToken == "" {
+				continue
+			}
+			if s.nextProactiveRefreshIn() > tokenRefreshMinInterval {
+				continue
+			}
+			if err := s.refreshSession(TokenRefreshProactive); err != nil {
+				log.Printf("[AUTH] proactive token refresh failed: %v", err)
+			}
+		}
+	}()
+}
+
+// StopTokenRefresher encerra o refresh em background.
+func (s *Service) StopTokenRefresher() {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+	if s.refreshStop != nil {
+		close(s.refreshStop)
+		s.refreshStop = nil
+	}
+}
+
+// nextProactiveRefreshIn calcula quanto esperar até o próximo refresh proativo.
+func (s *Service) nextProactiveRefreshIn() time.Duration {
+	expiresStr, err := keyring.Get(config.KeychainService(), keychainExpiresAt)
+	if err != nil {
+		return tokenRefreshIdleInterval
+	}
+	expiresAt, err := time.Parse(time.RFC3339, strings.TrimSpace(expiresStr))
+	if err != nil {
+		return tokenRefreshIdleInterval
+	}
+	wait := time.Until(expiresAt.Add(-tokenRefreshLeadTime))
+	if wait < tokenRefreshMinInterval {
+		return tokenRefreshMinInterval
+	}
+	return wait
+}
+
+func (s *Service) httpClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return httpclient.Client(httpclient.ServiceAuth)
+}
+
+// === Cifragem de refresh tokens ===
+
+// tokenEncryptionKey lê (ou cria) a chave AES-256 guardada no Keychain.
+func tokenEncryptionKey() ([]byte, error) {
+	if encoded, err := keyring.Get(config.KeychainService(), keychainTokenEncryptionKey); err == nil {
+		if key, decodeErr := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded)); decodeErr == nil && len(key) == 32 {
+			return key, nil
+		}
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate token encryption key: %w", err)
+	}
+	if err := keyring.Set(config.KeychainService(), keychainTokenEncryptionKey, base64.StdEncoding.EncodeToString(key)); err != nil {
+		return nil, fmt.Errorf("failed to store token encryption key: %w", err)
+	}
+	return key, nil
+}
+
+func tokenCipher() (cipher.AEAD, error) {
+	key, err := tokenEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptToken cifra o token com AES-GCM ("enc:v1:" + base64(nonce|ciphertext)).
+func encryptToken(plain string) (string, error) {
+	if plain == "" {
+		return "", nil
+	}
+	aead, err := tokenCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plain), nil)
+	return encryptedTokenPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptToken decifra valores "enc:v1:"; valores antigos em texto puro passam direto.
+func decryptToken(stored string) (string, error) {
+	encoded, ok := strings.CutPrefix(stored, encryptedTokenPrefix)
+	if !ok {
+		return stored, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted token: %w", err)
+	}
+	aead, err := tokenCipher()
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("invalid encrypted token")
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt token: %w", err)
+	}
+	return string(plain), nil
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"orch/internal/config"
+
+	"github.com/zalando/go-keyring"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func TestStoreTokensEncryptsRefreshToken(t *testing.T) {
+	s := newAccountsTestService(t)
+
+	if err := s.storeTokens(&TokenPair{AccessToken: "access", RefreshToken: "refresh-secret", Provider: "github", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("storeTokens() error: %v", err)
+	}
+	raw, _ := keyring.Get(config.KeychainService(), keychainRefreshToken)
+	if !strings.HasPrefix(raw, encryptedTokenPrefix) || strings.Contains(raw, "refresh-secret") {
+		t.Fatalf("expected refresh token encrypted at rest, got %q", raw)
+	}
+	if token, err := s.getRefreshToken(); err != nil || token != "refresh-secret" {
+		t.Fatalf("expected decrypted refresh token, got %q err=%v", token, err)
+	}
+
+	// Valores gravados antes da cifragem continuam legíveis.
+	_ = keyring.Set(config.KeychainService(), keychainRefreshToken, "legacy-refresh")
+	if token, err := s.getRefreshToken(); err != nil || token != "legacy-refresh" {
+		t.Fatalf("expected legacy plaintext refresh token, got %q err=%v", token, err)
+	}
+}
+
+func TestRefreshSessionEmitsEventAndDeduplicates(t *testing.T) {
+	s := newAccountsTestService(t)
+	if err := s.storeTokens(&TokenPair{AccessToken: "old-access", RefreshToken: "old-refresh", ProviderAccessToken: "primary-token", Provider: "github", ExpiresAt: time.Now().Add(time.Minute)}); err != nil {
+		t.Fatalf("storeTokens() error: %v", err)
+	}
+
+	requests := 0
+	s.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		body, _ := io.ReadAll(req.Body)
+		if !strings.Contains(string(body), "refresh_token=old-refresh") {
+			t.Fatalf("expected decrypted refresh token in request, got %q", body)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"access_token":"new-access","refresh_token":"new-refresh","provider_token":"new-gh","expires_in":3600}`)),
+		}, nil
+	})}
+	var events []TokenRefreshedEvent
+	s.SetTokenRefreshedHandler(func(event TokenRefreshedEvent) {
+		events = append(events, event)
+	})
+
+	if !s.RefreshGitHubAccountToken("dev") {
+		t.Fatalf("expected primary account refresh after 401")
+	}
+	if err := s.RefreshToken(); err != nil {
+		t.Fatalf("RefreshToken() error: %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected concurrent refreshes to be deduplicated, got %d requests", requests)
+	}
+	if len(events) != 1 || events[0].Reason != TokenRefreshUnauthorized || events[0].Provider != "github" {
+		t.Fatalf("unexpected refresh events: %+v", events)
+	}
+	if token, _ := s.getRefreshToken(); token != "new-refresh" {
+		t.Fatalf("expected rotated refresh token, got %q", token)
+	}
+	if token, _ := s.getProviderAccessToken(); token != "new-gh" {
+		t.Fatalf("expected refreshed provider token, got %q", token)
+	}
+	if wait := s.nextProactiveRefreshIn(); wait < 50*time.Minute || wait > 56*time.Minute {
+		t.Fatalf("expected proactive refresh 5 minutes before expiry, got %s", wait)
+	}
+}
+
+func TestRefreshGitHubAccountTokenSkipsStaticTokens(t *testing.T) {
+	s := newAccountsTestService(t)
+	if _, err := s.AddGitHubAccount("work", "", "", "work-token"); err != nil {
+		t.Fatalf("AddGitHubAccount() error: %v", err)
+	}
+	s.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected session refresh for PAT account")
+		return nil, nil
+	})}
+
+	if s.RefreshGitHubAccountToken("work") {
+		t.Fatalf("expected static token account not to be refreshed")
+	}
+}
@@ -20,6 +20,23 @@ type AuthState struct {
 	HasGitHubToken  bool   `json:"hasGitHubToken"`
 }
 
+// GitHubAccount representa uma conta GitHub disponível para chamadas da API
+type GitHubAccount struct {
+	ID             string            `json:"id"` // login em minúsculas ("app-<installationId>" para GitHub App)
+	Login          string            `json:"login"`
+	Name           string            `json:"name,omitempty"`
+	AvatarURL      string            `json:"avatarUrl,omitempty"`
+	Kind           string            `json:"kind"`                  // oauth | pat_classic | pat_fine_grained | app
+	Scopes         []string          `json:"scopes,omitempty"`      // escopos OAuth (X-OAuth-Scopes)
+	Permissions    map[string]string `json:"permissions,omitempty"` // permissões da instalação (GitHub App)
+	ExpiresAt      *time.Time        `json:"expiresAt,omitempty"`   // expiração do PAT
+	AppID          int64             `json:"appId,omitempty"`
+	InstallationID int64             `json:"installationId,omitempty"`
+	Primary        bool              `json:"primary"` // conta da sessão principal (login OAuth)
+	Active         bool              `json:"active"`  // conta padrão para repositórios sem vínculo
+	AddedAt        time.Time         `json:"addedAt,omitempty"`
+}
+
 // AuthResult é o resultado de uma operação de autenticação
 type AuthResult struct {
 	Success bool   `json:"success"`
@@ -36,6 +53,13 @@ type TokenPair struct {
 	Provider            string    `json:"provider"`
 }
 
+// TokenRefreshedEvent é emitido (auth:token_refreshed) após renovar a sessão
+type TokenRefreshedEvent struct {
+	Provider  string    `json:"provider"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Reason    string    `json:"reason"` // proactive | expired | unauthorized
+}
+
 // PKCEChallenge representa os dados do PKCE flow
 type PKCEChallenge struct {
 	CodeVerifier  string `json:"codeVerifier"`
@@ -0,0 +1,447 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"orch/internal/config"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	// Formato: magic + nonce + AES-256-GCM(tar.gz). O magic entra como dado autenticado.
+	archiveMagic         = "ORCHBAK1"
+	archiveFormatVersion = 1
+	archiveExtension     = ".orchbak"
+	archiveDBName        = "orch_data.db"
+	archiveManifestName  = "manifest.json"
+	archiveConfigPrefix  = "config/"
+
+	// Chave AES-256 dos arquivos de backup (Keychain do usuário).
+	keychainBackupKey = "backup_encryption_key"
+)
+
+// Store é o banco a ser copiado/restaurado (implementado por database.Service).
+type Store interface {
+	SnapshotTo(dest string) error
+	RestoreFromSnapshot(src string) error
+	SchemaVersion() int
+}
+
+// FileEntry descreve um arquivo do backup com checksum SHA-256.
+type FileEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest descreve o conteúdo de um arquivo de backup.
+type Manifest struct {
+	FormatVersion int         `json:"formatVersion"`
+	AppVersion    string      `json:"appVersion"`
+	SchemaVersion int         `json:"schemaVersion"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	Files         []FileEntry `json:"files"`
+	ArchivePath   string      `json:"archivePath,omitempty"`
+	ArchiveSize   int64       `json:"archiveSize,omitempty"`
+}
+
+// Service gera e restaura backups completos (SQLite + arquivos de configuração do DataDir).
+type Service struct {
+	store   Store
+	dataDir string
+	emit    func(eventName string, data interface{})
+
+	opMu sync.Mutex // um backup/restore por vez
+
+	mu       sync.Mutex
+	schedule Schedule
+	status   ScheduleStatus
+	stop     chan struct{}
+	now      func() time.Time
+
+	// Lease entre instâncias: só uma roda o backup agendado do data dir (nil = sempre roda).
+	acquireLease func(resource string) bool
+	releaseLease func(resource string)
+}
+
+// NewService cria o serviço de backup; emit recebe eventos do agendamento (pode ser nil).
+func NewService(store Store, dataDir string, emit func(eventName string, data interface{})) *Service {
+	return &Service{
+		store:   store,
+		dataDir: dataDir,
+		emit:    emit,
+		now:     time.Now,
+	}
+}
+
+// Backup grava um arquivo criptografado em path (diretório = nome gerado automaticamente).
+func (s *Service) Backup(archivePath string) (*Manifest, error) {
+	s.opMu.Lock()
+	defer s.opMu.Unlock()
+
+	target, err := s.resolveArchivePath(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir, err := os.MkdirTemp("", "orch-backup-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workDir)
+
+	snapshotPath := filepath.Join(workDir, archiveDBName)
+	if err := s.store.SnapshotTo(snapshotPath); err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		FormatVersion: archiveFormatVersion,
+		AppVersion:    config.AppVersion,
+		SchemaVersion: s.store.SchemaVersion(),
+		CreatedAt:     s.now().UTC(),
+	}
+	files := map[string]string{archiveDBName: snapshotPath}
+	configFiles, err := s.configFiles()
+	if err != nil {
+		return nil, err
+	}
+	for name, source := range configFiles {
+		files[archiveConfigPrefix+name] = source
+	}
+
+	plain, err := buildTarball(files, manifest)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := encryptArchive(plain)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(target, sealed); err != nil {
+		return nil, err
+	}
+
+	manifest.ArchivePath = target
+	manifest.ArchiveSize = int64(len(sealed))
+	return manifest, nil
+}
+
+// Restore valida checksums do arquivo e substitui banco e configurações locais.
+func (s *Service) Restore(archivePath string) (*Manifest, error) {
+	s.opMu.Lock()
+	defer s.opMu.Unlock()
+
+	sealed, err := os.ReadFile(strings.TrimSpace(archivePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	plain, err := decryptArchive(sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir, err := os.MkdirTemp("", "orch-restore-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workDir)
+
+	manifest, err := extractTarball(plain, workDir)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.FormatVersion > archiveFormatVersion {
+		return nil, fmt.Errorf("backup format v%d is newer than this app supports", manifest.FormatVersion)
+	}
+
+	if err := s.store.RestoreFromSnapshot(filepath.Join(workDir, archiveDBName)); err != nil {
+		return nil, err
+	}
+	for _, entry := range manifest.Files {
+		name, ok := strings.CutPrefix(entry.Path, archiveConfigPrefix)
+		if !ok || config.IsProfileRegistryPath(name) {
+			continue // backups antigos do perfil padrão traziam os outros perfis junto
+		}
+		data, err := os.ReadFile(filepath.Join(workDir, filepath.FromSlash(entry.Path)))
+		if err != nil {
+			return nil, err
+		}
+		if err := writeFileAtomic(filepath.Join(s.dataDir, filepath.FromSlash(name)), data); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", name, err)
+		}
+	}
+
+	manifest.ArchivePath = archivePath
+	manifest.ArchiveSize = int64(len(sealed))
+	return manifest, nil
+}
+
+func (s *Service) resolveArchivePath(archivePath string) (string, error) {
+	archivePath = strings.TrimSpace(archivePath)
+	if archivePath == "" {
+		return "", fmt.Errorf("backup path is required")
+	}
+	if stat, err := os.Stat(archivePath); err == nil && stat.IsDir() {
+		return filepath.Join(archivePath, archiveFileName(s.now())), nil
+	}
+	if !strings.HasSuffix(archivePath, archiveExtension) {
+		archivePath += archiveExtension
+	}
+	return archivePath, nil
+}
+
+// archiveFileName gera nomes ordenáveis cronologicamente (usado na rotação).
+func archiveFileName(at time.Time) string {
+	return "orch-backup-" + at.UTC().Format("20060102T150405") + archiveExtension
+}
+
+// configFiles lista os arquivos de configuração do DataDir (sem banco, logs, backups e os
+// outros perfis, que moram dentro do diretório do perfil padrão).
+func (s *Service) configFiles() (map[string]string, error) {
+	files := make(map[string]string)
+	if strings.TrimSpace(s.dataDir) == "" {
+		return files, nil
+	}
+	err := filepath.WalkDir(s.dataDir, func(current string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		rel, relErr := filepath.Rel(s.dataDir, current)
+		if relErr != nil || rel == "." {
+			return relErr
+		}
+		rel = filepath.ToSlash(rel)
+		if entry.IsDir() {
+			if rel == "logs" || rel == "backups" || config.IsProfileRegistryPath(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || config.IsProfileRegistryPath(rel) || strings.HasPrefix(path.Base(rel), config.DBFileName) || strings.HasSuffix(rel, archiveExtension) {
+			return nil
+		}
+		files[rel] = current
+		return nil
+	})
+	return files, err
+}
+
+// === Tarball + manifest ===
+
+func buildTarball(files map[string]string, manifest *Manifest) ([]byte, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		data, err := os.ReadFile(files[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		sum := sha256.Sum256(data)
+		manifest.Files = append(manifest.Files, FileEntry{Path: name, Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])})
+		if err := writeTarEntry(tw, name, data); err != nil {
+			return nil, err
+		}
+	}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeTarEntry(tw, archiveManifestName, manifestJSON); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeTarEntry(tw *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: time.Now()}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// extractTarball extrai para dir e confere cada arquivo contra o manifest.
+func extractTarball(plain []byte, dir string) (*Manifest, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(plain))
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	sums := make(map[string]string)
+	var manifest *Manifest
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid backup archive: %w", err)
+		}
+		name := path.Clean(header.Name)
+		if header.Typeflag != tar.TypeReg || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("invalid backup entry: %s", header.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		if name == archiveManifestName {
+			manifest = &Manifest{}
+			if err := json.Unmarshal(data, manifest); err != nil {
+				return nil, fmt.Errorf("invalid backup manifest: %w", err)
+			}
+			continue
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(target, data, 0600); err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		sums[name] = hex.EncodeToString(sum[:])
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("backup manifest missing")
+	}
+
+	hasDB := false
+	for _, entry := range manifest.Files {
+		if sums[entry.Path] != entry.SHA256 {
+			return nil, fmt.Errorf("checksum mismatch for %s", entry.Path)
+		}
+		delete(sums, entry.Path)
+		hasDB = hasDB || entry.Path == archiveDBName
+	}
+	if len(sums) > 0 || !hasDB {
+		return nil, fmt.Errorf("backup contents do not match manifest")
+	}
+	return manifest, nil
+}
+
+// === Criptografia ===
+
+// backupKey lê (ou cria) a chave AES-256 de backups no Keychain.
+func backupKey() ([]byte, error) {
+	if encoded, err := keyring.Get(config.KeychainService(), keychainBackupKey); err == nil {
+		if key, decodeErr := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded)); decodeErr == nil && len(key) == 32 {
+			return key, nil
+		}
+	}
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate backup key: %w", err)
+	}
+	if err := keyring.Set(config.KeychainService(), keychainBackupKey, base64.StdEncoding.EncodeToString(key)); err != nil {
+		return nil, fmt.Errorf("failed to store backup key: %w", err)
+	}
+	return key, nil
+}
+
+func archiveCipher() (cipher.AEAD, error) {
+	key, err := backupKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encryptArchive(plain []byte) ([]byte, error) {
+	aead, err := archiveCipher()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	out := append([]byte(archiveMagic), nonce...)
+	return aead.Seal(out, nonce, plain, []byte(archiveMagic)), nil
+}
+
+func decryptArchive(sealed []byte) ([]byte, error) {
+	if !bytes.HasPrefix(sealed, []byte(archiveMagic)) {
+		return nil, fmt.Errorf("not an ORCH backup archive")
+	}
+	aead, err := archiveCipher()
+	if err != nil {
+		return nil, err
+	}
+	body := sealed[len(archiveMagic):]
+	if len(body) < aead.NonceSize() {
+		return nil, fmt.Errorf("backup archive truncated")
+	}
+	plain, err := aead.Open(nil, body[:aead.NonceSize()], body[aead.NonceSize():], []byte(archiveMagic))
+	if err != nil {
+		return nil, fmt.Errorf("backup archive is corrupted or was encrypted with another key")
+	}
+	return plain, nil
+}
+
+func writeFileAtomic(target string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".orch-tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Chmod(tmpName, 0600); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return os.Rename(tmpName, target)
+}
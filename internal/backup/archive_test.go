@@ -0,0 +1,185 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"orch/internal/database"
+
+	"github.com/zalando/go-keyring"
+)
+
+func newBackupTestService(t *testing.T) (*Service, *database.Service, string) {
+	t.Helper()
+	keyring.MockInit()
+	root := t.TempDir()
+	dataDir := filepath.Join(root, "data")
+	if err := os.MkdirAll(dataDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ORCH_DB_PATH", filepath.Join(dataDir, "orch_data.db"))
+	db, err := database.NewService()
+	if err != nil {
+		t.Fatalf("failed to init database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return NewService(db, dataDir, nil), db, root
+}
+
+func workspaceNames(t *testing.T, db *database.Service) string {
+	t.Helper()
+	workspaces, err := db.ListWorkspaces()
+	if err != nil {
+		t.Fatalf("ListWorkspaces() error: %v", err)
+	}
+	names := make([]string, 0, len(workspaces))
+	for _, ws := range workspaces {
+		names = append(names, ws.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestBackupRestoreRoundTrip(t *testing.T) {
+	svc, db, root := newBackupTestService(t)
+	if err := db.CreateWorkspace(&database.Workspace{UserID: "local", Name: "Keep"}); err != nil {
+		t.Fatalf("CreateWorkspace() error: %v", err)
+	}
+	settingsPath := filepath.Join(svc.dataDir, "settings", "layout.json")
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(settingsPath, []byte(`{"v":1}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	before := workspaceNames(t, db)
+
+	manifest, err := svc.Backup(filepath.Join(root, "exports", "snapshot"))
+	if err != nil {
+		t.Fatalf("Backup() error: %v", err)
+	}
+	if !strings.HasSuffix(manifest.ArchivePath, archiveExtension) || len(manifest.Files) != 2 || manifest.SchemaVersion != database.LatestSchemaVersion() {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	sealed, _ := os.ReadFile(manifest.ArchivePath)
+	if strings.Contains(string(sealed), `{"v":1}`) || strings.Contains(string(sealed), "SQLite format") {
+		t.Fatalf("expected archive contents to be encrypted")
+	}
+
+	if err := db.CreateWorkspace(&database.Workspace{UserID: "local", Name: "Discard"}); err != nil {
+		t.Fatalf("CreateWorkspace() error: %v", err)
+	}
+	_ = os.WriteFile(settingsPath, []byte(`{"v":2}`), 0600)
+
+	if _, err := svc.Restore(manifest.ArchivePath); err != nil {
+		t.Fatalf("Restore() error: %v", err)
+	}
+	if got := workspaceNames(t, db); got != before {
+		t.Fatalf("expected workspaces restored to %q, got %q", before, got)
+	}
+	if data, _ := os.ReadFile(settingsPath); string(data) != `{"v":1}` {
+		t.Fatalf("expected config file restored, got %s", data)
+	}
+	if err := db.CreateWorkspace(&database.Workspace{UserID: "local", Name: "After"}); err != nil {
+		t.Fatalf("expected database writable after restore: %v", err)
+	}
+}
+
+func TestBackupSkipsOtherProfiles(t *testing.T) {
+	svc, db, root := newBackupTestService(t)
+	profilePath := filepath.Join(svc.dataDir, "profiles", "work", "settings.json")
+	if err := os.MkdirAll(filepath.Dir(profilePath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.WriteFile(profilePath, []byte(`{"profile":"work"}`), 0600)
+	_ = os.WriteFile(filepath.Join(svc.dataDir, "active_profile"), []byte("work"), 0600)
+	_ = os.WriteFile(filepath.Join(svc.dataDir, "layout.json"), []byte(`{"v":1}`), 0600)
+
+	manifest, err := svc.Backup(root)
+	if err != nil {
+		t.Fatalf("Backup() error: %v", err)
+	}
+	for _, entry := range manifest.Files {
+		if strings.Contains(entry.Path, "profiles/") || strings.HasSuffix(entry.Path, "active_profile") {
+			t.Fatalf("expected other profiles left out of the backup, got %s", entry.Path)
+		}
+	}
+
+	// Arquivos antigos ainda trazem os outros perfis: o restore não pode sobrescrevê-los.
+	snapshot := filepath.Join(root, "snapshot.db")
+	if err := db.SnapshotTo(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	staleProfile := filepath.Join(root, "stale.json")
+	_ = os.WriteFile(staleProfile, []byte(`{"profile":"stale"}`), 0600)
+	plain, err := buildTarball(map[string]string{
+		archiveDBName: snapshot,
+		archiveConfigPrefix + "profiles/work/settings.json": staleProfile,
+		archiveConfigPrefix + "active_profile":              staleProfile,
+	}, &Manifest{FormatVersion: archiveFormatVersion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := encryptArchive(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy := filepath.Join(root, "legacy"+archiveExtension)
+	_ = os.WriteFile(legacy, sealed, 0600)
+
+	if _, err := svc.Restore(legacy); err != nil {
+		t.Fatalf("Restore() error: %v", err)
+	}
+	if data, _ := os.ReadFile(profilePath); string(data) != `{"profile":"work"}` {
+		t.Fatalf("expected other profile untouched by restore, got %s", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(svc.dataDir, "active_profile")); string(data) != "work" {
+		t.Fatalf("expected active profile untouched by restore, got %s", data)
+	}
+}
+
+func TestRestoreRejectsTamperedArchive(t *testing.T) {
+	svc, _, root := newBackupTestService(t)
+	manifest, err := svc.Backup(root)
+	if err != nil {
+		t.Fatalf("Backup() error: %v", err)
+	}
+	sealed, _ := os.ReadFile(manifest.ArchivePath)
+	sealed[len(sealed)-1] ^= 0xff
+	tampered := filepath.Join(root, "tampered"+archiveExtension)
+	_ = os.WriteFile(tampered, sealed, 0600)
+
+	if _, err := svc.Restore(tampered); err == nil || !strings.Contains(err.Error(), "corrupted") {
+		t.Fatalf("expected tampered archive to be rejected, got %v", err)
+	}
+	_ = os.WriteFile(tampered, []byte("plain text"), 0600)
+	if _, err := svc.Restore(tampered); err == nil {
+		t.Fatalf("expected non-backup file to be rejected")
+	}
+}
+
+func TestScheduledBackupsRotate(t *testing.T) {
+	svc, _, root := newBackupTestService(t)
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time {
+		clock = clock.Add(time.Hour)
+		return clock
+	}
+	dir := filepath.Join(root, "rolling")
+	status := svc.ConfigureSchedule(Schedule{Retention: 2, Dir: dir})
+	if status.Enabled || status.IntervalHours != defaultIntervalHours || status.NextBackupAt != nil {
+		t.Fatalf("unexpected disabled schedule status: %+v", status)
+	}
+
+	for i := 0; i < 3; i++ {
+		if status = svc.RunScheduledBackup(); status.LastError != "" {
+			t.Fatalf("scheduled backup failed: %s", status.LastError)
+		}
+	}
+	archives := listArchives(dir)
+	if len(archives) != 2 || archives[1] != status.LastBackupPath {
+		t.Fatalf("expected rotation to keep 2 newest archives, got %v (last=%s)", archives, status.LastBackupPath)
+	}
+}
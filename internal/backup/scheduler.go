@@ -0,0 +1,239 @@
+package backup
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultIntervalHours = 24
+	maxIntervalHours     = 24 * 30
+	defaultRetention     = 7
+	maxRetention         = 100
+
+	scheduleLeaseName = "backup.scheduled"
+)
+
+// Schedule configura o backup automático rotativo.
+type Schedule struct {
+	Enabled       bool   `json:"enabled"`
+	IntervalHours int    `json:"intervalHours"`
+	Retention     int    `json:"retention"` // arquivos mantidos no diretório
+	Dir           string `json:"dir"`
+}
+
+// ScheduleStatus expõe o agendamento e o resultado do último backup automático.
+type ScheduleStatus struct {
+	Schedule
+	LastBackupAt   *time.Time `json:"lastBackupAt,omitempty"`
+	LastBackupPath string     `json:"lastBackupPath,omitempty"`
+	NextBackupAt   *time.Time `json:"nextBackupAt,omitempty"`
+	LastError      string     `json:"lastError,omitempty"`
+}
+
+// DefaultDir retorna o diretório padrão dos backups automáticos.
+func DefaultDir(dataDir string) string {
+	return filepath.Join(dataDir, "backups", "archives")
+}
+
+// NormalizeSchedule aplica defaults e limites ao agendamento.
+func NormalizeSchedule(schedule Schedule, dataDir string) Schedule {
+	if schedule.IntervalHours <= 0 {
+		schedule.IntervalHours = defaultIntervalHours
+	}
+	if schedule.IntervalHours > maxIntervalHours {
+		schedule.IntervalHours = maxIntervalHours
+	}
+	if schedule.Retention <= 0 {
+		schedule.Retention = defaultRetention
+	}
+	if schedule.Retention > maxRetention {
+		schedule.Retention = maxRetention
+	}
+	schedule.Dir = strings.TrimSpace(schedule.Dir)
+	if schedule.Dir == "" {
+		schedule.Dir = DefaultDir(dataDir)
+	}
+	return schedule
+}
+
+// ConfigureSchedule (re)inicia o agendamento; desabilitado apenas para o loop.
+func (s *Service) ConfigureSchedule(schedule Schedule) ScheduleStatus {
+	schedule = NormalizeSchedule(schedule, s.dataDir)
+	s.Stop()
+
+	s.mu.Lock()
+	s.schedule = schedule
+	s.status.Schedule = schedule
+	s.status.LastError = ""
+	if last, lastPath := latestArchive(schedule.Dir); !last.IsZero() {
+		s.status.LastBackupAt = &last
+		s.status.LastBackupPath = lastPath
+	}
+	if schedule.Enabled {
+		stop := make(chan struct{})
+		s.stop = stop
+		go s.runSchedule(stop)
+	}
+	s.mu.Unlock()
+	return s.ScheduleStatus()
+}
+
+// ScheduleStatus retorna o estado atual do agendamento.
+func (s *Service) ScheduleStatus() ScheduleStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := s.status
+	if s.schedule.Enabled {
+		next := s.nextBackupAtLocked()
+		status.NextBackupAt = &next
+	}
+	return status
+}
+
+// SetLeaseGate faz o agendamento consultar o lease do backup automático, para que duas
+// instâncias no mesmo data dir não gerem backups duplicados.
+func (s *Service) SetLeaseGate(acquire func(resource string) bool, release func(resource string)) {
+	s.mu.Lock()
+	s.acquireLease = acquire
+	s.releaseLease = release
+	s.mu.Unlock()
+}
+
+// Stop encerra o agendamento.
+func (s *Service) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+		if s.releaseLease != nil {
+			go s.releaseLease(scheduleLeaseName)
+		}
+	}
+}
+
+func (s *Service) nextBackupAtLocked() time.Time {
+	interval := time.Duration(s.schedule.IntervalHours) * time.Hour
+	if s.status.LastBackupAt == nil {
+		return s.now()
+	}
+	return s.status.LastBackupAt.Add(interval)
+}
+
+func (s *Service) runSchedule(stop chan struct{}) {
+	for {
+		s.mu.Lock()
+		wait := s.nextBackupAtLocked().Sub(s.now())
+		s.mu.Unlock()
+		if wait < 0 {
+			wait = 0
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if !s.holdsScheduleLease() {
+			s.deferToLeaseHolder()
+			continue
+		}
+		s.RunScheduledBackup()
+	}
+}
+
+func (s *Service) holdsScheduleLease() bool {
+	s.mu.Lock()
+	acquire := s.acquireLease
+	s.mu.Unlock()
+	return acquire == nil || acquire(scheduleLeaseName)
+}
+
+// deferToLeaseHolder adia o próximo ciclo: outra instância faz o backup deste data dir.
+// Usa o arquivo mais recente do diretório quando existir, para seguir o ritmo do dono do lease.
+func (s *Service) deferToLeaseHolder() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	last, lastPath := latestArchive(s.schedule.Dir)
+	if last.IsZero() || !last.Add(time.Duration(s.schedule.IntervalHours)*time.Hour).After(now) {
+		last, lastPath = now, s.status.LastBackupPath
+	}
+	s.status.LastBackupAt = &last
+	s.status.LastBackupPath = lastPath
+}
+
+// RunScheduledBackup executa um backup no diretório agendado e aplica a rotação.
+func (s *Service) RunScheduledBackup() ScheduleStatus {
+	s.mu.Lock()
+	schedule := s.schedule
+	s.mu.Unlock()
+
+	var manifest *Manifest
+	err := os.MkdirAll(schedule.Dir, 0700)
+	if err == nil {
+		manifest, err = s.Backup(schedule.Dir)
+	}
+	now := s.now()
+
+	s.mu.Lock()
+	if err != nil {
+		log.Printf("[BACKUP] scheduled backup failed: %v", err)
+		s.status.LastError = err.Error()
+		// Evita laço apertado em falhas persistentes: tenta no próximo intervalo.
+		s.status.LastBackupAt = &now
+	} else {
+		s.status.LastError = ""
+		s.status.LastBackupAt = &now
+		s.status.LastBackupPath = manifest.ArchivePath
+		pruneArchives(schedule.Dir, schedule.Retention)
+	}
+	s.mu.Unlock()
+
+	status := s.ScheduleStatus()
+	if s.emit != nil {
+		s.emit("database:backup:scheduled", status)
+	}
+	return status
+}
+
+// latestArchive retorna data e caminho do backup automático mais recente no diretório.
+func latestArchive(dir string) (time.Time, string) {
+	archives := listArchives(dir)
+	if len(archives) == 0 {
+		return time.Time{}, ""
+	}
+	latest := archives[len(archives)-1]
+	stat, err := os.Stat(latest)
+	if err != nil {
+		return time.Time{}, ""
+	}
+	return stat.ModTime(), latest
+}
+
+func listArchives(dir string) []string {
+	matches, err := filepath.Glob(filepath.Join(dir, "orch-backup-*"+archiveExtension))
+	if err != nil {
+		return nil
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+func pruneArchives(dir string, retention int) {
+	archives := listArchives(dir)
+	if len(archives) <= retention {
+		return
+	}
+	for _, stale := range archives[:len(archives)-retention] {
+		if err := os.Remove(stale); err != nil {
+			log.Printf("[BACKUP] failed to remove old backup %s: %v", stale, err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package cliapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gh "orch/internal/github"
+	gp "orch/internal/gitpanel"
+)
+
+type fakeBackend struct {
+	started []StackBuildRequest
+	cursors []int64
+}
+
+func (f *fakeBackend) Workspaces() ([]Workspace, error) {
+	return []Workspace{{ID: 1, Name: "api", Agents: []Agent{{ID: 7, Name: "claude", Running: true}}}}, nil
+}
+
+func (f *fakeBackend) PullRequests(workspaceID uint, state string) ([]gh.PullRequest, error) {
+	if workspaceID != 1 {
+		return nil, errors.New("workspace not found")
+	}
+	return []gh.PullRequest{{Number: 42, Title: "Fix login", State: strings.ToUpper(state)}}, nil
+}
+
+func (f *fakeBackend) GitStatus(uint) (gp.StatusDTO, error) {
+	return gp.StatusDTO{Branch: "main", Ahead: 2}, nil
+}
+
+func (f *fakeBackend) TerminalOutput(_ uint, cursor int64) (TerminalChunk, error) {
+	f.cursors = append(f.cursors, cursor)
+	return TerminalChunk{Output: "$ make\n", Cursor: cursor + 7, Running: true}, nil
+}
+
+func (f *fakeBackend) StartStackBuild(req StackBuildRequest) error {
+	if len(f.started) > 0 {
+		return errors.New("a build is already in progress")
+	}
+	f.started = append(f.started, req)
+	return nil
+}
+
+func (f *fakeBackend) StackBuildState() (StackBuildState, error) {
+	return StackBuildState{IsBuilding: true, Logs: []string{"step 1"}}, nil
+}
+
+func TestHandlerRequiresToken(t *testing.T) {
+	server := httptest.NewServer(NewHandler(&fakeBackend{}, "secret"))
+	defer server.Close()
+
+	if _, err := NewClient(server.URL, "wrong", nil).Workspaces(); err == nil || !strings.Contains(err.Error(), "invalid or missing CLI token") {
+		t.Fatalf("expected token rejection, got %v", err)
+	}
+	resp, err := http.Get(server.URL + routeWorkspaces)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without token, got %d", resp.StatusCode)
+	}
+}
+
+func TestClientRoundTrip(t *testing.T) {
+	backend := &fakeBackend{}
+	server := httptest.NewServer(NewHandler(backend, "secret"))
+	defer server.Close()
+	client := NewClient(server.URL+"/", "secret", server.Client())
+
+	workspaces, err := client.Workspaces()
+	if err != nil || len(workspaces) != 1 || workspaces[0].Agents[0].ID != 7 {
+		t.Fatalf("unexpected workspaces: %+v (%v)", workspaces, err)
+	}
+	prs, err := client.PullRequests(1, "open")
+	if err != nil || len(prs) != 1 || prs[0].Number != 42 || prs[0].State != "OPEN" {
+		t.Fatalf("unexpected prs: %+v (%v)", prs, err)
+	}
+	if _, err := client.PullRequests(9, ""); err == nil || !strings.Contains(err.Error(), "workspace not found") {
+		t.Fatalf("expected backend error to surface, got %v", err)
+	}
+	if status, err := client.GitStatus(1); err != nil || status.Branch != "main" || status.Ahead != 2 {
+		t.Fatalf("unexpected status: %+v (%v)", status, err)
+	}
+	chunk, err := client.TerminalOutput(7, 0)
+	if err != nil || chunk.Output != "$ make\n" {
+		t.Fatalf("unexpected chunk: %+v (%v)", chunk, err)
+	}
+	if _, err := client.TerminalOutput(7, chunk.Cursor); err != nil || backend.cursors[1] != 7 {
+		t.Fatalf("expected cursor to be forwarded, got %v (%v)", backend.cursors, err)
+	}
+
+	if err := client.StartStackBuild(StackBuildRequest{Name: "go"}); err != nil || backend.started[0].Name != "go" {
+		t.Fatalf("unexpected build start: %+v (%v)", backend.started, err)
+	}
+	if err := client.StartStackBuild(StackBuildRequest{}); err == nil || !strings.Contains(err.Error(), "already in progress") {
+		t.Fatalf("expected concurrent build to be rejected, got %v", err)
+	}
+	if state, err := client.StackBuildState(); err != nil || !state.IsBuilding || state.Logs[0] != "step 1" {
+		t.Fatalf("unexpected build state: %+v (%v)", state, err)
+	}
+}
+
+func TestLoadOrCreateTokenIsStable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", TokenFileName)
+	first, err := LoadOrCreateToken(path)
+	if err != nil || len(first) != 64 {
+		t.Fatalf("unexpected token %q (%v)", first, err)
+	}
+	second, err := LoadOrCreateToken(path)
+	if err != nil || second != first {
+		t.Fatalf("expected the saved token to be reused, got %q (%v)", second, err)
+	}
+	if read, err := ReadToken(path); err != nil || read != first {
+		t.Fatalf("ReadToken returned %q (%v)", read, err)
+	}
+}
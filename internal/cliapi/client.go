@@ -0,0 +1,187 @@
+package cliapi
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"orch/internal/config"
+	gh "orch/internal/github"
+	gp "orch/internal/gitpanel"
+	"orch/internal/session"
+)
+
+// TokenFileName é o arquivo (no data dir do app) com o token exigido pelas rotas do CLI.
+const TokenFileName = "cli-token"
+
+// TokenPath retorna o caminho padrão do token do CLI.
+func TokenPath() string {
+	return filepath.Join(config.DataDir(), TokenFileName)
+}
+
+// LoadOrCreateToken lê o token salvo em path ou gera um novo (arquivo 0600).
+func LoadOrCreateToken(path string) (string, error) {
+	if token, err := ReadToken(path); err == nil {
+		return token, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate cli token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("create cli token dir: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("write cli token: %w", err)
+	}
+	return token, nil
+}
+
+// ReadToken lê o token gravado pelo app.
+func ReadToken(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(raw))
+	if token == "" {
+		return "", fmt.Errorf("cli token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// Client acessa o gateway local do app (rotas do CLI e de sessão) e implementa Backend.
+type Client struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewClient cria o client; baseURL é a URL do gateway local (sem barra final).
+func NewClient(baseURL string, token string, client *http.Client) *Client {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Client{baseURL: strings.TrimRight(strings.TrimSpace(baseURL), "/"), token: token, client: client}
+}
+
+// Workspaces implementa Backend.
+func (c *Client) Workspaces() ([]Workspace, error) {
+	var workspaces []Workspace
+	err := c.do(http.MethodGet, routeWorkspaces, nil, nil, &workspaces)
+	return workspaces, err
+}
+
+// PullRequests implementa Backend.
+func (c *Client) PullRequests(workspaceID uint, state string) ([]gh.PullRequest, error) {
+	query := url.Values{"workspaceID": {strconv.FormatUint(uint64(workspaceID), 10)}, "state": {state}}
+	var prs []gh.PullRequest
+	err := c.do(http.MethodGet, routePullRequests, query, nil, &prs)
+	return prs, err
+}
+
+// GitStatus implementa Backend.
+func (c *Client) GitStatus(workspaceID uint) (gp.StatusDTO, error) {
+	query := url.Values{"workspaceID": {strconv.FormatUint(uint64(workspaceID), 10)}}
+	var status gp.StatusDTO
+	err := c.do(http.MethodGet, routeGitStatus, query, nil, &status)
+	return status, err
+}
+
+// TerminalOutput implementa Backend.
+func (c *Client) TerminalOutput(agentID uint, cursor int64) (TerminalChunk, error) {
+	query := url.Values{"agentID": {strconv.FormatUint(uint64(agentID), 10)}, "cursor": {strconv.FormatInt(cursor, 10)}}
+	var chunk TerminalChunk
+	err := c.do(http.MethodGet, routeTerminalOutput, query, nil, &chunk)
+	return chunk, err
+}
+
+// StartStackBuild implementa Backend.
+func (c *Client) StartStackBuild(req StackBuildRequest) error {
+	return c.do(http.MethodPost, routeStackBuild, nil, req, nil)
+}
+
+// StackBuildState implementa Backend.
+func (c *Client) StackBuildState() (StackBuildState, error) {
+	var state StackBuildState
+	err := c.do(http.MethodGet, routeStackBuild, nil, nil, &state)
+	return state, err
+}
+
+// JoinSession pede entrada numa sessão de colaboração pelo código (rota de sessão do gateway).
+func (c *Client) JoinSession(code string, guestUserID string, info session.GuestInfo) (*session.JoinResult, error) {
+	payload := map[string]interface{}{"code": code, "guestUserID": guestUserID, "guestInfo": info}
+	var result session.JoinResult
+	if err := c.do(http.MethodPost, "/api/session/join", nil, payload, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetSession retorna a sessão (inclui o status de cada guest).
+func (c *Client) GetSession(sessionID string) (*session.Session, error) {
+	var sess session.Session
+	if err := c.do(http.MethodGet, "/api/session/get", url.Values{"sessionID": {sessionID}}, nil, &sess); err != nil {
+		return nil, err
+	}
+	return &sess, nil
+}
+
+func (c *Client) do(method string, route string, query url.Values, payload interface{}, out interface{}) error {
+	endpoint := c.baseURL + route
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("marshal gateway request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("build gateway request: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("gateway request failed (is ORCH running?): %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var payload errorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err == nil && payload.Error != "" {
+			return fmt.Errorf("gateway: %s", payload.Error)
+		}
+		return fmt.Errorf("gateway request failed with status %d", resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode gateway response: %w", err)
+	}
+	return nil
+}
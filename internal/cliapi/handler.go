@@ -0,0 +1,181 @@
+package cliapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	gh "orch/internal/github"
+	gp "orch/internal/gitpanel"
+)
+
+// Rotas servidas pelo gateway local (registradas com o prefixo RoutePrefix).
+const (
+	RoutePrefix         = "/api/cli/"
+	routeWorkspaces     = "/api/cli/workspaces"
+	routePullRequests   = "/api/cli/prs"
+	routeGitStatus      = "/api/cli/git/status"
+	routeTerminalOutput = "/api/cli/terminal/output"
+	routeStackBuild     = "/api/cli/stack/build"
+
+	maxCLIRequestBytes = 64 << 10
+)
+
+// Agent é um terminal de agente visto pelo CLI.
+type Agent struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Running bool   `json:"running"`
+}
+
+// Workspace agrupa os agentes de um workspace do app.
+type Workspace struct {
+	ID     uint    `json:"id"`
+	Name   string  `json:"name"`
+	Path   string  `json:"path"`
+	Agents []Agent `json:"agents"`
+}
+
+// TerminalChunk é a saída de um terminal a partir de um cursor (bytes já emitidos pelo terminal).
+// Truncated indica que parte da saída anterior ao cursor já saiu do ring buffer.
+type TerminalChunk struct {
+	Output    string `json:"output"`
+	Cursor    int64  `json:"cursor"`
+	Truncated bool   `json:"truncated,omitempty"`
+	Running   bool   `json:"running"`
+}
+
+// StackBuildRequest inicia o build de uma stack nomeada com as ferramentas já salvas.
+type StackBuildRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
+// StackBuildState espelha o estado do build de stack exibido no app.
+type StackBuildState struct {
+	IsBuilding bool     `json:"isBuilding"`
+	Logs       []string `json:"logs"`
+	StartTime  int64    `json:"startTime"`
+	Result     string   `json:"result"` // "", "success", "error"
+	JobID      string   `json:"jobId,omitempty"`
+}
+
+// Backend é implementado pelo app dono do gateway.
+type Backend interface {
+	Workspaces() ([]Workspace, error)
+	PullRequests(workspaceID uint, state string) ([]gh.PullRequest, error)
+	GitStatus(workspaceID uint) (gp.StatusDTO, error)
+	TerminalOutput(agentID uint, cursor int64) (TerminalChunk, error)
+	StartStackBuild(req StackBuildRequest) error
+	StackBuildState() (StackBuildState, error)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewHandler expõe o backend via HTTP; toda requisição precisa do token em Authorization: Bearer.
+func NewHandler(backend Backend, token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(routeWorkspaces, func(w http.ResponseWriter, r *http.Request) {
+		if !requireMethod(w, r, http.MethodGet) {
+			return
+		}
+		workspaces, err := backend.Workspaces()
+		writeResult(w, workspaces, err)
+	})
+	mux.HandleFunc(routePullRequests, func(w http.ResponseWriter, r *http.Request) {
+		workspaceID, ok := workspaceQuery(w, r)
+		if !ok {
+			return
+		}
+		prs, err := backend.PullRequests(workspaceID, r.URL.Query().Get("state"))
+		writeResult(w, prs, err)
+	})
+	mux.HandleFunc(routeGitStatus, func(w http.ResponseWriter, r *http.Request) {
+		workspaceID, ok := workspaceQuery(w, r)
+		if !ok {
+			return
+		}
+		status, err := backend.GitStatus(workspaceID)
+		writeResult(w, status, err)
+	})
+	mux.HandleFunc(routeTerminalOutput, func(w http.ResponseWriter, r *http.Request) {
+		if !requireMethod(w, r, http.MethodGet) {
+			return
+		}
+		agentID, err := strconv.ParseUint(r.URL.Query().Get("agentID"), 10, 32)
+		if err != nil || agentID == 0 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "agentID is required"})
+			return
+		}
+		cursor, _ := strconv.ParseInt(r.URL.Query().Get("cursor"), 10, 64)
+		chunk, err := backend.TerminalOutput(uint(agentID), max(cursor, 0))
+		writeResult(w, chunk, err)
+	})
+	mux.HandleFunc(routeStackBuild, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			state, err := backend.StackBuildState()
+			writeResult(w, state, err)
+		case http.MethodPost:
+			var req StackBuildRequest
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxCLIRequestBytes)).Decode(&req); err != nil {
+				writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid json payload"})
+				return
+			}
+			if err := backend.StartStackBuild(req); err != nil {
+				writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+				return
+			}
+			writeJSON(w, http.StatusAccepted, map[string]bool{"ok": true})
+		default:
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+		}
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provided := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid or missing CLI token"})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func requireMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+		return false
+	}
+	return true
+}
+
+func workspaceQuery(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return 0, false
+	}
+	workspaceID, err := strconv.ParseUint(r.URL.Query().Get("workspaceID"), 10, 32)
+	if err != nil || workspaceID == 0 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "workspaceID is required"})
+		return 0, false
+	}
+	return uint(workspaceID), true
+}
+
+func writeResult(w http.ResponseWriter, payload interface{}, err error) {
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, payload)
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
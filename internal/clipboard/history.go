@@ -0,0 +1,381 @@
+package clipboard
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"orch/internal/config"
+
+	"github.com/google/uuid"
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	// Formato do arquivo: magic + nonce + AES-256-GCM(JSON). O magic entra como dado autenticado.
+	historyMagic = "ORCHCLP1"
+
+	// Chave AES-256 do histórico (Keychain do usuário).
+	keychainHistoryKey = "clipboard_history_key"
+
+	DefaultMaxEntries = 50
+	maxPinnedEntries  = 50
+	maxEntryLength    = 32 << 10 // textos maiores (dumps, arquivos inteiros) não entram no histórico
+)
+
+// Entry é um texto copiado. Text já passou pela redação de segredos.
+type Entry struct {
+	ID       string    `json:"id"`
+	Text     string    `json:"text"`
+	Redacted bool      `json:"redacted"` // algum segredo foi substituído por [REDACTED]
+	Pinned   bool      `json:"pinned"`
+	CopiedAt time.Time `json:"copiedAt"`
+}
+
+// History guarda os últimos textos copiados, cifrados em disco. Entradas fixadas não contam
+// no limite e sobrevivem ao Clear comum.
+type History struct {
+	mu         sync.Mutex
+	path       string
+	maxEntries int
+	sanitize   func(string) string
+	now        func() time.Time
+	entries    []Entry // mais recente primeiro
+	loaded     bool
+
+	watchStop chan struct{}
+	lastSeen  [32]byte // hash do último conteúdo lido do clipboard (o texto em si não fica em memória)
+}
+
+// NewHistory cria o histórico persistido em path. sanitize (obrigatório) remove segredos antes de
+// qualquer texto ser guardado.
+func NewHistory(path string, maxEntries int, sanitize func(string) string) *History {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &History{
+		path:       path,
+		maxEntries: maxEntries,
+		sanitize:   sanitize,
+		now:        time.Now,
+	}
+}
+
+// DefaultPath é o arquivo do histórico dentro do diretório de dados do app.
+func DefaultPath() string {
+	return filepath.Join(config.DataDir(), "clipboard_history.enc")
+}
+
+// Add registra um texto copiado. Copiar de novo um texto já presente só o move para o topo.
+// Retorna false quando o texto é ignorado (vazio ou grande demais).
+func (h *History) Add(text string) (Entry, bool, error) {
+	if strings.TrimSpace(text) == "" || len(text) > maxEntryLength {
+		return Entry{}, false, nil
+	}
+	clean := h.sanitize(text)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.loadLocked(); err != nil {
+		return Entry{}, false, err
+	}
+
+	entry := Entry{ID: uuid.NewString(), Text: clean, Redacted: clean != text, CopiedAt: h.now()}
+	for i, existing := range h.entries {
+		if existing.Text == clean {
+			entry.ID, entry.Pinned = existing.ID, existing.Pinned
+			h.entries = append(h.entries[:i], h.entries[i+1:]...)
+			break
+		}
+	}
+	h.entries = append([]Entry{entry}, h.entries...)
+	h.trimLocked()
+	return entry, true, h.saveLocked()
+}
+
+// List retorna as entradas, mais recentes primeiro.
+func (h *History) List() ([]Entry, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.loadLocked(); err != nil {
+		return nil, err
+	}
+	return append([]Entry{}, h.entries...), nil
+}
+
+// Pin fixa ou solta uma entrada.
+func (h *History) Pin(id string, pinned bool) (Entry, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.loadLocked(); err != nil {
+		return Entry{}, err
+	}
+	for i := range h.entries {
+		if h.entries[i].ID != id {
+			continue
+		}
+		if pinned && !h.entries[i].Pinned && h.pinnedCountLocked() >= maxPinnedEntries {
+			return Entry{}, fmt.Errorf("at most %d clipboard entries can be pinned", maxPinnedEntries)
+		}
+		h.entries[i].Pinned = pinned
+		entry := h.entries[i]
+		h.trimLocked()
+		return entry, h.saveLocked()
+	}
+	return Entry{}, fmt.Errorf("clipboard entry not found: %s", id)
+}
+
+// Clear apaga o histórico; includePinned também remove as entradas fixadas.
+func (h *History) Clear(includePinned bool) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.loadLocked(); err != nil && !includePinned {
+		return err
+	}
+	kept := h.entries[:0]
+	if !includePinned {
+		for _, entry := range h.entries {
+			if entry.Pinned {
+				kept = append(kept, entry)
+			}
+		}
+	}
+	h.entries = kept
+	h.loaded = true
+	if len(h.entries) == 0 {
+		if err := os.Remove(h.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return h.saveLocked()
+}
+
+// Watch lê o clipboard a cada interval e registra textos novos, chamando onAdd para cada um.
+// O conteúdo presente ao ligar o watcher não entra (só o que for copiado depois).
+func (h *History) Watch(interval time.Duration, read func() (string, error), onAdd func(Entry)) {
+	h.StopWatching()
+	stop := make(chan struct{})
+	h.mu.Lock()
+	h.watchStop = stop
+	h.mu.Unlock()
+
+	if current, err := read(); err == nil {
+		h.seen(current)
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				text, err := read()
+				if err != nil || !h.seen(text) {
+					continue
+				}
+				entry, added, err := h.Add(text)
+				if err != nil {
+					log.Printf("[CLIPBOARD] failed to store entry: %v", err)
+					continue
+				}
+				if added && onAdd != nil {
+					onAdd(entry)
+				}
+			}
+		}
+	}()
+}
+
+// StopWatching para o watcher (sem efeito se não estiver rodando).
+func (h *History) StopWatching() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.watchStop != nil {
+		close(h.watchStop)
+		h.watchStop = nil
+	}
+}
+
+// Watching indica se o watcher está ativo.
+func (h *History) Watching() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.watchStop != nil
+}
+
+// seen atualiza o hash do último conteúdo lido e indica se ele mudou.
+func (h *History) seen(text string) bool {
+	sum := sha256.Sum256([]byte(text))
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if sum == h.lastSeen {
+		return false
+	}
+	h.lastSeen = sum
+	return true
+}
+
+func (h *History) pinnedCountLocked() int {
+	count := 0
+	for _, entry := range h.entries {
+		if entry.Pinned {
+			count++
+		}
+	}
+	return count
+}
+
+// trimLocked descarta as entradas não fixadas mais antigas além do limite.
+func (h *History) trimLocked() {
+	unpinned := 0
+	kept := h.entries[:0]
+	for _, entry := range h.entries {
+		if !entry.Pinned {
+			unpinned++
+			if unpinned > h.maxEntries {
+				continue
+			}
+		}
+		kept = append(kept, entry)
+	}
+	h.entries = kept
+}
+
+func (h *History) loadLocked() error {
+	if h.loaded {
+		return nil
+	}
+	sealed, err := os.ReadFile(h.path)
+	if errors.Is(err, os.ErrNotExist) {
+		h.loaded = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	plain, err := decryptHistory(sealed)
+	if err != nil {
+		return err
+	}
+	var entries []Entry
+	if err := json.Unmarshal(plain, &entries); err != nil {
+		return fmt.Errorf("clipboard history is corrupted: %w", err)
+	}
+	h.entries = entries
+	h.loaded = true
+	return nil
+}
+
+func (h *History) saveLocked() error {
+	plain, err := json.Marshal(h.entries)
+	if err != nil {
+		return err
+	}
+	sealed, err := encryptHistory(plain)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(h.path, sealed)
+}
+
+// === Criptografia ===
+
+// historyKey lê (ou cria) a chave AES-256 do histórico no Keychain.
+func historyKey() ([]byte, error) {
+	if encoded, err := keyring.Get(config.KeychainService(), keychainHistoryKey); err == nil {
+		if key, decodeErr := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded)); decodeErr == nil && len(key) == 32 {
+			return key, nil
+		}
+	}
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate clipboard history key: %w", err)
+	}
+	if err := keyring.Set(config.KeychainService(), keychainHistoryKey, base64.StdEncoding.EncodeToString(key)); err != nil {
+		return nil, fmt.Errorf("failed to store clipboard history key: %w", err)
+	}
+	return key, nil
+}
+
+func historyCipher() (cipher.AEAD, error) {
+	key, err := historyKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encryptHistory(plain []byte) ([]byte, error) {
+	aead, err := historyCipher()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	out := append([]byte(historyMagic), nonce...)
+	return aead.Seal(out, nonce, plain, []byte(historyMagic)), nil
+}
+
+func decryptHistory(sealed []byte) ([]byte, error) {
+	if !strings.HasPrefix(string(sealed), historyMagic) {
+		return nil, fmt.Errorf("not an ORCH clipboard history file")
+	}
+	aead, err := historyCipher()
+	if err != nil {
+		return nil, err
+	}
+	body := sealed[len(historyMagic):]
+	if len(body) < aead.NonceSize() {
+		return nil, fmt.Errorf("clipboard history truncated")
+	}
+	plain, err := aead.Open(nil, body[:aead.NonceSize()], body[aead.NonceSize():], []byte(historyMagic))
+	if err != nil {
+		return nil, fmt.Errorf("clipboard history is corrupted or was encrypted with another key")
+	}
+	return plain, nil
+}
+
+func writeFileAtomic(target string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".orch-tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Chmod(tmpName, 0600); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return os.Rename(tmpName, target)
+}
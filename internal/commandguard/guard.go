@@ -0,0 +1,255 @@
+package commandguard
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Status de um comando segurado.
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusDenied   = "denied"
+)
+
+// ErrAwaitingApproval é devolvido a qualquer guest que digite num terminal com comando aguardando o
+// host.
+var ErrAwaitingApproval = errors.New("command awaiting host approval")
+
+// Request é um comando de guest segurado até a decisão do host.
+type Request struct {
+	ID          string     `json:"id"`
+	SessionID   string     `json:"sessionID"`
+	TerminalID  string     `json:"terminalID"`
+	GuestUserID string     `json:"guestUserID"`
+	Command     string     `json:"command"`
+	Rule        Rule       `json:"rule"`
+	Status      string     `json:"status"`
+	Reason      string     `json:"reason,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	DecidedAt   *time.Time `json:"decidedAt,omitempty"`
+
+	held []byte // a quebra de linha segurada e o que veio depois dela
+}
+
+// lineState reconstrói a linha do terminal a partir das teclas enviadas pelos guests.
+type lineState struct {
+	text   []rune
+	opaque bool // houve tecla de controle não modelada (histórico, cursor, corte): o texto final é desconhecido
+}
+
+// Guard segura, por sessão/terminal, a linha perigosa até o host aprovar. Os caracteres antes do
+// Enter seguem para o PTY normalmente; só a quebra de linha (e o que vier depois) fica retida,
+// então o host vê o comando no terminal antes de decidir. A linha é do terminal, não do guest:
+// o Enter de outro guest também conclui a linha, e enquanto há pedido pendente nenhum guest
+// escreve no terminal.
+type Guard struct {
+	mu      sync.Mutex
+	lines   map[guardKey]*lineState
+	pending map[string]*Request // id -> pedido
+	held    map[guardKey]string // terminal -> id do pedido
+}
+
+type guardKey struct {
+	sessionID  string
+	terminalID string
+}
+
+// NewGuard cria um guard vazio.
+func NewGuard() *Guard {
+	return &Guard{
+		lines:   make(map[guardKey]*lineState),
+		pending: make(map[string]*Request),
+		held:    make(map[guardKey]string),
+	}
+}
+
+func (r *Request) key() guardKey {
+	return guardKey{sessionID: r.SessionID, terminalID: r.TerminalID}
+}
+
+// Inspect acompanha o input do guest e decide o que pode seguir para o PTY. Com enforce
+// desligado apenas atualiza a linha corrente. Quando uma linha perigosa é concluída, devolve em
+// forward o trecho anterior ao Enter e o pedido criado em held.
+func (g *Guard) Inspect(sessionID, terminalID, guestUserID string, data []byte, enforce bool) ([]byte, *Request, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	key := guardKey{sessionID: sessionID, terminalID: terminalID}
+	if _, waiting := g.held[key]; waiting {
+		return nil, nil, ErrAwaitingApproval
+	}
+	state := g.lines[key]
+	if state == nil {
+		state = &lineState{}
+		g.lines[key] = state
+	}
+
+	for i := 0; i < len(data); {
+		b := data[i]
+		switch {
+		case b == '\r' || b == '\n':
+			line := string(state.text)
+			opaque := state.opaque
+			*state = lineState{}
+			if !enforce {
+				break
+			}
+			rule, dangerous := Match(line)
+			if !dangerous && opaque {
+				rule, dangerous = RuleUninspectable, true
+			}
+			if !dangerous {
+				break
+			}
+			request := &Request{
+				ID:          uuid.NewString(),
+				SessionID:   sessionID,
+				TerminalID:  terminalID,
+				GuestUserID: guestUserID,
+				Command:     line,
+				Rule:        rule,
+				Status:      StatusPending,
+				CreatedAt:   time.Now(),
+				held:        append([]byte(nil), data[i:]...),
+			}
+			g.pending[request.ID] = request
+			g.held[key] = request.ID
+			snapshot := *request
+			return data[:i], &snapshot, nil
+		case b == 0x7f || b == 0x08: // backspace
+			if len(state.text) > 0 {
+				state.text = state.text[:len(state.text)-1]
+			}
+		case b == 0x03: // Ctrl-C descarta a linha
+			*state = lineState{}
+		case b < 0x20 && b != '\t':
+			// Escape (setas), Ctrl-U/Ctrl-W (o texto cortado volta com Ctrl-Y), histórico
+			// (Ctrl-P/Ctrl-R), cursor (Ctrl-A/Ctrl-B/Ctrl-T) e os demais controles: o texto
+			// final deixa de ser conhecido. O Tab fica na linha e também a torna
+			// ininspecionável (ver Match).
+			state.opaque = true
+		default:
+			r, size := utf8.DecodeRune(data[i:])
+			state.text = append(state.text, r)
+			i += size
+			continue
+		}
+		i++
+	}
+	return data, nil, nil
+}
+
+// Approve libera o pedido e devolve os bytes retidos: a quebra de linha e o restante do input,
+// que deve passar de novo por Inspect.
+func (g *Guard) Approve(requestID string) (Request, []byte, error) {
+	request, err := g.decide(requestID, StatusApproved, "")
+	if err != nil {
+		return Request{}, nil, err
+	}
+	return *request, request.held, nil
+}
+
+// Holding indica se o terminal tem comando aguardando o host; input de broadcast não deve
+// alcançá-lo até a decisão.
+func (g *Guard) Holding(terminalID string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for key := range g.held {
+		if key.terminalID == terminalID {
+			return true
+		}
+	}
+	return false
+}
+
+// Deny descarta o pedido e tudo que veio depois dele.
+func (g *Guard) Deny(requestID, reason string) (Request, error) {
+	request, err := g.decide(requestID, StatusDenied, reason)
+	if err != nil {
+		return Request{}, err
+	}
+	return *request, nil
+}
+
+func (g *Guard) decide(requestID, status, reason string) (*Request, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	request, ok := g.pending[requestID]
+	if !ok {
+		return nil, fmt.Errorf("held command %s not found", requestID)
+	}
+	delete(g.pending, requestID)
+	delete(g.held, request.key())
+
+	now := time.Now()
+	request.Status = status
+	request.Reason = reason
+	request.DecidedAt = &now
+	return request, nil
+}
+
+// Pending lista os comandos aguardando decisão (todos, com sessionID vazio), do mais antigo ao
+// mais novo.
+func (g *Guard) Pending(sessionID string) []Request {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	requests := make([]Request, 0, len(g.pending))
+	for _, request := range g.pending {
+		if sessionID == "" || request.SessionID == sessionID {
+			requests = append(requests, *request)
+		}
+	}
+	sortRequests(requests)
+	return requests
+}
+
+// Reset esquece linhas e pedidos da sessão, devolvendo os pedidos descartados.
+func (g *Guard) Reset(sessionID string) []Request {
+	return g.drop(func(key guardKey) bool { return key.sessionID == sessionID })
+}
+
+// Forget esquece linhas e pedidos de um terminal encerrado.
+func (g *Guard) Forget(terminalID string) []Request {
+	return g.drop(func(key guardKey) bool { return key.terminalID == terminalID })
+}
+
+func (g *Guard) drop(match func(guardKey) bool) []Request {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	dropped := []Request{}
+	for id, request := range g.pending {
+		if !match(request.key()) {
+			continue
+		}
+		delete(g.pending, id)
+		delete(g.held, request.key())
+		dropped = append(dropped, *request)
+	}
+	for key := range g.lines {
+		if match(key) {
+			delete(g.lines, key)
+		}
+	}
+	sortRequests(dropped)
+	return dropped
+}
+
+func sortRequests(requests []Request) {
+	sort.Slice(requests, func(i, j int) bool {
+		if requests[i].CreatedAt.Equal(requests[j].CreatedAt) {
+			return requests[i].ID < requests[j].ID
+		}
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+}
@@ -0,0 +1,181 @@
+package commandguard
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMatchDetectsDangerousCommands(t *testing.T) {
+	cases := map[string]string{
+		"rm -rf build":                             RuleRecursiveDelete.ID,
+		"sudo rm -r -f /tmp/x":                     RuleRecursiveDelete.ID,
+		"cd repo && /bin/rm --recursive --force .": RuleRecursiveDelete.ID,
+		"git push --force origin main":             RuleForcePush.ID,
+		"git -C ../api push -f":                    RuleForcePush.ID,
+		"git push origin +main":                    RuleForcePush.ID,
+		"git push --force-with-lease":              RuleForcePush.ID,
+		"git reset --hard HEAD~1":                  RuleHardReset.ID,
+		"git clean -fdx":                           RuleGitClean.ID,
+		`psql -c "drop table users"`:               RuleDestructiveSQL.ID,
+		"TRUNCATE TABLE audit_logs;":               RuleDestructiveSQL.ID,
+		`\rm -rf /`:                                RuleRecursiveDelete.ID,
+		`r\m -rf /`:                                RuleRecursiveDelete.ID,
+		`"rm" -rf /`:                               RuleRecursiveDelete.ID,
+		`'git' push --force`:                       RuleForcePush.ID,
+		`bash -c "rm -rf /"`:                       RuleRecursiveDelete.ID,
+		`sh -c 'git reset --hard'`:                 RuleHardReset.ID,
+		`zsh -lc "git push -f origin main"`:        RuleForcePush.ID,
+		`sudo /bin/bash -o pipefail -c "rm -rf x"`: RuleRecursiveDelete.ID,
+		"find . -name '*.tmp' | xargs rm -rf":      RuleRecursiveDelete.ID,
+		"xargs -n 1 -I {} sudo rm -rf {}":          RuleRecursiveDelete.ID,
+		`eval "git clean -fdx"`:                    RuleGitClean.ID,
+		`eval \rm -rf build`:                       RuleRecursiveDelete.ID,
+		"rm -r\t":                                  RuleUninspectable.ID,
+		"ls\tbuild":                                RuleUninspectable.ID,
+	}
+	for line, want := range cases {
+		rule, ok := Match(line)
+		if !ok || rule.ID != want {
+			t.Fatalf("Match(%q) = (%+v, %v), want %s", line, rule, ok, want)
+		}
+	}
+
+	for _, line := range []string{"rm -r build", "rm -f file.txt", "git push origin main", "git reset --soft HEAD~1", "truncate -s 0 app.log", "echo dropped tables", "git clean -n", `bash -c "ls -la"`, "bash script.sh", "xargs rm -f", "eval echo rm -rf", `echo "\rm"`} {
+		if rule, ok := Match(line); ok {
+			t.Fatalf("Match(%q) unexpectedly matched %+v", line, rule)
+		}
+	}
+}
+
+func TestInspectHoldsDangerousLineUntilDecision(t *testing.T) {
+	g := NewGuard()
+
+	// Tecla a tecla: o texto segue para o PTY, só o Enter fica retido.
+	for _, key := range []string{"r", "m", " ", "-", "r", "f", " ", "x"} {
+		forward, held, err := g.Inspect("s1", "t1", "guest", []byte(key), true)
+		if err != nil || held != nil || string(forward) != key {
+			t.Fatalf("Inspect(%q) = (%q, %+v, %v)", key, forward, held, err)
+		}
+	}
+	forward, held, err := g.Inspect("s1", "t1", "guest", []byte("\rls\r"), true)
+	if err != nil || held == nil || len(forward) != 0 {
+		t.Fatalf("expected Enter to be held, got (%q, %+v, %v)", forward, held, err)
+	}
+	if held.Command != "rm -rf x" || held.Rule.ID != RuleRecursiveDelete.ID || held.Status != StatusPending {
+		t.Fatalf("unexpected held request: %+v", held)
+	}
+	if _, _, err := g.Inspect("s1", "t1", "guest", []byte("y"), true); !errors.Is(err, ErrAwaitingApproval) {
+		t.Fatalf("expected input to be refused while awaiting approval, got %v", err)
+	}
+	// Outro guest no mesmo terminal também fica bloqueado: seu Enter executaria a linha retida.
+	if forward, _, err := g.Inspect("s1", "t1", "other", []byte("\r"), true); !errors.Is(err, ErrAwaitingApproval) || len(forward) != 0 {
+		t.Fatalf("expected other guest to be blocked, got (%q, %v)", forward, err)
+	}
+	if !g.Holding("t1") || g.Holding("t2") {
+		t.Fatalf("expected only t1 to be holding")
+	}
+	// Outro terminal segue livre.
+	if _, held, err := g.Inspect("s1", "t2", "other", []byte("ls\r"), true); err != nil || held != nil {
+		t.Fatalf("unexpected result on other terminal: (%+v, %v)", held, err)
+	}
+	if pending := g.Pending("s1"); len(pending) != 1 || pending[0].ID != held.ID {
+		t.Fatalf("unexpected pending list: %+v", pending)
+	}
+
+	approved, rest, err := g.Approve(held.ID)
+	if err != nil || approved.Status != StatusApproved || approved.DecidedAt == nil || string(rest) != "\rls\r" {
+		t.Fatalf("unexpected approval (%+v, %q, %v)", approved, rest, err)
+	}
+	if _, _, err := g.Approve(held.ID); err == nil {
+		t.Fatalf("expected second approval to fail")
+	}
+	if g.Holding("t1") {
+		t.Fatalf("expected t1 to be released after approval")
+	}
+	if forward, held, err := g.Inspect("s1", "t1", "guest", rest[1:], true); err != nil || held != nil || string(forward) != "ls\r" {
+		t.Fatalf("expected remainder to pass after approval, got (%q, %+v, %v)", forward, held, err)
+	}
+}
+
+func TestInspectSharesLineAcrossGuests(t *testing.T) {
+	g := NewGuard()
+
+	// Um guest digita, outro aperta Enter: a linha do PTY é uma só.
+	if _, held, err := g.Inspect("s1", "t1", "guest", []byte("git push -f"), true); err != nil || held != nil {
+		t.Fatalf("unexpected result while typing: (%+v, %v)", held, err)
+	}
+	forward, held, err := g.Inspect("s1", "t1", "other", []byte("\r"), true)
+	if err != nil || held == nil || len(forward) != 0 {
+		t.Fatalf("expected Enter from other guest to be held, got (%q, %+v, %v)", forward, held, err)
+	}
+	if held.Command != "git push -f" || held.GuestUserID != "other" || held.Rule.ID != RuleForcePush.ID {
+		t.Fatalf("unexpected held request: %+v", held)
+	}
+	if dropped := g.Forget("t1"); len(dropped) != 1 || g.Holding("t1") {
+		t.Fatalf("expected Forget to drop the held request, got %+v", dropped)
+	}
+}
+
+func TestInspectTracksLineEditingAndEnforceFlag(t *testing.T) {
+	g := NewGuard()
+
+	// Backspace transforma "rm -rfx" em "rm -rf"; Ctrl-C descarta a linha.
+	if _, held, _ := g.Inspect("s1", "t1", "guest", []byte("rm -rfx\x7f build\r"), true); held == nil {
+		t.Fatalf("expected edited line to be held")
+	} else if _, err := g.Deny(held.ID, "no"); err != nil {
+		t.Fatalf("Deny() error: %v", err)
+	}
+	if _, held, _ := g.Inspect("s1", "t1", "guest", []byte("rm -rf x\x03ls\r"), true); held != nil {
+		t.Fatalf("expected canceled line to be ignored, got %+v", held)
+	}
+
+	// Ctrl-U/Ctrl-W guardam o texto para o Ctrl-Y: a linha vira ininspecionável, mesmo depois
+	// de um Ctrl-C (o texto cortado continua disponível).
+	if _, held, _ := g.Inspect("s1", "t4", "guest", []byte("rm -rf /\x15\x03"), true); held != nil {
+		t.Fatalf("expected no Enter yet, got %+v", held)
+	}
+	for _, input := range []string{"\x19\r", "ls\x15\x19\r", "ls\x17\x19\r"} {
+		_, held, _ := g.Inspect("s1", "t4", "guest", []byte(input), true)
+		if held == nil || held.Rule.ID != RuleUninspectable.ID {
+			t.Fatalf("expected %q to be held, got %+v", input, held)
+		}
+		if _, err := g.Deny(held.ID, "no"); err != nil {
+			t.Fatalf("Deny() error: %v", err)
+		}
+	}
+
+	// Histórico (Ctrl-P, Ctrl-R) e cursor (Ctrl-A, Ctrl-B, Ctrl-T) também exigem aprovação.
+	for _, input := range []string{"\x10\r", "\x12push\r", "ls\x01sudo \r", "ls -la\x02\x02\x14\r"} {
+		_, held, _ := g.Inspect("s1", "t5", "guest", []byte(input), true)
+		if held == nil || held.Rule.ID != RuleUninspectable.ID {
+			t.Fatalf("expected %q to be held, got %+v", input, held)
+		}
+		if _, err := g.Deny(held.ID, "no"); err != nil {
+			t.Fatalf("Deny() error: %v", err)
+		}
+	}
+
+	// Histórico/setas tornam a linha ilegível e exigem aprovação.
+	_, held, _ := g.Inspect("s1", "t1", "guest", []byte("\x1b[A\r"), true)
+	if held == nil || held.Rule.ID != RuleUninspectable.ID {
+		t.Fatalf("expected history recall to be held, got %+v", held)
+	}
+
+	// Tab completa a linha no shell: o texto final é desconhecido.
+	_, held, _ = g.Inspect("s1", "t3", "guest", []byte("rm -r\t\r"), true)
+	if held == nil || held.Rule.ID != RuleUninspectable.ID {
+		t.Fatalf("expected tab-completed line to be held, got %+v", held)
+	}
+
+	// Sem enforce nada é retido.
+	if forward, held, _ := g.Inspect("s2", "t2", "guest", []byte("git push -f\r"), false); held != nil || string(forward) != "git push -f\r" {
+		t.Fatalf("expected input to pass with enforcement off, got (%q, %+v)", forward, held)
+	}
+
+	if dropped := g.Reset("s1"); len(dropped) != 2 || dropped[0].Rule.ID != RuleUninspectable.ID || dropped[1].Rule.ID != RuleUninspectable.ID {
+		t.Fatalf("unexpected dropped requests: %+v", dropped)
+	}
+	if len(g.Pending("")) != 0 {
+		t.Fatalf("expected no pending requests after reset")
+	}
+}
@@ -0,0 +1,196 @@
+package commandguard
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Rule descreve um padrão de comando perigoso.
+type Rule struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+// Regras embutidas. RuleUninspectable cobre linhas editadas com histórico/setas ou completadas
+// com Tab, cujo texto final não é visível no input do guest.
+var (
+	RuleRecursiveDelete = Rule{ID: "rm_recursive_force", Description: "rm recursivo forçado"}
+	RuleForcePush       = Rule{ID: "git_force_push", Description: "git push forçado"}
+	RuleHardReset       = Rule{ID: "git_hard_reset", Description: "git reset --hard"}
+	RuleGitClean        = Rule{ID: "git_clean_force", Description: "git clean forçado"}
+	RuleDestructiveSQL  = Rule{ID: "sql_drop", Description: "DROP/TRUNCATE em SQL"}
+	RuleUninspectable   = Rule{ID: "uninspectable", Description: "linha editada com histórico, cursor ou Tab"}
+)
+
+var destructiveSQLPattern = regexp.MustCompile(`(?i)\b(drop\s+(table|database|schema)|truncate\s+table)\b`)
+
+// Escapes de shell não mudam o comando executado: \rm, r"m" e 'rm' são todos rm.
+var shellQuoteRemover = strings.NewReplacer(`\`, "", `"`, "", `'`, "")
+
+// Opções do xargs que consomem o argumento seguinte.
+var xargsValueOptions = map[string]bool{"-I": true, "-i": true, "-n": true, "-P": true, "-L": true, "-l": true, "-d": true, "-E": true, "-e": true, "-s": true, "-a": true}
+
+// Match verifica a linha digitada contra as regras embutidas. Tab aciona o autocompletar do
+// shell, então a linha que contém Tab não pode ser inspecionada.
+func Match(line string) (Rule, bool) {
+	if strings.Contains(line, "\t") {
+		return RuleUninspectable, true
+	}
+	if destructiveSQLPattern.MatchString(line) {
+		return RuleDestructiveSQL, true
+	}
+	for _, args := range simpleCommands(line) {
+		if rule, ok := matchCommand(args); ok {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// simpleCommands separa a linha em comandos (;, &&, ||, |), devolvendo os argumentos sem aspas
+// nem barras de escape.
+func simpleCommands(line string) [][]string {
+	replacer := strings.NewReplacer("&&", ";", "||", ";", "|", ";", "&", ";", "$(", ";", "`", ";", "(", ";", ")", ";")
+	commands := [][]string{}
+	for _, part := range strings.Split(replacer.Replace(line), ";") {
+		fields := strings.Fields(part)
+		args := make([]string, 0, len(fields))
+		for _, field := range fields {
+			if arg := shellQuoteRemover.Replace(field); arg != "" {
+				args = append(args, arg)
+			}
+		}
+		if len(args) > 0 {
+			commands = append(commands, args)
+		}
+	}
+	return commands
+}
+
+// matchCommand descarta prefixos como sudo e atribuições de ambiente e inspeciona o comando.
+// Scripts de sh/bash/zsh -c, argumentos de eval e o comando executado pelo xargs são
+// inspecionados também.
+func matchCommand(args []string) (Rule, bool) {
+	for len(args) > 0 && (args[0] == "sudo" || args[0] == "command" || args[0] == "exec" || args[0] == "env" || isEnvAssignment(args[0])) {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return Rule{}, false
+	}
+	name := args[0]
+	if slash := strings.LastIndex(name, "/"); slash >= 0 {
+		name = name[slash+1:]
+	}
+	switch name {
+	case "rm":
+		if rmIsRecursiveForce(args[1:]) {
+			return RuleRecursiveDelete, true
+		}
+	case "git":
+		return matchGit(args[1:])
+	case "sh", "bash", "zsh", "dash":
+		if script, ok := shellScript(args[1:]); ok {
+			return Match(script)
+		}
+	case "eval":
+		return Match(strings.Join(args[1:], " "))
+	case "xargs":
+		return matchCommand(xargsCommand(args[1:]))
+	}
+	return Rule{}, false
+}
+
+// shellScript devolve o script passado com -c (também combinado, como -lc ou -ec).
+func shellScript(args []string) (string, bool) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "-o" || arg == "+o" || arg == "-O" || arg == "+O":
+			i++
+		case strings.HasPrefix(arg, "--"):
+		case strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "+"):
+			if strings.Contains(arg, "c") {
+				return strings.Join(args[i+1:], " "), true
+			}
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// xargsCommand pula as opções do xargs e devolve o comando que ele executa.
+func xargsCommand(args []string) []string {
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		if args[0] == "--" {
+			return args[1:]
+		}
+		if xargsValueOptions[args[0]] {
+			args = args[1:]
+		}
+		if len(args) > 0 {
+			args = args[1:]
+		}
+	}
+	return args
+}
+
+func isEnvAssignment(arg string) bool {
+	eq := strings.Index(arg, "=")
+	return eq > 0 && !strings.HasPrefix(arg, "-")
+}
+
+func rmIsRecursiveForce(args []string) bool {
+	recursive, force := false, false
+	for _, arg := range args {
+		switch {
+		case arg == "--":
+			return recursive && force
+		case arg == "--recursive":
+			recursive = true
+		case arg == "--force":
+			force = true
+		case strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--"):
+			recursive = recursive || strings.ContainsAny(arg, "rR")
+			force = force || strings.Contains(arg, "f")
+		}
+	}
+	return recursive && force
+}
+
+// matchGit pula as opções globais do git (-C <dir>, -c <k=v>) e inspeciona o subcomando.
+func matchGit(args []string) (Rule, bool) {
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		if (args[0] == "-C" || args[0] == "-c") && len(args) > 1 {
+			args = args[1:]
+		}
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return Rule{}, false
+	}
+	sub, rest := args[0], args[1:]
+	switch sub {
+	case "push":
+		for _, arg := range rest {
+			if arg == "--force" || arg == "-f" || strings.HasPrefix(arg, "--force-with-lease") ||
+				(strings.HasPrefix(arg, "+") && len(arg) > 1) ||
+				(strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") && strings.Contains(arg, "f")) {
+				return RuleForcePush, true
+			}
+		}
+	case "reset":
+		for _, arg := range rest {
+			if arg == "--hard" {
+				return RuleHardReset, true
+			}
+		}
+	case "clean":
+		for _, arg := range rest {
+			if arg == "--force" || (strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") && strings.Contains(arg, "f")) {
+				return RuleGitClean, true
+			}
+		}
+	}
+	return Rule{}, false
+}
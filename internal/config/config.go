@@ -1,7 +1,6 @@
 package config
 
 import (
-	"os"
 	"path/filepath"
 )
 
@@ -15,6 +14,9 @@ const (
 	// AppBundleID é o bundle identifier macOS
 	AppBundleID = "com.orch.app"
 
+	// UpdateRepository é o repositório GitHub cujos releases distribuem o app
+	UpdateRepository = "peruccii/pando"
+
 	// DeepLinkScheme é o scheme para deep links (orch://)
 	DeepLinkScheme = "orch"
 
@@ -34,11 +36,10 @@ const (
 	TokenBudget = 4000
 )
 
-// DataDir retorna o diretório raiz de dados do app
-// ~/Library/Application Support/ORCH/
+// DataDir retorna o diretório de dados do perfil ativo
+// ~/Library/Application Support/ORCH/ (perfil padrão) ou .../ORCH/profiles/<perfil>/
 func DataDir() string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, "Library", "Application Support", "ORCH")
+	return ProfileDataDir(ActiveProfile())
 }
 
 // DBPath retorna o caminho do arquivo SQLite
@@ -51,21 +52,24 @@ func LogDir() string {
 	return filepath.Join(DataDir(), "logs")
 }
 
-// CacheDir retorna o diretório de cache
+// CacheDir retorna o diretório de cache do perfil ativo
 func CacheDir() string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, "Library", "Caches", "ORCH")
+	if profile := ActiveProfile(); profile != DefaultProfile {
+		return filepath.Join(baseCacheDir(), profilesDirName, profile)
+	}
+	return baseCacheDir()
 }
 
-// EnsureDataDirs cria os diretórios necessários se não existirem
+// EnsureDataDirs cria os diretórios necessários se não existirem, só com acesso do dono
 func EnsureDataDirs() error {
 	dirs := []string{
+		baseDataDir(),
 		DataDir(),
 		LogDir(),
 		CacheDir(),
 	}
 	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0700); err != nil {
+		if err := ensurePrivateDir(dir); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,282 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Origem de um valor resolvido: o arquivo de configuração prevalece sobre env vars, que prevalecem sobre o padrão.
+const (
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceDefault = "default"
+)
+
+// SettingKind define como o valor do arquivo é validado/interpretado.
+type SettingKind int
+
+const (
+	KindString       SettingKind = iota
+	KindSeconds                  // inteiro positivo em segundos
+	KindMilliseconds             // inteiro positivo em milissegundos
+	KindInteger                  // inteiro positivo
+)
+
+// Setting descreve uma chave aceita em config.toml.
+type Setting struct {
+	Key             string // "session.gateway_listen_addr"
+	Env             string // env var equivalente (opcional)
+	Default         string
+	Kind            SettingKind
+	RequiresRestart bool // mudanças só valem após reiniciar o app
+	Description     string
+}
+
+// SettingValue é o valor resolvido de uma chave e sua origem.
+type SettingValue struct {
+	Key             string `json:"key"`
+	Value           string `json:"value"`
+	Source          string `json:"source"` // file | env | default
+	Env             string `json:"env,omitempty"`
+	RequiresRestart bool   `json:"requiresRestart"`
+	Description     string `json:"description,omitempty"`
+}
+
+// EffectiveConfig é a configuração resolvida (arquivo + env + padrões).
+type EffectiveConfig struct {
+	Path       string         `json:"path"`
+	FileLoaded bool           `json:"fileLoaded"`
+	LoadError  string         `json:"loadError,omitempty"` // erro de leitura/sintaxe: valores caem para env/padrão
+	LoadedAt   time.Time      `json:"loadedAt"`
+	Warnings   []string       `json:"warnings"`
+	Values     []SettingValue `json:"values"`
+}
+
+// UserConfigFilePath retorna o arquivo de configuração (ORCH_CONFIG_FILE ou ~/.config/orch/config.toml).
+func UserConfigFilePath() string {
+	if override := strings.TrimSpace(os.Getenv("ORCH_CONFIG_FILE")); override != "" {
+		return override
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "orch", "config.toml")
+}
+
+// Value retorna o valor resolvido da chave ("" quando desconhecida).
+func (c *EffectiveConfig) Value(key string) string {
+	if c == nil {
+		return ""
+	}
+	for _, value := range c.Values {
+		if value.Key == key {
+			return value.Value
+		}
+	}
+	return ""
+}
+
+// Duration interpreta a chave conforme o sufixo do tipo (_seconds / _ms); 0 quando ausente ou inválida.
+func (c *EffectiveConfig) Duration(key string) time.Duration {
+	n, err := strconv.ParseInt(c.Value(key), 10, 64)
+	if err != nil || n <= 0 {
+		return 0
+	}
+	if strings.HasSuffix(key, "_ms") {
+		return time.Duration(n) * time.Millisecond
+	}
+	return time.Duration(n) * time.Second
+}
+
+// Int retorna o valor inteiro positivo da chave; 0 quando ausente ou inválido.
+func (c *EffectiveConfig) Int(key string) int {
+	n, err := strconv.Atoi(c.Value(key))
+	if err != nil || n <= 0 {
+		return 0
+	}
+	return n
+}
+
+// ResolveSettings combina os valores do arquivo e das env vars com os padrões de cada chave.
+func ResolveSettings(settings []Setting, fileValues map[string]interface{}, getenv func(string) string) ([]SettingValue, []string) {
+	known := make(map[string]bool, len(settings))
+	var warnings []string
+	values := make([]SettingValue, 0, len(settings))
+
+	for _, setting := range settings {
+		known[setting.Key] = true
+		value := SettingValue{
+			Key:             setting.Key,
+			Value:           setting.Default,
+			Source:          SourceDefault,
+			Env:             setting.Env,
+			RequiresRestart: setting.RequiresRestart,
+			Description:     setting.Description,
+		}
+		if setting.Env != "" && getenv != nil {
+			if env := strings.TrimSpace(getenv(setting.Env)); env != "" {
+				value.Value = env
+				value.Source = SourceEnv
+			}
+		}
+		if raw, ok := fileValues[setting.Key]; ok {
+			parsed, err := settingFromFile(setting, raw)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s: %v", setting.Key, err))
+			} else {
+				value.Value = parsed
+				value.Source = SourceFile
+			}
+		}
+		values = append(values, value)
+	}
+
+	var unknown []string
+	for key := range fileValues {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		warnings = append(warnings, fmt.Sprintf("%s: unknown setting (ignored)", key))
+	}
+	return values, warnings
+}
+
+func settingFromFile(setting Setting, raw interface{}) (string, error) {
+	switch setting.Kind {
+	case KindSeconds, KindMilliseconds, KindInteger:
+		n, ok := raw.(int64)
+		if !ok || n <= 0 {
+			return "", fmt.Errorf("expected a positive integer")
+		}
+		return strconv.FormatInt(n, 10), nil
+	default:
+		s, ok := raw.(string)
+		if !ok {
+			return "", fmt.Errorf("expected a string")
+		}
+		return strings.TrimSpace(s), nil
+	}
+}
+
+// FileLoader lê config.toml, resolve as chaves e recarrega quando o arquivo muda.
+type FileLoader struct {
+	path     string
+	settings []Setting
+	getenv   func(string) string
+
+	mu      sync.Mutex
+	current *EffectiveConfig
+	modTime time.Time
+	size    int64
+	exists  bool
+	stop    chan struct{}
+}
+
+// NewFileLoader cria o loader para o arquivo e as chaves informados.
+func NewFileLoader(path string, settings []Setting) *FileLoader {
+	return &FileLoader{path: path, settings: settings, getenv: os.Getenv}
+}
+
+// Load (re)lê o arquivo e retorna a configuração resolvida. Erros ficam em LoadError.
+func (l *FileLoader) Load() *EffectiveConfig {
+	effective := &EffectiveConfig{Path: l.path, LoadedAt: time.Now(), Warnings: []string{}}
+	var fileValues map[string]interface{}
+
+	stat, statErr := os.Stat(l.path)
+	if statErr == nil {
+		data, err := os.ReadFile(l.path)
+		if err == nil {
+			fileValues, err = parseTOML(string(data))
+		}
+		if err != nil {
+			effective.LoadError = err.Error()
+			fileValues = nil
+		} else {
+			effective.FileLoaded = true
+		}
+	} else if !os.IsNotExist(statErr) {
+		effective.LoadError = statErr.Error()
+	}
+
+	values, warnings := ResolveSettings(l.settings, fileValues, l.getenv)
+	effective.Values = values
+	effective.Warnings = append(effective.Warnings, warnings...)
+
+	l.mu.Lock()
+	l.current = effective
+	l.exists = statErr == nil
+	if statErr == nil {
+		l.modTime, l.size = stat.ModTime(), stat.Size()
+	}
+	l.mu.Unlock()
+	return effective
+}
+
+// Current retorna a última configuração carregada (carrega na primeira chamada).
+func (l *FileLoader) Current() *EffectiveConfig {
+	l.mu.Lock()
+	current := l.current
+	l.mu.Unlock()
+	if current == nil {
+		return l.Load()
+	}
+	return current
+}
+
+// Watch verifica o arquivo a cada interval e chama onChange após recarregar uma mudança.
+// Usa mtime/tamanho em vez de fsnotify para sobreviver a editores que salvam via rename.
+func (l *FileLoader) Watch(interval time.Duration, onChange func(*EffectiveConfig)) {
+	l.mu.Lock()
+	if l.stop != nil {
+		l.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	l.stop = stop
+	l.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if l.changed() {
+					effective := l.Load()
+					if onChange != nil {
+						onChange(effective)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Stop encerra o Watch.
+func (l *FileLoader) Stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stop != nil {
+		close(l.stop)
+		l.stop = nil
+	}
+}
+
+func (l *FileLoader) changed() bool {
+	stat, err := os.Stat(l.path)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err != nil {
+		return l.exists
+	}
+	return !l.exists || !stat.ModTime().Equal(l.modTime) || stat.Size() != l.size
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTOMLSubset(t *testing.T) {
+	values, err := parseTOML(`
+# comentário
+top = "x"
+
+[session]
+gateway_listen_addr = "0.0.0.0:9999" # inline
+signaling_base_url = 'ws://host/ws'
+
+[github]
+cache_ttl_seconds = 1_800
+enabled = true
+ratio = 0.5
+`)
+	if err != nil {
+		t.Fatalf("parseTOML: %v", err)
+	}
+	expected := map[string]interface{}{
+		"top":                         "x",
+		"session.gateway_listen_addr": "0.0.0.0:9999",
+		"session.signaling_base_url":  "ws://host/ws",
+		"github.cache_ttl_seconds":    int64(1800),
+		"github.enabled":              true,
+		"github.ratio":                0.5,
+	}
+	if len(values) != len(expected) {
+		t.Fatalf("expected %d keys, got %d: %#v", len(expected), len(values), values)
+	}
+	for key, want := range expected {
+		if values[key] != want {
+			t.Fatalf("%s: expected %#v, got %#v", key, want, values[key])
+		}
+	}
+}
+
+func TestParseTOMLRejectsInvalidInput(t *testing.T) {
+	cases := map[string]string{
+		"unterminated string": `a = "abc`,
+		"duplicate key":       "a = 1\na = 2",
+		"array":               `a = [1, 2]`,
+		"missing value":       `a =`,
+		"trailing content":    `a = "x" y`,
+		"table header":        `[session`,
+	}
+	for name, input := range cases {
+		if _, err := parseTOML(input); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestResolveSettingsPrecedence(t *testing.T) {
+	settings := []Setting{
+		{Key: "session.addr", Env: "ORCH_ADDR", Default: "127.0.0.1:1"},
+		{Key: "session.url", Env: "ORCH_URL", Default: "http://default"},
+		{Key: "github.ttl_seconds", Default: "300", Kind: KindSeconds},
+		{Key: "watch.debounce_ms", Default: "200", Kind: KindMilliseconds},
+	}
+	env := map[string]string{"ORCH_ADDR": "env:2", "ORCH_URL": "http://env"}
+	fileValues := map[string]interface{}{
+		"session.addr":       "file:3",
+		"github.ttl_seconds": int64(-5),
+		"watch.debounce_ms":  int64(50),
+		"unknown.key":        "x",
+	}
+
+	values, warnings := ResolveSettings(settings, fileValues, func(key string) string { return env[key] })
+	effective := &EffectiveConfig{Values: values}
+
+	checks := []struct{ key, value, source string }{
+		{"session.addr", "file:3", SourceFile},
+		{"session.url", "http://env", SourceEnv},
+		{"github.ttl_seconds", "300", SourceDefault},
+		{"watch.debounce_ms", "50", SourceFile},
+	}
+	for i, check := range checks {
+		if values[i].Key != check.key || values[i].Value != check.value || values[i].Source != check.source {
+			t.Fatalf("unexpected value for %s: %#v", check.key, values[i])
+		}
+	}
+	if got := effective.Duration("github.ttl_seconds"); got != 5*time.Minute {
+		t.Fatalf("expected 5m, got %s", got)
+	}
+	if got := effective.Duration("watch.debounce_ms"); got != 50*time.Millisecond {
+		t.Fatalf("expected 50ms, got %s", got)
+	}
+	if len(warnings) != 2 || !strings.HasPrefix(warnings[0], "github.ttl_seconds") || !strings.HasPrefix(warnings[1], "unknown.key") {
+		t.Fatalf("unexpected warnings: %#v", warnings)
+	}
+}
+
+func TestFileLoaderReloadsOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	loader := NewFileLoader(path, []Setting{{Key: "ai.ollama_model", Default: "llama3"}})
+	loader.getenv = func(string) string { return "" }
+
+	initial := loader.Load()
+	if initial.FileLoaded || initial.LoadError != "" || initial.Value("ai.ollama_model") != "llama3" {
+		t.Fatalf("unexpected config without file: %#v", initial)
+	}
+
+	reloaded := make(chan *EffectiveConfig, 4)
+	loader.Watch(10*time.Millisecond, func(effective *EffectiveConfig) { reloaded <- effective })
+	defer loader.Stop()
+
+	if err := os.WriteFile(path, []byte("[ai]\nollama_model = \"qwen\"\n"), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	select {
+	case effective := <-reloaded:
+		if !effective.FileLoaded || effective.Value("ai.ollama_model") != "qwen" {
+			t.Fatalf("unexpected reloaded config: %#v", effective)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("config reload not observed")
+	}
+
+	if err := os.WriteFile(path, []byte("[ai\n"), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	select {
+	case effective := <-reloaded:
+		if effective.LoadError == "" || effective.Value("ai.ollama_model") != "llama3" {
+			t.Fatalf("invalid file should fall back to defaults: %#v", effective)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("config reload not observed")
+	}
+	if current := loader.Current(); current.LoadError == "" {
+		t.Fatalf("Current should return the last load: %#v", current)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package config
+
+import (
+	"os"
+	"syscall"
+)
+
+// ownedByOtherUser compara o dono do arquivo com o usuário do processo.
+func ownedByOtherUser(info os.FileInfo) bool {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	return ok && int(stat.Uid) != os.Getuid()
+}
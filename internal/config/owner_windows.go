@@ -0,0 +1,10 @@
+//go:build windows
+
+package config
+
+import "os"
+
+// ownedByOtherUser: no Windows o perfil do usuário já isola o AppData pelas ACLs.
+func ownedByOtherUser(os.FileInfo) bool {
+	return false
+}
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultProfile usa o diretório de dados original (sem subpasta), mantendo instalações antigas.
+	DefaultProfile = "default"
+
+	// ProfileEnv força o perfil do processo (ex.: ORCH_PROFILE=trabalho), acima do perfil salvo.
+	ProfileEnv = "ORCH_PROFILE"
+
+	activeProfileFile = "active_profile"
+	profilesDirName   = "profiles"
+)
+
+var profileNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// ErrDataDirNotOwned indica um diretório de dados de outro usuário do sistema.
+var ErrDataDirNotOwned = errors.New("data directory belongs to another OS user")
+
+var (
+	activeProfileOnce  sync.Once
+	activeProfileValue string
+)
+
+// Profile é um perfil local do app: banco, histórico de terminal, tokens da API e segredos no
+// keychain ficam separados por perfil para organizar contas, não para proteger um perfil de
+// outro do mesmo usuário do sistema (ver KeychainService).
+type Profile struct {
+	Name      string `json:"name"`
+	DataDir   string `json:"dataDir"`
+	Active    bool   `json:"active"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+// baseDataDir é a raiz de dados do usuário do sistema; o perfil padrão mora nela.
+func baseDataDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, "Library", "Application Support", "ORCH")
+}
+
+func baseCacheDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, "Library", "Caches", "ORCH")
+}
+
+// NormalizeProfileName valida o nome do perfil (minúsculas, dígitos, "-" e "_"; até 32).
+func NormalizeProfileName(name string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if normalized == "" {
+		return DefaultProfile, nil
+	}
+	if !profileNameRegex.MatchString(normalized) {
+		return "", fmt.Errorf("invalid profile name %q: use lowercase letters, digits, '-' or '_' (max 32)", name)
+	}
+	return normalized, nil
+}
+
+// ActiveProfile retorna o perfil deste processo: ORCH_PROFILE ou o último escolhido no
+// seletor. É resolvido uma única vez; trocar de perfil exige reiniciar o app.
+func ActiveProfile() string {
+	activeProfileOnce.Do(func() {
+		activeProfileValue = resolveActiveProfile(os.Getenv)
+	})
+	return activeProfileValue
+}
+
+func resolveActiveProfile(getenv func(string) string) string {
+	if raw := strings.TrimSpace(getenv(ProfileEnv)); raw != "" {
+		if fromEnv, err := NormalizeProfileName(raw); err == nil {
+			return fromEnv
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(baseDataDir(), activeProfileFile))
+	if err != nil {
+		return DefaultProfile
+	}
+	saved, err := NormalizeProfileName(string(data))
+	if err != nil {
+		return DefaultProfile
+	}
+	return saved
+}
+
+// ProfileDataDir retorna o diretório de dados do perfil.
+func ProfileDataDir(name string) string {
+	if name == DefaultProfile {
+		return baseDataDir()
+	}
+	return filepath.Join(baseDataDir(), profilesDirName, name)
+}
+
+// IsProfileRegistryPath diz se um caminho relativo ao diretório do perfil padrão pertence ao
+// registro de perfis (outros perfis e o perfil escolhido), que não faz parte dos dados do padrão.
+func IsProfileRegistryPath(rel string) bool {
+	rel = filepath.ToSlash(filepath.Clean(rel))
+	return rel == activeProfileFile || rel == profilesDirName || strings.HasPrefix(rel, profilesDirName+"/")
+}
+
+// KeychainService é o serviço do keychain do perfil ativo, para que um perfil não leia os
+// tokens de outro por engano. É só um namespace: o keychain é do usuário do sistema, então
+// qualquer processo desse usuário (inclusive o app em outro perfil) consegue ler as entradas
+// de todos os perfis. Perfis separam contas de trabalho, não protegem uma da outra.
+func KeychainService() string {
+	if profile := ActiveProfile(); profile != DefaultProfile {
+		return AppBundleID + ".profile." + profile
+	}
+	return AppBundleID
+}
+
+// ListProfiles lista o perfil padrão e os criados em <dados>/profiles.
+func ListProfiles() []Profile {
+	active := ActiveProfile()
+	names := []string{DefaultProfile}
+	entries, _ := os.ReadDir(filepath.Join(baseDataDir(), profilesDirName))
+	for _, entry := range entries {
+		if name, err := NormalizeProfileName(entry.Name()); err == nil && entry.IsDir() && name == entry.Name() && name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+	if active != DefaultProfile && !containsString(names, active) {
+		names = append(names, active) // ORCH_PROFILE de um perfil ainda não criado em disco
+	}
+	sort.Strings(names[1:])
+
+	profiles := make([]Profile, 0, len(names))
+	for _, name := range names {
+		profiles = append(profiles, Profile{
+			Name:      name,
+			DataDir:   ProfileDataDir(name),
+			Active:    name == active,
+			IsDefault: name == DefaultProfile,
+		})
+	}
+	return profiles
+}
+
+// CreateProfile cria o diretório do perfil, acessível só pelo dono (0700).
+func CreateProfile(name string) (Profile, error) {
+	normalized, err := NormalizeProfileName(name)
+	if err != nil {
+		return Profile{}, err
+	}
+	if err := ensurePrivateDir(baseDataDir()); err != nil {
+		return Profile{}, err
+	}
+	dir := ProfileDataDir(normalized)
+	if err := ensurePrivateDir(dir); err != nil {
+		return Profile{}, err
+	}
+	return Profile{Name: normalized, DataDir: dir, Active: normalized == ActiveProfile(), IsDefault: normalized == DefaultProfile}, nil
+}
+
+// SetActiveProfile grava o perfil usado no próximo início do app (ORCH_PROFILE ainda vence).
+func SetActiveProfile(name string) (Profile, error) {
+	profile, err := CreateProfile(name)
+	if err != nil {
+		return Profile{}, err
+	}
+	path := filepath.Join(baseDataDir(), activeProfileFile)
+	if err := os.WriteFile(path, []byte(profile.Name+"\n"), 0600); err != nil {
+		return Profile{}, err
+	}
+	return profile, nil
+}
+
+// ensurePrivateDir cria o diretório com 0700, recusa diretórios de outro usuário do sistema e
+// remove permissões de grupo/outros herdadas de versões antigas.
+func ensurePrivateDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if ownedByOtherUser(info) {
+		return fmt.Errorf("%w: %s", ErrDataDirNotOwned, dir)
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return os.Chmod(dir, info.Mode().Perm()&0700)
+	}
+	return nil
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
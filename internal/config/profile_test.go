@@ -0,0 +1,84 @@
+//go:build !windows
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// resetActiveProfile simula um novo início do processo.
+func resetActiveProfile(t *testing.T) {
+	t.Helper()
+	activeProfileOnce = sync.Once{}
+	t.Cleanup(func() { activeProfileOnce = sync.Once{} })
+}
+
+func TestProfilesSeparateDataDirsAndKeychain(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(ProfileEnv, "")
+	resetActiveProfile(t)
+
+	base := filepath.Join(home, "Library", "Application Support", "ORCH")
+	if DataDir() != base || KeychainService() != AppBundleID {
+		t.Fatalf("default profile should keep the legacy paths, got %s / %s", DataDir(), KeychainService())
+	}
+
+	if _, err := CreateProfile("Work"); err != nil {
+		t.Fatalf("CreateProfile error: %v", err)
+	}
+	if _, err := CreateProfile("../escape"); err == nil {
+		t.Fatalf("expected invalid profile name to be rejected")
+	}
+	if _, err := SetActiveProfile("work"); err != nil {
+		t.Fatalf("SetActiveProfile error: %v", err)
+	}
+	if ActiveProfile() != DefaultProfile {
+		t.Fatalf("switching must only apply on the next start")
+	}
+
+	resetActiveProfile(t)
+	if ActiveProfile() != "work" || DataDir() != filepath.Join(base, "profiles", "work") {
+		t.Fatalf("unexpected active profile %q at %s", ActiveProfile(), DataDir())
+	}
+	if KeychainService() != AppBundleID+".profile.work" {
+		t.Fatalf("unexpected keychain service %q", KeychainService())
+	}
+	if err := EnsureDataDirs(); err != nil {
+		t.Fatalf("EnsureDataDirs error: %v", err)
+	}
+	info, err := os.Stat(DataDir())
+	if err != nil || info.Mode().Perm() != 0700 {
+		t.Fatalf("profile dir should be private, got %v (%v)", info.Mode().Perm(), err)
+	}
+
+	profiles := ListProfiles()
+	if len(profiles) != 2 || profiles[0].Name != DefaultProfile || !profiles[1].Active || profiles[0].Active {
+		t.Fatalf("unexpected profiles: %+v", profiles)
+	}
+
+	t.Setenv(ProfileEnv, "ci")
+	resetActiveProfile(t)
+	if ActiveProfile() != "ci" || len(ListProfiles()) != 3 {
+		t.Fatalf("ORCH_PROFILE should win over the saved profile, got %q", ActiveProfile())
+	}
+}
+
+func TestEnsurePrivateDirTightensPermissions(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.Chmod(dir, 0755); err != nil {
+		t.Fatalf("chmod: %v", err)
+	}
+	if err := ensurePrivateDir(dir); err != nil {
+		t.Fatalf("ensurePrivateDir error: %v", err)
+	}
+	if info, _ := os.Stat(dir); info.Mode().Perm() != 0700 {
+		t.Fatalf("expected 0700, got %v", info.Mode().Perm())
+	}
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseTOML lê o subconjunto de TOML usado em config.toml: tabelas ([session], [github.polling]),
+// chaves simples e valores string, inteiro, float e booleano. Retorna chaves achatadas ("session.gateway_listen_addr").
+func parseTOML(data string) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	table := ""
+	for index, rawLine := range strings.Split(data, "\n") {
+		lineNo := index + 1
+		line := strings.TrimSpace(strings.TrimSuffix(rawLine, "\r"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if strings.HasPrefix(line, "[[") {
+				return nil, fmt.Errorf("line %d: arrays of tables are not supported", lineNo)
+			}
+			end := strings.Index(line, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated table header", lineNo)
+			}
+			if rest := strings.TrimSpace(line[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+				return nil, fmt.Errorf("line %d: unexpected content after table header", lineNo)
+			}
+			name, err := parseTOMLKey(line[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			table = name
+			continue
+		}
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		key, err := parseTOMLKey(line[:eq])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if table != "" {
+			key = table + "." + key
+		}
+		value, err := parseTOMLValue(strings.TrimSpace(line[eq+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if _, exists := values[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", lineNo, key)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+func parseTOMLKey(raw string) (string, error) {
+	parts := strings.Split(strings.TrimSpace(raw), ".")
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return "", fmt.Errorf("empty key")
+		}
+		for _, r := range part {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+				return "", fmt.Errorf("unsupported key %q (only bare keys are supported)", part)
+			}
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, "."), nil
+}
+
+func parseTOMLValue(raw string) (interface{}, error) {
+	if raw == "" {
+		return nil, fmt.Errorf("missing value")
+	}
+	switch raw[0] {
+	case '"':
+		value, rest, err := parseTOMLBasicString(raw)
+		if err != nil {
+			return nil, err
+		}
+		return value, checkTOMLTrailing(rest)
+	case '\'':
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		return raw[1 : end+1], checkTOMLTrailing(raw[end+2:])
+	case '[', '{':
+		return nil, fmt.Errorf("arrays and inline tables are not supported")
+	}
+
+	token := raw
+	if idx := strings.Index(token, "#"); idx >= 0 {
+		token = token[:idx]
+	}
+	token = strings.TrimSpace(token)
+	switch token {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	digits := strings.ReplaceAll(token, "_", "")
+	if n, err := strconv.ParseInt(digits, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(digits, 64); err == nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("invalid value %q", token)
+}
+
+func parseTOMLBasicString(raw string) (string, string, error) {
+	var b strings.Builder
+	for i := 1; i < len(raw); i++ {
+		c := raw[i]
+		switch c {
+		case '"':
+			return b.String(), raw[i+1:], nil
+		case '\\':
+			if i+1 >= len(raw) {
+				return "", "", fmt.Errorf("unterminated string")
+			}
+			i++
+			switch raw[i] {
+			case '"', '\\':
+				b.WriteByte(raw[i])
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'u':
+				if i+4 >= len(raw) {
+					return "", "", fmt.Errorf("invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(raw[i+1:i+5], 16, 32)
+				if err != nil || !utf8.ValidRune(rune(code)) {
+					return "", "", fmt.Errorf("invalid unicode escape")
+				}
+				b.WriteRune(rune(code))
+				i += 4
+			default:
+				return "", "", fmt.Errorf("invalid escape \\%c", raw[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", "", fmt.Errorf("unterminated string")
+}
+
+func checkTOMLTrailing(rest string) error {
+	rest = strings.TrimSpace(rest)
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		return fmt.Errorf("unexpected content after value: %q", rest)
+	}
+	return nil
+}
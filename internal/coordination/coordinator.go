@@ -0,0 +1,226 @@
+package coordination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultLeaseTTL é curto para que o recurso volte a ficar livre logo se a instância morrer.
+	DefaultLeaseTTL = 30 * time.Second
+	// doRetryInterval é o intervalo entre tentativas de Do enquanto outra instância grava.
+	doRetryInterval = 100 * time.Millisecond
+)
+
+// ErrBusy indica que o recurso continuou com outra instância até o ctx expirar.
+var ErrBusy = errors.New("resource is held by another instance")
+
+// Coordinator mantém os leases desta instância e os renova em background.
+// Um Coordinator nil concede tudo, para que os consumidores funcionem sem coordenação.
+type Coordinator struct {
+	backend Backend
+	holder  string
+	ttl     time.Duration
+
+	mu          sync.Mutex
+	held        map[string]time.Time // recurso -> expiração conhecida
+	unreachable bool                 // último contato com o backend falhou (evita log repetido)
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewInstanceID gera um identificador de holder único por processo.
+func NewInstanceID() string {
+	return fmt.Sprintf("orch-%d-%d", os.Getpid(), time.Now().UnixNano())
+}
+
+// NewCoordinator cria o coordenador e inicia o loop de renovação (a cada ttl/3).
+func NewCoordinator(backend Backend, holder string, ttl time.Duration) *Coordinator {
+	if ttl <= 0 {
+		ttl = DefaultLeaseTTL
+	}
+	c := &Coordinator{
+		backend: backend,
+		holder:  strings.TrimSpace(holder),
+		ttl:     max(minLeaseTTL, min(ttl, maxLeaseTTL)),
+		held:    make(map[string]time.Time),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go c.renewLoop()
+	return c
+}
+
+// Holder retorna o identificador desta instância.
+func (c *Coordinator) Holder() string {
+	if c == nil {
+		return ""
+	}
+	return c.holder
+}
+
+// Acquire tenta obter (ou manter) o lease do recurso. Se o gateway estiver inacessível a
+// instância segue sozinha (fail-open): perder a coordenação é melhor que parar o polling.
+func (c *Coordinator) Acquire(resource string) bool {
+	if c == nil {
+		return true
+	}
+	resource = strings.TrimSpace(resource)
+	if resource == "" {
+		return true
+	}
+
+	c.mu.Lock()
+	expiresAt, ok := c.held[resource]
+	c.mu.Unlock()
+	if ok && time.Now().Before(expiresAt) {
+		return true
+	}
+
+	lease, granted, err := c.backend.Acquire(resource, c.holder, c.ttl)
+	if err != nil {
+		c.markUnreachable(err)
+		return true
+	}
+	c.markReachable()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !granted {
+		delete(c.held, resource)
+		return false
+	}
+	c.held[resource] = lease.ExpiresAt
+	return true
+}
+
+// Release libera o lease do recurso, se for desta instância.
+func (c *Coordinator) Release(resource string) {
+	if c == nil {
+		return
+	}
+	resource = strings.TrimSpace(resource)
+	c.mu.Lock()
+	_, ok := c.held[resource]
+	delete(c.held, resource)
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+	if err := c.backend.Release(resource, c.holder); err != nil {
+		c.markUnreachable(err)
+	}
+}
+
+// Do executa fn com o lease do recurso, esperando enquanto outra instância o detém.
+// O lease é liberado ao final; use para gravações curtas no banco compartilhado.
+func (c *Coordinator) Do(ctx context.Context, resource string, fn func() error) error {
+	if c == nil {
+		return fn()
+	}
+	for !c.Acquire(resource) {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s", ErrBusy, resource)
+		case <-time.After(doRetryInterval):
+		}
+	}
+	defer c.Release(resource)
+	return fn()
+}
+
+// Held retorna os recursos atualmente com esta instância, ordenados.
+func (c *Coordinator) Held() []string {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	resources := make([]string, 0, len(c.held))
+	for resource := range c.held {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	return resources
+}
+
+// Leases lista todos os leases ativos conhecidos pelo backend.
+func (c *Coordinator) Leases() ([]Lease, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return c.backend.List()
+}
+
+// Close para a renovação e libera todos os leases desta instância.
+func (c *Coordinator) Close() {
+	if c == nil {
+		return
+	}
+	c.stopOnce.Do(func() { close(c.stop) })
+	<-c.done
+	for _, resource := range c.Held() {
+		c.Release(resource)
+	}
+}
+
+func (c *Coordinator) renewLoop() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.renewHeld()
+		}
+	}
+}
+
+// renewHeld renova cada lease; os negados (expiraram e outra instância assumiu) são descartados.
+func (c *Coordinator) renewHeld() {
+	for _, resource := range c.Held() {
+		lease, granted, err := c.backend.Acquire(resource, c.holder, c.ttl)
+		if err != nil {
+			c.markUnreachable(err)
+			return
+		}
+		c.markReachable()
+		c.mu.Lock()
+		if _, stillHeld := c.held[resource]; stillHeld {
+			if granted {
+				c.held[resource] = lease.ExpiresAt
+			} else {
+				delete(c.held, resource)
+				log.Printf("[COORD] lease %q perdido para %s", resource, lease.Holder)
+			}
+		}
+		c.mu.Unlock()
+	}
+}
+
+func (c *Coordinator) markUnreachable(err error) {
+	c.mu.Lock()
+	first := !c.unreachable
+	c.unreachable = true
+	c.mu.Unlock()
+	if first {
+		log.Printf("[COORD] gateway de leases indisponível, seguindo sem coordenação: %v", err)
+	}
+}
+
+func (c *Coordinator) markReachable() {
+	c.mu.Lock()
+	c.unreachable = false
+	c.mu.Unlock()
+}
@@ -0,0 +1,112 @@
+package coordination
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTableGrantsRenewsAndExpiresLeases(t *testing.T) {
+	table := NewTable()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	table.now = func() time.Time { return now }
+
+	first, granted, err := table.Acquire("github.poller:acme/api", "a", 10*time.Second)
+	if err != nil || !granted {
+		t.Fatalf("expected first acquire granted, got %v %v", granted, err)
+	}
+	if current, granted, _ := table.Acquire("github.poller:acme/api", "b", 10*time.Second); granted || current.Holder != "a" {
+		t.Fatalf("expected lease held by a, got %+v granted=%v", current, granted)
+	}
+
+	now = now.Add(5 * time.Second)
+	renewed, granted, _ := table.Acquire("github.poller:acme/api", "a", 10*time.Second)
+	if !granted || !renewed.AcquiredAt.Equal(first.AcquiredAt) || !renewed.ExpiresAt.Equal(now.Add(10*time.Second)) {
+		t.Fatalf("expected renewal keeping AcquiredAt, got %+v", renewed)
+	}
+
+	now = now.Add(11 * time.Second)
+	if leases, _ := table.List(); len(leases) != 0 {
+		t.Fatalf("expected expired lease pruned, got %+v", leases)
+	}
+	if taken, granted, _ := table.Acquire("github.poller:acme/api", "b", 10*time.Second); !granted || taken.Holder != "b" {
+		t.Fatalf("expected expired lease taken over by b, got %+v", taken)
+	}
+
+	_ = table.Release("github.poller:acme/api", "a")
+	if leases, _ := table.List(); len(leases) != 1 {
+		t.Fatalf("release by a non-holder must be ignored, got %+v", leases)
+	}
+	if _, _, err := table.Acquire("", "a", time.Second); err == nil {
+		t.Fatalf("expected error for empty lease name")
+	}
+}
+
+func TestCoordinatorsShareLeasesOverHTTP(t *testing.T) {
+	server := httptest.NewServer(NewHandler(NewTable()))
+	defer server.Close()
+
+	owner := NewCoordinator(NewHTTPBackend(server.URL, nil), "owner", time.Minute)
+	defer owner.Close()
+	other := NewCoordinator(NewHTTPBackend(server.URL, nil), "other", time.Minute)
+	defer other.Close()
+
+	if !owner.Acquire("backup.scheduled") {
+		t.Fatalf("expected owner to acquire the lease")
+	}
+	if other.Acquire("backup.scheduled") {
+		t.Fatalf("expected second instance to be denied")
+	}
+	leases, err := other.Leases()
+	if err != nil || len(leases) != 1 || leases[0].Holder != "owner" {
+		t.Fatalf("unexpected leases: %+v (%v)", leases, err)
+	}
+
+	owner.Release("backup.scheduled")
+	if !other.Acquire("backup.scheduled") {
+		t.Fatalf("expected lease free after release")
+	}
+	if held := other.Held(); len(held) != 1 || held[0] != "backup.scheduled" {
+		t.Fatalf("unexpected held resources: %v", held)
+	}
+}
+
+func TestCoordinatorDoWaitsForLeaseAndFailsOpen(t *testing.T) {
+	table := NewTable()
+	holder := NewCoordinator(table, "holder", time.Minute)
+	defer holder.Close()
+	writer := NewCoordinator(table, "writer", time.Minute)
+	defer writer.Close()
+
+	holder.Acquire("db.terminal_snapshots")
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	ran := false
+	if err := writer.Do(ctx, "db.terminal_snapshots", func() error { ran = true; return nil }); !errors.Is(err, ErrBusy) || ran {
+		t.Fatalf("expected ErrBusy without running, got %v ran=%v", err, ran)
+	}
+
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		holder.Release("db.terminal_snapshots")
+	}()
+	if err := writer.Do(context.Background(), "db.terminal_snapshots", func() error { ran = true; return nil }); err != nil || !ran {
+		t.Fatalf("expected write after release, got %v ran=%v", err, ran)
+	}
+	if held := writer.Held(); len(held) != 0 {
+		t.Fatalf("expected Do to release the lease, held=%v", held)
+	}
+
+	unreachable := NewCoordinator(NewHTTPBackend("http://127.0.0.1:1", nil), "alone", time.Minute)
+	defer unreachable.Close()
+	if !unreachable.Acquire("github.poller:acme/api") {
+		t.Fatalf("expected fail-open when the gateway is unreachable")
+	}
+
+	var none *Coordinator
+	if !none.Acquire("x") || none.Do(context.Background(), "x", func() error { return nil }) != nil {
+		t.Fatalf("expected nil coordinator to grant everything")
+	}
+}
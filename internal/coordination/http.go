@@ -0,0 +1,165 @@
+package coordination
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Rotas servidas pelo gateway local (registradas com o prefixo RoutePrefix).
+const (
+	RoutePrefix  = "/api/lease/"
+	routeAcquire = "/api/lease/acquire"
+	routeRelease = "/api/lease/release"
+	routeList    = "/api/lease/list"
+
+	maxLeaseRequestBytes = 16 << 10
+)
+
+type leaseRequest struct {
+	Name   string `json:"name"`
+	Holder string `json:"holder"`
+	TTLMs  int64  `json:"ttlMs,omitempty"`
+}
+
+type acquireResponse struct {
+	Lease   Lease `json:"lease"`
+	Granted bool  `json:"granted"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewHandler expõe o backend (normalmente a Table do dono do gateway) via HTTP.
+func NewHandler(backend Backend) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(routeAcquire, func(w http.ResponseWriter, r *http.Request) {
+		req, ok := decodeLeaseRequest(w, r)
+		if !ok {
+			return
+		}
+		lease, granted, err := backend.Acquire(req.Name, req.Holder, time.Duration(req.TTLMs)*time.Millisecond)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, acquireResponse{Lease: lease, Granted: granted})
+	})
+	mux.HandleFunc(routeRelease, func(w http.ResponseWriter, r *http.Request) {
+		req, ok := decodeLeaseRequest(w, r)
+		if !ok {
+			return
+		}
+		if err := backend.Release(req.Name, req.Holder); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+	})
+	mux.HandleFunc(routeList, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+			return
+		}
+		leases, err := backend.List()
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, leases)
+	})
+	return mux
+}
+
+func decodeLeaseRequest(w http.ResponseWriter, r *http.Request) (leaseRequest, bool) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+		return leaseRequest{}, false
+	}
+	var req leaseRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxLeaseRequestBytes)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid json payload"})
+		return leaseRequest{}, false
+	}
+	return req, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+// HTTPBackend acessa a tabela de leases do gateway de outra instância.
+type HTTPBackend struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPBackend cria o backend remoto; baseURL é a URL do gateway local (sem barra final).
+func NewHTTPBackend(baseURL string, client *http.Client) *HTTPBackend {
+	if client == nil {
+		client = &http.Client{Timeout: 4 * time.Second}
+	}
+	return &HTTPBackend{baseURL: strings.TrimRight(strings.TrimSpace(baseURL), "/"), client: client}
+}
+
+// Acquire implementa Backend.
+func (b *HTTPBackend) Acquire(name string, holder string, ttl time.Duration) (Lease, bool, error) {
+	var resp acquireResponse
+	err := b.post(routeAcquire, leaseRequest{Name: name, Holder: holder, TTLMs: ttl.Milliseconds()}, &resp)
+	return resp.Lease, resp.Granted, err
+}
+
+// Release implementa Backend.
+func (b *HTTPBackend) Release(name string, holder string) error {
+	return b.post(routeRelease, leaseRequest{Name: name, Holder: holder}, nil)
+}
+
+// List implementa Backend.
+func (b *HTTPBackend) List() ([]Lease, error) {
+	resp, err := b.client.Get(b.baseURL + routeList)
+	if err != nil {
+		return nil, fmt.Errorf("lease gateway request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	var leases []Lease
+	if err := decodeResponse(resp, &leases); err != nil {
+		return nil, err
+	}
+	return leases, nil
+}
+
+func (b *HTTPBackend) post(route string, payload leaseRequest, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal lease request: %w", err)
+	}
+	resp, err := b.client.Post(b.baseURL+route, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("lease gateway request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	return decodeResponse(resp, out)
+}
+
+func decodeResponse(resp *http.Response, out interface{}) error {
+	if resp.StatusCode >= 400 {
+		var payload errorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err == nil && payload.Error != "" {
+			return fmt.Errorf("lease gateway: %s", payload.Error)
+		}
+		return fmt.Errorf("lease gateway request failed with status %d", resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode lease gateway response: %w", err)
+	}
+	return nil
+}
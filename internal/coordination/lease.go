@@ -0,0 +1,98 @@
+// Package coordination evita que instâncias do app apontando para o mesmo data dir façam
+// o mesmo trabalho em paralelo (polling, auto-fetch, backups, gravações de snapshot).
+// A instância dona do gateway local mantém a tabela de leases; as demais a acessam por HTTP.
+package coordination
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	minLeaseTTL = time.Second
+	maxLeaseTTL = 5 * time.Minute
+)
+
+// Lease representa a posse temporária de um recurso compartilhado.
+type Lease struct {
+	Name       string    `json:"name"`
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// Backend concede e libera leases (tabela local ou gateway via HTTP).
+type Backend interface {
+	// Acquire concede ou renova o lease; granted=false retorna o lease do dono atual.
+	Acquire(name string, holder string, ttl time.Duration) (lease Lease, granted bool, err error)
+	Release(name string, holder string) error
+	List() ([]Lease, error)
+}
+
+// Table é a tabela de leases em memória mantida pela instância dona do gateway.
+type Table struct {
+	mu     sync.Mutex
+	leases map[string]Lease
+	now    func() time.Time
+}
+
+// NewTable cria uma tabela vazia.
+func NewTable() *Table {
+	return &Table{leases: make(map[string]Lease), now: time.Now}
+}
+
+// Acquire concede o lease se ele estiver livre, expirado ou já for do mesmo holder.
+func (t *Table) Acquire(name string, holder string, ttl time.Duration) (Lease, bool, error) {
+	name = strings.TrimSpace(name)
+	holder = strings.TrimSpace(holder)
+	if name == "" || holder == "" {
+		return Lease{}, false, fmt.Errorf("lease name and holder are required")
+	}
+	ttl = max(minLeaseTTL, min(ttl, maxLeaseTTL))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	current, exists := t.leases[name]
+	if exists && current.Holder != holder && now.Before(current.ExpiresAt) {
+		return current, false, nil
+	}
+	if !exists || current.Holder != holder || !now.Before(current.ExpiresAt) {
+		current = Lease{Name: name, Holder: holder, AcquiredAt: now}
+	}
+	current.ExpiresAt = now.Add(ttl)
+	t.leases[name] = current
+	return current, true, nil
+}
+
+// Release libera o lease se ele pertencer ao holder.
+func (t *Table) Release(name string, holder string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if current, exists := t.leases[strings.TrimSpace(name)]; exists && current.Holder == strings.TrimSpace(holder) {
+		delete(t.leases, current.Name)
+	}
+	return nil
+}
+
+// List retorna os leases válidos ordenados por nome, descartando os expirados.
+func (t *Table) List() ([]Lease, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	leases := make([]Lease, 0, len(t.leases))
+	for name, lease := range t.leases {
+		if !now.Before(lease.ExpiresAt) {
+			delete(t.leases, name)
+			continue
+		}
+		leases = append(leases, lease)
+	}
+	sort.Slice(leases, func(i, j int) bool { return leases[i].Name < leases[j].Name })
+	return leases, nil
+}
@@ -0,0 +1,567 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration é um passo versionado do schema. Down nil = migração irreversível.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(tx *gorm.DB) error
+	Down        func(tx *gorm.DB) error
+}
+
+// SchemaVersion registra cada migração aplicada (tabela schema_version).
+type SchemaVersion struct {
+	Version     int       `gorm:"primaryKey;autoIncrement:false" json:"version"`
+	Description string    `json:"description"`
+	AppliedAt   time.Time `json:"appliedAt"`
+}
+
+// TableName mantém o nome singular da tabela de versionamento.
+func (SchemaVersion) TableName() string {
+	return "schema_version"
+}
+
+const maxMigrationBackups = 5
+
+// migrations lista o schema em ordem. Novas mudanças entram como nova versão; nunca editar uma aplicada.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "baseline schema",
+		Up: func(tx *gorm.DB) error {
+			// Bancos anteriores ao versionamento já têm as tabelas; AutoMigrate só completa colunas.
+			return tx.AutoMigrate(
+				&UserConfig{},
+				&Workspace{},
+				&AgentSession{},
+				&ChatHistory{},
+				&SessionHistory{},
+				&CollabSessionState{},
+				&AuditLog{},
+				&TerminalSnapshot{},
+				&WorkspaceTemplate{},
+				&WorkspaceRepo{},
+				&GitAutoFetchSetting{},
+				&GitHubRepoAccount{},
+			)
+		},
+	},
+	{
+		Version:     2,
+		Description: "composite indexes for chat history and workspace repos",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_chat_histories_agent_created ON chat_histories(agent_id, created_at)").Error; err != nil {
+				return err
+			}
+			return tx.Exec("CREATE INDEX IF NOT EXISTS idx_workspace_repos_workspace_sort ON workspace_repos(workspace_id, sort_order)").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_chat_histories_agent_created").Error; err != nil {
+				return err
+			}
+			return tx.Exec("DROP INDEX IF EXISTS idx_workspace_repos_workspace_sort").Error
+		},
+	},
+	{
+		Version:     3,
+		Description: "rolling backup settings",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&UserConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropUserConfigColumns(tx, "BackupEnabled", "BackupIntervalHours", "BackupRetention", "BackupDir")
+		},
+	},
+	{
+		Version:     4,
+		Description: "audit log retention settings and session search index",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&UserConfig{}); err != nil {
+				return err
+			}
+			return tx.Exec("CREATE INDEX IF NOT EXISTS idx_audit_logs_session_created ON audit_logs(session_id, created_at)").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_audit_logs_session_created").Error; err != nil {
+				return err
+			}
+			return dropUserConfigColumns(tx, "AuditRetentionDays", "AuditMaxRows")
+		},
+	},
+	{
+		Version:     5,
+		Description: "auto-update channel",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&UserConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropUserConfigColumns(tx, "UpdateChannel")
+		},
+	},
+	{
+		Version:     6,
+		Description: "proxy and custom CA settings",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&UserConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropUserConfigColumns(tx, "ProxyMode", "ProxyURL", "ProxyNoProxy", "CABundlePath")
+		},
+	},
+	{
+		Version:     7,
+		Description: "workspace file watching",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Workspace{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&Workspace{}, "WatchFiles") {
+				return tx.Migrator().DropColumn(&Workspace{}, "WatchFiles")
+			}
+			return nil
+		},
+	},
+	{
+		Version:     8,
+		Description: "workspace external editor",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Workspace{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&Workspace{}, "Editor") {
+				return tx.Migrator().DropColumn(&Workspace{}, "Editor")
+			}
+			return nil
+		},
+	},
+	{
+		Version:     9,
+		Description: "external diff/merge tools",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&UserConfig{}, &GitExternalToolSetting{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&GitExternalToolSetting{}); err != nil {
+				return err
+			}
+			return dropUserConfigColumns(tx, "MergeTool", "DiffTool")
+		},
+	},
+	{
+		Version:     10,
+		Description: "saved github issue filters",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&GitHubIssueFilter{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&GitHubIssueFilter{})
+		},
+	},
+	{
+		Version:     11,
+		Description: "terminal restore replay commands",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&AgentSession{}, &TerminalSnapshot{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropColumns(tx, &TerminalSnapshot{}, "ReplayCmds"); err != nil {
+				return err
+			}
+			return dropColumns(tx, &AgentSession{}, "ReplayCmds")
+		},
+	},
+	{
+		Version:     12,
+		Description: "agent auto-start policy",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&AgentSession{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &AgentSession{}, "AutoStart")
+		},
+	},
+	{
+		Version:     13,
+		Description: "agent startup dependencies and readiness",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&AgentSession{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, &AgentSession{}, "DependsOn", "ReadyMode", "ReadyRegex")
+		},
+	},
+	{
+		Version:     14,
+		Description: "command snippets",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&CommandSnippet{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&CommandSnippet{})
+		},
+	},
+	{
+		Version:     15,
+		Description: "lifecycle script hooks",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&LifecycleHook{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&LifecycleHook{})
+		},
+	},
+	{
+		Version:     16,
+		Description: "outbound notification targets",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&NotificationTarget{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&NotificationTarget{})
+		},
+	},
+	{
+		Version:     17,
+		Description: "issue tracker settings",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&UserConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropUserConfigColumns(tx, "IssueTracker", "IssueTrackerURL", "IssueTrackerEmail", "IssueProjectKeys")
+		},
+	},
+	{
+		Version:     18,
+		Description: "mcp server settings",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&UserConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropUserConfigColumns(tx, "MCPEnabled", "MCPPort", "MCPToolPolicies")
+		},
+	},
+	{
+		Version:     19,
+		Description: "rest api settings",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&UserConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropUserConfigColumns(tx, "RESTAPIEnabled", "RESTAPIPort", "RESTAPIAllowWrite")
+		},
+	},
+	{
+		Version:     20,
+		Description: "clipboard history opt-in",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&UserConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropUserConfigColumns(tx, "ClipboardHistory")
+		},
+	},
+	{
+		Version:     21,
+		Description: "workspace tasks",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&WorkspaceTask{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&WorkspaceTask{})
+		},
+	},
+	{
+		Version:     22,
+		Description: "problem matchers",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ProblemMatcher{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&ProblemMatcher{})
+		},
+	},
+	{
+		Version:     23,
+		Description: "git branch naming policies",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&GitBranchNamingPolicy{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&GitBranchNamingPolicy{})
+		},
+	},
+	{
+		Version:     24,
+		Description: "git PR base remote preferences",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&GitPRRemotePreference{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&GitPRRemotePreference{})
+		},
+	},
+	{
+		Version:     25,
+		Description: "workspace session co-authors",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Workspace{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&Workspace{}, "GitCoAuthorGuests") {
+				return tx.Migrator().DropColumn(&Workspace{}, "GitCoAuthorGuests")
+			}
+			return nil
+		},
+	},
+	{
+		Version:     26,
+		Description: "session timeout policies",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&UserConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropUserConfigColumns(tx, "SessionIdleMinutes", "SessionMaxMinutes", "SessionWarnMinutes")
+		},
+	},
+	{
+		Version:     27,
+		Description: "docker session security profile",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&UserConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropUserConfigColumns(tx, "SessionSandbox")
+		},
+	},
+	{
+		Version:     28,
+		Description: "docker session network policy",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&UserConfig{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropUserConfigColumns(tx, "SessionNetworkMode", "SessionAllowedHosts")
+		},
+	},
+}
+
+func dropUserConfigColumns(tx *gorm.DB, columns ...string) error {
+	return dropColumns(tx, &UserConfig{}, columns...)
+}
+
+func dropColumns(tx *gorm.DB, model interface{}, columns ...string) error {
+	for _, column := range columns {
+		if tx.Migrator().HasColumn(model, column) {
+			if err := tx.Migrator().DropColumn(model, column); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// LatestSchemaVersion retorna a versão mais recente conhecida pelo binário.
+func LatestSchemaVersion() int {
+	return latestMigrationVersion(migrations)
+}
+
+func latestMigrationVersion(list []Migration) int {
+	latest := 0
+	for _, migration := range list {
+		if migration.Version > latest {
+			latest = migration.Version
+		}
+	}
+	return latest
+}
+
+// currentSchemaVersion lê a maior versão aplicada (0 = banco sem versionamento).
+func currentSchemaVersion(db *gorm.DB) (int, error) {
+	if !db.Migrator().HasTable(&SchemaVersion{}) {
+		return 0, nil
+	}
+	var version int
+	if err := db.Model(&SchemaVersion{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// migrateTo aplica (up) ou reverte (down) migrações até target, com backup prévio do arquivo.
+// Retorna o caminho do backup criado ("" quando não houve mudança ou o banco estava vazio).
+func migrateTo(db *gorm.DB, dbPath string, list []Migration, target int) (string, error) {
+	if err := db.AutoMigrate(&SchemaVersion{}); err != nil {
+		return "", fmt.Errorf("failed to create schema_version table: %w", err)
+	}
+	current, err := currentSchemaVersion(db)
+	if err != nil {
+		return "", err
+	}
+	latest := latestMigrationVersion(list)
+	if current > latest {
+		return "", fmt.Errorf("database schema v%d is newer than this app supports (v%d)", current, latest)
+	}
+	if target < 0 || target > latest {
+		return "", fmt.Errorf("unknown schema version %d", target)
+	}
+	if current == target {
+		return "", nil
+	}
+
+	ordered := append([]Migration(nil), list...)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].Version < ordered[j].Version })
+
+	backupPath := ""
+	if current > 0 || hasUserTables(db) {
+		backupPath, err = backupDatabase(db, dbPath, current)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if target > current {
+		for _, migration := range ordered {
+			if migration.Version <= current || migration.Version > target {
+				continue
+			}
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := migration.Up(tx); err != nil {
+					return err
+				}
+				return tx.Create(&SchemaVersion{Version: migration.Version, Description: migration.Description, AppliedAt: time.Now()}).Error
+			})
+			if err != nil {
+				return backupPath, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+			}
+			log.Printf("[DB] Applied migration %d: %s", migration.Version, migration.Description)
+		}
+		return backupPath, nil
+	}
+
+	for i := len(ordered) - 1; i >= 0; i-- {
+		migration := ordered[i]
+		if migration.Version > current || migration.Version <= target {
+			continue
+		}
+		if migration.Down == nil {
+			return backupPath, fmt.Errorf("migration %d (%s) is irreversible", migration.Version, migration.Description)
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaVersion{}, migration.Version).Error
+		})
+		if err != nil {
+			return backupPath, fmt.Errorf("rollback of migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+		log.Printf("[DB] Reverted migration %d: %s", migration.Version, migration.Description)
+	}
+	return backupPath, nil
+}
+
+func hasUserTables(db *gorm.DB) bool {
+	var count int64
+	db.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name NOT IN ('schema_version', '_orch_write_probe')").Scan(&count)
+	return count > 0
+}
+
+// backupDatabase copia o banco (VACUUM INTO) para backups/ ao lado do arquivo e mantém os mais recentes.
+func backupDatabase(db *gorm.DB, dbPath string, fromVersion int) (string, error) {
+	if strings.TrimSpace(dbPath) == "" {
+		return "", nil
+	}
+	backupDir := filepath.Join(filepath.Dir(dbPath), "backups")
+	if err := os.MkdirAll(backupDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create backup dir: %w", err)
+	}
+	name := strings.TrimSuffix(filepath.Base(dbPath), filepath.Ext(dbPath))
+	// Timestamp antes da versão: ordem lexical = ordem cronológica (usada na limpeza).
+	backupPath := filepath.Join(backupDir, fmt.Sprintf("%s-%s-v%d.db", name, time.Now().Format("20060102T150405.000"), fromVersion))
+	if err := db.Exec("VACUUM INTO ?", backupPath).Error; err != nil {
+		return "", fmt.Errorf("failed to backup database before migrating: %w", err)
+	}
+	_ = os.Chmod(backupPath, 0600)
+	pruneDatabaseBackups(backupDir, name)
+	log.Printf("[DB] Backup created at %s", backupPath)
+	return backupPath, nil
+}
+
+func pruneDatabaseBackups(backupDir, name string) {
+	matches, err := filepath.Glob(filepath.Join(backupDir, name+"-*-v*.db"))
+	if err != nil || len(matches) <= maxMigrationBackups {
+		return
+	}
+	sort.Strings(matches)
+	for _, stale := range matches[:len(matches)-maxMigrationBackups] {
+		_ = os.Remove(stale)
+	}
+}
+
+// DatabaseInfo resume arquivo, tamanho e versão do schema.
+type DatabaseInfo struct {
+	Path              string          `json:"path"`
+	SizeBytes         int64           `json:"sizeBytes"`
+	WALSizeBytes      int64           `json:"walSizeBytes"`
+	SchemaVersion     int             `json:"schemaVersion"`
+	LatestVersion     int             `json:"latestVersion"`
+	PendingMigrations int             `json:"pendingMigrations"`
+	Migrations        []SchemaVersion `json:"migrations"`
+	LastBackupPath    string          `json:"lastBackupPath,omitempty"`
+}
+
+// GetDatabaseInfo retorna versão do schema, migrações aplicadas e tamanho do banco.
+func (s *Service) GetDatabaseInfo() (*DatabaseInfo, error) {
+	version, err := currentSchemaVersion(s.db)
+	if err != nil {
+		return nil, err
+	}
+	info := &DatabaseInfo{
+		Path:           s.path,
+		SchemaVersion:  version,
+		LatestVersion:  LatestSchemaVersion(),
+		Migrations:     []SchemaVersion{},
+		LastBackupPath: s.lastBackupPath,
+	}
+	for _, migration := range migrations {
+		if migration.Version > version {
+			info.PendingMigrations++
+		}
+	}
+	if version > 0 {
+		if err := s.db.Order("version ASC").Find(&info.Migrations).Error; err != nil {
+			return nil, fmt.Errorf("failed to list migrations: %w", err)
+		}
+	}
+
+	var pageCount, pageSize int64
+	s.db.Raw("PRAGMA page_count").Scan(&pageCount)
+	s.db.Raw("PRAGMA page_size").Scan(&pageSize)
+	info.SizeBytes = pageCount * pageSize
+	if s.path != "" {
+		if stat, err := os.Stat(s.path + "-wal"); err == nil {
+			info.WALSizeBytes = stat.Size()
+		}
+	}
+	return info, nil
+}
+
+// MigrateSchema leva o schema até a versão informada (down para versões anteriores).
+func (s *Service) MigrateSchema(target int) error {
+	backupPath, err := migrateTo(s.db, s.path, migrations, target)
+	if backupPath != "" {
+		s.lastBackupPath = backupPath
+	}
+	return err
+}
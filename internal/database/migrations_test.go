@@ -0,0 +1,114 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openMigrationTestDB(t *testing.T) (*gorm.DB, string) {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "orch.db")
+	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return db, dbPath
+}
+
+func hasIndex(db *gorm.DB, name string) bool {
+	var count int64
+	db.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = ?", name).Scan(&count)
+	return count > 0
+}
+
+func TestMigrateLegacyDatabaseBacksUpAndRecordsVersion(t *testing.T) {
+	db, dbPath := openMigrationTestDB(t)
+	// Banco criado antes do versionamento (só AutoMigrate).
+	if err := db.AutoMigrate(&Workspace{}, &ChatHistory{}, &WorkspaceRepo{}); err != nil {
+		t.Fatalf("failed to seed legacy schema: %v", err)
+	}
+	if err := db.Create(&Workspace{UserID: "local", Name: "Legacy"}).Error; err != nil {
+		t.Fatalf("failed to seed workspace: %v", err)
+	}
+
+	backupPath, err := migrateTo(db, dbPath, migrations, LatestSchemaVersion())
+	if err != nil {
+		t.Fatalf("migrateTo() error: %v", err)
+	}
+	if backupPath == "" || !strings.HasPrefix(backupPath, filepath.Join(filepath.Dir(dbPath), "backups")) {
+		t.Fatalf("expected backup before migrating legacy database, got %q", backupPath)
+	}
+	if _, err := os.Stat(backupPath); err != nil {
+		t.Fatalf("backup file missing: %v", err)
+	}
+	if version, _ := currentSchemaVersion(db); version != LatestSchemaVersion() {
+		t.Fatalf("expected schema version %d, got %d", LatestSchemaVersion(), version)
+	}
+	if !hasIndex(db, "idx_chat_histories_agent_created") || !db.Migrator().HasTable(&GitHubRepoAccount{}) {
+		t.Fatalf("expected baseline tables and indexes after migrating")
+	}
+
+	svc := &Service{db: db, path: dbPath}
+	if err := svc.MigrateSchema(1); err != nil {
+		t.Fatalf("MigrateSchema(1) error: %v", err)
+	}
+	if hasIndex(db, "idx_chat_histories_agent_created") {
+		t.Fatalf("expected down migration to drop index")
+	}
+	info, err := svc.GetDatabaseInfo()
+	if err != nil {
+		t.Fatalf("GetDatabaseInfo() error: %v", err)
+	}
+	if info.SchemaVersion != 1 || info.PendingMigrations != LatestSchemaVersion()-1 || len(info.Migrations) != 1 || info.SizeBytes <= 0 || info.LastBackupPath == "" {
+		t.Fatalf("unexpected database info: %+v", info)
+	}
+
+	if err := svc.MigrateSchema(0); err == nil || !strings.Contains(err.Error(), "irreversible") {
+		t.Fatalf("expected baseline rollback to be refused, got %v", err)
+	}
+}
+
+func TestMigrateFreshDatabaseSkipsBackup(t *testing.T) {
+	db, dbPath := openMigrationTestDB(t)
+	backupPath, err := migrateTo(db, dbPath, migrations, LatestSchemaVersion())
+	if err != nil || backupPath != "" {
+		t.Fatalf("expected fresh database migrated without backup, got %q err=%v", backupPath, err)
+	}
+	if backupPath, err := migrateTo(db, dbPath, migrations, LatestSchemaVersion()); err != nil || backupPath != "" {
+		t.Fatalf("expected no-op when already up to date, got %q err=%v", backupPath, err)
+	}
+}
+
+func TestMigrateRejectsNewerSchemaAndFailedStep(t *testing.T) {
+	db, dbPath := openMigrationTestDB(t)
+	if _, err := migrateTo(db, dbPath, migrations, LatestSchemaVersion()); err != nil {
+		t.Fatalf("migrateTo() error: %v", err)
+	}
+	if _, err := migrateTo(db, dbPath, migrations[:1], 1); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Fatalf("expected newer schema to be rejected, got %v", err)
+	}
+
+	broken := append(append([]Migration(nil), migrations...), Migration{
+		Version:     LatestSchemaVersion() + 1,
+		Description: "broken",
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE missing_table ADD COLUMN x TEXT").Error
+		},
+	})
+	if _, err := migrateTo(db, dbPath, broken, LatestSchemaVersion()+1); err == nil {
+		t.Fatalf("expected failing migration to return error")
+	}
+	if version, _ := currentSchemaVersion(db); version != LatestSchemaVersion() {
+		t.Fatalf("expected failed migration not to be recorded, got version %d", version)
+	}
+}
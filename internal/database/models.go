@@ -15,27 +15,80 @@ type UserConfig struct {
 	FontSize            int       `gorm:"default:14" json:"fontSize"`
 	FontFamily          string    `gorm:"default:JetBrains Mono" json:"fontFamily"`
 	CursorStyle         string    `gorm:"default:line" json:"cursorStyle"`
-	ShortcutBindings    string    `gorm:"type:text" json:"shortcutBindings,omitempty"` // JSON de atalhos customizados
-	LayoutState         string    `gorm:"type:text" json:"layoutState,omitempty"`      // Serialized Command Center layout
+	ShortcutBindings    string    `gorm:"type:text" json:"shortcutBindings,omitempty"`    // JSON de atalhos customizados
+	LayoutState         string    `gorm:"type:text" json:"layoutState,omitempty"`         // Serialized Command Center layout
+	GitHubWebhookRelay  string    `gorm:"default:''" json:"githubWebhookRelay,omitempty"` // URL SSE do relay de webhooks (vazio = só polling)
+	GitHubWebhookSecret string    `json:"-"`                                              // Secret do webhook para validar X-Hub-Signature-256
+	BackupEnabled       bool      `gorm:"default:false" json:"backupEnabled"`             // backup automático rotativo
+	BackupIntervalHours int       `gorm:"default:24" json:"backupIntervalHours"`
+	BackupRetention     int       `gorm:"default:7" json:"backupRetention"`      // quantidade de arquivos mantidos
+	BackupDir           string    `gorm:"default:''" json:"backupDir,omitempty"` // vazio = <DataDir>/backups/archives
+	AuditRetentionDays  int       `gorm:"default:90" json:"auditRetentionDays"`  // 0 = sem limite de idade
+	AuditMaxRows        int       `gorm:"default:50000" json:"auditMaxRows"`     // 0 = sem limite de linhas
+	UpdateChannel       string    `gorm:"default:stable" json:"updateChannel"`   // stable | beta
+	ProxyMode           string    `gorm:"default:system" json:"proxyMode"`       // system | manual | none
+	ProxyURL            string    `gorm:"default:''" json:"proxyURL,omitempty"`
+	ProxyNoProxy        string    `gorm:"default:''" json:"proxyNoProxy,omitempty"` // hosts separados por vírgula
+	CABundlePath        string    `gorm:"default:''" json:"caBundlePath,omitempty"` // PEM extra de CAs (proxy corporativo)
+	MergeTool           string    `gorm:"default:''" json:"mergeTool,omitempty"`    // Ferramenta externa de merge padrão (vazio = git mergetool)
+	DiffTool            string    `gorm:"default:''" json:"diffTool,omitempty"`     // Ferramenta externa de diff padrão (vazio = git difftool)
+	IssueTracker        string    `gorm:"default:''" json:"issueTracker,omitempty"` // "" | jira | linear (token fica no Keychain)
+	IssueTrackerURL     string    `gorm:"default:''" json:"issueTrackerUrl,omitempty"`
+	IssueTrackerEmail   string    `gorm:"default:''" json:"issueTrackerEmail,omitempty"`
+	IssueProjectKeys    string    `gorm:"default:''" json:"issueProjectKeys,omitempty"` // chaves separadas por vírgula (PROJ,OPS)
+	MCPEnabled          bool      `gorm:"default:false" json:"mcpEnabled"`              // servidor MCP local para agentes externos
+	MCPPort             int       `gorm:"default:7391" json:"mcpPort"`
+	MCPToolPolicies     string    `gorm:"type:text" json:"mcpToolPolicies,omitempty"` // JSON map ferramenta -> allow|ask|deny
+	RESTAPIEnabled      bool      `gorm:"default:false" json:"restApiEnabled"`        // API REST local (token no Keychain)
+	RESTAPIPort         int       `gorm:"default:7392" json:"restApiPort"`
+	RESTAPIAllowWrite   bool      `gorm:"default:false" json:"restApiAllowWrite"`   // libera rotas que alteram estado
+	ClipboardHistory    bool      `gorm:"default:false" json:"clipboardHistory"`    // opt-in do histórico de clipboard
+	SessionIdleMinutes  int       `gorm:"default:0" json:"sessionIdleMinutes"`      // revoga escrita de guests ociosos (0 = off)
+	SessionMaxMinutes   int       `gorm:"default:0" json:"sessionMaxMinutes"`       // duração máxima da sessão (0 = off)
+	SessionWarnMinutes  int       `gorm:"default:2" json:"sessionWarnMinutes"`      // aviso antes de aplicar a política
+	SessionSandbox      string    `gorm:"default:'strict'" json:"sessionSandbox"`   // perfil de segurança dos containers de sessão
+	SessionNetworkMode  string    `gorm:"default:'none'" json:"sessionNetworkMode"` // none | allowlist | full
+	SessionAllowedHosts string    `gorm:"type:text" json:"sessionAllowedHosts"`     // hosts da allow-list, um por linha
 	CreatedAt           time.Time `json:"createdAt"`
 	UpdatedAt           time.Time `json:"updatedAt"`
 }
 
 // Workspace representa um projeto/workspace do usuário
 type Workspace struct {
-	ID           uint           `gorm:"primaryKey" json:"id"`
-	UserID       string         `gorm:"index;not null" json:"userId"`
-	Name         string         `gorm:"not null" json:"name"`
-	Path         string         `gorm:"not null" json:"path"`
-	GitRemote    string         `json:"gitRemote,omitempty"`
-	Owner        string         `json:"owner,omitempty"`
-	Repo         string         `json:"repo,omitempty"`
-	Color        string         `gorm:"default:''" json:"color,omitempty"`
-	IsActive     bool           `gorm:"default:false" json:"isActive"`
-	Agents       []AgentSession `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"agents,omitempty"`
-	LastOpenedAt *time.Time     `json:"lastOpenedAt,omitempty"`
-	CreatedAt    time.Time      `json:"createdAt"`
-	UpdatedAt    time.Time      `json:"updatedAt"`
+	ID                uint            `gorm:"primaryKey" json:"id"`
+	UserID            string          `gorm:"index;not null" json:"userId"`
+	Name              string          `gorm:"not null" json:"name"`
+	Path              string          `gorm:"not null" json:"path"`
+	GitRemote         string          `json:"gitRemote,omitempty"`
+	Owner             string          `json:"owner,omitempty"`
+	Repo              string          `json:"repo,omitempty"`
+	Color             string          `gorm:"default:''" json:"color,omitempty"`
+	DockerImage       string          `gorm:"default:''" json:"dockerImage,omitempty"`   // Imagem padrão para terminais/sessões Docker
+	GitPathScope      string          `gorm:"default:''" json:"gitPathScope,omitempty"`  // Subdiretório filtrado no Git Panel (monorepo)
+	GitAllowNoVerify  bool            `gorm:"default:false" json:"gitAllowNoVerify"`     // Permite commit com --no-verify no Git Panel
+	GitCommitLintMode string          `gorm:"default:'warn'" json:"gitCommitLintMode"`   // Validação Conventional Commits: off | warn | block
+	GitHubAccount     string          `gorm:"default:''" json:"githubAccount,omitempty"` // Conta GitHub usada pelos repositórios do workspace (vazio = conta ativa)
+	WatchFiles        bool            `gorm:"default:false" json:"watchFiles"`           // Monitora os arquivos do projeto (eventos workspace:file_changed)
+	Editor            string          `gorm:"default:''" json:"editor,omitempty"`        // Editor externo preferido (id do pacote editor; vazio = detecção automática)
+	GitCoAuthorGuests bool            `gorm:"default:false" json:"gitCoAuthorGuests"`    // Adiciona Co-authored-by dos convidados conectados aos commits do Git Panel
+	IsActive          bool            `gorm:"default:false" json:"isActive"`
+	Agents            []AgentSession  `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"agents,omitempty"`
+	Repos             []WorkspaceRepo `gorm:"foreignKey:WorkspaceID;constraint:OnDelete:CASCADE" json:"repos,omitempty"`
+	LastOpenedAt      *time.Time      `json:"lastOpenedAt,omitempty"`
+	CreatedAt         time.Time       `json:"createdAt"`
+	UpdatedAt         time.Time       `json:"updatedAt"`
+}
+
+// WorkspaceRepo representa um repositório Git anexado a um workspace (multi-repo).
+type WorkspaceRepo struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint      `gorm:"index;not null" json:"workspaceId"`
+	Name        string    `gorm:"not null" json:"name"`
+	Path        string    `gorm:"not null" json:"path"`
+	IsPrimary   bool      `gorm:"default:false" json:"isPrimary"`
+	SortOrder   int       `gorm:"default:0" json:"sortOrder"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
 }
 
 // AgentSession representa uma sessão de agente/terminal vinculada a um workspace.
@@ -43,7 +96,7 @@ type AgentSession struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
 	WorkspaceID uint      `gorm:"index;not null" json:"workspaceId"`
 	Name        string    `gorm:"not null" json:"name"`
-	Type        string    `gorm:"default:terminal" json:"type"` // "terminal" | "ai_agent" (legacy: "github")
+	Type        string    `gorm:"default:terminal" json:"type"` // "terminal" | "ai_agent" | "kube_pod" (legacy: "github")
 	Shell       string    `gorm:"default:/bin/zsh" json:"shell"`
 	Cwd         string    `gorm:"default:''" json:"cwd"`
 	UseDocker   bool      `gorm:"default:false" json:"useDocker"`
@@ -52,6 +105,15 @@ type AgentSession struct {
 	LayoutJSON  string    `gorm:"type:text" json:"layoutJson,omitempty"` // MosaicNode coords serializado
 	SortOrder   int       `gorm:"default:0" json:"sortOrder"`
 	IsMinimized bool      `gorm:"default:false" json:"isMinimized"`
+	KubeTarget  string    `gorm:"type:text" json:"kubeTarget,omitempty"`  // JSON do alvo kube (context/namespace/pod/container)
+	Env         string    `gorm:"type:text" json:"env,omitempty"`         // JSON []string "KEY=VALUE" aplicado ao PTY
+	StartupCmds string    `gorm:"type:text" json:"startupCmds,omitempty"` // JSON []string executado ao abrir o terminal
+	ReplayCmds  string    `gorm:"type:text" json:"replayCmds,omitempty"`  // JSON []string reexecutado ao restaurar o terminal
+	RepoPath    string    `gorm:"default:''" json:"repoPath,omitempty"`   // Repositório vinculado (workspaces multi-repo)
+	AutoStart   string    `gorm:"default:manual" json:"autoStart"`        // "manual" | "app_launch" | "workspace_activated"
+	DependsOn   string    `gorm:"type:text" json:"dependsOn,omitempty"`   // JSON []uint de agentes que precisam estar prontos antes
+	ReadyMode   string    `gorm:"default:''" json:"readyMode,omitempty"`  // "started" | "pattern" | "exit"
+	ReadyRegex  string    `gorm:"type:text" json:"readyRegex,omitempty"`  // regex de prontidão (ReadyMode "pattern")
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
@@ -111,14 +173,171 @@ type AuditLog struct {
 
 // TerminalSnapshot persiste o estado de um terminal/CLI para restauração após restart.
 type TerminalSnapshot struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	PaneID     string    `gorm:"not null;index" json:"paneId"`
+	CLIType    string    `gorm:"default:''" json:"cliType"` // "gemini" | "claude" | "codex" | "opencode" | "" (terminal simples)
+	Shell      string    `gorm:"default:/bin/zsh" json:"shell"`
+	Cwd        string    `json:"cwd"`
+	UseDocker  bool      `gorm:"default:false" json:"useDocker"`
+	PaneTitle  string    `json:"paneTitle"`
+	PaneType   string    `gorm:"default:terminal" json:"paneType"` // "terminal" | "ai_agent"
+	Config     string    `gorm:"type:text" json:"config,omitempty"`
+	ReplayCmds string    `gorm:"type:text" json:"replayCmds,omitempty"` // JSON []string reexecutado na restauração
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// WorkspaceTemplate descreve um workspace pré-configurado (agentes, shell, env, imagem Docker).
+type WorkspaceTemplate struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"uniqueIndex;not null" json:"name"`
+	Description string    `gorm:"type:text" json:"description,omitempty"`
+	Path        string    `json:"path,omitempty"`
+	DockerImage string    `gorm:"default:''" json:"dockerImage,omitempty"`
+	Env         string    `gorm:"type:text" json:"env,omitempty"`    // JSON []string "KEY=VALUE" herdado por todos os agentes
+	Agents      string    `gorm:"type:text" json:"agents,omitempty"` // JSON []WorkspaceTemplateAgent
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// GitHubRepoAccount vincula um repositório (raiz Git) a uma conta GitHub específica.
+// Owner guarda o owner do origin no momento do vínculo para rotear tokens sem consultar o Git.
+type GitHubRepoAccount struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	RepoPath  string    `gorm:"uniqueIndex;not null" json:"repoPath"`
+	Owner     string    `gorm:"default:''" json:"owner"`
+	AccountID string    `gorm:"not null" json:"accountId"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// GitAutoFetchSetting persiste o toggle/intervalo de auto-fetch por repositório.
+type GitAutoFetchSetting struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	RepoPath        string    `gorm:"uniqueIndex;not null" json:"repoPath"`
+	Enabled         bool      `gorm:"default:false" json:"enabled"`
+	IntervalSeconds int       `gorm:"default:300" json:"intervalSeconds"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// GitExternalToolSetting sobrescreve, por repositório, as ferramentas externas de diff/merge
+// (campos vazios herdam o padrão global).
+type GitExternalToolSetting struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	RepoPath  string    `gorm:"uniqueIndex;not null" json:"repoPath"`
+	MergeTool string    `gorm:"default:''" json:"mergeTool"`
+	DiffTool  string    `gorm:"default:''" json:"diffTool"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// GitBranchNamingPolicy guarda, por repositório, os templates de nome de branch
+// (ex.: feature/{ticket}-{slug}, um por linha) e o modo de validação.
+type GitBranchNamingPolicy struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	RepoPath  string    `gorm:"uniqueIndex;not null" json:"repoPath"`
+	Templates string    `gorm:"type:text;default:''" json:"templates"`
+	Mode      string    `gorm:"default:'warn'" json:"mode"` // off | warn | block
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// GitPRRemotePreference guarda, por repositório, o remote preferido como base das Pull Requests
+// (ex.: upstream num clone de fork).
+type GitPRRemotePreference struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	RepoPath   string    `gorm:"uniqueIndex;not null" json:"repoPath"`
+	BaseRemote string    `gorm:"not null" json:"baseRemote"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// CommandSnippet é um comando nomeado da palette de snippets. WorkspaceID 0 = global.
+type CommandSnippet struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint      `gorm:"not null;default:0;uniqueIndex:idx_snippet_scope_name" json:"workspaceId"`
+	Name        string    `gorm:"not null;uniqueIndex:idx_snippet_scope_name" json:"name"`
+	Description string    `gorm:"type:text" json:"description,omitempty"`
+	Command     string    `gorm:"type:text;not null" json:"command"` // aceita placeholders {{nome}} / {{nome:padrão}}
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// LifecycleHook é um script executado em um evento de ciclo de vida (ex.: terminal.created).
+type LifecycleHook struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	Name           string    `gorm:"not null" json:"name"`
+	Event          string    `gorm:"not null;index" json:"event"`
+	Script         string    `gorm:"type:text;not null" json:"script"`
+	Cwd            string    `gorm:"default:''" json:"cwd,omitempty"`
+	TimeoutSeconds int       `gorm:"default:30" json:"timeoutSeconds"`
+	Enabled        bool      `gorm:"default:false" json:"enabled"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// NotificationTarget é um webhook de saída (generic, Slack ou Discord) inscrito em eventos do app.
+type NotificationTarget struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"not null" json:"name"`
+	Kind      string    `gorm:"not null;default:'generic'" json:"kind"` // "generic" | "slack" | "discord"
+	URL       string    `gorm:"type:text;not null" json:"-"`            // webhooks de Slack/Discord carregam o segredo na URL
+	Events    string    `gorm:"type:text" json:"events"`                // JSON []string
+	Template  string    `gorm:"type:text" json:"template,omitempty"`
+	Enabled   bool      `gorm:"default:false" json:"enabled"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Origens de WorkspaceTask.
+const (
+	TaskSourceManual  = "manual"
+	TaskSourceComment = "comment"
+)
+
+// WorkspaceTask é uma tarefa manual do workspace ou, com Source "comment", o registro de conclusão
+// de um TODO/FIXME do código (identificado por CommentKey, que não depende da linha).
+type WorkspaceTask struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint       `gorm:"not null;index" json:"workspaceId"`
+	Source      string     `gorm:"not null;default:'manual'" json:"source"`
+	CommentKey  string     `gorm:"index" json:"commentKey,omitempty"`
+	Title       string     `gorm:"type:text;not null" json:"title"`
+	Path        string     `json:"path,omitempty"` // relativo à raiz do workspace (opcional nas manuais)
+	Line        int        `json:"line,omitempty"`
+	Done        bool       `gorm:"default:false" json:"done"`
+	DoneAt      *time.Time `json:"doneAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// ProblemMatcher é um problem matcher do usuário (como nos tasks do VS Code): uma regex aplicada
+// a cada linha da saída dos terminais e os grupos de captura de cada campo do diagnóstico.
+type ProblemMatcher struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	Name          string    `gorm:"not null" json:"name"`
+	Pattern       string    `gorm:"type:text;not null" json:"pattern"`
+	FileGroup     int       `gorm:"not null" json:"fileGroup"`
+	LineGroup     int       `gorm:"not null" json:"lineGroup"`
+	ColumnGroup   int       `gorm:"default:0" json:"columnGroup"`   // 0 = sem coluna
+	SeverityGroup int       `gorm:"default:0" json:"severityGroup"` // 0 = usa Severity
+	MessageGroup  int       `gorm:"not null" json:"messageGroup"`
+	Severity      string    `gorm:"default:'error'" json:"severity"` // "error" | "warning" | "info"
+	Enabled       bool      `gorm:"default:false" json:"enabled"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// GitHubIssueFilter é um filtro de issues salvo para o quadro de triagem de um repositório GitHub.
+type GitHubIssueFilter struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
-	PaneID    string    `gorm:"not null;index" json:"paneId"`
-	CLIType   string    `gorm:"default:''" json:"cliType"` // "gemini" | "claude" | "codex" | "opencode" | "" (terminal simples)
-	Shell     string    `gorm:"default:/bin/zsh" json:"shell"`
-	Cwd       string    `json:"cwd"`
-	UseDocker bool      `gorm:"default:false" json:"useDocker"`
-	PaneTitle string    `json:"paneTitle"`
-	PaneType  string    `gorm:"default:terminal" json:"paneType"` // "terminal" | "ai_agent"
-	Config    string    `gorm:"type:text" json:"config,omitempty"`
+	Owner     string    `gorm:"not null;index:idx_issue_filter_repo" json:"owner"`
+	Repo      string    `gorm:"not null;index:idx_issue_filter_repo" json:"repo"`
+	Name      string    `gorm:"not null" json:"name"`
+	Labels    string    `gorm:"type:text" json:"labels,omitempty"` // JSON []string
+	Assignee  string    `gorm:"default:''" json:"assignee,omitempty"`
+	State     string    `gorm:"default:'open'" json:"state"` // "open" | "closed" | "all"
+	Query     string    `gorm:"default:''" json:"query,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -18,7 +19,9 @@ import (
 
 // Service encapsula o acesso ao SQLite via GORM
 type Service struct {
-	db *gorm.DB
+	db             *gorm.DB
+	path           string // arquivo SQLite ("" em bancos de teste em memória)
+	lastBackupPath string // último backup criado antes de migrar
 }
 
 var ErrLastWorkspace = errors.New("cannot delete the last workspace")
@@ -30,21 +33,13 @@ func NewService() (*Service, error) {
 		return nil, err
 	}
 
-	// Auto-migrate todos os models
-	if err := db.AutoMigrate(
-		&UserConfig{},
-		&Workspace{},
-		&AgentSession{},
-		&ChatHistory{},
-		&SessionHistory{},
-		&CollabSessionState{},
-		&AuditLog{},
-		&TerminalSnapshot{},
-	); err != nil {
-		return nil, fmt.Errorf("failed to auto-migrate: %w", err)
+	// Migrações versionadas (schema_version) com backup automático antes de alterar o schema
+	backupPath, err := migrateTo(db, dbPath, migrations, LatestSchemaVersion())
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
-	svc := &Service{db: db}
+	svc := &Service{db: db, path: dbPath, lastBackupPath: backupPath}
 	if err := svc.ensureDefaultWorkspace(); err != nil {
 		return nil, fmt.Errorf("failed to ensure default workspace: %w", err)
 	}
@@ -56,6 +51,11 @@ func NewService() (*Service, error) {
 	return svc, nil
 }
 
+// Path retorna o caminho do arquivo SQLite em uso.
+func (s *Service) Path() string {
+	return s.path
+}
+
 func openWritableDatabase() (string, *gorm.DB, error) {
 	candidates := make([]string, 0, 3)
 	if override := strings.TrimSpace(os.Getenv("ORCH_DB_PATH")); override != "" {
@@ -147,6 +147,19 @@ func (s *Service) Close() error {
 	return sqlDB.Close()
 }
 
+// Ping verifica se a conexão responde a consultas.
+func (s *Service) Ping(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return err
+	}
+	var one int
+	return s.db.WithContext(ctx).Raw("SELECT 1").Scan(&one).Error
+}
+
 func (s *Service) ensureDefaultWorkspace() error {
 	return s.db.Transaction(func(tx *gorm.DB) error {
 		var count int64
@@ -225,6 +238,150 @@ func (s *Service) UpdateConfig(cfg *UserConfig) error {
 	return s.db.Save(cfg).Error
 }
 
+// SetGitHubWebhookBridge persiste o relay de webhooks do GitHub (relayURL vazio desliga o bridge).
+func (s *Service) SetGitHubWebhookBridge(relayURL, secret string) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Updates(map[string]interface{}{
+		"git_hub_webhook_relay":  strings.TrimSpace(relayURL),
+		"git_hub_webhook_secret": strings.TrimSpace(secret),
+	}).Error
+}
+
+// SetBackupSettings persiste o agendamento de backups automáticos.
+func (s *Service) SetBackupSettings(enabled bool, intervalHours, retention int, dir string) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Updates(map[string]interface{}{
+		"backup_enabled":        enabled,
+		"backup_interval_hours": intervalHours,
+		"backup_retention":      retention,
+		"backup_dir":            strings.TrimSpace(dir),
+	}).Error
+}
+
+// SetUpdateChannel persiste o canal de atualização (stable | beta).
+func (s *Service) SetUpdateChannel(channel string) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Update("update_channel", strings.TrimSpace(channel)).Error
+}
+
+// SetNetworkSettings persiste proxy e bundle de CAs usados pelos clients HTTP.
+func (s *Service) SetNetworkSettings(proxyMode, proxyURL, noProxy, caBundlePath string) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Updates(map[string]interface{}{
+		"proxy_mode":     strings.TrimSpace(proxyMode),
+		"proxy_url":      strings.TrimSpace(proxyURL),
+		"proxy_no_proxy": strings.TrimSpace(noProxy),
+		"ca_bundle_path": strings.TrimSpace(caBundlePath),
+	}).Error
+}
+
+// SetIssueTrackerSettings persiste o provedor de issues (Jira/Linear); o token fica no Keychain.
+func (s *Service) SetIssueTrackerSettings(provider, baseURL, email, projects string) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Updates(map[string]interface{}{
+		"issue_tracker":       strings.TrimSpace(provider),
+		"issue_tracker_url":   strings.TrimSpace(baseURL),
+		"issue_tracker_email": strings.TrimSpace(email),
+		"issue_project_keys":  strings.TrimSpace(projects),
+	}).Error
+}
+
+// SetMCPSettings persiste o servidor MCP local; o token fica no Keychain.
+func (s *Service) SetMCPSettings(enabled bool, port int, toolPolicies string) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Updates(map[string]interface{}{
+		"mcp_enabled":       enabled,
+		"mcp_port":          port,
+		"mcp_tool_policies": toolPolicies,
+	}).Error
+}
+
+// SetRESTAPISettings persiste a configuração da API REST local.
+func (s *Service) SetRESTAPISettings(enabled bool, port int, allowWrite bool) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Updates(map[string]interface{}{
+		"rest_api_enabled":     enabled,
+		"rest_api_port":        port,
+		"rest_api_allow_write": allowWrite,
+	}).Error
+}
+
+// SetClipboardHistoryEnabled liga/desliga o histórico de clipboard.
+func (s *Service) SetClipboardHistoryEnabled(enabled bool) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Update("clipboard_history", enabled).Error
+}
+
+// SetSessionTimeoutPolicy define a política padrão (em minutos) das novas sessões colaborativas.
+func (s *Service) SetSessionTimeoutPolicy(idleMinutes, maxMinutes, warnMinutes int) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Updates(map[string]interface{}{
+		"session_idle_minutes": idleMinutes,
+		"session_max_minutes":  maxMinutes,
+		"session_warn_minutes": warnMinutes,
+	}).Error
+}
+
+// SetSessionSandbox define o perfil de segurança ("strict"/"standard") dos containers de sessão.
+func (s *Service) SetSessionSandbox(profile string) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Update("session_sandbox", profile).Error
+}
+
+// SetSessionNetworkPolicy define a rede padrão dos containers de sessão (hosts um por linha).
+func (s *Service) SetSessionNetworkPolicy(mode, allowedHosts string) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Updates(map[string]interface{}{
+		"session_network_mode":  mode,
+		"session_allowed_hosts": allowedHosts,
+	}).Error
+}
+
+// SetExternalTools define as ferramentas externas de merge/diff padrão (vazio = configuração do git).
+func (s *Service) SetExternalTools(mergeTool, diffTool string) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Updates(map[string]interface{}{
+		"merge_tool": strings.TrimSpace(mergeTool),
+		"diff_tool":  strings.TrimSpace(diffTool),
+	}).Error
+}
+
 // === Workspace CRUD ===
 
 // ListWorkspaces retorna todos os workspaces
@@ -248,6 +405,9 @@ func (s *Service) GetWorkspacesWithAgents() ([]Workspace, error) {
 		Preload("Agents", func(db *gorm.DB) *gorm.DB {
 			return db.Order("sort_order ASC, id ASC")
 		}).
+		Preload("Repos", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC, id ASC")
+		}).
 		Order("is_active DESC, updated_at DESC, id DESC").
 		Find(&workspaces).Error
 	return workspaces, err
@@ -307,6 +467,107 @@ func (s *Service) SetWorkspaceColor(id uint, color string) error {
 	return s.db.Model(&Workspace{}).Where("id = ?", id).Update("color", color).Error
 }
 
+// SetWorkspaceDockerImage define a imagem Docker padrão de um workspace (vazio remove).
+func (s *Service) SetWorkspaceDockerImage(id uint, image string) error {
+	result := s.db.Model(&Workspace{}).Where("id = ?", id).Update("docker_image", strings.TrimSpace(image))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetWorkspaceGitPathScope persiste o subdiretório usado para filtrar o Git Panel do workspace.
+func (s *Service) SetWorkspaceGitPathScope(id uint, scope string) error {
+	result := s.db.Model(&Workspace{}).Where("id = ?", id).Update("git_path_scope", strings.TrimSpace(scope))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetWorkspaceGitAllowNoVerify define se o Git Panel pode pular hooks (--no-verify) neste workspace.
+func (s *Service) SetWorkspaceGitAllowNoVerify(id uint, allow bool) error {
+	result := s.db.Model(&Workspace{}).Where("id = ?", id).Update("git_allow_no_verify", allow)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetWorkspaceWatchFiles liga/desliga o monitoramento dos arquivos do projeto neste workspace.
+func (s *Service) SetWorkspaceWatchFiles(id uint, enabled bool) error {
+	result := s.db.Model(&Workspace{}).Where("id = ?", id).Update("watch_files", enabled)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetWorkspaceGitCoAuthorGuests liga/desliga os trailers Co-authored-by automáticos dos convidados da sessão.
+func (s *Service) SetWorkspaceGitCoAuthorGuests(id uint, enabled bool) error {
+	result := s.db.Model(&Workspace{}).Where("id = ?", id).Update("git_co_author_guests", enabled)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetWorkspaceEditor define o editor externo preferido do workspace (vazio = detecção automática).
+func (s *Service) SetWorkspaceEditor(id uint, editorID string) error {
+	result := s.db.Model(&Workspace{}).Where("id = ?", id).Update("editor", strings.TrimSpace(editorID))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetWorkspaceGitCommitLintMode define como o Git Panel trata mensagens fora do padrão (off | warn | block).
+func (s *Service) SetWorkspaceGitCommitLintMode(id uint, mode string) error {
+	result := s.db.Model(&Workspace{}).Where("id = ?", id).Update("git_commit_lint_mode", strings.TrimSpace(mode))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetWorkspaceGitHubAccount define a conta GitHub usada pelos repositórios do workspace (vazio = conta ativa).
+func (s *Service) SetWorkspaceGitHubAccount(id uint, accountID string) error {
+	result := s.db.Model(&Workspace{}).Where("id = ?", id).Update("git_hub_account", strings.TrimSpace(accountID))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ClearWorkspaceDockerImageRefs remove a imagem informada de todos os workspaces que a usam.
+func (s *Service) ClearWorkspaceDockerImageRefs(image string) error {
+	return s.db.Model(&Workspace{}).Where("docker_image = ?", strings.TrimSpace(image)).Update("docker_image", "").Error
+}
+
 // SetActiveWorkspace define qual workspace está ativo (desativa os outros)
 func (s *Service) SetActiveWorkspace(id uint) error {
 	return s.db.Transaction(func(tx *gorm.DB) error {
@@ -345,6 +606,15 @@ func (s *Service) DeleteWorkspace(id uint) error {
 		if err := tx.Where("workspace_id = ?", id).Delete(&AgentSession{}).Error; err != nil {
 			return err
 		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&WorkspaceRepo{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&CommandSnippet{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&WorkspaceTask{}).Error; err != nil {
+			return err
+		}
 
 		if err := tx.Delete(&Workspace{}, id).Error; err != nil {
 			return err
@@ -363,6 +633,161 @@ func (s *Service) DeleteWorkspace(id uint) error {
 	})
 }
 
+// CreateWorkspaceWithAgents cria um workspace e seus agentes em uma única transação
+// e o marca como ativo.
+func (s *Service) CreateWorkspaceWithAgents(ws *Workspace, agents []AgentSession) error {
+	if ws == nil {
+		return fmt.Errorf("workspace cannot be nil")
+	}
+
+	ws.Name = strings.TrimSpace(ws.Name)
+	if ws.Name == "" {
+		ws.Name = "Workspace"
+	}
+	ws.UserID = strings.TrimSpace(ws.UserID)
+	if ws.UserID == "" {
+		ws.UserID = "local"
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		ws.IsActive = false
+		if err := tx.Create(ws).Error; err != nil {
+			return err
+		}
+
+		for idx := range agents {
+			agents[idx].ID = 0
+			agents[idx].WorkspaceID = ws.ID
+			agents[idx].SortOrder = idx
+			if err := tx.Create(&agents[idx]).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&Workspace{}).Where("is_active = ?", true).Update("is_active", false).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&Workspace{}).Where("id = ?", ws.ID).Update("is_active", true).Error; err != nil {
+			return err
+		}
+		ws.IsActive = true
+		ws.Agents = agents
+		return nil
+	})
+}
+
+// === WorkspaceRepo CRUD ===
+
+// ListWorkspaceRepos retorna os repositórios anexados a um workspace.
+func (s *Service) ListWorkspaceRepos(workspaceID uint) ([]WorkspaceRepo, error) {
+	var repos []WorkspaceRepo
+	err := s.db.Where("workspace_id = ?", workspaceID).Order("sort_order ASC, id ASC").Find(&repos).Error
+	return repos, err
+}
+
+// ListAllWorkspaceRepos retorna todos os repositórios anexados (todos os workspaces).
+func (s *Service) ListAllWorkspaceRepos() ([]WorkspaceRepo, error) {
+	var repos []WorkspaceRepo
+	err := s.db.Order("workspace_id ASC, sort_order ASC, id ASC").Find(&repos).Error
+	return repos, err
+}
+
+// GetWorkspaceRepo retorna um repositório anexado por ID.
+func (s *Service) GetWorkspaceRepo(id uint) (*WorkspaceRepo, error) {
+	var repo WorkspaceRepo
+	if err := s.db.First(&repo, id).Error; err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// AddWorkspaceRepo anexa um repositório ao workspace. O primeiro repositório vira primário.
+func (s *Service) AddWorkspaceRepo(repo *WorkspaceRepo) error {
+	if repo == nil {
+		return fmt.Errorf("workspace repo is nil")
+	}
+	repo.Path = strings.TrimSpace(repo.Path)
+	if repo.Path == "" {
+		return fmt.Errorf("workspace repo path cannot be empty")
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&WorkspaceRepo{}).
+			Where("workspace_id = ? AND path = ?", repo.WorkspaceID, repo.Path).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return fmt.Errorf("repository already attached to workspace: %s", repo.Path)
+		}
+
+		var total int64
+		if err := tx.Model(&WorkspaceRepo{}).Where("workspace_id = ?", repo.WorkspaceID).Count(&total).Error; err != nil {
+			return err
+		}
+		repo.SortOrder = int(total)
+		repo.IsPrimary = total == 0
+		return tx.Create(repo).Error
+	})
+}
+
+// RemoveWorkspaceRepo desanexa um repositório e remove o vínculo dos agentes que o usavam.
+func (s *Service) RemoveWorkspaceRepo(id uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var repo WorkspaceRepo
+		if err := tx.First(&repo, id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&AgentSession{}).
+			Where("workspace_id = ? AND repo_path = ?", repo.WorkspaceID, repo.Path).
+			Update("repo_path", "").Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&WorkspaceRepo{}, id).Error; err != nil {
+			return err
+		}
+		if !repo.IsPrimary {
+			return nil
+		}
+
+		var replacement WorkspaceRepo
+		err := tx.Where("workspace_id = ?", repo.WorkspaceID).Order("sort_order ASC, id ASC").First(&replacement).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&WorkspaceRepo{}).Where("id = ?", replacement.ID).Update("is_primary", true).Error
+	})
+}
+
+// SetAgentRepoPath vincula um agente a um repositório (vazio remove o vínculo).
+func (s *Service) SetAgentRepoPath(agentID uint, repoPath string) error {
+	return s.db.Model(&AgentSession{}).Where("id = ?", agentID).Update("repo_path", strings.TrimSpace(repoPath)).Error
+}
+
+// ListAgentsByRepoPath retorna os agentes vinculados a um repositório.
+func (s *Service) ListAgentsByRepoPath(repoPath string) ([]AgentSession, error) {
+	var agents []AgentSession
+	err := s.db.Where("repo_path = ?", strings.TrimSpace(repoPath)).Order("workspace_id ASC, sort_order ASC").Find(&agents).Error
+	return agents, err
+}
+
+// ListAgentsByAutoStart retorna os agentes com a política de auto-start informada.
+// workspaceID 0 considera todos os workspaces.
+func (s *Service) ListAgentsByAutoStart(policy string, workspaceID uint) ([]AgentSession, error) {
+	var agents []AgentSession
+	query := s.db.Where("auto_start = ?", policy)
+	if workspaceID != 0 {
+		query = query.Where("workspace_id = ?", workspaceID)
+	}
+	err := query.Order("workspace_id ASC, sort_order ASC").Find(&agents).Error
+	return agents, err
+}
+
 // === AgentSession CRUD ===
 
 // ListAgents retorna todos os agentes de um workspace
@@ -545,6 +970,31 @@ func (s *Service) UpdateAgentRuntime(id uint, sessionID, shell, cwd string, useD
 	return s.db.Model(&AgentSession{}).Where("id = ?", id).Updates(updates).Error
 }
 
+// UpdateAgentReplayCommands atualiza os comandos (JSON []string) reexecutados ao restaurar o terminal.
+func (s *Service) UpdateAgentReplayCommands(id uint, commandsJSON string) error {
+	return s.db.Model(&AgentSession{}).Where("id = ?", id).Update("replay_cmds", commandsJSON).Error
+}
+
+// UpdateAgentAutoStart atualiza a política de auto-start do agente.
+func (s *Service) UpdateAgentAutoStart(id uint, policy string) error {
+	return s.db.Model(&AgentSession{}).Where("id = ?", id).Update("auto_start", policy).Error
+}
+
+// UpdateAgentDependencies atualiza dependências (JSON []uint) e critério de prontidão do agente.
+func (s *Service) UpdateAgentDependencies(id uint, dependsOnJSON, readyMode, readyRegex string) error {
+	updates := map[string]interface{}{
+		"depends_on":  dependsOnJSON,
+		"ready_mode":  readyMode,
+		"ready_regex": readyRegex,
+	}
+	return s.db.Model(&AgentSession{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// UpdateAgentKubeTarget atualiza o alvo serializado de um agente kube_pod.
+func (s *Service) UpdateAgentKubeTarget(id uint, targetJSON string) error {
+	return s.db.Model(&AgentSession{}).Where("id = ?", id).Update("kube_target", targetJSON).Error
+}
+
 // ClearAgentRuntime limpa o vínculo com sessão de terminal ativa.
 func (s *Service) ClearAgentRuntime(id uint) error {
 	updates := map[string]interface{}{
@@ -659,6 +1109,159 @@ func (s *Service) ListAuditEvents(sessionID string, limit int) ([]AuditLog, erro
 	return logs, err
 }
 
+// AuditLogTimeRange filtra eventos por intervalo (limites opcionais, inclusivos).
+type AuditLogTimeRange struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+}
+
+// AuditLogPagination define página (1-indexed) e tamanho da busca.
+type AuditLogPagination struct {
+	Page    int `json:"page"`
+	PerPage int `json:"perPage"`
+}
+
+// AuditLogQuery agrupa os filtros de busca de auditoria.
+type AuditLogQuery struct {
+	SessionID  string
+	Actions    []string // ações exatas; sufixo "*" casa por prefixo (ex.: "join_*")
+	Text       string   // busca em details, action e user_id
+	TimeRange  AuditLogTimeRange
+	Pagination AuditLogPagination
+}
+
+// AuditLogPage representa uma página de resultados de auditoria.
+type AuditLogPage struct {
+	Items       []AuditLog `json:"items"`
+	Total       int64      `json:"total"`
+	Page        int        `json:"page"`
+	PerPage     int        `json:"perPage"`
+	HasNextPage bool       `json:"hasNextPage"`
+	NextPage    int        `json:"nextPage,omitempty"`
+}
+
+const (
+	defaultAuditSearchPerPage = 50
+	maxAuditSearchPerPage     = 200
+)
+
+// SearchAuditLogs busca eventos de auditoria com filtros combinados e paginação.
+func (s *Service) SearchAuditLogs(query AuditLogQuery) (*AuditLogPage, error) {
+	page := query.Pagination.Page
+	if page <= 0 {
+		page = 1
+	}
+	perPage := query.Pagination.PerPage
+	if perPage <= 0 {
+		perPage = defaultAuditSearchPerPage
+	}
+	if perPage > maxAuditSearchPerPage {
+		perPage = maxAuditSearchPerPage
+	}
+
+	scope := s.db.Model(&AuditLog{})
+	if sessionID := strings.TrimSpace(query.SessionID); sessionID != "" {
+		scope = scope.Where("session_id = ?", sessionID)
+	}
+
+	var exact []string
+	var clauses []string
+	var args []interface{}
+	for _, action := range query.Actions {
+		action = strings.TrimSpace(action)
+		if action == "" {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(action, "*"); ok {
+			clauses = append(clauses, `action LIKE ? ESCAPE '\'`)
+			args = append(args, escapeLikePattern(prefix)+"%")
+			continue
+		}
+		exact = append(exact, action)
+	}
+	if len(exact) > 0 {
+		clauses = append(clauses, "action IN ?")
+		args = append(args, exact)
+	}
+	if len(clauses) > 0 {
+		scope = scope.Where("("+strings.Join(clauses, " OR ")+")", args...)
+	}
+
+	if text := strings.TrimSpace(query.Text); text != "" {
+		pattern := "%" + escapeLikePattern(strings.ToLower(text)) + "%"
+		scope = scope.Where(`(LOWER(details) LIKE ? ESCAPE '\' OR LOWER(action) LIKE ? ESCAPE '\' OR LOWER(user_id) LIKE ? ESCAPE '\')`, pattern, pattern, pattern)
+	}
+	if query.TimeRange.From != nil {
+		scope = scope.Where("created_at >= ?", *query.TimeRange.From)
+	}
+	if query.TimeRange.To != nil {
+		scope = scope.Where("created_at <= ?", *query.TimeRange.To)
+	}
+
+	result := &AuditLogPage{Items: []AuditLog{}, Page: page, PerPage: perPage}
+	if err := scope.Count(&result.Total).Error; err != nil {
+		return nil, err
+	}
+	if err := scope.Order("created_at DESC, id DESC").
+		Offset((page - 1) * perPage).
+		Limit(perPage).
+		Find(&result.Items).Error; err != nil {
+		return nil, err
+	}
+	if int64(page*perPage) < result.Total {
+		result.HasNextPage = true
+		result.NextPage = page + 1
+	}
+	return result, nil
+}
+
+// PruneAuditLogs aplica a retenção global: remove eventos mais antigos que retentionDays
+// e mantém no máximo maxRows eventos (0 desativa cada limite). Retorna quantos foram removidos.
+func (s *Service) PruneAuditLogs(retentionDays, maxRows int) (int64, error) {
+	var removed int64
+	if retentionDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -retentionDays)
+		result := s.db.Where("created_at < ?", cutoff).Delete(&AuditLog{})
+		if result.Error != nil {
+			return removed, result.Error
+		}
+		removed += result.RowsAffected
+	}
+	if maxRows > 0 {
+		result := s.db.Exec(`
+			DELETE FROM audit_logs
+			WHERE id NOT IN (
+				SELECT id
+				FROM audit_logs
+				ORDER BY created_at DESC, id DESC
+				LIMIT ?
+			)
+		`, maxRows)
+		if result.Error != nil {
+			return removed, result.Error
+		}
+		removed += result.RowsAffected
+	}
+	return removed, nil
+}
+
+// SetAuditRetention persiste a política de retenção de auditoria.
+func (s *Service) SetAuditRetention(retentionDays, maxRows int) error {
+	cfg, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	return s.db.Model(cfg).Updates(map[string]interface{}{
+		"audit_retention_days": retentionDays,
+		"audit_max_rows":       maxRows,
+	}).Error
+}
+
+func escapeLikePattern(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return replacer.Replace(value)
+}
+
 // === CollabSessionState CRUD ===
 
 // UpsertCollabSessionState cria/atualiza o snapshot persistido de uma sessão colaborativa.
@@ -748,3 +1351,471 @@ func (s *Service) GetTerminalSnapshots() ([]TerminalSnapshot, error) {
 func (s *Service) ClearTerminalSnapshots() error {
 	return s.db.Where("1 = 1").Delete(&TerminalSnapshot{}).Error
 }
+
+// === WorkspaceTemplate CRUD ===
+
+// ListWorkspaceTemplates retorna todos os templates ordenados por nome.
+func (s *Service) ListWorkspaceTemplates() ([]WorkspaceTemplate, error) {
+	var templates []WorkspaceTemplate
+	err := s.db.Order("name ASC, id ASC").Find(&templates).Error
+	return templates, err
+}
+
+// GetWorkspaceTemplate retorna um template por ID.
+func (s *Service) GetWorkspaceTemplate(id uint) (*WorkspaceTemplate, error) {
+	var tpl WorkspaceTemplate
+	if err := s.db.First(&tpl, id).Error; err != nil {
+		return nil, err
+	}
+	return &tpl, nil
+}
+
+// SaveWorkspaceTemplate cria ou atualiza um template (ID zero = criação).
+func (s *Service) SaveWorkspaceTemplate(tpl *WorkspaceTemplate) error {
+	if tpl == nil {
+		return fmt.Errorf("workspace template is nil")
+	}
+	tpl.Name = strings.TrimSpace(tpl.Name)
+	if tpl.Name == "" {
+		return fmt.Errorf("workspace template name cannot be empty")
+	}
+	return s.db.Save(tpl).Error
+}
+
+// DeleteWorkspaceTemplate remove um template.
+func (s *Service) DeleteWorkspaceTemplate(id uint) error {
+	return s.db.Delete(&WorkspaceTemplate{}, id).Error
+}
+
+// === Git Auto-Fetch ===
+
+// ListGitAutoFetchSettings lista as configurações de auto-fetch persistidas.
+func (s *Service) ListGitAutoFetchSettings() ([]GitAutoFetchSetting, error) {
+	var settings []GitAutoFetchSetting
+	err := s.db.Order("repo_path ASC").Find(&settings).Error
+	return settings, err
+}
+
+// SaveGitAutoFetchSetting cria ou atualiza a configuração de auto-fetch de um repositório.
+func (s *Service) SaveGitAutoFetchSetting(repoPath string, enabled bool, intervalSeconds int) (*GitAutoFetchSetting, error) {
+	repoPath = strings.TrimSpace(repoPath)
+	if repoPath == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+
+	var setting GitAutoFetchSetting
+	err := s.db.Where("repo_path = ?", repoPath).First(&setting).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	setting.RepoPath = repoPath
+	setting.Enabled = enabled
+	setting.IntervalSeconds = intervalSeconds
+	if err := s.db.Save(&setting).Error; err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// GetGitExternalToolSetting retorna as ferramentas externas do repositório (nil se não houver override).
+func (s *Service) GetGitExternalToolSetting(repoPath string) (*GitExternalToolSetting, error) {
+	var setting GitExternalToolSetting
+	err := s.db.Where("repo_path = ?", strings.TrimSpace(repoPath)).First(&setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// SaveGitExternalToolSetting cria ou atualiza o override do repositório; ambos vazios removem o override.
+func (s *Service) SaveGitExternalToolSetting(repoPath, mergeTool, diffTool string) error {
+	repoPath = strings.TrimSpace(repoPath)
+	if repoPath == "" {
+		return fmt.Errorf("repo path is required")
+	}
+	mergeTool, diffTool = strings.TrimSpace(mergeTool), strings.TrimSpace(diffTool)
+	if mergeTool == "" && diffTool == "" {
+		return s.db.Where("repo_path = ?", repoPath).Delete(&GitExternalToolSetting{}).Error
+	}
+
+	var setting GitExternalToolSetting
+	err := s.db.Where("repo_path = ?", repoPath).First(&setting).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	setting.RepoPath = repoPath
+	setting.MergeTool = mergeTool
+	setting.DiffTool = diffTool
+	return s.db.Save(&setting).Error
+}
+
+// GetGitBranchNamingPolicy retorna a política de nomes de branch do repositório (nil se não houver).
+func (s *Service) GetGitBranchNamingPolicy(repoPath string) (*GitBranchNamingPolicy, error) {
+	var policy GitBranchNamingPolicy
+	err := s.db.Where("repo_path = ?", strings.TrimSpace(repoPath)).First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// SaveGitBranchNamingPolicy cria ou atualiza a política do repositório; sem templates a política é removida.
+func (s *Service) SaveGitBranchNamingPolicy(repoPath, templates, mode string) error {
+	repoPath = strings.TrimSpace(repoPath)
+	if repoPath == "" {
+		return fmt.Errorf("repo path is required")
+	}
+	if strings.TrimSpace(templates) == "" {
+		return s.db.Where("repo_path = ?", repoPath).Delete(&GitBranchNamingPolicy{}).Error
+	}
+
+	var policy GitBranchNamingPolicy
+	err := s.db.Where("repo_path = ?", repoPath).First(&policy).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	policy.RepoPath = repoPath
+	policy.Templates = templates
+	policy.Mode = mode
+	return s.db.Save(&policy).Error
+}
+
+// GetGitPRBaseRemote retorna o remote base preferido para PRs do repositório ("" se não houver).
+func (s *Service) GetGitPRBaseRemote(repoPath string) (string, error) {
+	var preference GitPRRemotePreference
+	err := s.db.Where("repo_path = ?", strings.TrimSpace(repoPath)).First(&preference).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return preference.BaseRemote, nil
+}
+
+// SetGitPRBaseRemote grava o remote base preferido do repositório; remote vazio remove a preferência.
+func (s *Service) SetGitPRBaseRemote(repoPath, remote string) error {
+	repoPath = strings.TrimSpace(repoPath)
+	if repoPath == "" {
+		return fmt.Errorf("repo path is required")
+	}
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		return s.db.Where("repo_path = ?", repoPath).Delete(&GitPRRemotePreference{}).Error
+	}
+
+	var preference GitPRRemotePreference
+	err := s.db.Where("repo_path = ?", repoPath).First(&preference).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	preference.RepoPath = repoPath
+	preference.BaseRemote = remote
+	return s.db.Save(&preference).Error
+}
+
+// === Command Snippets ===
+
+// ListCommandSnippets lista os snippets globais e os do workspace (0 = só globais), por nome.
+func (s *Service) ListCommandSnippets(workspaceID uint) ([]CommandSnippet, error) {
+	var snippets []CommandSnippet
+	err := s.db.Where("workspace_id IN ?", []uint{0, workspaceID}).Order("name ASC, workspace_id DESC, id ASC").Find(&snippets).Error
+	return snippets, err
+}
+
+// GetCommandSnippet retorna um snippet por ID.
+func (s *Service) GetCommandSnippet(id uint) (*CommandSnippet, error) {
+	var snippet CommandSnippet
+	if err := s.db.First(&snippet, id).Error; err != nil {
+		return nil, err
+	}
+	return &snippet, nil
+}
+
+// SaveCommandSnippet cria ou atualiza um snippet (ID zero = criação). O nome é único por escopo.
+func (s *Service) SaveCommandSnippet(snippet *CommandSnippet) error {
+	if snippet == nil {
+		return fmt.Errorf("snippet is nil")
+	}
+	snippet.Name = strings.TrimSpace(snippet.Name)
+	if snippet.Name == "" {
+		return fmt.Errorf("snippet name cannot be empty")
+	}
+	if strings.TrimSpace(snippet.Command) == "" {
+		return fmt.Errorf("snippet command cannot be empty")
+	}
+	var clash int64
+	if err := s.db.Model(&CommandSnippet{}).
+		Where("workspace_id = ? AND name = ? AND id <> ?", snippet.WorkspaceID, snippet.Name, snippet.ID).
+		Count(&clash).Error; err != nil {
+		return err
+	}
+	if clash > 0 {
+		return fmt.Errorf("snippet %q already exists in this scope", snippet.Name)
+	}
+	return s.db.Save(snippet).Error
+}
+
+// DeleteCommandSnippet remove um snippet.
+func (s *Service) DeleteCommandSnippet(id uint) error {
+	return s.db.Delete(&CommandSnippet{}, id).Error
+}
+
+// === Lifecycle Hooks ===
+
+// ListLifecycleHooks lista os hooks por evento e nome; event vazio lista todos.
+func (s *Service) ListLifecycleHooks(event string) ([]LifecycleHook, error) {
+	var hooks []LifecycleHook
+	query := s.db.Order("event ASC, name ASC, id ASC")
+	if event != "" {
+		query = query.Where("event = ?", event)
+	}
+	err := query.Find(&hooks).Error
+	return hooks, err
+}
+
+// GetLifecycleHook retorna um hook por ID.
+func (s *Service) GetLifecycleHook(id uint) (*LifecycleHook, error) {
+	var hook LifecycleHook
+	if err := s.db.First(&hook, id).Error; err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// SaveLifecycleHook cria ou atualiza um hook (ID zero = criação).
+func (s *Service) SaveLifecycleHook(hook *LifecycleHook) error {
+	if hook == nil {
+		return fmt.Errorf("lifecycle hook is nil")
+	}
+	hook.Name = strings.TrimSpace(hook.Name)
+	if hook.Name == "" {
+		return fmt.Errorf("hook name cannot be empty")
+	}
+	if strings.TrimSpace(hook.Script) == "" {
+		return fmt.Errorf("hook script cannot be empty")
+	}
+	return s.db.Save(hook).Error
+}
+
+// DeleteLifecycleHook remove um hook.
+func (s *Service) DeleteLifecycleHook(id uint) error {
+	return s.db.Delete(&LifecycleHook{}, id).Error
+}
+
+// === Notification Targets ===
+
+// ListNotificationTargets lista os webhooks de saída por nome.
+func (s *Service) ListNotificationTargets() ([]NotificationTarget, error) {
+	var targets []NotificationTarget
+	err := s.db.Order("name ASC, id ASC").Find(&targets).Error
+	return targets, err
+}
+
+// GetNotificationTarget retorna um webhook por ID.
+func (s *Service) GetNotificationTarget(id uint) (*NotificationTarget, error) {
+	var target NotificationTarget
+	if err := s.db.First(&target, id).Error; err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// SaveNotificationTarget cria ou atualiza um webhook (ID zero = criação).
+func (s *Service) SaveNotificationTarget(target *NotificationTarget) error {
+	if target == nil {
+		return fmt.Errorf("notification target is nil")
+	}
+	target.Name = strings.TrimSpace(target.Name)
+	if target.Name == "" {
+		return fmt.Errorf("notification name cannot be empty")
+	}
+	if strings.TrimSpace(target.URL) == "" {
+		return fmt.Errorf("notification URL cannot be empty")
+	}
+	return s.db.Save(target).Error
+}
+
+// DeleteNotificationTarget remove um webhook.
+func (s *Service) DeleteNotificationTarget(id uint) error {
+	return s.db.Delete(&NotificationTarget{}, id).Error
+}
+
+// === Problem Matchers ===
+
+// ListProblemMatchers lista os problem matchers do usuário por nome.
+func (s *Service) ListProblemMatchers() ([]ProblemMatcher, error) {
+	var matchers []ProblemMatcher
+	err := s.db.Order("name ASC, id ASC").Find(&matchers).Error
+	return matchers, err
+}
+
+// GetProblemMatcher retorna um problem matcher por ID.
+func (s *Service) GetProblemMatcher(id uint) (*ProblemMatcher, error) {
+	var matcher ProblemMatcher
+	if err := s.db.First(&matcher, id).Error; err != nil {
+		return nil, err
+	}
+	return &matcher, nil
+}
+
+// SaveProblemMatcher cria ou atualiza um problem matcher (ID zero = criação).
+func (s *Service) SaveProblemMatcher(matcher *ProblemMatcher) error {
+	if matcher == nil {
+		return fmt.Errorf("problem matcher is nil")
+	}
+	matcher.Name = strings.TrimSpace(matcher.Name)
+	if matcher.Name == "" {
+		return fmt.Errorf("problem matcher name cannot be empty")
+	}
+	if strings.TrimSpace(matcher.Pattern) == "" {
+		return fmt.Errorf("problem matcher pattern cannot be empty")
+	}
+	return s.db.Save(matcher).Error
+}
+
+// DeleteProblemMatcher remove um problem matcher.
+func (s *Service) DeleteProblemMatcher(id uint) error {
+	return s.db.Delete(&ProblemMatcher{}, id).Error
+}
+
+// === Workspace Tasks ===
+
+// ListWorkspaceTasks lista as tarefas manuais e as conclusões de TODOs do workspace, por criação.
+func (s *Service) ListWorkspaceTasks(workspaceID uint) ([]WorkspaceTask, error) {
+	var tasks []WorkspaceTask
+	err := s.db.Where("workspace_id = ?", workspaceID).Order("created_at ASC, id ASC").Find(&tasks).Error
+	return tasks, err
+}
+
+// GetWorkspaceTask retorna uma tarefa por ID.
+func (s *Service) GetWorkspaceTask(id uint) (*WorkspaceTask, error) {
+	var task WorkspaceTask
+	if err := s.db.First(&task, id).Error; err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// SaveWorkspaceTask cria ou atualiza uma tarefa manual (ID zero = criação).
+func (s *Service) SaveWorkspaceTask(task *WorkspaceTask) error {
+	if task == nil {
+		return fmt.Errorf("task is nil")
+	}
+	task.Title = strings.TrimSpace(task.Title)
+	if task.Title == "" {
+		return fmt.Errorf("task title cannot be empty")
+	}
+	if task.Source == "" {
+		task.Source = TaskSourceManual
+	}
+	return s.db.Save(task).Error
+}
+
+// DeleteWorkspaceTask remove uma tarefa.
+func (s *Service) DeleteWorkspaceTask(id uint) error {
+	return s.db.Delete(&WorkspaceTask{}, id).Error
+}
+
+// SetCommentTaskDone grava (ou desfaz) a conclusão de um TODO do código pelo CommentKey.
+func (s *Service) SetCommentTaskDone(task WorkspaceTask, done bool) (*WorkspaceTask, error) {
+	if strings.TrimSpace(task.CommentKey) == "" {
+		return nil, fmt.Errorf("comment key cannot be empty")
+	}
+	var existing WorkspaceTask
+	err := s.db.Where("workspace_id = ? AND source = ? AND comment_key = ?", task.WorkspaceID, TaskSourceComment, task.CommentKey).First(&existing).Error
+	switch {
+	case err == nil:
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		existing = task
+		existing.ID = 0
+		existing.Source = TaskSourceComment
+	default:
+		return nil, err
+	}
+	existing.Title, existing.Path, existing.Line = task.Title, task.Path, task.Line
+	existing.Done = done
+	existing.DoneAt = nil
+	if done {
+		now := time.Now()
+		existing.DoneAt = &now
+	}
+	if err := s.db.Save(&existing).Error; err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// === GitHub Issue Filters ===
+
+// ListGitHubIssueFilters lista os filtros salvos de um repositório na ordem de criação.
+func (s *Service) ListGitHubIssueFilters(owner, repo string) ([]GitHubIssueFilter, error) {
+	var filters []GitHubIssueFilter
+	err := s.db.Where("owner = ? AND repo = ?", owner, repo).Order("id ASC").Find(&filters).Error
+	return filters, err
+}
+
+// GetGitHubIssueFilter retorna um filtro salvo por ID.
+func (s *Service) GetGitHubIssueFilter(id uint) (*GitHubIssueFilter, error) {
+	var filter GitHubIssueFilter
+	if err := s.db.First(&filter, id).Error; err != nil {
+		return nil, err
+	}
+	return &filter, nil
+}
+
+// SaveGitHubIssueFilter cria ou atualiza um filtro (ID zero = criação).
+func (s *Service) SaveGitHubIssueFilter(filter *GitHubIssueFilter) error {
+	if filter == nil {
+		return fmt.Errorf("issue filter is nil")
+	}
+	filter.Name = strings.TrimSpace(filter.Name)
+	if filter.Name == "" {
+		return fmt.Errorf("issue filter name cannot be empty")
+	}
+	if strings.TrimSpace(filter.Owner) == "" || strings.TrimSpace(filter.Repo) == "" {
+		return fmt.Errorf("issue filter owner/repo is required")
+	}
+	return s.db.Save(filter).Error
+}
+
+// DeleteGitHubIssueFilter remove um filtro salvo.
+func (s *Service) DeleteGitHubIssueFilter(id uint) error {
+	return s.db.Delete(&GitHubIssueFilter{}, id).Error
+}
+
+// ListGitHubRepoAccounts lista os vínculos repositório -> conta GitHub.
+func (s *Service) ListGitHubRepoAccounts() ([]GitHubRepoAccount, error) {
+	var bindings []GitHubRepoAccount
+	err := s.db.Order("repo_path ASC").Find(&bindings).Error
+	return bindings, err
+}
+
+// SaveGitHubRepoAccount cria ou atualiza o vínculo do repositório; accountID vazio remove o vínculo.
+func (s *Service) SaveGitHubRepoAccount(repoPath, owner, accountID string) error {
+	repoPath = strings.TrimSpace(repoPath)
+	if repoPath == "" {
+		return fmt.Errorf("repo path is required")
+	}
+	accountID = strings.TrimSpace(accountID)
+	if accountID == "" {
+		return s.db.Where("repo_path = ?", repoPath).Delete(&GitHubRepoAccount{}).Error
+	}
+
+	var binding GitHubRepoAccount
+	err := s.db.Where("repo_path = ?", repoPath).First(&binding).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	binding.RepoPath = repoPath
+	binding.Owner = strings.TrimSpace(owner)
+	binding.AccountID = accountID
+	return s.db.Save(&binding).Error
+}
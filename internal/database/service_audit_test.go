@@ -0,0 +1,129 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func newAuditTestService(t *testing.T) *Service {
+	t.Helper()
+	db, _ := openMigrationTestDB(t)
+	if err := db.AutoMigrate(&AuditLog{}); err != nil {
+		t.Fatalf("failed to migrate audit logs: %v", err)
+	}
+	return &Service{db: db}
+}
+
+func seedAuditLog(t *testing.T, svc *Service, sessionID, action, details string, createdAt time.Time) {
+	t.Helper()
+	entry := AuditLog{SessionID: sessionID, UserID: "user-1", Action: action, Details: details, CreatedAt: createdAt}
+	if err := svc.db.Create(&entry).Error; err != nil {
+		t.Fatalf("failed to seed audit log: %v", err)
+	}
+}
+
+func TestSearchAuditLogsCombinesFilters(t *testing.T) {
+	svc := newAuditTestService(t)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	seedAuditLog(t, svc, "s1", "join_approved", "guest Alice joined", base)
+	seedAuditLog(t, svc, "s1", "join_rejected", "guest Bob_50% rejected", base.Add(time.Hour))
+	seedAuditLog(t, svc, "s1", "permission_changed", "Alice promoted", base.Add(2*time.Hour))
+	seedAuditLog(t, svc, "s2", "join_approved", "guest Alice joined", base.Add(3*time.Hour))
+
+	page, err := svc.SearchAuditLogs(AuditLogQuery{SessionID: "s1", Actions: []string{"join_*"}})
+	if err != nil {
+		t.Fatalf("SearchAuditLogs: %v", err)
+	}
+	if page.Total != 2 || page.Items[0].Action != "join_rejected" {
+		t.Fatalf("unexpected prefix result: total=%d items=%+v", page.Total, page.Items)
+	}
+
+	page, err = svc.SearchAuditLogs(AuditLogQuery{SessionID: "s1", Actions: []string{"permission_changed", "join_approved"}, Text: "alice"})
+	if err != nil {
+		t.Fatalf("SearchAuditLogs: %v", err)
+	}
+	if page.Total != 2 {
+		t.Fatalf("expected 2 matches for exact actions + text, got %d", page.Total)
+	}
+
+	// Curingas do LIKE no texto são tratados literalmente.
+	page, err = svc.SearchAuditLogs(AuditLogQuery{Text: "_50%"})
+	if err != nil {
+		t.Fatalf("SearchAuditLogs: %v", err)
+	}
+	if page.Total != 1 || page.Items[0].Action != "join_rejected" {
+		t.Fatalf("expected literal match, got total=%d", page.Total)
+	}
+
+	from := base.Add(30 * time.Minute)
+	to := base.Add(2 * time.Hour)
+	page, err = svc.SearchAuditLogs(AuditLogQuery{TimeRange: AuditLogTimeRange{From: &from, To: &to}})
+	if err != nil {
+		t.Fatalf("SearchAuditLogs: %v", err)
+	}
+	if page.Total != 2 {
+		t.Fatalf("expected 2 events in time range, got %d", page.Total)
+	}
+}
+
+func TestSearchAuditLogsPaginates(t *testing.T) {
+	svc := newAuditTestService(t)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		seedAuditLog(t, svc, "s1", "terminal_input", fmt.Sprintf("event %d", i), base.Add(time.Duration(i)*time.Minute))
+	}
+
+	first, err := svc.SearchAuditLogs(AuditLogQuery{Pagination: AuditLogPagination{PerPage: 2}})
+	if err != nil {
+		t.Fatalf("SearchAuditLogs: %v", err)
+	}
+	if first.Total != 5 || len(first.Items) != 2 || !first.HasNextPage || first.NextPage != 2 {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+	if first.Items[0].Details != "event 4" {
+		t.Fatalf("expected newest first, got %q", first.Items[0].Details)
+	}
+
+	last, err := svc.SearchAuditLogs(AuditLogQuery{Pagination: AuditLogPagination{Page: 3, PerPage: 2}})
+	if err != nil {
+		t.Fatalf("SearchAuditLogs: %v", err)
+	}
+	if len(last.Items) != 1 || last.HasNextPage || last.Items[0].Details != "event 0" {
+		t.Fatalf("unexpected last page: %+v", last)
+	}
+}
+
+func TestPruneAuditLogsAppliesDaysAndMaxRows(t *testing.T) {
+	svc := newAuditTestService(t)
+	now := time.Now()
+	seedAuditLog(t, svc, "s1", "old", "", now.AddDate(0, 0, -40))
+	for i := 0; i < 4; i++ {
+		seedAuditLog(t, svc, "s1", "recent", fmt.Sprintf("event %d", i), now.Add(-time.Duration(4-i)*time.Minute))
+	}
+
+	removed, err := svc.PruneAuditLogs(30, 0)
+	if err != nil {
+		t.Fatalf("PruneAuditLogs: %v", err)
+	}
+	if removed != 1 {
+		t.Fatalf("expected 1 expired event removed, got %d", removed)
+	}
+
+	removed, err = svc.PruneAuditLogs(0, 2)
+	if err != nil {
+		t.Fatalf("PruneAuditLogs: %v", err)
+	}
+	if removed != 2 {
+		t.Fatalf("expected 2 events removed by max rows, got %d", removed)
+	}
+	var remaining []AuditLog
+	svc.db.Order("created_at ASC").Find(&remaining)
+	if len(remaining) != 2 || remaining[0].Details != "event 2" {
+		t.Fatalf("expected newest events kept, got %+v", remaining)
+	}
+
+	if removed, err := svc.PruneAuditLogs(0, 0); err != nil || removed != 0 {
+		t.Fatalf("expected no-op with limits disabled, got removed=%d err=%v", removed, err)
+	}
+}
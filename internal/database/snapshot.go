@@ -0,0 +1,220 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SnapshotTo grava uma cópia consistente do banco (VACUUM INTO) em dest.
+func (s *Service) SnapshotTo(dest string) error {
+	dest = strings.TrimSpace(dest)
+	if dest == "" {
+		return fmt.Errorf("snapshot path is required")
+	}
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("snapshot destination already exists: %s", dest)
+	}
+	if err := s.db.Exec("VACUUM INTO ?", dest).Error; err != nil {
+		return fmt.Errorf("failed to snapshot database: %w", err)
+	}
+	return os.Chmod(dest, 0600)
+}
+
+// SchemaVersion retorna a versão atual do schema.
+func (s *Service) SchemaVersion() int {
+	version, _ := currentSchemaVersion(s.db)
+	return version
+}
+
+// RestoreFromSnapshot substitui os dados pelo snapshot (migrado até a versão atual antes da cópia).
+// A cópia roda numa única conexão com o snapshot anexado, sem trocar o *gorm.DB em uso;
+// o banco atual ganha backup automático antes da substituição.
+func (s *Service) RestoreFromSnapshot(src string) error {
+	if err := migrateSnapshot(src); err != nil {
+		return err
+	}
+
+	current, err := currentSchemaVersion(s.db)
+	if err != nil {
+		return err
+	}
+	backupPath, err := backupDatabase(s.db, s.path, current)
+	if err != nil {
+		return err
+	}
+	if backupPath != "" {
+		s.lastBackupPath = backupPath
+	}
+
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire database connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys=OFF"); err != nil {
+		return err
+	}
+	defer conn.ExecContext(ctx, "PRAGMA foreign_keys=ON")
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS restore_src", src); err != nil {
+		return fmt.Errorf("failed to attach snapshot: %w", err)
+	}
+	defer conn.ExecContext(ctx, "DETACH DATABASE restore_src")
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := copyAttachedTables(ctx, tx); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+	return tx.Commit()
+}
+
+// migrateSnapshot valida e leva o snapshot até a versão de schema do app.
+func migrateSnapshot(src string) error {
+	if _, err := os.Stat(src); err != nil {
+		return fmt.Errorf("snapshot not found: %w", err)
+	}
+	snapshot, err := gorm.Open(sqlite.Open(src), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	sqlDB, err := snapshot.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	var integrity string
+	if err := snapshot.Raw("PRAGMA integrity_check").Scan(&integrity).Error; err != nil || integrity != "ok" {
+		return fmt.Errorf("snapshot failed integrity check: %s", integrity)
+	}
+	if _, err := migrateTo(snapshot, "", migrations, LatestSchemaVersion()); err != nil {
+		return fmt.Errorf("failed to migrate snapshot: %w", err)
+	}
+	return nil
+}
+
+// copyAttachedTables troca o conteúdo de cada tabela de main pelo de restore_src.
+func copyAttachedTables(ctx context.Context, tx *sql.Tx) error {
+	tables, err := queryStrings(ctx, tx, "SELECT name FROM main.sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name NOT IN ('schema_version', '_orch_write_probe')")
+	if err != nil {
+		return err
+	}
+	sourceTables, err := queryStrings(ctx, tx, "SELECT name FROM restore_src.sqlite_master WHERE type = 'table'")
+	if err != nil {
+		return err
+	}
+	inSource := make(map[string]bool, len(sourceTables))
+	for _, name := range sourceTables {
+		inSource[name] = true
+	}
+
+	for _, table := range tables {
+		quoted := quoteIdentifier(table)
+		if _, err := tx.ExecContext(ctx, "DELETE FROM main."+quoted); err != nil {
+			return err
+		}
+		if !inSource[table] {
+			continue
+		}
+		columns, err := sharedColumns(ctx, tx, table)
+		if err != nil {
+			return err
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		list := strings.Join(columns, ", ")
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO main.%s (%s) SELECT %s FROM restore_src.%s", quoted, list, list, quoted)); err != nil {
+			return fmt.Errorf("table %s: %w", table, err)
+		}
+	}
+
+	// Mantém os contadores AUTOINCREMENT coerentes com os IDs restaurados.
+	if inSource["sqlite_sequence"] {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM main.sqlite_sequence"); err == nil {
+			if _, err := tx.ExecContext(ctx, "INSERT INTO main.sqlite_sequence (name, seq) SELECT name, seq FROM restore_src.sqlite_sequence"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sharedColumns retorna as colunas presentes na tabela de main e do snapshot.
+func sharedColumns(ctx context.Context, tx *sql.Tx, table string) ([]string, error) {
+	columnsOf := func(schema string) (map[string]bool, []string, error) {
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT name FROM pragma_table_info(%s, %s)", quoteLiteral(table), quoteLiteral(schema)))
+		if err != nil {
+			return nil, nil, err
+		}
+		defer rows.Close()
+		set := make(map[string]bool)
+		var ordered []string
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return nil, nil, err
+			}
+			set[name] = true
+			ordered = append(ordered, name)
+		}
+		return set, ordered, rows.Err()
+	}
+
+	_, mainColumns, err := columnsOf("main")
+	if err != nil {
+		return nil, err
+	}
+	sourceColumns, _, err := columnsOf("restore_src")
+	if err != nil {
+		return nil, err
+	}
+	shared := make([]string, 0, len(mainColumns))
+	for _, column := range mainColumns {
+		if sourceColumns[column] {
+			shared = append(shared, quoteIdentifier(column))
+		}
+	}
+	return shared, nil
+}
+
+func queryStrings(ctx context.Context, tx *sql.Tx, query string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteLiteral(value string) string {
+	return `'` + strings.ReplaceAll(value, `'`, `''`) + `'`
+}
@@ -0,0 +1,141 @@
+package deeplink
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"orch/internal/config"
+	"orch/internal/gitprs"
+	"orch/internal/session"
+)
+
+// Ações reconhecidas nos links orch://.
+const (
+	ActionAuthCallback = "auth_callback" // orch://auth/callback?code=...
+	ActionJoinSession  = "join_session"  // orch://join/<code> ou orch://join-session?code=...
+	ActionOpenRepo     = "open_repo"     // orch://open?path=... ou orch://open-repo?path=...
+	ActionOpenPR       = "open_pr"       // orch://pr?owner=..&repo=..&number=.. ou orch://pr/<owner>/<repo>/<number>
+)
+
+const maxLinkLength = 4096
+
+// Link é um deep link já validado.
+type Link struct {
+	Action string `json:"action"`
+	Code   string `json:"code,omitempty"` // código OAuth (auth) ou código da sessão (join)
+	State  string `json:"-"`
+	Path   string `json:"path,omitempty"`
+	Owner  string `json:"owner,omitempty"`
+	Repo   string `json:"repo,omitempty"`
+	Number int    `json:"number,omitempty"`
+}
+
+// Parse valida o link e extrai a ação. Parâmetros inválidos viram erro, nunca ação parcial.
+func Parse(raw string) (Link, error) {
+	raw = strings.TrimSpace(raw)
+	if len(raw) > maxLinkLength {
+		return Link{}, fmt.Errorf("deep link too long")
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return Link{}, fmt.Errorf("invalid deep link: %w", err)
+	}
+	if !strings.EqualFold(parsed.Scheme, config.DeepLinkScheme) {
+		return Link{}, fmt.Errorf("unsupported scheme %q", parsed.Scheme)
+	}
+	query := parsed.Query()
+	segments := strings.FieldsFunc(parsed.Path, func(r rune) bool { return r == '/' })
+
+	switch strings.ToLower(parsed.Host) {
+	case "auth":
+		if len(segments) != 1 || segments[0] != "callback" {
+			return Link{}, fmt.Errorf("unknown auth deep link")
+		}
+		code := strings.TrimSpace(query.Get("code"))
+		if code == "" {
+			return Link{}, fmt.Errorf("auth callback is missing code")
+		}
+		return Link{Action: ActionAuthCallback, Code: code, State: query.Get("state")}, nil
+
+	case "join", "join-session":
+		code := query.Get("code")
+		if len(segments) == 1 {
+			code = segments[0]
+		}
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if !session.IsValidCode(code) {
+			return Link{}, fmt.Errorf("invalid session code %q", code)
+		}
+		return Link{Action: ActionJoinSession, Code: code}, nil
+
+	case "open", "open-repo":
+		path, err := cleanPath(query.Get("path"))
+		if err != nil {
+			return Link{}, err
+		}
+		return Link{Action: ActionOpenRepo, Path: path}, nil
+
+	case "pr":
+		owner, repo, number := query.Get("owner"), query.Get("repo"), query.Get("number")
+		if len(segments) == 3 {
+			owner, repo, number = segments[0], segments[1], segments[2]
+		}
+		owner, repo, err := gitprs.NormalizeOwnerRepo(owner, repo)
+		if err != nil {
+			return Link{}, fmt.Errorf("invalid pull request link: %w", err)
+		}
+		prNumber, err := strconv.Atoi(strings.TrimSpace(number))
+		if err != nil || prNumber <= 0 {
+			return Link{}, fmt.Errorf("invalid pull request number %q", number)
+		}
+		return Link{Action: ActionOpenPR, Owner: owner, Repo: repo, Number: prNumber}, nil
+	}
+	return Link{}, fmt.Errorf("unknown deep link action %q", parsed.Host)
+}
+
+// Summary descreve a ação para o prompt de confirmação.
+func (l Link) Summary() string {
+	switch l.Action {
+	case ActionJoinSession:
+		return fmt.Sprintf("Join collaboration session %s", l.Code)
+	case ActionOpenRepo:
+		return fmt.Sprintf("Open repository %s", l.Path)
+	case ActionOpenPR:
+		return fmt.Sprintf("Open pull request %s/%s#%d", l.Owner, l.Repo, l.Number)
+	case ActionAuthCallback:
+		return "Complete GitHub sign-in"
+	}
+	return l.Action
+}
+
+// RequiresConfirmation indica ações que mudam estado (entrar em sessão remota, criar/ativar
+// workspace) e por isso só rodam depois que o usuário confirma na UI.
+func (l Link) RequiresConfirmation() bool {
+	return l.Action == ActionJoinSession || l.Action == ActionOpenRepo
+}
+
+// cleanPath aceita caminhos absolutos ou relativos ao home (~/...).
+func cleanPath(raw string) (string, error) {
+	path := strings.TrimSpace(raw)
+	if path == "" {
+		return "", fmt.Errorf("open link is missing path")
+	}
+	if strings.ContainsRune(path, 0) {
+		return "", fmt.Errorf("invalid path")
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("resolve home dir: %w", err)
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("path must be absolute: %q", raw)
+	}
+	return filepath.Clean(path), nil
+}
@@ -0,0 +1,55 @@
+package deeplink
+
+import "testing"
+
+func TestParseRoutesSupportedActions(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want Link
+	}{
+		{"auth", "orch://auth/callback?code=abc&state=xyz", Link{Action: ActionAuthCallback, Code: "abc", State: "xyz"}},
+		{"join_path", "orch://join/abcd-efg", Link{Action: ActionJoinSession, Code: "ABCD-EFG"}},
+		{"join_query", "orch://join-session?code=ABC-DE", Link{Action: ActionJoinSession, Code: "ABC-DE"}},
+		{"open", "orch://open?path=/tmp/../srv/repo", Link{Action: ActionOpenRepo, Path: "/srv/repo"}},
+		{"pr_query", "orch://pr?owner=octo&repo=hello.git&number=42", Link{Action: ActionOpenPR, Owner: "octo", Repo: "hello", Number: 42}},
+		{"pr_path", "orch://pr/octo/hello/7", Link{Action: ActionOpenPR, Owner: "octo", Repo: "hello", Number: 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.raw)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Fatalf("Parse(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejectsInvalidLinks(t *testing.T) {
+	for _, raw := range []string{
+		"https://example.com/join/ABCD-EFG",
+		"orch://unknown",
+		"orch://auth/callback",
+		"orch://join/not-a-code",
+		"orch://open?path=relative/repo",
+		"orch://open",
+		"orch://pr?owner=octo&repo=hello&number=0",
+		"orch://pr?owner=../x&repo=hello&number=1",
+	} {
+		if link, err := Parse(raw); err == nil {
+			t.Fatalf("Parse(%q) = %+v, want error", raw, link)
+		}
+	}
+}
+
+func TestRequiresConfirmation(t *testing.T) {
+	if !(Link{Action: ActionJoinSession}).RequiresConfirmation() || !(Link{Action: ActionOpenRepo}).RequiresConfirmation() {
+		t.Fatalf("join and open must require confirmation")
+	}
+	if (Link{Action: ActionOpenPR}).RequiresConfirmation() || (Link{Action: ActionAuthCallback}).RequiresConfirmation() {
+		t.Fatalf("pr and auth links must not require confirmation")
+	}
+}
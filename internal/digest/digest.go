@@ -0,0 +1,247 @@
+// Package digest monta o resumo de atividade de um workspace (commits, eventos Git, PRs,
+// testes rodados nos terminais e tempo de foco) usado como roteiro de daily/standup.
+package digest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Commit é um commit local do usuário.
+type Commit struct {
+	Repo       string    `json:"repo"`
+	Hash       string    `json:"hash"`
+	ShortHash  string    `json:"shortHash"`
+	Subject    string    `json:"subject"`
+	AuthoredAt time.Time `json:"authoredAt"`
+	Additions  int       `json:"additions"`
+	Deletions  int       `json:"deletions"`
+}
+
+// Activity é um evento Git relevante (branch criado/trocado, merge).
+type Activity struct {
+	Type      string    `json:"type"`
+	Repo      string    `json:"repo"`
+	Branch    string    `json:"branch,omitempty"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PullRequest é um PR aberto ou revisado pelo usuário no período.
+type PullRequest struct {
+	Repo      string    `json:"repo"` // owner/repo
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	State     string    `json:"state"`
+	IsDraft   bool      `json:"isDraft"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TestRun é uma execução de testes detectada nos terminais do workspace.
+type TestRun struct {
+	Command    string    `json:"command"`
+	Framework  string    `json:"framework"`
+	Status     string    `json:"status"`
+	Summary    string    `json:"summary,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+}
+
+// FocusSession é um período do timer de foco no workspace.
+type FocusSession struct {
+	StartedAt time.Time `json:"startedAt"`
+	EndedAt   time.Time `json:"endedAt"`
+	Minutes   int       `json:"minutes"`
+	Completed bool      `json:"completed"` // false quando o foco foi interrompido antes do fim
+}
+
+// Stats são os totais do período.
+type Stats struct {
+	Commits         int `json:"commits"`
+	Additions       int `json:"additions"`
+	Deletions       int `json:"deletions"`
+	PRsOpened       int `json:"prsOpened"`
+	PRsReviewed     int `json:"prsReviewed"`
+	TestRuns        int `json:"testRuns"`
+	TestRunsFailed  int `json:"testRunsFailed"`
+	TestRunsPassing int `json:"testRunsPassing"`
+	FocusSessions   int `json:"focusSessions"`
+	FocusMinutes    int `json:"focusMinutes"`
+}
+
+// Digest é o resultado entregue ao frontend. Warnings lista fontes que falharam sem
+// impedir o restante do resumo.
+type Digest struct {
+	WorkspaceID          uint           `json:"workspaceId"`
+	WorkspaceName        string         `json:"workspaceName"`
+	Since                time.Time      `json:"since"`
+	GeneratedAt          time.Time      `json:"generatedAt"`
+	Commits              []Commit       `json:"commits"`
+	Activity             []Activity     `json:"activity"`
+	PullRequestsOpened   []PullRequest  `json:"pullRequestsOpened"`
+	PullRequestsReviewed []PullRequest  `json:"pullRequestsReviewed"`
+	TestRuns             []TestRun      `json:"testRuns"`
+	FocusSessions        []FocusSession `json:"focusSessions"`
+	Stats                Stats          `json:"stats"`
+	Markdown             string         `json:"markdown"`
+	Summary              string         `json:"summary,omitempty"`
+	Warnings             []string       `json:"warnings"`
+}
+
+// Finalize ordena as seções (mais recentes primeiro), calcula os totais e gera o Markdown.
+func (d *Digest) Finalize() {
+	if d.Commits == nil {
+		d.Commits = []Commit{}
+	}
+	if d.Activity == nil {
+		d.Activity = []Activity{}
+	}
+	if d.PullRequestsOpened == nil {
+		d.PullRequestsOpened = []PullRequest{}
+	}
+	if d.PullRequestsReviewed == nil {
+		d.PullRequestsReviewed = []PullRequest{}
+	}
+	if d.TestRuns == nil {
+		d.TestRuns = []TestRun{}
+	}
+	if d.FocusSessions == nil {
+		d.FocusSessions = []FocusSession{}
+	}
+	if d.Warnings == nil {
+		d.Warnings = []string{}
+	}
+
+	sort.SliceStable(d.Commits, func(i, j int) bool { return d.Commits[i].AuthoredAt.After(d.Commits[j].AuthoredAt) })
+	sort.SliceStable(d.Activity, func(i, j int) bool { return d.Activity[i].Timestamp.After(d.Activity[j].Timestamp) })
+	sort.SliceStable(d.TestRuns, func(i, j int) bool { return d.TestRuns[i].StartedAt.After(d.TestRuns[j].StartedAt) })
+	sort.SliceStable(d.FocusSessions, func(i, j int) bool { return d.FocusSessions[i].StartedAt.After(d.FocusSessions[j].StartedAt) })
+
+	d.Stats = Stats{
+		Commits:     len(d.Commits),
+		PRsOpened:   len(d.PullRequestsOpened),
+		PRsReviewed: len(d.PullRequestsReviewed),
+		TestRuns:    len(d.TestRuns),
+
+		FocusSessions: len(d.FocusSessions),
+	}
+	for _, commit := range d.Commits {
+		d.Stats.Additions += commit.Additions
+		d.Stats.Deletions += commit.Deletions
+	}
+	for _, run := range d.TestRuns {
+		switch run.Status {
+		case "failed":
+			d.Stats.TestRunsFailed++
+		case "passed":
+			d.Stats.TestRunsPassing++
+		}
+	}
+	for _, session := range d.FocusSessions {
+		d.Stats.FocusMinutes += session.Minutes
+	}
+	d.Markdown = RenderMarkdown(*d)
+}
+
+// RenderMarkdown formata o digest em seções prontas para colar na daily.
+func RenderMarkdown(d Digest) string {
+	var sb strings.Builder
+	title := "Resumo de atividade"
+	if d.WorkspaceName != "" {
+		title += " — " + d.WorkspaceName
+	}
+	fmt.Fprintf(&sb, "# %s\n\n", title)
+	fmt.Fprintf(&sb, "Desde %s · %d commits (+%d/-%d) · %d PRs abertos · %d PRs revisados · %d execuções de teste\n",
+		d.Since.Local().Format("02/01 15:04"), d.Stats.Commits, d.Stats.Additions, d.Stats.Deletions,
+		d.Stats.PRsOpened, d.Stats.PRsReviewed, d.Stats.TestRuns)
+
+	if len(d.Commits) > 0 {
+		sb.WriteString("\n## Commits\n\n")
+		for _, commit := range d.Commits {
+			fmt.Fprintf(&sb, "- **%s** %s (`%s`, +%d/-%d)\n", commit.Repo, commit.Subject, commit.ShortHash, commit.Additions, commit.Deletions)
+		}
+	}
+	writePullRequests(&sb, "Pull Requests abertos", d.PullRequestsOpened)
+	writePullRequests(&sb, "Pull Requests revisados", d.PullRequestsReviewed)
+	if len(d.TestRuns) > 0 {
+		sb.WriteString("\n## Testes\n\n")
+		for _, run := range d.TestRuns {
+			line := fmt.Sprintf("- %s `%s`", testStatusLabel(run.Status), run.Command)
+			if run.Summary != "" {
+				line += " — " + run.Summary
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	if len(d.FocusSessions) > 0 {
+		sb.WriteString("\n## Foco\n\n")
+		workspace := ""
+		if d.WorkspaceName != "" {
+			workspace = " no workspace " + d.WorkspaceName
+		}
+		fmt.Fprintf(&sb, "Focado por %s%s em %d sessões.\n\n", formatFocusMinutes(d.Stats.FocusMinutes), workspace, d.Stats.FocusSessions)
+		for _, session := range d.FocusSessions {
+			line := fmt.Sprintf("- %s–%s (%s)", session.StartedAt.Local().Format("15:04"), session.EndedAt.Local().Format("15:04"), formatFocusMinutes(session.Minutes))
+			if !session.Completed {
+				line += " · interrompido"
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	if len(d.Activity) > 0 {
+		sb.WriteString("\n## Atividade Git\n\n")
+		for _, event := range d.Activity {
+			fmt.Fprintf(&sb, "- %s **%s** %s\n", event.Timestamp.Local().Format("15:04"), event.Repo, event.Message)
+		}
+	}
+	if d.Stats.Commits == 0 && d.Stats.PRsOpened == 0 && d.Stats.PRsReviewed == 0 && d.Stats.TestRuns == 0 && len(d.Activity) == 0 &&
+		d.Stats.FocusSessions == 0 {
+		sb.WriteString("\nNenhuma atividade registrada no período.\n")
+	}
+	return sb.String()
+}
+
+// SummaryPrompt pede à IA um resumo curto no formato de daily a partir do Markdown do digest.
+func SummaryPrompt(d Digest) string {
+	return "Resuma a atividade abaixo para uma daily/standup em até 5 tópicos curtos, " +
+		"agrupando o que foi feito, o que está em andamento e possíveis bloqueios (testes falhando, PRs aguardando). " +
+		"Não invente nada que não esteja nos dados.\n\n" + d.Markdown
+}
+
+func writePullRequests(sb *strings.Builder, title string, prs []PullRequest) {
+	if len(prs) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\n## %s\n\n", title)
+	for _, pr := range prs {
+		state := strings.ToLower(pr.State)
+		if pr.IsDraft {
+			state = "draft"
+		}
+		fmt.Fprintf(sb, "- [%s#%d](%s) %s (%s)\n", pr.Repo, pr.Number, pr.URL, pr.Title, state)
+	}
+}
+
+// formatFocusMinutes formata o tempo de foco ("45 min", "3h10").
+func formatFocusMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%d min", minutes)
+	}
+	return fmt.Sprintf("%dh%02d", minutes/60, minutes%60)
+}
+
+func testStatusLabel(status string) string {
+	switch status {
+	case "passed":
+		return "✅"
+	case "failed":
+		return "❌"
+	case "running":
+		return "⏳"
+	default:
+		return "❔"
+	}
+}
@@ -0,0 +1,92 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFinalizeSortsComputesStatsAndRendersMarkdown(t *testing.T) {
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	d := Digest{
+		WorkspaceName: "orch",
+		Since:         base.Add(-24 * time.Hour),
+		Commits: []Commit{
+			{Repo: "api", ShortHash: "aaa1111", Subject: "older", AuthoredAt: base, Additions: 3, Deletions: 1},
+			{Repo: "web", ShortHash: "bbb2222", Subject: "newer", AuthoredAt: base.Add(time.Hour), Additions: 10},
+		},
+		PullRequestsOpened: []PullRequest{{Repo: "orch-labs/api", Number: 12, Title: "Add digest", URL: "https://github.com/orch-labs/api/pull/12", State: "OPEN", IsDraft: true}},
+		TestRuns: []TestRun{
+			{Command: "go test ./...", Status: "failed", Summary: "FAIL\torch/internal/a", StartedAt: base},
+			{Command: "npm test", Status: "passed", StartedAt: base.Add(time.Minute)},
+		},
+	}
+	d.Finalize()
+
+	if d.Commits[0].Subject != "newer" || d.TestRuns[0].Command != "npm test" {
+		t.Fatalf("expected newest entries first, got commits=%+v runs=%+v", d.Commits, d.TestRuns)
+	}
+	want := Stats{Commits: 2, Additions: 13, Deletions: 1, PRsOpened: 1, TestRuns: 2, TestRunsFailed: 1, TestRunsPassing: 1}
+	if d.Stats != want {
+		t.Fatalf("unexpected stats: %+v", d.Stats)
+	}
+	if d.Activity == nil || d.PullRequestsReviewed == nil || d.Warnings == nil {
+		t.Fatalf("expected empty sections to be non-nil: %+v", d)
+	}
+
+	for _, fragment := range []string{
+		"# Resumo de atividade — orch",
+		"2 commits (+13/-1)",
+		"- **web** newer (`bbb2222`, +10/-0)",
+		"- [orch-labs/api#12](https://github.com/orch-labs/api/pull/12) Add digest (draft)",
+		"- ❌ `go test ./...` — FAIL\torch/internal/a",
+	} {
+		if !strings.Contains(d.Markdown, fragment) {
+			t.Fatalf("markdown missing %q:\n%s", fragment, d.Markdown)
+		}
+	}
+	if strings.Contains(d.Markdown, "Pull Requests revisados") || strings.Contains(d.Markdown, "Nenhuma atividade") {
+		t.Fatalf("unexpected section in markdown:\n%s", d.Markdown)
+	}
+	if !strings.HasSuffix(SummaryPrompt(d), d.Markdown) {
+		t.Fatalf("summary prompt should embed the markdown")
+	}
+}
+
+func TestRenderMarkdownWithoutActivity(t *testing.T) {
+	d := Digest{Since: time.Now().Add(-time.Hour)}
+	d.Finalize()
+	if !strings.Contains(d.Markdown, "Nenhuma atividade registrada no período.") {
+		t.Fatalf("expected empty notice, got:\n%s", d.Markdown)
+	}
+}
+
+func TestRenderMarkdownFocusSessions(t *testing.T) {
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	d := Digest{
+		WorkspaceName: "orch",
+		Since:         base.Add(-24 * time.Hour),
+		FocusSessions: []FocusSession{
+			{StartedAt: base, EndedAt: base.Add(2 * time.Hour), Minutes: 120, Completed: true},
+			{StartedAt: base.Add(3 * time.Hour), EndedAt: base.Add(3*time.Hour + 70*time.Minute), Minutes: 70},
+		},
+	}
+	d.Finalize()
+
+	if d.Stats.FocusSessions != 2 || d.Stats.FocusMinutes != 190 {
+		t.Fatalf("unexpected focus stats: %+v", d.Stats)
+	}
+	for _, fragment := range []string{
+		"## Foco",
+		"Focado por 3h10 no workspace orch em 2 sessões.",
+		"- 12:00–13:10 (1h10) · interrompido",
+		"- 09:00–11:00 (2h00)\n",
+	} {
+		if !strings.Contains(d.Markdown, fragment) {
+			t.Fatalf("markdown missing %q:\n%s", fragment, d.Markdown)
+		}
+	}
+	if strings.Contains(d.Markdown, "Nenhuma atividade") {
+		t.Fatalf("focus time should count as activity:\n%s", d.Markdown)
+	}
+}
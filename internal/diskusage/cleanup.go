@@ -0,0 +1,54 @@
+package diskusage
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CleanupResult resume o que uma limpeza removeu.
+type CleanupResult struct {
+	Removed    int      `json:"removed"`
+	FreedBytes int64    `json:"freedBytes"`
+	Paths      []string `json:"paths"`
+}
+
+// Add acumula o resultado de outra limpeza.
+func (r *CleanupResult) Add(other CleanupResult) {
+	r.Removed += other.Removed
+	r.FreedBytes += other.FreedBytes
+	r.Paths = append(r.Paths, other.Paths...)
+}
+
+// RemoveOlderThan apaga os arquivos de dir (sem descer em subdiretórios) cujo nome casa com
+// pattern (filepath.Match) e que não foram modificados desde cutoff.
+func RemoveOlderThan(dir, pattern string, cutoff time.Time) (CleanupResult, error) {
+	result := CleanupResult{Paths: []string{}}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		if matched, _ := filepath.Match(pattern, entry.Name()); !matched {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if err := os.Remove(path); err != nil {
+			return result, err
+		}
+		result.Removed++
+		result.FreedBytes += info.Size()
+		result.Paths = append(result.Paths, path)
+	}
+	return result, nil
+}
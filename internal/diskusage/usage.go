@@ -0,0 +1,135 @@
+package diskusage
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Category agrupa o espaço em disco pela origem.
+type Category string
+
+const (
+	CategoryWorkspace Category = "workspace"
+	CategoryDocker    Category = "docker"
+	CategoryDatabase  Category = "database"
+	CategoryBackups   Category = "backups"
+	CategoryExports   Category = "exports"
+	CategoryLogs      Category = "logs"
+	CategoryCache     Category = "cache"
+)
+
+// Item é uma entrada medida (um workspace, uma imagem, o banco...).
+type Item struct {
+	Category Category `json:"category"`
+	Name     string   `json:"name"`
+	Path     string   `json:"path,omitempty"`
+	Bytes    int64    `json:"bytes"`
+	Files    int      `json:"files"`
+	Partial  bool     `json:"partial,omitempty"` // varredura interrompida pelo timeout
+	Error    string   `json:"error,omitempty"`
+}
+
+// Alert indica uma categoria acima do limite configurado.
+type Alert struct {
+	Category   Category `json:"category"`
+	Bytes      int64    `json:"bytes"`
+	LimitBytes int64    `json:"limitBytes"`
+}
+
+// Report é o resultado de uma varredura.
+type Report struct {
+	Items      []Item             `json:"items"`
+	Totals     map[Category]int64 `json:"totals"`
+	TotalBytes int64              `json:"totalBytes"`
+	Alerts     []Alert            `json:"alerts"`
+	ScannedAt  time.Time          `json:"scannedAt"`
+}
+
+// Source descreve o que medir; Paths pode ter arquivos e diretórios (ex.: banco + WAL).
+type Source struct {
+	Category Category
+	Name     string
+	Paths    []string
+}
+
+// Measure soma o tamanho dos arquivos regulares em path, sem seguir symlinks.
+// Caminho inexistente conta como zero.
+func Measure(ctx context.Context, path string) (int64, int, error) {
+	var (
+		total int64
+		files int
+	)
+	err := filepath.WalkDir(path, func(current string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if errors.Is(walkErr, fs.ErrNotExist) || (current != path && errors.Is(walkErr, fs.ErrPermission)) {
+				return nil
+			}
+			return walkErr
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		total += info.Size()
+		files++
+		return nil
+	})
+	return total, files, err
+}
+
+// Scan mede as fontes, junta os itens já medidos (ex.: imagens Docker) e compara os totais por
+// categoria com os limites (em bytes; zero ou ausente = sem alerta).
+func Scan(ctx context.Context, sources []Source, measured []Item, limits map[Category]int64) Report {
+	report := Report{
+		Items:     make([]Item, 0, len(sources)+len(measured)),
+		Totals:    make(map[Category]int64),
+		Alerts:    []Alert{},
+		ScannedAt: time.Now(),
+	}
+	for _, source := range sources {
+		item := Item{Category: source.Category, Name: source.Name}
+		if len(source.Paths) > 0 {
+			item.Path = source.Paths[0]
+		}
+		for _, path := range source.Paths {
+			bytes, files, err := Measure(ctx, path)
+			item.Bytes += bytes
+			item.Files += files
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				item.Partial = true
+			} else if err != nil && item.Error == "" {
+				item.Error = err.Error()
+			}
+		}
+		report.Items = append(report.Items, item)
+	}
+	report.Items = append(report.Items, measured...)
+
+	for _, item := range report.Items {
+		report.Totals[item.Category] += item.Bytes
+		report.TotalBytes += item.Bytes
+	}
+	for category, limit := range limits {
+		if limit > 0 && report.Totals[category] > limit {
+			report.Alerts = append(report.Alerts, Alert{Category: category, Bytes: report.Totals[category], LimitBytes: limit})
+		}
+	}
+	sort.Slice(report.Alerts, func(i, j int) bool { return report.Alerts[i].Category < report.Alerts[j].Category })
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		if report.Items[i].Category != report.Items[j].Category {
+			return report.Items[i].Category < report.Items[j].Category
+		}
+		return report.Items[i].Bytes > report.Items[j].Bytes
+	})
+	return report
+}
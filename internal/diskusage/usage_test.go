@@ -0,0 +1,91 @@
+package diskusage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeSized(t *testing.T, path string, size int) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestScanMeasuresSourcesAndRaisesAlerts(t *testing.T) {
+	root := t.TempDir()
+	writeSized(t, filepath.Join(root, "ws", "main.go"), 300)
+	writeSized(t, filepath.Join(root, "ws", "node_modules", "lib.js"), 700)
+	writeSized(t, filepath.Join(root, "orch.db"), 50)
+	writeSized(t, filepath.Join(root, "orch.db-wal"), 25)
+
+	report := Scan(context.Background(), []Source{
+		{Category: CategoryWorkspace, Name: "ws", Paths: []string{filepath.Join(root, "ws")}},
+		{Category: CategoryDatabase, Name: "orch.db", Paths: []string{filepath.Join(root, "orch.db"), filepath.Join(root, "orch.db-wal"), filepath.Join(root, "orch.db-shm")}},
+	}, []Item{{Category: CategoryDocker, Name: "orch-stack-node:latest", Bytes: 5000}}, map[Category]int64{
+		CategoryWorkspace: 500,
+		CategoryDatabase:  1000,
+	})
+
+	if report.Totals[CategoryWorkspace] != 1000 || report.Totals[CategoryDatabase] != 75 || report.Totals[CategoryDocker] != 5000 {
+		t.Fatalf("unexpected totals: %+v", report.Totals)
+	}
+	if report.TotalBytes != 6075 {
+		t.Fatalf("TotalBytes = %d, want 6075", report.TotalBytes)
+	}
+	if len(report.Alerts) != 1 || report.Alerts[0].Category != CategoryWorkspace || report.Alerts[0].LimitBytes != 500 {
+		t.Fatalf("unexpected alerts: %+v", report.Alerts)
+	}
+	for _, item := range report.Items {
+		if item.Category == CategoryWorkspace && item.Files != 2 {
+			t.Fatalf("expected workspace to count 2 files, got %+v", item)
+		}
+	}
+}
+
+func TestScanMarksCanceledScansAsPartial(t *testing.T) {
+	root := t.TempDir()
+	writeSized(t, filepath.Join(root, "a.txt"), 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := Scan(ctx, []Source{{Category: CategoryWorkspace, Name: "ws", Paths: []string{root}}}, nil, nil)
+	if len(report.Items) != 1 || !report.Items[0].Partial || report.Items[0].Error != "" {
+		t.Fatalf("expected partial item, got %+v", report.Items)
+	}
+}
+
+func TestRemoveOlderThanOnlyTouchesMatchingStaleFiles(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-72 * time.Hour)
+	for _, name := range []string{"orch.log.1", "orch.log.2", "orch.log", "notes.txt"} {
+		writeSized(t, filepath.Join(dir, name), 100)
+		if err := os.Chtimes(filepath.Join(dir, name), old, old); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+	writeSized(t, filepath.Join(dir, "orch.log.3"), 100) // recente
+
+	result, err := RemoveOlderThan(dir, "orch.log.*", time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("RemoveOlderThan() error: %v", err)
+	}
+	if result.Removed != 2 || result.FreedBytes != 200 {
+		t.Fatalf("unexpected cleanup result: %+v", result)
+	}
+	for _, kept := range []string{"orch.log", "orch.log.3", "notes.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, kept)); err != nil {
+			t.Fatalf("expected %s to be kept: %v", kept, err)
+		}
+	}
+
+	if result, err := RemoveOlderThan(filepath.Join(dir, "missing"), "*", time.Now()); err != nil || result.Removed != 0 {
+		t.Fatalf("missing dir should be a no-op, got %+v %v", result, err)
+	}
+}
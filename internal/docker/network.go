@@ -0,0 +1,92 @@
+package docker
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+)
+
+const (
+	// egressRelayImage repassa TCP da rede interna para o proxy no host (Docker Desktop).
+	egressRelayImage = "alpine/socat:latest"
+	egressRelayPort  = "3128"
+)
+
+// CreateInternalNetwork cria uma rede bridge sem rota para fora (--internal) e devolve o IP do
+// gateway, onde o host escuta o proxy de saída da sessão no Docker nativo do Linux.
+func (s *Service) CreateInternalNetwork(name string) (string, error) {
+	if err := runDocker("network", "create", "--internal", "--label", "orch.egress=true", name); err != nil {
+		return "", err
+	}
+	out, err := exec.Command("docker", "network", "inspect", "--format", "{{range .IPAM.Config}}{{.Gateway}} {{end}}", name).CombinedOutput()
+	if err != nil {
+		_ = s.RemoveNetwork(name)
+		return "", fmt.Errorf("docker network inspect failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	for _, gateway := range strings.Fields(string(out)) {
+		if ip := net.ParseIP(gateway); ip != nil && ip.To4() != nil {
+			return gateway, nil
+		}
+	}
+	_ = s.RemoveNetwork(name)
+	return "", fmt.Errorf("docker network %s has no IPv4 gateway", name)
+}
+
+// RemoveNetwork remove a rede criada para a sessão.
+func (s *Service) RemoveNetwork(name string) error {
+	return runDocker("network", "rm", name)
+}
+
+// StartEgressRelay sobe, ligado à rede interna, um container que repassa as conexões para o
+// proxy no loopback do host (host.docker.internal:hostPort). É o caminho do Docker Desktop
+// (macOS/Windows): lá o gateway da rede fica dentro da VM e o host não consegue escutar nele.
+// Devolve o nome do container e a URL do proxy vista de dentro da rede.
+func (s *Service) StartEgressRelay(network string, hostPort string) (string, string, error) {
+	name := network + "-relay"
+	if err := runDocker(egressRelayRunArgs(name, hostPort)...); err != nil {
+		return "", "", fmt.Errorf("start egress relay (image %s): %w", egressRelayImage, err)
+	}
+	if err := runDocker("network", "connect", network, name); err != nil {
+		_ = s.RemoveEgressRelay(name)
+		return "", "", err
+	}
+	return name, "http://" + net.JoinHostPort(name, egressRelayPort), nil
+}
+
+// RemoveEgressRelay derruba o relay da sessão (antes da rede, que não sai com containers ligados).
+func (s *Service) RemoveEgressRelay(name string) error {
+	return runDocker("rm", "-f", name)
+}
+
+// egressRelayRunArgs: o relay nasce na bridge padrão (alcança o host) e só recebe a rede interna
+// depois, sem capabilities nem escrita no disco.
+func egressRelayRunArgs(name string, hostPort string) []string {
+	return []string{
+		"run", "-d", "--rm",
+		"--name", name,
+		"--label", "orch.egress=true",
+		"--network", "bridge",
+		"--add-host", "host.docker.internal:host-gateway",
+		"--user", "65534:65534",
+		"--cap-drop", "ALL",
+		"--security-opt", "no-new-privileges",
+		"--read-only",
+		"--memory", "32m",
+		egressRelayImage,
+		"TCP-LISTEN:" + egressRelayPort + ",fork,reuseaddr",
+		"TCP:host.docker.internal:" + hostPort,
+	}
+}
+
+// ProxyEnv devolve as variáveis que apontam ferramentas (curl, git, npm, pip) para o proxy.
+func ProxyEnv(proxyURL string) []string {
+	return []string{
+		"HTTP_PROXY=" + proxyURL,
+		"HTTPS_PROXY=" + proxyURL,
+		"http_proxy=" + proxyURL,
+		"https_proxy=" + proxyURL,
+		"NO_PROXY=localhost,127.0.0.1",
+		"no_proxy=localhost,127.0.0.1",
+	}
+}
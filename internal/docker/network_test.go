@@ -0,0 +1,22 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEgressRelayRunArgsForwardToHostProxy(t *testing.T) {
+	joined := strings.Join(egressRelayRunArgs("orch-egress-1-relay", "49152"), " ")
+	for _, want := range []string{
+		"--name orch-egress-1-relay",
+		"--network bridge",
+		"--add-host host.docker.internal:host-gateway",
+		"--cap-drop ALL",
+		"--read-only",
+		egressRelayImage + " TCP-LISTEN:3128,fork,reuseaddr TCP:host.docker.internal:49152",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("relay args missing %q: %s", want, joined)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SecurityProfile seleciona o endurecimento aplicado aos containers de sessão.
+type SecurityProfile string
+
+const (
+	// ProfileStrict bloqueia syscalls de escape via seccomp próprio, remove todas as capabilities
+	// e monta /tmp sem exec.
+	ProfileStrict SecurityProfile = "strict"
+	// ProfileStandard usa o seccomp padrão do Docker e devolve as capabilities mínimas para
+	// instalar pacotes e ajustar donos de arquivo dentro do container.
+	ProfileStandard SecurityProfile = "standard"
+)
+
+// StrictSeccompName identifica o perfil seccomp embutido nas configurações auditadas.
+const StrictSeccompName = "orch-strict"
+
+// standardCapabilities são as capabilities devolvidas no perfil standard (após --cap-drop ALL).
+var standardCapabilities = []string{"CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "SETGID", "SETUID"}
+
+// NormalizeSecurityProfile valida o perfil; vazio vira strict.
+func NormalizeSecurityProfile(profile string) (SecurityProfile, error) {
+	switch SecurityProfile(strings.ToLower(strings.TrimSpace(profile))) {
+	case "", ProfileStrict:
+		return ProfileStrict, nil
+	case ProfileStandard:
+		return ProfileStandard, nil
+	default:
+		return "", fmt.Errorf("unknown docker security profile %q (use strict or standard)", profile)
+	}
+}
+
+// SecuritySettings descreve o isolamento efetivo de um container, para exibição e auditoria.
+type SecuritySettings struct {
+	Profile         SecurityProfile `json:"profile"`
+	Seccomp         string          `json:"seccomp"`  // "orch-strict", "docker-default" ou "unconfined"
+	AppArmor        string          `json:"appArmor"` // vazio quando o host não usa AppArmor
+	CapDrop         []string        `json:"capDrop"`
+	CapAdd          []string        `json:"capAdd"`
+	NoNewPrivileges bool            `json:"noNewPrivileges"`
+	ReadOnlyRootfs  bool            `json:"readOnlyRootfs"`
+	WritableMounts  []string        `json:"writableMounts"` // destinos graváveis (bind + tmpfs)
+	PidsLimit       int64           `json:"pidsLimit"`
+	NetworkMode     string          `json:"networkMode"`
+}
+
+// Summary resume as configurações em uma linha para o audit log.
+func (s SecuritySettings) Summary() string {
+	return fmt.Sprintf(
+		"profile=%s seccomp=%s apparmor=%s capDrop=%s capAdd=%s noNewPrivileges=%t readOnlyRootfs=%t writable=%s pidsLimit=%d network=%s",
+		s.Profile,
+		s.Seccomp,
+		orNone(s.AppArmor),
+		orNone(strings.Join(s.CapDrop, ",")),
+		orNone(strings.Join(s.CapAdd, ",")),
+		s.NoNewPrivileges,
+		s.ReadOnlyRootfs,
+		orNone(strings.Join(s.WritableMounts, ",")),
+		s.PidsLimit,
+		orNone(s.NetworkMode),
+	)
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// ResolveSecuritySettings devolve o isolamento pedido para a configuração (já com defaults).
+func ResolveSecuritySettings(cfg ContainerConfig) SecuritySettings {
+	cfg = withDefaults(cfg)
+	settings := SecuritySettings{
+		Profile:         cfg.SecurityProfile,
+		Seccomp:         "docker-default",
+		AppArmor:        "docker-default",
+		CapDrop:         []string{"ALL"},
+		CapAdd:          []string{},
+		NoNewPrivileges: true,
+		ReadOnlyRootfs:  cfg.ReadOnly,
+		WritableMounts:  []string{},
+		PidsLimit:       256,
+		NetworkMode:     cfg.NetworkMode,
+	}
+	if cfg.ProjectPath != "" {
+		settings.WritableMounts = append(settings.WritableMounts, "/workspace")
+	}
+	if cfg.ReadOnly {
+		settings.WritableMounts = append(settings.WritableMounts, "/tmp", "/var/tmp")
+	}
+	if cfg.SecurityProfile == ProfileStrict {
+		settings.Seccomp = StrictSeccompName
+		settings.PidsLimit = 128
+	} else {
+		settings.CapAdd = append(settings.CapAdd, standardCapabilities...)
+		if cfg.ReadOnly {
+			settings.WritableMounts = append(settings.WritableMounts, "/root")
+		}
+	}
+	return settings
+}
+
+// securityRunArgs traduz o perfil em flags de `docker create`. seccompPath aponta para o perfil
+// strict gravado em disco.
+func securityRunArgs(cfg ContainerConfig, seccompPath string) []string {
+	settings := ResolveSecuritySettings(cfg)
+	args := []string{
+		"--pids-limit", strconv.FormatInt(settings.PidsLimit, 10),
+		"--security-opt", "no-new-privileges",
+		"--security-opt", "apparmor=" + settings.AppArmor,
+	}
+	if settings.Seccomp == StrictSeccompName {
+		args = append(args, "--security-opt", "seccomp="+seccompPath)
+	}
+	for _, capability := range settings.CapDrop {
+		args = append(args, "--cap-drop", capability)
+	}
+	for _, capability := range settings.CapAdd {
+		args = append(args, "--cap-add", capability)
+	}
+
+	if cfg.ReadOnly {
+		// strict não permite executar binários baixados para /tmp.
+		tmpOpts := "rw,noexec,nosuid,nodev"
+		if settings.Profile == ProfileStandard {
+			tmpOpts = "rw,nosuid,nodev"
+		}
+		args = append(args,
+			"--read-only",
+			"--tmpfs", "/tmp:"+tmpOpts+",size=512m",
+			"--tmpfs", "/var/tmp:"+tmpOpts+",size=256m",
+		)
+		if settings.Profile == ProfileStandard {
+			args = append(args, "--tmpfs", "/root:rw,nosuid,nodev,size=512m")
+		}
+	}
+	return args
+}
+
+// strictBlockedSyscalls são negadas no perfil strict: montagem (inclusive a API nova), módulos de
+// kernel, namespaces, ptrace/leitura de outros processos, eBPF, io_uring e keyrings.
+var strictBlockedSyscalls = []string{
+	"acct", "add_key", "bpf", "clock_adjtime", "clock_settime", "create_module", "delete_module",
+	"finit_module", "fsconfig", "fsmount", "fsopen", "fspick", "get_kernel_syms", "get_mempolicy",
+	"init_module", "io_uring_enter", "io_uring_register", "io_uring_setup", "ioperm", "iopl", "kcmp",
+	"kexec_file_load", "kexec_load", "keyctl", "lookup_dcookie", "mbind", "mount", "mount_setattr",
+	"move_mount", "move_pages", "name_to_handle_at", "nfsservctl", "open_by_handle_at", "open_tree",
+	"perf_event_open", "pivot_root", "process_vm_readv", "process_vm_writev", "ptrace",
+	"query_module", "quotactl", "reboot", "request_key", "set_mempolicy", "setns", "settimeofday",
+	"stime", "swapoff", "swapon", "sysfs", "_sysctl", "umount", "umount2", "unshare", "uselib",
+	"userfaultfd", "ustat", "vm86", "vm86old",
+}
+
+// cloneNamespaceFlags são as flags de clone(2) que criam namespaces (CLONE_NEWNS, NEWCGROUP,
+// NEWUTS, NEWIPC, NEWUSER, NEWPID, NEWNET).
+var cloneNamespaceFlags = []uint64{0x00020000, 0x02000000, 0x04000000, 0x08000000, 0x10000000, 0x20000000, 0x40000000}
+
+type seccompProfile struct {
+	DefaultAction string           `json:"defaultAction"`
+	Syscalls      []seccompSyscall `json:"syscalls"`
+}
+
+type seccompSyscall struct {
+	Names    []string     `json:"names"`
+	Action   string       `json:"action"`
+	ErrnoRet *uint        `json:"errnoRet,omitempty"`
+	Args     []seccompArg `json:"args,omitempty"`
+}
+
+type seccompArg struct {
+	Index    uint   `json:"index"`
+	Value    uint64 `json:"value"`
+	ValueTwo uint64 `json:"valueTwo"`
+	Op       string `json:"op"`
+}
+
+// StrictSeccompProfile gera o JSON do perfil strict: libera o resto e nega as syscalls de escape
+// com EPERM. clone3 devolve ENOSYS para que a libc recaia em clone, filtrado por flags.
+func StrictSeccompProfile() ([]byte, error) {
+	enosys := uint(38)
+	blocked := append([]string(nil), strictBlockedSyscalls...)
+	sort.Strings(blocked)
+
+	profile := seccompProfile{
+		DefaultAction: "SCMP_ACT_ALLOW",
+		Syscalls: []seccompSyscall{
+			{Names: blocked, Action: "SCMP_ACT_ERRNO"},
+			{Names: []string{"clone3"}, Action: "SCMP_ACT_ERRNO", ErrnoRet: &enosys},
+		},
+	}
+	for _, flag := range cloneNamespaceFlags {
+		profile.Syscalls = append(profile.Syscalls, seccompSyscall{
+			Names:  []string{"clone"},
+			Action: "SCMP_ACT_ERRNO",
+			Args:   []seccompArg{{Index: 0, Value: flag, ValueTwo: flag, Op: "SCMP_CMP_MASKED_EQ"}},
+		})
+	}
+	return json.MarshalIndent(profile, "", "  ")
+}
+
+// writeStrictSeccompProfile grava o perfil strict em dir e devolve o caminho.
+func writeStrictSeccompProfile(dir string) (string, error) {
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "orch-docker")
+	}
+	data, err := StrictSeccompProfile()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("create seccomp profile dir: %w", err)
+	}
+	path := filepath.Join(dir, "seccomp-"+StrictSeccompName+".json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("write seccomp profile: %w", err)
+	}
+	return path, nil
+}
+
+// hostConfigSecurity é o subconjunto de `docker inspect` (.HostConfig) usado para auditar o
+// isolamento efetivo.
+type hostConfigSecurity struct {
+	CapAdd         []string          `json:"CapAdd"`
+	CapDrop        []string          `json:"CapDrop"`
+	SecurityOpt    []string          `json:"SecurityOpt"`
+	ReadonlyRootfs bool              `json:"ReadonlyRootfs"`
+	Tmpfs          map[string]string `json:"Tmpfs"`
+	Binds          []string          `json:"Binds"`
+	PidsLimit      *int64            `json:"PidsLimit"`
+	NetworkMode    string            `json:"NetworkMode"`
+}
+
+// parseEffectiveSecurity converte o HostConfig inspecionado em SecuritySettings. O perfil vem de
+// quem criou o container, já que o Docker não o conhece.
+func parseEffectiveSecurity(raw []byte, profile SecurityProfile) (SecuritySettings, error) {
+	var host hostConfigSecurity
+	if err := json.Unmarshal(raw, &host); err != nil {
+		return SecuritySettings{}, fmt.Errorf("parse docker host config: %w", err)
+	}
+
+	settings := SecuritySettings{
+		Profile:        profile,
+		Seccomp:        "docker-default",
+		CapDrop:        upperAll(host.CapDrop),
+		CapAdd:         upperAll(host.CapAdd),
+		ReadOnlyRootfs: host.ReadonlyRootfs,
+		WritableMounts: []string{},
+		NetworkMode:    host.NetworkMode,
+	}
+	if host.PidsLimit != nil {
+		settings.PidsLimit = *host.PidsLimit
+	}
+	for _, opt := range host.SecurityOpt {
+		// Formatos "chave=valor" e o legado "chave:valor".
+		key, value := opt, ""
+		if sep := strings.IndexAny(opt, "=:"); sep >= 0 {
+			key, value = opt[:sep], opt[sep+1:]
+		}
+		switch key {
+		case "no-new-privileges":
+			settings.NoNewPrivileges = value == "" || value == "true"
+		case "apparmor":
+			settings.AppArmor = value
+		case "seccomp":
+			settings.Seccomp = classifySeccomp(value)
+		}
+	}
+	for _, bind := range host.Binds {
+		parts := strings.Split(bind, ":")
+		if len(parts) < 2 {
+			continue
+		}
+		if len(parts) == 3 && strings.Contains(","+parts[2]+",", ",ro,") {
+			continue
+		}
+		settings.WritableMounts = append(settings.WritableMounts, parts[1])
+	}
+	tmpfs := make([]string, 0, len(host.Tmpfs))
+	for target, opts := range host.Tmpfs {
+		if !strings.Contains(","+opts+",", ",ro,") {
+			tmpfs = append(tmpfs, target)
+		}
+	}
+	sort.Strings(tmpfs)
+	settings.WritableMounts = append(settings.WritableMounts, tmpfs...)
+	return settings, nil
+}
+
+// classifySeccomp reconhece o perfil aplicado: o Docker guarda o JSON (compactado pelo CLI) em
+// SecurityOpt, então o strict é identificado comparando com o perfil gerado.
+func classifySeccomp(value string) string {
+	switch value {
+	case "unconfined":
+		return "unconfined"
+	case "", "builtin":
+		return "docker-default"
+	}
+	strict, err := StrictSeccompProfile()
+	if err != nil {
+		return "custom"
+	}
+	var want, got bytes.Buffer
+	if json.Compact(&want, strict) == nil && json.Compact(&got, []byte(value)) == nil && want.String() == got.String() {
+		return StrictSeccompName
+	}
+	return "custom"
+}
+
+func upperAll(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, strings.TrimPrefix(strings.ToUpper(value), "CAP_"))
+	}
+	return result
+}
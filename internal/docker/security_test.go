@@ -0,0 +1,114 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildRunArgsAppliesSecurityProfile(t *testing.T) {
+	s := NewService()
+	strict := s.buildRunArgs(withDefaults(ContainerConfig{ProjectPath: "/src/app"}), "alpine:latest", "/tmp/strict.json")
+	joined := strings.Join(strict, " ")
+	for _, want := range []string{
+		"--pids-limit 128",
+		"--security-opt no-new-privileges",
+		"--security-opt apparmor=docker-default",
+		"--security-opt seccomp=/tmp/strict.json",
+		"--cap-drop ALL",
+		"--read-only",
+		"--tmpfs /tmp:rw,noexec,nosuid,nodev,size=512m",
+		"--network none",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("strict args missing %q: %s", want, joined)
+		}
+	}
+	if strings.Contains(joined, "--cap-add") {
+		t.Fatalf("strict profile must not add capabilities: %s", joined)
+	}
+
+	standard := strings.Join(s.buildRunArgs(withDefaults(ContainerConfig{SecurityProfile: ProfileStandard}), "alpine:latest", ""), " ")
+	for _, want := range []string{"--cap-drop ALL", "--cap-add CHOWN", "--cap-add SETUID", "--tmpfs /tmp:rw,nosuid,nodev,size=512m", "--tmpfs /root:"} {
+		if !strings.Contains(standard, want) {
+			t.Fatalf("standard args missing %q: %s", want, standard)
+		}
+	}
+	if strings.Contains(standard, "seccomp=") {
+		t.Fatalf("standard profile must keep Docker's default seccomp: %s", standard)
+	}
+}
+
+func TestNormalizeSecurityProfile(t *testing.T) {
+	if profile, err := NormalizeSecurityProfile(""); err != nil || profile != ProfileStrict {
+		t.Fatalf("empty profile = (%q, %v), want strict", profile, err)
+	}
+	if profile, err := NormalizeSecurityProfile(" Standard "); err != nil || profile != ProfileStandard {
+		t.Fatalf("Standard = (%q, %v)", profile, err)
+	}
+	if _, err := NormalizeSecurityProfile("privileged"); err == nil {
+		t.Fatalf("expected unknown profile to be rejected")
+	}
+}
+
+func TestStrictSeccompProfileBlocksEscapeSyscalls(t *testing.T) {
+	data, err := StrictSeccompProfile()
+	if err != nil {
+		t.Fatalf("StrictSeccompProfile() error: %v", err)
+	}
+	var profile seccompProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		t.Fatalf("invalid profile JSON: %v", err)
+	}
+	blocked := map[string]bool{}
+	cloneRules := 0
+	for _, rule := range profile.Syscalls {
+		for _, name := range rule.Names {
+			if name == "clone" {
+				cloneRules++
+				continue
+			}
+			blocked[name] = true
+		}
+	}
+	for _, name := range []string{"mount", "ptrace", "unshare", "setns", "bpf", "io_uring_setup", "keyctl", "clone3"} {
+		if !blocked[name] {
+			t.Fatalf("expected %s to be blocked", name)
+		}
+	}
+	if cloneRules != len(cloneNamespaceFlags) {
+		t.Fatalf("expected one clone rule per namespace flag, got %d", cloneRules)
+	}
+}
+
+func TestParseEffectiveSecurityReadsInspectedHostConfig(t *testing.T) {
+	strict, _ := StrictSeccompProfile()
+	var compact bytes.Buffer
+	_ = json.Compact(&compact, strict)
+	hostConfig, _ := json.Marshal(map[string]any{
+		"CapDrop":        []string{"ALL"},
+		"CapAdd":         nil,
+		"SecurityOpt":    []string{"no-new-privileges", "apparmor=docker-default", "seccomp=" + compact.String()},
+		"ReadonlyRootfs": true,
+		"Tmpfs":          map[string]string{"/var/tmp": "rw,noexec", "/tmp": "rw,noexec"},
+		"Binds":          []string{"/src/app:/workspace", "/etc/certs:/certs:ro"},
+		"PidsLimit":      128,
+		"NetworkMode":    "none",
+	})
+
+	settings, err := parseEffectiveSecurity(hostConfig, ProfileStrict)
+	if err != nil {
+		t.Fatalf("parseEffectiveSecurity() error: %v", err)
+	}
+	want := ResolveSecuritySettings(ContainerConfig{ProjectPath: "/src/app"})
+	if !reflect.DeepEqual(settings, want) {
+		t.Fatalf("effective settings = %+v\nwant %+v", settings, want)
+	}
+
+	loose, _ := parseEffectiveSecurity([]byte(`{"SecurityOpt":["seccomp=unconfined"]}`), ProfileStandard)
+	if loose.Seccomp != "unconfined" || loose.NoNewPrivileges || loose.ReadOnlyRootfs {
+		t.Fatalf("expected unconfined settings to be reported as such, got %+v", loose)
+	}
+}
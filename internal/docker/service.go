@@ -2,6 +2,7 @@ package docker
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"os/exec"
@@ -14,7 +15,9 @@ import (
 )
 
 // Service implementa operações Docker para sessões sandbox.
-type Service struct{}
+type Service struct {
+	profileDir string // onde o perfil seccomp strict é gravado (default: diretório temporário)
+}
 
 func NewService() *Service {
 	return &Service{}
@@ -29,6 +32,26 @@ func (s *Service) IsDockerAvailable() bool {
 	return cmd.Run() == nil
 }
 
+// Ping consulta o daemon e retorna a versão do servidor Docker.
+func (s *Service) Ping(ctx context.Context) (string, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return "", fmt.Errorf("docker CLI not found")
+	}
+	out, err := exec.CommandContext(ctx, "docker", "info", "--format", "{{.ServerVersion}}").CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return "", fmt.Errorf("docker daemon unavailable: %s", msg)
+		}
+		return "", fmt.Errorf("docker daemon unavailable: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// SetProfileDir define o diretório onde os perfis de segurança são gravados.
+func (s *Service) SetProfileDir(dir string) {
+	s.profileDir = dir
+}
+
 func (s *Service) ImageExists(imageName string) bool {
 	cmd := exec.Command("docker", "image", "inspect", imageName)
 	return cmd.Run() == nil
@@ -48,7 +71,16 @@ func (s *Service) CreateContainer(config ContainerConfig) (string, error) {
 		image = tag
 	}
 
-	args := s.buildRunArgs(cfg, image)
+	seccompPath := ""
+	if cfg.SecurityProfile == ProfileStrict {
+		path, err := writeStrictSeccompProfile(s.profileDir)
+		if err != nil {
+			return "", err
+		}
+		seccompPath = path
+	}
+
+	args := s.buildRunArgs(cfg, image, seccompPath)
 	cmd := exec.Command("docker", args...)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
@@ -158,6 +190,11 @@ func withDefaults(cfg ContainerConfig) ContainerConfig {
 	if cfg.ProjectPath != "" {
 		cfg.ProjectPath = filepath.Clean(cfg.ProjectPath)
 	}
+	if profile, err := NormalizeSecurityProfile(string(cfg.SecurityProfile)); err == nil {
+		cfg.SecurityProfile = profile
+	} else {
+		cfg.SecurityProfile = ProfileStrict
+	}
 	return cfg
 }
 
@@ -175,7 +212,7 @@ func shortID() string {
 }
 
 // buildRunArgs monta os argumentos de criação com isolamento de segurança.
-func (s *Service) buildRunArgs(config ContainerConfig, image string) []string {
+func (s *Service) buildRunArgs(config ContainerConfig, image string, seccompPath string) []string {
 	name := "orch-session-" + shortID()
 
 	args := []string{
@@ -184,9 +221,8 @@ func (s *Service) buildRunArgs(config ContainerConfig, image string) []string {
 		"--name", name,
 		"--memory", config.Memory,
 		"--cpus", config.CPUs,
-		"--pids-limit", "256",
-		"--security-opt", "no-new-privileges",
 	}
+	args = append(args, securityRunArgs(config, seccompPath)...)
 
 	if config.ProjectPath != "" {
 		args = append(args, "-v", fmt.Sprintf("%s:/workspace", config.ProjectPath))
@@ -207,14 +243,6 @@ func (s *Service) buildRunArgs(config ContainerConfig, image string) []string {
 		args = append(args, "-p", port)
 	}
 
-	if config.ReadOnly {
-		args = append(args,
-			"--read-only",
-			"--tmpfs", "/tmp:rw,noexec,nosuid,size=512m",
-			"--tmpfs", "/var/tmp:rw,noexec,nosuid,size=256m",
-		)
-	}
-
 	if config.NetworkMode != "" {
 		args = append(args, "--network", config.NetworkMode)
 	}
@@ -223,6 +251,16 @@ func (s *Service) buildRunArgs(config ContainerConfig, image string) []string {
 	return args
 }
 
+// InspectSecurity lê do Docker o isolamento efetivo do container.
+func (s *Service) InspectSecurity(containerID string, profile SecurityProfile) (SecuritySettings, error) {
+	cmd := exec.Command("docker", "inspect", "--format", "{{json .HostConfig}}", containerID)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return SecuritySettings{}, fmt.Errorf("docker inspect failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return parseEffectiveSecurity(out, profile)
+}
+
 // WaitUntilRunning aguarda o container ficar running por até timeout.
 func (s *Service) WaitUntilRunning(containerID string, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
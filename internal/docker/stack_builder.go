@@ -16,6 +16,7 @@ import (
 
 // StackConfig define as ferramentas a serem instaladas na imagem base.
 type StackConfig struct {
+	Name      string            `json:"name,omitempty"` // Nome lógico da stack (vazio = default)
 	ImageName string            `json:"imageName"`
 	Tools     map[string]string `json:"tools"` // Mapa de ferramenta -> versão (ex: "node": "20")
 }
@@ -174,7 +175,13 @@ func (s *Service) BuildStackImage(ctx context.Context, cfg StackConfig, logFn fu
 	}
 
 	// 4. Executar docker build
-	imageTag := "orch-custom-stack:latest"
+	stackName, err := NormalizeStackName(cfg.Name)
+	if err != nil {
+		return err
+	}
+	cfg.Name = stackName
+
+	imageTag := StackImageTag(stackName)
 	if cfg.ImageName != "" {
 		imageTag = cfg.ImageName
 	} else {
@@ -184,7 +191,7 @@ func (s *Service) BuildStackImage(ctx context.Context, cfg StackConfig, logFn fu
 	logFn(fmt.Sprintf("Iniciando build da imagem: %s", imageTag))
 	logFn("Contexto: " + tmpDir)
 
-	cmd := exec.CommandContext(ctx, "docker", "build", "-t", imageTag, ".")
+	cmd := exec.CommandContext(ctx, "docker", "build", "--label", StackImageLabel+"="+stackName, "-t", imageTag, ".")
 	cmd.Dir = tmpDir
 
 	stdoutPipe, err := cmd.StdoutPipe()
@@ -246,6 +253,14 @@ func (s *Service) BuildStackImage(ctx context.Context, cfg StackConfig, logFn fu
 }
 
 func (s *Service) saveStackConfig(cfg StackConfig) error {
+	if err := s.saveNamedStackConfig(cfg); err != nil {
+		return err
+	}
+	if cfg.Name != DefaultStackName {
+		return nil
+	}
+
+	// Stack default continua espelhada no stack.json legado.
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return err
@@ -0,0 +1,216 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"orch/internal/config"
+)
+
+const (
+	// DefaultStackName é o nome lógico da stack legada (orch-custom-stack).
+	DefaultStackName = "default"
+
+	// DefaultStackImage é a tag histórica gerada pelo Stack Builder.
+	DefaultStackImage = "orch-custom-stack:latest"
+
+	stackImageRepoPrefix = "orch-stack-"
+)
+
+var stackNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,62}$`)
+
+// StackImageInfo resume uma stack nomeada para listagem no frontend.
+type StackImageInfo struct {
+	Name      string            `json:"name"`
+	ImageName string            `json:"imageName"`
+	Tools     map[string]string `json:"tools"`
+	Built     bool              `json:"built"`
+	UpdatedAt int64             `json:"updatedAt"` // unix seconds
+}
+
+// NormalizeStackName valida e normaliza o nome de uma stack.
+// Nome vazio resolve para a stack default.
+func NormalizeStackName(name string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if normalized == "" {
+		return DefaultStackName, nil
+	}
+	if !stackNameRegex.MatchString(normalized) {
+		return "", fmt.Errorf("invalid stack name %q: use lowercase letters, digits, '.', '_' or '-'", name)
+	}
+	return normalized, nil
+}
+
+// StackImageTag retorna a tag Docker usada pela stack informada.
+func StackImageTag(name string) string {
+	if name == "" || name == DefaultStackName {
+		return DefaultStackImage
+	}
+	return stackImageRepoPrefix + name + ":latest"
+}
+
+// IsStackImage indica se a imagem foi gerada pelo Stack Builder.
+func IsStackImage(image string) bool {
+	image = strings.TrimSpace(image)
+	return image == DefaultStackImage || strings.HasPrefix(image, stackImageRepoPrefix)
+}
+
+func stacksDir() string {
+	return filepath.Join(config.DataDir(), "stacks")
+}
+
+func stackConfigPath(name string) string {
+	return filepath.Join(stacksDir(), name+".json")
+}
+
+// LoadNamedStackConfig carrega a configuração salva de uma stack nomeada.
+func (s *Service) LoadNamedStackConfig(name string) (StackConfig, error) {
+	normalized, err := NormalizeStackName(name)
+	if err != nil {
+		return StackConfig{}, err
+	}
+
+	data, err := os.ReadFile(stackConfigPath(normalized))
+	if os.IsNotExist(err) {
+		if normalized == DefaultStackName {
+			// Fallback para o stack.json legado.
+			return s.LoadStackConfig()
+		}
+		return StackConfig{}, nil
+	}
+	if err != nil {
+		return StackConfig{}, err
+	}
+
+	var cfg StackConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return StackConfig{}, err
+	}
+	return cfg, nil
+}
+
+// ListStackImages lista as stacks conhecidas, incluindo a default legada.
+func (s *Service) ListStackImages() ([]StackImageInfo, error) {
+	byName := make(map[string]StackImageInfo)
+
+	if legacy, err := s.LoadStackConfig(); err == nil && len(legacy.Tools) > 0 {
+		info := StackImageInfo{
+			Name:      DefaultStackName,
+			ImageName: DefaultStackImage,
+			Tools:     legacy.Tools,
+		}
+		if stat, statErr := os.Stat(filepath.Join(config.DataDir(), "stack.json")); statErr == nil {
+			info.UpdatedAt = stat.ModTime().Unix()
+		}
+		byName[DefaultStackName] = info
+	}
+
+	entries, err := os.ReadDir(stacksDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		if _, nameErr := NormalizeStackName(name); nameErr != nil {
+			continue
+		}
+
+		cfg, loadErr := s.LoadNamedStackConfig(name)
+		if loadErr != nil {
+			continue
+		}
+
+		info := StackImageInfo{
+			Name:      name,
+			ImageName: cfg.ImageName,
+			Tools:     cfg.Tools,
+		}
+		if info.ImageName == "" {
+			info.ImageName = StackImageTag(name)
+		}
+		if stat, statErr := entry.Info(); statErr == nil {
+			info.UpdatedAt = stat.ModTime().Unix()
+		}
+		byName[name] = info
+	}
+
+	stacks := make([]StackImageInfo, 0, len(byName))
+	for _, info := range byName {
+		if info.Tools == nil {
+			info.Tools = map[string]string{}
+		}
+		info.Built = s.ImageExists(info.ImageName)
+		stacks = append(stacks, info)
+	}
+
+	sort.Slice(stacks, func(i, j int) bool {
+		if stacks[i].Name == DefaultStackName {
+			return true
+		}
+		if stacks[j].Name == DefaultStackName {
+			return false
+		}
+		return stacks[i].Name < stacks[j].Name
+	})
+
+	return stacks, nil
+}
+
+// DeleteStackImage remove a imagem Docker e a configuração salva da stack.
+func (s *Service) DeleteStackImage(name string) error {
+	normalized, err := NormalizeStackName(name)
+	if err != nil {
+		return err
+	}
+
+	image := StackImageTag(normalized)
+	if cfg, loadErr := s.LoadNamedStackConfig(normalized); loadErr == nil && cfg.ImageName != "" {
+		image = cfg.ImageName
+	}
+
+	if s.ImageExists(image) {
+		cmd := exec.Command("docker", "image", "rm", image)
+		if out, rmErr := cmd.CombinedOutput(); rmErr != nil {
+			return fmt.Errorf("docker image rm failed: %w: %s", rmErr, strings.TrimSpace(string(out)))
+		}
+	}
+
+	if err := os.Remove(stackConfigPath(normalized)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if normalized == DefaultStackName {
+		legacyPath := filepath.Join(config.DataDir(), "stack.json")
+		if err := os.Remove(legacyPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) saveNamedStackConfig(cfg StackConfig) error {
+	name, err := NormalizeStackName(cfg.Name)
+	if err != nil {
+		return err
+	}
+	cfg.Name = name
+
+	if err := os.MkdirAll(stacksDir(), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(stackConfigPath(name), data, 0644)
+}
@@ -0,0 +1,69 @@
+package docker
+
+import "testing"
+
+func TestNormalizeStackName(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: DefaultStackName},
+		{input: "  Node-20 ", want: "node-20"},
+		{input: "py3.12_data", want: "py3.12_data"},
+		{input: "-leading", wantErr: true},
+		{input: "has space", wantErr: true},
+		{input: "../escape", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := NormalizeStackName(tc.input)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("NormalizeStackName(%q) expected error, got %q", tc.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("NormalizeStackName(%q) unexpected error: %v", tc.input, err)
+		}
+		if got != tc.want {
+			t.Fatalf("NormalizeStackName(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestStackImageTagKeepsLegacyDefault(t *testing.T) {
+	if got := StackImageTag(DefaultStackName); got != DefaultStackImage {
+		t.Fatalf("default stack tag = %q, want %q", got, DefaultStackImage)
+	}
+	if got := StackImageTag("node-20"); got != "orch-stack-node-20:latest" {
+		t.Fatalf("named stack tag = %q", got)
+	}
+	if !IsStackImage("orch-stack-node-20:latest") || !IsStackImage(DefaultStackImage) {
+		t.Fatalf("expected stack images to be recognized")
+	}
+	if IsStackImage("node:20-alpine") {
+		t.Fatalf("unexpected stack image match for public image")
+	}
+}
+
+func TestParseImageUsageKeepsOnlyOrchImages(t *testing.T) {
+	refs := map[string]string{
+		"sha256:aaa": "orch-stack-node:latest",
+		"sha256:bbb": "",
+		"sha256:ccc": "",
+	}
+	raw := "sha256:aaa|1048576|node\nsha256:bbb|2048|node\nsha256:ccc|4096|\nsha256:ddd|oops|x\n"
+
+	images := parseImageUsage(raw, refs)
+	if len(images) != 2 {
+		t.Fatalf("expected tagged stack + labeled dangling image, got %+v", images)
+	}
+	if images[0].Reference != "orch-stack-node:latest" || images[0].Dangling || images[0].Bytes != 1048576 {
+		t.Fatalf("unexpected tagged image: %+v", images[0])
+	}
+	if !images[1].Dangling || images[1].Stack != "node" || images[1].Bytes != 2048 {
+		t.Fatalf("unexpected dangling image: %+v", images[1])
+	}
+}
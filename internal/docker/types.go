@@ -29,6 +29,8 @@ type ContainerConfig struct {
 	EnvVars     []string `json:"envVars,omitempty"`
 	ReadOnly    bool     `json:"readOnly"`
 	NetworkMode string   `json:"networkMode"`
+	// SecurityProfile seleciona seccomp/capabilities (strict quando vazio).
+	SecurityProfile SecurityProfile `json:"securityProfile"`
 }
 
 // ContainerInfo representa dados resumidos de um container.
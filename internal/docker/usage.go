@@ -0,0 +1,111 @@
+package docker
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// StackImageLabel marca as imagens geradas pelo Stack Builder; sobrevive quando um rebuild
+// deixa a versão anterior sem tag (dangling).
+const StackImageLabel = "orch.stack"
+
+// ImageUsage é uma imagem criada pelo ORCH e o espaço que ocupa.
+type ImageUsage struct {
+	ID        string `json:"id"`
+	Reference string `json:"reference,omitempty"` // repo:tag (vazio quando dangling)
+	Stack     string `json:"stack,omitempty"`
+	Bytes     int64  `json:"bytes"`
+	Dangling  bool   `json:"dangling"`
+}
+
+// ListOrchImages lista as imagens de stack (com tag ou dangling) com o tamanho real.
+func (s *Service) ListOrchImages() ([]ImageUsage, error) {
+	out, err := exec.Command("docker", "images", "--no-trunc", "--format", "{{.ID}}|{{.Repository}}:{{.Tag}}").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("docker images failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	refs := make(map[string]string)
+	ids := make([]string, 0)
+	for _, line := range strings.Split(string(out), "\n") {
+		id, ref, ok := strings.Cut(strings.TrimSpace(line), "|")
+		if !ok {
+			continue
+		}
+		if ref == "<none>:<none>" {
+			ref = ""
+		} else if !IsStackImage(ref) {
+			continue
+		}
+		if _, seen := refs[id]; !seen {
+			ids = append(ids, id)
+		}
+		if refs[id] == "" {
+			refs[id] = ref
+		}
+	}
+	if len(ids) == 0 {
+		return []ImageUsage{}, nil
+	}
+
+	args := append([]string{"image", "inspect", "--format", `{{.Id}}|{{.Size}}|{{index .Config.Labels "` + StackImageLabel + `"}}`}, ids...)
+	out, err = exec.Command("docker", args...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("docker image inspect failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return parseImageUsage(string(out), refs), nil
+}
+
+// PruneDanglingStackImages remove as imagens de stack sem tag e devolve quantas e quanto liberou.
+func (s *Service) PruneDanglingStackImages() (int, int64, error) {
+	images, err := s.ListOrchImages()
+	if err != nil {
+		return 0, 0, err
+	}
+	removed, freed := 0, int64(0)
+	for _, image := range images {
+		if !image.Dangling {
+			continue
+		}
+		if err := runDocker("image", "rm", image.ID); err != nil {
+			// Imagem ainda usada por um container: fica para a próxima limpeza.
+			continue
+		}
+		removed++
+		freed += image.Bytes
+	}
+	return removed, freed, nil
+}
+
+// parseImageUsage lê a saída "id|size|label" do inspect. Imagens sem tag só contam se tiverem o
+// label do Stack Builder, para não tocar em imagens dangling de outras ferramentas.
+func parseImageUsage(raw string, refs map[string]string) []ImageUsage {
+	images := make([]ImageUsage, 0)
+	scanner := bufio.NewScanner(strings.NewReader(raw))
+	for scanner.Scan() {
+		parts := strings.SplitN(strings.TrimSpace(scanner.Text()), "|", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		size, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		ref := refs[parts[0]]
+		stack := strings.TrimSpace(parts[2])
+		if ref == "" && stack == "" {
+			continue
+		}
+		images = append(images, ImageUsage{
+			ID:        parts[0],
+			Reference: ref,
+			Stack:     stack,
+			Bytes:     size,
+			Dangling:  ref == "",
+		})
+	}
+	return images
+}
@@ -0,0 +1,250 @@
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Famílias de editores; cada uma tem uma sintaxe própria para "abrir na linha".
+const (
+	KindVSCode    = "vscode"
+	KindJetBrains = "jetbrains"
+	KindSublime   = "sublime"
+	KindVim       = "vim"
+)
+
+var (
+	ErrUnknownEditor  = errors.New("unknown editor")
+	ErrEditorNotFound = errors.New("editor not installed")
+	ErrNoEditor       = errors.New("no supported editor found")
+)
+
+// Editor é um editor externo suportado. Editores de terminal (vim) abrem numa sessão
+// de terminal integrada em vez de um processo solto.
+type Editor struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	Command   string `json:"command,omitempty"` // executável resolvido; vazio se não instalado
+	Terminal  bool   `json:"terminal"`
+	Available bool   `json:"available"`
+}
+
+type definition struct {
+	id       string
+	name     string
+	kind     string
+	binaries []string
+	// macApp é o caminho do CLI dentro do bundle em /Applications (GUIs no macOS raramente estão no PATH).
+	macApp string
+}
+
+// definitions em ordem de preferência da detecção automática.
+var definitions = []definition{
+	{id: "code", name: "Visual Studio Code", kind: KindVSCode, binaries: []string{"code"}, macApp: "Visual Studio Code.app/Contents/Resources/app/bin/code"},
+	{id: "cursor", name: "Cursor", kind: KindVSCode, binaries: []string{"cursor"}, macApp: "Cursor.app/Contents/Resources/app/bin/cursor"},
+	{id: "code-insiders", name: "VS Code Insiders", kind: KindVSCode, binaries: []string{"code-insiders"}, macApp: "Visual Studio Code - Insiders.app/Contents/Resources/app/bin/code"},
+	{id: "codium", name: "VSCodium", kind: KindVSCode, binaries: []string{"codium"}, macApp: "VSCodium.app/Contents/Resources/app/bin/codium"},
+	{id: "idea", name: "IntelliJ IDEA", kind: KindJetBrains, binaries: []string{"idea", "idea.sh"}, macApp: "IntelliJ IDEA.app/Contents/MacOS/idea"},
+	{id: "goland", name: "GoLand", kind: KindJetBrains, binaries: []string{"goland", "goland.sh"}, macApp: "GoLand.app/Contents/MacOS/goland"},
+	{id: "webstorm", name: "WebStorm", kind: KindJetBrains, binaries: []string{"webstorm", "webstorm.sh"}, macApp: "WebStorm.app/Contents/MacOS/webstorm"},
+	{id: "pycharm", name: "PyCharm", kind: KindJetBrains, binaries: []string{"pycharm", "pycharm.sh", "charm"}, macApp: "PyCharm.app/Contents/MacOS/pycharm"},
+	{id: "phpstorm", name: "PhpStorm", kind: KindJetBrains, binaries: []string{"phpstorm", "phpstorm.sh"}, macApp: "PhpStorm.app/Contents/MacOS/phpstorm"},
+	{id: "rubymine", name: "RubyMine", kind: KindJetBrains, binaries: []string{"rubymine", "rubymine.sh"}, macApp: "RubyMine.app/Contents/MacOS/rubymine"},
+	{id: "clion", name: "CLion", kind: KindJetBrains, binaries: []string{"clion", "clion.sh"}, macApp: "CLion.app/Contents/MacOS/clion"},
+	{id: "rider", name: "Rider", kind: KindJetBrains, binaries: []string{"rider", "rider.sh"}, macApp: "Rider.app/Contents/MacOS/rider"},
+	{id: "subl", name: "Sublime Text", kind: KindSublime, binaries: []string{"subl"}, macApp: "Sublime Text.app/Contents/SharedSupport/bin/subl"},
+	{id: "nvim", name: "Neovim", kind: KindVim, binaries: []string{"nvim"}},
+	{id: "vim", name: "Vim", kind: KindVim, binaries: []string{"vim", "vi"}},
+}
+
+// lookPath é substituível nos testes.
+var lookPath = defaultLookPath
+
+// IsKnown indica se o id corresponde a um editor suportado ("" = detecção automática).
+func IsKnown(id string) bool {
+	_, ok := findDefinition(id)
+	return ok || strings.TrimSpace(id) == ""
+}
+
+// List retorna todos os editores suportados, marcando os instalados.
+func List() []Editor {
+	editors := make([]Editor, 0, len(definitions))
+	for _, def := range definitions {
+		editors = append(editors, detect(def))
+	}
+	return editors
+}
+
+// Resolve retorna o editor preferido; com id vazio usa o primeiro instalado
+// (respeitando $VISUAL/$EDITOR quando apontam para um editor suportado).
+func Resolve(id string) (Editor, error) {
+	id = strings.TrimSpace(id)
+	if id != "" {
+		def, ok := findDefinition(id)
+		if !ok {
+			return Editor{}, fmt.Errorf("%w: %s", ErrUnknownEditor, id)
+		}
+		ed := detect(def)
+		if !ed.Available {
+			return Editor{}, fmt.Errorf("%w: %s", ErrEditorNotFound, ed.Name)
+		}
+		return ed, nil
+	}
+
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		fields := strings.Fields(os.Getenv(env))
+		if len(fields) == 0 {
+			continue
+		}
+		if def, ok := definitionForBinary(filepath.Base(fields[0])); ok {
+			if ed := detect(def); ed.Available {
+				return ed, nil
+			}
+		}
+	}
+	for _, def := range definitions {
+		if ed := detect(def); ed.Available {
+			return ed, nil
+		}
+	}
+	return Editor{}, ErrNoEditor
+}
+
+// Args monta os argumentos para abrir file na linha/coluna (1-based; 0 = sem posição).
+func (e Editor) Args(file string, line, column int) []string {
+	line, column = max(line, 0), max(column, 0)
+	if line == 0 {
+		column = 0
+	}
+	switch e.Kind {
+	case KindVSCode:
+		if line == 0 {
+			return []string{"--reuse-window", file}
+		}
+		return []string{"--reuse-window", "--goto", positionSuffix(file, line, column)}
+	case KindJetBrains:
+		args := make([]string, 0, 5)
+		if line > 0 {
+			args = append(args, "--line", strconv.Itoa(line))
+		}
+		if column > 0 {
+			args = append(args, "--column", strconv.Itoa(column))
+		}
+		return append(args, file)
+	case KindSublime:
+		return []string{positionSuffix(file, line, column)}
+	case KindVim:
+		if line == 0 {
+			return []string{file}
+		}
+		return []string{fmt.Sprintf("+call cursor(%d, %d)", line, max(column, 1)), file}
+	default:
+		return []string{file}
+	}
+}
+
+// Start abre um editor gráfico sem esperar ele fechar. Editores de terminal devem
+// ser abertos numa sessão de terminal com Command + Args.
+func Start(e Editor, file string, line, column int, dir string) error {
+	if e.Terminal {
+		return fmt.Errorf("%s runs in a terminal session", e.Name)
+	}
+	if e.Command == "" {
+		return fmt.Errorf("%w: %s", ErrEditorNotFound, e.Name)
+	}
+	cmd := exec.Command(e.Command, e.Args(file, line, column)...)
+	cmd.Dir = dir
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", e.Name, err)
+	}
+	// Os CLIs (code, idea, subl) costumam repassar para a instância aberta e sair; só evita zumbis.
+	go cmd.Wait()
+	return nil
+}
+
+func positionSuffix(file string, line, column int) string {
+	switch {
+	case line == 0:
+		return file
+	case column == 0:
+		return fmt.Sprintf("%s:%d", file, line)
+	default:
+		return fmt.Sprintf("%s:%d:%d", file, line, column)
+	}
+}
+
+func detect(def definition) Editor {
+	ed := Editor{ID: def.id, Name: def.name, Kind: def.kind, Terminal: def.kind == KindVim}
+	for _, binary := range def.binaries {
+		if resolved, ok := lookPath(binary, def.macApp); ok {
+			ed.Command, ed.Available = resolved, true
+			break
+		}
+	}
+	return ed
+}
+
+func findDefinition(id string) (definition, bool) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	for _, def := range definitions {
+		if def.id == id {
+			return def, true
+		}
+	}
+	return definition{}, false
+}
+
+func definitionForBinary(binary string) (definition, bool) {
+	for _, def := range definitions {
+		for _, candidate := range def.binaries {
+			if candidate == binary {
+				return def, true
+			}
+		}
+	}
+	return definition{}, false
+}
+
+// defaultLookPath procura no PATH e nos diretórios onde instaladores costumam colocar os CLIs
+// (o app iniciado pelo Finder/dock não herda o PATH do shell).
+func defaultLookPath(binary, macApp string) (string, bool) {
+	if resolved, err := exec.LookPath(binary); err == nil {
+		return resolved, true
+	}
+	home, _ := os.UserHomeDir()
+	var candidates []string
+	switch runtime.GOOS {
+	case "darwin":
+		if macApp != "" {
+			candidates = append(candidates, filepath.Join("/Applications", macApp))
+			if home != "" {
+				candidates = append(candidates, filepath.Join(home, "Applications", macApp))
+			}
+		}
+		candidates = append(candidates, filepath.Join("/opt/homebrew/bin", binary), filepath.Join("/usr/local/bin", binary))
+		if home != "" {
+			candidates = append(candidates, filepath.Join(home, "Library", "Application Support", "JetBrains", "Toolbox", "scripts", binary))
+		}
+	case "linux":
+		candidates = append(candidates, filepath.Join("/usr/local/bin", binary), filepath.Join("/snap/bin", binary))
+		if home != "" {
+			candidates = append(candidates,
+				filepath.Join(home, ".local", "bin", binary),
+				filepath.Join(home, ".local", "share", "JetBrains", "Toolbox", "scripts", binary),
+			)
+		}
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() && info.Mode()&0o111 != 0 {
+			return candidate, true
+		}
+	}
+	return "", false
+}
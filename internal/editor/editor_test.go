@@ -0,0 +1,87 @@
+package editor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func stubInstalled(t *testing.T, binaries ...string) {
+	t.Helper()
+	installed := make(map[string]bool, len(binaries))
+	for _, binary := range binaries {
+		installed[binary] = true
+	}
+	previous := lookPath
+	lookPath = func(binary, macApp string) (string, bool) {
+		if installed[binary] {
+			return "/usr/bin/" + binary, true
+		}
+		return "", false
+	}
+	t.Cleanup(func() { lookPath = previous })
+}
+
+func TestArgsOpenAtLinePerEditorFamily(t *testing.T) {
+	cases := []struct {
+		kind string
+		want []string
+	}{
+		{KindVSCode, []string{"--reuse-window", "--goto", "/repo/main.go:12:4"}},
+		{KindJetBrains, []string{"--line", "12", "--column", "4", "/repo/main.go"}},
+		{KindSublime, []string{"/repo/main.go:12:4"}},
+		{KindVim, []string{"+call cursor(12, 4)", "/repo/main.go"}},
+	}
+	for _, tc := range cases {
+		if got := (Editor{Kind: tc.kind}).Args("/repo/main.go", 12, 4); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s args = %q, want %q", tc.kind, got, tc.want)
+		}
+	}
+	if got := (Editor{Kind: KindVSCode}).Args("/repo/main.go", 0, 7); !reflect.DeepEqual(got, []string{"--reuse-window", "/repo/main.go"}) {
+		t.Fatalf("expected column without line to be ignored, got %q", got)
+	}
+	if got := (Editor{Kind: KindSublime}).Args("/repo/main.go", 3, 0); !reflect.DeepEqual(got, []string{"/repo/main.go:3"}) {
+		t.Fatalf("unexpected line-only args: %q", got)
+	}
+}
+
+func TestResolveDetectsInstalledEditors(t *testing.T) {
+	stubInstalled(t, "subl", "nvim")
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+
+	auto, err := Resolve("")
+	if err != nil || auto.ID != "subl" || auto.Command != "/usr/bin/subl" || auto.Terminal {
+		t.Fatalf("expected first installed editor, got %#v err=%v", auto, err)
+	}
+	t.Setenv("EDITOR", "nvim -u NONE")
+	if auto, err := Resolve(""); err != nil || auto.ID != "nvim" || !auto.Terminal {
+		t.Fatalf("expected $EDITOR to win auto-detection, got %#v err=%v", auto, err)
+	}
+
+	if _, err := Resolve("code"); !errors.Is(err, ErrEditorNotFound) {
+		t.Fatalf("expected missing editor error, got %v", err)
+	}
+	if _, err := Resolve("notepad"); !errors.Is(err, ErrUnknownEditor) {
+		t.Fatalf("expected unknown editor error, got %v", err)
+	}
+	if !IsKnown("") || !IsKnown("GoLand") || IsKnown("notepad") {
+		t.Fatalf("unexpected IsKnown results")
+	}
+
+	available := 0
+	for _, ed := range List() {
+		if ed.Available {
+			available++
+		}
+	}
+	if available != 2 {
+		t.Fatalf("expected 2 available editors, got %d", available)
+	}
+
+	stubInstalled(t)
+	t.Setenv("EDITOR", "")
+	if _, err := Resolve(""); !errors.Is(err, ErrNoEditor) {
+		t.Fatalf("expected no editor error, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package egress
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// maxAllowedHosts limita o tamanho da allow-list de uma sessão.
+const maxAllowedHosts = 100
+
+// NormalizeHosts valida e normaliza a allow-list: aceita "host", "host:porta", URLs (apenas o
+// host é usado) e curingas de subdomínio ("*.github.com"). Remove duplicados.
+func NormalizeHosts(hosts []string) ([]string, error) {
+	seen := make(map[string]bool)
+	normalized := make([]string, 0, len(hosts))
+	for _, raw := range hosts {
+		host := strings.ToLower(strings.TrimSpace(raw))
+		if host == "" {
+			continue
+		}
+		if strings.Contains(host, "://") {
+			parsed, err := url.Parse(host)
+			if err != nil || parsed.Host == "" {
+				return nil, fmt.Errorf("invalid allowed host %q", raw)
+			}
+			host = parsed.Host
+		}
+		host = strings.TrimSuffix(host, "/")
+
+		name := host
+		if h, port, err := net.SplitHostPort(host); err == nil {
+			if port == "" {
+				return nil, fmt.Errorf("invalid allowed host %q", raw)
+			}
+			name = h
+		}
+		if !validHostPattern(name) {
+			return nil, fmt.Errorf("invalid allowed host %q", raw)
+		}
+		if !seen[host] {
+			seen[host] = true
+			normalized = append(normalized, host)
+		}
+	}
+	if len(normalized) > maxAllowedHosts {
+		return nil, fmt.Errorf("allow-list cannot have more than %d hosts", maxAllowedHosts)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+func validHostPattern(name string) bool {
+	name = strings.TrimPrefix(name, "*.")
+	if name == "" || strings.ContainsAny(name, "*/ @") {
+		return false
+	}
+	if net.ParseIP(strings.Trim(name, "[]")) != nil {
+		return true
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowList decide se um destino host:porta está liberado.
+type AllowList struct {
+	entries []string
+}
+
+// NewAllowList cria a allow-list a partir de hosts já normalizados.
+func NewAllowList(hosts []string) *AllowList {
+	return &AllowList{entries: append([]string(nil), hosts...)}
+}
+
+// Allows verifica host:porta. Entradas sem porta liberam qualquer porta; "*.dominio" libera os
+// subdomínios mas não o próprio domínio.
+func (l *AllowList) Allows(target string) bool {
+	host, port, err := net.SplitHostPort(strings.ToLower(target))
+	if err != nil {
+		host, port = strings.ToLower(target), ""
+	}
+	host = strings.Trim(host, "[]")
+	for _, entry := range l.entries {
+		entryHost, entryPort := entry, ""
+		if h, p, err := net.SplitHostPort(entry); err == nil {
+			entryHost, entryPort = h, p
+		}
+		entryHost = strings.Trim(entryHost, "[]")
+		if entryPort != "" && entryPort != port {
+			continue
+		}
+		if suffix, wildcard := strings.CutPrefix(entryHost, "*."); wildcard {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == entryHost {
+			return true
+		}
+	}
+	return false
+}
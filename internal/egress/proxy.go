@@ -0,0 +1,180 @@
+package egress
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event registra uma requisição que passou pelo proxy.
+type Event struct {
+	Method  string    `json:"method"`
+	Host    string    `json:"host"` // host:porta de destino
+	Allowed bool      `json:"allowed"`
+	At      time.Time `json:"at"`
+}
+
+// Proxy é um proxy HTTP/CONNECT que só encaminha para hosts da allow-list. É a única saída de
+// containers em rede interna.
+type Proxy struct {
+	allow     *AllowList
+	dialer    *net.Dialer
+	transport *http.Transport
+	server    *http.Server
+
+	mu       sync.Mutex
+	observer func(Event)
+}
+
+// NewProxy cria o proxy com os hosts permitidos (ver NormalizeHosts).
+func NewProxy(hosts []string) *Proxy {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	return &Proxy{
+		allow:     NewAllowList(hosts),
+		dialer:    dialer,
+		transport: &http.Transport{Proxy: nil, DialContext: dialer.DialContext},
+	}
+}
+
+// OnRequest define quem recebe cada requisição (permitida ou não).
+func (p *Proxy) OnRequest(observer func(Event)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.observer = observer
+}
+
+// Start escuta em addr ("ip:0" escolhe a porta) e devolve o endereço efetivo.
+func (p *Proxy) Start(addr string) (string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("egress proxy listen %s: %w", addr, err)
+	}
+	p.server = &http.Server{
+		Handler:           p,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() { _ = p.server.Serve(listener) }()
+	return listener.Addr().String(), nil
+}
+
+// Close encerra o proxy e as conexões abertas.
+func (p *Proxy) Close() error {
+	if p.server == nil {
+		return nil
+	}
+	p.transport.CloseIdleConnections()
+	return p.server.Close()
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	target := r.Host
+	if r.Method != http.MethodConnect {
+		if r.URL == nil || !r.URL.IsAbs() {
+			http.Error(w, "this is a forward proxy: absolute URL required", http.StatusBadRequest)
+			return
+		}
+		target = r.URL.Host
+	}
+	target = withDefaultPort(target, r)
+
+	allowed := p.allow.Allows(target)
+	p.notify(Event{Method: r.Method, Host: target, Allowed: allowed, At: time.Now()})
+	if !allowed {
+		http.Error(w, fmt.Sprintf("host %s is not in the session network allow-list", target), http.StatusForbidden)
+		return
+	}
+
+	if r.Method == http.MethodConnect {
+		p.tunnel(w, r, target)
+		return
+	}
+	p.forward(w, r)
+}
+
+func (p *Proxy) notify(event Event) {
+	p.mu.Lock()
+	observer := p.observer
+	p.mu.Unlock()
+	if observer != nil {
+		observer(event)
+	}
+}
+
+func (p *Proxy) tunnel(w http.ResponseWriter, r *http.Request, target string) {
+	upstream, err := p.dialer.DialContext(r.Context(), "tcp", target)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		_ = upstream.Close()
+		http.Error(w, "connection hijacking not supported", http.StatusInternalServerError)
+		return
+	}
+	client, buffered, err := hijacker.Hijack()
+	if err != nil {
+		_ = upstream.Close()
+		return
+	}
+	if _, err := client.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n")); err != nil {
+		_ = client.Close()
+		_ = upstream.Close()
+		return
+	}
+
+	go func() {
+		// Bytes que o cliente já mandou junto com o CONNECT.
+		if n := buffered.Reader.Buffered(); n > 0 {
+			pending, _ := buffered.Reader.Peek(n)
+			_, _ = upstream.Write(pending)
+		}
+		_, _ = io.Copy(upstream, client)
+		_ = upstream.Close()
+	}()
+	_, _ = io.Copy(client, upstream)
+	_ = client.Close()
+}
+
+var hopHeaders = []string{"Connection", "Proxy-Connection", "Keep-Alive", "Proxy-Authenticate", "Proxy-Authorization", "Te", "Trailer", "Transfer-Encoding", "Upgrade"}
+
+func (p *Proxy) forward(w http.ResponseWriter, r *http.Request) {
+	outbound := r.Clone(r.Context())
+	outbound.RequestURI = ""
+	for _, header := range hopHeaders {
+		outbound.Header.Del(header)
+	}
+
+	resp, err := p.transport.RoundTrip(outbound)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	for _, header := range hopHeaders {
+		resp.Header.Del(header)
+	}
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+}
+
+func withDefaultPort(host string, r *http.Request) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return strings.ToLower(host)
+	}
+	port := "80"
+	if r.Method == http.MethodConnect || (r.URL != nil && r.URL.Scheme == "https") {
+		port = "443"
+	}
+	return net.JoinHostPort(strings.ToLower(host), port)
+}
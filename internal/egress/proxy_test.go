@@ -0,0 +1,110 @@
+package egress
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNormalizeHosts(t *testing.T) {
+	hosts, err := NormalizeHosts([]string{" GitHub.com ", "https://registry.npmjs.org/", "*.pypi.org", "github.com", "10.0.0.5:5432", ""})
+	if err != nil {
+		t.Fatalf("NormalizeHosts() error: %v", err)
+	}
+	want := []string{"*.pypi.org", "10.0.0.5:5432", "github.com", "registry.npmjs.org"}
+	if strings.Join(hosts, ",") != strings.Join(want, ",") {
+		t.Fatalf("NormalizeHosts() = %v, want %v", hosts, want)
+	}
+	for _, invalid := range []string{"*", "github.com/path", "user@host", "a..b"} {
+		if _, err := NormalizeHosts([]string{invalid}); err == nil {
+			t.Fatalf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestAllowListMatchesHostsPortsAndWildcards(t *testing.T) {
+	list := NewAllowList([]string{"github.com", "*.pypi.org", "10.0.0.5:5432"})
+	cases := map[string]bool{
+		"github.com:443":           true,
+		"api.github.com:443":       false,
+		"files.pypi.org:443":       true,
+		"pypi.org:443":             false,
+		"10.0.0.5:5432":            true,
+		"10.0.0.5:22":              false,
+		"evilgithub.com:443":       false,
+		"github.com.evil.test:443": false,
+	}
+	for target, want := range cases {
+		if got := list.Allows(target); got != want {
+			t.Fatalf("Allows(%q) = %v, want %v", target, got, want)
+		}
+	}
+}
+
+func TestProxyForwardsAllowedHostsAndReportsEveryRequest(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "hello from upstream")
+	}))
+	t.Cleanup(upstream.Close)
+	upstreamURL, _ := url.Parse(upstream.URL)
+	_, upstreamPort, _ := net.SplitHostPort(upstreamURL.Host)
+
+	proxy := NewProxy([]string{"127.0.0.1:" + upstreamPort})
+	var mu sync.Mutex
+	events := []Event{}
+	proxy.OnRequest(func(event Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	})
+	addr, err := proxy.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	t.Cleanup(func() { _ = proxy.Close() })
+
+	proxyURL, _ := url.Parse("http://" + addr)
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+
+	resp, err := client.Get(upstream.URL)
+	if err != nil {
+		t.Fatalf("GET through proxy error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "hello from upstream" {
+		t.Fatalf("unexpected proxied response %d %q", resp.StatusCode, body)
+	}
+
+	resp, err = client.Get("http://blocked.example.test/")
+	if err != nil {
+		t.Fatalf("GET blocked host error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected blocked host to get 403, got %d", resp.StatusCode)
+	}
+
+	// CONNECT para host fora da lista também é recusado.
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial proxy: %v", err)
+	}
+	defer conn.Close()
+	_, _ = io.WriteString(conn, "CONNECT blocked.example.test:443 HTTP/1.1\r\nHost: blocked.example.test:443\r\n\r\n")
+	status := make([]byte, 12)
+	if _, err := io.ReadFull(conn, status); err != nil || !strings.Contains(string(status), "403") {
+		t.Fatalf("expected CONNECT to be refused, got %q (%v)", status, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 3 || !events[0].Allowed || events[1].Allowed || events[2].Method != http.MethodConnect || events[2].Allowed {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+}
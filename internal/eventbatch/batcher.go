@@ -0,0 +1,141 @@
+package eventbatch
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWindow é a janela de agrupamento quando nenhuma é configurada.
+	DefaultWindow = 250 * time.Millisecond
+	// DefaultMaxSamples limita os payloads representativos guardados por lote.
+	DefaultMaxSamples = 5
+	// BatchSuffix é acrescentado ao nome do evento no payload agregado ("git:index:batch").
+	BatchSuffix = ":batch"
+)
+
+// Batch é o payload agregado de uma rajada do mesmo evento.
+type Batch struct {
+	Event   string        `json:"event"`
+	Count   int           `json:"count"`
+	Samples []interface{} `json:"samples"` // primeiros payloads + o mais recente
+	FirstAt time.Time     `json:"firstAt"`
+	LastAt  time.Time     `json:"lastAt"`
+}
+
+// Batcher agrupa rajadas de eventos: o primeiro evento de uma janela sai na hora, os seguintes
+// viram um único Batch emitido como "<evento>:batch" quando a janela fecha. Eventos prioritários
+// (ex.: conflitos) nunca são agrupados.
+type Batcher struct {
+	emit     func(eventName string, data interface{})
+	priority func(eventName string) bool
+
+	mu         sync.Mutex
+	window     time.Duration
+	maxSamples int
+	open       map[string]*pending
+	closed     bool
+}
+
+type pending struct {
+	batch *Batch // nil enquanto só o evento inicial passou
+	timer *time.Timer
+}
+
+// New cria o batcher. priority pode ser nil.
+func New(emit func(eventName string, data interface{}), priority func(eventName string) bool) *Batcher {
+	return &Batcher{
+		emit:       emit,
+		priority:   priority,
+		window:     DefaultWindow,
+		maxSamples: DefaultMaxSamples,
+		open:       make(map[string]*pending),
+	}
+}
+
+// SetWindow troca a janela; <= 0 desliga o agrupamento (todo evento sai na hora).
+func (b *Batcher) SetWindow(window time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.window = window
+}
+
+// Emit encaminha ou agrupa o evento.
+func (b *Batcher) Emit(eventName string, data interface{}) {
+	if b.priority != nil && b.priority(eventName) {
+		b.emit(eventName, data)
+		return
+	}
+
+	b.mu.Lock()
+	if b.closed || b.window <= 0 {
+		b.mu.Unlock()
+		b.emit(eventName, data)
+		return
+	}
+	current, exists := b.open[eventName]
+	if !exists {
+		window := b.window
+		b.open[eventName] = &pending{timer: time.AfterFunc(window, func() { b.flush(eventName) })}
+		b.mu.Unlock()
+		b.emit(eventName, data)
+		return
+	}
+
+	now := time.Now()
+	if current.batch == nil {
+		current.batch = &Batch{Event: eventName, FirstAt: now, Samples: make([]interface{}, 0, b.maxSamples)}
+	}
+	batch := current.batch
+	batch.Count++
+	batch.LastAt = now
+	if len(batch.Samples) < b.maxSamples {
+		batch.Samples = append(batch.Samples, data)
+	} else {
+		batch.Samples[len(batch.Samples)-1] = data
+	}
+	b.mu.Unlock()
+}
+
+// Flush emite todos os lotes pendentes agora.
+func (b *Batcher) Flush() {
+	b.mu.Lock()
+	names := make([]string, 0, len(b.open))
+	for name, current := range b.open {
+		current.timer.Stop()
+		names = append(names, name)
+	}
+	b.mu.Unlock()
+	for _, name := range names {
+		b.flush(name)
+	}
+}
+
+// Close emite o que está pendente e passa a encaminhar tudo sem agrupar.
+func (b *Batcher) Close() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	b.Flush()
+}
+
+func (b *Batcher) flush(eventName string) {
+	b.mu.Lock()
+	current, exists := b.open[eventName]
+	if !exists {
+		b.mu.Unlock()
+		return
+	}
+	delete(b.open, eventName)
+	batch := current.batch
+	b.mu.Unlock()
+
+	switch {
+	case batch == nil:
+	case batch.Count == 1:
+		// Um único evento atrasado sai com o formato original.
+		b.emit(eventName, batch.Samples[0])
+	default:
+		b.emit(eventName+BatchSuffix, *batch)
+	}
+}
@@ -0,0 +1,94 @@
+package eventbatch
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+	data   []interface{}
+}
+
+func (r *recorder) emit(eventName string, data interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, eventName)
+	r.data = append(r.data, data)
+}
+
+func (r *recorder) snapshot() ([]string, []interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...), append([]interface{}(nil), r.data...)
+}
+
+func TestBatcherCoalescesBurstsAndLetsPriorityThrough(t *testing.T) {
+	rec := &recorder{}
+	batcher := New(rec.emit, func(eventName string) bool { return eventName == "gitpanel:conflicts_changed" })
+	batcher.SetWindow(time.Hour) // só o Flush fecha a janela
+
+	for i := 0; i < 50; i++ {
+		batcher.Emit("git:index", i)
+	}
+	batcher.Emit("gitpanel:conflicts_changed", "conflict")
+	batcher.Emit("git:commit", "c1")
+	batcher.Emit("git:commit", "c2")
+
+	events, _ := rec.snapshot()
+	if len(events) != 3 || events[0] != "git:index" || events[1] != "gitpanel:conflicts_changed" || events[2] != "git:commit" {
+		t.Fatalf("expected leading events + priority event immediately, got %v", events)
+	}
+
+	batcher.Flush()
+	events, data := rec.snapshot()
+	got := map[string]interface{}{}
+	for i, name := range events[3:] {
+		got[name] = data[3+i]
+	}
+	batch, ok := got["git:index"+BatchSuffix].(Batch)
+	if !ok {
+		t.Fatalf("expected git:index batch, got %v", events)
+	}
+	if batch.Count != 49 || len(batch.Samples) != DefaultMaxSamples || batch.Samples[0] != 1 || batch.Samples[DefaultMaxSamples-1] != 49 {
+		t.Fatalf("unexpected batch: %+v", batch)
+	}
+	// Um único evento retido sai com o formato original.
+	if got["git:commit"] != "c2" {
+		t.Fatalf("expected single trailing commit event, got %v", got)
+	}
+}
+
+func TestBatcherWindowExpiresAndCanBeDisabled(t *testing.T) {
+	rec := &recorder{}
+	batcher := New(rec.emit, nil)
+	batcher.SetWindow(20 * time.Millisecond)
+
+	batcher.Emit("workspace:file_changed", "a")
+	batcher.Emit("workspace:file_changed", "b")
+	batcher.Emit("workspace:file_changed", "c")
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if events, _ := rec.snapshot(); len(events) == 2 {
+			if events[1] != "workspace:file_changed"+BatchSuffix {
+				t.Fatalf("unexpected trailing event %v", events)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			events, _ := rec.snapshot()
+			t.Fatalf("window did not flush, got %v", events)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	batcher.SetWindow(0)
+	batcher.Emit("workspace:file_changed", "d")
+	batcher.Emit("workspace:file_changed", "e")
+	if events, _ := rec.snapshot(); len(events) != 4 {
+		t.Fatalf("disabled batcher should forward every event, got %v", events)
+	}
+}
@@ -0,0 +1,337 @@
+package filesearch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxContentMatches limita os resultados de uma busca; acima disso ela é truncada.
+	MaxContentMatches  = 10000
+	maxMatchesPerLine  = 20
+	maxSearchFileSize  = 4 << 20 // arquivos maiores são pulados (bundles, dumps)
+	binarySniffBytes   = 8000
+	maxPreviewBytes    = 400
+	contentBatchSize   = 200
+	contentBatchWindow = 100 * time.Millisecond
+)
+
+var regexEscapes = regexp.MustCompile(`\\.`)
+
+// SearchContent procura a busca nos arquivos indexados do workspace (respeitando .gitignore)
+// e entrega os resultados em lotes por onBatch, sempre da mesma goroutine. Cancelar ctx
+// interrompe a busca e retorna ctx.Err() junto do resumo parcial.
+func (s *Service) SearchContent(ctx context.Context, workspaceID uint, root string, query ContentQuery, onBatch func([]ContentMatch)) (ContentSearchSummary, error) {
+	startedAt := time.Now()
+	var summary ContentSearchSummary
+
+	pattern, err := compileContentQuery(query)
+	if err != nil {
+		return summary, err
+	}
+	include, exclude, err := compileGlobs(query.Globs)
+	if err != nil {
+		return summary, err
+	}
+	idx, err := s.ensureIndex(workspaceID, root)
+	if err != nil {
+		return summary, err
+	}
+
+	idx.mu.RLock()
+	files := make([]string, 0, len(idx.files))
+	for file := range idx.files {
+		if globAllows(file, include, exclude) {
+			files = append(files, file)
+		}
+	}
+	idx.mu.RUnlock()
+	sort.Strings(files)
+
+	searchCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	paths := make(chan string)
+	results := make(chan []ContentMatch)
+	var workers sync.WaitGroup
+	for i := 0; i < min(runtime.NumCPU(), 8); i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for rel := range paths {
+				matches := searchFile(searchCtx, idx.root, rel, pattern)
+				select {
+				case results <- matches:
+				case <-searchCtx.Done():
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(results)
+		defer workers.Wait()
+		defer close(paths)
+		for _, rel := range files {
+			select {
+			case paths <- rel:
+			case <-searchCtx.Done():
+				return
+			}
+		}
+	}()
+
+	batch := make([]ContentMatch, 0, contentBatchSize)
+	flush := func() {
+		if len(batch) > 0 && onBatch != nil {
+			onBatch(batch)
+		}
+		batch = make([]ContentMatch, 0, contentBatchSize)
+	}
+	ticker := time.NewTicker(contentBatchWindow)
+	defer ticker.Stop()
+
+collect:
+	for {
+		select {
+		case matches, ok := <-results:
+			if !ok {
+				break collect
+			}
+			summary.SearchedFiles++
+			if len(matches) == 0 || summary.Truncated {
+				continue
+			}
+			if remaining := MaxContentMatches - summary.Matches; len(matches) >= remaining {
+				matches = matches[:remaining]
+				summary.Truncated = true
+				stop()
+			}
+			summary.MatchedFiles++
+			summary.Matches += len(matches)
+			batch = append(batch, matches...)
+			if len(batch) >= contentBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+	flush()
+	summary.DurationMs = time.Since(startedAt).Milliseconds()
+	return summary, ctx.Err()
+}
+
+// ValidateContentQuery confere busca e globs sem executar a busca.
+func ValidateContentQuery(query ContentQuery) error {
+	if _, err := compileContentQuery(query); err != nil {
+		return err
+	}
+	_, _, err := compileGlobs(query.Globs)
+	return err
+}
+
+// compileContentQuery monta a regexp da busca (texto literal é escapado).
+func compileContentQuery(query ContentQuery) (*regexp.Regexp, error) {
+	if strings.TrimSpace(query.Query) == "" {
+		return nil, fmt.Errorf("search query is empty")
+	}
+	pattern := query.Query
+	if !query.Regex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	literal := query.Query
+	if query.Regex {
+		literal = regexEscapes.ReplaceAllString(literal, "") // \S, \W etc. não contam para o smart case
+	}
+	if !strings.ContainsFunc(literal, unicode.IsUpper) {
+		pattern = "(?i)" + pattern
+	}
+	compiled, err := regexp.Compile("(?m)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression: %w", err)
+	}
+	return compiled, nil
+}
+
+// searchFile retorna as linhas do arquivo com ocorrências; binários e arquivos grandes são pulados.
+func searchFile(ctx context.Context, root, rel string, pattern *regexp.Regexp) []ContentMatch {
+	if ctx.Err() != nil {
+		return nil
+	}
+	full := filepath.Join(root, filepath.FromSlash(rel))
+	info, err := os.Stat(full)
+	if err != nil || !info.Mode().IsRegular() || info.Size() > maxSearchFileSize {
+		return nil
+	}
+	data, err := os.ReadFile(full)
+	if err != nil || bytes.IndexByte(data[:min(len(data), binarySniffBytes)], 0) >= 0 {
+		return nil
+	}
+	if !pattern.Match(data) {
+		return nil
+	}
+
+	var matches []ContentMatch
+	lineNumber := 0
+	for len(data) > 0 {
+		lineNumber++
+		line := data
+		if end := bytes.IndexByte(data, '\n'); end >= 0 {
+			line, data = data[:end], data[end+1:]
+		} else {
+			data = nil
+		}
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if match, ok := matchLine(rel, lineNumber, line, pattern); ok {
+			matches = append(matches, match)
+			if len(matches)%256 == 0 && ctx.Err() != nil {
+				return matches
+			}
+		}
+	}
+	return matches
+}
+
+func matchLine(rel string, lineNumber int, line []byte, pattern *regexp.Regexp) (ContentMatch, bool) {
+	var locs [][]int
+	for _, loc := range pattern.FindAllIndex(line, maxMatchesPerLine) {
+		if loc[0] < loc[1] { // matches vazios (ex: "a*") não contam
+			locs = append(locs, loc)
+		}
+	}
+	if len(locs) == 0 {
+		return ContentMatch{}, false
+	}
+
+	// Linhas longas (minificados) viram uma janela ao redor da primeira ocorrência.
+	start, end := 0, len(line)
+	if len(line) > maxPreviewBytes {
+		start = max(0, locs[0][0]-maxPreviewBytes/4)
+		for start > 0 && !utf8.RuneStart(line[start]) {
+			start--
+		}
+		end = min(len(line), start+maxPreviewBytes)
+		for end < len(line) && !utf8.RuneStart(line[end]) {
+			end++
+		}
+	}
+
+	match := ContentMatch{
+		Path:    rel,
+		Line:    lineNumber,
+		Column:  utf8.RuneCount(line[:locs[0][0]]) + 1,
+		Preview: string(line[start:end]),
+	}
+	for _, loc := range locs {
+		from, to := max(loc[0], start), min(loc[1], end)
+		if from >= to {
+			continue
+		}
+		offset := utf8.RuneCount(line[start:from])
+		match.Ranges = append(match.Ranges, MatchRange{Start: offset, End: offset + utf8.RuneCount(line[from:to])})
+	}
+	return match, true
+}
+
+// compileGlobs separa os padrões de inclusão e de exclusão ("!" no início).
+func compileGlobs(globs []string) (include, exclude []*regexp.Regexp, err error) {
+	for _, glob := range globs {
+		glob = strings.TrimSpace(glob)
+		if glob == "" {
+			continue
+		}
+		negated := strings.HasPrefix(glob, "!")
+		compiled, compileErr := compileGlob(strings.TrimPrefix(glob, "!"))
+		if compileErr != nil {
+			return nil, nil, fmt.Errorf("invalid glob %q: %w", glob, compileErr)
+		}
+		if negated {
+			exclude = append(exclude, compiled)
+		} else {
+			include = append(include, compiled)
+		}
+	}
+	return include, exclude, nil
+}
+
+// compileGlob converte um glob (*, **, ?, {a,b}, [abc]) em regexp. Sem "/" o padrão vale
+// para o nome do arquivo em qualquer diretório, como no ripgrep.
+func compileGlob(glob string) (*regexp.Regexp, error) {
+	glob = strings.TrimPrefix(filepath.ToSlash(glob), "./")
+	if !strings.Contains(glob, "/") {
+		glob = "**/" + glob
+	}
+	var b strings.Builder
+	b.WriteString("^")
+	inBraces := false
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case c == '*' && strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '{':
+			inBraces = true
+			b.WriteString("(?:")
+		case c == '}' && inBraces:
+			inBraces = false
+			b.WriteString(")")
+		case c == ',' && inBraces:
+			b.WriteString("|")
+		case c == '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class")
+			}
+			class := glob[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if inBraces {
+		return nil, fmt.Errorf("unterminated brace")
+	}
+	// Padrões que casam um diretório valem para tudo dentro dele.
+	b.WriteString("(?:/.*)?$")
+	return regexp.Compile(b.String())
+}
+
+func globAllows(rel string, include, exclude []*regexp.Regexp) bool {
+	for _, pattern := range exclude {
+		if pattern.MatchString(rel) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if pattern.MatchString(rel) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package filesearch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newContentSearchFixture(t *testing.T) (*Service, string) {
+	t.Helper()
+	root := t.TempDir()
+	writeSearchFiles(t, root, "cmd/main.go", "cmd/main_test.go", "docs/notes.md", "image.bin")
+	files := map[string]string{
+		"cmd/main.go":      "package main\n\nfunc main() {\n\tHandleRequest()\n\thandleRequest()\r\n}\n",
+		"cmd/main_test.go": "package main\n\n// handleRequest is covered here\n",
+		"docs/notes.md":    "Notes about HandleRequest\n" + strings.Repeat("x", 1000) + "handlerequest" + strings.Repeat("y", 1000) + "\n",
+		"image.bin":        "handleRequest\x00\x01",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, filepath.FromSlash(name)), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	svc := NewService()
+	svc.runGit = func(ctx context.Context, dir string, args ...string) (string, error) {
+		return "", errors.New("not a git repository")
+	}
+	return svc, root
+}
+
+func collectContentSearch(t *testing.T, svc *Service, root string, query ContentQuery) ([]ContentMatch, ContentSearchSummary) {
+	t.Helper()
+	var matches []ContentMatch
+	summary, err := svc.SearchContent(context.Background(), 1, root, query, func(batch []ContentMatch) {
+		matches = append(matches, batch...)
+	})
+	if err != nil {
+		t.Fatalf("SearchContent(%+v): %v", query, err)
+	}
+	return matches, summary
+}
+
+func TestSearchContentSmartCaseAndPreview(t *testing.T) {
+	svc, root := newContentSearchFixture(t)
+
+	matches, summary := collectContentSearch(t, svc, root, ContentQuery{Query: "handlerequest"})
+	if summary.Matches != 5 || summary.MatchedFiles != 3 || summary.SearchedFiles != 4 || summary.Truncated {
+		t.Fatalf("unexpected summary: %#v", summary)
+	}
+	var long ContentMatch
+	for _, match := range matches {
+		if match.Path == "cmd/main.go" && match.Line == 5 && (match.Column != 2 || match.Preview != "\thandleRequest()") {
+			t.Fatalf("unexpected match (CRLF must be trimmed): %#v", match)
+		}
+		if match.Path == "docs/notes.md" && match.Line == 2 {
+			long = match
+		}
+	}
+	if long.Column != 1001 || len(long.Preview) > maxPreviewBytes || len(long.Ranges) != 1 ||
+		long.Preview[long.Ranges[0].Start:long.Ranges[0].End] != "handlerequest" {
+		t.Fatalf("expected windowed preview around the match, got column=%d ranges=%v", long.Column, long.Ranges)
+	}
+
+	// Com maiúsculas a busca diferencia caixa.
+	if _, summary := collectContentSearch(t, svc, root, ContentQuery{Query: "HandleRequest"}); summary.Matches != 2 {
+		t.Fatalf("expected case-sensitive search, got %#v", summary)
+	}
+}
+
+func TestSearchContentRegexAndGlobs(t *testing.T) {
+	svc, root := newContentSearchFixture(t)
+
+	matches, _ := collectContentSearch(t, svc, root, ContentQuery{Query: `^\s+handle\w+\(`, Regex: true, Globs: []string{"*.go", "!*_test.go"}})
+	if len(matches) != 2 || matches[0].Path != "cmd/main.go" || matches[0].Ranges[0] != (MatchRange{Start: 0, End: 15}) {
+		t.Fatalf("unexpected regex matches: %#v", matches)
+	}
+	if _, summary := collectContentSearch(t, svc, root, ContentQuery{Query: "handle", Globs: []string{"docs"}}); summary.SearchedFiles != 1 {
+		t.Fatalf("expected directory glob to select docs/, got %#v", summary)
+	}
+
+	if _, err := svc.SearchContent(context.Background(), 1, root, ContentQuery{Query: "(", Regex: true}, nil); err == nil {
+		t.Fatalf("expected invalid regex to be rejected")
+	}
+	if _, err := svc.SearchContent(context.Background(), 1, root, ContentQuery{Query: "x", Globs: []string{"{a,b"}}, nil); err == nil {
+		t.Fatalf("expected invalid glob to be rejected")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := svc.SearchContent(ctx, 1, root, ContentQuery{Query: "handle"}, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancelled search to return context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package filesearch
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Pontuação do fuzzy match (no estilo do fzf): caracteres consecutivos e inícios de
+// segmento valem mais, lacunas custam pouco e casar só no nome do arquivo tem preferência.
+const (
+	scoreMatch       = 16
+	bonusConsecutive = 12
+	bonusBoundary    = 10
+	bonusBasename    = 40
+	bonusExactName   = 100
+	maxGapPenalty    = 12
+)
+
+// normalizeQuery prepara a busca: minúsculas, sem espaços e com "/" como separador.
+func normalizeQuery(query string) []rune {
+	query = strings.ReplaceAll(strings.ToLower(query), "\\", "/")
+	runes := make([]rune, 0, len(query))
+	for _, r := range query {
+		if !unicode.IsSpace(r) {
+			runes = append(runes, r)
+		}
+	}
+	return runes
+}
+
+// matchPath pontua o caminho contra a busca normalizada; positions são índices de rune no caminho.
+func matchPath(path string, query []rune) (score int, positions []int, ok bool) {
+	text := []rune(path)
+	base := 0
+	for i, r := range text {
+		if r == '/' {
+			base = i + 1
+		}
+	}
+	if score, positions, ok = matchRunes(text, base, query); ok {
+		score += bonusBasename
+		if strings.EqualFold(string(text[base:]), string(query)) {
+			score += bonusExactName
+		}
+		return score, positions, true
+	}
+	return matchRunes(text, 0, query)
+}
+
+// matchRunes encontra a ocorrência mais curta da busca como subsequência de text[from:]:
+// a ida acha o fim do primeiro match completo e a volta aperta o início.
+func matchRunes(text []rune, from int, query []rune) (int, []int, bool) {
+	if len(query) == 0 {
+		return 0, nil, true
+	}
+	qi, end := 0, -1
+	for i := from; i < len(text); i++ {
+		if unicode.ToLower(text[i]) == query[qi] {
+			qi++
+			if qi == len(query) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	positions := make([]int, len(query))
+	qi = len(query) - 1
+	for i := end; i >= from && qi >= 0; i-- {
+		if unicode.ToLower(text[i]) == query[qi] {
+			positions[qi] = i
+			qi--
+		}
+	}
+
+	score := 0
+	for k, pos := range positions {
+		score += scoreMatch
+		if isWordBoundary(text, pos) {
+			score += bonusBoundary
+		}
+		if k == 0 {
+			continue
+		}
+		if gap := pos - positions[k-1] - 1; gap == 0 {
+			score += bonusConsecutive
+		} else {
+			score -= min(gap, maxGapPenalty)
+		}
+	}
+	return score, positions, true
+}
+
+// isWordBoundary indica início de segmento: após separador, camelCase ou início de número.
+func isWordBoundary(text []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := text[i-1], text[i]
+	switch {
+	case strings.ContainsRune("/\\_-. ", prev):
+		return true
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return true
+	case !unicode.IsDigit(prev) && unicode.IsDigit(cur):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package filesearch
+
+import "testing"
+
+func TestMatchPathPrefersBasenameAndBoundaries(t *testing.T) {
+	query := normalizeQuery("svc go")
+
+	nameScore, positions, ok := matchPath("internal/gitpanel/svc.go", query)
+	if !ok || len(positions) != 5 || positions[0] != 18 {
+		t.Fatalf("expected basename match, got ok=%v positions=%v", ok, positions)
+	}
+	scatteredScore, _, ok := matchPath("src/views/components/go.txt", query)
+	if !ok {
+		t.Fatalf("expected scattered subsequence to match")
+	}
+	if nameScore <= scatteredScore {
+		t.Fatalf("expected basename match to win: %d <= %d", nameScore, scatteredScore)
+	}
+
+	if _, _, ok := matchPath("README.md", normalizeQuery("xyz")); ok {
+		t.Fatalf("expected non-subsequence to be rejected")
+	}
+
+	// Fronteira de camelCase vale mais que o mesmo caractere no meio da palavra.
+	camel, _, _ := matchPath("GitPanel.tsx", normalizeQuery("gp"))
+	inner, _, _ := matchPath("gaping.tsx", normalizeQuery("gp"))
+	if camel <= inner {
+		t.Fatalf("expected camelCase boundary to score higher: %d <= %d", camel, inner)
+	}
+}
@@ -0,0 +1,312 @@
+package filesearch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	fw "orch/internal/filewatcher"
+)
+
+const (
+	// DefaultLimit é a quantidade de resultados quando o limite não é informado.
+	DefaultLimit = 50
+	maxLimit     = 500
+	// maxIndexedFiles limita a memória do índice em monorepos muito grandes.
+	maxIndexedFiles = 200000
+	// defaultStaleAfter reconstrói índices que não recebem atualizações do file watcher.
+	defaultStaleAfter = 30 * time.Second
+	gitListTimeout    = 30 * time.Second
+)
+
+type gitCommandRunner func(ctx context.Context, dir string, args ...string) (string, error)
+
+// Service mantém um índice de arquivos por workspace para o quick-open.
+type Service struct {
+	mu         sync.Mutex
+	indexes    map[uint]*index
+	live       map[uint]bool // workspaces que recebem atualizações incrementais do file watcher
+	staleAfter time.Duration
+	runGit     gitCommandRunner
+}
+
+// index guarda os arquivos de uma raiz; buildMu serializa as varreduras.
+type index struct {
+	buildMu sync.Mutex
+
+	mu        sync.RWMutex
+	root      string
+	files     map[string]struct{} // relativos à raiz, com "/"
+	truncated bool
+	builtAt   time.Time
+	dirty     bool   // reconstruir na próxima busca
+	changes   uint64 // atualizações aplicadas (detecta mudanças durante a varredura)
+}
+
+// NewService cria o serviço de busca de arquivos.
+func NewService() *Service {
+	return &Service{
+		indexes:    make(map[uint]*index),
+		live:       make(map[uint]bool),
+		staleAfter: defaultStaleAfter,
+		runGit:     runGitCommand,
+	}
+}
+
+// FuzzyFind busca arquivos do workspace por fuzzy match, melhores resultados primeiro.
+// Busca vazia lista os arquivos em ordem alfabética. O índice é criado na primeira busca.
+func (s *Service) FuzzyFind(workspaceID uint, root string, query string, limit int) ([]FileMatch, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, maxLimit)
+
+	idx, err := s.ensureIndex(workspaceID, root)
+	if err != nil {
+		return nil, err
+	}
+	normalized := normalizeQuery(query)
+
+	idx.mu.RLock()
+	matches := make([]FileMatch, 0, min(len(idx.files), limit))
+	for file := range idx.files {
+		score, positions, ok := matchPath(file, normalized)
+		if !ok {
+			continue
+		}
+		matches = append(matches, FileMatch{Path: file, Name: path.Base(file), Score: score, Positions: positions})
+	}
+	idx.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if len(matches[i].Path) != len(matches[j].Path) && len(normalized) > 0 {
+			return len(matches[i].Path) < len(matches[j].Path)
+		}
+		return matches[i].Path < matches[j].Path
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// Files retorna os arquivos indexados do workspace (relativos à raiz, em ordem alfabética) e se
+// o índice foi truncado. Usa o mesmo índice do quick-open.
+func (s *Service) Files(workspaceID uint, root string) ([]string, bool, error) {
+	idx, err := s.ensureIndex(workspaceID, root)
+	if err != nil {
+		return nil, false, err
+	}
+	idx.mu.RLock()
+	files := make([]string, 0, len(idx.files))
+	for file := range idx.files {
+		files = append(files, file)
+	}
+	truncated := idx.truncated
+	idx.mu.RUnlock()
+	sort.Strings(files)
+	return files, truncated, nil
+}
+
+// SetLiveUpdates informa se o workspace recebe eventos do file watcher. Sem eles o índice
+// é reconstruído quando fica velho; ao ligar, a próxima busca reconstrói uma vez.
+func (s *Service) SetLiveUpdates(workspaceID uint, live bool) {
+	s.mu.Lock()
+	idx, wasLive := s.indexes[workspaceID], s.live[workspaceID]
+	s.live[workspaceID] = live
+	s.mu.Unlock()
+	if idx != nil && live && !wasLive {
+		idx.mu.Lock()
+		idx.dirty = true
+		idx.mu.Unlock()
+	}
+}
+
+// ApplyChange atualiza o índice com um lote workspace:file_changed do file watcher.
+func (s *Service) ApplyChange(change fw.WorkspaceFileChange) {
+	s.mu.Lock()
+	idx := s.indexes[change.WorkspaceID]
+	s.mu.Unlock()
+	if idx == nil || idx.root != filepath.Clean(change.Root) {
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.changes++
+	if change.Truncated {
+		idx.dirty = true
+		return
+	}
+	for _, rel := range change.Paths {
+		info, err := os.Lstat(filepath.Join(idx.root, filepath.FromSlash(rel)))
+		switch {
+		case err != nil:
+			// Removido ou renomeado: pode ser um arquivo ou um diretório inteiro.
+			delete(idx.files, rel)
+			prefix := rel + "/"
+			for file := range idx.files {
+				if strings.HasPrefix(file, prefix) {
+					delete(idx.files, file)
+				}
+			}
+		case info.IsDir():
+			// Conteúdo novo (ex: checkout, mv) exige varredura respeitando o .gitignore.
+			idx.dirty = true
+		case info.Mode().IsRegular():
+			if len(idx.files) < maxIndexedFiles {
+				idx.files[rel] = struct{}{}
+			} else {
+				idx.truncated = true
+			}
+		}
+	}
+}
+
+// Drop descarta o índice do workspace.
+func (s *Service) Drop(workspaceID uint) {
+	s.mu.Lock()
+	delete(s.indexes, workspaceID)
+	delete(s.live, workspaceID)
+	s.mu.Unlock()
+}
+
+// ensureIndex retorna o índice do workspace, (re)construindo quando necessário.
+func (s *Service) ensureIndex(workspaceID uint, root string) (*index, error) {
+	root = filepath.Clean(root)
+	s.mu.Lock()
+	idx := s.indexes[workspaceID]
+	if idx == nil || idx.root != root {
+		idx = &index{root: root, files: make(map[string]struct{}), dirty: true}
+		s.indexes[workspaceID] = idx
+	}
+	live, staleAfter := s.live[workspaceID], s.staleAfter
+	s.mu.Unlock()
+
+	idx.buildMu.Lock()
+	defer idx.buildMu.Unlock()
+
+	idx.mu.RLock()
+	needsBuild := idx.dirty || (!live && time.Since(idx.builtAt) > staleAfter)
+	generation := idx.changes
+	idx.mu.RUnlock()
+	if !needsBuild {
+		return idx, nil
+	}
+
+	files, truncated, err := s.scan(root)
+	if err != nil {
+		return nil, err
+	}
+	idx.mu.Lock()
+	idx.files = files
+	idx.truncated = truncated
+	idx.builtAt = time.Now()
+	// Mudanças durante a varredura podem ter sido lidas antes de acontecer.
+	idx.dirty = idx.changes != generation
+	idx.mu.Unlock()
+	return idx, nil
+}
+
+// scan lista os arquivos da raiz: via git (respeita .gitignore) ou, fora de um repositório,
+// percorrendo o disco sem os diretórios ignorados por padrão.
+func (s *Service) scan(root string) (map[string]struct{}, bool, error) {
+	if files, truncated, err := s.listGitFiles(root); err == nil {
+		return files, truncated, nil
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, false, fmt.Errorf("workspace root not accessible: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, false, fmt.Errorf("workspace root is not a directory: %s", root)
+	}
+	return walkFiles(root)
+}
+
+func (s *Service) listGitFiles(root string) (map[string]struct{}, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitListTimeout)
+	defer cancel()
+	output, err := s.runGit(ctx, root, "ls-files", "--cached", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, false, err
+	}
+	// Versionados mas apagados do disco ainda aparecem em --cached.
+	deleted := make(map[string]struct{})
+	if deletedOutput, err := s.runGit(ctx, root, "ls-files", "--deleted", "-z"); err == nil {
+		for _, entry := range strings.Split(deletedOutput, "\x00") {
+			deleted[entry] = struct{}{}
+		}
+	}
+
+	files := make(map[string]struct{})
+	for _, entry := range strings.Split(output, "\x00") {
+		if entry == "" {
+			continue
+		}
+		if _, gone := deleted[entry]; gone {
+			continue
+		}
+		if len(files) >= maxIndexedFiles {
+			return files, true, nil
+		}
+		files[entry] = struct{}{}
+	}
+	return files, false, nil
+}
+
+func walkFiles(root string) (map[string]struct{}, bool, error) {
+	files := make(map[string]struct{})
+	truncated := false
+	err := filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if p == root {
+				return err
+			}
+			return nil // sem permissão: segue com o resto
+		}
+		if entry.IsDir() {
+			if p != root && fw.IsDefaultIgnoredDir(entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		if len(files) >= maxIndexedFiles {
+			truncated = true
+			return filepath.SkipAll
+		}
+		rel, relErr := filepath.Rel(root, p)
+		if relErr == nil {
+			files[filepath.ToSlash(rel)] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return files, truncated, nil
+}
+
+func runGitCommand(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
@@ -0,0 +1,106 @@
+package filesearch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	fw "orch/internal/filewatcher"
+)
+
+func writeSearchFiles(t *testing.T, root string, files ...string) {
+	t.Helper()
+	for _, file := range files {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(file), 0o644); err != nil {
+			t.Fatalf("write %s: %v", file, err)
+		}
+	}
+}
+
+func matchedPaths(matches []FileMatch) []string {
+	paths := make([]string, 0, len(matches))
+	for _, match := range matches {
+		paths = append(paths, match.Path)
+	}
+	return paths
+}
+
+func TestFuzzyFindRespectsGitignore(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	if output, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, output)
+	}
+	writeSearchFiles(t, repo, ".gitignore", "app/main.go", "app/main_test.go", "node_modules/main.js", "debug.log")
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte("node_modules/\n*.log\n"), 0o644); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+
+	svc := NewService()
+	matches, err := svc.FuzzyFind(1, repo, "main", 0)
+	if err != nil {
+		t.Fatalf("FuzzyFind: %v", err)
+	}
+	if paths := matchedPaths(matches); len(paths) != 2 || paths[0] != "app/main.go" || paths[1] != "app/main_test.go" {
+		t.Fatalf("unexpected matches: %v", paths)
+	}
+	if matches[0].Name != "main.go" || len(matches[0].Positions) != 4 {
+		t.Fatalf("unexpected match details: %#v", matches[0])
+	}
+
+	if matches, _ := svc.FuzzyFind(1, repo, "", 2); len(matches) != 2 || matches[0].Path != ".gitignore" {
+		t.Fatalf("expected alphabetical listing for empty query, got %v", matchedPaths(matches))
+	}
+}
+
+func TestFuzzyFindAppliesWatcherChanges(t *testing.T) {
+	root := t.TempDir()
+	writeSearchFiles(t, root, "docs/guide.md", "dist/bundle.js", "pkg/util.go")
+
+	svc := NewService()
+	svc.runGit = func(ctx context.Context, dir string, args ...string) (string, error) {
+		return "", errors.New("not a git repository")
+	}
+	if matches, err := svc.FuzzyFind(3, root, "", 0); err != nil || len(matches) != 2 {
+		t.Fatalf("expected dist to be skipped outside git, got %v err=%v", matchedPaths(matches), err)
+	}
+	svc.SetLiveUpdates(3, true)
+	if _, err := svc.FuzzyFind(3, root, "", 0); err != nil {
+		t.Fatalf("FuzzyFind: %v", err)
+	}
+
+	writeSearchFiles(t, root, "pkg/parser.go")
+	if err := os.RemoveAll(filepath.Join(root, "docs")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	svc.ApplyChange(fw.WorkspaceFileChange{WorkspaceID: 3, Root: root, Paths: []string{"docs", "pkg/parser.go"}})
+
+	matches, err := svc.FuzzyFind(3, root, "", 0)
+	if err != nil {
+		t.Fatalf("FuzzyFind: %v", err)
+	}
+	if paths := matchedPaths(matches); len(paths) != 2 || paths[0] != "pkg/parser.go" || paths[1] != "pkg/util.go" {
+		t.Fatalf("expected incremental update, got %v", paths)
+	}
+	svc.mu.Lock()
+	dirty := svc.indexes[3].dirty
+	svc.mu.Unlock()
+	if dirty {
+		t.Fatalf("file-level changes must not force a rebuild")
+	}
+
+	svc.Drop(3)
+	svc.ApplyChange(fw.WorkspaceFileChange{WorkspaceID: 3, Root: root, Paths: []string{"pkg/util.go"}})
+	if _, exists := svc.indexes[3]; exists {
+		t.Fatalf("changes for dropped workspaces must be ignored")
+	}
+}
@@ -0,0 +1,41 @@
+package filesearch
+
+// FileMatch é um arquivo encontrado pelo fuzzy find do workspace.
+type FileMatch struct {
+	Path      string `json:"path"` // relativo à raiz do workspace, com "/"
+	Name      string `json:"name"`
+	Score     int    `json:"score"`
+	Positions []int  `json:"positions"` // índices (runes) de Path que casaram com a busca, para destaque
+}
+
+// ContentQuery descreve uma busca de conteúdo nos arquivos do workspace.
+// Sem letras maiúsculas a busca ignora caixa (smart case, como no ripgrep).
+type ContentQuery struct {
+	Query string   `json:"query"`
+	Regex bool     `json:"regex"`
+	Globs []string `json:"globs"` // "src/**/*.go" inclui; "!**/*_test.go" exclui
+}
+
+// ContentMatch é uma linha com ocorrências da busca.
+type ContentMatch struct {
+	Path    string       `json:"path"`   // relativo à raiz do workspace, com "/"
+	Line    int          `json:"line"`   // 1-based
+	Column  int          `json:"column"` // 1-based, em runes, da primeira ocorrência
+	Preview string       `json:"preview"`
+	Ranges  []MatchRange `json:"ranges"` // ocorrências dentro de Preview
+}
+
+// MatchRange é um intervalo [Start, End) em runes.
+type MatchRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// ContentSearchSummary resume uma busca de conteúdo finalizada.
+type ContentSearchSummary struct {
+	SearchedFiles int   `json:"searchedFiles"`
+	MatchedFiles  int   `json:"matchedFiles"`
+	Matches       int   `json:"matches"`
+	Truncated     bool  `json:"truncated"`
+	DurationMs    int64 `json:"durationMs"`
+}
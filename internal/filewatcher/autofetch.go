@@ -0,0 +1,271 @@
+package filewatcher
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultAutoFetchInterval é o intervalo usado quando nenhum é informado.
+	DefaultAutoFetchInterval = 5 * time.Minute
+
+	// MinAutoFetchInterval evita martelar o remoto com fetches muito frequentes.
+	MinAutoFetchInterval = time.Minute
+
+	autoFetchTimeout = 60 * time.Second
+	aheadReadTimeout = 5 * time.Second
+)
+
+// gitCommandRunner executa um comando git no repositório e retorna stdout.
+type gitCommandRunner func(ctx context.Context, repoPath string, args ...string) (string, error)
+
+type autoFetchEntry struct {
+	config  AutoFetchConfig
+	timer   *time.Timer
+	running bool
+	last    *AutoFetchResult
+}
+
+// NormalizeAutoFetchInterval aplica default e limite mínimo ao intervalo em segundos.
+func NormalizeAutoFetchInterval(seconds int) time.Duration {
+	if seconds <= 0 {
+		return DefaultAutoFetchInterval
+	}
+	interval := time.Duration(seconds) * time.Second
+	if interval < MinAutoFetchInterval {
+		return MinAutoFetchInterval
+	}
+	return interval
+}
+
+// SetAutoFetch liga/desliga o fetch periódico de um repositório.
+// O fetch só roda enquanto o repositório estiver sendo monitorado (Watch).
+func (s *Service) SetAutoFetch(config AutoFetchConfig) AutoFetchConfig {
+	config.RepoPath = filepath.Clean(strings.TrimSpace(config.RepoPath))
+	interval := NormalizeAutoFetchInterval(config.IntervalSeconds)
+	config.IntervalSeconds = int(interval / time.Second)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.autoFetch == nil {
+		s.autoFetch = make(map[string]*autoFetchEntry)
+	}
+	entry, exists := s.autoFetch[config.RepoPath]
+	if !exists {
+		entry = &autoFetchEntry{}
+		s.autoFetch[config.RepoPath] = entry
+	}
+	if entry.timer != nil {
+		entry.timer.Stop()
+		entry.timer = nil
+	}
+	entry.config = config
+
+	if config.Enabled && !s.closed {
+		s.scheduleAutoFetchLocked(config.RepoPath, entry, interval)
+	} else if _, watched := s.watches[config.RepoPath]; !watched && s.releaseLease != nil {
+		// Enquanto monitorado, o lease também cobre o watcher e fica com a instância.
+		go s.releaseLease(repoLeaseName(config.RepoPath))
+	}
+	return config
+}
+
+// AutoFetchConfigs lista as configurações de auto-fetch registradas.
+func (s *Service) AutoFetchConfigs() []AutoFetchConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	configs := make([]AutoFetchConfig, 0, len(s.autoFetch))
+	for _, entry := range s.autoFetch {
+		configs = append(configs, entry.config)
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].RepoPath < configs[j].RepoPath })
+	return configs
+}
+
+// LastAutoFetch retorna o resultado do último fetch do repositório, se houver.
+func (s *Service) LastAutoFetch(repoPath string) (AutoFetchResult, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, exists := s.autoFetch[filepath.Clean(strings.TrimSpace(repoPath))]
+	if !exists || entry.last == nil {
+		return AutoFetchResult{}, false
+	}
+	return *entry.last, true
+}
+
+// FetchNow executa um fetch imediato (fora do agendamento) e emite git:fetch.
+func (s *Service) FetchNow(repoPath string) (AutoFetchResult, error) {
+	repoPath = filepath.Clean(strings.TrimSpace(repoPath))
+	if _, err := resolveGitDir(repoPath); err != nil {
+		return AutoFetchResult{}, fmt.Errorf("not a git repository: %s", repoPath)
+	}
+
+	result := s.fetchRepo(repoPath)
+
+	s.mu.Lock()
+	if entry, exists := s.autoFetch[repoPath]; exists {
+		entry.last = &result
+	}
+	s.mu.Unlock()
+
+	s.dispatchEvent(autoFetchEvent(result, "manual"))
+	if result.Error != "" {
+		return result, fmt.Errorf("git fetch failed: %s", result.Error)
+	}
+	return result, nil
+}
+
+func (s *Service) scheduleAutoFetchLocked(repoPath string, entry *autoFetchEntry, interval time.Duration) {
+	entry.timer = time.AfterFunc(interval, func() {
+		s.runScheduledFetch(repoPath)
+	})
+}
+
+func (s *Service) runScheduledFetch(repoPath string) {
+	s.mu.Lock()
+	entry, exists := s.autoFetch[repoPath]
+	if !exists || s.closed || !entry.config.Enabled || entry.running {
+		s.mu.Unlock()
+		return
+	}
+	_, watched := s.watches[repoPath]
+	if !watched {
+		// Repositório fora do workspace ativo (ou em standby, com outra instância buscando): reagenda sem buscar.
+		s.scheduleAutoFetchLocked(repoPath, entry, NormalizeAutoFetchInterval(entry.config.IntervalSeconds))
+		s.mu.Unlock()
+		return
+	}
+	entry.running = true
+	firedTimer := entry.timer
+	acquireLease := s.acquireLease
+	s.mu.Unlock()
+
+	if acquireLease != nil && !acquireLease(repoLeaseName(repoPath)) {
+		// Outra instância faz o fetch deste repositório; o resultado chega pelo fsnotify dos refs.
+		s.mu.Lock()
+		entry.running = false
+		if !s.closed && entry.config.Enabled && entry.timer == firedTimer {
+			s.scheduleAutoFetchLocked(repoPath, entry, NormalizeAutoFetchInterval(entry.config.IntervalSeconds))
+		}
+		s.mu.Unlock()
+		return
+	}
+
+	result := s.fetchRepo(repoPath)
+
+	s.mu.Lock()
+	entry.running = false
+	entry.last = &result
+	// Se a config mudou durante o fetch, SetAutoFetch já reagendou (ou desligou).
+	if !s.closed && entry.config.Enabled && entry.timer == firedTimer {
+		s.scheduleAutoFetchLocked(repoPath, entry, NormalizeAutoFetchInterval(entry.config.IntervalSeconds))
+	}
+	s.mu.Unlock()
+
+	if result.Error != "" {
+		log.Printf("[FileWatcher] Auto-fetch failed for %s: %s", repoPath, result.Error)
+	}
+	s.dispatchEvent(autoFetchEvent(result, "auto_fetch"))
+}
+
+func (s *Service) fetchRepo(repoPath string) AutoFetchResult {
+	runner := s.runGit
+	if runner == nil {
+		runner = runGitCommand
+	}
+
+	result := AutoFetchResult{RepoPath: repoPath, FetchedAt: time.Now()}
+
+	fetchCtx, cancelFetch := context.WithTimeout(context.Background(), autoFetchTimeout)
+	_, err := runner(fetchCtx, repoPath, "fetch", "--prune", "--quiet")
+	cancelFetch()
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	readCtx, cancelRead := context.WithTimeout(context.Background(), aheadReadTimeout)
+	defer cancelRead()
+
+	upstream, err := runner(readCtx, repoPath, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	if err != nil {
+		// Branch sem upstream: fetch é válido, apenas não há ahead/behind.
+		return result
+	}
+	result.Upstream = strings.TrimSpace(upstream)
+
+	counts, err := runner(readCtx, repoPath, "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
+	if err != nil {
+		return result
+	}
+	result.Ahead, result.Behind = parseAheadBehind(counts)
+	return result
+}
+
+func parseAheadBehind(raw string) (int, int) {
+	fields := strings.Fields(raw)
+	if len(fields) != 2 {
+		return 0, 0
+	}
+	ahead, aheadErr := strconv.Atoi(fields[0])
+	behind, behindErr := strconv.Atoi(fields[1])
+	if aheadErr != nil || behindErr != nil {
+		return 0, 0
+	}
+	return ahead, behind
+}
+
+func autoFetchEvent(result AutoFetchResult, source string) FileEvent {
+	details := map[string]string{
+		"repoPath": result.RepoPath,
+		"source":   source,
+		"ahead":    strconv.Itoa(result.Ahead),
+		"behind":   strconv.Itoa(result.Behind),
+	}
+	if result.Upstream != "" {
+		details["upstream"] = result.Upstream
+	}
+	if result.Error != "" {
+		details["error"] = result.Error
+	}
+	// Mesmo path do evento fsnotify de FETCH_HEAD, para os consumidores resolverem o repo igual.
+	return FileEvent{
+		Type:      "fetch",
+		Path:      filepath.Join(result.RepoPath, ".git", "FETCH_HEAD"),
+		Timestamp: result.FetchedAt,
+		Details:   details,
+	}
+}
+
+func (s *Service) stopAutoFetchLocked() {
+	for _, entry := range s.autoFetch {
+		if entry.timer != nil {
+			entry.timer.Stop()
+			entry.timer = nil
+		}
+	}
+}
+
+func runGitCommand(ctx context.Context, repoPath string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repoPath}, args...)...)
+	// Nunca bloquear esperando credenciais interativas em background.
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(output), nil
+}
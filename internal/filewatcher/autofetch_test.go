@@ -0,0 +1,115 @@
+package filewatcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchNowReportsAheadBehindAndEmitsFetchEvent(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repoPath, ".git"), 0o755); err != nil {
+		t.Fatalf("failed to create fake .git: %v", err)
+	}
+
+	calls := make([]string, 0, 3)
+	emitted := make([]FileEvent, 0, 1)
+	svc := &Service{
+		autoFetch: make(map[string]*autoFetchEntry),
+		watches:   make(map[string]*repoWatch),
+		runGit: func(ctx context.Context, repo string, args ...string) (string, error) {
+			calls = append(calls, strings.Join(args, " "))
+			switch args[0] {
+			case "rev-parse":
+				return "origin/main\n", nil
+			case "rev-list":
+				return "1\t3\n", nil
+			}
+			return "", nil
+		},
+		emitEvent: func(eventName string, data interface{}) {
+			if eventName != "git:fetch" {
+				t.Fatalf("unexpected event name: %s", eventName)
+			}
+			emitted = append(emitted, *data.(*FileEvent))
+		},
+	}
+
+	result, err := svc.FetchNow(repoPath)
+	if err != nil {
+		t.Fatalf("FetchNow returned error: %v", err)
+	}
+	if result.Upstream != "origin/main" || result.Ahead != 1 || result.Behind != 3 {
+		t.Fatalf("unexpected fetch result: %+v", result)
+	}
+	if len(calls) != 3 || calls[0] != "fetch --prune --quiet" {
+		t.Fatalf("unexpected git calls: %v", calls)
+	}
+	if len(emitted) != 1 || emitted[0].Details["behind"] != "3" || emitted[0].Details["repoPath"] != repoPath {
+		t.Fatalf("unexpected emitted events: %+v", emitted)
+	}
+}
+
+func TestSetAutoFetchNormalizesIntervalAndStopsWhenDisabled(t *testing.T) {
+	svc := &Service{autoFetch: make(map[string]*autoFetchEntry)}
+
+	config := svc.SetAutoFetch(AutoFetchConfig{RepoPath: "/tmp/repo/", Enabled: true, IntervalSeconds: 5})
+	if config.RepoPath != "/tmp/repo" || config.IntervalSeconds != int(MinAutoFetchInterval/time.Second) {
+		t.Fatalf("unexpected normalized config: %+v", config)
+	}
+	if svc.autoFetch["/tmp/repo"].timer == nil {
+		t.Fatalf("expected enabled config to schedule a fetch")
+	}
+
+	svc.SetAutoFetch(AutoFetchConfig{RepoPath: "/tmp/repo", Enabled: false})
+	if svc.autoFetch["/tmp/repo"].timer != nil {
+		t.Fatalf("expected disabled config to stop the timer")
+	}
+	if configs := svc.AutoFetchConfigs(); len(configs) != 1 || configs[0].Enabled {
+		t.Fatalf("unexpected configs: %+v", configs)
+	}
+}
+
+func TestScheduledFetchSkipsWhenLeaseHeldElsewhere(t *testing.T) {
+	repoPath := "/tmp/repo"
+	fetched := 0
+	granted := false
+	svc := &Service{
+		autoFetch: map[string]*autoFetchEntry{
+			repoPath: {config: AutoFetchConfig{RepoPath: repoPath, Enabled: true, IntervalSeconds: 60}},
+		},
+		watches: map[string]*repoWatch{repoPath: {}},
+		runGit: func(ctx context.Context, repo string, args ...string) (string, error) {
+			if args[0] == "fetch" {
+				fetched++
+			}
+			return "", nil
+		},
+	}
+	var requested []string
+	svc.SetLeaseGate(func(resource string) bool {
+		requested = append(requested, resource)
+		return granted
+	}, nil)
+
+	svc.runScheduledFetch(repoPath)
+	entry := svc.autoFetch[repoPath]
+	if fetched != 0 || entry.running || entry.last != nil {
+		t.Fatalf("expected fetch skipped while another instance holds the lease (fetched=%d)", fetched)
+	}
+	if len(requested) != 1 || requested[0] != "git.repo:"+repoPath {
+		t.Fatalf("unexpected lease requests: %v", requested)
+	}
+
+	granted = true
+	entry.timer.Stop()
+	entry.timer = nil
+	svc.runScheduledFetch(repoPath)
+	entry.timer.Stop()
+	if fetched != 1 || entry.last == nil {
+		t.Fatalf("expected fetch once the lease is granted (fetched=%d)", fetched)
+	}
+}
@@ -0,0 +1,106 @@
+package filewatcher
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+// standbyRetryInterval é a frequência com que repositórios em standby tentam assumir o lease;
+// se a instância dona fechar sem liberar, o lease expira no TTL do coordenador.
+const standbyRetryInterval = 10 * time.Second
+
+// SetLeaseGate liga o watcher ao lease por repositório compartilhado entre instâncias no mesmo
+// data dir: só a dona monitora o repositório, emite seus eventos e roda o auto-fetch. As demais
+// deixam o repositório em standby e o assumem quando o lease fica livre.
+func (s *Service) SetLeaseGate(acquire func(resource string) bool, release func(resource string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.acquireLease = acquire
+	s.releaseLease = release
+	if acquire != nil && !s.standbyLoopOn && !s.closed && s.done != nil {
+		s.standbyLoopOn = true
+		go s.standbyLoop()
+	}
+}
+
+func repoLeaseName(repoPath string) string {
+	return "git.repo:" + repoPath
+}
+
+// markStandbyLocked registra um repositório pedido via Watch cujo lease está com outra instância.
+func (s *Service) markStandbyLocked(projectPath string) {
+	if s.standby == nil {
+		s.standby = make(map[string]struct{})
+	}
+	s.standby[projectPath] = struct{}{}
+}
+
+func (s *Service) standbyLoop() {
+	ticker := time.NewTicker(standbyRetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.retryStandby()
+		}
+	}
+}
+
+// retryStandby tenta assumir o lease dos repositórios em standby e passa a monitorá-los.
+func (s *Service) retryStandby() {
+	s.mu.RLock()
+	acquire, release := s.acquireLease, s.releaseLease
+	repos := make([]string, 0, len(s.standby))
+	for projectPath := range s.standby {
+		repos = append(repos, projectPath)
+	}
+	s.mu.RUnlock()
+	sort.Strings(repos)
+
+	for _, projectPath := range repos {
+		if acquire != nil && !acquire(repoLeaseName(projectPath)) {
+			continue
+		}
+		s.mu.Lock()
+		_, waiting := s.standby[projectPath]
+		delete(s.standby, projectPath)
+		s.mu.Unlock()
+		if !waiting {
+			// Unwatch chegou enquanto o lease era pedido.
+			if release != nil {
+				release(repoLeaseName(projectPath))
+			}
+			continue
+		}
+		log.Printf("[FileWatcher] Took over %s from another instance", projectPath)
+		if err := s.Watch(projectPath); err != nil {
+			log.Printf("[FileWatcher] Warning: could not watch %s after lease takeover: %v", projectPath, err)
+		}
+	}
+}
+
+// holdsRepoLease confirma o lease antes de emitir os eventos do repositório. Se outra instância
+// assumiu (ex.: renovação falhou), o repositório sai do fsnotify e volta para standby.
+func (s *Service) holdsRepoLease(projectPath string) bool {
+	s.mu.RLock()
+	acquire := s.acquireLease
+	s.mu.RUnlock()
+	if acquire == nil || acquire(repoLeaseName(projectPath)) {
+		return true
+	}
+
+	s.mu.Lock()
+	demoted := false
+	if !s.closed && s.unwatchLocked(projectPath) {
+		s.markStandbyLocked(projectPath)
+		demoted = true
+	}
+	s.mu.Unlock()
+	if demoted {
+		log.Printf("[FileWatcher] %s is now watched by another instance; standing by", projectPath)
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package filewatcher
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchFollowsRepoLease(t *testing.T) {
+	repo := initWatcherTestRepo(t)
+	svc, err := NewService(nil)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	defer svc.Close()
+	svc.SetDebounceWindow(20 * time.Millisecond)
+
+	var granted atomic.Bool
+	svc.SetLeaseGate(func(resource string) bool {
+		if resource != "git.repo:"+repo {
+			t.Errorf("unexpected lease resource %q", resource)
+		}
+		return granted.Load()
+	}, nil)
+
+	if err := svc.Watch(repo); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if watched := svc.WatchedProjects(); len(watched) != 0 {
+		t.Fatalf("repo leased elsewhere should not be watched, got %v", watched)
+	}
+	if _, waiting := svc.standby[repo]; !waiting {
+		t.Fatalf("expected repo in standby")
+	}
+
+	granted.Store(true)
+	svc.retryStandby()
+	if watched := svc.WatchedProjects(); len(watched) != 1 || watched[0] != repo {
+		t.Fatalf("expected repo watched after taking the lease, got %v", watched)
+	}
+
+	events := make(chan FileEvent, 16)
+	svc.OnChange(func(event FileEvent) { events <- event })
+	granted.Store(false)
+	if err := os.WriteFile(filepath.Join(repo, "src", "main.go"), []byte("package main"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("events should not be emitted after losing the lease: %#v", event)
+	case <-time.After(500 * time.Millisecond):
+	}
+	if watched := svc.WatchedProjects(); len(watched) != 0 {
+		t.Fatalf("expected repo back in standby after losing the lease, got %v", watched)
+	}
+
+	if err := svc.Unwatch(repo); err != nil {
+		t.Fatalf("Unwatch: %v", err)
+	}
+	if len(svc.standby) != 0 {
+		t.Fatalf("Unwatch should drop the standby entry")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -16,23 +18,70 @@ import (
 
 // Service implementa IFileWatcher usando fsnotify
 type Service struct {
-	mu       sync.RWMutex
-	watcher  *fsnotify.Watcher
-	handlers []func(FileEvent)
-	debounce map[string]*time.Timer
-	recent   map[string]time.Time
-	projects map[string]string // projectPath -> gitDir real monitorado
-	loopOn   bool
-	done     chan struct{}
-	closed   bool
-	rawLogs  bool
-	ignored  bool
-	window   time.Duration
+	mu             sync.RWMutex
+	watcher        *fsnotify.Watcher
+	handlers       []func(FileEvent)
+	wsHandlers     []func(WorkspaceFileChange)
+	recent         map[string]time.Time
+	watches        map[string]*repoWatch // projectPath -> diretórios monitorados (.git + working tree)
+	workspaces     map[uint]*workspaceWatch
+	watchRefs      map[string]int // diretório -> quantidade de donos (repos e workspaces) no fsnotify
+	budget         int            // máximo de diretórios por repositório/workspace
+	pending        map[string]fsnotify.Event
+	pendingTimer   *time.Timer
+	stats          watcherStats
+	loopOn         bool
+	done           chan struct{}
+	closed         bool
+	rawLogs        bool
+	ignored        bool
+	window         time.Duration
+	debounceWindow time.Duration
+
+	autoFetch map[string]*autoFetchEntry // repoPath -> agendamento de fetch
+	runGit    gitCommandRunner
+
+	// Lease entre instâncias por repositório: watcher, eventos e auto-fetch (nil = sempre monitora).
+	acquireLease  func(resource string) bool
+	releaseLease  func(resource string)
+	standby       map[string]struct{} // repos pedidos via Watch que outra instância monitora
+	standbyLoopOn bool
 
 	// Callback para emitir eventos Wails (injetado pelo app.go)
 	emitEvent func(eventName string, data interface{})
 }
 
+// DefaultDebounceWindow é a janela de agrupamento dos eventos brutos do fsnotify antes da classificação.
+const DefaultDebounceWindow = 200 * time.Millisecond
+
+type watcherStats struct {
+	rawEvents     uint64
+	droppedEvents uint64
+	batches       uint64
+	emittedEvents uint64
+}
+
+// SetDebounceWindow altera a janela de debounce dos eventos (d <= 0 é ignorado).
+func (s *Service) SetDebounceWindow(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	s.mu.Lock()
+	s.debounceWindow = d
+	s.mu.Unlock()
+}
+
+// SetWatchBudget altera o máximo de diretórios monitorados por repositório (n <= 0 é ignorado).
+// Vale para repositórios e diretórios adicionados depois da chamada.
+func (s *Service) SetWatchBudget(n int) {
+	if n <= 0 {
+		return
+	}
+	s.mu.Lock()
+	s.budget = n
+	s.mu.Unlock()
+}
+
 // NewService cria um novo FileWatcher Service
 func NewService(emitEvent func(eventName string, data interface{})) (*Service, error) {
 	watcher, err := fsnotify.NewWatcher()
@@ -41,51 +90,97 @@ func NewService(emitEvent func(eventName string, data interface{})) (*Service, e
 	}
 
 	return &Service{
-		watcher:   watcher,
-		handlers:  make([]func(FileEvent), 0),
-		debounce:  make(map[string]*time.Timer),
-		recent:    make(map[string]time.Time),
-		projects:  make(map[string]string),
-		done:      make(chan struct{}),
-		rawLogs:   readEnvBool("ORCH_FILEWATCHER_DEBUG_RAW"),
-		ignored:   readEnvBool("ORCH_FILEWATCHER_DEBUG_IGNORED"),
-		window:    900 * time.Millisecond,
-		emitEvent: emitEvent,
+		watcher:        watcher,
+		handlers:       make([]func(FileEvent), 0),
+		recent:         make(map[string]time.Time),
+		watches:        make(map[string]*repoWatch),
+		workspaces:     make(map[uint]*workspaceWatch),
+		watchRefs:      make(map[string]int),
+		budget:         DefaultWatchBudget,
+		pending:        make(map[string]fsnotify.Event),
+		done:           make(chan struct{}),
+		rawLogs:        readEnvBool("ORCH_FILEWATCHER_DEBUG_RAW"),
+		ignored:        readEnvBool("ORCH_FILEWATCHER_DEBUG_IGNORED"),
+		window:         900 * time.Millisecond,
+		debounceWindow: DefaultDebounceWindow,
+		autoFetch:      make(map[string]*autoFetchEntry),
+		runGit:         runGitCommand,
+		emitEvent:      emitEvent,
 	}, nil
 }
 
-// Watch inicia o monitoramento da pasta .git de um projeto
+// Watch inicia o monitoramento da pasta .git e do working tree (sem ignorados) de um projeto
 func (s *Service) Watch(projectPath string) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	projectPath = filepath.Clean(projectPath)
 
+	s.mu.Lock()
 	if s.closed {
+		s.mu.Unlock()
 		return fmt.Errorf("watcher is closed")
 	}
-
-	projectPath = filepath.Clean(projectPath)
-
 	// Verificar se já está monitorando
-	if _, alreadyWatching := s.projects[projectPath]; alreadyWatching {
+	if _, alreadyWatching := s.watches[projectPath]; alreadyWatching {
+		s.mu.Unlock()
 		return nil
 	}
+	budget, runGit, acquireLease := s.budget, s.runGit, s.acquireLease
+	s.mu.Unlock()
 
 	gitDir, err := resolveGitDir(projectPath)
 	if err != nil {
 		return fmt.Errorf("not a git repository: %s", projectPath)
 	}
+	if acquireLease != nil && !acquireLease(repoLeaseName(projectPath)) {
+		s.mu.Lock()
+		if !s.closed {
+			s.markStandbyLocked(projectPath)
+		}
+		s.mu.Unlock()
+		log.Printf("[FileWatcher] %s is watched by another instance; standing by", projectPath)
+		return nil
+	}
 
-	paths := collectWatchPaths(gitDir)
+	// Varredura do working tree fora do lock: em repos grandes o git ls-files leva alguns ms.
+	gitPaths := collectWatchPaths(gitDir)
+	ignoredDirs, err := listIgnoredDirs(runGit, projectPath)
+	if err != nil {
+		log.Printf("[FileWatcher] Warning: could not list ignored directories of %s: %v", projectPath, err)
+	}
+	plan := planWorktreeDirs(projectPath, ignoredDirs, budget-len(gitPaths))
 
-	// Adicionar watchers para cada path
-	for _, p := range paths {
-		if err := s.watcher.Add(p); err != nil {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return fmt.Errorf("watcher is closed")
+	}
+	if _, alreadyWatching := s.watches[projectPath]; alreadyWatching {
+		return nil
+	}
+
+	watch := newRepoWatch(gitDir)
+	watch.ignoredDirs = plan.ignored
+	watch.budgetExceeded = plan.truncated
+
+	// Adicionar watchers para cada path (.git tem prioridade sobre o working tree)
+	for _, p := range gitPaths {
+		if err := s.addWatchLocked(p); err != nil {
 			log.Printf("[FileWatcher] Warning: could not watch %s: %v", p, err)
+			continue
+		}
+		watch.gitPaths[p] = struct{}{}
+	}
+	for _, dir := range plan.dirs {
+		if !s.addWorktreeDirLocked(watch, dir) {
+			break
 		}
 	}
 
-	s.projects[projectPath] = gitDir
-	log.Printf("[FileWatcher] Watching %s", projectPath)
+	s.watches[projectPath] = watch
+	delete(s.standby, projectPath)
+	log.Printf("[FileWatcher] Watching %s (%d dirs, %d ignored)", projectPath, watch.watchedDirs(), watch.ignoredDirs)
+	if watch.budgetExceeded {
+		log.Printf("[FileWatcher] Watch budget reached for %s (%d dirs); deeper directories are not monitored", projectPath, s.budget)
+	}
 
 	// Iniciar event loop apenas uma vez
 	if !s.loopOn {
@@ -96,26 +191,105 @@ func (s *Service) Watch(projectPath string) error {
 	return nil
 }
 
+// addWorktreeDirLocked adiciona um diretório do working tree respeitando o budget.
+// Retorna false quando o budget ou o limite do sistema (inotify/fds) foi atingido.
+func (s *Service) addWorktreeDirLocked(watch *repoWatch, dir string) bool {
+	if _, exists := watch.worktreePaths[dir]; exists {
+		return true
+	}
+	if watch.watchedDirs() >= s.budget {
+		watch.budgetExceeded = true
+		return false
+	}
+	if err := s.addWatchLocked(dir); err != nil {
+		if isWatchLimitError(err) {
+			watch.budgetExceeded = true
+			log.Printf("[FileWatcher] Warning: system watch limit reached at %s: %v", dir, err)
+			return false
+		}
+		log.Printf("[FileWatcher] Warning: could not watch %s: %v", dir, err)
+		return true
+	}
+	watch.worktreePaths[dir] = struct{}{}
+	return true
+}
+
+// addWatchLocked registra mais um dono do diretório; só o primeiro chega ao fsnotify.
+// Repositórios e workspaces podem monitorar os mesmos diretórios.
+func (s *Service) addWatchLocked(dir string) error {
+	if s.watchRefs[dir] > 0 {
+		s.watchRefs[dir]++
+		return nil
+	}
+	if err := s.watcher.Add(dir); err != nil {
+		return err
+	}
+	s.watchRefs[dir] = 1
+	return nil
+}
+
+// removeWatchLocked libera um dono do diretório; o último remove o watch do fsnotify.
+func (s *Service) removeWatchLocked(dir string) {
+	refs := s.watchRefs[dir]
+	if refs <= 0 {
+		return
+	}
+	if refs > 1 {
+		s.watchRefs[dir] = refs - 1
+		return
+	}
+	delete(s.watchRefs, dir)
+	_ = s.watcher.Remove(dir)
+}
+
 // Unwatch para o monitoramento de um projeto
 func (s *Service) Unwatch(projectPath string) error {
+	projectPath = filepath.Clean(projectPath)
+
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	delete(s.standby, projectPath)
+	watched := s.unwatchLocked(projectPath)
+	release := s.releaseLease
+	s.mu.Unlock()
 
-	projectPath = filepath.Clean(projectPath)
-	gitDir, exists := s.projects[projectPath]
-	if !exists {
+	if !watched {
 		return nil
 	}
+	if release != nil {
+		go release(repoLeaseName(projectPath))
+	}
+	log.Printf("[FileWatcher] Unwatched %s", projectPath)
+	return nil
+}
 
-	paths := collectWatchPaths(gitDir)
+// unwatchLocked tira o repositório do fsnotify; false se ele não estava monitorado.
+func (s *Service) unwatchLocked(projectPath string) bool {
+	watch, exists := s.watches[projectPath]
+	if !exists {
+		return false
+	}
 
-	for _, p := range paths {
-		_ = s.watcher.Remove(p)
+	for p := range watch.gitPaths {
+		s.removeWatchLocked(p)
+	}
+	for p := range watch.worktreePaths {
+		s.removeWatchLocked(p)
 	}
 
-	delete(s.projects, projectPath)
-	log.Printf("[FileWatcher] Unwatched %s", projectPath)
-	return nil
+	delete(s.watches, projectPath)
+	return true
+}
+
+// WatchedProjects retorna os projetos monitorados no momento.
+func (s *Service) WatchedProjects() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	projects := make([]string, 0, len(s.watches))
+	for projectPath := range s.watches {
+		projects = append(projects, projectPath)
+	}
+	return projects
 }
 
 // OnChange registra um handler para receber eventos
@@ -146,10 +320,12 @@ func (s *Service) Close() error {
 
 	s.closed = true
 
-	// Cancelar todos os debounce timers
-	for _, timer := range s.debounce {
-		timer.Stop()
+	// Cancelar o lote pendente
+	if s.pendingTimer != nil {
+		s.pendingTimer.Stop()
+		s.pendingTimer = nil
 	}
+	s.stopAutoFetchLocked()
 
 	close(s.done)
 	return s.watcher.Close()
@@ -167,6 +343,9 @@ func (s *Service) eventLoop() {
 			if !ok {
 				return
 			}
+			s.mu.Lock()
+			s.stats.rawEvents++
+			s.mu.Unlock()
 			if s.rawLogs {
 				log.Printf("[FileWatcher][raw] op=%s path=%s", event.Op.String(), event.Name)
 			}
@@ -180,17 +359,7 @@ func (s *Service) eventLoop() {
 				continue
 			}
 
-			// Debounce: 200ms por arquivo (path normalizado).
-			key := normalizeGitEventPath(event.Name)
-			s.mu.Lock()
-			if timer, exists := s.debounce[key]; exists {
-				timer.Stop()
-			}
-			ev := event
-			s.debounce[key] = time.AfterFunc(200*time.Millisecond, func() {
-				s.handleDebouncedEvent(ev)
-			})
-			s.mu.Unlock()
+			s.queueRawEvent(event)
 
 		case err, ok := <-s.watcher.Errors:
 			if !ok {
@@ -201,15 +370,189 @@ func (s *Service) eventLoop() {
 	}
 }
 
+// queueRawEvent acumula o evento no lote atual (path normalizado, ops somadas).
+// O lote é processado uma vez por janela; com o lote cheio, eventos do working tree são descartados.
+func (s *Service) queueRawEvent(event fsnotify.Event) {
+	key := normalizeGitEventPath(event.Name)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	if previous, exists := s.pending[key]; exists {
+		event.Op |= previous.Op
+		s.pending[key] = event
+	} else if len(s.pending) >= maxPendingRawEvents && !s.isGitPathLocked(key) {
+		s.stats.droppedEvents++
+	} else {
+		s.pending[key] = event
+	}
+	if s.pendingTimer == nil {
+		s.pendingTimer = time.AfterFunc(s.debounceWindow, s.flushPendingEvents)
+	}
+}
+
+// flushPendingEvents classifica o lote: eventos do .git seguem o fluxo por arquivo,
+// mudanças no working tree viram um único evento "worktree" por repositório e edições
+// nos workspaces monitorados um único workspace:file_changed por workspace.
+func (s *Service) flushPendingEvents() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	batch := s.pending
+	s.pending = make(map[string]fsnotify.Event)
+	s.pendingTimer = nil
+	s.stats.batches++
+	s.mu.Unlock()
+
+	keys := make([]string, 0, len(batch))
+	for key := range batch {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	worktreeChanges := make(map[string][]fsnotify.Event)
+	workspaceChanges := make(map[uint][]fsnotify.Event)
+	leased := make(map[string]bool) // lease confirmado uma vez por repositório no lote
+	holdsLease := func(projectPath string) bool {
+		held, checked := leased[projectPath]
+		if !checked {
+			held = s.holdsRepoLease(projectPath)
+			leased[projectPath] = held
+		}
+		return held
+	}
+	for _, key := range keys {
+		event := batch[key]
+		s.mu.RLock()
+		workspaceIDs := s.workspacesForEventLocked(filepath.Clean(event.Name))
+		s.mu.RUnlock()
+		for _, id := range workspaceIDs {
+			workspaceChanges[id] = append(workspaceChanges[id], event)
+		}
+
+		projectPath, inGitDir := s.locateEvent(key)
+		switch {
+		case projectPath == "" || !holdsLease(projectPath):
+			continue
+		case inGitDir:
+			s.handleDebouncedEvent(event)
+		default:
+			worktreeChanges[projectPath] = append(worktreeChanges[projectPath], event)
+		}
+	}
+	for projectPath, events := range worktreeChanges {
+		s.handleWorktreeChanges(projectPath, events)
+	}
+	for workspaceID, events := range workspaceChanges {
+		s.handleWorkspaceChanges(workspaceID, events)
+	}
+}
+
+func (s *Service) handleWorktreeChanges(projectPath string, events []fsnotify.Event) {
+	paths := make([]string, 0, len(events))
+	byPath := make(map[string]fsnotify.Event, len(events))
+	for _, event := range events {
+		path := filepath.Clean(event.Name)
+		paths = append(paths, path)
+		byPath[path] = event
+	}
+
+	kept := filterIgnoredPaths(s.runGit, projectPath, paths)
+	if len(kept) == 0 {
+		if s.ignored {
+			log.Printf("[FileWatcher] Worktree changes ignored by .gitignore: project=%s count=%d", projectPath, len(paths))
+		}
+		return
+	}
+
+	for _, path := range kept {
+		event := byPath[path]
+		if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+			s.forgetWorktreeDir(projectPath, path)
+		}
+		if event.Has(fsnotify.Create) {
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				s.watchNewWorktreeDir(projectPath, path)
+			}
+		}
+	}
+
+	s.dispatchEvent(FileEvent{
+		Type:      "worktree",
+		Path:      projectPath,
+		Timestamp: time.Now(),
+		Details: map[string]string{
+			"repoPath": projectPath,
+			"changes":  strconv.Itoa(len(kept)),
+		},
+	})
+}
+
+// watchNewWorktreeDir monitora um diretório criado (e subdiretórios) dentro do budget restante.
+func (s *Service) watchNewWorktreeDir(projectPath, dir string) {
+	s.mu.RLock()
+	watch, exists := s.watches[projectPath]
+	remaining := 0
+	if exists {
+		remaining = s.budget - watch.watchedDirs()
+	}
+	s.mu.RUnlock()
+	if !exists {
+		return
+	}
+
+	plan := planWorktreeDirs(dir, nil, remaining)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || s.watches[projectPath] != watch {
+		return
+	}
+	if plan.truncated {
+		watch.budgetExceeded = true
+	}
+	for _, path := range plan.dirs {
+		if !s.addWorktreeDirLocked(watch, path) {
+			return
+		}
+	}
+}
+
+// forgetWorktreeDir remove um diretório apagado/renomeado (e descendentes) do working tree.
+func (s *Service) forgetWorktreeDir(projectPath, dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	watch, exists := s.watches[projectPath]
+	if !exists {
+		return
+	}
+	prefix := dir + string(os.PathSeparator)
+	for path := range watch.worktreePaths {
+		if path == dir || strings.HasPrefix(path, prefix) {
+			s.removeWatchLocked(path)
+			delete(watch.worktreePaths, path)
+		}
+	}
+}
+
 func (s *Service) handleDebouncedEvent(event fsnotify.Event) {
 	// Se uma nova subpasta de refs foi criada (ex: refs/heads/feature),
 	// adicionamos watch dinâmico para não perder eventos de branches com slash.
 	if event.Has(fsnotify.Create) {
 		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
 			s.mu.Lock()
-			if !s.closed {
-				if err := s.watcher.Add(event.Name); err != nil {
-					log.Printf("[FileWatcher] Warning: could not watch new directory %s: %v", event.Name, err)
+			dir := filepath.Clean(event.Name)
+			if projectPath, inGitDir := s.locateEventLocked(dir); !s.closed && inGitDir {
+				if _, exists := s.watches[projectPath].gitPaths[dir]; !exists {
+					if err := s.addWatchLocked(dir); err != nil {
+						log.Printf("[FileWatcher] Warning: could not watch new directory %s: %v", dir, err)
+					} else {
+						s.watches[projectPath].gitPaths[dir] = struct{}{}
+					}
 				}
 			}
 			s.mu.Unlock()
@@ -231,6 +574,8 @@ func (s *Service) handleDebouncedEvent(event fsnotify.Event) {
 		}
 		return
 	}
+	// Escopo explícito do repositório: permite filtrar eventos por repo em workspaces multi-repo.
+	fileEvent.Details["repoPath"] = projectPath
 
 	if !s.shouldEmit(*fileEvent) {
 		if s.ignored {
@@ -240,21 +585,28 @@ func (s *Service) handleDebouncedEvent(event fsnotify.Event) {
 	}
 
 	log.Printf("[FileWatcher] Event: %s (%s)", fileEvent.Type, fileEvent.Path)
+	s.dispatchEvent(*fileEvent)
+}
 
-	// Notificar handlers registrados
+// dispatchEvent notifica handlers registrados e emite o evento Wails correspondente.
+func (s *Service) dispatchEvent(fileEvent FileEvent) {
 	s.mu.RLock()
 	handlers := make([]func(FileEvent), len(s.handlers))
 	copy(handlers, s.handlers)
 	s.mu.RUnlock()
 
+	s.mu.Lock()
+	s.stats.emittedEvents++
+	s.mu.Unlock()
+
 	for _, handler := range handlers {
-		handler(*fileEvent)
+		handler(fileEvent)
 	}
 
 	// Emitir evento Wails se callback configurado
 	if s.emitEvent != nil {
 		eventName := "git:" + fileEvent.Type
-		s.emitEvent(eventName, fileEvent)
+		s.emitEvent(eventName, &fileEvent)
 	}
 }
 
@@ -297,20 +649,85 @@ func semanticEventKey(event FileEvent) string {
 	return b.String()
 }
 
-// findProjectPath encontra o projectPath correspondente ao evento
+// findProjectPath encontra o projectPath cujo .git contém o evento
 func (s *Service) findProjectPath(eventPath string) string {
+	projectPath, inGitDir := s.locateEvent(eventPath)
+	if !inGitDir {
+		return ""
+	}
+	return projectPath
+}
+
+// locateEvent encontra o projeto do evento e se ele ocorreu no .git ou no working tree.
+func (s *Service) locateEvent(eventPath string) (string, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	return s.locateEventLocked(eventPath)
+}
 
+func (s *Service) locateEventLocked(eventPath string) (string, bool) {
 	cleanEventPath := filepath.Clean(eventPath)
-	for projectPath, gitDir := range s.projects {
-		cleanGitDir := filepath.Clean(gitDir)
-		if cleanEventPath == cleanGitDir ||
-			strings.HasPrefix(cleanEventPath, cleanGitDir+string(os.PathSeparator)) {
-			return projectPath
+	worktreeMatch := ""
+	for projectPath, watch := range s.watches {
+		if isWithinDir(cleanEventPath, filepath.Clean(watch.gitDir)) {
+			return projectPath, true
+		}
+		// Repos aninhados: vence o working tree mais profundo.
+		if isWithinDir(cleanEventPath, projectPath) && len(projectPath) > len(worktreeMatch) {
+			worktreeMatch = projectPath
 		}
 	}
-	return ""
+	return worktreeMatch, false
+}
+
+func (s *Service) isGitPathLocked(eventPath string) bool {
+	_, inGitDir := s.locateEventLocked(eventPath)
+	return inGitDir
+}
+
+func isWithinDir(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(os.PathSeparator))
+}
+
+// Metrics retorna diretórios monitorados e contadores de eventos do watcher.
+func (s *Service) Metrics() WatcherMetrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	metrics := WatcherMetrics{
+		Budget:        s.budget,
+		RawEvents:     s.stats.rawEvents,
+		DroppedEvents: s.stats.droppedEvents,
+		Batches:       s.stats.batches,
+		EmittedEvents: s.stats.emittedEvents,
+		PendingEvents: len(s.pending),
+		WatchedDirs:   len(s.watchRefs),
+		Repos:         make([]RepoWatchMetrics, 0, len(s.watches)),
+		Workspaces:    make([]WorkspaceWatchMetrics, 0, len(s.workspaces)),
+	}
+	for projectPath, watch := range s.watches {
+		metrics.Repos = append(metrics.Repos, RepoWatchMetrics{
+			RepoPath:       projectPath,
+			GitDirs:        len(watch.gitPaths),
+			WorktreeDirs:   len(watch.worktreePaths),
+			IgnoredDirs:    watch.ignoredDirs,
+			BudgetExceeded: watch.budgetExceeded,
+		})
+	}
+	sort.Slice(metrics.Repos, func(i, j int) bool { return metrics.Repos[i].RepoPath < metrics.Repos[j].RepoPath })
+	for workspaceID, watch := range s.workspaces {
+		metrics.Workspaces = append(metrics.Workspaces, WorkspaceWatchMetrics{
+			WorkspaceID:    workspaceID,
+			Root:           watch.root,
+			Dirs:           len(watch.paths),
+			IgnoredDirs:    watch.ignoredDirs,
+			BudgetExceeded: watch.budgetExceeded,
+		})
+	}
+	sort.Slice(metrics.Workspaces, func(i, j int) bool {
+		return metrics.Workspaces[i].WorkspaceID < metrics.Workspaces[j].WorkspaceID
+	})
+	return metrics
 }
 
 // classifyEvent classifica um evento fsnotify em um FileEvent
@@ -2,9 +2,9 @@ package filewatcher
 
 import "time"
 
-// FileEvent representa um evento detectado no .git
+// FileEvent representa um evento detectado no .git ou no working tree
 type FileEvent struct {
-	Type      string            `json:"type"`      // "branch_changed", "commit", "merge", "fetch", "index"
+	Type      string            `json:"type"`      // "branch_changed", "commit", "merge", "fetch", "index", "worktree"
 	Path      string            `json:"path"`      // Caminho do arquivo alterado
 	Timestamp time.Time         `json:"timestamp"` // Quando o evento ocorreu
 	Details   map[string]string `json:"details"`   // Detalhes extras (nova branch, ref, etc.)
@@ -38,3 +38,61 @@ type IFileWatcher interface {
 	// Close encerra todos os watchers
 	Close() error
 }
+
+// AutoFetchConfig define o auto-fetch em background de um repositório monitorado.
+type AutoFetchConfig struct {
+	RepoPath        string `json:"repoPath"`
+	Enabled         bool   `json:"enabled"`
+	IntervalSeconds int    `json:"intervalSeconds"`
+}
+
+// AutoFetchResult descreve o resultado do último fetch e o estado ahead/behind do upstream.
+type AutoFetchResult struct {
+	RepoPath  string    `json:"repoPath"`
+	Upstream  string    `json:"upstream,omitempty"` // ex: origin/main
+	Ahead     int       `json:"ahead"`
+	Behind    int       `json:"behind"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// WatcherMetrics resume o custo do file watcher: diretórios monitorados e volume de eventos.
+type WatcherMetrics struct {
+	WatchedDirs   int                     `json:"watchedDirs"` // diretórios distintos no fsnotify
+	Budget        int                     `json:"budget"`      // máximo de diretórios por repositório/workspace
+	RawEvents     uint64                  `json:"rawEvents"`
+	DroppedEvents uint64                  `json:"droppedEvents"` // descartados com o lote cheio
+	Batches       uint64                  `json:"batches"`
+	EmittedEvents uint64                  `json:"emittedEvents"`
+	PendingEvents int                     `json:"pendingEvents"`
+	Repos         []RepoWatchMetrics      `json:"repos"`
+	Workspaces    []WorkspaceWatchMetrics `json:"workspaces"`
+}
+
+// RepoWatchMetrics descreve os diretórios monitorados de um repositório.
+type RepoWatchMetrics struct {
+	RepoPath       string `json:"repoPath"`
+	GitDirs        int    `json:"gitDirs"`
+	WorktreeDirs   int    `json:"worktreeDirs"`
+	IgnoredDirs    int    `json:"ignoredDirs"` // pulados por .gitignore
+	BudgetExceeded bool   `json:"budgetExceeded"`
+}
+
+// WorkspaceWatchMetrics descreve os diretórios monitorados dos arquivos de um workspace.
+type WorkspaceWatchMetrics struct {
+	WorkspaceID    uint   `json:"workspaceId"`
+	Root           string `json:"root"`
+	Dirs           int    `json:"dirs"`
+	IgnoredDirs    int    `json:"ignoredDirs"` // pulados por .gitignore ou pela lista padrão
+	BudgetExceeded bool   `json:"budgetExceeded"`
+}
+
+// WorkspaceFileChange agrupa as edições de arquivos de um workspace numa janela de debounce.
+type WorkspaceFileChange struct {
+	WorkspaceID uint      `json:"workspaceId"`
+	Root        string    `json:"root"`
+	Paths       []string  `json:"paths"` // relativos à raiz, com "/"; limitados a maxWorkspaceChangePaths
+	Count       int       `json:"count"` // total de caminhos alterados no lote
+	Truncated   bool      `json:"truncated"`
+	Timestamp   time.Time `json:"timestamp"`
+}
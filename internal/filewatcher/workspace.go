@@ -0,0 +1,352 @@
+package filewatcher
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WorkspaceFileChangedEvent é o evento Wails emitido para edições nos arquivos de um workspace.
+const WorkspaceFileChangedEvent = "workspace:file_changed"
+
+// maxWorkspaceChangePaths limita os caminhos listados em cada WorkspaceFileChange.
+const maxWorkspaceChangePaths = 100
+
+// defaultIgnoredDirNames são pulados em qualquer workspace, com ou sem .gitignore:
+// dependências, saídas de build e caches raramente interessam às integrações.
+var defaultIgnoredDirNames = map[string]struct{}{
+	".git":         {},
+	".hg":          {},
+	".svn":         {},
+	".idea":        {},
+	".vscode":      {},
+	".cache":       {},
+	".gradle":      {},
+	".next":        {},
+	".nuxt":        {},
+	".turbo":       {},
+	".venv":        {},
+	"venv":         {},
+	"__pycache__":  {},
+	"node_modules": {},
+	"vendor":       {},
+	"dist":         {},
+	"build":        {},
+	"out":          {},
+	"target":       {},
+	"coverage":     {},
+}
+
+// IsDefaultIgnoredDir indica se o diretório é pulado por padrão nos workspaces (node_modules, dist...).
+func IsDefaultIgnoredDir(name string) bool {
+	_, ignored := defaultIgnoredDirNames[name]
+	return ignored
+}
+
+// workspaceWatch guarda os diretórios monitorados dos arquivos de um workspace.
+type workspaceWatch struct {
+	root           string
+	inGitRepo      bool                // raiz dentro de um repositório: aplica .gitignore
+	gitIgnoredDirs map[string]struct{} // diretórios ignorados pelo git na varredura inicial
+	paths          map[string]struct{}
+	ignoredDirs    int
+	budgetExceeded bool
+}
+
+// WatchWorkspaceFiles monitora os arquivos de um workspace (não precisa ser um repositório git).
+// Diretórios ignorados pelo .gitignore ou pela lista padrão ficam de fora; as edições são
+// agrupadas na janela de debounce e emitidas como workspace:file_changed.
+func (s *Service) WatchWorkspaceFiles(workspaceID uint, root string) error {
+	root = filepath.Clean(root)
+	info, err := os.Stat(root)
+	if err != nil {
+		return fmt.Errorf("workspace root not accessible: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("workspace root is not a directory: %s", root)
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return fmt.Errorf("watcher is closed")
+	}
+	if current, exists := s.workspaces[workspaceID]; exists && current.root == root {
+		s.mu.Unlock()
+		return nil
+	}
+	budget, runGit := s.budget, s.runGit
+	s.mu.Unlock()
+
+	// Fora de um repositório o git falha e valem apenas os nomes padrão.
+	gitIgnored, gitErr := listIgnoredDirs(runGit, root)
+	plan := planWorkspaceDirs(root, gitIgnored, budget)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return fmt.Errorf("watcher is closed")
+	}
+	if current, exists := s.workspaces[workspaceID]; exists {
+		if current.root == root {
+			return nil
+		}
+		s.releaseWorkspaceLocked(current)
+	}
+
+	watch := &workspaceWatch{
+		root:           root,
+		inGitRepo:      gitErr == nil,
+		gitIgnoredDirs: gitIgnored,
+		paths:          make(map[string]struct{}),
+		ignoredDirs:    plan.ignored,
+		budgetExceeded: plan.truncated,
+	}
+	for _, dir := range plan.dirs {
+		if !s.addWorkspaceDirLocked(watch, dir) {
+			break
+		}
+	}
+	s.workspaces[workspaceID] = watch
+	log.Printf("[FileWatcher] Watching files of workspace %d at %s (%d dirs, %d ignored)", workspaceID, root, len(watch.paths), watch.ignoredDirs)
+	if watch.budgetExceeded {
+		log.Printf("[FileWatcher] Watch budget reached for workspace %d (%d dirs); deeper directories are not monitored", workspaceID, s.budget)
+	}
+
+	if !s.loopOn {
+		s.loopOn = true
+		go s.eventLoop()
+	}
+	return nil
+}
+
+// UnwatchWorkspaceFiles para o monitoramento dos arquivos de um workspace.
+func (s *Service) UnwatchWorkspaceFiles(workspaceID uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	watch, exists := s.workspaces[workspaceID]
+	if !exists {
+		return
+	}
+	s.releaseWorkspaceLocked(watch)
+	delete(s.workspaces, workspaceID)
+	log.Printf("[FileWatcher] Unwatched files of workspace %d", workspaceID)
+}
+
+// WatchedWorkspaces retorna os workspaces com arquivos monitorados (id -> raiz).
+func (s *Service) WatchedWorkspaces() map[uint]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	roots := make(map[uint]string, len(s.workspaces))
+	for id, watch := range s.workspaces {
+		roots[id] = watch.root
+	}
+	return roots
+}
+
+// OnWorkspaceFileChange registra um handler para as edições de arquivos dos workspaces.
+func (s *Service) OnWorkspaceFileChange(handler func(change WorkspaceFileChange)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.wsHandlers = append(s.wsHandlers, handler)
+}
+
+func (s *Service) releaseWorkspaceLocked(watch *workspaceWatch) {
+	for dir := range watch.paths {
+		s.removeWatchLocked(dir)
+	}
+	watch.paths = make(map[string]struct{})
+}
+
+// addWorkspaceDirLocked adiciona um diretório do workspace respeitando o budget.
+// Retorna false quando o budget ou o limite do sistema (inotify/fds) foi atingido.
+func (s *Service) addWorkspaceDirLocked(watch *workspaceWatch, dir string) bool {
+	if _, exists := watch.paths[dir]; exists {
+		return true
+	}
+	if len(watch.paths) >= s.budget {
+		watch.budgetExceeded = true
+		return false
+	}
+	if err := s.addWatchLocked(dir); err != nil {
+		if isWatchLimitError(err) {
+			watch.budgetExceeded = true
+			log.Printf("[FileWatcher] Warning: system watch limit reached at %s: %v", dir, err)
+			return false
+		}
+		log.Printf("[FileWatcher] Warning: could not watch %s: %v", dir, err)
+		return true
+	}
+	watch.paths[dir] = struct{}{}
+	return true
+}
+
+// planWorkspaceDirs percorre a raiz do workspace em largura, pulando diretórios ignorados
+// pelo git e os nomes padrão. Repositórios aninhados entram: fazem parte do workspace.
+func planWorkspaceDirs(root string, gitIgnored map[string]struct{}, budget int) worktreePlan {
+	return planDirs(root, budget, func(child string) (skip, ignored bool) {
+		if _, ok := gitIgnored[child]; ok {
+			return true, true
+		}
+		_, ok := defaultIgnoredDirNames[filepath.Base(child)]
+		return ok, ok
+	})
+}
+
+// workspacesForEventLocked retorna os workspaces cuja raiz contém o caminho.
+func (s *Service) workspacesForEventLocked(eventPath string) []uint {
+	var ids []uint
+	for id, watch := range s.workspaces {
+		if isWithinDir(eventPath, watch.root) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// handleWorkspaceChanges filtra o lote de um workspace e emite um único WorkspaceFileChange.
+func (s *Service) handleWorkspaceChanges(workspaceID uint, events []fsnotify.Event) {
+	s.mu.RLock()
+	watch, exists := s.workspaces[workspaceID]
+	s.mu.RUnlock()
+	if !exists {
+		return
+	}
+
+	paths := make([]string, 0, len(events))
+	byPath := make(map[string]fsnotify.Event, len(events))
+	for _, event := range events {
+		path := filepath.Clean(event.Name)
+		rel, err := filepath.Rel(watch.root, path)
+		if err != nil || isDefaultIgnoredPath(rel) || isEditorTempFile(filepath.Base(path)) {
+			continue
+		}
+		paths = append(paths, path)
+		byPath[path] = event
+	}
+	if watch.inGitRepo {
+		paths = filterIgnoredPaths(s.runGit, watch.root, paths)
+	}
+	if len(paths) == 0 {
+		if s.ignored {
+			log.Printf("[FileWatcher] Workspace changes ignored: workspace=%d count=%d", workspaceID, len(events))
+		}
+		return
+	}
+
+	change := WorkspaceFileChange{
+		WorkspaceID: workspaceID,
+		Root:        watch.root,
+		Count:       len(paths),
+		Timestamp:   time.Now(),
+	}
+	for _, path := range paths {
+		event := byPath[path]
+		if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+			s.forgetWorkspaceDir(workspaceID, watch, path)
+		}
+		if event.Has(fsnotify.Create) {
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				s.watchNewWorkspaceDir(workspaceID, watch, path)
+			}
+		}
+		if len(change.Paths) < maxWorkspaceChangePaths {
+			rel, _ := filepath.Rel(watch.root, path)
+			change.Paths = append(change.Paths, filepath.ToSlash(rel))
+		}
+	}
+	change.Truncated = len(paths) > len(change.Paths)
+	sort.Strings(change.Paths)
+
+	s.dispatchWorkspaceChange(change)
+}
+
+// watchNewWorkspaceDir monitora um diretório criado (e subdiretórios) dentro do budget restante.
+func (s *Service) watchNewWorkspaceDir(workspaceID uint, watch *workspaceWatch, dir string) {
+	s.mu.RLock()
+	remaining := s.budget - len(watch.paths)
+	s.mu.RUnlock()
+
+	plan := planWorkspaceDirs(dir, watch.gitIgnoredDirs, remaining)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || s.workspaces[workspaceID] != watch {
+		return
+	}
+	if plan.truncated {
+		watch.budgetExceeded = true
+	}
+	for _, path := range plan.dirs {
+		if !s.addWorkspaceDirLocked(watch, path) {
+			return
+		}
+	}
+}
+
+// forgetWorkspaceDir remove um diretório apagado/renomeado (e descendentes) do workspace.
+func (s *Service) forgetWorkspaceDir(workspaceID uint, watch *workspaceWatch, dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.workspaces[workspaceID] != watch {
+		return
+	}
+	for path := range watch.paths {
+		if path != watch.root && isWithinDir(path, dir) {
+			s.removeWatchLocked(path)
+			delete(watch.paths, path)
+		}
+	}
+}
+
+// dispatchWorkspaceChange notifica handlers registrados e emite o evento Wails.
+func (s *Service) dispatchWorkspaceChange(change WorkspaceFileChange) {
+	s.mu.Lock()
+	s.stats.emittedEvents++
+	handlers := make([]func(WorkspaceFileChange), len(s.wsHandlers))
+	copy(handlers, s.wsHandlers)
+	s.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(change)
+	}
+	if s.emitEvent != nil {
+		s.emitEvent(WorkspaceFileChangedEvent, &change)
+	}
+}
+
+// isDefaultIgnoredPath indica se algum segmento do caminho relativo está na lista padrão.
+func isDefaultIgnoredPath(rel string) bool {
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return true
+	}
+	for _, segment := range strings.Split(rel, string(os.PathSeparator)) {
+		if _, ignored := defaultIgnoredDirNames[segment]; ignored {
+			return true
+		}
+	}
+	return false
+}
+
+// isEditorTempFile reconhece arquivos temporários de editores (swap, backup, escrita atômica).
+func isEditorTempFile(name string) bool {
+	switch {
+	case name == ".DS_Store" || name == "4913": // 4913: teste de escrita do vim
+		return true
+	case strings.HasSuffix(name, "~"),
+		strings.HasSuffix(name, ".swp"),
+		strings.HasSuffix(name, ".swx"),
+		strings.HasSuffix(name, ".tmp"),
+		strings.HasPrefix(name, ".#"):
+		return true
+	}
+	return false
+}
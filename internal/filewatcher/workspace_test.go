@@ -0,0 +1,133 @@
+package filewatcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func waitWorkspaceChange(t *testing.T, changes <-chan WorkspaceFileChange) WorkspaceFileChange {
+	t.Helper()
+	select {
+	case change := <-changes:
+		return change
+	case <-time.After(3 * time.Second):
+		t.Fatal("workspace:file_changed not received")
+	}
+	return WorkspaceFileChange{}
+}
+
+func TestWatchWorkspaceFilesWithoutGit(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"src", "node_modules/lib", "build"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", dir, err)
+		}
+	}
+
+	emitted := make(chan string, 16)
+	svc, err := NewService(func(eventName string, data interface{}) { emitted <- eventName })
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	defer svc.Close()
+	svc.SetDebounceWindow(20 * time.Millisecond)
+
+	changes := make(chan WorkspaceFileChange, 16)
+	svc.OnWorkspaceFileChange(func(change WorkspaceFileChange) { changes <- change })
+	if err := svc.WatchWorkspaceFiles(7, root); err != nil {
+		t.Fatalf("WatchWorkspaceFiles: %v", err)
+	}
+	metrics := svc.Metrics()
+	if len(metrics.Workspaces) != 1 || metrics.Workspaces[0].Dirs != 2 || metrics.Workspaces[0].IgnoredDirs != 2 {
+		t.Fatalf("unexpected workspace metrics: %#v", metrics.Workspaces)
+	}
+
+	// Temporários de editor não geram evento; o arquivo real sim.
+	if err := os.WriteFile(filepath.Join(root, "src", "main.go.swp"), []byte("x"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "src", "main.go"), []byte("package main"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	change := waitWorkspaceChange(t, changes)
+	if change.WorkspaceID != 7 || change.Root != root || len(change.Paths) != 1 || change.Paths[0] != "src/main.go" {
+		t.Fatalf("unexpected change: %#v", change)
+	}
+	if name := <-emitted; name != WorkspaceFileChangedEvent {
+		t.Fatalf("expected %s to be emitted, got %s", WorkspaceFileChangedEvent, name)
+	}
+
+	// Diretórios criados depois passam a ser monitorados.
+	if err := os.Mkdir(filepath.Join(root, "docs"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	waitWorkspaceChange(t, changes)
+	if err := os.WriteFile(filepath.Join(root, "docs", "README.md"), []byte("# docs"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if change := waitWorkspaceChange(t, changes); len(change.Paths) != 1 || change.Paths[0] != "docs/README.md" {
+		t.Fatalf("unexpected change in new directory: %#v", change)
+	}
+
+	svc.UnwatchWorkspaceFiles(7)
+	if metrics := svc.Metrics(); metrics.WatchedDirs != 0 || len(metrics.Workspaces) != 0 {
+		t.Fatalf("expected no watched dirs after UnwatchWorkspaceFiles, got %#v", metrics)
+	}
+}
+
+func TestWorkspaceWatchSharesDirectoriesWithRepoWatch(t *testing.T) {
+	repo := initWatcherTestRepo(t)
+	svc, err := NewService(nil)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	defer svc.Close()
+	svc.SetDebounceWindow(20 * time.Millisecond)
+
+	changes := make(chan WorkspaceFileChange, 16)
+	svc.OnWorkspaceFileChange(func(change WorkspaceFileChange) { changes <- change })
+	if err := svc.Watch(repo); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if err := svc.WatchWorkspaceFiles(1, repo); err != nil {
+		t.Fatalf("WatchWorkspaceFiles: %v", err)
+	}
+	// O repo aninhado entra no workspace; node_modules continua ignorado pelo .gitignore.
+	if dirs := svc.Metrics().Workspaces[0].Dirs; dirs != 4 {
+		t.Fatalf("expected 4 workspace dirs, got %d", dirs)
+	}
+
+	// Parar o watch do repositório não pode derrubar os diretórios compartilhados.
+	if err := svc.Unwatch(repo); err != nil {
+		t.Fatalf("Unwatch: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "src", "debug.log"), []byte("x"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "src", "pkg", "lib.go"), []byte("package pkg"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	change := waitWorkspaceChange(t, changes)
+	if len(change.Paths) != 1 || change.Paths[0] != "src/pkg/lib.go" {
+		t.Fatalf("expected gitignored file to be filtered, got %#v", change)
+	}
+}
+
+func TestIsDefaultIgnoredPath(t *testing.T) {
+	cases := map[string]bool{
+		"src/main.go":                   false,
+		"..foo":                         false,
+		".":                             true,
+		"../outside.go":                 true,
+		".git/index":                    true,
+		"web/node_modules/react/x.js":   true,
+		filepath.Join("pkg", "dist.go"): false,
+	}
+	for rel, want := range cases {
+		if got := isDefaultIgnoredPath(filepath.FromSlash(rel)); got != want {
+			t.Errorf("isDefaultIgnoredPath(%q) = %v, want %v", rel, got, want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package filewatcher
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultWatchBudget é o máximo de diretórios monitorados por repositório (.git + working tree).
+	DefaultWatchBudget = 1000
+	// maxPendingRawEvents limita o lote de eventos brutos; excedentes são descartados e contados.
+	maxPendingRawEvents = 4096
+	// maxCheckIgnorePaths limita os caminhos enviados ao git check-ignore por lote.
+	maxCheckIgnorePaths = 256
+	gitIgnoreTimeout    = 10 * time.Second
+)
+
+// repoWatch guarda os diretórios monitorados de um repositório.
+type repoWatch struct {
+	gitDir         string
+	gitPaths       map[string]struct{}
+	worktreePaths  map[string]struct{}
+	ignoredDirs    int // diretórios pulados por .gitignore
+	budgetExceeded bool
+}
+
+func newRepoWatch(gitDir string) *repoWatch {
+	return &repoWatch{
+		gitDir:        gitDir,
+		gitPaths:      make(map[string]struct{}),
+		worktreePaths: make(map[string]struct{}),
+	}
+}
+
+func (w *repoWatch) watchedDirs() int {
+	return len(w.gitPaths) + len(w.worktreePaths)
+}
+
+// worktreePlan é o resultado da varredura do working tree, calculado fora do lock do serviço.
+type worktreePlan struct {
+	dirs      []string
+	ignored   int
+	truncated bool
+}
+
+// planWorktreeDirs percorre o working tree em largura (diretórios rasos primeiro),
+// pulando .git, repositórios aninhados e diretórios ignorados pelo git.
+func planWorktreeDirs(root string, ignoredDirs map[string]struct{}, budget int) worktreePlan {
+	return planDirs(root, budget, func(child string) (skip, ignored bool) {
+		if _, ok := ignoredDirs[child]; ok {
+			return true, true
+		}
+		if _, err := os.Lstat(filepath.Join(child, ".git")); err == nil {
+			return true, false // submódulo/repo aninhado: monitorado separadamente
+		}
+		return false, false
+	})
+}
+
+// planDirs faz a varredura em largura a partir de root (sempre sem .git); skip decide
+// quais subdiretórios ficam de fora e se contam como ignorados.
+func planDirs(root string, budget int, skip func(child string) (skip, ignored bool)) worktreePlan {
+	var plan worktreePlan
+	if budget <= 0 {
+		plan.truncated = true
+		return plan
+	}
+	queue := []string{filepath.Clean(root)}
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		if len(plan.dirs) >= budget {
+			plan.truncated = true
+			break
+		}
+		plan.dirs = append(plan.dirs, dir)
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || entry.Name() == ".git" {
+				continue
+			}
+			child := filepath.Join(dir, entry.Name())
+			if skipped, ignored := skip(child); skipped {
+				if ignored {
+					plan.ignored++
+				}
+				continue
+			}
+			queue = append(queue, child)
+		}
+	}
+	return plan
+}
+
+// listIgnoredDirs retorna os diretórios ignorados (.gitignore, info/exclude e excludes globais).
+func listIgnoredDirs(runGit gitCommandRunner, projectPath string) (map[string]struct{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitIgnoreTimeout)
+	defer cancel()
+	output, err := runGit(ctx, projectPath, "ls-files", "--others", "--ignored", "--exclude-standard", "--directory", "-z")
+	if err != nil {
+		return nil, err
+	}
+	ignored := make(map[string]struct{})
+	for _, entry := range strings.Split(output, "\x00") {
+		if !strings.HasSuffix(entry, "/") {
+			continue
+		}
+		ignored[filepath.Join(projectPath, filepath.FromSlash(strings.TrimSuffix(entry, "/")))] = struct{}{}
+	}
+	return ignored, nil
+}
+
+// filterIgnoredPaths remove os caminhos ignorados pelo git. Em caso de erro, ou com caminhos
+// demais, retorna a lista original (melhor emitir a mais do que perder mudanças).
+func filterIgnoredPaths(runGit gitCommandRunner, projectPath string, paths []string) []string {
+	if len(paths) == 0 || len(paths) > maxCheckIgnorePaths {
+		return paths
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), gitIgnoreTimeout)
+	defer cancel()
+	// Sem -z (exige --stdin): caminhos com caracteres especiais saem entre aspas e são mantidos.
+	args := append([]string{"check-ignore", "--"}, paths...)
+	output, err := runGit(ctx, projectPath, args...)
+	if err != nil && output == "" {
+		return paths // exit 1 = nenhum caminho ignorado
+	}
+	ignored := make(map[string]struct{})
+	for _, entry := range strings.Split(output, "\n") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			ignored[filepath.Clean(entry)] = struct{}{}
+		}
+	}
+	kept := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if _, skip := ignored[filepath.Clean(path)]; !skip {
+			kept = append(kept, path)
+		}
+	}
+	return kept
+}
+
+// isWatchLimitError indica esgotamento de inotify watches ou descritores de arquivo.
+func isWatchLimitError(err error) bool {
+	return errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EMFILE) || errors.Is(err, syscall.ENFILE)
+}
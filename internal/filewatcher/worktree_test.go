@@ -0,0 +1,170 @@
+package filewatcher
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func initWatcherTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	if output, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, output)
+	}
+	for _, dir := range []string{"src/pkg", "node_modules/lib", "nested/.git"} {
+		if err := os.MkdirAll(filepath.Join(repo, dir), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte("node_modules/\n*.log\n"), 0o644); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	return repo
+}
+
+func TestPlanWorktreeDirsSkipsIgnoredAndNestedRepos(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"a/b/c", "ignored/x", "sub/.git"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	ignored := map[string]struct{}{filepath.Join(root, "ignored"): {}}
+
+	plan := planWorktreeDirs(root, ignored, 10)
+	if len(plan.dirs) != 4 || plan.ignored != 1 || plan.truncated {
+		t.Fatalf("unexpected plan: %#v", plan)
+	}
+
+	// Em largura: com budget 2 ficam a raiz e o primeiro nível.
+	plan = planWorktreeDirs(root, ignored, 2)
+	if len(plan.dirs) != 2 || plan.dirs[1] != filepath.Join(root, "a") || !plan.truncated {
+		t.Fatalf("unexpected truncated plan: %#v", plan)
+	}
+}
+
+func TestQueueRawEventDropsWorktreeEventsWhenBatchIsFull(t *testing.T) {
+	svc := &Service{
+		watches:        map[string]*repoWatch{"/repo": newRepoWatch("/repo/.git")},
+		pending:        make(map[string]fsnotify.Event),
+		debounceWindow: time.Hour,
+	}
+	defer func() {
+		if svc.pendingTimer != nil {
+			svc.pendingTimer.Stop()
+		}
+	}()
+	for i := 0; i < maxPendingRawEvents; i++ {
+		svc.queueRawEvent(fsnotify.Event{Name: "/repo/file" + strconv.Itoa(i), Op: fsnotify.Write})
+	}
+	svc.queueRawEvent(fsnotify.Event{Name: "/repo/overflow", Op: fsnotify.Write})
+	svc.queueRawEvent(fsnotify.Event{Name: "/repo/.git/HEAD.lock", Op: fsnotify.Create})
+	svc.queueRawEvent(fsnotify.Event{Name: "/repo/file1", Op: fsnotify.Chmod})
+
+	metrics := svc.Metrics()
+	if metrics.DroppedEvents != 1 || metrics.PendingEvents != maxPendingRawEvents+1 {
+		t.Fatalf("unexpected metrics: %#v", metrics)
+	}
+	if event := svc.pending["/repo/.git/HEAD"]; !event.Has(fsnotify.Create) {
+		t.Fatalf(".git events must never be dropped")
+	}
+	if event := svc.pending["/repo/file1"]; !event.Has(fsnotify.Write) || !event.Has(fsnotify.Chmod) {
+		t.Fatalf("expected ops to be merged, got %s", event.Op)
+	}
+}
+
+func TestWatchMonitorsWorktreeRespectingGitignore(t *testing.T) {
+	repo := initWatcherTestRepo(t)
+	svc, err := NewService(nil)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	defer svc.Close()
+	svc.SetDebounceWindow(20 * time.Millisecond)
+
+	events := make(chan FileEvent, 16)
+	svc.OnChange(func(event FileEvent) {
+		if event.Type == "worktree" {
+			events <- event
+		}
+	})
+	if err := svc.Watch(repo); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	metrics := svc.Metrics()
+	if len(metrics.Repos) != 1 {
+		t.Fatalf("expected one repo, got %#v", metrics)
+	}
+	repoMetrics := metrics.Repos[0]
+	// raiz, src, src/pkg (node_modules ignorado, nested é outro repo)
+	if repoMetrics.WorktreeDirs != 3 || repoMetrics.IgnoredDirs != 1 || repoMetrics.BudgetExceeded || repoMetrics.GitDirs == 0 {
+		t.Fatalf("unexpected repo metrics: %#v", repoMetrics)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "src", "debug.log"), []byte("x"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("ignored file should not emit worktree event: %#v", event)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "src", "main.go"), []byte("package main"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	select {
+	case event := <-events:
+		if event.Details["repoPath"] != repo || event.Details["changes"] == "" {
+			t.Fatalf("unexpected worktree event: %#v", event)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("worktree event not received")
+	}
+
+	if err := os.Mkdir(filepath.Join(repo, "docs"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for svc.Metrics().Repos[0].WorktreeDirs != 4 {
+		if time.Now().After(deadline) {
+			t.Fatalf("new directory not watched: %#v", svc.Metrics().Repos[0])
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if err := svc.Unwatch(repo); err != nil {
+		t.Fatalf("Unwatch: %v", err)
+	}
+	if metrics := svc.Metrics(); metrics.WatchedDirs != 0 || len(metrics.Repos) != 0 {
+		t.Fatalf("expected no watched dirs after Unwatch, got %#v", metrics)
+	}
+}
+
+func TestWatchBudgetLimitsDirectories(t *testing.T) {
+	repo := initWatcherTestRepo(t)
+	svc, err := NewService(nil)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	defer svc.Close()
+
+	svc.SetWatchBudget(1)
+	if err := svc.Watch(repo); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	repoMetrics := svc.Metrics().Repos[0]
+	if repoMetrics.WorktreeDirs != 0 || !repoMetrics.BudgetExceeded {
+		t.Fatalf("expected budget to block worktree dirs, got %#v", repoMetrics)
+	}
+}
@@ -0,0 +1,412 @@
+// Package focus implementa o timer de foco (Pomodoro) por workspace: fases de foco e pausa,
+// Não Perturbe das notificações durante o foco e o registro das sessões para o digest.
+package focus
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Fases do timer.
+const (
+	PhaseIdle       = "idle"
+	PhaseFocus      = "focus"
+	PhaseShortBreak = "short_break"
+	PhaseLongBreak  = "long_break"
+)
+
+// Eventos emitidos ao frontend.
+const (
+	EventTick         = "focus:tick"          // a cada segundo e ao pausar/retomar
+	EventPhaseChanged = "focus:phase_changed" // início, troca de fase e parada
+)
+
+const (
+	defaultFocusMinutes      = 25
+	defaultShortBreakMinutes = 5
+	defaultLongBreakMinutes  = 15
+	defaultCyclesBeforeLong  = 4
+
+	// Focos interrompidos mais curtos que isso não entram no registro.
+	minRecordedFocus  = time.Minute
+	maxHeldDeliveries = 100
+)
+
+// Options configura um ciclo de foco. Campos zerados usam o padrão (25/5/15 min, pausa longa a cada 4 focos).
+type Options struct {
+	FocusMinutes          int  `json:"focusMinutes"`
+	ShortBreakMinutes     int  `json:"shortBreakMinutes"`
+	LongBreakMinutes      int  `json:"longBreakMinutes"`
+	CyclesBeforeLongBreak int  `json:"cyclesBeforeLongBreak"`
+	DoNotDisturb          bool `json:"doNotDisturb"` // segura as notificações enquanto o foco corre
+}
+
+// State é o estado atual do timer, enviado nos eventos e exibido na barra de status do terminal.
+type State struct {
+	Phase             string    `json:"phase"`
+	PreviousPhase     string    `json:"previousPhase,omitempty"` // só em focus:phase_changed
+	WorkspaceID       uint      `json:"workspaceId,omitempty"`
+	Paused            bool      `json:"paused"`
+	RemainingSeconds  int       `json:"remainingSeconds"`
+	DurationSeconds   int       `json:"durationSeconds"`
+	CompletedCycles   int       `json:"completedCycles"`
+	DoNotDisturb      bool      `json:"doNotDisturb"` // notificações retidas agora
+	HeldNotifications int       `json:"heldNotifications"`
+	PhaseStartedAt    time.Time `json:"phaseStartedAt,omitempty"`
+	Options           Options   `json:"options"`
+}
+
+// Session é um período de foco registrado (completo ou interrompido).
+type Session struct {
+	WorkspaceID    uint      `json:"workspaceId"`
+	StartedAt      time.Time `json:"startedAt"`
+	EndedAt        time.Time `json:"endedAt"`
+	FocusedSeconds int       `json:"focusedSeconds"` // sem o tempo pausado
+	Completed      bool      `json:"completed"`
+}
+
+// Timer é um timer de foco único para o app. Só um ciclo corre por vez.
+type Timer struct {
+	mu           sync.Mutex
+	emit         func(eventName string, data interface{})
+	now          func() time.Time
+	tickInterval time.Duration
+	maxSessions  int
+
+	opts           Options
+	phase          string
+	workspaceID    uint
+	paused         bool
+	remaining      time.Duration
+	duration       time.Duration
+	cycles         int
+	phaseStartedAt time.Time
+	lastTick       time.Time
+	focused        time.Duration
+	ticker         chan struct{} // fecha para parar a goroutine de tick
+
+	held     []func()
+	sessions []Session
+}
+
+// NewTimer cria o timer guardando até maxSessions sessões; emit pode ser nil.
+func NewTimer(maxSessions int, emit func(eventName string, data interface{})) *Timer {
+	if emit == nil {
+		emit = func(string, interface{}) {}
+	}
+	if maxSessions <= 0 {
+		maxSessions = 200
+	}
+	return &Timer{
+		emit:         emit,
+		now:          time.Now,
+		tickInterval: time.Second,
+		maxSessions:  maxSessions,
+		phase:        PhaseIdle,
+	}
+}
+
+// Start inicia um ciclo de foco no workspace.
+func (t *Timer) Start(workspaceID uint, opts Options) (State, error) {
+	opts, err := normalizeOptions(opts)
+	if err != nil {
+		return State{}, err
+	}
+	t.mu.Lock()
+	if t.phase != PhaseIdle {
+		t.mu.Unlock()
+		return State{}, fmt.Errorf("focus timer already running; stop it first")
+	}
+	t.opts = opts
+	t.workspaceID = workspaceID
+	t.cycles = 0
+	t.enterPhaseLocked(PhaseFocus, t.now())
+	t.startTickerLocked()
+	state := t.stateLocked()
+	t.mu.Unlock()
+
+	state.PreviousPhase = PhaseIdle
+	t.emit(EventPhaseChanged, state)
+	return state, nil
+}
+
+// Pause congela o tempo restante. Notificações retidas são entregues (pausa não é foco).
+func (t *Timer) Pause() (State, error) {
+	t.mu.Lock()
+	if t.phase == PhaseIdle {
+		t.mu.Unlock()
+		return State{}, fmt.Errorf("focus timer is not running")
+	}
+	if !t.paused {
+		t.elapseLocked(t.now())
+		t.paused = true
+		t.stopTickerLocked()
+	}
+	deliveries := t.releaseLocked()
+	state := t.stateLocked()
+	t.mu.Unlock()
+
+	t.emit(EventTick, state)
+	deliver(deliveries)
+	return state, nil
+}
+
+// Resume retoma um timer pausado.
+func (t *Timer) Resume() (State, error) {
+	t.mu.Lock()
+	if t.phase == PhaseIdle {
+		t.mu.Unlock()
+		return State{}, fmt.Errorf("focus timer is not running")
+	}
+	if t.paused {
+		t.paused = false
+		t.lastTick = t.now()
+		t.startTickerLocked()
+	}
+	state := t.stateLocked()
+	t.mu.Unlock()
+
+	t.emit(EventTick, state)
+	return state, nil
+}
+
+// Stop encerra o ciclo. Um foco interrompido com pelo menos um minuto é registrado.
+func (t *Timer) Stop() State {
+	t.mu.Lock()
+	previous := t.phase
+	if previous == PhaseIdle {
+		state := t.stateLocked()
+		t.mu.Unlock()
+		return state
+	}
+	now := t.now()
+	if !t.paused {
+		t.elapseLocked(now)
+	}
+	if previous == PhaseFocus && t.focused >= minRecordedFocus {
+		t.recordLocked(now, false)
+	}
+	t.stopTickerLocked()
+	t.phase = PhaseIdle
+	t.paused = false
+	t.remaining, t.duration, t.focused = 0, 0, 0
+	deliveries := t.releaseLocked()
+	state := t.stateLocked()
+	t.mu.Unlock()
+
+	state.PreviousPhase = previous
+	t.emit(EventPhaseChanged, state)
+	deliver(deliveries)
+	return state
+}
+
+// State retorna o estado atual.
+func (t *Timer) State() State {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stateLocked()
+}
+
+// Sessions retorna os focos registrados desde since (workspaceID 0 = todos).
+func (t *Timer) Sessions(since time.Time, workspaceID uint) []Session {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make([]Session, 0, len(t.sessions))
+	for _, session := range t.sessions {
+		if session.EndedAt.Before(since) || (workspaceID != 0 && session.WorkspaceID != workspaceID) {
+			continue
+		}
+		result = append(result, session)
+	}
+	return result
+}
+
+// Defer segura deliver enquanto o Não Perturbe estiver ativo e retorna true; fora do foco
+// retorna false e quem chamou entrega na hora. As entregas retidas rodam ao fim do foco.
+func (t *Timer) Defer(deliver func()) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.doNotDisturbLocked() {
+		return false
+	}
+	if len(t.held) == maxHeldDeliveries {
+		t.held = t.held[1:]
+	}
+	t.held = append(t.held, deliver)
+	return true
+}
+
+// Close para a goroutine de tick sem registrar nada (encerramento do app).
+func (t *Timer) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopTickerLocked()
+}
+
+// advance desconta o tempo decorrido e troca de fase quando o tempo acaba.
+func (t *Timer) advance(now time.Time) {
+	t.mu.Lock()
+	if t.phase == PhaseIdle || t.paused {
+		t.mu.Unlock()
+		return
+	}
+	t.elapseLocked(now)
+	if t.remaining > 0 {
+		state := t.stateLocked()
+		t.mu.Unlock()
+		t.emit(EventTick, state)
+		return
+	}
+
+	previous := t.phase
+	var deliveries []func()
+	if previous == PhaseFocus {
+		t.cycles++
+		t.recordLocked(now, true)
+		next := PhaseShortBreak
+		if t.cycles%t.opts.CyclesBeforeLongBreak == 0 {
+			next = PhaseLongBreak
+		}
+		t.enterPhaseLocked(next, now)
+		deliveries = t.releaseLocked()
+	} else {
+		t.enterPhaseLocked(PhaseFocus, now)
+	}
+	state := t.stateLocked()
+	t.mu.Unlock()
+
+	state.PreviousPhase = previous
+	t.emit(EventPhaseChanged, state)
+	deliver(deliveries)
+}
+
+func (t *Timer) enterPhaseLocked(phase string, now time.Time) {
+	minutes := t.opts.FocusMinutes
+	switch phase {
+	case PhaseShortBreak:
+		minutes = t.opts.ShortBreakMinutes
+	case PhaseLongBreak:
+		minutes = t.opts.LongBreakMinutes
+	}
+	t.phase = phase
+	t.duration = time.Duration(minutes) * time.Minute
+	t.remaining = t.duration
+	t.phaseStartedAt = now
+	t.lastTick = now
+	t.focused = 0
+}
+
+func (t *Timer) elapseLocked(now time.Time) {
+	elapsed := now.Sub(t.lastTick)
+	t.lastTick = now
+	if elapsed <= 0 {
+		return
+	}
+	if elapsed > t.remaining {
+		elapsed = t.remaining
+	}
+	t.remaining -= elapsed
+	if t.phase == PhaseFocus {
+		t.focused += elapsed
+	}
+}
+
+func (t *Timer) recordLocked(now time.Time, completed bool) {
+	t.sessions = append(t.sessions, Session{
+		WorkspaceID:    t.workspaceID,
+		StartedAt:      t.phaseStartedAt,
+		EndedAt:        now,
+		FocusedSeconds: int(t.focused / time.Second),
+		Completed:      completed,
+	})
+	if len(t.sessions) > t.maxSessions {
+		t.sessions = t.sessions[len(t.sessions)-t.maxSessions:]
+	}
+}
+
+func (t *Timer) doNotDisturbLocked() bool {
+	return t.phase == PhaseFocus && !t.paused && t.opts.DoNotDisturb
+}
+
+func (t *Timer) releaseLocked() []func() {
+	held := t.held
+	t.held = nil
+	return held
+}
+
+func (t *Timer) stateLocked() State {
+	state := State{
+		Phase:             t.phase,
+		Paused:            t.paused,
+		CompletedCycles:   t.cycles,
+		DoNotDisturb:      t.doNotDisturbLocked(),
+		HeldNotifications: len(t.held),
+	}
+	if t.phase != PhaseIdle {
+		state.WorkspaceID = t.workspaceID
+		state.RemainingSeconds = int((t.remaining + time.Second - 1) / time.Second)
+		state.DurationSeconds = int(t.duration / time.Second)
+		state.PhaseStartedAt = t.phaseStartedAt
+		state.Options = t.opts
+	}
+	return state
+}
+
+func (t *Timer) startTickerLocked() {
+	if t.ticker != nil {
+		return
+	}
+	stop := make(chan struct{})
+	t.ticker = stop
+	interval := t.tickInterval
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				t.advance(t.now())
+			}
+		}
+	}()
+}
+
+func (t *Timer) stopTickerLocked() {
+	if t.ticker != nil {
+		close(t.ticker)
+		t.ticker = nil
+	}
+}
+
+func normalizeOptions(opts Options) (Options, error) {
+	if opts.FocusMinutes == 0 {
+		opts.FocusMinutes = defaultFocusMinutes
+	}
+	if opts.ShortBreakMinutes == 0 {
+		opts.ShortBreakMinutes = defaultShortBreakMinutes
+	}
+	if opts.LongBreakMinutes == 0 {
+		opts.LongBreakMinutes = defaultLongBreakMinutes
+	}
+	if opts.CyclesBeforeLongBreak == 0 {
+		opts.CyclesBeforeLongBreak = defaultCyclesBeforeLong
+	}
+	switch {
+	case opts.FocusMinutes < 1 || opts.FocusMinutes > 240:
+		return Options{}, fmt.Errorf("focus duration must be between 1 and 240 minutes")
+	case opts.ShortBreakMinutes < 1 || opts.ShortBreakMinutes > 60 || opts.LongBreakMinutes < 1 || opts.LongBreakMinutes > 60:
+		return Options{}, fmt.Errorf("break duration must be between 1 and 60 minutes")
+	case opts.CyclesBeforeLongBreak < 1 || opts.CyclesBeforeLongBreak > 12:
+		return Options{}, fmt.Errorf("cycles before long break must be between 1 and 12")
+	}
+	return opts, nil
+}
+
+func deliver(deliveries []func()) {
+	for _, fn := range deliveries {
+		fn()
+	}
+}
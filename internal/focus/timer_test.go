@@ -0,0 +1,164 @@
+package focus
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordedEvent struct {
+	name  string
+	state State
+}
+
+func newTestTimer(t *testing.T) (*Timer, *time.Time, func() []recordedEvent) {
+	t.Helper()
+	var mu sync.Mutex
+	var events []recordedEvent
+	timer := NewTimer(10, func(name string, data interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, recordedEvent{name, data.(State)})
+	})
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	timer.now = func() time.Time { return now }
+	timer.tickInterval = time.Hour // os testes avançam o relógio manualmente
+	t.Cleanup(timer.Close)
+	return timer, &now, func() []recordedEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedEvent{}, events...)
+	}
+}
+
+func TestTimerCyclesPhasesAndRecordsCompletedFocus(t *testing.T) {
+	timer, now, events := newTestTimer(t)
+	state, err := timer.Start(7, Options{FocusMinutes: 25, ShortBreakMinutes: 5, LongBreakMinutes: 15, CyclesBeforeLongBreak: 2})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if state.Phase != PhaseFocus || state.RemainingSeconds != 25*60 || state.WorkspaceID != 7 {
+		t.Fatalf("unexpected initial state: %+v", state)
+	}
+	if _, err := timer.Start(7, Options{}); err == nil {
+		t.Fatalf("expected second Start to fail while running")
+	}
+
+	*now = now.Add(10 * time.Minute)
+	timer.advance(*now)
+	if got := timer.State(); got.RemainingSeconds != 15*60 {
+		t.Fatalf("expected 15 minutes left, got %+v", got)
+	}
+
+	*now = now.Add(15 * time.Minute)
+	timer.advance(*now)
+	if got := timer.State(); got.Phase != PhaseShortBreak || got.CompletedCycles != 1 {
+		t.Fatalf("expected short break after first focus, got %+v", got)
+	}
+	*now = now.Add(5 * time.Minute)
+	timer.advance(*now)
+	*now = now.Add(25 * time.Minute)
+	timer.advance(*now)
+	if got := timer.State(); got.Phase != PhaseLongBreak || got.CompletedCycles != 2 {
+		t.Fatalf("expected long break after second focus, got %+v", got)
+	}
+
+	sessions := timer.Sessions(time.Time{}, 7)
+	if len(sessions) != 2 || !sessions[0].Completed || sessions[0].FocusedSeconds != 25*60 {
+		t.Fatalf("unexpected sessions: %+v", sessions)
+	}
+	if len(timer.Sessions(time.Time{}, 8)) != 0 {
+		t.Fatalf("sessions should be filtered by workspace")
+	}
+
+	var phases []string
+	for _, event := range events() {
+		if event.name == EventPhaseChanged {
+			phases = append(phases, event.state.PreviousPhase+">"+event.state.Phase)
+		}
+	}
+	want := []string{"idle>focus", "focus>short_break", "short_break>focus", "focus>long_break"}
+	if len(phases) != len(want) {
+		t.Fatalf("unexpected phase events: %v", phases)
+	}
+	for i := range want {
+		if phases[i] != want[i] {
+			t.Fatalf("unexpected phase events: %v", phases)
+		}
+	}
+}
+
+func TestTimerPauseExcludesTimeAndStopRecordsPartialFocus(t *testing.T) {
+	timer, now, _ := newTestTimer(t)
+	if _, err := timer.Pause(); err == nil {
+		t.Fatalf("expected Pause to fail when idle")
+	}
+	if _, err := timer.Start(1, Options{}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	*now = now.Add(5 * time.Minute)
+	if state, _ := timer.Pause(); !state.Paused || state.RemainingSeconds != 20*60 {
+		t.Fatalf("unexpected paused state: %+v", state)
+	}
+	*now = now.Add(time.Hour)
+	timer.advance(*now)
+	if _, err := timer.Resume(); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	*now = now.Add(3 * time.Minute)
+	state := timer.Stop()
+	if state.Phase != PhaseIdle || state.PreviousPhase != PhaseFocus {
+		t.Fatalf("unexpected stopped state: %+v", state)
+	}
+	sessions := timer.Sessions(time.Time{}, 0)
+	if len(sessions) != 1 || sessions[0].Completed || sessions[0].FocusedSeconds != 8*60 {
+		t.Fatalf("expected one partial session of 8 minutes, got %+v", sessions)
+	}
+
+	// Focos curtos demais não entram no registro.
+	timer.Start(1, Options{})
+	*now = now.Add(30 * time.Second)
+	timer.Stop()
+	if len(timer.Sessions(time.Time{}, 0)) != 1 {
+		t.Fatalf("short focus should not be recorded")
+	}
+}
+
+func TestTimerDoNotDisturbHoldsDeliveriesUntilFocusEnds(t *testing.T) {
+	timer, now, _ := newTestTimer(t)
+	delivered := 0
+	if timer.Defer(func() { delivered++ }) {
+		t.Fatalf("Defer should not hold while idle")
+	}
+
+	timer.Start(1, Options{FocusMinutes: 1, DoNotDisturb: true})
+	if !timer.Defer(func() { delivered++ }) || !timer.Defer(func() { delivered++ }) {
+		t.Fatalf("Defer should hold during focus with do-not-disturb")
+	}
+	if state := timer.State(); !state.DoNotDisturb || state.HeldNotifications != 2 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	*now = now.Add(time.Minute)
+	timer.advance(*now)
+	if delivered != 2 {
+		t.Fatalf("expected held deliveries to run when focus ends, got %d", delivered)
+	}
+	if timer.Defer(func() { delivered++ }) {
+		t.Fatalf("Defer should not hold during a break")
+	}
+	timer.Stop()
+
+	timer.Start(1, Options{})
+	if timer.Defer(func() {}) {
+		t.Fatalf("Defer should not hold when do-not-disturb is off")
+	}
+}
+
+func TestStartRejectsInvalidOptions(t *testing.T) {
+	timer, _, _ := newTestTimer(t)
+	for _, opts := range []Options{{FocusMinutes: 500}, {ShortBreakMinutes: -1}, {CyclesBeforeLongBreak: 20}} {
+		if _, err := timer.Start(1, opts); err == nil {
+			t.Fatalf("expected %+v to be rejected", opts)
+		}
+	}
+}
@@ -192,5 +192,14 @@ func cloneEvent(event Event) Event {
 	if len(event.Details.Files) > 0 {
 		cloned.Details.Files = append([]EventFile(nil), event.Details.Files...)
 	}
+	if len(event.Issues) > 0 {
+		cloned.Issues = append([]IssueLink(nil), event.Issues...)
+	}
+	if event.MessageParams != nil {
+		cloned.MessageParams = make(map[string]string, len(event.MessageParams))
+		for k, v := range event.MessageParams {
+			cloned.MessageParams[k] = v
+		}
+	}
 	return cloned
 }
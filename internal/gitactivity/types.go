@@ -47,6 +47,19 @@ type Event struct {
 	Source    string       `json:"source,omitempty"`
 	DedupeKey string       `json:"dedupeKey,omitempty"`
 	Details   EventDetails `json:"details,omitempty"`
+	Issues    []IssueLink  `json:"issues,omitempty"` // tickets citados na branch/mensagem, preenchidos na listagem
+
+	// ID e parâmetros de Message no catálogo i18n, para o frontend renderizar em outro idioma.
+	MessageID     string            `json:"messageId,omitempty"`
+	MessageParams map[string]string `json:"messageParams,omitempty"`
+}
+
+// IssueLink é um ticket de issue tracker (Jira/Linear) associado ao evento.
+type IssueLink struct {
+	Key    string `json:"key"`
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status,omitempty"`
+	URL    string `json:"url,omitempty"`
 }
 
 // ListOptions controla filtros da listagem.
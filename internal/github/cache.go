@@ -20,6 +20,7 @@ type Cache struct {
 	reviews   map[string][]Review      // key: "owner/repo/prNumber"
 	comments  map[string][]Comment     // key: "owner/repo/prNumber"
 	repos     []Repository
+	dashboard *PullRequestDashboard
 	updatedAt map[string]time.Time
 	etags     map[string]string
 	ttl       time.Duration
@@ -44,6 +45,16 @@ func NewCache(ttl time.Duration) *Cache {
 	}
 }
 
+// SetTTL altera o TTL das entradas (ttl <= 0 é ignorado).
+func (c *Cache) SetTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	c.ttl = ttl
+	c.mu.Unlock()
+}
+
 // isExpired verifica se uma entrada do cache expirou
 func (c *Cache) isExpired(key string) bool {
 	t, ok := c.updatedAt[key]
@@ -382,6 +393,29 @@ func (c *Cache) SetBranches(owner, repo string, branches []Branch) {
 	c.updatedAt[key] = time.Now()
 }
 
+// GetProtectedBranches retorna as branches protegidas cacheadas
+func (c *Cache) GetProtectedBranches(owner, repo string) ([]Branch, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	key := owner + "/" + repo + "/protected-branches"
+	if c.isExpired(key) {
+		return nil, false
+	}
+	branches, ok := c.branches[key]
+	return branches, ok
+}
+
+// SetProtectedBranches armazena branches protegidas no cache
+func (c *Cache) SetProtectedBranches(owner, repo string, branches []Branch) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := owner + "/" + repo + "/protected-branches"
+	c.branches[key] = branches
+	c.updatedAt[key] = time.Now()
+}
+
 // === Reviews ===
 
 // GetReviews retorna reviews cacheados
@@ -457,6 +491,28 @@ func (c *Cache) SetRepos(repos []Repository) {
 	c.updatedAt["repos"] = time.Now()
 }
 
+// === Dashboard ===
+
+// GetDashboard retorna o dashboard de PRs cacheado
+func (c *Cache) GetDashboard() (*PullRequestDashboard, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.isExpired("dashboard") || c.dashboard == nil {
+		return nil, false
+	}
+	return c.dashboard, true
+}
+
+// SetDashboard armazena o dashboard de PRs no cache
+func (c *Cache) SetDashboard(dashboard *PullRequestDashboard) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.dashboard = dashboard
+	c.updatedAt["dashboard"] = time.Now()
+}
+
 // === Cache Management ===
 
 // InvalidatePRLists remove entradas de listagem de PRs de um repositorio.
@@ -488,6 +544,9 @@ func (c *Cache) InvalidatePRMutation(owner, repo string, prNumber int) {
 	if prNumber > 0 {
 		c.invalidatePRDetailLocked(owner, repo, prNumber)
 	}
+	// O dashboard cruza repositórios; qualquer mutação de PR pode mudar suas listas.
+	c.dashboard = nil
+	c.deleteCacheMetadataLocked("dashboard")
 }
 
 // Invalidate remove todas as entradas de um repositório do cache
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const prActionRequestReviewers = "pr_request_reviewers"
+
+// codeOwnersPaths segue a ordem em que o GitHub procura o CODEOWNERS.
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeOwnersFile é o CODEOWNERS lido pela API.
+type CodeOwnersFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// GetCodeOwnersFile busca o CODEOWNERS do repositório na ref (vazia = branch padrão). Devolve
+// nil sem erro quando o repositório não tem o arquivo.
+func (s *Service) GetCodeOwnersFile(ctx context.Context, owner, repo, ref string) (*CodeOwnersFile, error) {
+	normalizedOwner, normalizedRepo, err := normalizeOwnerRepoForPR(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if ref = strings.TrimSpace(ref); ref != "" {
+		query.Set("ref", ref)
+	}
+	for _, candidate := range codeOwnersPaths {
+		var response struct {
+			Type     string `json:"type"`
+			Content  string `json:"content"`
+			Encoding string `json:"encoding"`
+		}
+		endpoint := fmt.Sprintf("/repos/%s/%s/contents/%s", url.PathEscape(normalizedOwner), url.PathEscape(normalizedRepo), candidate)
+		if err := s.getRESTJSON(ctx, endpoint, query, &response); err != nil {
+			var githubErr *GitHubError
+			if errors.As(err, &githubErr) && githubErr.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, err
+		}
+		if response.Type != "file" || response.Encoding != "base64" {
+			continue
+		}
+		content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(response.Content, "\n", ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", candidate, err)
+		}
+		return &CodeOwnersFile{Path: candidate, Content: string(content)}, nil
+	}
+	return nil, nil
+}
+
+// RequestReviewers pede review a usuários (logins) e times (slugs) num PR.
+func (s *Service) RequestReviewers(ctx context.Context, owner, repo string, number int, reviewers, teamReviewers []string) error {
+	normalizedOwner, normalizedRepo, err := normalizeOwnerRepoForPR(owner, repo)
+	if err != nil {
+		return err
+	}
+	if number <= 0 {
+		return &GitHubError{StatusCode: http.StatusUnprocessableEntity, Message: "pull request number is required", Type: "validation"}
+	}
+	if len(reviewers) == 0 && len(teamReviewers) == 0 {
+		return nil
+	}
+	payload := map[string]interface{}{
+		"reviewers":      nonNilStrings(reviewers),
+		"team_reviewers": nonNilStrings(teamReviewers),
+	}
+	endpoint := fmt.Sprintf("/repos/%s/%s/pulls/%d/requested_reviewers", url.PathEscape(normalizedOwner), url.PathEscape(normalizedRepo), number)
+	if err := s.executePRRESTJSON(ctx, prActionRequestReviewers, http.MethodPost, endpoint, nil, payload, nil); err != nil {
+		return err
+	}
+	s.cache.Invalidate(normalizedOwner, normalizedRepo)
+	log.Printf("[GitHub] Requested %d reviewer(s) and %d team(s) on %s/%s#%d", len(reviewers), len(teamReviewers), normalizedOwner, normalizedRepo, number)
+	return nil
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetCodeOwnersFileFallsBackThroughPaths(t *testing.T) {
+	var requests []string
+	service := NewService(func() (string, error) {
+		return "gh-token", nil
+	})
+	service.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.URL.Path+"?"+req.URL.RawQuery)
+			status, body := http.StatusNotFound, `{"message":"Not Found"}`
+			if req.URL.Path == "/repos/orch-labs/orch/contents/CODEOWNERS" {
+				encoded := base64.StdEncoding.EncodeToString([]byte("* @ana\n"))
+				status, body = http.StatusOK, `{"type":"file","encoding":"base64","content":"`+encoded[:4]+`\n`+encoded[4:]+`"}`
+			}
+			return &http.Response{StatusCode: status, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(body))}, nil
+		}),
+	}
+
+	file, err := service.GetCodeOwnersFile(context.Background(), "orch-labs", "orch", "main")
+	if err != nil || file == nil || file.Path != "CODEOWNERS" || file.Content != "* @ana\n" {
+		t.Fatalf("unexpected CODEOWNERS: %+v (%v)", file, err)
+	}
+	want := "/repos/orch-labs/orch/contents/.github/CODEOWNERS?ref=main,/repos/orch-labs/orch/contents/CODEOWNERS?ref=main"
+	if got := strings.Join(requests, ","); got != want {
+		t.Fatalf("unexpected requests: %s", got)
+	}
+}
+
+func TestRequestReviewersSendsUsersAndTeams(t *testing.T) {
+	var requests []string
+	var payload map[string][]string
+	service := NewService(func() (string, error) {
+		return "gh-token", nil
+	})
+	service.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			raw, _ := io.ReadAll(req.Body)
+			_ = json.Unmarshal(raw, &payload)
+			return &http.Response{StatusCode: http.StatusCreated, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(`{"number":42}`))}, nil
+		}),
+	}
+
+	if err := service.RequestReviewers(context.Background(), "orch-labs", "orch", 42, nil, nil); err != nil || len(requests) != 0 {
+		t.Fatalf("empty reviewer list should be a no-op, got requests=%v err=%v", requests, err)
+	}
+	if err := service.RequestReviewers(context.Background(), "orch-labs", "orch", 42, []string{"ana"}, []string{"core"}); err != nil {
+		t.Fatalf("RequestReviewers() error: %v", err)
+	}
+	if got := strings.Join(requests, ","); got != "POST /repos/orch-labs/orch/pulls/42/requested_reviewers" {
+		t.Fatalf("unexpected requests: %s", got)
+	}
+	if strings.Join(payload["reviewers"], ",") != "ana" || strings.Join(payload["team_reviewers"], ",") != "core" {
+		t.Fatalf("unexpected payload: %v", payload)
+	}
+}
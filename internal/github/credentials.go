@@ -0,0 +1,379 @@
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// === Credenciais: PAT e GitHub App ===
+//
+// Além do OAuth, contas podem usar PAT (clássico ou fine-grained) ou instalação de GitHub App.
+// A introspecção reaproveita os headers de escopo (X-OAuth-Scopes) já usados nas dicas de 403.
+
+// Tipos de credencial GitHub.
+const (
+	CredentialKindOAuth          = "oauth"
+	CredentialKindClassicPAT     = "pat_classic"
+	CredentialKindFineGrainedPAT = "pat_fine_grained"
+	CredentialKindApp            = "app"
+)
+
+const (
+	githubAppJWTLifetime    = 9 * time.Minute
+	githubTokenExpiryLayout = "2006-01-02 15:04:05 MST"
+)
+
+// TokenIntrospection descreve um token validado em GET /user.
+type TokenIntrospection struct {
+	Login     string     `json:"login"`
+	Name      string     `json:"name,omitempty"`
+	AvatarURL string     `json:"avatarUrl,omitempty"`
+	Kind      string     `json:"kind"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// AppInstallationToken é o token de instalação emitido para um GitHub App.
+type AppInstallationToken struct {
+	Token               string            `json:"-"`
+	ExpiresAt           time.Time         `json:"expiresAt"`
+	Permissions         map[string]string `json:"permissions,omitempty"`
+	RepositorySelection string            `json:"repositorySelection,omitempty"`
+	AccountLogin        string            `json:"accountLogin"`
+	AccountAvatarURL    string            `json:"accountAvatarUrl,omitempty"`
+	AppSlug             string            `json:"appSlug,omitempty"`
+}
+
+// CredentialPermissions é o que se sabe sobre o acesso de uma credencial.
+type CredentialPermissions struct {
+	Kind        string            `json:"kind"`
+	Scopes      []string          `json:"scopes,omitempty"`      // OAuth / PAT clássico
+	Permissions map[string]string `json:"permissions,omitempty"` // GitHub App
+}
+
+// CredentialPermissionCheck compara as permissões exigidas por uma operação de PR com a credencial.
+// Verifiable=false quando não há como saber antes da chamada (ex.: PAT fine-grained).
+type CredentialPermissionCheck struct {
+	Kind       string   `json:"kind"`
+	Required   []string `json:"required"`
+	Missing    []string `json:"missing,omitempty"`
+	Verifiable bool     `json:"verifiable"`
+	Warning    string   `json:"warning,omitempty"`
+}
+
+// CredentialWarningEvent é emitido quando uma operação de PR usa credencial sem permissão suficiente.
+type CredentialWarningEvent struct {
+	Owner    string                    `json:"owner"`
+	Method   string                    `json:"method"`
+	Endpoint string                    `json:"endpoint"`
+	Check    CredentialPermissionCheck `json:"check"`
+}
+
+// ClassifyToken identifica o tipo do token pelo prefixo documentado pelo GitHub.
+func ClassifyToken(token string) string {
+	token = strings.TrimSpace(token)
+	switch {
+	case strings.HasPrefix(token, "github_pat_"):
+		return CredentialKindFineGrainedPAT
+	case strings.HasPrefix(token, "ghp_"):
+		return CredentialKindClassicPAT
+	case strings.HasPrefix(token, "ghs_"):
+		return CredentialKindApp
+	case strings.HasPrefix(token, "gho_"), strings.HasPrefix(token, "ghu_"):
+		return CredentialKindOAuth
+	default:
+		// Tokens legados (40 hex) são PATs clássicos.
+		return CredentialKindClassicPAT
+	}
+}
+
+// SetCredentialInspector registra como descobrir a credencial usada para um owner; requests de PR
+// com permissões insuficientes emitem "github:credential:warning" antes de seguir.
+func (s *Service) SetCredentialInspector(inspector func(owner string) (CredentialPermissions, bool)) {
+	s.credentials = inspector
+}
+
+// IntrospectToken valida o token em GET /user e lê escopos (X-OAuth-Scopes) e expiração.
+func (s *Service) IntrospectToken(token string) (*TokenIntrospection, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, &GitHubError{StatusCode: 401, Message: "GitHub token is required", Type: "auth"}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, s.restBaseURL()+"/user", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", githubRESTAcceptJSON)
+	req.Header.Set("X-GitHub-Api-Version", githubRESTAPIVersion)
+	req.Header.Set("User-Agent", "ORCH-App/1.0")
+
+	body, headers, err := s.doCredentialRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var user struct {
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := json.Unmarshal(body, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user: %w", err)
+	}
+
+	result := &TokenIntrospection{
+		Login:     user.Login,
+		Name:      user.Name,
+		AvatarURL: user.AvatarURL,
+		Kind:      ClassifyToken(token),
+		Scopes:    parseOAuthScopes(headers.Get("X-OAuth-Scopes")),
+	}
+	if raw := strings.TrimSpace(headers.Get("GitHub-Authentication-Token-Expiration")); raw != "" {
+		if expiresAt, err := time.Parse(githubTokenExpiryLayout, raw); err == nil {
+			result.ExpiresAt = &expiresAt
+		}
+	}
+	return result, nil
+}
+
+// CreateAppInstallationToken emite um token de instalação (JWT do App -> access_tokens).
+func (s *Service) CreateAppInstallationToken(appID, installationID int64, privateKeyPEM string) (*AppInstallationToken, error) {
+	if appID <= 0 || installationID <= 0 {
+		return nil, &GitHubError{StatusCode: 422, Message: "App ID and installation ID are required", Type: "validation"}
+	}
+	jwt, err := signGitHubAppJWT(appID, privateKeyPEM, time.Now())
+	if err != nil {
+		return nil, &GitHubError{StatusCode: 422, Message: "Invalid GitHub App private key: " + err.Error(), Type: "validation"}
+	}
+
+	newAppRequest := func(method, path string) (*http.Request, error) {
+		req, err := http.NewRequest(method, s.restBaseURL()+path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+jwt)
+		req.Header.Set("Accept", githubRESTAcceptJSON)
+		req.Header.Set("X-GitHub-Api-Version", githubRESTAPIVersion)
+		req.Header.Set("User-Agent", "ORCH-App/1.0")
+		return req, nil
+	}
+
+	req, err := newAppRequest(http.MethodGet, fmt.Sprintf("/app/installations/%d", installationID))
+	if err != nil {
+		return nil, err
+	}
+	body, _, err := s.doCredentialRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var installation struct {
+		AppSlug string `json:"app_slug"`
+		Account struct {
+			Login     string `json:"login"`
+			AvatarURL string `json:"avatar_url"`
+		} `json:"account"`
+	}
+	if err := json.Unmarshal(body, &installation); err != nil {
+		return nil, fmt.Errorf("failed to parse installation: %w", err)
+	}
+
+	req, err = newAppRequest(http.MethodPost, fmt.Sprintf("/app/installations/%d/access_tokens", installationID))
+	if err != nil {
+		return nil, err
+	}
+	body, _, err = s.doCredentialRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var tokenResp struct {
+		Token               string            `json:"token"`
+		ExpiresAt           time.Time         `json:"expires_at"`
+		Permissions         map[string]string `json:"permissions"`
+		RepositorySelection string            `json:"repository_selection"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to parse installation token: %w", err)
+	}
+	if strings.TrimSpace(tokenResp.Token) == "" {
+		return nil, &GitHubError{StatusCode: 502, Message: "GitHub did not return an installation token", Type: "unknown"}
+	}
+
+	return &AppInstallationToken{
+		Token:               tokenResp.Token,
+		ExpiresAt:           tokenResp.ExpiresAt,
+		Permissions:         tokenResp.Permissions,
+		RepositorySelection: tokenResp.RepositorySelection,
+		AccountLogin:        installation.Account.Login,
+		AccountAvatarURL:    installation.Account.AvatarURL,
+		AppSlug:             installation.AppSlug,
+	}, nil
+}
+
+// CheckCredentialPermissions avalia se a credencial cobre a operação de PR (method + endpoint REST).
+func CheckCredentialPermissions(creds CredentialPermissions, method, endpointPath string) CredentialPermissionCheck {
+	required := strings.Split(requiredPRPermissionsForRequest(method, endpointPath), ",")
+	check := CredentialPermissionCheck{Kind: creds.Kind, Required: required}
+
+	switch creds.Kind {
+	case CredentialKindApp:
+		check.Verifiable = creds.Permissions != nil
+		for _, permission := range required {
+			name, level, _ := strings.Cut(permission, ":")
+			if permissionLevelRank(creds.Permissions[name]) < permissionLevelRank(level) {
+				check.Missing = append(check.Missing, permission)
+			}
+		}
+	case CredentialKindFineGrainedPAT:
+		check.Warning = "Fine-grained tokens do not expose their permissions; make sure the token grants " + strings.Join(required, ", ") + " on this repository."
+	default:
+		// OAuth e PAT clássico: o escopo "repo" cobre leitura e escrita de PRs.
+		if len(creds.Scopes) == 0 {
+			break
+		}
+		check.Verifiable = true
+		if !containsScope(creds.Scopes, "repo") {
+			if containsScope(creds.Scopes, "public_repo") {
+				check.Warning = "Token only has public_repo scope; private repositories will fail."
+			} else {
+				check.Missing = append(check.Missing, "repo")
+			}
+		}
+	}
+
+	if len(check.Missing) > 0 {
+		check.Warning = "Credential lacks " + strings.Join(check.Missing, ", ") + " required for this pull request operation."
+	}
+	return check
+}
+
+// warnIfCredentialLacksPermissions emite aviso (sem bloquear) para requests de PR com credencial insuficiente.
+func (s *Service) warnIfCredentialLacksPermissions(method, endpointPath string) {
+	if s.credentials == nil || !strings.Contains(endpointPath, "/pulls") {
+		return
+	}
+	owner := restPathOwner(endpointPath)
+	creds, ok := s.credentials(owner)
+	if !ok {
+		return
+	}
+	check := CheckCredentialPermissions(creds, method, endpointPath)
+	if len(check.Missing) == 0 {
+		return
+	}
+	s.emitTelemetry("github:credential:warning", CredentialWarningEvent{
+		Owner:    owner,
+		Method:   method,
+		Endpoint: endpointPath,
+		Check:    check,
+	})
+}
+
+func (s *Service) restBaseURL() string {
+	baseURL := strings.TrimRight(strings.TrimSpace(s.restEndpoint), "/")
+	if baseURL == "" {
+		return githubRESTEndpoint
+	}
+	return baseURL
+}
+
+// doCredentialRequest executa requests de credencial fora do budget e do roteamento de token.
+func (s *Service) doCredentialRequest(req *http.Request) ([]byte, http.Header, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, nil, &GitHubError{StatusCode: 0, Message: "Network error: " + err.Error(), Type: "network"}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.Header, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		if ssoErr := ssoChallengeError(resp.Header); ssoErr != nil {
+			return nil, resp.Header, ssoErr
+		}
+		return nil, resp.Header, s.handleHTTPError(resp.StatusCode, body)
+	}
+	return body, resp.Header, nil
+}
+
+func signGitHubAppJWT(appID int64, privateKeyPEM string, now time.Time) (string, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(privateKeyPEM)))
+	if block == nil {
+		return "", fmt.Errorf("PEM block not found")
+	}
+	var key *rsa.PrivateKey
+	if parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		key = parsed
+	} else if parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return "", fmt.Errorf("private key is not RSA")
+		}
+		key = rsaKey
+	} else {
+		return "", fmt.Errorf("unsupported private key format")
+	}
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	// iat recuado 60s para tolerar relógio adiantado, conforme recomendação do GitHub.
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-60 * time.Second).Unix(),
+		"exp": now.Add(githubAppJWTLifetime).Unix(),
+		"iss": fmt.Sprintf("%d", appID),
+	})
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func parseOAuthScopes(header string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+func containsScope(scopes []string, want string) bool {
+	for _, scope := range scopes {
+		if strings.EqualFold(strings.TrimSpace(scope), want) {
+			return true
+		}
+	}
+	return false
+}
+
+func permissionLevelRank(level string) int {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "read":
+		return 1
+	case "write":
+		return 2
+	case "admin":
+		return 3
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestIntrospectTokenReadsScopesAndExpiration(t *testing.T) {
+	service := newPRRESTTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" || r.Header.Get("Authorization") != "Bearer ghp_classic" {
+			t.Fatalf("unexpected request: %s auth=%q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		w.Header().Set("X-OAuth-Scopes", "read:user, repo")
+		w.Header().Set("GitHub-Authentication-Token-Expiration", "2030-01-02 03:04:05 UTC")
+		_, _ = io.WriteString(w, `{"login":"dev","name":"Dev","avatar_url":"https://avatars/dev"}`)
+	})
+
+	info, err := service.IntrospectToken("ghp_classic")
+	if err != nil {
+		t.Fatalf("IntrospectToken() error: %v", err)
+	}
+	if info.Login != "dev" || info.Kind != CredentialKindClassicPAT || strings.Join(info.Scopes, ",") != "read:user,repo" {
+		t.Fatalf("unexpected introspection: %+v", info)
+	}
+	if info.ExpiresAt == nil || info.ExpiresAt.Year() != 2030 {
+		t.Fatalf("expected token expiration parsed, got %v", info.ExpiresAt)
+	}
+}
+
+func TestCreateAppInstallationTokenSignsJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+
+	service := newPRRESTTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(jwt, ".")
+		if len(parts) != 3 {
+			t.Fatalf("expected JWT, got %q", jwt)
+		}
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+			t.Fatalf("invalid JWT signature: %v", err)
+		}
+		rawClaims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		var claims map[string]interface{}
+		_ = json.Unmarshal(rawClaims, &claims)
+		if claims["iss"] != "42" {
+			t.Fatalf("unexpected issuer: %+v", claims)
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/app/installations/7":
+			_, _ = io.WriteString(w, `{"app_slug":"orch-bot","account":{"login":"acme"}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/app/installations/7/access_tokens":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"token":"ghs_abc","expires_at":"2030-01-01T00:00:00Z","permissions":{"pull_requests":"write","contents":"read"}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	installation, err := service.CreateAppInstallationToken(42, 7, keyPEM)
+	if err != nil {
+		t.Fatalf("CreateAppInstallationToken() error: %v", err)
+	}
+	if installation.Token != "ghs_abc" || installation.AccountLogin != "acme" || installation.AppSlug != "orch-bot" || installation.Permissions["pull_requests"] != "write" {
+		t.Fatalf("unexpected installation token: %+v", installation)
+	}
+
+	if _, err := service.CreateAppInstallationToken(42, 7, "not a key"); err == nil {
+		t.Fatalf("expected invalid private key to be rejected")
+	}
+}
+
+func TestCheckCredentialPermissions(t *testing.T) {
+	cases := []struct {
+		name       string
+		creds      CredentialPermissions
+		method     string
+		path       string
+		missing    string
+		verifiable bool
+	}{
+		{"oauth with repo", CredentialPermissions{Kind: CredentialKindOAuth, Scopes: []string{"repo", "read:user"}}, http.MethodPost, "/repos/o/r/pulls", "", true},
+		{"classic without repo", CredentialPermissions{Kind: CredentialKindClassicPAT, Scopes: []string{"read:user"}}, http.MethodGet, "/repos/o/r/pulls", "repo", true},
+		{"app read-only merge", CredentialPermissions{Kind: CredentialKindApp, Permissions: map[string]string{"pull_requests": "write", "contents": "read"}}, http.MethodPut, "/repos/o/r/pulls/1/merge", "contents:write", true},
+		{"app write", CredentialPermissions{Kind: CredentialKindApp, Permissions: map[string]string{"pull_requests": "admin"}}, http.MethodPatch, "/repos/o/r/pulls/1", "", true},
+		{"fine-grained", CredentialPermissions{Kind: CredentialKindFineGrainedPAT}, http.MethodPost, "/repos/o/r/pulls", "", false},
+	}
+	for _, tc := range cases {
+		check := CheckCredentialPermissions(tc.creds, tc.method, tc.path)
+		if strings.Join(check.Missing, ",") != tc.missing || check.Verifiable != tc.verifiable {
+			t.Fatalf("%s: unexpected check %+v", tc.name, check)
+		}
+		if tc.missing != "" && check.Warning == "" {
+			t.Fatalf("%s: expected warning", tc.name)
+		}
+	}
+}
+
+func TestPRRequestWithInsufficientCredentialEmitsWarning(t *testing.T) {
+	service := newPRRESTTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `[]`)
+	})
+	var warnings []CredentialWarningEvent
+	service.SetTelemetryEmitter(func(eventName string, payload interface{}) {
+		if eventName == "github:credential:warning" {
+			warnings = append(warnings, payload.(CredentialWarningEvent))
+		}
+	})
+	service.SetCredentialInspector(func(owner string) (CredentialPermissions, bool) {
+		return CredentialPermissions{Kind: CredentialKindApp, Permissions: map[string]string{"contents": "read"}}, owner == "acme"
+	})
+
+	if _, _, err := service.executeRESTRequest(context.Background(), http.MethodGet, "/repos/acme/api/pulls", nil, "", nil); err != nil {
+		t.Fatalf("executeRESTRequest() error: %v", err)
+	}
+	if _, _, err := service.executeRESTRequest(context.Background(), http.MethodGet, "/repos/other/api/pulls", nil, "", nil); err != nil {
+		t.Fatalf("executeRESTRequest() error: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Owner != "acme" || strings.Join(warnings[0].Check.Missing, ",") != "pull_requests:read" {
+		t.Fatalf("unexpected warnings: %+v", warnings)
+	}
+}
@@ -0,0 +1,169 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	dashboardPageSize = 50
+	// dashboardMaxItems limita cada seção; contas com centenas de PRs abertos não travam a tela inicial.
+	dashboardMaxItems = 150
+)
+
+// Buscas de cada seção do dashboard (sempre PRs abertos de repositórios não arquivados).
+const (
+	dashboardAuthoredQuery        = "is:pr is:open archived:false author:@me sort:updated-desc"
+	dashboardReviewRequestedQuery = "is:pr is:open archived:false review-requested:@me sort:updated-desc"
+	dashboardFailingChecksQuery   = "is:pr is:open archived:false author:@me status:failure sort:updated-desc"
+)
+
+// GetMyPullRequestDashboard agrega, em todos os repositórios, os PRs abertos do usuário,
+// os que aguardam review dele e os dele com checks falhando.
+func (s *Service) GetMyPullRequestDashboard(ctx context.Context) (*PullRequestDashboard, error) {
+	if dashboard, ok := s.cache.GetDashboard(); ok {
+		return dashboard, nil
+	}
+
+	authored, err := s.searchPullRequests(ctx, dashboardAuthoredQuery, dashboardMaxItems)
+	if err != nil {
+		return nil, err
+	}
+	reviewRequested, err := s.searchPullRequests(ctx, dashboardReviewRequestedQuery, dashboardMaxItems)
+	if err != nil {
+		return nil, err
+	}
+	failing, err := s.searchPullRequests(ctx, dashboardFailingChecksQuery, dashboardMaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &PullRequestDashboard{
+		Authored:        authored,
+		ReviewRequested: reviewRequested,
+		FailingChecks:   failing,
+		FetchedAt:       time.Now(),
+	}
+	s.cache.SetDashboard(dashboard)
+	return dashboard, nil
+}
+
+// SearchPullRequests roda uma busca livre de PRs (sintaxe de busca do GitHub), até maxItems resultados.
+func (s *Service) SearchPullRequests(ctx context.Context, query string, maxItems int) (DashboardSection, error) {
+	if maxItems <= 0 || maxItems > dashboardMaxItems {
+		maxItems = dashboardMaxItems
+	}
+	return s.searchPullRequests(ctx, query, maxItems)
+}
+
+// PullRequestActivityQueries monta as buscas de PRs abertos pelo usuário e revisados por ele
+// desde since, restritas aos repositórios informados ("owner/repo").
+func PullRequestActivityQueries(repos []string, since time.Time) (opened string, reviewed string) {
+	scope := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		scope = append(scope, "repo:"+repo)
+	}
+	repoFilter := strings.Join(scope, " ")
+	stamp := since.UTC().Format(time.RFC3339)
+	opened = strings.TrimSpace(fmt.Sprintf("is:pr author:@me created:>=%s %s sort:created-desc", stamp, repoFilter))
+	reviewed = strings.TrimSpace(fmt.Sprintf("is:pr reviewed-by:@me -author:@me updated:>=%s %s sort:updated-desc", stamp, repoFilter))
+	return opened, reviewed
+}
+
+// searchPullRequests pagina a busca até acabar ou atingir maxItems.
+func (s *Service) searchPullRequests(ctx context.Context, query string, maxItems int) (DashboardSection, error) {
+	section := DashboardSection{Items: make([]DashboardPullRequest, 0, min(dashboardPageSize, maxItems))}
+	var after *string
+	for len(section.Items) < maxItems {
+		data, err := s.executeQuery(ctx, QuerySearchPullRequests, map[string]interface{}{
+			"query": query,
+			"first": min(dashboardPageSize, maxItems-len(section.Items)),
+			"after": after,
+		})
+		if err != nil {
+			return DashboardSection{}, err
+		}
+
+		var result struct {
+			Search struct {
+				IssueCount int `json:"issueCount"`
+				PageInfo   struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []struct {
+					Number         int       `json:"number"`
+					Title          string    `json:"title"`
+					URL            string    `json:"url"`
+					State          string    `json:"state"`
+					IsDraft        bool      `json:"isDraft"`
+					CreatedAt      time.Time `json:"createdAt"`
+					UpdatedAt      time.Time `json:"updatedAt"`
+					HeadRefName    string    `json:"headRefName"`
+					BaseRefName    string    `json:"baseRefName"`
+					ReviewDecision string    `json:"reviewDecision"`
+					Repository     struct {
+						Name  string `json:"name"`
+						Owner struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"repository"`
+					Author struct {
+						Login     string `json:"login"`
+						AvatarURL string `json:"avatarUrl"`
+					} `json:"author"`
+					Commits struct {
+						Nodes []struct {
+							Commit struct {
+								StatusCheckRollup *struct {
+									State string `json:"state"`
+								} `json:"statusCheckRollup"`
+							} `json:"commit"`
+						} `json:"nodes"`
+					} `json:"commits"`
+				} `json:"nodes"`
+			} `json:"search"`
+		}
+		if err := json.Unmarshal(data, &result); err != nil {
+			return DashboardSection{}, fmt.Errorf("failed to parse pull request search: %w", err)
+		}
+
+		section.Total = result.Search.IssueCount
+		for _, n := range result.Search.Nodes {
+			// Nós que não são PR (não deveria acontecer com is:pr) chegam vazios pelo fragmento.
+			if n.Number == 0 {
+				continue
+			}
+			pr := DashboardPullRequest{
+				Owner:          n.Repository.Owner.Login,
+				Repo:           n.Repository.Name,
+				Number:         n.Number,
+				Title:          n.Title,
+				URL:            n.URL,
+				State:          n.State,
+				IsDraft:        n.IsDraft,
+				Author:         User{Login: n.Author.Login, AvatarURL: n.Author.AvatarURL},
+				HeadBranch:     n.HeadRefName,
+				BaseBranch:     n.BaseRefName,
+				ReviewDecision: n.ReviewDecision,
+				CreatedAt:      n.CreatedAt,
+				UpdatedAt:      n.UpdatedAt,
+			}
+			if len(n.Commits.Nodes) > 0 && n.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+				pr.ChecksState = n.Commits.Nodes[0].Commit.StatusCheckRollup.State
+			}
+			section.Items = append(section.Items, pr)
+		}
+
+		if !result.Search.PageInfo.HasNextPage || result.Search.PageInfo.EndCursor == "" {
+			return section, nil
+		}
+		cursor := result.Search.PageInfo.EndCursor
+		after = &cursor
+	}
+	section.Truncated = true
+	return section, nil
+}
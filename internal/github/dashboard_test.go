@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func dashboardSearchPage(count int, hasNext bool, cursor string, nodes ...string) string {
+	return fmt.Sprintf(`{"data":{"search":{"issueCount":%d,"pageInfo":{"hasNextPage":%t,"endCursor":%q},"nodes":[%s]}}}`,
+		count, hasNext, cursor, strings.Join(nodes, ","))
+}
+
+func dashboardPRNode(owner, repo string, number int, rollup string) string {
+	checks := "null"
+	if rollup != "" {
+		checks = fmt.Sprintf(`{"state":%q}`, rollup)
+	}
+	return fmt.Sprintf(`{"number":%d,"title":"PR %d","url":"https://github.com/%s/%s/pull/%d","state":"OPEN",
+		"headRefName":"feature","baseRefName":"main","reviewDecision":"REVIEW_REQUIRED",
+		"repository":{"name":%q,"owner":{"login":%q}},"author":{"login":"ana"},
+		"commits":{"nodes":[{"commit":{"statusCheckRollup":%s}}]}}`, number, number, owner, repo, number, repo, owner, checks)
+}
+
+func TestGetMyPullRequestDashboardPaginatesAndCaches(t *testing.T) {
+	var requests atomic.Int32
+	service := NewService(func() (string, error) {
+		return "gh-token", nil
+	})
+	service.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requests.Add(1)
+			var payload struct {
+				Variables struct {
+					Query string  `json:"query"`
+					After *string `json:"after"`
+				} `json:"variables"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("invalid graphql payload: %v", err)
+			}
+			body := dashboardSearchPage(0, false, "")
+			switch {
+			case strings.Contains(payload.Variables.Query, "review-requested:@me"):
+				if payload.Variables.After == nil {
+					body = dashboardSearchPage(2, true, "c1", dashboardPRNode("orch-labs", "orch", 7, "PENDING"))
+				} else if *payload.Variables.After == "c1" {
+					body = dashboardSearchPage(2, false, "", dashboardPRNode("acme", "api", 3, ""))
+				}
+			case strings.Contains(payload.Variables.Query, "status:failure"):
+				body = dashboardSearchPage(1, false, "", dashboardPRNode("orch-labs", "docs", 9, "FAILURE"))
+			case strings.Contains(payload.Variables.Query, "author:@me"):
+				body = dashboardSearchPage(1, false, "", dashboardPRNode("orch-labs", "docs", 9, "FAILURE"))
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}
+
+	dashboard, err := service.GetMyPullRequestDashboard(context.Background())
+	if err != nil {
+		t.Fatalf("GetMyPullRequestDashboard() error: %v", err)
+	}
+	review := dashboard.ReviewRequested
+	if len(review.Items) != 2 || review.Total != 2 || review.Truncated {
+		t.Fatalf("expected both review pages, got %+v", review)
+	}
+	if review.Items[1].Owner != "acme" || review.Items[1].Repo != "api" || review.Items[1].ChecksState != "" {
+		t.Fatalf("unexpected second page item: %+v", review.Items[1])
+	}
+	failing := dashboard.FailingChecks.Items
+	if len(failing) != 1 || failing[0].ChecksState != "FAILURE" || len(dashboard.Authored.Items) != 1 {
+		t.Fatalf("unexpected authored/failing sections: %+v", dashboard)
+	}
+	if got := requests.Load(); got != 4 {
+		t.Fatalf("expected 4 search requests, got %d", got)
+	}
+
+	if _, err := service.GetMyPullRequestDashboard(context.Background()); err != nil {
+		t.Fatalf("cached GetMyPullRequestDashboard() error: %v", err)
+	}
+	if got := requests.Load(); got != 4 {
+		t.Fatalf("expected cached dashboard, got %d requests", got)
+	}
+
+	service.cache.InvalidatePRMutation("orch-labs", "orch", 7)
+	if _, err := service.GetMyPullRequestDashboard(context.Background()); err != nil {
+		t.Fatalf("refetch error: %v", err)
+	}
+	if got := requests.Load(); got != 8 {
+		t.Fatalf("expected PR mutation to drop the dashboard cache, got %d requests", got)
+	}
+}
+
+func TestPullRequestActivityQueriesScopesReposAndDate(t *testing.T) {
+	since := time.Date(2026, 3, 2, 9, 30, 0, 0, time.FixedZone("BRT", -3*60*60))
+	opened, reviewed := PullRequestActivityQueries([]string{"orch-labs/orch", "acme/api"}, since)
+
+	if opened != "is:pr author:@me created:>=2026-03-02T12:30:00Z repo:orch-labs/orch repo:acme/api sort:created-desc" {
+		t.Fatalf("unexpected opened query: %q", opened)
+	}
+	if reviewed != "is:pr reviewed-by:@me -author:@me updated:>=2026-03-02T12:30:00Z repo:orch-labs/orch repo:acme/api sort:updated-desc" {
+		t.Fatalf("unexpected reviewed query: %q", reviewed)
+	}
+}
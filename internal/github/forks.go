@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const repoActionFork = "repo_fork"
+
+// RepositoryForkInfo diz se o repositório é um fork e qual é o repositório pai.
+type RepositoryForkInfo struct {
+	Owner         string `json:"owner"`
+	Repo          string `json:"repo"`
+	Fork          bool   `json:"fork"`
+	ParentOwner   string `json:"parentOwner,omitempty"`
+	ParentRepo    string `json:"parentRepo,omitempty"`
+	DefaultBranch string `json:"defaultBranch,omitempty"`
+	CloneURL      string `json:"cloneUrl,omitempty"`
+	SSHURL        string `json:"sshUrl,omitempty"`
+	HTMLURL       string `json:"htmlUrl,omitempty"`
+}
+
+type restRepositoryForkInfo struct {
+	Name          string `json:"name"`
+	Fork          bool   `json:"fork"`
+	DefaultBranch string `json:"default_branch"`
+	CloneURL      string `json:"clone_url"`
+	SSHURL        string `json:"ssh_url"`
+	HTMLURL       string `json:"html_url"`
+	Owner         struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	Parent *struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"parent"`
+}
+
+// GetRepositoryForkInfo consulta GET /repos/{owner}/{repo}; o campo parent só vem em forks.
+func (s *Service) GetRepositoryForkInfo(ctx context.Context, owner, repo string) (*RepositoryForkInfo, error) {
+	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(owner, repo)
+	if normalizeErr != nil {
+		return nil, normalizeErr
+	}
+
+	endpointPath := fmt.Sprintf("/repos/%s/%s", url.PathEscape(normalizedOwner), url.PathEscape(normalizedRepo))
+	var response restRepositoryForkInfo
+	if err := s.getRESTJSON(ctx, endpointPath, nil, &response); err != nil {
+		return nil, err
+	}
+	return parseRESTRepositoryForkInfo(normalizedOwner, normalizedRepo, response), nil
+}
+
+// ForkRepository cria (ou devolve, se já existir) o fork de owner/repo na conta autenticada.
+// O GitHub responde 202 e copia o conteúdo em segundo plano; o clone pode levar alguns segundos.
+func (s *Service) ForkRepository(ctx context.Context, owner, repo string) (*RepositoryForkInfo, error) {
+	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(owner, repo)
+	if normalizeErr != nil {
+		return nil, normalizeErr
+	}
+
+	endpointPath := fmt.Sprintf("/repos/%s/%s/forks", url.PathEscape(normalizedOwner), url.PathEscape(normalizedRepo))
+	var response restRepositoryForkInfo
+	if err := s.executePRRESTJSON(ctx, repoActionFork, http.MethodPost, endpointPath, nil, map[string]interface{}{}, &response); err != nil {
+		return nil, err
+	}
+	info := parseRESTRepositoryForkInfo("", normalizedRepo, response)
+	log.Printf("[GitHub] Forked %s/%s into %s/%s", normalizedOwner, normalizedRepo, info.Owner, info.Repo)
+	return info, nil
+}
+
+func parseRESTRepositoryForkInfo(owner, repo string, response restRepositoryForkInfo) *RepositoryForkInfo {
+	info := &RepositoryForkInfo{
+		Owner:         owner,
+		Repo:          repo,
+		Fork:          response.Fork,
+		DefaultBranch: strings.TrimSpace(response.DefaultBranch),
+		CloneURL:      strings.TrimSpace(response.CloneURL),
+		SSHURL:        strings.TrimSpace(response.SSHURL),
+		HTMLURL:       strings.TrimSpace(response.HTMLURL),
+	}
+	// Renomes/transferências: a API segue o redirect e devolve o nome atual.
+	if login := strings.TrimSpace(response.Owner.Login); login != "" {
+		info.Owner = login
+	}
+	if name := strings.TrimSpace(response.Name); name != "" {
+		info.Repo = name
+	}
+	if response.Fork && response.Parent != nil {
+		info.ParentOwner = strings.TrimSpace(response.Parent.Owner.Login)
+		info.ParentRepo = strings.TrimSpace(response.Parent.Name)
+	}
+	return info
+}
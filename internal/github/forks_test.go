@@ -0,0 +1,81 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newForkInfoTestService(t *testing.T, bodies map[string]string) *Service {
+	t.Helper()
+	service := NewService(func() (string, error) {
+		return "gh-token", nil
+	})
+	service.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, ok := bodies[req.URL.Path]
+			status := http.StatusOK
+			if req.Method == http.MethodPost {
+				status = http.StatusAccepted // forks são criados de forma assíncrona
+			}
+			if !ok {
+				status, body = http.StatusNotFound, `{"message":"Not Found"}`
+			}
+			return &http.Response{
+				StatusCode: status,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}
+	return service
+}
+
+func TestGetRepositoryForkInfoReadsParent(t *testing.T) {
+	service := newForkInfoTestService(t, map[string]string{
+		"/repos/ana/orch":       `{"name":"orch","fork":true,"default_branch":"main","owner":{"login":"ana"},"parent":{"name":"orch","owner":{"login":"orch-labs"}}}`,
+		"/repos/orch-labs/orch": `{"name":"orch","fork":false,"default_branch":"main","owner":{"login":"orch-labs"}}`,
+	})
+
+	fork, err := service.GetRepositoryForkInfo(context.Background(), "ana", "orch")
+	if err != nil {
+		t.Fatalf("GetRepositoryForkInfo(fork) error: %v", err)
+	}
+	if !fork.Fork || fork.ParentOwner != "orch-labs" || fork.ParentRepo != "orch" || fork.DefaultBranch != "main" {
+		t.Fatalf("unexpected fork info: %+v", fork)
+	}
+
+	upstream, err := service.GetRepositoryForkInfo(context.Background(), "orch-labs", "orch")
+	if err != nil {
+		t.Fatalf("GetRepositoryForkInfo(upstream) error: %v", err)
+	}
+	if upstream.Fork || upstream.ParentOwner != "" {
+		t.Fatalf("unexpected upstream info: %+v", upstream)
+	}
+
+	if _, err := service.GetRepositoryForkInfo(context.Background(), "ghost", "missing"); err == nil {
+		t.Fatalf("expected error for unknown repository")
+	}
+	if _, err := service.GetRepositoryForkInfo(context.Background(), "../etc", "orch"); err == nil {
+		t.Fatalf("expected invalid owner to be rejected")
+	}
+}
+
+func TestForkRepositoryReturnsNewFork(t *testing.T) {
+	service := newForkInfoTestService(t, map[string]string{
+		"/repos/orch-labs/orch/forks": `{"name":"orch","fork":true,"default_branch":"main","clone_url":"https://github.com/ana/orch.git","ssh_url":"git@github.com:ana/orch.git","owner":{"login":"ana"},"parent":{"name":"orch","owner":{"login":"orch-labs"}}}`,
+	})
+
+	fork, err := service.ForkRepository(context.Background(), "orch-labs", "orch")
+	if err != nil {
+		t.Fatalf("ForkRepository() error: %v", err)
+	}
+	if fork.Owner != "ana" || fork.Repo != "orch" || fork.ParentOwner != "orch-labs" || fork.SSHURL != "git@github.com:ana/orch.git" {
+		t.Fatalf("unexpected fork: %+v", fork)
+	}
+	if _, err := service.ForkRepository(context.Background(), "orch-labs", ""); err == nil {
+		t.Fatalf("expected missing repo to be rejected")
+	}
+}
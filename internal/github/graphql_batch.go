@@ -0,0 +1,376 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// === GraphQL Batching ===
+//
+// Queries de poller/background que chegam dentro de uma janela curta são fundidas em um único
+// documento: cada query recebe prefixo "bN_" nas variáveis e nos campos de topo (alias), e a
+// resposta é separada de volta por prefixo. Mutations e queries interativas nunca entram em lote.
+
+const (
+	graphqlBatchWindow  = 25 * time.Millisecond
+	graphqlBatchMaxSize = 10
+)
+
+var (
+	graphqlVariablePattern  = regexp.MustCompile(`\$([A-Za-z_][A-Za-z0-9_]*)`)
+	graphqlOperationPattern = regexp.MustCompile(`^\s*(query|mutation|subscription)\b\s*([A-Za-z_][A-Za-z0-9_]*)?`)
+)
+
+type graphqlBatchResult struct {
+	data json.RawMessage
+	err  error
+}
+
+type graphqlBatchItem struct {
+	ctx       context.Context
+	owner     string
+	query     string
+	variables map[string]interface{}
+	category  string
+	priority  RequestPriority
+	done      chan graphqlBatchResult
+}
+
+type graphqlBatcher struct {
+	mu      sync.Mutex
+	pending []*graphqlBatchItem
+	timer   *time.Timer
+	flush   func([]*graphqlBatchItem)
+}
+
+func newGraphQLBatcher(flush func([]*graphqlBatchItem)) *graphqlBatcher {
+	return &graphqlBatcher{flush: flush}
+}
+
+// enqueue agenda a query no lote atual e espera o resultado.
+func (b *graphqlBatcher) enqueue(ctx context.Context, item *graphqlBatchItem) (json.RawMessage, error) {
+	item.done = make(chan graphqlBatchResult, 1)
+
+	b.mu.Lock()
+	b.pending = append(b.pending, item)
+	var ready []*graphqlBatchItem
+	if len(b.pending) >= graphqlBatchMaxSize {
+		ready = b.takeLocked()
+	} else if b.timer == nil {
+		b.timer = time.AfterFunc(graphqlBatchWindow, func() {
+			b.mu.Lock()
+			batch := b.takeLocked()
+			b.mu.Unlock()
+			if len(batch) > 0 {
+				b.flush(batch)
+			}
+		})
+	}
+	b.mu.Unlock()
+
+	if len(ready) > 0 {
+		go b.flush(ready)
+	}
+
+	select {
+	case result := <-item.done:
+		return result.data, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// batchContext só é cancelado quando todos os itens do lote desistiram: um chamador que cancela
+// não aborta a resposta que os outros ainda esperam.
+func batchContext(items []*graphqlBatchItem) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(items[0].ctx))
+	var remaining atomic.Int32
+	remaining.Store(int32(len(items)))
+	stops := make([]func() bool, 0, len(items))
+	for _, item := range items {
+		stops = append(stops, context.AfterFunc(item.ctx, func() {
+			if remaining.Add(-1) == 0 {
+				cancel()
+			}
+		}))
+	}
+	return ctx, func() {
+		for _, stop := range stops {
+			stop()
+		}
+		cancel()
+	}
+}
+
+func (b *graphqlBatcher) takeLocked() []*graphqlBatchItem {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	batch := b.pending
+	b.pending = nil
+	return batch
+}
+
+// graphqlOperationName retorna o nome da operação (ex.: "ListBranches") para categorizar consumo.
+func graphqlOperationName(query string) string {
+	match := graphqlOperationPattern.FindStringSubmatch(query)
+	if len(match) < 3 {
+		return ""
+	}
+	return match[2]
+}
+
+func isBatchableGraphQLQuery(query string) bool {
+	trimmed := strings.TrimSpace(query)
+	if strings.HasPrefix(trimmed, "{") {
+		return true
+	}
+	match := graphqlOperationPattern.FindStringSubmatch(trimmed)
+	return len(match) > 1 && match[1] == "query"
+}
+
+// mergeGraphQLQueries funde as queries em um documento com variáveis e campos prefixados.
+func mergeGraphQLQueries(items []*graphqlBatchItem) (string, map[string]interface{}, error) {
+	var definitions []string
+	var selections []string
+	variables := make(map[string]interface{})
+
+	for idx, item := range items {
+		prefix := fmt.Sprintf("b%d_", idx)
+		varDefs, body, err := splitGraphQLOperation(item.query)
+		if err != nil {
+			return "", nil, err
+		}
+		rename := func(text string) string {
+			return graphqlVariablePattern.ReplaceAllString(text, "$$"+prefix+"$1")
+		}
+		if strings.TrimSpace(varDefs) != "" {
+			definitions = append(definitions, rename(varDefs))
+		}
+		aliased, err := aliasGraphQLTopLevelFields(rename(body), prefix)
+		if err != nil {
+			return "", nil, err
+		}
+		selections = append(selections, aliased)
+		// Só envia variáveis declaradas: o GitHub rejeita variáveis extras no documento fundido.
+		for name, value := range item.variables {
+			if strings.Contains(varDefs, "$"+name+":") || strings.Contains(varDefs, "$"+name+" ") {
+				variables[prefix+name] = value
+			}
+		}
+	}
+
+	header := "query"
+	if len(definitions) > 0 {
+		header += "(" + strings.Join(definitions, ", ") + ")"
+	}
+	return header + " {\n" + strings.Join(selections, "\n") + "\n}", variables, nil
+}
+
+// splitGraphQLOperation separa "query Nome($a: T) { corpo }" em definições de variáveis e corpo.
+func splitGraphQLOperation(query string) (string, string, error) {
+	trimmed := strings.TrimSpace(query)
+	if loc := graphqlOperationPattern.FindStringIndex(trimmed); loc != nil {
+		if !strings.HasPrefix(strings.TrimSpace(trimmed[loc[0]:loc[1]]), "query") {
+			return "", "", fmt.Errorf("only queries can be batched")
+		}
+		trimmed = strings.TrimSpace(trimmed[loc[1]:])
+	}
+
+	varDefs := ""
+	if strings.HasPrefix(trimmed, "(") {
+		end := matchingGraphQLDelimiter(trimmed, 0)
+		if end < 0 {
+			return "", "", fmt.Errorf("unbalanced variable definitions")
+		}
+		varDefs = trimmed[1:end]
+		trimmed = strings.TrimSpace(trimmed[end+1:])
+	}
+	if !strings.HasPrefix(trimmed, "{") {
+		return "", "", fmt.Errorf("query without selection set")
+	}
+	end := matchingGraphQLDelimiter(trimmed, 0)
+	if end < 0 {
+		return "", "", fmt.Errorf("unbalanced selection set")
+	}
+	if rest := strings.TrimSpace(trimmed[end+1:]); rest != "" {
+		return "", "", fmt.Errorf("documents with fragments cannot be batched")
+	}
+	return varDefs, trimmed[1:end], nil
+}
+
+// matchingGraphQLDelimiter retorna o índice do fechamento do delimitador em start (ignora strings).
+func matchingGraphQLDelimiter(text string, start int) int {
+	depth := 0
+	inString := false
+	for idx := start; idx < len(text); idx++ {
+		ch := text[idx]
+		if inString {
+			if ch == '\\' {
+				idx++
+			} else if ch == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch ch {
+		case '"':
+			inString = true
+		case '(', '{', '[':
+			depth++
+		case ')', '}', ']':
+			depth--
+			if depth == 0 {
+				return idx
+			}
+		}
+	}
+	return -1
+}
+
+// aliasGraphQLTopLevelFields prefixa o alias (ou nome) de cada campo de topo do corpo.
+func aliasGraphQLTopLevelFields(body string, prefix string) (string, error) {
+	var out strings.Builder
+	depth := 0
+	inString := false
+	afterAlias := false
+	afterDirective := false
+
+	for idx := 0; idx < len(body); idx++ {
+		ch := body[idx]
+		if inString {
+			out.WriteByte(ch)
+			if ch == '\\' && idx+1 < len(body) {
+				idx++
+				out.WriteByte(body[idx])
+			} else if ch == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case ch == '"':
+			inString = true
+		case ch == '(' || ch == '{' || ch == '[':
+			depth++
+		case ch == ')' || ch == '}' || ch == ']':
+			depth--
+		case depth == 0 && ch == '.':
+			return "", fmt.Errorf("top-level fragment spreads cannot be batched")
+		case depth == 0 && ch == '@':
+			afterDirective = true
+		case depth == 0 && isGraphQLNameStart(ch):
+			end := idx
+			for end < len(body) && isGraphQLNameChar(body[end]) {
+				end++
+			}
+			name := body[idx:end]
+			switch {
+			case afterDirective:
+				afterDirective = false
+				out.WriteString(name)
+			case afterAlias:
+				afterAlias = false
+				out.WriteString(name)
+			default:
+				next := end
+				for next < len(body) && (body[next] == ' ' || body[next] == '\t' || body[next] == '\n' || body[next] == '\r') {
+					next++
+				}
+				if next < len(body) && body[next] == ':' {
+					// Alias existente: prefixa o alias e preserva o campo.
+					out.WriteString(prefix + name)
+					afterAlias = true
+				} else {
+					out.WriteString(prefix + name + ": " + name)
+				}
+			}
+			idx = end - 1
+			continue
+		}
+		out.WriteByte(ch)
+	}
+	if depth != 0 {
+		return "", fmt.Errorf("unbalanced selection set")
+	}
+	return out.String(), nil
+}
+
+func isGraphQLNameStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isGraphQLNameChar(ch byte) bool {
+	return isGraphQLNameStart(ch) || (ch >= '0' && ch <= '9')
+}
+
+// splitGraphQLBatchResponse devolve a cada item do lote seus campos (sem prefixo) e erros.
+func splitGraphQLBatchResponse(count int, resp *graphqlResponse) []graphqlBatchResult {
+	results := make([]graphqlBatchResult, count)
+
+	var data map[string]json.RawMessage
+	if len(resp.Data) > 0 && string(resp.Data) != "null" {
+		_ = json.Unmarshal(resp.Data, &data)
+	}
+	parts := make([]map[string]json.RawMessage, count)
+	for idx := range parts {
+		parts[idx] = make(map[string]json.RawMessage)
+	}
+	for key, value := range data {
+		if idx, field, ok := parseGraphQLBatchAlias(key, count); ok {
+			parts[idx][field] = value
+		}
+	}
+
+	for _, gqlErr := range resp.Errors {
+		target := -1
+		if len(gqlErr.Path) > 0 {
+			if alias, ok := gqlErr.Path[0].(string); ok {
+				if idx, _, ok := parseGraphQLBatchAlias(alias, count); ok {
+					target = idx
+				}
+			}
+		}
+		for idx := range results {
+			if (target < 0 || target == idx) && results[idx].err == nil {
+				results[idx].err = &GitHubError{StatusCode: 200, Message: gqlErr.Message, Type: "graphql"}
+			}
+		}
+	}
+
+	for idx := range results {
+		if results[idx].err != nil {
+			continue
+		}
+		raw, err := json.Marshal(parts[idx])
+		if err != nil {
+			results[idx].err = err
+			continue
+		}
+		results[idx].data = raw
+	}
+	return results
+}
+
+func parseGraphQLBatchAlias(alias string, count int) (int, string, bool) {
+	if !strings.HasPrefix(alias, "b") {
+		return 0, "", false
+	}
+	rawIdx, field, found := strings.Cut(alias[1:], "_")
+	if !found {
+		return 0, "", false
+	}
+	var idx int
+	if _, err := fmt.Sscanf(rawIdx, "%d", &idx); err != nil || idx < 0 || idx >= count {
+		return 0, "", false
+	}
+	return idx, field, true
+}
@@ -0,0 +1,81 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeGraphQLQueriesPrefixesVariablesAndFields(t *testing.T) {
+	items := []*graphqlBatchItem{
+		{query: QueryListBranches, variables: map[string]interface{}{"owner": "o", "repo": "r", "first": 100}},
+		{query: `query { current: viewer { login } rateLimit @include(if: true) { remaining } }`},
+	}
+
+	merged, variables, err := mergeGraphQLQueries(items)
+	if err != nil {
+		t.Fatalf("mergeGraphQLQueries() error: %v", err)
+	}
+	for _, fragment := range []string{
+		"query($b0_owner: String!, $b0_repo: String!, $b0_first: Int!)",
+		"b0_repository: repository(owner: $b0_owner, name: $b0_repo)",
+		"b1_current: viewer",
+		"b1_rateLimit: rateLimit @include(if: true)",
+	} {
+		if !strings.Contains(merged, fragment) {
+			t.Fatalf("merged query missing %q:\n%s", fragment, merged)
+		}
+	}
+	if strings.Contains(merged, "b0_refs") || strings.Contains(merged, "b1_login") {
+		t.Fatalf("nested fields must not be aliased:\n%s", merged)
+	}
+	if variables["b0_owner"] != "o" || variables["b0_first"] != 100 || len(variables) != 3 {
+		t.Fatalf("unexpected variables: %+v", variables)
+	}
+
+	if _, _, err := mergeGraphQLQueries([]*graphqlBatchItem{{query: MutationCreateBranch}}); err == nil {
+		t.Fatalf("expected mutations to be rejected")
+	}
+}
+
+func TestSplitGraphQLBatchResponseRoutesErrorsByAlias(t *testing.T) {
+	var resp graphqlResponse
+	raw := `{"data":{"b0_repository":{"id":"R_1"},"b1_repository":null},
+		"errors":[{"message":"Could not resolve to a Repository","path":["b1_repository"]}]}`
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+
+	results := splitGraphQLBatchResponse(2, &resp)
+	if results[0].err != nil || string(results[0].data) != `{"repository":{"id":"R_1"}}` {
+		t.Fatalf("unexpected first result: data=%s err=%v", results[0].data, results[0].err)
+	}
+	if results[1].err == nil || !strings.Contains(results[1].err.Error(), "Could not resolve") {
+		t.Fatalf("expected error routed to second query, got %v", results[1].err)
+	}
+}
+
+func TestBatchContextCancelsOnlyWhenEveryItemGaveUp(t *testing.T) {
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	secondCtx, cancelSecond := context.WithCancel(context.Background())
+	defer cancelSecond()
+
+	ctx, cancel := batchContext([]*graphqlBatchItem{{ctx: firstCtx}, {ctx: secondCtx}})
+	defer cancel()
+
+	cancelFirst()
+	select {
+	case <-ctx.Done():
+		t.Fatalf("batch must keep running while another caller waits")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancelSecond()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("expected batch canceled after every caller gave up")
+	}
+}
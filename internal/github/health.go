@@ -0,0 +1,41 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Ping verifica se a API REST do GitHub está acessível (GET /rate_limit não consome cota).
+// Usa o token padrão quando disponível; 401 indica API acessível com token rejeitado.
+func (s *Service) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.restBaseURL()+"/rate_limit", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", githubRESTAcceptJSON)
+	req.Header.Set("X-GitHub-Api-Version", githubRESTAPIVersion)
+	req.Header.Set("User-Agent", "ORCH-App/1.0")
+	if s.token != nil {
+		if token, err := s.token(); err == nil && strings.TrimSpace(token) != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return &GitHubError{StatusCode: 0, Message: fmt.Sprintf("GitHub API unreachable: %v", err), Type: "network"}
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return &GitHubError{StatusCode: resp.StatusCode, Message: "GitHub token was rejected", Type: "auth"}
+	case resp.StatusCode >= 500:
+		return &GitHubError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("GitHub API returned %d", resp.StatusCode), Type: "server"}
+	}
+	return nil
+}
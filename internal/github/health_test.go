@@ -0,0 +1,33 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPingClassifiesRateLimitEndpointResponses(t *testing.T) {
+	status := http.StatusOK
+	service := newPRRESTTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rate_limit" || r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Fatalf("unexpected request %s auth=%q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(status)
+	})
+
+	if err := service.Ping(context.Background()); err != nil {
+		t.Fatalf("expected reachable API, got %v", err)
+	}
+
+	status = http.StatusUnauthorized
+	var ghErr *GitHubError
+	if err := service.Ping(context.Background()); !errors.As(err, &ghErr) || ghErr.Type != "auth" {
+		t.Fatalf("expected auth error, got %v", err)
+	}
+
+	status = http.StatusBadGateway
+	if err := service.Ping(context.Background()); !errors.As(err, &ghErr) || ghErr.Type != "server" {
+		t.Fatalf("expected server error, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultIssueSearchPageSize = 30
+	maxIssueSearchPageSize     = 100
+	// maxIssueCountsPerQuery limita quantas buscas vão no mesmo documento GraphQL (custo de rate limit).
+	maxIssueCountsPerQuery = 20
+)
+
+// BuildIssueSearchQuery converte o filtro em uma busca do GitHub restrita ao repositório.
+func BuildIssueSearchQuery(owner, repo string, filter IssueSearchFilter) string {
+	parts := []string{"repo:" + owner + "/" + repo, "is:issue"}
+	switch strings.ToLower(strings.TrimSpace(filter.State)) {
+	case "closed":
+		parts = append(parts, "is:closed")
+	case "all":
+	default:
+		parts = append(parts, "is:open")
+	}
+	for _, label := range filter.Labels {
+		if label = strings.TrimSpace(label); label != "" {
+			parts = append(parts, "label:"+quoteSearchTerm(label))
+		}
+	}
+	switch assignee := strings.TrimSpace(filter.Assignee); {
+	case assignee == "":
+	case strings.EqualFold(assignee, "none"):
+		parts = append(parts, "no:assignee")
+	default:
+		parts = append(parts, "assignee:"+assignee)
+	}
+	if text := strings.TrimSpace(filter.Query); text != "" {
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// SearchIssues retorna uma página de issues do repositório que atendem ao filtro.
+func (s *Service) SearchIssues(ctx context.Context, owner, repo string, filter IssueSearchFilter, first int, after string) (*IssueSearchPage, error) {
+	if first <= 0 {
+		first = defaultIssueSearchPageSize
+	}
+	vars := map[string]interface{}{
+		"query": BuildIssueSearchQuery(owner, repo, filter),
+		"first": min(first, maxIssueSearchPageSize),
+	}
+	if after = strings.TrimSpace(after); after != "" {
+		vars["after"] = after
+	}
+
+	data, err := s.executeOwnerQuery(ctx, owner, QuerySearchIssues, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Search struct {
+			IssueCount int `json:"issueCount"`
+			PageInfo   struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Nodes []issueNode `json:"nodes"`
+		} `json:"search"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse issue search: %w", err)
+	}
+
+	page := &IssueSearchPage{
+		Items:       make([]Issue, 0, len(result.Search.Nodes)),
+		Total:       result.Search.IssueCount,
+		HasNextPage: result.Search.PageInfo.HasNextPage,
+	}
+	if page.HasNextPage {
+		page.EndCursor = result.Search.PageInfo.EndCursor
+	}
+	for _, n := range result.Search.Nodes {
+		if n.Number == 0 {
+			continue
+		}
+		page.Items = append(page.Items, parseIssueNode(n))
+	}
+	return page, nil
+}
+
+// CountIssues retorna o total de issues de cada filtro, na mesma ordem, agrupando as
+// buscas em poucos documentos GraphQL com aliases.
+func (s *Service) CountIssues(ctx context.Context, owner, repo string, filters []IssueSearchFilter) ([]int, error) {
+	counts := make([]int, 0, len(filters))
+	for start := 0; start < len(filters); start += maxIssueCountsPerQuery {
+		chunk := filters[start:min(start+maxIssueCountsPerQuery, len(filters))]
+
+		var doc strings.Builder
+		doc.WriteString("query CountIssues(")
+		vars := make(map[string]interface{}, len(chunk))
+		for i := range chunk {
+			if i > 0 {
+				doc.WriteString(", ")
+			}
+			fmt.Fprintf(&doc, "$q%d: String!", i)
+			vars["q"+strconv.Itoa(i)] = BuildIssueSearchQuery(owner, repo, chunk[i])
+		}
+		doc.WriteString(") {\n")
+		for i := range chunk {
+			fmt.Fprintf(&doc, "  f%d: search(query: $q%d, type: ISSUE, first: 0) { issueCount }\n", i, i)
+		}
+		doc.WriteString("}")
+
+		data, err := s.executeOwnerQuery(ctx, owner, doc.String(), vars)
+		if err != nil {
+			return nil, err
+		}
+		var result map[string]struct {
+			IssueCount int `json:"issueCount"`
+		}
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse issue counts: %w", err)
+		}
+		for i := range chunk {
+			counts = append(counts, result["f"+strconv.Itoa(i)].IssueCount)
+		}
+	}
+	return counts, nil
+}
+
+// quoteSearchTerm coloca aspas em termos com espaço (ex.: label:"good first issue").
+func quoteSearchTerm(term string) string {
+	if strings.ContainsAny(term, " \t\"") {
+		return strconv.Quote(strings.ReplaceAll(term, `"`, ""))
+	}
+	return term
+}
@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBuildIssueSearchQuery(t *testing.T) {
+	cases := []struct {
+		filter IssueSearchFilter
+		want   string
+	}{
+		{IssueSearchFilter{}, "repo:orch-labs/orch is:issue is:open"},
+		{
+			IssueSearchFilter{State: "closed", Labels: []string{"bug", " good first issue ", ""}, Assignee: "ana"},
+			`repo:orch-labs/orch is:issue is:closed label:bug label:"good first issue" assignee:ana`,
+		},
+		{IssueSearchFilter{State: "all", Assignee: "None", Query: "crash in:title"}, "repo:orch-labs/orch is:issue no:assignee crash in:title"},
+		{IssueSearchFilter{Assignee: "@me"}, "repo:orch-labs/orch is:issue is:open assignee:@me"},
+	}
+	for _, tc := range cases {
+		if got := BuildIssueSearchQuery("orch-labs", "orch", tc.filter); got != tc.want {
+			t.Errorf("BuildIssueSearchQuery(%+v) = %q, want %q", tc.filter, got, tc.want)
+		}
+	}
+}
+
+func TestCountIssuesBatchesAliasedSearches(t *testing.T) {
+	var documents []string
+	service := NewService(func() (string, error) {
+		return "gh-token", nil
+	})
+	service.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			var payload struct {
+				Query     string            `json:"query"`
+				Variables map[string]string `json:"variables"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("invalid graphql payload: %v", err)
+			}
+			documents = append(documents, payload.Query)
+			// O total de cada alias é o tamanho da busca, para verificar a ordem.
+			fields := make([]string, 0, len(payload.Variables))
+			for i := 0; i < len(payload.Variables); i++ {
+				fields = append(fields, fmt.Sprintf(`"f%d":{"issueCount":%d}`, i, len(payload.Variables[fmt.Sprintf("q%d", i)])))
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(`{"data":{` + strings.Join(fields, ",") + `}}`)),
+			}, nil
+		}),
+	}
+
+	filters := make([]IssueSearchFilter, maxIssueCountsPerQuery+2)
+	filters[0] = IssueSearchFilter{Labels: []string{"bug"}}
+	filters[len(filters)-1] = IssueSearchFilter{State: "all"}
+	counts, err := service.CountIssues(context.Background(), "orch-labs", "orch", filters)
+	if err != nil {
+		t.Fatalf("CountIssues() error: %v", err)
+	}
+	if len(documents) != 2 || !strings.Contains(documents[0], "f19: search(query: $q19") {
+		t.Fatalf("expected two aliased documents, got %q", documents)
+	}
+	if len(counts) != len(filters) || counts[0] != len(BuildIssueSearchQuery("orch-labs", "orch", filters[0])) ||
+		counts[len(counts)-1] != len(BuildIssueSearchQuery("orch-labs", "orch", filters[len(filters)-1])) {
+		t.Fatalf("unexpected counts: %v", counts)
+	}
+}
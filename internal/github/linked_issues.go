@@ -0,0 +1,151 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type graphqlUserNodes struct {
+	Nodes []struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatarUrl"`
+	} `json:"nodes"`
+}
+
+type graphqlRepositoryRef struct {
+	NameWithOwner string `json:"nameWithOwner"`
+}
+
+// GetLinkedIssues lista as issues que o PR fecha ao ser mergeado (palavras-chave na
+// descrição ou vínculo manual na seção "Development").
+func (s *Service) GetLinkedIssues(ctx context.Context, owner, repo string, prNumber int) ([]LinkedIssue, error) {
+	data, err := s.executeQuery(ctx, QueryListPRClosingIssues, map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": prNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Repository struct {
+			PullRequest *struct {
+				ClosingIssuesReferences struct {
+					Nodes []struct {
+						Number     int                  `json:"number"`
+						Title      string               `json:"title"`
+						State      string               `json:"state"`
+						URL        string               `json:"url"`
+						Repository graphqlRepositoryRef `json:"repository"`
+						Assignees  graphqlUserNodes     `json:"assignees"`
+						Labels     struct {
+							Nodes []Label `json:"nodes"`
+						} `json:"labels"`
+					} `json:"nodes"`
+				} `json:"closingIssuesReferences"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	if result.Repository.PullRequest == nil {
+		return nil, &GitHubError{StatusCode: 404, Message: fmt.Sprintf("pull request #%d not found", prNumber), Type: "notfound"}
+	}
+
+	nodes := result.Repository.PullRequest.ClosingIssuesReferences.Nodes
+	issues := make([]LinkedIssue, 0, len(nodes))
+	for _, n := range nodes {
+		issue := LinkedIssue{
+			Number:     n.Number,
+			Title:      n.Title,
+			State:      n.State,
+			URL:        n.URL,
+			Repository: n.Repository.NameWithOwner,
+			Assignees:  make([]User, 0, len(n.Assignees.Nodes)),
+			Labels:     n.Labels.Nodes,
+		}
+		for _, a := range n.Assignees.Nodes {
+			issue.Assignees = append(issue.Assignees, User{Login: a.Login, AvatarURL: a.AvatarURL})
+		}
+		if issue.Labels == nil {
+			issue.Labels = []Label{}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// GetIssueDevelopment faz o caminho inverso: PRs (inclusive fechados) que fecham a issue
+// e branches vinculados a ela.
+func (s *Service) GetIssueDevelopment(ctx context.Context, owner, repo string, issueNumber int) (*IssueDevelopment, error) {
+	data, err := s.executeQuery(ctx, QueryGetIssueDevelopment, map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": issueNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Repository struct {
+			Issue *struct {
+				Number                         int `json:"number"`
+				ClosedByPullRequestsReferences struct {
+					Nodes []struct {
+						Number      int                  `json:"number"`
+						Title       string               `json:"title"`
+						State       string               `json:"state"`
+						IsDraft     bool                 `json:"isDraft"`
+						URL         string               `json:"url"`
+						HeadRefName string               `json:"headRefName"`
+						Repository  graphqlRepositoryRef `json:"repository"`
+					} `json:"nodes"`
+				} `json:"closedByPullRequestsReferences"`
+				LinkedBranches struct {
+					Nodes []struct {
+						Ref *struct {
+							Name       string               `json:"name"`
+							Repository graphqlRepositoryRef `json:"repository"`
+						} `json:"ref"`
+					} `json:"nodes"`
+				} `json:"linkedBranches"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	issue := result.Repository.Issue
+	if issue == nil {
+		return nil, &GitHubError{StatusCode: 404, Message: fmt.Sprintf("issue #%d not found", issueNumber), Type: "notfound"}
+	}
+
+	development := &IssueDevelopment{
+		Number:       issue.Number,
+		PullRequests: make([]LinkedPullRequest, 0, len(issue.ClosedByPullRequestsReferences.Nodes)),
+		Branches:     make([]LinkedBranch, 0, len(issue.LinkedBranches.Nodes)),
+	}
+	for _, n := range issue.ClosedByPullRequestsReferences.Nodes {
+		development.PullRequests = append(development.PullRequests, LinkedPullRequest{
+			Number:     n.Number,
+			Title:      n.Title,
+			State:      n.State,
+			IsDraft:    n.IsDraft,
+			URL:        n.URL,
+			HeadBranch: n.HeadRefName,
+			Repository: n.Repository.NameWithOwner,
+		})
+	}
+	// O ref some quando o branch é apagado; o vínculo continua existindo mas não há o que mostrar.
+	for _, n := range issue.LinkedBranches.Nodes {
+		if n.Ref == nil {
+			continue
+		}
+		development.Branches = append(development.Branches, LinkedBranch{Name: n.Ref.Name, Repository: n.Ref.Repository.NameWithOwner})
+	}
+	return development, nil
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newGraphQLTestService(t *testing.T, responses map[string]string) *Service {
+	t.Helper()
+	service := NewService(func() (string, error) {
+		return "gh-token", nil
+	})
+	service.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			var payload struct {
+				Query string `json:"query"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("invalid graphql payload: %v", err)
+			}
+			body := `{"errors":[{"message":"unexpected query"}]}`
+			if resp, ok := responses[graphqlOperationName(payload.Query)]; ok {
+				body = resp
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}
+	return service
+}
+
+func TestGetLinkedIssuesMapsClosingReferences(t *testing.T) {
+	service := newGraphQLTestService(t, map[string]string{
+		"ListPRClosingIssues": `{"data":{"repository":{"pullRequest":{"closingIssuesReferences":{"nodes":[
+			{"number":12,"title":"Login quebra","state":"OPEN","url":"https://github.com/orch-labs/orch/issues/12",
+			 "repository":{"nameWithOwner":"orch-labs/orch"},
+			 "assignees":{"nodes":[{"login":"ana","avatarUrl":"https://avatars/ana"}]},
+			 "labels":{"nodes":[{"name":"bug","color":"d73a4a"}]}},
+			{"number":3,"title":"Outro repo","state":"CLOSED","url":"https://github.com/orch-labs/docs/issues/3",
+			 "repository":{"nameWithOwner":"orch-labs/docs"},"assignees":{"nodes":[]},"labels":{"nodes":[]}}]}}}}}`,
+	})
+
+	issues, err := service.GetLinkedIssues(context.Background(), "orch-labs", "orch", 42)
+	if err != nil {
+		t.Fatalf("GetLinkedIssues() error: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Number != 12 || issues[0].Assignees[0].Login != "ana" || issues[0].Labels[0].Name != "bug" {
+		t.Fatalf("unexpected linked issues: %+v", issues)
+	}
+	if issues[1].Repository != "orch-labs/docs" || issues[1].Labels == nil {
+		t.Fatalf("expected cross-repo issue with empty labels, got %+v", issues[1])
+	}
+}
+
+func TestGetIssueDevelopmentListsPullRequestsAndBranches(t *testing.T) {
+	service := newGraphQLTestService(t, map[string]string{
+		"GetIssueDevelopment": `{"data":{"repository":{"issue":{"number":12,
+			"closedByPullRequestsReferences":{"nodes":[
+				{"number":42,"title":"Fix login","state":"OPEN","isDraft":true,"url":"https://github.com/orch-labs/orch/pull/42",
+				 "headRefName":"fix/12-login","repository":{"nameWithOwner":"orch-labs/orch"}}]},
+			"linkedBranches":{"nodes":[
+				{"ref":{"name":"12-login-quebra","repository":{"nameWithOwner":"orch-labs/orch"}}},
+				{"ref":null}]}}}}}`,
+	})
+
+	development, err := service.GetIssueDevelopment(context.Background(), "orch-labs", "orch", 12)
+	if err != nil {
+		t.Fatalf("GetIssueDevelopment() error: %v", err)
+	}
+	if len(development.PullRequests) != 1 || !development.PullRequests[0].IsDraft || development.PullRequests[0].HeadBranch != "fix/12-login" {
+		t.Fatalf("unexpected pull requests: %+v", development.PullRequests)
+	}
+	if len(development.Branches) != 1 || development.Branches[0].Name != "12-login-quebra" {
+		t.Fatalf("expected deleted branch refs to be skipped, got %+v", development.Branches)
+	}
+
+	missing := newGraphQLTestService(t, map[string]string{
+		"GetIssueDevelopment": `{"data":{"repository":{"issue":null}}}`,
+	})
+	if _, err := missing.GetIssueDevelopment(context.Background(), "orch-labs", "orch", 999); err == nil {
+		t.Fatalf("expected missing issue to fail")
+	}
+}
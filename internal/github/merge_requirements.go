@@ -0,0 +1,465 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Códigos de MergeBlocker (estáveis, para o frontend traduzir/agrupar).
+const (
+	MergeBlockerNotOpen          = "not_open"
+	MergeBlockerDraft            = "draft"
+	MergeBlockerConflicts        = "conflicts"
+	MergeBlockerBehind           = "behind"
+	MergeBlockerCheckFailed      = "check_failed"
+	MergeBlockerCheckPending     = "check_pending"
+	MergeBlockerCheckMissing     = "check_missing"
+	MergeBlockerReviewsRequired  = "reviews_required"
+	MergeBlockerChangesRequested = "changes_requested"
+	MergeBlockerProtection       = "protection"
+	MergeBlockerComputing        = "computing"
+	MergeBlockerFailingChecks    = "failing_checks"
+	MergeBlockerProtectionHidden = "protection_hidden"
+)
+
+// Estados de RequiredCheckStatus.
+const (
+	CheckStateSuccess = "success"
+	CheckStateFailure = "failure"
+	CheckStatePending = "pending"
+	CheckStateMissing = "missing"
+)
+
+// MergeRequirements junta proteção da branch, checks, reviews e mergeable_state do GitHub
+// para o botão de merge explicar o que está bloqueando.
+type MergeRequirements struct {
+	Number         int                    `json:"number"`
+	State          string                 `json:"state"`
+	IsDraft        bool                   `json:"isDraft"`
+	BaseBranch     string                 `json:"baseBranch"`
+	HeadSHA        string                 `json:"headSha"`
+	Mergeable      *bool                  `json:"mergeable"`      // nil enquanto o GitHub calcula
+	MergeableState string                 `json:"mergeableState"` // clean | blocked | behind | dirty | unstable | draft | has_hooks | unknown
+	Protection     *BranchProtectionRules `json:"protection,omitempty"`
+	RequiredChecks []RequiredCheckStatus  `json:"requiredChecks"`
+	Reviews        ReviewRequirement      `json:"reviews"`
+	Ready          bool                   `json:"ready"`
+	Blockers       []MergeBlocker         `json:"blockers"`
+	Warnings       []MergeBlocker         `json:"warnings"`
+}
+
+// BranchProtectionRules são as regras de proteção relevantes para o merge.
+type BranchProtectionRules struct {
+	Branch                        string   `json:"branch"`
+	RequiredApprovingReviews      int      `json:"requiredApprovingReviews"`
+	RequireCodeOwnerReviews       bool     `json:"requireCodeOwnerReviews"`
+	DismissStaleReviews           bool     `json:"dismissStaleReviews"`
+	RequiredStatusChecks          []string `json:"requiredStatusChecks"`
+	RequireUpToDate               bool     `json:"requireUpToDate"` // "strict": branch precisa estar atualizada com a base
+	EnforceAdmins                 bool     `json:"enforceAdmins"`
+	RequireLinearHistory          bool     `json:"requireLinearHistory"`
+	RequireConversationResolution bool     `json:"requireConversationResolution"`
+	// Partial indica que só os checks obrigatórios puderam ser lidos (ler a proteção completa exige admin).
+	Partial bool `json:"partial"`
+}
+
+// RequiredCheckStatus é o estado de um check obrigatório no head do PR.
+type RequiredCheckStatus struct {
+	Name       string `json:"name"`
+	State      string `json:"state"` // success | failure | pending | missing
+	Conclusion string `json:"conclusion,omitempty"`
+	DetailsURL string `json:"detailsUrl,omitempty"`
+
+	// IDs para re-executar o check (GHRerunCheckRun / GHRerunFailedJobs); zero em commit statuses.
+	CheckRunID    int64 `json:"checkRunId,omitempty"`
+	WorkflowRunID int64 `json:"workflowRunId,omitempty"` // só em checks do GitHub Actions
+}
+
+// ReviewRequirement resume os reviews exigidos e os recebidos (último review de cada autor).
+type ReviewRequirement struct {
+	Required         int      `json:"required"`
+	Approvals        int      `json:"approvals"`
+	ApprovedBy       []string `json:"approvedBy"`
+	ChangesRequested []string `json:"changesRequested"`
+	CodeOwnerReview  bool     `json:"codeOwnerReview"` // exige aprovação de code owner
+}
+
+// MergeBlocker é um motivo (bloqueante ou aviso) exibido ao lado do botão de merge.
+type MergeBlocker struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+type restMergeStatePullRequest struct {
+	Number         int    `json:"number"`
+	State          string `json:"state"`
+	Draft          bool   `json:"draft"`
+	Merged         bool   `json:"merged"`
+	Mergeable      *bool  `json:"mergeable"`
+	MergeableState string `json:"mergeable_state"`
+	Head           struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+type restRequiredCheck struct {
+	Context string `json:"context"`
+}
+
+type restBranchProtection struct {
+	RequiredStatusChecks *struct {
+		Strict   bool                `json:"strict"`
+		Contexts []string            `json:"contexts"`
+		Checks   []restRequiredCheck `json:"checks"`
+	} `json:"required_status_checks"`
+	RequiredPullRequestReviews *struct {
+		DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+		RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	} `json:"required_pull_request_reviews"`
+	EnforceAdmins *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"enforce_admins"`
+	RequiredLinearHistory *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"required_linear_history"`
+	RequiredConversationResolution *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"required_conversation_resolution"`
+}
+
+// GetMergeRequirements consulta o estado de merge do PR sem cache (mergeable_state muda a cada push/check).
+func (s *Service) GetMergeRequirements(ctx context.Context, owner, repo string, number int) (*MergeRequirements, error) {
+	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(owner, repo)
+	if normalizeErr != nil {
+		return nil, normalizeErr
+	}
+	if number <= 0 {
+		return nil, &GitHubError{StatusCode: 422, Message: "pull request number must be > 0", Type: "validation"}
+	}
+	repoPath := fmt.Sprintf("/repos/%s/%s", url.PathEscape(normalizedOwner), url.PathEscape(normalizedRepo))
+
+	var pr restMergeStatePullRequest
+	if err := s.getRESTJSON(ctx, fmt.Sprintf("%s/pulls/%d", repoPath, number), nil, &pr); err != nil {
+		return nil, err
+	}
+	req := &MergeRequirements{
+		Number:         pr.Number,
+		State:          strings.ToUpper(strings.TrimSpace(pr.State)),
+		IsDraft:        pr.Draft,
+		BaseBranch:     strings.TrimSpace(pr.Base.Ref),
+		HeadSHA:        strings.TrimSpace(pr.Head.SHA),
+		Mergeable:      pr.Mergeable,
+		MergeableState: strings.ToLower(strings.TrimSpace(pr.MergeableState)),
+		RequiredChecks: []RequiredCheckStatus{},
+		Blockers:       []MergeBlocker{},
+		Warnings:       []MergeBlocker{},
+	}
+	if pr.Merged {
+		req.State = "MERGED"
+	}
+	if req.MergeableState == "" {
+		req.MergeableState = "unknown"
+	}
+
+	protection, protectionErr := s.getBranchProtection(ctx, repoPath, req.BaseBranch)
+	if protectionErr != nil {
+		req.Warnings = append(req.Warnings, MergeBlocker{
+			Code:    MergeBlockerProtectionHidden,
+			Message: "Não foi possível ler as regras de proteção da branch base.",
+			Detail:  protectionErr.Error(),
+		})
+	}
+	req.Protection = protection
+
+	if protection != nil && len(protection.RequiredStatusChecks) > 0 && req.HeadSHA != "" {
+		checks, err := s.getCommitCheckStates(ctx, repoPath, req.HeadSHA)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range protection.RequiredStatusChecks {
+			status, ok := checks[name]
+			if !ok {
+				status = RequiredCheckStatus{Name: name, State: CheckStateMissing}
+			}
+			req.RequiredChecks = append(req.RequiredChecks, status)
+		}
+	}
+
+	var reviews []restPullRequestReview
+	if err := s.getRESTJSON(ctx, fmt.Sprintf("%s/pulls/%d/reviews", repoPath, number), url.Values{"per_page": {"100"}}, &reviews); err != nil {
+		return nil, err
+	}
+	req.Reviews = summarizeReviews(reviews, protection)
+
+	evaluateMergeRequirements(req)
+	return req, nil
+}
+
+// evaluateMergeRequirements preenche Blockers/Warnings/Ready a partir dos dados coletados.
+func evaluateMergeRequirements(req *MergeRequirements) {
+	block := func(code, message, detail string) {
+		req.Blockers = append(req.Blockers, MergeBlocker{Code: code, Message: message, Detail: detail})
+	}
+	warn := func(code, message, detail string) {
+		req.Warnings = append(req.Warnings, MergeBlocker{Code: code, Message: message, Detail: detail})
+	}
+
+	if req.State != "OPEN" {
+		block(MergeBlockerNotOpen, "O Pull Request não está aberto.", strings.ToLower(req.State))
+	}
+	if req.IsDraft {
+		block(MergeBlockerDraft, "O Pull Request está em modo draft.", "Marque como pronto para review antes do merge.")
+	}
+	if (req.Mergeable != nil && !*req.Mergeable) || req.MergeableState == "dirty" {
+		block(MergeBlockerConflicts, "Há conflitos com a branch base.", "Resolva os conflitos e faça push novamente.")
+	}
+	if req.MergeableState == "behind" {
+		block(MergeBlockerBehind, "A branch está desatualizada com a base.", "Atualize a branch (merge ou rebase da base) antes do merge.")
+	}
+
+	for _, check := range req.RequiredChecks {
+		switch check.State {
+		case CheckStateFailure:
+			block(MergeBlockerCheckFailed, fmt.Sprintf("Check obrigatório falhou: %s.", check.Name), check.Conclusion)
+		case CheckStatePending:
+			block(MergeBlockerCheckPending, fmt.Sprintf("Check obrigatório em andamento: %s.", check.Name), "")
+		case CheckStateMissing:
+			block(MergeBlockerCheckMissing, fmt.Sprintf("Check obrigatório não reportado: %s.", check.Name), "O check ainda não rodou para o último commit.")
+		}
+	}
+
+	reviews := req.Reviews
+	if reviews.Required > 0 && reviews.Approvals < reviews.Required {
+		block(MergeBlockerReviewsRequired,
+			fmt.Sprintf("Aprovações insuficientes: %d de %d.", reviews.Approvals, reviews.Required),
+			"")
+	}
+	if len(reviews.ChangesRequested) > 0 {
+		detail := "Pedido por: " + strings.Join(reviews.ChangesRequested, ", ")
+		if reviews.Required > 0 {
+			block(MergeBlockerChangesRequested, "Há reviews pedindo alterações.", detail)
+		} else {
+			warn(MergeBlockerChangesRequested, "Há reviews pedindo alterações.", detail)
+		}
+	}
+
+	switch req.MergeableState {
+	case "unknown":
+		if req.State == "OPEN" {
+			block(MergeBlockerComputing, "O GitHub ainda está calculando se o merge é possível.", "Tente novamente em alguns segundos.")
+		}
+	case "blocked":
+		// Bloqueado sem motivo identificado: regra que a API não detalha (code owners, conversas, rulesets).
+		if len(req.Blockers) == 0 {
+			detail := ""
+			if reviews.CodeOwnerReview {
+				detail = "A branch exige aprovação de code owner."
+			} else if req.Protection != nil && req.Protection.RequireConversationResolution {
+				detail = "A branch exige que todas as conversas estejam resolvidas."
+			}
+			block(MergeBlockerProtection, "Merge bloqueado pelas regras de proteção da branch.", detail)
+		}
+	case "unstable":
+		warn(MergeBlockerFailingChecks, "Há checks não obrigatórios falhando ou pendentes.", "")
+	}
+
+	req.Ready = len(req.Blockers) == 0
+}
+
+type restPullRequestReview struct {
+	State string `json:"state"`
+	User  struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// summarizeReviews considera só o último review decisivo de cada autor (comentários não mudam o estado).
+func summarizeReviews(reviews []restPullRequestReview, protection *BranchProtectionRules) ReviewRequirement {
+	latest := make(map[string]string)
+	for _, review := range reviews {
+		login := strings.TrimSpace(review.User.Login)
+		state := strings.ToUpper(strings.TrimSpace(review.State))
+		if login == "" {
+			continue
+		}
+		switch state {
+		case "APPROVED", "CHANGES_REQUESTED":
+			latest[login] = state
+		case "DISMISSED":
+			delete(latest, login)
+		}
+	}
+
+	summary := ReviewRequirement{ApprovedBy: []string{}, ChangesRequested: []string{}}
+	if protection != nil {
+		summary.Required = protection.RequiredApprovingReviews
+		summary.CodeOwnerReview = protection.RequireCodeOwnerReviews
+	}
+	for login, state := range latest {
+		if state == "APPROVED" {
+			summary.ApprovedBy = append(summary.ApprovedBy, login)
+		} else {
+			summary.ChangesRequested = append(summary.ChangesRequested, login)
+		}
+	}
+	sort.Strings(summary.ApprovedBy)
+	sort.Strings(summary.ChangesRequested)
+	summary.Approvals = len(summary.ApprovedBy)
+	return summary
+}
+
+// getBranchProtection lê a proteção da branch; sem permissão de admin cai para os checks
+// obrigatórios expostos em /branches/{branch}. Branch sem proteção retorna nil.
+func (s *Service) getBranchProtection(ctx context.Context, repoPath, branch string) (*BranchProtectionRules, error) {
+	if branch == "" {
+		return nil, nil
+	}
+	branchPath := fmt.Sprintf("%s/branches/%s", repoPath, url.PathEscape(branch))
+
+	var raw restBranchProtection
+	err := s.getRESTJSON(ctx, branchPath+"/protection", nil, &raw)
+	if err == nil {
+		rules := &BranchProtectionRules{Branch: branch, RequiredStatusChecks: []string{}}
+		if checks := raw.RequiredStatusChecks; checks != nil {
+			rules.RequireUpToDate = checks.Strict
+			rules.RequiredStatusChecks = mergeCheckNames(checks.Contexts, checks.Checks)
+		}
+		if reviews := raw.RequiredPullRequestReviews; reviews != nil {
+			rules.RequiredApprovingReviews = reviews.RequiredApprovingReviewCount
+			rules.RequireCodeOwnerReviews = reviews.RequireCodeOwnerReviews
+			rules.DismissStaleReviews = reviews.DismissStaleReviews
+		}
+		rules.EnforceAdmins = raw.EnforceAdmins != nil && raw.EnforceAdmins.Enabled
+		rules.RequireLinearHistory = raw.RequiredLinearHistory != nil && raw.RequiredLinearHistory.Enabled
+		rules.RequireConversationResolution = raw.RequiredConversationResolution != nil && raw.RequiredConversationResolution.Enabled
+		return rules, nil
+	}
+
+	var githubErr *GitHubError
+	if !errors.As(err, &githubErr) || (githubErr.StatusCode != http.StatusNotFound && githubErr.StatusCode != http.StatusForbidden) {
+		return nil, err
+	}
+
+	// 404 = branch sem proteção ou token sem admin; /branches/{branch} diz qual dos dois.
+	var summary struct {
+		Protected  bool `json:"protected"`
+		Protection struct {
+			RequiredStatusChecks struct {
+				Contexts []string            `json:"contexts"`
+				Checks   []restRequiredCheck `json:"checks"`
+			} `json:"required_status_checks"`
+		} `json:"protection"`
+	}
+	if fallbackErr := s.getRESTJSON(ctx, branchPath, nil, &summary); fallbackErr != nil {
+		return nil, fallbackErr
+	}
+	if !summary.Protected {
+		return nil, nil
+	}
+	checks := summary.Protection.RequiredStatusChecks
+	return &BranchProtectionRules{
+		Branch:               branch,
+		RequiredStatusChecks: mergeCheckNames(checks.Contexts, checks.Checks),
+		Partial:              true,
+	}, nil
+}
+
+func mergeCheckNames(contexts []string, checks []restRequiredCheck) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0, len(contexts)+len(checks))
+	add := func(name string) {
+		name = strings.TrimSpace(name)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, name := range contexts {
+		add(name)
+	}
+	for _, check := range checks {
+		add(check.Context)
+	}
+	return names
+}
+
+// getCommitCheckStates junta check runs (Actions/apps) e commit statuses (CI legado) do commit.
+func (s *Service) getCommitCheckStates(ctx context.Context, repoPath, sha string) (map[string]RequiredCheckStatus, error) {
+	commitPath := fmt.Sprintf("%s/commits/%s", repoPath, url.PathEscape(sha))
+	states := make(map[string]RequiredCheckStatus)
+
+	var statuses struct {
+		Statuses []struct {
+			Context   string `json:"context"`
+			State     string `json:"state"`
+			TargetURL string `json:"target_url"`
+		} `json:"statuses"`
+	}
+	if err := s.getRESTJSON(ctx, commitPath+"/status", url.Values{"per_page": {"100"}}, &statuses); err != nil {
+		return nil, err
+	}
+	for _, status := range statuses.Statuses {
+		state := CheckStatePending
+		switch strings.ToLower(status.State) {
+		case "success":
+			state = CheckStateSuccess
+		case "failure", "error":
+			state = CheckStateFailure
+		}
+		states[status.Context] = RequiredCheckStatus{Name: status.Context, State: state, Conclusion: status.State, DetailsURL: status.TargetURL}
+	}
+
+	var runs struct {
+		CheckRuns []struct {
+			ID         int64  `json:"id"`
+			Name       string `json:"name"`
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+			DetailsURL string `json:"details_url"`
+			HTMLURL    string `json:"html_url"`
+		} `json:"check_runs"`
+	}
+	if err := s.getRESTJSON(ctx, commitPath+"/check-runs", url.Values{"per_page": {"100"}, "filter": {"latest"}}, &runs); err != nil {
+		return nil, err
+	}
+	for _, run := range runs.CheckRuns {
+		state := CheckStatePending
+		if strings.EqualFold(run.Status, "completed") {
+			switch strings.ToLower(run.Conclusion) {
+			case "success", "neutral", "skipped":
+				state = CheckStateSuccess
+			default:
+				state = CheckStateFailure
+			}
+		}
+		states[run.Name] = RequiredCheckStatus{
+			Name:          run.Name,
+			State:         state,
+			Conclusion:    run.Conclusion,
+			DetailsURL:    cmp.Or(run.HTMLURL, run.DetailsURL),
+			CheckRunID:    run.ID,
+			WorkflowRunID: cmp.Or(workflowRunIDFromURL(run.DetailsURL), workflowRunIDFromURL(run.HTMLURL)),
+		}
+	}
+	return states, nil
+}
+
+func (s *Service) getRESTJSON(ctx context.Context, endpointPath string, query url.Values, result interface{}) error {
+	body, _, err := s.executeRESTRequest(ctx, http.MethodGet, endpointPath, query, githubRESTAcceptJSON, nil)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, result)
+}
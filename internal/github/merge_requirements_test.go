@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newMergeRequirementsTestService(t *testing.T, routes map[string]string) *Service {
+	t.Helper()
+	service := NewService(func() (string, error) {
+		return "gh-token", nil
+	})
+	service.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, ok := routes[req.URL.Path]
+			status := http.StatusOK
+			if !ok {
+				status, body = http.StatusNotFound, `{"message":"Not Found"}`
+			}
+			if strings.HasPrefix(body, "403:") {
+				status, body = http.StatusForbidden, strings.TrimPrefix(body, "403:")
+			}
+			return &http.Response{
+				StatusCode: status,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}
+	return service
+}
+
+func blockerCodes(blockers []MergeBlocker) []string {
+	codes := make([]string, 0, len(blockers))
+	for _, blocker := range blockers {
+		codes = append(codes, blocker.Code)
+	}
+	return codes
+}
+
+func TestGetMergeRequirementsExplainsBlockers(t *testing.T) {
+	service := newMergeRequirementsTestService(t, map[string]string{
+		"/repos/orch-labs/orch/pulls/42": `{"number":42,"state":"open","draft":false,"mergeable":true,"mergeable_state":"blocked",
+			"head":{"sha":"abc123"},"base":{"ref":"main"}}`,
+		"/repos/orch-labs/orch/branches/main/protection": `{
+			"required_status_checks":{"strict":true,"contexts":["ci/lint"],"checks":[{"context":"build"},{"context":"ci/lint"},{"context":"e2e"}]},
+			"required_pull_request_reviews":{"required_approving_review_count":2,"require_code_owner_reviews":true},
+			"required_conversation_resolution":{"enabled":true}}`,
+		"/repos/orch-labs/orch/commits/abc123/status": `{"statuses":[{"context":"ci/lint","state":"success","target_url":"https://ci/lint"}]}`,
+		"/repos/orch-labs/orch/commits/abc123/check-runs": `{"check_runs":[
+			{"id":31,"name":"build","status":"completed","conclusion":"failure","html_url":"https://github.com/run/1",
+				"details_url":"https://github.com/orch-labs/orch/actions/runs/808/job/31"}]}`,
+		"/repos/orch-labs/orch/pulls/42/reviews": `[
+			{"state":"CHANGES_REQUESTED","user":{"login":"ana"}},
+			{"state":"APPROVED","user":{"login":"ana"}},
+			{"state":"COMMENTED","user":{"login":"bruno"}},
+			{"state":"CHANGES_REQUESTED","user":{"login":"caio"}}]`,
+	})
+
+	req, err := service.GetMergeRequirements(context.Background(), "orch-labs", "orch", 42)
+	if err != nil {
+		t.Fatalf("GetMergeRequirements() error: %v", err)
+	}
+	if req.Ready || req.Protection == nil || req.Protection.Partial || !req.Protection.RequireUpToDate {
+		t.Fatalf("unexpected readiness/protection: %+v", req)
+	}
+	if got := strings.Join(req.Protection.RequiredStatusChecks, ","); got != "ci/lint,build,e2e" {
+		t.Fatalf("unexpected required checks: %s", got)
+	}
+	states := map[string]string{}
+	for _, check := range req.RequiredChecks {
+		states[check.Name] = check.State
+		if check.Name == "build" && (check.CheckRunID != 31 || check.WorkflowRunID != 808) {
+			t.Fatalf("expected re-run ids on failed check, got %+v", check)
+		}
+	}
+	if states["ci/lint"] != CheckStateSuccess || states["build"] != CheckStateFailure || states["e2e"] != CheckStateMissing {
+		t.Fatalf("unexpected check states: %v", states)
+	}
+	if req.Reviews.Required != 2 || req.Reviews.Approvals != 1 || strings.Join(req.Reviews.ChangesRequested, ",") != "caio" {
+		t.Fatalf("unexpected review summary: %+v", req.Reviews)
+	}
+	want := "check_failed,check_missing,reviews_required,changes_requested"
+	if got := strings.Join(blockerCodes(req.Blockers), ","); got != want {
+		t.Fatalf("unexpected blockers: got=%s want=%s", got, want)
+	}
+}
+
+func TestGetMergeRequirementsFallsBackWithoutAdminAccess(t *testing.T) {
+	service := newMergeRequirementsTestService(t, map[string]string{
+		"/repos/orch-labs/orch/pulls/7": `{"number":7,"state":"open","mergeable":null,"mergeable_state":"unknown",
+			"head":{"sha":"def456"},"base":{"ref":"release/1.x"}}`,
+		"/repos/orch-labs/orch/branches/release/1.x/protection": `403:{"message":"Must have admin rights to Repository."}`,
+		"/repos/orch-labs/orch/branches/release/1.x": `{"name":"release/1.x","protected":true,
+			"protection":{"required_status_checks":{"contexts":["build"]}}}`,
+		"/repos/orch-labs/orch/commits/def456/status":     `{"statuses":[]}`,
+		"/repos/orch-labs/orch/commits/def456/check-runs": `{"check_runs":[{"name":"build","status":"in_progress"}]}`,
+		"/repos/orch-labs/orch/pulls/7/reviews":           `[]`,
+	})
+
+	req, err := service.GetMergeRequirements(context.Background(), "orch-labs", "orch", 7)
+	if err != nil {
+		t.Fatalf("GetMergeRequirements() error: %v", err)
+	}
+	if req.Protection == nil || !req.Protection.Partial || len(req.RequiredChecks) != 1 || req.RequiredChecks[0].State != CheckStatePending {
+		t.Fatalf("expected partial protection with pending check, got %+v", req)
+	}
+	if got := strings.Join(blockerCodes(req.Blockers), ","); got != "check_pending,computing" {
+		t.Fatalf("unexpected blockers: %s", got)
+	}
+
+	unprotected := newMergeRequirementsTestService(t, map[string]string{
+		"/repos/orch-labs/orch/pulls/8": `{"number":8,"state":"open","mergeable":true,"mergeable_state":"clean",
+			"head":{"sha":"fff000"},"base":{"ref":"dev"}}`,
+		"/repos/orch-labs/orch/branches/dev":    `{"name":"dev","protected":false}`,
+		"/repos/orch-labs/orch/pulls/8/reviews": `[{"state":"CHANGES_REQUESTED","user":{"login":"ana"}}]`,
+	})
+	req, err = unprotected.GetMergeRequirements(context.Background(), "orch-labs", "orch", 8)
+	if err != nil {
+		t.Fatalf("GetMergeRequirements() error: %v", err)
+	}
+	if !req.Ready || req.Protection != nil || len(req.Warnings) != 1 || req.Warnings[0].Code != MergeBlockerChangesRequested {
+		t.Fatalf("expected ready PR with a changes-requested warning, got %+v", req)
+	}
+}
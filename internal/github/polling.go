@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"strings"
 	"sync"
 	"time"
 )
@@ -30,6 +31,12 @@ var pollingIntervals = map[PollingContext]time.Duration{
 	PollingContextCollaborate: 10 * time.Second,
 }
 
+// Com o webhook bridge conectado o polling vira rede de segurança para eventos perdidos.
+const (
+	pushModeBackoffFactor = 8
+	pushModeMinInterval   = 5 * time.Minute
+)
+
 // === Rate Limit Tracker ===
 
 // RateLimitTracker rastreia o rate limit do GitHub API
@@ -117,9 +124,10 @@ func (t *RateLimitTracker) GetInfo() RateLimitInfo {
 
 // RateLimitInfo é a info de rate limit exposta ao frontend
 type RateLimitInfo struct {
-	Remaining int       `json:"remaining"`
-	Limit     int       `json:"limit"`
-	ResetAt   time.Time `json:"resetAt"`
+	Remaining int               `json:"remaining"`
+	Limit     int               `json:"limit"`
+	ResetAt   time.Time         `json:"resetAt"`
+	Budget    *RateBudgetStatus `json:"budget,omitempty"`
 }
 
 // === Poller ===
@@ -134,12 +142,20 @@ type Poller struct {
 	repo      string
 	cancel    context.CancelFunc
 	running   bool
+	pushMode  bool                             // webhook bridge conectado
+	wake      chan struct{}                    // interrompe a espera quando o push mode cai
+	intervals map[PollingContext]time.Duration // overrides do arquivo de configuração
 
 	// Callback para emitir eventos Wails
 	emitEvent func(eventName string, data interface{})
 
 	// lastPRsUpdatedAt armazena o updatedAt mais recente dos PRs
 	lastPRsUpdatedAt time.Time
+
+	// Lease entre instâncias: só quem o detém faz polling do repositório (nil = sempre).
+	acquireLease func(resource string) bool
+	releaseLease func(resource string)
+	standby      bool // último ciclo foi pulado por outra instância deter o lease
 }
 
 // NewPoller cria um novo Poller
@@ -149,9 +165,45 @@ func NewPoller(service *Service, emitEvent func(eventName string, data interface
 		rateLimit: NewRateLimitTracker(),
 		context:   PollingContextBackground,
 		emitEvent: emitEvent,
+		wake:      make(chan struct{}, 1),
 	}
 }
 
+// DefaultPollingInterval retorna o intervalo base padrão do contexto.
+func DefaultPollingInterval(pollingCtx PollingContext) time.Duration {
+	if interval, ok := pollingIntervals[pollingCtx]; ok {
+		return interval
+	}
+	return 120 * time.Second
+}
+
+// SetIntervals substitui os intervalos base por contexto (vale a partir do próximo ciclo).
+func (p *Poller) SetIntervals(intervals map[PollingContext]time.Duration) {
+	overrides := make(map[PollingContext]time.Duration, len(intervals))
+	for pollingCtx, interval := range intervals {
+		if interval > 0 {
+			overrides[pollingCtx] = interval
+		}
+	}
+	p.mu.Lock()
+	p.intervals = overrides
+	p.mu.Unlock()
+}
+
+// SetLeaseGate faz o poller consultar o lease do repositório antes de cada ciclo, para que
+// duas instâncias no mesmo data dir não façam polling em dobro.
+func (p *Poller) SetLeaseGate(acquire func(resource string) bool, release func(resource string)) {
+	p.mu.Lock()
+	p.acquireLease = acquire
+	p.releaseLease = release
+	p.mu.Unlock()
+}
+
+// pollerLeaseName identifica o lease de polling do repositório.
+func pollerLeaseName(owner, repo string) string {
+	return "github.poller:" + strings.ToLower(owner+"/"+repo)
+}
+
 // StartPolling inicia o polling para um repositório
 func (p *Poller) StartPolling(owner, repo string) {
 	p.mu.Lock()
@@ -161,6 +213,9 @@ func (p *Poller) StartPolling(owner, repo string) {
 	if p.cancel != nil {
 		p.cancel()
 	}
+	if p.releaseLease != nil && p.owner != "" && pollerLeaseName(p.owner, p.repo) != pollerLeaseName(owner, repo) {
+		go p.releaseLease(pollerLeaseName(p.owner, p.repo))
+	}
 
 	p.owner = owner
 	p.repo = repo
@@ -183,7 +238,11 @@ func (p *Poller) StopPolling() {
 		p.cancel()
 		p.cancel = nil
 	}
+	if p.releaseLease != nil && p.owner != "" {
+		go p.releaseLease(pollerLeaseName(p.owner, p.repo))
+	}
 	p.running = false
+	p.standby = false
 	log.Println("[Poller] Stopped polling")
 }
 
@@ -201,9 +260,87 @@ func (p *Poller) SetContext(ctx PollingContext) {
 	log.Printf("[Poller] Context changed: %s → %s", oldCtx, ctx)
 }
 
-// GetRateLimitInfo retorna informações do rate limit
+// SetPushMode liga o recuo do polling enquanto o webhook bridge entrega eventos.
+// Ao desligar, faz um poll imediato para cobrir eventos perdidos na desconexão.
+func (p *Poller) SetPushMode(active bool) {
+	p.mu.Lock()
+	changed := p.pushMode != active
+	p.pushMode = active
+	p.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	log.Printf("[Poller] Push mode: %t", active)
+	if !active {
+		select {
+		case p.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// HandleWebhookEvent invalida o cache do repositório do evento e notifica o frontend.
+func (p *Poller) HandleWebhookEvent(event WebhookEvent) {
+	if event.Owner == "" || event.Repo == "" {
+		return
+	}
+	p.service.cache.Invalidate(event.Owner, event.Repo)
+	if p.emitEvent == nil {
+		return
+	}
+
+	switch event.Event {
+	case "pull_request", "pull_request_review", "pull_request_review_comment", "pull_request_review_thread":
+		p.emitWebhookPRChange(event)
+	case "issues", "issue_comment":
+		if event.IsPullRequest {
+			p.emitWebhookPRChange(event)
+			return
+		}
+		p.emitEvent("github:issues:updated", map[string]interface{}{
+			"owner":  event.Owner,
+			"repo":   event.Repo,
+			"number": event.Number,
+			"action": event.Action,
+			"source": "webhook",
+		})
+	case "check_run", "check_suite", "status":
+		p.emitEvent("github:checks:updated", map[string]interface{}{
+			"owner":  event.Owner,
+			"repo":   event.Repo,
+			"number": event.Number,
+			"event":  event.Event,
+			"source": "webhook",
+		})
+	}
+}
+
+func (p *Poller) emitWebhookPRChange(event WebhookEvent) {
+	changeType := "updated"
+	if event.Event == "pull_request" && (event.Action == "opened" || event.Action == "reopened") {
+		changeType = "new"
+	}
+	p.emitEvent("github:prs:updated", map[string]interface{}{
+		"owner": event.Owner,
+		"repo":  event.Repo,
+		"changes": []PRChange{{
+			Number:     event.Number,
+			Title:      event.Title,
+			UpdatedAt:  event.ReceivedAt,
+			ChangeType: changeType,
+		}},
+		"count":  1,
+		"source": "webhook",
+	})
+}
+
+// GetRateLimitInfo retorna informações do rate limit, com o budget por categoria
 func (p *Poller) GetRateLimitInfo() RateLimitInfo {
-	return p.rateLimit.GetInfo()
+	info := p.rateLimit.GetInfo()
+	budget := p.service.RateBudgetStatus()
+	info.Budget = &budget
+	return info
 }
 
 // IsRunning retorna se o poller está rodando
@@ -228,6 +365,8 @@ func (p *Poller) pollingLoop(ctx context.Context) {
 			return
 		case <-time.After(interval):
 			p.poll(ctx)
+		case <-p.wake:
+			p.poll(ctx)
 		}
 	}
 }
@@ -235,12 +374,21 @@ func (p *Poller) pollingLoop(ctx context.Context) {
 func (p *Poller) calculateInterval() time.Duration {
 	p.mu.RLock()
 	pollingCtx := p.context
+	pushMode := p.pushMode
 	p.mu.RUnlock()
 
-	// Pegar intervalo base do contexto
-	base, ok := pollingIntervals[pollingCtx]
+	// Pegar intervalo base do contexto (override configurado > padrão)
+	p.mu.RLock()
+	base, ok := p.intervals[pollingCtx]
+	p.mu.RUnlock()
 	if !ok {
-		base = 120 * time.Second
+		base = DefaultPollingInterval(pollingCtx)
+	}
+	if pushMode {
+		base *= pushModeBackoffFactor
+		if base < pushModeMinInterval {
+			base = pushModeMinInterval
+		}
 	}
 
 	// Ajustar baseado no rate limit
@@ -264,12 +412,28 @@ func (p *Poller) poll(ctx context.Context) {
 	p.mu.RLock()
 	owner := p.owner
 	repo := p.repo
+	acquireLease := p.acquireLease
 	p.mu.RUnlock()
 
 	if owner == "" || repo == "" {
 		return
 	}
 
+	// Outra instância já faz polling deste repositório: fica em espera e tenta no próximo ciclo.
+	if acquireLease != nil && !acquireLease(pollerLeaseName(owner, repo)) {
+		p.mu.Lock()
+		first := !p.standby
+		p.standby = true
+		p.mu.Unlock()
+		if first {
+			log.Printf("[Poller] Another instance is polling %s/%s, standing by", owner, repo)
+		}
+		return
+	}
+	p.mu.Lock()
+	p.standby = false
+	p.mu.Unlock()
+
 	// Delta-based polling: buscar PRs recentes e comparar updatedAt
 	changes := p.pollForPRChanges(ctx, owner, repo)
 
@@ -321,7 +485,7 @@ func (p *Poller) pollForPRChanges(ctx context.Context, owner, repo string) []PRC
 		}
 	}`
 
-	data, err := p.service.executeQuery(query, map[string]interface{}{
+	data, err := p.service.executeQueryWithPriority(ctx, PriorityPoller, "poller", query, map[string]interface{}{
 		"owner": owner,
 		"repo":  repo,
 	})
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"orch/internal/httpclient"
 )
 
 // PRRequestTelemetry representa metrica minima de request de PR REST.
@@ -87,6 +89,11 @@ func (s *Service) emitPRReadTelemetry(method, endpoint string, statusCode int, s
 		}
 	}
 
+	if normalizedCache == "hit" && statusCode != http.StatusNotModified {
+		// 304 já aparece no inspector pelo transporte; aqui só o cache servido sem request.
+		httpclient.RecordCacheHit(httpclient.ServiceGitHub, normalizedMethod, normalizedEndpoint)
+	}
+
 	s.emitTelemetry("gitpanel:prs_cache", PRCacheTelemetry{
 		Method:   normalizedMethod,
 		Endpoint: normalizedEndpoint,
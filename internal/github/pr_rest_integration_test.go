@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -232,7 +233,7 @@ func TestPRRESTIntegrationReadFlowWithMockGitHubAPI(t *testing.T) {
 		t.Fatalf("unexpected request path in read integration test: %s", r.URL.Path)
 	})
 
-	firstList, err := service.ListPullRequests("acme", "orch", PRFilters{
+	firstList, err := service.ListPullRequests(context.Background(), "acme", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -249,7 +250,7 @@ func TestPRRESTIntegrationReadFlowWithMockGitHubAPI(t *testing.T) {
 	service.cache.updatedAt[listCacheKey] = time.Now().Add(-time.Minute)
 	service.cache.mu.Unlock()
 
-	secondList, err := service.ListPullRequests("acme", "orch", PRFilters{
+	secondList, err := service.ListPullRequests(context.Background(), "acme", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -264,7 +265,7 @@ func TestPRRESTIntegrationReadFlowWithMockGitHubAPI(t *testing.T) {
 	// A listagem reaproveita detalhes no cache; invalidamos para forçar GET /pulls/{number}.
 	service.cache.InvalidatePRDetail("acme", "orch", 42)
 
-	detail, err := service.GetPullRequest("acme", "orch", 42)
+	detail, err := service.GetPullRequest(context.Background(), "acme", "orch", 42)
 	if err != nil {
 		t.Fatalf("GetPullRequest() error: %v", err)
 	}
@@ -272,7 +273,7 @@ func TestPRRESTIntegrationReadFlowWithMockGitHubAPI(t *testing.T) {
 		t.Fatalf("unexpected detail payload: %+v", detail)
 	}
 
-	commitPage, err := service.GetPullRequestCommits("acme", "orch", 42, 1, 25)
+	commitPage, err := service.GetPullRequestCommits(context.Background(), "acme", "orch", 42, 1, 25)
 	if err != nil {
 		t.Fatalf("GetPullRequestCommits() error: %v", err)
 	}
@@ -280,7 +281,7 @@ func TestPRRESTIntegrationReadFlowWithMockGitHubAPI(t *testing.T) {
 		t.Fatalf("unexpected commits payload: %+v", commitPage)
 	}
 
-	filePage, err := service.GetPullRequestFiles("acme", "orch", 42, 1, 25)
+	filePage, err := service.GetPullRequestFiles(context.Background(), "acme", "orch", 42, 1, 25)
 	if err != nil {
 		t.Fatalf("GetPullRequestFiles() error: %v", err)
 	}
@@ -288,7 +289,7 @@ func TestPRRESTIntegrationReadFlowWithMockGitHubAPI(t *testing.T) {
 		t.Fatalf("unexpected files payload: %+v", filePage)
 	}
 
-	rawDiff, err := service.GetPullRequestRawDiff("acme", "orch", 42)
+	rawDiff, err := service.GetPullRequestRawDiff(context.Background(), "acme", "orch", 42)
 	if err != nil {
 		t.Fatalf("GetPullRequestRawDiff() error: %v", err)
 	}
@@ -296,7 +297,7 @@ func TestPRRESTIntegrationReadFlowWithMockGitHubAPI(t *testing.T) {
 		t.Fatalf("unexpected raw diff payload: %q", rawDiff)
 	}
 
-	merged, err := service.CheckPullRequestMerged("acme", "orch", 42)
+	merged, err := service.CheckPullRequestMerged(context.Background(), "acme", "orch", 42)
 	if err != nil {
 		t.Fatalf("CheckPullRequestMerged() error: %v", err)
 	}
@@ -593,7 +594,7 @@ func TestPRRESTIntegrationWriteFlowWithMockGitHubAPI(t *testing.T) {
 		t.Fatalf("unexpected request in write integration test: method=%s path=%s", r.Method, r.URL.Path)
 	})
 
-	openBeforeCreate, err := service.ListPullRequests("acme", "orch", PRFilters{
+	openBeforeCreate, err := service.ListPullRequests(context.Background(), "acme", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -606,7 +607,7 @@ func TestPRRESTIntegrationWriteFlowWithMockGitHubAPI(t *testing.T) {
 	}
 
 	canModifyOnCreate := true
-	created, err := service.CreatePullRequest(CreatePRInput{
+	created, err := service.CreatePullRequest(context.Background(), CreatePRInput{
 		Owner:               "acme",
 		Repo:                "orch",
 		Title:               "Create integration PR",
@@ -623,7 +624,7 @@ func TestPRRESTIntegrationWriteFlowWithMockGitHubAPI(t *testing.T) {
 		t.Fatalf("unexpected created PR payload: %+v", created)
 	}
 
-	openAfterCreate, err := service.ListPullRequests("acme", "orch", PRFilters{
+	openAfterCreate, err := service.ListPullRequests(context.Background(), "acme", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -640,7 +641,7 @@ func TestPRRESTIntegrationWriteFlowWithMockGitHubAPI(t *testing.T) {
 	updateState := "OPEN"
 	updateBase := "release/v2"
 	canModifyOnUpdate := false
-	updated, err := service.UpdatePullRequest(UpdatePRInput{
+	updated, err := service.UpdatePullRequest(context.Background(), UpdatePRInput{
 		Owner:               "acme",
 		Repo:                "orch",
 		Number:              102,
@@ -657,7 +658,7 @@ func TestPRRESTIntegrationWriteFlowWithMockGitHubAPI(t *testing.T) {
 		t.Fatalf("unexpected updated PR payload: %+v", updated)
 	}
 
-	mergedBeforeMerge, err := service.CheckPullRequestMerged("acme", "orch", 102)
+	mergedBeforeMerge, err := service.CheckPullRequestMerged(context.Background(), "acme", "orch", 102)
 	if err != nil {
 		t.Fatalf("CheckPullRequestMerged() before merge error: %v", err)
 	}
@@ -666,7 +667,7 @@ func TestPRRESTIntegrationWriteFlowWithMockGitHubAPI(t *testing.T) {
 	}
 
 	expectedHeadSHA := "A145A1F1CE6AEE16DE4F9517D2F41295BB12F341"
-	updateBranchResult, err := service.UpdatePullRequestBranch(UpdatePRBranchInput{
+	updateBranchResult, err := service.UpdatePullRequestBranch(context.Background(), UpdatePRBranchInput{
 		Owner:           "acme",
 		Repo:            "orch",
 		Number:          102,
@@ -680,7 +681,7 @@ func TestPRRESTIntegrationWriteFlowWithMockGitHubAPI(t *testing.T) {
 	}
 
 	mergeSHA := "B145A1F1CE6AEE16DE4F9517D2F41295BB12F342"
-	mergeResult, err := service.MergePullRequestREST(MergePRInput{
+	mergeResult, err := service.MergePullRequestREST(context.Background(), MergePRInput{
 		Owner:       "acme",
 		Repo:        "orch",
 		Number:      102,
@@ -694,7 +695,7 @@ func TestPRRESTIntegrationWriteFlowWithMockGitHubAPI(t *testing.T) {
 		t.Fatalf("unexpected merge result payload: %+v", mergeResult)
 	}
 
-	mergedAfterMerge, err := service.CheckPullRequestMerged("acme", "orch", 102)
+	mergedAfterMerge, err := service.CheckPullRequestMerged(context.Background(), "acme", "orch", 102)
 	if err != nil {
 		t.Fatalf("CheckPullRequestMerged() after merge error: %v", err)
 	}
@@ -702,7 +703,7 @@ func TestPRRESTIntegrationWriteFlowWithMockGitHubAPI(t *testing.T) {
 		t.Fatalf("expected merged=true after merge")
 	}
 
-	openAfterMerge, err := service.ListPullRequests("acme", "orch", PRFilters{
+	openAfterMerge, err := service.ListPullRequests(context.Background(), "acme", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -714,7 +715,7 @@ func TestPRRESTIntegrationWriteFlowWithMockGitHubAPI(t *testing.T) {
 		t.Fatalf("merged PR should not remain in open list: %+v", openAfterMerge)
 	}
 
-	closedAfterMerge, err := service.ListPullRequests("acme", "orch", PRFilters{
+	closedAfterMerge, err := service.ListPullRequests(context.Background(), "acme", "orch", PRFilters{
 		State:   "closed",
 		Page:    1,
 		PerPage: 25,
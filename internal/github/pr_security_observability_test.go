@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -24,7 +25,7 @@ func TestListPullRequestsRESTRejectsUnsafeOwnerRepoBeforeHTTP(t *testing.T) {
 		}),
 	}
 
-	_, err := service.ListPullRequests("../bad-owner", "orch", PRFilters{State: "open"})
+	_, err := service.ListPullRequests(context.Background(), "../bad-owner", "orch", PRFilters{State: "open"})
 	if err == nil {
 		t.Fatalf("expected validation error for invalid owner")
 	}
@@ -94,11 +95,11 @@ func TestListPullRequestsRESTEmitsRequestTelemetryWithCacheState(t *testing.T) {
 		}),
 	}
 
-	_, err := service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
+	_, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("first ListPullRequests() error: %v", err)
 	}
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("second ListPullRequests() error: %v", err)
 	}
@@ -197,7 +198,7 @@ func TestCreatePullRequestEmitsActionResultTelemetryOnSuccess(t *testing.T) {
 		}),
 	}
 
-	created, err := service.CreatePullRequest(CreatePRInput{
+	created, err := service.CreatePullRequest(context.Background(), CreatePRInput{
 		Owner:      "orch-labs",
 		Repo:       "orch",
 		Title:      "Telemetry action",
@@ -278,7 +279,7 @@ func TestMergePullRequestRESTEmitsActionResultTelemetryOnConflict(t *testing.T)
 		}),
 	}
 
-	result, err := service.MergePullRequestREST(MergePRInput{
+	result, err := service.MergePullRequestREST(context.Background(), MergePRInput{
 		Owner:       "orch-labs",
 		Repo:        "orch",
 		Number:      42,
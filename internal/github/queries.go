@@ -227,6 +227,159 @@ query ListIssues($owner: String!, $repo: String!, $first: Int!, $after: String,
 }
 `
 
+// QueryListPRClosingIssues busca as issues que um PR fecha ao ser mergeado
+const QueryListPRClosingIssues = `
+query ListPRClosingIssues($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      closingIssuesReferences(first: 50) {
+        nodes {
+          number
+          title
+          state
+          url
+          repository {
+            nameWithOwner
+          }
+          assignees(first: 5) {
+            nodes {
+              login
+              avatarUrl
+            }
+          }
+          labels(first: 10) {
+            nodes {
+              name
+              color
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+// QueryGetIssueDevelopment busca PRs que fecham a issue e branches vinculados a ela
+const QueryGetIssueDevelopment = `
+query GetIssueDevelopment($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    issue(number: $number) {
+      number
+      closedByPullRequestsReferences(first: 50, includeClosedPrs: true) {
+        nodes {
+          number
+          title
+          state
+          isDraft
+          url
+          headRefName
+          repository {
+            nameWithOwner
+          }
+        }
+      }
+      linkedBranches(first: 20) {
+        nodes {
+          ref {
+            name
+            repository {
+              nameWithOwner
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+// QuerySearchPullRequests busca PRs de qualquer repositório via search (dashboard)
+const QuerySearchPullRequests = `
+query SearchPullRequests($query: String!, $first: Int!, $after: String) {
+  search(query: $query, type: ISSUE, first: $first, after: $after) {
+    issueCount
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    nodes {
+      ... on PullRequest {
+        number
+        title
+        url
+        state
+        isDraft
+        createdAt
+        updatedAt
+        headRefName
+        baseRefName
+        reviewDecision
+        repository {
+          name
+          owner {
+            login
+          }
+        }
+        author {
+          login
+          avatarUrl
+        }
+        commits(last: 1) {
+          nodes {
+            commit {
+              statusCheckRollup {
+                state
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+// QuerySearchIssues busca issues via search (filtros salvos do quadro de triagem)
+const QuerySearchIssues = `
+query SearchIssues($query: String!, $first: Int!, $after: String) {
+  search(query: $query, type: ISSUE, first: $first, after: $after) {
+    issueCount
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    nodes {
+      ... on Issue {
+        id
+        number
+        title
+        body
+        state
+        createdAt
+        updatedAt
+        author {
+          login
+          avatarUrl
+        }
+        assignees(first: 5) {
+          nodes {
+            login
+            avatarUrl
+          }
+        }
+        labels(first: 10) {
+          nodes {
+            name
+            color
+          }
+        }
+      }
+    }
+  }
+}
+`
+
 // QueryListBranches busca branches de um repositório
 const QueryListBranches = `
 query ListBranches($owner: String!, $repo: String!, $first: Int!) {
@@ -246,6 +399,18 @@ query ListBranches($owner: String!, $repo: String!, $first: Int!) {
 }
 `
 
+// QueryListPRTemplates busca os templates de descrição de PR do branch padrão
+const QueryListPRTemplates = `
+query ListPRTemplates($owner: String!, $repo: String!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequestTemplates {
+      filename
+      body
+    }
+  }
+}
+`
+
 // QueryListRepositories busca repositórios do usuário autenticado
 const QueryListRepositories = `
 query ListRepositories($first: Int!, $after: String) {
@@ -0,0 +1,422 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// === Rate Limit Budget ===
+//
+// RateBudget é o ponto único por onde passam as chamadas ao GitHub: limita concorrência,
+// atende interativo antes de poller/background e adia tráfego de baixa prioridade quando
+// a cota do recurso (core/graphql) está baixa. O estado é persistido para sobreviver a restarts
+// dentro da mesma janela de rate limit.
+
+// RequestPriority define a ordem de atendimento no budget.
+type RequestPriority int
+
+const (
+	PriorityInteractive RequestPriority = iota // ação do usuário / tela aberta
+	PriorityPoller                             // polling periódico
+	PriorityBackground                         // pré-carregamentos e enriquecimentos
+)
+
+const (
+	rateResourceCore    = "core"
+	rateResourceGraphQL = "graphql"
+
+	rateBudgetMaxConcurrent = 4
+	// Abaixo destas reservas o tráfego de menor prioridade é adiado até o reset.
+	rateBudgetPollerReserve     = 500
+	rateBudgetBackgroundReserve = 1000
+	rateBudgetSaveInterval      = 10 * time.Second
+	rateBudgetDefaultLimit      = 5000
+)
+
+func (p RequestPriority) String() string {
+	switch p {
+	case PriorityInteractive:
+		return "interactive"
+	case PriorityPoller:
+		return "poller"
+	default:
+		return "background"
+	}
+}
+
+// RateResourceInfo é o estado de um recurso de rate limit do GitHub.
+type RateResourceInfo struct {
+	Resource  string    `json:"resource"`
+	Remaining int       `json:"remaining"`
+	Limit     int       `json:"limit"`
+	Used      int       `json:"used"`
+	ResetAt   time.Time `json:"resetAt"`
+}
+
+// RateCategoryUsage é o consumo de uma categoria de chamadas na janela atual do recurso.
+type RateCategoryUsage struct {
+	Category string `json:"category"`
+	Resource string `json:"resource"`
+	Requests int    `json:"requests"`
+	Points   int    `json:"points"`
+	Batched  int    `json:"batched"`  // requests atendidos dentro de um lote GraphQL
+	Deferred int    `json:"deferred"` // requests adiados por cota baixa
+}
+
+// RateBudgetStatus é o resumo do budget exposto em GetRateLimitInfo.
+type RateBudgetStatus struct {
+	Mode       string              `json:"mode"` // normal | cautious | economy | critical
+	InFlight   int                 `json:"inFlight"`
+	Queued     int                 `json:"queued"`
+	Resources  []RateResourceInfo  `json:"resources"`
+	Categories []RateCategoryUsage `json:"categories"`
+}
+
+type rateBudgetSnapshot struct {
+	Resources  map[string]RateResourceInfo  `json:"resources"`
+	Categories map[string]RateCategoryUsage `json:"categories"`
+}
+
+// restCategory agrupa o consumo REST pelo recurso do endpoint (/repos/{o}/{r}/pulls → rest:pulls).
+func restCategory(endpointPath string) string {
+	segments := strings.Split(strings.Trim(endpointPath, "/"), "/")
+	if len(segments) >= 4 && segments[0] == "repos" {
+		return "rest:" + segments[3]
+	}
+	if segments[0] != "" {
+		return "rest:" + segments[0]
+	}
+	return "rest"
+}
+
+// RateBudget controla concorrência, prioridade e consumo das chamadas ao GitHub.
+type RateBudget struct {
+	mu         sync.Mutex
+	resources  map[string]RateResourceInfo
+	categories map[string]RateCategoryUsage // key: resource + "/" + category
+	active     int
+	waiting    [PriorityBackground + 1][]chan struct{}
+	storePath  string
+	lastSaved  time.Time
+	now        func() time.Time
+}
+
+// NewRateBudget cria um budget em memória; use SetStorePath para persistir.
+func NewRateBudget() *RateBudget {
+	return &RateBudget{
+		resources:  make(map[string]RateResourceInfo),
+		categories: make(map[string]RateCategoryUsage),
+		now:        time.Now,
+	}
+}
+
+// SetStorePath carrega o estado salvo (se ainda na mesma janela) e passa a persistir nele.
+func (b *RateBudget) SetStorePath(path string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.storePath = strings.TrimSpace(path)
+	if b.storePath == "" {
+		return
+	}
+	raw, err := os.ReadFile(b.storePath)
+	if err != nil {
+		return
+	}
+	var snapshot rateBudgetSnapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		log.Printf("[GitHub][Budget] Ignoring corrupt budget file: %v", err)
+		return
+	}
+	now := b.now()
+	for name, info := range snapshot.Resources {
+		if info.ResetAt.After(now) {
+			b.resources[name] = info
+		}
+	}
+	for key, usage := range snapshot.Categories {
+		if _, ok := b.resources[usage.Resource]; ok {
+			b.categories[key] = usage
+		}
+	}
+}
+
+// Acquire reserva uma vaga para a chamada. Retorna erro de rate limit quando a chamada é adiada
+// (poller/background com cota baixa) ou quando o recurso está esgotado até o reset.
+func (b *RateBudget) Acquire(ctx context.Context, priority RequestPriority, resource, category string) error {
+	b.mu.Lock()
+	if err := b.admissionErrorLocked(priority, resource, category); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	if b.active < rateBudgetMaxConcurrent && !b.hasWaitersAtOrAboveLocked(priority) {
+		b.active++
+		b.mu.Unlock()
+		return nil
+	}
+
+	ready := make(chan struct{})
+	b.waiting[priority] = append(b.waiting[priority], ready)
+	b.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		select {
+		case <-ready:
+			// A vaga chegou junto com o cancelamento: devolve para o próximo.
+			b.releaseSlotLocked()
+		default:
+			b.removeWaiterLocked(priority, ready)
+		}
+		return ctx.Err()
+	}
+}
+
+// Release devolve a vaga e contabiliza o consumo com base nos headers da resposta.
+// categories recebe uma entrada por request lógico (lotes GraphQL passam todas as categorias).
+func (b *RateBudget) Release(resource string, headers http.Header, categories ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.releaseSlotLocked()
+	cost := b.updateFromHeadersLocked(resource, headers)
+	if len(categories) == 0 {
+		return
+	}
+	if headerResource := strings.TrimSpace(headers.Get("X-RateLimit-Resource")); headerResource != "" {
+		resource = headerResource
+	}
+	share, remainder := cost/len(categories), cost%len(categories)
+	for idx, category := range categories {
+		usage := b.usageLocked(resource, category)
+		usage.Requests++
+		usage.Points += share
+		if idx == 0 {
+			usage.Points += remainder
+		}
+		if len(categories) > 1 {
+			usage.Batched++
+		}
+		b.categories[resource+"/"+category] = usage
+	}
+	b.saveLocked(false)
+}
+
+// Status retorna o resumo atual do budget.
+func (b *RateBudget) Status() RateBudgetStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := RateBudgetStatus{
+		Mode:       "normal",
+		InFlight:   b.active,
+		Resources:  []RateResourceInfo{},
+		Categories: []RateCategoryUsage{},
+	}
+	for _, list := range b.waiting {
+		status.Queued += len(list)
+	}
+	lowest := -1
+	for _, name := range []string{rateResourceCore, rateResourceGraphQL} {
+		info := b.resourceLocked(name)
+		status.Resources = append(status.Resources, info)
+		if lowest < 0 || info.Remaining < lowest {
+			lowest = info.Remaining
+		}
+	}
+	switch {
+	case lowest < 100:
+		status.Mode = "critical"
+	case lowest < 200:
+		status.Mode = "economy"
+	case lowest < rateBudgetPollerReserve:
+		status.Mode = "cautious"
+	}
+
+	for _, usage := range b.categories {
+		status.Categories = append(status.Categories, usage)
+	}
+	sort.Slice(status.Categories, func(i, j int) bool {
+		if status.Categories[i].Points != status.Categories[j].Points {
+			return status.Categories[i].Points > status.Categories[j].Points
+		}
+		return status.Categories[i].Resource+status.Categories[i].Category < status.Categories[j].Resource+status.Categories[j].Category
+	})
+	return status
+}
+
+// Flush grava o estado imediatamente (usado no shutdown).
+func (b *RateBudget) Flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.saveLocked(true)
+}
+
+func (b *RateBudget) admissionErrorLocked(priority RequestPriority, resource, category string) error {
+	info := b.resourceLocked(resource)
+	reserve := 0
+	switch priority {
+	case PriorityPoller:
+		reserve = rateBudgetPollerReserve
+	case PriorityBackground:
+		reserve = rateBudgetBackgroundReserve
+	}
+
+	if info.Remaining > 0 && info.Remaining >= reserve {
+		return nil
+	}
+	if priority != PriorityInteractive {
+		usage := b.usageLocked(resource, category)
+		usage.Deferred++
+		b.categories[resource+"/"+category] = usage
+	}
+	return &GitHubError{
+		StatusCode: http.StatusTooManyRequests,
+		Message:    fmt.Sprintf("GitHub %s budget low (%d remaining); %s request deferred until %s", resource, info.Remaining, priority, info.ResetAt.Format(time.Kitchen)),
+		Type:       "ratelimit",
+	}
+}
+
+// resourceLocked retorna o estado do recurso, assumindo cota cheia quando a janela já virou.
+func (b *RateBudget) resourceLocked(resource string) RateResourceInfo {
+	info, ok := b.resources[resource]
+	if !ok || (!info.ResetAt.IsZero() && !info.ResetAt.After(b.now())) {
+		limit := rateBudgetDefaultLimit
+		if ok && info.Limit > 0 {
+			limit = info.Limit
+		}
+		if ok {
+			b.resetCategoriesLocked(resource)
+			delete(b.resources, resource)
+		}
+		return RateResourceInfo{Resource: resource, Remaining: limit, Limit: limit}
+	}
+	return info
+}
+
+func (b *RateBudget) resetCategoriesLocked(resource string) {
+	for key, usage := range b.categories {
+		if usage.Resource == resource {
+			delete(b.categories, key)
+		}
+	}
+}
+
+func (b *RateBudget) usageLocked(resource, category string) RateCategoryUsage {
+	if usage, ok := b.categories[resource+"/"+category]; ok {
+		return usage
+	}
+	return RateCategoryUsage{Category: category, Resource: resource}
+}
+
+// updateFromHeadersLocked aplica X-RateLimit-* e retorna o custo estimado da chamada (mínimo 1).
+func (b *RateBudget) updateFromHeadersLocked(resource string, headers http.Header) int {
+	if headers == nil || headers.Get("X-RateLimit-Remaining") == "" {
+		return 1
+	}
+	if headerResource := strings.TrimSpace(headers.Get("X-RateLimit-Resource")); headerResource != "" {
+		resource = headerResource
+	}
+	previous := b.resourceLocked(resource)
+	next := previous
+
+	if n, err := strconv.Atoi(headers.Get("X-RateLimit-Remaining")); err == nil {
+		next.Remaining = n
+	}
+	if n, err := strconv.Atoi(headers.Get("X-RateLimit-Limit")); err == nil && n > 0 {
+		next.Limit = n
+	}
+	if n, err := strconv.Atoi(headers.Get("X-RateLimit-Used")); err == nil {
+		next.Used = n
+	}
+	if ts, err := strconv.ParseInt(headers.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		next.ResetAt = time.Unix(ts, 0)
+	}
+	if next.Used == 0 && next.Limit >= next.Remaining {
+		next.Used = next.Limit - next.Remaining
+	}
+	b.resources[resource] = next
+
+	// Respostas concorrentes chegam fora de ordem: custo negativo/zero conta como 1.
+	if cost := previous.Remaining - next.Remaining; cost > 0 && previous.ResetAt.Equal(next.ResetAt) {
+		return cost
+	}
+	return 1
+}
+
+func (b *RateBudget) hasWaitersAtOrAboveLocked(priority RequestPriority) bool {
+	for p := PriorityInteractive; p <= priority; p++ {
+		if len(b.waiting[p]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseSlotLocked passa a vaga ao waiter de maior prioridade ou a libera.
+func (b *RateBudget) releaseSlotLocked() {
+	for p := range b.waiting {
+		if len(b.waiting[p]) == 0 {
+			continue
+		}
+		next := b.waiting[p][0]
+		b.waiting[p] = b.waiting[p][1:]
+		close(next)
+		return
+	}
+	if b.active > 0 {
+		b.active--
+	}
+}
+
+func (b *RateBudget) removeWaiterLocked(priority RequestPriority, target chan struct{}) {
+	list := b.waiting[priority]
+	for idx, ch := range list {
+		if ch == target {
+			b.waiting[priority] = append(list[:idx], list[idx+1:]...)
+			return
+		}
+	}
+}
+
+func (b *RateBudget) saveLocked(force bool) {
+	if b.storePath == "" {
+		return
+	}
+	now := b.now()
+	if !force && now.Sub(b.lastSaved) < rateBudgetSaveInterval {
+		return
+	}
+	b.lastSaved = now
+
+	raw, err := json.Marshal(rateBudgetSnapshot{Resources: b.resources, Categories: b.categories})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(b.storePath), 0o755); err != nil {
+		log.Printf("[GitHub][Budget] Could not create budget dir: %v", err)
+		return
+	}
+	tmpPath := b.storePath + ".tmp"
+	if err := os.WriteFile(tmpPath, raw, 0o600); err != nil {
+		log.Printf("[GitHub][Budget] Could not save budget: %v", err)
+		return
+	}
+	if err := os.Rename(tmpPath, b.storePath); err != nil {
+		log.Printf("[GitHub][Budget] Could not save budget: %v", err)
+	}
+}
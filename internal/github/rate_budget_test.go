@@ -0,0 +1,216 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func rateHeaders(resource string, remaining int, resetAt time.Time) http.Header {
+	headers := make(http.Header)
+	headers.Set("X-RateLimit-Resource", resource)
+	headers.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	headers.Set("X-RateLimit-Limit", "5000")
+	headers.Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+	return headers
+}
+
+func TestRateBudgetServesInteractiveBeforeQueuedBackground(t *testing.T) {
+	budget := NewRateBudget()
+	for i := 0; i < rateBudgetMaxConcurrent; i++ {
+		if err := budget.Acquire(context.Background(), PriorityInteractive, rateResourceCore, "rest:pulls"); err != nil {
+			t.Fatalf("Acquire() error: %v", err)
+		}
+	}
+
+	order := make(chan RequestPriority, 2)
+	var wg sync.WaitGroup
+	start := func(priority RequestPriority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := budget.Acquire(context.Background(), priority, rateResourceGraphQL, priority.String()); err != nil {
+				t.Errorf("Acquire(%s) error: %v", priority, err)
+				return
+			}
+			order <- priority
+			budget.Release(rateResourceGraphQL, nil, priority.String())
+		}()
+	}
+	start(PriorityBackground)
+	waitForQueued(t, budget, 1)
+	start(PriorityInteractive)
+	waitForQueued(t, budget, 2)
+
+	budget.Release(rateResourceCore, nil, "rest:pulls")
+	if first := <-order; first != PriorityInteractive {
+		t.Fatalf("expected interactive request first, got %s", first)
+	}
+	for i := 1; i < rateBudgetMaxConcurrent; i++ {
+		budget.Release(rateResourceCore, nil, "rest:pulls")
+	}
+	wg.Wait()
+	if status := budget.Status(); status.InFlight != 0 || status.Queued != 0 {
+		t.Fatalf("expected all slots released, got %+v", status)
+	}
+}
+
+func TestRateBudgetDefersLowPriorityWhenQuotaIsLow(t *testing.T) {
+	budget := NewRateBudget()
+	resetAt := time.Now().Add(30 * time.Minute)
+
+	if err := budget.Acquire(context.Background(), PriorityPoller, rateResourceGraphQL, "poller"); err != nil {
+		t.Fatalf("Acquire() error: %v", err)
+	}
+	budget.Release(rateResourceGraphQL, rateHeaders("graphql", 700, resetAt), "poller")
+	if err := budget.Acquire(context.Background(), PriorityPoller, rateResourceGraphQL, "poller"); err != nil {
+		t.Fatalf("Acquire() error: %v", err)
+	}
+	budget.Release(rateResourceGraphQL, rateHeaders("graphql", 300, resetAt), "poller")
+
+	err := budget.Acquire(context.Background(), PriorityPoller, rateResourceGraphQL, "poller")
+	if githubErr, ok := err.(*GitHubError); !ok || githubErr.Type != "ratelimit" {
+		t.Fatalf("expected poller request deferred, got %v", err)
+	}
+	if err := budget.Acquire(context.Background(), PriorityInteractive, rateResourceGraphQL, "ListPullRequests"); err != nil {
+		t.Fatalf("interactive request should pass with low quota: %v", err)
+	}
+	budget.Release(rateResourceGraphQL, nil, "ListPullRequests")
+	// Outro recurso não é afetado pela cota do GraphQL.
+	if err := budget.Acquire(context.Background(), PriorityBackground, rateResourceCore, "rest:branches"); err != nil {
+		t.Fatalf("core request should not be deferred: %v", err)
+	}
+	budget.Release(rateResourceCore, nil, "rest:branches")
+
+	status := budget.Status()
+	if status.Mode != "cautious" {
+		t.Fatalf("unexpected mode: %s", status.Mode)
+	}
+	var poller RateCategoryUsage
+	for _, usage := range status.Categories {
+		if usage.Category == "poller" {
+			poller = usage
+		}
+	}
+	if poller.Requests != 2 || poller.Points != 401 || poller.Deferred != 1 {
+		t.Fatalf("unexpected poller usage: %+v", poller)
+	}
+}
+
+func TestRateBudgetPersistsWithinResetWindow(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "budget.json")
+	budget := NewRateBudget()
+	budget.SetStorePath(storePath)
+
+	if err := budget.Acquire(context.Background(), PriorityInteractive, rateResourceCore, "rest:pulls"); err != nil {
+		t.Fatalf("Acquire() error: %v", err)
+	}
+	budget.Release(rateResourceCore, rateHeaders("core", 42, time.Now().Add(time.Hour)), "rest:pulls")
+	budget.Flush()
+
+	restored := NewRateBudget()
+	restored.SetStorePath(storePath)
+	status := restored.Status()
+	if status.Resources[0].Resource != rateResourceCore || status.Resources[0].Remaining != 42 || status.Mode != "critical" {
+		t.Fatalf("expected persisted core quota, got %+v", status)
+	}
+	if len(status.Categories) != 1 || status.Categories[0].Category != "rest:pulls" {
+		t.Fatalf("expected persisted category usage, got %+v", status.Categories)
+	}
+
+	expired := NewRateBudget()
+	expired.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	expired.SetStorePath(storePath)
+	if status := expired.Status(); status.Resources[0].Remaining != rateBudgetDefaultLimit || len(status.Categories) != 0 {
+		t.Fatalf("expected state from past window to be discarded, got %+v", status)
+	}
+}
+
+func TestBackgroundGraphQLQueriesAreBatched(t *testing.T) {
+	var requests int32
+	service := NewService(func() (string, error) { return "gh-token", nil })
+	service.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&requests, 1)
+			var payload struct {
+				Query     string                 `json:"query"`
+				Variables map[string]interface{} `json:"variables"`
+			}
+			_ = json.NewDecoder(req.Body).Decode(&payload)
+			// A ordem no lote depende do agendamento; responde conforme o prefixo recebido.
+			repoPrefix, viewerPrefix := "b0_", "b1_"
+			if strings.Contains(payload.Query, "b0_viewer: viewer") {
+				repoPrefix, viewerPrefix = "b1_", "b0_"
+			}
+			if !strings.Contains(payload.Query, repoPrefix+"repository: repository(owner: $"+repoPrefix+"owner") ||
+				len(payload.Variables) != 2 || payload.Variables[repoPrefix+"owner"] != "o" {
+				t.Errorf("unexpected batched request: %+v", payload)
+			}
+			body := fmt.Sprintf(`{"data":{"%srepository":{"id":"R_1"},"%sviewer":{"login":"dev"}}}`, repoPrefix, viewerPrefix)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     rateHeaders("graphql", 4990, time.Now().Add(time.Hour)),
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}
+
+	type result struct {
+		data string
+		err  error
+	}
+	results := make([]result, 2)
+	queries := []string{
+		`query RepoID($owner: String!, $repo: String!) { repository(owner: $owner, name: $repo) { id } }`,
+		`query Viewer { viewer { login } }`,
+	}
+	var wg sync.WaitGroup
+	for idx, query := range queries {
+		wg.Add(1)
+		go func(idx int, query string) {
+			defer wg.Done()
+			data, err := service.executeQueryWithPriority(context.Background(), PriorityBackground, "", query, map[string]interface{}{"owner": "o", "repo": "r"})
+			results[idx] = result{data: string(data), err: err}
+		}(idx, query)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Fatalf("expected a single batched request, got %d", got)
+	}
+	if results[0].err != nil || results[0].data != `{"repository":{"id":"R_1"}}` {
+		t.Fatalf("unexpected first result: %+v", results[0])
+	}
+	if results[1].err != nil || results[1].data != `{"viewer":{"login":"dev"}}` {
+		t.Fatalf("unexpected second result: %+v", results[1])
+	}
+
+	status := service.RateBudgetStatus()
+	batched := 0
+	for _, usage := range status.Categories {
+		batched += usage.Batched
+	}
+	if batched != 2 {
+		t.Fatalf("expected both queries counted as batched, got %+v", status.Categories)
+	}
+}
+
+func waitForQueued(t *testing.T, budget *RateBudget, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for budget.Status().Queued < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d queued requests", want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,124 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	releaseActionDraftCreate = "release_draft_create"
+	releaseActionDraftUpdate = "release_draft_update"
+
+	// Drafts não aparecem em /releases/tags/{tag}; procura entre as mais recentes.
+	releaseDraftLookupPerPage = "50"
+)
+
+// Release representa uma release do GitHub.
+type Release struct {
+	ID              int64  `json:"id"`
+	TagName         string `json:"tagName"`
+	TargetCommitish string `json:"targetCommitish,omitempty"`
+	Name            string `json:"name"`
+	Body            string `json:"body"`
+	Draft           bool   `json:"draft"`
+	Prerelease      bool   `json:"prerelease"`
+	HTMLURL         string `json:"htmlUrl"`
+}
+
+// ReleaseDraftInput define a release em rascunho a criar ou atualizar.
+type ReleaseDraftInput struct {
+	Owner           string `json:"owner"`
+	Repo            string `json:"repo"`
+	TagName         string `json:"tagName"`
+	TargetCommitish string `json:"targetCommitish,omitempty"` // usado só na criação (tag ainda inexistente)
+	Name            string `json:"name,omitempty"`
+	Body            string `json:"body"`
+}
+
+type restRelease struct {
+	ID              int64  `json:"id"`
+	TagName         string `json:"tag_name"`
+	TargetCommitish string `json:"target_commitish"`
+	Name            string `json:"name"`
+	Body            string `json:"body"`
+	Draft           bool   `json:"draft"`
+	Prerelease      bool   `json:"prerelease"`
+	HTMLURL         string `json:"html_url"`
+}
+
+// UpsertReleaseDraft atualiza o corpo do rascunho de release da tag ou cria um novo rascunho.
+// Releases já publicadas não são alteradas.
+func (s *Service) UpsertReleaseDraft(ctx context.Context, input ReleaseDraftInput) (*Release, error) {
+	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(input.Owner, input.Repo)
+	if normalizeErr != nil {
+		return nil, normalizeErr
+	}
+	tagName := strings.TrimSpace(input.TagName)
+	if tagName == "" {
+		return nil, &GitHubError{StatusCode: http.StatusUnprocessableEntity, Message: "release tag name is required", Type: "validation"}
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		name = tagName
+	}
+
+	releasesPath := fmt.Sprintf("/repos/%s/%s/releases", url.PathEscape(normalizedOwner), url.PathEscape(normalizedRepo))
+	query := url.Values{}
+	query.Set("per_page", releaseDraftLookupPerPage)
+	var existing []restRelease
+	if err := s.executePRRESTJSON(ctx, releaseActionDraftUpdate, http.MethodGet, releasesPath, query, nil, &existing); err != nil {
+		return nil, err
+	}
+
+	var response restRelease
+	for _, release := range existing {
+		if release.TagName != tagName {
+			continue
+		}
+		if !release.Draft {
+			return nil, &GitHubError{
+				StatusCode: http.StatusConflict,
+				Message:    fmt.Sprintf("release %s is already published", tagName),
+				Type:       "conflict",
+			}
+		}
+		payload := map[string]interface{}{"body": input.Body, "name": name}
+		if err := s.executePRRESTJSON(ctx, releaseActionDraftUpdate, http.MethodPatch, fmt.Sprintf("%s/%d", releasesPath, release.ID), nil, payload, &response); err != nil {
+			return nil, err
+		}
+		log.Printf("[GitHub] Updated release draft %s on %s/%s", tagName, normalizedOwner, normalizedRepo)
+		return parseRESTRelease(response), nil
+	}
+
+	payload := map[string]interface{}{
+		"tag_name": tagName,
+		"name":     name,
+		"body":     input.Body,
+		"draft":    true,
+	}
+	if target := strings.TrimSpace(input.TargetCommitish); target != "" {
+		payload["target_commitish"] = target
+	}
+	if err := s.executePRRESTJSON(ctx, releaseActionDraftCreate, http.MethodPost, releasesPath, nil, payload, &response); err != nil {
+		return nil, err
+	}
+	log.Printf("[GitHub] Created release draft %s on %s/%s", tagName, normalizedOwner, normalizedRepo)
+	return parseRESTRelease(response), nil
+}
+
+func parseRESTRelease(raw restRelease) *Release {
+	return &Release{
+		ID:              raw.ID,
+		TagName:         raw.TagName,
+		TargetCommitish: raw.TargetCommitish,
+		Name:            raw.Name,
+		Body:            raw.Body,
+		Draft:           raw.Draft,
+		Prerelease:      raw.Prerelease,
+		HTMLURL:         raw.HTMLURL,
+	}
+}
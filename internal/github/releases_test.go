@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newReleaseTestService(t *testing.T, existing string, requests *[]string, payloads *[]map[string]interface{}) *Service {
+	t.Helper()
+	service := NewService(func() (string, error) {
+		return "gh-token", nil
+	})
+	service.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			*requests = append(*requests, req.Method+" "+req.URL.Path)
+			body := existing
+			if req.Method != http.MethodGet {
+				var payload map[string]interface{}
+				raw, _ := io.ReadAll(req.Body)
+				_ = json.Unmarshal(raw, &payload)
+				*payloads = append(*payloads, payload)
+				body = `{"id":7,"tag_name":"v1.2.0","name":"v1.2.0","body":"notes","draft":true,"html_url":"https://github.com/orch-labs/orch/releases/tag/untagged-1"}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}
+	return service
+}
+
+func TestUpsertReleaseDraftCreatesOrUpdatesDraft(t *testing.T) {
+	var requests []string
+	var payloads []map[string]interface{}
+	service := newReleaseTestService(t, `[{"id":3,"tag_name":"v1.1.0","draft":false}]`, &requests, &payloads)
+	release, err := service.UpsertReleaseDraft(context.Background(), ReleaseDraftInput{Owner: "orch-labs", Repo: "orch", TagName: "v1.2.0", TargetCommitish: "abc123", Body: "notes"})
+	if err != nil || release == nil || !release.Draft || release.ID != 7 {
+		t.Fatalf("unexpected create result: %+v (%v)", release, err)
+	}
+	if got := strings.Join(requests, ","); got != "GET /repos/orch-labs/orch/releases,POST /repos/orch-labs/orch/releases" {
+		t.Fatalf("unexpected requests: %s", got)
+	}
+	if payloads[0]["draft"] != true || payloads[0]["target_commitish"] != "abc123" || payloads[0]["tag_name"] != "v1.2.0" {
+		t.Fatalf("unexpected create payload: %v", payloads[0])
+	}
+
+	requests, payloads = nil, nil
+	service = newReleaseTestService(t, `[{"id":7,"tag_name":"v1.2.0","draft":true}]`, &requests, &payloads)
+	if _, err := service.UpsertReleaseDraft(context.Background(), ReleaseDraftInput{Owner: "orch-labs", Repo: "orch", TagName: "v1.2.0", Body: "new notes"}); err != nil {
+		t.Fatalf("update returned error: %v", err)
+	}
+	if got := strings.Join(requests, ","); got != "GET /repos/orch-labs/orch/releases,PATCH /repos/orch-labs/orch/releases/7" {
+		t.Fatalf("unexpected requests: %s", got)
+	}
+	if payloads[0]["body"] != "new notes" {
+		t.Fatalf("unexpected update payload: %v", payloads[0])
+	}
+
+	requests, payloads = nil, nil
+	service = newReleaseTestService(t, `[{"id":7,"tag_name":"v1.2.0","draft":false}]`, &requests, &payloads)
+	if _, err := service.UpsertReleaseDraft(context.Background(), ReleaseDraftInput{Owner: "orch-labs", Repo: "orch", TagName: "v1.2.0"}); err == nil {
+		t.Fatalf("expected published release to be left untouched")
+	}
+	if len(payloads) != 0 {
+		t.Fatalf("published release should not be modified, got %v", payloads)
+	}
+}
@@ -2,6 +2,7 @@ package github
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -48,7 +49,7 @@ type restPullRequest struct {
 }
 
 // ListPullRequests lista PRs de um repositório via GitHub REST API v3.
-func (s *Service) ListPullRequests(owner, repo string, filters PRFilters) ([]PullRequest, error) {
+func (s *Service) ListPullRequests(ctx context.Context, owner, repo string, filters PRFilters) ([]PullRequest, error) {
 	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(owner, repo)
 	if normalizeErr != nil {
 		return nil, normalizeErr
@@ -109,6 +110,7 @@ func (s *Service) ListPullRequests(owner, repo string, filters PRFilters) ([]Pul
 	query.Set("per_page", strconv.Itoa(perPage))
 
 	respBody, headers, statusCode, err := s.executeRESTRequestConditional(
+		ctx,
 		http.MethodGet,
 		endpointPath,
 		query,
@@ -164,7 +166,7 @@ func (s *Service) ListPullRequests(owner, repo string, filters PRFilters) ([]Pul
 }
 
 // GetPullRequest busca detalhes de um PR via GitHub REST API v3.
-func (s *Service) GetPullRequest(owner, repo string, number int) (*PullRequest, error) {
+func (s *Service) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, error) {
 	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(owner, repo)
 	if normalizeErr != nil {
 		return nil, normalizeErr
@@ -194,6 +196,7 @@ func (s *Service) GetPullRequest(owner, repo string, number int) (*PullRequest,
 	}
 
 	respBody, headers, statusCode, err := s.executeRESTRequestConditional(
+		ctx,
 		http.MethodGet,
 		endpointPath,
 		nil,
@@ -235,7 +238,7 @@ func (s *Service) GetPullRequest(owner, repo string, number int) (*PullRequest,
 	return &pr, nil
 }
 
-func (s *Service) executePRRESTJSON(action, method, path string, query url.Values, payload interface{}, result interface{}) error {
+func (s *Service) executePRRESTJSON(ctx context.Context, action, method, path string, query url.Values, payload interface{}, result interface{}) error {
 	requestStartedAt := time.Now()
 
 	var requestBody io.Reader
@@ -250,6 +253,7 @@ func (s *Service) executePRRESTJSON(action, method, path string, query url.Value
 	}
 
 	responseBody, _, statusCode, err := s.executeRESTRequestConditional(
+		ctx,
 		method,
 		path,
 		query,
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -55,7 +56,7 @@ func TestListPullRequestsRESTRetriesReadOnTransientServerError(t *testing.T) {
 		}),
 	}
 
-	prs, err := service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
+	prs, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("ListPullRequests() error: %v", err)
 	}
@@ -111,7 +112,7 @@ func TestListPullRequestsRESTRetriesOnSecondaryRateLimit(t *testing.T) {
 		}),
 	}
 
-	prs, err := service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
+	prs, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("ListPullRequests() error: %v", err)
 	}
@@ -142,7 +143,7 @@ func TestListPullRequestsRESTDoesNotRetryOnPermissionForbidden(t *testing.T) {
 		}),
 	}
 
-	_, err := service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
+	_, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
 	if err == nil {
 		t.Fatalf("expected permission error")
 	}
@@ -178,7 +179,7 @@ func TestCreatePullRequestRESTDoesNotRetryOnServerError(t *testing.T) {
 		}),
 	}
 
-	_, err := service.CreatePullRequest(CreatePRInput{
+	_, err := service.CreatePullRequest(context.Background(), CreatePRInput{
 		Owner:      "orch-labs",
 		Repo:       "orch",
 		Title:      "Create without blind retry",
@@ -263,7 +264,7 @@ func TestListPullRequestsRESTUsesOfficialHeadersAndPagination(t *testing.T) {
 		}),
 	}
 
-	prs, err := service.ListPullRequests("orch-labs", "orch", PRFilters{
+	prs, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    2,
 		PerPage: 50,
@@ -355,11 +356,11 @@ func TestListPullRequestsRESTCachesByEndpointAndParams(t *testing.T) {
 		}),
 	}
 
-	_, err := service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
+	_, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("first open list error: %v", err)
 	}
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("second open list error: %v", err)
 	}
@@ -367,7 +368,7 @@ func TestListPullRequestsRESTCachesByEndpointAndParams(t *testing.T) {
 		t.Fatalf("expected cache hit for repeated open page=1 list, requests=%d", requestCount)
 	}
 
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{State: "closed", Page: 1, PerPage: 25})
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "closed", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("closed list error: %v", err)
 	}
@@ -375,7 +376,7 @@ func TestListPullRequestsRESTCachesByEndpointAndParams(t *testing.T) {
 		t.Fatalf("expected separate cache key for closed list, requests=%d", requestCount)
 	}
 
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 2, PerPage: 25})
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 2, PerPage: 25})
 	if err != nil {
 		t.Fatalf("open page=2 list error: %v", err)
 	}
@@ -383,11 +384,11 @@ func TestListPullRequestsRESTCachesByEndpointAndParams(t *testing.T) {
 		t.Fatalf("expected separate cache key for open page=2, requests=%d", requestCount)
 	}
 
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{State: "MERGED", Page: 1, PerPage: 25})
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "MERGED", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("merged list error: %v", err)
 	}
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{State: "MERGED", Page: 1, PerPage: 25})
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "MERGED", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("merged list cache hit error: %v", err)
 	}
@@ -440,7 +441,7 @@ func TestListPullRequestsRESTMergedFilter(t *testing.T) {
 		}),
 	}
 
-	prs, err := service.ListPullRequests("orch-labs", "orch", PRFilters{
+	prs, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "MERGED",
 		Page:    1,
 		PerPage: 20,
@@ -507,7 +508,7 @@ func TestGetPullRequestRESTUsesOfficialHeaders(t *testing.T) {
 		}),
 	}
 
-	pr, err := service.GetPullRequest("orch-labs", "orch", 7)
+	pr, err := service.GetPullRequest(context.Background(), "orch-labs", "orch", 7)
 	if err != nil {
 		t.Fatalf("GetPullRequest() error: %v", err)
 	}
@@ -603,7 +604,7 @@ func TestCreatePullRequestRESTUsesOfficialHeadersAndPayload(t *testing.T) {
 		}),
 	}
 
-	pr, err := service.CreatePullRequest(CreatePRInput{
+	pr, err := service.CreatePullRequest(context.Background(), CreatePRInput{
 		Owner:               "orch-labs",
 		Repo:                "orch",
 		Title:               "Create via REST",
@@ -704,7 +705,7 @@ func TestCreatePullRequestRESTInvalidatesRepositoryCache(t *testing.T) {
 		}),
 	}
 
-	_, err := service.ListPullRequests("orch-labs", "orch", PRFilters{
+	_, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -712,7 +713,7 @@ func TestCreatePullRequestRESTInvalidatesRepositoryCache(t *testing.T) {
 	if err != nil {
 		t.Fatalf("first ListPullRequests() error: %v", err)
 	}
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -724,7 +725,7 @@ func TestCreatePullRequestRESTInvalidatesRepositoryCache(t *testing.T) {
 		t.Fatalf("expected list cache hit before mutation, getCalls=%d", getCalls)
 	}
 
-	_, err = service.CreatePullRequest(CreatePRInput{
+	_, err = service.CreatePullRequest(context.Background(), CreatePRInput{
 		Owner:      "orch-labs",
 		Repo:       "orch",
 		Title:      "Created PR",
@@ -738,7 +739,7 @@ func TestCreatePullRequestRESTInvalidatesRepositoryCache(t *testing.T) {
 		t.Fatalf("expected one create request, postCalls=%d", postCalls)
 	}
 
-	listAfterCreate, err := service.ListPullRequests("orch-labs", "orch", PRFilters{
+	listAfterCreate, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -839,11 +840,11 @@ func TestCreatePullRequestRESTInvalidatesOnlyPRListCache(t *testing.T) {
 		}),
 	}
 
-	_, err := service.GetPullRequest("orch-labs", "orch", 900)
+	_, err := service.GetPullRequest(context.Background(), "orch-labs", "orch", 900)
 	if err != nil {
 		t.Fatalf("first GetPullRequest() error: %v", err)
 	}
-	_, err = service.GetPullRequest("orch-labs", "orch", 900)
+	_, err = service.GetPullRequest(context.Background(), "orch-labs", "orch", 900)
 	if err != nil {
 		t.Fatalf("second GetPullRequest() error: %v", err)
 	}
@@ -851,11 +852,11 @@ func TestCreatePullRequestRESTInvalidatesOnlyPRListCache(t *testing.T) {
 		t.Fatalf("expected PR detail cache hit before mutation, detailCalls=%d", detailCalls)
 	}
 
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("first ListPullRequests() error: %v", err)
 	}
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("second ListPullRequests() error: %v", err)
 	}
@@ -863,7 +864,7 @@ func TestCreatePullRequestRESTInvalidatesOnlyPRListCache(t *testing.T) {
 		t.Fatalf("expected PR list cache hit before mutation, listCalls=%d", listCalls)
 	}
 
-	_, err = service.CreatePullRequest(CreatePRInput{
+	_, err = service.CreatePullRequest(context.Background(), CreatePRInput{
 		Owner:      "orch-labs",
 		Repo:       "orch",
 		Title:      "Created PR",
@@ -877,7 +878,7 @@ func TestCreatePullRequestRESTInvalidatesOnlyPRListCache(t *testing.T) {
 		t.Fatalf("expected one create call, postCalls=%d", postCalls)
 	}
 
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("third ListPullRequests() error: %v", err)
 	}
@@ -885,7 +886,7 @@ func TestCreatePullRequestRESTInvalidatesOnlyPRListCache(t *testing.T) {
 		t.Fatalf("expected list cache invalidated after create, listCalls=%d", listCalls)
 	}
 
-	_, err = service.GetPullRequest("orch-labs", "orch", 900)
+	_, err = service.GetPullRequest(context.Background(), "orch-labs", "orch", 900)
 	if err != nil {
 		t.Fatalf("third GetPullRequest() error: %v", err)
 	}
@@ -971,7 +972,7 @@ func TestUpdatePullRequestRESTUsesOfficialHeadersAndPayload(t *testing.T) {
 		}),
 	}
 
-	pr, err := service.UpdatePullRequest(UpdatePRInput{
+	pr, err := service.UpdatePullRequest(context.Background(), UpdatePRInput{
 		Owner:               "orch-labs",
 		Repo:                "orch",
 		Number:              55,
@@ -1072,7 +1073,7 @@ func TestUpdatePullRequestRESTInvalidatesRepositoryCache(t *testing.T) {
 		}),
 	}
 
-	_, err := service.ListPullRequests("orch-labs", "orch", PRFilters{
+	_, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -1080,7 +1081,7 @@ func TestUpdatePullRequestRESTInvalidatesRepositoryCache(t *testing.T) {
 	if err != nil {
 		t.Fatalf("first ListPullRequests() error: %v", err)
 	}
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -1093,7 +1094,7 @@ func TestUpdatePullRequestRESTInvalidatesRepositoryCache(t *testing.T) {
 	}
 
 	title := "Updated PR"
-	_, err = service.UpdatePullRequest(UpdatePRInput{
+	_, err = service.UpdatePullRequest(context.Background(), UpdatePRInput{
 		Owner:  "orch-labs",
 		Repo:   "orch",
 		Number: 77,
@@ -1106,7 +1107,7 @@ func TestUpdatePullRequestRESTInvalidatesRepositoryCache(t *testing.T) {
 		t.Fatalf("expected one update request, patchCalls=%d", patchCalls)
 	}
 
-	listAfterUpdate, err := service.ListPullRequests("orch-labs", "orch", PRFilters{
+	listAfterUpdate, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -1193,19 +1194,19 @@ func TestUpdatePullRequestRESTRefreshesMutatedDetailAndKeepsUnrelatedDetailCache
 		}),
 	}
 
-	_, err := service.GetPullRequest("orch-labs", "orch", 77)
+	_, err := service.GetPullRequest(context.Background(), "orch-labs", "orch", 77)
 	if err != nil {
 		t.Fatalf("warm cache PR 77 error: %v", err)
 	}
-	_, err = service.GetPullRequest("orch-labs", "orch", 99)
+	_, err = service.GetPullRequest(context.Background(), "orch-labs", "orch", 99)
 	if err != nil {
 		t.Fatalf("warm cache PR 99 error: %v", err)
 	}
-	_, err = service.GetPullRequest("orch-labs", "orch", 77)
+	_, err = service.GetPullRequest(context.Background(), "orch-labs", "orch", 77)
 	if err != nil {
 		t.Fatalf("cache hit PR 77 error: %v", err)
 	}
-	_, err = service.GetPullRequest("orch-labs", "orch", 99)
+	_, err = service.GetPullRequest(context.Background(), "orch-labs", "orch", 99)
 	if err != nil {
 		t.Fatalf("cache hit PR 99 error: %v", err)
 	}
@@ -1217,7 +1218,7 @@ func TestUpdatePullRequestRESTRefreshesMutatedDetailAndKeepsUnrelatedDetailCache
 	}
 
 	title := "Updated PR 77"
-	_, err = service.UpdatePullRequest(UpdatePRInput{
+	_, err = service.UpdatePullRequest(context.Background(), UpdatePRInput{
 		Owner:  "orch-labs",
 		Repo:   "orch",
 		Number: 77,
@@ -1230,7 +1231,7 @@ func TestUpdatePullRequestRESTRefreshesMutatedDetailAndKeepsUnrelatedDetailCache
 		t.Fatalf("expected one update call, patchCalls=%d", patchCalls)
 	}
 
-	updatedPR, err := service.GetPullRequest("orch-labs", "orch", 77)
+	updatedPR, err := service.GetPullRequest(context.Background(), "orch-labs", "orch", 77)
 	if err != nil {
 		t.Fatalf("refresh updated PR 77 error: %v", err)
 	}
@@ -1241,7 +1242,7 @@ func TestUpdatePullRequestRESTRefreshesMutatedDetailAndKeepsUnrelatedDetailCache
 		t.Fatalf("expected updated PR detail served from refreshed cache, calls=%d", detailCallsByPath["/repos/orch-labs/orch/pulls/77"])
 	}
 
-	_, err = service.GetPullRequest("orch-labs", "orch", 99)
+	_, err = service.GetPullRequest(context.Background(), "orch-labs", "orch", 99)
 	if err != nil {
 		t.Fatalf("read unrelated PR 99 error: %v", err)
 	}
@@ -1255,7 +1256,7 @@ func TestUpdatePullRequestRESTRejectsEmptyPayload(t *testing.T) {
 		return "gh-token", nil
 	})
 
-	_, err := service.UpdatePullRequest(UpdatePRInput{
+	_, err := service.UpdatePullRequest(context.Background(), UpdatePRInput{
 		Owner:  "orch-labs",
 		Repo:   "orch",
 		Number: 22,
@@ -1332,7 +1333,7 @@ func TestMergePullRequestRESTUsesOfficialHeadersAndPayload(t *testing.T) {
 		}),
 	}
 
-	result, err := service.MergePullRequestREST(MergePRInput{
+	result, err := service.MergePullRequestREST(context.Background(), MergePRInput{
 		Owner:       "orch-labs",
 		Repo:        "orch",
 		Number:      55,
@@ -1418,7 +1419,7 @@ func TestMergePullRequestRESTInvalidatesRepositoryCache(t *testing.T) {
 		}),
 	}
 
-	_, err := service.ListPullRequests("orch-labs", "orch", PRFilters{
+	_, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -1426,7 +1427,7 @@ func TestMergePullRequestRESTInvalidatesRepositoryCache(t *testing.T) {
 	if err != nil {
 		t.Fatalf("first ListPullRequests() error: %v", err)
 	}
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -1438,7 +1439,7 @@ func TestMergePullRequestRESTInvalidatesRepositoryCache(t *testing.T) {
 		t.Fatalf("expected list cache hit before merge, getCalls=%d", getCalls)
 	}
 
-	_, err = service.MergePullRequestREST(MergePRInput{
+	_, err = service.MergePullRequestREST(context.Background(), MergePRInput{
 		Owner:       "orch-labs",
 		Repo:        "orch",
 		Number:      77,
@@ -1451,7 +1452,7 @@ func TestMergePullRequestRESTInvalidatesRepositoryCache(t *testing.T) {
 		t.Fatalf("expected one merge request, putCalls=%d", putCalls)
 	}
 
-	listAfterMerge, err := service.ListPullRequests("orch-labs", "orch", PRFilters{
+	listAfterMerge, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -1474,7 +1475,7 @@ func TestMergePullRequestRESTRejectsInvalidSHA(t *testing.T) {
 		return "gh-token", nil
 	})
 
-	_, err := service.MergePullRequestREST(MergePRInput{
+	_, err := service.MergePullRequestREST(context.Background(), MergePRInput{
 		Owner:       "orch-labs",
 		Repo:        "orch",
 		Number:      42,
@@ -1546,7 +1547,7 @@ func TestUpdatePullRequestBranchRESTUsesOfficialHeadersAndPayload(t *testing.T)
 		}),
 	}
 
-	result, err := service.UpdatePullRequestBranch(UpdatePRBranchInput{
+	result, err := service.UpdatePullRequestBranch(context.Background(), UpdatePRBranchInput{
 		Owner:           "orch-labs",
 		Repo:            "orch",
 		Number:          55,
@@ -1627,7 +1628,7 @@ func TestUpdatePullRequestBranchRESTInvalidatesRepositoryCache(t *testing.T) {
 		}),
 	}
 
-	_, err := service.ListPullRequests("orch-labs", "orch", PRFilters{
+	_, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -1635,7 +1636,7 @@ func TestUpdatePullRequestBranchRESTInvalidatesRepositoryCache(t *testing.T) {
 	if err != nil {
 		t.Fatalf("first ListPullRequests() error: %v", err)
 	}
-	_, err = service.ListPullRequests("orch-labs", "orch", PRFilters{
+	_, err = service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -1647,7 +1648,7 @@ func TestUpdatePullRequestBranchRESTInvalidatesRepositoryCache(t *testing.T) {
 		t.Fatalf("expected list cache hit before update-branch, getCalls=%d", getCalls)
 	}
 
-	_, err = service.UpdatePullRequestBranch(UpdatePRBranchInput{
+	_, err = service.UpdatePullRequestBranch(context.Background(), UpdatePRBranchInput{
 		Owner:  "orch-labs",
 		Repo:   "orch",
 		Number: 77,
@@ -1659,7 +1660,7 @@ func TestUpdatePullRequestBranchRESTInvalidatesRepositoryCache(t *testing.T) {
 		t.Fatalf("expected one update-branch request, putCalls=%d", putCalls)
 	}
 
-	listAfterUpdateBranch, err := service.ListPullRequests("orch-labs", "orch", PRFilters{
+	listAfterUpdateBranch, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{
 		State:   "open",
 		Page:    1,
 		PerPage: 25,
@@ -1682,7 +1683,7 @@ func TestUpdatePullRequestBranchRESTRejectsInvalidExpectedHeadSHA(t *testing.T)
 		return "gh-token", nil
 	})
 
-	_, err := service.UpdatePullRequestBranch(UpdatePRBranchInput{
+	_, err := service.UpdatePullRequestBranch(context.Background(), UpdatePRBranchInput{
 		Owner:           "orch-labs",
 		Repo:            "orch",
 		Number:          42,
@@ -1747,7 +1748,7 @@ func TestListPullRequestsRESTUsesETagConditionalRequestOnExpiredCache(t *testing
 		}),
 	}
 
-	first, err := service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
+	first, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("first ListPullRequests() error: %v", err)
 	}
@@ -1760,7 +1761,7 @@ func TestListPullRequestsRESTUsesETagConditionalRequestOnExpiredCache(t *testing
 	service.cache.updatedAt[cacheKey] = time.Now().Add(-time.Minute)
 	service.cache.mu.Unlock()
 
-	second, err := service.ListPullRequests("orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
+	second, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "open", Page: 1, PerPage: 25})
 	if err != nil {
 		t.Fatalf("second ListPullRequests() error: %v", err)
 	}
@@ -1816,7 +1817,7 @@ func TestGetPullRequestRESTUsesETagConditionalRequestOnExpiredCache(t *testing.T
 		}),
 	}
 
-	first, err := service.GetPullRequest("orch-labs", "orch", 33)
+	first, err := service.GetPullRequest(context.Background(), "orch-labs", "orch", 33)
 	if err != nil {
 		t.Fatalf("first GetPullRequest() error: %v", err)
 	}
@@ -1829,7 +1830,7 @@ func TestGetPullRequestRESTUsesETagConditionalRequestOnExpiredCache(t *testing.T
 	service.cache.updatedAt[cacheKey] = time.Now().Add(-time.Minute)
 	service.cache.mu.Unlock()
 
-	second, err := service.GetPullRequest("orch-labs", "orch", 33)
+	second, err := service.GetPullRequest(context.Background(), "orch-labs", "orch", 33)
 	if err != nil {
 		t.Fatalf("second GetPullRequest() error: %v", err)
 	}
@@ -1846,7 +1847,7 @@ func TestListPullRequestsRESTRejectsInvalidState(t *testing.T) {
 		return "gh-token", nil
 	})
 
-	_, err := service.ListPullRequests("orch-labs", "orch", PRFilters{State: "invalid-state"})
+	_, err := service.ListPullRequests(context.Background(), "orch-labs", "orch", PRFilters{State: "invalid-state"})
 	if err == nil {
 		t.Fatalf("expected invalid state error")
 	}
@@ -1916,7 +1917,7 @@ func TestCreateInlineCommentUsesRESTClientHeaders(t *testing.T) {
 		}),
 	}
 
-	comment, err := service.CreateInlineComment(InlineCommentInput{
+	comment, err := service.CreateInlineComment(context.Background(), InlineCommentInput{
 		Owner:    "orch-labs",
 		Repo:     "orch",
 		PRNumber: 9,
@@ -2,6 +2,7 @@ package github
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,7 +15,10 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"orch/internal/httpclient"
 )
 
 const (
@@ -23,7 +27,7 @@ const (
 	githubRESTAPIVersion  = "2022-11-28"
 	githubRESTAcceptJSON  = "application/vnd.github+json"
 	githubRESTAcceptDiff  = "application/vnd.github.diff"
-	defaultCacheTTL       = 30 * time.Second
+	DefaultCacheTTL       = 30 * time.Second // TTL padrão do cache de PRs/issues (configurável)
 
 	restReadRetryMaxAttempts = 3
 	restReadRetryBaseDelay   = 250 * time.Millisecond
@@ -43,31 +47,91 @@ const (
 
 // Service implementa IGitHubService
 type Service struct {
-	token        func() (string, error) // Função que retorna o token GitHub
-	client       *http.Client
-	cache        *Cache
-	restEndpoint string
-	telemetry    func(eventName string, payload interface{})
-	rateLeft     int // Rate limit remaining
-	rateReset    time.Time
-	retrySleep   func(time.Duration)
-	retryRand    func() float64
+	token          func() (string, error) // Função que retorna o token GitHub
+	tokenRouter    func(owner string) (string, error)
+	onUnauthorized func(owner string) bool // renova o token após 401; true = repetir o request
+	credentials    func(owner string) (CredentialPermissions, bool)
+	client         *http.Client
+	cache          *Cache
+	restEndpoint   string
+	telemetry      func(eventName string, payload interface{})
+	rateLeft       int // Rate limit remaining
+	rateReset      time.Time
+	budget         *RateBudget
+	ssoMu          sync.Mutex
+	ssoChallenges  map[string]SSOChallenge // org (minúsculo) -> desafio SAML SSO pendente
+	batcher        *graphqlBatcher
+	retrySleep     func(time.Duration) // só em testes; nil espera com timer cancelável
+	retryRand      func() float64
 }
 
 // NewService cria um novo serviço GitHub
 // tokenFn é uma função que retorna o token de acesso GitHub do usuário autenticado
 func NewService(tokenFn func() (string, error)) *Service {
-	return &Service{
-		token: tokenFn,
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		cache:        NewCache(defaultCacheTTL),
+	s := &Service{
+		token:        tokenFn,
+		client:       httpclient.NewClient(httpclient.ServiceGitHub, 30*time.Second),
+		cache:        NewCache(DefaultCacheTTL),
 		restEndpoint: githubRESTEndpoint,
 		rateLeft:     5000,
-		retrySleep:   time.Sleep,
+		budget:       NewRateBudget(),
 		retryRand:    rand.Float64,
 	}
+	s.batcher = newGraphQLBatcher(s.flushGraphQLBatch)
+	return s
+}
+
+// SetTokenRouter define como escolher o token pelo owner do repositório (multi-conta).
+// Sem router, ou quando o owner é desconhecido, usa tokenFn.
+func (s *Service) SetTokenRouter(router func(owner string) (string, error)) {
+	s.tokenRouter = router
+}
+
+// SetUnauthorizedRefresher define como renovar o token quando o GitHub responde 401.
+// Requests são repetidos uma única vez com o token renovado.
+func (s *Service) SetUnauthorizedRefresher(refresher func(owner string) bool) {
+	s.onUnauthorized = refresher
+}
+
+// refreshAfterUnauthorized renova o token do owner e retorna o novo valor para o retry.
+func (s *Service) refreshAfterUnauthorized(owner string) (string, bool) {
+	if s.onUnauthorized == nil || !s.onUnauthorized(owner) {
+		return "", false
+	}
+	token, err := s.tokenForOwner(owner)
+	if err != nil || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// tokenForOwner resolve o token da conta vinculada ao owner.
+func (s *Service) tokenForOwner(owner string) (string, error) {
+	owner = strings.TrimSpace(owner)
+	if s.tokenRouter != nil && owner != "" {
+		return s.tokenRouter(owner)
+	}
+	return s.token()
+}
+
+// SetCacheTTL altera o TTL do cache em memória (vale para as próximas leituras).
+func (s *Service) SetCacheTTL(ttl time.Duration) {
+	s.cache.SetTTL(ttl)
+}
+
+// SetRateBudgetStore persiste o budget de rate limit no arquivo informado.
+func (s *Service) SetRateBudgetStore(path string) {
+	s.budget.SetStorePath(path)
+}
+
+// FlushRateBudget grava o budget imediatamente (shutdown).
+func (s *Service) FlushRateBudget() {
+	s.budget.Flush()
+}
+
+// RateBudgetStatus retorna consumo por categoria, filas e estado dos recursos core/graphql.
+func (s *Service) RateBudgetStatus() RateBudgetStatus {
+	return s.budget.Status()
 }
 
 // === GraphQL Client ===
@@ -82,14 +146,136 @@ type graphqlRequest struct {
 type graphqlResponse struct {
 	Data   json.RawMessage `json:"data"`
 	Errors []struct {
-		Message string `json:"message"`
-		Type    string `json:"type"`
+		Message string        `json:"message"`
+		Type    string        `json:"type"`
+		Path    []interface{} `json:"path,omitempty"`
 	} `json:"errors,omitempty"`
 }
 
-// executeQuery executa uma query/mutation GraphQL
-func (s *Service) executeQuery(query string, variables map[string]interface{}) (json.RawMessage, error) {
-	token, err := s.token()
+// executeQuery executa uma query/mutation GraphQL com prioridade interativa.
+func (s *Service) executeQuery(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	return s.executeQueryWithPriority(ctx, PriorityInteractive, "", query, variables)
+}
+
+// executeOwnerQuery executa query/mutation cujas variáveis não trazem o owner (ex.: mutations por ID),
+// roteando o token pela conta do repositório.
+func (s *Service) executeOwnerQuery(ctx context.Context, owner string, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	return s.runQuery(ctx, owner, PriorityInteractive, "", query, variables)
+}
+
+// graphqlVariablesOwner extrai o owner das variáveis ($owner) para o roteamento de token.
+func graphqlVariablesOwner(variables map[string]interface{}) string {
+	owner, _ := variables["owner"].(string)
+	return strings.TrimSpace(owner)
+}
+
+// executeQueryWithPriority passa pelo budget; queries de poller/background podem ser agrupadas em lote.
+// category vazia usa o nome da operação GraphQL.
+func (s *Service) executeQueryWithPriority(ctx context.Context, priority RequestPriority, category string, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	return s.runQuery(ctx, graphqlVariablesOwner(variables), priority, category, query, variables)
+}
+
+func (s *Service) runQuery(ctx context.Context, owner string, priority RequestPriority, category string, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	if category == "" {
+		category = graphqlOperationName(query)
+	}
+	if category == "" {
+		category = "graphql"
+	}
+	if priority != PriorityInteractive && isBatchableGraphQLQuery(query) {
+		return s.batcher.enqueue(ctx, &graphqlBatchItem{
+			ctx:       ctx,
+			owner:     owner,
+			query:     query,
+			variables: variables,
+			category:  category,
+			priority:  priority,
+		})
+	}
+
+	gqlResp, err := s.postGraphQL(ctx, owner, priority, []string{category}, query, variables)
+	if err != nil {
+		return nil, err
+	}
+	if len(gqlResp.Errors) > 0 {
+		return nil, &GitHubError{
+			StatusCode: 200,
+			Message:    gqlResp.Errors[0].Message,
+			Type:       "graphql",
+		}
+	}
+	return gqlResp.Data, nil
+}
+
+// flushGraphQLBatch envia o lote como um documento por owner (cada conta usa seu token);
+// se a fusão falhar, envia um a um.
+func (s *Service) flushGraphQLBatch(items []*graphqlBatchItem) {
+	var owners []string
+	groups := make(map[string][]*graphqlBatchItem)
+	for _, item := range items {
+		key := strings.ToLower(item.owner)
+		if _, ok := groups[key]; !ok {
+			owners = append(owners, key)
+		}
+		groups[key] = append(groups[key], item)
+	}
+	for _, key := range owners {
+		s.flushGraphQLOwnerBatch(groups[key])
+	}
+}
+
+func (s *Service) flushGraphQLOwnerBatch(items []*graphqlBatchItem) {
+	owner := items[0].owner
+	priority := PriorityBackground
+	categories := make([]string, len(items))
+	for idx, item := range items {
+		categories[idx] = item.category
+		if item.priority < priority {
+			priority = item.priority
+		}
+	}
+
+	var merged string
+	var variables map[string]interface{}
+	mergeErr := fmt.Errorf("single query")
+	if len(items) > 1 {
+		merged, variables, mergeErr = mergeGraphQLQueries(items)
+	}
+	if mergeErr != nil {
+		for _, item := range items {
+			gqlResp, err := s.postGraphQL(item.ctx, owner, item.priority, []string{item.category}, item.query, item.variables)
+			item.done <- singleGraphQLBatchResult(gqlResp, err)
+		}
+		return
+	}
+
+	ctx, cancel := batchContext(items)
+	defer cancel()
+	gqlResp, err := s.postGraphQL(ctx, owner, priority, categories, merged, variables)
+	if err != nil {
+		for _, item := range items {
+			item.done <- graphqlBatchResult{err: err}
+		}
+		return
+	}
+	for idx, result := range splitGraphQLBatchResponse(len(items), gqlResp) {
+		items[idx].done <- result
+	}
+}
+
+func singleGraphQLBatchResult(gqlResp *graphqlResponse, err error) graphqlBatchResult {
+	if err != nil {
+		return graphqlBatchResult{err: err}
+	}
+	if len(gqlResp.Errors) > 0 {
+		return graphqlBatchResult{err: &GitHubError{StatusCode: 200, Message: gqlResp.Errors[0].Message, Type: "graphql"}}
+	}
+	return graphqlBatchResult{data: gqlResp.Data}
+}
+
+// postGraphQL executa o POST GraphQL dentro do budget e retorna a resposta com erros GraphQL intactos.
+func (s *Service) postGraphQL(ctx context.Context, owner string, priority RequestPriority, categories []string, query string, variables map[string]interface{}) (*graphqlResponse, error) {
+	token, err := s.tokenForOwner(owner)
 	if err != nil {
 		return nil, &GitHubError{StatusCode: 401, Message: "Not authenticated with GitHub", Type: "auth"}
 	}
@@ -104,31 +290,46 @@ func (s *Service) executeQuery(query string, variables map[string]interface{}) (
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", githubGraphQLEndpoint, bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+	var resp *http.Response
+	var respBody []byte
+	for refreshed := false; ; refreshed = true {
+		req, err := http.NewRequest("POST", githubGraphQLEndpoint, bytes.NewBuffer(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
 
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "ORCH-App/1.0")
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "ORCH-App/1.0")
 
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, &GitHubError{StatusCode: 0, Message: "Network error: " + err.Error(), Type: "network"}
-	}
-	defer resp.Body.Close()
+		resp, err = s.doBudgeted(ctx, req, priority, rateResourceGraphQL, categories...)
+		if err != nil {
+			return nil, err
+		}
 
-	// Atualizar rate limit info dos headers
-	s.updateRateLimit(resp.Header)
+		// Atualizar rate limit info dos headers
+		s.updateRateLimit(resp.Header)
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		respBody, err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && !refreshed {
+			if next, ok := s.refreshAfterUnauthorized(owner); ok {
+				token = next
+				continue
+			}
+		}
+		break
 	}
 
 	// Tratar erros HTTP
 	if resp.StatusCode != 200 {
+		if ssoErr := ssoChallengeError(resp.Header); ssoErr != nil {
+			return nil, ssoErr
+		}
 		return nil, s.handleHTTPError(resp.StatusCode, respBody)
 	}
 
@@ -136,21 +337,56 @@ func (s *Service) executeQuery(query string, variables map[string]interface{}) (
 	if err := json.Unmarshal(respBody, &gqlResp); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
-
+	// GraphQL responde 200 com erro FORBIDDEN quando a org exige SSO.
 	if len(gqlResp.Errors) > 0 {
-		return nil, &GitHubError{
-			StatusCode: 200,
-			Message:    gqlResp.Errors[0].Message,
-			Type:       "graphql",
+		if ssoErr := ssoChallengeError(resp.Header); ssoErr != nil {
+			return nil, ssoErr
 		}
 	}
+	return &gqlResp, nil
+}
 
-	return gqlResp.Data, nil
+// doBudgeted executa o request reservando vaga no budget; a vaga é devolvida ao receber os headers.
+func (s *Service) doBudgeted(ctx context.Context, req *http.Request, priority RequestPriority, resource string, categories ...string) (*http.Response, error) {
+	category := ""
+	if len(categories) > 0 {
+		category = categories[0]
+	}
+	if err := s.budget.Acquire(ctx, priority, resource, category); err != nil {
+		if githubErr, ok := err.(*GitHubError); ok {
+			return nil, githubErr
+		}
+		if ctx.Err() != nil {
+			return nil, canceledRequestError(ctx.Err())
+		}
+		return nil, &GitHubError{StatusCode: 0, Message: "Request canceled: " + err.Error(), Type: "network"}
+	}
+
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		s.budget.Release(resource, nil, categories...)
+		if ctx.Err() != nil {
+			return nil, canceledRequestError(ctx.Err())
+		}
+		return nil, &GitHubError{StatusCode: 0, Message: "Network error: " + err.Error(), Type: "network"}
+	}
+	s.budget.Release(resource, resp.Header, categories...)
+	s.observeSSOHeader(resp.Header)
+	return resp, nil
+}
+
+// canceledRequestError representa request abortado pelo chamador (CancelOperation, JobCancel).
+func canceledRequestError(cause error) *GitHubError {
+	message := "Request canceled"
+	if errors.Is(cause, context.DeadlineExceeded) {
+		message = "Request deadline exceeded"
+	}
+	return &GitHubError{StatusCode: 0, Message: message, Type: "canceled"}
 }
 
 // executeRESTRequest executa chamadas REST para GitHub com headers oficiais de PR API.
-func (s *Service) executeRESTRequest(method, endpointPath string, queryValues url.Values, acceptHeader string, body io.Reader) ([]byte, http.Header, error) {
-	respBody, headers, statusCode, err := s.executeRESTRequestConditional(method, endpointPath, queryValues, acceptHeader, body, "")
+func (s *Service) executeRESTRequest(ctx context.Context, method, endpointPath string, queryValues url.Values, acceptHeader string, body io.Reader) ([]byte, http.Header, error) {
+	respBody, headers, statusCode, err := s.executeRESTRequestConditional(ctx, method, endpointPath, queryValues, acceptHeader, body, "")
 	if err != nil {
 		return nil, headers, err
 	}
@@ -164,8 +400,10 @@ func (s *Service) executeRESTRequest(method, endpointPath string, queryValues ur
 	return respBody, headers, nil
 }
 
-// executeRESTRequestConditional executa request REST opcionalmente condicional via If-None-Match.
+// executeRESTRequestConditional executa request REST opcionalmente condicional via If-None-Match;
+// aborta o request (e os retries) quando ctx é cancelado.
 func (s *Service) executeRESTRequestConditional(
+	ctx context.Context,
 	method,
 	endpointPath string,
 	queryValues url.Values,
@@ -173,11 +411,6 @@ func (s *Service) executeRESTRequestConditional(
 	body io.Reader,
 	ifNoneMatch string,
 ) ([]byte, http.Header, int, error) {
-	token, err := s.token()
-	if err != nil {
-		return nil, nil, 0, &GitHubError{StatusCode: 401, Message: "Not authenticated with GitHub", Type: "auth"}
-	}
-
 	normalizedPath := strings.TrimSpace(endpointPath)
 	if normalizedPath == "" {
 		return nil, nil, 0, fmt.Errorf("empty REST endpoint path")
@@ -186,6 +419,12 @@ func (s *Service) executeRESTRequestConditional(
 		normalizedPath = "/" + normalizedPath
 	}
 
+	owner := restPathOwner(normalizedPath)
+	token, err := s.tokenForOwner(owner)
+	if err != nil {
+		return nil, nil, 0, &GitHubError{StatusCode: 401, Message: "Not authenticated with GitHub", Type: "auth"}
+	}
+
 	baseURL := strings.TrimRight(strings.TrimSpace(s.restEndpoint), "/")
 	if baseURL == "" {
 		baseURL = githubRESTEndpoint
@@ -203,6 +442,7 @@ func (s *Service) executeRESTRequestConditional(
 	if normalizedMethod == "" {
 		normalizedMethod = http.MethodGet
 	}
+	s.warnIfCredentialLacksPermissions(normalizedMethod, normalizedPath)
 
 	normalizedAccept := strings.TrimSpace(acceptHeader)
 	if normalizedAccept == "" {
@@ -214,8 +454,22 @@ func (s *Service) executeRESTRequestConditional(
 		maxAttempts = restReadRetryMaxAttempts
 	}
 
+	// Corpo em memória para poder repetir o request após renovar o token.
+	var bodyBytes []byte
+	if body != nil {
+		bodyBytes, err = io.ReadAll(body)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to read REST request body: %w", err)
+		}
+	}
+	refreshedAuth := false
+
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		req, err := http.NewRequest(normalizedMethod, requestURL.String(), body)
+		var requestBody io.Reader
+		if bodyBytes != nil {
+			requestBody = bytes.NewReader(bodyBytes)
+		}
+		req, err := http.NewRequest(normalizedMethod, requestURL.String(), requestBody)
 		if err != nil {
 			return nil, nil, 0, fmt.Errorf("failed to create REST request: %w", err)
 		}
@@ -227,16 +481,21 @@ func (s *Service) executeRESTRequestConditional(
 		if normalizedIfNoneMatch := strings.TrimSpace(ifNoneMatch); normalizedIfNoneMatch != "" {
 			req.Header.Set("If-None-Match", normalizedIfNoneMatch)
 		}
-		if body != nil {
+		if bodyBytes != nil {
 			req.Header.Set("Content-Type", "application/json")
 		}
 
-		resp, requestErr := s.client.Do(req)
+		resp, requestErr := s.doBudgeted(ctx, req, PriorityInteractive, rateResourceCore, restCategory(normalizedPath))
 		if requestErr != nil {
-			wrapped := &GitHubError{StatusCode: 0, Message: "Network error: " + requestErr.Error(), Type: "network"}
+			wrapped, ok := requestErr.(*GitHubError)
+			if !ok || wrapped.Type == "ratelimit" || wrapped.Type == "canceled" {
+				return nil, nil, 0, requestErr
+			}
 			if delay, reason, shouldRetry := s.shouldRetryRESTRead(normalizedMethod, attempt, maxAttempts, 0, nil, nil, wrapped); shouldRetry {
 				log.Printf("[GitHub][PR-REST] retrying read request method=%s path=%s attempt=%d/%d reason=%s delay=%s status=%d", normalizedMethod, normalizedPath, attempt+1, maxAttempts, reason, delay, 0)
-				s.sleepRetryDelay(delay)
+				if sleepErr := s.sleepRetryDelay(ctx, delay); sleepErr != nil {
+					return nil, nil, 0, canceledRequestError(sleepErr)
+				}
 				continue
 			}
 			return nil, nil, 0, wrapped
@@ -257,17 +516,30 @@ func (s *Service) executeRESTRequestConditional(
 			wrappedReadErr := fmt.Errorf("failed to read REST response: %w", readErr)
 			if delay, reason, shouldRetry := s.shouldRetryRESTRead(normalizedMethod, attempt, maxAttempts, resp.StatusCode, resp.Header, nil, wrappedReadErr); shouldRetry {
 				log.Printf("[GitHub][PR-REST] retrying read request method=%s path=%s attempt=%d/%d reason=%s delay=%s status=%d", normalizedMethod, normalizedPath, attempt+1, maxAttempts, reason, delay, resp.StatusCode)
-				s.sleepRetryDelay(delay)
+				if sleepErr := s.sleepRetryDelay(ctx, delay); sleepErr != nil {
+					return nil, nil, 0, canceledRequestError(sleepErr)
+				}
 				continue
 			}
 			return nil, resp.Header, resp.StatusCode, wrappedReadErr
 		}
 
+		if resp.StatusCode == http.StatusUnauthorized && !refreshedAuth {
+			refreshedAuth = true
+			if next, ok := s.refreshAfterUnauthorized(owner); ok {
+				token = next
+				attempt-- // retry de autenticação não consome tentativa de leitura
+				continue
+			}
+		}
+
 		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
 			httpErr := s.handleRESTHTTPError(normalizedMethod, normalizedPath, resp.StatusCode, resp.Header, respBody)
 			if delay, reason, shouldRetry := s.shouldRetryRESTRead(normalizedMethod, attempt, maxAttempts, resp.StatusCode, resp.Header, respBody, nil); shouldRetry {
 				log.Printf("[GitHub][PR-REST] retrying read request method=%s path=%s attempt=%d/%d reason=%s delay=%s status=%d", normalizedMethod, normalizedPath, attempt+1, maxAttempts, reason, delay, resp.StatusCode)
-				s.sleepRetryDelay(delay)
+				if sleepErr := s.sleepRetryDelay(ctx, delay); sleepErr != nil {
+					return nil, nil, 0, canceledRequestError(sleepErr)
+				}
 				continue
 			}
 			return nil, resp.Header, resp.StatusCode, httpErr
@@ -279,6 +551,19 @@ func (s *Service) executeRESTRequestConditional(
 	return nil, nil, 0, fmt.Errorf("failed to execute REST request")
 }
 
+// restPathOwner extrai o owner de caminhos /repos/{owner}/... para o roteamento de token.
+func restPathOwner(endpointPath string) string {
+	rest, ok := strings.CutPrefix(endpointPath, "/repos/")
+	if !ok {
+		return ""
+	}
+	owner, _, _ := strings.Cut(rest, "/")
+	if unescaped, err := url.PathUnescape(owner); err == nil {
+		owner = unescaped
+	}
+	return owner
+}
+
 func (s *Service) shouldRetryRESTRead(
 	method string,
 	attempt,
@@ -438,15 +723,26 @@ func (s *Service) retryRandomFloat64() float64 {
 	return value
 }
 
-func (s *Service) sleepRetryDelay(delay time.Duration) {
+// sleepRetryDelay espera o backoff, mas retorna assim que ctx é cancelado.
+func (s *Service) sleepRetryDelay(ctx context.Context, delay time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	if delay <= 0 {
-		return
+		return nil
 	}
-	if s != nil && s.retrySleep != nil {
+	if s.retrySleep != nil {
 		s.retrySleep(delay)
-		return
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-	time.Sleep(delay)
 }
 
 // updateRateLimit atualiza informações de rate limit dos headers HTTP
@@ -498,6 +794,11 @@ func (s *Service) handleHTTPError(statusCode int, body []byte) *GitHubError {
 }
 
 func (s *Service) handleRESTHTTPError(method, endpointPath string, statusCode int, headers http.Header, body []byte) *GitHubError {
+	if statusCode == http.StatusForbidden {
+		if ssoErr := ssoChallengeError(headers); ssoErr != nil {
+			return ssoErr
+		}
+	}
 	githubErr := s.handleHTTPError(statusCode, body)
 	if githubErr == nil {
 		return nil
@@ -605,13 +906,13 @@ func sanitizePermissionHeaderValue(value string) string {
 // === Repositories ===
 
 // ListRepositories lista repositórios do usuário autenticado
-func (s *Service) ListRepositories() ([]Repository, error) {
+func (s *Service) ListRepositories(ctx context.Context) ([]Repository, error) {
 	// Checar cache
 	if repos, ok := s.cache.GetRepos(); ok {
 		return repos, nil
 	}
 
-	data, err := s.executeQuery(QueryListRepositories, map[string]interface{}{
+	data, err := s.executeQuery(ctx, QueryListRepositories, map[string]interface{}{
 		"first": 50,
 	})
 	if err != nil {
@@ -669,7 +970,7 @@ func (s *Service) ListRepositories() ([]Repository, error) {
 // === Pull Requests ===
 
 // GetPullRequestDiff busca o diff de um PR
-func (s *Service) GetPullRequestDiff(owner, repo string, number int, pagination DiffPagination) (*Diff, error) {
+func (s *Service) GetPullRequestDiff(ctx context.Context, owner, repo string, number int, pagination DiffPagination) (*Diff, error) {
 	if pagination.First == 0 {
 		pagination.First = 20 // Chunk de 20 arquivos
 	}
@@ -684,7 +985,7 @@ func (s *Service) GetPullRequestDiff(owner, repo string, number int, pagination
 		vars["after"] = *pagination.After
 	}
 
-	data, err := s.executeQuery(QueryGetPRDiff, vars)
+	data, err := s.executeQuery(ctx, QueryGetPRDiff, vars)
 	if err != nil {
 		return nil, err
 	}
@@ -745,7 +1046,7 @@ func (s *Service) GetPullRequestDiff(owner, repo string, number int, pagination
 }
 
 // GetPullRequestCommits busca commits de um PR via REST com paginação.
-func (s *Service) GetPullRequestCommits(owner, repo string, number int, page, perPage int) (*PRCommitPage, error) {
+func (s *Service) GetPullRequestCommits(ctx context.Context, owner, repo string, number int, page, perPage int) (*PRCommitPage, error) {
 	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(owner, repo)
 	if normalizeErr != nil {
 		return nil, normalizeErr
@@ -780,6 +1081,7 @@ func (s *Service) GetPullRequestCommits(owner, repo string, number int, page, pe
 	queryValues.Set("per_page", strconv.Itoa(perPage))
 
 	respBody, headers, statusCode, err := s.executeRESTRequestConditional(
+		ctx,
 		http.MethodGet,
 		endpointPath,
 		queryValues,
@@ -905,7 +1207,7 @@ func (s *Service) GetPullRequestCommits(owner, repo string, number int, page, pe
 }
 
 // GetPullRequestFiles busca arquivos de um PR via REST com paginação.
-func (s *Service) GetPullRequestFiles(owner, repo string, number int, page, perPage int) (*PRFilePage, error) {
+func (s *Service) GetPullRequestFiles(ctx context.Context, owner, repo string, number int, page, perPage int) (*PRFilePage, error) {
 	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(owner, repo)
 	if normalizeErr != nil {
 		return nil, normalizeErr
@@ -940,6 +1242,7 @@ func (s *Service) GetPullRequestFiles(owner, repo string, number int, page, perP
 	queryValues.Set("per_page", strconv.Itoa(perPage))
 
 	respBody, headers, statusCode, err := s.executeRESTRequestConditional(
+		ctx,
 		http.MethodGet,
 		endpointPath,
 		queryValues,
@@ -1055,7 +1358,7 @@ func (s *Service) GetPullRequestFiles(owner, repo string, number int, page, perP
 }
 
 // GetPullRequestRawDiff busca o diff completo bruto de um PR sob demanda.
-func (s *Service) GetPullRequestRawDiff(owner, repo string, number int) (string, error) {
+func (s *Service) GetPullRequestRawDiff(ctx context.Context, owner, repo string, number int) (string, error) {
 	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(owner, repo)
 	if normalizeErr != nil {
 		return "", normalizeErr
@@ -1084,6 +1387,7 @@ func (s *Service) GetPullRequestRawDiff(owner, repo string, number int) (string,
 	}
 
 	respBody, headers, statusCode, err := s.executeRESTRequestConditional(
+		ctx,
 		http.MethodGet,
 		endpointPath,
 		nil,
@@ -1120,7 +1424,7 @@ func (s *Service) GetPullRequestRawDiff(owner, repo string, number int) (string,
 }
 
 // GetCommitRawDiff busca o diff bruto de um commit especifico.
-func (s *Service) GetCommitRawDiff(owner, repo, sha string) (string, error) {
+func (s *Service) GetCommitRawDiff(ctx context.Context, owner, repo, sha string) (string, error) {
 	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(owner, repo)
 	if normalizeErr != nil {
 		return "", normalizeErr
@@ -1139,6 +1443,7 @@ func (s *Service) GetCommitRawDiff(owner, repo, sha string) (string, error) {
 	requestStartedAt := time.Now()
 
 	respBody, _, statusCode, err := s.executeRESTRequestConditional(
+		ctx,
 		http.MethodGet,
 		endpointPath,
 		nil,
@@ -1157,7 +1462,7 @@ func (s *Service) GetCommitRawDiff(owner, repo, sha string) (string, error) {
 }
 
 // CheckPullRequestMerged verifica se uma PR ja foi mergeada via endpoint REST /merge.
-func (s *Service) CheckPullRequestMerged(owner, repo string, number int) (bool, error) {
+func (s *Service) CheckPullRequestMerged(ctx context.Context, owner, repo string, number int) (bool, error) {
 	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(owner, repo)
 	if normalizeErr != nil {
 		return false, normalizeErr
@@ -1189,6 +1494,7 @@ func (s *Service) CheckPullRequestMerged(owner, repo string, number int) (bool,
 	}
 
 	_, headers, statusCode, err := s.executeRESTRequestConditional(
+		ctx,
 		http.MethodGet,
 		endpointPath,
 		nil,
@@ -1234,7 +1540,7 @@ func (s *Service) CheckPullRequestMerged(owner, repo string, number int) (bool,
 }
 
 // CreatePullRequest cria um novo PR
-func (s *Service) CreatePullRequest(input CreatePRInput) (*PullRequest, error) {
+func (s *Service) CreatePullRequest(ctx context.Context, input CreatePRInput) (*PullRequest, error) {
 	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(input.Owner, input.Repo)
 	if normalizeErr != nil {
 		return nil, normalizeErr
@@ -1289,7 +1595,7 @@ func (s *Service) CreatePullRequest(input CreatePRInput) (*PullRequest, error) {
 	)
 
 	var payloadResponse restPullRequest
-	if err := s.executePRRESTJSON(prActionCreate, http.MethodPost, endpointPath, nil, requestPayload, &payloadResponse); err != nil {
+	if err := s.executePRRESTJSON(ctx, prActionCreate, http.MethodPost, endpointPath, nil, requestPayload, &payloadResponse); err != nil {
 		return nil, err
 	}
 
@@ -1338,7 +1644,7 @@ func normalizePRRESTOptionalSHA(rawSHA string) (string, error) {
 }
 
 // UpdatePullRequest atualiza campos de um PR via GitHub REST API v3.
-func (s *Service) UpdatePullRequest(input UpdatePRInput) (*PullRequest, error) {
+func (s *Service) UpdatePullRequest(ctx context.Context, input UpdatePRInput) (*PullRequest, error) {
 	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(input.Owner, input.Repo)
 	if normalizeErr != nil {
 		return nil, normalizeErr
@@ -1407,7 +1713,7 @@ func (s *Service) UpdatePullRequest(input UpdatePRInput) (*PullRequest, error) {
 	)
 
 	var payloadResponse restPullRequest
-	if err := s.executePRRESTJSON(prActionUpdate, http.MethodPatch, endpointPath, nil, requestPayload, &payloadResponse); err != nil {
+	if err := s.executePRRESTJSON(ctx, prActionUpdate, http.MethodPatch, endpointPath, nil, requestPayload, &payloadResponse); err != nil {
 		return nil, err
 	}
 
@@ -1420,7 +1726,7 @@ func (s *Service) UpdatePullRequest(input UpdatePRInput) (*PullRequest, error) {
 }
 
 // MergePullRequestREST executa merge de PR via endpoint REST /pulls/{pull_number}/merge.
-func (s *Service) MergePullRequestREST(input MergePRInput) (*PRMergeResult, error) {
+func (s *Service) MergePullRequestREST(ctx context.Context, input MergePRInput) (*PRMergeResult, error) {
 	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(input.Owner, input.Repo)
 	if normalizeErr != nil {
 		return nil, normalizeErr
@@ -1472,7 +1778,7 @@ func (s *Service) MergePullRequestREST(input MergePRInput) (*PRMergeResult, erro
 		Merged  bool   `json:"merged"`
 		Message string `json:"message"`
 	}
-	if err := s.executePRRESTJSON(prActionMerge, http.MethodPut, endpointPath, nil, requestPayload, &payloadResponse); err != nil {
+	if err := s.executePRRESTJSON(ctx, prActionMerge, http.MethodPut, endpointPath, nil, requestPayload, &payloadResponse); err != nil {
 		return nil, err
 	}
 
@@ -1491,7 +1797,7 @@ func (s *Service) MergePullRequestREST(input MergePRInput) (*PRMergeResult, erro
 }
 
 // UpdatePullRequestBranch atualiza branch de PR via endpoint REST /pulls/{pull_number}/update-branch.
-func (s *Service) UpdatePullRequestBranch(input UpdatePRBranchInput) (*PRUpdateBranchResult, error) {
+func (s *Service) UpdatePullRequestBranch(ctx context.Context, input UpdatePRBranchInput) (*PRUpdateBranchResult, error) {
 	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(input.Owner, input.Repo)
 	if normalizeErr != nil {
 		return nil, normalizeErr
@@ -1529,7 +1835,7 @@ func (s *Service) UpdatePullRequestBranch(input UpdatePRBranchInput) (*PRUpdateB
 	var payloadResponse struct {
 		Message string `json:"message"`
 	}
-	if err := s.executePRRESTJSON(prActionUpdateBranch, http.MethodPut, endpointPath, nil, requestPayload, &payloadResponse); err != nil {
+	if err := s.executePRRESTJSON(ctx, prActionUpdateBranch, http.MethodPut, endpointPath, nil, requestPayload, &payloadResponse); err != nil {
 		return nil, err
 	}
 
@@ -1546,8 +1852,8 @@ func (s *Service) UpdatePullRequestBranch(input UpdatePRBranchInput) (*PRUpdateB
 }
 
 // MergePullRequest faz merge de um PR
-func (s *Service) MergePullRequest(owner, repo string, number int, method MergeMethod) error {
-	_, err := s.MergePullRequestREST(MergePRInput{
+func (s *Service) MergePullRequest(ctx context.Context, owner, repo string, number int, method MergeMethod) error {
+	_, err := s.MergePullRequestREST(ctx, MergePRInput{
 		Owner:       owner,
 		Repo:        repo,
 		Number:      number,
@@ -1557,13 +1863,13 @@ func (s *Service) MergePullRequest(owner, repo string, number int, method MergeM
 }
 
 // ClosePullRequest fecha um PR
-func (s *Service) ClosePullRequest(owner, repo string, number int) error {
-	pr, err := s.GetPullRequest(owner, repo, number)
+func (s *Service) ClosePullRequest(ctx context.Context, owner, repo string, number int) error {
+	pr, err := s.GetPullRequest(ctx, owner, repo, number)
 	if err != nil {
 		return err
 	}
 
-	_, err = s.executeQuery(MutationClosePR, map[string]interface{}{
+	_, err = s.executeOwnerQuery(ctx, owner, MutationClosePR, map[string]interface{}{
 		"input": map[string]interface{}{
 			"pullRequestId": pr.ID,
 		},
@@ -1580,12 +1886,12 @@ func (s *Service) ClosePullRequest(owner, repo string, number int) error {
 // === Reviews ===
 
 // ListReviews lista reviews de um PR
-func (s *Service) ListReviews(owner, repo string, prNumber int) ([]Review, error) {
+func (s *Service) ListReviews(ctx context.Context, owner, repo string, prNumber int) ([]Review, error) {
 	if reviews, ok := s.cache.GetReviews(owner, repo, prNumber); ok {
 		return reviews, nil
 	}
 
-	data, err := s.executeQuery(QueryListReviews, map[string]interface{}{
+	data, err := s.executeQuery(ctx, QueryListReviews, map[string]interface{}{
 		"owner":  owner,
 		"repo":   repo,
 		"number": prNumber,
@@ -1633,13 +1939,13 @@ func (s *Service) ListReviews(owner, repo string, prNumber int) ([]Review, error
 }
 
 // CreateReview cria um review em um PR
-func (s *Service) CreateReview(input CreateReviewInput) (*Review, error) {
-	pr, err := s.GetPullRequest(input.Owner, input.Repo, input.PRNumber)
+func (s *Service) CreateReview(ctx context.Context, input CreateReviewInput) (*Review, error) {
+	pr, err := s.GetPullRequest(ctx, input.Owner, input.Repo, input.PRNumber)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := s.executeQuery(MutationCreateReview, map[string]interface{}{
+	data, err := s.executeOwnerQuery(ctx, input.Owner, MutationCreateReview, map[string]interface{}{
 		"input": map[string]interface{}{
 			"pullRequestId": pr.ID,
 			"body":          input.Body,
@@ -1687,12 +1993,12 @@ func (s *Service) CreateReview(input CreateReviewInput) (*Review, error) {
 // === Comments ===
 
 // ListComments lista comentários de um PR
-func (s *Service) ListComments(owner, repo string, prNumber int) ([]Comment, error) {
+func (s *Service) ListComments(ctx context.Context, owner, repo string, prNumber int) ([]Comment, error) {
 	if comments, ok := s.cache.GetComments(owner, repo, prNumber); ok {
 		return comments, nil
 	}
 
-	data, err := s.executeQuery(QueryListComments, map[string]interface{}{
+	data, err := s.executeQuery(ctx, QueryListComments, map[string]interface{}{
 		"owner":  owner,
 		"repo":   repo,
 		"number": prNumber,
@@ -1740,13 +2046,13 @@ func (s *Service) ListComments(owner, repo string, prNumber int) ([]Comment, err
 }
 
 // CreateComment cria um comentário em um PR
-func (s *Service) CreateComment(input CreateCommentInput) (*Comment, error) {
-	pr, err := s.GetPullRequest(input.Owner, input.Repo, input.PRNumber)
+func (s *Service) CreateComment(ctx context.Context, input CreateCommentInput) (*Comment, error) {
+	pr, err := s.GetPullRequest(ctx, input.Owner, input.Repo, input.PRNumber)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := s.executeQuery(MutationCreateComment, map[string]interface{}{
+	data, err := s.executeOwnerQuery(ctx, input.Owner, MutationCreateComment, map[string]interface{}{
 		"input": map[string]interface{}{
 			"subjectId": pr.ID,
 			"body":      input.Body,
@@ -1775,7 +2081,7 @@ func (s *Service) CreateComment(input CreateCommentInput) (*Comment, error) {
 }
 
 // CreateInlineComment cria um comentário inline no diff (via REST API, pois GraphQL não suporta position facilmente)
-func (s *Service) CreateInlineComment(input InlineCommentInput) (*Comment, error) {
+func (s *Service) CreateInlineComment(ctx context.Context, input InlineCommentInput) (*Comment, error) {
 	payload := map[string]interface{}{
 		"body": input.Body,
 		"path": input.Path,
@@ -1796,6 +2102,7 @@ func (s *Service) CreateInlineComment(input InlineCommentInput) (*Comment, error
 	}
 
 	if err := s.executePRRESTJSON(
+		ctx,
 		prActionInlineCommentCreate,
 		http.MethodPost,
 		fmt.Sprintf("/repos/%s/%s/pulls/%d/comments", input.Owner, input.Repo, input.PRNumber),
@@ -1826,7 +2133,7 @@ func (s *Service) CreateInlineComment(input InlineCommentInput) (*Comment, error
 // === Issues ===
 
 // ListIssues lista issues de um repositório
-func (s *Service) ListIssues(owner, repo string, filters IssueFilters) ([]Issue, error) {
+func (s *Service) ListIssues(ctx context.Context, owner, repo string, filters IssueFilters) ([]Issue, error) {
 	if filters.First == 0 {
 		filters.First = 25
 	}
@@ -1857,7 +2164,7 @@ func (s *Service) ListIssues(owner, repo string, filters IssueFilters) ([]Issue,
 		vars["after"] = *filters.After
 	}
 
-	data, err := s.executeQuery(QueryListIssues, vars)
+	data, err := s.executeQuery(ctx, QueryListIssues, vars)
 	if err != nil {
 		return nil, err
 	}
@@ -1888,8 +2195,8 @@ func (s *Service) ListIssues(owner, repo string, filters IssueFilters) ([]Issue,
 }
 
 // CreateIssue cria uma nova issue
-func (s *Service) CreateIssue(input CreateIssueInput) (*Issue, error) {
-	repoData, err := s.executeQuery(`query($owner: String!, $repo: String!) { repository(owner: $owner, name: $repo) { id } }`,
+func (s *Service) CreateIssue(ctx context.Context, input CreateIssueInput) (*Issue, error) {
+	repoData, err := s.executeQuery(ctx, `query($owner: String!, $repo: String!) { repository(owner: $owner, name: $repo) { id } }`,
 		map[string]interface{}{"owner": input.Owner, "repo": input.Repo})
 	if err != nil {
 		return nil, err
@@ -1910,7 +2217,7 @@ func (s *Service) CreateIssue(input CreateIssueInput) (*Issue, error) {
 		},
 	}
 
-	data, err := s.executeQuery(MutationCreateIssue, vars)
+	data, err := s.executeOwnerQuery(ctx, input.Owner, MutationCreateIssue, vars)
 	if err != nil {
 		return nil, err
 	}
@@ -1945,8 +2252,8 @@ func (s *Service) CreateIssue(input CreateIssueInput) (*Issue, error) {
 }
 
 // UpdateIssue atualiza uma issue
-func (s *Service) UpdateIssue(owner, repo string, number int, input UpdateIssueInput) error {
-	issueData, err := s.executeQuery(`query($owner: String!, $repo: String!, $number: Int!) { repository(owner: $owner, name: $repo) { issue(number: $number) { id } } }`,
+func (s *Service) UpdateIssue(ctx context.Context, owner, repo string, number int, input UpdateIssueInput) error {
+	issueData, err := s.executeQuery(ctx, `query($owner: String!, $repo: String!, $number: Int!) { repository(owner: $owner, name: $repo) { issue(number: $number) { id } } }`,
 		map[string]interface{}{"owner": owner, "repo": repo, "number": number})
 	if err != nil {
 		return err
@@ -1972,7 +2279,7 @@ func (s *Service) UpdateIssue(owner, repo string, number int, input UpdateIssueI
 		updateInput["state"] = *input.State
 	}
 
-	_, err = s.executeQuery(MutationUpdateIssue, map[string]interface{}{
+	_, err = s.executeOwnerQuery(ctx, owner, MutationUpdateIssue, map[string]interface{}{
 		"input": updateInput,
 	})
 	if err != nil {
@@ -2037,7 +2344,7 @@ func normalizeLabelDescription(raw *string) (*string, error) {
 }
 
 // CreateLabel cria uma label de repositorio via GitHub REST API v3.
-func (s *Service) CreateLabel(input CreateLabelInput) (*Label, error) {
+func (s *Service) CreateLabel(ctx context.Context, input CreateLabelInput) (*Label, error) {
 	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(input.Owner, input.Repo)
 	if normalizeErr != nil {
 		return nil, normalizeErr
@@ -2078,6 +2385,7 @@ func (s *Service) CreateLabel(input CreateLabelInput) (*Label, error) {
 		Description string `json:"description"`
 	}
 	if err := s.executePRRESTJSON(
+		ctx,
 		prActionLabelCreate,
 		http.MethodPost,
 		endpointPath,
@@ -2110,15 +2418,41 @@ func (s *Service) CreateLabel(input CreateLabelInput) (*Label, error) {
 	return label, nil
 }
 
+// === Templates ===
+
+// ListPullRequestTemplates lista os templates de PR do branch padrão (sem cache: uso pontual)
+func (s *Service) ListPullRequestTemplates(ctx context.Context, owner, repo string) ([]PRTemplate, error) {
+	data, err := s.executeQuery(ctx, QueryListPRTemplates, map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Repository struct {
+			PullRequestTemplates []PRTemplate `json:"pullRequestTemplates"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	if result.Repository.PullRequestTemplates == nil {
+		return []PRTemplate{}, nil
+	}
+	return result.Repository.PullRequestTemplates, nil
+}
+
 // === Branches ===
 
 // ListBranches lista branches de um repositório
-func (s *Service) ListBranches(owner, repo string) ([]Branch, error) {
+func (s *Service) ListBranches(ctx context.Context, owner, repo string) ([]Branch, error) {
 	if branches, ok := s.cache.GetBranches(owner, repo); ok {
 		return branches, nil
 	}
 
-	data, err := s.executeQuery(QueryListBranches, map[string]interface{}{
+	data, err := s.executeQuery(ctx, QueryListBranches, map[string]interface{}{
 		"owner": owner,
 		"repo":  repo,
 		"first": 100,
@@ -2160,9 +2494,9 @@ func (s *Service) ListBranches(owner, repo string) ([]Branch, error) {
 }
 
 // CreateBranch cria uma nova branch
-func (s *Service) CreateBranch(owner, repo, name, sourceBranch string) (*Branch, error) {
+func (s *Service) CreateBranch(ctx context.Context, owner, repo, name, sourceBranch string) (*Branch, error) {
 	// Buscar OID da source branch
-	branches, err := s.ListBranches(owner, repo)
+	branches, err := s.ListBranches(ctx, owner, repo)
 	if err != nil {
 		return nil, err
 	}
@@ -2179,7 +2513,7 @@ func (s *Service) CreateBranch(owner, repo, name, sourceBranch string) (*Branch,
 	}
 
 	// Buscar repositoryId
-	repoData, err := s.executeQuery(`query($owner: String!, $repo: String!) { repository(owner: $owner, name: $repo) { id } }`,
+	repoData, err := s.executeQuery(ctx, `query($owner: String!, $repo: String!) { repository(owner: $owner, name: $repo) { id } }`,
 		map[string]interface{}{"owner": owner, "repo": repo})
 	if err != nil {
 		return nil, err
@@ -2192,7 +2526,7 @@ func (s *Service) CreateBranch(owner, repo, name, sourceBranch string) (*Branch,
 		return nil, err
 	}
 
-	data, err := s.executeQuery(MutationCreateBranch, map[string]interface{}{
+	data, err := s.executeOwnerQuery(ctx, owner, MutationCreateBranch, map[string]interface{}{
 		"input": map[string]interface{}{
 			"repositoryId": repoResult.Repository.ID,
 			"name":         "refs/heads/" + name,
@@ -2230,6 +2564,49 @@ func (s *Service) CreateBranch(owner, repo, name, sourceBranch string) (*Branch,
 	return branch, nil
 }
 
+// ListProtectedBranches lista branches com proteção ativa via REST (não exige permissão de admin).
+func (s *Service) ListProtectedBranches(ctx context.Context, owner, repo string) ([]Branch, error) {
+	normalizedOwner, normalizedRepo, normalizeErr := normalizeOwnerRepoForPR(owner, repo)
+	if normalizeErr != nil {
+		return nil, normalizeErr
+	}
+	if branches, ok := s.cache.GetProtectedBranches(normalizedOwner, normalizedRepo); ok {
+		return branches, nil
+	}
+
+	query := url.Values{}
+	query.Set("protected", "true")
+	query.Set("per_page", "100")
+	respBody, _, err := s.executeRESTRequest(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("/repos/%s/%s/branches", url.PathEscape(normalizedOwner), url.PathEscape(normalizedRepo)),
+		query,
+		githubRESTAcceptJSON,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload []struct {
+		Name   string `json:"name"`
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	if err := json.Unmarshal(respBody, &payload); err != nil {
+		return nil, err
+	}
+
+	branches := make([]Branch, len(payload))
+	for i, b := range payload {
+		branches[i] = Branch{Name: b.Name, Prefix: "refs/heads/", Commit: b.Commit.SHA}
+	}
+	s.cache.SetProtectedBranches(normalizedOwner, normalizedRepo, branches)
+	return branches, nil
+}
+
 // === Cache ===
 
 // InvalidateCache invalida o cache de um repositório
@@ -2245,7 +2622,7 @@ func (s *Service) GetCachedPullRequest(owner, repo string, number int) (*PullReq
 
 // ResolveCommitAuthors resolve login/avatar de autores de commits por SHA.
 // Retorna apenas commits encontrados e vinculados a usuários do GitHub.
-func (s *Service) ResolveCommitAuthors(owner, repo string, hashes []string) (map[string]User, error) {
+func (s *Service) ResolveCommitAuthors(ctx context.Context, owner, repo string, hashes []string) (map[string]User, error) {
 	normalizedOwner := strings.TrimSpace(owner)
 	normalizedRepo := strings.TrimSpace(repo)
 	if normalizedOwner == "" || normalizedRepo == "" {
@@ -2270,7 +2647,8 @@ func (s *Service) ResolveCommitAuthors(owner, repo string, hashes []string) (map
 			continue
 		}
 
-		data, err := s.executeQuery(query, map[string]interface{}{
+		// Avatares de autores são enriquecimento: entram como background no budget.
+		data, err := s.executeQueryWithPriority(ctx, PriorityBackground, "ResolveCommitAuthors", query, map[string]interface{}{
 			"owner": normalizedOwner,
 			"repo":  normalizedRepo,
 		})
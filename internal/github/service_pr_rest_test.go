@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -54,7 +55,7 @@ func TestGetPullRequestCommitsRESTPagination(t *testing.T) {
 		]`)
 	})
 
-	page, err := service.GetPullRequestCommits("acme", "orch", 42, 2, 50)
+	page, err := service.GetPullRequestCommits(context.Background(), "acme", "orch", 42, 2, 50)
 	if err != nil {
 		t.Fatalf("GetPullRequestCommits() error: %v", err)
 	}
@@ -105,11 +106,11 @@ func TestGetPullRequestCommitsRESTUsesReadThroughCacheByPage(t *testing.T) {
 		]`)
 	})
 
-	_, err := service.GetPullRequestCommits("acme", "orch", 42, 1, 25)
+	_, err := service.GetPullRequestCommits(context.Background(), "acme", "orch", 42, 1, 25)
 	if err != nil {
 		t.Fatalf("first GetPullRequestCommits() error: %v", err)
 	}
-	_, err = service.GetPullRequestCommits("acme", "orch", 42, 1, 25)
+	_, err = service.GetPullRequestCommits(context.Background(), "acme", "orch", 42, 1, 25)
 	if err != nil {
 		t.Fatalf("second GetPullRequestCommits() error: %v", err)
 	}
@@ -117,7 +118,7 @@ func TestGetPullRequestCommitsRESTUsesReadThroughCacheByPage(t *testing.T) {
 		t.Fatalf("expected cached commits response for same page, requests=%d", requestCount)
 	}
 
-	_, err = service.GetPullRequestCommits("acme", "orch", 42, 2, 25)
+	_, err = service.GetPullRequestCommits(context.Background(), "acme", "orch", 42, 2, 25)
 	if err != nil {
 		t.Fatalf("GetPullRequestCommits() page 2 error: %v", err)
 	}
@@ -153,7 +154,7 @@ func TestGetPullRequestCommitsRESTUsesETagConditionalRequestOnExpiredCache(t *te
 		w.WriteHeader(http.StatusNotModified)
 	})
 
-	first, err := service.GetPullRequestCommits("acme", "orch", 42, 1, 25)
+	first, err := service.GetPullRequestCommits(context.Background(), "acme", "orch", 42, 1, 25)
 	if err != nil {
 		t.Fatalf("first GetPullRequestCommits() error: %v", err)
 	}
@@ -166,7 +167,7 @@ func TestGetPullRequestCommitsRESTUsesETagConditionalRequestOnExpiredCache(t *te
 	service.cache.updatedAt[cacheKey] = time.Now().Add(-time.Minute)
 	service.cache.mu.Unlock()
 
-	second, err := service.GetPullRequestCommits("acme", "orch", 42, 1, 25)
+	second, err := service.GetPullRequestCommits(context.Background(), "acme", "orch", 42, 1, 25)
 	if err != nil {
 		t.Fatalf("second GetPullRequestCommits() error: %v", err)
 	}
@@ -232,7 +233,7 @@ func TestGetPullRequestFilesPatchStates(t *testing.T) {
 		]`)
 	})
 
-	page, err := service.GetPullRequestFiles("acme", "orch", 7, 1, 25)
+	page, err := service.GetPullRequestFiles(context.Background(), "acme", "orch", 7, 1, 25)
 	if err != nil {
 		t.Fatalf("GetPullRequestFiles() error: %v", err)
 	}
@@ -294,7 +295,7 @@ func TestGetPullRequestFilesCapsLargePatchPayload(t *testing.T) {
 		]`, encodedPatch)
 	})
 
-	page, err := service.GetPullRequestFiles("acme", "orch", 99, 1, 25)
+	page, err := service.GetPullRequestFiles(context.Background(), "acme", "orch", 99, 1, 25)
 	if err != nil {
 		t.Fatalf("GetPullRequestFiles() error: %v", err)
 	}
@@ -334,11 +335,11 @@ func TestGetPullRequestFilesRESTUsesReadThroughCacheByPage(t *testing.T) {
 		]`)
 	})
 
-	_, err := service.GetPullRequestFiles("acme", "orch", 7, 1, 25)
+	_, err := service.GetPullRequestFiles(context.Background(), "acme", "orch", 7, 1, 25)
 	if err != nil {
 		t.Fatalf("first GetPullRequestFiles() error: %v", err)
 	}
-	_, err = service.GetPullRequestFiles("acme", "orch", 7, 1, 25)
+	_, err = service.GetPullRequestFiles(context.Background(), "acme", "orch", 7, 1, 25)
 	if err != nil {
 		t.Fatalf("second GetPullRequestFiles() error: %v", err)
 	}
@@ -346,7 +347,7 @@ func TestGetPullRequestFilesRESTUsesReadThroughCacheByPage(t *testing.T) {
 		t.Fatalf("expected cached files response for same page, requests=%d", requestCount)
 	}
 
-	_, err = service.GetPullRequestFiles("acme", "orch", 7, 2, 25)
+	_, err = service.GetPullRequestFiles(context.Background(), "acme", "orch", 7, 2, 25)
 	if err != nil {
 		t.Fatalf("GetPullRequestFiles() page 2 error: %v", err)
 	}
@@ -382,7 +383,7 @@ func TestGetPullRequestFilesRESTUsesETagConditionalRequestOnExpiredCache(t *test
 		w.WriteHeader(http.StatusNotModified)
 	})
 
-	first, err := service.GetPullRequestFiles("acme", "orch", 7, 1, 25)
+	first, err := service.GetPullRequestFiles(context.Background(), "acme", "orch", 7, 1, 25)
 	if err != nil {
 		t.Fatalf("first GetPullRequestFiles() error: %v", err)
 	}
@@ -395,7 +396,7 @@ func TestGetPullRequestFilesRESTUsesETagConditionalRequestOnExpiredCache(t *test
 	service.cache.updatedAt[cacheKey] = time.Now().Add(-time.Minute)
 	service.cache.mu.Unlock()
 
-	second, err := service.GetPullRequestFiles("acme", "orch", 7, 1, 25)
+	second, err := service.GetPullRequestFiles(context.Background(), "acme", "orch", 7, 1, 25)
 	if err != nil {
 		t.Fatalf("second GetPullRequestFiles() error: %v", err)
 	}
@@ -425,7 +426,7 @@ func TestGetPullRequestRawDiffUsesDiffAcceptHeader(t *testing.T) {
 		_, _ = io.WriteString(w, "diff --git a/file.txt b/file.txt\nindex 111..222 100644\n--- a/file.txt\n+++ b/file.txt\n@@ -1 +1 @@\n-old\n+new\n")
 	})
 
-	rawDiff, err := service.GetPullRequestRawDiff("acme", "orch", 77)
+	rawDiff, err := service.GetPullRequestRawDiff(context.Background(), "acme", "orch", 77)
 	if err != nil {
 		t.Fatalf("GetPullRequestRawDiff() error: %v", err)
 	}
@@ -437,6 +438,72 @@ func TestGetPullRequestRawDiffUsesDiffAcceptHeader(t *testing.T) {
 	}
 }
 
+func TestGetPullRequestRawDiffAbortsOnCancel(t *testing.T) {
+	requestStarted := make(chan struct{})
+	service := NewService(func() (string, error) {
+		return "test-token", nil
+	})
+	service.retrySleep = func(time.Duration) { t.Fatalf("canceled request must not be retried") }
+	service.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		close(requestStarted)
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-requestStarted
+		cancel()
+	}()
+
+	_, err := service.GetPullRequestRawDiff(ctx, "acme", "orch", 77)
+	var githubErr *GitHubError
+	if !errors.As(err, &githubErr) || githubErr.Type != "canceled" {
+		t.Fatalf("expected canceled GitHubError, got %v", err)
+	}
+	if _, ok := service.cache.GetPRRawDiff("acme", "orch", 77); ok {
+		t.Fatalf("canceled request must not populate cache")
+	}
+}
+
+func TestGraphQLQueryAbortsOnCancel(t *testing.T) {
+	requestStarted := make(chan struct{})
+	service := NewService(func() (string, error) {
+		return "test-token", nil
+	})
+	service.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		close(requestStarted)
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-requestStarted
+		cancel()
+	}()
+
+	_, err := service.ListBranches(ctx, "acme", "orch")
+	var githubErr *GitHubError
+	if !errors.As(err, &githubErr) || githubErr.Type != "canceled" {
+		t.Fatalf("expected canceled GitHubError, got %v", err)
+	}
+}
+
+func TestSleepRetryDelayReturnsOnCancel(t *testing.T) {
+	service := NewService(func() (string, error) { return "test-token", nil })
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	startedAt := time.Now()
+	if err := service.sleepRetryDelay(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(startedAt); elapsed > 5*time.Second {
+		t.Fatalf("expected backoff to stop on cancel, waited %s", elapsed)
+	}
+}
+
 func TestGetPullRequestRawDiffUsesReadThroughCache(t *testing.T) {
 	requestCount := 0
 	service := newPRRESTTestService(t, func(w http.ResponseWriter, r *http.Request) {
@@ -444,11 +511,11 @@ func TestGetPullRequestRawDiffUsesReadThroughCache(t *testing.T) {
 		_, _ = io.WriteString(w, "diff --git a/file.txt b/file.txt\n@@ -1 +1 @@\n-old\n+new\n")
 	})
 
-	_, err := service.GetPullRequestRawDiff("acme", "orch", 77)
+	_, err := service.GetPullRequestRawDiff(context.Background(), "acme", "orch", 77)
 	if err != nil {
 		t.Fatalf("first GetPullRequestRawDiff() error: %v", err)
 	}
-	_, err = service.GetPullRequestRawDiff("acme", "orch", 77)
+	_, err = service.GetPullRequestRawDiff(context.Background(), "acme", "orch", 77)
 	if err != nil {
 		t.Fatalf("second GetPullRequestRawDiff() error: %v", err)
 	}
@@ -474,7 +541,7 @@ func TestGetPullRequestRawDiffUsesETagConditionalRequestOnExpiredCache(t *testin
 		w.WriteHeader(http.StatusNotModified)
 	})
 
-	first, err := service.GetPullRequestRawDiff("acme", "orch", 77)
+	first, err := service.GetPullRequestRawDiff(context.Background(), "acme", "orch", 77)
 	if err != nil {
 		t.Fatalf("first GetPullRequestRawDiff() error: %v", err)
 	}
@@ -487,7 +554,7 @@ func TestGetPullRequestRawDiffUsesETagConditionalRequestOnExpiredCache(t *testin
 	service.cache.updatedAt[cacheKey] = time.Now().Add(-time.Minute)
 	service.cache.mu.Unlock()
 
-	second, err := service.GetPullRequestRawDiff("acme", "orch", 77)
+	second, err := service.GetPullRequestRawDiff(context.Background(), "acme", "orch", 77)
 	if err != nil {
 		t.Fatalf("second GetPullRequestRawDiff() error: %v", err)
 	}
@@ -522,7 +589,7 @@ func TestCheckPullRequestMergedRESTInterprets204And404(t *testing.T) {
 		}
 	})
 
-	merged, err := service.CheckPullRequestMerged("acme", "orch", 88)
+	merged, err := service.CheckPullRequestMerged(context.Background(), "acme", "orch", 88)
 	if err != nil {
 		t.Fatalf("CheckPullRequestMerged() merged path error: %v", err)
 	}
@@ -530,7 +597,7 @@ func TestCheckPullRequestMergedRESTInterprets204And404(t *testing.T) {
 		t.Fatalf("expected merged=true for 204 response")
 	}
 
-	notMerged, err := service.CheckPullRequestMerged("acme", "orch", 89)
+	notMerged, err := service.CheckPullRequestMerged(context.Background(), "acme", "orch", 89)
 	if err != nil {
 		t.Fatalf("CheckPullRequestMerged() not merged path error: %v", err)
 	}
@@ -546,7 +613,7 @@ func TestCheckPullRequestMergedRESTUsesReadThroughCache(t *testing.T) {
 		w.WriteHeader(http.StatusNoContent)
 	})
 
-	merged, err := service.CheckPullRequestMerged("acme", "orch", 88)
+	merged, err := service.CheckPullRequestMerged(context.Background(), "acme", "orch", 88)
 	if err != nil {
 		t.Fatalf("first CheckPullRequestMerged() error: %v", err)
 	}
@@ -554,7 +621,7 @@ func TestCheckPullRequestMergedRESTUsesReadThroughCache(t *testing.T) {
 		t.Fatalf("expected merged=true on first request")
 	}
 
-	merged, err = service.CheckPullRequestMerged("acme", "orch", 88)
+	merged, err = service.CheckPullRequestMerged(context.Background(), "acme", "orch", 88)
 	if err != nil {
 		t.Fatalf("second CheckPullRequestMerged() error: %v", err)
 	}
@@ -583,7 +650,7 @@ func TestCheckPullRequestMergedRESTUsesETagConditionalRequestOnExpiredCache(t *t
 		w.WriteHeader(http.StatusNotModified)
 	})
 
-	merged, err := service.CheckPullRequestMerged("acme", "orch", 88)
+	merged, err := service.CheckPullRequestMerged(context.Background(), "acme", "orch", 88)
 	if err != nil {
 		t.Fatalf("first CheckPullRequestMerged() error: %v", err)
 	}
@@ -596,7 +663,7 @@ func TestCheckPullRequestMergedRESTUsesETagConditionalRequestOnExpiredCache(t *t
 	service.cache.updatedAt[cacheKey] = time.Now().Add(-time.Minute)
 	service.cache.mu.Unlock()
 
-	merged, err = service.CheckPullRequestMerged("acme", "orch", 88)
+	merged, err = service.CheckPullRequestMerged(context.Background(), "acme", "orch", 88)
 	if err != nil {
 		t.Fatalf("second CheckPullRequestMerged() error: %v", err)
 	}
@@ -642,7 +709,7 @@ func TestCreateLabelRESTSuccess(t *testing.T) {
 		_, _ = io.WriteString(w, `{"name":"P0","color":"d73a4a","description":"Prioridade critica"}`)
 	})
 
-	created, err := service.CreateLabel(CreateLabelInput{
+	created, err := service.CreateLabel(context.Background(), CreateLabelInput{
 		Owner:       "acme",
 		Repo:        "orch",
 		Name:        "P0",
@@ -688,7 +755,7 @@ func TestCreateLabelRESTValidation(t *testing.T) {
 		}
 	}
 
-	_, missingNameErr := service.CreateLabel(CreateLabelInput{
+	_, missingNameErr := service.CreateLabel(context.Background(), CreateLabelInput{
 		Owner: "acme",
 		Repo:  "orch",
 		Name:  "",
@@ -696,7 +763,7 @@ func TestCreateLabelRESTValidation(t *testing.T) {
 	})
 	assertValidationError(missingNameErr, "label name")
 
-	_, missingColorErr := service.CreateLabel(CreateLabelInput{
+	_, missingColorErr := service.CreateLabel(context.Background(), CreateLabelInput{
 		Owner: "acme",
 		Repo:  "orch",
 		Name:  "bug",
@@ -704,7 +771,7 @@ func TestCreateLabelRESTValidation(t *testing.T) {
 	})
 	assertValidationError(missingColorErr, "label color")
 
-	_, invalidColorErr := service.CreateLabel(CreateLabelInput{
+	_, invalidColorErr := service.CreateLabel(context.Background(), CreateLabelInput{
 		Owner: "acme",
 		Repo:  "orch",
 		Name:  "bug",
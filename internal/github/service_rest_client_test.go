@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/url"
@@ -53,6 +54,7 @@ func TestExecuteRESTRequestConditionalBuildsOfficialHeadersAndQuery(t *testing.T
 	query.Set("per_page", "50")
 
 	body, headers, statusCode, err := service.executeRESTRequestConditional(
+		context.Background(),
 		http.MethodGet,
 		"repos/acme/orch/pulls",
 		query,
@@ -84,6 +86,7 @@ func TestExecuteRESTRequestConditionalReturnsNotModifiedWithoutError(t *testing.
 	})
 
 	body, headers, statusCode, err := service.executeRESTRequestConditional(
+		context.Background(),
 		http.MethodGet,
 		"/repos/acme/orch/pulls/42",
 		nil,
@@ -189,6 +192,7 @@ func TestExecuteRESTRequestConditionalMapsHTTPErrors(t *testing.T) {
 			}
 
 			_, _, statusCode, err := service.executeRESTRequestConditional(
+				context.Background(),
 				http.MethodGet,
 				"/repos/acme/orch/pulls",
 				nil,
@@ -229,6 +233,7 @@ func TestExecuteRESTRequestConditionalPermissionErrorIncludesScopeHint(t *testin
 	})
 
 	_, _, statusCode, err := service.executeRESTRequestConditional(
+		context.Background(),
 		http.MethodPut,
 		"/repos/acme/orch/pulls/42/merge",
 		nil,
@@ -324,3 +329,105 @@ func TestParseNextPageFromLinkHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenRouterPicksAccountByOwner(t *testing.T) {
+	service := newPRRESTTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		want := "Bearer personal-token"
+		if strings.HasPrefix(r.URL.Path, "/repos/work-org/") {
+			want = "Bearer work-token"
+		}
+		if got := r.Header.Get("Authorization"); got != want {
+			t.Fatalf("unexpected token for %s: got=%q want=%q", r.URL.Path, got, want)
+		}
+		_, _ = io.WriteString(w, `[]`)
+	})
+	service.SetTokenRouter(func(owner string) (string, error) {
+		if strings.EqualFold(owner, "work-org") {
+			return "work-token", nil
+		}
+		return "personal-token", nil
+	})
+
+	for _, path := range []string{"/repos/work-org/api/pulls", "/repos/dev/dotfiles/pulls"} {
+		if _, _, err := service.executeRESTRequest(context.Background(), http.MethodGet, path, nil, "", nil); err != nil {
+			t.Fatalf("executeRESTRequest(%s) error: %v", path, err)
+		}
+	}
+	if got := restPathOwner("/repos/Work-Org/api/pulls"); got != "Work-Org" {
+		t.Fatalf("unexpected owner from path: %q", got)
+	}
+	if got := restPathOwner("/user/repos"); got != "" {
+		t.Fatalf("expected no owner for non-repo path, got %q", got)
+	}
+	if got := graphqlVariablesOwner(map[string]interface{}{"owner": " work-org "}); got != "work-org" {
+		t.Fatalf("unexpected owner from variables: %q", got)
+	}
+}
+
+func TestUnauthorizedRequestRetriesOnceAfterTokenRefresh(t *testing.T) {
+	currentToken := "stale-token"
+	var bodies []string
+	service := newPRRESTTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(payload))
+		if r.Header.Get("Authorization") != "Bearer fresh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"message":"Bad credentials"}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{}`)
+	})
+	service.SetTokenRouter(func(owner string) (string, error) { return currentToken, nil })
+	refreshes := 0
+	service.SetUnauthorizedRefresher(func(owner string) bool {
+		refreshes++
+		if owner != "acme" {
+			t.Fatalf("unexpected owner for refresh: %q", owner)
+		}
+		currentToken = "fresh-token"
+		return true
+	})
+
+	if _, _, err := service.executeRESTRequest(context.Background(), http.MethodPost, "/repos/acme/api/pulls", nil, "", strings.NewReader(`{"title":"x"}`)); err != nil {
+		t.Fatalf("executeRESTRequest() error: %v", err)
+	}
+	if refreshes != 1 || len(bodies) != 2 || bodies[1] != `{"title":"x"}` {
+		t.Fatalf("expected single retry with same body, refreshes=%d bodies=%q", refreshes, bodies)
+	}
+
+	// Token renovado que continua inválido não gera loop de refresh.
+	currentToken = "revoked-token"
+	service.SetUnauthorizedRefresher(func(owner string) bool {
+		refreshes++
+		return true
+	})
+	_, _, err := service.executeRESTRequest(context.Background(), http.MethodGet, "/repos/acme/api/pulls", nil, "", nil)
+	if githubErr, ok := err.(*GitHubError); !ok || githubErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 after failed retry, got %v", err)
+	}
+	if refreshes != 2 {
+		t.Fatalf("expected exactly one refresh per request, got %d", refreshes)
+	}
+}
+
+func TestGraphQLUnauthorizedRetriesAfterTokenRefresh(t *testing.T) {
+	token := "stale-token"
+	service := NewService(func() (string, error) { return token, nil })
+	service.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		status, body := http.StatusOK, `{"data":{"viewer":{"login":"dev"}}}`
+		if req.Header.Get("Authorization") != "Bearer fresh-token" {
+			status, body = http.StatusUnauthorized, `{"message":"Bad credentials"}`
+		}
+		return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}
+	service.SetUnauthorizedRefresher(func(owner string) bool {
+		token = "fresh-token"
+		return true
+	})
+
+	data, err := service.executeQuery(context.Background(), `query { viewer { login } }`, nil)
+	if err != nil || !strings.Contains(string(data), `"dev"`) {
+		t.Fatalf("expected query to succeed after refresh, data=%s err=%v", data, err)
+	}
+}
@@ -0,0 +1,136 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// === SAML SSO ===
+//
+// Orgs com SAML SSO respondem 403 (REST) ou erros FORBIDDEN (GraphQL) com o header
+// "X-GitHub-SSO: required; url=https://github.com/orgs/<org>/sso?authorization_request=...".
+// Listagens que omitem dados dessas orgs trazem "X-GitHub-SSO: partial-results; organizations=1,2".
+
+// SSOChallenge é uma org que exige autorizar o token via SAML SSO.
+type SSOChallenge struct {
+	Org        string    `json:"org"`
+	URL        string    `json:"url"`
+	DetectedAt time.Time `json:"detectedAt"`
+}
+
+// SSOPartialResultsEvent é emitido quando uma listagem omitiu dados de orgs sem autorização SSO.
+type SSOPartialResultsEvent struct {
+	OrganizationIDs []string `json:"organizationIds"`
+}
+
+// parseSSOHeader interpreta X-GitHub-SSO; retorna o tipo ("required" | "partial-results") e parâmetros.
+func parseSSOHeader(header string) (string, map[string]string) {
+	parts := strings.Split(header, ";")
+	kind := strings.ToLower(strings.TrimSpace(parts[0]))
+	params := make(map[string]string)
+	for _, part := range parts[1:] {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if found {
+			params[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+		}
+	}
+	return kind, params
+}
+
+// ssoOrgFromURL extrai a org de https://github.com/orgs/<org>/sso.
+func ssoOrgFromURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) >= 3 && segments[0] == "orgs" && segments[2] == "sso" {
+		return segments[1]
+	}
+	return ""
+}
+
+// ssoChallengeError retorna GitHubError tipo "sso" quando a resposta é um desafio SAML SSO.
+func ssoChallengeError(headers http.Header) *GitHubError {
+	if headers == nil {
+		return nil
+	}
+	kind, params := parseSSOHeader(headers.Get("X-GitHub-SSO"))
+	if kind != "required" || params["url"] == "" {
+		return nil
+	}
+	org := ssoOrgFromURL(params["url"])
+	target := org
+	if target == "" {
+		target = "this organization"
+	}
+	return &GitHubError{
+		StatusCode: http.StatusForbidden,
+		Message:    fmt.Sprintf("%s requires SAML SSO authorization for this token. Authorize at %s", target, params["url"]),
+		Type:       "sso",
+		SSOOrg:     org,
+		SSOURL:     params["url"],
+	}
+}
+
+// observeSSOHeader registra desafios SSO e avisa sobre resultados parciais.
+func (s *Service) observeSSOHeader(headers http.Header) {
+	if headers == nil || headers.Get("X-GitHub-SSO") == "" {
+		return
+	}
+	kind, params := parseSSOHeader(headers.Get("X-GitHub-SSO"))
+	switch kind {
+	case "required":
+		ssoErr := ssoChallengeError(headers)
+		if ssoErr == nil || ssoErr.SSOOrg == "" {
+			return
+		}
+		challenge := SSOChallenge{Org: ssoErr.SSOOrg, URL: ssoErr.SSOURL, DetectedAt: time.Now()}
+		s.ssoMu.Lock()
+		if s.ssoChallenges == nil {
+			s.ssoChallenges = make(map[string]SSOChallenge)
+		}
+		s.ssoChallenges[strings.ToLower(challenge.Org)] = challenge
+		s.ssoMu.Unlock()
+		s.emitTelemetry("github:sso:required", challenge)
+	case "partial-results":
+		var ids []string
+		for _, id := range strings.Split(params["organizations"], ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		s.emitTelemetry("github:sso:partial", SSOPartialResultsEvent{OrganizationIDs: ids})
+	}
+}
+
+// SSOAuthorizationURL retorna a URL de autorização SSO mais recente detectada para a org.
+func (s *Service) SSOAuthorizationURL(org string) (string, bool) {
+	s.ssoMu.Lock()
+	defer s.ssoMu.Unlock()
+	challenge, ok := s.ssoChallenges[strings.ToLower(strings.TrimSpace(org))]
+	return challenge.URL, ok
+}
+
+// PendingSSOChallenges lista orgs com desafio SSO ainda não reautorizado.
+func (s *Service) PendingSSOChallenges() []SSOChallenge {
+	s.ssoMu.Lock()
+	defer s.ssoMu.Unlock()
+	challenges := make([]SSOChallenge, 0, len(s.ssoChallenges))
+	for _, challenge := range s.ssoChallenges {
+		challenges = append(challenges, challenge)
+	}
+	sort.Slice(challenges, func(i, j int) bool { return challenges[i].Org < challenges[j].Org })
+	return challenges
+}
+
+// ClearSSOChallenge esquece o desafio da org (após abrir a reautorização).
+func (s *Service) ClearSSOChallenge(org string) {
+	s.ssoMu.Lock()
+	delete(s.ssoChallenges, strings.ToLower(strings.TrimSpace(org)))
+	s.ssoMu.Unlock()
+}
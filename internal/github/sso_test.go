@@ -0,0 +1,86 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRESTSSOChallengeReturnsTypedError(t *testing.T) {
+	const ssoURL = "https://github.com/orgs/acme/sso?authorization_request=abc"
+	service := newPRRESTTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-GitHub-SSO", "required; url="+ssoURL)
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `{"message":"Resource protected by organization SAML enforcement."}`)
+	})
+	var events []SSOChallenge
+	service.SetTelemetryEmitter(func(eventName string, payload interface{}) {
+		if eventName == "github:sso:required" {
+			events = append(events, payload.(SSOChallenge))
+		}
+	})
+
+	_, _, err := service.executeRESTRequest(context.Background(), http.MethodGet, "/repos/acme/api/pulls", nil, "", nil)
+	var githubErr *GitHubError
+	if !errors.As(err, &githubErr) || githubErr.Type != "sso" {
+		t.Fatalf("expected sso error, got %v", err)
+	}
+	if githubErr.SSOOrg != "acme" || githubErr.SSOURL != ssoURL {
+		t.Fatalf("unexpected sso error: %+v", githubErr)
+	}
+	if len(events) != 1 || events[0].Org != "acme" {
+		t.Fatalf("expected sso telemetry event, got %+v", events)
+	}
+	if got, ok := service.SSOAuthorizationURL("ACME"); !ok || got != ssoURL {
+		t.Fatalf("expected pending challenge, got %q %v", got, ok)
+	}
+
+	service.ClearSSOChallenge("acme")
+	if len(service.PendingSSOChallenges()) != 0 {
+		t.Fatalf("expected challenge cleared")
+	}
+}
+
+func TestGraphQLSSOChallengeReturnsTypedError(t *testing.T) {
+	service := NewService(func() (string, error) { return "gh-token", nil })
+	service.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			header := http.Header{}
+			header.Set("X-GitHub-SSO", "required; url=https://github.com/orgs/acme/sso?authorization_request=xyz")
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     header,
+				Body:       io.NopCloser(strings.NewReader(`{"data":null,"errors":[{"type":"FORBIDDEN","message":"Resource protected by organization SAML enforcement."}]}`)),
+			}, nil
+		}),
+	}
+
+	_, err := service.executeQuery(context.Background(), `query { viewer { login } }`, nil)
+	var githubErr *GitHubError
+	if !errors.As(err, &githubErr) || githubErr.Type != "sso" || githubErr.SSOOrg != "acme" {
+		t.Fatalf("expected sso error, got %v", err)
+	}
+}
+
+func TestSSOPartialResultsEmitsEvent(t *testing.T) {
+	service := newPRRESTTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-GitHub-SSO", "partial-results; organizations=21955855, 20582480")
+		_, _ = io.WriteString(w, `[]`)
+	})
+	var partial []SSOPartialResultsEvent
+	service.SetTelemetryEmitter(func(eventName string, payload interface{}) {
+		if eventName == "github:sso:partial" {
+			partial = append(partial, payload.(SSOPartialResultsEvent))
+		}
+	})
+
+	if _, _, err := service.executeRESTRequest(context.Background(), http.MethodGet, "/repos/acme/api/pulls", nil, "", nil); err != nil {
+		t.Fatalf("executeRESTRequest() error: %v", err)
+	}
+	if len(partial) != 1 || len(partial[0].OrganizationIDs) != 2 || partial[0].OrganizationIDs[1] != "20582480" {
+		t.Fatalf("unexpected partial results events: %+v", partial)
+	}
+}
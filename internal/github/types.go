@@ -1,6 +1,9 @@
 package github
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // === Core Types ===
 
@@ -307,6 +310,90 @@ type Issue struct {
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// IssueSearchFilter descreve um filtro de issues convertido em busca do GitHub
+type IssueSearchFilter struct {
+	Labels   []string `json:"labels"`   // todas precisam estar presentes
+	Assignee string   `json:"assignee"` // login, "@me" ou "none"
+	State    string   `json:"state"`    // "open", "closed" ou "all"
+	Query    string   `json:"query"`    // texto livre (aceita qualificadores do GitHub)
+}
+
+// IssueSearchPage é uma página de issues retornada pela busca
+type IssueSearchPage struct {
+	Items       []Issue `json:"items"`
+	Total       int     `json:"total"`
+	HasNextPage bool    `json:"hasNextPage"`
+	EndCursor   string  `json:"endCursor,omitempty"`
+}
+
+// LinkedIssue é uma issue que o PR fecha ao ser mergeado
+type LinkedIssue struct {
+	Number     int     `json:"number"`
+	Title      string  `json:"title"`
+	State      string  `json:"state"`
+	URL        string  `json:"url"`
+	Repository string  `json:"repository"` // owner/repo; pode diferir do repo do PR
+	Assignees  []User  `json:"assignees"`
+	Labels     []Label `json:"labels"`
+}
+
+// LinkedPullRequest é um PR que fecha uma issue
+type LinkedPullRequest struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"` // "OPEN", "CLOSED", "MERGED"
+	IsDraft    bool   `json:"isDraft"`
+	URL        string `json:"url"`
+	HeadBranch string `json:"headBranch"`
+	Repository string `json:"repository"`
+}
+
+// LinkedBranch é um branch criado/vinculado pela seção "Development" da issue
+type LinkedBranch struct {
+	Name       string `json:"name"`
+	Repository string `json:"repository"`
+}
+
+// IssueDevelopment resume o trabalho ligado a uma issue
+type IssueDevelopment struct {
+	Number       int                 `json:"number"`
+	PullRequests []LinkedPullRequest `json:"pullRequests"`
+	Branches     []LinkedBranch      `json:"branches"`
+}
+
+// DashboardPullRequest é um PR de qualquer repositório exibido no dashboard inicial
+type DashboardPullRequest struct {
+	Owner          string    `json:"owner"`
+	Repo           string    `json:"repo"`
+	Number         int       `json:"number"`
+	Title          string    `json:"title"`
+	URL            string    `json:"url"`
+	State          string    `json:"state"`
+	IsDraft        bool      `json:"isDraft"`
+	Author         User      `json:"author"`
+	HeadBranch     string    `json:"headBranch"`
+	BaseBranch     string    `json:"baseBranch"`
+	ReviewDecision string    `json:"reviewDecision,omitempty"` // "APPROVED", "CHANGES_REQUESTED", "REVIEW_REQUIRED"
+	ChecksState    string    `json:"checksState,omitempty"`    // statusCheckRollup do último commit
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// DashboardSection é uma lista do dashboard; Total vem da busca e pode passar do que foi carregado
+type DashboardSection struct {
+	Items     []DashboardPullRequest `json:"items"`
+	Total     int                    `json:"total"`
+	Truncated bool                   `json:"truncated"`
+}
+
+// PullRequestDashboard agrega os PRs abertos do usuário em todos os repositórios
+type PullRequestDashboard struct {
+	Authored        DashboardSection `json:"authored"`
+	ReviewRequested DashboardSection `json:"reviewRequested"`
+	FailingChecks   DashboardSection `json:"failingChecks"`
+	FetchedAt       time.Time        `json:"fetchedAt"`
+}
+
 // IssueFilters define os filtros para listagem de issues
 type IssueFilters struct {
 	State    string   `json:"state"`
@@ -353,6 +440,12 @@ type Branch struct {
 	Commit string `json:"commit"` // SHA do último commit
 }
 
+// PRTemplate é um template de descrição de PR publicado no repositório remoto
+type PRTemplate struct {
+	Filename string `json:"filename"`
+	Body     string `json:"body"`
+}
+
 // === Pagination ===
 
 // PageInfo contém informações de paginação GraphQL
@@ -368,7 +461,9 @@ type PageInfo struct {
 type GitHubError struct {
 	StatusCode int    `json:"statusCode"`
 	Message    string `json:"message"`
-	Type       string `json:"type"` // "auth", "ratelimit", "notfound", "permission", "conflict", "network"
+	Type       string `json:"type"`             // "auth", "ratelimit", "notfound", "permission", "conflict", "network", "sso"
+	SSOOrg     string `json:"ssoOrg,omitempty"` // org que exige SAML SSO (Type "sso")
+	SSOURL     string `json:"ssoUrl,omitempty"` // URL para autorizar o token na org
 }
 
 func (e *GitHubError) Error() string {
@@ -378,41 +473,66 @@ func (e *GitHubError) Error() string {
 // IGitHubService define a interface do serviço GitHub
 type IGitHubService interface {
 	// Repositórios
-	ListRepositories() ([]Repository, error)
+	ListRepositories(ctx context.Context) ([]Repository, error)
 
 	// Pull Requests
-	ListPullRequests(owner, repo string, filters PRFilters) ([]PullRequest, error)
-	GetPullRequest(owner, repo string, number int) (*PullRequest, error)
-	GetPullRequestDiff(owner, repo string, number int, pagination DiffPagination) (*Diff, error)
-	GetPullRequestCommits(owner, repo string, number int, page, perPage int) (*PRCommitPage, error)
-	GetPullRequestFiles(owner, repo string, number int, page, perPage int) (*PRFilePage, error)
-	GetPullRequestRawDiff(owner, repo string, number int) (string, error)
-	CheckPullRequestMerged(owner, repo string, number int) (bool, error)
-	CreatePullRequest(input CreatePRInput) (*PullRequest, error)
-	UpdatePullRequest(input UpdatePRInput) (*PullRequest, error)
-	MergePullRequestREST(input MergePRInput) (*PRMergeResult, error)
-	UpdatePullRequestBranch(input UpdatePRBranchInput) (*PRUpdateBranchResult, error)
-	MergePullRequest(owner, repo string, number int, method MergeMethod) error
-	ClosePullRequest(owner, repo string, number int) error
+	ListPullRequests(ctx context.Context, owner, repo string, filters PRFilters) ([]PullRequest, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, error)
+	GetPullRequestDiff(ctx context.Context, owner, repo string, number int, pagination DiffPagination) (*Diff, error)
+	GetPullRequestCommits(ctx context.Context, owner, repo string, number int, page, perPage int) (*PRCommitPage, error)
+	GetPullRequestFiles(ctx context.Context, owner, repo string, number int, page, perPage int) (*PRFilePage, error)
+	GetPullRequestRawDiff(ctx context.Context, owner, repo string, number int) (string, error)
+	CheckPullRequestMerged(ctx context.Context, owner, repo string, number int) (bool, error)
+	GetMergeRequirements(ctx context.Context, owner, repo string, number int) (*MergeRequirements, error)
+	CreatePullRequest(ctx context.Context, input CreatePRInput) (*PullRequest, error)
+	UpdatePullRequest(ctx context.Context, input UpdatePRInput) (*PullRequest, error)
+	MergePullRequestREST(ctx context.Context, input MergePRInput) (*PRMergeResult, error)
+	UpdatePullRequestBranch(ctx context.Context, input UpdatePRBranchInput) (*PRUpdateBranchResult, error)
+	MergePullRequest(ctx context.Context, owner, repo string, number int, method MergeMethod) error
+	ClosePullRequest(ctx context.Context, owner, repo string, number int) error
 
 	// Reviews & Comentários
-	ListReviews(owner, repo string, prNumber int) ([]Review, error)
-	CreateReview(input CreateReviewInput) (*Review, error)
-	ListComments(owner, repo string, prNumber int) ([]Comment, error)
-	CreateComment(input CreateCommentInput) (*Comment, error)
-	CreateInlineComment(input InlineCommentInput) (*Comment, error)
+	ListReviews(ctx context.Context, owner, repo string, prNumber int) ([]Review, error)
+	CreateReview(ctx context.Context, input CreateReviewInput) (*Review, error)
+	ListComments(ctx context.Context, owner, repo string, prNumber int) ([]Comment, error)
+	CreateComment(ctx context.Context, input CreateCommentInput) (*Comment, error)
+	CreateInlineComment(ctx context.Context, input InlineCommentInput) (*Comment, error)
 
 	// Issues
-	ListIssues(owner, repo string, filters IssueFilters) ([]Issue, error)
-	CreateIssue(input CreateIssueInput) (*Issue, error)
-	UpdateIssue(owner, repo string, number int, input UpdateIssueInput) error
-	CreateLabel(input CreateLabelInput) (*Label, error)
+	ListIssues(ctx context.Context, owner, repo string, filters IssueFilters) ([]Issue, error)
+	CreateIssue(ctx context.Context, input CreateIssueInput) (*Issue, error)
+	UpdateIssue(ctx context.Context, owner, repo string, number int, input UpdateIssueInput) error
+	GetLinkedIssues(ctx context.Context, owner, repo string, prNumber int) ([]LinkedIssue, error)
+	SearchIssues(ctx context.Context, owner, repo string, filter IssueSearchFilter, first int, after string) (*IssueSearchPage, error)
+	CountIssues(ctx context.Context, owner, repo string, filters []IssueSearchFilter) ([]int, error)
+	GetIssueDevelopment(ctx context.Context, owner, repo string, issueNumber int) (*IssueDevelopment, error)
+	CreateLabel(ctx context.Context, input CreateLabelInput) (*Label, error)
 
 	// Branches
-	ListBranches(owner, repo string) ([]Branch, error)
-	CreateBranch(owner, repo, name, sourceBranch string) (*Branch, error)
+	ListBranches(ctx context.Context, owner, repo string) ([]Branch, error)
+	CreateBranch(ctx context.Context, owner, repo, name, sourceBranch string) (*Branch, error)
+	ListProtectedBranches(ctx context.Context, owner, repo string) ([]Branch, error)
+
+	// Releases
+	UpsertReleaseDraft(ctx context.Context, input ReleaseDraftInput) (*Release, error)
+
+	// Checks & Workflows
+	RerunCheckRun(ctx context.Context, owner, repo string, checkRunID int64) error
+	RerunFailedJobs(ctx context.Context, owner, repo string, runID int64) error
+	DispatchWorkflow(ctx context.Context, input WorkflowDispatchInput) error
+
+	// Code owners
+	GetCodeOwnersFile(ctx context.Context, owner, repo, ref string) (*CodeOwnersFile, error)
+	RequestReviewers(ctx context.Context, owner, repo string, number int, reviewers, teamReviewers []string) error
+
+	// Templates
+	ListPullRequestTemplates(ctx context.Context, owner, repo string) ([]PRTemplate, error)
+
+	// Dashboard
+	GetMyPullRequestDashboard(ctx context.Context) (*PullRequestDashboard, error)
+	SearchPullRequests(ctx context.Context, query string, maxItems int) (DashboardSection, error)
 
 	// Cache & Polling
 	InvalidateCache(owner, repo string)
-	ResolveCommitAuthors(owner, repo string, hashes []string) (map[string]User, error)
+	ResolveCommitAuthors(ctx context.Context, owner, repo string, hashes []string) (map[string]User, error)
 }
@@ -0,0 +1,56 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// UserIdentity é o mínimo de um usuário do GitHub para montar o e-mail noreply de commits.
+type UserIdentity struct {
+	ID           int64  `json:"id"`
+	Login        string `json:"login"`
+	Name         string `json:"name,omitempty"`
+	NoReplyEmail string `json:"noreplyEmail"`
+}
+
+type restUserIdentity struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+// GetUserIdentity consulta GET /users/{login} e devolve o e-mail
+// <id>+<login>@users.noreply.github.com, que o GitHub associa à conta mesmo com o e-mail privado.
+func (s *Service) GetUserIdentity(ctx context.Context, login string) (*UserIdentity, error) {
+	normalizedLogin := strings.TrimSpace(login)
+	if normalizedLogin == "" || !gitHubOwnerRegex.MatchString(normalizedLogin) {
+		return nil, &GitHubError{
+			StatusCode: 422,
+			Message:    "invalid GitHub login",
+			Type:       "validation",
+		}
+	}
+
+	var response restUserIdentity
+	if err := s.getRESTJSON(ctx, fmt.Sprintf("/users/%s", url.PathEscape(normalizedLogin)), nil, &response); err != nil {
+		return nil, err
+	}
+	if response.ID <= 0 || strings.TrimSpace(response.Login) == "" {
+		return nil, &GitHubError{
+			StatusCode: 502,
+			Message:    "GitHub user response without id/login",
+			Type:       "unknown",
+		}
+	}
+
+	resolvedLogin := strings.TrimSpace(response.Login)
+	return &UserIdentity{
+		ID:           response.ID,
+		Login:        resolvedLogin,
+		Name:         strings.TrimSpace(response.Name),
+		NoReplyEmail: strconv.FormatInt(response.ID, 10) + "+" + resolvedLogin + "@users.noreply.github.com",
+	}, nil
+}
@@ -0,0 +1,27 @@
+package github
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetUserIdentityBuildsNoReplyEmail(t *testing.T) {
+	service := newForkInfoTestService(t, map[string]string{
+		"/users/ana-dev": `{"id":583231,"login":"Ana-Dev","name":"Ana Souza"}`,
+		"/users/broken":  `{"login":"broken"}`,
+	})
+
+	identity, err := service.GetUserIdentity(context.Background(), " ana-dev ")
+	if err != nil {
+		t.Fatalf("GetUserIdentity() error: %v", err)
+	}
+	if identity.ID != 583231 || identity.Login != "Ana-Dev" || identity.NoReplyEmail != "583231+Ana-Dev@users.noreply.github.com" {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+
+	for _, login := range []string{"", "../users", "ghost", "broken"} {
+		if _, err := service.GetUserIdentity(context.Background(), login); err == nil {
+			t.Fatalf("expected GetUserIdentity(%q) to fail", login)
+		}
+	}
+}
@@ -0,0 +1,413 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"orch/internal/httpclient"
+)
+
+// === Webhook Bridge ===
+//
+// O bridge consome um relay SSE (ex.: smee.io ou gosmee) apontado por um webhook de repositório
+// ou GitHub App. Cada mensagem traz os headers do webhook e o corpo original; com o bridge
+// conectado o Poller vira rede de segurança e recua o intervalo.
+
+const (
+	webhookMaxMessageBytes  = 10 << 20
+	webhookReconnectInitial = time.Second
+	webhookReconnectMax     = time.Minute
+)
+
+// WebhookEvent representa um evento de webhook do GitHub recebido pelo relay.
+type WebhookEvent struct {
+	Event         string    `json:"event"` // valor de X-GitHub-Event
+	Action        string    `json:"action,omitempty"`
+	DeliveryID    string    `json:"deliveryId,omitempty"`
+	Owner         string    `json:"owner"`
+	Repo          string    `json:"repo"`
+	Number        int       `json:"number,omitempty"` // PR/issue afetado, quando houver
+	Title         string    `json:"title,omitempty"`
+	IsPullRequest bool      `json:"isPullRequest"`
+	ReceivedAt    time.Time `json:"receivedAt"`
+}
+
+// WebhookBridgeStatus é o estado do bridge exposto ao frontend.
+type WebhookBridgeStatus struct {
+	Enabled        bool       `json:"enabled"`
+	Connected      bool       `json:"connected"`
+	RelayURL       string     `json:"relayUrl,omitempty"`
+	HasSecret      bool       `json:"hasSecret"`
+	EventsReceived int        `json:"eventsReceived"`
+	LastEventAt    *time.Time `json:"lastEventAt,omitempty"`
+	LastError      string     `json:"lastError,omitempty"`
+}
+
+// WebhookBridge mantém a conexão SSE com o relay e reconecta com backoff.
+type WebhookBridge struct {
+	mu     sync.Mutex
+	client *http.Client
+	secret string
+	cancel context.CancelFunc
+	status WebhookBridgeStatus
+
+	onEvent  func(WebhookEvent)
+	onStatus func(WebhookBridgeStatus)
+}
+
+// NewWebhookBridge cria um bridge desabilitado; use Configure para conectar.
+func NewWebhookBridge(onEvent func(WebhookEvent), onStatus func(WebhookBridgeStatus)) *WebhookBridge {
+	return &WebhookBridge{
+		client:   httpclient.Client(httpclient.ServiceGitHub),
+		onEvent:  onEvent,
+		onStatus: onStatus,
+	}
+}
+
+// ValidateWebhookRelayURL aceita https ou http apenas em loopback (relay local).
+func ValidateWebhookRelayURL(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", nil
+	}
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" {
+		return "", fmt.Errorf("invalid relay URL: %s", trimmed)
+	}
+	switch parsed.Scheme {
+	case "https":
+	case "http":
+		host := parsed.Hostname()
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return "", fmt.Errorf("relay URL must use https outside localhost: %s", trimmed)
+		}
+	default:
+		return "", fmt.Errorf("unsupported relay URL scheme: %s", parsed.Scheme)
+	}
+	return parsed.String(), nil
+}
+
+// Configure (re)conecta ao relay; relayURL vazio desliga o bridge.
+func (b *WebhookBridge) Configure(relayURL, secret string) error {
+	normalized, err := ValidateWebhookRelayURL(relayURL)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	if b.cancel != nil {
+		b.cancel()
+		b.cancel = nil
+	}
+	b.secret = strings.TrimSpace(secret)
+	b.status = WebhookBridgeStatus{
+		Enabled:   normalized != "",
+		RelayURL:  normalized,
+		HasSecret: b.secret != "",
+	}
+	if normalized != "" {
+		ctx, cancel := context.WithCancel(context.Background())
+		b.cancel = cancel
+		go b.run(ctx, normalized)
+	}
+	status := b.status
+	b.mu.Unlock()
+
+	b.notifyStatus(status)
+	return nil
+}
+
+// Stop desconecta o bridge mantendo a configuração.
+func (b *WebhookBridge) Stop() {
+	b.mu.Lock()
+	if b.cancel != nil {
+		b.cancel()
+		b.cancel = nil
+	}
+	b.status.Connected = false
+	b.mu.Unlock()
+}
+
+// GetStatus retorna o estado atual do bridge.
+func (b *WebhookBridge) GetStatus() WebhookBridgeStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.status
+}
+
+func (b *WebhookBridge) run(ctx context.Context, relayURL string) {
+	backoff := webhookReconnectInitial
+	for {
+		connected, err := b.consume(ctx, relayURL)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = webhookReconnectInitial
+		}
+		message := "relay connection closed"
+		if err != nil {
+			message = err.Error()
+		}
+		log.Printf("[WebhookBridge] Disconnected from relay: %s (retry in %s)", message, backoff)
+		b.updateStatus(ctx, func(status *WebhookBridgeStatus) {
+			status.Connected = false
+			status.LastError = message
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > webhookReconnectMax {
+			backoff = webhookReconnectMax
+		}
+	}
+}
+
+// consume lê o stream SSE até erro ou cancelamento; retorna se chegou a conectar.
+func (b *WebhookBridge) consume(ctx context.Context, relayURL string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, relayURL, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("relay returned HTTP %d", resp.StatusCode)
+	}
+
+	log.Printf("[WebhookBridge] Connected to relay %s", relayURL)
+	b.updateStatus(ctx, func(status *WebhookBridgeStatus) {
+		status.Connected = true
+		status.LastError = ""
+	})
+
+	err = readServerSentEvents(resp.Body, func(eventType string, data []byte) {
+		b.handleMessage(ctx, eventType, data)
+	})
+	return true, err
+}
+
+func (b *WebhookBridge) handleMessage(ctx context.Context, eventType string, data []byte) {
+	// smee/gosmee enviam "ready" e "ping" como keepalive.
+	if eventType != "" && eventType != "message" {
+		return
+	}
+
+	b.mu.Lock()
+	secret := b.secret
+	b.mu.Unlock()
+
+	event, err := parseWebhookRelayMessage(data, secret, time.Now())
+	if err != nil {
+		log.Printf("[WebhookBridge] Dropped relay message: %v", err)
+		b.updateStatus(ctx, func(status *WebhookBridgeStatus) {
+			status.LastError = err.Error()
+		})
+		return
+	}
+	if event.Event == "" || event.Event == "ping" {
+		return
+	}
+
+	receivedAt := event.ReceivedAt
+	b.updateStatus(ctx, func(status *WebhookBridgeStatus) {
+		status.EventsReceived++
+		status.LastEventAt = &receivedAt
+	})
+	if b.onEvent != nil {
+		b.onEvent(event)
+	}
+}
+
+// updateStatus aplica a mudança só se a conexão ainda é a atual (ctx não cancelado por Configure).
+func (b *WebhookBridge) updateStatus(ctx context.Context, apply func(*WebhookBridgeStatus)) {
+	b.mu.Lock()
+	if ctx.Err() != nil {
+		b.mu.Unlock()
+		return
+	}
+	apply(&b.status)
+	status := b.status
+	b.mu.Unlock()
+
+	b.notifyStatus(status)
+}
+
+func (b *WebhookBridge) notifyStatus(status WebhookBridgeStatus) {
+	if b.onStatus != nil {
+		b.onStatus(status)
+	}
+}
+
+// readServerSentEvents despacha cada evento SSE (linhas "event:"/"data:" terminadas por linha vazia).
+func readServerSentEvents(body io.Reader, dispatch func(eventType string, data []byte)) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), webhookMaxMessageBytes)
+
+	eventType := ""
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			if data.Len() > 0 {
+				dispatch(eventType, data.Bytes())
+			}
+			eventType = ""
+			data.Reset()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return errors.New("relay stream ended")
+}
+
+// parseWebhookRelayMessage extrai o evento de uma mensagem do relay no formato do smee
+// (headers do webhook em minúsculas no topo ou em "headers", payload em "body").
+func parseWebhookRelayMessage(data []byte, secret string, receivedAt time.Time) (WebhookEvent, error) {
+	var message map[string]json.RawMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		return WebhookEvent{}, fmt.Errorf("invalid relay message: %w", err)
+	}
+	var nestedHeaders map[string]string
+	if raw, ok := message["headers"]; ok {
+		_ = json.Unmarshal(raw, &nestedHeaders)
+	}
+	header := func(name string) string {
+		if raw, ok := message[name]; ok {
+			var value string
+			if json.Unmarshal(raw, &value) == nil {
+				return value
+			}
+		}
+		for key, value := range nestedHeaders {
+			if strings.EqualFold(key, name) {
+				return value
+			}
+		}
+		return ""
+	}
+
+	body := message["body"]
+	if secret != "" {
+		if !validWebhookSignature(body, header("x-hub-signature-256"), secret) {
+			return WebhookEvent{}, errors.New("invalid webhook signature")
+		}
+	}
+
+	event := WebhookEvent{
+		Event:      header("x-github-event"),
+		DeliveryID: header("x-github-delivery"),
+		ReceivedAt: receivedAt,
+	}
+	if len(body) == 0 {
+		return event, nil
+	}
+
+	var payload struct {
+		Action     string `json:"action"`
+		Repository struct {
+			Name  string `json:"name"`
+			Owner struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+		} `json:"repository"`
+		PullRequest *struct {
+			Number int    `json:"number"`
+			Title  string `json:"title"`
+		} `json:"pull_request"`
+		Issue *struct {
+			Number      int             `json:"number"`
+			Title       string          `json:"title"`
+			PullRequest json.RawMessage `json:"pull_request"`
+		} `json:"issue"`
+		CheckRun *struct {
+			PullRequests []struct {
+				Number int `json:"number"`
+			} `json:"pull_requests"`
+		} `json:"check_run"`
+		CheckSuite *struct {
+			PullRequests []struct {
+				Number int `json:"number"`
+			} `json:"pull_requests"`
+		} `json:"check_suite"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return WebhookEvent{}, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	event.Action = payload.Action
+	event.Owner = payload.Repository.Owner.Login
+	event.Repo = payload.Repository.Name
+	switch {
+	case payload.PullRequest != nil:
+		event.Number = payload.PullRequest.Number
+		event.Title = payload.PullRequest.Title
+		event.IsPullRequest = true
+	case payload.Issue != nil:
+		event.Number = payload.Issue.Number
+		event.Title = payload.Issue.Title
+		event.IsPullRequest = len(payload.Issue.PullRequest) > 0 && string(payload.Issue.PullRequest) != "null"
+	case payload.CheckRun != nil && len(payload.CheckRun.PullRequests) > 0:
+		event.Number = payload.CheckRun.PullRequests[0].Number
+		event.IsPullRequest = true
+	case payload.CheckSuite != nil && len(payload.CheckSuite.PullRequests) > 0:
+		event.Number = payload.CheckSuite.PullRequests[0].Number
+		event.IsPullRequest = true
+	}
+	return event, nil
+}
+
+// validWebhookSignature confere X-Hub-Signature-256; exige relay que preserve o corpo original.
+func validWebhookSignature(body []byte, signature, secret string) bool {
+	expected, ok := strings.CutPrefix(strings.TrimSpace(signature), "sha256=")
+	if !ok {
+		return false
+	}
+	decoded, err := hex.DecodeString(expected)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(decoded, mac.Sum(nil))
+}
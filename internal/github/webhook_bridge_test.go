@@ -0,0 +1,128 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func signWebhookBody(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseWebhookRelayMessage(t *testing.T) {
+	body := `{"action":"created","issue":{"number":42,"title":"Fix poller","pull_request":{"url":"x"}},"repository":{"name":"pando","owner":{"login":"peruccii"}}}`
+	message := fmt.Sprintf(`{"x-github-event":"issue_comment","x-github-delivery":"abc","x-hub-signature-256":%q,"body":%s}`, signWebhookBody("s3cret", body), body)
+
+	event, err := parseWebhookRelayMessage([]byte(message), "s3cret", time.Unix(100, 0))
+	if err != nil {
+		t.Fatalf("parseWebhookRelayMessage() error: %v", err)
+	}
+	if event.Event != "issue_comment" || event.Action != "created" || event.Owner != "peruccii" || event.Repo != "pando" ||
+		event.Number != 42 || !event.IsPullRequest || event.DeliveryID != "abc" {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	if _, err := parseWebhookRelayMessage([]byte(message), "other", time.Now()); err == nil {
+		t.Fatalf("expected signature mismatch to be rejected")
+	}
+
+	nested := `{"headers":{"X-GitHub-Event":"check_run"},"body":{"check_run":{"pull_requests":[{"number":7}]},"repository":{"name":"pando","owner":{"login":"peruccii"}}}}`
+	event, err = parseWebhookRelayMessage([]byte(nested), "", time.Now())
+	if err != nil || event.Event != "check_run" || event.Number != 7 || !event.IsPullRequest {
+		t.Fatalf("unexpected nested-header event: %+v err=%v", event, err)
+	}
+}
+
+func TestValidateWebhookRelayURL(t *testing.T) {
+	for _, valid := range []string{"", "https://smee.io/abc", "http://localhost:3000/events", "http://127.0.0.1:9000/"} {
+		if _, err := ValidateWebhookRelayURL(valid); err != nil {
+			t.Fatalf("expected %q to be valid: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"http://relay.example.com/x", "ftp://smee.io/abc", "smee.io/abc"} {
+		if _, err := ValidateWebhookRelayURL(invalid); err == nil {
+			t.Fatalf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestWebhookBridgeDeliversRelayEvents(t *testing.T) {
+	body := `{"action":"opened","pull_request":{"number":3,"title":"New"},"repository":{"name":"pando","owner":{"login":"peruccii"}}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("unexpected Accept header: %q", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: ready\ndata: {}\n\n")
+		fmt.Fprintf(w, ": keepalive\nevent: message\ndata: {\"x-github-event\":\"pull_request\",\"body\":%s}\n\n", body)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	events := make(chan WebhookEvent, 1)
+	bridge := NewWebhookBridge(func(event WebhookEvent) { events <- event }, nil)
+	if err := bridge.Configure(server.URL, ""); err != nil {
+		t.Fatalf("Configure() error: %v", err)
+	}
+	defer bridge.Stop()
+
+	select {
+	case event := <-events:
+		if event.Event != "pull_request" || event.Number != 3 || event.Action != "opened" {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for relay event")
+	}
+
+	status := bridge.GetStatus()
+	if !status.Enabled || !status.Connected || status.EventsReceived != 1 || status.LastEventAt == nil {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestPollerBacksOffInPushModeAndEmitsWebhookEvents(t *testing.T) {
+	service := NewService(func() (string, error) { return "token", nil })
+	emitted := make(map[string]interface{})
+	poller := NewPoller(service, func(eventName string, data interface{}) { emitted[eventName] = data })
+	poller.SetContext(PollingContextPRList)
+
+	if got := poller.calculateInterval(); got != 30*time.Second {
+		t.Fatalf("unexpected base interval: %s", got)
+	}
+	poller.SetPushMode(true)
+	if got := poller.calculateInterval(); got != pushModeMinInterval {
+		t.Fatalf("expected push-mode floor of %s, got %s", pushModeMinInterval, got)
+	}
+	poller.SetContext(PollingContextMinimized)
+	if got := poller.calculateInterval(); got != 300*time.Second*pushModeBackoffFactor {
+		t.Fatalf("expected backed-off interval, got %s", got)
+	}
+
+	service.cache.SetBranches("peruccii", "pando", []Branch{{Name: "main"}})
+	poller.HandleWebhookEvent(WebhookEvent{Event: "pull_request", Action: "opened", Owner: "peruccii", Repo: "pando", Number: 3})
+	if _, ok := service.cache.GetBranches("peruccii", "pando"); ok {
+		t.Fatalf("expected webhook event to invalidate repository cache")
+	}
+	payload, ok := emitted["github:prs:updated"].(map[string]interface{})
+	if !ok || payload["source"] != "webhook" {
+		t.Fatalf("expected github:prs:updated from webhook, got %+v", emitted)
+	}
+	if changes := payload["changes"].([]PRChange); len(changes) != 1 || changes[0].ChangeType != "new" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+
+	poller.HandleWebhookEvent(WebhookEvent{Event: "issues", Action: "closed", Owner: "peruccii", Repo: "pando", Number: 9})
+	if _, ok := emitted["github:issues:updated"]; !ok {
+		t.Fatalf("expected github:issues:updated, got %+v", emitted)
+	}
+}
@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	workflowActionRerunCheckRun = "check_run_rerequest"
+	workflowActionRerunFailed   = "workflow_run_rerun_failed_jobs"
+	workflowActionDispatch      = "workflow_dispatch"
+	maxWorkflowDispatchInputs   = 25 // limite do GitHub para inputs de workflow_dispatch
+	workflowsDirPrefix          = ".github/workflows/"
+)
+
+// Check runs do Actions apontam para .../actions/runs/{runID}/job/{jobID}.
+var actionsRunURLRegex = regexp.MustCompile(`/actions/runs/(\d+)`)
+
+// WorkflowDispatchInput dispara um workflow com gatilho workflow_dispatch.
+type WorkflowDispatchInput struct {
+	Owner    string            `json:"owner"`
+	Repo     string            `json:"repo"`
+	Workflow string            `json:"workflow"` // arquivo (deploy.yml, .github/workflows/deploy.yml) ou ID numérico
+	Ref      string            `json:"ref"`      // branch ou tag onde o workflow roda
+	Inputs   map[string]string `json:"inputs,omitempty"`
+}
+
+// RerunCheckRun pede ao app dono do check run para executá-lo de novo (check-runs/{id}/rerequest).
+func (s *Service) RerunCheckRun(ctx context.Context, owner, repo string, checkRunID int64) error {
+	normalizedOwner, normalizedRepo, err := normalizeOwnerRepoForPR(owner, repo)
+	if err != nil {
+		return err
+	}
+	if checkRunID <= 0 {
+		return workflowValidationError("check run id is required")
+	}
+	endpoint := fmt.Sprintf("/repos/%s/%s/check-runs/%d/rerequest", url.PathEscape(normalizedOwner), url.PathEscape(normalizedRepo), checkRunID)
+	if err := s.executePRRESTJSON(ctx, workflowActionRerunCheckRun, http.MethodPost, endpoint, nil, nil, nil); err != nil {
+		return err
+	}
+	log.Printf("[GitHub] Re-requested check run %d on %s/%s", checkRunID, normalizedOwner, normalizedRepo)
+	return nil
+}
+
+// RerunFailedJobs executa de novo só os jobs que falharam numa execução do GitHub Actions.
+func (s *Service) RerunFailedJobs(ctx context.Context, owner, repo string, runID int64) error {
+	normalizedOwner, normalizedRepo, err := normalizeOwnerRepoForPR(owner, repo)
+	if err != nil {
+		return err
+	}
+	if runID <= 0 {
+		return workflowValidationError("workflow run id is required")
+	}
+	endpoint := fmt.Sprintf("/repos/%s/%s/actions/runs/%d/rerun-failed-jobs", url.PathEscape(normalizedOwner), url.PathEscape(normalizedRepo), runID)
+	if err := s.executePRRESTJSON(ctx, workflowActionRerunFailed, http.MethodPost, endpoint, nil, nil, nil); err != nil {
+		return err
+	}
+	log.Printf("[GitHub] Re-running failed jobs of run %d on %s/%s", runID, normalizedOwner, normalizedRepo)
+	return nil
+}
+
+// DispatchWorkflow dispara o workflow na ref informada (ex.: deploy a partir da tela de checks do PR).
+func (s *Service) DispatchWorkflow(ctx context.Context, input WorkflowDispatchInput) error {
+	normalizedOwner, normalizedRepo, err := normalizeOwnerRepoForPR(input.Owner, input.Repo)
+	if err != nil {
+		return err
+	}
+	workflow, err := normalizeWorkflowID(input.Workflow)
+	if err != nil {
+		return err
+	}
+	ref := strings.TrimSpace(input.Ref)
+	if ref == "" {
+		return workflowValidationError("workflow ref is required")
+	}
+	if len(input.Inputs) > maxWorkflowDispatchInputs {
+		return workflowValidationError(fmt.Sprintf("workflow dispatch accepts at most %d inputs", maxWorkflowDispatchInputs))
+	}
+
+	payload := map[string]interface{}{"ref": ref}
+	if len(input.Inputs) > 0 {
+		inputs := make(map[string]string, len(input.Inputs))
+		for key, value := range input.Inputs {
+			if key = strings.TrimSpace(key); key == "" {
+				return workflowValidationError("workflow input name is required")
+			}
+			inputs[key] = value
+		}
+		payload["inputs"] = inputs
+	}
+	endpoint := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s/dispatches", url.PathEscape(normalizedOwner), url.PathEscape(normalizedRepo), url.PathEscape(workflow))
+	if err := s.executePRRESTJSON(ctx, workflowActionDispatch, http.MethodPost, endpoint, nil, payload, nil); err != nil {
+		return err
+	}
+	log.Printf("[GitHub] Dispatched workflow %s on %s/%s@%s", workflow, normalizedOwner, normalizedRepo, ref)
+	return nil
+}
+
+// normalizeWorkflowID aceita o ID numérico ou o arquivo do workflow, com ou sem .github/workflows/.
+func normalizeWorkflowID(raw string) (string, error) {
+	workflow := strings.TrimPrefix(strings.TrimSpace(raw), "./")
+	workflow = strings.TrimPrefix(workflow, workflowsDirPrefix)
+	if workflow == "" {
+		return "", workflowValidationError("workflow file is required")
+	}
+	if _, err := strconv.ParseInt(workflow, 10, 64); err == nil {
+		return workflow, nil
+	}
+	ext := path.Ext(workflow)
+	if strings.ContainsAny(workflow, `/\`) || (ext != ".yml" && ext != ".yaml") {
+		return "", workflowValidationError(fmt.Sprintf("invalid workflow file %q: expected a .yml/.yaml file in %s", raw, workflowsDirPrefix))
+	}
+	return workflow, nil
+}
+
+// workflowRunIDFromURL extrai o ID da execução do Actions do link de um check run (0 se não for do Actions).
+func workflowRunIDFromURL(detailsURL string) int64 {
+	match := actionsRunURLRegex.FindStringSubmatch(detailsURL)
+	if match == nil {
+		return 0
+	}
+	runID, _ := strconv.ParseInt(match[1], 10, 64)
+	return runID
+}
+
+func workflowValidationError(message string) error {
+	return &GitHubError{StatusCode: http.StatusUnprocessableEntity, Message: message, Type: "validation"}
+}
@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newWorkflowTestService(t *testing.T, status int, requests *[]string, payloads *[]map[string]interface{}) *Service {
+	t.Helper()
+	service := NewService(func() (string, error) {
+		return "gh-token", nil
+	})
+	service.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			*requests = append(*requests, req.Method+" "+req.URL.EscapedPath())
+			var payload map[string]interface{}
+			if req.Body != nil {
+				raw, _ := io.ReadAll(req.Body)
+				_ = json.Unmarshal(raw, &payload)
+			}
+			*payloads = append(*payloads, payload)
+			body := ""
+			if status >= 400 {
+				body = `{"message":"Workflow does not have 'workflow_dispatch' trigger"}`
+			}
+			return &http.Response{
+				StatusCode: status,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}
+	return service
+}
+
+func TestRerunCheckRunsAndFailedJobs(t *testing.T) {
+	var requests []string
+	var payloads []map[string]interface{}
+	service := newWorkflowTestService(t, http.StatusCreated, &requests, &payloads)
+
+	if err := service.RerunCheckRun(context.Background(), "orch-labs", "orch", 901); err != nil {
+		t.Fatalf("RerunCheckRun() error: %v", err)
+	}
+	if err := service.RerunFailedJobs(context.Background(), "orch-labs", "orch", 5501); err != nil {
+		t.Fatalf("RerunFailedJobs() error: %v", err)
+	}
+	want := "POST /repos/orch-labs/orch/check-runs/901/rerequest,POST /repos/orch-labs/orch/actions/runs/5501/rerun-failed-jobs"
+	if got := strings.Join(requests, ","); got != want {
+		t.Fatalf("unexpected requests: %s", got)
+	}
+
+	requests = nil
+	if err := service.RerunCheckRun(context.Background(), "orch-labs", "orch", 0); err == nil {
+		t.Fatalf("expected missing check run id to be rejected")
+	}
+	if err := service.RerunFailedJobs(context.Background(), "", "orch", 10); err == nil {
+		t.Fatalf("expected missing owner to be rejected")
+	}
+	if len(requests) != 0 {
+		t.Fatalf("invalid input should not reach GitHub, got %v", requests)
+	}
+}
+
+func TestDispatchWorkflow(t *testing.T) {
+	var requests []string
+	var payloads []map[string]interface{}
+	service := newWorkflowTestService(t, http.StatusNoContent, &requests, &payloads)
+
+	err := service.DispatchWorkflow(context.Background(), WorkflowDispatchInput{
+		Owner:    "orch-labs",
+		Repo:     "orch",
+		Workflow: ".github/workflows/deploy.yml",
+		Ref:      "feature/login",
+		Inputs:   map[string]string{"environment": "staging"},
+	})
+	if err != nil {
+		t.Fatalf("DispatchWorkflow() error: %v", err)
+	}
+	if got := strings.Join(requests, ","); got != "POST /repos/orch-labs/orch/actions/workflows/deploy.yml/dispatches" {
+		t.Fatalf("unexpected requests: %s", got)
+	}
+	inputs, _ := payloads[0]["inputs"].(map[string]interface{})
+	if payloads[0]["ref"] != "feature/login" || inputs["environment"] != "staging" {
+		t.Fatalf("unexpected payload: %v", payloads[0])
+	}
+
+	requests = nil
+	for _, invalid := range []WorkflowDispatchInput{
+		{Owner: "orch-labs", Repo: "orch", Workflow: "deploy.yml"},
+		{Owner: "orch-labs", Repo: "orch", Workflow: "../secrets/deploy.yml", Ref: "main"},
+		{Owner: "orch-labs", Repo: "orch", Workflow: "deploy.sh", Ref: "main"},
+	} {
+		if err := service.DispatchWorkflow(context.Background(), invalid); err == nil {
+			t.Fatalf("expected %+v to be rejected", invalid)
+		}
+	}
+	if len(requests) != 0 {
+		t.Fatalf("invalid input should not reach GitHub, got %v", requests)
+	}
+
+	service = newWorkflowTestService(t, http.StatusUnprocessableEntity, &requests, &payloads)
+	if err := service.DispatchWorkflow(context.Background(), WorkflowDispatchInput{Owner: "orch-labs", Repo: "orch", Workflow: "1234", Ref: "main"}); err == nil {
+		t.Fatalf("expected GitHub rejection to surface as error")
+	}
+}
+
+func TestNormalizeWorkflowIDAndRunURL(t *testing.T) {
+	for raw, want := range map[string]string{"deploy.yaml": "deploy.yaml", "./.github/workflows/ci.yml": "ci.yml", " 42 ": "42"} {
+		if got, err := normalizeWorkflowID(raw); err != nil || got != want {
+			t.Fatalf("normalizeWorkflowID(%q) = (%q, %v), want %q", raw, got, err, want)
+		}
+	}
+	if got := workflowRunIDFromURL("https://github.com/orch-labs/orch/actions/runs/777/job/12"); got != 777 {
+		t.Fatalf("workflowRunIDFromURL() = %d, want 777", got)
+	}
+	if got := workflowRunIDFromURL("https://ci.example.com/build/9"); got != 0 {
+		t.Fatalf("non-Actions URL should not yield a run id, got %d", got)
+	}
+}
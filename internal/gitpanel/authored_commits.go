@@ -0,0 +1,53 @@
+package gitpanel
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListAuthoredCommits lista os commits do usuário configurado (user.email) feitos desde since
+// em qualquer branch local, sem merges. Sem user.email configurado não há como filtrar.
+func (s *Service) ListAuthoredCommits(ctx context.Context, repoPath string, since time.Time) ([]HistoryItemDTO, error) {
+	preflight, err := s.preflightContext(ctx, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	root := preflight.RepoRoot
+
+	out, _, _, runErr := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "config", "user.email")
+	email := strings.TrimSpace(out)
+	if runErr != nil || email == "" {
+		return make([]HistoryItemDTO, 0), nil
+	}
+
+	out, errOut, exitCode, runErr := s.runGit(
+		ctx,
+		defaultReadTimeout,
+		"",
+		"-C", root,
+		"log",
+		"--branches",
+		"--no-merges",
+		"--fixed-strings",
+		"--author="+email,
+		"--since="+since.Format(time.RFC3339),
+		"--date=iso-strict",
+		"--pretty=format:%H%x1f%h%x1f%an%x1f%aI%x1f%ae%x1f%s%x1e",
+		"--numstat",
+		"-n", strconv.Itoa(maxComparisonCommits),
+	)
+	if runErr != nil {
+		return nil, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao listar commits do autor.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+	items := parseHistoryItems(out)
+	if items == nil {
+		items = make([]HistoryItemDTO, 0)
+	}
+	return items, nil
+}
@@ -0,0 +1,45 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestListAuthoredCommitsFiltersByConfiguredAuthor(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+
+	runGitOrFail(t, repoRoot, "checkout", "-b", "feature")
+	if err := os.WriteFile(filepath.Join(repoRoot, "feature.txt"), []byte("a\n"), 0o644); err != nil {
+		t.Fatalf("failed to create feature file: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "add", "--", "feature.txt")
+	runGitOrFail(t, repoRoot, "commit", "-m", "mine on feature")
+	if err := os.WriteFile(filepath.Join(repoRoot, "other.txt"), []byte("b\n"), 0o644); err != nil {
+		t.Fatalf("failed to create other file: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "add", "--", "other.txt")
+	runGitOrFail(t, repoRoot, "commit", "--author", "Someone Else <someone@orch.local>", "-m", "someone else")
+
+	commits, err := svc.ListAuthoredCommits(context.Background(), repoRoot, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ListAuthoredCommits returned error: %v", err)
+	}
+	subjects := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		subjects = append(subjects, commit.Subject)
+	}
+	slices.Sort(subjects)
+	if !slices.Equal(subjects, []string{"initial commit", "mine on feature"}) {
+		t.Fatalf("unexpected authored commits: %+v", commits)
+	}
+
+	commits, err = svc.ListAuthoredCommits(context.Background(), repoRoot, time.Now().Add(time.Hour))
+	if err != nil || len(commits) != 0 {
+		t.Fatalf("expected no commits in the future, got %+v err=%v", commits, err)
+	}
+}
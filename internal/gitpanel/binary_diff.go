@@ -0,0 +1,158 @@
+package gitpanel
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxImagePreviewBytes      = 1 * 1024 * 1024
+	maxImagePreviewTotalBytes = 4 * 1024 * 1024
+	maxBinaryDiffFiles        = 50
+)
+
+var imageMimeTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".bmp":  "image/bmp",
+	".ico":  "image/x-icon",
+}
+
+// blobSide identifica de onde ler um lado do diff binário.
+type blobSide struct {
+	kind string // "rev" | "index" | "worktree"
+	rev  string
+}
+
+func revBlobSide(rev string) blobSide { return blobSide{kind: "rev", rev: rev} }
+
+var (
+	indexBlobSide    = blobSide{kind: "index"}
+	worktreeBlobSide = blobSide{kind: "worktree"}
+)
+
+func imageMimeType(filePath string) string {
+	return imageMimeTypes[strings.ToLower(path.Ext(filePath))]
+}
+
+// attachBinaryDiffs enriquece arquivos binários do diff com tamanho/hash de cada lado
+// e, para imagens, preview base64 limitado por arquivo e por diff.
+func (s *Service) attachBinaryDiffs(ctx context.Context, repoRoot string, result *DiffDTO, oldSide blobSide, newSide blobSide) {
+	if result == nil {
+		return
+	}
+
+	budget := int64(maxImagePreviewTotalBytes)
+	described := 0
+	for idx := range result.Files {
+		file := &result.Files[idx]
+		if !file.IsBinary || strings.TrimSpace(file.Path) == "" {
+			continue
+		}
+		if described >= maxBinaryDiffFiles {
+			break
+		}
+		described++
+
+		oldPath := strings.TrimSpace(file.OldPath)
+		if oldPath == "" {
+			oldPath = file.Path
+		}
+		mimeType := imageMimeType(file.Path)
+
+		diff := &BinaryDiffDTO{IsImage: mimeType != ""}
+		if file.Status != "added" {
+			diff.Old = s.describeBlob(ctx, repoRoot, oldSide, oldPath, imageMimeType(oldPath), &budget)
+		}
+		if file.Status != "deleted" {
+			diff.New = s.describeBlob(ctx, repoRoot, newSide, file.Path, mimeType, &budget)
+		}
+		diff.SizeDelta = diff.New.Size - diff.Old.Size
+		file.Binary = diff
+	}
+}
+
+func (s *Service) describeBlob(ctx context.Context, repoRoot string, side blobSide, filePath string, mimeType string, budget *int64) BinaryBlobDTO {
+	blob := BinaryBlobDTO{MimeType: mimeType}
+
+	var content []byte
+	wantsPreview := func(size int64) bool {
+		return mimeType != "" && size <= maxImagePreviewBytes && size <= *budget
+	}
+
+	switch side.kind {
+	case "worktree":
+		fullPath := filepath.Join(repoRoot, filepath.FromSlash(filePath))
+		info, err := os.Stat(fullPath)
+		if err != nil || info.IsDir() {
+			return blob
+		}
+		blob.Exists = true
+		blob.Size = info.Size()
+		if out, _, _, err := s.runGit(ctx, defaultReadTimeout, "", "-C", repoRoot, "hash-object", "--", filePath); err == nil {
+			blob.Hash = strings.TrimSpace(out)
+		}
+		if wantsPreview(blob.Size) {
+			content, _ = os.ReadFile(fullPath)
+		}
+
+	case "rev", "index":
+		spec := ":" + filePath
+		if side.kind == "rev" {
+			spec = side.rev + ":" + filePath
+		}
+		out, _, _, err := s.runGit(ctx, defaultReadTimeout, spec+"\n", "-C", repoRoot, "cat-file", "--batch-check")
+		if err != nil {
+			return blob
+		}
+		// "<hash> blob <size>" ou "<spec> missing"
+		fields := strings.Fields(strings.TrimSpace(out))
+		if len(fields) != 3 || fields[1] != "blob" {
+			return blob
+		}
+		size, sizeErr := strconv.ParseInt(fields[2], 10, 64)
+		if sizeErr != nil {
+			return blob
+		}
+		blob.Exists = true
+		blob.Hash = fields[0]
+		blob.Size = size
+		if wantsPreview(blob.Size) {
+			if raw, _, _, catErr := s.runGit(ctx, defaultReadTimeout, "", "-C", repoRoot, "cat-file", "blob", blob.Hash); catErr == nil {
+				content = []byte(raw)
+			}
+		}
+
+	default:
+		return blob
+	}
+
+	if mimeType == "" {
+		return blob
+	}
+	if content == nil {
+		blob.PreviewOmitted = blob.Exists
+		return blob
+	}
+
+	*budget -= int64(len(content))
+	blob.DataURL = "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(content)
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(content)); err == nil {
+		blob.Width = cfg.Width
+		blob.Height = cfg.Height
+	}
+	return blob
+}
@@ -0,0 +1,75 @@
+package gitpanel
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestPNG(t *testing.T, filePath string, width int, height int) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	if err := os.WriteFile(filePath, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("failed to write png: %v", err)
+	}
+}
+
+func TestDiffAttachesImagePreviewsAndBinarySummary(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+
+	logoPath := filepath.Join(repoRoot, "logo.png")
+	writeTestPNG(t, logoPath, 4, 2)
+	if err := os.WriteFile(filepath.Join(repoRoot, "data.bin"), []byte{0, 1, 2, 3}, 0o644); err != nil {
+		t.Fatalf("failed to write binary file: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "add", "--", "logo.png", "data.bin")
+	runGitOrFail(t, repoRoot, "commit", "-m", "add assets")
+
+	writeTestPNG(t, logoPath, 8, 6)
+	if err := os.WriteFile(filepath.Join(repoRoot, "data.bin"), []byte{0, 1, 2, 3, 4, 5}, 0o644); err != nil {
+		t.Fatalf("failed to modify binary file: %v", err)
+	}
+
+	diff, err := svc.GetDiff(repoRoot, "", "unified", 3)
+	if err != nil {
+		t.Fatalf("GetDiff returned error: %v", err)
+	}
+
+	byPath := make(map[string]DiffFileDTO)
+	for _, file := range diff.Files {
+		byPath[file.Path] = file
+	}
+
+	logo := byPath["logo.png"].Binary
+	if logo == nil || !logo.IsImage {
+		t.Fatalf("expected image diff for logo.png, got %+v", byPath["logo.png"])
+	}
+	if logo.Old.Width != 4 || logo.Old.Height != 2 || logo.New.Width != 8 || logo.New.Height != 6 {
+		t.Fatalf("unexpected image dimensions: old=%dx%d new=%dx%d", logo.Old.Width, logo.Old.Height, logo.New.Width, logo.New.Height)
+	}
+	if !strings.HasPrefix(logo.New.DataURL, "data:image/png;base64,") || logo.Old.Hash == "" || logo.Old.Hash == logo.New.Hash {
+		t.Fatalf("unexpected image blobs: %+v", logo)
+	}
+
+	data := byPath["data.bin"].Binary
+	if data == nil || data.IsImage || data.SizeDelta != 2 || data.Old.DataURL != "" {
+		t.Fatalf("unexpected binary summary: %+v", data)
+	}
+
+	runGitOrFail(t, repoRoot, "commit", "-am", "resize logo")
+	commitDiff, err := svc.GetCommitDiff(repoRoot, "logo.png", "HEAD", 3)
+	if err != nil {
+		t.Fatalf("GetCommitDiff returned error: %v", err)
+	}
+	if len(commitDiff.Files) != 1 || commitDiff.Files[0].Binary == nil || commitDiff.Files[0].Binary.New.Width != 8 {
+		t.Fatalf("unexpected commit image diff: %+v", commitDiff.Files)
+	}
+}
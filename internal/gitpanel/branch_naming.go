@@ -0,0 +1,323 @@
+package gitpanel
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	BranchNamingModeOff   = "off"
+	BranchNamingModeWarn  = "warn"
+	BranchNamingModeBlock = "block"
+
+	BranchSuggestionSourceHeuristic = "heuristic"
+	BranchSuggestionSourceAI        = "ai"
+
+	maxBranchSlugLength = 48
+	maxBranchTemplates  = 20
+)
+
+// branchNamingPlaceholders são os placeholders aceitos nos templates e a regex de cada um.
+var branchNamingPlaceholders = map[string]string{
+	"ticket": `(?:[A-Z][A-Z0-9]+-\d+|#?\d+)`,
+	"slug":   `[a-z0-9]+(?:-[a-z0-9]+)*`,
+	"type":   `(?:feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)`,
+	"user":   `[a-z0-9][a-z0-9._-]*`,
+}
+
+var (
+	branchNamingPlaceholderRegex = regexp.MustCompile(`\{([a-z]+)\}`)
+	branchTicketRegex            = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9]+-\d+|#?\d+)$`)
+	branchTicketAnywhereRegex    = regexp.MustCompile(branchNamingPlaceholders["ticket"])
+	branchSlugInvalidRegex       = regexp.MustCompile(`[^a-z0-9]+`)
+
+	// branchAccentFolder remove os acentos mais comuns (pt/es/fr) antes de montar o slug.
+	branchAccentFolder = strings.NewReplacer(
+		"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+		"é", "e", "è", "e", "ê", "e", "ë", "e",
+		"í", "i", "ì", "i", "î", "i", "ï", "i",
+		"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+		"ú", "u", "ù", "u", "û", "u", "ü", "u",
+		"ç", "c", "ñ", "n",
+	)
+)
+
+// branchTypeKeywords infere o type da descrição; a ordem importa (bug antes de feature).
+var branchTypeKeywords = []struct {
+	kind     string
+	keywords []string
+}{
+	{"fix", []string{"fix", "bug", "hotfix", "corrige", "corrigir", "erro", "crash"}},
+	{"docs", []string{"doc", "docs", "readme", "documenta", "documentacao"}},
+	{"refactor", []string{"refactor", "refatora", "refatorar", "cleanup"}},
+	{"test", []string{"test", "tests", "teste", "testes"}},
+	{"chore", []string{"chore", "bump", "deps", "upgrade", "atualiza"}},
+}
+
+// branchTypeAliases são prefixos usuais equivalentes a cada type.
+var branchTypeAliases = map[string][]string{
+	"feat":     {"feat", "feature", "features"},
+	"fix":      {"fix", "bugfix", "hotfix", "bug"},
+	"docs":     {"docs", "doc"},
+	"refactor": {"refactor"},
+	"test":     {"test", "tests"},
+	"chore":    {"chore"},
+}
+
+// NormalizeBranchNamingMode aplica o default (warn) a modos vazios ou desconhecidos.
+func NormalizeBranchNamingMode(mode string) string {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case BranchNamingModeOff:
+		return BranchNamingModeOff
+	case BranchNamingModeBlock:
+		return BranchNamingModeBlock
+	default:
+		return BranchNamingModeWarn
+	}
+}
+
+// ParseBranchNamingTemplates separa os templates (um por linha), sem vazios nem repetidos.
+func ParseBranchNamingTemplates(raw string) []string {
+	templates := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		templates = append(templates, line)
+	}
+	return templates
+}
+
+// CompileBranchNamingTemplate converte o template em regex ancorada. O texto fora dos placeholders
+// é regex (permite alternativas como (feature|feat)/{slug}).
+func CompileBranchNamingTemplate(template string) (*regexp.Regexp, error) {
+	template = strings.TrimSpace(template)
+	if template == "" {
+		return nil, fmt.Errorf("branch naming template cannot be empty")
+	}
+	var unknown string
+	pattern := branchNamingPlaceholderRegex.ReplaceAllStringFunc(template, func(match string) string {
+		name := match[1 : len(match)-1]
+		expr, ok := branchNamingPlaceholders[name]
+		if !ok {
+			unknown = name
+			return match
+		}
+		return expr
+	})
+	if unknown != "" {
+		return nil, fmt.Errorf("unknown placeholder {%s} in branch naming template %q", unknown, template)
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid branch naming template %q: %w", template, err)
+	}
+	return re, nil
+}
+
+// ValidateBranchNamingPolicy normaliza a política e garante que todos os templates compilam.
+func ValidateBranchNamingPolicy(policy BranchNamingPolicyDTO) (BranchNamingPolicyDTO, error) {
+	templates := make([]string, 0, len(policy.Templates))
+	seen := make(map[string]bool)
+	for _, template := range policy.Templates {
+		template = strings.TrimSpace(template)
+		if template == "" || seen[template] {
+			continue
+		}
+		if _, err := CompileBranchNamingTemplate(template); err != nil {
+			return BranchNamingPolicyDTO{}, err
+		}
+		seen[template] = true
+		templates = append(templates, template)
+	}
+	if len(templates) > maxBranchTemplates {
+		return BranchNamingPolicyDTO{}, fmt.Errorf("too many branch naming templates (max %d)", maxBranchTemplates)
+	}
+	return BranchNamingPolicyDTO{Templates: templates, Mode: NormalizeBranchNamingMode(policy.Mode)}, nil
+}
+
+// ValidateBranchName confere o nome contra os templates e explica, por template, o que não bate.
+func ValidateBranchName(branch string, policy BranchNamingPolicyDTO) BranchNamingValidationDTO {
+	result := BranchNamingValidationDTO{
+		Branch:     strings.TrimSpace(branch),
+		Mode:       NormalizeBranchNamingMode(policy.Mode),
+		Templates:  policy.Templates,
+		Valid:      true,
+		Violations: make([]string, 0),
+	}
+	if result.Mode == BranchNamingModeOff || len(policy.Templates) == 0 {
+		return result
+	}
+	for _, template := range policy.Templates {
+		re, err := CompileBranchNamingTemplate(template)
+		if err != nil {
+			continue
+		}
+		if re.MatchString(result.Branch) {
+			result.MatchedTemplate = template
+			result.Violations = result.Violations[:0]
+			return result
+		}
+		result.Violations = append(result.Violations, explainBranchNamingMismatch(result.Branch, template))
+	}
+	result.Valid = false
+	result.Blocking = result.Mode == BranchNamingModeBlock
+	return result
+}
+
+// explainBranchNamingMismatch aponta o primeiro motivo provável de o nome não seguir o template.
+func explainBranchNamingMismatch(branch string, template string) string {
+	prefix := branchTemplateLiteralPrefix(template)
+	switch {
+	case prefix != "" && !strings.HasPrefix(branch, prefix):
+		return fmt.Sprintf("%s: o nome deve começar com %q", template, prefix)
+	case strings.Contains(template, "{ticket}") && !branchTicketAnywhereRegex.MatchString(branch):
+		return fmt.Sprintf("%s: falta o ticket (ex.: ABC-123)", template)
+	case strings.Contains(template, "{slug}") && branch != strings.ToLower(branch):
+		return fmt.Sprintf("%s: o slug deve usar letras minúsculas, números e hífens", template)
+	case strings.Contains(branch, "_") || strings.Contains(branch, " "):
+		return fmt.Sprintf("%s: use hífens em vez de espaços ou underscores", template)
+	default:
+		return fmt.Sprintf("%s: o nome não segue o formato", template)
+	}
+}
+
+// branchTemplateLiteralPrefix retorna o texto literal antes do primeiro placeholder ou metacaractere.
+func branchTemplateLiteralPrefix(template string) string {
+	end := len(template)
+	if idx := strings.IndexAny(template, `{([\.*+?|^$`); idx >= 0 {
+		end = idx
+	}
+	return template[:end]
+}
+
+// SlugifyBranchDescription converte a descrição em slug kebab-case ASCII, cortado em palavras inteiras.
+func SlugifyBranchDescription(description string) string {
+	folded := branchAccentFolder.Replace(strings.ToLower(description))
+	slug := strings.Trim(branchSlugInvalidRegex.ReplaceAllString(folded, "-"), "-")
+	for len(slug) > maxBranchSlugLength {
+		idx := strings.LastIndex(slug[:maxBranchSlugLength], "-")
+		if idx <= 0 {
+			slug = slug[:maxBranchSlugLength]
+			break
+		}
+		slug = slug[:idx]
+	}
+	return strings.Trim(slug, "-")
+}
+
+// InferBranchType escolhe o type Conventional Commits a partir das palavras da descrição (padrão feat).
+func InferBranchType(description string) string {
+	words := strings.Split(SlugifyBranchDescription(description), "-")
+	for _, candidate := range branchTypeKeywords {
+		for _, word := range words {
+			for _, keyword := range candidate.keywords {
+				if word == keyword {
+					return candidate.kind
+				}
+			}
+		}
+	}
+	return "feat"
+}
+
+// BranchSlugPrompt pede à IA um slug curto para a descrição.
+func BranchSlugPrompt(description string) string {
+	return "Gere um slug curto (2 a 6 palavras em inglês, kebab-case, só letras minúsculas, números e hífens) " +
+		"para o nome de um branch git que implementa a tarefa abaixo. Responda apenas com o slug.\n\nTarefa: " +
+		strings.TrimSpace(description)
+}
+
+// SuggestBranchName gera nomes que seguem a política a partir da descrição e do ticket.
+// slug vazio é derivado da descrição; sem templates usa {type}/{ticket}-{slug}.
+func SuggestBranchName(description string, ticket string, slug string, user string, policy BranchNamingPolicyDTO) (BranchNameSuggestionDTO, error) {
+	ticket = strings.TrimSpace(ticket)
+	if ticket != "" && !branchTicketRegex.MatchString(ticket) {
+		return BranchNameSuggestionDTO{}, fmt.Errorf("invalid ticket %q (expected ABC-123 or 123)", ticket)
+	}
+	ticket = strings.TrimPrefix(strings.ToUpper(ticket), "#")
+	slug = SlugifyBranchDescription(slug)
+	if slug == "" {
+		slug = SlugifyBranchDescription(description)
+	}
+	if slug == "" {
+		return BranchNameSuggestionDTO{}, fmt.Errorf("description is required to suggest a branch name")
+	}
+
+	kind := InferBranchType(description)
+	values := map[string]string{
+		"ticket": ticket,
+		"slug":   slug,
+		"type":   kind,
+		"user":   SlugifyBranchDescription(user),
+	}
+
+	templates := policy.Templates
+	if len(templates) == 0 {
+		templates = []string{"{type}/{ticket}-{slug}", "{type}/{slug}"}
+	}
+	// Templates cujo prefixo combina com o type inferido (fix/, feature/...) vêm primeiro.
+	ordered := make([]string, 0, len(templates))
+	for _, template := range templates {
+		if branchTemplateMatchesType(template, kind) {
+			ordered = append(ordered, template)
+		}
+	}
+	for _, template := range templates {
+		if !branchTemplateMatchesType(template, kind) {
+			ordered = append(ordered, template)
+		}
+	}
+
+	suggestion := BranchNameSuggestionDTO{Type: kind, Slug: slug, Source: BranchSuggestionSourceHeuristic, Candidates: make([]string, 0)}
+	for _, template := range ordered {
+		name, ok := renderBranchTemplate(template, values)
+		if !ok {
+			continue
+		}
+		if re, err := CompileBranchNamingTemplate(template); err != nil || !re.MatchString(name) {
+			continue
+		}
+		if suggestion.Name == "" {
+			suggestion.Name, suggestion.Template = name, template
+		}
+		suggestion.Candidates = append(suggestion.Candidates, name)
+	}
+	if suggestion.Name == "" {
+		return suggestion, fmt.Errorf("no branch naming template can be filled (a ticket may be required)")
+	}
+	return suggestion, nil
+}
+
+// renderBranchTemplate preenche os placeholders; falha se faltar valor ou sobrar regex no template.
+func renderBranchTemplate(template string, values map[string]string) (string, bool) {
+	ok := true
+	name := branchNamingPlaceholderRegex.ReplaceAllStringFunc(template, func(match string) string {
+		value := values[match[1:len(match)-1]]
+		if value == "" {
+			ok = false
+		}
+		return value
+	})
+	if !ok || strings.ContainsAny(name, `()[]\*+?|^$`) {
+		return "", false
+	}
+	return name, true
+}
+
+func branchTemplateMatchesType(template string, kind string) bool {
+	if strings.HasPrefix(template, "{type}") {
+		return true
+	}
+	prefix := strings.Trim(strings.ToLower(branchTemplateLiteralPrefix(template)), "/-_")
+	for _, alias := range branchTypeAliases[kind] {
+		if prefix == alias {
+			return true
+		}
+	}
+	return false
+}
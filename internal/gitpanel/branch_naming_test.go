@@ -0,0 +1,72 @@
+package gitpanel
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateBranchNameExplainsViolations(t *testing.T) {
+	policy, err := ValidateBranchNamingPolicy(BranchNamingPolicyDTO{
+		Templates: []string{" feature/{ticket}-{slug} ", "(fix|hotfix)/{slug}", "feature/{ticket}-{slug}"},
+		Mode:      "BLOCK",
+	})
+	if err != nil {
+		t.Fatalf("ValidateBranchNamingPolicy returned error: %v", err)
+	}
+	if len(policy.Templates) != 2 || policy.Mode != BranchNamingModeBlock {
+		t.Fatalf("unexpected normalized policy: %+v", policy)
+	}
+	if _, err := ValidateBranchNamingPolicy(BranchNamingPolicyDTO{Templates: []string{"feature/{issue}"}}); err == nil {
+		t.Fatalf("expected unknown placeholder to be rejected")
+	}
+
+	for _, branch := range []string{"feature/ABC-123-add-search", "hotfix/login-crash"} {
+		if result := ValidateBranchName(branch, policy); !result.Valid || result.MatchedTemplate == "" {
+			t.Fatalf("expected %s to be valid, got %+v", branch, result)
+		}
+	}
+
+	result := ValidateBranchName("feature/add-search", policy)
+	if result.Valid || !result.Blocking || len(result.Violations) != 2 {
+		t.Fatalf("unexpected validation: %+v", result)
+	}
+	if !strings.Contains(result.Violations[0], "falta o ticket") {
+		t.Fatalf("expected missing ticket explanation, got %q", result.Violations[0])
+	}
+	if result := ValidateBranchName("Feature/ABC-1-x", policy); !strings.Contains(result.Violations[0], `começar com "feature/"`) {
+		t.Fatalf("expected prefix explanation, got %+v", result.Violations)
+	}
+
+	policy.Mode = BranchNamingModeOff
+	if result := ValidateBranchName("anything", policy); !result.Valid {
+		t.Fatalf("mode off should accept any name: %+v", result)
+	}
+}
+
+func TestSuggestBranchNamePrefersTemplateMatchingInferredType(t *testing.T) {
+	policy := BranchNamingPolicyDTO{Templates: []string{"feature/{ticket}-{slug}", "fix/{ticket}-{slug}", "users/{user}/{slug}"}}
+
+	suggestion, err := SuggestBranchName("Corrigir crash na sincronização de sessão", "abc-42", "", "Ana Souza", policy)
+	if err != nil {
+		t.Fatalf("SuggestBranchName returned error: %v", err)
+	}
+	if suggestion.Name != "fix/ABC-42-corrigir-crash-na-sincronizacao-de-sessao" || suggestion.Type != "fix" {
+		t.Fatalf("unexpected suggestion: %+v", suggestion)
+	}
+	if len(suggestion.Candidates) != 3 || suggestion.Candidates[2] != "users/ana-souza/corrigir-crash-na-sincronizacao-de-sessao" {
+		t.Fatalf("unexpected candidates: %v", suggestion.Candidates)
+	}
+
+	withoutTicket, err := SuggestBranchName("Add search endpoint", "", "search-api", "", policy)
+	if err == nil {
+		t.Fatalf("expected templates requiring ticket/user to fail without them, got %+v", withoutTicket)
+	}
+
+	defaults, err := SuggestBranchName("Add search endpoint", "", "", "", BranchNamingPolicyDTO{})
+	if err != nil || defaults.Name != "feat/add-search-endpoint" {
+		t.Fatalf("unexpected default suggestion: %+v (%v)", defaults, err)
+	}
+	if _, err := SuggestBranchName("x", "not a ticket", "", "", BranchNamingPolicyDTO{}); err == nil {
+		t.Fatalf("expected invalid ticket to be rejected")
+	}
+}
@@ -0,0 +1,289 @@
+package gitpanel
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxCacheTTL evita que um override esquecido congele o painel por horas.
+	maxCacheTTL = 10 * time.Minute
+	// maxWarmDiffs limita quantos diffs de arquivo são pré-calculados no warm-up.
+	maxWarmDiffs = 10
+	// maxPendingComputes descarta medições órfãs (miss sem set, ex.: erro no git).
+	maxPendingComputes   = 1024
+	warmDiffContextLines = 3
+)
+
+// TTLs embutidos, expostos como padrão das chaves de config.toml.
+const (
+	DefaultStatusCacheTTL  = statusCacheTTL
+	DefaultHistoryCacheTTL = historyCacheTTL
+	DefaultDiffCacheTTL    = diffCacheTTL
+)
+
+type cacheKind string
+
+const (
+	cacheKindPreflight cacheKind = "preflight"
+	cacheKindStatus    cacheKind = "status"
+	cacheKindHistory   cacheKind = "history"
+	cacheKindDiff      cacheKind = "diff"
+)
+
+var cacheKinds = []cacheKind{cacheKindPreflight, cacheKindStatus, cacheKindHistory, cacheKindDiff}
+
+type cacheCounter struct {
+	hits         uint64
+	misses       uint64
+	computes     uint64
+	computeTotal time.Duration
+	pending      map[string]time.Time // chave -> instante do miss, para medir o cálculo até o set
+}
+
+// cacheMetrics mede hit rate e tempo de cálculo por tipo de cache.
+type cacheMetrics struct {
+	mu         sync.Mutex
+	counters   map[cacheKind]*cacheCounter
+	since      time.Time
+	warmups    uint64
+	lastWarmup time.Duration
+}
+
+func newCacheMetrics() *cacheMetrics {
+	m := &cacheMetrics{}
+	m.reset()
+	return m
+}
+
+func (m *cacheMetrics) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters = make(map[cacheKind]*cacheCounter, len(cacheKinds))
+	for _, kind := range cacheKinds {
+		m.counters[kind] = &cacheCounter{pending: make(map[string]time.Time)}
+	}
+	m.since = time.Now()
+	m.warmups = 0
+	m.lastWarmup = 0
+}
+
+func (m *cacheMetrics) hit(kind cacheKind) {
+	m.mu.Lock()
+	m.counters[kind].hits++
+	m.mu.Unlock()
+}
+
+func (m *cacheMetrics) miss(kind cacheKind, key string) {
+	m.mu.Lock()
+	counter := m.counters[kind]
+	counter.misses++
+	if len(counter.pending) >= maxPendingComputes {
+		counter.pending = make(map[string]time.Time)
+	}
+	counter.pending[key] = time.Now()
+	m.mu.Unlock()
+}
+
+func (m *cacheMetrics) stored(kind cacheKind, key string) {
+	m.mu.Lock()
+	counter := m.counters[kind]
+	if missedAt, ok := counter.pending[key]; ok {
+		delete(counter.pending, key)
+		counter.computes++
+		counter.computeTotal += time.Since(missedAt)
+	}
+	m.mu.Unlock()
+}
+
+func (m *cacheMetrics) warmed(duration time.Duration) {
+	m.mu.Lock()
+	m.warmups++
+	m.lastWarmup = duration
+	m.mu.Unlock()
+}
+
+func (m *cacheMetrics) snapshot() CacheMetricsDTO {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := CacheMetricsDTO{
+		Since:        m.since.UTC().Format(time.RFC3339),
+		Kinds:        make([]CacheKindMetricsDTO, 0, len(cacheKinds)),
+		Warmups:      m.warmups,
+		LastWarmupMs: m.lastWarmup.Milliseconds(),
+	}
+	for _, kind := range cacheKinds {
+		counter := m.counters[kind]
+		item := CacheKindMetricsDTO{
+			Kind:     string(kind),
+			Hits:     counter.hits,
+			Misses:   counter.misses,
+			Computes: counter.computes,
+		}
+		if total := counter.hits + counter.misses; total > 0 {
+			item.HitRate = float64(counter.hits) / float64(total)
+		}
+		if counter.computes > 0 {
+			item.AvgComputeMs = float64(counter.computeTotal.Microseconds()) / float64(counter.computes) / 1000
+		}
+		result.Kinds = append(result.Kinds, item)
+	}
+	return result
+}
+
+// SetDefaultCacheTTLs define os TTLs usados por repositórios sem override; campos zerados
+// voltam ao padrão embutido.
+func (s *Service) SetDefaultCacheTTLs(ttls CacheTTLsDTO) {
+	s.cacheMu.Lock()
+	s.defaultTTLs = clampCacheTTLs(ttls)
+	s.cacheMu.Unlock()
+}
+
+// SetRepoCacheTTLs define TTLs específicos do repositório (campos zerados herdam o padrão;
+// tudo zerado remove o override) e retorna os TTLs efetivos. Entradas já em cache mantêm
+// a validade com que foram gravadas.
+func (s *Service) SetRepoCacheTTLs(repoPath string, ttls CacheTTLsDTO) (CacheTTLsDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return CacheTTLsDTO{}, err
+	}
+	root := filepath.Clean(preflight.RepoRoot)
+	ttls = clampCacheTTLs(ttls)
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if ttls == (CacheTTLsDTO{}) {
+		delete(s.repoTTLs, root)
+	} else {
+		s.repoTTLs[root] = ttls
+	}
+	return s.effectiveCacheTTLsLocked(root), nil
+}
+
+// GetRepoCacheTTLs retorna os TTLs efetivos do repositório.
+func (s *Service) GetRepoCacheTTLs(repoPath string) (CacheTTLsDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return CacheTTLsDTO{}, err
+	}
+	s.cacheMu.RLock()
+	defer s.cacheMu.RUnlock()
+	return s.effectiveCacheTTLsLocked(filepath.Clean(preflight.RepoRoot)), nil
+}
+
+// GetCacheMetrics retorna hit rate e tempo médio de cálculo por tipo de cache desde o último reset.
+func (s *Service) GetCacheMetrics() CacheMetricsDTO {
+	return s.metrics.snapshot()
+}
+
+// ResetCacheMetrics zera as métricas (útil para comparar janelas de debounce diferentes).
+func (s *Service) ResetCacheMetrics() {
+	s.metrics.reset()
+}
+
+// WarmCache pré-calcula status, primeira página do histórico e os diffs dos primeiros arquivos
+// alterados, para que a troca de branch não pague tudo na primeira renderização.
+func (s *Service) WarmCache(repoPath string, scope string) (CacheWarmResultDTO, error) {
+	started := time.Now()
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return CacheWarmResultDTO{}, err
+	}
+	root := preflight.RepoRoot
+	result := CacheWarmResultDTO{RepoRoot: root}
+
+	status, err := s.GetStatusInScope(root, scope)
+	if err != nil {
+		return CacheWarmResultDTO{}, err
+	}
+	result.Status = true
+
+	if _, err := s.GetHistoryInScope(root, "", defaultHistoryLimit, "", scope); err != nil {
+		return CacheWarmResultDTO{}, err
+	}
+	result.History = true
+
+	warmDiff := func(filePath string, mode string) {
+		if result.Diffs >= maxWarmDiffs {
+			return
+		}
+		// Um diff que falha (arquivo removido no meio do caminho) não invalida o warm-up.
+		if _, err := s.GetDiffInScope(root, filePath, mode, warmDiffContextLines, scope); err == nil {
+			result.Diffs++
+		}
+	}
+	for _, file := range status.Unstaged {
+		warmDiff(file.Path, "unified")
+	}
+	for _, file := range status.Staged {
+		warmDiff(file.Path, "staged")
+	}
+
+	elapsed := time.Since(started)
+	result.DurationMs = elapsed.Milliseconds()
+	s.metrics.warmed(elapsed)
+	return result, nil
+}
+
+// cacheTTLLocked resolve o TTL do tipo para o repositório; exige cacheMu.
+func (s *Service) cacheTTLLocked(kind cacheKind, repoRoot string) time.Duration {
+	ttls := s.effectiveCacheTTLsLocked(filepath.Clean(strings.TrimSpace(repoRoot)))
+	switch kind {
+	case cacheKindPreflight:
+		return time.Duration(ttls.PreflightMs) * time.Millisecond
+	case cacheKindStatus:
+		return time.Duration(ttls.StatusMs) * time.Millisecond
+	case cacheKindHistory:
+		return time.Duration(ttls.HistoryMs) * time.Millisecond
+	default:
+		return time.Duration(ttls.DiffMs) * time.Millisecond
+	}
+}
+
+func (s *Service) effectiveCacheTTLsLocked(repoRoot string) CacheTTLsDTO {
+	builtin := CacheTTLsDTO{
+		PreflightMs: int(preflightCacheTTL / time.Millisecond),
+		StatusMs:    int(statusCacheTTL / time.Millisecond),
+		HistoryMs:   int(historyCacheTTL / time.Millisecond),
+		DiffMs:      int(diffCacheTTL / time.Millisecond),
+	}
+	return mergeCacheTTLs(mergeCacheTTLs(builtin, s.defaultTTLs), s.repoTTLs[repoRoot])
+}
+
+// mergeCacheTTLs sobrepõe os campos não zerados de override.
+func mergeCacheTTLs(base CacheTTLsDTO, override CacheTTLsDTO) CacheTTLsDTO {
+	if override.PreflightMs > 0 {
+		base.PreflightMs = override.PreflightMs
+	}
+	if override.StatusMs > 0 {
+		base.StatusMs = override.StatusMs
+	}
+	if override.HistoryMs > 0 {
+		base.HistoryMs = override.HistoryMs
+	}
+	if override.DiffMs > 0 {
+		base.DiffMs = override.DiffMs
+	}
+	return base
+}
+
+func clampCacheTTLs(ttls CacheTTLsDTO) CacheTTLsDTO {
+	clamp := func(value int) int {
+		return max(0, min(value, int(maxCacheTTL/time.Millisecond)))
+	}
+	return CacheTTLsDTO{
+		PreflightMs: clamp(ttls.PreflightMs),
+		StatusMs:    clamp(ttls.StatusMs),
+		HistoryMs:   clamp(ttls.HistoryMs),
+		DiffMs:      clamp(ttls.DiffMs),
+	}
+}
+
+// cacheKeyRepoRoot extrai a raiz do repositório das chaves de histórico/diff ("raiz\x1f...").
+func cacheKeyRepoRoot(cacheKey string) string {
+	root, _, _ := strings.Cut(cacheKey, "\x1f")
+	return root
+}
@@ -0,0 +1,99 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRepoCacheTTLsOverrideDefaults(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	svc.SetDefaultCacheTTLs(CacheTTLsDTO{HistoryMs: 5000})
+	effective, err := svc.SetRepoCacheTTLs(repoRoot, CacheTTLsDTO{StatusMs: 30000, DiffMs: int(time.Hour / time.Millisecond)})
+	if err != nil {
+		t.Fatalf("SetRepoCacheTTLs returned error: %v", err)
+	}
+	want := CacheTTLsDTO{
+		PreflightMs: int(preflightCacheTTL / time.Millisecond),
+		StatusMs:    30000,
+		HistoryMs:   5000,
+		DiffMs:      int(maxCacheTTL / time.Millisecond),
+	}
+	if effective != want {
+		t.Fatalf("unexpected effective TTLs: got=%+v want=%+v", effective, want)
+	}
+
+	// Status fica em cache pelo TTL do repo: um arquivo novo não aparece até a invalidação.
+	if _, err := svc.GetStatus(repoRoot); err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+	time.Sleep(statusCacheTTL + 100*time.Millisecond)
+	if err := os.WriteFile(filepath.Join(repoRoot, "new.txt"), []byte("x\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	cached, err := svc.GetStatus(repoRoot)
+	if err != nil || len(cached.Unstaged) != 0 {
+		t.Fatalf("expected status served from cache with repo TTL, got %+v err=%v", cached, err)
+	}
+
+	reset, err := svc.SetRepoCacheTTLs(repoRoot, CacheTTLsDTO{})
+	if err != nil || reset.StatusMs != int(statusCacheTTL/time.Millisecond) || reset.HistoryMs != 5000 {
+		t.Fatalf("expected override removal to restore defaults, got %+v err=%v", reset, err)
+	}
+}
+
+func TestWarmCacheRecordsMetrics(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	if err := os.WriteFile(filepath.Join(repoRoot, "README.md"), []byte("hello\nworld\n"), 0o644); err != nil {
+		t.Fatalf("failed to modify README: %v", err)
+	}
+	if _, err := svc.SetRepoCacheTTLs(repoRoot, CacheTTLsDTO{StatusMs: 60000, HistoryMs: 60000, DiffMs: 60000}); err != nil {
+		t.Fatalf("SetRepoCacheTTLs returned error: %v", err)
+	}
+	svc.ResetCacheMetrics()
+
+	result, err := svc.WarmCache(repoRoot, "")
+	if err != nil {
+		t.Fatalf("WarmCache returned error: %v", err)
+	}
+	if !result.Status || !result.History || result.Diffs != 1 {
+		t.Fatalf("unexpected warm result: %+v", result)
+	}
+
+	// As leituras do painel depois do warm-up saem do cache.
+	if _, err := svc.GetStatus(repoRoot); err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+	if _, err := svc.GetHistory(repoRoot, "", 0, ""); err != nil {
+		t.Fatalf("GetHistory returned error: %v", err)
+	}
+	if _, err := svc.GetDiff(repoRoot, "README.md", "unified", 3); err != nil {
+		t.Fatalf("GetDiff returned error: %v", err)
+	}
+
+	metrics := svc.GetCacheMetrics()
+	if metrics.Warmups != 1 || len(metrics.Kinds) != 4 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+	for _, kind := range metrics.Kinds {
+		switch kind.Kind {
+		case "status", "history", "diff":
+			if kind.Hits != 1 || kind.Misses != 1 || kind.Computes != 1 || kind.HitRate != 0.5 {
+				t.Fatalf("unexpected %s metrics: %+v", kind.Kind, kind)
+			}
+		}
+	}
+
+	svc.ResetCacheMetrics()
+	if metrics := svc.GetCacheMetrics(); metrics.Warmups != 0 || metrics.Kinds[1].Hits != 0 {
+		t.Fatalf("expected metrics reset, got %+v", metrics)
+	}
+}
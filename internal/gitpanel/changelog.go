@@ -0,0 +1,415 @@
+package gitpanel
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	ChangelogStyleConventional   = "conventional"   // seções por type (conventional-changelog)
+	ChangelogStyleKeepAChangelog = "keepachangelog" // Added/Changed/Fixed (keepachangelog.com)
+
+	ChangelogFileName        = "CHANGELOG.md"
+	ChangelogUnreleased      = "Unreleased" // versão quando toRef não é uma tag
+	maxChangelogCommits      = 1000
+	changelogOtherSectionKey = "other"
+)
+
+type changelogSection struct {
+	key   string
+	title string
+}
+
+// conventionalChangelogSections define a ordem das seções no estilo conventional.
+var conventionalChangelogSections = []changelogSection{
+	{"breaking", "Breaking Changes"},
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance"},
+	{"refactor", "Refactoring"},
+	{"revert", "Reverts"},
+	{"docs", "Documentation"},
+	{"style", "Styles"},
+	{"test", "Tests"},
+	{"build", "Build System"},
+	{"ci", "Continuous Integration"},
+	{"chore", "Chores"},
+	{changelogOtherSectionKey, "Other Changes"},
+}
+
+// keepAChangelogSections mapeia types para as seções do keepachangelog; docs, testes,
+// CI e afins não interessam a quem lê as notas de versão e ficam de fora.
+var keepAChangelogSections = []changelogSection{
+	{"added", "Added"},
+	{"changed", "Changed"},
+	{"fixed", "Fixed"},
+}
+
+var (
+	changelogPRRegex     = regexp.MustCompile(`\s*\(#(\d+)\)\s*$`)
+	changelogRevertRegex = regexp.MustCompile(`^Revert "(.+)"$`)
+)
+
+// ChangelogPRResolver completa o PR citado no commit (link, título e autor) com dados do GitHub.
+type ChangelogPRResolver func(number int) (ChangelogPRDTO, bool)
+
+// NormalizeChangelogStyle aplica o default (conventional) a estilos vazios ou desconhecidos.
+func NormalizeChangelogStyle(style string) string {
+	switch strings.ToLower(strings.TrimSpace(style)) {
+	case ChangelogStyleKeepAChangelog, "keep-a-changelog":
+		return ChangelogStyleKeepAChangelog
+	default:
+		return ChangelogStyleConventional
+	}
+}
+
+// GenerateChangelog agrupa os commits de fromRef..toRef por type Conventional Commits e gera o Markdown.
+// toRef vazio usa HEAD; fromRef vazio usa a tag anterior a toRef (ou todo o histórico, sem tags).
+func (s *Service) GenerateChangelog(ctx context.Context, repoPath string, fromRef string, toRef string, style string, resolvePR ChangelogPRResolver) (ChangelogDTO, error) {
+	preflight, err := s.preflightContext(ctx, repoPath)
+	if err != nil {
+		return ChangelogDTO{}, err
+	}
+	root := preflight.RepoRoot
+
+	toRef = strings.TrimSpace(toRef)
+	if toRef == "" {
+		toRef = "HEAD"
+	}
+	toHash, err := s.resolveRevision(ctx, root, toRef)
+	if err != nil {
+		return ChangelogDTO{}, err
+	}
+
+	version := ChangelogUnreleased
+	if toRef != "HEAD" && s.isTag(ctx, root, toRef) {
+		version = toRef
+	}
+
+	fromRef = strings.TrimSpace(fromRef)
+	if fromRef == "" {
+		// A tag de toRef não pode ser o início do próprio intervalo.
+		base := toHash
+		if version != ChangelogUnreleased {
+			base = toHash + "^"
+		}
+		fromRef = s.latestTag(ctx, root, base)
+	}
+	fromHash := ""
+	if fromRef != "" {
+		if fromHash, err = s.resolveRevision(ctx, root, fromRef); err != nil {
+			return ChangelogDTO{}, err
+		}
+	}
+
+	entries, truncated, err := s.listChangelogEntries(ctx, root, fromHash, toHash)
+	if err != nil {
+		return ChangelogDTO{}, err
+	}
+	if resolvePR != nil {
+		for i := range entries {
+			if entries[i].PR == nil {
+				continue
+			}
+			if pr, ok := resolvePR(entries[i].PR.Number); ok {
+				pr.Number = entries[i].PR.Number
+				entries[i].PR = &pr
+			}
+		}
+	}
+
+	date, _, _, _ := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "log", "-1", "--format=%cs", toHash)
+	changelog := ChangelogDTO{
+		FromRef:     fromRef,
+		ToRef:       toRef,
+		FromHash:    fromHash,
+		ToHash:      toHash,
+		Version:     version,
+		Style:       NormalizeChangelogStyle(style),
+		Date:        strings.TrimSpace(date),
+		CommitCount: len(entries),
+		Truncated:   truncated,
+	}
+	changelog.Sections = groupChangelogEntries(entries, changelog.Style)
+	changelog.Markdown = renderChangelogMarkdown(changelog)
+	return changelog, nil
+}
+
+// WriteChangelogFile grava a seção do changelog no CHANGELOG.md da raiz do repositório:
+// substitui a seção da mesma versão, se existir, ou insere antes da versão mais recente.
+func (s *Service) WriteChangelogFile(repoPath string, changelog ChangelogDTO) (ChangelogFileResultDTO, error) {
+	commandID, startedAt := s.beginCommand("write_changelog")
+	args := []string{"changelog", "write", changelog.Version}
+
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		s.emitCommandFailure(commandID, repoPath, "write_changelog", args, startedAt, err)
+		return ChangelogFileResultDTO{}, err
+	}
+	if strings.TrimSpace(changelog.Markdown) == "" {
+		err := NewBindingError(CodeCommandFailed, "Changelog vazio.", "Gere o changelog antes de gravar o arquivo.")
+		s.emitCommandFailure(commandID, repoPath, "write_changelog", args, startedAt, err)
+		return ChangelogFileResultDTO{}, err
+	}
+
+	result := ChangelogFileResultDTO{Path: filepath.Join(preflight.RepoRoot, ChangelogFileName)}
+	if err := s.executeWrite(
+		preflight.RepoRoot,
+		commandID,
+		"write_changelog",
+		args,
+		startedAt,
+		defaultWriteTimeout,
+		func(ctx context.Context, diag *commandDiagnosticState) error {
+			existing, readErr := os.ReadFile(result.Path)
+			if readErr != nil && !os.IsNotExist(readErr) {
+				return NewBindingError(CodeCommandFailed, "Falha ao ler CHANGELOG.md.", readErr.Error())
+			}
+			result.Created = os.IsNotExist(readErr)
+
+			var content string
+			content, result.Replaced = mergeChangelogSection(string(existing), changelog.Version, changelog.Markdown)
+			if writeErr := writeFileAtomic(result.Path, []byte(content)); writeErr != nil {
+				return NewBindingError(CodeCommandFailed, "Falha ao atualizar CHANGELOG.md.", writeErr.Error())
+			}
+			return nil
+		}); err != nil {
+		return ChangelogFileResultDTO{}, err
+	}
+
+	s.emitPostWriteReconciliation(preflight.RepoRoot, "write_changelog", false)
+	return result, nil
+}
+
+func (s *Service) isTag(ctx context.Context, root string, ref string) bool {
+	if strings.HasPrefix(ref, "-") {
+		return false
+	}
+	_, _, _, err := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "show-ref", "--verify", "--quiet", "refs/tags/"+ref)
+	return err == nil
+}
+
+// latestTag retorna a tag mais recente alcançável por rev (vazio se não houver).
+func (s *Service) latestTag(ctx context.Context, root string, rev string) string {
+	out, _, _, err := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "describe", "--tags", "--abbrev=0", rev)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+func (s *Service) listChangelogEntries(ctx context.Context, root string, fromHash string, toHash string) ([]ChangelogEntryDTO, bool, error) {
+	revRange := toHash
+	if fromHash != "" {
+		revRange = fromHash + ".." + toHash
+	}
+	out, errOut, exitCode, runErr := s.runGit(
+		ctx,
+		defaultReadTimeout,
+		"",
+		"-C", root,
+		"log",
+		"--no-merges",
+		"--pretty=format:%H%x1f%h%x1f%an%x1f%s%x1f%b%x1e",
+		"-n", strconv.Itoa(maxChangelogCommits+1),
+		revRange,
+	)
+	if runErr != nil {
+		return nil, false, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao listar commits do changelog.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+
+	entries := make([]ChangelogEntryDTO, 0)
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\r\n"), "\x1f", 5)
+		if len(fields) < 5 || fields[0] == "" {
+			continue
+		}
+		entries = append(entries, parseChangelogEntry(fields[0], fields[1], fields[2], fields[3], fields[4]))
+	}
+	truncated := len(entries) > maxChangelogCommits
+	if truncated {
+		entries = entries[:maxChangelogCommits]
+	}
+	return entries, truncated, nil
+}
+
+func parseChangelogEntry(hash, shortHash, author, subject, body string) ChangelogEntryDTO {
+	entry := ChangelogEntryDTO{Hash: hash, ShortHash: shortHash, Author: author}
+	subject = strings.TrimSpace(subject)
+	if m := changelogPRRegex.FindStringSubmatch(subject); m != nil {
+		number, _ := strconv.Atoi(m[1])
+		entry.PR = &ChangelogPRDTO{Number: number}
+		subject = strings.TrimSpace(subject[:len(subject)-len(m[0])])
+	}
+
+	if m := conventionalHeaderRegex.FindStringSubmatch(subject); m != nil {
+		entry.Type = strings.ToLower(m[1])
+		entry.Scope = strings.TrimSpace(m[2])
+		entry.Breaking = m[3] == "!"
+		entry.Description = strings.TrimSpace(m[4])
+	} else if m := changelogRevertRegex.FindStringSubmatch(subject); m != nil {
+		entry.Type = "revert"
+		entry.Description = m[1]
+	} else {
+		entry.Description = subject
+	}
+	if strings.Contains(body, "BREAKING CHANGE:") || strings.Contains(body, "BREAKING-CHANGE:") {
+		entry.Breaking = true
+	}
+	return entry
+}
+
+// changelogSectionKey decide a seção da entrada conforme o estilo ("" = fora do changelog).
+func changelogSectionKey(entry ChangelogEntryDTO, style string) string {
+	if style == ChangelogStyleKeepAChangelog {
+		switch {
+		case entry.Breaking:
+			return "changed"
+		case entry.Type == "feat":
+			return "added"
+		case entry.Type == "fix":
+			return "fixed"
+		case entry.Type == "", entry.Type == "perf", entry.Type == "refactor", entry.Type == "revert":
+			return "changed"
+		default:
+			return ""
+		}
+	}
+	if entry.Breaking {
+		return "breaking"
+	}
+	for _, section := range conventionalChangelogSections {
+		if section.key == entry.Type {
+			return section.key
+		}
+	}
+	return changelogOtherSectionKey
+}
+
+func groupChangelogEntries(entries []ChangelogEntryDTO, style string) []ChangelogSectionDTO {
+	layout := conventionalChangelogSections
+	if style == ChangelogStyleKeepAChangelog {
+		layout = keepAChangelogSections
+	}
+	byKey := make(map[string][]ChangelogEntryDTO, len(layout))
+	for _, entry := range entries {
+		if key := changelogSectionKey(entry, style); key != "" {
+			byKey[key] = append(byKey[key], entry)
+		}
+	}
+	sections := make([]ChangelogSectionDTO, 0, len(byKey))
+	for _, section := range layout {
+		if len(byKey[section.key]) == 0 {
+			continue
+		}
+		sections = append(sections, ChangelogSectionDTO{Key: section.key, Title: section.title, Entries: byKey[section.key]})
+	}
+	return sections
+}
+
+func renderChangelogMarkdown(changelog ChangelogDTO) string {
+	var sb strings.Builder
+	switch {
+	case changelog.Style == ChangelogStyleKeepAChangelog && changelog.Version == ChangelogUnreleased:
+		sb.WriteString("## [Unreleased]\n")
+	case changelog.Style == ChangelogStyleKeepAChangelog:
+		fmt.Fprintf(&sb, "## [%s] - %s\n", changelog.Version, changelog.Date)
+	case changelog.Version == ChangelogUnreleased:
+		sb.WriteString("## Unreleased\n")
+	default:
+		fmt.Fprintf(&sb, "## %s (%s)\n", changelog.Version, changelog.Date)
+	}
+	if len(changelog.Sections) == 0 {
+		sb.WriteString("\nNenhuma alteração relevante.\n")
+		return sb.String()
+	}
+	for _, section := range changelog.Sections {
+		fmt.Fprintf(&sb, "\n### %s\n\n", section.Title)
+		for _, entry := range section.Entries {
+			sb.WriteString("- ")
+			if entry.Breaking && changelog.Style == ChangelogStyleKeepAChangelog {
+				sb.WriteString("**BREAKING:** ")
+			}
+			if entry.Scope != "" {
+				fmt.Fprintf(&sb, "**%s:** ", entry.Scope)
+			}
+			sb.WriteString(entry.Description)
+			if entry.PR != nil {
+				if entry.PR.URL != "" {
+					fmt.Fprintf(&sb, " ([#%d](%s))", entry.PR.Number, entry.PR.URL)
+				} else {
+					fmt.Fprintf(&sb, " (#%d)", entry.PR.Number)
+				}
+				if entry.PR.Author != "" {
+					fmt.Fprintf(&sb, " by @%s", entry.PR.Author)
+				}
+			}
+			fmt.Fprintf(&sb, " (%s)\n", entry.ShortHash)
+		}
+	}
+	return sb.String()
+}
+
+// mergeChangelogSection insere section no conteúdo do CHANGELOG.md, trocando a seção da mesma versão.
+func mergeChangelogSection(content string, version string, section string) (string, bool) {
+	section = strings.TrimRight(section, "\n") + "\n"
+	if strings.TrimSpace(content) == "" {
+		return "# Changelog\n\n" + section, false
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	start, end, insertAt := -1, len(lines), -1
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "## ") {
+			continue
+		}
+		if start >= 0 {
+			end = i
+			break
+		}
+		if insertAt < 0 {
+			insertAt = i
+		}
+		if changelogHeadingVersion(line) == version {
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		replacement := section
+		if end < len(lines) {
+			replacement += "\n"
+		}
+		return strings.Join(lines[:start], "") + replacement + strings.Join(lines[end:], ""), true
+	}
+	if insertAt < 0 {
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		return content + "\n" + section, false
+	}
+	return strings.Join(lines[:insertAt], "") + section + "\n" + strings.Join(lines[insertAt:], ""), false
+}
+
+// changelogHeadingVersion extrai a versão de "## v1.2.0 (data)" ou "## [v1.2.0] - data".
+func changelogHeadingVersion(line string) string {
+	heading := strings.TrimSpace(strings.TrimPrefix(line, "## "))
+	if strings.HasPrefix(heading, "[") {
+		if end := strings.Index(heading, "]"); end > 0 {
+			return heading[1:end]
+		}
+	}
+	if fields := strings.Fields(heading); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateChangelogGroupsConventionalCommitsSincePreviousTag(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+
+	commit := func(message string) {
+		t.Helper()
+		runGitOrFail(t, repoRoot, "commit", "--allow-empty", "-m", message)
+	}
+	runGitOrFail(t, repoRoot, "tag", "v1.0.0")
+	commit("feat(api): add search endpoint (#12)")
+	commit("fix: handle empty query")
+	commit("docs: describe search")
+	commit("refactor!: drop legacy client")
+	commit("tweak build script")
+	runGitOrFail(t, repoRoot, "tag", "v1.1.0")
+	commit("feat: unreleased work")
+
+	resolved := make([]int, 0)
+	changelog, err := svc.GenerateChangelog(context.Background(), repoRoot, "", "v1.1.0", "", func(number int) (ChangelogPRDTO, bool) {
+		resolved = append(resolved, number)
+		return ChangelogPRDTO{URL: "https://github.com/orch-labs/orch/pull/12", Author: "ana"}, true
+	})
+	if err != nil {
+		t.Fatalf("GenerateChangelog returned error: %v", err)
+	}
+	if changelog.FromRef != "v1.0.0" || changelog.Version != "v1.1.0" || changelog.CommitCount != 5 || changelog.Style != ChangelogStyleConventional {
+		t.Fatalf("unexpected changelog: %+v", changelog)
+	}
+	var keys []string
+	for _, section := range changelog.Sections {
+		keys = append(keys, section.Key)
+	}
+	if got := strings.Join(keys, ","); got != "breaking,feat,fix,docs,other" {
+		t.Fatalf("unexpected sections: %s", got)
+	}
+	if len(resolved) != 1 || resolved[0] != 12 {
+		t.Fatalf("expected PR #12 to be resolved, got %v", resolved)
+	}
+	for _, want := range []string{
+		"## v1.1.0 (",
+		"### Breaking Changes\n\n- drop legacy client (",
+		"- **api:** add search endpoint ([#12](https://github.com/orch-labs/orch/pull/12)) by @ana (",
+		"### Other Changes\n\n- tweak build script (",
+	} {
+		if !strings.Contains(changelog.Markdown, want) {
+			t.Fatalf("markdown missing %q:\n%s", want, changelog.Markdown)
+		}
+	}
+	if strings.Contains(changelog.Markdown, "unreleased work") {
+		t.Fatalf("commits after toRef should not be included:\n%s", changelog.Markdown)
+	}
+
+	unreleased, err := svc.GenerateChangelog(context.Background(), repoRoot, "", "", ChangelogStyleKeepAChangelog, nil)
+	if err != nil {
+		t.Fatalf("GenerateChangelog(HEAD) returned error: %v", err)
+	}
+	if unreleased.FromRef != "v1.1.0" || unreleased.Version != ChangelogUnreleased || !strings.HasPrefix(unreleased.Markdown, "## [Unreleased]\n\n### Added\n\n- unreleased work (") {
+		t.Fatalf("unexpected unreleased changelog: %+v", unreleased)
+	}
+}
+
+func TestWriteChangelogFileInsertsAndReplacesVersionSection(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	path := filepath.Join(repoRoot, ChangelogFileName)
+
+	first, err := svc.WriteChangelogFile(repoRoot, ChangelogDTO{Version: "v1.0.0", Markdown: "## v1.0.0 (2026-01-01)\n\n### Features\n\n- first (abc1234)\n"})
+	if err != nil || !first.Created || first.Replaced {
+		t.Fatalf("unexpected first write: %+v (%v)", first, err)
+	}
+	if _, err := svc.WriteChangelogFile(repoRoot, ChangelogDTO{Version: ChangelogUnreleased, Markdown: "## Unreleased\n\n- draft (def5678)\n"}); err != nil {
+		t.Fatalf("second write returned error: %v", err)
+	}
+	replaced, err := svc.WriteChangelogFile(repoRoot, ChangelogDTO{Version: ChangelogUnreleased, Markdown: "## Unreleased\n\n- updated (def5678)\n"})
+	if err != nil || replaced.Created || !replaced.Replaced {
+		t.Fatalf("unexpected replace: %+v (%v)", replaced, err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read changelog: %v", err)
+	}
+	want := "# Changelog\n\n## Unreleased\n\n- updated (def5678)\n\n## v1.0.0 (2026-01-01)\n\n### Features\n\n- first (abc1234)\n"
+	if string(content) != want {
+		t.Fatalf("unexpected CHANGELOG.md:\n%q", content)
+	}
+}
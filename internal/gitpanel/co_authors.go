@@ -0,0 +1,70 @@
+package gitpanel
+
+import (
+	"regexp"
+	"strings"
+)
+
+const coAuthorTrailerKey = "Co-authored-by"
+
+var (
+	coAuthorRegex     = regexp.MustCompile(`^([^<>\r\n]+?)\s*<([^<>@\s]+@[^<>@\s]+)>$`)
+	trailerLineRegex  = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*:\s`)
+	coAuthorLineRegex = regexp.MustCompile(`(?im)^co-authored-by:\s*.*<([^<>\s]+)>\s*$`)
+)
+
+// NormalizeCoAuthor valida "Nome <email>" e devolve a forma canônica (espaços colapsados).
+func NormalizeCoAuthor(value string) (string, bool) {
+	match := coAuthorRegex.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return "", false
+	}
+	name := strings.Join(strings.Fields(match[1]), " ")
+	if name == "" {
+		return "", false
+	}
+	return name + " <" + match[2] + ">", true
+}
+
+// AppendCoAuthorTrailers acrescenta um trailer Co-authored-by por co-autor, pulando e-mails que já
+// aparecem na mensagem. Se o último parágrafo já é um bloco de trailers, os novos entram nele.
+func AppendCoAuthorTrailers(message string, coAuthors []string) string {
+	present := map[string]bool{}
+	for _, match := range coAuthorLineRegex.FindAllStringSubmatch(message, -1) {
+		present[strings.ToLower(match[1])] = true
+	}
+
+	lines := []string{}
+	for _, coAuthor := range coAuthors {
+		normalized, ok := NormalizeCoAuthor(coAuthor)
+		if !ok {
+			continue
+		}
+		email := strings.ToLower(normalized[strings.LastIndex(normalized, "<")+1 : len(normalized)-1])
+		if present[email] {
+			continue
+		}
+		present[email] = true
+		lines = append(lines, coAuthorTrailerKey+": "+normalized)
+	}
+	if len(lines) == 0 {
+		return message
+	}
+
+	trimmed := strings.TrimRight(message, " \t\r\n")
+	separator := "\n\n"
+	paragraphs := strings.Split(trimmed, "\n\n")
+	if last := paragraphs[len(paragraphs)-1]; len(paragraphs) > 1 && isTrailerBlock(last) {
+		separator = "\n"
+	}
+	return trimmed + separator + strings.Join(lines, "\n") + "\n"
+}
+
+func isTrailerBlock(paragraph string) bool {
+	for _, line := range strings.Split(strings.TrimSpace(paragraph), "\n") {
+		if !trailerLineRegex.MatchString(strings.TrimSpace(line)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAppendCoAuthorTrailers(t *testing.T) {
+	cases := []struct {
+		message   string
+		coAuthors []string
+		want      string
+	}{
+		{"fix: login", []string{"Ana  Souza <1+ana@users.noreply.github.com>"}, "fix: login\n\nCo-authored-by: Ana Souza <1+ana@users.noreply.github.com>\n"},
+		{"fix: login\n\nBody text.\n\nRefs: #42\n", []string{"Bob <bob@example.com>"}, "fix: login\n\nBody text.\n\nRefs: #42\nCo-authored-by: Bob <bob@example.com>\n"},
+		{"fix: login\n\nCo-authored-by: Bob <BOB@example.com>", []string{"Bob <bob@example.com>"}, "fix: login\n\nCo-authored-by: Bob <BOB@example.com>"},
+		{"fix: login", []string{"not an author"}, "fix: login"},
+	}
+	for _, tc := range cases {
+		if got := AppendCoAuthorTrailers(tc.message, tc.coAuthors); got != tc.want {
+			t.Fatalf("AppendCoAuthorTrailers(%q, %v) = %q, want %q", tc.message, tc.coAuthors, got, tc.want)
+		}
+	}
+}
+
+func TestCommitAddsCoAuthorTrailers(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	if err := os.WriteFile(filepath.Join(repoRoot, "pair.txt"), []byte("pairing\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "add", "--", "pair.txt")
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	if _, err := svc.Commit(repoRoot, "feat: pairing", CommitOptionsDTO{CoAuthors: []string{"ana@example.com"}}); err == nil {
+		t.Fatalf("expected malformed co-author to be rejected")
+	}
+
+	result, err := svc.Commit(repoRoot, "feat: pairing", CommitOptionsDTO{CoAuthors: []string{"Ana <42+ana@users.noreply.github.com>"}})
+	if err != nil || !result.Committed || len(result.CoAuthors) != 1 {
+		t.Fatalf("Commit() returned (%+v, %v)", result, err)
+	}
+	trailers := gitOutputOrFail(t, repoRoot, "log", "-1", "--format=%(trailers:key=Co-authored-by,valueonly)")
+	if strings.TrimSpace(trailers) != "Ana <42+ana@users.noreply.github.com>" {
+		t.Fatalf("unexpected co-author trailers: %q", trailers)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -27,11 +28,13 @@ type queuedWriteCommand struct {
 	run        func(context.Context) error
 	result     chan error
 	diag       *commandDiagnosticState
+	enqueuedAt time.Time
 }
 
 type repoCommandQueue struct {
 	repoRoot string
 	items    chan queuedWriteCommand
+	pending  atomic.Int32 // writes na fila que ainda não começaram
 }
 
 func sleepWithContext(ctx context.Context, d time.Duration) error {
@@ -51,6 +54,12 @@ func sleepWithContext(ctx context.Context, d time.Duration) error {
 }
 
 func (s *Service) executeWrite(repoRoot string, commandID string, action string, args []string, startedAt time.Time, timeout time.Duration, run func(context.Context, *commandDiagnosticState) error) error {
+	return s.executeWriteContext(context.Background(), repoRoot, commandID, action, args, startedAt, timeout, run)
+}
+
+// executeWriteContext é executeWrite com cancelamento do chamador (ex.: JobCancel), tanto na
+// fila quanto durante a execução.
+func (s *Service) executeWriteContext(requestCtx context.Context, repoRoot string, commandID string, action string, args []string, startedAt time.Time, timeout time.Duration, run func(context.Context, *commandDiagnosticState) error) error {
 	if timeout <= 0 {
 		timeout = defaultWriteTimeout
 	}
@@ -67,7 +76,7 @@ func (s *Service) executeWrite(repoRoot string, commandID string, action string,
 	}
 
 	command := queuedWriteCommand{
-		requestCtx: context.Background(),
+		requestCtx: requestCtx,
 		commandID:  commandID,
 		action:     action,
 		timeout:    timeout,
@@ -100,15 +109,26 @@ func (s *Service) enqueueWrite(repoRoot string, command queuedWriteCommand) erro
 		command.result = make(chan error, 1)
 	}
 
+	if err := command.requestCtx.Err(); err != nil {
+		if mapped := queueErrorFromContext(err, "Comando cancelado antes de entrar na fila."); mapped != nil {
+			return mapped
+		}
+		return err
+	}
+
+	// Fila cheia não bloqueia o chamador: devolve E_BUSY para a UI tentar de novo.
+	depth := int(s.writeQueueDepth.Load())
+	if int(queue.pending.Add(1)) > depth {
+		queue.pending.Add(-1)
+		s.execMetrics.recordRejectedWrite(queue.repoRoot)
+		return busyWriteQueueError(queue.repoRoot, depth)
+	}
+	command.enqueuedAt = time.Now()
 	select {
 	case queue.items <- command:
 		s.emitCommandDiagnostic(command.diag, commandStatusQueued, nil)
-	case <-command.requestCtx.Done():
-		if mapped := queueErrorFromContext(command.requestCtx.Err(), "Comando cancelado antes de entrar na fila."); mapped != nil {
-			return mapped
-		}
-		return command.requestCtx.Err()
 	case <-s.shutdownCtx.Done():
+		queue.pending.Add(-1)
 		return serviceClosedError()
 	}
 
@@ -168,6 +188,8 @@ func (s *Service) runRepoQueueWorker(queue *repoCommandQueue) {
 		case <-s.shutdownCtx.Done():
 			return
 		case command := <-queue.items:
+			queue.pending.Add(-1)
+			s.execMetrics.recordWriteWait(queue.repoRoot, time.Since(command.enqueuedAt))
 			if command.run == nil {
 				s.emitCommandDiagnostic(command.diag, commandStatusFailed, NewBindingError(
 					CodeUnknown,
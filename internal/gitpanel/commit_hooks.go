@@ -0,0 +1,342 @@
+package gitpanel
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commitHookTimeout cobre hooks lentos (lint-staged, testes) que rodam dentro do commit.
+const commitHookTimeout = 5 * time.Minute
+
+// commitHookNames lista, em ordem de execução, os hooks disparados por git commit.
+var commitHookNames = []string{"pre-commit", "prepare-commit-msg", "commit-msg", "post-commit"}
+
+var lintStagedConfigFiles = []string{
+	".lintstagedrc",
+	".lintstagedrc.json",
+	".lintstagedrc.yaml",
+	".lintstagedrc.yml",
+	".lintstagedrc.js",
+	".lintstagedrc.cjs",
+	".lintstagedrc.mjs",
+	"lint-staged.config.js",
+	"lint-staged.config.cjs",
+	"lint-staged.config.mjs",
+}
+
+var lefthookConfigFiles = []string{"lefthook.yml", "lefthook.yaml", ".lefthook.yml", ".lefthook.yaml"}
+
+// Commit cria um commit com o conteúdo do stage e retorna a saída estruturada de cada hook.
+// Falha de hook não é erro de binding: o resultado volta com Committed=false e FailedHook preenchido.
+// O mesmo vale para segredos no stage sem override (Secrets.Blocking > 0).
+func (s *Service) Commit(repoPath string, message string, options CommitOptionsDTO) (CommitResultDTO, error) {
+	return s.CommitContext(context.Background(), repoPath, message, options)
+}
+
+// CommitContext é Commit cancelável pelo chamador: o git commit (e seus hooks) é morto no cancelamento.
+func (s *Service) CommitContext(ctx context.Context, repoPath string, message string, options CommitOptionsDTO) (CommitResultDTO, error) {
+	args := []string{"commit", "-F", "-"}
+	if options.NoVerify {
+		args = append(args, "--no-verify")
+	}
+	commandID, startedAt := s.beginCommand("commit")
+
+	if strings.TrimSpace(message) == "" {
+		err := NewBindingError(CodeCommandFailed, "Mensagem de commit vazia.", "")
+		s.emitCommandFailure(commandID, repoPath, "commit", args, startedAt, err)
+		return CommitResultDTO{}, err
+	}
+	coAuthors := make([]string, 0, len(options.CoAuthors))
+	seenCoAuthors := map[string]bool{}
+	for _, coAuthor := range options.CoAuthors {
+		normalized, ok := NormalizeCoAuthor(coAuthor)
+		if !ok {
+			err := NewBindingError(CodeCommandFailed, "Co-autor inválido.", `Use "Nome <email>": `+strings.TrimSpace(coAuthor))
+			s.emitCommandFailure(commandID, repoPath, "commit", args, startedAt, err)
+			return CommitResultDTO{}, err
+		}
+		if key := strings.ToLower(normalized[strings.LastIndex(normalized, "<"):]); !seenCoAuthors[key] {
+			seenCoAuthors[key] = true
+			coAuthors = append(coAuthors, normalized)
+		}
+	}
+	message = AppendCoAuthorTrailers(message, coAuthors)
+
+	preflight, err := s.preflightContext(ctx, repoPath)
+	if err != nil {
+		s.emitCommandFailure(commandID, repoPath, "commit", args, startedAt, err)
+		return CommitResultDTO{}, err
+	}
+
+	result := CommitResultDTO{NoVerify: options.NoVerify, Hooks: []HookRunDTO{}}
+	if len(coAuthors) > 0 {
+		result.CoAuthors = coAuthors
+	}
+	if lintMode := NormalizeCommitLintMode(options.LintMode); lintMode != CommitLintModeOff {
+		lint := lintCommitMessage(message, readCommitLintConfig(preflight.RepoRoot), lintMode)
+		if lint.Blocking {
+			err := NewBindingError(CodeCommitLintFailed, "Mensagem de commit fora do padrão Conventional Commits.", formatCommitLintIssues(lint.Issues))
+			s.emitCommandFailure(commandID, preflight.RepoRoot, "commit", args, startedAt, err)
+			return CommitResultDTO{}, err
+		}
+		result.Lint = &lint
+	}
+
+	secrets, scanErr := s.scanStagedSecrets(ctx, preflight.RepoRoot, "", options.SecretOverrides)
+	if scanErr != nil {
+		s.emitCommandFailure(commandID, preflight.RepoRoot, "commit", args, startedAt, scanErr)
+		return CommitResultDTO{}, scanErr
+	}
+	if len(secrets.Findings) > 0 {
+		result.Secrets = &secrets
+	}
+	if secrets.Blocking > 0 {
+		s.emitCommandFailure(commandID, preflight.RepoRoot, "commit", args, startedAt, NewBindingError(
+			CodeSecretsDetected,
+			"Possíveis segredos no stage bloquearam o commit.",
+			strconv.Itoa(secrets.Blocking)+" achado(s) sem override",
+		))
+		return result, nil
+	}
+
+	runErr := s.executeWriteContext(
+		ctx,
+		preflight.RepoRoot,
+		commandID,
+		"commit",
+		args,
+		startedAt,
+		commitHookTimeout,
+		func(ctx context.Context, diag *commandDiagnosticState) error {
+			// Trace2 em stderr marca início/fim de cada hook com nome e exit code.
+			traceCtx := withGitEnv(ctx, "GIT_TRACE2_EVENT=1")
+			gitArgs := append([]string{"-C", preflight.RepoRoot}, args...)
+			_, errOut, exitCode, commitErr := s.runGit(traceCtx, remainingTimeout(ctx, commitHookTimeout), message, gitArgs...)
+
+			result.Hooks, result.Output = parseHookTrace(errOut)
+			diag.recordAttempt(gitArgs, result.Output, exitCode, 1)
+			if commitErr == nil {
+				return nil
+			}
+			if mapped := queueErrorFromContext(commitErr, "Commit interrompido."); mapped != nil {
+				return mapped
+			}
+			if failed := failedCommitHook(result.Hooks); failed != nil {
+				result.FailedHook = failed.Name
+				return NewBindingError(
+					CodeHookFailed,
+					"Hook "+failed.Name+" rejeitou o commit.",
+					formatCommandFailureDetails(failed.Output, failed.ExitCode, nil),
+				)
+			}
+			return wrapWriteCommandError(
+				CodeCommandFailed,
+				"Falha ao criar commit.",
+				result.Output,
+				exitCode,
+				commitErr,
+			)
+		})
+
+	// Hooks como lint-staged podem reescrever arquivos mesmo quando rejeitam o commit.
+	s.emitPostWriteReconciliation(preflight.RepoRoot, "commit", false)
+
+	if runErr != nil {
+		if result.FailedHook != "" {
+			return result, nil
+		}
+		return CommitResultDTO{}, runErr
+	}
+
+	s.emitHistoryInvalidatedWithContext(preflight.RepoRoot, "post_write_reconcile", "commit")
+	result.Committed = true
+	if out, _, _, headErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", preflight.RepoRoot, "rev-parse", "HEAD"); headErr == nil {
+		result.CommitHash = strings.TrimSpace(out)
+	}
+	return result, nil
+}
+
+// GetHookSetup lista hooks de commit instalados e detecta husky, lint-staged, pre-commit e lefthook.
+func (s *Service) GetHookSetup(repoPath string) (HookSetupDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return HookSetupDTO{}, err
+	}
+	root := preflight.RepoRoot
+
+	setup := HookSetupDTO{Installed: []string{}, Frameworks: []HookFrameworkDTO{}}
+	hooksDir := filepath.Join(resolveRepoGitDir(root), "hooks")
+	if out, _, _, runErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", root, "rev-parse", "--git-path", "hooks"); runErr == nil {
+		if trimmed := strings.TrimSpace(out); trimmed != "" {
+			hooksDir = trimmed
+			if !filepath.IsAbs(hooksDir) {
+				hooksDir = filepath.Join(root, hooksDir)
+			}
+		}
+	}
+	setup.HooksPath = filepath.Clean(hooksDir)
+
+	for _, name := range commitHookNames {
+		info, statErr := os.Stat(filepath.Join(setup.HooksPath, name))
+		if statErr == nil && !info.IsDir() && info.Mode().Perm()&0o111 != 0 {
+			setup.Installed = append(setup.Installed, name)
+		}
+	}
+
+	setup.Frameworks = detectHookFrameworks(root, setup.HooksPath)
+	return setup, nil
+}
+
+func detectHookFrameworks(repoRoot string, hooksPath string) []HookFrameworkDTO {
+	frameworks := make([]HookFrameworkDTO, 0, 2)
+	pkg := readPackageJSONKeys(repoRoot)
+
+	if info, err := os.Stat(filepath.Join(repoRoot, ".husky")); err == nil && info.IsDir() {
+		frameworks = append(frameworks, HookFrameworkDTO{Name: "husky", ConfigPath: ".husky"})
+	} else if strings.Contains(filepath.ToSlash(hooksPath), "/.husky") || pkg["husky"] {
+		frameworks = append(frameworks, HookFrameworkDTO{Name: "husky"})
+	}
+
+	if configPath := firstExistingFile(repoRoot, lintStagedConfigFiles); configPath != "" {
+		frameworks = append(frameworks, HookFrameworkDTO{Name: "lint-staged", ConfigPath: configPath})
+	} else if pkg["lint-staged"] {
+		frameworks = append(frameworks, HookFrameworkDTO{Name: "lint-staged", ConfigPath: "package.json"})
+	}
+
+	if configPath := firstExistingFile(repoRoot, []string{".pre-commit-config.yaml", ".pre-commit-config.yml"}); configPath != "" {
+		frameworks = append(frameworks, HookFrameworkDTO{Name: "pre-commit", ConfigPath: configPath})
+	}
+
+	if configPath := firstExistingFile(repoRoot, lefthookConfigFiles); configPath != "" {
+		frameworks = append(frameworks, HookFrameworkDTO{Name: "lefthook", ConfigPath: configPath})
+	}
+	return frameworks
+}
+
+// readPackageJSONKeys indica quais chaves de configuração/dependência aparecem no package.json da raiz.
+func readPackageJSONKeys(repoRoot string) map[string]bool {
+	keys := make(map[string]bool)
+	raw, err := os.ReadFile(filepath.Join(repoRoot, "package.json"))
+	if err != nil {
+		return keys
+	}
+
+	var pkg map[string]json.RawMessage
+	if json.Unmarshal(raw, &pkg) != nil {
+		return keys
+	}
+	for key := range pkg {
+		keys[key] = true
+	}
+	for _, section := range []string{"dependencies", "devDependencies"} {
+		var deps map[string]string
+		if json.Unmarshal(pkg[section], &deps) != nil {
+			continue
+		}
+		for name := range deps {
+			keys[name] = true
+		}
+	}
+	return keys
+}
+
+func firstExistingFile(repoRoot string, candidates []string) string {
+	for _, candidate := range candidates {
+		if info, err := os.Stat(filepath.Join(repoRoot, candidate)); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	return ""
+}
+
+type trace2Event struct {
+	Event      string   `json:"event"`
+	SID        string   `json:"sid"`
+	ChildID    *int     `json:"child_id"`
+	ChildClass string   `json:"child_class"`
+	HookName   string   `json:"hook_name"`
+	Argv       []string `json:"argv"`
+	Code       int      `json:"code"`
+	TRel       float64  `json:"t_rel"`
+}
+
+// parseHookTrace separa o stderr de um git commit (com GIT_TRACE2_EVENT) em saída por hook
+// e saída restante do git. Eventos de processos git aninhados (disparados pelos hooks) são descartados.
+func parseHookTrace(stderr string) ([]HookRunDTO, string) {
+	hooks := make([]HookRunDTO, 0, 2)
+	outputs := make([][]string, 0, 2)
+	hookByChild := make(map[int]int)
+	general := make([]string, 0)
+	rootSID := ""
+	current := -1
+
+	for _, line := range strings.Split(strings.ReplaceAll(stderr, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(line, `{"event":`) {
+			var event trace2Event
+			if json.Unmarshal([]byte(line), &event) == nil {
+				if rootSID == "" {
+					rootSID = event.SID
+				}
+				if event.SID != rootSID || event.ChildID == nil {
+					continue
+				}
+				switch event.Event {
+				case "child_start":
+					if event.ChildClass != "hook" {
+						continue
+					}
+					hook := HookRunDTO{Name: event.HookName}
+					if len(event.Argv) > 0 {
+						hook.Command = event.Argv[0]
+						if hook.Name == "" {
+							hook.Name = path.Base(filepath.ToSlash(hook.Command))
+						}
+					}
+					hookByChild[*event.ChildID] = len(hooks)
+					current = len(hooks)
+					hooks = append(hooks, hook)
+					outputs = append(outputs, nil)
+				case "child_exit":
+					idx, ok := hookByChild[*event.ChildID]
+					if !ok {
+						continue
+					}
+					hooks[idx].ExitCode = event.Code
+					hooks[idx].Failed = event.Code != 0
+					hooks[idx].DurationMs = int64(event.TRel * 1000)
+					if current == idx {
+						current = -1
+					}
+				}
+				continue
+			}
+		}
+
+		if current >= 0 {
+			outputs[current] = append(outputs[current], line)
+		} else {
+			general = append(general, line)
+		}
+	}
+
+	for idx := range hooks {
+		hooks[idx].Output = strings.TrimSpace(strings.Join(outputs[idx], "\n"))
+	}
+	return hooks, strings.TrimSpace(strings.Join(general, "\n"))
+}
+
+// failedCommitHook retorna o hook que bloqueou o commit (post-commit não bloqueia).
+func failedCommitHook(hooks []HookRunDTO) *HookRunDTO {
+	for idx := range hooks {
+		if hooks[idx].Failed && hooks[idx].Name != "post-commit" {
+			return &hooks[idx]
+		}
+	}
+	return nil
+}
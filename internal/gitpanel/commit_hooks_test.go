@@ -0,0 +1,130 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseHookTraceSplitsOutputPerHook(t *testing.T) {
+	stderr := strings.Join([]string{
+		`{"event":"version","sid":"root","evt":"3"}`,
+		`{"event":"child_start","sid":"root","child_id":0,"child_class":"hook","hook_name":"pre-commit","argv":[".husky/_/pre-commit"]}`,
+		`lint ok`,
+		`{"event":"child_start","sid":"root/nested","child_id":0,"child_class":"git"}`,
+		`warn: trailing space`,
+		`{"event":"child_exit","sid":"root","child_id":0,"code":0,"t_rel":0.25}`,
+		`{"event":"child_start","sid":"root","child_id":1,"child_class":"hook","argv":[".git/hooks/commit-msg",".git/COMMIT_EDITMSG"]}`,
+		`subject must be lowercase`,
+		`{"event":"child_exit","sid":"root","child_id":1,"code":1,"t_rel":0.01}`,
+		`fatal: something else`,
+	}, "\n")
+
+	hooks, output := parseHookTrace(stderr)
+	if len(hooks) != 2 {
+		t.Fatalf("expected 2 hooks, got %+v", hooks)
+	}
+	if hooks[0].Name != "pre-commit" || hooks[0].Failed || hooks[0].DurationMs != 250 || hooks[0].Output != "lint ok\nwarn: trailing space" {
+		t.Fatalf("unexpected pre-commit run: %+v", hooks[0])
+	}
+	if hooks[1].Name != "commit-msg" || !hooks[1].Failed || hooks[1].ExitCode != 1 || hooks[1].Output != "subject must be lowercase" {
+		t.Fatalf("unexpected commit-msg run: %+v", hooks[1])
+	}
+	if output != "fatal: something else" {
+		t.Fatalf("unexpected git output: %q", output)
+	}
+	if failed := failedCommitHook(hooks); failed == nil || failed.Name != "commit-msg" {
+		t.Fatalf("expected commit-msg as failed hook, got %+v", failed)
+	}
+}
+
+func TestCommitReportsFailingHookAndHonorsNoVerify(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell hooks not supported on windows test environment")
+	}
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	hookPath := filepath.Join(repoRoot, ".git", "hooks", "pre-commit")
+	hook := "#!/bin/sh\necho checking staged files\necho lint failed >&2\nexit 3\n"
+	if err := os.WriteFile(hookPath, []byte(hook), 0o755); err != nil {
+		t.Fatalf("failed to write hook: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoRoot, "README.md"), []byte("changed\n"), 0o644); err != nil {
+		t.Fatalf("failed to update file: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "add", "README.md")
+
+	result, err := svc.Commit(repoRoot, "docs: update readme", CommitOptionsDTO{})
+	if err != nil {
+		t.Fatalf("Commit returned error: %v", err)
+	}
+	if result.Committed || result.FailedHook != "pre-commit" {
+		t.Fatalf("expected pre-commit rejection, got %+v", result)
+	}
+	if len(result.Hooks) != 1 || result.Hooks[0].ExitCode != 3 || result.Hooks[0].Output != "checking staged files\nlint failed" {
+		t.Fatalf("unexpected hook runs: %+v", result.Hooks)
+	}
+
+	result, err = svc.Commit(repoRoot, "docs: update readme", CommitOptionsDTO{NoVerify: true})
+	if err != nil {
+		t.Fatalf("Commit(no-verify) returned error: %v", err)
+	}
+	if !result.Committed || result.CommitHash == "" || len(result.Hooks) != 0 {
+		t.Fatalf("expected commit without hooks, got %+v", result)
+	}
+
+	if _, err := svc.Commit(repoRoot, "   ", CommitOptionsDTO{}); err == nil {
+		t.Fatalf("expected error for empty message")
+	}
+}
+
+func TestGetHookSetupDetectsFrameworks(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	if err := os.MkdirAll(filepath.Join(repoRoot, ".husky", "_"), 0o755); err != nil {
+		t.Fatalf("failed to create .husky: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoRoot, ".husky", "_", "pre-commit"), []byte("#!/bin/sh\nnpx lint-staged\n"), 0o755); err != nil {
+		t.Fatalf("failed to write husky hook: %v", err)
+	}
+	pkg := `{"name":"web","lint-staged":{"*.ts":"eslint --fix"},"devDependencies":{"husky":"^9.0.0"}}`
+	if err := os.WriteFile(filepath.Join(repoRoot, "package.json"), []byte(pkg), 0o644); err != nil {
+		t.Fatalf("failed to write package.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoRoot, ".pre-commit-config.yaml"), []byte("repos: []\n"), 0o644); err != nil {
+		t.Fatalf("failed to write pre-commit config: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "config", "core.hooksPath", ".husky/_")
+
+	setup, err := svc.GetHookSetup(repoRoot)
+	if err != nil {
+		t.Fatalf("GetHookSetup returned error: %v", err)
+	}
+	if filepath.ToSlash(setup.HooksPath) != filepath.ToSlash(filepath.Join(repoRoot, ".husky", "_")) {
+		t.Fatalf("unexpected hooks path: %s", setup.HooksPath)
+	}
+	if len(setup.Installed) != 1 || setup.Installed[0] != "pre-commit" {
+		t.Fatalf("unexpected installed hooks: %+v", setup.Installed)
+	}
+
+	got := make(map[string]string)
+	for _, framework := range setup.Frameworks {
+		got[framework.Name] = framework.ConfigPath
+	}
+	want := map[string]string{"husky": ".husky", "lint-staged": "package.json", "pre-commit": ".pre-commit-config.yaml"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected frameworks: %+v", setup.Frameworks)
+	}
+	for name, configPath := range want {
+		if got[name] != configPath {
+			t.Fatalf("unexpected framework %s: got=%q want=%q", name, got[name], configPath)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	CommitLintModeOff   = "off"
+	CommitLintModeWarn  = "warn"
+	CommitLintModeBlock = "block"
+
+	defaultCommitHeaderMaxLength = 100
+)
+
+// defaultCommitTypes segue @commitlint/config-conventional.
+var defaultCommitTypes = []string{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"}
+
+var commitlintConfigFiles = []string{
+	".commitlintrc",
+	".commitlintrc.json",
+	".commitlintrc.yaml",
+	".commitlintrc.yml",
+	".commitlintrc.js",
+	".commitlintrc.cjs",
+	".commitlintrc.mjs",
+	".commitlintrc.ts",
+	"commitlint.config.js",
+	"commitlint.config.cjs",
+	"commitlint.config.mjs",
+	"commitlint.config.ts",
+}
+
+// scopeContainerDirs são diretórios agrupadores ignorados ao inferir o escopo a partir do caminho.
+var scopeContainerDirs = map[string]struct{}{
+	"src": {}, "internal": {}, "pkg": {}, "cmd": {}, "lib": {}, "libs": {},
+	"packages": {}, "apps": {}, "services": {}, "modules": {}, "features": {},
+}
+
+var (
+	conventionalHeaderRegex = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^()]*)\))?(!)?: (.*)$`)
+	commitlintEnumRuleRegex = regexp.MustCompile(`['"]?(type-enum|scope-enum)['"]?\s*:\s*\[\s*(\d)\s*,\s*['"]?(always|never)['"]?\s*,\s*\[([^\]]*)\]`)
+	commitlintMaxRuleRegex  = regexp.MustCompile(`['"]?header-max-length['"]?\s*:\s*\[\s*(\d)\s*,\s*['"]?always['"]?\s*,\s*(\d+)`)
+	commitlintScopeEmpty    = regexp.MustCompile(`['"]?scope-empty['"]?\s*:\s*\[\s*(\d)\s*,\s*['"]?never['"]?`)
+)
+
+// NormalizeCommitLintMode aplica o default (warn) a modos vazios ou desconhecidos.
+func NormalizeCommitLintMode(mode string) string {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case CommitLintModeOff:
+		return CommitLintModeOff
+	case CommitLintModeBlock:
+		return CommitLintModeBlock
+	default:
+		return CommitLintModeWarn
+	}
+}
+
+// GetCommitLintConfig detecta a configuração commitlint do repositório (ou usa config-conventional).
+func (s *Service) GetCommitLintConfig(repoPath string) (CommitLintConfigDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return CommitLintConfigDTO{}, err
+	}
+	return readCommitLintConfig(preflight.RepoRoot), nil
+}
+
+// LintCommitMessage valida a mensagem contra Conventional Commits usando a config do repositório.
+func (s *Service) LintCommitMessage(repoPath string, message string, mode string) (CommitLintResultDTO, error) {
+	config, err := s.GetCommitLintConfig(repoPath)
+	if err != nil {
+		return CommitLintResultDTO{}, err
+	}
+	return lintCommitMessage(message, config, mode), nil
+}
+
+// SuggestCommitScopes sugere types e scopes a partir dos arquivos em stage.
+func (s *Service) SuggestCommitScopes(repoPath string) (CommitSuggestionsDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return CommitSuggestionsDTO{}, err
+	}
+
+	out, errOut, exitCode, runErr := s.runGit(
+		context.Background(),
+		defaultReadTimeout,
+		"",
+		"-C", preflight.RepoRoot,
+		"diff",
+		"--cached",
+		"--name-only",
+		"-z",
+	)
+	if runErr != nil {
+		return CommitSuggestionsDTO{}, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao listar arquivos em stage.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+
+	staged := make([]string, 0)
+	for _, item := range strings.Split(out, "\x00") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			staged = append(staged, trimmed)
+		}
+	}
+	return buildCommitSuggestions(staged, readCommitLintConfig(preflight.RepoRoot)), nil
+}
+
+func readCommitLintConfig(repoRoot string) CommitLintConfigDTO {
+	config := CommitLintConfigDTO{
+		Source:          "default",
+		Types:           append([]string{}, defaultCommitTypes...),
+		HeaderMaxLength: defaultCommitHeaderMaxLength,
+	}
+
+	content := ""
+	if configPath := firstExistingFile(repoRoot, commitlintConfigFiles); configPath != "" {
+		if raw, err := os.ReadFile(filepath.Join(repoRoot, configPath)); err == nil {
+			config.Source = configPath
+			content = string(raw)
+		}
+	} else if readPackageJSONKeys(repoRoot)["commitlint"] {
+		if raw, err := os.ReadFile(filepath.Join(repoRoot, "package.json")); err == nil {
+			config.Source = "package.json"
+			content = string(raw)
+		}
+	}
+	if content == "" {
+		return config
+	}
+
+	// Extração textual das regras: cobre JSON, YAML em fluxo e configs JS/TS simples.
+	for _, match := range commitlintEnumRuleRegex.FindAllStringSubmatch(content, -1) {
+		if match[2] == "0" || match[3] != "always" {
+			continue
+		}
+		values := parseCommitlintList(match[4])
+		switch match[1] {
+		case "type-enum":
+			if len(values) > 0 {
+				config.Types = values
+			}
+		case "scope-enum":
+			config.Scopes = values
+		}
+	}
+	if match := commitlintMaxRuleRegex.FindStringSubmatch(content); match != nil {
+		if match[1] == "0" {
+			config.HeaderMaxLength = 0
+		} else if value, err := strconv.Atoi(match[2]); err == nil {
+			config.HeaderMaxLength = value
+		}
+	}
+	if match := commitlintScopeEmpty.FindStringSubmatch(content); match != nil && match[1] != "0" {
+		config.ScopeRequired = true
+	}
+	return config
+}
+
+func parseCommitlintList(raw string) []string {
+	values := make([]string, 0)
+	for _, item := range strings.Split(raw, ",") {
+		value := strings.Trim(strings.TrimSpace(item), `'"`+"`")
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func lintCommitMessage(message string, config CommitLintConfigDTO, mode string) CommitLintResultDTO {
+	result := CommitLintResultDTO{
+		Mode:   NormalizeCommitLintMode(mode),
+		Issues: []CommitLintIssueDTO{},
+		Config: config,
+	}
+	addIssue := func(rule string, severity string, text string) {
+		result.Issues = append(result.Issues, CommitLintIssueDTO{Rule: rule, Severity: severity, Message: text})
+	}
+
+	lines := strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+	header := strings.TrimSpace(lines[0])
+
+	match := conventionalHeaderRegex.FindStringSubmatch(header)
+	if match == nil {
+		addIssue("header-format", "error", "Cabeçalho deve seguir \"type(scope): subject\".")
+	} else {
+		result.Type = match[1]
+		result.Scope = strings.TrimSpace(match[2])
+		result.Breaking = match[3] == "!"
+		result.Subject = strings.TrimSpace(match[4])
+
+		if !containsString(config.Types, result.Type) {
+			addIssue("type-enum", "error", "Type \""+result.Type+"\" não permitido. Use: "+strings.Join(config.Types, ", ")+".")
+		}
+		if result.Scope == "" && config.ScopeRequired {
+			addIssue("scope-empty", "error", "Scope é obrigatório neste repositório.")
+		}
+		if result.Scope != "" && len(config.Scopes) > 0 {
+			for _, scope := range strings.Split(result.Scope, ",") {
+				if !containsString(config.Scopes, strings.TrimSpace(scope)) {
+					addIssue("scope-enum", "error", "Scope \""+strings.TrimSpace(scope)+"\" não permitido. Use: "+strings.Join(config.Scopes, ", ")+".")
+				}
+			}
+		}
+		if result.Subject == "" {
+			addIssue("subject-empty", "error", "Subject não pode ser vazio.")
+		} else if strings.HasSuffix(result.Subject, ".") {
+			addIssue("subject-full-stop", "warning", "Subject não deve terminar com ponto.")
+		}
+	}
+	if config.HeaderMaxLength > 0 && len([]rune(header)) > config.HeaderMaxLength {
+		addIssue("header-max-length", "error", "Cabeçalho excede "+strconv.Itoa(config.HeaderMaxLength)+" caracteres.")
+	}
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		addIssue("body-leading-blank", "warning", "Deixe uma linha em branco entre cabeçalho e corpo.")
+	}
+
+	result.Valid = true
+	for _, issue := range result.Issues {
+		if issue.Severity == "error" {
+			result.Valid = false
+			break
+		}
+	}
+	result.Blocking = !result.Valid && result.Mode == CommitLintModeBlock
+	return result
+}
+
+func formatCommitLintIssues(issues []CommitLintIssueDTO) string {
+	parts := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		if issue.Severity == "error" {
+			parts = append(parts, issue.Rule+": "+issue.Message)
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+func buildCommitSuggestions(staged []string, config CommitLintConfigDTO) CommitSuggestionsDTO {
+	suggestions := CommitSuggestionsDTO{StagedCount: len(staged), Types: []string{}, Scopes: []string{}}
+	if len(staged) == 0 {
+		return suggestions
+	}
+
+	kindCounts := make(map[string]int)
+	scopeCounts := make(map[string]int)
+	for _, filePath := range staged {
+		kindCounts[classifyCommitPath(filePath)]++
+		if scope := inferCommitScope(filePath); scope != "" {
+			scopeCounts[scope]++
+		}
+	}
+
+	// Um único tipo específico (docs/test/ci/build) cobrindo todo o stage vira a primeira sugestão.
+	ranked := make([]string, 0, len(config.Types))
+	if len(kindCounts) == 1 {
+		for kind := range kindCounts {
+			if kind != "" {
+				ranked = append(ranked, kind)
+			}
+		}
+	}
+	ranked = append(ranked, "feat", "fix", "refactor")
+	for kind := range kindCounts {
+		if kind != "" {
+			ranked = append(ranked, kind)
+		}
+	}
+	seen := make(map[string]struct{})
+	for _, kind := range ranked {
+		if _, exists := seen[kind]; exists || !containsString(config.Types, kind) {
+			continue
+		}
+		seen[kind] = struct{}{}
+		suggestions.Types = append(suggestions.Types, kind)
+	}
+
+	for scope := range scopeCounts {
+		if len(config.Scopes) > 0 && !containsString(config.Scopes, scope) {
+			continue
+		}
+		suggestions.Scopes = append(suggestions.Scopes, scope)
+	}
+	sort.Slice(suggestions.Scopes, func(i, j int) bool {
+		left, right := suggestions.Scopes[i], suggestions.Scopes[j]
+		if scopeCounts[left] != scopeCounts[right] {
+			return scopeCounts[left] > scopeCounts[right]
+		}
+		return left < right
+	})
+	return suggestions
+}
+
+// classifyCommitPath retorna o type implícito no caminho ("" quando não há um óbvio).
+func classifyCommitPath(filePath string) string {
+	normalized := strings.ToLower(filepath.ToSlash(filePath))
+	base := path.Base(normalized)
+
+	switch {
+	case strings.HasPrefix(normalized, ".github/workflows/") || base == ".gitlab-ci.yml" || strings.HasPrefix(normalized, ".circleci/"):
+		return "ci"
+	case strings.HasSuffix(base, "_test.go") || strings.Contains(base, ".test.") || strings.Contains(base, ".spec.") ||
+		strings.HasPrefix(normalized, "test/") || strings.HasPrefix(normalized, "tests/") || strings.Contains(normalized, "/__tests__/"):
+		return "test"
+	case strings.HasSuffix(base, ".md") || strings.HasPrefix(normalized, "docs/"):
+		return "docs"
+	case base == "go.mod" || base == "go.sum" || base == "package.json" || base == "package-lock.json" ||
+		base == "pnpm-lock.yaml" || base == "yarn.lock" || base == "makefile" || strings.HasPrefix(base, "dockerfile"):
+		return "build"
+	default:
+		return ""
+	}
+}
+
+// inferCommitScope usa o primeiro diretório significativo do caminho (ex.: internal/gitpanel/x.go => gitpanel).
+func inferCommitScope(filePath string) string {
+	segments := strings.Split(strings.Trim(filepath.ToSlash(filePath), "/"), "/")
+	if len(segments) < 2 {
+		return ""
+	}
+	dirs := segments[:len(segments)-1]
+	for _, dir := range dirs {
+		if _, container := scopeContainerDirs[strings.ToLower(dir)]; container || strings.HasPrefix(dir, ".") {
+			continue
+		}
+		return strings.ToLower(dir)
+	}
+	return ""
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
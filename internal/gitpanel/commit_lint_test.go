@@ -0,0 +1,112 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLintCommitMessageWithDefaultConfig(t *testing.T) {
+	config := readCommitLintConfig(t.TempDir())
+
+	valid := lintCommitMessage("feat(gitpanel)!: add commit linter\n\nBody text", config, "")
+	if !valid.Valid || valid.Mode != CommitLintModeWarn || valid.Type != "feat" || valid.Scope != "gitpanel" || !valid.Breaking {
+		t.Fatalf("unexpected result for valid message: %+v", valid)
+	}
+
+	invalid := lintCommitMessage("feature: stuff.\nno blank line", config, CommitLintModeBlock)
+	if invalid.Valid || !invalid.Blocking {
+		t.Fatalf("expected blocking result, got %+v", invalid)
+	}
+	rules := make(map[string]string)
+	for _, issue := range invalid.Issues {
+		rules[issue.Rule] = issue.Severity
+	}
+	if rules["type-enum"] != "error" || rules["subject-full-stop"] != "warning" || rules["body-leading-blank"] != "warning" {
+		t.Fatalf("unexpected issues: %+v", invalid.Issues)
+	}
+
+	if freeform := lintCommitMessage("Update stuff", config, CommitLintModeWarn); freeform.Valid || freeform.Blocking {
+		t.Fatalf("expected non-blocking invalid result, got %+v", freeform)
+	}
+}
+
+func TestReadCommitLintConfigParsesRules(t *testing.T) {
+	repoRoot := t.TempDir()
+	config := `module.exports = {
+  extends: ['@commitlint/config-conventional'],
+  rules: {
+    'type-enum': [2, 'always', ['feat', 'fix', 'chore']],
+    'scope-enum': [2, 'always', ['api', 'web']],
+    'scope-empty': [2, 'never'],
+    'header-max-length': [2, 'always', 72],
+  },
+};
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "commitlint.config.js"), []byte(config), 0o644); err != nil {
+		t.Fatalf("failed to write commitlint config: %v", err)
+	}
+
+	parsed := readCommitLintConfig(repoRoot)
+	if parsed.Source != "commitlint.config.js" || strings.Join(parsed.Types, ",") != "feat,fix,chore" ||
+		strings.Join(parsed.Scopes, ",") != "api,web" || !parsed.ScopeRequired || parsed.HeaderMaxLength != 72 {
+		t.Fatalf("unexpected parsed config: %+v", parsed)
+	}
+
+	result := lintCommitMessage("docs(cli): update", parsed, CommitLintModeWarn)
+	rules := make([]string, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		rules = append(rules, issue.Rule)
+	}
+	if strings.Join(rules, ",") != "type-enum,scope-enum" {
+		t.Fatalf("unexpected issues: %+v", result.Issues)
+	}
+}
+
+func TestBuildCommitSuggestionsFromStagedPaths(t *testing.T) {
+	config := readCommitLintConfig(t.TempDir())
+
+	docs := buildCommitSuggestions([]string{"docs/setup.md", "README.md"}, config)
+	if len(docs.Types) == 0 || docs.Types[0] != "docs" {
+		t.Fatalf("expected docs first, got %+v", docs)
+	}
+
+	mixed := buildCommitSuggestions([]string{
+		"internal/gitpanel/service.go",
+		"internal/gitpanel/service_test.go",
+		"frontend/src/features/git-panel/Panel.tsx",
+		"go.mod",
+	}, config)
+	if mixed.Types[0] != "feat" {
+		t.Fatalf("expected feat first for mixed changes, got %+v", mixed.Types)
+	}
+	if strings.Join(mixed.Scopes, ",") != "gitpanel,frontend" {
+		t.Fatalf("unexpected scopes: %+v", mixed.Scopes)
+	}
+}
+
+func TestCommitBlocksInvalidMessageInBlockMode(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	if err := os.WriteFile(filepath.Join(repoRoot, "README.md"), []byte("changed\n"), 0o644); err != nil {
+		t.Fatalf("failed to update file: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "add", "README.md")
+
+	_, err := svc.Commit(repoRoot, "updated readme", CommitOptionsDTO{LintMode: CommitLintModeBlock})
+	if bindingErr := AsBindingError(err); bindingErr == nil || bindingErr.Code != CodeCommitLintFailed {
+		t.Fatalf("expected %s, got %v", CodeCommitLintFailed, err)
+	}
+
+	result, err := svc.Commit(repoRoot, "updated readme", CommitOptionsDTO{LintMode: CommitLintModeWarn})
+	if err != nil {
+		t.Fatalf("Commit(warn) returned error: %v", err)
+	}
+	if !result.Committed || result.Lint == nil || result.Lint.Valid {
+		t.Fatalf("expected commit with lint warning, got %+v", result)
+	}
+}
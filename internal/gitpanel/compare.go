@@ -0,0 +1,136 @@
+package gitpanel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxComparisonCommits limita cada lista de commits da comparação; os totais continuam exatos.
+const maxComparisonCommits = 250
+
+// CompareBranches mostra o que um PR de head para base conteria: commits à frente/atrás,
+// resumo dos arquivos e o diff a partir do merge-base (equivalente a base...head).
+func (s *Service) CompareBranches(ctx context.Context, repoPath string, base string, head string) (BranchComparisonDTO, error) {
+	preflight, err := s.preflightContext(ctx, repoPath)
+	if err != nil {
+		return BranchComparisonDTO{}, err
+	}
+	root := preflight.RepoRoot
+
+	baseHash, err := s.resolveRevision(ctx, root, base)
+	if err != nil {
+		return BranchComparisonDTO{}, err
+	}
+	headHash, err := s.resolveRevision(ctx, root, head)
+	if err != nil {
+		return BranchComparisonDTO{}, err
+	}
+
+	out, errOut, exitCode, runErr := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "merge-base", baseHash, headHash)
+	mergeBase := strings.TrimSpace(out)
+	if runErr != nil || mergeBase == "" {
+		return BranchComparisonDTO{}, NewBindingError(
+			CodeCommandFailed,
+			"Os branches não têm histórico em comum.",
+			strings.TrimSpace(fmt.Sprintf("%s...%s %s", base, head, formatCommandFailureDetails(errOut, exitCode, runErr))),
+		)
+	}
+
+	behind, ahead, err := s.countLeftRight(ctx, root, baseHash, headHash)
+	if err != nil {
+		return BranchComparisonDTO{}, err
+	}
+	aheadCommits, err := s.listRangeCommits(ctx, root, baseHash, headHash)
+	if err != nil {
+		return BranchComparisonDTO{}, err
+	}
+	behindCommits, err := s.listRangeCommits(ctx, root, headHash, baseHash)
+	if err != nil {
+		return BranchComparisonDTO{}, err
+	}
+
+	diff, err := s.GetRangeDiff(ctx, root, mergeBase, headHash, "", 0)
+	if err != nil {
+		return BranchComparisonDTO{}, err
+	}
+
+	result := BranchComparisonDTO{
+		Base:             strings.TrimSpace(base),
+		Head:             strings.TrimSpace(head),
+		BaseHash:         baseHash,
+		HeadHash:         headHash,
+		MergeBase:        mergeBase,
+		Ahead:            ahead,
+		Behind:           behind,
+		AheadCommits:     aheadCommits,
+		BehindCommits:    behindCommits,
+		CommitsTruncated: len(aheadCommits) < ahead || len(behindCommits) < behind,
+		Files:            make([]ComparisonFileDTO, 0, len(diff.Files)),
+		Diff:             diff,
+	}
+	for _, file := range diff.Files {
+		result.Files = append(result.Files, ComparisonFileDTO{
+			Path:      file.Path,
+			OldPath:   file.OldPath,
+			Status:    file.Status,
+			Additions: file.Additions,
+			Deletions: file.Deletions,
+			IsBinary:  file.IsBinary,
+		})
+		result.Additions += file.Additions
+		result.Deletions += file.Deletions
+	}
+	return result, nil
+}
+
+// countLeftRight retorna quantos commits só existem em left e quantos só existem em right.
+func (s *Service) countLeftRight(ctx context.Context, root string, left string, right string) (int, int, error) {
+	out, errOut, exitCode, runErr := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "rev-list", "--left-right", "--count", left+"..."+right)
+	if runErr != nil {
+		return 0, 0, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao contar commits entre os branches.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 0, 0, NewBindingError(CodeCommandFailed, "Falha ao contar commits entre os branches.", fmt.Sprintf("Saída inesperada: %q", strings.TrimSpace(out)))
+	}
+	leftCount, leftErr := strconv.Atoi(fields[0])
+	rightCount, rightErr := strconv.Atoi(fields[1])
+	if leftErr != nil || rightErr != nil {
+		return 0, 0, NewBindingError(CodeCommandFailed, "Falha ao contar commits entre os branches.", fmt.Sprintf("Saída inesperada: %q", strings.TrimSpace(out)))
+	}
+	return leftCount, rightCount, nil
+}
+
+// listRangeCommits lista os commits alcançáveis por to e não por from (from..to).
+func (s *Service) listRangeCommits(ctx context.Context, root string, from string, to string) ([]HistoryItemDTO, error) {
+	out, errOut, exitCode, runErr := s.runGit(
+		ctx,
+		defaultReadTimeout,
+		"",
+		"-C", root,
+		"log",
+		"--date=iso-strict",
+		"--pretty=format:%H%x1f%h%x1f%an%x1f%aI%x1f%ae%x1f%s%x1e",
+		"--numstat",
+		"-n", strconv.Itoa(maxComparisonCommits),
+		from+".."+to,
+	)
+	if runErr != nil {
+		return nil, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao listar commits entre os branches.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+	items := parseHistoryItems(out)
+	if items == nil {
+		items = make([]HistoryItemDTO, 0)
+	}
+	return items, nil
+}
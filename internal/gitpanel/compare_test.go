@@ -0,0 +1,49 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompareBranchesPreviewsPullRequestContents(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+
+	runGitOrFail(t, repoRoot, "branch", "-M", "main")
+	runGitOrFail(t, repoRoot, "checkout", "-b", "feature")
+	if err := os.WriteFile(filepath.Join(repoRoot, "feature.txt"), []byte("a\nb\n"), 0o644); err != nil {
+		t.Fatalf("failed to create feature file: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "add", "--", "feature.txt")
+	runGitOrFail(t, repoRoot, "commit", "-m", "add feature")
+	runGitOrFail(t, repoRoot, "checkout", "main")
+	if err := os.WriteFile(filepath.Join(repoRoot, "README.md"), []byte("hello\nmain\n"), 0o644); err != nil {
+		t.Fatalf("failed to modify README: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "commit", "-am", "main moves on")
+
+	result, err := svc.CompareBranches(context.Background(), repoRoot, "main", "feature")
+	if err != nil {
+		t.Fatalf("CompareBranches returned error: %v", err)
+	}
+	if result.Ahead != 1 || result.Behind != 1 || result.CommitsTruncated {
+		t.Fatalf("unexpected ahead/behind: %+v", result)
+	}
+	if len(result.AheadCommits) != 1 || result.AheadCommits[0].Subject != "add feature" || result.BehindCommits[0].Subject != "main moves on" {
+		t.Fatalf("unexpected commit lists: ahead=%+v behind=%+v", result.AheadCommits, result.BehindCommits)
+	}
+	// Mudanças só do base não entram no diff do PR.
+	if len(result.Files) != 1 || result.Files[0].Path != "feature.txt" || result.Files[0].Status != "added" || result.Additions != 2 {
+		t.Fatalf("unexpected file summary: %+v additions=%d", result.Files, result.Additions)
+	}
+	if !strings.Contains(result.Diff.Raw, "+++ b/feature.txt") || strings.Contains(result.Diff.Raw, "README.md") {
+		t.Fatalf("unexpected diff: %q", result.Diff.Raw)
+	}
+
+	if _, err := svc.CompareBranches(context.Background(), repoRoot, "main", "missing"); err == nil {
+		t.Fatalf("expected unknown head to fail")
+	}
+}
@@ -0,0 +1,212 @@
+package gitpanel
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultContributorRangeDays = 90
+	maxContributorRangeDays     = 3650
+	defaultContributorPageSize  = 20
+	maxContributorPageSize      = 100
+)
+
+type contributorStatsCacheEntry struct {
+	items      []ContributorStatsDTO
+	commits    int
+	computedAt string
+	expiresAt  time.Time
+}
+
+// GetContributorStats agrega, por autor (respeitando .mailmap), commits, linhas e dias ativos
+// dos últimos rangeDays no HEAD, sem merges. A lista completa fica em cache (mesmo TTL das
+// métricas do repositório) e é paginada por offset; cursor vazio = primeira página.
+func (s *Service) GetContributorStats(repoPath string, rangeDays int, cursor string, limit int, refresh bool) (ContributorStatsPageDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return ContributorStatsPageDTO{}, err
+	}
+	offset, err := parseOffsetCursor(cursor)
+	if err != nil {
+		return ContributorStatsPageDTO{}, err
+	}
+	if rangeDays <= 0 {
+		rangeDays = defaultContributorRangeDays
+	}
+	rangeDays = min(rangeDays, maxContributorRangeDays)
+	if limit <= 0 {
+		limit = defaultContributorPageSize
+	}
+	limit = min(limit, maxContributorPageSize)
+
+	root := preflight.RepoRoot
+	cacheKey := root + "\x1f" + strconv.Itoa(rangeDays)
+	entry, ok := s.getCachedContributorStats(cacheKey)
+	if refresh || !ok {
+		entry, err = s.computeContributorStats(root, rangeDays)
+		if err != nil {
+			return ContributorStatsPageDTO{}, err
+		}
+		s.setCachedContributorStats(cacheKey, entry)
+	}
+
+	page := ContributorStatsPageDTO{
+		RepoRoot:          root,
+		RangeDays:         rangeDays,
+		Items:             []ContributorStatsDTO{},
+		TotalContributors: len(entry.items),
+		TotalCommits:      entry.commits,
+		ComputedAt:        entry.computedAt,
+	}
+	if offset < len(entry.items) {
+		end := min(offset+limit, len(entry.items))
+		// Cópia: o chamador enriquece os itens (login GitHub) e não pode alterar o cache.
+		page.Items = append(page.Items, entry.items[offset:end]...)
+		if end < len(entry.items) {
+			page.HasMore = true
+			page.NextCursor = strconv.Itoa(end)
+		}
+	}
+	return page, nil
+}
+
+func (s *Service) computeContributorStats(root string, rangeDays int) (contributorStatsCacheEntry, error) {
+	now := time.Now()
+	entry := contributorStatsCacheEntry{
+		items:      []ContributorStatsDTO{},
+		computedAt: now.UTC().Format(time.RFC3339),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), repoStatsTimeout)
+	defer cancel()
+
+	// Repositório sem commits: nada a agregar.
+	if _, _, _, headErr := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "rev-parse", "--verify", "--quiet", "HEAD"); headErr != nil {
+		return entry, nil
+	}
+
+	since := now.AddDate(0, 0, -rangeDays)
+	out, errOut, exitCode, runErr := s.runGit(
+		ctx,
+		repoStatsTimeout,
+		"",
+		"-C", root,
+		"log",
+		"--no-merges",
+		"--since="+strconv.FormatInt(since.Unix(), 10),
+		"--format=%x1e%H%x1f%aN%x1f%aE%x1f%aI",
+		"--numstat",
+		"HEAD",
+	)
+	if runErr != nil {
+		return contributorStatsCacheEntry{}, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao calcular estatísticas de contribuidores.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+	entry.items, entry.commits = parseContributorLog(out)
+	return entry, nil
+}
+
+// parseContributorLog lê registros "\x1e hash \x1f nome \x1f email \x1f data" seguidos de numstat.
+// O log vem do mais recente para o mais antigo, então o primeiro commit visto de cada autor é o último.
+func parseContributorLog(out string) ([]ContributorStatsDTO, int) {
+	byAuthor := make(map[string]*ContributorStatsDTO)
+	activeDays := make(map[string]map[string]struct{})
+	order := make([]string, 0)
+	commits := 0
+
+	for _, record := range strings.Split(out, "\x1e") {
+		header, numstat, _ := strings.Cut(strings.TrimLeft(record, "\r\n"), "\n")
+		fields := strings.Split(strings.TrimRight(header, "\r"), "\x1f")
+		if len(fields) != 4 || strings.TrimSpace(fields[0]) == "" {
+			continue
+		}
+		hash, name, email, authoredAt := fields[0], strings.TrimSpace(fields[1]), strings.TrimSpace(fields[2]), fields[3]
+		key := strings.ToLower(email)
+		if key == "" {
+			key = strings.ToLower(name)
+		}
+
+		contributor, ok := byAuthor[key]
+		if !ok {
+			contributor = &ContributorStatsDTO{Name: name, Email: email, LastCommitAt: authoredAt, LastCommitHash: hash}
+			byAuthor[key] = contributor
+			activeDays[key] = make(map[string]struct{})
+			order = append(order, key)
+		}
+		commits++
+		contributor.Commits++
+		contributor.FirstCommitAt = authoredAt
+		// Dia no fuso do autor: um commit às 23h não conta no dia seguinte de quem está em UTC.
+		if len(authoredAt) >= 10 {
+			activeDays[key][authoredAt[:10]] = struct{}{}
+		}
+
+		for _, line := range strings.Split(numstat, "\n") {
+			parts := strings.SplitN(strings.TrimSpace(line), "\t", 3)
+			if len(parts) != 3 {
+				continue
+			}
+			// Binários aparecem como "-\t-".
+			added, addErr := strconv.Atoi(parts[0])
+			removed, removeErr := strconv.Atoi(parts[1])
+			if addErr == nil && removeErr == nil {
+				contributor.Additions += added
+				contributor.Deletions += removed
+			}
+		}
+	}
+
+	items := make([]ContributorStatsDTO, 0, len(order))
+	for _, key := range order {
+		contributor := byAuthor[key]
+		contributor.ActiveDays = len(activeDays[key])
+		items = append(items, *contributor)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Commits != items[j].Commits {
+			return items[i].Commits > items[j].Commits
+		}
+		return items[i].Additions+items[i].Deletions > items[j].Additions+items[j].Deletions
+	})
+	return items, commits
+}
+
+func parseOffsetCursor(cursor string) (int, error) {
+	normalized := strings.TrimSpace(cursor)
+	if normalized == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(normalized)
+	if err != nil || offset < 0 {
+		return 0, NewBindingError(
+			CodeInvalidCursor,
+			"Cursor de paginação inválido.",
+			"O cursor deve ser o valor de nextCursor da página anterior.",
+		)
+	}
+	return offset, nil
+}
+
+func (s *Service) getCachedContributorStats(cacheKey string) (contributorStatsCacheEntry, bool) {
+	s.cacheMu.RLock()
+	entry, ok := s.contributorStatsCache[cacheKey]
+	s.cacheMu.RUnlock()
+	if !ok || time.Now().After(entry.expiresAt) {
+		return contributorStatsCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (s *Service) setCachedContributorStats(cacheKey string, entry contributorStatsCacheEntry) {
+	entry.expiresAt = time.Now().Add(repoStatsCacheTTL)
+	s.cacheMu.Lock()
+	s.contributorStatsCache[cacheKey] = entry
+	s.cacheMu.Unlock()
+}
@@ -0,0 +1,56 @@
+package gitpanel
+
+import (
+	"context"
+	"strings"
+)
+
+// Níveis de detalhe das leituras de status e histórico. O summary atende atualizações de
+// alta frequência (badge da status bar) com payload mínimo pela ponte do Wails.
+const (
+	DetailFull    = "full"
+	DetailSummary = "summary"
+)
+
+// NormalizeDetailLevel aceita "summary"; qualquer outro valor (inclusive vazio) vira "full".
+func NormalizeDetailLevel(detail string) string {
+	if strings.EqualFold(strings.TrimSpace(detail), DetailSummary) {
+		return DetailSummary
+	}
+	return DetailFull
+}
+
+// ApplyStatusDetail preenche os contadores e, no summary, troca as listas de arquivos por
+// listas vazias. Não altera os slices originais (que podem estar em cache).
+func ApplyStatusDetail(status StatusDTO, detail string) StatusDTO {
+	status.StagedCount = len(status.Staged)
+	status.UnstagedCount = len(status.Unstaged)
+	status.ConflictedCount = len(status.Conflicted)
+	status.Detail = NormalizeDetailLevel(detail)
+	if status.Detail == DetailSummary {
+		status.Staged = []FileChangeDTO{}
+		status.Unstaged = []FileChangeDTO{}
+		status.Conflicted = []ConflictFileDTO{}
+	}
+	return status
+}
+
+// GetStatusWithDetail retorna o status no escopo com o nível de detalhe pedido.
+func (s *Service) GetStatusWithDetail(repoPath string, scope string, detail string) (StatusDTO, error) {
+	return s.GetStatusWithDetailContext(context.Background(), repoPath, scope, detail)
+}
+
+// GetStatusWithDetailContext é GetStatusWithDetail com o git status cancelável pelo chamador.
+func (s *Service) GetStatusWithDetailContext(ctx context.Context, repoPath string, scope string, detail string) (StatusDTO, error) {
+	status, err := s.getStatusInScopeContext(ctx, repoPath, scope)
+	if err != nil {
+		return StatusDTO{}, err
+	}
+	return ApplyStatusDetail(status, detail), nil
+}
+
+// GetHistoryWithDetail retorna a página de histórico; no summary o git log roda sem --numstat,
+// então additions/deletions/changedFiles vêm zerados.
+func (s *Service) GetHistoryWithDetail(repoPath string, cursor string, limit int, search string, scope string, detail string) (HistoryPageDTO, error) {
+	return s.getHistory(repoPath, cursor, limit, search, scope, NormalizeDetailLevel(detail))
+}
@@ -0,0 +1,56 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStatusAndHistorySummaryDetail(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	if err := os.WriteFile(filepath.Join(repoRoot, "README.md"), []byte("changed\n"), 0o644); err != nil {
+		t.Fatalf("failed to modify file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoRoot, "new.txt"), []byte("new\n"), 0o644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "add", "--", "new.txt")
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	full, err := svc.GetStatusWithDetail(repoRoot, "", "")
+	if err != nil {
+		t.Fatalf("GetStatusWithDetail(full) returned error: %v", err)
+	}
+	if full.Detail != DetailFull || len(full.Staged) != 1 || full.StagedCount != 1 || full.UnstagedCount != 1 {
+		t.Fatalf("unexpected full status: %+v", full)
+	}
+
+	summary, err := svc.GetStatusWithDetail(repoRoot, "", "Summary")
+	if err != nil {
+		t.Fatalf("GetStatusWithDetail(summary) returned error: %v", err)
+	}
+	if summary.Detail != DetailSummary || summary.Branch != full.Branch || summary.StagedCount != 1 || summary.UnstagedCount != 1 {
+		t.Fatalf("unexpected summary status: %+v", summary)
+	}
+	if summary.Staged == nil || len(summary.Staged) != 0 || len(summary.Unstaged) != 0 {
+		t.Fatalf("expected empty (non-nil) file lists in summary, got %+v", summary)
+	}
+	if again, _ := svc.GetStatus(repoRoot); len(again.Staged) != 1 {
+		t.Fatalf("summary must not clear the cached status, got %+v", again)
+	}
+
+	history, err := svc.GetHistoryWithDetail(repoRoot, "", 10, "", "", DetailSummary)
+	if err != nil || len(history.Items) != 1 || history.Detail != DetailSummary {
+		t.Fatalf("unexpected summary history: %+v (%v)", history, err)
+	}
+	if item := history.Items[0]; item.ChangedFiles != 0 || item.Subject != "initial commit" {
+		t.Fatalf("expected summary item without numstat, got %+v", item)
+	}
+	fullHistory, err := svc.GetHistoryWithDetail(repoRoot, "", 10, "", "", DetailFull)
+	if err != nil || fullHistory.Items[0].ChangedFiles != 1 {
+		t.Fatalf("expected full history with numstat, got %+v (%v)", fullHistory, err)
+	}
+}
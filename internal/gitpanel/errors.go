@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"orch/internal/i18n"
 )
 
 const (
@@ -18,6 +20,13 @@ const (
 	CodeInvalidCursor      = "E_INVALID_CURSOR"
 	CodePatchInvalid       = "E_PATCH_INVALID"
 	CodeCommandFailed      = "E_COMMAND_FAILED"
+	CodeHookFailed         = "E_HOOK_FAILED"
+	CodeHookBypassDenied   = "E_HOOK_BYPASS_DENIED"
+	CodeCommitLintFailed   = "E_COMMIT_LINT_FAILED"
+	CodeSecretsDetected    = "E_SECRETS_DETECTED"
+	CodePushNotConfirmed   = "E_PUSH_NOT_CONFIRMED"
+	CodeToolUnavailable    = "E_TOOL_UNAVAILABLE"
+	CodeBusy               = "E_BUSY"
 	CodeTimeout            = "E_TIMEOUT"
 	CodeCanceled           = "E_CANCELED"
 	CodeUnknown            = "E_UNKNOWN"
@@ -28,6 +37,8 @@ type BindingError struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
+	// MessageID aponta a mensagem genérica do código no catálogo i18n (texto no idioma do usuário).
+	MessageID string `json:"messageId,omitempty"`
 }
 
 func (e *BindingError) Error() string {
@@ -43,9 +54,10 @@ func (e *BindingError) Error() string {
 
 func NewBindingError(code, message, details string) *BindingError {
 	return &BindingError{
-		Code:    strings.TrimSpace(code),
-		Message: strings.TrimSpace(message),
-		Details: strings.TrimSpace(details),
+		Code:      strings.TrimSpace(code),
+		Message:   strings.TrimSpace(message),
+		Details:   strings.TrimSpace(details),
+		MessageID: i18n.ErrorID(code),
 	}
 }
 
@@ -0,0 +1,311 @@
+package gitpanel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxExecWorkers limita quantos processos git o painel roda ao mesmo tempo.
+	maxExecWorkers = 16
+)
+
+// DefaultExecWorkers é o tamanho padrão do pool de processos git.
+var DefaultExecWorkers = defaultExecWorkers()
+
+// DefaultWriteQueueDepth é quantos comandos write podem aguardar por repositório.
+const DefaultWriteQueueDepth = 16
+
+func defaultExecWorkers() int {
+	workers := runtime.NumCPU()
+	if workers < 2 {
+		return 2
+	}
+	if workers > 8 {
+		return 8
+	}
+	return workers
+}
+
+// execPool é um semáforo FIFO de processos git: leituras e escritas esperam um worker livre
+// (ou o cancelamento do contexto); a serialização por repositório fica com a fila de writes.
+type execPool struct {
+	mu      sync.Mutex
+	workers int
+	running int
+	waiters []chan struct{}
+}
+
+func newExecPool(workers int) *execPool {
+	return &execPool{workers: workers}
+}
+
+func (p *execPool) acquire(ctx context.Context) (time.Duration, error) {
+	started := time.Now()
+	p.mu.Lock()
+	if p.running < p.workers && len(p.waiters) == 0 {
+		p.running++
+		p.mu.Unlock()
+		return 0, nil
+	}
+	ready := make(chan struct{})
+	p.waiters = append(p.waiters, ready)
+	p.mu.Unlock()
+
+	select {
+	case <-ready:
+		return time.Since(started), nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		for i, waiter := range p.waiters {
+			if waiter == ready {
+				p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+				p.mu.Unlock()
+				return time.Since(started), ctx.Err()
+			}
+		}
+		p.mu.Unlock()
+		// O worker já tinha sido entregue: devolve.
+		p.release()
+		return time.Since(started), ctx.Err()
+	}
+}
+
+func (p *execPool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.waiters) > 0 && p.running <= p.workers {
+		next := p.waiters[0]
+		p.waiters = p.waiters[1:]
+		close(next) // o worker passa direto para o próximo da fila
+		return
+	}
+	p.running--
+}
+
+func (p *execPool) resize(workers int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.workers = workers
+	for p.running < p.workers && len(p.waiters) > 0 {
+		next := p.waiters[0]
+		p.waiters = p.waiters[1:]
+		p.running++
+		close(next)
+	}
+}
+
+func (p *execPool) load() (workers, running, waiting int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.workers, p.running, len(p.waiters)
+}
+
+type execRepoCounter struct {
+	commands       uint64
+	queueWait      time.Duration
+	maxQueueWait   time.Duration
+	exec           time.Duration
+	maxExec        time.Duration
+	writes         uint64
+	writeWait      time.Duration
+	maxWriteWait   time.Duration
+	rejectedWrites uint64
+}
+
+// execMetrics mede espera e duração dos processos git por repositório.
+type execMetrics struct {
+	mu    sync.Mutex
+	since time.Time
+	repos map[string]*execRepoCounter
+}
+
+func newExecMetrics() *execMetrics {
+	m := &execMetrics{}
+	m.reset()
+	return m
+}
+
+func (m *execMetrics) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.since = time.Now()
+	m.repos = make(map[string]*execRepoCounter)
+}
+
+func (m *execMetrics) counterLocked(repoRoot string) *execRepoCounter {
+	counter, ok := m.repos[repoRoot]
+	if !ok {
+		counter = &execRepoCounter{}
+		m.repos[repoRoot] = counter
+	}
+	return counter
+}
+
+func (m *execMetrics) recordExec(repoRoot string, wait, exec time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	counter := m.counterLocked(repoRoot)
+	counter.commands++
+	counter.queueWait += wait
+	counter.exec += exec
+	if wait > counter.maxQueueWait {
+		counter.maxQueueWait = wait
+	}
+	if exec > counter.maxExec {
+		counter.maxExec = exec
+	}
+}
+
+func (m *execMetrics) recordWriteWait(repoRoot string, wait time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	counter := m.counterLocked(repoRoot)
+	counter.writes++
+	counter.writeWait += wait
+	if wait > counter.maxWriteWait {
+		counter.maxWriteWait = wait
+	}
+}
+
+func (m *execMetrics) recordRejectedWrite(repoRoot string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counterLocked(repoRoot).rejectedWrites++
+}
+
+func averageMs(total time.Duration, count uint64) float64 {
+	if count == 0 {
+		return 0
+	}
+	return float64(total.Microseconds()) / float64(count) / 1000
+}
+
+// repoFromGitArgs extrai o repositório do "-C <path>" dos argumentos (vazio se ausente).
+func repoFromGitArgs(args []string) string {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "-C" {
+			return filepath.Clean(strings.TrimSpace(args[i+1]))
+		}
+	}
+	return ""
+}
+
+// pooled envolve o runner para que todo processo git passe pelo pool e seja medido.
+func (s *Service) pooled(run gitRunner) gitRunner {
+	return func(ctx context.Context, timeout time.Duration, stdin string, args ...string) (string, string, int, error) {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		repoRoot := repoFromGitArgs(args)
+		wait, err := s.execPool.acquire(ctx)
+		if err != nil {
+			if mapped := queueErrorFromContext(err, "Comando cancelado aguardando um worker do Git Panel."); mapped != nil {
+				return "", "", -1, mapped
+			}
+			return "", "", -1, err
+		}
+		started := time.Now()
+		stdout, stderr, exitCode, runErr := run(ctx, timeout, stdin, args...)
+		s.execPool.release()
+		s.execMetrics.recordExec(repoRoot, wait, time.Since(started))
+		return stdout, stderr, exitCode, runErr
+	}
+}
+
+func busyWriteQueueError(repoRoot string, depth int) error {
+	return NewBindingError(
+		CodeBusy,
+		"Git Panel ocupado: fila de comandos do repositório cheia.",
+		fmt.Sprintf("%s já tem %d comandos aguardando; tente novamente em instantes.", repoRoot, depth),
+	)
+}
+
+// SetExecLimits define o tamanho do pool de processos git e quantos writes podem aguardar por
+// repositório; valores <= 0 voltam ao padrão.
+func (s *Service) SetExecLimits(workers, writeQueueDepth int) {
+	if workers <= 0 {
+		workers = DefaultExecWorkers
+	}
+	if workers > maxExecWorkers {
+		workers = maxExecWorkers
+	}
+	if writeQueueDepth <= 0 {
+		writeQueueDepth = DefaultWriteQueueDepth
+	}
+	if writeQueueDepth > writeQueueBufferSize {
+		writeQueueDepth = writeQueueBufferSize
+	}
+	s.execPool.resize(workers)
+	s.writeQueueDepth.Store(int32(writeQueueDepth))
+}
+
+// GetExecMetrics retorna a ocupação do pool e, por repositório, espera na fila e duração dos
+// processos git desde o último reset.
+func (s *Service) GetExecMetrics() ExecMetricsDTO {
+	workers, running, waiting := s.execPool.load()
+	result := ExecMetricsDTO{
+		Workers:         workers,
+		WriteQueueDepth: int(s.writeQueueDepth.Load()),
+		Running:         running,
+		Waiting:         waiting,
+	}
+
+	queued := make(map[string]int)
+	s.queueMu.Lock()
+	for root, queue := range s.queues {
+		queued[root] = int(queue.pending.Load())
+	}
+	s.queueMu.Unlock()
+
+	s.execMetrics.mu.Lock()
+	result.Since = s.execMetrics.since.UTC().Format(time.RFC3339)
+	var totalWait, totalExec time.Duration
+	for root, counter := range s.execMetrics.repos {
+		result.Commands += counter.commands
+		result.RejectedWrites += counter.rejectedWrites
+		totalWait += counter.queueWait
+		totalExec += counter.exec
+		result.Repos = append(result.Repos, ExecRepoMetricsDTO{
+			RepoRoot:            root,
+			Commands:            counter.commands,
+			AvgQueueWaitMs:      averageMs(counter.queueWait, counter.commands),
+			MaxQueueWaitMs:      counter.maxQueueWait.Milliseconds(),
+			AvgExecMs:           averageMs(counter.exec, counter.commands),
+			MaxExecMs:           counter.maxExec.Milliseconds(),
+			Writes:              counter.writes,
+			AvgWriteQueueWaitMs: averageMs(counter.writeWait, counter.writes),
+			MaxWriteQueueWaitMs: counter.maxWriteWait.Milliseconds(),
+			RejectedWrites:      counter.rejectedWrites,
+			QueuedWrites:        queued[root],
+		})
+	}
+	s.execMetrics.mu.Unlock()
+
+	result.AvgQueueWaitMs = averageMs(totalWait, result.Commands)
+	result.AvgExecMs = averageMs(totalExec, result.Commands)
+	if result.Repos == nil {
+		result.Repos = []ExecRepoMetricsDTO{}
+	}
+	// Repositórios que mais consomem tempo de git primeiro.
+	sort.Slice(result.Repos, func(i, j int) bool {
+		left := result.Repos[i].AvgExecMs * float64(result.Repos[i].Commands)
+		right := result.Repos[j].AvgExecMs * float64(result.Repos[j].Commands)
+		if left != right {
+			return left > right
+		}
+		return result.Repos[i].RepoRoot < result.Repos[j].RepoRoot
+	})
+	return result
+}
+
+// ResetExecMetrics zera as métricas de execução.
+func (s *Service) ResetExecMetrics() {
+	s.execMetrics.reset()
+}
@@ -0,0 +1,132 @@
+package gitpanel
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecPoolBoundsConcurrentGitProcesses(t *testing.T) {
+	var running atomic.Int32
+	var maxRunning atomic.Int32
+	runner := func(ctx context.Context, timeout time.Duration, stdin string, args ...string) (string, string, int, error) {
+		current := running.Add(1)
+		for {
+			prev := maxRunning.Load()
+			if current <= prev || maxRunning.CompareAndSwap(prev, current) {
+				break
+			}
+		}
+		defer running.Add(-1)
+		time.Sleep(20 * time.Millisecond)
+		return "", "", 0, nil
+	}
+
+	svc := newServiceWithDeps(nil, runner, sleepWithContext)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+	svc.SetExecLimits(2, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, _, _ = svc.runGit(context.Background(), time.Second, "", "-C", fmt.Sprintf("/tmp/orch-pool-%d", i%2), "status")
+		}(i)
+	}
+	wg.Wait()
+
+	if maxRunning.Load() != 2 {
+		t.Fatalf("expected at most 2 concurrent git processes, got %d", maxRunning.Load())
+	}
+	metrics := svc.GetExecMetrics()
+	if metrics.Workers != 2 || metrics.Commands != 6 || len(metrics.Repos) != 2 {
+		t.Fatalf("unexpected exec metrics: %+v", metrics)
+	}
+	if metrics.AvgExecMs <= 0 || metrics.Running != 0 || metrics.Waiting != 0 {
+		t.Fatalf("expected exec duration and an idle pool, got %+v", metrics)
+	}
+
+	svc.ResetExecMetrics()
+	if metrics := svc.GetExecMetrics(); metrics.Commands != 0 || len(metrics.Repos) != 0 {
+		t.Fatalf("expected metrics to reset, got %+v", metrics)
+	}
+}
+
+func TestExecPoolWaitHonorsContextCancel(t *testing.T) {
+	pool := newExecPool(1)
+	if _, err := pool.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire() error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := pool.acquire(ctx); err == nil {
+		t.Fatalf("expected acquire to fail once the context expires")
+	}
+	pool.release()
+	if _, running, waiting := pool.load(); running != 0 || waiting != 0 {
+		t.Fatalf("expected empty pool, got running=%d waiting=%d", running, waiting)
+	}
+}
+
+func TestWriteQueueRejectsWithBusyWhenFull(t *testing.T) {
+	release := make(chan struct{})
+	runner := func(ctx context.Context, timeout time.Duration, stdin string, args ...string) (string, string, int, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return "", "", 0, nil
+	}
+
+	svc := newServiceWithDeps(nil, runner, sleepWithContext)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+	svc.SetExecLimits(0, 1)
+
+	repoRoot := "/tmp/orch-test-repo-busy"
+	write := func(id string) error {
+		return svc.executeWrite(repoRoot, id, "stage_file", []string{"add", "--", "README.md"}, time.Now(), 2*time.Second,
+			func(ctx context.Context, diag *commandDiagnosticState) error {
+				_, _, _, runErr := svc.runWriteGitWithRetry(ctx, diag, "", "-C", repoRoot, "add", "--", "README.md")
+				return runErr
+			})
+	}
+
+	// O primeiro roda (e fica preso), o segundo ocupa a única vaga da fila.
+	errCh := make(chan error, 2)
+	go func() { errCh <- write("cmd_running") }()
+	waitForExecState(t, svc, func(m ExecMetricsDTO) bool { return m.Running == 1 })
+	go func() { errCh <- write("cmd_queued") }()
+	waitForExecState(t, svc, func(m ExecMetricsDTO) bool { return len(m.Repos) == 1 && m.Repos[0].QueuedWrites == 1 })
+
+	err := write("cmd_rejected")
+	if bindingErr := AsBindingError(err); bindingErr == nil || bindingErr.Code != CodeBusy {
+		t.Fatalf("expected %s, got %v", CodeBusy, err)
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-errCh; err != nil {
+			t.Fatalf("expected accepted writes to succeed, got %v", err)
+		}
+	}
+	metrics := svc.GetExecMetrics()
+	if metrics.RejectedWrites != 1 || metrics.Repos[0].Writes != 2 || metrics.Repos[0].QueuedWrites != 0 {
+		t.Fatalf("unexpected write queue metrics: %+v", metrics)
+	}
+}
+
+func waitForExecState(t *testing.T, svc *Service, ready func(ExecMetricsDTO) bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if ready(svc.GetExecMetrics()) {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for exec state: %+v", svc.GetExecMetrics())
+}
@@ -0,0 +1,320 @@
+package gitpanel
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ExternalToolDTO descreve uma ferramenta externa de diff/merge do registro interno.
+type ExternalToolDTO struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Command   string `json:"command,omitempty"` // executável resolvido; vazio se não instalado
+	Available bool   `json:"available"`
+}
+
+// toolStarter inicia a ferramenta e retorna a função que espera ela fechar.
+type toolStarter func(ctx context.Context, command string, args []string, dir string) (func() error, error)
+
+type externalToolDefinition struct {
+	id       string
+	name     string
+	binaries []string
+	// Caminhos absolutos usados quando o binário não está no PATH (app aberto pelo Finder/dock).
+	fallbacks []string
+	// Placeholders: $BASE, $LOCAL, $REMOTE e $MERGED (como no git mergetool).
+	mergeArgs []string
+	diffArgs  []string
+}
+
+var externalToolDefinitions = []externalToolDefinition{
+	{
+		id:        "kdiff3",
+		name:      "KDiff3",
+		binaries:  []string{"kdiff3"},
+		fallbacks: []string{"/Applications/kdiff3.app/Contents/MacOS/kdiff3"},
+		mergeArgs: []string{"--auto", "--L1", "Base", "--L2", "Local", "--L3", "Remote", "-o", "$MERGED", "$BASE", "$LOCAL", "$REMOTE"},
+		diffArgs:  []string{"--L1", "$LOCAL_LABEL", "--L2", "$REMOTE_LABEL", "$LOCAL", "$REMOTE"},
+	},
+	{
+		id:        "bcompare",
+		name:      "Beyond Compare",
+		binaries:  []string{"bcompare", "bcomp"},
+		fallbacks: []string{"/Applications/Beyond Compare.app/Contents/MacOS/bcomp"},
+		mergeArgs: []string{"$LOCAL", "$REMOTE", "$BASE", "-mergeoutput=$MERGED"},
+		diffArgs:  []string{"$LOCAL", "$REMOTE", "-lefttitle=$LOCAL_LABEL", "-righttitle=$REMOTE_LABEL"},
+	},
+	{
+		id:        "vscode",
+		name:      "VS Code",
+		binaries:  []string{"code"},
+		fallbacks: []string{"/Applications/Visual Studio Code.app/Contents/Resources/app/bin/code"},
+		mergeArgs: []string{"--new-window", "--wait", "--merge", "$LOCAL", "$REMOTE", "$BASE", "$MERGED"},
+		diffArgs:  []string{"--new-window", "--wait", "--diff", "$LOCAL", "$REMOTE"},
+	},
+	{
+		id:        "meld",
+		name:      "Meld",
+		binaries:  []string{"meld"},
+		fallbacks: []string{"/Applications/Meld.app/Contents/MacOS/Meld"},
+		mergeArgs: []string{"--output=$MERGED", "$LOCAL", "$BASE", "$REMOTE"},
+		diffArgs:  []string{"--label=$LOCAL_LABEL", "--label=$REMOTE_LABEL", "$LOCAL", "$REMOTE"},
+	},
+}
+
+// IsKnownExternalTool indica se o id existe no registro ("" = usar a configuração do git).
+func IsKnownExternalTool(id string) bool {
+	_, ok := findExternalTool(id)
+	return ok || strings.TrimSpace(id) == ""
+}
+
+// ListExternalTools lista as ferramentas do registro, marcando as instaladas.
+func (s *Service) ListExternalTools() []ExternalToolDTO {
+	tools := make([]ExternalToolDTO, 0, len(externalToolDefinitions))
+	for _, def := range externalToolDefinitions {
+		tool := ExternalToolDTO{ID: def.id, Name: def.name}
+		tool.Command, tool.Available = s.resolveExternalTool(def)
+		tools = append(tools, tool)
+	}
+	return tools
+}
+
+// OpenExternalMergeToolWith resolve o conflito com uma ferramenta do registro (toolID vazio usa
+// git mergetool). As versões base/local/remote vão para arquivos temporários removidos quando a
+// ferramenta fecha; se ela sair com sucesso sem marcadores de conflito, o arquivo é adicionado ao stage.
+func (s *Service) OpenExternalMergeToolWith(repoPath string, filePath string, toolID string) error {
+	if strings.TrimSpace(toolID) == "" {
+		return s.OpenExternalMergeTool(repoPath, filePath)
+	}
+	def, command, err := s.requireExternalTool(toolID)
+	if err != nil {
+		return err
+	}
+
+	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(context.Background(), repoPath, filePath, "open_external_tool")
+	if err != nil {
+		s.emitCommandFailure(commandID, repoPath, "open_external_tool", []string{def.id, "--", filePath}, startedAt, err)
+		return err
+	}
+
+	if err := s.executeWrite(
+		preflight.RepoRoot,
+		commandID,
+		"open_external_tool",
+		[]string{def.id, "--", cleanPath},
+		startedAt,
+		externalToolTimeout,
+		func(ctx context.Context, diag *commandDiagnosticState) error {
+			tempDir, err := os.MkdirTemp("", "orch-merge-*")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(tempDir)
+
+			vars := map[string]string{"$MERGED": filepath.Join(preflight.RepoRoot, filepath.FromSlash(cleanPath))}
+			for stage, name := range map[string]string{"1": "$BASE", "2": "$LOCAL", "3": "$REMOTE"} {
+				// Conflitos add/add não têm base: a ferramenta recebe um arquivo vazio.
+				content, _, _, _ := s.runGit(ctx, defaultReadTimeout, "", "-C", preflight.RepoRoot, "show", ":"+stage+":"+cleanPath)
+				target := filepath.Join(tempDir, strings.ToLower(strings.TrimPrefix(name, "$"))+"_"+filepath.Base(cleanPath))
+				if err := os.WriteFile(target, []byte(content), 0o600); err != nil {
+					return err
+				}
+				vars[name] = target
+			}
+
+			wait, err := s.startTool(ctx, command, expandToolArgs(def.mergeArgs, vars), preflight.RepoRoot)
+			if err != nil {
+				return NewBindingError(CodeCommandFailed, "Falha ao abrir a ferramenta externa de merge.", err.Error())
+			}
+			if err := wait(); err != nil {
+				return NewBindingError(CodeCommandFailed, "A ferramenta externa de merge não concluiu o merge.", err.Error())
+			}
+			if firstConflictMarkerLine(vars["$MERGED"]) > 0 {
+				return nil
+			}
+			_, errOut, exitCode, runErr := s.runWriteGitWithRetry(ctx, diag, "", "-C", preflight.RepoRoot, "add", "--", cleanPath)
+			if runErr != nil {
+				return wrapWriteCommandError(CodeCommandFailed, "Falha ao marcar o conflito como resolvido.", errOut, exitCode, runErr)
+			}
+			return nil
+		}); err != nil {
+		return err
+	}
+
+	s.emitPostWriteReconciliation(preflight.RepoRoot, "open_external_tool", true)
+	return nil
+}
+
+// OpenExternalDiffTool compara a versão do HEAD com a working tree numa ferramenta externa
+// (toolID vazio usa git difftool). Não bloqueia: o temporário é removido quando a ferramenta fecha.
+func (s *Service) OpenExternalDiffTool(repoPath string, filePath string, toolID string) error {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return err
+	}
+	cleanPath, err := ensurePathWithinRepo(preflight.RepoRoot, filePath)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(toolID) == "" {
+		go func() {
+			_, errOut, exitCode, runErr := s.runGit(context.Background(), externalToolTimeout, "", "-C", preflight.RepoRoot, "difftool", "--no-prompt", "HEAD", "--", cleanPath)
+			if runErr != nil {
+				log.Printf("[GITPANEL] git difftool failed for %s: %s", cleanPath, formatCommandFailureDetails(errOut, exitCode, runErr))
+			}
+		}()
+		return nil
+	}
+
+	def, command, err := s.requireExternalTool(toolID)
+	if err != nil {
+		return err
+	}
+	tempDir, err := os.MkdirTemp("", "orch-diff-*")
+	if err != nil {
+		return err
+	}
+	// Arquivo novo (fora do HEAD) compara contra vazio.
+	headContent, _, _, _ := s.runGit(context.Background(), defaultReadTimeout, "", "-C", preflight.RepoRoot, "show", "HEAD:"+cleanPath)
+	headPath := filepath.Join(tempDir, "HEAD_"+filepath.Base(cleanPath))
+	if err := os.WriteFile(headPath, []byte(headContent), 0o600); err != nil {
+		os.RemoveAll(tempDir)
+		return err
+	}
+	vars := map[string]string{
+		"$LOCAL":        headPath,
+		"$REMOTE":       filepath.Join(preflight.RepoRoot, filepath.FromSlash(cleanPath)),
+		"$LOCAL_LABEL":  cleanPath + " (HEAD)",
+		"$REMOTE_LABEL": cleanPath,
+	}
+	return s.launchDetachedTool(def, command, expandToolArgs(def.diffArgs, vars), preflight.RepoRoot, tempDir)
+}
+
+// TestExternalTool abre a ferramenta com dois arquivos de exemplo para conferir a instalação.
+func (s *Service) TestExternalTool(toolID string) error {
+	def, command, err := s.requireExternalTool(toolID)
+	if err != nil {
+		return err
+	}
+	tempDir, err := os.MkdirTemp("", "orch-tool-test-*")
+	if err != nil {
+		return err
+	}
+	vars := map[string]string{
+		"$LOCAL":        filepath.Join(tempDir, "before.txt"),
+		"$REMOTE":       filepath.Join(tempDir, "after.txt"),
+		"$LOCAL_LABEL":  "before.txt",
+		"$REMOTE_LABEL": "after.txt",
+	}
+	samples := map[string]string{
+		"$LOCAL":  "ORCH external tool test\nthis line was removed\nunchanged line\n",
+		"$REMOTE": "ORCH external tool test\nthis line was added\nunchanged line\n",
+	}
+	for name, content := range samples {
+		if err := os.WriteFile(vars[name], []byte(content), 0o600); err != nil {
+			os.RemoveAll(tempDir)
+			return err
+		}
+	}
+	return s.launchDetachedTool(def, command, expandToolArgs(def.diffArgs, vars), tempDir, tempDir)
+}
+
+// launchDetachedTool inicia a ferramenta sem esperar e remove tempDir quando ela fecha.
+func (s *Service) launchDetachedTool(def externalToolDefinition, command string, args []string, dir string, tempDir string) error {
+	wait, err := s.startTool(context.Background(), command, args, dir)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		return NewBindingError(CodeCommandFailed, fmt.Sprintf("Falha ao abrir %s.", def.name), err.Error())
+	}
+	go func() {
+		defer os.RemoveAll(tempDir)
+		if err := wait(); err != nil {
+			log.Printf("[GITPANEL] %s exited with error: %v", def.id, err)
+		}
+	}()
+	return nil
+}
+
+func (s *Service) requireExternalTool(toolID string) (externalToolDefinition, string, error) {
+	def, ok := findExternalTool(toolID)
+	if !ok {
+		return externalToolDefinition{}, "", NewBindingError(CodeToolUnavailable, "Ferramenta externa desconhecida.", toolID)
+	}
+	command, available := s.resolveExternalTool(def)
+	if !available {
+		return externalToolDefinition{}, "", NewBindingError(
+			CodeToolUnavailable,
+			fmt.Sprintf("%s não encontrado.", def.name),
+			"Instale a ferramenta ou escolha outra nas configurações do repositório.",
+		)
+	}
+	return def, command, nil
+}
+
+func (s *Service) resolveExternalTool(def externalToolDefinition) (string, bool) {
+	for _, binary := range def.binaries {
+		if command, ok := s.lookupTool(binary); ok {
+			return command, true
+		}
+	}
+	if runtime.GOOS == "darwin" {
+		for _, candidate := range def.fallbacks {
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate, true
+			}
+		}
+	}
+	return "", false
+}
+
+func findExternalTool(id string) (externalToolDefinition, bool) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	for _, def := range externalToolDefinitions {
+		if def.id == id {
+			return def, true
+		}
+	}
+	return externalToolDefinition{}, false
+}
+
+// expandToolArgs substitui os placeholders; argumentos como "-mergeoutput=$MERGED" também são expandidos.
+func expandToolArgs(template []string, vars map[string]string) []string {
+	args := make([]string, 0, len(template))
+	for _, arg := range template {
+		// Placeholders mais longos primeiro ($LOCAL_LABEL antes de $LOCAL).
+		for _, name := range []string{"$LOCAL_LABEL", "$REMOTE_LABEL", "$MERGED", "$BASE", "$LOCAL", "$REMOTE"} {
+			arg = strings.ReplaceAll(arg, name, vars[name])
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+func lookupToolInPath(binary string) (string, bool) {
+	if command, err := exec.LookPath(binary); err == nil {
+		return command, true
+	}
+	for _, dir := range []string{"/opt/homebrew/bin", "/usr/local/bin"} {
+		candidate := filepath.Join(dir, binary)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() && info.Mode()&0o111 != 0 {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+func startToolProcess(ctx context.Context, command string, args []string, dir string) (func() error, error) {
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = dir
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return cmd.Wait, nil
+}
@@ -0,0 +1,167 @@
+package gitpanel
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeToolLaunch struct {
+	command string
+	args    []string
+	dir     string
+}
+
+func newExternalToolTestService(t *testing.T, repoRoot string, runner gitRunner, onWait func(fakeToolLaunch) error) (*Service, chan fakeToolLaunch) {
+	t.Helper()
+	svc := newServiceWithDeps(nil, runner, sleepWithContext)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	launches := make(chan fakeToolLaunch, 4)
+	svc.lookupTool = func(binary string) (string, bool) {
+		if binary == "kdiff3" || binary == "bcomp" {
+			return "/opt/tools/" + binary, true
+		}
+		return "", false
+	}
+	svc.startTool = func(ctx context.Context, command string, args []string, dir string) (func() error, error) {
+		launch := fakeToolLaunch{command: command, args: args, dir: dir}
+		launches <- launch
+		return func() error { return onWait(launch) }, nil
+	}
+	return svc, launches
+}
+
+func fakeRepoRunner(repoRoot string, calls *[]string, mu *sync.Mutex) gitRunner {
+	return func(ctx context.Context, timeout time.Duration, stdin string, args ...string) (string, string, int, error) {
+		mu.Lock()
+		*calls = append(*calls, strings.Join(args, " "))
+		mu.Unlock()
+		switch {
+		case hasArgSequence(args, "rev-parse", "--show-toplevel"):
+			return repoRoot, "", 0, nil
+		case hasArgSequence(args, "rev-parse", "--abbrev-ref", "HEAD"):
+			return "main\n", "", 0, nil
+		case hasArgSequence(args, "show", ":1:conflict.txt"):
+			return "", "fatal: path not in stage 1", 128, errors.New("exit status 128")
+		case hasArgSequence(args, "show"):
+			return "version of " + args[len(args)-1] + "\n", "", 0, nil
+		}
+		return "", "", 0, nil
+	}
+}
+
+func TestListExternalToolsMarksInstalledTools(t *testing.T) {
+	svc, _ := newExternalToolTestService(t, t.TempDir(), nil, nil)
+
+	available := map[string]string{}
+	for _, tool := range svc.ListExternalTools() {
+		if tool.Available {
+			available[tool.ID] = tool.Command
+		}
+	}
+	if len(available) != 2 || available["kdiff3"] != "/opt/tools/kdiff3" || available["bcompare"] != "/opt/tools/bcomp" {
+		t.Fatalf("unexpected available tools: %v", available)
+	}
+	if !IsKnownExternalTool("") || !IsKnownExternalTool("VSCode") || IsKnownExternalTool("notepad") {
+		t.Fatalf("unexpected IsKnownExternalTool results")
+	}
+	if err := svc.TestExternalTool("meld"); AsBindingError(err) == nil || AsBindingError(err).Code != CodeToolUnavailable {
+		t.Fatalf("expected missing tool to be reported, got %v", err)
+	}
+}
+
+func TestOpenExternalMergeToolWithStagesResolvedFileAndCleansTempFiles(t *testing.T) {
+	repoRoot := t.TempDir()
+	merged := filepath.Join(repoRoot, "conflict.txt")
+	if err := os.WriteFile(merged, []byte("<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> feature\n"), 0o644); err != nil {
+		t.Fatalf("failed to create conflict file: %v", err)
+	}
+
+	var mu sync.Mutex
+	var calls []string
+	var tempFiles []string
+	svc, launches := newExternalToolTestService(t, repoRoot, fakeRepoRunner(repoRoot, &calls, &mu), func(launch fakeToolLaunch) error {
+		for _, arg := range launch.args {
+			if strings.Contains(arg, "orch-merge-") {
+				tempFiles = append(tempFiles, arg)
+			}
+		}
+		return os.WriteFile(merged, []byte("resolved\n"), 0o644)
+	})
+
+	if err := svc.OpenExternalMergeToolWith(repoRoot, "conflict.txt", "kdiff3"); err != nil {
+		t.Fatalf("OpenExternalMergeToolWith failed: %v", err)
+	}
+	launch := <-launches
+	if launch.command != "/opt/tools/kdiff3" || launch.dir != repoRoot || !containsArg(launch.args, merged) {
+		t.Fatalf("unexpected launch: %+v", launch)
+	}
+	if len(tempFiles) != 3 {
+		t.Fatalf("expected base/local/remote temp files, got %v", tempFiles)
+	}
+	for _, file := range tempFiles {
+		if _, err := os.Stat(file); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected temp file %s to be removed, got %v", file, err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	staged := false
+	for _, call := range calls {
+		if strings.HasSuffix(call, "add -- conflict.txt") {
+			staged = true
+		}
+	}
+	if !staged {
+		t.Fatalf("expected resolved file to be staged, calls=%v", calls)
+	}
+}
+
+func TestOpenExternalDiffToolRemovesTempDirAfterToolExits(t *testing.T) {
+	repoRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoRoot, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	var mu sync.Mutex
+	var calls []string
+	release := make(chan struct{})
+	exited := make(chan struct{})
+	svc, launches := newExternalToolTestService(t, repoRoot, fakeRepoRunner(repoRoot, &calls, &mu), func(fakeToolLaunch) error {
+		<-release
+		close(exited)
+		return nil
+	})
+
+	if err := svc.OpenExternalDiffTool(repoRoot, "main.go", "bcompare"); err != nil {
+		t.Fatalf("OpenExternalDiffTool failed: %v", err)
+	}
+	launch := <-launches
+	if len(launch.args) != 4 || launch.args[1] != filepath.Join(repoRoot, "main.go") || launch.args[2] != "-lefttitle=main.go (HEAD)" {
+		t.Fatalf("unexpected diff args: %q", launch.args)
+	}
+	headCopy := launch.args[0]
+	if data, err := os.ReadFile(headCopy); err != nil || string(data) != "version of HEAD:main.go\n" {
+		t.Fatalf("expected HEAD copy while the tool is open, got %q err=%v", data, err)
+	}
+
+	close(release)
+	<-exited
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(filepath.Dir(headCopy)); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected temp dir to be removed after the tool exits")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,69 @@
+package gitpanel
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// fetchTimeout cobre fetches grandes (primeiro fetch de um fork, muitas tags).
+const fetchTimeout = 5 * time.Minute
+
+// FetchContext atualiza as refs remotas pela fila de escrita do repositório; ctx permite cancelar.
+func (s *Service) FetchContext(ctx context.Context, repoPath string, options FetchOptionsDTO) (FetchResultDTO, error) {
+	commandID, startedAt := s.beginCommand("fetch")
+
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		s.emitCommandFailure(commandID, repoPath, "fetch", nil, startedAt, err)
+		return FetchResultDTO{}, err
+	}
+	root := preflight.RepoRoot
+
+	remote := strings.TrimSpace(options.Remote)
+	args := []string{"fetch", "--no-write-fetch-head"}
+	if options.Prune {
+		args = append(args, "--prune")
+	}
+	if remote == "" {
+		args = append(args, "--all")
+	} else {
+		if strings.HasPrefix(remote, "-") || s.readGitConfigString(root, "remote."+remote+".url") == "" {
+			err := NewBindingError(CodeCommandFailed, "Remoto não configurado para fetch.", remote)
+			s.emitCommandFailure(commandID, root, "fetch", nil, startedAt, err)
+			return FetchResultDTO{}, err
+		}
+		args = append(args, remote)
+	}
+
+	result := FetchResultDTO{Remote: remote}
+	runErr := s.executeWriteContext(ctx, root, commandID, "fetch", args, startedAt, fetchTimeout, func(ctx context.Context, diag *commandDiagnosticState) error {
+		fetchCtx := withGitEnv(ctx, "GIT_TERMINAL_PROMPT=0")
+		gitArgs := append([]string{"-C", root}, args...)
+		out, errOut, exitCode, fetchErr := s.runGit(fetchCtx, remainingTimeout(ctx, fetchTimeout), "", gitArgs...)
+
+		result.Output = strings.TrimSpace(strings.TrimSpace(out) + "\n" + strings.TrimSpace(errOut))
+		diag.recordAttempt(gitArgs, errOut, exitCode, 1)
+		if fetchErr == nil {
+			return nil
+		}
+		if mapped := queueErrorFromContext(fetchErr, "Fetch interrompido."); mapped != nil {
+			return mapped
+		}
+		return wrapWriteCommandError(
+			CodeCommandFailed,
+			"Falha ao buscar atualizações do remoto.",
+			result.Output,
+			exitCode,
+			fetchErr,
+		)
+	})
+	if runErr != nil {
+		return FetchResultDTO{}, runErr
+	}
+
+	// Fetch muda ahead/behind e as refs remotas do histórico.
+	s.emitPostWriteReconciliation(root, "fetch", false)
+	s.emitHistoryInvalidatedWithContext(root, "post_write_reconcile", "fetch")
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package gitpanel
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchContextUpdatesRemoteRefs(t *testing.T) {
+	sourceRoot := mustInitTestRepo(t)
+	remoteRoot := t.TempDir()
+	runGitOrFail(t, remoteRoot, "init", "--bare")
+	runGitOrFail(t, sourceRoot, "remote", "add", "origin", remoteRoot)
+	runGitOrFail(t, sourceRoot, "push", "origin", "HEAD:refs/heads/feature")
+
+	repoRoot := mustInitTestRepo(t)
+	runGitOrFail(t, repoRoot, "remote", "add", "origin", remoteRoot)
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	if _, err := svc.FetchContext(context.Background(), repoRoot, FetchOptionsDTO{Remote: "missing"}); err == nil {
+		t.Fatalf("expected error for unknown remote")
+	}
+	if _, err := svc.FetchContext(context.Background(), repoRoot, FetchOptionsDTO{Remote: "origin", Prune: true}); err != nil {
+		t.Fatalf("FetchContext returned error: %v", err)
+	}
+	out, _, _, err := runGitWithInput(context.Background(), 5*time.Second, "", "-C", repoRoot, "rev-parse", "--verify", "refs/remotes/origin/feature")
+	if err != nil || strings.TrimSpace(out) == "" {
+		t.Fatalf("expected refs/remotes/origin/feature after fetch, got %q err=%v", out, err)
+	}
+}
+
+func TestPushContextHonorsCancellation(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	remoteRoot := t.TempDir()
+	runGitOrFail(t, remoteRoot, "init", "--bare")
+	runGitOrFail(t, repoRoot, "remote", "add", "origin", remoteRoot)
+	runGitOrFail(t, repoRoot, "checkout", "-b", "feature")
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := svc.PushContext(ctx, repoRoot, PushOptionsDTO{})
+	if bindingErr := AsBindingError(err); bindingErr == nil || bindingErr.Code != CodeCanceled {
+		t.Fatalf("expected canceled push, got %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+package gitpanel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GetFileHistory retorna o histórico paginado de um arquivo, seguindo renomeações (git log --follow).
+func (s *Service) GetFileHistory(repoPath string, filePath string, cursor string, limit int) (FileHistoryPageDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return FileHistoryPageDTO{}, err
+	}
+	cleanPath, err := ensurePathWithinRepo(preflight.RepoRoot, filePath)
+	if err != nil {
+		return FileHistoryPageDTO{}, err
+	}
+	cursorHash, err := parseHistoryCursor(cursor)
+	if err != nil {
+		return FileHistoryPageDTO{}, err
+	}
+
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+
+	// rev-list não suporta --follow e --skip é ignorado junto com --follow;
+	// o cursor vira um offset resolvido sobre a própria lista do log.
+	skipCount := 0
+	if cursorHash != "" {
+		hashesOut, errOut, exitCode, runErr := s.runGit(
+			context.Background(),
+			defaultReadTimeout,
+			"",
+			"-C", preflight.RepoRoot,
+			"log",
+			"--follow",
+			"--format=%H",
+			"--",
+			cleanPath,
+		)
+		if runErr != nil {
+			return FileHistoryPageDTO{}, NewBindingError(
+				CodeInvalidCursor,
+				"Cursor de histórico inválido.",
+				formatCommandFailureDetails(errOut, exitCode, runErr),
+			)
+		}
+		skipCount = -1
+		for idx, hash := range strings.Fields(hashesOut) {
+			if strings.HasPrefix(strings.ToLower(hash), cursorHash) {
+				skipCount = idx + 1
+				break
+			}
+		}
+		if skipCount < 0 {
+			return FileHistoryPageDTO{}, NewBindingError(
+				CodeInvalidCursor,
+				"Cursor de histórico inválido.",
+				"O commit do cursor não pertence ao histórico do arquivo.",
+			)
+		}
+	}
+
+	out, errOut, exitCode, runErr := s.runGit(
+		context.Background(),
+		defaultReadTimeout,
+		"",
+		"-C", preflight.RepoRoot,
+		"log",
+		"--follow",
+		"--date=iso-strict",
+		"--pretty=format:%H%x1f%h%x1f%an%x1f%aI%x1f%ae%x1f%s%x1e",
+		"--name-status",
+		"-n", strconv.Itoa(skipCount+limit+1),
+		"--",
+		cleanPath,
+	)
+	if runErr != nil {
+		return FileHistoryPageDTO{}, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao obter histórico do arquivo.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+
+	items := parseFileHistoryItems(out)
+	if skipCount >= len(items) {
+		items = make([]FileHistoryItemDTO, 0)
+	} else {
+		items = items[skipCount:]
+	}
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+	nextCursor := ""
+	if hasMore {
+		nextCursor = items[len(items)-1].Hash
+	}
+
+	return FileHistoryPageDTO{
+		FilePath:   cleanPath,
+		Items:      items,
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+		Warning:    s.partialCloneWarning(preflight.RepoRoot),
+	}, nil
+}
+
+// GetRangeDiff compara duas revisões quaisquer (branches, tags, hashes).
+// toRev vazio compara fromRev com a working tree.
+func (s *Service) GetRangeDiff(ctx context.Context, repoPath string, fromRev string, toRev string, filePath string, contextLines int) (DiffDTO, error) {
+	preflight, err := s.preflightContext(ctx, repoPath)
+	if err != nil {
+		return DiffDTO{}, err
+	}
+
+	fromHash, err := s.resolveRevision(ctx, preflight.RepoRoot, fromRev)
+	if err != nil {
+		return DiffDTO{}, err
+	}
+	toHash := ""
+	if strings.TrimSpace(toRev) != "" {
+		toHash, err = s.resolveRevision(ctx, preflight.RepoRoot, toRev)
+		if err != nil {
+			return DiffDTO{}, err
+		}
+	}
+
+	if contextLines <= 0 {
+		contextLines = 3
+	}
+	if contextLines > 120 {
+		contextLines = 120
+	}
+
+	args := []string{
+		"-C", preflight.RepoRoot,
+		"diff",
+		"-M",
+		fmt.Sprintf("--unified=%d", contextLines),
+		fromHash,
+	}
+	if toHash != "" {
+		args = append(args, toHash)
+	}
+
+	cleanFilePath := ""
+	if strings.TrimSpace(filePath) != "" {
+		pathWithinRepo, pathErr := ensurePathWithinRepo(preflight.RepoRoot, filePath)
+		if pathErr != nil {
+			return DiffDTO{}, pathErr
+		}
+		cleanFilePath = pathWithinRepo
+		args = append(args, "--", cleanFilePath)
+	}
+
+	// Só cacheamos comparações entre commits (imutáveis); a working tree muda a todo momento.
+	cacheKey := ""
+	if toHash != "" {
+		cacheKey = buildCommitDiffCacheKey(preflight.RepoRoot, cleanFilePath, "range:"+fromHash+".."+toHash, contextLines)
+		if cached, ok := s.getCachedDiff(cacheKey); ok {
+			return cached, nil
+		}
+	}
+
+	out, errOut, exitCode, runErr := s.runGit(ctx, defaultReadTimeout, "", args...)
+	if runErr != nil {
+		if isTimeoutBindingError(runErr) {
+			return buildTimeoutDiffFallback("range", cleanFilePath), nil
+		}
+		return DiffDTO{}, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao comparar revisões.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+
+	result := buildDiffDTO("range", cleanFilePath, out)
+	newSide := worktreeBlobSide
+	if toHash != "" {
+		newSide = revBlobSide(toHash)
+	}
+	s.attachBinaryDiffs(ctx, preflight.RepoRoot, &result, revBlobSide(fromHash), newSide)
+	if cacheKey != "" {
+		s.setCachedDiff(cacheKey, result)
+	}
+	return result, nil
+}
+
+// resolveRevision valida uma revisão informada pelo usuário e a resolve para o hash do commit.
+func (s *Service) resolveRevision(ctx context.Context, repoRoot string, rev string) (string, error) {
+	trimmed := strings.TrimSpace(rev)
+	if trimmed == "" || strings.HasPrefix(trimmed, "-") || strings.ContainsAny(trimmed, " \t\r\n\x00") {
+		return "", NewBindingError(
+			CodeInvalidCursor,
+			"Revisão inválida.",
+			fmt.Sprintf("Informe um branch, tag ou hash válido: %q", rev),
+		)
+	}
+
+	out, errOut, exitCode, runErr := s.runGit(
+		ctx,
+		defaultReadTimeout,
+		"",
+		"-C", repoRoot,
+		"rev-parse",
+		"--verify",
+		"--quiet",
+		trimmed+"^{commit}",
+	)
+	hash := strings.TrimSpace(out)
+	if runErr != nil || hash == "" {
+		return "", NewBindingError(
+			CodeInvalidCursor,
+			"Revisão não encontrada.",
+			strings.TrimSpace(trimmed+" "+formatCommandFailureDetails(errOut, exitCode, runErr)),
+		)
+	}
+	return hash, nil
+}
+
+func parseFileHistoryItems(raw string) []FileHistoryItemDTO {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	normalizedRaw := strings.ReplaceAll(raw, "\x1e", "\x1e\n")
+	items := make([]FileHistoryItemDTO, 0, 32)
+	currentIndex := -1
+	for _, rawLine := range strings.Split(normalizedRaw, "\n") {
+		line := strings.TrimRight(rawLine, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if header, ok := parseHistoryHeaderLine(line); ok {
+			items = append(items, FileHistoryItemDTO{
+				Hash:       header.Hash,
+				ShortHash:  header.ShortHash,
+				Author:     header.Author,
+				AuthoredAt: header.AuthoredAt,
+				Subject:    header.Subject,
+			})
+			currentIndex = len(items) - 1
+			continue
+		}
+
+		if currentIndex < 0 || items[currentIndex].Path != "" {
+			continue
+		}
+		parts := strings.Split(line, "\t")
+		if len(parts) < 2 {
+			continue
+		}
+		items[currentIndex].Status = strings.TrimSpace(parts[0])
+		items[currentIndex].Path = strings.TrimSpace(parts[len(parts)-1])
+		if len(parts) >= 3 {
+			items[currentIndex].OldPath = strings.TrimSpace(parts[1])
+		}
+	}
+	return items
+}
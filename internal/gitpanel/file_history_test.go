@@ -0,0 +1,73 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetFileHistoryFollowsRenamesAndPaginates(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+
+	writeAndCommit := func(name string, content string, message string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repoRoot, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		runGitOrFail(t, repoRoot, "add", "--", name)
+		runGitOrFail(t, repoRoot, "commit", "-m", message)
+	}
+
+	writeAndCommit("notes.txt", "line one\nline two\nline three\n", "add notes")
+	writeAndCommit("notes.txt", "line one\nline two\nline three\nline four\n", "extend notes")
+	runGitOrFail(t, repoRoot, "mv", "notes.txt", "docs.txt")
+	runGitOrFail(t, repoRoot, "commit", "-m", "rename notes")
+
+	firstPage, err := svc.GetFileHistory(repoRoot, "docs.txt", "", 2)
+	if err != nil {
+		t.Fatalf("GetFileHistory returned error: %v", err)
+	}
+	if len(firstPage.Items) != 2 || !firstPage.HasMore {
+		t.Fatalf("unexpected first page: %+v", firstPage)
+	}
+	renamed := firstPage.Items[0]
+	if renamed.Subject != "rename notes" || renamed.Path != "docs.txt" || renamed.OldPath != "notes.txt" {
+		t.Fatalf("unexpected rename entry: %+v", renamed)
+	}
+
+	secondPage, err := svc.GetFileHistory(repoRoot, "docs.txt", firstPage.NextCursor, 2)
+	if err != nil {
+		t.Fatalf("GetFileHistory(cursor) returned error: %v", err)
+	}
+	if len(secondPage.Items) != 1 || secondPage.HasMore || secondPage.Items[0].Subject != "add notes" || secondPage.Items[0].Path != "notes.txt" {
+		t.Fatalf("unexpected second page: %+v", secondPage)
+	}
+}
+
+func TestGetRangeDiffComparesArbitraryRevisions(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+
+	runGitOrFail(t, repoRoot, "tag", "v1")
+	if err := os.WriteFile(filepath.Join(repoRoot, "README.md"), []byte("hello\nworld\n"), 0o644); err != nil {
+		t.Fatalf("failed to modify README: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "commit", "-am", "update readme")
+
+	diff, err := svc.GetRangeDiff(context.Background(), repoRoot, "v1", "HEAD", "README.md", 0)
+	if err != nil {
+		t.Fatalf("GetRangeDiff returned error: %v", err)
+	}
+	if diff.Mode != "range" || len(diff.Files) != 1 || diff.Files[0].Additions != 1 {
+		t.Fatalf("unexpected range diff: %+v", diff)
+	}
+
+	if _, err := svc.GetRangeDiff(context.Background(), repoRoot, "--output=/tmp/x", "HEAD", "", 0); err == nil {
+		t.Fatalf("expected option-like revision to be rejected")
+	}
+	if _, err := svc.GetRangeDiff(context.Background(), repoRoot, "does-not-exist", "HEAD", "", 0); err == nil {
+		t.Fatalf("expected unknown revision to fail")
+	}
+}
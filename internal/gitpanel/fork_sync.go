@@ -0,0 +1,241 @@
+package gitpanel
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Estratégias de sincronização do fork com o upstream.
+const (
+	ForkSyncUpToDate    = "up_to_date"
+	ForkSyncFastForward = "fast_forward"
+	ForkSyncMerge       = "merge"
+)
+
+// GetForkSyncStatus compara a branch padrão local e a do fork com a do upstream usando as refs
+// remotas já buscadas (sem acesso à rede).
+func (s *Service) GetForkSyncStatus(ctx context.Context, repoPath string, upstreamRemote string, forkRemote string) (ForkSyncStatusDTO, error) {
+	preflight, err := s.preflightContext(ctx, repoPath)
+	if err != nil {
+		return ForkSyncStatusDTO{}, err
+	}
+	root := preflight.RepoRoot
+	if err := s.validateForkSyncRemotes(root, upstreamRemote, forkRemote); err != nil {
+		return ForkSyncStatusDTO{}, err
+	}
+	return s.readForkSyncStatus(ctx, root, strings.TrimSpace(upstreamRemote), strings.TrimSpace(forkRemote))
+}
+
+// SyncForkWithUpstream busca o upstream (e o fork) e atualiza a branch padrão local: fast-forward
+// quando ela não tem commits próprios, merge quando tem (exige a branch em checkout). O fork no
+// GitHub só muda no próximo push da branch.
+func (s *Service) SyncForkWithUpstream(ctx context.Context, repoPath string, upstreamRemote string, forkRemote string) (ForkSyncStatusDTO, error) {
+	commandID, startedAt := s.beginCommand("sync_fork")
+	upstreamRemote, forkRemote = strings.TrimSpace(upstreamRemote), strings.TrimSpace(forkRemote)
+	args := []string{"fetch", "--no-write-fetch-head", upstreamRemote}
+
+	preflight, err := s.preflightContext(ctx, repoPath)
+	if err != nil {
+		s.emitCommandFailure(commandID, repoPath, "sync_fork", args, startedAt, err)
+		return ForkSyncStatusDTO{}, err
+	}
+	root := preflight.RepoRoot
+	if err := s.validateForkSyncRemotes(root, upstreamRemote, forkRemote); err != nil {
+		s.emitCommandFailure(commandID, root, "sync_fork", args, startedAt, err)
+		return ForkSyncStatusDTO{}, err
+	}
+
+	strategy := ForkSyncUpToDate
+	if err := s.executeWriteContext(ctx, root, commandID, "sync_fork", args, startedAt, fetchTimeout, func(ctx context.Context, diag *commandDiagnosticState) error {
+		for _, remote := range []string{upstreamRemote, forkRemote} {
+			if remote == "" {
+				continue
+			}
+			if err := s.fetchForkSyncRemote(ctx, diag, root, remote); err != nil {
+				return err
+			}
+		}
+		if !s.gitRefExists(ctx, root, "refs/remotes/"+upstreamRemote+"/HEAD") {
+			// Clones antigos/remotes adicionados à mão não têm o HEAD remoto; pergunta ao servidor.
+			fetchCtx := withGitEnv(ctx, "GIT_TERMINAL_PROMPT=0")
+			_, _, _, _ = s.runGit(fetchCtx, remainingTimeout(ctx, fetchTimeout), "", "-C", root, "remote", "set-head", upstreamRemote, "--auto")
+		}
+
+		status, err := s.readForkSyncStatus(ctx, root, upstreamRemote, forkRemote)
+		if err != nil {
+			return err
+		}
+		if status.DefaultBranch == "" || !status.LocalExists {
+			return NewBindingError(CodeCommandFailed, "Branch padrão do upstream não encontrada localmente.", strings.Join(status.Warnings, " "))
+		}
+		strategy = status.Strategy
+		upstreamRef := "refs/remotes/" + upstreamRemote + "/" + status.DefaultBranch
+		localRef := "refs/heads/" + status.DefaultBranch
+		onDefault := status.CurrentBranch == status.DefaultBranch
+
+		var gitArgs []string
+		switch {
+		case strategy == ForkSyncUpToDate:
+			return nil
+		case strategy == ForkSyncFastForward && onDefault:
+			gitArgs = []string{"-C", root, "merge", "--ff-only", upstreamRef}
+		case strategy == ForkSyncFastForward:
+			// Fora do checkout basta mover a ref; o valor antigo protege contra corrida.
+			oldHash, _, _, _ := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "rev-parse", localRef)
+			gitArgs = []string{"-C", root, "update-ref", "-m", "sync_fork: fast-forward from " + upstreamRemote, localRef, upstreamRef, strings.TrimSpace(oldHash)}
+		case !onDefault:
+			return NewBindingError(
+				CodeCommandFailed,
+				"A branch padrão tem commits próprios e precisa de merge.",
+				"Faça checkout de "+status.DefaultBranch+" para mesclar o upstream.",
+			)
+		default:
+			gitArgs = []string{"-C", root, "merge", "--no-edit", upstreamRef}
+		}
+
+		_, errOut, exitCode, runErr := s.runGit(ctx, remainingTimeout(ctx, fetchTimeout), "", gitArgs...)
+		diag.recordAttempt(gitArgs, errOut, exitCode, 1)
+		if runErr != nil {
+			if mapped := queueErrorFromContext(runErr, "Sincronização interrompida."); mapped != nil {
+				return mapped
+			}
+			return wrapWriteCommandError(CodeCommandFailed, "Falha ao atualizar a branch padrão com o upstream.", errOut, exitCode, runErr)
+		}
+		return nil
+	}); err != nil {
+		if strategy == ForkSyncMerge {
+			// Um merge com conflito deixa o repositório em MERGING; o painel precisa ver isso.
+			s.emitPostWriteReconciliation(root, "sync_fork", true)
+		}
+		return ForkSyncStatusDTO{}, err
+	}
+
+	s.emitPostWriteReconciliation(root, "sync_fork", strategy == ForkSyncMerge)
+	s.emitHistoryInvalidatedWithContext(root, "post_write_reconcile", "sync_fork")
+	result, err := s.readForkSyncStatus(ctx, root, upstreamRemote, forkRemote)
+	if err != nil {
+		return ForkSyncStatusDTO{}, err
+	}
+	result.Strategy = strategy
+	result.Synced = true
+	return result, nil
+}
+
+func (s *Service) validateForkSyncRemotes(root string, upstreamRemote string, forkRemote string) error {
+	upstreamRemote, forkRemote = strings.TrimSpace(upstreamRemote), strings.TrimSpace(forkRemote)
+	if upstreamRemote == "" {
+		return NewBindingError(CodeCommandFailed, "Remote upstream obrigatório.", "Adicione o repositório original como remote (ex.: upstream).")
+	}
+	if upstreamRemote == forkRemote {
+		return NewBindingError(CodeCommandFailed, "O fork e o upstream são o mesmo remote.", upstreamRemote)
+	}
+	for _, remote := range []string{upstreamRemote, forkRemote} {
+		if remote == "" {
+			continue
+		}
+		if strings.HasPrefix(remote, "-") || s.readGitConfigString(root, "remote."+remote+".url") == "" {
+			return NewBindingError(CodeCommandFailed, "Remoto não configurado.", remote)
+		}
+	}
+	return nil
+}
+
+func (s *Service) fetchForkSyncRemote(ctx context.Context, diag *commandDiagnosticState, root string, remote string) error {
+	fetchCtx := withGitEnv(ctx, "GIT_TERMINAL_PROMPT=0")
+	gitArgs := []string{"-C", root, "fetch", "--no-write-fetch-head", remote}
+	_, errOut, exitCode, fetchErr := s.runGit(fetchCtx, remainingTimeout(ctx, fetchTimeout), "", gitArgs...)
+	diag.recordAttempt(gitArgs, errOut, exitCode, 1)
+	if fetchErr == nil {
+		return nil
+	}
+	if mapped := queueErrorFromContext(fetchErr, "Fetch interrompido."); mapped != nil {
+		return mapped
+	}
+	return wrapWriteCommandError(CodeCommandFailed, "Falha ao buscar o remoto "+remote+".", errOut, exitCode, fetchErr)
+}
+
+func (s *Service) readForkSyncStatus(ctx context.Context, root string, upstreamRemote string, forkRemote string) (ForkSyncStatusDTO, error) {
+	status := ForkSyncStatusDTO{
+		UpstreamRemote: upstreamRemote,
+		ForkRemote:     forkRemote,
+		Strategy:       ForkSyncUpToDate,
+		Warnings:       []string{},
+	}
+	if out, _, _, err := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "symbolic-ref", "--quiet", "--short", "HEAD"); err == nil {
+		status.CurrentBranch = strings.TrimSpace(out)
+	}
+
+	status.DefaultBranch = s.readRemoteDefaultBranch(ctx, root, upstreamRemote)
+	if status.DefaultBranch == "" {
+		status.Warnings = append(status.Warnings, "Branch padrão de "+upstreamRemote+" desconhecida; faça fetch do upstream.")
+		return status, nil
+	}
+	upstreamRef := "refs/remotes/" + upstreamRemote + "/" + status.DefaultBranch
+
+	localRef := "refs/heads/" + status.DefaultBranch
+	status.LocalExists = s.gitRefExists(ctx, root, localRef)
+	if status.LocalExists {
+		ahead, behind, err := s.countLeftRight(ctx, root, localRef, upstreamRef)
+		if err != nil {
+			return ForkSyncStatusDTO{}, err
+		}
+		status.LocalAhead, status.LocalBehind = ahead, behind
+		switch {
+		case behind == 0:
+			status.Strategy = ForkSyncUpToDate
+		case ahead == 0:
+			status.Strategy = ForkSyncFastForward
+		default:
+			status.Strategy = ForkSyncMerge
+		}
+	} else {
+		status.Warnings = append(status.Warnings, "Branch local "+status.DefaultBranch+" não existe; crie-a a partir de "+upstreamRemote+"/"+status.DefaultBranch+".")
+	}
+
+	if forkRemote != "" {
+		forkRef := "refs/remotes/" + forkRemote + "/" + status.DefaultBranch
+		if s.gitRefExists(ctx, root, forkRef) {
+			ahead, behind, err := s.countLeftRight(ctx, root, forkRef, upstreamRef)
+			if err != nil {
+				return ForkSyncStatusDTO{}, err
+			}
+			status.ForkAhead, status.ForkBehind = ahead, behind
+		}
+		if status.ForkBehind > 0 && status.LocalBehind == 0 {
+			status.Warnings = append(status.Warnings, "O fork ("+forkRemote+") está "+pluralizeCommits(status.ForkBehind)+" atrás; faça push de "+status.DefaultBranch+" para atualizá-lo.")
+		}
+	}
+	if status.Strategy == ForkSyncMerge && status.CurrentBranch != status.DefaultBranch {
+		status.Warnings = append(status.Warnings, "A branch "+status.DefaultBranch+" divergiu do upstream; faça checkout dela para mesclar.")
+	}
+	return status, nil
+}
+
+// readRemoteDefaultBranch usa refs/remotes/<remote>/HEAD e, sem ele, main/master do remote.
+func (s *Service) readRemoteDefaultBranch(ctx context.Context, root string, remote string) string {
+	prefix := remote + "/"
+	if out, _, _, err := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "symbolic-ref", "--quiet", "--short", "refs/remotes/"+remote+"/HEAD"); err == nil {
+		if branch := strings.TrimPrefix(strings.TrimSpace(out), prefix); branch != "" {
+			return branch
+		}
+	}
+	for _, candidate := range []string{"main", "master"} {
+		if s.gitRefExists(ctx, root, "refs/remotes/"+prefix+candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func (s *Service) gitRefExists(ctx context.Context, root string, ref string) bool {
+	_, _, _, err := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "rev-parse", "--verify", "--quiet", ref)
+	return err == nil
+}
+
+func pluralizeCommits(count int) string {
+	if count == 1 {
+		return "1 commit"
+	}
+	return strconv.Itoa(count) + " commits"
+}
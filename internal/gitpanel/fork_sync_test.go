@@ -0,0 +1,98 @@
+package gitpanel
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+// mustInitForkTestRepos cria upstream, um fork bare e o clone local (remotes upstream e origin).
+func mustInitForkTestRepos(t *testing.T) (string, string, string) {
+	t.Helper()
+	upstream := mustInitTestRepo(t)
+	parent := t.TempDir()
+	fork := filepath.Join(parent, "fork.git")
+	local := filepath.Join(parent, "local")
+	runGitOrFail(t, parent, "clone", "--bare", upstream, fork)
+	runGitOrFail(t, parent, "clone", upstream, local)
+	runGitOrFail(t, local, "config", "user.email", "tests@orch.local")
+	runGitOrFail(t, local, "config", "user.name", "ORCH Tests")
+	runGitOrFail(t, local, "remote", "rename", "origin", "upstream")
+	runGitOrFail(t, local, "remote", "add", "origin", fork)
+	runGitOrFail(t, local, "fetch", "origin")
+	return upstream, fork, local
+}
+
+func TestSyncForkWithUpstreamFastForwards(t *testing.T) {
+	upstream, _, local := mustInitForkTestRepos(t)
+	defaultBranch := gitOutputOrFail(t, local, "rev-parse", "--abbrev-ref", "HEAD")
+	commitTestFile(t, upstream, "upstream.txt", "news\n", "upstream work")
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	status, err := svc.GetForkSyncStatus(context.Background(), local, "upstream", "origin")
+	if err != nil || status.DefaultBranch != defaultBranch || status.LocalBehind != 0 || status.Strategy != ForkSyncUpToDate {
+		t.Fatalf("status before fetch must use cached refs, got (%+v, %v)", status, err)
+	}
+
+	result, err := svc.SyncForkWithUpstream(context.Background(), local, "upstream", "origin")
+	if err != nil {
+		t.Fatalf("SyncForkWithUpstream() returned error: %v", err)
+	}
+	if !result.Synced || result.Strategy != ForkSyncFastForward || result.LocalBehind != 0 || result.ForkBehind != 1 || len(result.Warnings) == 0 {
+		t.Fatalf("unexpected sync result: %+v", result)
+	}
+	if got, want := gitOutputOrFail(t, local, "rev-parse", "HEAD"), gitOutputOrFail(t, upstream, "rev-parse", "HEAD"); got != want {
+		t.Fatalf("expected local default branch at upstream head %s, got %s", want, got)
+	}
+
+	// Fora da branch padrão o fast-forward só move a ref.
+	runGitOrFail(t, local, "checkout", "-b", "feature")
+	commitTestFile(t, upstream, "second.txt", "more\n", "second upstream work")
+	if result, err = svc.SyncForkWithUpstream(context.Background(), local, "upstream", "origin"); err != nil || result.Strategy != ForkSyncFastForward {
+		t.Fatalf("unexpected sync off default branch: (%+v, %v)", result, err)
+	}
+	if got, want := gitOutputOrFail(t, local, "rev-parse", defaultBranch), gitOutputOrFail(t, upstream, "rev-parse", "HEAD"); got != want {
+		t.Fatalf("expected %s moved to %s, got %s", defaultBranch, want, got)
+	}
+	if branch := gitOutputOrFail(t, local, "rev-parse", "--abbrev-ref", "HEAD"); branch != "feature" {
+		t.Fatalf("sync must not change the checked out branch, got %s", branch)
+	}
+}
+
+func TestSyncForkWithUpstreamMergesDivergedBranch(t *testing.T) {
+	upstream, _, local := mustInitForkTestRepos(t)
+	defaultBranch := gitOutputOrFail(t, local, "rev-parse", "--abbrev-ref", "HEAD")
+	commitTestFile(t, upstream, "upstream.txt", "news\n", "upstream work")
+	commitTestFile(t, local, "local.txt", "mine\n", "local work")
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	runGitOrFail(t, local, "checkout", "-b", "feature")
+	if _, err := svc.SyncForkWithUpstream(context.Background(), local, "upstream", "origin"); err == nil {
+		t.Fatalf("expected merge outside the default branch to be refused")
+	}
+
+	runGitOrFail(t, local, "checkout", defaultBranch)
+	result, err := svc.SyncForkWithUpstream(context.Background(), local, "upstream", "origin")
+	if err != nil {
+		t.Fatalf("SyncForkWithUpstream() returned error: %v", err)
+	}
+	if result.Strategy != ForkSyncMerge || result.LocalBehind != 0 || result.LocalAhead != 2 {
+		t.Fatalf("unexpected merge result: %+v", result)
+	}
+}
+
+func TestForkSyncRejectsInvalidRemotes(t *testing.T) {
+	_, _, local := mustInitForkTestRepos(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	for _, remotes := range [][2]string{{"", "origin"}, {"origin", "origin"}, {"missing", "origin"}, {"--upload-pack=x", ""}} {
+		if _, err := svc.GetForkSyncStatus(context.Background(), local, remotes[0], remotes[1]); err == nil {
+			t.Fatalf("expected remotes %v to be rejected", remotes)
+		}
+	}
+}
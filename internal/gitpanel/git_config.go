@@ -0,0 +1,295 @@
+package gitpanel
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// Escopos editáveis do git config.
+const (
+	GitConfigScopeLocal  = "local"
+	GitConfigScopeGlobal = "global"
+)
+
+// Tipos de valor das chaves editáveis (orientam a validação e o componente do editor).
+const (
+	GitConfigKindText   = "text"
+	GitConfigKindEmail  = "email"
+	GitConfigKindBool   = "bool"
+	GitConfigKindEnum   = "enum"
+	GitConfigKindTool   = "tool"
+	GitConfigKindBranch = "branch"
+)
+
+type gitConfigKeyDefinition struct {
+	key         string
+	description string
+	kind        string
+	options     []string
+}
+
+// gitConfigWhitelist são as únicas chaves que o editor lê e grava: nada que execute comandos
+// (core.sshCommand, aliases, hooks) ou redirecione credenciais.
+var gitConfigWhitelist = []gitConfigKeyDefinition{
+	{key: "user.name", description: "Nome do autor dos commits.", kind: GitConfigKindText},
+	{key: "user.email", description: "E-mail do autor dos commits.", kind: GitConfigKindEmail},
+	{key: "pull.rebase", description: "Pull faz rebase em vez de merge.", kind: GitConfigKindEnum, options: []string{"false", "true", "merges", "interactive"}},
+	{key: "core.autocrlf", description: "Conversão de fim de linha (CRLF/LF).", kind: GitConfigKindEnum, options: []string{"false", "true", "input"}},
+	{key: "merge.tool", description: "Ferramenta usada pelo git mergetool.", kind: GitConfigKindTool},
+	{key: "diff.tool", description: "Ferramenta usada pelo git difftool.", kind: GitConfigKindTool},
+	{key: "fetch.prune", description: "Remove refs remotas apagadas a cada fetch.", kind: GitConfigKindBool},
+	{key: "push.autoSetupRemote", description: "Primeiro push cria o upstream automaticamente.", kind: GitConfigKindBool},
+	{key: "rebase.autoStash", description: "Guarda alterações locais antes do rebase.", kind: GitConfigKindBool},
+	{key: "init.defaultBranch", description: "Branch inicial de novos repositórios.", kind: GitConfigKindBranch},
+}
+
+var (
+	gitConfigEmailRegex = regexp.MustCompile(`^[^@\s<>]+@[^@\s<>]+\.[^@\s<>]+$`)
+	gitConfigToolRegex  = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+)
+
+// NormalizeGitConfigScope aceita local (padrão) e global; "" para escopos não editáveis.
+func NormalizeGitConfigScope(scope string) string {
+	switch strings.ToLower(strings.TrimSpace(scope)) {
+	case "", GitConfigScopeLocal, "repo":
+		return GitConfigScopeLocal
+	case GitConfigScopeGlobal, "user":
+		return GitConfigScopeGlobal
+	default:
+		return ""
+	}
+}
+
+// GetConfig lê as chaves editáveis no escopo pedido e o valor efetivo (com o escopo de origem).
+func (s *Service) GetConfig(ctx context.Context, repoPath string, scope string) (GitConfigDTO, error) {
+	normalizedScope := NormalizeGitConfigScope(scope)
+	if normalizedScope == "" {
+		return GitConfigDTO{}, invalidGitConfigScopeError(scope)
+	}
+	preflight, err := s.preflightContext(ctx, repoPath)
+	if err != nil {
+		return GitConfigDTO{}, err
+	}
+	return s.readGitConfigEntries(ctx, preflight.RepoRoot, normalizedScope)
+}
+
+// SetConfig grava (ou remove, com valor vazio) uma chave da whitelist no escopo pedido.
+func (s *Service) SetConfig(ctx context.Context, repoPath string, scope string, key string, value string) (GitConfigDTO, error) {
+	commandID, startedAt := s.beginCommand("set_config")
+	normalizedScope := NormalizeGitConfigScope(scope)
+	args := []string{"config", "--" + normalizedScope, strings.TrimSpace(key)}
+
+	var validationErr error
+	definition, known := findGitConfigDefinition(key)
+	switch {
+	case normalizedScope == "":
+		validationErr = invalidGitConfigScopeError(scope)
+	case !known:
+		validationErr = NewBindingError(CodeCommandFailed, "Chave de configuração não permitida.", "O editor aceita apenas: "+strings.Join(gitConfigWhitelistKeys(), ", ")+".")
+	}
+	if validationErr != nil {
+		s.emitCommandFailure(commandID, repoPath, "set_config", args, startedAt, validationErr)
+		return GitConfigDTO{}, validationErr
+	}
+
+	preflight, err := s.preflightContext(ctx, repoPath)
+	if err != nil {
+		s.emitCommandFailure(commandID, repoPath, "set_config", args, startedAt, err)
+		return GitConfigDTO{}, err
+	}
+	root := preflight.RepoRoot
+
+	normalizedValue, err := s.validateGitConfigValue(ctx, root, definition, value)
+	if err != nil {
+		s.emitCommandFailure(commandID, root, "set_config", args, startedAt, err)
+		return GitConfigDTO{}, err
+	}
+
+	if err := s.executeWriteContext(
+		ctx,
+		root,
+		commandID,
+		"set_config",
+		args,
+		startedAt,
+		defaultWriteTimeout,
+		func(ctx context.Context, diag *commandDiagnosticState) error {
+			gitArgs := []string{"-C", root, "config", "--" + normalizedScope, definition.key, normalizedValue}
+			if normalizedValue == "" {
+				gitArgs = []string{"-C", root, "config", "--" + normalizedScope, "--unset-all", definition.key}
+			}
+			_, errOut, exitCode, runErr := s.runWriteGitWithRetry(ctx, diag, "", gitArgs...)
+			// exit 5 no --unset-all: a chave já não existia.
+			if runErr != nil && !(normalizedValue == "" && exitCode == 5) {
+				return wrapWriteCommandError(CodeCommandFailed, "Falha ao gravar a configuração.", errOut, exitCode, runErr)
+			}
+			return nil
+		}); err != nil {
+		return GitConfigDTO{}, err
+	}
+
+	s.invalidateRepoCaches(root)
+	return s.readGitConfigEntries(ctx, root, normalizedScope)
+}
+
+func (s *Service) readGitConfigEntries(ctx context.Context, root string, scope string) (GitConfigDTO, error) {
+	out, errOut, exitCode, runErr := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "config", "--list", "--show-scope", "-z")
+	if runErr != nil {
+		return GitConfigDTO{}, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao ler a configuração do Git.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+	values := parseGitConfigList(out)
+
+	result := GitConfigDTO{
+		Scope:    scope,
+		RepoRoot: root,
+		Entries:  make([]GitConfigEntryDTO, 0, len(gitConfigWhitelist)),
+		Warnings: []string{},
+	}
+	for _, definition := range gitConfigWhitelist {
+		entry := GitConfigEntryDTO{
+			Key:         definition.key,
+			Description: definition.description,
+			Kind:        definition.kind,
+			Options:     definition.options,
+		}
+		if definition.kind == GitConfigKindTool {
+			entry.Options = gitConfigToolOptions()
+		}
+		// A lista vem em ordem de precedência (system, global, local, worktree): o último vence.
+		for _, item := range values {
+			if !strings.EqualFold(item.key, definition.key) {
+				continue
+			}
+			entry.EffectiveValue, entry.EffectiveScope = item.value, item.scope
+			if item.scope == scope {
+				entry.Value, entry.Set = item.value, true
+			}
+		}
+		if entry.Options == nil {
+			entry.Options = []string{}
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	if GitConfigEffectiveValue(result, "user.email") == "" {
+		result.Warnings = append(result.Warnings, "user.email não definido: o Git recusará novos commits neste repositório.")
+	}
+	if GitConfigEffectiveValue(result, "user.name") == "" {
+		result.Warnings = append(result.Warnings, "user.name não definido: o Git recusará novos commits neste repositório.")
+	}
+	return result, nil
+}
+
+// GitConfigEffectiveValue retorna o valor efetivo de uma chave (vazio quando não definida).
+func GitConfigEffectiveValue(config GitConfigDTO, key string) string {
+	for _, entry := range config.Entries {
+		if strings.EqualFold(entry.Key, key) {
+			return entry.EffectiveValue
+		}
+	}
+	return ""
+}
+
+func (s *Service) validateGitConfigValue(ctx context.Context, root string, definition gitConfigKeyDefinition, value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return "", nil
+	}
+	invalid := func(details string) (string, error) {
+		return "", NewBindingError(CodeCommandFailed, "Valor inválido para "+definition.key+".", details)
+	}
+	if strings.ContainsAny(trimmed, "\r\n\x00") {
+		return invalid("O valor deve ocupar uma única linha.")
+	}
+
+	switch definition.kind {
+	case GitConfigKindText:
+		if len(trimmed) > 256 {
+			return invalid("Use no máximo 256 caracteres.")
+		}
+	case GitConfigKindEmail:
+		if !gitConfigEmailRegex.MatchString(trimmed) {
+			return invalid("Informe um e-mail no formato nome@dominio.")
+		}
+	case GitConfigKindBool:
+		switch strings.ToLower(trimmed) {
+		case "true", "yes", "on", "1":
+			return "true", nil
+		case "false", "no", "off", "0":
+			return "false", nil
+		}
+		return invalid("Use true ou false.")
+	case GitConfigKindEnum:
+		for _, option := range definition.options {
+			if strings.EqualFold(option, trimmed) {
+				return option, nil
+			}
+		}
+		return invalid("Opções: " + strings.Join(definition.options, ", ") + ".")
+	case GitConfigKindTool:
+		if !gitConfigToolRegex.MatchString(trimmed) {
+			return invalid("Use o nome da ferramenta (ex.: vscode, meld, kdiff3).")
+		}
+	case GitConfigKindBranch:
+		if _, _, _, runErr := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "check-ref-format", "--branch", trimmed); runErr != nil || strings.HasPrefix(trimmed, "-") {
+			return invalid("Nome de branch inválido.")
+		}
+	}
+	return trimmed, nil
+}
+
+type gitConfigListItem struct {
+	scope string
+	key   string
+	value string
+}
+
+// parseGitConfigList lê "git config --list --show-scope -z": "<escopo>\0<chave>\n<valor>\0".
+func parseGitConfigList(output string) []gitConfigListItem {
+	tokens := strings.Split(output, "\x00")
+	items := make([]gitConfigListItem, 0, len(tokens)/2)
+	for i := 0; i+1 < len(tokens); i += 2 {
+		key, value, _ := strings.Cut(tokens[i+1], "\n")
+		if key == "" {
+			continue
+		}
+		items = append(items, gitConfigListItem{scope: tokens[i], key: key, value: value})
+	}
+	return items
+}
+
+func findGitConfigDefinition(key string) (gitConfigKeyDefinition, bool) {
+	trimmed := strings.TrimSpace(key)
+	for _, definition := range gitConfigWhitelist {
+		if strings.EqualFold(definition.key, trimmed) {
+			return definition, true
+		}
+	}
+	return gitConfigKeyDefinition{}, false
+}
+
+func gitConfigWhitelistKeys() []string {
+	keys := make([]string, 0, len(gitConfigWhitelist))
+	for _, definition := range gitConfigWhitelist {
+		keys = append(keys, definition.key)
+	}
+	return keys
+}
+
+// gitConfigToolOptions sugere as ferramentas do registro interno de merge/diff.
+func gitConfigToolOptions() []string {
+	options := make([]string, 0, len(externalToolDefinitions))
+	for _, definition := range externalToolDefinitions {
+		options = append(options, definition.id)
+	}
+	return options
+}
+
+func invalidGitConfigScopeError(scope string) error {
+	return NewBindingError(CodeCommandFailed, "Escopo de configuração inválido.", "Use local ou global (recebido: "+strings.TrimSpace(scope)+").")
+}
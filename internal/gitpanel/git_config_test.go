@@ -0,0 +1,70 @@
+package gitpanel
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGitConfigEditorValidatesWhitelistAndScopes(t *testing.T) {
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	config, err := svc.GetConfig(context.Background(), repoRoot, "")
+	if err != nil {
+		t.Fatalf("GetConfig() returned error: %v", err)
+	}
+	if config.Scope != GitConfigScopeLocal || len(config.Entries) != len(gitConfigWhitelist) || len(config.Warnings) != 0 {
+		t.Fatalf("unexpected local config: %+v", config)
+	}
+	if GitConfigEffectiveValue(config, "user.email") != "tests@orch.local" {
+		t.Fatalf("expected local user.email, got %+v", config.Entries)
+	}
+
+	rejected := []struct{ scope, key, value string }{
+		{"system", "user.name", "Dev"},
+		{"local", "core.sshCommand", "ssh -i key"},
+		{"local", "user.email", "not-an-email"},
+		{"local", "pull.rebase", "sometimes"},
+		{"local", "init.defaultBranch", "bad..name"},
+		{"local", "user.name", "line\nbreak"},
+	}
+	for _, tc := range rejected {
+		if _, err := svc.SetConfig(context.Background(), repoRoot, tc.scope, tc.key, tc.value); err == nil {
+			t.Fatalf("expected SetConfig(%q, %q, %q) to fail", tc.scope, tc.key, tc.value)
+		}
+	}
+
+	if _, err := svc.SetConfig(context.Background(), repoRoot, "global", "Pull.Rebase", "TRUE"); err != nil {
+		t.Fatalf("SetConfig(global) returned error: %v", err)
+	}
+	config, err = svc.SetConfig(context.Background(), repoRoot, "local", "fetch.prune", "yes")
+	if err != nil {
+		t.Fatalf("SetConfig(local) returned error: %v", err)
+	}
+	entries := make(map[string]GitConfigEntryDTO, len(config.Entries))
+	for _, entry := range config.Entries {
+		entries[entry.Key] = entry
+	}
+	if entry := entries["fetch.prune"]; !entry.Set || entry.Value != "true" || entry.EffectiveScope != "local" {
+		t.Fatalf("unexpected fetch.prune entry: %+v", entry)
+	}
+	if entry := entries["pull.rebase"]; entry.Set || entry.EffectiveValue != "true" || entry.EffectiveScope != "global" {
+		t.Fatalf("expected pull.rebase inherited from global, got %+v", entry)
+	}
+	if entry := entries["merge.tool"]; entry.Kind != GitConfigKindTool || len(entry.Options) == 0 {
+		t.Fatalf("expected merge.tool suggestions, got %+v", entry)
+	}
+
+	// Valor vazio remove a chave do escopo.
+	config, err = svc.SetConfig(context.Background(), repoRoot, "local", "user.email", "")
+	if err != nil {
+		t.Fatalf("SetConfig(unset) returned error: %v", err)
+	}
+	if GitConfigEffectiveValue(config, "user.email") != "" || len(config.Warnings) != 1 || !strings.Contains(config.Warnings[0], "user.email") {
+		t.Fatalf("expected missing identity warning, got %+v", config.Warnings)
+	}
+}
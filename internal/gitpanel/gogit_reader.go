@@ -0,0 +1,520 @@
+package gitpanel
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+const (
+	// maxAheadBehindWalk limita os commits visitados para calcular ahead/behind em processo.
+	maxAheadBehindWalk = 50000
+	goGitShortHashLen  = 7
+	goGitDateLayout    = "2006-01-02T15:04:05-07:00" // igual ao %aI do git (UTC vira +00:00)
+)
+
+// goGitFallback indica que o go-git não reproduz o CLI neste repositório; o chamador usa o CLI.
+type goGitFallback struct {
+	reason string
+}
+
+func (f *goGitFallback) Error() string {
+	return "go-git fallback: " + f.reason
+}
+
+func fallbackToCLI(format string, args ...any) error {
+	return &goGitFallback{reason: fmt.Sprintf(format, args...)}
+}
+
+// goGitFallbackReason retorna o motivo quando err é um fallback.
+func goGitFallbackReason(err error) (string, bool) {
+	var fallback *goGitFallback
+	if errors.As(err, &fallback) {
+		return fallback.reason, true
+	}
+	return "", false
+}
+
+// openGoGitRepo abre o repositório só quando .git é um diretório comum; worktrees vinculados,
+// submódulos e clones rasos ficam com o CLI.
+func openGoGitRepo(repoRoot string) (*git.Repository, string, error) {
+	gitDir := filepath.Join(repoRoot, ".git")
+	info, err := os.Stat(gitDir)
+	if err != nil || !info.IsDir() {
+		return nil, "", fallbackToCLI("worktree vinculado (.git não é diretório)")
+	}
+	if fileExists(filepath.Join(gitDir, "shallow")) {
+		return nil, "", fallbackToCLI("clone raso")
+	}
+	repo, err := git.PlainOpen(repoRoot)
+	if err != nil {
+		return nil, "", fallbackToCLI("go-git não abriu o repositório: %v", err)
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return nil, "", fallbackToCLI("config ilegível pelo go-git: %v", err)
+	}
+	if format := strings.ToLower(cfg.Raw.Section("extensions").Options.Get("objectformat")); format != "" && format != "sha1" {
+		return nil, "", fallbackToCLI("object format %s", format)
+	}
+	return repo, gitDir, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// goGitStatusPorcelain gera a mesma saída de "git status --porcelain=v1 -z --branch" sem criar
+// processo, para reaproveitar o parser do CLI.
+func goGitStatusPorcelain(repoRoot string) (string, error) {
+	if fileExists(filepath.Join(repoRoot, ".gitmodules")) {
+		return "", fallbackToCLI("submódulos")
+	}
+	if fileExists(filepath.Join(repoRoot, ".gitattributes")) {
+		return "", fallbackToCLI(".gitattributes (filtros/eol)")
+	}
+	repo, gitDir, err := openGoGitRepo(repoRoot)
+	if err != nil {
+		return "", err
+	}
+	if operation := detectInProgressOperation(gitDir); operation != "" {
+		return "", fallbackToCLI("operação em andamento (%s)", operation)
+	}
+
+	cfg, _ := repo.Config()
+	core := cfg.Raw.Section("core").Options
+	if strings.EqualFold(core.Get("sparsecheckout"), "true") {
+		return "", fallbackToCLI("sparse-checkout")
+	}
+	if autocrlf := strings.ToLower(core.Get("autocrlf")); autocrlf != "" && autocrlf != "false" {
+		return "", fallbackToCLI("core.autocrlf=%s", autocrlf)
+	}
+	if strings.EqualFold(core.Get("filemode"), "false") {
+		return "", fallbackToCLI("core.fileMode=false")
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", fallbackToCLI("HEAD sem commits")
+	}
+	index, err := repo.Storer.Index()
+	if err != nil {
+		return "", fallbackToCLI("índice ilegível pelo go-git: %v", err)
+	}
+	trackedDirs := make(map[string]struct{})
+	for _, entry := range index.Entries {
+		if entry.Stage != 0 {
+			return "", fallbackToCLI("conflitos no índice")
+		}
+		if entry.SkipWorktree || entry.IntentToAdd {
+			return "", fallbackToCLI("entradas skip-worktree/intent-to-add")
+		}
+		name := entry.Name
+		for i := strings.LastIndexByte(name, '/'); i > 0; i = strings.LastIndexByte(name[:i], '/') {
+			trackedDirs[name[:i]] = struct{}{}
+		}
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", fallbackToCLI("worktree indisponível: %v", err)
+	}
+	worktree.Excludes = userExcludePatterns()
+	status, err := worktree.Status()
+	if err != nil {
+		return "", fallbackToCLI("status do go-git falhou: %v", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(goGitBranchHeader(repo, head))
+	builder.WriteByte(0)
+
+	paths := make([]string, 0, len(status))
+	for path := range status {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	stagedAdded, stagedDeleted := false, false
+	untrackedSeen := make(map[string]struct{})
+	for _, path := range paths {
+		fileStatus := status[path]
+		x, y := byte(fileStatus.Staging), byte(fileStatus.Worktree)
+		if fileStatus.Staging == git.Untracked && fileStatus.Worktree == git.Untracked {
+			// O CLI (-unormal) mostra "dir/" quando o diretório não tem nenhum arquivo rastreado.
+			entry := collapseUntrackedPath(path, trackedDirs)
+			if _, seen := untrackedSeen[entry]; seen {
+				continue
+			}
+			untrackedSeen[entry] = struct{}{}
+			builder.WriteString("?? " + entry)
+			builder.WriteByte(0)
+			continue
+		}
+		if fileStatus.Staging == git.Untracked {
+			x = ' '
+		}
+		if x == ' ' && y == ' ' {
+			continue
+		}
+		stagedAdded = stagedAdded || x == 'A'
+		stagedDeleted = stagedDeleted || x == 'D'
+		builder.WriteString(string([]byte{x, y, ' '}) + path)
+		builder.WriteByte(0)
+	}
+	if stagedAdded && stagedDeleted {
+		// O status do go-git não detecta renomeações; o CLI mostraria "R".
+		return "", fallbackToCLI("possível renomeação no stage")
+	}
+
+	// A ordem do porcelain coloca "??" depois das entradas rastreadas.
+	return reorderUntrackedLast(builder.String()), nil
+}
+
+func collapseUntrackedPath(path string, trackedDirs map[string]struct{}) string {
+	parts := strings.Split(path, "/")
+	for i := 1; i < len(parts); i++ {
+		dir := strings.Join(parts[:i], "/")
+		if _, tracked := trackedDirs[dir]; !tracked {
+			return dir + "/"
+		}
+	}
+	return path
+}
+
+func reorderUntrackedLast(raw string) string {
+	records := strings.Split(strings.TrimSuffix(raw, "\x00"), "\x00")
+	ordered := make([]string, 0, len(records))
+	untracked := make([]string, 0)
+	for _, record := range records {
+		if strings.HasPrefix(record, "?? ") {
+			untracked = append(untracked, record)
+			continue
+		}
+		ordered = append(ordered, record)
+	}
+	ordered = append(ordered, untracked...)
+	return strings.Join(ordered, "\x00") + "\x00"
+}
+
+// userExcludePatterns carrega core.excludesFile global/sistema, ou ~/.config/git/ignore (padrão
+// do git quando core.excludesFile não está definido). O .git/info/exclude o go-git já lê.
+func userExcludePatterns() []gitignore.Pattern {
+	root := osfs.New("/")
+	patterns, _ := gitignore.LoadSystemPatterns(root)
+	global, _ := gitignore.LoadGlobalPatterns(root)
+	if len(global) == 0 {
+		global = readXDGIgnorePatterns()
+	}
+	return append(patterns, global...)
+}
+
+func readXDGIgnorePatterns() []gitignore.Pattern {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	data, err := os.ReadFile(filepath.Join(configHome, "git", "ignore"))
+	if err != nil {
+		return nil
+	}
+	patterns := make([]gitignore.Pattern, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, nil))
+	}
+	return patterns
+}
+
+// goGitBranchHeader monta a linha "## ..." do porcelain (branch, upstream, ahead/behind).
+func goGitBranchHeader(repo *git.Repository, head *plumbing.Reference) string {
+	if !head.Name().IsBranch() {
+		return "## HEAD (no branch)"
+	}
+	branch := head.Name().Short()
+	header := "## " + branch
+
+	cfg, err := repo.Config()
+	if err != nil {
+		return header
+	}
+	branchCfg, ok := cfg.Branches[branch]
+	if !ok || branchCfg.Remote == "" || branchCfg.Merge == "" {
+		return header
+	}
+
+	upstreamRef := branchCfg.Merge
+	upstreamName := branchCfg.Merge.Short()
+	if branchCfg.Remote != "." {
+		upstreamRef = plumbing.NewRemoteReferenceName(branchCfg.Remote, branchCfg.Merge.Short())
+		upstreamName = branchCfg.Remote + "/" + branchCfg.Merge.Short()
+	}
+	header += "..." + upstreamName
+
+	upstream, err := repo.Reference(upstreamRef, true)
+	if err != nil {
+		return header + " [gone]"
+	}
+	ahead, behind, ok := countAheadBehind(repo, head.Hash(), upstream.Hash())
+	switch {
+	case !ok:
+		// Histórico grande demais para o walk em processo: deixa sem contadores.
+		return header
+	case ahead > 0 && behind > 0:
+		return fmt.Sprintf("%s [ahead %d, behind %d]", header, ahead, behind)
+	case ahead > 0:
+		return fmt.Sprintf("%s [ahead %d]", header, ahead)
+	case behind > 0:
+		return fmt.Sprintf("%s [behind %d]", header, behind)
+	}
+	return header
+}
+
+const (
+	sideLocal    uint8 = 1
+	sideUpstream uint8 = 2
+	sideBoth           = sideLocal | sideUpstream
+)
+
+type aheadBehindItem struct {
+	commit *object.Commit
+	when   time.Time
+}
+
+type aheadBehindQueue []aheadBehindItem
+
+func (q aheadBehindQueue) Len() int           { return len(q) }
+func (q aheadBehindQueue) Less(i, j int) bool { return q[i].when.After(q[j].when) }
+func (q aheadBehindQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *aheadBehindQueue) Push(x any)        { *q = append(*q, x.(aheadBehindItem)) }
+func (q *aheadBehindQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// countAheadBehind faz o mesmo que "rev-list --left-right --count local...upstream": pinta os
+// ancestrais de cada lado, do mais recente ao mais antigo, até só restarem commits comuns.
+func countAheadBehind(repo *git.Repository, local, upstream plumbing.Hash) (int, int, bool) {
+	if local == upstream {
+		return 0, 0, true
+	}
+	flags := make(map[plumbing.Hash]uint8)
+	queued := make(map[plumbing.Hash]int)
+	queue := &aheadBehindQueue{}
+	pending := 0 // entradas da fila cujo commit ainda só pertence a um lado
+
+	push := func(hash plumbing.Hash, side uint8) bool {
+		previous := flags[hash]
+		updated := previous | side
+		if updated == previous {
+			return true
+		}
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			return false
+		}
+		flags[hash] = updated
+		if previous != 0 && updated == sideBoth {
+			pending -= queued[hash] // entradas antigas deixam de ser exclusivas
+		}
+		queued[hash]++
+		if updated != sideBoth {
+			pending++
+		}
+		heap.Push(queue, aheadBehindItem{commit: commit, when: commit.Committer.When})
+		return true
+	}
+	if !push(local, sideLocal) || !push(upstream, sideUpstream) {
+		return 0, 0, false
+	}
+
+	visited := 0
+	for queue.Len() > 0 && pending > 0 {
+		item := heap.Pop(queue).(aheadBehindItem)
+		side := flags[item.commit.Hash]
+		queued[item.commit.Hash]--
+		if side != sideBoth {
+			pending--
+		}
+		visited++
+		if visited > maxAheadBehindWalk {
+			return 0, 0, false
+		}
+		for _, parent := range item.commit.ParentHashes {
+			if !push(parent, side) {
+				return 0, 0, false
+			}
+		}
+	}
+
+	ahead, behind := 0, 0
+	for _, side := range flags {
+		switch side {
+		case sideLocal:
+			ahead++
+		case sideUpstream:
+			behind++
+		}
+	}
+	return ahead, behind, true
+}
+
+// goGitHistoryPage lê até limit+1 commits a partir do HEAD (após o cursor), na mesma ordem e
+// formato do git log do histórico. Busca e escopo de caminho ficam com o CLI.
+func goGitHistoryPage(ctx context.Context, repoRoot string, cursorHash string, limit int, withStats bool) ([]HistoryItemDTO, error) {
+	repo, _, err := openGoGitRepo(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fallbackToCLI("HEAD sem commits")
+	}
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash(), Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, fallbackToCLI("log do go-git falhou: %v", err)
+	}
+	defer iter.Close()
+
+	items := make([]HistoryItemDTO, 0, limit+1)
+	found := cursorHash == ""
+	var walkErr error
+	err = iter.ForEach(func(commit *object.Commit) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !found {
+			found = strings.HasPrefix(commit.Hash.String(), cursorHash)
+			return nil
+		}
+		if commit.PGPSignature != "" {
+			// A verificação (%G?) depende do gpg/ssh do usuário.
+			walkErr = fallbackToCLI("commits assinados")
+			return storer.ErrStop
+		}
+		item := goGitHistoryItem(commit)
+		if withStats && commit.NumParents() <= 1 {
+			if statsErr := fillGoGitNumstat(ctx, commit, &item); statsErr != nil {
+				walkErr = fallbackToCLI("numstat do go-git falhou: %v", statsErr)
+				return storer.ErrStop
+			}
+		}
+		items = append(items, item)
+		if len(items) > limit {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return nil, walkErr
+	}
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fallbackToCLI("log do go-git falhou: %v", err)
+	}
+	if !found {
+		return nil, fallbackToCLI("cursor fora do histórico do HEAD")
+	}
+	return items, nil
+}
+
+func goGitHistoryItem(commit *object.Commit) HistoryItemDTO {
+	hash := commit.Hash.String()
+	return HistoryItemDTO{
+		Hash:        hash,
+		ShortHash:   hash[:goGitShortHashLen],
+		Author:      commit.Author.Name,
+		AuthoredAt:  commit.Author.When.Format(goGitDateLayout),
+		Subject:     commitSubject(commit.Message),
+		AuthorEmail: commit.Author.Email,
+	}
+}
+
+// commitSubject reproduz o %s do git: o primeiro parágrafo com as quebras viradas espaço.
+func commitSubject(message string) string {
+	lines := make([]string, 0, 2)
+	for _, line := range strings.Split(strings.TrimLeft(message, "\r\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, " ")
+}
+
+// fillGoGitNumstat soma as linhas como o --numstat: renomeações contam uma vez e binários
+// entram em ChangedFiles com 0/0. Merges ficam zerados, como no git log sem -m.
+func fillGoGitNumstat(ctx context.Context, commit *object.Commit, item *HistoryItemDTO) error {
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	parentTree := &object.Tree{}
+	if commit.NumParents() == 1 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return err
+		}
+	}
+	changes, err := parentTree.DiffContext(ctx, tree)
+	if err != nil {
+		return err
+	}
+	patch, err := changes.PatchContext(ctx)
+	if err != nil {
+		return err
+	}
+	for _, filePatch := range patch.FilePatches() {
+		item.ChangedFiles++
+		if filePatch.IsBinary() {
+			continue
+		}
+		for _, chunk := range filePatch.Chunks() {
+			content := chunk.Content()
+			if content == "" {
+				continue
+			}
+			count := strings.Count(content, "\n")
+			if !strings.HasSuffix(content, "\n") {
+				count++
+			}
+			switch chunk.Type() {
+			case fdiff.Add:
+				item.Additions += count
+			case fdiff.Delete:
+				item.Deletions += count
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package gitpanel
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// mustInitParityRepo cria um repositório com upstream divergente e alterações de todos os tipos.
+func mustInitParityRepo(t *testing.T) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	origin := mustInitTestRepo(t)
+	for i := range 3 {
+		t.Setenv("GIT_COMMITTER_DATE", fmt.Sprintf("2026-03-0%dT10:00:00+02:00", i+1))
+		t.Setenv("GIT_AUTHOR_DATE", fmt.Sprintf("2026-03-0%dT09:00:00Z", i+1))
+		writeParityFile(t, origin, fmt.Sprintf("src/file%d.txt", i), fmt.Sprintf("line %d\nsecond\n", i))
+		runGitOrFail(t, origin, "add", "--", ".")
+		runGitOrFail(t, origin, "commit", "-m", fmt.Sprintf("change %d\nwrapped subject\n\nbody", i))
+	}
+
+	repoRoot := filepath.Join(t.TempDir(), "clone")
+	runGitOrFail(t, origin, "clone", "-q", origin, repoRoot)
+	runGitOrFail(t, repoRoot, "config", "user.email", "tests@orch.local")
+	runGitOrFail(t, repoRoot, "config", "user.name", "ORCH Tests")
+
+	t.Setenv("GIT_COMMITTER_DATE", "2026-03-05T10:00:00Z")
+	writeParityFile(t, origin, "upstream.txt", "remote only\n")
+	runGitOrFail(t, origin, "add", "--", ".")
+	runGitOrFail(t, origin, "commit", "-m", "remote change")
+	runGitOrFail(t, repoRoot, "fetch", "-q")
+
+	t.Setenv("GIT_COMMITTER_DATE", "2026-03-06T10:00:00Z")
+	writeParityFile(t, repoRoot, "src/file0.txt", "rewritten\n")
+	writeParityFile(t, repoRoot, "bin.dat", "\x00\x01binary")
+	runGitOrFail(t, repoRoot, "add", "--", ".")
+	runGitOrFail(t, repoRoot, "commit", "-m", "local change")
+
+	writeParityFile(t, repoRoot, "src/file1.txt", "staged\n")
+	runGitOrFail(t, repoRoot, "add", "--", "src/file1.txt")
+	writeParityFile(t, repoRoot, "src/file1.txt", "staged then edited\n")
+	if err := os.Remove(filepath.Join(repoRoot, "src", "file2.txt")); err != nil {
+		t.Fatalf("remove file: %v", err)
+	}
+	writeParityFile(t, repoRoot, "src/new.txt", "untracked in tracked dir\n")
+	writeParityFile(t, repoRoot, "docs/guide/intro.md", "untracked dir\n")
+	writeParityFile(t, repoRoot, "docs/guide/more.md", "untracked dir\n")
+	writeParityFile(t, repoRoot, "build/out.log", "ignored\n")
+	writeParityFile(t, repoRoot, ".gitignore", "build/\n")
+	return repoRoot
+}
+
+func writeParityFile(t *testing.T, repoRoot string, name string, content string) {
+	t.Helper()
+	path := filepath.Join(repoRoot, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func TestGoGitReadBackendMatchesCLI(t *testing.T) {
+	repoRoot := mustInitParityRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	cliStatus, err := svc.GetStatus(repoRoot)
+	if err != nil {
+		t.Fatalf("GetStatus (cli) error: %v", err)
+	}
+	cliHistory, err := svc.GetHistory(repoRoot, "", 2, "")
+	if err != nil {
+		t.Fatalf("GetHistory (cli) error: %v", err)
+	}
+	cliNext, err := svc.GetHistory(repoRoot, cliHistory.NextCursor, 2, "")
+	if err != nil {
+		t.Fatalf("GetHistory page 2 (cli) error: %v", err)
+	}
+
+	var samples []ReadSample
+	svc.SetReadObserver(func(sample ReadSample) { samples = append(samples, sample) })
+	backend, err := svc.SetRepoReadBackend(repoRoot, ReadBackendGoGit)
+	if err != nil || backend.Backend != ReadBackendGoGit || !backend.Override {
+		t.Fatalf("SetRepoReadBackend() = %+v, %v", backend, err)
+	}
+
+	goStatus, err := svc.GetStatus(repoRoot)
+	if err != nil {
+		t.Fatalf("GetStatus (gogit) error: %v", err)
+	}
+	if !reflect.DeepEqual(cliStatus, goStatus) {
+		t.Fatalf("status mismatch\ncli:   %+v\ngogit: %+v", cliStatus, goStatus)
+	}
+	if cliStatus.Ahead != 1 || cliStatus.Behind != 1 || cliStatus.Upstream != "origin/master" && cliStatus.Upstream != "origin/main" {
+		t.Fatalf("fixture should diverge from upstream, got %+v", cliStatus)
+	}
+
+	goHistory, err := svc.GetHistory(repoRoot, "", 2, "")
+	if err != nil {
+		t.Fatalf("GetHistory (gogit) error: %v", err)
+	}
+	goNext, err := svc.GetHistory(repoRoot, goHistory.NextCursor, 2, "")
+	if err != nil {
+		t.Fatalf("GetHistory page 2 (gogit) error: %v", err)
+	}
+	// A estimativa de total é calculada em background e pode ainda não estar pronta.
+	for _, page := range []*HistoryPageDTO{&cliHistory, &cliNext, &goHistory, &goNext} {
+		page.EstimatedTotal = 0
+	}
+	if !reflect.DeepEqual(cliHistory, goHistory) || !reflect.DeepEqual(cliNext, goNext) {
+		t.Fatalf("history mismatch\ncli:   %+v %+v\ngogit: %+v %+v", cliHistory, cliNext, goHistory, goNext)
+	}
+
+	for _, sample := range samples {
+		if sample.Backend != ReadBackendGoGit || sample.Fallback != "" {
+			t.Fatalf("expected reads served by go-git, got %+v", sample)
+		}
+	}
+	if len(samples) != 3 {
+		t.Fatalf("expected 3 observed reads, got %d", len(samples))
+	}
+}
+
+func TestGoGitReadBackendFallsBackToCLI(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+	svc.SetDefaultReadBackend(ReadBackendGoGit)
+
+	// Renomeação no stage: o go-git veria A + D, o CLI mostra R.
+	runGitOrFail(t, repoRoot, "mv", "README.md", "GUIDE.md")
+	status, err := svc.GetStatus(repoRoot)
+	if err != nil {
+		t.Fatalf("GetStatus error: %v", err)
+	}
+	if len(status.Staged) != 1 || status.Staged[0].Status[0] != 'R' {
+		t.Fatalf("expected rename from CLI fallback, got %+v", status.Staged)
+	}
+	backend, err := svc.GetRepoReadBackend(repoRoot)
+	if err != nil || backend.Backend != ReadBackendGoGit || backend.Override || backend.LastFallback == "" {
+		t.Fatalf("expected recorded fallback, got %+v (%v)", backend, err)
+	}
+
+	if _, err := svc.GetHistoryInScope(repoRoot, "", 10, "", "src"); err != nil {
+		t.Fatalf("GetHistoryInScope error: %v", err)
+	}
+	if backend, _ := svc.GetRepoReadBackend(repoRoot); backend.LastFallback != "busca ou escopo de caminho" {
+		t.Fatalf("expected scoped history to use the CLI, got %+v", backend)
+	}
+}
+
+func TestBenchmarkReadBackendsReportsBothBackends(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	result, err := svc.BenchmarkReadBackends(repoRoot, 2)
+	if err != nil {
+		t.Fatalf("BenchmarkReadBackends error: %v", err)
+	}
+	if result.Iterations != 2 || len(result.Results) != 4 {
+		t.Fatalf("unexpected benchmark: %+v", result)
+	}
+	for _, entry := range result.Results {
+		if entry.AvgMs <= 0 || entry.Error != "" || entry.Fallback != "" {
+			t.Fatalf("unexpected benchmark entry: %+v", entry)
+		}
+	}
+	if _, err := NormalizeReadBackend("libgit2"); err == nil {
+		t.Fatalf("expected unknown backend to be rejected")
+	}
+}
+
+func TestCollapseUntrackedPathAndCommitSubject(t *testing.T) {
+	tracked := map[string]struct{}{"src": {}, "src/pkg": {}}
+	cases := map[string]string{
+		"docs/a/b.md":     "docs/",
+		"src/new.go":      "src/new.go",
+		"src/pkg/x/y.go":  "src/pkg/x/",
+		"top.txt":         "top.txt",
+		"src/pkg/file.go": "src/pkg/file.go",
+	}
+	for path, want := range cases {
+		if got := collapseUntrackedPath(path, tracked); got != want {
+			t.Fatalf("collapseUntrackedPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+	if got := commitSubject("first\nline\n\nbody"); got != "first line" {
+		t.Fatalf("commitSubject() = %q", got)
+	}
+}
@@ -0,0 +1,439 @@
+package gitpanel
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// %G? verifica a assinatura de cada commit assinado (commits sem assinatura não custam nada).
+	historyLogFormat = "%H%x1f%h%x1f%an%x1f%aI%x1f%ae%x1f%G?%x1f%s%x1e"
+
+	// keysetHistoryMinCommits: abaixo disso --skip é barato e exato; acima, páginas após a
+	// primeira usam o timestamp do commit do cursor em vez de contar e pular N commits.
+	keysetHistoryMinCommits = 5000
+	// maxKeysetLeadingCommits limita quantos commits anteriores ao cursor (mesmo timestamp ou
+	// datas fora de ordem) são descartados antes de desistir do keyset.
+	maxKeysetLeadingCommits = 2000
+
+	historyStreamTimeout      = 5 * time.Minute
+	historyCountTimeout       = 30 * time.Second
+	defaultHistoryStreamBatch = 200
+	maxHistoryStreamBatch     = 1000
+	defaultHistoryStreamMax   = 5000
+	maxHistoryStreamMax       = 50000
+)
+
+type historyCountEntry struct {
+	head       string
+	total      int
+	computedAt time.Time
+	computing  bool
+}
+
+// historyLogArgs monta o git log do painel (header e, com withStats, numstat) com busca,
+// escopo e filtros extras.
+func historyLogArgs(repoRoot string, search string, pathScope string, withStats bool, extra ...string) []string {
+	args := []string{
+		"-C", repoRoot,
+		"log",
+		"--date=iso-strict",
+		"--pretty=format:" + historyLogFormat,
+	}
+	if withStats {
+		args = append(args, "--numstat")
+	}
+	if trimmedSearch := strings.TrimSpace(search); trimmedSearch != "" {
+		args = append(args, "--grep="+trimmedSearch, "-i")
+	}
+	args = append(args, extra...)
+	if pathScope != "" {
+		args = append(args, "--", pathScope)
+	}
+	return args
+}
+
+// streamHistoryItems executa o git log e entrega cada commit (já com numstat) assim que o
+// próximo header chega; fn retorna false para encerrar o processo sem ler o restante.
+func streamHistoryItems(ctx context.Context, timeout time.Duration, args []string, fn func(HistoryItemDTO) bool) error {
+	childCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(childCtx, "git", args...)
+	cmd.WaitDelay = gitWaitDelay
+	if env := gitEnvFromContext(ctx); len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	var current *HistoryItemDTO
+	stopped := false
+	flush := func() {
+		if current != nil && !stopped && !fn(*current) {
+			stopped = true
+			cancel()
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for !stopped && scanner.Scan() {
+		// Mesmo tratamento de parseHistoryItems: o header termina em \x1e.
+		for _, line := range strings.Split(strings.ReplaceAll(scanner.Text(), "\x1e", "\x1e\n"), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if header, ok := parseHistoryHeaderLine(line); ok {
+				flush()
+				if stopped {
+					break
+				}
+				current = &header
+				continue
+			}
+			if current == nil {
+				continue
+			}
+			if parsed, additions, deletions := parseHistoryNumstatLine(line); parsed {
+				current.ChangedFiles++
+				current.Additions += additions
+				current.Deletions += deletions
+			}
+		}
+	}
+	scanErr := scanner.Err()
+	if !stopped {
+		flush()
+	}
+	waitErr := cmd.Wait()
+
+	switch {
+	case stopped:
+		return nil
+	case childCtx.Err() == context.DeadlineExceeded:
+		return NewBindingError(CodeTimeout, "Comando Git excedeu o tempo limite.", strings.TrimSpace(stderr.String()))
+	case childCtx.Err() == context.Canceled:
+		return NewBindingError(CodeCanceled, "Comando Git cancelado.", strings.TrimSpace(stderr.String()))
+	case waitErr != nil:
+		exitCode := 0
+		if exitErr, ok := waitErr.(*exec.ExitError); ok {
+			exitCode = exitErr.ExitCode()
+		}
+		return NewBindingError(CodeCommandFailed, "Falha ao obter histórico do repositório.", formatCommandFailureDetails(stderr.String(), exitCode, waitErr))
+	case scanErr != nil:
+		return NewBindingError(CodeCommandFailed, "Falha ao ler histórico do repositório.", scanErr.Error())
+	}
+	return nil
+}
+
+// streamHistoryAfter percorre o histórico a partir do commit seguinte ao cursor usando keyset:
+// o git log é limitado a commits com data <= a do cursor e os que aparecem antes dele são
+// descartados (mesmo timestamp ou datas fora de ordem). found=false indica que o cursor não
+// apareceu e o chamador deve cair no --skip.
+func (s *Service) streamHistoryAfter(ctx context.Context, repoRoot string, cursorHash string, search string, pathScope string, withStats bool, fn func(HistoryItemDTO) bool) (bool, error) {
+	extra := []string{}
+	if cursorHash != "" {
+		timestamp, err := s.commitTimestamp(ctx, repoRoot, cursorHash)
+		if err != nil {
+			return false, err
+		}
+		extra = append(extra, "--min-age="+strconv.FormatInt(timestamp, 10))
+	}
+
+	found := cursorHash == ""
+	leading := 0
+	err := streamHistoryItems(ctx, historyStreamTimeout, historyLogArgs(repoRoot, search, pathScope, withStats, extra...), func(item HistoryItemDTO) bool {
+		if !found {
+			if strings.EqualFold(item.Hash, cursorHash) {
+				found = true
+				return true
+			}
+			leading++
+			return leading < maxKeysetLeadingCommits
+		}
+		return fn(item)
+	})
+	return found, err
+}
+
+func (s *Service) commitTimestamp(ctx context.Context, repoRoot string, commitHash string) (int64, error) {
+	out, errOut, exitCode, runErr := s.runGit(ctx, defaultReadTimeout, "", "-C", repoRoot, "show", "-s", "--format=%ct", commitHash+"^{commit}")
+	if runErr != nil {
+		return 0, NewBindingError(
+			CodeInvalidCursor,
+			"Cursor de histórico inválido.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+	timestamp, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		return 0, NewBindingError(CodeInvalidCursor, "Cursor de histórico inválido.", err.Error())
+	}
+	return timestamp, nil
+}
+
+// keysetHistoryPage busca uma página após o cursor sem --skip; ok=false pede o caminho antigo.
+func (s *Service) keysetHistoryPage(repoRoot string, cursorHash string, limit int, search string, pathScope string, withStats bool) ([]HistoryItemDTO, bool, error) {
+	items := make([]HistoryItemDTO, 0, limit+1)
+	found, err := s.streamHistoryAfter(context.Background(), repoRoot, cursorHash, search, pathScope, withStats, func(item HistoryItemDTO) bool {
+		items = append(items, item)
+		return len(items) <= limit
+	})
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return items, true, nil
+}
+
+// useKeysetHistory decide pelo tamanho do repositório (estimativa em cache, sem bloquear).
+func (s *Service) useKeysetHistory(repoRoot string) bool {
+	total, ok := s.cachedHistoryCount(repoRoot, "")
+	return ok && total >= keysetHistoryMinCommits
+}
+
+// GetHistoryCountEstimate retorna o total de commits do HEAD (no escopo, se informado),
+// recalculando quando o HEAD mudou desde a última contagem.
+func (s *Service) GetHistoryCountEstimate(repoPath string, scope string) (HistoryCountEstimateDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return HistoryCountEstimateDTO{}, err
+	}
+	pathScope, err := NormalizePathScope(preflight.RepoRoot, scope)
+	if err != nil {
+		return HistoryCountEstimateDTO{}, err
+	}
+	root := filepath.Clean(preflight.RepoRoot)
+	head := s.resolveHeadHash(context.Background(), root)
+
+	s.historyCountMu.Lock()
+	entry := s.historyCounts[historyCountKey(root, pathScope)]
+	if entry != nil && entry.head == head && !entry.computedAt.IsZero() {
+		result := historyCountDTO(root, pathScope, entry)
+		s.historyCountMu.Unlock()
+		return result, nil
+	}
+	s.historyCountMu.Unlock()
+
+	if err := s.computeHistoryCount(context.Background(), root, pathScope, head); err != nil {
+		return HistoryCountEstimateDTO{}, err
+	}
+	s.historyCountMu.Lock()
+	defer s.historyCountMu.Unlock()
+	return historyCountDTO(root, pathScope, s.historyCounts[historyCountKey(root, pathScope)]), nil
+}
+
+// precomputeHistoryCount conta os commits em background para a UI mostrar progresso na
+// rolagem profunda e para decidir entre --skip e keyset.
+func (s *Service) precomputeHistoryCount(repoRoot string, pathScope string) {
+	root := filepath.Clean(repoRoot)
+	key := historyCountKey(root, pathScope)
+
+	s.historyCountMu.Lock()
+	if s.historyCounts == nil {
+		s.historyCounts = make(map[string]*historyCountEntry)
+	}
+	entry := s.historyCounts[key]
+	if entry == nil {
+		entry = &historyCountEntry{}
+		s.historyCounts[key] = entry
+	}
+	if entry.computing {
+		s.historyCountMu.Unlock()
+		return
+	}
+	entry.computing = true
+	s.historyCountMu.Unlock()
+
+	ctx := s.shutdownCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	go func() {
+		defer func() {
+			s.historyCountMu.Lock()
+			entry.computing = false
+			s.historyCountMu.Unlock()
+		}()
+		head := s.resolveHeadHash(ctx, root)
+		s.historyCountMu.Lock()
+		fresh := entry.head == head && !entry.computedAt.IsZero()
+		s.historyCountMu.Unlock()
+		if head == "" || fresh {
+			return
+		}
+		_ = s.computeHistoryCount(ctx, root, pathScope, head)
+	}()
+}
+
+func (s *Service) computeHistoryCount(ctx context.Context, repoRoot string, pathScope string, head string) error {
+	args := []string{"-C", repoRoot, "rev-list", "--count", "HEAD"}
+	if pathScope != "" {
+		args = append(args, "--", pathScope)
+	}
+	out, errOut, exitCode, runErr := s.runGit(ctx, historyCountTimeout, "", args...)
+	if runErr != nil {
+		return NewBindingError(
+			CodeCommandFailed,
+			"Falha ao contar commits do repositório.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+	total, err := strconv.Atoi(strings.TrimSpace(out))
+	if err != nil {
+		return NewBindingError(CodeCommandFailed, "Falha ao contar commits do repositório.", err.Error())
+	}
+
+	s.historyCountMu.Lock()
+	defer s.historyCountMu.Unlock()
+	if s.historyCounts == nil {
+		s.historyCounts = make(map[string]*historyCountEntry)
+	}
+	key := historyCountKey(repoRoot, pathScope)
+	entry := s.historyCounts[key]
+	if entry == nil {
+		entry = &historyCountEntry{}
+		s.historyCounts[key] = entry
+	}
+	entry.head = head
+	entry.total = total
+	entry.computedAt = time.Now()
+	return nil
+}
+
+// cachedHistoryCount retorna a última contagem conhecida (pode ser de um HEAD anterior).
+func (s *Service) cachedHistoryCount(repoRoot string, pathScope string) (int, bool) {
+	s.historyCountMu.Lock()
+	defer s.historyCountMu.Unlock()
+	entry := s.historyCounts[historyCountKey(filepath.Clean(repoRoot), pathScope)]
+	if entry == nil || entry.computedAt.IsZero() {
+		return 0, false
+	}
+	return entry.total, true
+}
+
+func (s *Service) resolveHeadHash(ctx context.Context, repoRoot string) string {
+	out, _, _, err := s.runGit(ctx, defaultReadTimeout, "", "-C", repoRoot, "rev-parse", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+func historyCountKey(repoRoot string, pathScope string) string {
+	return repoRoot + "\x1f" + pathScope
+}
+
+// historyCountDTO monta a resposta; Exact indica que a contagem corresponde ao HEAD atual.
+func historyCountDTO(repoRoot string, pathScope string, entry *historyCountEntry) HistoryCountEstimateDTO {
+	if entry == nil {
+		return HistoryCountEstimateDTO{RepoRoot: repoRoot, PathScope: pathScope}
+	}
+	return HistoryCountEstimateDTO{
+		RepoRoot:   repoRoot,
+		PathScope:  pathScope,
+		Total:      entry.total,
+		Head:       entry.head,
+		Exact:      true,
+		ComputedAt: entry.computedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// StreamHistory entrega o histórico em lotes a partir do cursor (vazio = HEAD) até maxItems
+// commits, sem paginação por --skip. Cada lote traz o cursor para retomar dali.
+func (s *Service) StreamHistory(ctx context.Context, repoPath string, options HistoryStreamOptionsDTO, onBatch func(HistoryStreamBatchDTO)) (HistoryStreamBatchDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return HistoryStreamBatchDTO{}, err
+	}
+	root := preflight.RepoRoot
+	pathScope, err := NormalizePathScope(root, options.Scope)
+	if err != nil {
+		return HistoryStreamBatchDTO{}, err
+	}
+	cursorHash, err := parseHistoryCursor(options.Cursor)
+	if err != nil {
+		return HistoryStreamBatchDTO{}, err
+	}
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultHistoryStreamBatch
+	}
+	batchSize = min(batchSize, maxHistoryStreamBatch)
+	maxItems := options.MaxItems
+	if maxItems <= 0 {
+		maxItems = defaultHistoryStreamMax
+	}
+	maxItems = min(maxItems, maxHistoryStreamMax)
+
+	estimate := 0
+	if strings.TrimSpace(options.Search) == "" {
+		if total, ok := s.cachedHistoryCount(root, pathScope); ok {
+			estimate = total
+		} else {
+			s.precomputeHistoryCount(root, pathScope)
+		}
+	}
+
+	batch := HistoryStreamBatchDTO{RepoRoot: root, PathScope: pathScope, EstimatedTotal: estimate}
+	loaded := 0
+	lastHash := cursorHash
+	emit := func() {
+		if len(batch.Items) == 0 {
+			return
+		}
+		batch.Loaded = loaded
+		batch.NextCursor = lastHash
+		onBatch(batch)
+		batch.Items = nil
+	}
+
+	found, err := s.streamHistoryAfter(ctx, root, cursorHash, options.Search, pathScope, true, func(item HistoryItemDTO) bool {
+		if loaded >= maxItems {
+			batch.HasMore = true
+			return false
+		}
+		batch.Items = append(batch.Items, item)
+		loaded++
+		lastHash = item.Hash
+		if len(batch.Items) >= batchSize {
+			emit()
+		}
+		return true
+	})
+	if err != nil {
+		return HistoryStreamBatchDTO{}, err
+	}
+	if !found {
+		return HistoryStreamBatchDTO{}, NewBindingError(
+			CodeInvalidCursor,
+			"Cursor de histórico inválido.",
+			fmt.Sprintf("O commit %s não faz parte do histórico listado.", cursorHash),
+		)
+	}
+
+	// O lote final leva o restante dos itens (pode vir vazio) e sinaliza o fim da transmissão.
+	batch.Loaded = loaded
+	batch.Done = true
+	batch.NextCursor = ""
+	if batch.HasMore {
+		batch.NextCursor = lastHash
+	}
+	onBatch(batch)
+	return batch, nil
+}
@@ -0,0 +1,110 @@
+package gitpanel
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// mustInitHistoryRepo cria commits com o mesmo timestamp de commit para exercitar o desempate do keyset.
+func mustInitHistoryRepo(t *testing.T, commits int) string {
+	t.Helper()
+	t.Setenv("GIT_COMMITTER_DATE", "2026-03-01T10:00:00Z")
+	repoRoot := mustInitTestRepo(t)
+	for i := range commits {
+		if err := os.WriteFile(filepath.Join(repoRoot, "log.txt"), []byte(fmt.Sprintf("line %d\n", i)), 0o644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		runGitOrFail(t, repoRoot, "add", "--", "log.txt")
+		runGitOrFail(t, repoRoot, "commit", "-m", fmt.Sprintf("change %d", i))
+	}
+	return repoRoot
+}
+
+func TestGetHistoryUsesKeysetCursorOnLargeRepos(t *testing.T) {
+	repoRoot := mustInitHistoryRepo(t, 6)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	estimate, err := svc.GetHistoryCountEstimate(repoRoot, "")
+	if err != nil {
+		t.Fatalf("GetHistoryCountEstimate returned error: %v", err)
+	}
+	if estimate.Total != 7 || !estimate.Exact || estimate.Head == "" {
+		t.Fatalf("unexpected estimate: %+v", estimate)
+	}
+
+	baseline, err := svc.GetHistory(repoRoot, "", 7, "")
+	if err != nil || len(baseline.Items) != 7 {
+		t.Fatalf("unexpected baseline history: %+v (%v)", baseline, err)
+	}
+	if baseline.EstimatedTotal != 7 {
+		t.Fatalf("expected page to carry the count estimate, got %d", baseline.EstimatedTotal)
+	}
+
+	// Força o caminho keyset como se o repositório fosse grande.
+	svc.historyCounts[historyCountKey(filepath.Clean(repoRoot), "")].total = keysetHistoryMinCommits
+	svc.InvalidateRepoCache(repoRoot)
+
+	seen := make([]string, 0, 7)
+	cursor := ""
+	for page := 0; page < 5; page++ {
+		result, err := svc.GetHistory(repoRoot, cursor, 3, "")
+		if err != nil {
+			t.Fatalf("GetHistory page %d returned error: %v", page, err)
+		}
+		for _, item := range result.Items {
+			seen = append(seen, item.Hash)
+		}
+		if !result.HasMore {
+			break
+		}
+		cursor = result.NextCursor
+	}
+	if len(seen) != len(baseline.Items) {
+		t.Fatalf("expected %d commits across keyset pages, got %d", len(baseline.Items), len(seen))
+	}
+	for i, item := range baseline.Items {
+		if seen[i] != item.Hash {
+			t.Fatalf("keyset page order diverged at %d: got %s want %s", i, seen[i], item.Hash)
+		}
+	}
+}
+
+func TestStreamHistoryEmitsBatchesFromCursor(t *testing.T) {
+	repoRoot := mustInitHistoryRepo(t, 4)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	baseline, err := svc.GetHistory(repoRoot, "", 10, "")
+	if err != nil || len(baseline.Items) != 5 {
+		t.Fatalf("unexpected baseline history: %+v (%v)", baseline, err)
+	}
+
+	var batches []HistoryStreamBatchDTO
+	final, err := svc.StreamHistory(context.Background(), repoRoot, HistoryStreamOptionsDTO{
+		Cursor:    baseline.Items[0].Hash,
+		BatchSize: 2,
+		MaxItems:  3,
+	}, func(batch HistoryStreamBatchDTO) {
+		batches = append(batches, batch)
+	})
+	if err != nil {
+		t.Fatalf("StreamHistory returned error: %v", err)
+	}
+	if len(batches) != 2 || len(batches[0].Items) != 2 || batches[0].Done || len(batches[1].Items) != 1 || !batches[1].Done {
+		t.Fatalf("unexpected batches: %+v", batches)
+	}
+	if batches[0].Items[0].Hash != baseline.Items[1].Hash || batches[0].Items[0].ChangedFiles != 1 {
+		t.Fatalf("expected stream to resume after the cursor with numstat, got %+v", batches[0].Items[0])
+	}
+	if !final.HasMore || final.Loaded != 3 || final.NextCursor != baseline.Items[3].Hash {
+		t.Fatalf("unexpected final batch: %+v", final)
+	}
+
+	if _, err := svc.StreamHistory(context.Background(), repoRoot, HistoryStreamOptionsDTO{Cursor: "deadbeefdeadbeef"}, func(HistoryStreamBatchDTO) {}); err == nil {
+		t.Fatalf("expected error for unknown cursor")
+	}
+}
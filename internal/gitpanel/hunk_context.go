@@ -0,0 +1,138 @@
+package gitpanel
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+type contextPattern struct {
+	kind  string
+	regex *regexp.Regexp
+}
+
+// Extratores leves por linguagem: o primeiro grupo de captura é o texto exibido.
+var (
+	goContextPatterns = []contextPattern{
+		{kind: "method", regex: regexp.MustCompile(`^\s*(func\s*\([^)]*\)\s*[A-Za-z_]\w*)`)},
+		{kind: "function", regex: regexp.MustCompile(`^\s*(func\s+[A-Za-z_]\w*)`)},
+		{kind: "type", regex: regexp.MustCompile(`^\s*(type\s+[A-Za-z_]\w*)`)},
+	}
+	pythonContextPatterns = []contextPattern{
+		{kind: "function", regex: regexp.MustCompile(`^\s*((?:async\s+)?def\s+[A-Za-z_]\w*)`)},
+		{kind: "class", regex: regexp.MustCompile(`^\s*(class\s+[A-Za-z_]\w*)`)},
+	}
+	jsContextPatterns = []contextPattern{
+		{kind: "class", regex: regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:abstract\s+)?(class\s+[A-Za-z_$][\w$]*)`)},
+		{kind: "function", regex: regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?((?:async\s+)?function\s*\*?\s*[A-Za-z_$][\w$]*)`)},
+		{kind: "function", regex: regexp.MustCompile(`^\s*(?:export\s+)?((?:const|let|var)\s+[A-Za-z_$][\w$]*)\s*=\s*(?:async\s+)?(?:\([^)]*\)|[A-Za-z_$][\w$]*)\s*(?::\s*[^=]+)?=>`)},
+		{kind: "type", regex: regexp.MustCompile(`^\s*(?:export\s+)?((?:interface|type|enum)\s+[A-Za-z_$][\w$]*)`)},
+		{kind: "method", regex: regexp.MustCompile(`^\s+(?:(?:public|private|protected|static|readonly|async|get|set)\s+)*([A-Za-z_$][\w$]*)\s*\([^)]*\)\s*(?::\s*[^{]+)?\{\s*$`)},
+	}
+	rustContextPatterns = []contextPattern{
+		{kind: "function", regex: regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:async\s+)?(?:unsafe\s+)?(fn\s+[A-Za-z_]\w*)`)},
+		{kind: "type", regex: regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?((?:struct|enum|trait)\s+[A-Za-z_]\w*)`)},
+		{kind: "class", regex: regexp.MustCompile(`^\s*(impl(?:<[^>]*>)?\s+[^{]+?)\s*\{?\s*$`)},
+	}
+	javaLikeContextPatterns = []contextPattern{
+		{kind: "class", regex: regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal|abstract|final|static|sealed|partial|data|open)\s+)*((?:class|interface|enum|record|object)\s+[A-Za-z_]\w*)`)},
+		{kind: "method", regex: regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal|static|final|abstract|synchronized|override|virtual|async|suspend)\s+)+[\w<>\[\],.? ]*?\b([A-Za-z_]\w*)\s*\([^;]*$`)},
+		{kind: "function", regex: regexp.MustCompile(`^\s*(fun\s+(?:<[^>]*>\s*)?[A-Za-z_][\w.]*)`)},
+	}
+	rubyContextPatterns = []contextPattern{
+		{kind: "function", regex: regexp.MustCompile(`^\s*(def\s+[A-Za-z_][\w.?!=]*)`)},
+		{kind: "class", regex: regexp.MustCompile(`^\s*((?:class|module)\s+[A-Z][\w:]*)`)},
+	}
+	phpContextPatterns = []contextPattern{
+		{kind: "function", regex: regexp.MustCompile(`^\s*(?:(?:public|private|protected|static|abstract|final)\s+)*(function\s+&?[A-Za-z_]\w*)`)},
+		{kind: "class", regex: regexp.MustCompile(`^\s*(?:(?:abstract|final)\s+)?((?:class|interface|trait)\s+[A-Za-z_]\w*)`)},
+	}
+	cContextPatterns = []contextPattern{
+		{kind: "class", regex: regexp.MustCompile(`^\s*((?:class|struct|namespace)\s+[A-Za-z_]\w*)`)},
+		{kind: "function", regex: regexp.MustCompile(`^[A-Za-z_][\w\s\*&:<>,]*?\b([A-Za-z_][\w:~]*)\s*\([^;]*$`)},
+	}
+)
+
+var contextPatternsByExt = map[string][]contextPattern{
+	".go":    goContextPatterns,
+	".py":    pythonContextPatterns,
+	".js":    jsContextPatterns,
+	".jsx":   jsContextPatterns,
+	".mjs":   jsContextPatterns,
+	".cjs":   jsContextPatterns,
+	".ts":    jsContextPatterns,
+	".tsx":   jsContextPatterns,
+	".rs":    rustContextPatterns,
+	".java":  javaLikeContextPatterns,
+	".kt":    javaLikeContextPatterns,
+	".cs":    javaLikeContextPatterns,
+	".scala": javaLikeContextPatterns,
+	".rb":    rubyContextPatterns,
+	".php":   phpContextPatterns,
+	".c":     cContextPatterns,
+	".h":     cContextPatterns,
+	".cc":    cContextPatterns,
+	".cpp":   cContextPatterns,
+	".hpp":   cContextPatterns,
+}
+
+// annotateHunkContexts preenche Context/ContextKind de cada hunk.
+// Uma declaração no contexto inicial do hunk (antes da primeira alteração) tem prioridade,
+// pois é mais interna que o cabeçalho calculado pelo git (que aponta para a declaração anterior ao hunk).
+func annotateHunkContexts(files []DiffFileDTO) {
+	for fileIdx := range files {
+		patterns := contextPatternsByExt[strings.ToLower(path.Ext(files[fileIdx].Path))]
+		for hunkIdx := range files[fileIdx].Hunks {
+			hunk := &files[fileIdx].Hunks[hunkIdx]
+			hunk.Context, hunk.ContextKind = resolveHunkContext(*hunk, patterns)
+		}
+	}
+}
+
+func resolveHunkContext(hunk DiffHunkDTO, patterns []contextPattern) (string, string) {
+	if len(patterns) > 0 {
+		leading := ""
+		leadingKind := ""
+		for _, line := range hunk.Lines {
+			if line.Type != "context" {
+				break
+			}
+			if text, kind, ok := matchContextLine(line.Content, patterns); ok {
+				leading, leadingKind = text, kind
+			}
+		}
+		if leading != "" {
+			return leading, leadingKind
+		}
+		if text, kind, ok := matchContextLine(hunk.Header, patterns); ok {
+			return text, kind
+		}
+	}
+
+	header := strings.TrimSpace(hunk.Header)
+	if header == "" {
+		return "", ""
+	}
+	return header, "section"
+}
+
+// controlKeywords evita que blocos de controle ("if (x) {") sejam confundidos com métodos.
+var controlKeywords = map[string]struct{}{
+	"if": {}, "for": {}, "while": {}, "switch": {}, "catch": {}, "with": {}, "return": {}, "else": {}, "do": {},
+}
+
+func matchContextLine(line string, patterns []contextPattern) (string, string, bool) {
+	for _, pattern := range patterns {
+		if matches := pattern.regex.FindStringSubmatch(line); len(matches) > 1 {
+			text := strings.TrimSpace(matches[1])
+			if text == "" {
+				continue
+			}
+			if _, isKeyword := controlKeywords[text]; isKeyword {
+				continue
+			}
+			return text, pattern.kind, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,58 @@
+package gitpanel
+
+import "testing"
+
+func TestAnnotateHunkContextsPerLanguage(t *testing.T) {
+	files := []DiffFileDTO{
+		{
+			Path: "internal/github/service.go",
+			Hunks: []DiffHunkDTO{{
+				Header: "func (s *Service) CreatePullRequest(ctx context.Context, input CreatePRInput) (*PullRequest, error) {",
+				Lines:  []DiffLineDTO{{Type: "context", Content: "\tif input.Title == \"\" {"}, {Type: "add", Content: "\t\treturn nil, errEmptyTitle"}},
+			}},
+		},
+		{
+			Path: "app/models.py",
+			Hunks: []DiffHunkDTO{{
+				Header: "class Invoice(Base):",
+				Lines: []DiffLineDTO{
+					{Type: "context", Content: "    async def total(self):"},
+					{Type: "delete", Content: "        return 0"},
+				},
+			}},
+		},
+		{
+			Path: "src/store.ts",
+			Hunks: []DiffHunkDTO{{
+				Header: "export class Store {",
+				Lines: []DiffLineDTO{
+					{Type: "context", Content: "  private async load(id: string): Promise<void> {"},
+					{Type: "context", Content: "    if (cached) {"},
+					{Type: "add", Content: "      return"},
+				},
+			}},
+		},
+		{
+			Path:  "README.md",
+			Hunks: []DiffHunkDTO{{Header: "## Install", Lines: []DiffLineDTO{{Type: "add", Content: "npm i"}}}},
+		},
+	}
+
+	annotateHunkContexts(files)
+
+	cases := []struct {
+		context string
+		kind    string
+	}{
+		{"func (s *Service) CreatePullRequest", "method"},
+		{"async def total", "function"},
+		{"load", "method"},
+		{"## Install", "section"},
+	}
+	for idx, tc := range cases {
+		hunk := files[idx].Hunks[0]
+		if hunk.Context != tc.context || hunk.ContextKind != tc.kind {
+			t.Fatalf("%s: got context=%q kind=%q, want %q/%q", files[idx].Path, hunk.Context, hunk.ContextKind, tc.context, tc.kind)
+		}
+	}
+}
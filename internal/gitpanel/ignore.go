@@ -0,0 +1,346 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const maxIgnoreSuggestionMatches = 5
+
+type ignoreSuggestionRule struct {
+	pattern string
+	reason  string
+	match   func(name string, isDir bool) bool
+}
+
+func matchDirName(names ...string) func(string, bool) bool {
+	return func(name string, isDir bool) bool {
+		if !isDir {
+			return false
+		}
+		for _, candidate := range names {
+			if name == candidate {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func matchFileSuffix(suffix string) func(string, bool) bool {
+	return func(name string, isDir bool) bool {
+		return !isDir && strings.HasSuffix(name, suffix)
+	}
+}
+
+// ignoreSuggestionRules cobre os artefatos mais comuns que vazam para o status.
+var ignoreSuggestionRules = []ignoreSuggestionRule{
+	{pattern: "node_modules/", reason: "Dependências Node.js", match: matchDirName("node_modules")},
+	{pattern: "dist/", reason: "Artefatos de build", match: matchDirName("dist")},
+	{pattern: "build/", reason: "Artefatos de build", match: matchDirName("build")},
+	{pattern: "out/", reason: "Artefatos de build", match: matchDirName("out")},
+	{pattern: "target/", reason: "Artefatos de build (Rust/Maven)", match: matchDirName("target")},
+	{pattern: ".next/", reason: "Cache do Next.js", match: matchDirName(".next")},
+	{pattern: "coverage/", reason: "Relatórios de cobertura", match: matchDirName("coverage")},
+	{pattern: "__pycache__/", reason: "Bytecode Python", match: matchDirName("__pycache__")},
+	{pattern: ".venv/", reason: "Ambiente virtual Python", match: matchDirName(".venv", "venv")},
+	{pattern: ".idea/", reason: "Configuração local de IDE", match: matchDirName(".idea")},
+	{pattern: ".env", reason: "Variáveis de ambiente (podem conter segredos)", match: func(name string, isDir bool) bool {
+		return !isDir && name == ".env"
+	}},
+	{pattern: ".env.*", reason: "Variáveis de ambiente (podem conter segredos)", match: func(name string, isDir bool) bool {
+		if isDir || !strings.HasPrefix(name, ".env.") {
+			return false
+		}
+		// Arquivos de exemplo costumam ser versionados de propósito.
+		return !strings.HasSuffix(name, ".example") && !strings.HasSuffix(name, ".sample") && !strings.HasSuffix(name, ".template")
+	}},
+	{pattern: ".DS_Store", reason: "Metadados do macOS", match: func(name string, isDir bool) bool {
+		return !isDir && name == ".DS_Store"
+	}},
+	{pattern: "*.log", reason: "Arquivos de log", match: matchFileSuffix(".log")},
+	{pattern: "*.pyc", reason: "Bytecode Python", match: matchFileSuffix(".pyc")},
+	{pattern: "*.swp", reason: "Arquivos temporários do editor", match: matchFileSuffix(".swp")},
+}
+
+// GetIgnoreRules lê o .gitignore da raiz do repositório.
+func (s *Service) GetIgnoreRules(repoPath string) (IgnoreRulesDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return IgnoreRulesDTO{}, err
+	}
+	return readIgnoreRules(preflight.RepoRoot)
+}
+
+// AddIgnorePattern acrescenta um padrão ao .gitignore da raiz (sem duplicar).
+func (s *Service) AddIgnorePattern(repoPath string, pattern string) (IgnoreRulesDTO, error) {
+	normalized, patternErr := normalizeIgnorePattern(pattern)
+	commandID, startedAt := s.beginCommand("add_ignore_pattern")
+	args := []string{"gitignore", "add", strings.TrimSpace(pattern)}
+	if patternErr != nil {
+		s.emitCommandFailure(commandID, repoPath, "add_ignore_pattern", args, startedAt, patternErr)
+		return IgnoreRulesDTO{}, patternErr
+	}
+
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		s.emitCommandFailure(commandID, repoPath, "add_ignore_pattern", args, startedAt, err)
+		return IgnoreRulesDTO{}, err
+	}
+
+	var result IgnoreRulesDTO
+	if err := s.executeWrite(
+		preflight.RepoRoot,
+		commandID,
+		"add_ignore_pattern",
+		args,
+		startedAt,
+		defaultWriteTimeout,
+		func(ctx context.Context, diag *commandDiagnosticState) error {
+			rules, readErr := readIgnoreRules(preflight.RepoRoot)
+			if readErr != nil {
+				return readErr
+			}
+			for _, existing := range rules.Patterns {
+				if existing == normalized {
+					result = rules
+					return nil
+				}
+			}
+
+			content := rules.Content
+			if content != "" && !strings.HasSuffix(content, "\n") {
+				content += "\n"
+			}
+			content += normalized + "\n"
+			if writeErr := writeFileAtomic(rules.Path, []byte(content)); writeErr != nil {
+				return NewBindingError(CodeCommandFailed, "Falha ao atualizar .gitignore.", writeErr.Error())
+			}
+
+			rules.Exists = true
+			rules.Content = content
+			rules.Patterns = append(rules.Patterns, normalized)
+			result = rules
+			return nil
+		}); err != nil {
+		return IgnoreRulesDTO{}, err
+	}
+
+	s.emitPostWriteReconciliation(preflight.RepoRoot, "add_ignore_pattern", false)
+	return result, nil
+}
+
+// SuggestIgnorePatterns propõe padrões para arquivos não rastreados comuns (node_modules/, dist/, .env...).
+func (s *Service) SuggestIgnorePatterns(repoPath string) ([]IgnoreSuggestionDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	status, err := s.GetStatus(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := readIgnoreRules(preflight.RepoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	untracked := make([]string, 0)
+	for _, change := range status.Unstaged {
+		if change.Status == "??" {
+			untracked = append(untracked, change.Path)
+		}
+	}
+	return buildIgnoreSuggestions(untracked, rules.Patterns), nil
+}
+
+// CheckIgnored informa se o caminho é ignorado e qual regra o ignora (git check-ignore -v).
+func (s *Service) CheckIgnored(repoPath string, filePath string) (IgnoreCheckDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return IgnoreCheckDTO{}, err
+	}
+	cleanPath, err := ensurePathWithinRepo(preflight.RepoRoot, filePath)
+	if err != nil {
+		return IgnoreCheckDTO{}, err
+	}
+
+	out, errOut, exitCode, runErr := s.runGit(
+		context.Background(),
+		defaultReadTimeout,
+		"",
+		"-C", preflight.RepoRoot,
+		"check-ignore",
+		"-v",
+		"--",
+		cleanPath,
+	)
+	result := IgnoreCheckDTO{Path: cleanPath}
+	if runErr != nil {
+		// Exit 1 sem stderr = caminho não ignorado.
+		if exitCode == 1 && strings.TrimSpace(errOut) == "" {
+			return result, nil
+		}
+		return IgnoreCheckDTO{}, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao verificar regras de ignore.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+
+	source, line, pattern, ok := parseCheckIgnoreLine(out)
+	if !ok {
+		return result, nil
+	}
+	result.Source = source
+	result.Line = line
+	result.Pattern = pattern
+	// Padrão negado ("!foo") significa que a última regra reincluiu o caminho.
+	result.Ignored = !strings.HasPrefix(pattern, "!")
+	return result, nil
+}
+
+func readIgnoreRules(repoRoot string) (IgnoreRulesDTO, error) {
+	rules := IgnoreRulesDTO{
+		Path:     filepath.Join(repoRoot, ".gitignore"),
+		Patterns: make([]string, 0),
+	}
+
+	data, err := os.ReadFile(rules.Path)
+	if os.IsNotExist(err) {
+		return rules, nil
+	}
+	if err != nil {
+		return IgnoreRulesDTO{}, NewBindingError(CodeCommandFailed, "Falha ao ler .gitignore.", err.Error())
+	}
+
+	rules.Exists = true
+	rules.Content = string(data)
+	for _, line := range strings.Split(rules.Content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		rules.Patterns = append(rules.Patterns, trimmed)
+	}
+	return rules, nil
+}
+
+func normalizeIgnorePattern(pattern string) (string, error) {
+	trimmed := strings.TrimSpace(pattern)
+	if trimmed == "" {
+		return "", NewBindingError(CodeInvalidPath, "Padrão de ignore obrigatório.", "Informe um padrão no formato do .gitignore.")
+	}
+	if strings.ContainsAny(trimmed, "\r\n\x00") {
+		return "", NewBindingError(CodeInvalidPath, "Padrão de ignore inválido.", "O padrão deve ocupar uma única linha.")
+	}
+	if strings.HasPrefix(trimmed, "#") {
+		return "", NewBindingError(CodeInvalidPath, "Padrão de ignore inválido.", "Padrões iniciados por '#' são comentários; use '\\#' para o literal.")
+	}
+	return trimmed, nil
+}
+
+func buildIgnoreSuggestions(untracked []string, existingPatterns []string) []IgnoreSuggestionDTO {
+	existing := make(map[string]struct{}, len(existingPatterns))
+	for _, pattern := range existingPatterns {
+		existing[strings.TrimPrefix(pattern, "/")] = struct{}{}
+	}
+
+	byPattern := make(map[string]*IgnoreSuggestionDTO)
+	order := make([]string, 0)
+	for _, rawPath := range untracked {
+		isDir := strings.HasSuffix(rawPath, "/")
+		segments := strings.Split(strings.Trim(rawPath, "/"), "/")
+		for idx, segment := range segments {
+			segmentIsDir := isDir || idx < len(segments)-1
+			for _, rule := range ignoreSuggestionRules {
+				if !rule.match(segment, segmentIsDir) {
+					continue
+				}
+				if _, covered := existing[rule.pattern]; covered {
+					continue
+				}
+				if _, covered := existing[strings.TrimSuffix(rule.pattern, "/")]; covered {
+					continue
+				}
+
+				suggestion, found := byPattern[rule.pattern]
+				if !found {
+					suggestion = &IgnoreSuggestionDTO{Pattern: rule.pattern, Reason: rule.reason, Matches: make([]string, 0, 1)}
+					byPattern[rule.pattern] = suggestion
+					order = append(order, rule.pattern)
+				}
+				if len(suggestion.Matches) < maxIgnoreSuggestionMatches {
+					suggestion.Matches = append(suggestion.Matches, path.Clean(rawPath))
+				}
+			}
+		}
+	}
+
+	suggestions := make([]IgnoreSuggestionDTO, 0, len(order))
+	for _, pattern := range order {
+		suggestions = append(suggestions, *byPattern[pattern])
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return len(suggestions[i].Matches) > len(suggestions[j].Matches)
+	})
+	return suggestions
+}
+
+// parseCheckIgnoreLine interpreta "<source>:<linenum>:<pattern>\t<pathname>".
+func parseCheckIgnoreLine(raw string) (string, int, string, bool) {
+	line := strings.TrimSpace(strings.SplitN(raw, "\n", 2)[0])
+	tabIdx := strings.Index(line, "\t")
+	if tabIdx < 0 {
+		return "", 0, "", false
+	}
+	meta := line[:tabIdx]
+
+	// O padrão pode conter ':'; por isso só os dois primeiros separadores são considerados.
+	firstColon := strings.Index(meta, ":")
+	if firstColon < 0 {
+		return "", 0, "", false
+	}
+	rest := meta[firstColon+1:]
+	secondColon := strings.Index(rest, ":")
+	if secondColon < 0 {
+		return "", 0, "", false
+	}
+	lineNumber, err := strconv.Atoi(rest[:secondColon])
+	if err != nil {
+		return "", 0, "", false
+	}
+	return meta[:firstColon], lineNumber, rest[secondColon+1:], true
+}
+
+func writeFileAtomic(targetPath string, data []byte) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(targetPath); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(targetPath), ".gitignore-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, targetPath)
+}
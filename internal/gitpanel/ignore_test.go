@@ -0,0 +1,82 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuildIgnoreSuggestionsSkipsCoveredPatterns(t *testing.T) {
+	untracked := []string{
+		"node_modules/",
+		"web/node_modules/",
+		"dist/",
+		".env",
+		".env.example",
+		".env.local",
+		"server.log",
+	}
+
+	suggestions := buildIgnoreSuggestions(untracked, []string{"/dist", "*.log"})
+
+	got := make(map[string]int)
+	for _, suggestion := range suggestions {
+		got[suggestion.Pattern] = len(suggestion.Matches)
+	}
+	want := map[string]int{"node_modules/": 2, ".env": 1, ".env.*": 1}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected suggestions: %+v", suggestions)
+	}
+	for pattern, count := range want {
+		if got[pattern] != count {
+			t.Fatalf("unexpected matches for %s: got=%d want=%d (%+v)", pattern, got[pattern], count, suggestions)
+		}
+	}
+	if suggestions[0].Pattern != "node_modules/" {
+		t.Fatalf("expected most frequent suggestion first, got %+v", suggestions)
+	}
+}
+
+func TestAddIgnorePatternAndCheckIgnored(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	if err := os.WriteFile(filepath.Join(repoRoot, ".gitignore"), []byte("*.tmp"), 0o644); err != nil {
+		t.Fatalf("failed to write .gitignore: %v", err)
+	}
+
+	rules, err := svc.AddIgnorePattern(repoRoot, " build/ ")
+	if err != nil {
+		t.Fatalf("AddIgnorePattern returned error: %v", err)
+	}
+	if rules.Content != "*.tmp\nbuild/\n" {
+		t.Fatalf("unexpected .gitignore content: %q", rules.Content)
+	}
+	if _, err := svc.AddIgnorePattern(repoRoot, "build/"); err != nil {
+		t.Fatalf("AddIgnorePattern(duplicate) returned error: %v", err)
+	}
+	if rules, _ = svc.GetIgnoreRules(repoRoot); len(rules.Patterns) != 2 {
+		t.Fatalf("expected duplicate pattern to be skipped, got %+v", rules.Patterns)
+	}
+	if _, err := svc.AddIgnorePattern(repoRoot, "a\nb"); err == nil {
+		t.Fatalf("expected multi-line pattern to be rejected")
+	}
+
+	check, err := svc.CheckIgnored(repoRoot, "build/app.bin")
+	if err != nil {
+		t.Fatalf("CheckIgnored returned error: %v", err)
+	}
+	if !check.Ignored || check.Pattern != "build/" || check.Line != 2 || check.Source != ".gitignore" {
+		t.Fatalf("unexpected check result: %+v", check)
+	}
+
+	check, err = svc.CheckIgnored(repoRoot, "README.md")
+	if err != nil {
+		t.Fatalf("CheckIgnored returned error: %v", err)
+	}
+	if check.Ignored {
+		t.Fatalf("expected README.md not to be ignored: %+v", check)
+	}
+}
@@ -0,0 +1,215 @@
+package gitpanel
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// Filtros de partial clone mais comuns.
+const (
+	PartialCloneFilterBlobNone = "blob:none"
+	PartialCloneFilterTreeless = "tree:0"
+)
+
+var partialCloneBlobLimitRegex = regexp.MustCompile(`^blob:limit=\d+[kmg]?$`)
+
+// NormalizePartialCloneFilter aceita blob:none, tree:0 e blob:limit=<n>[kmg]; "" para filtros inválidos.
+func NormalizePartialCloneFilter(filter string) string {
+	normalized := strings.ToLower(strings.TrimSpace(filter))
+	switch {
+	case normalized == PartialCloneFilterBlobNone, normalized == PartialCloneFilterTreeless:
+		return normalized
+	case partialCloneBlobLimitRegex.MatchString(normalized):
+		return normalized
+	default:
+		return ""
+	}
+}
+
+// GetPartialClone retorna o filtro de partial clone do repositório e os avisos de desempenho.
+func (s *Service) GetPartialClone(repoPath string) (PartialCloneDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return PartialCloneDTO{}, err
+	}
+	return s.readPartialClone(preflight.RepoRoot), nil
+}
+
+// SetPartialClone converte o clone em partial (filtro não vazio) ou de volta em completo (filtro
+// vazio). A conversão para partial só afeta fetches futuros: objetos já baixados continuam no disco.
+// A volta para completo baixa de novo todos os objetos do remoto (fetch --refetch).
+func (s *Service) SetPartialClone(repoPath string, remote string, filter string) (PartialCloneDTO, error) {
+	action := "partial_clone_enable"
+	if strings.TrimSpace(filter) == "" {
+		action = "partial_clone_disable"
+	}
+	commandID, startedAt := s.beginCommand(action)
+
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		s.emitCommandFailure(commandID, repoPath, action, nil, startedAt, err)
+		return PartialCloneDTO{}, err
+	}
+	root := preflight.RepoRoot
+
+	current := s.readPartialClone(root)
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		remote = current.Remote
+	}
+	if remote == "" {
+		remote = "origin"
+	}
+	if strings.HasPrefix(remote, "-") || s.readGitConfigString(root, "remote."+remote+".url") == "" {
+		err := NewBindingError(CodeCommandFailed, "Remoto não configurado.", remote)
+		s.emitCommandFailure(commandID, root, action, nil, startedAt, err)
+		return PartialCloneDTO{}, err
+	}
+
+	normalizedFilter := ""
+	if action == "partial_clone_enable" {
+		normalizedFilter = NormalizePartialCloneFilter(filter)
+		if normalizedFilter == "" {
+			err := NewBindingError(CodeCommandFailed, "Filtro de partial clone inválido.", "Use blob:none, tree:0 ou blob:limit=<tamanho>.")
+			s.emitCommandFailure(commandID, root, action, nil, startedAt, err)
+			return PartialCloneDTO{}, err
+		}
+	}
+
+	var args []string
+	var run func(ctx context.Context, diag *commandDiagnosticState) error
+	if normalizedFilter != "" {
+		args = []string{"config", "remote." + remote + ".partialclonefilter", normalizedFilter}
+		run = func(ctx context.Context, diag *commandDiagnosticState) error {
+			// Mesma configuração que "git clone --filter" grava.
+			for _, setting := range [][2]string{
+				{"core.repositoryformatversion", "1"},
+				{"remote." + remote + ".promisor", "true"},
+				{"remote." + remote + ".partialclonefilter", normalizedFilter},
+				{"extensions.partialClone", remote},
+			} {
+				_, errOut, exitCode, runErr := s.runWriteGitWithRetry(ctx, diag, "", "-C", root, "config", "--local", setting[0], setting[1])
+				if runErr != nil {
+					return wrapWriteCommandError(CodeCommandFailed, "Falha ao configurar o partial clone.", errOut, exitCode, runErr)
+				}
+			}
+			return nil
+		}
+	} else {
+		args = []string{"fetch", "--refetch", remote}
+		run = func(ctx context.Context, diag *commandDiagnosticState) error {
+			if !current.Enabled && !current.Promisor {
+				return nil
+			}
+			s.unsetGitConfig(ctx, diag, root, "remote."+remote+".partialclonefilter")
+
+			fetchCtx := withGitEnv(ctx, "GIT_TERMINAL_PROMPT=0")
+			gitArgs := append([]string{"-C", root}, args...)
+			_, errOut, exitCode, fetchErr := s.runGit(fetchCtx, remainingTimeout(ctx, fetchTimeout), "", gitArgs...)
+			diag.recordAttempt(gitArgs, errOut, exitCode, 1)
+			if fetchErr != nil {
+				if mapped := queueErrorFromContext(fetchErr, "Fetch interrompido."); mapped != nil {
+					return mapped
+				}
+				return wrapWriteCommandError(CodeCommandFailed, "Falha ao baixar os objetos que faltam.", errOut, exitCode, fetchErr)
+			}
+
+			// Só deixa de ser promisor se nenhum objeto alcançável continua faltando;
+			// caso contrário o git perderia a origem para buscá-los sob demanda.
+			out, _, _, missingErr := s.runGit(ctx, remainingTimeout(ctx, fetchTimeout), "", "-C", root, "rev-list", "--objects", "--all", "--missing=print")
+			if missingErr != nil || countMissingObjects(out) > 0 {
+				return nil
+			}
+			s.unsetGitConfig(ctx, diag, root, "remote."+remote+".promisor")
+			s.unsetGitConfig(ctx, diag, root, "extensions.partialClone")
+			return nil
+		}
+	}
+
+	if err := s.executeWrite(root, commandID, action, args, startedAt, fetchTimeout, run); err != nil {
+		return PartialCloneDTO{}, err
+	}
+
+	s.emitPostWriteReconciliation(root, action, false)
+	result := s.readPartialClone(root)
+	if normalizedFilter != "" {
+		result.Warnings = append(result.Warnings, "Objetos já baixados continuam no disco; apenas fetches futuros usam o filtro.")
+	}
+	return result, nil
+}
+
+func (s *Service) unsetGitConfig(ctx context.Context, diag *commandDiagnosticState, root string, key string) {
+	// Falha quando a chave não existe (exit 5), o que já é o estado desejado.
+	_, _, _, _ = s.runWriteGitWithRetry(ctx, diag, "", "-C", root, "config", "--local", "--unset-all", key)
+}
+
+func (s *Service) readPartialClone(root string) PartialCloneDTO {
+	result := PartialCloneDTO{Warnings: []string{}}
+	out, _, _, runErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", root, "config", "--get-regexp", `^remote\..*\.(promisor|partialclonefilter)$`)
+	if runErr == nil {
+		for _, line := range strings.Split(out, "\n") {
+			key, value, ok := strings.Cut(strings.TrimSpace(line), " ")
+			if !ok {
+				continue
+			}
+			lowerKey := strings.ToLower(key)
+			remote := strings.TrimPrefix(key[:strings.LastIndex(key, ".")], "remote.")
+			switch {
+			case strings.HasSuffix(lowerKey, ".partialclonefilter") && strings.TrimSpace(value) != "":
+				result.Enabled = true
+				result.Remote = remote
+				result.Filter = strings.TrimSpace(value)
+			case strings.HasSuffix(lowerKey, ".promisor") && strings.EqualFold(strings.TrimSpace(value), "true"):
+				result.Promisor = true
+				if result.Remote == "" {
+					result.Remote = remote
+				}
+			}
+		}
+	}
+	if !result.Enabled && result.Promisor {
+		result.Warnings = append(result.Warnings, "O remoto "+result.Remote+" ainda é promisor: objetos ausentes são baixados sob demanda.")
+	}
+	if warning := partialCloneFilterWarning(result.Filter); warning != "" {
+		result.Warnings = append(result.Warnings, warning)
+	}
+	return result
+}
+
+// partialCloneWarning avisa que leituras que precisam de objetos antigos (diffs e estatísticas do
+// histórico, histórico de arquivo com renomes) podem ficar lentas por baixar objetos sob demanda.
+func (s *Service) partialCloneWarning(root string) string {
+	out, _, _, runErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", root, "config", "--get-regexp", `^remote\..*\.partialclonefilter$`)
+	if runErr != nil {
+		return ""
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if _, value, ok := strings.Cut(strings.TrimSpace(line), " "); ok && strings.TrimSpace(value) != "" {
+			return partialCloneFilterWarning(strings.TrimSpace(value))
+		}
+	}
+	return ""
+}
+
+func partialCloneFilterWarning(filter string) string {
+	switch {
+	case filter == "":
+		return ""
+	case strings.HasPrefix(filter, "tree:"):
+		return "Partial clone sem árvores (" + filter + "): histórico, diffs e histórico de arquivo baixam árvores e blobs sob demanda e podem ficar lentos."
+	default:
+		return "Partial clone (" + filter + "): diffs e estatísticas de commits antigos baixam blobs sob demanda e podem ficar lentos."
+	}
+}
+
+// countMissingObjects conta as linhas "?<hash>" de rev-list --missing=print.
+func countMissingObjects(output string) int {
+	count := 0
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "?") {
+			count++
+		}
+	}
+	return count
+}
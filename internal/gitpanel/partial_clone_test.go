@@ -0,0 +1,80 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPartialCloneDetectionAndConversionBackToFull(t *testing.T) {
+	sourceRoot := mustInitTestRepo(t)
+	runGitOrFail(t, sourceRoot, "config", "uploadpack.allowFilter", "true")
+	if err := os.WriteFile(filepath.Join(sourceRoot, "big.txt"), []byte(strings.Repeat("data\n", 1000)), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGitOrFail(t, sourceRoot, "add", "big.txt")
+	runGitOrFail(t, sourceRoot, "commit", "-m", "add big file")
+
+	cloneRoot := filepath.Join(t.TempDir(), "clone")
+	runGitOrFail(t, sourceRoot, "clone", "--filter=blob:none", "--no-checkout", "file://"+sourceRoot, cloneRoot)
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	state, err := svc.GetPartialClone(cloneRoot)
+	if err != nil {
+		t.Fatalf("GetPartialClone() returned error: %v", err)
+	}
+	if !state.Enabled || !state.Promisor || state.Remote != "origin" || state.Filter != PartialCloneFilterBlobNone || len(state.Warnings) != 1 {
+		t.Fatalf("unexpected partial clone state: %+v", state)
+	}
+	page, err := svc.GetHistory(cloneRoot, "", 5, "")
+	if err != nil || page.Warning == "" {
+		t.Fatalf("expected history warning on partial clone, got (%q, %v)", page.Warning, err)
+	}
+	stats, err := svc.GetRepoStats(cloneRoot, 0, true)
+	if err != nil || stats.PartialClone.Filter != PartialCloneFilterBlobNone {
+		t.Fatalf("expected repo stats to report the filter, got (%+v, %v)", stats.PartialClone, err)
+	}
+
+	if _, err := svc.SetPartialClone(cloneRoot, "origin", "blob:everything"); err == nil {
+		t.Fatalf("expected invalid filter to be rejected")
+	}
+
+	state, err = svc.SetPartialClone(cloneRoot, "", "")
+	if err != nil {
+		t.Fatalf("SetPartialClone(full) returned error: %v", err)
+	}
+	if state.Enabled || state.Promisor || len(state.Warnings) != 0 {
+		t.Fatalf("expected a full clone after refetch, got %+v", state)
+	}
+	if page, _ := svc.GetHistory(cloneRoot, "", 5, ""); page.Warning != "" {
+		t.Fatalf("expected no history warning on a full clone, got %q", page.Warning)
+	}
+}
+
+func TestSetPartialCloneConfiguresFilterForFutureFetches(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	remoteRoot := t.TempDir()
+	runGitOrFail(t, remoteRoot, "init", "--bare")
+	runGitOrFail(t, repoRoot, "remote", "add", "origin", remoteRoot)
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	if _, err := svc.SetPartialClone(repoRoot, "upstream", PartialCloneFilterBlobNone); err == nil {
+		t.Fatalf("expected unknown remote to be rejected")
+	}
+	state, err := svc.SetPartialClone(repoRoot, "origin", " Blob:Limit=1m ")
+	if err != nil {
+		t.Fatalf("SetPartialClone() returned error: %v", err)
+	}
+	if !state.Enabled || state.Filter != "blob:limit=1m" || !state.Promisor || len(state.Warnings) != 2 {
+		t.Fatalf("unexpected state after enabling partial clone: %+v", state)
+	}
+	if got := svc.readGitConfigString(repoRoot, "extensions.partialClone"); got != "origin" {
+		t.Fatalf("expected extensions.partialClone=origin, got %q", got)
+	}
+}
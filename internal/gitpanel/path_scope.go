@@ -0,0 +1,174 @@
+package gitpanel
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SparseCheckoutDTO descreve o estado de sparse-checkout do repositório.
+type SparseCheckoutDTO struct {
+	Enabled     bool     `json:"enabled"`
+	Cone        bool     `json:"cone"`
+	Directories []string `json:"directories,omitempty"` // diretórios incluídos (modo cone)
+	Patterns    []string `json:"patterns,omitempty"`    // padrões brutos (modo não-cone)
+}
+
+// NormalizePathScope valida um filtro de subdiretório relativo à raiz do repositório.
+// Escopo vazio ou "." significa repositório inteiro.
+func NormalizePathScope(repoRoot string, scope string) (string, error) {
+	trimmed := strings.Trim(strings.TrimSpace(filepath.ToSlash(scope)), "/")
+	if trimmed == "" || trimmed == "." {
+		return "", nil
+	}
+	return ensurePathWithinRepo(repoRoot, trimmed)
+}
+
+// PathInScope indica se o caminho (relativo ao repo) pertence ao escopo.
+func PathInScope(filePath string, scope string) bool {
+	if scope == "" {
+		return true
+	}
+	return filePath == scope || strings.HasPrefix(filePath, scope+"/")
+}
+
+// FilterStatusByScope mantém apenas as alterações dentro do escopo.
+// Renomeações entram se origem ou destino pertencerem ao escopo.
+func FilterStatusByScope(status StatusDTO, scope string) StatusDTO {
+	if scope == "" {
+		return status
+	}
+
+	filterChanges := func(items []FileChangeDTO) []FileChangeDTO {
+		filtered := make([]FileChangeDTO, 0, len(items))
+		for _, item := range items {
+			if PathInScope(item.Path, scope) || (item.OriginalPath != "" && PathInScope(item.OriginalPath, scope)) {
+				filtered = append(filtered, item)
+			}
+		}
+		return filtered
+	}
+
+	scoped := status
+	scoped.Staged = filterChanges(status.Staged)
+	scoped.Unstaged = filterChanges(status.Unstaged)
+	scoped.Conflicted = make([]ConflictFileDTO, 0, len(status.Conflicted))
+	for _, item := range status.Conflicted {
+		if PathInScope(item.Path, scope) {
+			scoped.Conflicted = append(scoped.Conflicted, item)
+		}
+	}
+	scoped.PathScope = scope
+	return scoped
+}
+
+// GetStatusInScope retorna o status limitado a um subdiretório do repositório.
+func (s *Service) GetStatusInScope(repoPath string, scope string) (StatusDTO, error) {
+	return s.getStatusInScopeContext(context.Background(), repoPath, scope)
+}
+
+func (s *Service) getStatusInScopeContext(ctx context.Context, repoPath string, scope string) (StatusDTO, error) {
+	preflight, err := s.preflightContext(ctx, repoPath)
+	if err != nil {
+		return StatusDTO{}, err
+	}
+	normalizedScope, err := NormalizePathScope(preflight.RepoRoot, scope)
+	if err != nil {
+		return StatusDTO{}, err
+	}
+
+	status, err := s.getStatusContext(ctx, repoPath)
+	if err != nil {
+		return StatusDTO{}, err
+	}
+	return FilterStatusByScope(status, normalizedScope), nil
+}
+
+// GetSparseCheckout detecta se o repositório usa sparse-checkout e quais caminhos estão materializados.
+func (s *Service) GetSparseCheckout(repoPath string) (SparseCheckoutDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return SparseCheckoutDTO{}, err
+	}
+
+	result := SparseCheckoutDTO{}
+	if !s.readGitConfigBool(preflight.RepoRoot, "core.sparseCheckout") {
+		return result, nil
+	}
+	result.Enabled = true
+	result.Cone = s.readGitConfigBool(preflight.RepoRoot, "core.sparseCheckoutCone")
+
+	patterns := readSparseCheckoutPatterns(preflight.RepoRoot)
+	if result.Cone {
+		result.Directories = parseConeDirectories(patterns)
+	} else {
+		result.Patterns = patterns
+	}
+	return result, nil
+}
+
+func (s *Service) readGitConfigBool(repoRoot string, key string) bool {
+	out, _, _, runErr := s.runGit(
+		context.Background(),
+		defaultReadTimeout,
+		"",
+		"-C", repoRoot,
+		"config",
+		"--bool",
+		"--get",
+		key,
+	)
+	if runErr != nil {
+		return false
+	}
+	return strings.TrimSpace(out) == "true"
+}
+
+func readSparseCheckoutPatterns(repoRoot string) []string {
+	file, err := os.Open(filepath.Join(resolveRepoGitDir(repoRoot), "info", "sparse-checkout"))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	patterns := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns
+}
+
+// parseConeDirectories extrai os diretórios "folha" de um arquivo sparse-checkout em modo cone.
+// Ex.: "/packages/" + "!/packages/*/" + "/packages/api/" => ["packages/api"].
+func parseConeDirectories(patterns []string) []string {
+	positive := make(map[string]struct{})
+	negated := make(map[string]struct{})
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "!") {
+			negated[strings.TrimPrefix(pattern, "!")] = struct{}{}
+			continue
+		}
+		if pattern == "/*" || !strings.HasPrefix(pattern, "/") || !strings.HasSuffix(pattern, "/") {
+			continue
+		}
+		positive[pattern] = struct{}{}
+	}
+
+	directories := make([]string, 0, len(positive))
+	for pattern := range positive {
+		if _, isParent := negated[pattern+"*/"]; isParent {
+			continue
+		}
+		directories = append(directories, strings.Trim(pattern, "/"))
+	}
+	sort.Strings(directories)
+	return directories
+}
@@ -0,0 +1,90 @@
+package gitpanel
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPathScopeFiltersStatusAndHistory(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+
+	for _, dir := range []string{"packages/api", "packages/web"} {
+		if err := os.MkdirAll(filepath.Join(repoRoot, dir), 0o755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(repoRoot, dir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		runGitOrFail(t, repoRoot, "add", "--", dir)
+		runGitOrFail(t, repoRoot, "commit", "-m", "add "+dir)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoRoot, "packages/api/main.go"), []byte("package api\n"), 0o644); err != nil {
+		t.Fatalf("failed to modify file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoRoot, "README.md"), []byte("changed\n"), 0o644); err != nil {
+		t.Fatalf("failed to modify file: %v", err)
+	}
+
+	status, err := svc.GetStatusInScope(repoRoot, "packages/api/")
+	if err != nil {
+		t.Fatalf("GetStatusInScope returned error: %v", err)
+	}
+	if status.PathScope != "packages/api" || len(status.Unstaged) != 1 || status.Unstaged[0].Path != "packages/api/main.go" {
+		t.Fatalf("unexpected scoped status: %+v", status)
+	}
+
+	page, err := svc.GetHistoryInScope(repoRoot, "", 10, "", "packages/web")
+	if err != nil {
+		t.Fatalf("GetHistoryInScope returned error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Subject != "add packages/web" {
+		t.Fatalf("unexpected scoped history: %+v", page.Items)
+	}
+
+	diff, err := svc.GetDiffInScope(repoRoot, "", "unified", 3, "packages/api")
+	if err != nil {
+		t.Fatalf("GetDiffInScope returned error: %v", err)
+	}
+	if len(diff.Files) != 1 || diff.Files[0].Path != "packages/api/main.go" {
+		t.Fatalf("unexpected scoped diff files: %+v", diff.Files)
+	}
+
+	if _, err := svc.GetStatusInScope(repoRoot, "../outside"); err == nil {
+		t.Fatalf("expected scope outside the repository to be rejected")
+	}
+}
+
+func TestGetSparseCheckoutDetectsConeDirectories(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+
+	sparse, err := svc.GetSparseCheckout(repoRoot)
+	if err != nil {
+		t.Fatalf("GetSparseCheckout returned error: %v", err)
+	}
+	if sparse.Enabled {
+		t.Fatalf("expected sparse-checkout to be disabled by default")
+	}
+
+	runGitOrFail(t, repoRoot, "config", "core.sparseCheckout", "true")
+	runGitOrFail(t, repoRoot, "config", "core.sparseCheckoutCone", "true")
+	patterns := "/*\n!/*/\n/packages/\n!/packages/*/\n/packages/api/\n/tools/\n"
+	if err := os.WriteFile(filepath.Join(repoRoot, ".git", "info", "sparse-checkout"), []byte(patterns), 0o644); err != nil {
+		t.Fatalf("failed to write sparse-checkout file: %v", err)
+	}
+
+	sparse, err = svc.GetSparseCheckout(repoRoot)
+	if err != nil {
+		t.Fatalf("GetSparseCheckout returned error: %v", err)
+	}
+	if !sparse.Enabled || !sparse.Cone {
+		t.Fatalf("expected cone sparse-checkout, got %+v", sparse)
+	}
+	if want := []string{"packages/api", "tools"}; !reflect.DeepEqual(sparse.Directories, want) {
+		t.Fatalf("unexpected sparse directories: got=%v want=%v", sparse.Directories, want)
+	}
+}
@@ -0,0 +1,332 @@
+package gitpanel
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	pushTimeout      = 2 * time.Minute
+	pushGuardTimeout = 30 * time.Second
+	// defaultLargePushFileThreshold segue o aviso do GitHub (50 MiB); o limite rígido é 100 MiB.
+	defaultLargePushFileThreshold = 50 << 20
+	maxLargePushFiles             = 20
+
+	protectedBranchesConfigKey = "orch.protectedBranches"
+	largeFileThresholdKey      = "orch.pushLargeFileThreshold"
+
+	PushViolationProtectedBranch = "protected_branch"
+	PushViolationForcePush       = "force_push"
+	PushViolationLargeFiles      = "large_files"
+)
+
+// fallbackDefaultBranches são tratadas como default quando o remoto não publica HEAD.
+var fallbackDefaultBranches = []string{"main", "master"}
+
+// CheckPushSafety avalia o push descrito em options sem executá-lo.
+func (s *Service) CheckPushSafety(repoPath string, options PushOptionsDTO) (PushSafetyReportDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return PushSafetyReportDTO{}, err
+	}
+	remote, branch, err := s.resolvePushTarget(preflight.RepoRoot, options)
+	if err != nil {
+		return PushSafetyReportDTO{}, err
+	}
+	return s.checkPushSafety(preflight.RepoRoot, remote, branch, options), nil
+}
+
+// Push envia a branch ao remoto depois das verificações de segurança.
+// Violação sem confirmação não é erro de binding: o resultado volta com Pushed=false e o relatório.
+func (s *Service) Push(repoPath string, options PushOptionsDTO) (PushResultDTO, error) {
+	return s.PushContext(context.Background(), repoPath, options)
+}
+
+// PushContext é Push cancelável pelo chamador (jobs em background).
+func (s *Service) PushContext(ctx context.Context, repoPath string, options PushOptionsDTO) (PushResultDTO, error) {
+	commandID, startedAt := s.beginCommand("push")
+
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		s.emitCommandFailure(commandID, repoPath, "push", nil, startedAt, err)
+		return PushResultDTO{}, err
+	}
+	root := preflight.RepoRoot
+
+	remote, branch, err := s.resolvePushTarget(root, options)
+	if err != nil {
+		s.emitCommandFailure(commandID, root, "push", nil, startedAt, err)
+		return PushResultDTO{}, err
+	}
+
+	args := []string{"push", "--porcelain"}
+	if options.Force {
+		args = append(args, "--force-with-lease")
+	}
+	if options.SetUpstream {
+		args = append(args, "--set-upstream")
+	}
+	args = append(args, remote, "refs/heads/"+branch+":refs/heads/"+branch)
+
+	result := PushResultDTO{Safety: s.checkPushSafety(root, remote, branch, options)}
+	if result.Safety.RequiresConfirmation {
+		s.emitCommandFailure(commandID, root, "push", args, startedAt, NewBindingError(
+			CodePushNotConfirmed,
+			"Push exige confirmação explícita.",
+			formatPushViolations(result.Safety.Violations),
+		))
+		return result, nil
+	}
+
+	runErr := s.executeWriteContext(ctx, root, commandID, "push", args, startedAt, pushTimeout, func(ctx context.Context, diag *commandDiagnosticState) error {
+		// Sem terminal: credencial ausente deve falhar em vez de travar esperando prompt.
+		pushCtx := withGitEnv(ctx, "GIT_TERMINAL_PROMPT=0")
+		gitArgs := append([]string{"-C", root}, args...)
+		out, errOut, exitCode, pushErr := s.runGit(pushCtx, remainingTimeout(ctx, pushTimeout), "", gitArgs...)
+
+		result.Output = strings.TrimSpace(strings.TrimSpace(out) + "\n" + strings.TrimSpace(errOut))
+		diag.recordAttempt(gitArgs, errOut, exitCode, 1)
+		if pushErr == nil {
+			return nil
+		}
+		if mapped := queueErrorFromContext(pushErr, "Push interrompido."); mapped != nil {
+			return mapped
+		}
+		return wrapWriteCommandError(
+			CodeCommandFailed,
+			"Falha ao enviar branch para o remoto.",
+			result.Output,
+			exitCode,
+			pushErr,
+		)
+	})
+	if runErr != nil {
+		return PushResultDTO{}, runErr
+	}
+
+	// Push altera upstream (ahead/behind) e refs remotas exibidas no histórico.
+	s.emitPostWriteReconciliation(root, "push", false)
+	s.emitHistoryInvalidatedWithContext(root, "post_write_reconcile", "push")
+	result.Pushed = true
+	return result, nil
+}
+
+// resolvePushTarget valida a branch (vazia = atual) e o remoto (vazio = upstream da branch ou origin).
+func (s *Service) resolvePushTarget(repoRoot string, options PushOptionsDTO) (string, string, error) {
+	branch := strings.TrimSpace(options.Branch)
+	if branch == "" {
+		out, _, _, runErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", repoRoot, "symbolic-ref", "--quiet", "--short", "HEAD")
+		if runErr != nil {
+			return "", "", NewBindingError(CodeCommandFailed, "HEAD destacado: informe a branch para o push.", "")
+		}
+		branch = strings.TrimSpace(out)
+	}
+	branch = strings.TrimPrefix(branch, "refs/heads/")
+	if _, _, _, runErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", repoRoot, "check-ref-format", "--branch", branch); runErr != nil || strings.HasPrefix(branch, "-") {
+		return "", "", NewBindingError(CodeCommandFailed, "Nome de branch inválido para push.", branch)
+	}
+	if _, _, _, runErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", repoRoot, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); runErr != nil {
+		return "", "", NewBindingError(CodeCommandFailed, "Branch local não encontrada para push.", branch)
+	}
+
+	remote := strings.TrimSpace(options.Remote)
+	if remote == "" {
+		remote = s.readGitConfigString(repoRoot, "branch."+branch+".remote")
+	}
+	if remote == "" || remote == "." {
+		remote = "origin"
+	}
+	if strings.HasPrefix(remote, "-") || s.readGitConfigString(repoRoot, "remote."+remote+".url") == "" {
+		return "", "", NewBindingError(CodeCommandFailed, "Remoto não configurado para push.", remote)
+	}
+	return remote, branch, nil
+}
+
+func (s *Service) checkPushSafety(repoRoot string, remote string, branch string, options PushOptionsDTO) PushSafetyReportDTO {
+	report := PushSafetyReportDTO{
+		Remote:     remote,
+		Branch:     branch,
+		Force:      options.Force,
+		LargeFiles: []RepoFileSizeDTO{},
+		Violations: []PushViolationDTO{},
+	}
+
+	report.DefaultBranch = s.remoteDefaultBranch(repoRoot, remote)
+	report.ProtectedSource = s.protectedBranchSource(repoRoot, branch, report.DefaultBranch, options.ProtectedBranches)
+	report.ProtectedBranch = report.ProtectedSource != ""
+	if report.ProtectedBranch {
+		report.Violations = append(report.Violations, PushViolationDTO{
+			Kind:      PushViolationProtectedBranch,
+			Message:   "Push direto para a branch protegida " + branch + ".",
+			Confirmed: options.Confirm.ProtectedBranch,
+		})
+	}
+
+	if options.Force {
+		report.Violations = append(report.Violations, PushViolationDTO{
+			Kind:      PushViolationForcePush,
+			Message:   "Force push pode sobrescrever commits de " + remote + "/" + branch + ".",
+			Confirmed: options.Confirm.ForcePush,
+		})
+	}
+
+	report.LargeFileThresholdBytes = s.readGitConfigInt64(repoRoot, largeFileThresholdKey, defaultLargePushFileThreshold)
+	report.LargeFiles = s.findLargePushFiles(repoRoot, remote, branch, report.LargeFileThresholdBytes)
+	if len(report.LargeFiles) > 0 {
+		report.Violations = append(report.Violations, PushViolationDTO{
+			Kind:      PushViolationLargeFiles,
+			Message:   strconv.Itoa(len(report.LargeFiles)) + " arquivo(s) acima de " + formatPushBytes(report.LargeFileThresholdBytes) + " nos commits enviados.",
+			Confirmed: options.Confirm.LargeFiles,
+		})
+	}
+
+	for _, violation := range report.Violations {
+		if !violation.Confirmed {
+			report.RequiresConfirmation = true
+		}
+	}
+	return report
+}
+
+// remoteDefaultBranch lê refs/remotes/<remote>/HEAD (definido no clone ou por "git remote set-head").
+func (s *Service) remoteDefaultBranch(repoRoot string, remote string) string {
+	out, _, _, runErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", repoRoot, "symbolic-ref", "--quiet", "--short", "refs/remotes/"+remote+"/HEAD")
+	if runErr != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(out), remote+"/")
+}
+
+// protectedBranchSource indica por que a branch é protegida: config local, GitHub ou branch default.
+func (s *Service) protectedBranchSource(repoRoot string, branch string, defaultBranch string, githubProtected []string) string {
+	out, _, _, runErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", repoRoot, "config", "--get-all", protectedBranchesConfigKey)
+	if runErr == nil {
+		patterns := strings.FieldsFunc(out, func(r rune) bool { return r == ',' || r == ' ' || r == '\n' || r == '\t' })
+		if matchesBranchPattern(patterns, branch) {
+			return "config"
+		}
+	}
+	if matchesBranchPattern(githubProtected, branch) {
+		return "github"
+	}
+	if defaultBranch != "" {
+		if branch == defaultBranch {
+			return "default"
+		}
+		return ""
+	}
+	if containsString(fallbackDefaultBranches, branch) {
+		return "default"
+	}
+	return ""
+}
+
+// matchesBranchPattern aceita nomes exatos e globs no estilo da proteção do GitHub ("release/*").
+func matchesBranchPattern(patterns []string, branch string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if pattern == branch {
+			return true
+		}
+		if matched, err := path.Match(pattern, branch); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// findLargePushFiles lista blobs acima do limite que ainda não existem em refs do remoto.
+func (s *Service) findLargePushFiles(repoRoot string, remote string, branch string, threshold int64) []RepoFileSizeDTO {
+	files := []RepoFileSizeDTO{}
+	ctx, cancel := context.WithTimeout(context.Background(), pushGuardTimeout)
+	defer cancel()
+
+	objects, _, _, runErr := s.runGit(ctx, pushGuardTimeout, "", "-C", repoRoot, "rev-list", "--objects", "refs/heads/"+branch, "--not", "--remotes="+remote)
+	if runErr != nil || strings.TrimSpace(objects) == "" {
+		return files
+	}
+	out, _, _, runErr := s.runGit(ctx, pushGuardTimeout, objects, "-C", repoRoot, "cat-file", "--batch-check=%(objecttype) %(objectsize) %(rest)")
+	if runErr != nil {
+		return files
+	}
+	return parseLargePushObjects(out, threshold, maxLargePushFiles)
+}
+
+// parseLargePushObjects lê "tipo tamanho caminho" do cat-file e mantém o maior blob por caminho.
+func parseLargePushObjects(out string, threshold int64, limit int) []RepoFileSizeDTO {
+	largest := make(map[string]int64)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) != 3 || fields[0] != "blob" || fields[2] == "" {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || size < threshold {
+			continue
+		}
+		if size > largest[fields[2]] {
+			largest[fields[2]] = size
+		}
+	}
+
+	files := make([]RepoFileSizeDTO, 0, len(largest))
+	for filePath, size := range largest {
+		files = append(files, RepoFileSizeDTO{Path: filePath, SizeBytes: size})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].SizeBytes != files[j].SizeBytes {
+			return files[i].SizeBytes > files[j].SizeBytes
+		}
+		return files[i].Path < files[j].Path
+	})
+	if len(files) > limit {
+		files = files[:limit]
+	}
+	return files
+}
+
+// formatPushViolations resume as violações não confirmadas para detalhes de erro.
+func formatPushViolations(violations []PushViolationDTO) string {
+	lines := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		if !violation.Confirmed {
+			lines = append(lines, violation.Kind+": "+violation.Message)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatPushBytes(value int64) string {
+	if value >= 1<<20 && value%(1<<20) == 0 {
+		return strconv.FormatInt(value>>20, 10) + " MiB"
+	}
+	return strconv.FormatInt(value, 10) + " bytes"
+}
+
+func (s *Service) readGitConfigString(repoRoot string, key string) string {
+	out, _, _, runErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", repoRoot, "config", "--get", key)
+	if runErr != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// readGitConfigInt64 aceita sufixos do git (k, m, g) via --int.
+func (s *Service) readGitConfigInt64(repoRoot string, key string, fallback int64) int64 {
+	out, _, _, runErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", repoRoot, "config", "--int", "--get", key)
+	if runErr != nil {
+		return fallback
+	}
+	value, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
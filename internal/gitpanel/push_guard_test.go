@@ -0,0 +1,90 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPushRequiresConfirmationForDefaultBranchAndLargeFiles(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	remoteRoot := t.TempDir()
+	runGitOrFail(t, remoteRoot, "init", "--bare")
+	runGitOrFail(t, repoRoot, "remote", "add", "origin", remoteRoot)
+	runGitOrFail(t, repoRoot, "config", largeFileThresholdKey, "1k")
+
+	if err := os.WriteFile(filepath.Join(repoRoot, "asset.bin"), []byte(strings.Repeat("x", 4096)), 0o644); err != nil {
+		t.Fatalf("failed to write large file: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "add", "asset.bin")
+	runGitOrFail(t, repoRoot, "commit", "-m", "add asset")
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	result, err := svc.Push(repoRoot, PushOptionsDTO{})
+	if err != nil {
+		t.Fatalf("Push() returned error: %v", err)
+	}
+	if result.Pushed || !result.Safety.RequiresConfirmation || result.Safety.ProtectedSource != "default" {
+		t.Fatalf("expected unconfirmed push to default branch, got %+v", result)
+	}
+	if len(result.Safety.LargeFiles) != 1 || result.Safety.LargeFiles[0].Path != "asset.bin" || result.Safety.LargeFileThresholdBytes != 1024 {
+		t.Fatalf("unexpected large files: %+v", result.Safety)
+	}
+
+	result, err = svc.Push(repoRoot, PushOptionsDTO{
+		SetUpstream: true,
+		Confirm:     PushConfirmationDTO{ProtectedBranch: true, LargeFiles: true},
+	})
+	if err != nil {
+		t.Fatalf("Push(confirmed) returned error: %v", err)
+	}
+	if !result.Pushed {
+		t.Fatalf("expected confirmed push, got %+v", result)
+	}
+
+	// Objetos já presentes no remoto não contam mais como arquivos grandes.
+	report, err := svc.CheckPushSafety(repoRoot, PushOptionsDTO{Force: true})
+	if err != nil {
+		t.Fatalf("CheckPushSafety() returned error: %v", err)
+	}
+	kinds := make([]string, 0, len(report.Violations))
+	for _, violation := range report.Violations {
+		kinds = append(kinds, violation.Kind)
+	}
+	if len(report.LargeFiles) != 0 || strings.Join(kinds, ",") != PushViolationProtectedBranch+","+PushViolationForcePush {
+		t.Fatalf("unexpected report after push: %+v", report)
+	}
+}
+
+func TestProtectedBranchSourcePrecedence(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	runGitOrFail(t, repoRoot, "config", protectedBranchesConfigKey, "release/*, hotfix")
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	cases := []struct {
+		branch        string
+		defaultBranch string
+		github        []string
+		want          string
+	}{
+		{branch: "release/2.0", want: "config"},
+		{branch: "hotfix", want: "config"},
+		{branch: "release/2.0/rc", want: ""},
+		{branch: "staging", github: []string{"staging"}, want: "github"},
+		{branch: "develop", defaultBranch: "develop", want: "default"},
+		{branch: "main", defaultBranch: "develop", want: ""},
+		{branch: "master", want: "default"},
+		{branch: "feature/x", want: ""},
+	}
+	for _, tc := range cases {
+		if got := svc.protectedBranchSource(repoRoot, tc.branch, tc.defaultBranch, tc.github); got != tc.want {
+			t.Fatalf("protectedBranchSource(%q) = %q, want %q", tc.branch, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package gitpanel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Backends de leitura de status/histórico. O go-git lê o repositório em processo (sem spawn) e
+// volta para o CLI nos casos que não reproduz igual (submódulos, conflitos, commits assinados...).
+const (
+	ReadBackendCLI   = "cli"
+	ReadBackendGoGit = "gogit"
+)
+
+// Operações medidas pelo ReadObserver.
+const (
+	ReadOperationStatus  = "status"
+	ReadOperationHistory = "history"
+)
+
+const (
+	defaultBenchmarkIterations = 5
+	maxBenchmarkIterations     = 50
+)
+
+// ReadSample descreve uma leitura de status/histórico calculada (cache miss).
+type ReadSample struct {
+	RepoRoot  string
+	Operation string
+	Backend   string // backend que produziu o resultado
+	Fallback  string // motivo quando o go-git foi pedido mas o CLI respondeu
+	Duration  time.Duration
+	Err       error
+}
+
+// NormalizeReadBackend aceita "cli" e "gogit" (vazio vira "cli").
+func NormalizeReadBackend(backend string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(backend)) {
+	case "", ReadBackendCLI:
+		return ReadBackendCLI, nil
+	case ReadBackendGoGit, "go-git":
+		return ReadBackendGoGit, nil
+	}
+	return "", NewBindingError(
+		CodeCommandFailed,
+		"Backend de leitura inválido.",
+		fmt.Sprintf("%q não é suportado; use %q ou %q.", backend, ReadBackendCLI, ReadBackendGoGit),
+	)
+}
+
+// SetDefaultReadBackend define o backend dos repositórios sem override (config.toml).
+func (s *Service) SetDefaultReadBackend(backend string) {
+	normalized, err := NormalizeReadBackend(backend)
+	if err != nil {
+		normalized = ReadBackendCLI
+	}
+	s.readBackendMu.Lock()
+	changed := s.defaultReadBackend != normalized
+	s.defaultReadBackend = normalized
+	s.readBackendMu.Unlock()
+	if changed {
+		s.invalidateRepoCaches("")
+	}
+}
+
+// SetRepoReadBackend escolhe o backend do repositório; vazio remove o override. Vale até o app
+// reiniciar, como os TTLs por repositório.
+func (s *Service) SetRepoReadBackend(repoPath string, backend string) (ReadBackendDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return ReadBackendDTO{}, err
+	}
+	root := filepath.Clean(preflight.RepoRoot)
+
+	s.readBackendMu.Lock()
+	if strings.TrimSpace(backend) == "" {
+		delete(s.repoReadBackends, root)
+	} else {
+		normalized, normErr := NormalizeReadBackend(backend)
+		if normErr != nil {
+			s.readBackendMu.Unlock()
+			return ReadBackendDTO{}, normErr
+		}
+		s.repoReadBackends[root] = normalized
+	}
+	delete(s.readFallbacks, root)
+	s.readBackendMu.Unlock()
+
+	// Resultados em cache vieram do backend anterior.
+	s.invalidateRepoCaches(root)
+	return s.readBackendDTO(root), nil
+}
+
+// GetRepoReadBackend retorna o backend efetivo e o último fallback do repositório.
+func (s *Service) GetRepoReadBackend(repoPath string) (ReadBackendDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return ReadBackendDTO{}, err
+	}
+	return s.readBackendDTO(filepath.Clean(preflight.RepoRoot)), nil
+}
+
+// SetReadObserver registra quem recebe cada leitura calculada (ex.: API inspector).
+func (s *Service) SetReadObserver(observer func(ReadSample)) {
+	s.readBackendMu.Lock()
+	s.readObserver = observer
+	s.readBackendMu.Unlock()
+}
+
+func (s *Service) readBackendDTO(root string) ReadBackendDTO {
+	s.readBackendMu.RLock()
+	defer s.readBackendMu.RUnlock()
+	override, ok := s.repoReadBackends[root]
+	backend := s.defaultReadBackend
+	if ok {
+		backend = override
+	}
+	return ReadBackendDTO{
+		RepoRoot:     root,
+		Backend:      backend,
+		Override:     ok,
+		LastFallback: s.readFallbacks[root],
+	}
+}
+
+func (s *Service) readBackendFor(repoRoot string) string {
+	return s.readBackendDTO(filepath.Clean(repoRoot)).Backend
+}
+
+// observeRead registra o fallback do repositório e repassa a medição ao observer.
+func (s *Service) observeRead(sample ReadSample) {
+	root := filepath.Clean(sample.RepoRoot)
+	s.readBackendMu.Lock()
+	if sample.Fallback != "" {
+		s.readFallbacks[root] = sample.Fallback
+	}
+	observer := s.readObserver
+	s.readBackendMu.Unlock()
+	if observer != nil {
+		observer(sample)
+	}
+}
+
+// readStatusPorcelain obtém o status porcelain pelo backend pedido; retorna também o motivo
+// quando o go-git devolveu a leitura ao CLI.
+func (s *Service) readStatusPorcelain(ctx context.Context, repoRoot string, backend string) (string, string, error) {
+	started := time.Now()
+	sample := ReadSample{RepoRoot: repoRoot, Operation: ReadOperationStatus, Backend: backend}
+	if backend == ReadBackendGoGit {
+		out, err := goGitStatusPorcelain(repoRoot)
+		if err == nil {
+			sample.Duration = time.Since(started)
+			s.observeRead(sample)
+			return out, "", nil
+		}
+		sample.Fallback = err.Error()
+		if reason, ok := goGitFallbackReason(err); ok {
+			sample.Fallback = reason
+		}
+		sample.Backend = ReadBackendCLI
+	}
+
+	out, errOut, exitCode, runErr := s.runGit(
+		ctx,
+		defaultReadTimeout,
+		"",
+		"-C", repoRoot,
+		"status",
+		"--porcelain=v1",
+		"-z",
+		"--branch",
+	)
+	if runErr != nil && !isCanceledBindingError(runErr) {
+		runErr = NewBindingError(
+			CodeCommandFailed,
+			"Falha ao obter status do repositório.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+	sample.Duration = time.Since(started)
+	sample.Err = runErr
+	s.observeRead(sample)
+	return out, sample.Fallback, runErr
+}
+
+// readGoGitHistory tenta a página pelo go-git; um motivo de fallback não vazio manda o chamador
+// para o CLI.
+func readGoGitHistory(repoRoot string, cursorHash string, limit int, search string, pathScope string, withStats bool) ([]HistoryItemDTO, string) {
+	if strings.TrimSpace(search) != "" || pathScope != "" {
+		return nil, "busca ou escopo de caminho"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultReadTimeout)
+	defer cancel()
+	items, err := goGitHistoryPage(ctx, repoRoot, cursorHash, limit, withStats)
+	if err == nil {
+		return items, ""
+	}
+	if reason, ok := goGitFallbackReason(err); ok {
+		return nil, reason
+	}
+	return nil, err.Error()
+}
+
+// BenchmarkReadBackends roda status e a primeira página do histórico em cada backend, sem
+// cache, e compara os tempos. As leituras também aparecem no observer (API inspector).
+func (s *Service) BenchmarkReadBackends(repoPath string, iterations int) (ReadBenchmarkDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return ReadBenchmarkDTO{}, err
+	}
+	if iterations <= 0 {
+		iterations = defaultBenchmarkIterations
+	}
+	if iterations > maxBenchmarkIterations {
+		iterations = maxBenchmarkIterations
+	}
+	root := preflight.RepoRoot
+	result := ReadBenchmarkDTO{RepoRoot: root, Iterations: iterations, Results: []ReadBenchmarkResultDTO{}}
+
+	for _, backend := range []string{ReadBackendCLI, ReadBackendGoGit} {
+		status := ReadBenchmarkResultDTO{Backend: backend, Operation: ReadOperationStatus}
+		history := ReadBenchmarkResultDTO{Backend: backend, Operation: ReadOperationHistory}
+		for i := 0; i < iterations; i++ {
+			started := time.Now()
+			_, fallback, statusErr := s.readStatusPorcelain(context.Background(), root, backend)
+			status.add(time.Since(started))
+			if statusErr != nil && status.Error == "" {
+				status.Error = statusErr.Error()
+			}
+			if status.Fallback == "" {
+				status.Fallback = fallback
+			}
+
+			started = time.Now()
+			historyErr := s.benchmarkHistoryPage(root, backend, &history)
+			if historyErr != nil && history.Error == "" {
+				history.Error = historyErr.Error()
+			}
+			history.add(time.Since(started))
+		}
+		status.finish(iterations)
+		history.finish(iterations)
+		result.Results = append(result.Results, status, history)
+	}
+	return result, nil
+}
+
+func (s *Service) benchmarkHistoryPage(repoRoot string, backend string, result *ReadBenchmarkResultDTO) error {
+	started := time.Now()
+	sample := ReadSample{RepoRoot: repoRoot, Operation: ReadOperationHistory, Backend: backend}
+	if backend == ReadBackendGoGit {
+		if _, fallback := readGoGitHistory(repoRoot, "", defaultHistoryLimit, "", "", true); fallback == "" {
+			sample.Duration = time.Since(started)
+			s.observeRead(sample)
+			return nil
+		} else if result.Fallback == "" {
+			result.Fallback = fallback
+		}
+		sample.Backend, sample.Fallback = ReadBackendCLI, result.Fallback
+	}
+	_, _, err := s.skipHistoryPage(repoRoot, "", defaultHistoryLimit, "", "", true)
+	sample.Duration = time.Since(started)
+	sample.Err = err
+	s.observeRead(sample)
+	return err
+}
+
+func (r *ReadBenchmarkResultDTO) add(elapsed time.Duration) {
+	ms := float64(elapsed.Microseconds()) / 1000
+	if r.MinMs == 0 || ms < r.MinMs {
+		r.MinMs = ms
+	}
+	if ms > r.MaxMs {
+		r.MaxMs = ms
+	}
+	r.AvgMs += ms
+}
+
+func (r *ReadBenchmarkResultDTO) finish(iterations int) {
+	if iterations > 0 {
+		r.AvgMs /= float64(iterations)
+	}
+}
@@ -0,0 +1,310 @@
+package gitpanel
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Operações reconhecidas no reflog do HEAD.
+const (
+	ReflogActionCommit     = "commit"
+	ReflogActionAmend      = "amend"
+	ReflogActionMerge      = "merge"
+	ReflogActionPull       = "pull"
+	ReflogActionReset      = "reset"
+	ReflogActionRebase     = "rebase"
+	ReflogActionCheckout   = "checkout"
+	ReflogActionCherryPick = "cherry-pick"
+	ReflogActionRevert     = "revert"
+	ReflogActionOther      = "other"
+
+	defaultReflogLimit = 50
+	reflogLogFormat    = "%H%x1f%h%x1f%gd%x1f%gs%x1e"
+)
+
+var (
+	reflogDateSelectorRegex = regexp.MustCompile(`@\{(.+)\}$`)
+	reflogCheckoutRegex     = regexp.MustCompile(`^moving from (.+) to (.+)$`)
+)
+
+// GetReflog lista as entradas mais recentes do reflog do HEAD (HEAD@{0} primeiro).
+func (s *Service) GetReflog(repoPath string, limit int) ([]ReflogEntryDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultReflogLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+	return s.readReflog(preflight.RepoRoot, limit)
+}
+
+// UndoLast desfaz a operação mais recente do reflog com o comando de recuperação adequado.
+// Com dryRun apenas devolve o plano (comando e avisos) sem alterar o repositório.
+func (s *Service) UndoLast(repoPath string, dryRun bool) (UndoPlanDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return UndoPlanDTO{}, err
+	}
+	root := preflight.RepoRoot
+
+	plan, err := s.planUndo(root)
+	if err != nil {
+		return UndoPlanDTO{}, err
+	}
+	plan.DryRun = dryRun
+	if dryRun {
+		return plan, nil
+	}
+	if !plan.Supported {
+		return plan, NewBindingError(CodeCommandFailed, "Não é possível desfazer a última operação.", plan.Reason)
+	}
+
+	commandID, startedAt := s.beginCommand("undo_last")
+	if err := s.executeWrite(
+		root,
+		commandID,
+		"undo_last",
+		plan.Command,
+		startedAt,
+		defaultWriteTimeout,
+		func(ctx context.Context, diag *commandDiagnosticState) error {
+			// O reflog pode ter mudado entre o plano e a execução (outra janela, terminal).
+			out, _, _, headErr := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "rev-parse", "HEAD")
+			if headErr != nil || strings.TrimSpace(out) != plan.CurrentHash {
+				return NewBindingError(CodeCommandFailed, "O HEAD mudou desde a prévia do undo.", "Gere a prévia novamente antes de desfazer.")
+			}
+			args := append([]string{"-C", root}, plan.Command...)
+			_, errOut, exitCode, runErr := s.runWriteGitWithRetry(ctx, diag, "", args...)
+			if runErr != nil {
+				return wrapWriteCommandError(CodeCommandFailed, "Falha ao desfazer a última operação.", errOut, exitCode, runErr)
+			}
+			return nil
+		}); err != nil {
+		return UndoPlanDTO{}, err
+	}
+
+	s.emitPostWriteReconciliation(root, "undo_last", true)
+	plan.Applied = true
+	return plan, nil
+}
+
+func (s *Service) readReflog(root string, limit int) ([]ReflogEntryDTO, error) {
+	entries := []ReflogEntryDTO{}
+	if _, _, _, headErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", root, "rev-parse", "--verify", "--quiet", "HEAD"); headErr != nil {
+		return entries, nil // repositório sem commits
+	}
+	out, errOut, exitCode, runErr := s.runGit(
+		context.Background(),
+		defaultReadTimeout,
+		"",
+		"-C", root,
+		"log",
+		"-g",
+		"--date=iso-strict",
+		"--format="+reflogLogFormat,
+		"-n", strconv.Itoa(limit),
+		"HEAD",
+		"--",
+	)
+	if runErr != nil {
+		return nil, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao ler o reflog.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+	return parseReflogEntries(out), nil
+}
+
+func parseReflogEntries(output string) []ReflogEntryDTO {
+	entries := []ReflogEntryDTO{}
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimLeft(record, "\r\n")
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		fields := strings.SplitN(record, "\x1f", 4)
+		if len(fields) < 4 {
+			continue
+		}
+		entry := ReflogEntryDTO{
+			Selector:  fmt.Sprintf("HEAD@{%d}", len(entries)),
+			Hash:      strings.TrimSpace(fields[0]),
+			ShortHash: strings.TrimSpace(fields[1]),
+			Message:   strings.TrimRight(fields[3], "\r\n"),
+		}
+		if match := reflogDateSelectorRegex.FindStringSubmatch(strings.TrimSpace(fields[2])); match != nil {
+			entry.Date = match[1]
+		}
+		entry.Action, entry.Detail = classifyReflogMessage(entry.Message)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// classifyReflogMessage separa "commit (amend): msg" em ação normalizada e detalhe.
+func classifyReflogMessage(message string) (string, string) {
+	head, detail, found := strings.Cut(message, ": ")
+	if !found {
+		head, detail = strings.TrimSuffix(message, ":"), ""
+	}
+	head = strings.TrimSpace(head)
+	verb, qualifier := head, ""
+	if open := strings.Index(head, " ("); open >= 0 && strings.HasSuffix(head, ")") {
+		verb, qualifier = head[:open], head[open+2:len(head)-1]
+	}
+
+	switch {
+	case verb == "commit" && qualifier == "amend":
+		return ReflogActionAmend, detail
+	case verb == "commit" && qualifier == "merge":
+		return ReflogActionMerge, detail
+	case verb == "commit":
+		return ReflogActionCommit, detail
+	case verb == "merge" || strings.HasPrefix(verb, "merge "):
+		return ReflogActionMerge, detail
+	case verb == "pull" || strings.HasPrefix(verb, "pull "):
+		return ReflogActionPull, detail
+	case verb == "reset":
+		return ReflogActionReset, detail
+	case verb == "rebase" || strings.HasPrefix(verb, "rebase "):
+		// O qualificador (start/pick/finish...) fica no detalhe para o plano de undo.
+		if qualifier != "" {
+			detail = qualifier + ": " + detail
+		}
+		return ReflogActionRebase, detail
+	case verb == "checkout" || verb == "switch":
+		return ReflogActionCheckout, detail
+	case verb == "cherry-pick":
+		return ReflogActionCherryPick, detail
+	case verb == "revert":
+		return ReflogActionRevert, detail
+	default:
+		return ReflogActionOther, message
+	}
+}
+
+// planUndo mapeia HEAD@{0} para o comando de recuperação:
+//   - commit/amend: reset --soft para HEAD@{1} (as mudanças voltam ao stage);
+//   - merge/pull/cherry-pick/revert: reset --keep para HEAD@{1} (preserva alterações locais);
+//   - reset: volta para HEAD@{1} (--mixed se os arquivos já batem com o alvo, senão --keep);
+//   - rebase: reset --keep para o HEAD anterior ao "rebase (start)";
+//   - checkout: checkout da branch de origem.
+func (s *Service) planUndo(root string) (UndoPlanDTO, error) {
+	plan := UndoPlanDTO{Command: []string{}, Warnings: []string{}}
+	if operation := detectInProgressOperation(resolveRepoGitDir(root)); operation != "" {
+		plan.Operation = operation
+		plan.Reason = "Há uma operação em andamento (" + operation + "); conclua ou aborte antes de desfazer."
+		return plan, nil
+	}
+
+	entries, err := s.readReflog(root, maxHistoryLimit)
+	if err != nil {
+		return UndoPlanDTO{}, err
+	}
+	if len(entries) == 0 {
+		plan.Reason = "O reflog está vazio."
+		return plan, nil
+	}
+	last := entries[0]
+	plan.Operation = last.Action
+	plan.Entry = last
+	plan.CurrentHash = last.Hash
+
+	previous := func() (ReflogEntryDTO, bool) {
+		if len(entries) < 2 {
+			plan.Reason = "Não há estado anterior no reflog para esta operação."
+			return ReflogEntryDTO{}, false
+		}
+		return entries[1], true
+	}
+
+	switch last.Action {
+	case ReflogActionCommit, ReflogActionAmend:
+		target, ok := previous()
+		if !ok {
+			if strings.HasPrefix(last.Message, "commit (initial)") {
+				plan.Reason = "O commit inicial não tem estado anterior para onde voltar."
+			}
+			return plan, nil
+		}
+		plan.TargetHash = target.Hash
+		plan.Command = []string{"reset", "--soft", target.Hash}
+		if last.Action == ReflogActionAmend {
+			plan.Description = "Desfaz o amend: volta ao commit original e deixa as mudanças do amend no stage."
+		} else {
+			plan.Description = "Desfaz o commit \"" + last.Detail + "\" mantendo as mudanças no stage."
+		}
+	case ReflogActionMerge, ReflogActionPull, ReflogActionCherryPick, ReflogActionRevert:
+		target, ok := previous()
+		if !ok {
+			return plan, nil
+		}
+		plan.TargetHash = target.Hash
+		plan.Command = []string{"reset", "--keep", target.Hash}
+		plan.Description = "Volta o branch para " + target.ShortHash + ", antes de \"" + last.Message + "\"."
+	case ReflogActionReset:
+		target, ok := previous()
+		if !ok {
+			return plan, nil
+		}
+		plan.TargetHash = target.Hash
+		plan.Description = "Desfaz o reset: volta o branch para " + target.ShortHash + "."
+		if _, _, _, diffErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", root, "diff", "--quiet", target.Hash, "--"); diffErr == nil {
+			// reset --soft/--mixed: os arquivos já estão como no alvo, basta mover o branch.
+			plan.Command = []string{"reset", "--mixed", target.Hash}
+		} else {
+			plan.Command = []string{"reset", "--keep", target.Hash}
+			plan.Warnings = append(plan.Warnings, "Alterações descartadas por um reset --hard que nunca foram commitadas não podem ser recuperadas.")
+		}
+	case ReflogActionRebase:
+		start := -1
+		for i, entry := range entries {
+			if entry.Action == ReflogActionRebase && strings.HasPrefix(entry.Detail, "start:") {
+				start = i
+				break
+			}
+			if entry.Action != ReflogActionRebase {
+				break
+			}
+		}
+		if start < 0 || start+1 >= len(entries) {
+			plan.Reason = "Não foi possível localizar o início do rebase no reflog."
+			return plan, nil
+		}
+		target := entries[start+1]
+		plan.TargetHash = target.Hash
+		plan.Command = []string{"reset", "--keep", target.Hash}
+		plan.Description = "Desfaz o rebase: volta o branch para " + target.ShortHash + ", o estado antes do rebase."
+	case ReflogActionCheckout:
+		match := reflogCheckoutRegex.FindStringSubmatch(last.Detail)
+		if match == nil {
+			plan.Reason = "Checkout sem branch de origem identificável."
+			return plan, nil
+		}
+		if target, ok := previous(); ok {
+			plan.TargetHash = target.Hash
+		}
+		plan.Command = []string{"checkout", match[1]}
+		plan.Description = "Volta para " + match[1] + "."
+	default:
+		plan.Reason = "A operação \"" + last.Message + "\" não tem undo automático; use o reflog para escolher o estado."
+		return plan, nil
+	}
+
+	plan.Supported = true
+	if plan.Operation != ReflogActionCheckout && plan.Command[1] == "--keep" {
+		plan.Warnings = append(plan.Warnings, "Alterações locais em arquivos afetados fazem o git recusar o undo; faça commit ou stash antes.")
+	}
+	if remotes, _, _, _ := s.runGit(context.Background(), defaultReadTimeout, "", "-C", root, "branch", "-r", "--contains", plan.CurrentHash); strings.TrimSpace(remotes) != "" && plan.Operation != ReflogActionCheckout {
+		plan.Warnings = append(plan.Warnings, "O commit atual já foi enviado ao remoto; o próximo push exigirá --force.")
+	}
+	return plan, nil
+}
@@ -0,0 +1,144 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func commitTestFile(t *testing.T, repoRoot string, name string, content string, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(repoRoot, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	runGitOrFail(t, repoRoot, "add", "--", name)
+	runGitOrFail(t, repoRoot, "commit", "-m", message)
+}
+
+func gitOutputOrFail(t *testing.T, repoRoot string, args ...string) string {
+	t.Helper()
+	out, stderr, _, err := runGitWithInput(context.Background(), 5*time.Second, "", append([]string{"-C", repoRoot}, args...)...)
+	if err != nil {
+		t.Fatalf("git %s failed: %v stderr=%s", strings.Join(args, " "), err, stderr)
+	}
+	return strings.TrimSpace(out)
+}
+
+func TestUndoLastCommitKeepsChangesStaged(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	initial := gitOutputOrFail(t, repoRoot, "rev-parse", "HEAD")
+	commitTestFile(t, repoRoot, "feature.txt", "feature\n", "add feature")
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	entries, err := svc.GetReflog(repoRoot, 10)
+	if err != nil {
+		t.Fatalf("GetReflog() returned error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Selector != "HEAD@{0}" || entries[0].Action != ReflogActionCommit || entries[0].Detail != "add feature" || entries[0].Date == "" {
+		t.Fatalf("unexpected reflog: %+v", entries)
+	}
+
+	plan, err := svc.UndoLast(repoRoot, true)
+	if err != nil {
+		t.Fatalf("UndoLast(dryRun) returned error: %v", err)
+	}
+	if !plan.Supported || plan.Applied || strings.Join(plan.Command, " ") != "reset --soft "+initial {
+		t.Fatalf("unexpected dry-run plan: %+v", plan)
+	}
+	if head := gitOutputOrFail(t, repoRoot, "rev-parse", "HEAD"); head == initial {
+		t.Fatalf("dry-run must not move HEAD")
+	}
+
+	plan, err = svc.UndoLast(repoRoot, false)
+	if err != nil || !plan.Applied {
+		t.Fatalf("UndoLast() returned (%+v, %v)", plan, err)
+	}
+	if head := gitOutputOrFail(t, repoRoot, "rev-parse", "HEAD"); head != initial {
+		t.Fatalf("expected HEAD at %s, got %s", initial, head)
+	}
+	if staged := gitOutputOrFail(t, repoRoot, "diff", "--cached", "--name-only"); staged != "feature.txt" {
+		t.Fatalf("expected feature.txt staged after undo, got %q", staged)
+	}
+
+	// O próprio undo entra no reflog como reset; desfazê-lo refaz o commit.
+	plan, err = svc.UndoLast(repoRoot, false)
+	if err != nil || plan.Operation != ReflogActionReset || strings.Join(plan.Command[:2], " ") != "reset --mixed" {
+		t.Fatalf("expected redo via reset --mixed, got (%+v, %v)", plan, err)
+	}
+	if subject := gitOutputOrFail(t, repoRoot, "log", "-1", "--format=%s"); subject != "add feature" {
+		t.Fatalf("expected commit restored, got %q", subject)
+	}
+}
+
+func TestUndoLastRebaseAndCheckout(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	mainBranch := gitOutputOrFail(t, repoRoot, "rev-parse", "--abbrev-ref", "HEAD")
+	runGitOrFail(t, repoRoot, "checkout", "-b", "feature")
+	commitTestFile(t, repoRoot, "feature.txt", "feature\n", "feature work")
+	beforeRebase := gitOutputOrFail(t, repoRoot, "rev-parse", "HEAD")
+	runGitOrFail(t, repoRoot, "checkout", mainBranch)
+	commitTestFile(t, repoRoot, "main.txt", "main\n", "main work")
+	runGitOrFail(t, repoRoot, "checkout", "feature")
+	runGitOrFail(t, repoRoot, "rebase", mainBranch)
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	plan, err := svc.UndoLast(repoRoot, false)
+	if err != nil || plan.Operation != ReflogActionRebase || plan.TargetHash != beforeRebase {
+		t.Fatalf("unexpected rebase undo: (%+v, %v)", plan, err)
+	}
+	if head := gitOutputOrFail(t, repoRoot, "rev-parse", "HEAD"); head != beforeRebase {
+		t.Fatalf("expected HEAD back at %s, got %s", beforeRebase, head)
+	}
+
+	runGitOrFail(t, repoRoot, "checkout", mainBranch)
+	plan, err = svc.UndoLast(repoRoot, false)
+	if err != nil || plan.Operation != ReflogActionCheckout || strings.Join(plan.Command, " ") != "checkout feature" {
+		t.Fatalf("unexpected checkout undo: (%+v, %v)", plan, err)
+	}
+	if branch := gitOutputOrFail(t, repoRoot, "rev-parse", "--abbrev-ref", "HEAD"); branch != "feature" {
+		t.Fatalf("expected to be back on feature, got %s", branch)
+	}
+}
+
+func TestUndoLastRejectsInitialCommit(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	plan, err := svc.UndoLast(repoRoot, true)
+	if err != nil || plan.Supported || plan.Reason == "" {
+		t.Fatalf("expected unsupported dry-run plan, got (%+v, %v)", plan, err)
+	}
+	if _, err := svc.UndoLast(repoRoot, false); err == nil {
+		t.Fatalf("expected undo of the initial commit to fail")
+	}
+}
+
+func TestClassifyReflogMessage(t *testing.T) {
+	cases := []struct {
+		message string
+		action  string
+		detail  string
+	}{
+		{"commit (amend): fix typo", ReflogActionAmend, "fix typo"},
+		{"commit (merge): Merge branch 'x'", ReflogActionMerge, "Merge branch 'x'"},
+		{"merge feature: Fast-forward", ReflogActionMerge, "Fast-forward"},
+		{"pull --rebase origin main: Fast-forward", ReflogActionPull, "Fast-forward"},
+		{"rebase -i (start): checkout main", ReflogActionRebase, "start: checkout main"},
+		{"checkout: moving from main to feature", ReflogActionCheckout, "moving from main to feature"},
+		{"branch: Created from HEAD", ReflogActionOther, "branch: Created from HEAD"},
+	}
+	for _, tc := range cases {
+		action, detail := classifyReflogMessage(tc.message)
+		if action != tc.action || detail != tc.detail {
+			t.Fatalf("classifyReflogMessage(%q) = (%q, %q), want (%q, %q)", tc.message, action, detail, tc.action, tc.detail)
+		}
+	}
+}
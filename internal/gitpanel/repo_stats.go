@@ -0,0 +1,290 @@
+package gitpanel
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// repoStatsCacheTTL é longo de propósito: as estatísticas são caras e o refresh é explícito.
+	repoStatsCacheTTL     = 15 * time.Minute
+	repoStatsTimeout      = 30 * time.Second
+	defaultStaleDays      = 90
+	maxLargestFiles       = 20
+	maxTopContributors    = 20
+	commitHistogramWeeks  = 52
+	commitHistogramLayout = "2006-01-02"
+)
+
+type repoStatsCacheEntry struct {
+	value     RepoStatsDTO
+	expiresAt time.Time
+}
+
+// GetRepoStats calcula métricas de saúde do repositório (tamanho, maiores arquivos, branches,
+// contribuidores e frequência de commits). O resultado fica em cache até refresh=true ou expirar.
+func (s *Service) GetRepoStats(repoPath string, staleDays int, refresh bool) (RepoStatsDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return RepoStatsDTO{}, err
+	}
+	if staleDays <= 0 {
+		staleDays = defaultStaleDays
+	}
+
+	cacheKey := preflight.RepoRoot + "\x1f" + strconv.Itoa(staleDays)
+	if !refresh {
+		if cached, ok := s.getCachedRepoStats(cacheKey); ok {
+			return cached, nil
+		}
+	}
+
+	now := time.Now()
+	stats := RepoStatsDTO{
+		RepoRoot:         preflight.RepoRoot,
+		StaleDays:        staleDays,
+		LargestFiles:     []RepoFileSizeDTO{},
+		StaleBranches:    []StaleBranchDTO{},
+		Contributors:     []ContributorDTO{},
+		CommitsByWeek:    []CommitBucketDTO{},
+		CommitsByWeekday: make([]int, 7),
+		CommitsByHour:    make([]int, 24),
+		ComputedAt:       now.UTC().Format(time.RFC3339),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), repoStatsTimeout)
+	defer cancel()
+	root := preflight.RepoRoot
+
+	stats.PartialClone = s.readPartialClone(root)
+	if sparse, sparseErr := s.GetSparseCheckout(root); sparseErr == nil {
+		stats.SparseCheckout = sparse
+	}
+
+	if out, _, _, runErr := s.runGit(ctx, repoStatsTimeout, "", "-C", root, "count-objects", "-v"); runErr == nil {
+		stats.SizeBytes, stats.ObjectCount = parseCountObjects(out)
+	}
+
+	out, errOut, exitCode, runErr := s.runGit(
+		ctx,
+		repoStatsTimeout,
+		"",
+		"-C", root,
+		"for-each-ref",
+		"--format=%(refname)%09%(committerdate:unix)%09%(authorname)",
+		"refs/heads",
+		"refs/remotes",
+		"refs/tags",
+	)
+	if runErr != nil {
+		return RepoStatsDTO{}, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao listar refs do repositório.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+	applyRefStats(&stats, out, now)
+
+	// Repositório sem commits: não há árvore, contribuidores nem histograma.
+	if _, _, _, headErr := s.runGit(ctx, defaultReadTimeout, "", "-C", root, "rev-parse", "--verify", "--quiet", "HEAD"); headErr != nil {
+		s.setCachedRepoStats(cacheKey, stats)
+		return stats, nil
+	}
+
+	if out, _, _, runErr := s.runGit(ctx, repoStatsTimeout, "", "-C", root, "ls-tree", "-r", "-l", "-z", "HEAD"); runErr == nil {
+		stats.LargestFiles, stats.TrackedFiles = parseLargestFiles(out, maxLargestFiles)
+	}
+
+	if out, _, _, runErr := s.runGit(ctx, repoStatsTimeout, "", "-C", root, "shortlog", "-sne", "HEAD"); runErr == nil {
+		stats.Contributors, stats.ContributorCount = parseShortlog(out, maxTopContributors)
+	}
+
+	since := now.AddDate(0, 0, -7*commitHistogramWeeks)
+	if out, _, _, runErr := s.runGit(ctx, repoStatsTimeout, "", "-C", root, "log", "--format=%at", "--since="+strconv.FormatInt(since.Unix(), 10), "HEAD"); runErr == nil {
+		applyCommitHistograms(&stats, out, now)
+	}
+	if out, _, _, runErr := s.runGit(ctx, repoStatsTimeout, "", "-C", root, "rev-list", "--count", "HEAD"); runErr == nil {
+		stats.TotalCommits, _ = strconv.Atoi(strings.TrimSpace(out))
+	}
+
+	s.setCachedRepoStats(cacheKey, stats)
+	return stats, nil
+}
+
+// parseCountObjects soma objetos soltos e empacotados de "git count-objects -v" (tamanhos em KiB).
+func parseCountObjects(out string) (int64, int64) {
+	values := make(map[string]int64)
+	for _, line := range strings.Split(out, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err == nil {
+			values[strings.TrimSpace(key)] = parsed
+		}
+	}
+	return (values["size"] + values["size-pack"]) * 1024, values["count"] + values["in-pack"]
+}
+
+func applyRefStats(stats *RepoStatsDTO, out string, now time.Time) {
+	staleBefore := now.AddDate(0, 0, -stats.StaleDays)
+	for _, line := range strings.Split(out, "\n") {
+		// Tags anotadas não têm committerdate: as colunas finais podem vir vazias.
+		fields := strings.SplitN(strings.TrimRight(line, "\r"), "\t", 3)
+		for len(fields) < 3 {
+			fields = append(fields, "")
+		}
+		refName, author := fields[0], fields[2]
+
+		var name string
+		remote := false
+		switch {
+		case strings.HasPrefix(refName, "refs/tags/"):
+			stats.TagCount++
+			continue
+		case strings.HasPrefix(refName, "refs/heads/"):
+			stats.BranchCount++
+			name = strings.TrimPrefix(refName, "refs/heads/")
+		case strings.HasPrefix(refName, "refs/remotes/"):
+			name = strings.TrimPrefix(refName, "refs/remotes/")
+			if strings.HasSuffix(name, "/HEAD") {
+				continue
+			}
+			stats.RemoteBranchCount++
+			remote = true
+		default:
+			continue
+		}
+
+		committedAt, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || committedAt <= 0 {
+			continue
+		}
+		lastCommit := time.Unix(committedAt, 0)
+		if !lastCommit.Before(staleBefore) {
+			continue
+		}
+		stats.StaleBranches = append(stats.StaleBranches, StaleBranchDTO{
+			Name:         name,
+			Remote:       remote,
+			LastAuthor:   author,
+			LastCommitAt: lastCommit.UTC().Format(time.RFC3339),
+			DaysInactive: int(now.Sub(lastCommit).Hours() / 24),
+		})
+	}
+
+	sort.Slice(stats.StaleBranches, func(i, j int) bool {
+		return stats.StaleBranches[i].DaysInactive > stats.StaleBranches[j].DaysInactive
+	})
+}
+
+// parseLargestFiles lê "git ls-tree -r -l -z" e retorna os maiores blobs e o total de arquivos.
+func parseLargestFiles(out string, limit int) ([]RepoFileSizeDTO, int) {
+	files := make([]RepoFileSizeDTO, 0)
+	for _, record := range strings.Split(out, "\x00") {
+		meta, filePath, found := strings.Cut(record, "\t")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 4 || fields[1] != "blob" {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			continue
+		}
+		files = append(files, RepoFileSizeDTO{Path: filePath, SizeBytes: size})
+	}
+
+	total := len(files)
+	sort.SliceStable(files, func(i, j int) bool { return files[i].SizeBytes > files[j].SizeBytes })
+	if len(files) > limit {
+		files = files[:limit]
+	}
+	return files, total
+}
+
+// parseShortlog lê "git shortlog -sne" ("  42\tNome <email>").
+func parseShortlog(out string, limit int) ([]ContributorDTO, int) {
+	contributors := make([]ContributorDTO, 0)
+	for _, line := range strings.Split(out, "\n") {
+		countRaw, identity, found := strings.Cut(strings.TrimSpace(line), "\t")
+		if !found {
+			continue
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(countRaw))
+		if err != nil {
+			continue
+		}
+		contributor := ContributorDTO{Name: strings.TrimSpace(identity), Commits: count}
+		if open := strings.LastIndex(identity, " <"); open >= 0 && strings.HasSuffix(identity, ">") {
+			contributor.Name = strings.TrimSpace(identity[:open])
+			contributor.Email = identity[open+2 : len(identity)-1]
+		}
+		contributors = append(contributors, contributor)
+	}
+
+	total := len(contributors)
+	if len(contributors) > limit {
+		contributors = contributors[:limit]
+	}
+	return contributors, total
+}
+
+// applyCommitHistograms agrupa timestamps de autoria por semana (últimas 52), dia da semana e hora.
+func applyCommitHistograms(stats *RepoStatsDTO, out string, now time.Time) {
+	currentWeek := startOfWeek(now)
+	weeks := make([]CommitBucketDTO, commitHistogramWeeks)
+	for idx := range weeks {
+		weeks[idx].Start = currentWeek.AddDate(0, 0, -7*(commitHistogramWeeks-1-idx)).Format(commitHistogramLayout)
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		unix, err := strconv.ParseInt(strings.TrimSpace(line), 10, 64)
+		if err != nil {
+			continue
+		}
+		authoredAt := time.Unix(unix, 0).In(now.Location())
+		stats.CommitsByWeekday[int(authoredAt.Weekday())]++
+		stats.CommitsByHour[authoredAt.Hour()]++
+
+		// Arredonda para tolerar semanas de 167/169h em transições de horário de verão.
+		weeksAgo := int(math.Round(currentWeek.Sub(startOfWeek(authoredAt)).Hours() / (24 * 7)))
+		if weeksAgo >= 0 && weeksAgo < commitHistogramWeeks {
+			weeks[commitHistogramWeeks-1-weeksAgo].Count++
+		}
+	}
+	stats.CommitsByWeek = weeks
+}
+
+// startOfWeek retorna a segunda-feira 00:00 da semana do instante.
+func startOfWeek(value time.Time) time.Time {
+	offset := (int(value.Weekday()) + 6) % 7
+	day := time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, value.Location())
+	return day.AddDate(0, 0, -offset)
+}
+
+func (s *Service) getCachedRepoStats(cacheKey string) (RepoStatsDTO, bool) {
+	s.cacheMu.RLock()
+	entry, ok := s.repoStatsCache[cacheKey]
+	s.cacheMu.RUnlock()
+	if !ok || time.Now().After(entry.expiresAt) {
+		return RepoStatsDTO{}, false
+	}
+	return entry.value, true
+}
+
+func (s *Service) setCachedRepoStats(cacheKey string, value RepoStatsDTO) {
+	s.cacheMu.Lock()
+	s.repoStatsCache[cacheKey] = repoStatsCacheEntry{
+		value:     value,
+		expiresAt: time.Now().Add(repoStatsCacheTTL),
+	}
+	s.cacheMu.Unlock()
+}
@@ -0,0 +1,149 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRepoStatsOutputs(t *testing.T) {
+	size, objects := parseCountObjects("count: 3\nsize: 12\nin-pack: 40\npacks: 1\nsize-pack: 100\nprune-packable: 0\n")
+	if size != 112*1024 || objects != 43 {
+		t.Fatalf("unexpected count-objects parse: size=%d objects=%d", size, objects)
+	}
+
+	tree := "100644 blob aaa     120\tsmall.txt\x00100644 blob bbb   90000\tassets/logo.png\x00160000 commit ccc       -\tvendor/sub\x00"
+	files, total := parseLargestFiles(tree, 1)
+	if total != 2 || len(files) != 1 || files[0].Path != "assets/logo.png" || files[0].SizeBytes != 90000 {
+		t.Fatalf("unexpected largest files: total=%d files=%+v", total, files)
+	}
+
+	contributors, count := parseShortlog("    10\tAna Dev <ana@example.com>\n     2\tBot\n", 5)
+	if count != 2 || contributors[0].Name != "Ana Dev" || contributors[0].Email != "ana@example.com" || contributors[1].Name != "Bot" {
+		t.Fatalf("unexpected contributors: %+v", contributors)
+	}
+}
+
+func TestApplyRefStatsFlagsStaleBranches(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, 0, -120).Unix()
+	recent := now.AddDate(0, 0, -3).Unix()
+	refs := strings.Join([]string{
+		"refs/heads/main\t" + formatUnix(recent) + "\tAna",
+		"refs/heads/legacy\t" + formatUnix(old) + "\tBia",
+		"refs/remotes/origin/HEAD\t" + formatUnix(recent) + "\tAna",
+		"refs/remotes/origin/main\t" + formatUnix(recent) + "\tAna",
+		"refs/tags/v1.0.0\t\t",
+	}, "\n")
+
+	stats := RepoStatsDTO{StaleDays: 90, StaleBranches: []StaleBranchDTO{}}
+	applyRefStats(&stats, refs, now)
+	if stats.BranchCount != 2 || stats.RemoteBranchCount != 1 || stats.TagCount != 1 {
+		t.Fatalf("unexpected ref counts: %+v", stats)
+	}
+	if len(stats.StaleBranches) != 1 || stats.StaleBranches[0].Name != "legacy" || stats.StaleBranches[0].DaysInactive != 120 {
+		t.Fatalf("unexpected stale branches: %+v", stats.StaleBranches)
+	}
+}
+
+func TestGetRepoStatsCachesUntilRefresh(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	stats, err := svc.GetRepoStats(repoRoot, 0, false)
+	if err != nil {
+		t.Fatalf("GetRepoStats returned error: %v", err)
+	}
+	if stats.TotalCommits != 1 || stats.BranchCount != 1 || stats.ContributorCount != 1 || stats.TrackedFiles != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if len(stats.CommitsByWeek) != commitHistogramWeeks || stats.CommitsByWeek[commitHistogramWeeks-1].Count != 1 {
+		t.Fatalf("expected initial commit in current week: %+v", stats.CommitsByWeek)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoRoot, "NOTES.md"), []byte("notes\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "add", "NOTES.md")
+	runGitOrFail(t, repoRoot, "commit", "-m", "docs: notes")
+
+	cached, err := svc.GetRepoStats(repoRoot, 0, false)
+	if err != nil || cached.TotalCommits != 1 {
+		t.Fatalf("expected cached stats, got %+v err=%v", cached, err)
+	}
+	refreshed, err := svc.GetRepoStats(repoRoot, 0, true)
+	if err != nil || refreshed.TotalCommits != 2 || refreshed.TrackedFiles != 2 {
+		t.Fatalf("expected refreshed stats, got %+v err=%v", refreshed, err)
+	}
+}
+
+func TestParseContributorLogAggregatesByEmail(t *testing.T) {
+	out := strings.Join([]string{
+		"\x1eaaa\x1fAna Dev\x1fana@example.com\x1f2026-03-10T23:30:00-03:00\n\n3\t1\tmain.go\n-\t-\tlogo.png\n",
+		"\x1ebbb\x1fBia\x1fbia@example.com\x1f2026-03-10T10:00:00+00:00\n\n10\t0\tREADME.md\n",
+		"\x1eccc\x1fAna D.\x1fANA@example.com\x1f2026-03-09T09:00:00-03:00\n\n1\t1\tmain.go\n",
+		"\x1eddd\x1fAna Dev\x1fana@example.com\x1f2026-03-09T15:00:00-03:00\n",
+	}, "")
+
+	items, commits := parseContributorLog(out)
+	if commits != 4 || len(items) != 2 {
+		t.Fatalf("unexpected aggregation: commits=%d items=%+v", commits, items)
+	}
+	ana := items[0]
+	if ana.Name != "Ana Dev" || ana.Commits != 3 || ana.Additions != 4 || ana.Deletions != 2 || ana.ActiveDays != 2 {
+		t.Fatalf("unexpected ana stats: %+v", ana)
+	}
+	if ana.LastCommitHash != "aaa" || ana.LastCommitAt != "2026-03-10T23:30:00-03:00" || ana.FirstCommitAt != "2026-03-09T15:00:00-03:00" {
+		t.Fatalf("unexpected ana range: %+v", ana)
+	}
+	if items[1].Email != "bia@example.com" || items[1].ActiveDays != 1 || items[1].Additions != 10 {
+		t.Fatalf("unexpected bia stats: %+v", items[1])
+	}
+}
+
+func TestGetContributorStatsPaginatesAndCaches(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	if err := os.WriteFile(filepath.Join(repoRoot, "other.txt"), []byte("a\nb\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "add", "other.txt")
+	runGitOrFail(t, repoRoot, "commit", "--author", "Bia <bia@orch.local>", "-m", "other")
+
+	first, err := svc.GetContributorStats(repoRoot, 30, "", 1, false)
+	if err != nil {
+		t.Fatalf("GetContributorStats returned error: %v", err)
+	}
+	if first.TotalContributors != 2 || first.TotalCommits != 2 || len(first.Items) != 1 || !first.HasMore || first.NextCursor != "1" {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+	second, err := svc.GetContributorStats(repoRoot, 30, first.NextCursor, 1, false)
+	if err != nil || len(second.Items) != 1 || second.HasMore || second.Items[0].Email == first.Items[0].Email {
+		t.Fatalf("unexpected second page: %+v err=%v", second, err)
+	}
+
+	runGitOrFail(t, repoRoot, "commit", "--allow-empty", "-m", "empty")
+	cached, err := svc.GetContributorStats(repoRoot, 30, "", 10, false)
+	if err != nil || cached.TotalCommits != 2 {
+		t.Fatalf("expected cached stats, got %+v err=%v", cached, err)
+	}
+	refreshed, err := svc.GetContributorStats(repoRoot, 30, "", 10, true)
+	if err != nil || refreshed.TotalCommits != 3 || refreshed.Items[0].Email != "tests@orch.local" || refreshed.Items[0].Commits != 2 {
+		t.Fatalf("expected refreshed stats, got %+v err=%v", refreshed, err)
+	}
+
+	if _, err := svc.GetContributorStats(repoRoot, 30, "abc", 10, false); err == nil {
+		t.Fatalf("expected invalid cursor to fail")
+	}
+}
+
+func formatUnix(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
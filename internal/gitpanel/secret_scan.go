@@ -0,0 +1,162 @@
+package gitpanel
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxSecretFindings      = 200
+	maxSecretPreviewLength = 200
+)
+
+// ScanStagedSecrets procura segredos nas linhas adicionadas do stage (filePath vazio = stage inteiro).
+func (s *Service) ScanStagedSecrets(repoPath string, filePath string) (SecretScanResultDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return SecretScanResultDTO{}, err
+	}
+
+	cleanPath := ""
+	if strings.TrimSpace(filePath) != "" {
+		cleanPath, err = ensurePathWithinRepo(preflight.RepoRoot, filePath)
+		if err != nil {
+			return SecretScanResultDTO{}, err
+		}
+	}
+	return s.scanStagedSecrets(context.Background(), preflight.RepoRoot, cleanPath, nil)
+}
+
+func (s *Service) scanStagedSecrets(ctx context.Context, repoRoot string, cleanPath string, overrides []string) (SecretScanResultDTO, error) {
+	args := []string{"-C", repoRoot, "diff", "--cached", "--no-color", "--no-ext-diff", "-U0"}
+	if cleanPath != "" {
+		args = append(args, "--", cleanPath)
+	}
+	out, errOut, exitCode, runErr := s.runGit(ctx, defaultReadTimeout, "", args...)
+	if isCanceledBindingError(runErr) {
+		return SecretScanResultDTO{}, runErr
+	}
+	if runErr != nil {
+		return SecretScanResultDTO{}, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao ler diff do stage para varredura de segredos.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+
+	result := s.scanDiffForSecrets(out, overrides)
+	result.RepoPath = repoRoot
+	return result, nil
+}
+
+// scanDiffForSecrets varre as linhas "+" de um diff unificado (-U0).
+func (s *Service) scanDiffForSecrets(diffText string, overrides []string) SecretScanResultDTO {
+	overridden := make(map[string]struct{}, len(overrides))
+	for _, id := range overrides {
+		overridden[strings.TrimSpace(id)] = struct{}{}
+	}
+
+	result := SecretScanResultDTO{Findings: []SecretFindingDTO{}}
+	currentFile := ""
+	newLine := 0
+	files := make(map[string]struct{})
+	previous := ""
+
+	for _, line := range strings.Split(diffText, "\n") {
+		isFileHeader := strings.HasPrefix(line, "+++ ") && strings.HasPrefix(previous, "--- ")
+		previous = line
+		switch {
+		case isFileHeader:
+			currentFile = ""
+			if target := strings.TrimPrefix(line, "+++ "); target != "/dev/null" {
+				currentFile = strings.TrimPrefix(target, "b/")
+				files[currentFile] = struct{}{}
+			}
+			continue
+		case strings.HasPrefix(line, "@@"):
+			newLine = parseHunkNewStart(line)
+			continue
+		case strings.HasPrefix(line, "+"):
+		default:
+			continue
+		}
+		if currentFile == "" {
+			continue
+		}
+
+		content := strings.TrimPrefix(line, "+")
+		for _, match := range s.secrets.ScanLine(content) {
+			if len(result.Findings) >= maxSecretFindings {
+				result.Truncated = true
+				break
+			}
+			finding := SecretFindingDTO{
+				ID:          secretFindingID(currentFile, match.RuleID, match.Secret),
+				RuleID:      match.RuleID,
+				Description: match.Description,
+				FilePath:    currentFile,
+				Line:        newLine,
+				Column:      match.Start + 1,
+				Preview:     maskSecretPreview(content, match.Start, match.End),
+			}
+			if _, ok := overridden[finding.ID]; ok {
+				finding.Overridden = true
+			} else {
+				result.Blocking++
+			}
+			result.Findings = append(result.Findings, finding)
+		}
+		newLine++
+	}
+
+	result.ScannedFiles = len(files)
+	return result
+}
+
+// parseHunkNewStart extrai o início do lado novo de "@@ -a,b +c,d @@".
+func parseHunkNewStart(header string) int {
+	plus := strings.Index(header, " +")
+	if plus < 0 {
+		return 0
+	}
+	rest := header[plus+2:]
+	if end := strings.IndexAny(rest, ", "); end >= 0 {
+		rest = rest[:end]
+	}
+	start, err := strconv.Atoi(rest)
+	if err != nil {
+		return 0
+	}
+	return start
+}
+
+// secretFindingID é estável entre deslocamentos de linha: depende de arquivo, regra e valor.
+func secretFindingID(filePath string, ruleID string, secret string) string {
+	sum := sha256.Sum256([]byte(filePath + "\x1f" + ruleID + "\x1f" + secret))
+	return hex.EncodeToString(sum[:8])
+}
+
+func maskSecretPreview(line string, start int, end int) string {
+	visible := end
+	if end-start > 4 {
+		visible = start + 4
+	}
+	masked := line[:visible] + strings.Repeat("*", 8) + line[end:]
+	masked = strings.TrimSpace(masked)
+	if runes := []rune(masked); len(runes) > maxSecretPreviewLength {
+		masked = string(runes[:maxSecretPreviewLength]) + "…"
+	}
+	return masked
+}
+
+// emitStagedSecretFindings avisa a UI quando o stage recém-alterado contém segredos.
+func (s *Service) emitStagedSecretFindings(repoRoot string, cleanPath string) {
+	result, err := s.scanStagedSecrets(context.Background(), repoRoot, cleanPath, nil)
+	if err != nil || len(result.Findings) == 0 {
+		return
+	}
+	s.emit("gitpanel:secrets_detected", result)
+}
@@ -1,6 +1,7 @@
 package gitpanel
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -14,6 +15,8 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"orch/internal/security"
 )
 
 type EventEmitter func(eventName string, data interface{})
@@ -22,6 +25,9 @@ const (
 	defaultReadTimeout  = 8 * time.Second
 	defaultWriteTimeout = 12 * time.Second
 	externalToolTimeout = 5 * time.Minute
+	// gitWaitDelay limita a espera por filhos do git (remote helpers, ssh) que herdaram os pipes
+	// depois que o processo principal foi morto por cancelamento ou timeout.
+	gitWaitDelay        = 2 * time.Second
 	maxHistoryLimit     = 500
 	historyFallbackMax  = 80
 	defaultHistoryLimit = 200
@@ -71,6 +77,7 @@ type Service struct {
 	emit       EventEmitter
 	runGit     gitRunner
 	sleep      backoffSleeper
+	secrets    *security.SecretScanner
 	commandSeq uint64
 
 	queueMu        sync.Mutex
@@ -80,11 +87,42 @@ type Service struct {
 	shutdownCancel context.CancelFunc
 	closed         atomic.Bool
 
+	// Backend de leitura (CLI ou go-git) por repositório; ver read_backend.go.
+	readBackendMu      sync.RWMutex
+	defaultReadBackend string
+	repoReadBackends   map[string]string // raiz do repo -> override
+	readFallbacks      map[string]string // raiz do repo -> último motivo de fallback para o CLI
+	readObserver       func(ReadSample)
+
+	// Último status calculado por repositório, base dos deltas de gitpanel:status_changed.
+	snapshotMu      sync.Mutex
+	statusSeq       uint64
+	statusSnapshots map[string]statusSnapshot
+
+	// Pool de processos git e limite de writes aguardando por repositório (ver exec_pool.go).
+	execPool        *execPool
+	execMetrics     *execMetrics
+	writeQueueDepth atomic.Int32
+
 	cacheMu        sync.RWMutex
 	preflightCache map[string]preflightCacheEntry
 	statusCache    map[string]statusCacheEntry
 	historyCache   map[string]historyCacheEntry
 	diffCache      map[string]diffCacheEntry
+	repoStatsCache map[string]repoStatsCacheEntry
+
+	contributorStatsCache map[string]contributorStatsCacheEntry
+
+	historyCountMu sync.Mutex
+	historyCounts  map[string]*historyCountEntry // raiz\x1fescopo -> contagem de commits do HEAD
+
+	defaultTTLs CacheTTLsDTO            // protegido por cacheMu; zerado usa os TTLs embutidos
+	repoTTLs    map[string]CacheTTLsDTO // raiz do repo -> override de TTL
+	metrics     *cacheMetrics
+
+	// Ferramentas externas de diff/merge (substituíveis nos testes).
+	lookupTool func(binary string) (string, bool)
+	startTool  toolStarter
 }
 
 func NewService(emit EventEmitter) *Service {
@@ -103,10 +141,10 @@ func newServiceWithDeps(emit EventEmitter, runner gitRunner, sleeper backoffSlee
 	}
 
 	shutdownCtx, shutdownCancel := context.WithCancel(context.Background())
-	return &Service{
+	s := &Service{
 		emit:           emit,
-		runGit:         runner,
 		sleep:          sleeper,
+		secrets:        security.NewSecretScanner(),
 		queues:         make(map[string]*repoCommandQueue),
 		shutdownCtx:    shutdownCtx,
 		shutdownCancel: shutdownCancel,
@@ -114,10 +152,32 @@ func newServiceWithDeps(emit EventEmitter, runner gitRunner, sleeper backoffSlee
 		statusCache:    make(map[string]statusCacheEntry),
 		historyCache:   make(map[string]historyCacheEntry),
 		diffCache:      make(map[string]diffCacheEntry),
-	}
+		repoStatsCache: make(map[string]repoStatsCacheEntry),
+		historyCounts:  make(map[string]*historyCountEntry),
+		lookupTool:     lookupToolInPath,
+		startTool:      startToolProcess,
+
+		contributorStatsCache: make(map[string]contributorStatsCacheEntry),
+		repoTTLs:              make(map[string]CacheTTLsDTO),
+		metrics:               newCacheMetrics(),
+		execPool:              newExecPool(DefaultExecWorkers),
+		execMetrics:           newExecMetrics(),
+		defaultReadBackend:    ReadBackendCLI,
+		repoReadBackends:      make(map[string]string),
+		readFallbacks:         make(map[string]string),
+		statusSnapshots:       make(map[string]statusSnapshot),
+	}
+	s.writeQueueDepth.Store(DefaultWriteQueueDepth)
+	s.runGit = s.pooled(runner)
+	return s
 }
 
 func (s *Service) Preflight(repoPath string) (PreflightResult, error) {
+	return s.preflightContext(context.Background(), repoPath)
+}
+
+// preflightContext é Preflight com os comandos git canceláveis pelo chamador.
+func (s *Service) preflightContext(ctx context.Context, repoPath string) (PreflightResult, error) {
 	result := PreflightResult{}
 
 	if _, err := exec.LookPath("git"); err != nil {
@@ -168,7 +228,10 @@ func (s *Service) Preflight(repoPath string) (PreflightResult, error) {
 		)
 	}
 
-	rootOut, rootErrOut, rootExitCode, rootErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", absRepoPath, "rev-parse", "--show-toplevel")
+	rootOut, rootErrOut, rootExitCode, rootErr := s.runGit(ctx, defaultReadTimeout, "", "-C", absRepoPath, "rev-parse", "--show-toplevel")
+	if isCanceledBindingError(rootErr) {
+		return result, rootErr
+	}
 	if rootErr != nil {
 		return result, NewBindingError(
 			CodeRepoNotGit,
@@ -185,7 +248,10 @@ func (s *Service) Preflight(repoPath string) (PreflightResult, error) {
 		)
 	}
 
-	branchOut, branchErrOut, branchExitCode, branchErr := s.runGit(context.Background(), defaultReadTimeout, "", "-C", repoRoot, "rev-parse", "--abbrev-ref", "HEAD")
+	branchOut, branchErrOut, branchExitCode, branchErr := s.runGit(ctx, defaultReadTimeout, "", "-C", repoRoot, "rev-parse", "--abbrev-ref", "HEAD")
+	if isCanceledBindingError(branchErr) {
+		return result, branchErr
+	}
 	branch := strings.TrimSpace(branchOut)
 	if branchErr != nil {
 		branch = ""
@@ -200,7 +266,7 @@ func (s *Service) Preflight(repoPath string) (PreflightResult, error) {
 	result.Branch = branch
 
 	// Detect active merge by checking .git/MERGE_HEAD
-	mergeHeadPath := filepath.Join(repoRoot, ".git", "MERGE_HEAD")
+	mergeHeadPath := filepath.Join(resolveRepoGitDir(repoRoot), "MERGE_HEAD")
 	if _, mergeErr := os.Stat(mergeHeadPath); mergeErr == nil {
 		result.MergeActive = true
 	}
@@ -214,7 +280,11 @@ func (s *Service) Preflight(repoPath string) (PreflightResult, error) {
 }
 
 func (s *Service) GetStatus(repoPath string) (StatusDTO, error) {
-	preflight, err := s.Preflight(repoPath)
+	return s.getStatusContext(context.Background(), repoPath)
+}
+
+func (s *Service) getStatusContext(ctx context.Context, repoPath string) (StatusDTO, error) {
+	preflight, err := s.preflightContext(ctx, repoPath)
 	if err != nil {
 		return StatusDTO{}, err
 	}
@@ -223,22 +293,9 @@ func (s *Service) GetStatus(repoPath string) (StatusDTO, error) {
 		return cached, nil
 	}
 
-	out, errOut, exitCode, runErr := s.runGit(
-		context.Background(),
-		defaultReadTimeout,
-		"",
-		"-C", preflight.RepoRoot,
-		"status",
-		"--porcelain=v1",
-		"-z",
-		"--branch",
-	)
+	out, _, runErr := s.readStatusPorcelain(ctx, preflight.RepoRoot, s.readBackendFor(preflight.RepoRoot))
 	if runErr != nil {
-		return StatusDTO{}, NewBindingError(
-			CodeCommandFailed,
-			"Falha ao obter status do repositório.",
-			formatCommandFailureDetails(errOut, exitCode, runErr),
-		)
+		return StatusDTO{}, runErr
 	}
 
 	status := parsePorcelainStatus(out)
@@ -249,24 +306,43 @@ func (s *Service) GetStatus(repoPath string) (StatusDTO, error) {
 	} else if strings.TrimSpace(status.Branch) == "" {
 		status.Branch = canonicalBranch
 	}
+	if strings.EqualFold(canonicalBranch, "HEAD") {
+		status.Detached = true
+	}
+	if status.Detached {
+		if headOut, _, _, headErr := s.runGit(ctx, defaultReadTimeout, "", "-C", preflight.RepoRoot, "rev-parse", "--short", "HEAD"); headErr == nil {
+			status.HeadCommit = strings.TrimSpace(headOut)
+		}
+	}
+	status.Operation = detectInProgressOperation(resolveRepoGitDir(preflight.RepoRoot))
+	status = s.recordStatusSnapshot(preflight.RepoRoot, status)
 	s.setCachedStatus(preflight.RepoRoot, status)
 	return status, nil
 }
 
 func (s *Service) GetHistory(repoPath string, cursor string, limit int, search string) (HistoryPageDTO, error) {
+	return s.GetHistoryInScope(repoPath, cursor, limit, search, "")
+}
+
+// GetHistoryInScope retorna o histórico limitado a commits que tocam o subdiretório informado.
+func (s *Service) GetHistoryInScope(repoPath string, cursor string, limit int, search string, scope string) (HistoryPageDTO, error) {
+	return s.getHistory(repoPath, cursor, limit, search, scope, DetailFull)
+}
+
+func (s *Service) getHistory(repoPath string, cursor string, limit int, search string, scope string, detail string) (HistoryPageDTO, error) {
 	preflight, err := s.Preflight(repoPath)
 	if err != nil {
 		return HistoryPageDTO{}, err
 	}
+	pathScope, scopeErr := NormalizePathScope(preflight.RepoRoot, scope)
+	if scopeErr != nil {
+		return HistoryPageDTO{}, scopeErr
+	}
 
 	cursorHash, cursorErr := parseHistoryCursor(cursor)
 	if cursorErr != nil {
 		return HistoryPageDTO{}, cursorErr
 	}
-	skipCount, skipErr := s.resolveHistorySkip(preflight.RepoRoot, cursorHash, search)
-	if skipErr != nil {
-		return HistoryPageDTO{}, skipErr
-	}
 
 	if limit <= 0 {
 		limit = defaultHistoryLimit
@@ -274,57 +350,65 @@ func (s *Service) GetHistory(repoPath string, cursor string, limit int, search s
 	if limit > maxHistoryLimit {
 		limit = maxHistoryLimit
 	}
-	cacheKey := buildHistoryCacheKey(preflight.RepoRoot, cursorHash, limit, search)
-	if cached, ok := s.getCachedHistory(cacheKey); ok {
-		return cached, nil
+	countSearch := strings.TrimSpace(search) == ""
+	if cursorHash == "" && countSearch {
+		s.precomputeHistoryCount(preflight.RepoRoot, "")
+		if pathScope != "" {
+			s.precomputeHistoryCount(preflight.RepoRoot, pathScope)
+		}
 	}
-
-	pageSize := limit + 1
-	format := "%H%x1f%h%x1f%an%x1f%aI%x1f%ae%x1f%s%x1e"
-	buildLogArgs := func(pageLimit int) []string {
-		args := []string{
-			"-C", preflight.RepoRoot,
-			"log",
-			"--date=iso-strict",
-			"--pretty=format:" + format,
-			"--numstat",
+	withEstimate := func(page HistoryPageDTO) HistoryPageDTO {
+		if countSearch {
+			if total, ok := s.cachedHistoryCount(preflight.RepoRoot, pathScope); ok {
+				page.EstimatedTotal = total
+			}
 		}
+		return page
+	}
 
-		trimmedSearch := strings.TrimSpace(search)
-		if trimmedSearch != "" {
-			args = append(args, "--grep="+trimmedSearch, "-i")
+	withStats := detail != DetailSummary
+	cacheKey := buildHistoryCacheKey(preflight.RepoRoot, cursorHash, limit, search)
+	if pathScope != "" {
+		cacheKey += "\x1f" + pathScope
+	}
+	if !withStats {
+		cacheKey += "\x1f" + DetailSummary
+	}
+	if cached, ok := s.getCachedHistory(cacheKey); ok {
+		return withEstimate(cached), nil
+	}
+
+	var items []HistoryItemDTO
+	read := ReadSample{RepoRoot: preflight.RepoRoot, Operation: ReadOperationHistory, Backend: s.readBackendFor(preflight.RepoRoot)}
+	started := time.Now()
+	served := false
+	if read.Backend == ReadBackendGoGit {
+		items, read.Fallback = readGoGitHistory(preflight.RepoRoot, cursorHash, limit, search, pathScope, withStats)
+		served = read.Fallback == ""
+		if !served {
+			read.Backend = ReadBackendCLI
 		}
-
-		args = append(args,
-			fmt.Sprintf("--skip=%d", skipCount),
-			"-n", strconv.Itoa(pageLimit),
-		)
-		return args
 	}
-
-	args := buildLogArgs(pageSize)
-
-	out, errOut, exitCode, runErr := s.runGit(context.Background(), defaultReadTimeout, "", args...)
-	if runErr != nil && isTimeoutBindingError(runErr) && limit > historyFallbackMax {
-		fallbackLimit := historyFallbackMax
-		if fallbackLimit > limit {
-			fallbackLimit = limit
+	keyset := served
+	if !keyset && cursorHash != "" && s.useKeysetHistory(preflight.RepoRoot) {
+		keysetItems, ok, keysetErr := s.keysetHistoryPage(preflight.RepoRoot, cursorHash, limit, search, pathScope, withStats)
+		if keysetErr != nil {
+			return HistoryPageDTO{}, keysetErr
 		}
-		fallbackPageSize := fallbackLimit + 1
-		out, errOut, exitCode, runErr = s.runGit(context.Background(), defaultReadTimeout, "", buildLogArgs(fallbackPageSize)...)
-		if runErr == nil {
-			limit = fallbackLimit
+		items, keyset = keysetItems, ok
+	}
+	if !keyset {
+		skipItems, skipLimit, skipErr := s.skipHistoryPage(preflight.RepoRoot, cursorHash, limit, search, pathScope, withStats)
+		read.Duration, read.Err = time.Since(started), skipErr
+		if skipErr != nil {
+			s.observeRead(read)
+			return HistoryPageDTO{}, skipErr
 		}
+		items, limit = skipItems, skipLimit
 	}
-	if runErr != nil {
-		return HistoryPageDTO{}, NewBindingError(
-			CodeCommandFailed,
-			"Falha ao obter histórico do repositório.",
-			formatCommandFailureDetails(errOut, exitCode, runErr),
-		)
-	}
+	read.Duration = time.Since(started)
+	s.observeRead(read)
 
-	items := parseHistoryItems(out)
 	hasMore := len(items) > limit
 	if hasMore {
 		items = items[:limit]
@@ -339,9 +423,47 @@ func (s *Service) GetHistory(repoPath string, cursor string, limit int, search s
 		Items:      items,
 		NextCursor: nextCursor,
 		HasMore:    hasMore,
+		PathScope:  pathScope,
+		Detail:     detail,
+	}
+	if withStats {
+		page.Warning = s.partialCloneWarning(preflight.RepoRoot)
 	}
 	s.setCachedHistory(cacheKey, page)
-	return page, nil
+	return withEstimate(page), nil
+}
+
+// skipHistoryPage pagina com --skip a partir da posição do cursor; retorna o limite efetivo
+// (reduzido quando a página cheia excede o tempo limite).
+func (s *Service) skipHistoryPage(repoRoot string, cursorHash string, limit int, search string, pathScope string, withStats bool) ([]HistoryItemDTO, int, error) {
+	skipCount, skipErr := s.resolveHistorySkip(repoRoot, cursorHash, search, pathScope)
+	if skipErr != nil {
+		return nil, 0, skipErr
+	}
+
+	buildLogArgs := func(pageLimit int) []string {
+		return historyLogArgs(repoRoot, search, pathScope, withStats,
+			fmt.Sprintf("--skip=%d", skipCount),
+			"-n", strconv.Itoa(pageLimit),
+		)
+	}
+
+	out, errOut, exitCode, runErr := s.runGit(context.Background(), defaultReadTimeout, "", buildLogArgs(limit+1)...)
+	if runErr != nil && isTimeoutBindingError(runErr) && limit > historyFallbackMax {
+		fallbackLimit := historyFallbackMax
+		out, errOut, exitCode, runErr = s.runGit(context.Background(), defaultReadTimeout, "", buildLogArgs(fallbackLimit+1)...)
+		if runErr == nil {
+			limit = fallbackLimit
+		}
+	}
+	if runErr != nil {
+		return nil, 0, NewBindingError(
+			CodeCommandFailed,
+			"Falha ao obter histórico do repositório.",
+			formatCommandFailureDetails(errOut, exitCode, runErr),
+		)
+	}
+	return parseHistoryItems(out), limit, nil
 }
 
 func (s *Service) GetCommitDetails(repoPath string, commitHash string) (CommitDetailsDTO, error) {
@@ -431,7 +553,16 @@ func (s *Service) GetCommitDiff(repoPath string, filePath string, commitHash str
 		)
 	}
 
+	result := buildDiffDTO("unified", cleanFilePath, out)
+	s.attachBinaryDiffs(context.Background(), preflight.RepoRoot, &result, revBlobSide(commitHash+"^"), revBlobSide(commitHash))
+	s.setCachedDiff(cacheKey, result)
+	return result, nil
+}
+
+// buildDiffDTO monta o DiffDTO a partir da saída bruta do git diff/show.
+func buildDiffDTO(mode string, filePath string, out string) DiffDTO {
 	files := parseDiffFiles(out)
+	annotateHunkContexts(files)
 	isBinary := strings.Contains(out, "Binary files ") || strings.Contains(out, "GIT binary patch")
 	if !isBinary {
 		for _, file := range files {
@@ -449,16 +580,14 @@ func (s *Service) GetCommitDiff(repoPath string, filePath string, commitHash str
 		isTruncated = true
 	}
 
-	result := DiffDTO{
-		Mode:        "unified",
-		FilePath:    cleanFilePath,
+	return DiffDTO{
+		Mode:        mode,
+		FilePath:    filePath,
 		Raw:         raw,
 		Files:       files,
 		IsBinary:    isBinary,
 		IsTruncated: isTruncated,
 	}
-	s.setCachedDiff(cacheKey, result)
-	return result, nil
 }
 
 func parseCommitFiles(raw string) []CommitFileDTO {
@@ -501,10 +630,24 @@ func buildCommitDiffCacheKey(repoRoot string, filePath string, commitHash string
 }
 
 func (s *Service) GetDiff(repoPath string, filePath string, mode string, contextLines int) (DiffDTO, error) {
-	preflight, err := s.Preflight(repoPath)
+	return s.GetDiffInScope(repoPath, filePath, mode, contextLines, "")
+}
+
+// GetDiffInScope retorna o diff; sem arquivo informado, limita o diff completo ao subdiretório.
+func (s *Service) GetDiffInScope(repoPath string, filePath string, mode string, contextLines int, scope string) (DiffDTO, error) {
+	return s.GetDiffInScopeContext(context.Background(), repoPath, filePath, mode, contextLines, scope)
+}
+
+// GetDiffInScopeContext é GetDiffInScope com o git diff cancelável pelo chamador.
+func (s *Service) GetDiffInScopeContext(ctx context.Context, repoPath string, filePath string, mode string, contextLines int, scope string) (DiffDTO, error) {
+	preflight, err := s.preflightContext(ctx, repoPath)
 	if err != nil {
 		return DiffDTO{}, err
 	}
+	pathScope, scopeErr := NormalizePathScope(preflight.RepoRoot, scope)
+	if scopeErr != nil {
+		return DiffDTO{}, scopeErr
+	}
 
 	normalizedMode := strings.ToLower(strings.TrimSpace(mode))
 	if normalizedMode == "" {
@@ -535,8 +678,14 @@ func (s *Service) GetDiff(repoPath string, filePath string, mode string, context
 		}
 		cleanFilePath = pathWithinRepo
 		args = append(args, "--", cleanFilePath)
+	} else if pathScope != "" {
+		args = append(args, "--", pathScope)
+	}
+	cacheTarget := cleanFilePath
+	if cacheTarget == "" && pathScope != "" {
+		cacheTarget = pathScope + "/"
 	}
-	cacheKey := buildDiffCacheKey(preflight.RepoRoot, cleanFilePath, normalizedMode, contextLines)
+	cacheKey := buildDiffCacheKey(preflight.RepoRoot, cacheTarget, normalizedMode, contextLines)
 	if cached, ok := s.getCachedDiff(cacheKey); ok {
 		return cached, nil
 	}
@@ -548,13 +697,16 @@ func (s *Service) GetDiff(repoPath string, filePath string, mode string, context
 		}
 	}
 
-	out, errOut, exitCode, runErr := s.runGit(context.Background(), defaultReadTimeout, "", args...)
+	out, errOut, exitCode, runErr := s.runGit(ctx, defaultReadTimeout, "", args...)
 	if runErr != nil {
 		if isTimeoutBindingError(runErr) {
 			degraded := buildTimeoutDiffFallback(normalizedMode, cleanFilePath)
 			s.setCachedDiff(cacheKey, degraded)
 			return degraded, nil
 		}
+		if isCanceledBindingError(runErr) {
+			return DiffDTO{}, runErr
+		}
 		return DiffDTO{}, NewBindingError(
 			CodeCommandFailed,
 			"Falha ao obter diff do repositório.",
@@ -562,52 +714,72 @@ func (s *Service) GetDiff(repoPath string, filePath string, mode string, context
 		)
 	}
 
-	files := parseDiffFiles(out)
-	isBinary := strings.Contains(out, "Binary files ") || strings.Contains(out, "GIT binary patch")
-	if !isBinary {
-		for _, file := range files {
-			if file.IsBinary {
-				isBinary = true
-				break
-			}
-		}
-	}
-
-	isTruncated := false
-	raw := out
-	if len(out) > maxDiffBytes {
-		raw = out[:maxDiffBytes] + "\n\n... (diff truncado para manter responsividade)"
-		isTruncated = true
+	result := buildDiffDTO(normalizedMode, cleanFilePath, out)
+	if normalizedMode == "staged" {
+		s.attachBinaryDiffs(ctx, preflight.RepoRoot, &result, revBlobSide("HEAD"), indexBlobSide)
+	} else {
+		s.attachBinaryDiffs(ctx, preflight.RepoRoot, &result, indexBlobSide, worktreeBlobSide)
 	}
-
-	result := DiffDTO{
-		Mode:        normalizedMode,
-		FilePath:    cleanFilePath,
-		Raw:         raw,
-		Files:       files,
-		IsBinary:    isBinary,
-		IsTruncated: isTruncated,
+	if err := ctx.Err(); err != nil {
+		// Cancelado durante os binários: o resultado parcial não entra no cache.
+		return DiffDTO{}, queueErrorFromContext(err, "Diff interrompido.")
 	}
 	s.setCachedDiff(cacheKey, result)
 	return result, nil
 }
 
 func (s *Service) GetConflicts(repoPath string) ([]ConflictFileDTO, error) {
-	status, err := s.GetStatus(repoPath)
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	status, err := s.GetStatus(preflight.RepoRoot)
 	if err != nil {
 		return nil, err
 	}
-	return status.Conflicted, nil
+	// Cópia: o status fica em cache e não deve carregar as linhas dos marcadores.
+	conflicts := make([]ConflictFileDTO, len(status.Conflicted))
+	for i, item := range status.Conflicted {
+		item.Line = firstConflictMarkerLine(filepath.Join(preflight.RepoRoot, filepath.FromSlash(item.Path)))
+		conflicts[i] = item
+	}
+	return conflicts, nil
+}
+
+// firstConflictMarkerLine retorna a linha (1-based) do primeiro "<<<<<<<" do arquivo, ou 0.
+func firstConflictMarkerLine(path string) int {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		text, err := reader.ReadString('\n')
+		if strings.HasPrefix(text, "<<<<<<< ") || strings.TrimRight(text, "\r\n") == "<<<<<<<" {
+			return line
+		}
+		if err != nil {
+			return 0
+		}
+	}
 }
 
 func (s *Service) StageFile(repoPath string, filePath string) error {
-	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(repoPath, filePath, "stage_file")
+	return s.StageFileContext(context.Background(), repoPath, filePath)
+}
+
+// StageFileContext é StageFile cancelável pelo chamador, na fila e durante o git.
+func (s *Service) StageFileContext(ctx context.Context, repoPath string, filePath string) error {
+	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(ctx, repoPath, filePath, "stage_file")
 	if err != nil {
 		s.emitCommandFailure(commandID, repoPath, "stage_file", []string{"add", "--", filePath}, startedAt, err)
 		return err
 	}
 
-	if err := s.executeWrite(
+	if err := s.executeWriteContext(
+		ctx,
 		preflight.RepoRoot,
 		commandID,
 		"stage_file",
@@ -639,17 +811,24 @@ func (s *Service) StageFile(repoPath string, filePath string) error {
 	}
 
 	s.emitPostWriteReconciliation(preflight.RepoRoot, "stage_file", false)
+	s.emitStagedSecretFindings(preflight.RepoRoot, cleanPath)
 	return nil
 }
 
 func (s *Service) UnstageFile(repoPath string, filePath string) error {
-	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(repoPath, filePath, "unstage_file")
+	return s.UnstageFileContext(context.Background(), repoPath, filePath)
+}
+
+// UnstageFileContext é UnstageFile cancelável pelo chamador, na fila e durante o git.
+func (s *Service) UnstageFileContext(ctx context.Context, repoPath string, filePath string) error {
+	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(ctx, repoPath, filePath, "unstage_file")
 	if err != nil {
 		s.emitCommandFailure(commandID, repoPath, "unstage_file", []string{"restore", "--staged", "--", filePath}, startedAt, err)
 		return err
 	}
 
-	if err := s.executeWrite(
+	if err := s.executeWriteContext(
+		ctx,
 		preflight.RepoRoot,
 		commandID,
 		"unstage_file",
@@ -686,13 +865,19 @@ func (s *Service) UnstageFile(repoPath string, filePath string) error {
 }
 
 func (s *Service) DiscardFile(repoPath string, filePath string) error {
-	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(repoPath, filePath, "discard_file")
+	return s.DiscardFileContext(context.Background(), repoPath, filePath)
+}
+
+// DiscardFileContext é DiscardFile cancelável pelo chamador, na fila e durante o git.
+func (s *Service) DiscardFileContext(ctx context.Context, repoPath string, filePath string) error {
+	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(ctx, repoPath, filePath, "discard_file")
 	if err != nil {
 		s.emitCommandFailure(commandID, repoPath, "discard_file", []string{"checkout", "--", filePath}, startedAt, err)
 		return err
 	}
 
-	if err := s.executeWrite(
+	if err := s.executeWriteContext(
+		ctx,
 		preflight.RepoRoot,
 		commandID,
 		"discard_file",
@@ -728,6 +913,11 @@ func (s *Service) DiscardFile(repoPath string, filePath string) error {
 }
 
 func (s *Service) StagePatch(repoPath string, patchText string) error {
+	return s.StagePatchContext(context.Background(), repoPath, patchText)
+}
+
+// StagePatchContext é StagePatch cancelável pelo chamador, na fila e durante o git.
+func (s *Service) StagePatchContext(ctx context.Context, repoPath string, patchText string) error {
 	commandID, startedAt := s.beginCommand("stage_patch")
 
 	trimmedPatch := strings.TrimSpace(patchText)
@@ -741,7 +931,7 @@ func (s *Service) StagePatch(repoPath string, patchText string) error {
 		return err
 	}
 
-	preflight, err := s.Preflight(repoPath)
+	preflight, err := s.preflightContext(ctx, repoPath)
 	if err != nil {
 		s.emitCommandFailure(commandID, repoPath, "stage_patch", []string{"apply", "--cached", "--unidiff-zero", "--whitespace=nowarn", "-"}, startedAt, err)
 		return err
@@ -751,7 +941,8 @@ func (s *Service) StagePatch(repoPath string, patchText string) error {
 		return validationErr
 	}
 
-	if err := s.executeWrite(
+	if err := s.executeWriteContext(
+		ctx,
 		preflight.RepoRoot,
 		commandID,
 		"stage_patch",
@@ -785,10 +976,16 @@ func (s *Service) StagePatch(repoPath string, patchText string) error {
 	}
 
 	s.emitPostWriteReconciliation(preflight.RepoRoot, "stage_patch", false)
+	s.emitStagedSecretFindings(preflight.RepoRoot, "")
 	return nil
 }
 
 func (s *Service) UnstagePatch(repoPath string, patchText string) error {
+	return s.UnstagePatchContext(context.Background(), repoPath, patchText)
+}
+
+// UnstagePatchContext é UnstagePatch cancelável pelo chamador, na fila e durante o git.
+func (s *Service) UnstagePatchContext(ctx context.Context, repoPath string, patchText string) error {
 	commandID, startedAt := s.beginCommand("unstage_patch")
 
 	trimmedPatch := strings.TrimSpace(patchText)
@@ -802,7 +999,7 @@ func (s *Service) UnstagePatch(repoPath string, patchText string) error {
 		return err
 	}
 
-	preflight, err := s.Preflight(repoPath)
+	preflight, err := s.preflightContext(ctx, repoPath)
 	if err != nil {
 		s.emitCommandFailure(commandID, repoPath, "unstage_patch", []string{"apply", "--cached", "--reverse", "--unidiff-zero", "--whitespace=nowarn", "-"}, startedAt, err)
 		return err
@@ -812,7 +1009,8 @@ func (s *Service) UnstagePatch(repoPath string, patchText string) error {
 		return validationErr
 	}
 
-	if err := s.executeWrite(
+	if err := s.executeWriteContext(
+		ctx,
 		preflight.RepoRoot,
 		commandID,
 		"unstage_patch",
@@ -851,7 +1049,7 @@ func (s *Service) UnstagePatch(repoPath string, patchText string) error {
 }
 
 func (s *Service) AcceptOurs(repoPath string, filePath string, autoStage bool) error {
-	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(repoPath, filePath, "accept_ours")
+	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(context.Background(), repoPath, filePath, "accept_ours")
 	if err != nil {
 		s.emitCommandFailure(commandID, repoPath, "accept_ours", []string{"checkout", "--ours", "--", filePath}, startedAt, err)
 		return err
@@ -917,7 +1115,7 @@ func (s *Service) AcceptOurs(repoPath string, filePath string, autoStage bool) e
 }
 
 func (s *Service) AcceptTheirs(repoPath string, filePath string, autoStage bool) error {
-	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(repoPath, filePath, "accept_theirs")
+	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(context.Background(), repoPath, filePath, "accept_theirs")
 	if err != nil {
 		s.emitCommandFailure(commandID, repoPath, "accept_theirs", []string{"checkout", "--theirs", "--", filePath}, startedAt, err)
 		return err
@@ -983,7 +1181,7 @@ func (s *Service) AcceptTheirs(repoPath string, filePath string, autoStage bool)
 }
 
 func (s *Service) OpenExternalMergeTool(repoPath string, filePath string) error {
-	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(repoPath, filePath, "open_external_tool")
+	preflight, cleanPath, commandID, startedAt, err := s.prepareWrite(context.Background(), repoPath, filePath, "open_external_tool")
 	if err != nil {
 		s.emitCommandFailure(commandID, repoPath, "open_external_tool", []string{"mergetool", "--no-prompt", "--", filePath}, startedAt, err)
 		return err
@@ -1025,10 +1223,10 @@ func (s *Service) OpenExternalMergeTool(repoPath string, filePath string) error
 	return nil
 }
 
-func (s *Service) prepareWrite(repoPath string, filePath string, action string) (PreflightResult, string, string, time.Time, error) {
+func (s *Service) prepareWrite(ctx context.Context, repoPath string, filePath string, action string) (PreflightResult, string, string, time.Time, error) {
 	commandID, startedAt := s.beginCommand(action)
 
-	preflight, err := s.Preflight(repoPath)
+	preflight, err := s.preflightContext(ctx, repoPath)
 	if err != nil {
 		return PreflightResult{}, "", commandID, startedAt, err
 	}
@@ -1136,7 +1334,7 @@ func parseHistoryCursor(cursor string) (string, error) {
 	return strings.ToLower(normalized), nil
 }
 
-func (s *Service) resolveHistorySkip(repoRoot string, cursorHash string, search string) (int, error) {
+func (s *Service) resolveHistorySkip(repoRoot string, cursorHash string, search string, pathScope string) (int, error) {
 	if strings.TrimSpace(cursorHash) == "" {
 		return 0, nil
 	}
@@ -1153,6 +1351,9 @@ func (s *Service) resolveHistorySkip(repoRoot string, cursorHash string, search
 	}
 
 	args = append(args, cursorHash+"..HEAD")
+	if pathScope != "" {
+		args = append(args, "--", pathScope)
+	}
 
 	out, errOut, exitCode, runErr := s.runGit(
 		context.Background(),
@@ -1225,10 +1426,20 @@ func parseHistoryHeaderLine(rawLine string) (HistoryItemDTO, bool) {
 	}
 
 	header = strings.TrimRight(header, "\x1e")
-	fields := strings.SplitN(header, "\x1f", 6)
+	fields := strings.SplitN(header, "\x1f", 7)
 	if len(fields) < 6 {
 		return HistoryItemDTO{}, false
 	}
+	// O formato do histórico principal traz %G? antes do assunto; os demais (compare, autor) não.
+	signature := ""
+	if len(fields) == 7 {
+		if isSignatureCode(fields[5]) {
+			signature = SignatureStatusFromCode(fields[5])
+			fields = append(fields[:5], fields[6])
+		} else {
+			fields = append(fields[:5], fields[5]+"\x1f"+fields[6])
+		}
+	}
 
 	hash := strings.TrimSpace(fields[0])
 	shortHash := strings.TrimSpace(fields[1])
@@ -1243,6 +1454,7 @@ func parseHistoryHeaderLine(rawLine string) (HistoryItemDTO, bool) {
 		AuthoredAt:   strings.TrimSpace(fields[3]),
 		Subject:      strings.TrimRight(fields[5], "\r\n\x1e"),
 		AuthorEmail:  strings.TrimSpace(fields[4]),
+		Signature:    signature,
 		Additions:    0,
 		Deletions:    0,
 		ChangedFiles: 0,
@@ -1538,6 +1750,13 @@ func isTimeoutBindingError(err error) bool {
 	return false
 }
 
+func isCanceledBindingError(err error) bool {
+	if bindingErr := AsBindingError(err); bindingErr != nil {
+		return bindingErr.Code == CodeCanceled
+	}
+	return false
+}
+
 func statRepoFileSize(repoRoot string, filePath string) (int64, bool) {
 	root := filepath.Clean(strings.TrimSpace(repoRoot))
 	normalized := strings.TrimSpace(filePath)
@@ -1602,12 +1821,15 @@ func buildDiffCacheKey(repoRoot string, filePath string, mode string, contextLin
 }
 
 func (s *Service) getCachedPreflight(repoPath string) (PreflightResult, bool) {
+	key := filepath.Clean(strings.TrimSpace(repoPath))
 	s.cacheMu.RLock()
-	entry, ok := s.preflightCache[filepath.Clean(strings.TrimSpace(repoPath))]
+	entry, ok := s.preflightCache[key]
 	s.cacheMu.RUnlock()
 	if !ok || time.Now().After(entry.expiresAt) {
+		s.metrics.miss(cacheKindPreflight, key)
 		return PreflightResult{}, false
 	}
+	s.metrics.hit(cacheKindPreflight)
 	return entry.value, true
 }
 
@@ -1620,9 +1842,10 @@ func (s *Service) setCachedPreflight(repoPath string, value PreflightResult) {
 	s.cacheMu.Lock()
 	s.preflightCache[key] = preflightCacheEntry{
 		value:     value,
-		expiresAt: time.Now().Add(preflightCacheTTL),
+		expiresAt: time.Now().Add(s.cacheTTLLocked(cacheKindPreflight, value.RepoRoot)),
 	}
 	s.cacheMu.Unlock()
+	s.metrics.stored(cacheKindPreflight, key)
 }
 
 func (s *Service) getCachedStatus(repoRoot string) (StatusDTO, bool) {
@@ -1631,8 +1854,10 @@ func (s *Service) getCachedStatus(repoRoot string) (StatusDTO, bool) {
 	entry, ok := s.statusCache[key]
 	s.cacheMu.RUnlock()
 	if !ok || time.Now().After(entry.expiresAt) {
+		s.metrics.miss(cacheKindStatus, key)
 		return StatusDTO{}, false
 	}
+	s.metrics.hit(cacheKindStatus)
 	return entry.value, true
 }
 
@@ -1645,9 +1870,10 @@ func (s *Service) setCachedStatus(repoRoot string, value StatusDTO) {
 	s.cacheMu.Lock()
 	s.statusCache[key] = statusCacheEntry{
 		value:     value,
-		expiresAt: time.Now().Add(statusCacheTTL),
+		expiresAt: time.Now().Add(s.cacheTTLLocked(cacheKindStatus, key)),
 	}
 	s.cacheMu.Unlock()
+	s.metrics.stored(cacheKindStatus, key)
 }
 
 func (s *Service) getCachedHistory(cacheKey string) (HistoryPageDTO, bool) {
@@ -1656,8 +1882,10 @@ func (s *Service) getCachedHistory(cacheKey string) (HistoryPageDTO, bool) {
 	entry, ok := s.historyCache[key]
 	s.cacheMu.RUnlock()
 	if !ok || time.Now().After(entry.expiresAt) {
+		s.metrics.miss(cacheKindHistory, key)
 		return HistoryPageDTO{}, false
 	}
+	s.metrics.hit(cacheKindHistory)
 	return entry.value, true
 }
 
@@ -1670,9 +1898,10 @@ func (s *Service) setCachedHistory(cacheKey string, value HistoryPageDTO) {
 	s.cacheMu.Lock()
 	s.historyCache[key] = historyCacheEntry{
 		value:     value,
-		expiresAt: time.Now().Add(historyCacheTTL),
+		expiresAt: time.Now().Add(s.cacheTTLLocked(cacheKindHistory, cacheKeyRepoRoot(key))),
 	}
 	s.cacheMu.Unlock()
+	s.metrics.stored(cacheKindHistory, key)
 }
 
 func (s *Service) getCachedDiff(cacheKey string) (DiffDTO, bool) {
@@ -1681,8 +1910,10 @@ func (s *Service) getCachedDiff(cacheKey string) (DiffDTO, bool) {
 	entry, ok := s.diffCache[key]
 	s.cacheMu.RUnlock()
 	if !ok || time.Now().After(entry.expiresAt) {
+		s.metrics.miss(cacheKindDiff, key)
 		return DiffDTO{}, false
 	}
+	s.metrics.hit(cacheKindDiff)
 	return entry.value, true
 }
 
@@ -1695,9 +1926,10 @@ func (s *Service) setCachedDiff(cacheKey string, value DiffDTO) {
 	s.cacheMu.Lock()
 	s.diffCache[key] = diffCacheEntry{
 		value:     value,
-		expiresAt: time.Now().Add(diffCacheTTL),
+		expiresAt: time.Now().Add(s.cacheTTLLocked(cacheKindDiff, cacheKeyRepoRoot(key))),
 	}
 	s.cacheMu.Unlock()
+	s.metrics.stored(cacheKindDiff, key)
 }
 
 func (s *Service) invalidateRepoCaches(repoPath string) {
@@ -1779,10 +2011,7 @@ func parsePorcelainStatusText(raw string) StatusDTO {
 		}
 
 		if strings.HasPrefix(line, "## ") {
-			branch, ahead, behind := parseBranchHeader(line)
-			status.Branch = branch
-			status.Ahead = ahead
-			status.Behind = behind
+			applyBranchHeader(&status, parseBranchHeader(line))
 			continue
 		}
 
@@ -1817,10 +2046,7 @@ func parsePorcelainStatusZ(raw string) StatusDTO {
 		}
 
 		if strings.HasPrefix(record, "## ") {
-			branch, ahead, behind := parseBranchHeader(record)
-			status.Branch = branch
-			status.Ahead = ahead
-			status.Behind = behind
+			applyBranchHeader(&status, parseBranchHeader(record))
 			continue
 		}
 
@@ -1893,10 +2119,22 @@ func porcelainEntryHasSecondaryPath(xy string) bool {
 	return xy[0] == 'R' || xy[0] == 'C' || xy[1] == 'R' || xy[1] == 'C'
 }
 
-func parseBranchHeader(line string) (string, int, int) {
+type branchHeader struct {
+	Branch       string
+	Upstream     string
+	Ahead        int
+	Behind       int
+	UpstreamGone bool
+	Detached     bool
+}
+
+// parseBranchHeader interpreta a linha "## ..." do status porcelain, ex.:
+// "## main...origin/main [ahead 1, behind 2]", "## HEAD (no branch)", "## No commits yet on main".
+func parseBranchHeader(line string) branchHeader {
+	header := branchHeader{}
 	trimmed := strings.TrimSpace(strings.TrimPrefix(line, "## "))
 	if trimmed == "" {
-		return "", 0, 0
+		return header
 	}
 
 	branchSection := trimmed
@@ -1908,31 +2146,97 @@ func parseBranchHeader(line string) (string, int, int) {
 		metaSection = strings.TrimPrefix(metaSection, "[")
 	}
 
-	branch := branchSection
+	for _, prefix := range []string{"No commits yet on ", "Initial commit on "} {
+		if strings.HasPrefix(branchSection, prefix) {
+			branchSection = strings.TrimSpace(strings.TrimPrefix(branchSection, prefix))
+			break
+		}
+	}
+
+	header.Branch = branchSection
 	if idx := strings.Index(branchSection, "..."); idx >= 0 {
-		branch = strings.TrimSpace(branchSection[:idx])
+		header.Branch = strings.TrimSpace(branchSection[:idx])
+		header.Upstream = strings.TrimSpace(branchSection[idx+3:])
+	}
+	if strings.HasPrefix(header.Branch, "HEAD (") {
+		header.Detached = true
 	}
 
-	ahead := 0
-	behind := 0
 	if metaSection != "" {
 		parts := strings.Split(metaSection, ",")
 		for _, part := range parts {
 			token := strings.TrimSpace(part)
+			if token == "gone" {
+				header.UpstreamGone = true
+			}
 			if strings.HasPrefix(token, "ahead ") {
 				if value, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(token, "ahead "))); err == nil {
-					ahead = value
+					header.Ahead = value
 				}
 			}
 			if strings.HasPrefix(token, "behind ") {
 				if value, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(token, "behind "))); err == nil {
-					behind = value
+					header.Behind = value
 				}
 			}
 		}
 	}
 
-	return branch, ahead, behind
+	return header
+}
+
+// resolveRepoGitDir resolve o diretório git real (suporta worktrees, onde .git é um arquivo "gitdir: ...").
+func resolveRepoGitDir(repoRoot string) string {
+	gitPath := filepath.Join(repoRoot, ".git")
+	info, err := os.Stat(gitPath)
+	if err != nil || info.IsDir() {
+		return gitPath
+	}
+
+	data, err := os.ReadFile(gitPath)
+	if err != nil {
+		return gitPath
+	}
+	content := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(content, "gitdir:") {
+		return gitPath
+	}
+	gitDir := strings.TrimSpace(strings.TrimPrefix(content, "gitdir:"))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(repoRoot, gitDir)
+	}
+	return filepath.Clean(gitDir)
+}
+
+// detectInProgressOperation identifica merge/rebase/cherry-pick/revert/bisect em andamento.
+func detectInProgressOperation(gitDir string) string {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(gitDir, name))
+		return err == nil
+	}
+
+	switch {
+	case exists("rebase-merge") || exists("rebase-apply"):
+		return "rebasing"
+	case exists("MERGE_HEAD"):
+		return "merging"
+	case exists("CHERRY_PICK_HEAD"):
+		return "cherry_picking"
+	case exists("REVERT_HEAD"):
+		return "reverting"
+	case exists("BISECT_LOG"):
+		return "bisecting"
+	}
+	return ""
+}
+
+func applyBranchHeader(status *StatusDTO, header branchHeader) {
+	status.Branch = header.Branch
+	status.Upstream = header.Upstream
+	status.Ahead = header.Ahead
+	status.Behind = header.Behind
+	status.UpstreamGone = header.UpstreamGone
+	status.Detached = header.Detached
 }
 
 func parsePorcelainPath(raw string) string {
@@ -2038,6 +2342,22 @@ func formatCommandFailureDetails(stderr string, exitCode int, err error) string
 	return strings.Join(parts, " | ")
 }
 
+type gitEnvContextKey struct{}
+
+// withGitEnv anexa variáveis de ambiente extras ao próximo comando git executado com o contexto.
+func withGitEnv(ctx context.Context, env ...string) context.Context {
+	merged := append(append([]string{}, gitEnvFromContext(ctx)...), env...)
+	return context.WithValue(ctx, gitEnvContextKey{}, merged)
+}
+
+func gitEnvFromContext(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+	env, _ := ctx.Value(gitEnvContextKey{}).([]string)
+	return env
+}
+
 func runGitWithInput(ctx context.Context, timeout time.Duration, stdin string, args ...string) (string, string, int, error) {
 	if timeout <= 0 {
 		timeout = defaultReadTimeout
@@ -2047,6 +2367,10 @@ func runGitWithInput(ctx context.Context, timeout time.Duration, stdin string, a
 	defer cancel()
 
 	cmd := exec.CommandContext(childCtx, "git", args...)
+	cmd.WaitDelay = gitWaitDelay
+	if env := gitEnvFromContext(ctx); len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -2068,6 +2392,13 @@ func runGitWithInput(ctx context.Context, timeout time.Duration, stdin string, a
 				formatCommandFailureDetails(stderr.String(), exitCode, runErr),
 			)
 		}
+		if childCtx.Err() == context.Canceled {
+			return stdout.String(), stderr.String(), exitCode, NewBindingError(
+				CodeCanceled,
+				"Comando Git cancelado.",
+				formatCommandFailureDetails(stderr.String(), exitCode, runErr),
+			)
+		}
 		return stdout.String(), stderr.String(), exitCode, runErr
 	}
 
@@ -1153,6 +1153,45 @@ func hasWriteCommand(args []string) bool {
 		hasArgSequence(args, "checkout")
 }
 
+func TestPanelOperationsHonorCancellation(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+	if err := os.WriteFile(filepath.Join(repoRoot, "README.md"), []byte("hello\nchanged\n"), 0o644); err != nil {
+		t.Fatalf("failed to modify README: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	expectCanceled := func(name string, err error) {
+		t.Helper()
+		if bindingErr := AsBindingError(err); bindingErr == nil || bindingErr.Code != CodeCanceled {
+			t.Fatalf("expected %s to be canceled, got %v", name, err)
+		}
+	}
+
+	_, err := svc.GetStatusWithDetailContext(ctx, repoRoot, "", DetailFull)
+	expectCanceled("status", err)
+	_, err = svc.GetDiffInScopeContext(ctx, repoRoot, "README.md", "unified", 3, "")
+	expectCanceled("diff", err)
+	expectCanceled("stage", svc.StageFileContext(ctx, repoRoot, "README.md"))
+	_, err = svc.CommitContext(ctx, repoRoot, "canceled", CommitOptionsDTO{})
+	expectCanceled("commit", err)
+	_, err = svc.CompareBranches(ctx, repoRoot, "HEAD", "HEAD")
+	expectCanceled("compare", err)
+	_, err = svc.GetConfig(ctx, repoRoot, GitConfigScopeLocal)
+	expectCanceled("config", err)
+
+	// Nada foi aplicado nem ficou em cache: sem cancelamento tudo segue normal.
+	status, err := svc.GetStatus(repoRoot)
+	if err != nil || len(status.Staged) != 0 || len(status.Unstaged) != 1 {
+		t.Fatalf("unexpected status after canceled calls: %+v err=%v", status, err)
+	}
+	if err := svc.StageFileContext(context.Background(), repoRoot, "README.md"); err != nil {
+		t.Fatalf("StageFileContext returned error: %v", err)
+	}
+}
+
 func mustInitTestRepo(t *testing.T) string {
 	t.Helper()
 
@@ -1184,3 +1223,74 @@ func runGitOrFail(t *testing.T, repoRoot string, args ...string) {
 		t.Fatalf("git %s failed: %v stderr=%s", strings.Join(args, " "), err, strings.TrimSpace(stderr))
 	}
 }
+
+func TestParseBranchHeaderTracksUpstreamAndDetachedHead(t *testing.T) {
+	cases := []struct {
+		line string
+		want branchHeader
+	}{
+		{"## main...origin/main [ahead 1, behind 2]", branchHeader{Branch: "main", Upstream: "origin/main", Ahead: 1, Behind: 2}},
+		{"## feature/x...origin/feature/x [gone]", branchHeader{Branch: "feature/x", Upstream: "origin/feature/x", UpstreamGone: true}},
+		{"## HEAD (no branch)", branchHeader{Branch: "HEAD (no branch)", Detached: true}},
+		{"## No commits yet on main", branchHeader{Branch: "main"}},
+		{"## dev", branchHeader{Branch: "dev"}},
+	}
+
+	for _, tc := range cases {
+		if got := parseBranchHeader(tc.line); got != tc.want {
+			t.Fatalf("parseBranchHeader(%q) = %+v, want %+v", tc.line, got, tc.want)
+		}
+	}
+}
+
+func TestGetStatusReportsDetachedHeadAndOperation(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	runGitOrFail(t, repoRoot, "checkout", "--detach", "HEAD")
+
+	if err := os.WriteFile(filepath.Join(repoRoot, ".git", "CHERRY_PICK_HEAD"), []byte("deadbeef\n"), 0o644); err != nil {
+		t.Fatalf("failed to create CHERRY_PICK_HEAD: %v", err)
+	}
+
+	status, err := NewService(nil).GetStatus(repoRoot)
+	if err != nil {
+		t.Fatalf("GetStatus returned error: %v", err)
+	}
+	if !status.Detached || status.HeadCommit == "" {
+		t.Fatalf("expected detached HEAD with short hash, got %+v", status)
+	}
+	if status.Operation != "cherry_picking" {
+		t.Fatalf("unexpected operation: got=%q want=cherry_picking", status.Operation)
+	}
+}
+
+func TestGetConflictsReportsFirstMarkerLine(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	writeReadme := func(content string) {
+		if err := os.WriteFile(filepath.Join(repoRoot, "README.md"), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write README.md: %v", err)
+		}
+	}
+	runGitOrFail(t, repoRoot, "checkout", "-b", "feature")
+	writeReadme("hello\nsame\nfeature\n")
+	runGitOrFail(t, repoRoot, "commit", "-am", "feature change")
+	runGitOrFail(t, repoRoot, "checkout", "-")
+	writeReadme("hello\nsame\nmain\n")
+	runGitOrFail(t, repoRoot, "commit", "-am", "main change")
+
+	allArgs := []string{"-C", repoRoot, "merge", "feature"}
+	if _, _, _, err := runGitWithInput(context.Background(), 5*time.Second, "", allArgs...); err == nil {
+		t.Fatalf("expected merge to stop on conflict")
+	}
+
+	svc := NewService(nil)
+	conflicts, err := svc.GetConflicts(repoRoot)
+	if err != nil {
+		t.Fatalf("GetConflicts returned error: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Path != "README.md" || conflicts[0].Line != 3 {
+		t.Fatalf("expected README.md conflict at line 3, got %+v", conflicts)
+	}
+	if status, _ := svc.GetStatus(repoRoot); len(status.Conflicted) != 1 || status.Conflicted[0].Line != 0 {
+		t.Fatalf("cached status must not be mutated, got %+v", status.Conflicted)
+	}
+}
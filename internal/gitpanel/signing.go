@@ -0,0 +1,411 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Formatos de assinatura aceitos pelo git (gpg.format).
+const (
+	SigningFormatOpenPGP = "openpgp"
+	SigningFormatSSH     = "ssh"
+	SigningFormatX509    = "x509"
+)
+
+// Estado de verificação de um commit no histórico (derivado de %G?).
+const (
+	SignatureVerified   = "verified"
+	SignatureSigned     = "signed"
+	SignatureUnverified = "unverified"
+)
+
+const (
+	allowedSignersFileName = "allowed_signers"
+	signingProbeTimeout    = 5 * time.Second
+)
+
+// Pontos de injeção para testes: diretório home e listagem de chaves GPG.
+var (
+	signingHomeDir       = os.UserHomeDir
+	listGPGSecretKeys    = defaultListGPGSecretKeys
+	lookupSigningProgram = exec.LookPath
+)
+
+// SignatureStatusFromCode traduz o placeholder %G? do git log.
+// G = boa e confiável; U/E = assinada, mas sem confiança ou sem chave pública para checar;
+// B/X/Y/R = assinatura inválida, expirada ou revogada; N = sem assinatura.
+func SignatureStatusFromCode(code string) string {
+	switch strings.TrimSpace(code) {
+	case "G":
+		return SignatureVerified
+	case "U", "E":
+		return SignatureSigned
+	case "B", "X", "Y", "R":
+		return SignatureUnverified
+	default:
+		return ""
+	}
+}
+
+// isSignatureCode distingue o campo %G? de um assunto que contenha o separador \x1f.
+func isSignatureCode(field string) bool {
+	return len(field) == 1 && strings.Contains("GBUXYREN", field)
+}
+
+// NormalizeSigningFormat aplica o default do git (openpgp) e rejeita formatos desconhecidos ("").
+func NormalizeSigningFormat(format string) string {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", SigningFormatOpenPGP, "gpg":
+		return SigningFormatOpenPGP
+	case SigningFormatSSH:
+		return SigningFormatSSH
+	case SigningFormatX509:
+		return SigningFormatX509
+	default:
+		return ""
+	}
+}
+
+// GetSigningStatus detecta a configuração de assinatura (GPG/SSH) efetiva no repositório.
+func (s *Service) GetSigningStatus(repoPath string) (SigningStatusDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return SigningStatusDTO{}, err
+	}
+	return s.readSigningStatus(preflight.RepoRoot), nil
+}
+
+// ConfigureSigning grava gpg.format, user.signingkey e commit/tag.gpgsign no config local do
+// repositório. Para SSH também registra a chave em um allowed_signers do repositório, sem o qual
+// o git não consegue verificar os próprios commits.
+func (s *Service) ConfigureSigning(repoPath string, setup SigningSetupDTO) (SigningStatusDTO, error) {
+	commandID, startedAt := s.beginCommand("configure_signing")
+	format := NormalizeSigningFormat(setup.Format)
+	key := strings.TrimSpace(setup.SigningKey)
+	args := []string{"config", "--local", "gpg.format", format}
+
+	var validationErr error
+	switch {
+	case format == "":
+		validationErr = NewBindingError(CodeCommandFailed, "Formato de assinatura inválido.", "Use openpgp, ssh ou x509.")
+	case format == SigningFormatSSH && key == "":
+		validationErr = NewBindingError(CodeCommandFailed, "Chave SSH obrigatória.", "Informe o caminho da chave pública (~/.ssh/id_ed25519.pub) ou a chave literal.")
+	case strings.ContainsAny(key, "\r\n"):
+		validationErr = NewBindingError(CodeCommandFailed, "Chave de assinatura inválida.", "A chave deve ocupar uma única linha.")
+	}
+	if validationErr != nil {
+		s.emitCommandFailure(commandID, repoPath, "configure_signing", args, startedAt, validationErr)
+		return SigningStatusDTO{}, validationErr
+	}
+
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		s.emitCommandFailure(commandID, repoPath, "configure_signing", args, startedAt, err)
+		return SigningStatusDTO{}, err
+	}
+	root := preflight.RepoRoot
+
+	var publicKey string
+	if format == SigningFormatSSH {
+		publicKey, err = readSSHPublicKey(key)
+		if err != nil {
+			s.emitCommandFailure(commandID, root, "configure_signing", args, startedAt, err)
+			return SigningStatusDTO{}, err
+		}
+	}
+
+	settings := [][2]string{
+		{"gpg.format", format},
+		{"user.signingkey", key},
+		{"commit.gpgsign", boolConfigValue(setup.SignCommits)},
+		{"tag.gpgsign", boolConfigValue(setup.SignTags)},
+	}
+
+	if err := s.executeWrite(
+		root,
+		commandID,
+		"configure_signing",
+		args,
+		startedAt,
+		defaultWriteTimeout,
+		func(ctx context.Context, diag *commandDiagnosticState) error {
+			for _, setting := range settings {
+				gitArgs := []string{"-C", root, "config", "--local", setting[0], setting[1]}
+				if setting[1] == "" {
+					// Sem chave o git usa a identidade do committer para escolher a chave GPG.
+					gitArgs = []string{"-C", root, "config", "--local", "--unset-all", setting[0]}
+				}
+				_, errOut, exitCode, runErr := s.runWriteGitWithRetry(ctx, diag, "", gitArgs...)
+				// exit 5 no --unset-all: a chave não existia.
+				if runErr != nil && !(setting[1] == "" && exitCode == 5) {
+					return wrapWriteCommandError(CodeCommandFailed, "Falha ao gravar a configuração de assinatura.", errOut, exitCode, runErr)
+				}
+			}
+			if format != SigningFormatSSH {
+				return nil
+			}
+			return s.registerAllowedSigner(ctx, diag, root, publicKey)
+		}); err != nil {
+		return SigningStatusDTO{}, err
+	}
+
+	s.emitPostWriteReconciliation(root, "configure_signing", false)
+	return s.readSigningStatus(root), nil
+}
+
+func (s *Service) readSigningStatus(root string) SigningStatusDTO {
+	format := NormalizeSigningFormat(s.readGitConfigString(root, "gpg.format"))
+	if format == "" {
+		format = SigningFormatOpenPGP
+	}
+	status := SigningStatusDTO{
+		Format:             format,
+		SigningKey:         s.readGitConfigString(root, "user.signingkey"),
+		SignCommits:        s.readGitConfigBool(root, "commit.gpgsign"),
+		SignTags:           s.readGitConfigBool(root, "tag.gpgsign"),
+		AllowedSignersFile: s.readGitConfigString(root, "gpg.ssh.allowedSignersFile"),
+		AvailableKeys:      []SigningKeyDTO{},
+		Issues:             []string{},
+	}
+
+	status.Program = s.readGitConfigString(root, "gpg."+format+".program")
+	if status.Program == "" && format == SigningFormatOpenPGP {
+		status.Program = s.readGitConfigString(root, "gpg.program")
+	}
+	if status.Program == "" {
+		status.Program = defaultSigningProgram(format)
+	}
+	if _, err := lookupSigningProgram(status.Program); err == nil {
+		status.ProgramAvailable = true
+	} else {
+		status.Issues = append(status.Issues, "Programa de assinatura não encontrado: "+status.Program+".")
+	}
+
+	switch format {
+	case SigningFormatSSH:
+		status.AvailableKeys = listSSHPublicKeys()
+		if status.SigningKey == "" {
+			status.Issues = append(status.Issues, "user.signingkey não definido: o formato SSH exige a chave.")
+		} else if _, err := readSSHPublicKey(status.SigningKey); err == nil {
+			status.KeyAvailable = true
+		} else {
+			status.Issues = append(status.Issues, "Chave SSH não encontrada: "+status.SigningKey+".")
+		}
+		if status.AllowedSignersFile == "" {
+			status.Issues = append(status.Issues, "gpg.ssh.allowedSignersFile não configurado: commits SSH aparecem como não verificados.")
+		}
+	case SigningFormatOpenPGP:
+		if status.ProgramAvailable {
+			status.AvailableKeys = listGPGSecretKeys(status.Program)
+		}
+		needle := status.SigningKey
+		if needle == "" {
+			needle = s.readGitConfigString(root, "user.email")
+		}
+		status.KeyAvailable = matchGPGKey(status.AvailableKeys, needle)
+		if !status.KeyAvailable && status.ProgramAvailable {
+			if status.SigningKey == "" {
+				status.Issues = append(status.Issues, "Nenhuma chave GPG secreta corresponde ao e-mail do committer; defina user.signingkey.")
+			} else {
+				status.Issues = append(status.Issues, "Chave GPG secreta não encontrada: "+status.SigningKey+".")
+			}
+		}
+	default:
+		// x509 (gpgsm): a chave é resolvida pelo próprio programa.
+		status.KeyAvailable = status.SigningKey != ""
+	}
+
+	if !status.SignCommits {
+		status.Issues = append(status.Issues, "commit.gpgsign desativado: novos commits não serão assinados.")
+	}
+	status.Configured = status.SignCommits && status.ProgramAvailable && status.KeyAvailable
+	return status
+}
+
+// registerAllowedSigner acrescenta "<email> <chave>" ao allowed_signers do repositório. Um arquivo
+// já configurado pelo usuário (global ou local) é respeitado e apenas recebe a entrada.
+func (s *Service) registerAllowedSigner(ctx context.Context, diag *commandDiagnosticState, root string, publicKey string) error {
+	email := s.readGitConfigString(root, "user.email")
+	if email == "" {
+		return NewBindingError(CodeCommandFailed, "user.email não definido.", "O allowed_signers associa a chave SSH ao e-mail do committer.")
+	}
+
+	path := expandHomePath(s.readGitConfigString(root, "gpg.ssh.allowedSignersFile"))
+	if path == "" {
+		path = filepath.Join(resolveRepoGitDir(root), allowedSignersFileName)
+		_, errOut, exitCode, runErr := s.runWriteGitWithRetry(ctx, diag, "", "-C", root, "config", "--local", "gpg.ssh.allowedSignersFile", path)
+		if runErr != nil {
+			return wrapWriteCommandError(CodeCommandFailed, "Falha ao configurar gpg.ssh.allowedSignersFile.", errOut, exitCode, runErr)
+		}
+	}
+
+	entry := email + " " + publicKey
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return NewBindingError(CodeCommandFailed, "Falha ao ler allowed_signers.", err.Error())
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) == entry {
+			return nil
+		}
+	}
+	text := string(content)
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	text += entry + "\n"
+	if err := writeFileAtomic(path, []byte(text)); err != nil {
+		return NewBindingError(CodeCommandFailed, "Falha ao atualizar allowed_signers.", err.Error())
+	}
+	return nil
+}
+
+// readSSHPublicKey devolve "<tipo> <base64>" a partir da chave literal ("key::..." ou "ssh-...")
+// ou do caminho de uma chave (a .pub ao lado da privada também é aceita).
+func readSSHPublicKey(value string) (string, error) {
+	value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), "key::"))
+	if fields := strings.Fields(value); len(fields) >= 2 && isSSHKeyType(fields[0]) {
+		return fields[0] + " " + fields[1], nil
+	}
+
+	path := expandHomePath(value)
+	candidates := []string{path}
+	if !strings.HasSuffix(path, ".pub") {
+		candidates = append(candidates, path+".pub")
+	}
+	for _, candidate := range candidates {
+		content, err := os.ReadFile(candidate)
+		if err != nil {
+			continue
+		}
+		fields := strings.Fields(string(content))
+		if len(fields) >= 2 && isSSHKeyType(fields[0]) {
+			return fields[0] + " " + fields[1], nil
+		}
+	}
+	return "", NewBindingError(CodeCommandFailed, "Chave SSH pública não encontrada.", value)
+}
+
+func isSSHKeyType(value string) bool {
+	return strings.HasPrefix(value, "ssh-") || strings.HasPrefix(value, "ecdsa-sha2-") || strings.HasPrefix(value, "sk-")
+}
+
+// listSSHPublicKeys lista as chaves públicas em ~/.ssh (o comentário vira o rótulo).
+func listSSHPublicKeys() []SigningKeyDTO {
+	keys := []SigningKeyDTO{}
+	home, err := signingHomeDir()
+	if err != nil || home == "" {
+		return keys
+	}
+	matches, _ := filepath.Glob(filepath.Join(home, ".ssh", "*.pub"))
+	sort.Strings(matches)
+	for _, path := range matches {
+		content, readErr := os.ReadFile(path)
+		if readErr != nil {
+			continue
+		}
+		fields := strings.Fields(string(content))
+		if len(fields) < 2 || !isSSHKeyType(fields[0]) {
+			continue
+		}
+		label := filepath.Base(path)
+		if len(fields) >= 3 {
+			label = strings.Join(fields[2:], " ")
+		}
+		keys = append(keys, SigningKeyDTO{Format: SigningFormatSSH, ID: path, Label: label})
+	}
+	return keys
+}
+
+func defaultListGPGSecretKeys(program string) []SigningKeyDTO {
+	ctx, cancel := context.WithTimeout(context.Background(), signingProbeTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, program, "--list-secret-keys", "--with-colons").Output()
+	if err != nil {
+		return []SigningKeyDTO{}
+	}
+	return parseGPGSecretKeys(string(out))
+}
+
+// parseGPGSecretKeys lê a saída --with-colons: "sec" abre a chave, "fpr" e "uid" a completam.
+func parseGPGSecretKeys(output string) []SigningKeyDTO {
+	keys := []SigningKeyDTO{}
+	var current *SigningKeyDTO
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimRight(line, "\r"), ":")
+		if len(fields) < 10 {
+			continue
+		}
+		switch fields[0] {
+		case "sec":
+			keys = append(keys, SigningKeyDTO{Format: SigningFormatOpenPGP, ID: fields[4]})
+			current = &keys[len(keys)-1]
+		case "pub":
+			current = nil
+		case "fpr":
+			// O primeiro fpr é o da chave primária; os das subchaves (ssb) são ignorados.
+			if current != nil && current.Fingerprint == "" {
+				current.Fingerprint = fields[9]
+			}
+		case "uid":
+			if current != nil && current.Label == "" {
+				current.Label = fields[9]
+			}
+		}
+	}
+	return keys
+}
+
+// matchGPGKey aceita ID curto/longo, fingerprint ou trecho do uid (e-mail), como o gpg faz.
+func matchGPGKey(keys []SigningKeyDTO, needle string) bool {
+	needle = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(needle), "0x"), "!")
+	if needle == "" {
+		return false
+	}
+	upper := strings.ToUpper(needle)
+	lower := strings.ToLower(needle)
+	for _, key := range keys {
+		if strings.HasSuffix(strings.ToUpper(key.Fingerprint), upper) || strings.HasSuffix(strings.ToUpper(key.ID), upper) {
+			return true
+		}
+		if strings.Contains(strings.ToLower(key.Label), lower) {
+			return true
+		}
+	}
+	return false
+}
+
+func defaultSigningProgram(format string) string {
+	switch format {
+	case SigningFormatSSH:
+		return "ssh-keygen"
+	case SigningFormatX509:
+		return "gpgsm"
+	default:
+		return "gpg"
+	}
+}
+
+func expandHomePath(path string) string {
+	path = strings.TrimSpace(path)
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := signingHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+func boolConfigValue(value bool) string {
+	if value {
+		return "true"
+	}
+	return "false"
+}
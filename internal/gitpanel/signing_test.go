@@ -0,0 +1,123 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigureSSHSigningMarksHistoryAsVerified(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	home := t.TempDir()
+	previousHome := signingHomeDir
+	signingHomeDir = func() (string, error) { return home, nil }
+	t.Cleanup(func() { signingHomeDir = previousHome })
+
+	keyPath := filepath.Join(home, ".ssh", "id_ed25519")
+	if err := os.MkdirAll(filepath.Dir(keyPath), 0o700); err != nil {
+		t.Fatalf("failed to create .ssh: %v", err)
+	}
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "tests@orch.local", "-f", keyPath).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen failed: %v (%s)", err, out)
+	}
+
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	status, err := svc.GetSigningStatus(repoRoot)
+	if err != nil {
+		t.Fatalf("GetSigningStatus() returned error: %v", err)
+	}
+	if status.SignCommits || status.Configured {
+		t.Fatalf("expected signing disabled in a fresh repo, got %+v", status)
+	}
+
+	if _, err := svc.ConfigureSigning(repoRoot, SigningSetupDTO{Format: "ssh", SigningKey: ""}); err == nil {
+		t.Fatalf("expected SSH setup without key to fail")
+	}
+
+	status, err = svc.ConfigureSigning(repoRoot, SigningSetupDTO{Format: "ssh", SigningKey: "~/.ssh/id_ed25519.pub", SignCommits: true})
+	if err != nil {
+		t.Fatalf("ConfigureSigning() returned error: %v", err)
+	}
+	if status.Format != SigningFormatSSH || !status.SignCommits || !status.KeyAvailable || !status.Configured {
+		t.Fatalf("unexpected status after setup: %+v", status)
+	}
+	if len(status.AvailableKeys) != 1 || status.AvailableKeys[0].Label != "tests@orch.local" {
+		t.Fatalf("expected the generated key to be listed, got %+v", status.AvailableKeys)
+	}
+	signers, err := os.ReadFile(status.AllowedSignersFile)
+	if err != nil || !strings.HasPrefix(string(signers), "tests@orch.local ssh-ed25519 ") {
+		t.Fatalf("unexpected allowed_signers %q: %v", signers, err)
+	}
+
+	// Repetir o setup não duplica a entrada.
+	if _, err := svc.ConfigureSigning(repoRoot, SigningSetupDTO{Format: "ssh", SigningKey: keyPath, SignCommits: true}); err != nil {
+		t.Fatalf("ConfigureSigning(again) returned error: %v", err)
+	}
+	signers, _ = os.ReadFile(status.AllowedSignersFile)
+	if strings.Count(string(signers), "\n") != 1 {
+		t.Fatalf("expected a single allowed signer, got %q", signers)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoRoot, "signed.txt"), []byte("signed\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGitOrFail(t, repoRoot, "add", "signed.txt")
+	runGitOrFail(t, repoRoot, "commit", "-m", "signed commit")
+
+	page, err := svc.GetHistory(repoRoot, "", 10, "")
+	if err != nil {
+		t.Fatalf("GetHistory() returned error: %v", err)
+	}
+	if len(page.Items) < 2 {
+		t.Fatalf("expected at least two commits, got %+v", page.Items)
+	}
+	if page.Items[0].Signature != SignatureVerified || page.Items[0].Subject != "signed commit" {
+		t.Fatalf("expected signed commit to be verified, got %+v", page.Items[0])
+	}
+	if page.Items[len(page.Items)-1].Signature != "" {
+		t.Fatalf("expected unsigned commit without signature, got %+v", page.Items[len(page.Items)-1])
+	}
+}
+
+func TestSignatureStatusFromCode(t *testing.T) {
+	cases := map[string]string{"G": SignatureVerified, "U": SignatureSigned, "E": SignatureSigned, "B": SignatureUnverified, "R": SignatureUnverified, "N": ""}
+	for code, want := range cases {
+		if got := SignatureStatusFromCode(code); got != want {
+			t.Fatalf("SignatureStatusFromCode(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestParseGPGSecretKeysAndMatch(t *testing.T) {
+	output := strings.Join([]string{
+		"sec:u:255:22:ABCDEF0123456789:1700000000:::u:::scESC:::+:::ed25519:::0:",
+		"fpr:::::::::0123456789ABCDEF0123ABCDEF0123456789:",
+		"grp:::::::::AAAA:",
+		"uid:u::::1700000000::HASH::Dev Tests <tests@orch.local>::::::::::0:",
+		"ssb:u:255:18:1111222233334444:1700000000::::::e:::+:::cv25519::",
+		"fpr:::::::::FFFF222233334444:",
+	}, "\n")
+	keys := parseGPGSecretKeys(output)
+	if len(keys) != 1 {
+		t.Fatalf("expected one key, got %+v", keys)
+	}
+	if keys[0].ID != "ABCDEF0123456789" || keys[0].Fingerprint != "0123456789ABCDEF0123ABCDEF0123456789" || keys[0].Label != "Dev Tests <tests@orch.local>" {
+		t.Fatalf("unexpected key: %+v", keys[0])
+	}
+	for _, needle := range []string{"0123456789", "0xabcdef0123456789", "tests@orch.local"} {
+		if !matchGPGKey(keys, needle) {
+			t.Fatalf("expected %q to match", needle)
+		}
+	}
+	if matchGPGKey(keys, "other@orch.local") || matchGPGKey(keys, "") {
+		t.Fatalf("unexpected match")
+	}
+}
@@ -0,0 +1,125 @@
+package gitpanel
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sparseCheckoutTimeout cobre a reescrita do working tree em repositórios grandes.
+const sparseCheckoutTimeout = 5 * time.Minute
+
+// SetSparseCheckout ativa o sparse-checkout em modo cone com os diretórios informados
+// (relativos à raiz). Lista vazia mantém só os arquivos da raiz.
+func (s *Service) SetSparseCheckout(repoPath string, directories []string) (SparseCheckoutDTO, error) {
+	commandID, startedAt := s.beginCommand("sparse_checkout_set")
+	args := []string{"sparse-checkout", "set", "--cone", "--stdin"}
+
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		s.emitCommandFailure(commandID, repoPath, "sparse_checkout_set", args, startedAt, err)
+		return SparseCheckoutDTO{}, err
+	}
+	root := preflight.RepoRoot
+
+	normalized, err := normalizeConeDirectories(root, directories)
+	if err != nil {
+		s.emitCommandFailure(commandID, root, "sparse_checkout_set", args, startedAt, err)
+		return SparseCheckoutDTO{}, err
+	}
+
+	if err := s.executeWrite(
+		root,
+		commandID,
+		"sparse_checkout_set",
+		args,
+		startedAt,
+		sparseCheckoutTimeout,
+		func(ctx context.Context, diag *commandDiagnosticState) error {
+			// --stdin evita que um diretório seja lido como opção do git.
+			stdin := strings.Join(normalized, "\n")
+			if stdin != "" {
+				stdin += "\n"
+			}
+			gitArgs := append([]string{"-C", root}, args...)
+			_, errOut, exitCode, runErr := s.runGit(ctx, remainingTimeout(ctx, sparseCheckoutTimeout), stdin, gitArgs...)
+			diag.recordAttempt(gitArgs, errOut, exitCode, 1)
+			if runErr != nil {
+				return wrapWriteCommandError(CodeCommandFailed, "Falha ao configurar o sparse-checkout.", errOut, exitCode, runErr)
+			}
+			return nil
+		}); err != nil {
+		return SparseCheckoutDTO{}, err
+	}
+
+	s.emitPostWriteReconciliation(root, "sparse_checkout_set", false)
+	return s.GetSparseCheckout(root)
+}
+
+// DisableSparseCheckout desativa o sparse-checkout e materializa o working tree completo.
+func (s *Service) DisableSparseCheckout(repoPath string) (SparseCheckoutDTO, error) {
+	commandID, startedAt := s.beginCommand("sparse_checkout_disable")
+	args := []string{"sparse-checkout", "disable"}
+
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		s.emitCommandFailure(commandID, repoPath, "sparse_checkout_disable", args, startedAt, err)
+		return SparseCheckoutDTO{}, err
+	}
+	root := preflight.RepoRoot
+
+	if err := s.executeWrite(
+		root,
+		commandID,
+		"sparse_checkout_disable",
+		args,
+		startedAt,
+		sparseCheckoutTimeout,
+		func(ctx context.Context, diag *commandDiagnosticState) error {
+			gitArgs := append([]string{"-C", root}, args...)
+			_, errOut, exitCode, runErr := s.runGit(ctx, remainingTimeout(ctx, sparseCheckoutTimeout), "", gitArgs...)
+			diag.recordAttempt(gitArgs, errOut, exitCode, 1)
+			if runErr != nil {
+				return wrapWriteCommandError(CodeCommandFailed, "Falha ao desativar o sparse-checkout.", errOut, exitCode, runErr)
+			}
+			return nil
+		}); err != nil {
+		return SparseCheckoutDTO{}, err
+	}
+
+	s.emitPostWriteReconciliation(root, "sparse_checkout_disable", false)
+	return s.GetSparseCheckout(root)
+}
+
+// normalizeConeDirectories valida e deduplica os diretórios do modo cone (sem curingas).
+func normalizeConeDirectories(repoRoot string, directories []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(directories))
+	result := make([]string, 0, len(directories))
+	for _, directory := range directories {
+		if strings.TrimSpace(directory) == "" {
+			continue
+		}
+		if strings.ContainsAny(directory, "*?[]!\n\r") {
+			return nil, NewBindingError(
+				CodeInvalidPath,
+				"Diretório de sparse-checkout inválido.",
+				"O modo cone aceita apenas diretórios, sem curingas: "+directory,
+			)
+		}
+		normalized, err := NormalizePathScope(repoRoot, directory)
+		if err != nil {
+			return nil, err
+		}
+		if normalized == "" {
+			continue
+		}
+		if _, ok := seen[normalized]; ok {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		result = append(result, normalized)
+	}
+	sort.Strings(result)
+	return result, nil
+}
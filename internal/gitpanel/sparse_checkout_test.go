@@ -0,0 +1,55 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetSparseCheckoutMaterializesOnlyConeDirectories(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	for _, dir := range []string{"packages/api", "packages/web", "docs"} {
+		if err := os.MkdirAll(filepath.Join(repoRoot, dir), 0o755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(repoRoot, dir, "README.md"), []byte(dir+"\n"), 0o644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	runGitOrFail(t, repoRoot, "add", ".")
+	runGitOrFail(t, repoRoot, "commit", "-m", "add packages")
+
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	if _, err := svc.SetSparseCheckout(repoRoot, []string{"packages/*"}); err == nil {
+		t.Fatalf("expected glob directory to be rejected")
+	}
+	if _, err := svc.SetSparseCheckout(repoRoot, []string{"../outside"}); err == nil {
+		t.Fatalf("expected directory outside the repo to be rejected")
+	}
+
+	sparse, err := svc.SetSparseCheckout(repoRoot, []string{"packages/api/", "packages/api", " "})
+	if err != nil {
+		t.Fatalf("SetSparseCheckout() returned error: %v", err)
+	}
+	if !sparse.Enabled || !sparse.Cone || strings.Join(sparse.Directories, ",") != "packages/api" {
+		t.Fatalf("unexpected sparse state: %+v", sparse)
+	}
+	if _, err := os.Stat(filepath.Join(repoRoot, "packages", "web", "README.md")); !os.IsNotExist(err) {
+		t.Fatalf("expected packages/web to be removed from the working tree, stat err=%v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repoRoot, "README.md")); err != nil {
+		t.Fatalf("expected root files to stay materialized: %v", err)
+	}
+
+	sparse, err = svc.DisableSparseCheckout(repoRoot)
+	if err != nil || sparse.Enabled {
+		t.Fatalf("DisableSparseCheckout() returned (%+v, %v)", sparse, err)
+	}
+	if _, err := os.Stat(filepath.Join(repoRoot, "packages", "web", "README.md")); err != nil {
+		t.Fatalf("expected full working tree after disable: %v", err)
+	}
+}
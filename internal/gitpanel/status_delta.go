@@ -0,0 +1,126 @@
+package gitpanel
+
+import (
+	"path/filepath"
+	"reflect"
+)
+
+// statusSnapshot guarda o último status calculado do repositório para gerar deltas.
+type statusSnapshot struct {
+	seq    uint64
+	status StatusDTO
+}
+
+// recordStatusSnapshot numera o status recém-calculado: o seq só avança quando o conteúdo muda,
+// então recalcular após uma invalidação sem efeito mantém o mesmo número.
+func (s *Service) recordStatusSnapshot(repoRoot string, status StatusDTO) StatusDTO {
+	root := filepath.Clean(repoRoot)
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+
+	if previous, ok := s.statusSnapshots[root]; ok && sameStatusContent(previous.status, status) {
+		status.Seq = previous.seq
+		return status
+	}
+	s.statusSeq++
+	status.Seq = s.statusSeq
+	s.statusSnapshots[root] = statusSnapshot{seq: status.Seq, status: status}
+	return status
+}
+
+// ComputeStatusDelta recalcula o status (respeitando o cache) e devolve o que mudou desde o
+// snapshot anterior. Sem snapshot anterior o delta vem com Full=true e as listas completas.
+func (s *Service) ComputeStatusDelta(repoPath string) (StatusDeltaDTO, error) {
+	preflight, err := s.Preflight(repoPath)
+	if err != nil {
+		return StatusDeltaDTO{}, err
+	}
+	root := filepath.Clean(preflight.RepoRoot)
+
+	s.snapshotMu.Lock()
+	previous, hasPrevious := s.statusSnapshots[root]
+	s.snapshotMu.Unlock()
+
+	current, err := s.GetStatus(root)
+	if err != nil {
+		return StatusDeltaDTO{}, err
+	}
+	if !hasPrevious {
+		return diffStatus(root, StatusDTO{}, current, true), nil
+	}
+	return diffStatus(root, previous.status, current, false), nil
+}
+
+// diffStatus compara dois snapshots. O cabeçalho (branch, upstream, operação) e os contadores vão
+// sempre inteiros; as listas só com os caminhos inseridos/alterados e removidos.
+func diffStatus(repoRoot string, previous StatusDTO, current StatusDTO, full bool) StatusDeltaDTO {
+	delta := StatusDeltaDTO{
+		RepoRoot:        repoRoot,
+		Seq:             current.Seq,
+		BaseSeq:         previous.Seq,
+		Full:            full,
+		Branch:          current.Branch,
+		Upstream:        current.Upstream,
+		UpstreamGone:    current.UpstreamGone,
+		Ahead:           current.Ahead,
+		Behind:          current.Behind,
+		Detached:        current.Detached,
+		HeadCommit:      current.HeadCommit,
+		Operation:       current.Operation,
+		StagedCount:     len(current.Staged),
+		UnstagedCount:   len(current.Unstaged),
+		ConflictedCount: len(current.Conflicted),
+		Staged:          diffFileChanges(previous.Staged, current.Staged),
+		Unstaged:        diffFileChanges(previous.Unstaged, current.Unstaged),
+		Conflicted:      diffConflicts(previous.Conflicted, current.Conflicted),
+	}
+	delta.Empty = !full && previous.Seq == current.Seq
+	return delta
+}
+
+func diffFileChanges(previous []FileChangeDTO, current []FileChangeDTO) FileChangesDeltaDTO {
+	delta := FileChangesDeltaDTO{Upserted: []FileChangeDTO{}, Removed: []string{}}
+	before := make(map[string]FileChangeDTO, len(previous))
+	for _, file := range previous {
+		before[file.Path] = file
+	}
+	seen := make(map[string]struct{}, len(current))
+	for _, file := range current {
+		seen[file.Path] = struct{}{}
+		if old, ok := before[file.Path]; !ok || old != file {
+			delta.Upserted = append(delta.Upserted, file)
+		}
+	}
+	for _, file := range previous {
+		if _, ok := seen[file.Path]; !ok {
+			delta.Removed = append(delta.Removed, file.Path)
+		}
+	}
+	return delta
+}
+
+func diffConflicts(previous []ConflictFileDTO, current []ConflictFileDTO) ConflictsDeltaDTO {
+	delta := ConflictsDeltaDTO{Upserted: []ConflictFileDTO{}, Removed: []string{}}
+	before := make(map[string]ConflictFileDTO, len(previous))
+	for _, file := range previous {
+		before[file.Path] = file
+	}
+	seen := make(map[string]struct{}, len(current))
+	for _, file := range current {
+		seen[file.Path] = struct{}{}
+		if old, ok := before[file.Path]; !ok || old != file {
+			delta.Upserted = append(delta.Upserted, file)
+		}
+	}
+	for _, file := range previous {
+		if _, ok := seen[file.Path]; !ok {
+			delta.Removed = append(delta.Removed, file.Path)
+		}
+	}
+	return delta
+}
+
+func sameStatusContent(a StatusDTO, b StatusDTO) bool {
+	a.Seq, b.Seq = 0, 0
+	return reflect.DeepEqual(a, b)
+}
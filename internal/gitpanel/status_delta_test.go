@@ -0,0 +1,73 @@
+package gitpanel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestComputeStatusDeltaTracksChangesBetweenSnapshots(t *testing.T) {
+	repoRoot := mustInitTestRepo(t)
+	svc := NewService(nil)
+	t.Cleanup(func() { _ = svc.Close(context.Background()) })
+
+	writeParityFile(t, repoRoot, "a.txt", "a\n")
+	writeParityFile(t, repoRoot, "b.txt", "b\n")
+	first, err := svc.ComputeStatusDelta(repoRoot)
+	if err != nil {
+		t.Fatalf("ComputeStatusDelta error: %v", err)
+	}
+	if !first.Full || first.BaseSeq != 0 || first.Seq == 0 || len(first.Unstaged.Upserted) != 2 || first.UnstagedCount != 2 {
+		t.Fatalf("expected full first delta, got %+v", first)
+	}
+
+	// Sem invalidação o status vem do cache: delta vazio com o mesmo seq.
+	unchanged, err := svc.ComputeStatusDelta(repoRoot)
+	if err != nil || !unchanged.Empty || unchanged.Seq != first.Seq || len(unchanged.Unstaged.Upserted) != 0 {
+		t.Fatalf("expected empty delta, got %+v (%v)", unchanged, err)
+	}
+
+	runGitOrFail(t, repoRoot, "add", "--", "a.txt")
+	if err := os.Remove(filepath.Join(repoRoot, "b.txt")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	svc.InvalidateRepoCache(repoRoot)
+	next, err := svc.ComputeStatusDelta(repoRoot)
+	if err != nil {
+		t.Fatalf("ComputeStatusDelta error: %v", err)
+	}
+	if next.Full || next.Empty || next.BaseSeq != first.Seq || next.Seq <= first.Seq {
+		t.Fatalf("unexpected sequence: %+v", next)
+	}
+	if len(next.Staged.Upserted) != 1 || next.Staged.Upserted[0].Path != "a.txt" || next.StagedCount != 1 {
+		t.Fatalf("expected a.txt staged, got %+v", next.Staged)
+	}
+	if len(next.Unstaged.Removed) != 2 || next.UnstagedCount != 0 {
+		t.Fatalf("expected both untracked entries removed, got %+v", next.Unstaged)
+	}
+
+	status, err := svc.GetStatus(repoRoot)
+	if err != nil || status.Seq != next.Seq {
+		t.Fatalf("GetStatus seq = %d, want %d (%v)", status.Seq, next.Seq, err)
+	}
+
+	// Recalcular sem mudança de conteúdo mantém o seq.
+	svc.InvalidateRepoCache(repoRoot)
+	again, err := svc.ComputeStatusDelta(repoRoot)
+	if err != nil || !again.Empty || again.Seq != next.Seq {
+		t.Fatalf("expected stable seq after no-op invalidation, got %+v (%v)", again, err)
+	}
+}
+
+func TestDiffFileChangesDetectsModifiedEntries(t *testing.T) {
+	previous := []FileChangeDTO{{Path: "a", Status: "M", Added: 1}, {Path: "b", Status: "M"}}
+	current := []FileChangeDTO{{Path: "a", Status: "M", Added: 3}, {Path: "c", Status: "A"}}
+	delta := diffFileChanges(previous, current)
+	if len(delta.Upserted) != 2 || delta.Upserted[0].Added != 3 || delta.Upserted[1].Path != "c" {
+		t.Fatalf("unexpected upserted: %+v", delta.Upserted)
+	}
+	if len(delta.Removed) != 1 || delta.Removed[0] != "b" {
+		t.Fatalf("unexpected removed: %+v", delta.Removed)
+	}
+}
@@ -22,16 +22,71 @@ type FileChangeDTO struct {
 type ConflictFileDTO struct {
 	Path   string `json:"path"`
 	Status string `json:"status"`
+	Line   int    `json:"line,omitempty"` // primeira linha com marcador "<<<<<<<" (abrir no editor); 0 se não houver
 }
 
 // StatusDTO representa snapshot de status para o painel.
 type StatusDTO struct {
-	Branch     string            `json:"branch"`
-	Ahead      int               `json:"ahead"`
-	Behind     int               `json:"behind"`
-	Staged     []FileChangeDTO   `json:"staged"`
-	Unstaged   []FileChangeDTO   `json:"unstaged"`
-	Conflicted []ConflictFileDTO `json:"conflicted"`
+	Branch       string            `json:"branch"`
+	Upstream     string            `json:"upstream,omitempty"` // ex: origin/main
+	UpstreamGone bool              `json:"upstreamGone"`       // upstream configurado mas removido no remoto
+	Ahead        int               `json:"ahead"`
+	Behind       int               `json:"behind"`
+	Detached     bool              `json:"detached"`
+	HeadCommit   string            `json:"headCommit,omitempty"` // hash curto quando em detached HEAD
+	Operation    string            `json:"operation,omitempty"`  // "merging" | "rebasing" | "cherry_picking" | "reverting" | "bisecting"
+	Staged       []FileChangeDTO   `json:"staged"`
+	Unstaged     []FileChangeDTO   `json:"unstaged"`
+	Conflicted   []ConflictFileDTO `json:"conflicted"`
+	PathScope    string            `json:"pathScope,omitempty"` // subdiretório filtrado (monorepo)
+
+	// Contadores e nível de detalhe: no nível summary as listas acima vêm vazias.
+	StagedCount     int    `json:"stagedCount"`
+	UnstagedCount   int    `json:"unstagedCount"`
+	ConflictedCount int    `json:"conflictedCount"`
+	Detail          string `json:"detail,omitempty"`
+
+	// Seq numera o snapshot (avança só quando o conteúdo muda); base dos deltas de status.
+	Seq uint64 `json:"seq,omitempty"`
+}
+
+// FileChangesDeltaDTO lista os arquivos novos/alterados e os caminhos que saíram da lista.
+type FileChangesDeltaDTO struct {
+	Upserted []FileChangeDTO `json:"upserted"`
+	Removed  []string        `json:"removed"`
+}
+
+// ConflictsDeltaDTO é o equivalente de FileChangesDeltaDTO para os conflitos.
+type ConflictsDeltaDTO struct {
+	Upserted []ConflictFileDTO `json:"upserted"`
+	Removed  []string          `json:"removed"`
+}
+
+// StatusDeltaDTO acompanha gitpanel:status_changed: o frontend aplica o delta quando o seq que tem
+// é BaseSeq e refaz GetStatus caso contrário. As listas mantêm a ordem por caminho do git status.
+type StatusDeltaDTO struct {
+	RepoRoot string `json:"repoRoot"`
+	Seq      uint64 `json:"seq"`
+	BaseSeq  uint64 `json:"baseSeq"`
+	Full     bool   `json:"full"`  // sem snapshot anterior: Upserted traz a lista inteira
+	Empty    bool   `json:"empty"` // nada mudou no status (diffs abertos ainda podem ter mudado)
+
+	Branch       string `json:"branch"`
+	Upstream     string `json:"upstream,omitempty"`
+	UpstreamGone bool   `json:"upstreamGone"`
+	Ahead        int    `json:"ahead"`
+	Behind       int    `json:"behind"`
+	Detached     bool   `json:"detached"`
+	HeadCommit   string `json:"headCommit,omitempty"`
+	Operation    string `json:"operation,omitempty"`
+
+	StagedCount     int `json:"stagedCount"`
+	UnstagedCount   int `json:"unstagedCount"`
+	ConflictedCount int `json:"conflictedCount"`
+
+	Staged     FileChangesDeltaDTO `json:"staged"`
+	Unstaged   FileChangesDeltaDTO `json:"unstaged"`
+	Conflicted ConflictsDeltaDTO   `json:"conflicted"`
 }
 
 // HistoryItemDTO representa item do histórico linear.
@@ -46,7 +101,18 @@ type HistoryItemDTO struct {
 	ChangedFiles    int    `json:"changedFiles"`
 	GitHubLogin     string `json:"githubLogin,omitempty"`
 	GitHubAvatarURL string `json:"githubAvatarUrl,omitempty"`
+	Signature       string `json:"signature,omitempty"` // verified | signed | unverified; vazio = sem assinatura
 	AuthorEmail     string `json:"-"`
+	// Issues são os tickets (Jira/Linear) citados no assunto, preenchidos pelo app.
+	Issues []IssueLinkDTO `json:"issues,omitempty"`
+}
+
+// IssueLinkDTO é um ticket de issue tracker citado em um commit; Title/Status ficam vazios até resolver.
+type IssueLinkDTO struct {
+	Key    string `json:"key"`
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status,omitempty"`
+	URL    string `json:"url,omitempty"`
 }
 
 // HistoryPageDTO representa página de histórico paginado.
@@ -54,6 +120,45 @@ type HistoryPageDTO struct {
 	Items      []HistoryItemDTO `json:"items"`
 	NextCursor string           `json:"nextCursor"`
 	HasMore    bool             `json:"hasMore"`
+	PathScope  string           `json:"pathScope,omitempty"`
+	// EstimatedTotal é a contagem de commits pré-calculada em background (0 enquanto não há).
+	EstimatedTotal int `json:"estimatedTotal,omitempty"`
+	// Detail é o nível de detalhe da página; no summary os itens vêm sem numstat.
+	Detail string `json:"detail,omitempty"`
+	// Warning avisa que a leitura pode ser lenta (partial clone baixa objetos sob demanda).
+	Warning string `json:"warning,omitempty"`
+}
+
+// HistoryCountEstimateDTO é o total de commits do HEAD usado para progresso na rolagem.
+type HistoryCountEstimateDTO struct {
+	RepoRoot   string `json:"repoRoot"`
+	PathScope  string `json:"pathScope,omitempty"`
+	Total      int    `json:"total"`
+	Head       string `json:"head,omitempty"`
+	Exact      bool   `json:"exact"`
+	ComputedAt string `json:"computedAt,omitempty"`
+}
+
+// HistoryStreamOptionsDTO configura a transmissão incremental do histórico.
+type HistoryStreamOptionsDTO struct {
+	Cursor    string `json:"cursor,omitempty"` // hash do último commit recebido; vazio = HEAD
+	Search    string `json:"search,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	BatchSize int    `json:"batchSize,omitempty"`
+	MaxItems  int    `json:"maxItems,omitempty"`
+}
+
+// HistoryStreamBatchDTO é um lote de commits transmitido; o último tem Done=true.
+type HistoryStreamBatchDTO struct {
+	StreamID       string           `json:"streamId,omitempty"`
+	RepoRoot       string           `json:"repoRoot"`
+	PathScope      string           `json:"pathScope,omitempty"`
+	Items          []HistoryItemDTO `json:"items"`
+	Loaded         int              `json:"loaded"`
+	EstimatedTotal int              `json:"estimatedTotal,omitempty"`
+	NextCursor     string           `json:"nextCursor,omitempty"`
+	HasMore        bool             `json:"hasMore"`
+	Done           bool             `json:"done"`
 }
 
 // CommitFileDTO representa arquivo alterado num commit.
@@ -70,31 +175,67 @@ type CommitDetailsDTO struct {
 
 // DiffLineDTO representa linha individual no diff estruturado.
 type DiffLineDTO struct {
-	Type    string `json:"type"`
-	Content string `json:"content"`
-	OldLine *int   `json:"oldLine,omitempty"`
-	NewLine *int   `json:"newLine,omitempty"`
+	Type    string         `json:"type"`
+	Content string         `json:"content"`
+	OldLine *int           `json:"oldLine,omitempty"`
+	NewLine *int           `json:"newLine,omitempty"`
+	Changes []WordRangeDTO `json:"changes,omitempty"` // trechos alterados na linha (word diff)
+}
+
+// WordRangeDTO delimita um trecho alterado dentro de Content.
+// Offsets em unidades UTF-16 (compatível com String.slice no frontend), End exclusivo.
+type WordRangeDTO struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// DiffOptionsDTO controla enriquecimentos opcionais do diff estruturado.
+type DiffOptionsDTO struct {
+	WordDiff bool `json:"wordDiff"`
 }
 
 // DiffHunkDTO representa bloco de alterações de um arquivo.
 type DiffHunkDTO struct {
-	Header   string        `json:"header"`
-	OldStart int           `json:"oldStart"`
-	OldLines int           `json:"oldLines"`
-	NewStart int           `json:"newStart"`
-	NewLines int           `json:"newLines"`
-	Lines    []DiffLineDTO `json:"lines"`
+	Header      string        `json:"header"`
+	OldStart    int           `json:"oldStart"`
+	OldLines    int           `json:"oldLines"`
+	NewStart    int           `json:"newStart"`
+	NewLines    int           `json:"newLines"`
+	Lines       []DiffLineDTO `json:"lines"`
+	Context     string        `json:"context,omitempty"`     // declaração envolvente, ex: "func (s *Service) CreatePullRequest"
+	ContextKind string        `json:"contextKind,omitempty"` // "function" | "method" | "class" | "type" | "section"
 }
 
 // DiffFileDTO representa um arquivo alterado no diff estruturado.
 type DiffFileDTO struct {
-	Path      string        `json:"path"`
-	OldPath   string        `json:"oldPath,omitempty"`
-	Status    string        `json:"status"`
-	Additions int           `json:"additions"`
-	Deletions int           `json:"deletions"`
-	IsBinary  bool          `json:"isBinary"`
-	Hunks     []DiffHunkDTO `json:"hunks"`
+	Path      string         `json:"path"`
+	OldPath   string         `json:"oldPath,omitempty"`
+	Status    string         `json:"status"`
+	Additions int            `json:"additions"`
+	Deletions int            `json:"deletions"`
+	IsBinary  bool           `json:"isBinary"`
+	Hunks     []DiffHunkDTO  `json:"hunks"`
+	Binary    *BinaryDiffDTO `json:"binary,omitempty"` // preview/resumo quando IsBinary
+}
+
+// BinaryBlobDTO descreve um lado (antigo/novo) de um arquivo binário.
+type BinaryBlobDTO struct {
+	Exists         bool   `json:"exists"`
+	Size           int64  `json:"size"`
+	Hash           string `json:"hash,omitempty"`
+	MimeType       string `json:"mimeType,omitempty"`
+	Width          int    `json:"width,omitempty"`
+	Height         int    `json:"height,omitempty"`
+	DataURL        string `json:"dataUrl,omitempty"`        // data:image/...;base64 (somente imagens, com limite de tamanho)
+	PreviewOmitted bool   `json:"previewOmitted,omitempty"` // imagem acima do limite de preview
+}
+
+// BinaryDiffDTO resume a alteração de um arquivo binário (com preview para imagens).
+type BinaryDiffDTO struct {
+	IsImage   bool          `json:"isImage"`
+	Old       BinaryBlobDTO `json:"old"`
+	New       BinaryBlobDTO `json:"new"`
+	SizeDelta int64         `json:"sizeDelta"`
 }
 
 // DiffDTO representa payload base de diff para o frontend.
@@ -120,3 +261,587 @@ type CommandResultDTO struct {
 	Attempt         int      `json:"attempt,omitempty"`
 	Error           string   `json:"error,omitempty"`
 }
+
+// IgnoreRulesDTO representa o conteúdo do .gitignore da raiz do repositório.
+type IgnoreRulesDTO struct {
+	Path     string   `json:"path"`
+	Exists   bool     `json:"exists"`
+	Content  string   `json:"content"`
+	Patterns []string `json:"patterns"`
+}
+
+// IgnoreSuggestionDTO representa um padrão sugerido a partir dos arquivos não rastreados.
+type IgnoreSuggestionDTO struct {
+	Pattern string   `json:"pattern"`
+	Reason  string   `json:"reason"`
+	Matches []string `json:"matches"`
+}
+
+// IgnoreCheckDTO representa o resultado de git check-ignore para um caminho.
+type IgnoreCheckDTO struct {
+	Path    string `json:"path"`
+	Ignored bool   `json:"ignored"`
+	Source  string `json:"source,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// FileHistoryItemDTO representa um commit que alterou o arquivo (seguindo renomeações).
+type FileHistoryItemDTO struct {
+	Hash       string `json:"hash"`
+	ShortHash  string `json:"shortHash"`
+	Author     string `json:"author"`
+	AuthoredAt string `json:"authoredAt"`
+	Subject    string `json:"subject"`
+	Status     string `json:"status"`            // M, A, D, R100...
+	Path       string `json:"path"`              // caminho do arquivo neste commit
+	OldPath    string `json:"oldPath,omitempty"` // caminho anterior quando renomeado
+}
+
+// FileHistoryPageDTO representa página do histórico de um arquivo.
+type FileHistoryPageDTO struct {
+	FilePath   string               `json:"filePath"`
+	Items      []FileHistoryItemDTO `json:"items"`
+	NextCursor string               `json:"nextCursor"`
+	HasMore    bool                 `json:"hasMore"`
+	Warning    string               `json:"warning,omitempty"` // partial clone: objetos baixados sob demanda
+}
+
+// ComparisonFileDTO resume um arquivo alterado entre dois branches (sem hunks).
+type ComparisonFileDTO struct {
+	Path      string `json:"path"`
+	OldPath   string `json:"oldPath,omitempty"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	IsBinary  bool   `json:"isBinary"`
+}
+
+// BranchComparisonDTO é a prévia do que um PR de head para base conteria.
+// Ahead/Behind são os totais; as listas de commits podem vir truncadas.
+type BranchComparisonDTO struct {
+	Base             string              `json:"base"`
+	Head             string              `json:"head"`
+	BaseHash         string              `json:"baseHash"`
+	HeadHash         string              `json:"headHash"`
+	MergeBase        string              `json:"mergeBase"`
+	Ahead            int                 `json:"ahead"`
+	Behind           int                 `json:"behind"`
+	AheadCommits     []HistoryItemDTO    `json:"aheadCommits"`
+	BehindCommits    []HistoryItemDTO    `json:"behindCommits"`
+	CommitsTruncated bool                `json:"commitsTruncated"`
+	Files            []ComparisonFileDTO `json:"files"`
+	Additions        int                 `json:"additions"`
+	Deletions        int                 `json:"deletions"`
+	Diff             DiffDTO             `json:"diff"`
+}
+
+// CommitOptionsDTO representa opções do commit feito pelo Git Panel.
+type CommitOptionsDTO struct {
+	NoVerify bool   `json:"noVerify"` // pula hooks pre-commit/commit-msg (exige permissão no workspace)
+	LintMode string `json:"-"`        // off | warn | block; definido pela App a partir do workspace
+	// SecretOverrides lista IDs de achados de segredo que o usuário aceitou commitar.
+	SecretOverrides []string `json:"secretOverrides,omitempty"`
+	// CoAuthors ("Nome <email>") viram trailers Co-authored-by no fim da mensagem.
+	CoAuthors []string `json:"coAuthors,omitempty"`
+}
+
+// HookRunDTO representa a execução de um hook Git durante o commit.
+// O Git redireciona o stdout dos hooks para stderr, então Output traz as duas saídas intercaladas.
+type HookRunDTO struct {
+	Name       string `json:"name"`
+	Command    string `json:"command,omitempty"`
+	ExitCode   int    `json:"exitCode"`
+	Failed     bool   `json:"failed"`
+	DurationMs int64  `json:"durationMs"`
+	Output     string `json:"output,omitempty"`
+}
+
+// CommitResultDTO representa o resultado estruturado de um commit, incluindo os hooks executados.
+type CommitResultDTO struct {
+	Committed  bool                 `json:"committed"`
+	CommitHash string               `json:"commitHash,omitempty"`
+	NoVerify   bool                 `json:"noVerify"`
+	FailedHook string               `json:"failedHook,omitempty"`
+	Hooks      []HookRunDTO         `json:"hooks"`
+	Output     string               `json:"output,omitempty"` // saída do git fora dos hooks
+	Lint       *CommitLintResultDTO `json:"lint,omitempty"`
+	Secrets    *SecretScanResultDTO `json:"secrets,omitempty"` // achados no stage; Blocking > 0 impede o commit
+	CoAuthors  []string             `json:"coAuthors,omitempty"`
+}
+
+// HookFrameworkDTO representa um gerenciador de hooks detectado no repositório.
+type HookFrameworkDTO struct {
+	Name       string `json:"name"` // husky | lint-staged | pre-commit | lefthook
+	ConfigPath string `json:"configPath,omitempty"`
+}
+
+// HookSetupDTO descreve os hooks de commit instalados e os frameworks que os gerenciam.
+type HookSetupDTO struct {
+	HooksPath     string             `json:"hooksPath"`
+	Installed     []string           `json:"installed"`
+	Frameworks    []HookFrameworkDTO `json:"frameworks"`
+	AllowNoVerify bool               `json:"allowNoVerify"` // preenchido pela App a partir do workspace
+}
+
+// CommitLintConfigDTO representa as regras Conventional Commits efetivas do repositório.
+type CommitLintConfigDTO struct {
+	Source          string   `json:"source"` // arquivo commitlint detectado ou "default"
+	Types           []string `json:"types"`
+	Scopes          []string `json:"scopes,omitempty"` // vazio = qualquer scope
+	ScopeRequired   bool     `json:"scopeRequired"`
+	HeaderMaxLength int      `json:"headerMaxLength"` // 0 = sem limite
+}
+
+// CommitLintIssueDTO representa uma violação encontrada na mensagem de commit.
+type CommitLintIssueDTO struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"` // error | warning
+	Message  string `json:"message"`
+}
+
+// CommitLintResultDTO representa a validação de uma mensagem de commit.
+type CommitLintResultDTO struct {
+	Valid    bool                 `json:"valid"`
+	Blocking bool                 `json:"blocking"`
+	Mode     string               `json:"mode"` // off | warn | block
+	Type     string               `json:"type,omitempty"`
+	Scope    string               `json:"scope,omitempty"`
+	Breaking bool                 `json:"breaking"`
+	Subject  string               `json:"subject,omitempty"`
+	Issues   []CommitLintIssueDTO `json:"issues"`
+	Config   CommitLintConfigDTO  `json:"config"`
+}
+
+// CommitSuggestionsDTO representa types/scopes sugeridos a partir dos arquivos em stage.
+type CommitSuggestionsDTO struct {
+	StagedCount int      `json:"stagedCount"`
+	Types       []string `json:"types"`
+	Scopes      []string `json:"scopes"`
+}
+
+// RepoStatsDTO representa as métricas de saúde do repositório (view "repo insights").
+type RepoStatsDTO struct {
+	RepoRoot          string            `json:"repoRoot"`
+	SizeBytes         int64             `json:"sizeBytes"`
+	ObjectCount       int64             `json:"objectCount"`
+	TrackedFiles      int               `json:"trackedFiles"`
+	LargestFiles      []RepoFileSizeDTO `json:"largestFiles"`
+	BranchCount       int               `json:"branchCount"`
+	RemoteBranchCount int               `json:"remoteBranchCount"`
+	TagCount          int               `json:"tagCount"`
+	StaleDays         int               `json:"staleDays"`
+	StaleBranches     []StaleBranchDTO  `json:"staleBranches"`
+	TotalCommits      int               `json:"totalCommits"`
+	ContributorCount  int               `json:"contributorCount"`
+	Contributors      []ContributorDTO  `json:"contributors"`     // top contribuidores por número de commits
+	CommitsByWeek     []CommitBucketDTO `json:"commitsByWeek"`    // últimas 52 semanas, da mais antiga para a atual
+	CommitsByWeekday  []int             `json:"commitsByWeekday"` // índice 0 = domingo
+	CommitsByHour     []int             `json:"commitsByHour"`
+	PartialClone      PartialCloneDTO   `json:"partialClone"`
+	SparseCheckout    SparseCheckoutDTO `json:"sparseCheckout"`
+	ComputedAt        string            `json:"computedAt"`
+}
+
+// RepoFileSizeDTO representa um arquivo versionado em HEAD e seu tamanho.
+type RepoFileSizeDTO struct {
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"sizeBytes"`
+}
+
+// StaleBranchDTO representa uma branch sem commits há mais de StaleDays.
+type StaleBranchDTO struct {
+	Name         string `json:"name"`
+	Remote       bool   `json:"remote"`
+	LastAuthor   string `json:"lastAuthor,omitempty"`
+	LastCommitAt string `json:"lastCommitAt"`
+	DaysInactive int    `json:"daysInactive"`
+}
+
+// ContributorDTO representa um autor e sua contagem de commits em HEAD.
+type ContributorDTO struct {
+	Name    string `json:"name"`
+	Email   string `json:"email,omitempty"`
+	Commits int    `json:"commits"`
+}
+
+// ContributorStatsDTO representa a contribuição de um autor no período (sem merges).
+type ContributorStatsDTO struct {
+	Name            string `json:"name"`
+	Email           string `json:"email,omitempty"`
+	Commits         int    `json:"commits"`
+	Additions       int    `json:"additions"`
+	Deletions       int    `json:"deletions"`
+	ActiveDays      int    `json:"activeDays"`
+	FirstCommitAt   string `json:"firstCommitAt"`
+	LastCommitAt    string `json:"lastCommitAt"`
+	LastCommitHash  string `json:"lastCommitHash"` // usado para resolver o login GitHub do autor
+	GitHubLogin     string `json:"githubLogin,omitempty"`
+	GitHubAvatarURL string `json:"githubAvatarUrl,omitempty"`
+}
+
+// ContributorStatsPageDTO representa uma página de estatísticas de contribuidores.
+type ContributorStatsPageDTO struct {
+	RepoRoot          string                `json:"repoRoot"`
+	RangeDays         int                   `json:"rangeDays"`
+	Items             []ContributorStatsDTO `json:"items"`
+	TotalContributors int                   `json:"totalContributors"`
+	TotalCommits      int                   `json:"totalCommits"`
+	NextCursor        string                `json:"nextCursor"`
+	HasMore           bool                  `json:"hasMore"`
+	ComputedAt        string                `json:"computedAt"`
+}
+
+// CommitBucketDTO representa a contagem de commits de uma semana (Start = segunda-feira).
+type CommitBucketDTO struct {
+	Start string `json:"start"`
+	Count int    `json:"count"`
+}
+
+// SecretFindingDTO representa um possível segredo em uma linha adicionada ao stage.
+type SecretFindingDTO struct {
+	ID          string `json:"id"` // estável por arquivo+regra+valor; usado para override
+	RuleID      string `json:"ruleId"`
+	Description string `json:"description"`
+	FilePath    string `json:"filePath"`
+	Line        int    `json:"line"`
+	Column      int    `json:"column"`
+	Preview     string `json:"preview"` // linha com o segredo mascarado
+	Overridden  bool   `json:"overridden"`
+}
+
+// SecretScanResultDTO representa a varredura de segredos do stage.
+type SecretScanResultDTO struct {
+	RepoPath     string             `json:"repoPath"`
+	ScannedFiles int                `json:"scannedFiles"`
+	Findings     []SecretFindingDTO `json:"findings"`
+	Blocking     int                `json:"blocking"` // achados sem override
+	Truncated    bool               `json:"truncated"`
+}
+
+// PushConfirmationDTO carrega as confirmações explícitas exigidas pelo guard de push.
+type PushConfirmationDTO struct {
+	ProtectedBranch bool `json:"protectedBranch"`
+	ForcePush       bool `json:"forcePush"`
+	LargeFiles      bool `json:"largeFiles"`
+}
+
+// PushOptionsDTO representa um push da UI (Branch vazio = branch atual, Remote vazio = upstream ou origin).
+type PushOptionsDTO struct {
+	Remote      string              `json:"remote,omitempty"`
+	Branch      string              `json:"branch,omitempty"`
+	Force       bool                `json:"force"` // usa --force-with-lease
+	SetUpstream bool                `json:"setUpstream"`
+	Confirm     PushConfirmationDTO `json:"confirm"`
+	// ProtectedBranches vem da proteção de branches do GitHub, resolvida pelo App.
+	ProtectedBranches []string `json:"-"`
+}
+
+// PushViolationDTO representa um risco detectado antes do push.
+type PushViolationDTO struct {
+	Kind      string `json:"kind"` // protected_branch | force_push | large_files
+	Message   string `json:"message"`
+	Confirmed bool   `json:"confirmed"`
+}
+
+// PushSafetyReportDTO representa o resultado das verificações pré-push.
+type PushSafetyReportDTO struct {
+	Remote                  string             `json:"remote"`
+	Branch                  string             `json:"branch"`
+	DefaultBranch           string             `json:"defaultBranch,omitempty"`
+	ProtectedBranch         bool               `json:"protectedBranch"`
+	ProtectedSource         string             `json:"protectedSource,omitempty"` // config | github | default
+	Force                   bool               `json:"force"`
+	LargeFiles              []RepoFileSizeDTO  `json:"largeFiles"`
+	LargeFileThresholdBytes int64              `json:"largeFileThresholdBytes"`
+	Violations              []PushViolationDTO `json:"violations"`
+	RequiresConfirmation    bool               `json:"requiresConfirmation"` // há violação sem confirmação
+}
+
+// PushResultDTO representa o resultado de um push guardado.
+type PushResultDTO struct {
+	Pushed bool                `json:"pushed"`
+	Safety PushSafetyReportDTO `json:"safety"`
+	Output string              `json:"output,omitempty"`
+}
+
+// FetchOptionsDTO representa um fetch; Remote vazio = todos os remotos.
+type FetchOptionsDTO struct {
+	Remote string `json:"remote,omitempty"`
+	Prune  bool   `json:"prune"`
+}
+
+// FetchResultDTO representa o resultado de um fetch.
+type FetchResultDTO struct {
+	Remote string `json:"remote,omitempty"`
+	Output string `json:"output,omitempty"`
+}
+
+// CacheTTLsDTO representa os TTLs (ms) dos caches de leitura do Git Panel; 0 = padrão.
+type CacheTTLsDTO struct {
+	PreflightMs int `json:"preflightMs"`
+	StatusMs    int `json:"statusMs"`
+	HistoryMs   int `json:"historyMs"`
+	DiffMs      int `json:"diffMs"`
+}
+
+// CacheKindMetricsDTO representa hits/misses e custo médio de cálculo de um tipo de cache.
+type CacheKindMetricsDTO struct {
+	Kind         string  `json:"kind"` // preflight | status | history | diff
+	Hits         uint64  `json:"hits"`
+	Misses       uint64  `json:"misses"`
+	HitRate      float64 `json:"hitRate"` // 0..1
+	Computes     uint64  `json:"computes"`
+	AvgComputeMs float64 `json:"avgComputeMs"`
+}
+
+// CacheMetricsDTO representa as métricas de cache desde Since (início ou último reset).
+type CacheMetricsDTO struct {
+	Since        string                `json:"since"`
+	Kinds        []CacheKindMetricsDTO `json:"kinds"`
+	Warmups      uint64                `json:"warmups"`
+	LastWarmupMs int64                 `json:"lastWarmupMs"`
+}
+
+// CacheWarmResultDTO representa o que foi pré-calculado por um warm-up.
+type CacheWarmResultDTO struct {
+	RepoRoot   string `json:"repoRoot"`
+	Status     bool   `json:"status"`
+	History    bool   `json:"history"`
+	Diffs      int    `json:"diffs"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// ExecRepoMetricsDTO representa espera e duração dos processos git de um repositório.
+type ExecRepoMetricsDTO struct {
+	RepoRoot            string  `json:"repoRoot"`
+	Commands            uint64  `json:"commands"`
+	AvgQueueWaitMs      float64 `json:"avgQueueWaitMs"` // espera por um worker do pool
+	MaxQueueWaitMs      int64   `json:"maxQueueWaitMs"`
+	AvgExecMs           float64 `json:"avgExecMs"`
+	MaxExecMs           int64   `json:"maxExecMs"`
+	Writes              uint64  `json:"writes"`
+	AvgWriteQueueWaitMs float64 `json:"avgWriteQueueWaitMs"` // espera na fila serial de writes
+	MaxWriteQueueWaitMs int64   `json:"maxWriteQueueWaitMs"`
+	RejectedWrites      uint64  `json:"rejectedWrites"` // recusados com E_BUSY
+	QueuedWrites        int     `json:"queuedWrites"`   // aguardando agora
+}
+
+// ExecMetricsDTO representa a ocupação do pool de processos git do Git Panel.
+type ExecMetricsDTO struct {
+	Since           string               `json:"since"`
+	Workers         int                  `json:"workers"`
+	WriteQueueDepth int                  `json:"writeQueueDepth"`
+	Running         int                  `json:"running"`
+	Waiting         int                  `json:"waiting"`
+	Commands        uint64               `json:"commands"`
+	RejectedWrites  uint64               `json:"rejectedWrites"`
+	AvgQueueWaitMs  float64              `json:"avgQueueWaitMs"`
+	AvgExecMs       float64              `json:"avgExecMs"`
+	Repos           []ExecRepoMetricsDTO `json:"repos"`
+}
+
+// ReadBackendDTO representa o backend de leitura (status/histórico) de um repositório.
+type ReadBackendDTO struct {
+	RepoRoot     string `json:"repoRoot"`
+	Backend      string `json:"backend"`                // cli | gogit
+	Override     bool   `json:"override"`               // escolhido para este repositório
+	LastFallback string `json:"lastFallback,omitempty"` // por que o go-git devolveu a leitura ao CLI
+}
+
+// ReadBenchmarkResultDTO representa os tempos de uma operação em um backend.
+type ReadBenchmarkResultDTO struct {
+	Backend   string  `json:"backend"`
+	Operation string  `json:"operation"` // status | history
+	AvgMs     float64 `json:"avgMs"`
+	MinMs     float64 `json:"minMs"`
+	MaxMs     float64 `json:"maxMs"`
+	Fallback  string  `json:"fallback,omitempty"` // o go-git caiu para o CLI (tempos incluem o CLI)
+	Error     string  `json:"error,omitempty"`
+}
+
+// ReadBenchmarkDTO compara os backends de leitura num repositório.
+type ReadBenchmarkDTO struct {
+	RepoRoot   string                   `json:"repoRoot"`
+	Iterations int                      `json:"iterations"`
+	Results    []ReadBenchmarkResultDTO `json:"results"`
+}
+
+// ChangelogPRDTO representa o PR associado a um commit do changelog.
+type ChangelogPRDTO struct {
+	Number int    `json:"number"`
+	URL    string `json:"url,omitempty"`
+	Title  string `json:"title,omitempty"`  // do cache do GitHub, quando disponível
+	Author string `json:"author,omitempty"` // login do autor do PR
+}
+
+// ChangelogEntryDTO representa um commit no changelog.
+type ChangelogEntryDTO struct {
+	Hash        string          `json:"hash"`
+	ShortHash   string          `json:"shortHash"`
+	Type        string          `json:"type"` // type Conventional Commits; vazio se a mensagem não segue o padrão
+	Scope       string          `json:"scope,omitempty"`
+	Description string          `json:"description"`
+	Breaking    bool            `json:"breaking"`
+	Author      string          `json:"author"`
+	PR          *ChangelogPRDTO `json:"pr,omitempty"`
+}
+
+// ChangelogSectionDTO agrupa as entradas de uma seção do changelog.
+type ChangelogSectionDTO struct {
+	Key     string              `json:"key"`
+	Title   string              `json:"title"`
+	Entries []ChangelogEntryDTO `json:"entries"`
+}
+
+// ChangelogDTO é o changelog gerado entre duas refs.
+type ChangelogDTO struct {
+	FromRef     string                `json:"fromRef"` // vazio = desde o primeiro commit
+	ToRef       string                `json:"toRef"`
+	FromHash    string                `json:"fromHash,omitempty"`
+	ToHash      string                `json:"toHash"`
+	Version     string                `json:"version"` // título da seção (tag de toRef ou "Unreleased")
+	Style       string                `json:"style"`
+	Date        string                `json:"date"` // AAAA-MM-DD do commit de toRef
+	Sections    []ChangelogSectionDTO `json:"sections"`
+	CommitCount int                   `json:"commitCount"`
+	Truncated   bool                  `json:"truncated"`
+	Markdown    string                `json:"markdown"`
+}
+
+// ChangelogFileResultDTO representa a gravação da seção no CHANGELOG.md.
+type ChangelogFileResultDTO struct {
+	Path     string `json:"path"`
+	Created  bool   `json:"created"`  // o arquivo não existia
+	Replaced bool   `json:"replaced"` // já havia uma seção da mesma versão
+}
+
+// BranchNamingPolicyDTO representa a política de nomes de branch do repositório.
+type BranchNamingPolicyDTO struct {
+	Templates []string `json:"templates"` // ex.: feature/{ticket}-{slug}; placeholders: ticket, slug, type, user
+	Mode      string   `json:"mode"`      // off | warn | block
+}
+
+// BranchNamingValidationDTO explica se o nome segue a política.
+type BranchNamingValidationDTO struct {
+	Branch          string   `json:"branch"`
+	Mode            string   `json:"mode"`
+	Valid           bool     `json:"valid"`
+	Blocking        bool     `json:"blocking"` // inválido com modo block
+	MatchedTemplate string   `json:"matchedTemplate,omitempty"`
+	Templates       []string `json:"templates"`
+	Violations      []string `json:"violations"` // um motivo por template
+}
+
+// BranchNameSuggestionDTO representa os nomes de branch sugeridos para uma tarefa.
+type BranchNameSuggestionDTO struct {
+	Name       string   `json:"name"`
+	Template   string   `json:"template"`
+	Type       string   `json:"type"`
+	Slug       string   `json:"slug"`
+	Source     string   `json:"source"` // heuristic | ai
+	Candidates []string `json:"candidates"`
+	Warning    string   `json:"warning,omitempty"`
+}
+
+// SigningKeyDTO representa uma chave de assinatura disponível na máquina.
+type SigningKeyDTO struct {
+	Format      string `json:"format"` // openpgp | ssh
+	ID          string `json:"id"`     // key ID (GPG) ou caminho da .pub (SSH); valor para user.signingkey
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Label       string `json:"label,omitempty"` // uid (GPG) ou comentário da chave (SSH)
+}
+
+// SigningStatusDTO descreve a configuração efetiva de assinatura de commits do repositório.
+type SigningStatusDTO struct {
+	Format             string          `json:"format"` // openpgp | ssh | x509
+	SigningKey         string          `json:"signingKey,omitempty"`
+	SignCommits        bool            `json:"signCommits"`
+	SignTags           bool            `json:"signTags"`
+	Program            string          `json:"program"`
+	ProgramAvailable   bool            `json:"programAvailable"`
+	KeyAvailable       bool            `json:"keyAvailable"`
+	AllowedSignersFile string          `json:"allowedSignersFile,omitempty"`
+	Configured         bool            `json:"configured"` // commits novos sairão assinados
+	AvailableKeys      []SigningKeyDTO `json:"availableKeys"`
+	Issues             []string        `json:"issues"`
+}
+
+// SigningSetupDTO é a escolha do assistente de configuração de assinatura.
+type SigningSetupDTO struct {
+	Format      string `json:"format"`
+	SigningKey  string `json:"signingKey"`
+	SignCommits bool   `json:"signCommits"`
+	SignTags    bool   `json:"signTags"`
+}
+
+// ReflogEntryDTO representa uma entrada do reflog do HEAD.
+type ReflogEntryDTO struct {
+	Selector  string `json:"selector"` // HEAD@{n}
+	Hash      string `json:"hash"`
+	ShortHash string `json:"shortHash"`
+	Date      string `json:"date,omitempty"`
+	Action    string `json:"action"` // commit | amend | merge | pull | reset | rebase | checkout | cherry-pick | revert | other
+	Detail    string `json:"detail,omitempty"`
+	Message   string `json:"message"` // texto original do reflog
+}
+
+// UndoPlanDTO descreve como a última operação será (ou foi) desfeita.
+type UndoPlanDTO struct {
+	Operation   string         `json:"operation"`
+	Entry       ReflogEntryDTO `json:"entry"`
+	Supported   bool           `json:"supported"`
+	Reason      string         `json:"reason,omitempty"` // por que não há undo automático
+	Description string         `json:"description,omitempty"`
+	Command     []string       `json:"command"` // argumentos do git (sem o "git")
+	CurrentHash string         `json:"currentHash,omitempty"`
+	TargetHash  string         `json:"targetHash,omitempty"`
+	Warnings    []string       `json:"warnings"`
+	DryRun      bool           `json:"dryRun"`
+	Applied     bool           `json:"applied"`
+}
+
+// PartialCloneDTO descreve o filtro de partial clone do repositório.
+type PartialCloneDTO struct {
+	Enabled  bool     `json:"enabled"`
+	Remote   string   `json:"remote,omitempty"`
+	Filter   string   `json:"filter,omitempty"` // blob:none | tree:0 | blob:limit=<n>
+	Promisor bool     `json:"promisor"`         // o remoto fornece objetos ausentes sob demanda
+	Warnings []string `json:"warnings"`
+}
+
+// GitConfigEntryDTO representa uma chave editável do git config.
+type GitConfigEntryDTO struct {
+	Key            string   `json:"key"`
+	Description    string   `json:"description"`
+	Kind           string   `json:"kind"` // text | email | bool | enum | tool | branch
+	Options        []string `json:"options"`
+	Value          string   `json:"value"` // valor no escopo pedido
+	Set            bool     `json:"set"`
+	EffectiveValue string   `json:"effectiveValue"`
+	EffectiveScope string   `json:"effectiveScope,omitempty"` // system | global | local | worktree | command
+}
+
+// GitConfigDTO lista as chaves editáveis de um escopo com avisos de identidade.
+type GitConfigDTO struct {
+	Scope    string              `json:"scope"` // local | global
+	RepoRoot string              `json:"repoRoot"`
+	Entries  []GitConfigEntryDTO `json:"entries"`
+	Warnings []string            `json:"warnings"`
+}
+
+// ForkSyncStatusDTO mostra quanto a branch padrão local e a do fork estão atrás do upstream.
+type ForkSyncStatusDTO struct {
+	UpstreamRemote string   `json:"upstreamRemote"`
+	ForkRemote     string   `json:"forkRemote,omitempty"`
+	DefaultBranch  string   `json:"defaultBranch"` // branch padrão do upstream ("" antes do primeiro fetch)
+	CurrentBranch  string   `json:"currentBranch,omitempty"`
+	LocalExists    bool     `json:"localExists"`
+	LocalAhead     int      `json:"localAhead"`  // commits locais que o upstream não tem
+	LocalBehind    int      `json:"localBehind"` // commits do upstream que faltam na branch local
+	ForkAhead      int      `json:"forkAhead"`
+	ForkBehind     int      `json:"forkBehind"` // commits do upstream que faltam no fork remoto
+	Strategy       string   `json:"strategy"`   // up_to_date | fast_forward | merge
+	Synced         bool     `json:"synced"`
+	Warnings       []string `json:"warnings"`
+}
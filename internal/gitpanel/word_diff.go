@@ -0,0 +1,190 @@
+package gitpanel
+
+import (
+	"unicode"
+	"unicode/utf16"
+)
+
+// maxWordDiffTokens limita o custo do LCS (O(n*m)) em linhas muito longas.
+const maxWordDiffTokens = 400
+
+type wordToken struct {
+	text  string
+	start int // offset UTF-16
+	end   int
+}
+
+// ApplyDiffOptions retorna uma cópia do diff com os enriquecimentos pedidos.
+// O DiffDTO original (possivelmente em cache) não é alterado.
+func ApplyDiffOptions(diff DiffDTO, options DiffOptionsDTO) DiffDTO {
+	if !options.WordDiff {
+		return diff
+	}
+
+	result := diff
+	result.Files = make([]DiffFileDTO, len(diff.Files))
+	for fileIdx, file := range diff.Files {
+		clonedFile := file
+		clonedFile.Hunks = make([]DiffHunkDTO, len(file.Hunks))
+		for hunkIdx, hunk := range file.Hunks {
+			clonedHunk := hunk
+			clonedHunk.Lines = make([]DiffLineDTO, len(hunk.Lines))
+			copy(clonedHunk.Lines, hunk.Lines)
+			applyWordDiffToHunk(clonedHunk.Lines)
+			clonedFile.Hunks[hunkIdx] = clonedHunk
+		}
+		result.Files[fileIdx] = clonedFile
+	}
+	return result
+}
+
+// applyWordDiffToHunk pareia blocos consecutivos de delete+add (linha a linha)
+// e marca os tokens que diferem entre cada par.
+func applyWordDiffToHunk(lines []DiffLineDTO) {
+	for idx := 0; idx < len(lines); {
+		if lines[idx].Type != "delete" {
+			idx++
+			continue
+		}
+
+		delStart := idx
+		for idx < len(lines) && lines[idx].Type == "delete" {
+			idx++
+		}
+		addStart := idx
+		for idx < len(lines) && lines[idx].Type == "add" {
+			idx++
+		}
+
+		deletes := addStart - delStart
+		adds := idx - addStart
+		pairs := deletes
+		if adds < pairs {
+			pairs = adds
+		}
+		for pair := 0; pair < pairs; pair++ {
+			oldLine := &lines[delStart+pair]
+			newLine := &lines[addStart+pair]
+			oldLine.Changes, newLine.Changes = computeWordRanges(oldLine.Content, newLine.Content)
+		}
+	}
+}
+
+func computeWordRanges(oldText string, newText string) ([]WordRangeDTO, []WordRangeDTO) {
+	oldTokens := tokenizeWords(oldText)
+	newTokens := tokenizeWords(newText)
+
+	if len(oldTokens) > maxWordDiffTokens || len(newTokens) > maxWordDiffTokens {
+		return wholeLineRange(oldTokens), wholeLineRange(newTokens)
+	}
+
+	// LCS clássico sobre tokens.
+	rows := len(oldTokens) + 1
+	cols := len(newTokens) + 1
+	table := make([]int, rows*cols)
+	for i := len(oldTokens) - 1; i >= 0; i-- {
+		for j := len(newTokens) - 1; j >= 0; j-- {
+			if oldTokens[i].text == newTokens[j].text {
+				table[i*cols+j] = table[(i+1)*cols+j+1] + 1
+			} else if table[(i+1)*cols+j] >= table[i*cols+j+1] {
+				table[i*cols+j] = table[(i+1)*cols+j]
+			} else {
+				table[i*cols+j] = table[i*cols+j+1]
+			}
+		}
+	}
+
+	oldChanged := make([]bool, len(oldTokens))
+	newChanged := make([]bool, len(newTokens))
+	i, j := 0, 0
+	for i < len(oldTokens) && j < len(newTokens) {
+		switch {
+		case oldTokens[i].text == newTokens[j].text:
+			i++
+			j++
+		case table[(i+1)*cols+j] >= table[i*cols+j+1]:
+			oldChanged[i] = true
+			i++
+		default:
+			newChanged[j] = true
+			j++
+		}
+	}
+	for ; i < len(oldTokens); i++ {
+		oldChanged[i] = true
+	}
+	for ; j < len(newTokens); j++ {
+		newChanged[j] = true
+	}
+
+	return mergeChangedTokens(oldTokens, oldChanged), mergeChangedTokens(newTokens, newChanged)
+}
+
+func mergeChangedTokens(tokens []wordToken, changed []bool) []WordRangeDTO {
+	ranges := make([]WordRangeDTO, 0)
+	for idx, token := range tokens {
+		if !changed[idx] {
+			continue
+		}
+		if last := len(ranges) - 1; last >= 0 && ranges[last].End == token.start {
+			ranges[last].End = token.end
+			continue
+		}
+		ranges = append(ranges, WordRangeDTO{Start: token.start, End: token.end})
+	}
+	if len(ranges) == 0 {
+		return nil
+	}
+	return ranges
+}
+
+func wholeLineRange(tokens []wordToken) []WordRangeDTO {
+	if len(tokens) == 0 {
+		return nil
+	}
+	return []WordRangeDTO{{Start: tokens[0].start, End: tokens[len(tokens)-1].end}}
+}
+
+// tokenizeWords separa a linha em palavras (letras/dígitos/_), blocos de espaço e pontuação isolada.
+func tokenizeWords(text string) []wordToken {
+	tokens := make([]wordToken, 0, len(text)/3+1)
+	runes := []rune(text)
+	offset := 0
+
+	classOf := func(r rune) int {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		default:
+			return 3
+		}
+	}
+	width := func(r rune) int {
+		if n := utf16.RuneLen(r); n > 0 {
+			return n
+		}
+		return 1
+	}
+
+	for idx := 0; idx < len(runes); {
+		class := classOf(runes[idx])
+		startIdx := idx
+		startOffset := offset
+		offset += width(runes[idx])
+		idx++
+		if class != 3 {
+			for idx < len(runes) && classOf(runes[idx]) == class {
+				offset += width(runes[idx])
+				idx++
+			}
+		}
+		tokens = append(tokens, wordToken{
+			text:  string(runes[startIdx:idx]),
+			start: startOffset,
+			end:   offset,
+		})
+	}
+	return tokens
+}
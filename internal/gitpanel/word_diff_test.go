@@ -0,0 +1,49 @@
+package gitpanel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeWordRangesMarksChangedTokens(t *testing.T) {
+	oldRanges, newRanges := computeWordRanges("return fmt.Errorf(\"bad\")", "return fmt.Errorf(\"worse: %w\", err)")
+
+	if want := []WordRangeDTO{{Start: 19, End: 22}}; !reflect.DeepEqual(oldRanges, want) {
+		t.Fatalf("unexpected old ranges: got=%v want=%v", oldRanges, want)
+	}
+	if want := []WordRangeDTO{{Start: 19, End: 28}, {Start: 29, End: 34}}; !reflect.DeepEqual(newRanges, want) {
+		t.Fatalf("unexpected new ranges: got=%v want=%v", newRanges, want)
+	}
+}
+
+func TestComputeWordRangesUsesUTF16Offsets(t *testing.T) {
+	_, newRanges := computeWordRanges("😀 ok", "😀 okay")
+	if want := []WordRangeDTO{{Start: 3, End: 7}}; !reflect.DeepEqual(newRanges, want) {
+		t.Fatalf("unexpected ranges: got=%v want=%v", newRanges, want)
+	}
+}
+
+func TestApplyDiffOptionsDoesNotMutateSource(t *testing.T) {
+	source := DiffDTO{Files: []DiffFileDTO{{
+		Path: "main.go",
+		Hunks: []DiffHunkDTO{{Lines: []DiffLineDTO{
+			{Type: "context", Content: "func main() {"},
+			{Type: "delete", Content: "\tx := 1"},
+			{Type: "add", Content: "\tx := 2"},
+			{Type: "add", Content: "\ty := 3"},
+		}}},
+	}}}
+
+	enriched := ApplyDiffOptions(source, DiffOptionsDTO{WordDiff: true})
+
+	lines := enriched.Files[0].Hunks[0].Lines
+	if want := []WordRangeDTO{{Start: 6, End: 7}}; !reflect.DeepEqual(lines[1].Changes, want) || !reflect.DeepEqual(lines[2].Changes, want) {
+		t.Fatalf("unexpected paired ranges: %+v / %+v", lines[1].Changes, lines[2].Changes)
+	}
+	if lines[3].Changes != nil {
+		t.Fatalf("unpaired add line should not carry ranges: %+v", lines[3].Changes)
+	}
+	if source.Files[0].Hunks[0].Lines[1].Changes != nil {
+		t.Fatalf("source diff must not be mutated")
+	}
+}
@@ -0,0 +1,245 @@
+package gitprs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxCodeOwnersBytes é o limite do GitHub: arquivos CODEOWNERS maiores são ignorados.
+const maxCodeOwnersBytes = 3 << 20
+
+// CodeOwnersPaths segue a ordem em que o GitHub procura o arquivo; vale o primeiro encontrado.
+var CodeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+var codeOwnerRegex = regexp.MustCompile(`^(@[A-Za-z0-9][A-Za-z0-9-]*(/[A-Za-z0-9._-]+)?|[^@\s]+@[^@\s]+\.[^@\s]+)$`)
+
+// CodeOwnerRule é uma linha válida do CODEOWNERS. Sem owners, o padrão marca os caminhos como
+// sem dono (sobrescreve regras anteriores).
+type CodeOwnerRule struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+	Line    int      `json:"line"`
+	matcher *regexp.Regexp
+}
+
+// CodeOwners é o arquivo interpretado; a última regra que casa com o caminho vence.
+type CodeOwners struct {
+	Source       string          `json:"source"`
+	Rules        []CodeOwnerRule `json:"rules"`
+	InvalidLines []int           `json:"invalidLines"` // padrões não suportados ou owners inválidos
+}
+
+// FileOwners são os owners de um arquivo alterado.
+type FileOwners struct {
+	Path   string   `json:"path"`
+	Owners []string `json:"owners"`
+	Line   int      `json:"line,omitempty"` // linha da regra aplicada; 0 = nenhuma
+}
+
+// CodeOwnersReport resume os owners dos arquivos alterados numa branch ou PR.
+type CodeOwnersReport struct {
+	Source             string       `json:"source"` // arquivo CODEOWNERS usado; vazio quando não há
+	Files              []FileOwners `json:"files"`
+	Owners             []string     `json:"owners"`             // @usuario, @org/time ou e-mail
+	SuggestedReviewers []string     `json:"suggestedReviewers"` // logins para requested_reviewers
+	SuggestedTeams     []string     `json:"suggestedTeams"`     // slugs para team_reviewers
+	UnownedPaths       []string     `json:"unownedPaths"`
+	InvalidLines       []int        `json:"invalidLines"`
+	Warnings           []string     `json:"warnings"`
+}
+
+// ParseCodeOwners interpreta o conteúdo de um CODEOWNERS. Negação (!) e colchetes não são
+// suportados pelo GitHub e viram InvalidLines, assim como owners em formato inválido.
+func ParseCodeOwners(source string, content string) CodeOwners {
+	parsed := CodeOwners{Source: source, Rules: []CodeOwnerRule{}, InvalidLines: []int{}}
+	for index, raw := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		pattern := fields[0]
+		if strings.HasPrefix(pattern, `\#`) {
+			pattern = pattern[1:]
+		}
+		matcher, ok := compileCodeOwnersPattern(pattern)
+		if !ok {
+			parsed.InvalidLines = append(parsed.InvalidLines, index+1)
+			continue
+		}
+		owners := []string{}
+		valid := true
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			if !codeOwnerRegex.MatchString(owner) {
+				valid = false
+				break
+			}
+			owners = append(owners, owner)
+		}
+		if !valid {
+			parsed.InvalidLines = append(parsed.InvalidLines, index+1)
+			continue
+		}
+		parsed.Rules = append(parsed.Rules, CodeOwnerRule{Pattern: pattern, Owners: owners, Line: index + 1, matcher: matcher})
+	}
+	return parsed
+}
+
+// FindLocalCodeOwners lê o primeiro CODEOWNERS do clone (ver CodeOwnersPaths).
+func FindLocalCodeOwners(repoRoot string) (CodeOwners, bool) {
+	for _, candidate := range CodeOwnersPaths {
+		fullPath := filepath.Join(repoRoot, filepath.FromSlash(candidate))
+		info, err := os.Lstat(fullPath)
+		if err != nil || !info.Mode().IsRegular() || info.Size() > maxCodeOwnersBytes {
+			continue
+		}
+		content, err := os.ReadFile(fullPath)
+		if err != nil {
+			continue
+		}
+		return ParseCodeOwners(candidate, string(content)), true
+	}
+	return CodeOwners{}, false
+}
+
+// OwnersFor devolve os owners do caminho pela última regra que casa; line=0 sem regra.
+func (c CodeOwners) OwnersFor(filePath string) (owners []string, line int) {
+	filePath = strings.TrimPrefix(filepath.ToSlash(filePath), "/")
+	for i := len(c.Rules) - 1; i >= 0; i-- {
+		if c.Rules[i].matcher != nil && c.Rules[i].matcher.MatchString(filePath) {
+			return c.Rules[i].Owners, c.Rules[i].Line
+		}
+	}
+	return nil, 0
+}
+
+// BuildCodeOwnersReport calcula os owners dos arquivos alterados, os reviewers sugeridos e os
+// caminhos sem dono. found=false indica que o repositório não tem CODEOWNERS.
+func BuildCodeOwnersReport(codeOwners CodeOwners, found bool, files []string) CodeOwnersReport {
+	report := CodeOwnersReport{
+		Files:              []FileOwners{},
+		Owners:             []string{},
+		SuggestedReviewers: []string{},
+		SuggestedTeams:     []string{},
+		UnownedPaths:       []string{},
+		InvalidLines:       []int{},
+		Warnings:           []string{},
+	}
+	if !found {
+		report.Warnings = append(report.Warnings, "Repositorio sem arquivo CODEOWNERS.")
+		return report
+	}
+	report.Source = codeOwners.Source
+	report.InvalidLines = append(report.InvalidLines, codeOwners.InvalidLines...)
+
+	seenFiles := map[string]bool{}
+	seenOwners := map[string]bool{}
+	for _, file := range files {
+		file = strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(file)), "/")
+		if file == "" || seenFiles[file] {
+			continue
+		}
+		seenFiles[file] = true
+		owners, line := codeOwners.OwnersFor(file)
+		report.Files = append(report.Files, FileOwners{Path: file, Owners: append([]string{}, owners...), Line: line})
+		if len(owners) == 0 {
+			report.UnownedPaths = append(report.UnownedPaths, file)
+		}
+		for _, owner := range owners {
+			if seenOwners[owner] {
+				continue
+			}
+			seenOwners[owner] = true
+			report.Owners = append(report.Owners, owner)
+			switch login := strings.TrimPrefix(owner, "@"); {
+			case !strings.HasPrefix(owner, "@"):
+				// E-mail: o GitHub não aceita como reviewer pela API.
+			case strings.Contains(login, "/"):
+				report.SuggestedTeams = append(report.SuggestedTeams, login[strings.Index(login, "/")+1:])
+			default:
+				report.SuggestedReviewers = append(report.SuggestedReviewers, login)
+			}
+		}
+	}
+	sort.Strings(report.Owners)
+	sort.Strings(report.SuggestedReviewers)
+	sort.Strings(report.SuggestedTeams)
+
+	if count := len(report.UnownedPaths); count > 0 {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%d arquivo(s) alterado(s) sem owner no %s.", count, codeOwners.Source))
+	}
+	if len(report.InvalidLines) > 0 {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("Linhas ignoradas no %s: %v.", codeOwners.Source, report.InvalidLines))
+	}
+	return report
+}
+
+// compileCodeOwnersPattern converte o padrão (sintaxe do .gitignore, sem ! e []) em regex
+// sobre caminhos relativos à raiz. Como no GitHub, "docs/*" não alcança subpastas.
+func compileCodeOwnersPattern(pattern string) (*regexp.Regexp, bool) {
+	if pattern == "" || strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]") {
+		return nil, false
+	}
+	if pattern == "*" || pattern == "/*" || pattern == "**" || pattern == "/**" {
+		return regexp.MustCompile(`^.+$`), true
+	}
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" {
+		return nil, false
+	}
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	segments := strings.Split(trimmed, "/")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if segment == "**" {
+			if last {
+				b.WriteString(".*")
+			} else {
+				b.WriteString("(?:.*/)?")
+			}
+			continue
+		}
+		for _, r := range segment {
+			switch r {
+			case '*':
+				b.WriteString("[^/]*")
+			case '?':
+				b.WriteString("[^/]")
+			default:
+				b.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		if !last {
+			b.WriteString("/")
+		}
+	}
+	lastSegment := segments[len(segments)-1]
+	switch {
+	case dirOnly:
+		b.WriteString("/.+$")
+	case lastSegment == "**" || strings.ContainsAny(lastSegment, "*?"):
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.+)?$") // arquivo ou pasta com tudo abaixo dela
+	}
+	matcher, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, false
+	}
+	return matcher, true
+}
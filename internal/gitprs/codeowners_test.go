@@ -0,0 +1,88 @@
+package gitprs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const sampleCodeOwners = `# Owners padrão
+*                @orch-labs/core
+*.go             @ana
+/docs/           docs@example.com
+apps/            @orch-labs/frontend @bruno
+internal/*       @caio
+/internal/vendor
+!internal/keep.go @ana
+scripts/** @invalid owner!
+`
+
+func TestParseCodeOwnersMatchesLikeGitHub(t *testing.T) {
+	parsed := ParseCodeOwners(".github/CODEOWNERS", sampleCodeOwners)
+	if !reflect.DeepEqual(parsed.InvalidLines, []int{8, 9}) {
+		t.Fatalf("InvalidLines = %v, want [8 9]", parsed.InvalidLines)
+	}
+
+	cases := map[string][]string{
+		"README.md":                 {"@orch-labs/core"},
+		"cmd/orch/main.go":          {"@ana"},
+		"docs/guide/setup.md":       {"docs@example.com"},
+		"web/apps/login/index.ts":   {"@orch-labs/frontend", "@bruno"},
+		"internal/helpers.txt":      {"@caio"},
+		"internal/session/types.rb": {"@orch-labs/core"}, // internal/* não alcança subpastas
+		"internal/vendor/lib.c":     nil,                 // regra sem owners remove o dono
+	}
+	for path, want := range cases {
+		got, _ := parsed.OwnersFor(path)
+		if len(want) == 0 && len(got) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("OwnersFor(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestBuildCodeOwnersReport(t *testing.T) {
+	parsed := ParseCodeOwners("CODEOWNERS", "/src/ @ana @orch-labs/backend\n/src/generated/\n/docs/*.md @bruno @ana\n")
+	report := BuildCodeOwnersReport(parsed, true, []string{"src/app.go", "docs/intro.md", "src/generated/api.go", "Makefile", "src/app.go"})
+
+	if report.Source != "CODEOWNERS" || len(report.Files) != 4 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if !reflect.DeepEqual(report.Owners, []string{"@ana", "@bruno", "@orch-labs/backend"}) {
+		t.Fatalf("Owners = %v", report.Owners)
+	}
+	if !reflect.DeepEqual(report.SuggestedReviewers, []string{"ana", "bruno"}) || !reflect.DeepEqual(report.SuggestedTeams, []string{"backend"}) {
+		t.Fatalf("unexpected suggestions: reviewers=%v teams=%v", report.SuggestedReviewers, report.SuggestedTeams)
+	}
+	if !reflect.DeepEqual(report.UnownedPaths, []string{"src/generated/api.go", "Makefile"}) || len(report.Warnings) != 1 {
+		t.Fatalf("unexpected unowned paths: %v warnings=%v", report.UnownedPaths, report.Warnings)
+	}
+
+	missing := BuildCodeOwnersReport(CodeOwners{}, false, []string{"a.go"})
+	if missing.Source != "" || len(missing.Files) != 0 || len(missing.Warnings) != 1 {
+		t.Fatalf("unexpected report without CODEOWNERS: %+v", missing)
+	}
+}
+
+func TestFindLocalCodeOwnersPrefersGitHubDir(t *testing.T) {
+	root := t.TempDir()
+	if _, ok := FindLocalCodeOwners(root); ok {
+		t.Fatalf("expected no CODEOWNERS in empty repo")
+	}
+	for path, content := range map[string]string{"CODEOWNERS": "* @root\n", ".github/CODEOWNERS": "* @github\n"} {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	parsed, ok := FindLocalCodeOwners(root)
+	if owners, _ := parsed.OwnersFor("main.go"); !ok || parsed.Source != ".github/CODEOWNERS" || !reflect.DeepEqual(owners, []string{"@github"}) {
+		t.Fatalf("unexpected CODEOWNERS: %+v", parsed)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"orch/internal/i18n"
 )
 
 const (
@@ -19,7 +21,10 @@ const (
 	CodeNotFound           = "E_PR_NOT_FOUND"
 	CodeConflict           = "E_PR_CONFLICT"
 	CodeValidationFailed   = "E_PR_VALIDATION_FAILED"
+	CodePushNotConfirmed   = "E_PR_PUSH_NOT_CONFIRMED"
 	CodeRateLimited        = "E_PR_RATE_LIMITED"
+	CodeSSORequired        = "E_PR_SSO_REQUIRED"
+	CodeCanceled           = "E_PR_CANCELED"
 	CodeUnknown            = "E_PR_UNKNOWN"
 )
 
@@ -28,6 +33,8 @@ type BindingError struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
+	// MessageID aponta a mensagem genérica do código no catálogo i18n (texto no idioma do usuário).
+	MessageID string `json:"messageId,omitempty"`
 }
 
 func (e *BindingError) Error() string {
@@ -43,9 +50,10 @@ func (e *BindingError) Error() string {
 
 func NewBindingError(code, message, details string) *BindingError {
 	return &BindingError{
-		Code:    strings.TrimSpace(code),
-		Message: strings.TrimSpace(message),
-		Details: strings.TrimSpace(details),
+		Code:      strings.TrimSpace(code),
+		Message:   strings.TrimSpace(message),
+		Details:   strings.TrimSpace(details),
+		MessageID: i18n.ErrorID(code),
 	}
 }
 
@@ -94,6 +102,20 @@ func NormalizeBindingError(err error) *BindingError {
 	)
 }
 
+// NewSSOBindingError sinaliza que a org exige autorizar o token via SAML SSO.
+func NewSSOBindingError(org, authorizationURL string) *BindingError {
+	details := "org=" + strings.TrimSpace(org)
+	if strings.TrimSpace(authorizationURL) != "" {
+		details += " url=" + strings.TrimSpace(authorizationURL)
+	}
+	return NewBindingError(CodeSSORequired, messageForHTTPCode(CodeSSORequired), details)
+}
+
+// NewCanceledBindingError sinaliza operacao abortada pelo usuario (CancelOperation).
+func NewCanceledBindingError(details string) *BindingError {
+	return NewBindingError(CodeCanceled, messageForHTTPCode(CodeCanceled), details)
+}
+
 // NewHTTPBindingError converte status HTTP do GitHub em erro de dominio padrao.
 func NewHTTPBindingError(statusCode int, details string) *BindingError {
 	code := CodeForHTTPStatus(statusCode)
@@ -134,6 +156,10 @@ func messageForHTTPCode(code string) string {
 		return "Payload invalido para operacao de Pull Request."
 	case CodeRateLimited:
 		return "Rate limit excedido para operacoes de Pull Request."
+	case CodeSSORequired:
+		return "Organizacao exige autorizacao SAML SSO para o token GitHub."
+	case CodeCanceled:
+		return "Operacao de Pull Request cancelada."
 	default:
 		return "Falha ao executar operacao de Pull Requests."
 	}
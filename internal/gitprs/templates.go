@@ -0,0 +1,142 @@
+package gitprs
+
+import (
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	TemplateSourceLocal  = "local"
+	TemplateSourceRemote = "remote"
+
+	// maxTemplateBytes evita carregar arquivos enormes como descrição de PR.
+	maxTemplateBytes = 64 << 10
+)
+
+// Template é um template de descrição de Pull Request do repositório.
+type Template struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"` // relativo à raiz do repositório
+	Body   string `json:"body"`
+	Source string `json:"source"`
+}
+
+// templateDirs segue a ordem em que o GitHub procura os templates.
+var templateDirs = []string{".github", "", "docs"}
+
+// jiraKeyRegex remove chaves como PROJ-123, que não são issues do GitHub.
+var jiraKeyRegex = regexp.MustCompile(`[A-Z][A-Z0-9]+-\d+`)
+
+// FindLocalTemplates procura PULL_REQUEST_TEMPLATE(.md|.txt) e a pasta PULL_REQUEST_TEMPLATE/
+// na raiz, em .github/ e em docs/. Arquivos ilegíveis ou symlinks são ignorados.
+func FindLocalTemplates(repoRoot string) []Template {
+	templates := make([]Template, 0, 2)
+	for _, dir := range templateDirs {
+		entries, err := os.ReadDir(filepath.Join(repoRoot, dir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := strings.ToLower(entry.Name())
+			switch {
+			case entry.Type().IsRegular() && isTemplateFileName(name):
+				if tpl, ok := readTemplate(repoRoot, path.Join(dir, entry.Name())); ok {
+					templates = append(templates, tpl)
+				}
+			case entry.IsDir() && name == "pull_request_template":
+				templates = append(templates, readTemplateDir(repoRoot, path.Join(dir, entry.Name()))...)
+			}
+		}
+	}
+	return templates
+}
+
+// SuggestIssueLinks extrai números de issue do nome da branch (ex.: fix/123-login, gh-45)
+// e devolve linhas "Fixes #N" na ordem em que aparecem.
+func SuggestIssueLinks(branch string) []string {
+	cleaned := jiraKeyRegex.ReplaceAllString(strings.TrimSpace(branch), "")
+	tokens := strings.FieldsFunc(cleaned, func(r rune) bool {
+		return r == '/' || r == '-' || r == '_'
+	})
+
+	links := make([]string, 0, 1)
+	seen := make(map[string]bool)
+	for _, token := range tokens {
+		token = strings.TrimPrefix(token, "#")
+		if token == "" || len(token) > 7 || token[0] == '0' {
+			continue
+		}
+		if _, err := strconv.Atoi(token); err != nil || seen[token] {
+			continue
+		}
+		seen[token] = true
+		links = append(links, "Fixes #"+token)
+	}
+	return links
+}
+
+// PrefillBody junta o corpo do template às sugestões de vínculo, sem repetir issues já citadas.
+func PrefillBody(templateBody string, issueLinks []string) string {
+	body := strings.TrimRight(templateBody, "\r\n\t ")
+	missing := make([]string, 0, len(issueLinks))
+	for _, link := range issueLinks {
+		number := link[strings.LastIndex(link, "#")+1:]
+		if regexp.MustCompile(`#` + number + `\b`).MatchString(body) {
+			continue
+		}
+		missing = append(missing, link)
+	}
+	if len(missing) == 0 {
+		return body
+	}
+	if body == "" {
+		return strings.Join(missing, "\n")
+	}
+	return body + "\n\n" + strings.Join(missing, "\n")
+}
+
+func isTemplateFileName(lowerName string) bool {
+	return lowerName == "pull_request_template.md" || lowerName == "pull_request_template.txt" || lowerName == "pull_request_template"
+}
+
+func readTemplateDir(repoRoot string, dir string) []Template {
+	entries, err := os.ReadDir(filepath.Join(repoRoot, dir))
+	if err != nil {
+		return nil
+	}
+	templates := make([]Template, 0, len(entries))
+	for _, entry := range entries {
+		ext := strings.ToLower(path.Ext(entry.Name()))
+		if !entry.Type().IsRegular() || (ext != ".md" && ext != ".txt") {
+			continue
+		}
+		if tpl, ok := readTemplate(repoRoot, path.Join(dir, entry.Name())); ok {
+			templates = append(templates, tpl)
+		}
+	}
+	return templates
+}
+
+func readTemplate(repoRoot string, relPath string) (Template, bool) {
+	file, err := os.Open(filepath.Join(repoRoot, filepath.FromSlash(relPath)))
+	if err != nil {
+		return Template{}, false
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxTemplateBytes))
+	if err != nil {
+		return Template{}, false
+	}
+	return Template{
+		Name:   path.Base(relPath),
+		Path:   relPath,
+		Body:   string(content),
+		Source: TemplateSourceLocal,
+	}, true
+}
@@ -0,0 +1,72 @@
+package gitprs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindLocalTemplatesCoversFileAndDirectoryLayouts(t *testing.T) {
+	repoRoot := t.TempDir()
+	files := map[string]string{
+		".github/pull_request_template.md":         "## Resumo\n",
+		".github/PULL_REQUEST_TEMPLATE/feature.md": "## Feature\n",
+		".github/PULL_REQUEST_TEMPLATE/notes.png":  "binary",
+		"docs/PULL_REQUEST_TEMPLATE.txt":           "docs template\n",
+		"README.md":                                "not a template\n",
+	}
+	for rel, content := range files {
+		full := filepath.Join(repoRoot, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	templates := FindLocalTemplates(repoRoot)
+	paths := make([]string, 0, len(templates))
+	for _, tpl := range templates {
+		if tpl.Source != TemplateSourceLocal || tpl.Body != files[tpl.Path] {
+			t.Fatalf("unexpected template: %+v", tpl)
+		}
+		paths = append(paths, tpl.Path)
+	}
+	want := []string{".github/PULL_REQUEST_TEMPLATE/feature.md", ".github/pull_request_template.md", "docs/PULL_REQUEST_TEMPLATE.txt"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("paths = %q, want %q", paths, want)
+	}
+
+	if got := FindLocalTemplates(t.TempDir()); len(got) != 0 {
+		t.Fatalf("expected no templates, got %+v", got)
+	}
+}
+
+func TestSuggestIssueLinksFromBranchName(t *testing.T) {
+	cases := map[string][]string{
+		"fix/123-login-redirect":   {"Fixes #123"},
+		"gh-45_and-46":             {"Fixes #45", "Fixes #46"},
+		"feature/#7":               {"Fixes #7"},
+		"feature/PROJ-321-payment": {},
+		"release/1.2.3":            {},
+		"hotfix/v2-crash":          {},
+		"main":                     {},
+	}
+	for branch, want := range cases {
+		if got := SuggestIssueLinks(branch); !reflect.DeepEqual(got, want) {
+			t.Errorf("SuggestIssueLinks(%q) = %q, want %q", branch, got, want)
+		}
+	}
+}
+
+func TestPrefillBodySkipsIssuesAlreadyReferenced(t *testing.T) {
+	body := PrefillBody("## Resumo\n\nRelacionado a #12\n\n", []string{"Fixes #12", "Fixes #123"})
+	if body != "## Resumo\n\nRelacionado a #12\n\nFixes #123" {
+		t.Fatalf("unexpected body: %q", body)
+	}
+	if got := PrefillBody("", []string{"Fixes #9"}); got != "Fixes #9" {
+		t.Fatalf("unexpected body without template: %q", got)
+	}
+}
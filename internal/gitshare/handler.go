@@ -0,0 +1,138 @@
+package gitshare
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gp "orch/internal/gitpanel"
+)
+
+// Rotas servidas pelo gateway do host (registradas com o prefixo RoutePrefix). Todas exigem
+// sessionID e guestUserID na query e só respondem a guests aprovados de uma sessão com share ativo.
+const (
+	RoutePrefix  = "/api/session/gitpanel/"
+	RouteInfo    = "/api/session/gitpanel/info"
+	RouteStatus  = "/api/session/gitpanel/status"
+	RouteHistory = "/api/session/gitpanel/history"
+	RouteDiff    = "/api/session/gitpanel/diff"
+
+	maxHistoryLimit = 200
+)
+
+// ErrRedacted indica um arquivo ocultado pelo host.
+var ErrRedacted = errors.New("file hidden by the session host")
+
+// Backend é implementado pelo app do host; as leituras recebem a raiz do repositório compartilhado.
+type Backend interface {
+	AuthorizeGuest(sessionID, guestUserID string) error
+	Status(repoRoot string) (gp.StatusDTO, error)
+	History(repoRoot string, cursor string, limit int, query string) (gp.HistoryPageDTO, error)
+	Diff(repoRoot string, filePath string, mode string, contextLines int) (gp.DiffDTO, error)
+}
+
+// RequestPath monta o caminho (com query) de uma rota do share para o guest.
+func RequestPath(route string, sessionID string, guestUserID string, params url.Values) string {
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = append([]string(nil), values...)
+	}
+	query.Set("sessionID", strings.TrimSpace(sessionID))
+	query.Set("guestUserID", strings.TrimSpace(guestUserID))
+	return route + "?" + query.Encode()
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewHandler expõe o Git Panel compartilhado em modo somente leitura.
+func NewHandler(registry *Registry, backend Backend) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(RouteInfo, func(w http.ResponseWriter, r *http.Request) {
+		share, ok := authorize(w, r, registry, backend)
+		if !ok {
+			return
+		}
+		writeJSON(w, http.StatusOK, share.GuestView())
+	})
+	mux.HandleFunc(RouteStatus, func(w http.ResponseWriter, r *http.Request) {
+		share, ok := authorize(w, r, registry, backend)
+		if !ok {
+			return
+		}
+		status, err := backend.Status(share.RepoRoot)
+		writeResult(w, share, share.RedactStatus(status), err)
+	})
+	mux.HandleFunc(RouteHistory, func(w http.ResponseWriter, r *http.Request) {
+		share, ok := authorize(w, r, registry, backend)
+		if !ok {
+			return
+		}
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		page, err := backend.History(share.RepoRoot, query.Get("cursor"), min(max(limit, 0), maxHistoryLimit), query.Get("query"))
+		writeResult(w, share, page, err)
+	})
+	mux.HandleFunc(RouteDiff, func(w http.ResponseWriter, r *http.Request) {
+		share, ok := authorize(w, r, registry, backend)
+		if !ok {
+			return
+		}
+		query := r.URL.Query()
+		filePath := strings.TrimSpace(query.Get("filePath"))
+		if share.IsRedacted(filePath) {
+			writeJSON(w, http.StatusForbidden, errorResponse{Error: ErrRedacted.Error()})
+			return
+		}
+		contextLines, _ := strconv.Atoi(query.Get("contextLines"))
+		diff, err := backend.Diff(share.RepoRoot, filePath, query.Get("mode"), contextLines)
+		writeResult(w, share, share.RedactDiff(diff), err)
+	})
+	return mux
+}
+
+func authorize(w http.ResponseWriter, r *http.Request, registry *Registry, backend Backend) (Share, bool) {
+	if r.Method != http.MethodGet {
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+		return Share{}, false
+	}
+	sessionID := strings.TrimSpace(r.URL.Query().Get("sessionID"))
+	guestUserID := strings.TrimSpace(r.URL.Query().Get("guestUserID"))
+	if sessionID == "" || guestUserID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "sessionID and guestUserID are required"})
+		return Share{}, false
+	}
+	if err := backend.AuthorizeGuest(sessionID, guestUserID); err != nil {
+		writeJSON(w, http.StatusForbidden, errorResponse{Error: err.Error()})
+		return Share{}, false
+	}
+	share, ok := registry.Get(sessionID)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: "git panel is not shared in this session"})
+		return Share{}, false
+	}
+	return share, true
+}
+
+// writeResult troca o caminho local do host pelo nome do repositório nas mensagens de erro.
+func writeResult(w http.ResponseWriter, share Share, payload interface{}, err error) {
+	if err != nil {
+		message := err.Error()
+		if share.RepoRoot != "" {
+			message = strings.ReplaceAll(message, share.RepoRoot, share.RepoName)
+		}
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: message})
+		return
+	}
+	writeJSON(w, http.StatusOK, payload)
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,186 @@
+package gitshare
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gp "orch/internal/gitpanel"
+)
+
+// Share é o repositório cujo Git Panel o host compartilha (somente leitura) com os guests de uma sessão.
+type Share struct {
+	SessionID string `json:"sessionId"`
+	// RepoRoot nunca sai do host: as rotas dos guests devolvem só RepoName.
+	RepoRoot       string    `json:"repoRoot,omitempty"`
+	RepoName       string    `json:"repoName"`
+	RedactPatterns []string  `json:"redactPatterns,omitempty"`
+	SharedAt       time.Time `json:"sharedAt"`
+}
+
+// GuestView é a cópia do share exposta aos guests (sem o caminho local do host).
+func (s Share) GuestView() Share {
+	s.RepoRoot = ""
+	s.RedactPatterns = append([]string(nil), s.RedactPatterns...)
+	return s
+}
+
+// NormalizeRedactPatterns limpa e valida os padrões (sintaxe de path.Match, relativos à raiz do
+// repositório). "dir/" oculta o diretório inteiro; padrões sem "/" valem para o nome do arquivo.
+func NormalizeRedactPatterns(patterns []string) ([]string, error) {
+	normalized := make([]string, 0, len(patterns))
+	seen := map[string]bool{}
+	for _, raw := range patterns {
+		pattern := strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(raw)), "./")
+		if pattern == "" || seen[pattern] {
+			continue
+		}
+		if strings.HasPrefix(pattern, "/") || strings.Contains(pattern, "..") {
+			return nil, fmt.Errorf("redact pattern must be relative to the repository: %s", raw)
+		}
+		if _, err := path.Match(strings.TrimSuffix(pattern, "/"), ""); err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", raw, err)
+		}
+		seen[pattern] = true
+		normalized = append(normalized, pattern)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// IsRedacted diz se o caminho (relativo à raiz) é ocultado por algum padrão.
+func (s Share) IsRedacted(filePath string) bool {
+	filePath = strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(filePath)), "./")
+	if filePath == "" {
+		return false
+	}
+	base := path.Base(filePath)
+	for _, pattern := range s.RedactPatterns {
+		if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+			if strings.HasPrefix(filePath+"/", dir+"/") {
+				return true
+			}
+			if !strings.Contains(dir, "/") {
+				// "secrets/" oculta qualquer diretório com esse nome, em qualquer nível.
+				for _, segment := range strings.Split(path.Dir(filePath), "/") {
+					if matched, _ := path.Match(dir, segment); matched {
+						return true
+					}
+				}
+			}
+			continue
+		}
+		if matched, _ := path.Match(pattern, filePath); matched {
+			return true
+		}
+		if !strings.Contains(pattern, "/") {
+			if matched, _ := path.Match(pattern, base); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RedactStatus remove do snapshot os arquivos ocultados e ajusta os contadores.
+func (s Share) RedactStatus(status gp.StatusDTO) gp.StatusDTO {
+	if len(s.RedactPatterns) == 0 {
+		return status
+	}
+	status.Staged = s.filterChanges(status.Staged)
+	status.Unstaged = s.filterChanges(status.Unstaged)
+	conflicted := make([]gp.ConflictFileDTO, 0, len(status.Conflicted))
+	for _, file := range status.Conflicted {
+		if !s.IsRedacted(file.Path) {
+			conflicted = append(conflicted, file)
+		}
+	}
+	status.Conflicted = conflicted
+	if status.Detail != gp.DetailSummary {
+		status.StagedCount, status.UnstagedCount, status.ConflictedCount = len(status.Staged), len(status.Unstaged), len(status.Conflicted)
+	}
+	return status
+}
+
+// RedactDiff remove os arquivos ocultados; o patch bruto some quando algum arquivo foi removido.
+func (s Share) RedactDiff(diff gp.DiffDTO) gp.DiffDTO {
+	if len(s.RedactPatterns) == 0 {
+		return diff
+	}
+	files := make([]gp.DiffFileDTO, 0, len(diff.Files))
+	for _, file := range diff.Files {
+		if s.IsRedacted(file.Path) || s.IsRedacted(file.OldPath) {
+			continue
+		}
+		files = append(files, file)
+	}
+	if len(files) != len(diff.Files) {
+		diff.Raw = ""
+	}
+	diff.Files = files
+	return diff
+}
+
+func (s Share) filterChanges(changes []gp.FileChangeDTO) []gp.FileChangeDTO {
+	filtered := make([]gp.FileChangeDTO, 0, len(changes))
+	for _, change := range changes {
+		if s.IsRedacted(change.Path) || s.IsRedacted(change.OriginalPath) {
+			continue
+		}
+		filtered = append(filtered, change)
+	}
+	return filtered
+}
+
+// Registry guarda os shares ativos por sessão (um repositório por sessão).
+type Registry struct {
+	mu     sync.RWMutex
+	shares map[string]Share
+}
+
+func NewRegistry() *Registry {
+	return &Registry{shares: make(map[string]Share)}
+}
+
+// Set substitui o share da sessão.
+func (r *Registry) Set(share Share) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.shares[share.SessionID] = share
+}
+
+// Get devolve o share da sessão, se houver.
+func (r *Registry) Get(sessionID string) (Share, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	share, ok := r.shares[sessionID]
+	return share, ok
+}
+
+// Remove encerra o compartilhamento da sessão; devolve false se não havia share.
+func (r *Registry) Remove(sessionID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.shares[sessionID]
+	delete(r.shares, sessionID)
+	return ok
+}
+
+// SessionsForRepo lista as sessões que compartilham o repositório com a raiz informada.
+func (r *Registry) SessionsForRepo(repoRoot string) []string {
+	repoRoot = filepath.Clean(strings.TrimSpace(repoRoot))
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sessionIDs := []string{}
+	for sessionID, share := range r.shares {
+		if filepath.Clean(share.RepoRoot) == repoRoot {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+	sort.Strings(sessionIDs)
+	return sessionIDs
+}
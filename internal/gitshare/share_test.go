@@ -0,0 +1,128 @@
+package gitshare
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	gp "orch/internal/gitpanel"
+)
+
+func TestShareRedactsMatchingPaths(t *testing.T) {
+	patterns, err := NormalizeRedactPatterns([]string{" .env ", "./secrets/", "config/*.key", ".env"})
+	if err != nil {
+		t.Fatalf("NormalizeRedactPatterns() error: %v", err)
+	}
+	share := Share{RedactPatterns: patterns}
+	for filePath, want := range map[string]bool{
+		".env":                  true,
+		"apps/api/.env":         true,
+		"secrets/token.txt":     true,
+		"apps/secrets/db.json":  true,
+		"config/tls.key":        true,
+		"config/nested/tls.key": false,
+		"src/main.go":           false,
+		"secrets.md":            false,
+	} {
+		if got := share.IsRedacted(filePath); got != want {
+			t.Fatalf("IsRedacted(%q) = %v, want %v", filePath, got, want)
+		}
+	}
+
+	status := share.RedactStatus(gp.StatusDTO{
+		Staged:     []gp.FileChangeDTO{{Path: ".env"}, {Path: "src/main.go"}},
+		Unstaged:   []gp.FileChangeDTO{{Path: "README.md", OriginalPath: "secrets/README.md"}},
+		Conflicted: []gp.ConflictFileDTO{{Path: "config/tls.key"}},
+	})
+	if len(status.Staged) != 1 || status.StagedCount != 1 || status.UnstagedCount != 0 || status.ConflictedCount != 0 {
+		t.Fatalf("unexpected redacted status: %+v", status)
+	}
+
+	diff := share.RedactDiff(gp.DiffDTO{Raw: "diff --git", Files: []gp.DiffFileDTO{{Path: ".env"}, {Path: "src/main.go"}}})
+	if diff.Raw != "" || len(diff.Files) != 1 {
+		t.Fatalf("unexpected redacted diff: %+v", diff)
+	}
+
+	for _, invalid := range [][]string{{"/etc/passwd"}, {"../outside"}, {"[unclosed"}} {
+		if _, err := NormalizeRedactPatterns(invalid); err == nil {
+			t.Fatalf("expected %v to be rejected", invalid)
+		}
+	}
+}
+
+type fakeBackend struct {
+	diffCalls int
+}
+
+func (b *fakeBackend) AuthorizeGuest(sessionID, guestUserID string) error {
+	if guestUserID != "guest-ok" {
+		return errors.New("guest guest-pending is not approved")
+	}
+	return nil
+}
+
+func (b *fakeBackend) Status(repoRoot string) (gp.StatusDTO, error) {
+	return gp.StatusDTO{Branch: "main", Staged: []gp.FileChangeDTO{{Path: ".env"}, {Path: "app.go"}}}, nil
+}
+
+func (b *fakeBackend) History(repoRoot string, cursor string, limit int, query string) (gp.HistoryPageDTO, error) {
+	return gp.HistoryPageDTO{}, errors.New("repository not found: " + repoRoot)
+}
+
+func (b *fakeBackend) Diff(repoRoot string, filePath string, mode string, contextLines int) (gp.DiffDTO, error) {
+	b.diffCalls++
+	return gp.DiffDTO{FilePath: filePath}, nil
+}
+
+func TestHandlerAuthorizesGuestsAndHidesHostPaths(t *testing.T) {
+	registry := NewRegistry()
+	backend := &fakeBackend{}
+	server := httptest.NewServer(NewHandler(registry, backend))
+	t.Cleanup(server.Close)
+
+	get := func(route, guestUserID string, params url.Values, out interface{}) int {
+		t.Helper()
+		resp, err := http.Get(server.URL + RequestPath(route, "s1", guestUserID, params))
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", route, err)
+		}
+		defer resp.Body.Close()
+		if out != nil {
+			_ = json.NewDecoder(resp.Body).Decode(out)
+		}
+		return resp.StatusCode
+	}
+
+	if code := get(RouteStatus, "guest-ok", nil, nil); code != http.StatusNotFound {
+		t.Fatalf("expected 404 without share, got %d", code)
+	}
+	registry.Set(Share{SessionID: "s1", RepoRoot: "/home/host/work/orch", RepoName: "orch", RedactPatterns: []string{".env"}})
+
+	if code := get(RouteStatus, "guest-pending", nil, nil); code != http.StatusForbidden {
+		t.Fatalf("expected 403 for unapproved guest, got %d", code)
+	}
+
+	var info Share
+	if code := get(RouteInfo, "guest-ok", nil, &info); code != http.StatusOK || info.RepoRoot != "" || info.RepoName != "orch" {
+		t.Fatalf("unexpected info (%d): %+v", code, info)
+	}
+	var status gp.StatusDTO
+	if code := get(RouteStatus, "guest-ok", nil, &status); code != http.StatusOK || len(status.Staged) != 1 || status.Staged[0].Path != "app.go" {
+		t.Fatalf("unexpected status (%d): %+v", code, status)
+	}
+	var failure errorResponse
+	if code := get(RouteHistory, "guest-ok", nil, &failure); code != http.StatusBadRequest || failure.Error != "repository not found: orch" {
+		t.Fatalf("unexpected history failure (%d): %+v", code, failure)
+	}
+	if code := get(RouteDiff, "guest-ok", url.Values{"filePath": {"apps/.env"}}, nil); code != http.StatusForbidden || backend.diffCalls != 0 {
+		t.Fatalf("expected redacted diff to be refused, got %d (calls=%d)", code, backend.diffCalls)
+	}
+
+	registry.Remove("s1")
+	if code := get(RouteDiff, "guest-ok", url.Values{"filePath": {"app.go"}}, nil); code != http.StatusNotFound {
+		t.Fatalf("expected 404 after share stopped, got %d", code)
+	}
+}
@@ -0,0 +1,288 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	goruntime "runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Eventos de ciclo de vida que disparam hooks.
+const (
+	EventAppStartup         = "app.startup"
+	EventWorkspaceActivated = "workspace.activated"
+	EventTerminalCreated    = "terminal.created"
+	EventTerminalDestroyed  = "terminal.destroyed"
+	EventSessionGuestJoined = "session.guest_joined"
+)
+
+const (
+	DefaultTimeout = 30 * time.Second
+	MaxTimeout     = 10 * time.Minute
+	// maxOutputBytes limita a saída guardada por execução (stdout+stderr).
+	maxOutputBytes = 64 << 10
+	maxRuns        = 100
+)
+
+// Events lista os eventos suportados, na ordem exibida nas configurações.
+func Events() []string {
+	return []string{EventAppStartup, EventWorkspaceActivated, EventTerminalCreated, EventTerminalDestroyed, EventSessionGuestJoined}
+}
+
+// ValidEvent indica se o evento é suportado.
+func ValidEvent(event string) bool {
+	for _, known := range Events() {
+		if known == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Hook é um script registrado para um evento.
+type Hook struct {
+	ID      uint
+	Name    string
+	Event   string
+	Script  string
+	Cwd     string
+	Timeout time.Duration
+}
+
+// Run registra uma execução de hook.
+type Run struct {
+	HookID     uint      `json:"hookId"`
+	HookName   string    `json:"hookName"`
+	Event      string    `json:"event"`
+	StartedAt  time.Time `json:"startedAt"`
+	DurationMs int64     `json:"durationMs"`
+	ExitCode   int       `json:"exitCode"`
+	TimedOut   bool      `json:"timedOut,omitempty"`
+	Output     string    `json:"output,omitempty"`
+	Truncated  bool      `json:"truncated,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Runner executa os hooks habilitados de cada evento em background e guarda as últimas execuções.
+type Runner struct {
+	load func(event string) ([]Hook, error)
+
+	mu   sync.Mutex
+	runs []Run
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRunner cria o runner; load retorna os hooks habilitados do evento.
+func NewRunner(load func(event string) ([]Hook, error)) *Runner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Runner{load: load, ctx: ctx, cancel: cancel}
+}
+
+// Fire dispara, sem bloquear, os hooks do evento com o payload exposto como ORCH_<CHAVE>.
+func (r *Runner) Fire(event string, payload map[string]string) {
+	if r == nil || r.load == nil || r.ctx.Err() != nil {
+		return
+	}
+	hooks, err := r.load(event)
+	if err != nil {
+		log.Printf("[HOOKS] failed to load hooks for %s: %v", event, err)
+		return
+	}
+	for _, hook := range hooks {
+		r.wg.Add(1)
+		go func(hook Hook) {
+			defer r.wg.Done()
+			r.record(r.Execute(r.ctx, hook, event, payload))
+		}(hook)
+	}
+}
+
+// Execute roda um hook de forma síncrona, sem registrar no histórico.
+func (r *Runner) Execute(ctx context.Context, hook Hook, event string, payload map[string]string) Run {
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	timeout = min(timeout, MaxTimeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	run := Run{HookID: hook.ID, HookName: hook.Name, Event: event, StartedAt: time.Now()}
+	cmd := shellCommand(ctx, hook.Script)
+	cmd.Env = hookEnv(event, payload)
+	if strings.TrimSpace(hook.Cwd) != "" {
+		cmd.Dir = hook.Cwd
+	} else if home, err := os.UserHomeDir(); err == nil {
+		cmd.Dir = home
+	}
+	output := &limitedBuffer{limit: maxOutputBytes}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	run.DurationMs = time.Since(run.StartedAt).Milliseconds()
+	run.Output = output.String()
+	run.Truncated = output.truncated
+	run.ExitCode = -1
+	if cmd.ProcessState != nil {
+		run.ExitCode = cmd.ProcessState.ExitCode()
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		run.TimedOut = true
+		run.Error = fmt.Sprintf("timed out after %s", timeout)
+	} else if err != nil {
+		run.Error = err.Error()
+	}
+
+	status := "ok"
+	if run.Error != "" {
+		status = run.Error
+	}
+	log.Printf("[HOOKS] %s hook=%q exit=%d (%dms): %s", event, hook.Name, run.ExitCode, run.DurationMs, status)
+	return run
+}
+
+// ExecuteAndRecord roda o hook de forma síncrona e guarda a execução no histórico.
+func (r *Runner) ExecuteAndRecord(ctx context.Context, hook Hook, event string, payload map[string]string) Run {
+	run := r.Execute(ctx, hook, event, payload)
+	r.record(run)
+	return run
+}
+
+// Runs retorna as últimas execuções, mais recentes primeiro.
+func (r *Runner) Runs() []Run {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]Run, len(r.runs))
+	for i, run := range r.runs {
+		out[len(r.runs)-1-i] = run
+	}
+	return out
+}
+
+// Close cancela os hooks em execução e espera terminarem.
+func (r *Runner) Close() {
+	if r == nil {
+		return
+	}
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *Runner) record(run Run) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs = append(r.runs, run)
+	if len(r.runs) > maxRuns {
+		r.runs = append([]Run(nil), r.runs[len(r.runs)-maxRuns:]...)
+	}
+}
+
+func shellCommand(ctx context.Context, script string) *exec.Cmd {
+	if goruntime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", script)
+	}
+	return exec.CommandContext(ctx, "/bin/sh", "-c", script)
+}
+
+// safeEnvKeys são as únicas variáveis herdadas do app; tokens e segredos do processo não vazam.
+var safeEnvKeys = []string{"PATH", "HOME", "USER", "LOGNAME", "SHELL", "LANG", "LC_ALL", "TMPDIR", "TEMP", "TMP", "SYSTEMROOT", "USERPROFILE"}
+
+// hookEnv monta o ambiente do hook: variáveis seguras do host + ORCH_EVENT + payload.
+func hookEnv(event string, payload map[string]string) []string {
+	env := make([]string, 0, len(safeEnvKeys)+len(payload)+1)
+	for _, key := range safeEnvKeys {
+		if value, ok := os.LookupEnv(key); ok {
+			env = append(env, key+"="+value)
+		}
+	}
+	env = append(env, "ORCH_EVENT="+event)
+
+	keys := make([]string, 0, len(payload))
+	for key := range payload {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		name := EnvName(key)
+		if name == "" {
+			continue
+		}
+		env = append(env, name+"="+strings.ReplaceAll(payload[key], "\x00", ""))
+	}
+	return env
+}
+
+// EnvName converte uma chave do payload (ex.: "sessionId") em ORCH_SESSION_ID.
+func EnvName(key string) string {
+	var b strings.Builder
+	prevLower := false
+	for _, r := range strings.TrimSpace(key) {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			if prevLower {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			prevLower = false
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(r - 'a' + 'A')
+			prevLower = true
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			prevLower = true
+		default:
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteByte('_')
+			}
+			prevLower = false
+		}
+	}
+	name := strings.Trim(b.String(), "_")
+	if name == "" {
+		return ""
+	}
+	return "ORCH_" + name
+}
+
+// limitedBuffer guarda só os primeiros limit bytes da saída.
+type limitedBuffer struct {
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if room := b.limit - b.buf.Len(); room < len(p) {
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		b.truncated = true
+		return len(p), nil
+	}
+	b.buf.Write(p)
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
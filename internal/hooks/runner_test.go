@@ -0,0 +1,55 @@
+//go:build !windows
+
+package hooks
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecuteExposesPayloadWithSafeEnv(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "secret")
+	runner := NewRunner(nil)
+	defer runner.Close()
+
+	run := runner.Execute(context.Background(), Hook{
+		ID:     1,
+		Name:   "print",
+		Script: `echo "$ORCH_EVENT $ORCH_SESSION_ID $ORCH_WORKSPACE_NAME token=${GITHUB_TOKEN:-none}"; exit 3`,
+		Cwd:    t.TempDir(),
+	}, EventTerminalCreated, map[string]string{"sessionId": "s-1", "workspace name": "api"})
+
+	if run.ExitCode != 3 || run.Error == "" {
+		t.Fatalf("expected exit code 3 with error, got %+v", run)
+	}
+	if strings.TrimSpace(run.Output) != "terminal.created s-1 api token=none" {
+		t.Fatalf("unexpected output: %q", run.Output)
+	}
+}
+
+func TestExecuteTimesOutAndFireRecordsRuns(t *testing.T) {
+	runner := NewRunner(func(event string) ([]Hook, error) {
+		if event != EventAppStartup {
+			return nil, nil
+		}
+		return []Hook{{ID: 7, Name: "slow", Script: "sleep 5", Timeout: 100 * time.Millisecond}}, nil
+	})
+	defer runner.Close()
+
+	runner.Fire(EventWorkspaceActivated, nil)
+	runner.Fire(EventAppStartup, nil)
+	deadline := time.Now().Add(3 * time.Second)
+	for len(runner.Runs()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	runs := runner.Runs()
+	if len(runs) != 1 || !runs[0].TimedOut || runs[0].HookID != 7 || runs[0].Event != EventAppStartup {
+		t.Fatalf("expected one timed out run, got %+v", runs)
+	}
+	if runs[0].DurationMs >= 5000 {
+		t.Fatalf("expected hook to be killed on timeout, took %dms", runs[0].DurationMs)
+	}
+}
@@ -0,0 +1,231 @@
+package httpclient
+
+import (
+	"errors"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const maxTraceEntries = 1000
+
+// Resultado de cache registrado no trace.
+const (
+	CacheHit  = "hit"  // resposta servida do cache local (sem request ou 304)
+	CacheMiss = "miss" // request condicional que trouxe conteúdo novo
+)
+
+// TraceEntry é um request de saída registrado pelo API inspector.
+type TraceEntry struct {
+	Seq           int64     `json:"seq"`
+	Time          time.Time `json:"time"`
+	Service       string    `json:"service"` // github | ai | gateway | auth | updater | git
+	Method        string    `json:"method"`
+	Host          string    `json:"host,omitempty"`
+	Path          string    `json:"path"` // sem query string
+	Status        int       `json:"status"`
+	DurationMs    int64     `json:"durationMs"` // até os headers da resposta
+	RateLimitCost int       `json:"rateLimitCost"`
+	RateRemaining int       `json:"rateRemaining"` // -1 quando a API não informa
+	Cache         string    `json:"cache,omitempty"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// tracer guarda os últimos requests num ring buffer; desligado não registra nada.
+type tracer struct {
+	enabled atomic.Bool
+
+	mu          sync.Mutex
+	seq         int64
+	ring        []TraceEntry
+	ringNext    int
+	ringFull    bool
+	subscribers map[int]func(TraceEntry)
+	nextSubID   int
+	graphqlUsed map[uint64]int // hash(token, janela) -> último X-RateLimit-Used do GraphQL
+}
+
+var defaultTracer = &tracer{
+	ring:        make([]TraceEntry, maxTraceEntries),
+	subscribers: make(map[int]func(TraceEntry)),
+	graphqlUsed: make(map[uint64]int),
+}
+
+// SetTracingEnabled liga/desliga o API inspector.
+func SetTracingEnabled(enabled bool) {
+	defaultTracer.enabled.Store(enabled)
+}
+
+// TracingEnabled indica se os requests estão sendo registrados.
+func TracingEnabled() bool {
+	return defaultTracer.enabled.Load()
+}
+
+// RecentTraces retorna os últimos requests (mais antigos primeiro); limit <= 0 retorna todos.
+func RecentTraces(limit int) []TraceEntry {
+	t := defaultTracer
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ordered := make([]TraceEntry, 0, len(t.ring))
+	if t.ringFull {
+		ordered = append(ordered, t.ring[t.ringNext:]...)
+	}
+	ordered = append(ordered, t.ring[:t.ringNext]...)
+	if limit > 0 && len(ordered) > limit {
+		ordered = ordered[len(ordered)-limit:]
+	}
+	return ordered
+}
+
+// ClearTraces descarta os requests registrados.
+func ClearTraces() {
+	t := defaultTracer
+	t.mu.Lock()
+	t.ring = make([]TraceEntry, maxTraceEntries)
+	t.ringNext, t.ringFull = 0, false
+	t.graphqlUsed = make(map[uint64]int)
+	t.mu.Unlock()
+}
+
+// SubscribeTraces recebe cada novo request registrado; retorna a função de cancelamento.
+// O callback roda fora do lock e não deve bloquear.
+func SubscribeTraces(fn func(TraceEntry)) func() {
+	t := defaultTracer
+	t.mu.Lock()
+	id := t.nextSubID
+	t.nextSubID++
+	t.subscribers[id] = fn
+	t.mu.Unlock()
+	return func() {
+		t.mu.Lock()
+		delete(t.subscribers, id)
+		t.mu.Unlock()
+	}
+}
+
+// RecordCacheHit registra uma resposta servida do cache sem request HTTP.
+func RecordCacheHit(service, method, path string) {
+	if !TracingEnabled() {
+		return
+	}
+	defaultTracer.record(TraceEntry{
+		Time:          time.Now(),
+		Service:       service,
+		Method:        strings.ToUpper(method),
+		Path:          path,
+		Status:        http.StatusOK,
+		RateRemaining: -1,
+		Cache:         CacheHit,
+	})
+}
+
+// RecordLocalOperation registra uma operação local (sem HTTP) medida pelo chamador, como as
+// leituras do Git Panel; host identifica o backend usado.
+func RecordLocalOperation(service, method, host, path string, duration time.Duration, err error) {
+	if !TracingEnabled() {
+		return
+	}
+	entry := TraceEntry{
+		Time:          time.Now().Add(-duration),
+		Service:       service,
+		Method:        strings.ToUpper(method),
+		Host:          host,
+		Path:          path,
+		Status:        http.StatusOK,
+		DurationMs:    duration.Milliseconds(),
+		RateRemaining: -1,
+	}
+	if err != nil {
+		entry.Status = 0
+		entry.Error = err.Error()
+	}
+	defaultTracer.record(entry)
+}
+
+func recordRoundTrip(service string, req *http.Request, resp *http.Response, err error, startedAt time.Time) {
+	entry := TraceEntry{
+		Time:          startedAt,
+		Service:       service,
+		Method:        req.Method,
+		Host:          req.URL.Host,
+		Path:          req.URL.Path,
+		DurationMs:    time.Since(startedAt).Milliseconds(),
+		RateRemaining: -1,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			entry.Error = urlErr.Err.Error() // sem a URL completa (query pode ter credenciais)
+		}
+		defaultTracer.record(entry)
+		return
+	}
+	entry.Status = resp.StatusCode
+	if req.Header.Get("If-None-Match") != "" {
+		entry.Cache = CacheMiss
+		if resp.StatusCode == http.StatusNotModified {
+			entry.Cache = CacheHit
+		}
+	}
+	if remaining, convErr := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); convErr == nil {
+		entry.RateRemaining = remaining
+		entry.RateLimitCost = defaultTracer.rateLimitCost(req, resp)
+	}
+	defaultTracer.record(entry)
+}
+
+// rateLimitCost estima a cota consumida: REST custa 1 (304 é gratuito); no GraphQL usa a
+// variação de X-RateLimit-Used na mesma janela de reset.
+func (t *tracer) rateLimitCost(req *http.Request, resp *http.Response) int {
+	if resp.StatusCode == http.StatusNotModified {
+		return 0
+	}
+	if resp.Header.Get("X-RateLimit-Resource") != "graphql" {
+		return 1
+	}
+	used, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Used"))
+	if err != nil {
+		return 1
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(req.Header.Get("Authorization") + "|" + resp.Header.Get("X-RateLimit-Reset")))
+	key := hash.Sum64()
+	t.mu.Lock()
+	if len(t.graphqlUsed) > 64 { // janelas antigas
+		t.graphqlUsed = make(map[uint64]int)
+	}
+	previous, known := t.graphqlUsed[key]
+	t.graphqlUsed[key] = used
+	t.mu.Unlock()
+	if !known || used < previous {
+		return 1
+	}
+	return used - previous
+}
+
+func (t *tracer) record(entry TraceEntry) {
+	t.mu.Lock()
+	t.seq++
+	entry.Seq = t.seq
+	t.ring[t.ringNext] = entry
+	t.ringNext = (t.ringNext + 1) % len(t.ring)
+	if t.ringNext == 0 {
+		t.ringFull = true
+	}
+	subscribers := make([]func(TraceEntry), 0, len(t.subscribers))
+	for _, fn := range t.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	t.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(entry)
+	}
+}
@@ -0,0 +1,120 @@
+package httpclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func enableTracing(t *testing.T) {
+	t.Helper()
+	ClearTraces()
+	SetTracingEnabled(true)
+	t.Cleanup(func() {
+		SetTracingEnabled(false)
+		ClearTraces()
+	})
+}
+
+func TestTracingRecordsRequestsWithRateLimitAndCache(t *testing.T) {
+	enableTracing(t)
+	used := 10
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4990")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		switch r.URL.Path {
+		case "/graphql":
+			used += 3
+			w.Header().Set("X-RateLimit-Resource", "graphql")
+			w.Header().Set("X-RateLimit-Used", strconv.Itoa(used))
+		case "/repos/o/r/pulls":
+			if r.Header.Get("If-None-Match") != "" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var received []TraceEntry
+	unsubscribe := SubscribeTraces(func(entry TraceEntry) { received = append(received, entry) })
+	defer unsubscribe()
+
+	client := Client(ServiceGitHub)
+	do := func(method, path, etag string) {
+		req, _ := http.NewRequest(method, server.URL+path, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+	do(http.MethodGet, "/repos/o/r/pulls?access_token=secret", "")
+	do(http.MethodGet, "/repos/o/r/pulls", `"etag"`)
+	do(http.MethodPost, "/graphql", "")
+	do(http.MethodPost, "/graphql", "")
+	RecordCacheHit(ServiceGitHub, "get", "/repos/o/r/pulls/1")
+
+	traces := RecentTraces(0)
+	if len(traces) != 5 || len(received) != 5 {
+		t.Fatalf("expected 5 traces and 5 notifications, got %d/%d", len(traces), len(received))
+	}
+	first := traces[0]
+	if first.Service != ServiceGitHub || first.Method != http.MethodGet || first.Path != "/repos/o/r/pulls" ||
+		first.Status != http.StatusOK || first.RateLimitCost != 1 || first.RateRemaining != 4990 || first.Cache != "" {
+		t.Fatalf("unexpected first trace: %#v", first)
+	}
+	if traces[1].Status != http.StatusNotModified || traces[1].Cache != CacheHit || traces[1].RateLimitCost != 0 {
+		t.Fatalf("expected free 304 cache hit, got %#v", traces[1])
+	}
+	if traces[3].RateLimitCost != 3 {
+		t.Fatalf("expected graphql cost from X-RateLimit-Used delta, got %#v", traces[3])
+	}
+	if traces[4].Cache != CacheHit || traces[4].Method != http.MethodGet || traces[4].RateRemaining != -1 {
+		t.Fatalf("unexpected cache hit trace: %#v", traces[4])
+	}
+	if got := RecentTraces(2); len(got) != 2 || got[1].Seq != traces[4].Seq {
+		t.Fatalf("expected the 2 most recent traces, got %#v", got)
+	}
+}
+
+func TestTracingDisabledRecordsNothing(t *testing.T) {
+	ClearTraces()
+	SetTracingEnabled(false)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	resp, err := Client(ServiceAI).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	RecordCacheHit(ServiceGitHub, http.MethodGet, "/x")
+	if traces := RecentTraces(0); len(traces) != 0 {
+		t.Fatalf("expected no traces while disabled, got %#v", traces)
+	}
+}
+
+func TestRecordLocalOperation(t *testing.T) {
+	enableTracing(t)
+	RecordLocalOperation(ServiceGit, "status", "gogit", "/repo", 12*time.Millisecond, nil)
+	RecordLocalOperation(ServiceGit, "history", "cli", "/repo", time.Millisecond, errors.New("boom"))
+
+	traces := RecentTraces(0)
+	if len(traces) != 2 {
+		t.Fatalf("expected 2 traces, got %#v", traces)
+	}
+	if traces[0].Method != "STATUS" || traces[0].Host != "gogit" || traces[0].DurationMs != 12 || traces[0].Status != http.StatusOK {
+		t.Fatalf("unexpected local trace: %#v", traces[0])
+	}
+	if traces[1].Error != "boom" || traces[1].Status != 0 {
+		t.Fatalf("expected failed local trace, got %#v", traces[1])
+	}
+}
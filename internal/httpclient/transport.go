@@ -0,0 +1,211 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Modos de proxy aceitos nas configurações.
+const (
+	ProxyModeSystem = "system" // HTTP_PROXY / HTTPS_PROXY / NO_PROXY do ambiente
+	ProxyModeManual = "manual" // URL definida pelo usuário
+	ProxyModeNone   = "none"   // conexão direta
+)
+
+// Settings descreve o proxy e o bundle de CAs aplicados a todos os clients HTTP do app.
+type Settings struct {
+	ProxyMode    string `json:"proxyMode"`              // system | manual | none
+	ProxyURL     string `json:"proxyURL,omitempty"`     // obrigatório no modo manual
+	NoProxy      string `json:"noProxy,omitempty"`      // hosts separados por vírgula (modo manual)
+	CABundlePath string `json:"caBundlePath,omitempty"` // PEM somado às CAs do sistema
+}
+
+// NormalizeProxyMode retorna o modo válido ("" vira system).
+func NormalizeProxyMode(mode string) (string, error) {
+	switch normalized := strings.ToLower(strings.TrimSpace(mode)); normalized {
+	case "", ProxyModeSystem:
+		return ProxyModeSystem, nil
+	case ProxyModeManual, ProxyModeNone:
+		return normalized, nil
+	}
+	return "", fmt.Errorf("unknown proxy mode %q (use system, manual or none)", mode)
+}
+
+// Normalize valida as configurações e retorna a versão limpa.
+func (s Settings) Normalize() (Settings, error) {
+	mode, err := NormalizeProxyMode(s.ProxyMode)
+	if err != nil {
+		return Settings{}, err
+	}
+	normalized := Settings{
+		ProxyMode:    mode,
+		CABundlePath: strings.TrimSpace(s.CABundlePath),
+	}
+	if mode == ProxyModeManual {
+		proxyURL := strings.TrimSpace(s.ProxyURL)
+		if proxyURL == "" {
+			return Settings{}, fmt.Errorf("proxy URL is required in manual mode")
+		}
+		parsed, err := url.Parse(proxyURL)
+		if err != nil || parsed.Host == "" {
+			return Settings{}, fmt.Errorf("invalid proxy URL %q", proxyURL)
+		}
+		switch parsed.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return Settings{}, fmt.Errorf("unsupported proxy scheme %q (use http, https or socks5)", parsed.Scheme)
+		}
+		normalized.ProxyURL = proxyURL
+		normalized.NoProxy = strings.TrimSpace(s.NoProxy)
+	}
+	return normalized, nil
+}
+
+// Serviços usados para identificar os requests no API inspector.
+const (
+	ServiceGitHub  = "github"
+	ServiceAI      = "ai"
+	ServiceGateway = "gateway"
+	ServiceAuth    = "auth"
+	ServiceUpdater = "updater"
+	ServiceNotify  = "notify"
+	ServiceIssues  = "issues"
+	ServiceGit     = "git" // leituras locais do Git Panel (sem HTTP), para comparar backends
+)
+
+var (
+	mu      sync.RWMutex
+	current = Settings{ProxyMode: ProxyModeSystem}
+	base    = newTransport(http.ProxyFromEnvironment, nil)
+)
+
+// sharedTransport delega ao transporte configurado no momento de cada request,
+// então clients criados antes de Configure passam a usar o proxy/CA novos.
+type sharedTransport struct {
+	service string
+}
+
+func (t *sharedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	mu.RLock()
+	transport := base
+	mu.RUnlock()
+	if !TracingEnabled() {
+		return transport.RoundTrip(req)
+	}
+	startedAt := time.Now()
+	resp, err := transport.RoundTrip(req)
+	recordRoundTrip(t.service, req, resp, err, startedAt)
+	return resp, err
+}
+
+// Transport retorna o RoundTripper compartilhado (proxy + CAs configurados) do serviço.
+func Transport(service string) http.RoundTripper {
+	return &sharedTransport{service: service}
+}
+
+// NewClient cria um client do serviço com o transporte compartilhado (timeout 0 = sem limite).
+func NewClient(service string, timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: Transport(service)}
+}
+
+// Client retorna um client do serviço sem timeout, substituto de http.DefaultClient.
+func Client(service string) *http.Client {
+	return NewClient(service, 0)
+}
+
+// Current retorna as configurações aplicadas.
+func Current() Settings {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Configure valida e aplica proxy/CA a todos os clients do pacote.
+// Em caso de erro (ex.: bundle ilegível) o transporte anterior é mantido.
+func Configure(settings Settings) error {
+	normalized, err := settings.Normalize()
+	if err != nil {
+		return err
+	}
+	rootCAs, err := loadRootCAs(normalized.CABundlePath)
+	if err != nil {
+		return err
+	}
+	transport := newTransport(proxyFunc(normalized), rootCAs)
+
+	mu.Lock()
+	previous := base
+	base, current = transport, normalized
+	mu.Unlock()
+	previous.CloseIdleConnections()
+	return nil
+}
+
+func proxyFunc(settings Settings) func(*http.Request) (*url.URL, error) {
+	var config *httpproxy.Config
+	switch settings.ProxyMode {
+	case ProxyModeNone:
+		return nil
+	case ProxyModeManual:
+		config = &httpproxy.Config{
+			HTTPProxy:  settings.ProxyURL,
+			HTTPSProxy: settings.ProxyURL,
+			NoProxy:    settings.NoProxy,
+		}
+	default:
+		// Lido a cada Configure (http.ProxyFromEnvironment guarda o ambiente da primeira chamada).
+		config = httpproxy.FromEnvironment()
+	}
+	resolve := config.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return resolve(req.URL)
+	}
+}
+
+func loadRootCAs(path string) (*x509.CertPool, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", path)
+	}
+	return pool, nil
+}
+
+// newTransport segue os valores de http.DefaultTransport, trocando proxy e CAs.
+func newTransport(proxy func(*http.Request) (*url.URL, error), rootCAs *x509.CertPool) *http.Transport {
+	transport := &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if rootCAs != nil {
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	}
+	return transport
+}
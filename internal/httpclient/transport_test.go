@@ -0,0 +1,132 @@
+package httpclient
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func resetSettings(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		if err := Configure(Settings{}); err != nil {
+			t.Fatalf("reset settings: %v", err)
+		}
+	})
+}
+
+func TestSettingsNormalize(t *testing.T) {
+	settings, err := Settings{ProxyMode: " Manual ", ProxyURL: " http://proxy.corp:3128 ", NoProxy: " .corp "}.Normalize()
+	if err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+	if settings.ProxyMode != ProxyModeManual || settings.ProxyURL != "http://proxy.corp:3128" || settings.NoProxy != ".corp" {
+		t.Fatalf("unexpected normalized settings: %#v", settings)
+	}
+
+	settings, err = Settings{ProxyMode: "none", ProxyURL: "http://ignored:1"}.Normalize()
+	if err != nil || settings.ProxyURL != "" {
+		t.Fatalf("proxy URL should be dropped outside manual mode: %#v (%v)", settings, err)
+	}
+
+	invalid := []Settings{
+		{ProxyMode: "pac"},
+		{ProxyMode: ProxyModeManual},
+		{ProxyMode: ProxyModeManual, ProxyURL: "ftp://proxy:21"},
+		{ProxyMode: ProxyModeManual, ProxyURL: "proxy.corp"},
+	}
+	for _, candidate := range invalid {
+		if _, err := candidate.Normalize(); err == nil {
+			t.Fatalf("expected %#v to be rejected", candidate)
+		}
+	}
+}
+
+func TestManualProxyRoutesRequests(t *testing.T) {
+	resetSettings(t)
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		_, _ = io.WriteString(w, "via proxy")
+	}))
+	defer proxy.Close()
+
+	client := Client("test") // criado antes do Configure: deve seguir o transporte novo
+	if err := Configure(Settings{ProxyMode: ProxyModeManual, ProxyURL: proxy.URL, NoProxy: "skip.example"}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+
+	resp, err := client.Get("http://api.example.test/status")
+	if err != nil {
+		t.Fatalf("request through proxy failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "via proxy" || proxied != "http://api.example.test/status" {
+		t.Fatalf("expected request to go through proxy, got body=%q url=%q", body, proxied)
+	}
+
+	proxyFor := func(rawURL string) string {
+		req, _ := http.NewRequest(http.MethodGet, rawURL, nil)
+		proxyURL, err := base.Proxy(req)
+		if err != nil || proxyURL == nil {
+			return ""
+		}
+		return proxyURL.String()
+	}
+	if got := proxyFor("https://skip.example/x"); got != "" {
+		t.Fatalf("NoProxy host should connect directly, got proxy %q", got)
+	}
+
+	if err := Configure(Settings{ProxyMode: ProxyModeNone}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+	if base.Proxy != nil {
+		t.Fatalf("expected direct connections in none mode")
+	}
+}
+
+func TestCustomCABundleTrustsServer(t *testing.T) {
+	resetSettings(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	client := Client("test")
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatalf("expected self-signed server to be rejected without custom CA")
+	}
+
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, certPEM, 0600); err != nil {
+		t.Fatalf("write bundle: %v", err)
+	}
+	if err := Configure(Settings{ProxyMode: ProxyModeNone, CABundlePath: bundle}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected custom CA to be trusted: %v", err)
+	}
+	resp.Body.Close()
+
+	invalid := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalid, []byte("not a certificate"), 0600); err != nil {
+		t.Fatalf("write invalid bundle: %v", err)
+	}
+	err = Configure(Settings{CABundlePath: invalid})
+	if err == nil || !strings.Contains(err.Error(), "no PEM certificates") {
+		t.Fatalf("expected invalid bundle error, got %v", err)
+	}
+	if Current().CABundlePath != bundle {
+		t.Fatalf("failed Configure must keep previous settings, got %#v", Current())
+	}
+}
@@ -0,0 +1,141 @@
+package i18n
+
+// IDs das mensagens de atividade Git (formatGitActivityMessage).
+const (
+	GitActivityBranchChanged        = "gitactivity.branch_changed"
+	GitActivityBranchChangedUnknown = "gitactivity.branch_changed_unknown"
+	GitActivityRefUpdated           = "gitactivity.ref_updated"
+	GitActivityCommitCreated        = "gitactivity.commit_created"
+	GitActivityCommitPreparing      = "gitactivity.commit_preparing"
+	GitActivityIndexUpdated         = "gitactivity.index_updated"
+	GitActivityMerge                = "gitactivity.merge"
+	GitActivityFetch                = "gitactivity.fetch"
+	GitActivityGeneric              = "gitactivity.generic"
+	GitActivityUnknownRepo          = "gitactivity.unknown_repo"
+)
+
+// IDs das anotações do transcript acessível do terminal.
+const (
+	TranscriptCommand       = "terminal.transcript.command"
+	TranscriptCommandEnd    = "terminal.transcript.command_end"
+	TranscriptInitialOutput = "terminal.transcript.initial_output"
+	TranscriptNoOutput      = "terminal.transcript.no_output"
+	TranscriptTruncated     = "terminal.transcript.truncated"
+	TranscriptDropped       = "terminal.transcript.dropped"
+)
+
+// catalogs mapeia idioma -> ID -> template. Parâmetros entre chaves: {actor}, {repo}...
+var catalogs = map[string]map[string]string{
+	LocalePTBR: {
+		GitActivityBranchChanged:        "{actor} mudou para a branch {branch} no repositório {repo}",
+		GitActivityBranchChangedUnknown: "{actor} alterou branch no repositório {repo}",
+		GitActivityRefUpdated:           "{actor} atualizou a ref {ref} no repositório {repo}",
+		GitActivityCommitCreated:        "{actor} criou um commit no repositório {repo}",
+		GitActivityCommitPreparing:      "{actor} iniciou preparação de commit no repositório {repo}",
+		GitActivityIndexUpdated:         "{actor} atualizou arquivos staged no repositório {repo}",
+		GitActivityMerge:                "{actor} executou merge no repositório {repo}",
+		GitActivityFetch:                "{actor} executou fetch no repositório {repo}",
+		GitActivityGeneric:              "{actor} executou uma ação Git no repositório {repo}",
+		GitActivityUnknownRepo:          "repositório",
+
+		TranscriptCommand:       "Comando {index}, às {time}: {command}",
+		TranscriptCommandEnd:    "Fim do comando {index}.",
+		TranscriptInitialOutput: "Saída antes do primeiro comando",
+		TranscriptNoOutput:      "(sem saída)",
+		TranscriptTruncated:     "(início da saída omitido)",
+		TranscriptDropped:       "{count} comandos anteriores omitidos.",
+
+		"error.service_unavailable":     "Serviço do Git Panel indisponível.",
+		"error.git_unavailable":         "Git não encontrado no sistema.",
+		"error.repo_not_resolved":       "Não foi possível resolver o repositório.",
+		"error.repo_not_found":          "Repositório não encontrado.",
+		"error.repo_not_git":            "O diretório não é um repositório Git.",
+		"error.repo_out_of_scope":       "Repositório fora do escopo do workspace.",
+		"error.invalid_path":            "Caminho inválido.",
+		"error.invalid_cursor":          "Cursor de paginação inválido.",
+		"error.patch_invalid":           "Patch inválido.",
+		"error.command_failed":          "Falha ao executar comando Git.",
+		"error.hook_failed":             "Um hook do Git falhou.",
+		"error.hook_bypass_denied":      "Pular hooks não é permitido neste repositório.",
+		"error.commit_lint_failed":      "A mensagem de commit não passou nas regras.",
+		"error.secrets_detected":        "Possíveis segredos encontrados nas alterações.",
+		"error.push_not_confirmed":      "Push não confirmado.",
+		"error.tool_unavailable":        "Ferramenta externa indisponível.",
+		"error.busy":                    "Muitas operações na fila; tente novamente.",
+		"error.timeout":                 "A operação excedeu o tempo limite.",
+		"error.canceled":                "Operação cancelada.",
+		"error.unknown":                 "Erro desconhecido.",
+		"error.pr_service_unavailable":  "Serviço de pull requests indisponível.",
+		"error.pr_repo_path_required":   "Informe o repositório.",
+		"error.pr_repo_unavailable":     "Repositório indisponível.",
+		"error.pr_repo_resolve_failed":  "Não foi possível identificar o repositório no GitHub.",
+		"error.pr_manual_repo_invalid":  "Repositório informado é inválido.",
+		"error.pr_repo_target_mismatch": "O repositório não corresponde ao destino do pull request.",
+		"error.pr_unauthorized":         "Autenticação no GitHub necessária.",
+		"error.pr_forbidden":            "Sem permissão para esta operação no GitHub.",
+		"error.pr_not_found":            "Pull request não encontrado.",
+		"error.pr_conflict":             "Conflito ao atualizar o pull request.",
+		"error.pr_validation_failed":    "O GitHub rejeitou os dados enviados.",
+		"error.pr_push_not_confirmed":   "Push da branch não confirmado.",
+		"error.pr_rate_limited":         "Limite de requisições do GitHub atingido.",
+		"error.pr_sso_required":         "A organização exige autorização SSO.",
+		"error.pr_canceled":             "Operação cancelada.",
+		"error.pr_unknown":              "Erro desconhecido ao acessar o GitHub.",
+	},
+	LocaleENUS: {
+		GitActivityBranchChanged:        "{actor} switched to branch {branch} in repository {repo}",
+		GitActivityBranchChangedUnknown: "{actor} changed branch in repository {repo}",
+		GitActivityRefUpdated:           "{actor} updated ref {ref} in repository {repo}",
+		GitActivityCommitCreated:        "{actor} created a commit in repository {repo}",
+		GitActivityCommitPreparing:      "{actor} started preparing a commit in repository {repo}",
+		GitActivityIndexUpdated:         "{actor} updated staged files in repository {repo}",
+		GitActivityMerge:                "{actor} ran a merge in repository {repo}",
+		GitActivityFetch:                "{actor} ran fetch in repository {repo}",
+		GitActivityGeneric:              "{actor} ran a Git action in repository {repo}",
+		GitActivityUnknownRepo:          "repository",
+
+		TranscriptCommand:       "Command {index}, at {time}: {command}",
+		TranscriptCommandEnd:    "End of command {index}.",
+		TranscriptInitialOutput: "Output before the first command",
+		TranscriptNoOutput:      "(no output)",
+		TranscriptTruncated:     "(beginning of output omitted)",
+		TranscriptDropped:       "{count} earlier commands omitted.",
+
+		"error.service_unavailable":     "Git Panel service unavailable.",
+		"error.git_unavailable":         "Git was not found on this system.",
+		"error.repo_not_resolved":       "Could not resolve the repository.",
+		"error.repo_not_found":          "Repository not found.",
+		"error.repo_not_git":            "The directory is not a Git repository.",
+		"error.repo_out_of_scope":       "Repository is outside the workspace scope.",
+		"error.invalid_path":            "Invalid path.",
+		"error.invalid_cursor":          "Invalid pagination cursor.",
+		"error.patch_invalid":           "Invalid patch.",
+		"error.command_failed":          "Git command failed.",
+		"error.hook_failed":             "A Git hook failed.",
+		"error.hook_bypass_denied":      "Skipping hooks is not allowed in this repository.",
+		"error.commit_lint_failed":      "The commit message does not follow the rules.",
+		"error.secrets_detected":        "Possible secrets found in the changes.",
+		"error.push_not_confirmed":      "Push not confirmed.",
+		"error.tool_unavailable":        "External tool unavailable.",
+		"error.busy":                    "Too many queued operations; try again.",
+		"error.timeout":                 "The operation timed out.",
+		"error.canceled":                "Operation canceled.",
+		"error.unknown":                 "Unknown error.",
+		"error.pr_service_unavailable":  "Pull request service unavailable.",
+		"error.pr_repo_path_required":   "A repository is required.",
+		"error.pr_repo_unavailable":     "Repository unavailable.",
+		"error.pr_repo_resolve_failed":  "Could not identify the repository on GitHub.",
+		"error.pr_manual_repo_invalid":  "The repository provided is invalid.",
+		"error.pr_repo_target_mismatch": "The repository does not match the pull request target.",
+		"error.pr_unauthorized":         "GitHub authentication required.",
+		"error.pr_forbidden":            "Not allowed to perform this operation on GitHub.",
+		"error.pr_not_found":            "Pull request not found.",
+		"error.pr_conflict":             "Conflict while updating the pull request.",
+		"error.pr_validation_failed":    "GitHub rejected the submitted data.",
+		"error.pr_push_not_confirmed":   "Branch push not confirmed.",
+		"error.pr_rate_limited":         "GitHub rate limit reached.",
+		"error.pr_sso_required":         "The organization requires SSO authorization.",
+		"error.pr_canceled":             "Operation canceled.",
+		"error.pr_unknown":              "Unknown error while contacting GitHub.",
+	},
+}
@@ -0,0 +1,117 @@
+// Package i18n guarda o catálogo de mensagens geradas no backend (atividade Git, erros de
+// bindings). Os DTOs levam o ID e os parâmetros junto do texto já traduzido, então o frontend
+// pode renderizar de novo no idioma atual com o mesmo catálogo (GetMessageCatalog).
+package i18n
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Idiomas suportados; LocalePTBR é o padrão de UserConfig.Language.
+const (
+	LocalePTBR    = "pt-BR"
+	LocaleENUS    = "en-US"
+	DefaultLocale = LocalePTBR
+)
+
+// Message é uma mensagem do catálogo com seus parâmetros ({nome} no template).
+type Message struct {
+	ID     string            `json:"id"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// NormalizeLocale mapeia o valor salvo em UserConfig.Language para um idioma do catálogo.
+func NormalizeLocale(locale string) string {
+	switch strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")) {
+	case "en", "en-us", "en-gb":
+		return LocaleENUS
+	default:
+		return DefaultLocale
+	}
+}
+
+// Locales lista os idiomas com catálogo.
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Catalog devolve uma cópia do catálogo do idioma, completada com o padrão nas chaves ausentes.
+func Catalog(locale string) map[string]string {
+	out := make(map[string]string, len(catalogs[DefaultLocale]))
+	for id, template := range catalogs[DefaultLocale] {
+		out[id] = template
+	}
+	for id, template := range catalogs[NormalizeLocale(locale)] {
+		out[id] = template
+	}
+	return out
+}
+
+// Localize renderiza a mensagem no idioma; cai no idioma padrão e, por fim, no próprio ID.
+func Localize(locale string, id string, params map[string]string) string {
+	template, ok := catalogs[NormalizeLocale(locale)][id]
+	if !ok {
+		template, ok = catalogs[DefaultLocale][id]
+	}
+	if !ok {
+		return id
+	}
+	return render(template, params)
+}
+
+// ErrorID é o ID do catálogo para um código de erro de binding ("E_BUSY" -> "error.busy").
+func ErrorID(code string) string {
+	code = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(code)), "E_")
+	if code == "" {
+		return ""
+	}
+	return "error." + strings.ToLower(code)
+}
+
+func render(template string, params map[string]string) string {
+	if len(params) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for key, value := range params {
+		pairs = append(pairs, "{"+key+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+// Translator guarda o idioma escolhido pelo usuário; seguro para uso concorrente.
+type Translator struct {
+	mu     sync.RWMutex
+	locale string
+}
+
+// NewTranslator cria o tradutor no idioma informado (normalizado).
+func NewTranslator(locale string) *Translator {
+	return &Translator{locale: NormalizeLocale(locale)}
+}
+
+// SetLocale troca o idioma (ex.: SaveLanguage).
+func (t *Translator) SetLocale(locale string) {
+	t.mu.Lock()
+	t.locale = NormalizeLocale(locale)
+	t.mu.Unlock()
+}
+
+// Locale retorna o idioma atual.
+func (t *Translator) Locale() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.locale
+}
+
+// T renderiza a mensagem no idioma atual.
+func (t *Translator) T(id string, params map[string]string) string {
+	return Localize(t.Locale(), id, params)
+}
@@ -0,0 +1,43 @@
+package i18n
+
+import "testing"
+
+func TestCatalogsDefineTheSameMessages(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for id := range catalogs[DefaultLocale] {
+			if _, ok := catalog[id]; !ok {
+				t.Errorf("%s is missing %q", locale, id)
+			}
+		}
+		for id := range catalog {
+			if _, ok := catalogs[DefaultLocale][id]; !ok {
+				t.Errorf("%s defines %q missing from %s", locale, id, DefaultLocale)
+			}
+		}
+	}
+}
+
+func TestLocalizeRendersParamsAndFallsBack(t *testing.T) {
+	params := map[string]string{"actor": "ana", "branch": "main", "repo": "orch"}
+	if got := Localize("en", GitActivityBranchChanged, params); got != "ana switched to branch main in repository orch" {
+		t.Fatalf("Localize(en) = %q", got)
+	}
+	if got := Localize("fr-FR", GitActivityBranchChanged, params); got != "ana mudou para a branch main no repositório orch" {
+		t.Fatalf("Localize(fallback) = %q", got)
+	}
+	if got := Localize(LocaleENUS, "missing.id", nil); got != "missing.id" {
+		t.Fatalf("Localize(missing) = %q", got)
+	}
+
+	translator := NewTranslator("")
+	translator.SetLocale("en_US")
+	if translator.Locale() != LocaleENUS || translator.T(ErrorID("E_BUSY"), nil) != "Too many queued operations; try again." {
+		t.Fatalf("unexpected translator state: %q", translator.Locale())
+	}
+	if ErrorID("E_PR_NOT_FOUND") != "error.pr_not_found" || ErrorID(" ") != "" {
+		t.Fatalf("unexpected ErrorID mapping")
+	}
+	if len(Catalog(LocaleENUS)) != len(catalogs[DefaultLocale]) || len(Locales()) != 2 {
+		t.Fatalf("unexpected catalog size")
+	}
+}
@@ -0,0 +1,68 @@
+package issuetracker
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// keyRegex casa chaves de ticket no formato PROJ-123 (projeto com 2 a 10 caracteres).
+var keyRegex = regexp.MustCompile(`\b[A-Z][A-Z0-9]{1,9}-[1-9][0-9]{0,6}\b`)
+
+var projectRegex = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,9}$`)
+
+// ExtractKeys devolve as chaves de ticket citadas no texto, sem repetição e na ordem em que aparecem.
+// Com projects, só chaves desses projetos são aceitas.
+func ExtractKeys(text string, projects []string) []string {
+	return collectKeys(keyRegex.FindAllString(text, -1), projects)
+}
+
+// ExtractBranchKeys é ExtractKeys para nomes de branch, que costumam vir em minúsculas
+// (feature/proj-123-login). Chaves minúsculas só são aceitas para projetos configurados,
+// para não confundir fix-12 ou release-2 com tickets.
+func ExtractBranchKeys(branch string, projects []string) []string {
+	keys := keyRegex.FindAllString(branch, -1)
+	if len(projects) > 0 {
+		keys = append(keys, keyRegex.FindAllString(strings.ToUpper(branch), -1)...)
+	}
+	return collectKeys(keys, projects)
+}
+
+// StripBranchKeys remove da branch as chaves detectadas por ExtractBranchKeys, para que
+// proj-123 não vire sugestão "Fixes #123" de issue do GitHub.
+func StripBranchKeys(branch string, projects []string) string {
+	for _, key := range ExtractBranchKeys(branch, projects) {
+		branch = regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(key)+`\b`).ReplaceAllString(branch, "")
+	}
+	return branch
+}
+
+// NormalizeProjects converte "proj, ops" em ["PROJ", "OPS"], validando o formato de cada chave.
+func NormalizeProjects(raw string) ([]string, error) {
+	projects := []string{}
+	for _, part := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == ' ' || r == ';' }) {
+		project := strings.ToUpper(strings.TrimSpace(part))
+		if !projectRegex.MatchString(project) {
+			return nil, fmt.Errorf("invalid project key: %s", part)
+		}
+		if !slices.Contains(projects, project) {
+			projects = append(projects, project)
+		}
+	}
+	return projects, nil
+}
+
+func collectKeys(matches []string, projects []string) []string {
+	keys := []string{}
+	for _, key := range matches {
+		if slices.Contains(keys, key) {
+			continue
+		}
+		if len(projects) > 0 && !slices.Contains(projects, key[:strings.LastIndex(key, "-")]) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,416 @@
+package issuetracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Provedores suportados.
+const (
+	ProviderNone   = ""
+	ProviderJira   = "jira"
+	ProviderLinear = "linear"
+)
+
+const (
+	linearEndpoint = "https://api.linear.app/graphql"
+	cacheTTL       = 10 * time.Minute
+	// missTTL evita refazer requests para chaves inexistentes (ex.: falso positivo "UTF-8").
+	missTTL = time.Hour
+	// maxConcurrentFetches limita requests simultâneos ao provedor.
+	maxConcurrentFetches = 4
+	maxErrorBodyBytes    = 1 << 10
+)
+
+// ErrNotConfigured indica que nenhum provedor foi configurado.
+var ErrNotConfigured = errors.New("issue tracker not configured")
+
+// errNotFound indica que a chave não existe (ou o token não tem acesso a ela).
+var errNotFound = errors.New("issue not found")
+
+// Config define o provedor ativo; Token vem do Keychain.
+type Config struct {
+	Provider string
+	BaseURL  string // Jira: https://empresa.atlassian.net (ignorado no Linear)
+	Email    string // Jira Cloud: e-mail do token; vazio usa Bearer (Jira Server/DC)
+	Token    string
+	Projects []string // filtra as chaves detectadas; vazio aceita qualquer PROJ-123
+}
+
+// Issue é um ticket resolvido no provedor.
+type Issue struct {
+	Key      string `json:"key"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	URL      string `json:"url"`
+	Provider string `json:"provider"`
+}
+
+// NormalizeProvider valida o provedor; "none" equivale a vazio (desabilitado).
+func NormalizeProvider(provider string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "", "none":
+		return ProviderNone, nil
+	case ProviderJira:
+		return ProviderJira, nil
+	case ProviderLinear:
+		return ProviderLinear, nil
+	default:
+		return "", fmt.Errorf("unsupported issue tracker: %s", provider)
+	}
+}
+
+// NormalizeBaseURL exige uma URL http(s) e remove a barra final.
+func NormalizeBaseURL(raw string) (string, error) {
+	trimmed := strings.TrimRight(strings.TrimSpace(raw), "/")
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return "", fmt.Errorf("issue tracker URL must be an http(s) URL")
+	}
+	return trimmed, nil
+}
+
+type cacheEntry struct {
+	issue     Issue
+	found     bool
+	expiresAt time.Time
+}
+
+// Service resolve chaves de ticket no provedor configurado, com cache em memória.
+type Service struct {
+	client         *http.Client
+	linearEndpoint string
+
+	mu       sync.Mutex
+	config   Config
+	cache    map[string]cacheEntry
+	inFlight map[string]struct{}
+}
+
+// NewService cria o serviço sem provedor configurado.
+func NewService(client *http.Client) *Service {
+	return &Service{
+		client:         client,
+		linearEndpoint: linearEndpoint,
+		cache:          make(map[string]cacheEntry),
+		inFlight:       make(map[string]struct{}),
+	}
+}
+
+// Configure troca o provedor ativo e limpa o cache.
+func (s *Service) Configure(cfg Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = cfg
+	s.cache = make(map[string]cacheEntry)
+}
+
+// Enabled indica se há provedor e token configurados.
+func (s *Service) Enabled() bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.config.Provider != ProviderNone && s.config.Token != ""
+}
+
+// Projects retorna o filtro de projetos configurado.
+func (s *Service) Projects() []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.config.Projects...)
+}
+
+// Cached devolve as issues já resolvidas e as chaves que ainda precisam de request.
+// Chaves inexistentes em cache não entram em nenhuma das listas.
+func (s *Service) Cached(keys []string) (map[string]Issue, []string) {
+	found := make(map[string]Issue, len(keys))
+	missing := []string{}
+	if s == nil {
+		return found, missing
+	}
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		entry, ok := s.cache[key]
+		if ok && now.Before(entry.expiresAt) {
+			if entry.found {
+				found[key] = entry.issue
+			}
+			continue
+		}
+		missing = append(missing, key)
+	}
+	return found, missing
+}
+
+// Resolve busca as chaves (cache primeiro) e devolve as encontradas na ordem pedida.
+func (s *Service) Resolve(ctx context.Context, keys []string) ([]Issue, error) {
+	if !s.Enabled() {
+		return nil, ErrNotConfigured
+	}
+	found, missing := s.Cached(keys)
+	if len(missing) > 0 {
+		fetched, err := s.fetchAll(ctx, missing)
+		for key, issue := range fetched {
+			found[key] = issue
+		}
+		if err != nil && len(found) == 0 {
+			return nil, err
+		}
+	}
+	issues := make([]Issue, 0, len(found))
+	for _, key := range keys {
+		if issue, ok := found[key]; ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// Prefetch resolve em background as chaves que não estão em cache nem em andamento e
+// chama onResolved com as que foram encontradas.
+func (s *Service) Prefetch(keys []string, onResolved func([]Issue)) {
+	if !s.Enabled() {
+		return
+	}
+	_, missing := s.Cached(keys)
+	s.mu.Lock()
+	pending := make([]string, 0, len(missing))
+	for _, key := range missing {
+		if _, busy := s.inFlight[key]; busy {
+			continue
+		}
+		s.inFlight[key] = struct{}{}
+		pending = append(pending, key)
+	}
+	s.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			for _, key := range pending {
+				delete(s.inFlight, key)
+			}
+			s.mu.Unlock()
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		fetched, _ := s.fetchAll(ctx, pending)
+		if len(fetched) == 0 || onResolved == nil {
+			return
+		}
+		issues := make([]Issue, 0, len(fetched))
+		for _, key := range pending {
+			if issue, ok := fetched[key]; ok {
+				issues = append(issues, issue)
+			}
+		}
+		onResolved(issues)
+	}()
+}
+
+// fetchAll busca as chaves em paralelo e grava no cache; o erro retornado é o primeiro
+// que não seja "não encontrado".
+func (s *Service) fetchAll(ctx context.Context, keys []string) (map[string]Issue, error) {
+	s.mu.Lock()
+	cfg := s.config
+	s.mu.Unlock()
+
+	var (
+		wg       sync.WaitGroup
+		resultMu sync.Mutex
+		firstErr error
+	)
+	fetched := make(map[string]Issue, len(keys))
+	slots := make(chan struct{}, maxConcurrentFetches)
+	for _, key := range keys {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			issue, err := s.fetch(ctx, cfg, key)
+			resultMu.Lock()
+			defer resultMu.Unlock()
+			switch {
+			case err == nil:
+				fetched[key] = issue
+				s.store(key, cacheEntry{issue: issue, found: true, expiresAt: time.Now().Add(cacheTTL)})
+			case errors.Is(err, errNotFound):
+				s.store(key, cacheEntry{expiresAt: time.Now().Add(missTTL)})
+			case firstErr == nil:
+				firstErr = err
+			}
+		}(key)
+	}
+	wg.Wait()
+	return fetched, firstErr
+}
+
+func (s *Service) store(key string, entry cacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache[key] = entry
+}
+
+func (s *Service) fetch(ctx context.Context, cfg Config, key string) (Issue, error) {
+	switch cfg.Provider {
+	case ProviderJira:
+		return s.fetchJira(ctx, cfg, key)
+	case ProviderLinear:
+		return s.fetchLinear(ctx, cfg, key)
+	default:
+		return Issue{}, ErrNotConfigured
+	}
+}
+
+// fetchJira usa a API REST v2, disponível tanto no Jira Cloud quanto no Server/DC.
+func (s *Service) fetchJira(ctx context.Context, cfg Config, key string) (Issue, error) {
+	endpoint := cfg.BaseURL + "/rest/api/2/issue/" + url.PathEscape(key) + "?fields=summary,status"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return Issue{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if cfg.Email != "" {
+		req.SetBasicAuth(cfg.Email, cfg.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+cfg.Token)
+	}
+
+	var payload struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary string `json:"summary"`
+			Status  struct {
+				Name string `json:"name"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	if err := s.doJSON(req, &payload); err != nil {
+		return Issue{}, err
+	}
+	return Issue{
+		Key:      payload.Key,
+		Title:    payload.Fields.Summary,
+		Status:   payload.Fields.Status.Name,
+		URL:      cfg.BaseURL + "/browse/" + payload.Key,
+		Provider: ProviderJira,
+	}, nil
+}
+
+const linearIssueQuery = `query($id: String!) { issue(id: $id) { identifier title url state { name } } }`
+
+// fetchLinear consulta a API GraphQL; issue(id:) aceita o identificador (ENG-123).
+func (s *Service) fetchLinear(ctx context.Context, cfg Config, key string) (Issue, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     linearIssueQuery,
+		"variables": map[string]string{"id": key},
+	})
+	if err != nil {
+		return Issue{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.linearEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return Issue{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Chaves pessoais do Linear vão sem o prefixo Bearer.
+	req.Header.Set("Authorization", cfg.Token)
+
+	var payload struct {
+		Data struct {
+			Issue *struct {
+				Identifier string `json:"identifier"`
+				Title      string `json:"title"`
+				URL        string `json:"url"`
+				State      struct {
+					Name string `json:"name"`
+				} `json:"state"`
+			} `json:"issue"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := s.doJSON(req, &payload); err != nil {
+		return Issue{}, err
+	}
+	if payload.Data.Issue == nil {
+		if len(payload.Errors) > 0 && !strings.Contains(strings.ToLower(payload.Errors[0].Message), "not found") {
+			return Issue{}, fmt.Errorf("linear: %s", payload.Errors[0].Message)
+		}
+		return Issue{}, errNotFound
+	}
+	issue := payload.Data.Issue
+	return Issue{
+		Key:      issue.Identifier,
+		Title:    issue.Title,
+		Status:   issue.State.Name,
+		URL:      issue.URL,
+		Provider: ProviderLinear,
+	}, nil
+}
+
+func (s *Service) doJSON(req *http.Request, out interface{}) error {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errNotFound
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("issue tracker rejected the token (HTTP %d)", resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("issue tracker returned %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// AppendReferences acrescenta ao corpo do PR uma linha por issue ainda não citada.
+func AppendReferences(body string, issues []Issue) string {
+	body = strings.TrimRight(body, "\r\n\t ")
+	lines := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		if strings.Contains(body, issue.Key) {
+			continue
+		}
+		line := "- " + issue.Key
+		if issue.URL != "" {
+			line = fmt.Sprintf("- [%s](%s)", issue.Key, issue.URL)
+		}
+		if issue.Title != "" {
+			line += " " + issue.Title
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return body
+	}
+	if body == "" {
+		return strings.Join(lines, "\n")
+	}
+	return body + "\n\n" + strings.Join(lines, "\n")
+}
@@ -0,0 +1,107 @@
+package issuetracker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestExtractKeys(t *testing.T) {
+	if got := ExtractKeys("PROJ-12: fix login (refs OPS-7, PROJ-12, UTF-8)", nil); !slices.Equal(got, []string{"PROJ-12", "OPS-7", "UTF-8"}) {
+		t.Fatalf("unexpected keys: %v", got)
+	}
+	if got := ExtractKeys("PROJ-12 and OPS-7", []string{"OPS"}); !slices.Equal(got, []string{"OPS-7"}) {
+		t.Fatalf("expected project filter, got %v", got)
+	}
+	if got := ExtractBranchKeys("feature/proj-45-login-fix-2", []string{"PROJ"}); !slices.Equal(got, []string{"PROJ-45"}) {
+		t.Fatalf("expected lowercase key for configured project, got %v", got)
+	}
+	if got := ExtractBranchKeys("feature/proj-45-login", nil); len(got) != 0 {
+		t.Fatalf("lowercase keys need a configured project, got %v", got)
+	}
+	if got := StripBranchKeys("fix/proj-45-gh-12", []string{"PROJ"}); got != "fix/-gh-12" {
+		t.Fatalf("unexpected stripped branch: %q", got)
+	}
+	if _, err := NormalizeProjects("proj, p.qr"); err == nil {
+		t.Fatalf("expected invalid project key to be rejected")
+	}
+}
+
+func TestResolveJiraCachesHitsAndMisses(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if user, pass, ok := r.BasicAuth(); !ok || user != "dev@acme.io" || pass != "tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/rest/api/2/issue/PROJ-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"key":"PROJ-1","fields":{"summary":"Login breaks","status":{"name":"In Progress"}}}`))
+	}))
+	defer server.Close()
+
+	svc := NewService(server.Client())
+	if _, err := svc.Resolve(context.Background(), []string{"PROJ-1"}); err != ErrNotConfigured {
+		t.Fatalf("expected ErrNotConfigured, got %v", err)
+	}
+	svc.Configure(Config{Provider: ProviderJira, BaseURL: server.URL, Email: "dev@acme.io", Token: "tok"})
+
+	for range 2 {
+		issues, err := svc.Resolve(context.Background(), []string{"UTF-8", "PROJ-1"})
+		if err != nil {
+			t.Fatalf("Resolve returned error: %v", err)
+		}
+		want := Issue{Key: "PROJ-1", Title: "Login breaks", Status: "In Progress", URL: server.URL + "/browse/PROJ-1", Provider: ProviderJira}
+		if len(issues) != 1 || issues[0] != want {
+			t.Fatalf("unexpected issues: %+v", issues)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected hits and misses to be cached, got %d requests", calls.Load())
+	}
+
+	svc.Configure(Config{Provider: ProviderJira, BaseURL: server.URL, Token: "wrong"})
+	if _, err := svc.Resolve(context.Background(), []string{"PROJ-1"}); err == nil {
+		t.Fatalf("expected token rejection to surface as error")
+	}
+}
+
+func TestResolveLinear(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]string `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if r.Header.Get("Authorization") != "lin_api_x" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if body.Variables["id"] != "ENG-9" {
+			_, _ = w.Write([]byte(`{"data":{"issue":null},"errors":[{"message":"Entity not found: Issue"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"issue":{"identifier":"ENG-9","title":"Cache builds","url":"https://linear.app/acme/issue/ENG-9","state":{"name":"Todo"}}}}`))
+	}))
+	defer server.Close()
+
+	svc := NewService(server.Client())
+	svc.linearEndpoint = server.URL
+	svc.Configure(Config{Provider: ProviderLinear, Token: "lin_api_x"})
+
+	issues, err := svc.Resolve(context.Background(), []string{"ENG-9", "ENG-10"})
+	if err != nil || len(issues) != 1 || issues[0].Status != "Todo" || issues[0].URL != "https://linear.app/acme/issue/ENG-9" {
+		t.Fatalf("unexpected linear issues: %+v (%v)", issues, err)
+	}
+
+	body := AppendReferences("## Summary\nRefs ENG-10", append(issues, Issue{Key: "ENG-10"}))
+	if body != "## Summary\nRefs ENG-10\n\n- [ENG-9](https://linear.app/acme/issue/ENG-9) Cache builds" {
+		t.Fatalf("unexpected PR body: %q", body)
+	}
+}
@@ -0,0 +1,319 @@
+// Package jobs executa operações longas (push, fetch, builds) em background com ID,
+// progresso, log e cancelamento uniformes para o frontend.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Estados de um job.
+const (
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusCanceled  = "canceled"
+)
+
+// Eventos emitidos ao frontend.
+const (
+	EventUpdated = "jobs:updated" // snapshot do job (sem logs)
+	EventLog     = "jobs:log"     // {jobId, line}
+)
+
+const (
+	defaultMaxFinished = 50
+	maxLogLines        = 500
+)
+
+var (
+	ErrNotFound = errors.New("job not found")
+	// ErrConflict indica que já existe um job ativo com a mesma chave exclusiva.
+	ErrConflict = errors.New("a job for this target is already running")
+)
+
+// Job é o snapshot serializável de uma operação.
+type Job struct {
+	ID            string      `json:"id"`
+	Kind          string      `json:"kind"`   // ex.: git.push, docker.stack_build
+	Module        string      `json:"module"` // gitpanel | docker | github
+	Title         string      `json:"title"`
+	Target        string      `json:"target,omitempty"` // repo, stack etc.
+	Status        string      `json:"status"`
+	Progress      float64     `json:"progress"` // 0..1; negativo = indeterminado
+	Message       string      `json:"message,omitempty"`
+	Error         string      `json:"error,omitempty"`
+	Result        interface{} `json:"result,omitempty"`
+	Logs          []string    `json:"logs,omitempty"`
+	LogsTruncated bool        `json:"logsTruncated,omitempty"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	FinishedAt    time.Time   `json:"finishedAt,omitempty"`
+}
+
+// Done indica se o job terminou (com sucesso, falha ou cancelamento).
+func (j Job) Done() bool {
+	return j.Status != StatusRunning
+}
+
+// Spec descreve o job a submeter. ExclusiveKey (opcional) impede dois jobs ativos
+// sobre o mesmo alvo, ex.: dois pushes no mesmo repositório.
+type Spec struct {
+	Kind         string
+	Module       string
+	Title        string
+	Target       string
+	ExclusiveKey string
+}
+
+// Func é o trabalho do job; deve respeitar ctx para que JobCancel funcione.
+type Func func(ctx context.Context, report *Reporter) (interface{}, error)
+
+// Emitter publica eventos ao frontend.
+type Emitter func(eventName string, data interface{})
+
+type entry struct {
+	job    Job
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Manager mantém os jobs ativos e os últimos finalizados em memória.
+type Manager struct {
+	mu          sync.Mutex
+	jobs        map[string]*entry
+	exclusive   map[string]string // chave exclusiva -> job ativo
+	seq         uint64
+	maxFinished int
+	emit        Emitter
+	baseCtx     context.Context
+	stop        context.CancelFunc
+}
+
+// NewManager cria o gerenciador; emit pode ser nil.
+func NewManager(emit Emitter) *Manager {
+	if emit == nil {
+		emit = func(string, interface{}) {}
+	}
+	baseCtx, stop := context.WithCancel(context.Background())
+	return &Manager{
+		jobs:        make(map[string]*entry),
+		exclusive:   make(map[string]string),
+		maxFinished: defaultMaxFinished,
+		emit:        emit,
+		baseCtx:     baseCtx,
+		stop:        stop,
+	}
+}
+
+// Submit inicia o job em background e retorna o snapshot inicial.
+func (m *Manager) Submit(spec Spec, fn Func) (Job, error) {
+	if fn == nil {
+		return Job{}, fmt.Errorf("job function is required")
+	}
+	kind := strings.TrimSpace(spec.Kind)
+	if kind == "" {
+		return Job{}, fmt.Errorf("job kind is required")
+	}
+
+	m.mu.Lock()
+	if key := strings.TrimSpace(spec.ExclusiveKey); key != "" {
+		if _, busy := m.exclusive[key]; busy {
+			m.mu.Unlock()
+			return Job{}, ErrConflict
+		}
+	}
+	m.seq++
+	ctx, cancel := context.WithCancel(m.baseCtx)
+	e := &entry{
+		job: Job{
+			ID:        fmt.Sprintf("job-%d-%d", time.Now().UnixMilli(), m.seq),
+			Kind:      kind,
+			Module:    strings.TrimSpace(spec.Module),
+			Title:     strings.TrimSpace(spec.Title),
+			Target:    strings.TrimSpace(spec.Target),
+			Status:    StatusRunning,
+			Progress:  -1,
+			CreatedAt: time.Now(),
+		},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	if key := strings.TrimSpace(spec.ExclusiveKey); key != "" {
+		m.exclusive[key] = e.job.ID
+	}
+	m.jobs[e.job.ID] = e
+	snapshot := e.job
+	m.mu.Unlock()
+
+	m.emit(EventUpdated, snapshot)
+	go m.run(ctx, e, strings.TrimSpace(spec.ExclusiveKey), fn)
+	return snapshot, nil
+}
+
+// Cancel pede o cancelamento; jobs já finalizados são ignorados.
+func (m *Manager) Cancel(id string) error {
+	m.mu.Lock()
+	e, ok := m.jobs[strings.TrimSpace(id)]
+	m.mu.Unlock()
+	if !ok {
+		return ErrNotFound
+	}
+	e.cancel()
+	return nil
+}
+
+// Get retorna o job com o log completo.
+func (m *Manager) Get(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.jobs[strings.TrimSpace(id)]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+	return cloneJob(e.job, true), nil
+}
+
+// List retorna os jobs ativos e depois os finalizados, mais recentes primeiro, sem logs.
+func (m *Manager) List() []Job {
+	m.mu.Lock()
+	result := make([]Job, 0, len(m.jobs))
+	for _, e := range m.jobs {
+		result = append(result, cloneJob(e.job, false))
+	}
+	m.mu.Unlock()
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Done() != result[j].Done() {
+			return !result[i].Done()
+		}
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result
+}
+
+// Wait bloqueia até o job terminar ou ctx expirar.
+func (m *Manager) Wait(ctx context.Context, id string) (Job, error) {
+	m.mu.Lock()
+	e, ok := m.jobs[strings.TrimSpace(id)]
+	m.mu.Unlock()
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+	select {
+	case <-e.done:
+		return m.Get(id)
+	case <-ctx.Done():
+		return Job{}, ctx.Err()
+	}
+}
+
+// Shutdown cancela todos os jobs ativos.
+func (m *Manager) Shutdown() {
+	m.stop()
+}
+
+func (m *Manager) run(ctx context.Context, e *entry, exclusiveKey string, fn Func) {
+	result, err := fn(ctx, &Reporter{manager: m, entry: e})
+
+	m.mu.Lock()
+	e.job.FinishedAt = time.Now()
+	switch {
+	case ctx.Err() != nil:
+		// Erro de execução depois do cancelamento é consequência dele.
+		e.job.Status = StatusCanceled
+		e.job.Message = "Cancelado"
+	case err != nil:
+		e.job.Status = StatusFailed
+		e.job.Error = err.Error()
+	default:
+		e.job.Status = StatusSucceeded
+		e.job.Progress = 1
+		e.job.Result = result
+	}
+	if exclusiveKey != "" && m.exclusive[exclusiveKey] == e.job.ID {
+		delete(m.exclusive, exclusiveKey)
+	}
+	snapshot := cloneJob(e.job, false)
+	m.pruneFinishedLocked()
+	m.mu.Unlock()
+
+	e.cancel()
+	close(e.done)
+	m.emit(EventUpdated, snapshot)
+}
+
+// pruneFinishedLocked mantém só os maxFinished jobs finalizados mais recentes.
+func (m *Manager) pruneFinishedLocked() {
+	finished := make([]*entry, 0, len(m.jobs))
+	for _, e := range m.jobs {
+		if e.job.Done() {
+			finished = append(finished, e)
+		}
+	}
+	if len(finished) <= m.maxFinished {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].job.FinishedAt.After(finished[j].job.FinishedAt) })
+	for _, e := range finished[m.maxFinished:] {
+		delete(m.jobs, e.job.ID)
+	}
+}
+
+func cloneJob(job Job, withLogs bool) Job {
+	if withLogs {
+		job.Logs = append([]string(nil), job.Logs...)
+	} else {
+		job.Logs = nil
+	}
+	return job
+}
+
+// Reporter é entregue à Func para publicar progresso e log.
+type Reporter struct {
+	manager *Manager
+	entry   *entry
+}
+
+// JobID retorna o ID do job em execução.
+func (r *Reporter) JobID() string {
+	return r.entry.job.ID
+}
+
+// Progress atualiza a fração concluída (0..1; negativo = indeterminado) e a mensagem.
+func (r *Reporter) Progress(fraction float64, message string) {
+	m := r.manager
+	m.mu.Lock()
+	if fraction > 1 {
+		fraction = 1
+	}
+	r.entry.job.Progress = fraction
+	if message = strings.TrimSpace(message); message != "" {
+		r.entry.job.Message = message
+	}
+	snapshot := cloneJob(r.entry.job, false)
+	m.mu.Unlock()
+	m.emit(EventUpdated, snapshot)
+}
+
+// Log acrescenta linhas ao log do job (mantém as últimas maxLogLines).
+func (r *Reporter) Log(text string) {
+	m := r.manager
+	for _, line := range strings.Split(strings.TrimRight(text, "\r\n"), "\n") {
+		line = strings.TrimRight(line, "\r")
+		m.mu.Lock()
+		job := &r.entry.job
+		job.Logs = append(job.Logs, line)
+		if len(job.Logs) > maxLogLines {
+			job.Logs = job.Logs[len(job.Logs)-maxLogLines:]
+			job.LogsTruncated = true
+		}
+		id := job.ID
+		m.mu.Unlock()
+		m.emit(EventLog, map[string]string{"jobId": id, "line": line})
+	}
+}
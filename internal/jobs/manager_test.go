@@ -0,0 +1,119 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func waitJob(t *testing.T, m *Manager, id string) Job {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job, err := m.Wait(ctx, id)
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	return job
+}
+
+func TestSubmitReportsProgressLogsAndResult(t *testing.T) {
+	var mu sync.Mutex
+	events := map[string]int{}
+	m := NewManager(func(name string, _ interface{}) {
+		mu.Lock()
+		events[name]++
+		mu.Unlock()
+	})
+	defer m.Shutdown()
+
+	job, err := m.Submit(Spec{Kind: "git.push", Module: "gitpanel", Title: "Push"}, func(ctx context.Context, report *Reporter) (interface{}, error) {
+		report.Progress(0.5, "enviando")
+		report.Log("linha 1\nlinha 2\n")
+		return "ok", nil
+	})
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	if job.Status != StatusRunning || job.ID == "" {
+		t.Fatalf("unexpected initial snapshot: %+v", job)
+	}
+
+	done := waitJob(t, m, job.ID)
+	if done.Status != StatusSucceeded || done.Progress != 1 || done.Result != "ok" || done.Message != "enviando" {
+		t.Fatalf("unexpected finished job: %+v", done)
+	}
+	if len(done.Logs) != 2 || done.Logs[1] != "linha 2" {
+		t.Fatalf("unexpected logs: %#v", done.Logs)
+	}
+	if listed := m.List(); len(listed) != 1 || listed[0].Logs != nil {
+		t.Fatalf("expected list without logs, got %+v", listed)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if events[EventLog] != 2 || events[EventUpdated] < 3 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+}
+
+func TestCancelStopsJobAndReleasesExclusiveKey(t *testing.T) {
+	m := NewManager(nil)
+	defer m.Shutdown()
+
+	started := make(chan struct{})
+	spec := Spec{Kind: "docker.stack_build", ExclusiveKey: "stack:api"}
+	job, err := m.Submit(spec, func(ctx context.Context, _ *Reporter) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, errors.New("signal: killed")
+	})
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	<-started
+
+	if _, err := m.Submit(spec, func(context.Context, *Reporter) (interface{}, error) { return nil, nil }); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected ErrConflict for concurrent exclusive job, got %v", err)
+	}
+	if listed := m.List(); len(listed) != 1 || listed[0].Done() {
+		t.Fatalf("expected one active job, got %+v", listed)
+	}
+
+	if err := m.Cancel(job.ID); err != nil {
+		t.Fatalf("Cancel returned error: %v", err)
+	}
+	if done := waitJob(t, m, job.ID); done.Status != StatusCanceled || done.Error != "" {
+		t.Fatalf("expected canceled job, got %+v", done)
+	}
+
+	next, err := m.Submit(spec, func(context.Context, *Reporter) (interface{}, error) { return nil, errors.New("boom") })
+	if err != nil {
+		t.Fatalf("expected exclusive key released, got %v", err)
+	}
+	if done := waitJob(t, m, next.ID); done.Status != StatusFailed || done.Error != "boom" {
+		t.Fatalf("expected failed job, got %+v", done)
+	}
+	if err := m.Cancel("missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestFinishedJobsArePruned(t *testing.T) {
+	m := NewManager(nil)
+	defer m.Shutdown()
+	m.maxFinished = 2
+
+	for range 4 {
+		job, err := m.Submit(Spec{Kind: "git.fetch"}, func(context.Context, *Reporter) (interface{}, error) { return nil, nil })
+		if err != nil {
+			t.Fatalf("Submit returned error: %v", err)
+		}
+		waitJob(t, m, job.ID)
+	}
+	if listed := m.List(); len(listed) != 2 {
+		t.Fatalf("expected only the 2 most recent finished jobs, got %d", len(listed))
+	}
+}
@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Tokens associa tokens gerados pelo frontend a chamadas de binding em andamento,
+// para que CancelOperation aborte requests HTTP e subprocessos git da chamada.
+type Tokens struct {
+	mu  sync.Mutex
+	ops map[string]*tokenOp
+}
+
+type tokenOp struct {
+	cancel context.CancelFunc
+}
+
+// NewTokens cria o registro de tokens de cancelamento.
+func NewTokens() *Tokens {
+	return &Tokens{ops: make(map[string]*tokenOp)}
+}
+
+// Begin deriva um contexto cancelável pelo token; done deve ser chamado ao fim da chamada.
+// Token vazio retorna um contexto não cancelável pelo frontend. Reusar um token ativo
+// cancela a chamada anterior (ex.: o usuário trocou de PR antes do diff carregar).
+func (t *Tokens) Begin(parent context.Context, token string) (context.Context, func()) {
+	if parent == nil {
+		parent = context.Background()
+	}
+	token = strings.TrimSpace(token)
+	ctx, cancel := context.WithCancel(parent)
+	if token == "" {
+		return ctx, cancel
+	}
+
+	op := &tokenOp{cancel: cancel}
+	t.mu.Lock()
+	if previous, ok := t.ops[token]; ok {
+		previous.cancel()
+	}
+	t.ops[token] = op
+	t.mu.Unlock()
+
+	return ctx, func() {
+		t.mu.Lock()
+		// Só remove se o token ainda pertence a esta chamada.
+		if t.ops[token] == op {
+			delete(t.ops, token)
+		}
+		t.mu.Unlock()
+		cancel()
+	}
+}
+
+// Cancel cancela a chamada associada ao token; false se ela já terminou ou não existe.
+func (t *Tokens) Cancel(token string) bool {
+	token = strings.TrimSpace(token)
+	t.mu.Lock()
+	op, ok := t.ops[token]
+	delete(t.ops, token)
+	t.mu.Unlock()
+	if ok {
+		op.cancel()
+	}
+	return ok
+}
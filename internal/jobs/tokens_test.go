@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTokensCancelAbortsOperation(t *testing.T) {
+	tokens := NewTokens()
+
+	ctx, done := tokens.Begin(context.Background(), "diff-1")
+	if !tokens.Cancel("diff-1") {
+		t.Fatalf("expected active token to be canceled")
+	}
+	if ctx.Err() == nil {
+		t.Fatalf("expected context canceled")
+	}
+	done()
+	if tokens.Cancel("diff-1") {
+		t.Fatalf("expected finished token to be gone")
+	}
+}
+
+func TestTokensReuseCancelsPreviousCall(t *testing.T) {
+	tokens := NewTokens()
+
+	first, doneFirst := tokens.Begin(context.Background(), "pr-files")
+	second, doneSecond := tokens.Begin(context.Background(), "pr-files")
+	if first.Err() == nil || second.Err() != nil {
+		t.Fatalf("expected only the previous call canceled: first=%v second=%v", first.Err(), second.Err())
+	}
+
+	// O done da chamada antiga não pode liberar o token da nova.
+	doneFirst()
+	if !tokens.Cancel("pr-files") || second.Err() == nil {
+		t.Fatalf("expected token to still cancel the newer call")
+	}
+	doneSecond()
+
+	ctx, done := tokens.Begin(context.Background(), " ")
+	defer done()
+	if tokens.Cancel("") || ctx.Err() != nil {
+		t.Fatalf("expected empty token to be untracked")
+	}
+}
@@ -0,0 +1,434 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultCommandTimeout = 10 * time.Second
+
+	// DefaultReconnectAttempts limita as tentativas automáticas de reconexão do exec.
+	DefaultReconnectAttempts = 5
+)
+
+var (
+	// Nomes Kubernetes (DNS-1123) e nomes de contexto (mais permissivos, sem espaços/aspas).
+	resourceNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]{0,251}[a-z0-9])?$`)
+	contextNameRegex  = regexp.MustCompile(`^[A-Za-z0-9_.:@/-]{1,253}$`)
+	shellPathRegex    = regexp.MustCompile(`^/[A-Za-z0-9_./-]{1,127}$`)
+)
+
+// crashReasons agrupa estados de container que impedem exec e justificam streaming de logs.
+var crashReasons = map[string]struct{}{
+	"CrashLoopBackOff":           {},
+	"Error":                      {},
+	"OOMKilled":                  {},
+	"CreateContainerError":       {},
+	"CreateContainerConfigError": {},
+	"RunContainerError":          {},
+	"ContainerCannotRun":         {},
+}
+
+// CommandRunner executa o kubectl e retorna stdout.
+type CommandRunner func(ctx context.Context, args ...string) ([]byte, error)
+
+// Service lista recursos do kubeconfig e monta comandos exec/logs via kubectl.
+type Service struct {
+	kubectl    string
+	kubeconfig string
+	run        CommandRunner
+}
+
+// NewService cria o serviço usando o kubectl do PATH.
+// ORCH_KUBECONFIG permite apontar um kubeconfig alternativo ao padrão do kubectl.
+func NewService() *Service {
+	s := &Service{
+		kubectl:    "kubectl",
+		kubeconfig: strings.TrimSpace(os.Getenv("ORCH_KUBECONFIG")),
+	}
+	s.run = s.runKubectl
+	return s
+}
+
+// NewServiceWithRunner cria o serviço com um runner customizado (testes).
+func NewServiceWithRunner(run CommandRunner) *Service {
+	return &Service{kubectl: "kubectl", run: run}
+}
+
+func (s *Service) runKubectl(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, s.kubectl, args...)
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("kubectl %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("kubectl %s failed: %w", strings.Join(args, " "), err)
+	}
+	return out, nil
+}
+
+func (s *Service) baseArgs(kubeContext string) []string {
+	args := make([]string, 0, 4)
+	if s.kubeconfig != "" {
+		args = append(args, "--kubeconfig", s.kubeconfig)
+	}
+	if kubeContext != "" {
+		args = append(args, "--context", kubeContext)
+	}
+	return args
+}
+
+func (s *Service) exec(kubeContext string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCommandTimeout)
+	defer cancel()
+	return s.run(ctx, append(s.baseArgs(kubeContext), args...)...)
+}
+
+// IsAvailable indica se o kubectl está instalado.
+func (s *Service) IsAvailable() bool {
+	_, err := exec.LookPath(s.kubectl)
+	return err == nil
+}
+
+// ListContexts retorna os contextos do kubeconfig, marcando o contexto atual.
+func (s *Service) ListContexts() ([]Context, error) {
+	out, err := s.exec("", "config", "view", "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+	return parseContexts(out)
+}
+
+// ListNamespaces retorna os namespaces visíveis no contexto informado.
+func (s *Service) ListNamespaces(kubeContext string) ([]string, error) {
+	if err := validateContextName(kubeContext, true); err != nil {
+		return nil, err
+	}
+	out, err := s.exec(kubeContext, "get", "namespaces", "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+	return parseNamespaces(out)
+}
+
+// ListPods retorna os pods de um namespace com status resumido por container.
+func (s *Service) ListPods(kubeContext, namespace string) ([]Pod, error) {
+	if err := validateContextName(kubeContext, true); err != nil {
+		return nil, err
+	}
+	if err := validateResourceName("namespace", namespace); err != nil {
+		return nil, err
+	}
+	out, err := s.exec(kubeContext, "get", "pods", "-n", namespace, "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+	return parsePods(out)
+}
+
+// GetPod retorna o estado atual de um pod.
+func (s *Service) GetPod(kubeContext, namespace, pod string) (*Pod, error) {
+	if err := validateContextName(kubeContext, true); err != nil {
+		return nil, err
+	}
+	if err := validateResourceName("namespace", namespace); err != nil {
+		return nil, err
+	}
+	if err := validateResourceName("pod", pod); err != nil {
+		return nil, err
+	}
+	out, err := s.exec(kubeContext, "get", "pod", pod, "-n", namespace, "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+	var item podJSON
+	if err := json.Unmarshal(out, &item); err != nil {
+		return nil, fmt.Errorf("invalid pod payload: %w", err)
+	}
+	parsed := item.toPod()
+	return &parsed, nil
+}
+
+// ValidateTarget valida o alvo antes de montar comandos de shell.
+func ValidateTarget(target PodTarget) error {
+	if err := validateContextName(target.Context, true); err != nil {
+		return err
+	}
+	if err := validateResourceName("namespace", target.Namespace); err != nil {
+		return err
+	}
+	if err := validateResourceName("pod", target.Pod); err != nil {
+		return err
+	}
+	if target.Container != "" {
+		if err := validateResourceName("container", target.Container); err != nil {
+			return err
+		}
+	}
+	if target.Shell != "" && !shellPathRegex.MatchString(target.Shell) {
+		return fmt.Errorf("invalid shell path: %q", target.Shell)
+	}
+	return nil
+}
+
+// ExecArgs monta os argumentos do kubectl exec interativo para o alvo.
+func (s *Service) ExecArgs(target PodTarget) []string {
+	args := s.baseArgs(target.Context)
+	args = append(args, "exec", "-it", "-n", target.Namespace, target.Pod)
+	if target.Container != "" {
+		args = append(args, "-c", target.Container)
+	}
+	args = append(args, "--")
+	if target.Shell != "" {
+		return append(args, target.Shell)
+	}
+	return append(args, "/bin/sh", "-c", "command -v bash >/dev/null 2>&1 && exec bash || exec sh")
+}
+
+// LogsArgs monta os argumentos do kubectl logs. previous=true lê o container anterior (crash).
+func (s *Service) LogsArgs(target PodTarget, previous bool, follow bool) []string {
+	args := s.baseArgs(target.Context)
+	args = append(args, "logs", "-n", target.Namespace, target.Pod, "--tail", "500")
+	if target.Container != "" {
+		args = append(args, "-c", target.Container)
+	}
+	if previous {
+		args = append(args, "--previous")
+	}
+	if follow {
+		args = append(args, "-f")
+	}
+	return args
+}
+
+// BuildExecScript gera um script POSIX que executa o kubectl exec e reconecta
+// automaticamente quando a conexão cai (até maxAttempts tentativas consecutivas).
+func (s *Service) BuildExecScript(target PodTarget, maxAttempts int) string {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultReconnectAttempts
+	}
+	execCmd := shellJoin(append([]string{s.kubectl}, s.ExecArgs(target)...))
+	return fmt.Sprintf(
+		`attempt=0; while [ "$attempt" -lt %d ]; do %s && exit 0; attempt=$((attempt+1)); echo "[orch] kubectl exec disconnected (attempt $attempt/%d), reconnecting in 2s..."; sleep 2; done; echo "[orch] giving up after %d attempts"`,
+		maxAttempts, execCmd, maxAttempts, maxAttempts,
+	)
+}
+
+// BuildLogsScript gera um script POSIX que mostra os logs do container anterior
+// (crash) seguido do stream dos logs atuais.
+func (s *Service) BuildLogsScript(target PodTarget) string {
+	previous := shellJoin(append([]string{s.kubectl}, s.LogsArgs(target, true, false)...))
+	current := shellJoin(append([]string{s.kubectl}, s.LogsArgs(target, false, true)...))
+	return fmt.Sprintf(
+		`echo "[orch] pod %s is not running; showing logs of the previous container"; %s; echo "[orch] streaming current logs (Ctrl+C to stop)"; %s`,
+		target.Pod, previous, current,
+	)
+}
+
+func validateContextName(name string, allowEmpty bool) error {
+	if name == "" {
+		if allowEmpty {
+			return nil
+		}
+		return fmt.Errorf("kube context is required")
+	}
+	if !contextNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid kube context name: %q", name)
+	}
+	return nil
+}
+
+func validateResourceName(kind, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%s is required", kind)
+	}
+	if !resourceNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid %s name: %q", kind, name)
+	}
+	return nil
+}
+
+func shellJoin(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		quoted = append(quoted, "'"+strings.ReplaceAll(arg, "'", `'"'"'`)+"'")
+	}
+	return strings.Join(quoted, " ")
+}
+
+// === Parsing ===
+
+type kubeconfigJSON struct {
+	CurrentContext string `json:"current-context"`
+	Contexts       []struct {
+		Name    string `json:"name"`
+		Context struct {
+			Cluster   string `json:"cluster"`
+			User      string `json:"user"`
+			Namespace string `json:"namespace"`
+		} `json:"context"`
+	} `json:"contexts"`
+}
+
+func parseContexts(data []byte) ([]Context, error) {
+	var cfg kubeconfigJSON
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig payload: %w", err)
+	}
+
+	contexts := make([]Context, 0, len(cfg.Contexts))
+	for _, item := range cfg.Contexts {
+		if item.Name == "" {
+			continue
+		}
+		contexts = append(contexts, Context{
+			Name:      item.Name,
+			Cluster:   item.Context.Cluster,
+			User:      item.Context.User,
+			Namespace: item.Context.Namespace,
+			IsCurrent: item.Name == cfg.CurrentContext,
+		})
+	}
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].Name < contexts[j].Name })
+	return contexts, nil
+}
+
+func parseNamespaces(data []byte) ([]string, error) {
+	var payload struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("invalid namespace payload: %w", err)
+	}
+
+	namespaces := make([]string, 0, len(payload.Items))
+	for _, item := range payload.Items {
+		if item.Metadata.Name != "" {
+			namespaces = append(namespaces, item.Metadata.Name)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+type containerStateJSON struct {
+	Running *struct{} `json:"running"`
+	Waiting *struct {
+		Reason string `json:"reason"`
+	} `json:"waiting"`
+	Terminated *struct {
+		Reason string `json:"reason"`
+	} `json:"terminated"`
+}
+
+type podJSON struct {
+	Metadata struct {
+		Name              string `json:"name"`
+		Namespace         string `json:"namespace"`
+		CreationTimestamp string `json:"creationTimestamp"`
+	} `json:"metadata"`
+	Spec struct {
+		NodeName   string `json:"nodeName"`
+		Containers []struct {
+			Name string `json:"name"`
+		} `json:"containers"`
+	} `json:"spec"`
+	Status struct {
+		Phase             string `json:"phase"`
+		ContainerStatuses []struct {
+			Name         string             `json:"name"`
+			Ready        bool               `json:"ready"`
+			RestartCount int                `json:"restartCount"`
+			State        containerStateJSON `json:"state"`
+		} `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+func (p podJSON) toPod() Pod {
+	pod := Pod{
+		Name:       p.Metadata.Name,
+		Namespace:  p.Metadata.Namespace,
+		Phase:      p.Status.Phase,
+		Node:       p.Spec.NodeName,
+		CreatedAt:  p.Metadata.CreationTimestamp,
+		Containers: make([]ContainerStatus, 0, len(p.Spec.Containers)),
+		Crashed:    p.Status.Phase == "Failed",
+	}
+
+	statusByName := make(map[string]ContainerStatus, len(p.Status.ContainerStatuses))
+	for _, cs := range p.Status.ContainerStatuses {
+		status := ContainerStatus{
+			Name:         cs.Name,
+			Ready:        cs.Ready,
+			RestartCount: cs.RestartCount,
+			State:        "unknown",
+		}
+		switch {
+		case cs.State.Running != nil:
+			status.State = "running"
+		case cs.State.Waiting != nil:
+			status.State = "waiting"
+			status.Reason = cs.State.Waiting.Reason
+		case cs.State.Terminated != nil:
+			status.State = "terminated"
+			status.Reason = cs.State.Terminated.Reason
+		}
+		if _, crashed := crashReasons[status.Reason]; crashed {
+			pod.Crashed = true
+		}
+		statusByName[cs.Name] = status
+	}
+
+	// Mantém a ordem do spec (primeiro container = default do kubectl exec).
+	for _, c := range p.Spec.Containers {
+		if status, ok := statusByName[c.Name]; ok {
+			pod.Containers = append(pod.Containers, status)
+			continue
+		}
+		pod.Containers = append(pod.Containers, ContainerStatus{Name: c.Name, State: "unknown"})
+	}
+	return pod
+}
+
+func parsePods(data []byte) ([]Pod, error) {
+	var payload struct {
+		Items []podJSON `json:"items"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("invalid pod list payload: %w", err)
+	}
+
+	pods := make([]Pod, 0, len(payload.Items))
+	for _, item := range payload.Items {
+		pods = append(pods, item.toPod())
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	return pods, nil
+}
+
+// ContainerCrashed indica se o container selecionado (ou qualquer um, se vazio) está em crash.
+func (p Pod) ContainerCrashed(container string) bool {
+	if container == "" {
+		return p.Crashed
+	}
+	for _, c := range p.Containers {
+		if c.Name != container {
+			continue
+		}
+		_, crashed := crashReasons[c.Reason]
+		return crashed || (c.State == "terminated" && p.Phase != "Succeeded")
+	}
+	return p.Crashed
+}
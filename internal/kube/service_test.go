@@ -0,0 +1,119 @@
+package kube
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const podListFixture = `{
+  "items": [
+    {
+      "metadata": {"name": "web-7d9f", "namespace": "prod", "creationTimestamp": "2026-01-02T10:00:00Z"},
+      "spec": {"nodeName": "node-a", "containers": [{"name": "app"}, {"name": "sidecar"}]},
+      "status": {
+        "phase": "Running",
+        "containerStatuses": [
+          {"name": "sidecar", "ready": true, "restartCount": 0, "state": {"running": {}}},
+          {"name": "app", "ready": false, "restartCount": 7, "state": {"waiting": {"reason": "CrashLoopBackOff"}}}
+        ]
+      }
+    },
+    {
+      "metadata": {"name": "api-1", "namespace": "prod"},
+      "spec": {"containers": [{"name": "api"}]},
+      "status": {"phase": "Running", "containerStatuses": [{"name": "api", "ready": true, "state": {"running": {}}}]}
+    }
+  ]
+}`
+
+func TestListPodsParsesContainerStateAndCrash(t *testing.T) {
+	var gotArgs []string
+	svc := NewServiceWithRunner(func(_ context.Context, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(podListFixture), nil
+	})
+
+	pods, err := svc.ListPods("dev-cluster", "prod")
+	if err != nil {
+		t.Fatalf("ListPods returned error: %v", err)
+	}
+	if strings.Join(gotArgs, " ") != "--context dev-cluster get pods -n prod -o json" {
+		t.Fatalf("unexpected kubectl args: %v", gotArgs)
+	}
+	if len(pods) != 2 || pods[0].Name != "api-1" || pods[1].Name != "web-7d9f" {
+		t.Fatalf("unexpected pods order: %+v", pods)
+	}
+
+	web := pods[1]
+	if !web.Crashed {
+		t.Fatalf("expected web pod to be flagged as crashed")
+	}
+	if web.Containers[0].Name != "app" || web.Containers[0].Reason != "CrashLoopBackOff" || web.Containers[0].RestartCount != 7 {
+		t.Fatalf("unexpected app container status: %+v", web.Containers[0])
+	}
+	if web.ContainerCrashed("sidecar") {
+		t.Fatalf("sidecar should not be considered crashed")
+	}
+	if !web.ContainerCrashed("app") {
+		t.Fatalf("app container should be considered crashed")
+	}
+	if pods[0].Crashed {
+		t.Fatalf("api pod should not be crashed")
+	}
+}
+
+func TestParseContextsMarksCurrent(t *testing.T) {
+	data := []byte(`{"current-context":"b","contexts":[{"name":"b","context":{"cluster":"c2","namespace":"ops"}},{"name":"a","context":{"cluster":"c1"}}]}`)
+	contexts, err := parseContexts(data)
+	if err != nil {
+		t.Fatalf("parseContexts returned error: %v", err)
+	}
+	if len(contexts) != 2 || contexts[0].Name != "a" || contexts[1].Name != "b" {
+		t.Fatalf("unexpected contexts: %+v", contexts)
+	}
+	if contexts[0].IsCurrent || !contexts[1].IsCurrent || contexts[1].Namespace != "ops" {
+		t.Fatalf("unexpected current-context flags: %+v", contexts)
+	}
+}
+
+func TestValidateTargetRejectsShellInjection(t *testing.T) {
+	invalid := []PodTarget{
+		{Namespace: "prod", Pod: "web; rm -rf /"},
+		{Namespace: "Prod", Pod: "web"},
+		{Namespace: "prod", Pod: "web", Container: "$(id)"},
+		{Context: "ctx 'x'", Namespace: "prod", Pod: "web"},
+		{Namespace: "prod", Pod: "web", Shell: "bash -c id"},
+	}
+	for _, target := range invalid {
+		if err := ValidateTarget(target); err == nil {
+			t.Fatalf("expected validation error for %+v", target)
+		}
+	}
+
+	valid := PodTarget{Context: "arn:aws:eks:us-east-1:123:cluster/dev", Namespace: "prod", Pod: "web-7d9f", Container: "app", Shell: "/bin/bash"}
+	if err := ValidateTarget(valid); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+}
+
+func TestBuildExecScriptReconnectsWithSelectedContainer(t *testing.T) {
+	svc := NewServiceWithRunner(nil)
+	script := svc.BuildExecScript(PodTarget{Context: "dev", Namespace: "prod", Pod: "web", Container: "app"}, 3)
+
+	if !strings.Contains(script, `'exec' '-it' '-n' 'prod' 'web' '-c' 'app'`) {
+		t.Fatalf("script does not exec into selected container: %s", script)
+	}
+	if !strings.Contains(script, `-lt 3`) || !strings.Contains(script, "reconnecting") {
+		t.Fatalf("script does not include reconnection loop: %s", script)
+	}
+}
+
+func TestBuildLogsScriptIncludesPreviousContainer(t *testing.T) {
+	svc := NewServiceWithRunner(nil)
+	script := svc.BuildLogsScript(PodTarget{Namespace: "prod", Pod: "web", Container: "app"})
+
+	if !strings.Contains(script, `'--previous'`) || !strings.Contains(script, `'-f'`) {
+		t.Fatalf("logs script should show previous logs and follow current ones: %s", script)
+	}
+}
@@ -0,0 +1,39 @@
+package kube
+
+// Context representa um contexto do kubeconfig.
+type Context struct {
+	Name      string `json:"name"`
+	Cluster   string `json:"cluster"`
+	User      string `json:"user,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	IsCurrent bool   `json:"isCurrent"`
+}
+
+// ContainerStatus resume o estado de um container dentro do pod.
+type ContainerStatus struct {
+	Name         string `json:"name"`
+	Ready        bool   `json:"ready"`
+	RestartCount int    `json:"restartCount"`
+	State        string `json:"state"`            // "running" | "waiting" | "terminated" | "unknown"
+	Reason       string `json:"reason,omitempty"` // ex: CrashLoopBackOff, Error, OOMKilled
+}
+
+// Pod representa um pod listado para seleção de terminal.
+type Pod struct {
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	Phase      string            `json:"phase"`
+	Node       string            `json:"node,omitempty"`
+	Containers []ContainerStatus `json:"containers"`
+	Crashed    bool              `json:"crashed"`
+	CreatedAt  string            `json:"createdAt,omitempty"`
+}
+
+// PodTarget identifica o alvo de um terminal exec/logs.
+type PodTarget struct {
+	Context   string `json:"context"`
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container,omitempty"`
+	Shell     string `json:"shell,omitempty"` // vazio = auto (bash -> sh)
+}
@@ -0,0 +1,414 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Level é o nível de severidade de uma entrada de log.
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+const defaultRingSize = 2000
+
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return "info"
+}
+
+// MarshalJSON serializa o nível pelo nome.
+func (l Level) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.String())
+}
+
+// UnmarshalJSON aceita o nível pelo nome.
+func (l *Level) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	parsed, err := ParseLevel(name)
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+// ParseLevel converte "debug", "info", "warn"/"warning" ou "error" em Level.
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug, nil
+	case "", "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", name)
+}
+
+// Entry é uma linha de log estruturada (formato do arquivo JSON e do visualizador).
+type Entry struct {
+	Seq       uint64            `json:"seq"`
+	Time      time.Time         `json:"time"`
+	Level     Level             `json:"level" ts_type:"string"`
+	Component string            `json:"component,omitempty"`
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+// Logger grava entradas estruturadas em arquivo rotativo, stderr e num ring buffer em memória.
+type Logger struct {
+	level atomic.Int32
+
+	mu          sync.Mutex
+	seq         uint64
+	ring        []Entry
+	ringNext    int
+	ringFull    bool
+	file        *rotatingFile
+	console     io.Writer
+	sanitize    func(string) string
+	subscribers map[int]func(Entry)
+	nextSubID   int
+}
+
+// New cria um logger sem arquivo (apenas console e memória).
+func New(level Level) *Logger {
+	logger := &Logger{
+		ring:        make([]Entry, defaultRingSize),
+		console:     os.Stderr,
+		subscribers: make(map[int]func(Entry)),
+	}
+	logger.level.Store(int32(level))
+	return logger
+}
+
+var defaultLogger = New(levelFromEnv())
+
+func levelFromEnv() Level {
+	level, err := ParseLevel(os.Getenv("ORCH_LOG_LEVEL"))
+	if err != nil {
+		return LevelInfo
+	}
+	return level
+}
+
+// Default retorna o logger global do app.
+func Default() *Logger {
+	return defaultLogger
+}
+
+// Init abre o arquivo rotativo em dir e redireciona o pacote log padrão para o logger global.
+func Init(dir string) error {
+	if err := defaultLogger.OpenFile(dir); err != nil {
+		return err
+	}
+	log.SetFlags(0)
+	log.SetOutput(defaultLogger)
+	return nil
+}
+
+// OpenFile passa a gravar as entradas em dir/orch.log (JSON por linha, com rotação por tamanho).
+func (l *Logger) OpenFile(dir string) error {
+	file, err := openRotatingFile(dir, logFileName, maxLogFileBytes, maxLogFileBackups)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	previous := l.file
+	l.file = file
+	l.mu.Unlock()
+	if previous != nil {
+		_ = previous.Close()
+	}
+	return nil
+}
+
+// Close fecha o arquivo de log.
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	file := l.file
+	l.file = nil
+	l.mu.Unlock()
+	if file == nil {
+		return nil
+	}
+	return file.Close()
+}
+
+// FilePath retorna o arquivo de log atual ("" quando só há console).
+func (l *Logger) FilePath() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return ""
+	}
+	return l.file.path
+}
+
+// SetConsole troca a saída de console (nil desativa).
+func (l *Logger) SetConsole(w io.Writer) {
+	l.mu.Lock()
+	l.console = w
+	l.mu.Unlock()
+}
+
+// SetSanitizer define o filtro de segredos aplicado a mensagens e campos.
+func (l *Logger) SetSanitizer(sanitize func(string) string) {
+	l.mu.Lock()
+	l.sanitize = sanitize
+	l.mu.Unlock()
+}
+
+// Level retorna o nível mínimo atual.
+func (l *Logger) Level() Level {
+	return Level(l.level.Load())
+}
+
+// SetLevel altera o nível mínimo em tempo de execução.
+func (l *Logger) SetLevel(level Level) {
+	l.level.Store(int32(level))
+}
+
+// Enabled informa se entradas do nível seriam registradas.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.Level()
+}
+
+// Log registra uma entrada com campos opcionais (pares chave/valor).
+func (l *Logger) Log(level Level, component, message string, keyvals ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+	var fields map[string]string
+	if len(keyvals) > 0 {
+		fields = make(map[string]string, len(keyvals)/2)
+		for i := 0; i+1 < len(keyvals); i += 2 {
+			fields[fmt.Sprint(keyvals[i])] = fmt.Sprint(keyvals[i+1])
+		}
+	}
+	l.record(Entry{Time: time.Now(), Level: level, Component: component, Message: message, Fields: fields})
+}
+
+// Debugf registra uma entrada debug.
+func (l *Logger) Debugf(component, format string, args ...interface{}) {
+	l.logf(LevelDebug, component, format, args...)
+}
+
+// Infof registra uma entrada info.
+func (l *Logger) Infof(component, format string, args ...interface{}) {
+	l.logf(LevelInfo, component, format, args...)
+}
+
+// Warnf registra uma entrada warn.
+func (l *Logger) Warnf(component, format string, args ...interface{}) {
+	l.logf(LevelWarn, component, format, args...)
+}
+
+// Errorf registra uma entrada error.
+func (l *Logger) Errorf(component, format string, args ...interface{}) {
+	l.logf(LevelError, component, format, args...)
+}
+
+func (l *Logger) logf(level Level, component, format string, args ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+	l.record(Entry{Time: time.Now(), Level: level, Component: component, Message: fmt.Sprintf(format, args...)})
+}
+
+// Write adapta linhas do pacote log padrão ("[ORCH][GitSync] msg key=value") em entradas estruturadas.
+func (l *Logger) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		entry := parseLegacyLine(line)
+		if l.Enabled(entry.Level) {
+			entry.Time = time.Now()
+			l.record(entry)
+		}
+	}
+	return len(p), nil
+}
+
+// Recent retorna até limit entradas (mais antigas primeiro) com nível >= minLevel.
+func (l *Logger) Recent(minLevel Level, limit int) []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ordered := make([]Entry, 0, len(l.ring))
+	if l.ringFull {
+		ordered = append(ordered, l.ring[l.ringNext:]...)
+	}
+	ordered = append(ordered, l.ring[:l.ringNext]...)
+
+	filtered := make([]Entry, 0, len(ordered))
+	for _, entry := range ordered {
+		if entry.Level >= minLevel {
+			filtered = append(filtered, entry)
+		}
+	}
+	if limit > 0 && len(filtered) > limit {
+		filtered = filtered[len(filtered)-limit:]
+	}
+	return filtered
+}
+
+// Subscribe recebe cada nova entrada registrada; retorna a função de cancelamento.
+// O callback roda fora do lock do logger, mas não deve bloquear.
+func (l *Logger) Subscribe(fn func(Entry)) func() {
+	l.mu.Lock()
+	id := l.nextSubID
+	l.nextSubID++
+	l.subscribers[id] = fn
+	l.mu.Unlock()
+	return func() {
+		l.mu.Lock()
+		delete(l.subscribers, id)
+		l.mu.Unlock()
+	}
+}
+
+func (l *Logger) record(entry Entry) {
+	l.mu.Lock()
+	if l.sanitize != nil {
+		entry.Message = l.sanitize(entry.Message)
+		for key, value := range entry.Fields {
+			entry.Fields[key] = l.sanitize(value)
+		}
+	}
+	l.seq++
+	entry.Seq = l.seq
+	l.ring[l.ringNext] = entry
+	l.ringNext = (l.ringNext + 1) % len(l.ring)
+	if l.ringNext == 0 {
+		l.ringFull = true
+	}
+
+	if l.file != nil {
+		if line, err := json.Marshal(entry); err == nil {
+			if _, err := l.file.Write(append(line, '\n')); err != nil && l.console != nil {
+				fmt.Fprintf(l.console, "[LOG] failed to write log file: %v\n", err)
+			}
+		}
+	}
+	if l.console != nil {
+		fmt.Fprintln(l.console, formatConsole(entry))
+	}
+	subscribers := make([]func(Entry), 0, len(l.subscribers))
+	for _, fn := range l.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	l.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(entry)
+	}
+}
+
+func formatConsole(entry Entry) string {
+	var b strings.Builder
+	b.WriteString(entry.Time.Format("2006/01/02 15:04:05"))
+	b.WriteString(" ")
+	b.WriteString(strings.ToUpper(entry.Level.String()))
+	if entry.Component != "" {
+		b.WriteString(" [")
+		b.WriteString(entry.Component)
+		b.WriteString("]")
+	}
+	b.WriteString(" ")
+	b.WriteString(entry.Message)
+	return b.String()
+}
+
+var (
+	legacyTagPattern   = regexp.MustCompile(`^((?:\[[^\]\s]+\])+)\s*`)
+	legacyFieldPattern = regexp.MustCompile(`(?:^|\s)([A-Za-z][A-Za-z0-9_]*)=("[^"]*"|\S+)`)
+	legacyErrorHints   = []string{"error", "failed", "failure", "panic", "unable to"}
+	legacyWarnHints    = []string{"warn", "could not", "unavailable", "fallback", "falling back", "skipping", "retry", "disabled"}
+)
+
+// parseLegacyLine extrai componente ([ORCH][GitSync] -> ORCH/GitSync), campos key=value e
+// infere o nível pelo texto, já que as chamadas log.Printf não carregam severidade.
+func parseLegacyLine(line string) Entry {
+	entry := Entry{Level: LevelInfo, Message: line}
+	if match := legacyTagPattern.FindStringSubmatch(line); match != nil {
+		tags := strings.Split(strings.Trim(match[1], "[]"), "][")
+		entry.Component = strings.Join(tags, "/")
+		entry.Message = line[len(match[0]):]
+	}
+
+	for _, match := range legacyFieldPattern.FindAllStringSubmatch(entry.Message, -1) {
+		if entry.Fields == nil {
+			entry.Fields = make(map[string]string)
+		}
+		entry.Fields[match[1]] = strings.Trim(match[2], `"`)
+	}
+
+	lower := strings.ToLower(entry.Message)
+	if _, ok := entry.Fields["err"]; ok {
+		entry.Level = LevelError
+		return entry
+	}
+	for _, hint := range legacyErrorHints {
+		if strings.Contains(lower, hint) {
+			entry.Level = LevelError
+			return entry
+		}
+	}
+	for _, hint := range legacyWarnHints {
+		if strings.Contains(lower, hint) {
+			entry.Level = LevelWarn
+			return entry
+		}
+	}
+	return entry
+}
+
+// Debugf registra debug no logger global.
+func Debugf(component, format string, args ...interface{}) {
+	defaultLogger.Debugf(component, format, args...)
+}
+
+// Infof registra info no logger global.
+func Infof(component, format string, args ...interface{}) {
+	defaultLogger.Infof(component, format, args...)
+}
+
+// Warnf registra warn no logger global.
+func Warnf(component, format string, args ...interface{}) {
+	defaultLogger.Warnf(component, format, args...)
+}
+
+// Errorf registra error no logger global.
+func Errorf(component, format string, args ...interface{}) {
+	defaultLogger.Errorf(component, format, args...)
+}
@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestLogger(level Level) *Logger {
+	logger := New(level)
+	logger.SetConsole(io.Discard)
+	return logger
+}
+
+func TestParseLegacyLineExtractsComponentFieldsAndLevel(t *testing.T) {
+	entry := parseLegacyLine("[ORCH][GitSync] session=abc watch failed repo=/tmp/r err=boom")
+	if entry.Component != "ORCH/GitSync" {
+		t.Fatalf("unexpected component %q", entry.Component)
+	}
+	if entry.Level != LevelError {
+		t.Fatalf("expected error level, got %s", entry.Level)
+	}
+	if entry.Fields["session"] != "abc" || entry.Fields["repo"] != "/tmp/r" {
+		t.Fatalf("unexpected fields %+v", entry.Fields)
+	}
+
+	if entry := parseLegacyLine("[ORCH] Could not auto-watch workspace repo"); entry.Level != LevelWarn {
+		t.Fatalf("expected warn level, got %s", entry.Level)
+	}
+	if entry := parseLegacyLine("[ORCH] Auth service initialized"); entry.Level != LevelInfo || entry.Message != "Auth service initialized" {
+		t.Fatalf("unexpected info entry %+v", entry)
+	}
+}
+
+func TestRecentFiltersByLevelAndLimit(t *testing.T) {
+	logger := newTestLogger(LevelDebug)
+	logger.Debugf("T", "debug")
+	logger.Infof("T", "info")
+	logger.Warnf("T", "warn")
+	logger.Errorf("T", "error")
+
+	entries := logger.Recent(LevelWarn, 0)
+	if len(entries) != 2 || entries[0].Message != "warn" || entries[1].Message != "error" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	if entries := logger.Recent(LevelDebug, 1); len(entries) != 1 || entries[0].Message != "error" {
+		t.Fatalf("expected only the newest entry, got %+v", entries)
+	}
+}
+
+func TestRecentWrapsRingBuffer(t *testing.T) {
+	logger := newTestLogger(LevelInfo)
+	for i := 0; i < defaultRingSize+10; i++ {
+		logger.Infof("T", "entry")
+	}
+	entries := logger.Recent(LevelDebug, 0)
+	if len(entries) != defaultRingSize {
+		t.Fatalf("expected ring size %d, got %d", defaultRingSize, len(entries))
+	}
+	if entries[0].Seq != 11 || entries[len(entries)-1].Seq != uint64(defaultRingSize+10) {
+		t.Fatalf("unexpected ring order: first=%d last=%d", entries[0].Seq, entries[len(entries)-1].Seq)
+	}
+}
+
+func TestLevelGatingAndSanitizer(t *testing.T) {
+	logger := newTestLogger(LevelWarn)
+	logger.SetSanitizer(func(s string) string { return strings.ReplaceAll(s, "secret", "***") })
+
+	logger.Infof("T", "ignored")
+	logger.Log(LevelError, "T", "token secret leaked", "value", "secret")
+	entries := logger.Recent(LevelDebug, 0)
+	if len(entries) != 1 {
+		t.Fatalf("expected info entry to be gated, got %+v", entries)
+	}
+	if entries[0].Message != "token *** leaked" || entries[0].Fields["value"] != "***" {
+		t.Fatalf("expected sanitized entry, got %+v", entries[0])
+	}
+
+	logger.SetLevel(LevelDebug)
+	logger.Debugf("T", "visible")
+	if entries := logger.Recent(LevelDebug, 1); entries[0].Message != "visible" {
+		t.Fatalf("expected debug entry after level change, got %+v", entries)
+	}
+}
+
+func TestSubscribeReceivesEntriesUntilCancelled(t *testing.T) {
+	logger := newTestLogger(LevelInfo)
+	var received []string
+	cancel := logger.Subscribe(func(entry Entry) { received = append(received, entry.Message) })
+
+	logger.Infof("T", "first")
+	cancel()
+	logger.Infof("T", "second")
+	if len(received) != 1 || received[0] != "first" {
+		t.Fatalf("unexpected subscription entries %+v", received)
+	}
+}
+
+func TestFileOutputIsJSONAndRotates(t *testing.T) {
+	dir := t.TempDir()
+	logger := newTestLogger(LevelInfo)
+	if err := logger.OpenFile(dir); err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	t.Cleanup(func() { _ = logger.Close() })
+
+	if _, err := logger.Write([]byte("[AUDIT] pruned 3 events\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	file, err := os.Open(filepath.Join(dir, logFileName))
+	if err != nil {
+		t.Fatalf("open log file: %v", err)
+	}
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		t.Fatalf("expected a log line")
+	}
+	var entry Entry
+	if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+		t.Fatalf("log line is not JSON: %v", err)
+	}
+	_ = file.Close()
+	if entry.Component != "AUDIT" || entry.Message != "pruned 3 events" || entry.Level != LevelInfo {
+		t.Fatalf("unexpected file entry %+v", entry)
+	}
+
+	logger.file.maxBytes = 1
+	logger.Infof("T", "after rotation")
+	if _, err := os.Stat(filepath.Join(dir, logFileName+".1")); err != nil {
+		t.Fatalf("expected rotated file: %v", err)
+	}
+}
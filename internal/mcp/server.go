@@ -0,0 +1,439 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProtocolVersion é a versão do MCP anunciada quando o cliente pede uma que não suportamos.
+const ProtocolVersion = "2025-06-18"
+
+var supportedVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+const (
+	// Path é o endpoint do transporte Streamable HTTP.
+	Path = "/mcp"
+
+	sessionHeader   = "Mcp-Session-Id"
+	maxRequestBytes = 1 << 20
+	maxSessions     = 64
+)
+
+// Códigos de erro JSON-RPC.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Tool é uma ferramenta exposta aos agentes externos.
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]interface{}
+	ReadOnly    bool // só leitura: não altera terminais, arquivos ou repositórios
+	Handler     func(ctx context.Context, call Call) (string, error)
+}
+
+// Call é uma chamada de ferramenta feita por um cliente MCP.
+type Call struct {
+	Client    string
+	Tool      string
+	Arguments json.RawMessage
+}
+
+// Bind decodifica os argumentos da chamada (ausentes = objeto vazio).
+func (c Call) Bind(target interface{}) error {
+	if len(c.Arguments) == 0 || string(c.Arguments) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(c.Arguments, target); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+// CallRecord descreve uma chamada concluída, para auditoria.
+type CallRecord struct {
+	Client     string
+	Tool       string
+	Arguments  string
+	Allowed    bool
+	Error      string
+	DurationMs int64
+}
+
+// Server implementa o lado servidor do MCP (tools) sobre HTTP local.
+type Server struct {
+	name    string
+	version string
+
+	mu        sync.RWMutex
+	tools     []Tool
+	authorize func(ctx context.Context, call Call) error
+	observe   func(CallRecord)
+	sessions  map[string]string // Mcp-Session-Id -> nome do cliente
+
+	httpMu   sync.Mutex
+	httpSrv  *http.Server
+	listener net.Listener
+	token    string
+}
+
+// NewServer cria o servidor sem ferramentas registradas.
+func NewServer(name, version string) *Server {
+	return &Server{name: name, version: version, sessions: make(map[string]string)}
+}
+
+// Register adiciona (ou substitui) uma ferramenta.
+func (s *Server) Register(tool Tool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if idx := slices.IndexFunc(s.tools, func(t Tool) bool { return t.Name == tool.Name }); idx >= 0 {
+		s.tools[idx] = tool
+		return
+	}
+	s.tools = append(s.tools, tool)
+}
+
+// Tools lista as ferramentas na ordem de registro.
+func (s *Server) Tools() []Tool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Tool(nil), s.tools...)
+}
+
+// SetAuthorizer define a checagem de permissão executada antes de cada ferramenta.
+func (s *Server) SetAuthorizer(fn func(ctx context.Context, call Call) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authorize = fn
+}
+
+// SetCallObserver recebe cada chamada concluída (permitida ou não).
+func (s *Server) SetCallObserver(fn func(CallRecord)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observe = fn
+}
+
+// Start abre o listener em addr (ex.: 127.0.0.1:7391); requests precisam de "Authorization: Bearer token".
+func (s *Server) Start(addr string, token string) error {
+	if strings.TrimSpace(token) == "" {
+		return fmt.Errorf("mcp server token cannot be empty")
+	}
+	s.httpMu.Lock()
+	defer s.httpMu.Unlock()
+	if s.httpSrv != nil {
+		return fmt.Errorf("mcp server already running on %s", s.listener.Addr())
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.token = token
+	s.listener = listener
+	s.httpSrv = &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	go func(srv *http.Server) {
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("[MCP] server stopped: %v", err)
+		}
+	}(s.httpSrv)
+	log.Printf("[MCP] listening on %s", listener.Addr())
+	return nil
+}
+
+// Stop fecha o listener e descarta as sessões.
+func (s *Server) Stop() {
+	s.httpMu.Lock()
+	srv := s.httpSrv
+	s.httpSrv, s.listener, s.token = nil, nil, ""
+	s.httpMu.Unlock()
+	if srv == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = srv.Shutdown(ctx)
+	s.mu.Lock()
+	s.sessions = make(map[string]string)
+	s.mu.Unlock()
+}
+
+// Addr retorna o endereço em uso ("" quando parado).
+func (s *Server) Addr() string {
+	s.httpMu.Lock()
+	defer s.httpMu.Unlock()
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// ServeHTTP implementa o transporte Streamable HTTP (só respostas JSON, sem stream SSE).
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != Path {
+		http.NotFound(w, r)
+		return
+	}
+	// Páginas abertas no navegador não podem falar com o servidor local (DNS rebinding).
+	if origin := r.Header.Get("Origin"); origin != "" && !isLoopbackOrigin(origin) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sessionID := r.Header.Get(sessionHeader)
+	switch r.Method {
+	case http.MethodPost:
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.sessions, sessionID)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		return
+	default:
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	client := "unknown"
+	if sessionID != "" {
+		s.mu.RLock()
+		name, ok := s.sessions[sessionID]
+		s.mu.RUnlock()
+		if !ok {
+			http.Error(w, "unknown session", http.StatusNotFound)
+			return
+		}
+		client = name
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBytes+1))
+	if err != nil || len(body) > maxRequestBytes {
+		http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	response, initializedClient := s.handle(r.Context(), client, body)
+	if initializedClient != "" {
+		w.Header().Set(sessionHeader, s.newSession(initializedClient))
+	}
+	if response == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(response)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	s.httpMu.Lock()
+	token := s.token
+	s.httpMu.Unlock()
+	provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && token != "" && subtle.ConstantTimeCompare([]byte(strings.TrimSpace(provided)), []byte(token)) == 1
+}
+
+func isLoopbackOrigin(origin string) bool {
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	host := parsed.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (s *Server) newSession(client string) string {
+	id := NewToken()[:32]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.sessions) >= maxSessions {
+		for existing := range s.sessions {
+			delete(s.sessions, existing)
+			break
+		}
+	}
+	s.sessions[id] = client
+	return id
+}
+
+// NewToken gera um token aleatório (hex de 32 bytes).
+func NewToken() string {
+	buf := make([]byte, 32)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// handle processa uma mensagem JSON-RPC; retorna nil para notificações e, no initialize,
+// o nome do cliente para abrir a sessão.
+func (s *Server) handle(ctx context.Context, client string, raw []byte) ([]byte, string) {
+	var req rpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return encodeResponse(nil, nil, &rpcError{Code: codeParseError, Message: "invalid JSON-RPC message"}), ""
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return encodeResponse(req.ID, nil, &rpcError{Code: codeInvalidRequest, Message: "invalid JSON-RPC request"}), ""
+	}
+	if len(req.ID) == 0 {
+		// Notificações (notifications/initialized, cancelled...) não têm resposta.
+		return nil, ""
+	}
+
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+			ClientInfo      struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"clientInfo"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		version := ProtocolVersion
+		if slices.Contains(supportedVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		name := strings.TrimSpace(params.ClientInfo.Name)
+		if name == "" {
+			name = "unknown"
+		}
+		result := map[string]interface{}{
+			"protocolVersion": version,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{"listChanged": false}},
+			"serverInfo":      map[string]string{"name": s.name, "version": s.version},
+		}
+		return encodeResponse(req.ID, result, nil), name
+	case "ping":
+		return encodeResponse(req.ID, map[string]interface{}{}, nil), ""
+	case "tools/list":
+		return encodeResponse(req.ID, map[string]interface{}{"tools": s.describeTools()}, nil), ""
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return encodeResponse(req.ID, nil, &rpcError{Code: codeInvalidParams, Message: "invalid tools/call params"}), ""
+		}
+		result, rpcErr := s.callTool(ctx, Call{Client: client, Tool: params.Name, Arguments: params.Arguments})
+		return encodeResponse(req.ID, result, rpcErr), ""
+	default:
+		return encodeResponse(req.ID, nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}), ""
+	}
+}
+
+func (s *Server) describeTools() []map[string]interface{} {
+	tools := s.Tools()
+	described := make([]map[string]interface{}, 0, len(tools))
+	for _, tool := range tools {
+		schema := tool.InputSchema
+		if schema == nil {
+			schema = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+		}
+		described = append(described, map[string]interface{}{
+			"name":        tool.Name,
+			"description": tool.Description,
+			"inputSchema": schema,
+			"annotations": map[string]bool{"readOnlyHint": tool.ReadOnly},
+		})
+	}
+	return described
+}
+
+// callTool executa a ferramenta; permissão negada e falhas viram resultado com isError,
+// como o protocolo pede para erros de execução.
+func (s *Server) callTool(ctx context.Context, call Call) (interface{}, *rpcError) {
+	s.mu.RLock()
+	idx := slices.IndexFunc(s.tools, func(t Tool) bool { return t.Name == call.Tool })
+	var tool Tool
+	if idx >= 0 {
+		tool = s.tools[idx]
+	}
+	authorize, observe := s.authorize, s.observe
+	s.mu.RUnlock()
+	if idx < 0 {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + call.Tool}
+	}
+
+	started := time.Now()
+	record := CallRecord{Client: call.Client, Tool: call.Tool, Arguments: string(call.Arguments), Allowed: true}
+	var (
+		text string
+		err  error
+	)
+	if authorize != nil {
+		if authErr := authorize(ctx, call); authErr != nil {
+			record.Allowed = false
+			err = fmt.Errorf("permission denied: %w", authErr)
+		}
+	}
+	if err == nil {
+		text, err = tool.Handler(ctx, call)
+	}
+	record.DurationMs = time.Since(started).Milliseconds()
+	if err != nil {
+		record.Error = err.Error()
+		text = err.Error()
+	}
+	if observe != nil {
+		observe(record)
+	}
+	return map[string]interface{}{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": err != nil,
+	}, nil
+}
+
+func encodeResponse(id json.RawMessage, result interface{}, rpcErr *rpcError) []byte {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	encoded, err := json.Marshal(rpcResponse{JSONRPC: "2.0", ID: id, Result: result, Error: rpcErr})
+	if err != nil {
+		encoded, _ = json.Marshal(rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: -32603, Message: err.Error()}})
+	}
+	return encoded
+}
@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	server := NewServer("orch", "test")
+	server.Register(Tool{
+		Name:     "echo",
+		ReadOnly: true,
+		Handler: func(_ context.Context, call Call) (string, error) {
+			var args struct {
+				Text string `json:"text"`
+			}
+			if err := call.Bind(&args); err != nil {
+				return "", err
+			}
+			return call.Client + ":" + args.Text, nil
+		},
+	})
+	server.Register(Tool{Name: "danger", Handler: func(context.Context, Call) (string, error) { return "ran", nil }})
+	if err := server.Start("127.0.0.1:0", "secret-token"); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	t.Cleanup(server.Stop)
+	return server, "http://" + server.Addr() + Path
+}
+
+func post(t *testing.T, endpoint, token, session, body string, headers ...string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	if session != "" {
+		req.Header.Set(sessionHeader, session)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var decoded map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp, decoded
+}
+
+func TestServerInitializeListAndCall(t *testing.T) {
+	server, endpoint := newTestServer(t)
+	var records []CallRecord
+	server.SetCallObserver(func(record CallRecord) { records = append(records, record) })
+	server.SetAuthorizer(func(_ context.Context, call Call) error {
+		if call.Tool == "danger" {
+			return errors.New("tool is disabled")
+		}
+		return nil
+	})
+
+	if resp, _ := post(t, endpoint, "wrong", "", `{"jsonrpc":"2.0","id":1,"method":"ping"}`); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for bad token, got %d", resp.StatusCode)
+	}
+	if resp, _ := post(t, endpoint, "secret-token", "", `{"jsonrpc":"2.0","id":1,"method":"ping"}`, "Origin", "https://evil.example"); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for foreign origin, got %d", resp.StatusCode)
+	}
+
+	resp, body := post(t, endpoint, "secret-token", "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","clientInfo":{"name":"ide-agent"}}}`)
+	session := resp.Header.Get(sessionHeader)
+	result, _ := body["result"].(map[string]interface{})
+	if session == "" || result["protocolVersion"] != "2025-03-26" {
+		t.Fatalf("unexpected initialize: session=%q body=%v", session, body)
+	}
+	if resp, _ := post(t, endpoint, "secret-token", session, `{"jsonrpc":"2.0","method":"notifications/initialized"}`); resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected 202 for notification, got %d", resp.StatusCode)
+	}
+
+	_, body = post(t, endpoint, "secret-token", session, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+	tools := body["result"].(map[string]interface{})["tools"].([]interface{})
+	if len(tools) != 2 || tools[0].(map[string]interface{})["annotations"].(map[string]interface{})["readOnlyHint"] != true {
+		t.Fatalf("unexpected tools: %v", tools)
+	}
+
+	_, body = post(t, endpoint, "secret-token", session, `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`)
+	call := body["result"].(map[string]interface{})
+	if call["isError"] != false || call["content"].([]interface{})[0].(map[string]interface{})["text"] != "ide-agent:hi" {
+		t.Fatalf("unexpected echo result: %v", call)
+	}
+
+	_, body = post(t, endpoint, "secret-token", session, `{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"danger"}}`)
+	call = body["result"].(map[string]interface{})
+	if call["isError"] != true || !strings.Contains(call["content"].([]interface{})[0].(map[string]interface{})["text"].(string), "permission denied") {
+		t.Fatalf("expected denied call, got %v", call)
+	}
+
+	_, body = post(t, endpoint, "secret-token", session, `{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"nope"}}`)
+	if body["error"] == nil {
+		t.Fatalf("expected JSON-RPC error for unknown tool, got %v", body)
+	}
+	if len(records) != 2 || !records[0].Allowed || records[1].Allowed || records[1].Client != "ide-agent" {
+		t.Fatalf("unexpected call records: %+v", records)
+	}
+}
+
+func TestServerRejectsUnknownSession(t *testing.T) {
+	server := NewServer("orch", "test")
+	server.token = "t"
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+	req.Header.Set("Authorization", "Bearer t")
+	req.Header.Set(sessionHeader, "missing")
+	server.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown session, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,357 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// Eventos que podem disparar notificações.
+const (
+	EventPRMerged       = "pr.merged"
+	EventSessionStarted = "session.started"
+	EventBuildFailed    = "build.failed"
+	EventSecretDetected = "secret.detected"
+)
+
+// Formatos de destino suportados.
+const (
+	KindGeneric = "generic" // JSON do evento (ou o template renderizado)
+	KindSlack   = "slack"   // Incoming Webhook: {"text": ...}
+	KindDiscord = "discord" // Webhook: {"content": ...}
+)
+
+const (
+	defaultMaxAttempts = 4
+	defaultBackoff     = time.Second
+	maxBackoff         = 30 * time.Second
+	maxDeliveries      = 100
+	maxResponseBytes   = 2 << 10
+)
+
+// Events lista os eventos suportados.
+func Events() []string {
+	return []string{EventPRMerged, EventSessionStarted, EventBuildFailed, EventSecretDetected}
+}
+
+// Event é o que aconteceu no app; Fields entra no payload e fica disponível no template.
+type Event struct {
+	Name       string            `json:"event"`
+	Title      string            `json:"title"`
+	Message    string            `json:"message,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	OccurredAt time.Time         `json:"occurredAt"`
+}
+
+// Target é um webhook de saída configurado.
+type Target struct {
+	ID       uint
+	Name     string
+	Kind     string
+	URL      string
+	Events   []string
+	Template string // vazio usa o formato padrão do Kind
+}
+
+// Delivery registra o envio de um evento para um destino.
+type Delivery struct {
+	TargetID   uint      `json:"targetId"`
+	TargetName string    `json:"targetName"`
+	Event      string    `json:"event"`
+	SentAt     time.Time `json:"sentAt"`
+	Attempts   int       `json:"attempts"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	OK         bool      `json:"ok"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// NormalizeKind valida o formato; vazio equivale a generic.
+func NormalizeKind(kind string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "", KindGeneric:
+		return KindGeneric, nil
+	case KindSlack:
+		return KindSlack, nil
+	case KindDiscord:
+		return KindDiscord, nil
+	default:
+		return "", fmt.Errorf("unsupported notification kind: %s", kind)
+	}
+}
+
+// ValidateURL exige http(s) com host.
+func ValidateURL(raw string) error {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return fmt.Errorf("webhook URL must be an http(s) URL")
+	}
+	return nil
+}
+
+// MaskURL esconde o caminho do webhook (Slack/Discord embutem o segredo na URL).
+func MaskURL(raw string) string {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	if parsed.Path == "" || parsed.Path == "/" {
+		return parsed.Scheme + "://" + parsed.Host
+	}
+	return parsed.Scheme + "://" + parsed.Host + "/…"
+}
+
+var templateFuncs = template.FuncMap{
+	// json serializa o valor para uso dentro de templates de payload JSON.
+	"json": func(v interface{}) (string, error) {
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	},
+}
+
+// ValidateTemplate compila o template e, para generic, confere se gera JSON válido.
+func ValidateTemplate(kind string, text string) error {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	sample := Event{Name: EventPRMerged, Title: "Sample", Message: "sample", Fields: map[string]string{"repo": "acme/api"}, OccurredAt: time.Now()}
+	_, err := Render(Target{Kind: kind, Template: text}, sample)
+	return err
+}
+
+// Render monta o corpo enviado ao destino.
+func Render(target Target, event Event) ([]byte, error) {
+	text, err := renderText(target, event)
+	if err != nil {
+		return nil, err
+	}
+	switch target.Kind {
+	case KindSlack:
+		return json.Marshal(map[string]string{"text": text})
+	case KindDiscord:
+		// Discord limita content a 2000 caracteres.
+		if runes := []rune(text); len(runes) > 2000 {
+			text = string(runes[:1999]) + "…"
+		}
+		return json.Marshal(map[string]string{"content": text})
+	default:
+		if strings.TrimSpace(target.Template) == "" {
+			return json.Marshal(event)
+		}
+		if !json.Valid([]byte(text)) {
+			return nil, fmt.Errorf("template must render valid JSON for generic webhooks")
+		}
+		return []byte(text), nil
+	}
+}
+
+func renderText(target Target, event Event) (string, error) {
+	if strings.TrimSpace(target.Template) == "" {
+		if target.Kind == KindGeneric || target.Kind == "" {
+			return "", nil
+		}
+		return defaultText(target.Kind, event), nil
+	}
+	tpl, err := template.New("notification").Funcs(templateFuncs).Option("missingkey=zero").Parse(target.Template)
+	if err != nil {
+		return "", fmt.Errorf("invalid notification template: %w", err)
+	}
+	var out bytes.Buffer
+	if err := tpl.Execute(&out, event); err != nil {
+		return "", fmt.Errorf("notification template failed: %w", err)
+	}
+	return out.String(), nil
+}
+
+func defaultText(kind string, event Event) string {
+	bold := "*"
+	if kind == KindDiscord {
+		bold = "**"
+	}
+	lines := []string{bold + event.Title + bold}
+	if event.Message != "" {
+		lines = append(lines, event.Message)
+	}
+	keys := make([]string, 0, len(event.Fields))
+	for key := range event.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("• %s: %s", key, event.Fields[key]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Dispatcher envia eventos aos destinos inscritos, em background, com retry e backoff.
+type Dispatcher struct {
+	client      *http.Client
+	load        func(event string) ([]Target, error)
+	maxAttempts int
+	backoff     time.Duration
+	sleep       func(ctx context.Context, d time.Duration) error
+
+	mu         sync.Mutex
+	deliveries []Delivery
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDispatcher cria o dispatcher; load retorna os destinos habilitados inscritos no evento.
+func NewDispatcher(client *http.Client, load func(event string) ([]Target, error)) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		client:      client,
+		load:        load,
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultBackoff,
+		sleep:       sleepWithContext,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Notify envia o evento, sem bloquear, para todos os destinos inscritos.
+func (d *Dispatcher) Notify(event Event) {
+	if d == nil || d.load == nil || d.ctx.Err() != nil {
+		return
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	targets, err := d.load(event.Name)
+	if err != nil {
+		log.Printf("[NOTIFY] failed to load targets for %s: %v", event.Name, err)
+		return
+	}
+	for _, target := range targets {
+		d.wg.Add(1)
+		go func(target Target) {
+			defer d.wg.Done()
+			d.Send(d.ctx, target, event)
+		}(target)
+	}
+}
+
+// Send entrega o evento ao destino (síncrono), tentando de novo em erro de rede, 429 e 5xx.
+// O resultado entra no histórico de entregas.
+func (d *Dispatcher) Send(ctx context.Context, target Target, event Event) Delivery {
+	delivery := Delivery{TargetID: target.ID, TargetName: target.Name, Event: event.Name, SentAt: time.Now()}
+	body, err := Render(target, event)
+	if err != nil {
+		delivery.Error = err.Error()
+		d.record(delivery)
+		return delivery
+	}
+
+	wait := d.backoff
+	for attempt := 1; attempt <= d.maxAttempts; attempt++ {
+		delivery.Attempts = attempt
+		status, retryAfter, sendErr := d.post(ctx, target.URL, body)
+		delivery.StatusCode = status
+		if sendErr == nil {
+			delivery.OK = true
+			delivery.Error = ""
+			break
+		}
+		delivery.Error = sendErr.Error()
+		if !retryable(status) || attempt == d.maxAttempts {
+			break
+		}
+		if retryAfter > 0 {
+			wait = min(retryAfter, maxBackoff)
+		}
+		if d.sleep(ctx, wait) != nil {
+			break
+		}
+		wait = min(wait*2, maxBackoff)
+	}
+	if !delivery.OK {
+		log.Printf("[NOTIFY] %s -> %q failed after %d attempt(s): %s", event.Name, target.Name, delivery.Attempts, delivery.Error)
+	}
+	d.record(delivery)
+	return delivery
+}
+
+// Deliveries retorna as últimas entregas, mais recentes primeiro.
+func (d *Dispatcher) Deliveries() []Delivery {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]Delivery, len(d.deliveries))
+	for i, delivery := range d.deliveries {
+		out[len(d.deliveries)-1-i] = delivery
+	}
+	return out
+}
+
+// Close cancela retries pendentes e espera os envios em andamento.
+func (d *Dispatcher) Close() {
+	if d == nil {
+		return
+	}
+	d.cancel()
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) post(ctx context.Context, endpoint string, body []byte) (int, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ORCH-Notifications")
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, 0, nil
+	}
+	var retryAfter time.Duration
+	if seconds, convErr := strconv.Atoi(strings.TrimSpace(resp.Header.Get("Retry-After"))); convErr == nil && seconds > 0 {
+		retryAfter = time.Duration(seconds) * time.Second
+	}
+	return resp.StatusCode, retryAfter, fmt.Errorf("webhook returned %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+}
+
+// retryable: erro de rede (status 0), rate limit e falhas do servidor.
+func retryable(status int) bool {
+	return status == 0 || status == http.StatusTooManyRequests || status >= 500
+}
+
+func (d *Dispatcher) record(delivery Delivery) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deliveries = append(d.deliveries, delivery)
+	if len(d.deliveries) > maxDeliveries {
+		d.deliveries = append([]Delivery(nil), d.deliveries[len(d.deliveries)-maxDeliveries:]...)
+	}
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
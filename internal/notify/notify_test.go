@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRenderFormatsPerKind(t *testing.T) {
+	event := Event{
+		Name:    EventPRMerged,
+		Title:   "PR #12 merged",
+		Message: "Add login",
+		Fields:  map[string]string{"repo": "acme/api", "branch": "main"},
+	}
+
+	slack, err := Render(Target{Kind: KindSlack}, event)
+	if err != nil || string(slack) != `{"text":"*PR #12 merged*\nAdd login\n• branch: main\n• repo: acme/api"}` {
+		t.Fatalf("unexpected slack payload: %s (%v)", slack, err)
+	}
+	discord, _ := Render(Target{Kind: KindDiscord, Template: "{{.Title}} em {{index .Fields \"repo\"}}"}, event)
+	if string(discord) != `{"content":"PR #12 merged em acme/api"}` {
+		t.Fatalf("unexpected discord payload: %s", discord)
+	}
+	generic, _ := Render(Target{Kind: KindGeneric, Template: `{"kind":{{json .Name}},"repo":{{json (index .Fields "repo")}}}`}, event)
+	if string(generic) != `{"kind":"pr.merged","repo":"acme/api"}` {
+		t.Fatalf("unexpected generic payload: %s", generic)
+	}
+
+	if err := ValidateTemplate(KindGeneric, "{{.Title}}"); err == nil {
+		t.Fatalf("expected non-JSON generic template to be rejected")
+	}
+	if err := ValidateTemplate(KindSlack, "{{.Nope"); err == nil {
+		t.Fatalf("expected parse error")
+	}
+	if MaskURL("https://hooks.slack.com/services/T000/B000/XXXX") != "https://hooks.slack.com/…" {
+		t.Fatalf("unexpected masked URL")
+	}
+}
+
+func TestSendRetriesServerErrorsWithBackoff(t *testing.T) {
+	var calls atomic.Int32
+	var lastBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		lastBody, _ = io.ReadAll(r.Body)
+		switch n {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(server.Client(), nil)
+	defer dispatcher.Close()
+	var waits []time.Duration
+	dispatcher.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	delivery := dispatcher.Send(context.Background(), Target{ID: 3, Name: "ops", Kind: KindGeneric, URL: server.URL}, Event{Name: EventBuildFailed, Title: "Build failed"})
+	if !delivery.OK || delivery.Attempts != 3 || delivery.StatusCode != http.StatusNoContent {
+		t.Fatalf("unexpected delivery: %+v", delivery)
+	}
+	if len(waits) != 2 || waits[0] != time.Second || waits[1] != 7*time.Second {
+		t.Fatalf("unexpected backoff waits: %v", waits)
+	}
+	var payload Event
+	if err := json.Unmarshal(lastBody, &payload); err != nil || payload.Name != EventBuildFailed {
+		t.Fatalf("unexpected generic body: %s", lastBody)
+	}
+
+	calls.Store(100)
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer rejecting.Close()
+	failed := dispatcher.Send(context.Background(), Target{ID: 4, Kind: KindSlack, URL: rejecting.URL}, Event{Name: EventBuildFailed, Title: "x"})
+	if failed.OK || failed.Attempts != 1 || failed.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected client errors not to be retried, got %+v", failed)
+	}
+	if deliveries := dispatcher.Deliveries(); len(deliveries) != 2 || deliveries[0].TargetID != 4 {
+		t.Fatalf("unexpected delivery history: %+v", deliveries)
+	}
+}
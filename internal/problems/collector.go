@@ -0,0 +1,274 @@
+package problems
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxProblemsPerWorkspace limita os diagnósticos guardados; os excedentes são ignorados.
+	MaxProblemsPerWorkspace = 1000
+	maxPendingLine          = 4096
+)
+
+var ansiEscapeRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]|\x1b\][^\x07]*\x07`)
+
+// Problem é um diagnóstico encontrado na saída de um terminal.
+type Problem struct {
+	Key         string    `json:"key"` // estável para o mesmo arquivo/posição/mensagem
+	WorkspaceID uint      `json:"workspaceId"`
+	SessionID   string    `json:"sessionId"`
+	Source      string    `json:"source"` // nome do matcher
+	File        string    `json:"file"`   // como aparece na saída (relativo ao diretório do comando)
+	Line        int       `json:"line"`
+	Column      int       `json:"column,omitempty"`
+	Severity    string    `json:"severity"`
+	Message     string    `json:"message"`
+	SeenAt      time.Time `json:"seenAt"`
+}
+
+type sessionState struct {
+	pending     string
+	typed       int // bytes digitados desde o último Enter
+	workspaceID uint
+	resolved    bool
+}
+
+// Collector aplica os matchers à saída dos terminais. Os problemas de uma sessão são
+// descartados quando um novo comando é digitado nela (a build rodou de novo).
+type Collector struct {
+	mu         sync.Mutex
+	matchers   []*Compiled
+	sessions   map[string]*sessionState
+	workspaces map[uint]map[string]Problem
+	now        func() time.Time
+
+	resolveWorkspace func(sessionID string) uint
+	onChange         func(workspaceID uint, problems []Problem)
+}
+
+// NewCollector cria o coletor com os matchers embutidos. resolveWorkspace associa a sessão ao
+// workspace; onChange (opcional) recebe a lista atualizada do workspace a cada mudança.
+func NewCollector(resolveWorkspace func(sessionID string) uint, onChange func(workspaceID uint, problems []Problem)) *Collector {
+	c := &Collector{
+		sessions:         make(map[string]*sessionState),
+		workspaces:       make(map[uint]map[string]Problem),
+		now:              time.Now,
+		resolveWorkspace: resolveWorkspace,
+		onChange:         onChange,
+	}
+	c.SetMatchers(nil)
+	return c
+}
+
+// SetMatchers troca os matchers do usuário (os embutidos continuam ativos). Matchers inválidos
+// são ignorados e devolvidos como erros.
+func (c *Collector) SetMatchers(custom []Matcher) []error {
+	compiled := make([]*Compiled, 0, len(Builtin)+len(custom))
+	var errs []error
+	for _, matcher := range Builtin {
+		if m, err := Compile(matcher); err == nil {
+			compiled = append(compiled, m)
+		}
+	}
+	for _, matcher := range custom {
+		m, err := Compile(matcher)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", matcher.Name, err))
+			continue
+		}
+		compiled = append(compiled, m)
+	}
+	c.mu.Lock()
+	c.matchers = compiled
+	c.mu.Unlock()
+	return errs
+}
+
+// ObserveInput detecta um novo comando (Enter depois de digitar algo) e limpa os problemas da sessão.
+func (c *Collector) ObserveInput(sessionID string, data []byte) {
+	if sessionID == "" || len(data) == 0 {
+		return
+	}
+	c.mu.Lock()
+	session := c.sessionLocked(sessionID)
+	cleared := false
+	for _, b := range data {
+		switch {
+		case b == '\r' || b == '\n':
+			if session.typed > 0 && c.clearSessionLocked(sessionID, session.workspaceID) {
+				cleared = true
+			}
+			session.typed = 0
+			// O terminal pode ter mudado de agente; resolve de novo na próxima saída.
+			session.resolved = false
+		case b == 0x03:
+			session.typed = 0
+		case b >= 32 || b == '\t':
+			session.typed++
+		}
+	}
+	workspaceID := session.workspaceID
+	c.mu.Unlock()
+	if cleared {
+		c.notify(workspaceID)
+	}
+}
+
+// ObserveOutput aplica os matchers às linhas concluídas no chunk.
+func (c *Collector) ObserveOutput(sessionID string, data []byte) {
+	if sessionID == "" || len(data) == 0 {
+		return
+	}
+	c.mu.Lock()
+	session := c.sessionLocked(sessionID)
+	needsResolve := !session.resolved && c.resolveWorkspace != nil
+	c.mu.Unlock()
+	var resolved uint
+	if needsResolve {
+		// Fora do lock: o resolver pode consultar o banco.
+		resolved = c.resolveWorkspace(sessionID)
+	}
+
+	c.mu.Lock()
+	if needsResolve {
+		session.workspaceID, session.resolved = resolved, true
+	}
+	text := session.pending + strings.ReplaceAll(string(data), "\r", "\n")
+	lines := strings.Split(text, "\n")
+	session.pending = lines[len(lines)-1]
+	if len(session.pending) > maxPendingLine {
+		session.pending = session.pending[len(session.pending)-maxPendingLine:]
+	}
+	workspaceID := session.workspaceID
+	changed := false
+	for _, raw := range lines[:len(lines)-1] {
+		if c.matchLineLocked(sessionID, workspaceID, raw) {
+			changed = true
+		}
+	}
+	c.mu.Unlock()
+	if changed {
+		c.notify(workspaceID)
+	}
+}
+
+// Forget descarta o estado da sessão encerrada; os problemas já encontrados continuam na lista.
+func (c *Collector) Forget(sessionID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, sessionID)
+}
+
+// Problems retorna os diagnósticos do workspace: erros primeiro, depois por arquivo e linha.
+func (c *Collector) Problems(workspaceID uint) []Problem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.problemsLocked(workspaceID)
+}
+
+// Clear apaga os diagnósticos do workspace.
+func (c *Collector) Clear(workspaceID uint) {
+	c.mu.Lock()
+	_, had := c.workspaces[workspaceID]
+	delete(c.workspaces, workspaceID)
+	c.mu.Unlock()
+	if had {
+		c.notify(workspaceID)
+	}
+}
+
+func (c *Collector) sessionLocked(sessionID string) *sessionState {
+	session, ok := c.sessions[sessionID]
+	if !ok {
+		session = &sessionState{}
+		c.sessions[sessionID] = session
+	}
+	return session
+}
+
+func (c *Collector) matchLineLocked(sessionID string, workspaceID uint, raw string) bool {
+	line := strings.TrimRight(ansiEscapeRegex.ReplaceAllString(raw, ""), " \t")
+	if strings.TrimSpace(line) == "" || len(line) > maxPendingLine {
+		return false
+	}
+	for _, matcher := range c.matchers {
+		problem, ok := matcher.Match(line)
+		if !ok {
+			continue
+		}
+		problem.Key = fmt.Sprintf("%s:%d:%d:%s", problem.File, problem.Line, problem.Column, problem.Message)
+		problem.WorkspaceID = workspaceID
+		problem.SessionID = sessionID
+		problem.SeenAt = c.now()
+
+		bucket := c.workspaces[workspaceID]
+		if bucket == nil {
+			bucket = make(map[string]Problem)
+			c.workspaces[workspaceID] = bucket
+		}
+		if _, exists := bucket[problem.Key]; !exists && len(bucket) >= MaxProblemsPerWorkspace {
+			return false
+		}
+		bucket[problem.Key] = problem
+		// Uma linha gera no máximo um problema (o primeiro matcher que reconhecer).
+		return true
+	}
+	return false
+}
+
+func (c *Collector) clearSessionLocked(sessionID string, workspaceID uint) bool {
+	bucket := c.workspaces[workspaceID]
+	removed := false
+	for key, problem := range bucket {
+		if problem.SessionID == sessionID {
+			delete(bucket, key)
+			removed = true
+		}
+	}
+	return removed
+}
+
+func (c *Collector) problemsLocked(workspaceID uint) []Problem {
+	bucket := c.workspaces[workspaceID]
+	result := make([]Problem, 0, len(bucket))
+	for _, problem := range bucket {
+		result = append(result, problem)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if severityRank(a.Severity) != severityRank(b.Severity) {
+			return severityRank(a.Severity) < severityRank(b.Severity)
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return result
+}
+
+func (c *Collector) notify(workspaceID uint) {
+	if c.onChange == nil {
+		return
+	}
+	c.onChange(workspaceID, c.Problems(workspaceID))
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,162 @@
+// Package problems aplica problem matchers (regex por linha, como nos tasks do VS Code) à saída
+// dos terminais e agrega os diagnósticos encontrados por workspace para o painel de problemas.
+package problems
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Severidades de um diagnóstico.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Matcher descreve um problem matcher: a regex e o grupo de captura de cada campo.
+// Grupos de coluna e severidade são opcionais (0).
+type Matcher struct {
+	Name          string `json:"name"`
+	Pattern       string `json:"pattern"`
+	FileGroup     int    `json:"fileGroup"`
+	LineGroup     int    `json:"lineGroup"`
+	ColumnGroup   int    `json:"columnGroup"`
+	SeverityGroup int    `json:"severityGroup"`
+	MessageGroup  int    `json:"messageGroup"`
+	Severity      string `json:"severity"` // padrão quando não há grupo de severidade
+}
+
+// Builtin são os matchers sempre ativos para as ferramentas mais comuns.
+var Builtin = []Matcher{
+	{
+		// main.go:12:5: undefined: foo (go build, go vet, gopls, golangci-lint)
+		Name:         "$go",
+		Pattern:      `^\s*((?:\.{0,2}/)?[^\s:]+\.go):(\d+)(?::(\d+))?:\s+(.+)$`,
+		FileGroup:    1,
+		LineGroup:    2,
+		ColumnGroup:  3,
+		MessageGroup: 4,
+		Severity:     SeverityError,
+	},
+	{
+		// src/app.ts(3,7): error TS2322: Type 'string' is not assignable to type 'number'.
+		Name:          "$tsc",
+		Pattern:       `^([^\s(]+\.(?:ts|tsx|mts|cts|js|jsx))\((\d+),(\d+)\):\s+(error|warning|info)\s+(TS\d+\s*:\s*.+)$`,
+		FileGroup:     1,
+		LineGroup:     2,
+		ColumnGroup:   3,
+		SeverityGroup: 4,
+		MessageGroup:  5,
+	},
+	{
+		// main.c:10:3: error: expected ';' (gcc, clang, swiftc)
+		Name:          "$gcc",
+		Pattern:       `^([^\s:]+\.(?:c|cc|cpp|cxx|h|hh|hpp|m|mm|swift|zig)):(\d+):(\d+):\s+(?:fatal\s+)?(error|warning|note):\s+(.+)$`,
+		FileGroup:     1,
+		LineGroup:     2,
+		ColumnGroup:   3,
+		SeverityGroup: 4,
+		MessageGroup:  5,
+	},
+	{
+		// /repo/src/a.js: line 3, col 7, Error - 'x' is not defined. (no-undef) (eslint -f compact)
+		Name:          "$eslint-compact",
+		Pattern:       `^(.+?): line (\d+), col (\d+), (Error|Warning|Info) - (.+)$`,
+		FileGroup:     1,
+		LineGroup:     2,
+		ColumnGroup:   3,
+		SeverityGroup: 4,
+		MessageGroup:  5,
+	},
+}
+
+// Compiled é um matcher pronto para uso.
+type Compiled struct {
+	Matcher
+	re *regexp.Regexp
+}
+
+// Compile valida a regex e os grupos do matcher.
+func Compile(m Matcher) (*Compiled, error) {
+	m.Name = strings.TrimSpace(m.Name)
+	if m.Name == "" {
+		return nil, fmt.Errorf("problem matcher name cannot be empty")
+	}
+	re, err := regexp.Compile(m.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid problem matcher pattern: %w", err)
+	}
+	groups := re.NumSubexp()
+	for _, g := range []struct {
+		name     string
+		value    int
+		required bool
+	}{
+		{"file", m.FileGroup, true},
+		{"line", m.LineGroup, true},
+		{"message", m.MessageGroup, true},
+		{"column", m.ColumnGroup, false},
+		{"severity", m.SeverityGroup, false},
+	} {
+		if g.required && (g.value < 1 || g.value > groups) {
+			return nil, fmt.Errorf("%s group must be between 1 and %d", g.name, groups)
+		}
+		if g.value < 0 || g.value > groups {
+			return nil, fmt.Errorf("%s group must be between 0 and %d", g.name, groups)
+		}
+	}
+	if m.Severity == "" {
+		m.Severity = SeverityError
+	}
+	if normalizeSeverity(m.Severity) == "" {
+		return nil, fmt.Errorf("unknown severity %q", m.Severity)
+	}
+	return &Compiled{Matcher: m, re: re}, nil
+}
+
+// Match aplica o matcher a uma linha já sem ANSI.
+func (c *Compiled) Match(line string) (Problem, bool) {
+	m := c.re.FindStringSubmatch(line)
+	if m == nil {
+		return Problem{}, false
+	}
+	lineNumber, err := strconv.Atoi(m[c.LineGroup])
+	if err != nil || lineNumber <= 0 {
+		return Problem{}, false
+	}
+	problem := Problem{
+		Source:   c.Name,
+		File:     strings.TrimSpace(m[c.FileGroup]),
+		Line:     lineNumber,
+		Message:  strings.TrimSpace(m[c.MessageGroup]),
+		Severity: normalizeSeverity(c.Severity),
+	}
+	if c.ColumnGroup > 0 {
+		problem.Column, _ = strconv.Atoi(m[c.ColumnGroup])
+	}
+	if c.SeverityGroup > 0 {
+		if severity := normalizeSeverity(m[c.SeverityGroup]); severity != "" {
+			problem.Severity = severity
+		}
+	}
+	if problem.File == "" || problem.Message == "" {
+		return Problem{}, false
+	}
+	return problem, true
+}
+
+func normalizeSeverity(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "error", "fatal", "err", "e":
+		return SeverityError
+	case "warning", "warn", "w":
+		return SeverityWarning
+	case "info", "information", "note", "hint", "i":
+		return SeverityInfo
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,99 @@
+package problems
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuiltinMatchers(t *testing.T) {
+	collector := NewCollector(nil, nil)
+	collector.ObserveOutput("s1", []byte(strings.Join([]string{
+		"# orch/internal/app",
+		"\x1b[31m./app.go:12:5: undefined: foo\x1b[0m",
+		"src/main.ts(3,7): error TS2322: Type 'string' is not assignable to type 'number'.",
+		"lib/util.c:10:3: warning: unused variable 'x' [-Wunused-variable]",
+		"/repo/web/a.js: line 4, col 1, Error - 'y' is not defined. (no-undef)",
+		"Done in 1.2s",
+		"",
+	}, "\r\n")))
+
+	got := collector.Problems(0)
+	if len(got) != 4 {
+		t.Fatalf("expected 4 problems, got %#v", got)
+	}
+	// Erros primeiro, por arquivo.
+	want := []struct {
+		source, file, severity string
+		line, column           int
+	}{
+		{"$go", "./app.go", SeverityError, 12, 5},
+		{"$eslint-compact", "/repo/web/a.js", SeverityError, 4, 1},
+		{"$tsc", "src/main.ts", SeverityError, 3, 7},
+		{"$gcc", "lib/util.c", SeverityWarning, 10, 3},
+	}
+	for i, w := range want {
+		p := got[i]
+		if p.Source != w.source || p.File != w.file || p.Severity != w.severity || p.Line != w.line || p.Column != w.column {
+			t.Fatalf("problem %d = %#v, want %+v", i, p, w)
+		}
+	}
+	if got[2].Message != "TS2322: Type 'string' is not assignable to type 'number'." {
+		t.Fatalf("unexpected tsc message: %q", got[2].Message)
+	}
+}
+
+func TestCollectorClearsSessionProblemsOnNewCommand(t *testing.T) {
+	var updates [][]Problem
+	collector := NewCollector(func(sessionID string) uint { return 7 }, func(workspaceID uint, problems []Problem) {
+		if workspaceID != 7 {
+			t.Fatalf("unexpected workspace %d", workspaceID)
+		}
+		updates = append(updates, problems)
+	})
+
+	collector.ObserveOutput("s1", []byte("main.go:3:1: syntax error"))
+	if len(collector.Problems(7)) != 0 {
+		t.Fatalf("incomplete lines must not be matched yet")
+	}
+	collector.ObserveOutput("s1", []byte("\nmain.go:3:1: syntax error\n"))
+	collector.ObserveOutput("s2", []byte("other.go:9: vet: unreachable code\n"))
+	if problems := collector.Problems(7); len(problems) != 2 {
+		t.Fatalf("expected duplicate line to be merged, got %#v", problems)
+	}
+
+	// Enter sem comando não limpa; um novo comando limpa só os problemas da sessão.
+	collector.ObserveInput("s1", []byte("\r"))
+	if len(collector.Problems(7)) != 2 {
+		t.Fatalf("empty Enter should keep problems")
+	}
+	collector.ObserveInput("s1", []byte("go build ./...\r"))
+	problems := collector.Problems(7)
+	if len(problems) != 1 || problems[0].SessionID != "s2" {
+		t.Fatalf("expected only s2 problems to remain, got %#v", problems)
+	}
+
+	collector.Clear(7)
+	if len(collector.Problems(7)) != 0 || len(updates) != 4 || len(updates[3]) != 0 {
+		t.Fatalf("unexpected updates after clear: %d %#v", len(updates), collector.Problems(7))
+	}
+}
+
+func TestCustomMatchers(t *testing.T) {
+	collector := NewCollector(nil, nil)
+	errs := collector.SetMatchers([]Matcher{
+		{Name: "lint", Pattern: `^LINT (\w+) (\S+)@(\d+) (.+)$`, SeverityGroup: 1, FileGroup: 2, LineGroup: 3, MessageGroup: 4, Severity: "warning"},
+		{Name: "broken", Pattern: `([`, FileGroup: 1, LineGroup: 1, MessageGroup: 1},
+		{Name: "missing", Pattern: `^(\S+):(\d+)$`, FileGroup: 1, LineGroup: 2},
+	})
+	if len(errs) != 2 {
+		t.Fatalf("expected two invalid matchers, got %v", errs)
+	}
+	collector.ObserveOutput("s1", []byte("LINT warn pkg/a.py@12 line too long\nLINT x pkg/b.py@3 unknown severity\n"))
+	problems := collector.Problems(0)
+	if len(problems) != 2 || problems[0].Severity != SeverityWarning || problems[0].Source != "lint" || problems[1].File != "pkg/b.py" {
+		t.Fatalf("unexpected problems: %#v", problems)
+	}
+	if _, err := Compile(Matcher{Name: "sev", Pattern: `(a)(b)(c)`, FileGroup: 1, LineGroup: 2, MessageGroup: 3, Severity: "critical"}); err == nil {
+		t.Fatalf("expected unknown severity to be rejected")
+	}
+}
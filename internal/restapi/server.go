@@ -0,0 +1,297 @@
+package restapi
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Prefix é o prefixo de todas as rotas da API (versionada).
+	Prefix = "/api/v1"
+
+	maxRequestBytes = 1 << 20
+)
+
+// Route é um binding do app exposto via REST.
+type Route struct {
+	Method      string // GET, POST, DELETE...
+	Path        string // relativo a Prefix, com parâmetros {nome} (padrões do net/http)
+	Description string
+	Write       bool // altera estado (terminais, workspaces, sessões); exige AllowWrite
+	Handler     func(req Request) (interface{}, error)
+}
+
+// RouteInfo descreve uma rota no índice GET /api/v1.
+type RouteInfo struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	Write       bool   `json:"write"`
+}
+
+// Request dá acesso aos parâmetros da chamada.
+type Request struct {
+	*http.Request
+}
+
+// PathUint lê um parâmetro numérico do caminho (ex.: {id}).
+func (r Request) PathUint(name string) (uint, error) {
+	value, err := strconv.ParseUint(r.PathValue(name), 10, 32)
+	if err != nil || value == 0 {
+		return 0, Errorf(http.StatusBadRequest, "invalid %s: %q", name, r.PathValue(name))
+	}
+	return uint(value), nil
+}
+
+// Bind decodifica o corpo JSON (corpo vazio = objeto vazio).
+func (r Request) Bind(target interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil && !errors.Is(err, io.EOF) {
+		return Errorf(http.StatusBadRequest, "invalid json payload: %v", err)
+	}
+	return nil
+}
+
+// StatusError é um erro com status HTTP explícito; os demais erros viram 400.
+type StatusError struct {
+	Status  int
+	Message string
+}
+
+func (e *StatusError) Error() string { return e.Message }
+
+// Errorf cria um StatusError.
+func Errorf(status int, format string, args ...interface{}) error {
+	return &StatusError{Status: status, Message: fmt.Sprintf(format, args...)}
+}
+
+// CallRecord descreve uma requisição atendida, para auditoria.
+type CallRecord struct {
+	Method     string
+	Route      string
+	Path       string
+	Write      bool
+	Status     int
+	Error      string
+	DurationMs int64
+}
+
+// Server serve as rotas registradas em 127.0.0.1 com token Bearer.
+type Server struct {
+	mu      sync.RWMutex
+	routes  []Route
+	observe func(CallRecord)
+
+	httpMu     sync.Mutex
+	httpSrv    *http.Server
+	listener   net.Listener
+	token      string
+	allowWrite bool
+}
+
+// NewServer cria o servidor sem rotas.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Register adiciona uma rota; precisa ser chamado antes do Start.
+func (s *Server) Register(route Route) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes = append(s.routes, route)
+}
+
+// Routes lista as rotas na ordem de registro.
+func (s *Server) Routes() []RouteInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	infos := make([]RouteInfo, 0, len(s.routes))
+	for _, route := range s.routes {
+		infos = append(infos, RouteInfo{Method: route.Method, Path: Prefix + route.Path, Description: route.Description, Write: route.Write})
+	}
+	return infos
+}
+
+// SetCallObserver recebe cada requisição atendida.
+func (s *Server) SetCallObserver(fn func(CallRecord)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observe = fn
+}
+
+// Start abre o listener em addr; sem allowWrite as rotas de escrita respondem 403.
+func (s *Server) Start(addr string, token string, allowWrite bool) error {
+	if strings.TrimSpace(token) == "" {
+		return fmt.Errorf("rest api token cannot be empty")
+	}
+	s.httpMu.Lock()
+	defer s.httpMu.Unlock()
+	if s.httpSrv != nil {
+		return fmt.Errorf("rest api already running on %s", s.listener.Addr())
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.token = token
+	s.allowWrite = allowWrite
+	s.listener = listener
+	s.httpSrv = &http.Server{Handler: s.handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func(srv *http.Server) {
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("[REST] server stopped: %v", err)
+		}
+	}(s.httpSrv)
+	log.Printf("[REST] listening on %s", listener.Addr())
+	return nil
+}
+
+// Stop fecha o listener.
+func (s *Server) Stop() {
+	s.httpMu.Lock()
+	srv := s.httpSrv
+	s.httpSrv, s.listener, s.token = nil, nil, ""
+	s.httpMu.Unlock()
+	if srv == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = srv.Shutdown(ctx)
+}
+
+// Addr retorna o endereço em uso ("" quando parado).
+func (s *Server) Addr() string {
+	s.httpMu.Lock()
+	defer s.httpMu.Unlock()
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+Prefix, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"routes": s.Routes()})
+	})
+	s.mu.RLock()
+	for _, route := range s.routes {
+		mux.HandleFunc(route.Method+" "+Prefix+route.Path, s.serveRoute(route))
+	}
+	s.mu.RUnlock()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Páginas abertas no navegador não podem falar com a API local (DNS rebinding).
+		if origin := r.Header.Get("Origin"); origin != "" && !isLoopbackOrigin(origin) {
+			writeError(w, http.StatusForbidden, "origin not allowed")
+			return
+		}
+		if !s.authorized(r) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) serveRoute(route Route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+		record := CallRecord{Method: route.Method, Route: Prefix + route.Path, Path: r.URL.Path, Write: route.Write}
+		status, payload := s.dispatch(route, r)
+		record.Status = status
+		if failure, ok := payload.(errorBody); ok {
+			record.Error = failure.Error
+		}
+		record.DurationMs = time.Since(started).Milliseconds()
+		writeJSON(w, status, payload)
+
+		s.mu.RLock()
+		observe := s.observe
+		s.mu.RUnlock()
+		if observe != nil {
+			observe(record)
+		}
+	}
+}
+
+func (s *Server) dispatch(route Route, r *http.Request) (int, interface{}) {
+	s.httpMu.Lock()
+	allowWrite := s.allowWrite
+	s.httpMu.Unlock()
+	if route.Write && !allowWrite {
+		return http.StatusForbidden, errorBody{Error: "write access is disabled in ORCH settings"}
+	}
+	r.Body = http.MaxBytesReader(nil, r.Body, maxRequestBytes)
+	result, err := route.Handler(Request{Request: r})
+	if err != nil {
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) {
+			return statusErr.Status, errorBody{Error: statusErr.Message}
+		}
+		return http.StatusBadRequest, errorBody{Error: err.Error()}
+	}
+	if result == nil {
+		result = map[string]bool{"ok": true}
+	}
+	return http.StatusOK, result
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	s.httpMu.Lock()
+	token := s.token
+	s.httpMu.Unlock()
+	provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
+
+func isLoopbackOrigin(origin string) bool {
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	host := parsed.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorBody{Error: message})
+}
+
+// NewToken gera um token aleatório (hex de 32 bytes).
+func NewToken() string {
+	buf := make([]byte, 32)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
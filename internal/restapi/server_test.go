@@ -0,0 +1,100 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newTestServer(t *testing.T, allowWrite bool) (*Server, string) {
+	t.Helper()
+	server := NewServer()
+	server.Register(Route{Method: "GET", Path: "/items/{id}", Handler: func(req Request) (interface{}, error) {
+		id, err := req.PathUint("id")
+		if err != nil {
+			return nil, err
+		}
+		if id == 404 {
+			return nil, Errorf(http.StatusNotFound, "item %d not found", id)
+		}
+		return map[string]uint{"id": id}, nil
+	}})
+	server.Register(Route{Method: "POST", Path: "/items", Write: true, Handler: func(req Request) (interface{}, error) {
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := req.Bind(&body); err != nil {
+			return nil, err
+		}
+		return map[string]string{"created": body.Name}, nil
+	}})
+	if err := server.Start("127.0.0.1:0", "secret", allowWrite); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	t.Cleanup(server.Stop)
+	return server, "http://" + server.Addr() + Prefix
+}
+
+func call(t *testing.T, method, endpoint, token, body string, headers ...string) (int, map[string]interface{}) {
+	t.Helper()
+	req, _ := http.NewRequest(method, endpoint, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var decoded map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp.StatusCode, decoded
+}
+
+func TestServerAuthAndRoutes(t *testing.T) {
+	server, base := newTestServer(t, false)
+	var records []CallRecord
+	server.SetCallObserver(func(record CallRecord) { records = append(records, record) })
+
+	if status, _ := call(t, "GET", base+"/items/1", "wrong", ""); status != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for bad token, got %d", status)
+	}
+	if status, _ := call(t, "GET", base+"/items/1", "secret", "", "Origin", "https://evil.example"); status != http.StatusForbidden {
+		t.Fatalf("expected 403 for foreign origin, got %d", status)
+	}
+	if status, body := call(t, "GET", base+"/items/7", "secret", "", "Origin", "http://localhost:3000"); status != http.StatusOK || body["id"] != float64(7) {
+		t.Fatalf("unexpected item: %d %v", status, body)
+	}
+	if status, body := call(t, "GET", base+"/items/404", "secret", ""); status != http.StatusNotFound || body["error"] != "item 404 not found" {
+		t.Fatalf("expected StatusError to map to 404, got %d %v", status, body)
+	}
+	if status, _ := call(t, "GET", base+"/items/abc", "secret", ""); status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid id, got %d", status)
+	}
+	if status, body := call(t, "POST", base+"/items", "secret", `{"name":"x"}`); status != http.StatusForbidden || !strings.Contains(body["error"].(string), "write access is disabled") {
+		t.Fatalf("expected write route to be blocked, got %d %v", status, body)
+	}
+
+	status, body := call(t, "GET", base, "secret", "")
+	if routes, _ := body["routes"].([]interface{}); status != http.StatusOK || len(routes) != 2 {
+		t.Fatalf("unexpected index: %d %v", status, body)
+	}
+	if len(records) != 4 || !records[3].Write || records[3].Status != http.StatusForbidden || records[0].Route != Prefix+"/items/{id}" {
+		t.Fatalf("unexpected call records: %+v", records)
+	}
+}
+
+func TestServerWriteRoutesWhenAllowed(t *testing.T) {
+	_, base := newTestServer(t, true)
+	if status, body := call(t, "POST", base+"/items", "secret", `{"name":"report"}`); status != http.StatusOK || body["created"] != "report" {
+		t.Fatalf("unexpected create: %d %v", status, body)
+	}
+	if status, _ := call(t, "POST", base+"/items", "secret", `{"unknown":1}`); status != http.StatusBadRequest {
+		t.Fatalf("expected unknown fields to be rejected, got %d", status)
+	}
+	if status, _ := call(t, "DELETE", base+"/items", "secret", ""); status != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405 for unregistered method, got %d", status)
+	}
+}
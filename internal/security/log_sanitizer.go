@@ -2,6 +2,16 @@ package security
 
 import "regexp"
 
+// Padrões de credenciais compartilhados entre LogSanitizer e SecretScanner.
+var (
+	credentialAssignmentPattern = regexp.MustCompile(`(?i)(api[_-]?key|token|secret|password|authorization)\s*[:=]\s*['"]?[\w\-\.]+['"]?`)
+	bearerTokenPattern          = regexp.MustCompile(`(?i)bearer\s+[\w\-\.=]+`)
+	githubTokenPattern          = regexp.MustCompile(`gh[pousr]_[a-zA-Z0-9]{20,}`)
+	openAIKeyPattern            = regexp.MustCompile(`sk-[a-zA-Z0-9]{20,}`)
+	googleAPIKeyPattern         = regexp.MustCompile(`AIza[a-zA-Z0-9_\-]{35}`)
+	cookieHeaderPattern         = regexp.MustCompile(`(?i)(cookie|set-cookie):\s*[^\s;]+`)
+)
+
 // LogSanitizer remove credenciais e segredos antes de persistir logs/auditoria.
 type LogSanitizer struct {
 	patterns []*regexp.Regexp
@@ -10,12 +20,12 @@ type LogSanitizer struct {
 func NewLogSanitizer() *LogSanitizer {
 	return &LogSanitizer{
 		patterns: []*regexp.Regexp{
-			regexp.MustCompile(`(?i)(api[_-]?key|token|secret|password|authorization)\s*[:=]\s*['"]?[\w\-\.]+['"]?`),
-			regexp.MustCompile(`(?i)bearer\s+[\w\-\.=]+`),
-			regexp.MustCompile(`gh[pousr]_[a-zA-Z0-9]{20,}`),
-			regexp.MustCompile(`sk-[a-zA-Z0-9]{20,}`),
-			regexp.MustCompile(`AIza[a-zA-Z0-9_\-]{35}`),
-			regexp.MustCompile(`(?i)(cookie|set-cookie):\s*[^\s;]+`),
+			credentialAssignmentPattern,
+			bearerTokenPattern,
+			githubTokenPattern,
+			openAIKeyPattern,
+			googleAPIKeyPattern,
+			cookieHeaderPattern,
 		},
 	}
 }
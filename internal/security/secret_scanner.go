@@ -0,0 +1,137 @@
+package security
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+// SecretRule descreve um padrão de segredo detectável em código.
+type SecretRule struct {
+	ID          string
+	Description string
+	pattern     *regexp.Regexp
+	group       int     // grupo de captura com o segredo (0 = match inteiro)
+	minEntropy  float64 // > 0 exige entropia mínima do valor capturado
+}
+
+// SecretMatch representa um segredo encontrado em uma linha.
+type SecretMatch struct {
+	RuleID      string
+	Description string
+	Secret      string
+	Start       int
+	End         int
+}
+
+// SecretScanner aplica as regras de segredo (mesmos padrões do LogSanitizer + regras de código).
+type SecretScanner struct {
+	rules []SecretRule
+}
+
+// secretAllowlistMarkers permitem marcar uma linha como falso positivo conhecido.
+var secretAllowlistMarkers = []string{"pragma: allowlist secret", "gitleaks:allow"}
+
+func NewSecretScanner() *SecretScanner {
+	return &SecretScanner{
+		rules: []SecretRule{
+			{ID: "private-key", Description: "Chave privada", pattern: regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )*PRIVATE KEY(?: BLOCK)?-----`)},
+			{ID: "aws-access-key", Description: "AWS Access Key ID", pattern: regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA)[0-9A-Z]{16}\b`)},
+			{ID: "aws-secret-key", Description: "AWS Secret Access Key", pattern: regexp.MustCompile(`(?i)aws.{0,20}(?:secret|key).{0,20}?[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`), group: 1},
+			{ID: "github-token", Description: "Token do GitHub", pattern: githubTokenPattern},
+			{ID: "openai-key", Description: "Chave de API (sk-)", pattern: openAIKeyPattern},
+			{ID: "google-api-key", Description: "Chave de API do Google", pattern: googleAPIKeyPattern},
+			{ID: "slack-token", Description: "Token do Slack", pattern: regexp.MustCompile(`xox[abposr]-[0-9A-Za-z-]{10,}`)},
+			{ID: "stripe-key", Description: "Chave secreta do Stripe", pattern: regexp.MustCompile(`(?:sk|rk)_live_[0-9A-Za-z]{20,}`)},
+			{ID: "credential-assignment", Description: "Credencial atribuída em código", pattern: regexp.MustCompile(`(?i)(?:api[_-]?key|secret|password|passwd|token)["']?\s*[:=]\s*["']([^"'\s]{8,})["']`), group: 1},
+			{ID: "high-entropy-string", Description: "String com alta entropia", pattern: regexp.MustCompile(`["'\x60]([A-Za-z0-9+/=_\-]{32,})["'\x60]`), group: 1, minEntropy: 4.5},
+		},
+	}
+}
+
+// ScanLine retorna os segredos encontrados na linha; cada trecho é reportado por uma única regra.
+func (s *SecretScanner) ScanLine(line string) []SecretMatch {
+	if s == nil || strings.TrimSpace(line) == "" {
+		return nil
+	}
+	lower := strings.ToLower(line)
+	for _, marker := range secretAllowlistMarkers {
+		if strings.Contains(lower, marker) {
+			return nil
+		}
+	}
+
+	matches := make([]SecretMatch, 0)
+	overlaps := func(start, end int) bool {
+		for _, existing := range matches {
+			if start < existing.End && existing.Start < end {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, rule := range s.rules {
+		for _, loc := range rule.pattern.FindAllStringSubmatchIndex(line, -1) {
+			start, end := loc[2*rule.group], loc[2*rule.group+1]
+			if start < 0 || overlaps(start, end) {
+				continue
+			}
+			secret := line[start:end]
+			if rule.minEntropy > 0 && (shannonEntropy(secret) < rule.minEntropy || !hasLettersAndDigits(secret)) {
+				continue
+			}
+			if isPlaceholderSecret(secret) {
+				continue
+			}
+			matches = append(matches, SecretMatch{
+				RuleID:      rule.ID,
+				Description: rule.Description,
+				Secret:      secret,
+				Start:       start,
+				End:         end,
+			})
+		}
+	}
+	return matches
+}
+
+func shannonEntropy(value string) float64 {
+	if value == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	for _, r := range value {
+		counts[r]++
+	}
+	total := float64(len([]rune(value)))
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / total
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+func hasLettersAndDigits(value string) bool {
+	hasLetter, hasDigit := false, false
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			hasLetter = true
+		}
+	}
+	return hasLetter && hasDigit
+}
+
+func isPlaceholderSecret(value string) bool {
+	lower := strings.ToLower(value)
+	for _, marker := range []string{"example", "changeme", "placeholder", "your_", "your-", "xxxx", "<", "${", "{{", "process.env", "os.getenv"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
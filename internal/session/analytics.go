@@ -0,0 +1,201 @@
+package session
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxAnalyticsTimeline limita a linha do tempo devolvida em SessionGetAnalytics.
+const maxAnalyticsTimeline = 500
+
+// Sinais de higiene de acesso apontados pelas análises de sessão.
+const (
+	HygieneAnonymousAllowed      = "anonymous_allowed"        // sessão aceitava guests sem login
+	HygieneFailedJoinAttempts    = "failed_join_attempts"     // tentativas de entrada falharam ou foram bloqueadas
+	HygieneWriteAccessNotRevoked = "write_access_not_revoked" // guest terminou a sessão ainda com escrita
+	HygieneCommandApprovalOff    = "command_approval_disabled"
+)
+
+// AuditEvent é um evento de auditoria da sessão (database.AuditLog sem o ID).
+type AuditEvent struct {
+	UserID  string
+	Action  string
+	Details string
+	At      time.Time
+}
+
+// TimelineEntry é um evento da linha do tempo da sessão.
+type TimelineEntry struct {
+	At      time.Time `json:"at"`
+	Action  string    `json:"action"`
+	UserID  string    `json:"userId,omitempty"`
+	Details string    `json:"details,omitempty"`
+}
+
+// GuestAnalytics resume a participação de um guest.
+type GuestAnalytics struct {
+	UserID            string    `json:"userId"`
+	Name              string    `json:"name,omitempty"`
+	FirstSeenAt       time.Time `json:"firstSeenAt"`
+	ConnectedSeconds  int64     `json:"connectedSeconds"`
+	Connections       int       `json:"connections"`
+	CommandsExecuted  int       `json:"commandsExecuted"`
+	CommandsHeld      int       `json:"commandsHeld"` // retidos para aprovação do host
+	PermissionChanges int       `json:"permissionChanges"`
+	FinalPermission   string    `json:"finalPermission,omitempty"`
+	Kicked            bool      `json:"kicked"`
+}
+
+// Analytics resume uma sessão de colaboração a partir dos eventos de auditoria.
+type Analytics struct {
+	SessionID         string           `json:"sessionId"`
+	StartedAt         time.Time        `json:"startedAt"`
+	EndedAt           time.Time        `json:"endedAt"`
+	Ended             bool             `json:"ended"` // false: EndedAt é o agora (ativa) ou o último evento
+	DurationSeconds   int64            `json:"durationSeconds"`
+	Guests            []GuestAnalytics `json:"guests"`
+	CommandsExecuted  int              `json:"commandsExecuted"`
+	PermissionChanges int              `json:"permissionChanges"`
+	DockerRestarts    int              `json:"dockerRestarts"`
+	FailedJoins       int              `json:"failedJoins"`
+	NetworkRequests   int              `json:"networkRequests"` // fora da linha do tempo, só contados
+	Timeline          []TimelineEntry  `json:"timeline"`
+	TimelineTruncated bool             `json:"timelineTruncated"`
+	HygieneFlags      []string         `json:"hygieneFlags"`
+}
+
+type guestTracker struct {
+	stats       *GuestAnalytics
+	connectedAt time.Time // zero quando desconectado
+}
+
+// BuildAnalytics monta a análise da sessão. active indica que a sessão ainda está em
+// andamento: guests conectados contam até now. Eventos podem vir em qualquer ordem.
+func BuildAnalytics(sessionID string, events []AuditEvent, active bool, now time.Time) Analytics {
+	result := Analytics{SessionID: sessionID, Guests: []GuestAnalytics{}, Timeline: []TimelineEntry{}, HygieneFlags: []string{}}
+	if len(events) == 0 {
+		return result
+	}
+	sorted := append([]AuditEvent(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At.Before(sorted[j].At) })
+
+	result.StartedAt = sorted[0].At
+	result.EndedAt = sorted[len(sorted)-1].At
+	guests := map[string]*guestTracker{}
+	var order []string
+	guest := func(event AuditEvent) *guestTracker {
+		tracker, ok := guests[event.UserID]
+		if !ok {
+			tracker = &guestTracker{stats: &GuestAnalytics{UserID: event.UserID, FirstSeenAt: event.At}}
+			guests[event.UserID] = tracker
+			order = append(order, event.UserID)
+		}
+		return tracker
+	}
+	flags := map[string]bool{}
+	approvalOff := false
+
+	for _, event := range sorted {
+		switch event.Action {
+		case "session_created":
+			result.StartedAt = event.At
+			if strings.Contains(event.Details, "allowAnonymous=true") {
+				flags[HygieneAnonymousAllowed] = true
+			}
+		case "session_ended":
+			result.EndedAt, result.Ended = event.At, true
+		case "guest_requested_join":
+			tracker := guest(event)
+			if name, ok := strings.CutPrefix(event.Details, "name="); ok && strings.TrimSpace(name) != "" { // nome pode ter espaços
+				tracker.stats.Name = strings.TrimSpace(name)
+			}
+		case "guest_connected", "guest_entered":
+			if tracker := guest(event); tracker.connectedAt.IsZero() {
+				tracker.connectedAt = event.At
+				tracker.stats.Connections++
+			}
+		case "guest_disconnected", "guest_left", "guest_kicked":
+			tracker := guest(event)
+			tracker.closeAt(event.At)
+			if event.Action == "guest_kicked" {
+				tracker.stats.Kicked = true
+			}
+		case "command_executed":
+			result.CommandsExecuted++
+			guest(event).stats.CommandsExecuted++
+		case "command_held":
+			guest(event).stats.CommandsHeld++
+		case "permission_changed":
+			result.PermissionChanges++
+			tracker := guest(event)
+			tracker.stats.PermissionChanges++
+			if to := detailValue(event.Details, "to"); to != "" {
+				tracker.stats.FinalPermission = to
+			}
+		case "container_restarted":
+			result.DockerRestarts++
+		case "guest_join_failed", "guest_join_blocked":
+			result.FailedJoins++
+			flags[HygieneFailedJoinAttempts] = true
+		case "command_approval_changed":
+			approvalOff = strings.Contains(event.Details, "enabled=false")
+		case "network_request":
+			result.NetworkRequests++
+			continue
+		}
+		if len(result.Timeline) < maxAnalyticsTimeline {
+			result.Timeline = append(result.Timeline, TimelineEntry{At: event.At, Action: event.Action, UserID: event.UserID, Details: event.Details})
+		} else {
+			result.TimelineTruncated = true
+		}
+	}
+
+	if !result.Ended && active && now.After(result.EndedAt) {
+		result.EndedAt = now
+	}
+	if result.EndedAt.After(result.StartedAt) {
+		result.DurationSeconds = int64(result.EndedAt.Sub(result.StartedAt).Seconds())
+	}
+	if approvalOff {
+		flags[HygieneCommandApprovalOff] = true
+	}
+
+	for _, userID := range order {
+		tracker := guests[userID]
+		if userID == "" || userID == "host" || userID == "system" {
+			continue
+		}
+		tracker.closeAt(result.EndedAt)
+		if tracker.stats.FinalPermission == string(PermReadWrite) && !tracker.stats.Kicked {
+			flags[HygieneWriteAccessNotRevoked] = true
+		}
+		result.Guests = append(result.Guests, *tracker.stats)
+	}
+	for _, flag := range []string{HygieneAnonymousAllowed, HygieneFailedJoinAttempts, HygieneWriteAccessNotRevoked, HygieneCommandApprovalOff} {
+		if flags[flag] {
+			result.HygieneFlags = append(result.HygieneFlags, flag)
+		}
+	}
+	return result
+}
+
+func (g *guestTracker) closeAt(at time.Time) {
+	if g.connectedAt.IsZero() {
+		return
+	}
+	if at.After(g.connectedAt) {
+		g.stats.ConnectedSeconds += int64(at.Sub(g.connectedAt).Seconds())
+	}
+	g.connectedAt = time.Time{}
+}
+
+// detailValue lê "chave=valor" dos detalhes de auditoria (valores sem espaço).
+func detailValue(details string, key string) string {
+	for _, field := range strings.Fields(details) {
+		if value, ok := strings.CutPrefix(field, key+"="); ok {
+			return value
+		}
+	}
+	return ""
+}
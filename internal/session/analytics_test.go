@@ -0,0 +1,91 @@
+package session
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuildAnalyticsSummarizesParticipation(t *testing.T) {
+	start := time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	events := []AuditEvent{
+		{UserID: "host-1", Action: "session_created", Details: "mode=docker allowAnonymous=true workspaceID=1", At: at(0)},
+		{UserID: "guest-1", Action: "guest_requested_join", Details: "name=Ana Souza", At: at(1)},
+		{UserID: "guest-1", Action: "guest_entered", At: at(2)},
+		{UserID: "guest-1", Action: "guest_connected", At: at(2)}, // mesma conexão, não reabre
+		{UserID: "guest-1", Action: "permission_changed", Details: "from=read_only to=read_write", At: at(5)},
+		{UserID: "guest-1", Action: "command_executed", Details: "terminal=t1 command=ls", At: at(6)},
+		{UserID: "system", Action: "network_request", Details: "host=example.com", At: at(6)},
+		{UserID: "guest-1", Action: "command_held", Details: "rm -rf", At: at(7)},
+		{UserID: "guest-1", Action: "guest_disconnected", At: at(10)},
+		{UserID: "guest-1", Action: "guest_connected", At: at(20)},
+		{UserID: "guest-1", Action: "command_executed", Details: "terminal=t1 command=pwd", At: at(21)},
+		{UserID: "host", Action: "container_restarted", Details: "container=abc", At: at(25)},
+		{UserID: "guest-2", Action: "guest_join_failed", Details: "reason=invalid_code", At: at(26)},
+		{UserID: "guest-1", Action: "guest_disconnected", At: at(30)},
+		{UserID: "host", Action: "session_ended", At: at(40)},
+	}
+	// Ordem do banco é decrescente; BuildAnalytics precisa reordenar.
+	reversed := make([]AuditEvent, 0, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		reversed = append(reversed, events[i])
+	}
+
+	analytics := BuildAnalytics("sess-1", reversed, false, at(90))
+	if !analytics.Ended || analytics.DurationSeconds != 40*60 {
+		t.Fatalf("unexpected duration: ended=%v seconds=%d", analytics.Ended, analytics.DurationSeconds)
+	}
+	if analytics.CommandsExecuted != 2 || analytics.PermissionChanges != 1 || analytics.DockerRestarts != 1 || analytics.FailedJoins != 1 || analytics.NetworkRequests != 1 {
+		t.Fatalf("unexpected totals: %+v", analytics)
+	}
+	if len(analytics.Timeline) != len(events)-1 || analytics.Timeline[0].Action != "session_created" {
+		t.Fatalf("timeline should be ascending without network requests, got %+v", analytics.Timeline)
+	}
+
+	guests := map[string]GuestAnalytics{}
+	for _, guest := range analytics.Guests {
+		guests[guest.UserID] = guest
+	}
+	if _, ok := guests["host"]; ok {
+		t.Fatalf("host should not be listed as guest")
+	}
+	ana := guests["guest-1"]
+	if ana.Name != "Ana Souza" || ana.Connections != 2 || ana.ConnectedSeconds != 18*60 {
+		t.Fatalf("unexpected guest stats: %+v", ana)
+	}
+	if ana.CommandsExecuted != 2 || ana.CommandsHeld != 1 || ana.PermissionChanges != 1 || ana.FinalPermission != string(PermReadWrite) {
+		t.Fatalf("unexpected guest activity: %+v", ana)
+	}
+
+	want := []string{HygieneAnonymousAllowed, HygieneFailedJoinAttempts, HygieneWriteAccessNotRevoked}
+	if !reflect.DeepEqual(analytics.HygieneFlags, want) {
+		t.Fatalf("HygieneFlags = %v, want %v", analytics.HygieneFlags, want)
+	}
+}
+
+func TestBuildAnalyticsActiveSessionCountsUntilNow(t *testing.T) {
+	start := time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC)
+	events := []AuditEvent{
+		{UserID: "host-1", Action: "session_created", Details: "allowAnonymous=false", At: start},
+		{UserID: "guest-1", Action: "guest_entered", At: start.Add(5 * time.Minute)},
+		{UserID: "guest-1", Action: "permission_changed", Details: "from=read_only to=read_write", At: start.Add(6 * time.Minute)},
+		{UserID: "guest-1", Action: "guest_kicked", At: start.Add(8 * time.Minute)},
+	}
+
+	analytics := BuildAnalytics("sess-1", events, true, start.Add(time.Hour))
+	if analytics.Ended || analytics.DurationSeconds != 3600 {
+		t.Fatalf("active session should count until now, got ended=%v seconds=%d", analytics.Ended, analytics.DurationSeconds)
+	}
+	if len(analytics.Guests) != 1 || !analytics.Guests[0].Kicked || analytics.Guests[0].ConnectedSeconds != 180 {
+		t.Fatalf("unexpected guests: %+v", analytics.Guests)
+	}
+	if len(analytics.HygieneFlags) != 0 {
+		t.Fatalf("kicked guest should not be flagged, got %v", analytics.HygieneFlags)
+	}
+
+	empty := BuildAnalytics("sess-2", nil, false, start)
+	if empty.SessionID != "sess-2" || empty.Guests == nil || empty.Timeline == nil || empty.DurationSeconds != 0 {
+		t.Fatalf("unexpected empty analytics: %+v", empty)
+	}
+}
@@ -19,6 +19,8 @@ type GatewayServer struct {
 
 	onSessionChanged func(sessionID string)
 	onSessionDeleted func(sessionID string)
+
+	extraRoutes map[string]http.Handler // rotas de outros módulos (ex.: leases), registradas antes do Start
 }
 
 func NewGatewayServer(service *Service, addr string) *GatewayServer {
@@ -28,6 +30,17 @@ func NewGatewayServer(service *Service, addr string) *GatewayServer {
 	}
 }
 
+// Handle registra uma rota adicional servida pelo gateway; precisa ser chamado antes do Start.
+func (g *GatewayServer) Handle(pattern string, handler http.Handler) {
+	if g == nil || handler == nil {
+		return
+	}
+	if g.extraRoutes == nil {
+		g.extraRoutes = make(map[string]http.Handler)
+	}
+	g.extraRoutes[pattern] = handler
+}
+
 func (g *GatewayServer) Start() error {
 	if g == nil || g.service == nil {
 		return fmt.Errorf("session gateway service is nil")
@@ -54,8 +67,14 @@ func (g *GatewayServer) Start() error {
 	mux.HandleFunc("/api/session/code/regenerate", g.handleRegenerateCode)
 	mux.HandleFunc("/api/session/code/revoke", g.handleRevokeCode)
 	mux.HandleFunc("/api/session/allow-joins", g.handleSetAllowNewJoins)
+	mux.HandleFunc("/api/session/policy", g.handleSetTimeoutPolicy)
+	mux.HandleFunc("/api/session/activity", g.handleTouchGuestActivity)
+	mux.HandleFunc("/api/session/command-approval", g.handleSetCommandApproval)
 	mux.HandleFunc("/api/session/metrics/join-security", g.handleGetJoinSecurityMetrics)
 	mux.HandleFunc("/api/session/ice", g.handleGetICEServers)
+	for pattern, handler := range g.extraRoutes {
+		mux.Handle(pattern, handler)
+	}
 
 	listener, err := net.Listen("tcp", g.addr)
 	if err != nil {
@@ -406,6 +425,73 @@ func (g *GatewayServer) handleSetAllowNewJoins(w http.ResponseWriter, r *http.Re
 	writeGatewayJSON(w, http.StatusOK, session)
 }
 
+type setTimeoutPolicyRequest struct {
+	SessionID string        `json:"sessionID"`
+	Policy    TimeoutPolicy `json:"policy"`
+}
+
+func (g *GatewayServer) handleSetTimeoutPolicy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeGatewayError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req setTimeoutPolicyRequest
+	if err := decodeGatewayJSON(r, &req); err != nil {
+		writeGatewayError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	session, err := g.service.SetTimeoutPolicy(req.SessionID, req.Policy)
+	if err != nil {
+		writeGatewayError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if g.onSessionChanged != nil {
+		g.onSessionChanged(req.SessionID)
+	}
+	writeGatewayJSON(w, http.StatusOK, session)
+}
+
+type setCommandApprovalRequest struct {
+	SessionID string `json:"sessionID"`
+	Enabled   bool   `json:"enabled"`
+}
+
+func (g *GatewayServer) handleSetCommandApproval(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeGatewayError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req setCommandApprovalRequest
+	if err := decodeGatewayJSON(r, &req); err != nil {
+		writeGatewayError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	session, err := g.service.SetCommandApproval(req.SessionID, req.Enabled)
+	if err != nil {
+		writeGatewayError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if g.onSessionChanged != nil {
+		g.onSessionChanged(req.SessionID)
+	}
+	writeGatewayJSON(w, http.StatusOK, session)
+}
+
+// handleTouchGuestActivity não persiste a sessão: a atividade muda a cada input do guest.
+func (g *GatewayServer) handleTouchGuestActivity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeGatewayError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req sessionGuestActionRequest
+	if err := decodeGatewayJSON(r, &req); err != nil {
+		writeGatewayError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	g.service.TouchGuestActivity(req.SessionID, req.GuestUserID)
+	writeGatewayJSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
 func (g *GatewayServer) handleGetICEServers(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		writeGatewayError(w, http.StatusMethodNotAllowed, "method not allowed")
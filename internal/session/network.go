@@ -0,0 +1,43 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+
+	"orch/internal/egress"
+)
+
+// Modos de rede do container no modo docker.
+const (
+	NetworkNone      = "none"      // sem rede
+	NetworkAllowList = "allowlist" // só os hosts liberados, via proxy embutido
+	NetworkFull      = "full"      // rede bridge padrão do Docker
+)
+
+// NetworkPolicy define a saída de rede do container da sessão; é aplicada na criação.
+type NetworkPolicy struct {
+	Mode         string   `json:"mode"`
+	AllowedHosts []string `json:"allowedHosts,omitempty"` // usado só em "allowlist"
+}
+
+// NormalizeNetworkPolicy valida o modo e a allow-list. Vazio vira "none".
+func NormalizeNetworkPolicy(policy NetworkPolicy) (NetworkPolicy, error) {
+	mode := strings.ToLower(strings.TrimSpace(policy.Mode))
+	switch mode {
+	case "":
+		return NetworkPolicy{Mode: NetworkNone}, nil
+	case NetworkNone, NetworkFull:
+		return NetworkPolicy{Mode: mode}, nil
+	case NetworkAllowList:
+		hosts, err := egress.NormalizeHosts(policy.AllowedHosts)
+		if err != nil {
+			return NetworkPolicy{}, err
+		}
+		if len(hosts) == 0 {
+			return NetworkPolicy{}, fmt.Errorf("allowlist network policy needs at least one host")
+		}
+		return NetworkPolicy{Mode: mode, AllowedHosts: hosts}, nil
+	default:
+		return NetworkPolicy{}, fmt.Errorf("unknown network policy %q (use none, allowlist or full)", policy.Mode)
+	}
+}
@@ -0,0 +1,204 @@
+package session
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Limites das políticas de tempo de uma sessão.
+const (
+	maxGuestIdleMinutes      = 24 * 60
+	maxSessionDurationMins   = 7 * 24 * 60
+	maxPolicyWarnMinutes     = 60
+	defaultPolicyWarnMinutes = 2
+)
+
+// TimeoutPolicy define a inatividade máxima de guests com escrita e a duração máxima da sessão.
+// Zero desliga a regra correspondente.
+type TimeoutPolicy struct {
+	GuestIdleMinutes   int `json:"guestIdleMinutes"`   // revoga escrita após N minutos sem input
+	MaxDurationMinutes int `json:"maxDurationMinutes"` // encerra a sessão após N minutos de vida
+	WarnBeforeMinutes  int `json:"warnBeforeMinutes"`  // antecedência do aviso (default: 2)
+}
+
+// Enabled diz se alguma regra está ligada.
+func (p TimeoutPolicy) Enabled() bool {
+	return p.GuestIdleMinutes > 0 || p.MaxDurationMinutes > 0
+}
+
+// Tipos de ação produzidos por EvaluateTimeoutPolicies.
+const (
+	PolicyGuestIdleWarning  = "guest_idle_warning"
+	PolicyGuestWriteRevoked = "guest_write_revoked"
+	PolicySessionEndWarning = "session_end_warning"
+	PolicySessionExpired    = "session_expired"
+)
+
+// PolicyAction é um aviso ou uma aplicação de política que o host deve executar.
+type PolicyAction struct {
+	Type        string    `json:"type"`
+	SessionID   string    `json:"sessionID"`
+	HostUserID  string    `json:"hostUserID"`
+	GuestUserID string    `json:"guestUserID,omitempty"`
+	DeadlineAt  time.Time `json:"deadlineAt"`
+}
+
+// NormalizeTimeoutPolicy valida os limites e aplica o aviso padrão quando alguma regra está ligada.
+func NormalizeTimeoutPolicy(policy TimeoutPolicy) (TimeoutPolicy, error) {
+	if policy.GuestIdleMinutes < 0 || policy.MaxDurationMinutes < 0 || policy.WarnBeforeMinutes < 0 {
+		return TimeoutPolicy{}, fmt.Errorf("policy values cannot be negative")
+	}
+	if policy.GuestIdleMinutes > maxGuestIdleMinutes {
+		return TimeoutPolicy{}, fmt.Errorf("guest idle timeout cannot exceed %d minutes", maxGuestIdleMinutes)
+	}
+	if policy.MaxDurationMinutes > maxSessionDurationMins {
+		return TimeoutPolicy{}, fmt.Errorf("session duration cap cannot exceed %d minutes", maxSessionDurationMins)
+	}
+	if policy.WarnBeforeMinutes > maxPolicyWarnMinutes {
+		return TimeoutPolicy{}, fmt.Errorf("warning lead time cannot exceed %d minutes", maxPolicyWarnMinutes)
+	}
+	if !policy.Enabled() {
+		return TimeoutPolicy{}, nil
+	}
+	if policy.WarnBeforeMinutes == 0 {
+		policy.WarnBeforeMinutes = defaultPolicyWarnMinutes
+	}
+	return policy, nil
+}
+
+// warnLead é a antecedência efetiva do aviso: nunca mais que metade do limite, para que o aviso
+// não dispare logo no início em limites curtos.
+func (p TimeoutPolicy) warnLead(limitMinutes int) time.Duration {
+	lead := time.Duration(p.WarnBeforeMinutes) * time.Minute
+	if half := time.Duration(limitMinutes) * time.Minute / 2; lead > half {
+		lead = half
+	}
+	return lead
+}
+
+// SetTimeoutPolicy troca a política da sessão; avisos já emitidos são descartados.
+func (s *Service) SetTimeoutPolicy(sessionID string, policy TimeoutPolicy) (*Session, error) {
+	normalized, err := NormalizeTimeoutPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok || session.Status == StatusEnded {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+	session.Config.Policy = normalized
+	s.clearPolicyWarningsLocked(sessionID, "")
+
+	if s.emitEvent != nil {
+		s.emitEvent("session:policy_changed", map[string]interface{}{
+			"sessionID": sessionID,
+			"policy":    normalized,
+		})
+	}
+	return session, nil
+}
+
+// TouchGuestActivity registra input do guest, reiniciando a contagem de inatividade.
+func (s *Service) TouchGuestActivity(sessionID, guestUserID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		return
+	}
+	for i, g := range session.Guests {
+		if g.UserID == guestUserID {
+			session.Guests[i].LastActivityAt = time.Now()
+			s.clearPolicyWarningsLocked(sessionID, guestUserID)
+			return
+		}
+	}
+}
+
+// EvaluateTimeoutPolicies devolve os avisos e aplicações devidos em now. Avisos saem uma única
+// vez por prazo; a aplicação (revogar escrita, encerrar a sessão) fica a cargo do chamador, que
+// deve repeti-la no próximo ciclo se falhar.
+func (s *Service) EvaluateTimeoutPolicies(now time.Time) []PolicyAction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessionIDs := make([]string, 0, len(s.sessions))
+	for id := range s.sessions {
+		sessionIDs = append(sessionIDs, id)
+	}
+	sort.Strings(sessionIDs)
+
+	actions := []PolicyAction{}
+	for _, id := range sessionIDs {
+		session := s.sessions[id]
+		policy := session.Config.Policy
+		if session.Status == StatusEnded || !policy.Enabled() {
+			continue
+		}
+		action := func(kind string, guestUserID string, deadline time.Time) PolicyAction {
+			return PolicyAction{Type: kind, SessionID: id, HostUserID: session.HostUserID, GuestUserID: guestUserID, DeadlineAt: deadline}
+		}
+
+		if policy.MaxDurationMinutes > 0 {
+			deadline := session.CreatedAt.Add(time.Duration(policy.MaxDurationMinutes) * time.Minute)
+			if !now.Before(deadline) {
+				actions = append(actions, action(PolicySessionExpired, "", deadline))
+				continue
+			}
+			if !now.Before(deadline.Add(-policy.warnLead(policy.MaxDurationMinutes))) && s.markPolicyWarningLocked(id, "", PolicySessionEndWarning) {
+				actions = append(actions, action(PolicySessionEndWarning, "", deadline))
+			}
+		}
+
+		if policy.GuestIdleMinutes > 0 {
+			for _, g := range session.Guests {
+				if g.Permission != PermReadWrite || (g.Status != GuestApproved && g.Status != GuestConnected) {
+					continue
+				}
+				lastActivity := g.LastActivityAt
+				if lastActivity.IsZero() {
+					lastActivity = g.JoinedAt
+				}
+				deadline := lastActivity.Add(time.Duration(policy.GuestIdleMinutes) * time.Minute)
+				if !now.Before(deadline) {
+					actions = append(actions, action(PolicyGuestWriteRevoked, g.UserID, deadline))
+					continue
+				}
+				if !now.Before(deadline.Add(-policy.warnLead(policy.GuestIdleMinutes))) && s.markPolicyWarningLocked(id, g.UserID, PolicyGuestIdleWarning) {
+					actions = append(actions, action(PolicyGuestIdleWarning, g.UserID, deadline))
+				}
+			}
+		}
+	}
+	return actions
+}
+
+// markPolicyWarningLocked marca o aviso como emitido; devolve false se já tinha sido.
+func (s *Service) markPolicyWarningLocked(sessionID, guestUserID, kind string) bool {
+	key := sessionID + "|" + guestUserID + "|" + kind
+	if s.policyWarnings[key] {
+		return false
+	}
+	s.policyWarnings[key] = true
+	return true
+}
+
+// clearPolicyWarningsLocked esquece os avisos do guest (ou da sessão inteira, com guestUserID vazio).
+func (s *Service) clearPolicyWarningsLocked(sessionID, guestUserID string) {
+	prefix := sessionID + "|"
+	if guestUserID != "" {
+		prefix += guestUserID + "|"
+	}
+	for key := range s.policyWarnings {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.policyWarnings, key)
+		}
+	}
+}
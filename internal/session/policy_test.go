@@ -0,0 +1,141 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNormalizeTimeoutPolicy(t *testing.T) {
+	if policy, err := NormalizeTimeoutPolicy(TimeoutPolicy{WarnBeforeMinutes: 5}); err != nil || policy != (TimeoutPolicy{}) {
+		t.Fatalf("disabled policy should normalize to zero, got (%+v, %v)", policy, err)
+	}
+	policy, err := NormalizeTimeoutPolicy(TimeoutPolicy{GuestIdleMinutes: 10})
+	if err != nil || policy.WarnBeforeMinutes != defaultPolicyWarnMinutes {
+		t.Fatalf("expected default warning lead, got (%+v, %v)", policy, err)
+	}
+	for _, invalid := range []TimeoutPolicy{
+		{GuestIdleMinutes: -1},
+		{GuestIdleMinutes: maxGuestIdleMinutes + 1},
+		{MaxDurationMinutes: maxSessionDurationMins + 1},
+		{MaxDurationMinutes: 60, WarnBeforeMinutes: maxPolicyWarnMinutes + 1},
+	} {
+		if _, err := NormalizeTimeoutPolicy(invalid); err == nil {
+			t.Fatalf("expected %+v to be rejected", invalid)
+		}
+	}
+}
+
+func TestEvaluateTimeoutPoliciesWarnsThenEnforces(t *testing.T) {
+	svc := newServiceForTest(nil)
+	session, err := svc.CreateSession("host-1", SessionConfig{Policy: TimeoutPolicy{GuestIdleMinutes: 10, MaxDurationMinutes: 60, WarnBeforeMinutes: 2}})
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	for _, guestID := range []string{"writer", "reader"} {
+		if _, err := svc.JoinSession(session.Code, guestID, GuestInfo{Name: guestID}); err != nil {
+			t.Fatalf("JoinSession(%s) error = %v", guestID, err)
+		}
+		if err := svc.ApproveGuest(session.ID, guestID); err != nil {
+			t.Fatalf("ApproveGuest(%s) error = %v", guestID, err)
+		}
+	}
+	if err := svc.SetGuestPermission(session.ID, "writer", string(PermReadWrite)); err != nil {
+		t.Fatalf("SetGuestPermission() error = %v", err)
+	}
+
+	start := time.Now()
+	svc.mu.Lock()
+	session.CreatedAt = start
+	for i := range session.Guests {
+		session.Guests[i].LastActivityAt = start
+	}
+	svc.mu.Unlock()
+
+	types := func(actions []PolicyAction) []string {
+		result := []string{}
+		for _, action := range actions {
+			result = append(result, action.Type+":"+action.GuestUserID)
+		}
+		return result
+	}
+
+	if got := svc.EvaluateTimeoutPolicies(start.Add(5 * time.Minute)); len(got) != 0 {
+		t.Fatalf("expected nothing before the warning window, got %v", types(got))
+	}
+	got := svc.EvaluateTimeoutPolicies(start.Add(8*time.Minute + time.Second))
+	if len(got) != 1 || got[0].Type != PolicyGuestIdleWarning || got[0].GuestUserID != "writer" {
+		t.Fatalf("expected idle warning for the writer only, got %v", types(got))
+	}
+	if got := svc.EvaluateTimeoutPolicies(start.Add(9 * time.Minute)); len(got) != 0 {
+		t.Fatalf("warnings must be emitted once, got %v", types(got))
+	}
+
+	// Atividade reinicia a contagem e permite um novo aviso no próximo prazo.
+	svc.TouchGuestActivity(session.ID, "writer")
+	svc.mu.Lock()
+	session.Guests[0].LastActivityAt = start.Add(9 * time.Minute)
+	svc.mu.Unlock()
+	if got := svc.EvaluateTimeoutPolicies(start.Add(10 * time.Minute)); len(got) != 0 {
+		t.Fatalf("expected activity to postpone enforcement, got %v", types(got))
+	}
+	got = svc.EvaluateTimeoutPolicies(start.Add(19 * time.Minute))
+	if len(got) != 1 || got[0].Type != PolicyGuestWriteRevoked {
+		t.Fatalf("expected write revocation, got %v", types(got))
+	}
+
+	if err := svc.SetGuestPermission(session.ID, "writer", string(PermReadOnly)); err != nil {
+		t.Fatalf("SetGuestPermission() error = %v", err)
+	}
+	got = svc.EvaluateTimeoutPolicies(start.Add(58*time.Minute + time.Second))
+	if len(got) != 1 || got[0].Type != PolicySessionEndWarning || !got[0].DeadlineAt.Equal(start.Add(time.Hour)) {
+		t.Fatalf("expected session end warning, got %v", types(got))
+	}
+	got = svc.EvaluateTimeoutPolicies(start.Add(time.Hour))
+	if len(got) != 1 || got[0].Type != PolicySessionExpired || got[0].HostUserID != "host-1" {
+		t.Fatalf("expected session expiration, got %v", types(got))
+	}
+
+	if err := svc.EndSession(session.ID); err != nil {
+		t.Fatalf("EndSession() error = %v", err)
+	}
+	if got := svc.EvaluateTimeoutPolicies(start.Add(2 * time.Hour)); len(got) != 0 {
+		t.Fatalf("ended sessions must not be evaluated, got %v", types(got))
+	}
+}
+
+func TestSetTimeoutPolicyRejectsInvalidValues(t *testing.T) {
+	svc := newServiceForTest(nil)
+	session, err := svc.CreateSession("host-1", SessionConfig{})
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	if _, err := svc.SetTimeoutPolicy(session.ID, TimeoutPolicy{GuestIdleMinutes: -5}); err == nil {
+		t.Fatalf("expected negative idle timeout to be rejected")
+	}
+	updated, err := svc.SetTimeoutPolicy(session.ID, TimeoutPolicy{MaxDurationMinutes: 3})
+	if err != nil || updated.Config.Policy.WarnBeforeMinutes != defaultPolicyWarnMinutes {
+		t.Fatalf("unexpected policy (%+v, %v)", updated, err)
+	}
+	// Limite curto: o aviso não pode vir antes da metade do prazo.
+	if lead := updated.Config.Policy.warnLead(3); lead != 90*time.Second {
+		t.Fatalf("warnLead(3) = %v, want 1m30s", lead)
+	}
+}
+
+func TestNormalizeNetworkPolicy(t *testing.T) {
+	if policy, err := NormalizeNetworkPolicy(NetworkPolicy{}); err != nil || policy.Mode != NetworkNone {
+		t.Fatalf("empty policy = (%+v, %v), want none", policy, err)
+	}
+	policy, err := NormalizeNetworkPolicy(NetworkPolicy{Mode: "AllowList", AllowedHosts: []string{"github.com", "GitHub.com", "*.npmjs.org"}})
+	if err != nil || policy.Mode != NetworkAllowList || len(policy.AllowedHosts) != 2 {
+		t.Fatalf("unexpected allowlist policy (%+v, %v)", policy, err)
+	}
+	if full, _ := NormalizeNetworkPolicy(NetworkPolicy{Mode: NetworkFull, AllowedHosts: []string{"github.com"}}); len(full.AllowedHosts) != 0 {
+		t.Fatalf("expected hosts to be dropped outside allowlist mode, got %+v", full)
+	}
+	for _, invalid := range []NetworkPolicy{{Mode: "host"}, {Mode: NetworkAllowList}, {Mode: NetworkAllowList, AllowedHosts: []string{"bad/host"}}} {
+		if _, err := NormalizeNetworkPolicy(invalid); err == nil {
+			t.Fatalf("expected %+v to be rejected", invalid)
+		}
+	}
+}
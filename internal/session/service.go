@@ -46,6 +46,7 @@ type Service struct {
 	joinRateLimits      map[string]joinRateLimitState      // sessionID|guestUserID -> janela de tentativas de join
 	invalidJoinAttempts map[string]invalidJoinAttemptState // guestUserID -> tentativas inválidas + lock temporário
 	joinSecurityMetrics JoinSecurityMetrics
+	policyWarnings      map[string]bool // sessionID|guestUserID|tipo -> aviso já emitido
 	emitEvent           func(eventName string, data interface{})
 	mu                  sync.RWMutex
 }
@@ -58,6 +59,7 @@ func NewService(emitEvent func(eventName string, data interface{})) *Service {
 		hostIndex:           make(map[string]string),
 		joinRateLimits:      make(map[string]joinRateLimitState),
 		invalidJoinAttempts: make(map[string]invalidJoinAttemptState),
+		policyWarnings:      make(map[string]bool),
 		emitEvent:           emitEvent,
 	}
 
@@ -443,6 +445,16 @@ func (s *Service) CreateSession(hostUserID string, config SessionConfig) (*Sessi
 	if config.CodeTTLMinutes <= 0 {
 		config.CodeTTLMinutes = 15
 	}
+	policy, err := NormalizeTimeoutPolicy(config.Policy)
+	if err != nil {
+		return nil, err
+	}
+	config.Policy = policy
+	network, err := NormalizeNetworkPolicy(config.Network)
+	if err != nil {
+		return nil, err
+	}
+	config.Network = network
 
 	code, err := s.generateUniqueShortCodeLocked()
 	if err != nil {
@@ -606,6 +618,7 @@ func (s *Service) JoinSession(code string, guestUserID string, guestInfo GuestIn
 	guest := SessionGuest{
 		UserID:     guestUserID,
 		Name:       guestInfo.Name,
+		Login:      strings.TrimSpace(guestInfo.Login),
 		AvatarURL:  guestInfo.AvatarURL,
 		Permission: session.Config.DefaultPerm,
 		JoinedAt:   joinedAt,
@@ -662,6 +675,7 @@ func (s *Service) ApproveGuest(sessionID, guestUserID string) error {
 			}
 
 			session.Guests[i].Status = GuestApproved
+			session.Guests[i].LastActivityAt = time.Now()
 			log.Printf("[SESSION] Guest %s approved in session %s", guestUserID, session.Code)
 
 			// Notificar o guest que foi aprovado
@@ -789,6 +803,7 @@ func (s *Service) EndSession(sessionID string) error {
 	session.AllowNewJoins = false
 	delete(s.hostIndex, session.HostUserID)
 	s.clearJoinRateLimitStateForSessionLocked(sessionID)
+	s.clearPolicyWarningsLocked(sessionID, "")
 
 	log.Printf("[SESSION] Session %s ended", session.Code)
 
@@ -989,6 +1004,11 @@ func (s *Service) SetGuestPermission(sessionID, guestUserID, permission string)
 	for i, g := range session.Guests {
 		if g.UserID == guestUserID {
 			session.Guests[i].Permission = perm
+			if perm == PermReadWrite {
+				// Conceder escrita reinicia a contagem de inatividade.
+				session.Guests[i].LastActivityAt = time.Now()
+			}
+			s.clearPolicyWarningsLocked(sessionID, guestUserID)
 			log.Printf("[SESSION] Guest %s permission set to %s in session %s", guestUserID, permission, session.Code)
 
 			if s.emitEvent != nil {
@@ -1006,6 +1026,47 @@ func (s *Service) SetGuestPermission(sessionID, guestUserID, permission string)
 	return fmt.Errorf("guest not found: %s", guestUserID)
 }
 
+// SetCommandApproval liga ou desliga a regra de duas pessoas para comandos perigosos de guests.
+func (s *Service) SetCommandApproval(sessionID string, enabled bool) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok || session.Status == StatusEnded {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+	session.Config.RequireCommandApproval = enabled
+
+	if s.emitEvent != nil {
+		s.emitEvent("session:command_approval_changed", map[string]interface{}{
+			"sessionID": sessionID,
+			"enabled":   enabled,
+		})
+	}
+	return session, nil
+}
+
+// AuthorizedGuest devolve o guest quando ele foi aprovado (ou já conectou) em uma sessão não encerrada.
+func (s *Service) AuthorizedGuest(sessionID, guestUserID string) (SessionGuest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok || session.Status == StatusEnded {
+		return SessionGuest{}, fmt.Errorf("session not found: %s", sessionID)
+	}
+	for _, g := range session.Guests {
+		if g.UserID != guestUserID {
+			continue
+		}
+		if g.Status != GuestApproved && g.Status != GuestConnected {
+			return SessionGuest{}, fmt.Errorf("guest %s is not approved", guestUserID)
+		}
+		return g, nil
+	}
+	return SessionGuest{}, fmt.Errorf("guest not found: %s", guestUserID)
+}
+
 // KickGuest remove um guest da sessão
 func (s *Service) KickGuest(sessionID, guestUserID string) error {
 	s.mu.Lock()
@@ -1060,6 +1121,7 @@ func (s *Service) cleanupLoop() {
 				delete(s.codeIndex, normalizeCode(session.Code))
 				delete(s.hostIndex, session.HostUserID)
 				s.clearJoinRateLimitStateForSessionLocked(id)
+				s.clearPolicyWarningsLocked(id, "")
 				delete(s.sessions, id)
 				continue
 			}
@@ -13,6 +13,7 @@ func newServiceForTest(emit func(eventName string, data interface{})) *Service {
 		hostIndex:           make(map[string]string),
 		joinRateLimits:      make(map[string]joinRateLimitState),
 		invalidJoinAttempts: make(map[string]invalidJoinAttemptState),
+		policyWarnings:      make(map[string]bool),
 		emitEvent:           emit,
 	}
 }
@@ -737,3 +738,29 @@ func TestJoinSessionSecurityMetricsAndEvents(t *testing.T) {
 		t.Fatalf("expected active-lock blocked event for valid code during lock window")
 	}
 }
+
+func TestSetCommandApprovalTogglesConfigAndEmitsEvent(t *testing.T) {
+	var events []string
+	svc := newServiceForTest(func(eventName string, data interface{}) {
+		events = append(events, eventName)
+	})
+	created, err := svc.CreateSession("host-1", SessionConfig{})
+	if err != nil {
+		t.Fatalf("CreateSession() error: %v", err)
+	}
+
+	updated, err := svc.SetCommandApproval(created.ID, true)
+	if err != nil || !updated.Config.RequireCommandApproval {
+		t.Fatalf("SetCommandApproval() = (%+v, %v)", updated, err)
+	}
+	if events[len(events)-1] != "session:command_approval_changed" {
+		t.Fatalf("expected command approval event, got %v", events)
+	}
+
+	if err := svc.EndSession(created.ID); err != nil {
+		t.Fatalf("EndSession() error: %v", err)
+	}
+	if _, err := svc.SetCommandApproval(created.ID, false); err == nil {
+		t.Fatalf("expected ended session to be rejected")
+	}
+}
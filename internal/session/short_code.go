@@ -89,3 +89,8 @@ func matchesCodeFormat(code string, part1Len int, part2Len int) bool {
 
 	return true
 }
+
+// IsValidCode indica se o código tem um formato aceito (XXXX-XXX ou o legado XXX-YY), sem consultar sessões.
+func IsValidCode(code string) bool {
+	return validateCodeFormat(code)
+}
@@ -527,8 +527,13 @@ func (s *SignalingService) StartSignalingServerAddr(addr string) error {
 
 	log.Printf("[SIGNALING] Starting signaling server on %s", addr)
 
+	// Listen síncrono: porta ocupada vira erro para quem chamou, não só um log.
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("signaling listen %s: %w", addr, err)
+	}
 	go func() {
-		if err := http.ListenAndServe(addr, mux); err != nil {
+		if err := http.Serve(listener, mux); err != nil {
 			log.Printf("[SIGNALING] Server error: %v", err)
 		}
 	}()
@@ -569,6 +574,51 @@ func (s *SignalingService) NotifyPermissionChange(sessionID, guestUserID, permis
 	})
 }
 
+// NotifyAuthorizedGuests envia uma mensagem do host somente aos guests aprovados/conectados;
+// quem ainda está na sala de espera não recebe.
+func (s *SignalingService) NotifyAuthorizedGuests(sessionID string, msg SignalMessage) {
+	if sessionID == "" || s.sessionService == nil {
+		return
+	}
+
+	s.mu.RLock()
+	targets := make([]*wsConnection, 0, len(s.connections[sessionID]))
+	for _, conn := range s.connections[sessionID] {
+		if !conn.isHost {
+			targets = append(targets, conn)
+		}
+	}
+	s.mu.RUnlock()
+
+	msg.SessionID = sessionID
+	if msg.FromUserID == "" {
+		msg.FromUserID = "host"
+	}
+	for _, conn := range targets {
+		if _, err := s.sessionService.AuthorizedGuest(sessionID, conn.userID); err != nil {
+			continue
+		}
+		s.writeJSON(conn, msg)
+	}
+}
+
+// NotifyGuest envia uma mensagem do host a um guest específico, se ele estiver autorizado.
+func (s *SignalingService) NotifyGuest(sessionID, guestUserID string, msg SignalMessage) {
+	if sessionID == "" || guestUserID == "" || s.sessionService == nil {
+		return
+	}
+	if _, err := s.sessionService.AuthorizedGuest(sessionID, guestUserID); err != nil {
+		return
+	}
+
+	msg.SessionID = sessionID
+	msg.TargetUserID = guestUserID
+	if msg.FromUserID == "" {
+		msg.FromUserID = "host"
+	}
+	s.sendToUser(sessionID, guestUserID, msg)
+}
+
 // NotifySessionEnded notifica todos os peers de que a sessão encerrou e limpa recursos de signaling.
 func (s *SignalingService) NotifySessionEnded(sessionID, fromUserID string) {
 	if sessionID == "" {
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -231,6 +232,55 @@ func TestNotifyPermissionChangeTargetsGuest(t *testing.T) {
 	}
 }
 
+func TestNotifyAuthorizedGuestsSkipsPendingGuests(t *testing.T) {
+	svc := newServiceForTest(nil)
+	session, err := svc.CreateSession("host-1", SessionConfig{})
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	for _, guestID := range []string{"guest-1", "guest-2"} {
+		if _, err := svc.JoinSession(session.Code, guestID, GuestInfo{Name: guestID}); err != nil {
+			t.Fatalf("JoinSession(%s) error = %v", guestID, err)
+		}
+	}
+	if err := svc.ApproveGuest(session.ID, "guest-1"); err != nil {
+		t.Fatalf("ApproveGuest() error = %v", err)
+	}
+
+	signaling := NewSignalingService(svc)
+	server := httptest.NewServer(http.HandlerFunc(signaling.HandleWebSocket))
+	t.Cleanup(server.Close)
+
+	_ = connectSignalWS(t, server.URL, session.ID, "host-1", "host")
+	approvedConn := connectSignalWS(t, server.URL, session.ID, "guest-1", "guest")
+	pendingConn := connectSignalWS(t, server.URL, session.ID, "guest-2", "guest")
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		signaling.mu.RLock()
+		count := len(signaling.connections[session.ID])
+		signaling.mu.RUnlock()
+		if count == 3 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	signaling.NotifyAuthorizedGuests(session.ID, SignalMessage{Type: "gitpanel_event", Payload: `{"event":"gitpanel:status_changed"}`})
+
+	msg := mustReadSignal(t, approvedConn)
+	if msg.Type != "gitpanel_event" || msg.SessionID != session.ID || msg.FromUserID != "host" {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+	_ = pendingConn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	var leaked SignalMessage
+	if err := pendingConn.ReadJSON(&leaked); err == nil {
+		t.Fatalf("pending guest must not receive host events, got %+v", leaked)
+	}
+	if _, err := svc.AuthorizedGuest(session.ID, "guest-2"); err == nil {
+		t.Fatalf("expected pending guest to be unauthorized")
+	}
+}
+
 func TestNotifySessionEndedCleansUpConnections(t *testing.T) {
 	svc := newServiceForTest(nil)
 	session, err := svc.CreateSession("host-1", SessionConfig{})
@@ -418,3 +468,16 @@ func TestSignalingAllowsConfiguredOrigin(t *testing.T) {
 		_ = conn.Close()
 	})
 }
+
+func TestStartSignalingServerReportsBusyPort(t *testing.T) {
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer busy.Close()
+
+	signaling := NewSignalingService(NewService(nil))
+	if err := signaling.StartSignalingServerAddr(busy.Addr().String()); err == nil {
+		t.Fatalf("expected busy signaling port to be reported")
+	}
+}
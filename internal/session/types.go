@@ -88,30 +88,41 @@ type Session struct {
 
 // SessionConfig configura uma sessão
 type SessionConfig struct {
-	MaxGuests      int         `json:"maxGuests"`      // Default: 10
-	DefaultPerm    Permission  `json:"defaultPerm"`    // "read_only"
-	AllowAnonymous bool        `json:"allowAnonymous"` // Guests sem login GitHub
-	Mode           SessionMode `json:"mode"`           // "docker" ou "liveshare"
-	WorkspaceID    uint        `json:"workspaceID"`
-	WorkspaceName  string      `json:"workspaceName,omitempty"`
-	DockerImage    string      `json:"dockerImage,omitempty"`
-	ProjectPath    string      `json:"projectPath,omitempty"`
-	CodeTTLMinutes int         `json:"codeTTLMinutes"` // Default: 15
+	MaxGuests      int           `json:"maxGuests"`      // Default: 10
+	DefaultPerm    Permission    `json:"defaultPerm"`    // "read_only"
+	AllowAnonymous bool          `json:"allowAnonymous"` // Guests sem login GitHub
+	Mode           SessionMode   `json:"mode"`           // "docker" ou "liveshare"
+	WorkspaceID    uint          `json:"workspaceID"`
+	WorkspaceName  string        `json:"workspaceName,omitempty"`
+	DockerImage    string        `json:"dockerImage,omitempty"`
+	ProjectPath    string        `json:"projectPath,omitempty"`
+	CodeTTLMinutes int           `json:"codeTTLMinutes"` // Default: 15
+	Policy         TimeoutPolicy `json:"policy"`         // Idle/duração máxima (zero = desligado)
+	// SecurityProfile é o perfil do container no modo docker ("strict"/"standard").
+	SecurityProfile string `json:"securityProfile,omitempty"`
+	// Network é a política de saída de rede do container no modo docker.
+	Network NetworkPolicy `json:"network"`
+	// RequireCommandApproval segura comandos perigosos de guests até o host aprovar.
+	RequireCommandApproval bool `json:"requireCommandApproval"`
 }
 
 // SessionGuest representa um guest conectado/pendente
 type SessionGuest struct {
 	UserID     string      `json:"userID"`
 	Name       string      `json:"name"`
+	Login      string      `json:"login,omitempty"`
 	AvatarURL  string      `json:"avatarUrl,omitempty"`
 	Permission Permission  `json:"permission"`
 	JoinedAt   time.Time   `json:"joinedAt"`
 	Status     GuestStatus `json:"status"`
+	// LastActivityAt é a última entrada do guest (ou concessão de escrita); base do idle timeout.
+	LastActivityAt time.Time `json:"lastActivityAt"`
 }
 
 // GuestInfo são as informações que o Guest envia ao fazer Join
 type GuestInfo struct {
 	Name      string `json:"name"`
+	Login     string `json:"login,omitempty"` // login do GitHub, usado para sugerir co-autores
 	Email     string `json:"email,omitempty"`
 	AvatarURL string `json:"avatarUrl,omitempty"`
 }
@@ -138,7 +149,7 @@ type JoinResult struct {
 
 // SignalMessage é uma mensagem trocada via WebSocket para signaling WebRTC
 type SignalMessage struct {
-	Type         string `json:"type"` // "sdp_offer", "sdp_answer", "ice_candidate", "guest_request", "guest_approved", "guest_rejected", "session_ended", "permission_change"
+	Type         string `json:"type"` // "sdp_offer", "sdp_answer", "ice_candidate", "guest_request", "guest_approved", "guest_rejected", "session_ended", "permission_change", "gitpanel_event", "review_mode_changed", "review_draft_status", "policy_warning", "policy_enforced", "command_held", "command_decided"
 	Payload      string `json:"payload,omitempty"`
 	TargetUserID string `json:"targetUserID,omitempty"`
 	FromUserID   string `json:"fromUserID,omitempty"`
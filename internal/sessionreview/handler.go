@@ -0,0 +1,117 @@
+package sessionreview
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Rotas servidas pelo gateway do host (registradas com o prefixo RoutePrefix). Todas exigem
+// sessionID e guestUserID e só respondem a guests com permissão de escrita.
+const (
+	RoutePrefix  = "/api/session/review/"
+	RouteTarget  = "/api/session/review/target"
+	RouteDrafts  = "/api/session/review/drafts"
+	maxBodyBytes = 64 << 10
+)
+
+// Backend é implementado pelo app do host.
+type Backend interface {
+	// AuthorizeReviewer exige guest aprovado com permissão read_write e devolve sua identidade.
+	AuthorizeReviewer(sessionID, guestUserID string) (Reviewer, error)
+	// DraftSubmitted avisa o host de um novo rascunho aguardando aprovação.
+	DraftSubmitted(draft Draft)
+}
+
+// SubmitRequest é o corpo de POST RouteDrafts.
+type SubmitRequest struct {
+	SessionID   string     `json:"sessionID"`
+	GuestUserID string     `json:"guestUserID"`
+	Draft       DraftInput `json:"draft"`
+}
+
+// RequestPath monta o caminho (com query) de uma rota GET para o guest.
+func RequestPath(route string, sessionID string, guestUserID string) string {
+	query := url.Values{}
+	query.Set("sessionID", strings.TrimSpace(sessionID))
+	query.Set("guestUserID", strings.TrimSpace(guestUserID))
+	return route + "?" + query.Encode()
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewHandler expõe o modo de revisão aos guests: leitura do PR alvo, envio e acompanhamento dos
+// próprios rascunhos. A publicação no GitHub só acontece pelo host.
+func NewHandler(store *Store, backend Backend) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(RouteTarget, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+			return
+		}
+		sessionID, _, ok := authorize(w, r.URL.Query().Get("sessionID"), r.URL.Query().Get("guestUserID"), backend)
+		if !ok {
+			return
+		}
+		target, active := store.Target(sessionID)
+		if !active {
+			writeJSON(w, http.StatusNotFound, errorResponse{Error: "PR review mode is not active in this session"})
+			return
+		}
+		writeJSON(w, http.StatusOK, target)
+	})
+	mux.HandleFunc(RouteDrafts, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			sessionID, reviewer, ok := authorize(w, r.URL.Query().Get("sessionID"), r.URL.Query().Get("guestUserID"), backend)
+			if !ok {
+				return
+			}
+			writeJSON(w, http.StatusOK, store.List(sessionID, reviewer.UserID))
+		case http.MethodPost:
+			var req SubmitRequest
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+				writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+				return
+			}
+			sessionID, reviewer, ok := authorize(w, req.SessionID, req.GuestUserID, backend)
+			if !ok {
+				return
+			}
+			draft, err := store.Submit(sessionID, reviewer, req.Draft)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+				return
+			}
+			backend.DraftSubmitted(draft)
+			writeJSON(w, http.StatusCreated, draft)
+		default:
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+		}
+	})
+	return mux
+}
+
+func authorize(w http.ResponseWriter, sessionID string, guestUserID string, backend Backend) (string, Reviewer, bool) {
+	sessionID, guestUserID = strings.TrimSpace(sessionID), strings.TrimSpace(guestUserID)
+	if sessionID == "" || guestUserID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "sessionID and guestUserID are required"})
+		return "", Reviewer{}, false
+	}
+	reviewer, err := backend.AuthorizeReviewer(sessionID, guestUserID)
+	if err != nil {
+		writeJSON(w, http.StatusForbidden, errorResponse{Error: err.Error()})
+		return "", Reviewer{}, false
+	}
+	reviewer.UserID = guestUserID
+	return sessionID, reviewer, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,299 @@
+package sessionreview
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Estados de um rascunho de comentário enviado por um guest.
+const (
+	DraftPending  = "pending"
+	DraftPosting  = "posting" // aprovado pelo host; publicação no GitHub em andamento
+	DraftPosted   = "posted"
+	DraftRejected = "rejected"
+	DraftFailed   = "failed" // aprovado, mas a publicação no GitHub falhou; pode ser aprovado de novo
+)
+
+const (
+	maxDraftBodyRunes       = 16000
+	maxPendingDraftsByGuest = 50
+)
+
+// Target é o PR do host que os guests podem revisar na sessão.
+type Target struct {
+	SessionID string    `json:"sessionId"`
+	Owner     string    `json:"owner"`
+	Repo      string    `json:"repo"`
+	PRNumber  int       `json:"prNumber"`
+	Title     string    `json:"title,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// DraftInput é o comentário proposto pelo guest; sem Path vira comentário geral no PR.
+type DraftInput struct {
+	Body string `json:"body"`
+	Path string `json:"path,omitempty"`
+	Line int    `json:"line,omitempty"`
+	Side string `json:"side,omitempty"` // "LEFT" | "RIGHT" (padrão)
+}
+
+// Reviewer identifica o guest autor do rascunho.
+type Reviewer struct {
+	UserID string `json:"userId"`
+	Name   string `json:"name"`
+	Login  string `json:"login,omitempty"`
+}
+
+// Draft é um comentário aguardando (ou já com) decisão do host.
+type Draft struct {
+	ID           string     `json:"id"`
+	SessionID    string     `json:"sessionId"`
+	Owner        string     `json:"owner"`
+	Repo         string     `json:"repo"`
+	PRNumber     int        `json:"prNumber"`
+	Author       Reviewer   `json:"author"`
+	Body         string     `json:"body"`
+	Path         string     `json:"path,omitempty"`
+	Line         int        `json:"line,omitempty"`
+	Side         string     `json:"side,omitempty"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	DecidedAt    *time.Time `json:"decidedAt,omitempty"`
+	RejectReason string     `json:"rejectReason,omitempty"`
+	CommentID    string     `json:"commentId,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// NormalizeDraftInput valida o rascunho: corpo obrigatório e, em comentários inline, caminho
+// relativo e linha positiva.
+func NormalizeDraftInput(input DraftInput) (DraftInput, error) {
+	input.Body = strings.TrimSpace(input.Body)
+	if input.Body == "" {
+		return DraftInput{}, fmt.Errorf("comment body is required")
+	}
+	if utf8.RuneCountInString(input.Body) > maxDraftBodyRunes {
+		return DraftInput{}, fmt.Errorf("comment body exceeds %d characters", maxDraftBodyRunes)
+	}
+	input.Path = strings.TrimPrefix(strings.ReplaceAll(strings.TrimSpace(input.Path), "\\", "/"), "./")
+	if input.Path == "" {
+		input.Line, input.Side = 0, ""
+		return input, nil
+	}
+	if strings.HasPrefix(input.Path, "/") || path.Clean(input.Path) != input.Path || strings.HasPrefix(input.Path, "../") {
+		return DraftInput{}, fmt.Errorf("invalid file path: %s", input.Path)
+	}
+	if input.Line <= 0 {
+		return DraftInput{}, fmt.Errorf("inline comments require a line number")
+	}
+	switch strings.ToUpper(strings.TrimSpace(input.Side)) {
+	case "", "RIGHT":
+		input.Side = "RIGHT"
+	case "LEFT":
+		input.Side = "LEFT"
+	default:
+		return DraftInput{}, fmt.Errorf("invalid diff side: %s", input.Side)
+	}
+	return input, nil
+}
+
+// AttributedBody é o texto publicado pelo host: o comentário do guest mais a autoria.
+func AttributedBody(draft Draft) string {
+	author := strings.TrimSpace(draft.Author.Name)
+	if login := strings.TrimSpace(draft.Author.Login); login != "" {
+		if author == "" || strings.EqualFold(author, login) {
+			author = "@" + login
+		} else {
+			author = "@" + login + " (" + author + ")"
+		}
+	}
+	if author == "" {
+		author = "a session guest"
+	}
+	return strings.TrimSpace(draft.Body) + "\n\n---\n_Suggested by " + author + " during an ORCH collaboration session._"
+}
+
+// Store guarda o PR em revisão e os rascunhos de cada sessão (somente em memória, no host).
+type Store struct {
+	mu      sync.Mutex
+	targets map[string]Target
+	drafts  map[string][]*Draft // sessionID -> rascunhos em ordem de envio
+	now     func() time.Time
+}
+
+func NewStore() *Store {
+	return &Store{
+		targets: make(map[string]Target),
+		drafts:  make(map[string][]*Draft),
+		now:     time.Now,
+	}
+}
+
+// SetTarget inicia (ou troca) o PR em revisão; rascunhos pendentes do PR anterior são descartados.
+func (s *Store) SetTarget(target Target) Target {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if previous, ok := s.targets[target.SessionID]; ok && (previous.Owner != target.Owner || previous.Repo != target.Repo || previous.PRNumber != target.PRNumber) {
+		s.dropPendingLocked(target.SessionID)
+	}
+	if target.StartedAt.IsZero() {
+		target.StartedAt = s.now()
+	}
+	s.targets[target.SessionID] = target
+	return target
+}
+
+// Target devolve o PR em revisão na sessão.
+func (s *Store) Target(sessionID string) (Target, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	target, ok := s.targets[sessionID]
+	return target, ok
+}
+
+// Stop encerra o modo de revisão e descarta todos os rascunhos da sessão.
+func (s *Store) Stop(sessionID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.targets[sessionID]
+	delete(s.targets, sessionID)
+	delete(s.drafts, sessionID)
+	return ok
+}
+
+// Submit registra o rascunho do guest para o PR em revisão.
+func (s *Store) Submit(sessionID string, author Reviewer, input DraftInput) (Draft, error) {
+	normalized, err := NormalizeDraftInput(input)
+	if err != nil {
+		return Draft{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	target, ok := s.targets[sessionID]
+	if !ok {
+		return Draft{}, fmt.Errorf("PR review mode is not active in this session")
+	}
+	pending := 0
+	for _, draft := range s.drafts[sessionID] {
+		if draft.Author.UserID == author.UserID && draft.Status == DraftPending {
+			pending++
+		}
+	}
+	if pending >= maxPendingDraftsByGuest {
+		return Draft{}, fmt.Errorf("too many pending comments; wait for the host to review them")
+	}
+
+	draft := &Draft{
+		ID:        uuid.NewString(),
+		SessionID: sessionID,
+		Owner:     target.Owner,
+		Repo:      target.Repo,
+		PRNumber:  target.PRNumber,
+		Author:    author,
+		Body:      normalized.Body,
+		Path:      normalized.Path,
+		Line:      normalized.Line,
+		Side:      normalized.Side,
+		Status:    DraftPending,
+		CreatedAt: s.now(),
+	}
+	s.drafts[sessionID] = append(s.drafts[sessionID], draft)
+	return *draft, nil
+}
+
+// List devolve os rascunhos da sessão; com guestUserID, apenas os desse guest.
+func (s *Store) List(sessionID string, guestUserID string) []Draft {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := []Draft{}
+	for _, draft := range s.drafts[sessionID] {
+		if guestUserID == "" || draft.Author.UserID == guestUserID {
+			result = append(result, *draft)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result
+}
+
+// Get devolve o rascunho pelo ID.
+func (s *Store) Get(sessionID string, draftID string) (Draft, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	draft := s.findLocked(sessionID, draftID)
+	if draft == nil {
+		return Draft{}, false
+	}
+	return *draft, true
+}
+
+// BeginPost reserva o rascunho para publicação, impedindo que duas aprovações o publiquem.
+func (s *Store) BeginPost(sessionID string, draftID string) (Draft, error) {
+	return s.transition(sessionID, draftID, []string{DraftPending, DraftFailed}, func(draft *Draft) {
+		draft.Status = DraftPosting
+	})
+}
+
+// MarkPosted registra a publicação aprovada pelo host.
+func (s *Store) MarkPosted(sessionID string, draftID string, commentID string) (Draft, error) {
+	return s.transition(sessionID, draftID, []string{DraftPosting}, func(draft *Draft) {
+		draft.Status, draft.CommentID, draft.Error = DraftPosted, commentID, ""
+	})
+}
+
+// MarkFailed mantém o rascunho reaprovável quando o GitHub recusou a publicação.
+func (s *Store) MarkFailed(sessionID string, draftID string, cause error) (Draft, error) {
+	return s.transition(sessionID, draftID, []string{DraftPosting}, func(draft *Draft) {
+		draft.Status, draft.Error = DraftFailed, cause.Error()
+	})
+}
+
+// MarkRejected descarta o rascunho com o motivo informado pelo host.
+func (s *Store) MarkRejected(sessionID string, draftID string, reason string) (Draft, error) {
+	return s.transition(sessionID, draftID, []string{DraftPending, DraftFailed}, func(draft *Draft) {
+		draft.Status, draft.RejectReason = DraftRejected, strings.TrimSpace(reason)
+	})
+}
+
+func (s *Store) transition(sessionID string, draftID string, from []string, apply func(draft *Draft)) (Draft, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	draft := s.findLocked(sessionID, draftID)
+	if draft == nil {
+		return Draft{}, fmt.Errorf("review draft not found: %s", draftID)
+	}
+	if !slices.Contains(from, draft.Status) {
+		return Draft{}, fmt.Errorf("review draft is %s", draft.Status)
+	}
+	apply(draft)
+	decidedAt := s.now()
+	draft.DecidedAt = &decidedAt
+	return *draft, nil
+}
+
+func (s *Store) findLocked(sessionID string, draftID string) *Draft {
+	for _, draft := range s.drafts[sessionID] {
+		if draft.ID == draftID {
+			return draft
+		}
+	}
+	return nil
+}
+
+func (s *Store) dropPendingLocked(sessionID string) {
+	kept := s.drafts[sessionID][:0]
+	for _, draft := range s.drafts[sessionID] {
+		if draft.Status != DraftPending && draft.Status != DraftFailed {
+			kept = append(kept, draft)
+		}
+	}
+	s.drafts[sessionID] = kept
+}
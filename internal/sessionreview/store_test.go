@@ -0,0 +1,187 @@
+package sessionreview
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeDraftInput(t *testing.T) {
+	general, err := NormalizeDraftInput(DraftInput{Body: "  looks good  ", Line: 4, Side: "LEFT"})
+	if err != nil || general.Body != "looks good" || general.Line != 0 || general.Side != "" {
+		t.Fatalf("unexpected general comment (%+v, %v)", general, err)
+	}
+	inline, err := NormalizeDraftInput(DraftInput{Body: "nit", Path: "./internal\\app.go", Line: 12})
+	if err != nil || inline.Path != "internal/app.go" || inline.Side != "RIGHT" {
+		t.Fatalf("unexpected inline comment (%+v, %v)", inline, err)
+	}
+
+	for name, input := range map[string]DraftInput{
+		"empty body":    {Body: "   "},
+		"absolute path": {Body: "x", Path: "/etc/passwd", Line: 1},
+		"parent path":   {Body: "x", Path: "../secret", Line: 1},
+		"missing line":  {Body: "x", Path: "app.go"},
+		"invalid side":  {Body: "x", Path: "app.go", Line: 1, Side: "UP"},
+		"oversized":     {Body: strings.Repeat("a", maxDraftBodyRunes+1)},
+	} {
+		if _, err := NormalizeDraftInput(input); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestAttributedBodyCreditsGuest(t *testing.T) {
+	cases := map[string]Reviewer{
+		"_Suggested by @ana (Ana Souza) during": {Name: "Ana Souza", Login: "ana"},
+		"_Suggested by @ana during":             {Name: "ana", Login: "ana"},
+		"_Suggested by Ana during":              {Name: "Ana"},
+		"_Suggested by a session guest during":  {},
+	}
+	for want, author := range cases {
+		body := AttributedBody(Draft{Body: "Rename this.\n", Author: author})
+		if !strings.HasPrefix(body, "Rename this.\n\n---\n") || !strings.Contains(body, want) {
+			t.Fatalf("AttributedBody(%+v) = %q, want %q", author, body, want)
+		}
+	}
+}
+
+func TestStoreDraftLifecycle(t *testing.T) {
+	store := NewStore()
+	ana := Reviewer{UserID: "guest-ana", Name: "Ana", Login: "ana"}
+	if _, err := store.Submit("s1", ana, DraftInput{Body: "hi"}); err == nil {
+		t.Fatalf("expected submit without review mode to fail")
+	}
+
+	store.SetTarget(Target{SessionID: "s1", Owner: "orch-labs", Repo: "orch", PRNumber: 7})
+	first, err := store.Submit("s1", ana, DraftInput{Body: "first"})
+	if err != nil || first.Status != DraftPending || first.PRNumber != 7 {
+		t.Fatalf("unexpected draft (%+v, %v)", first, err)
+	}
+	second, _ := store.Submit("s1", ana, DraftInput{Body: "second", Path: "app.go", Line: 3})
+	_, _ = store.Submit("s1", Reviewer{UserID: "guest-bia"}, DraftInput{Body: "other"})
+	if got := store.List("s1", ana.UserID); len(got) != 2 {
+		t.Fatalf("expected 2 drafts for ana, got %d", len(got))
+	}
+
+	if _, err := store.BeginPost("s1", first.ID); err != nil {
+		t.Fatalf("BeginPost() error: %v", err)
+	}
+	if _, err := store.BeginPost("s1", first.ID); err == nil {
+		t.Fatalf("expected concurrent approval to be refused")
+	}
+	if _, err := store.MarkRejected("s1", first.ID, "dup"); err == nil {
+		t.Fatalf("expected rejecting a draft being posted to fail")
+	}
+	failed, err := store.MarkFailed("s1", first.ID, errors.New("422 line outside diff"))
+	if err != nil || failed.Status != DraftFailed || failed.Error == "" {
+		t.Fatalf("unexpected failed draft (%+v, %v)", failed, err)
+	}
+	_, _ = store.BeginPost("s1", first.ID)
+	posted, err := store.MarkPosted("s1", first.ID, "c-1")
+	if err != nil || posted.Status != DraftPosted || posted.CommentID != "c-1" || posted.Error != "" || posted.DecidedAt == nil {
+		t.Fatalf("unexpected posted draft (%+v, %v)", posted, err)
+	}
+
+	rejected, err := store.MarkRejected("s1", second.ID, "  out of scope ")
+	if err != nil || rejected.Status != DraftRejected || rejected.RejectReason != "out of scope" {
+		t.Fatalf("unexpected rejected draft (%+v, %v)", rejected, err)
+	}
+
+	// Trocar de PR descarta só o que ainda não foi decidido.
+	store.SetTarget(Target{SessionID: "s1", Owner: "orch-labs", Repo: "orch", PRNumber: 8})
+	if got := store.List("s1", ""); len(got) != 2 {
+		t.Fatalf("expected only decided drafts after switching PR, got %+v", got)
+	}
+	if !store.Stop("s1") || store.Stop("s1") || len(store.List("s1", "")) != 0 {
+		t.Fatalf("expected Stop to clear the review mode once")
+	}
+}
+
+func TestStoreLimitsPendingDraftsPerGuest(t *testing.T) {
+	store := NewStore()
+	store.SetTarget(Target{SessionID: "s1", Owner: "o", Repo: "r", PRNumber: 1})
+	guest := Reviewer{UserID: "guest-1"}
+	for i := 0; i < maxPendingDraftsByGuest; i++ {
+		if _, err := store.Submit("s1", guest, DraftInput{Body: fmt.Sprintf("c%d", i)}); err != nil {
+			t.Fatalf("Submit(%d) error: %v", i, err)
+		}
+	}
+	if _, err := store.Submit("s1", guest, DraftInput{Body: "one more"}); err == nil {
+		t.Fatalf("expected pending limit to be enforced")
+	}
+}
+
+type fakeBackend struct {
+	writers   map[string]Reviewer
+	submitted []Draft
+}
+
+func (b *fakeBackend) AuthorizeReviewer(sessionID, guestUserID string) (Reviewer, error) {
+	reviewer, ok := b.writers[guestUserID]
+	if !ok {
+		return Reviewer{}, errors.New("PR review requires read-write permission")
+	}
+	return reviewer, nil
+}
+
+func (b *fakeBackend) DraftSubmitted(draft Draft) {
+	b.submitted = append(b.submitted, draft)
+}
+
+func TestHandlerAuthorizesReviewers(t *testing.T) {
+	store := NewStore()
+	backend := &fakeBackend{writers: map[string]Reviewer{"guest-rw": {Name: "Ana", Login: "ana"}}}
+	server := httptest.NewServer(NewHandler(store, backend))
+	t.Cleanup(server.Close)
+
+	get := func(route string, guestUserID string) *http.Response {
+		resp, err := http.Get(server.URL + RequestPath(route, "s1", guestUserID))
+		if err != nil {
+			t.Fatalf("GET %s error: %v", route, err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+	submit := func(guestUserID string, input DraftInput) *http.Response {
+		payload, _ := json.Marshal(SubmitRequest{SessionID: "s1", GuestUserID: guestUserID, Draft: input})
+		resp, err := http.Post(server.URL+RouteDrafts, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			t.Fatalf("POST drafts error: %v", err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	if resp := get(RouteTarget, "guest-ro"); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("read-only guest status = %d, want 403", resp.StatusCode)
+	}
+	if resp := get(RouteTarget, "guest-rw"); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("inactive review status = %d, want 404", resp.StatusCode)
+	}
+
+	store.SetTarget(Target{SessionID: "s1", Owner: "orch-labs", Repo: "orch", PRNumber: 7})
+	if resp := submit("guest-ro", DraftInput{Body: "hi"}); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("read-only submit status = %d, want 403", resp.StatusCode)
+	}
+	if resp := submit("guest-rw", DraftInput{Body: ""}); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("empty submit status = %d, want 400", resp.StatusCode)
+	}
+	resp := submit("guest-rw", DraftInput{Body: "please add a test"})
+	var draft Draft
+	if resp.StatusCode != http.StatusCreated || json.NewDecoder(resp.Body).Decode(&draft) != nil {
+		t.Fatalf("submit status = %d, want 201", resp.StatusCode)
+	}
+	if draft.Author.UserID != "guest-rw" || draft.Author.Login != "ana" || len(backend.submitted) != 1 {
+		t.Fatalf("unexpected submitted draft %+v (notified %d)", draft, len(backend.submitted))
+	}
+
+	var mine []Draft
+	if resp := get(RouteDrafts, "guest-rw"); json.NewDecoder(resp.Body).Decode(&mine) != nil || len(mine) != 1 {
+		t.Fatalf("expected guest to list its draft, got %+v", mine)
+	}
+}
@@ -0,0 +1,79 @@
+package snippets
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// placeholderRegex casa {{nome}} e {{nome:padrão}}.
+var placeholderRegex = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*(?::([^}]*))?\}\}`)
+
+// Placeholder é uma variável do comando, preenchida na palette antes de rodar.
+type Placeholder struct {
+	Name     string `json:"name"`
+	Default  string `json:"default,omitempty"`
+	Required bool   `json:"required"`
+}
+
+// Placeholders lista as variáveis do comando na ordem em que aparecem (sem repetição).
+// Se a mesma variável aparece com e sem padrão, vale o primeiro padrão declarado.
+func Placeholders(command string) []Placeholder {
+	matches := placeholderRegex.FindAllStringSubmatchIndex(command, -1)
+	out := make([]Placeholder, 0, len(matches))
+	index := make(map[string]int, len(matches))
+	for _, m := range matches {
+		name := command[m[2]:m[3]]
+		hasDefault := m[4] >= 0
+		def := ""
+		if hasDefault {
+			def = command[m[4]:m[5]]
+		}
+		if i, ok := index[name]; ok {
+			if hasDefault && out[i].Required {
+				out[i].Default = def
+				out[i].Required = false
+			}
+			continue
+		}
+		index[name] = len(out)
+		out = append(out, Placeholder{Name: name, Default: def, Required: !hasDefault})
+	}
+	return out
+}
+
+// Expand substitui as variáveis do comando. Variáveis sem valor usam o padrão; sem padrão é erro.
+// Valores multi-linha são rejeitados para que um placeholder não injete comandos extras.
+func Expand(command string, vars map[string]string) (string, error) {
+	for name, value := range vars {
+		if strings.ContainsAny(value, "\r\n") {
+			return "", fmt.Errorf("value for %q must be a single line", name)
+		}
+	}
+	defaults := make(map[string]string)
+	for _, placeholder := range Placeholders(command) {
+		if !placeholder.Required {
+			defaults[placeholder.Name] = placeholder.Default
+		}
+	}
+
+	var missing []string
+	expanded := placeholderRegex.ReplaceAllStringFunc(command, func(token string) string {
+		name := placeholderRegex.FindStringSubmatch(token)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		if def, ok := defaults[name]; ok {
+			return def
+		}
+		if !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+		return token
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing value for %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
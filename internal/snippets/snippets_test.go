@@ -0,0 +1,38 @@
+package snippets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlaceholdersAndExpand(t *testing.T) {
+	command := "kubectl -n {{ns:default}} logs {{pod}} --tail={{lines:100}} && echo {{pod}}"
+
+	got := Placeholders(command)
+	want := []Placeholder{
+		{Name: "ns", Default: "default"},
+		{Name: "pod", Required: true},
+		{Name: "lines", Default: "100"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected placeholders: %+v", got)
+	}
+
+	expanded, err := Expand(command, map[string]string{"pod": "api-0", "lines": ""})
+	if err != nil {
+		t.Fatalf("Expand returned error: %v", err)
+	}
+	if expanded != "kubectl -n default logs api-0 --tail= && echo api-0" {
+		t.Fatalf("unexpected expansion: %q", expanded)
+	}
+
+	if _, err := Expand(command, nil); err == nil || err.Error() != "missing value for pod" {
+		t.Fatalf("expected missing value error, got %v", err)
+	}
+	if _, err := Expand(command, map[string]string{"pod": "x\nrm -rf /"}); err == nil {
+		t.Fatalf("expected multi-line value to be rejected")
+	}
+	if plain, err := Expand("echo ${HOME} {{ not a var }}", nil); err != nil || plain != "echo ${HOME} {{ not a var }}" {
+		t.Fatalf("expected text without placeholders untouched, got %q (%v)", plain, err)
+	}
+}
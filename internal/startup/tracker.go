@@ -0,0 +1,129 @@
+package startup
+
+import (
+	"sync"
+	"time"
+)
+
+// Status de uma etapa do startup.
+const (
+	StatusRunning = "running"
+	StatusReady   = "ready"
+	StatusFailed  = "failed"
+)
+
+// Phase é uma etapa medida do startup. Etapas async rodam depois que a janela já abriu.
+type Phase struct {
+	Name       string    `json:"name"`
+	Async      bool      `json:"async"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+// Timings resume o startup: quanto tempo a janela esperou e o que ainda está subindo.
+type Timings struct {
+	StartedAt     time.Time `json:"startedAt"`
+	WindowReadyMs int64     `json:"windowReadyMs"` // 0 enquanto o Startup não retornou
+	Phases        []Phase   `json:"phases"`
+	Pending       []string  `json:"pending"`
+}
+
+// Tracker mede as etapas do startup e avisa quando cada subsistema async fica pronto.
+type Tracker struct {
+	mu          sync.Mutex
+	startedAt   time.Time
+	windowReady time.Duration
+	phases      []Phase
+	onReady     func(Phase)
+	wg          sync.WaitGroup
+}
+
+// NewTracker começa a contar a partir de agora. onReady recebe cada etapa async concluída.
+func NewTracker(onReady func(Phase)) *Tracker {
+	return &Tracker{startedAt: time.Now(), onReady: onReady}
+}
+
+// Begin abre uma etapa síncrona; a função devolvida a encerra com o erro (ou nil).
+func (t *Tracker) Begin(name string) func(error) {
+	index := t.open(name, false)
+	return func(err error) {
+		t.finish(index, err)
+	}
+}
+
+// Go roda fn em background como uma etapa async e notifica onReady ao terminar.
+func (t *Tracker) Go(name string, fn func() error) {
+	index := t.open(name, true)
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		phase := t.finish(index, fn())
+		if t.onReady != nil {
+			t.onReady(phase)
+		}
+	}()
+}
+
+// MarkWindowReady registra o fim do Startup (a partir daqui a janela responde).
+func (t *Tracker) MarkWindowReady() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.windowReady = time.Since(t.startedAt)
+}
+
+// Wait espera as etapas async até timeout; devolve false se alguma ainda não terminou.
+func (t *Tracker) Wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// Timings devolve uma cópia das medições atuais.
+func (t *Tracker) Timings() Timings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timings := Timings{
+		StartedAt:     t.startedAt,
+		WindowReadyMs: t.windowReady.Milliseconds(),
+		Phases:        make([]Phase, len(t.phases)),
+		Pending:       []string{},
+	}
+	copy(timings.Phases, t.phases)
+	for i, phase := range timings.Phases {
+		if phase.Status == StatusRunning {
+			timings.Phases[i].DurationMs = time.Since(phase.StartedAt).Milliseconds()
+			timings.Pending = append(timings.Pending, phase.Name)
+		}
+	}
+	return timings
+}
+
+func (t *Tracker) open(name string, async bool) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.phases = append(t.phases, Phase{Name: name, Async: async, Status: StatusRunning, StartedAt: time.Now()})
+	return len(t.phases) - 1
+}
+
+func (t *Tracker) finish(index int, err error) Phase {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	phase := &t.phases[index]
+	phase.DurationMs = time.Since(phase.StartedAt).Milliseconds()
+	phase.Status = StatusReady
+	if err != nil {
+		phase.Status = StatusFailed
+		phase.Error = err.Error()
+	}
+	return *phase
+}
@@ -0,0 +1,65 @@
+package startup
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTrackerMeasuresSyncAndAsyncPhases(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		ready []Phase
+	)
+	tracker := NewTracker(func(phase Phase) {
+		mu.Lock()
+		defer mu.Unlock()
+		ready = append(ready, phase)
+	})
+
+	done := tracker.Begin("database")
+	done(nil)
+
+	release := make(chan struct{})
+	tracker.Go("docker", func() error {
+		<-release
+		return errors.New("daemon not reachable")
+	})
+	tracker.Go("signaling", func() error { return nil })
+	tracker.MarkWindowReady()
+
+	// Docker ainda está subindo depois que a janela abriu.
+	if tracker.Wait(10 * time.Millisecond) {
+		t.Fatalf("Wait() should time out while docker is pending")
+	}
+	timings := tracker.Timings()
+	if len(timings.Pending) != 1 || timings.Pending[0] != "docker" {
+		t.Fatalf("expected docker to be pending, got %+v", timings.Pending)
+	}
+
+	close(release)
+	if !tracker.Wait(time.Second) {
+		t.Fatalf("async phases did not finish")
+	}
+	timings = tracker.Timings()
+	if len(timings.Pending) != 0 || len(timings.Phases) != 3 {
+		t.Fatalf("unexpected timings: %+v", timings)
+	}
+	byName := map[string]Phase{}
+	for _, phase := range timings.Phases {
+		byName[phase.Name] = phase
+	}
+	if byName["database"].Async || byName["database"].Status != StatusReady {
+		t.Fatalf("unexpected database phase: %+v", byName["database"])
+	}
+	if !byName["docker"].Async || byName["docker"].Status != StatusFailed || byName["docker"].Error != "daemon not reachable" {
+		t.Fatalf("unexpected docker phase: %+v", byName["docker"])
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(ready) != 2 {
+		t.Fatalf("expected a readiness notification per async phase, got %+v", ready)
+	}
+}
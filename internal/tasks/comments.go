@@ -0,0 +1,91 @@
+package tasks
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxScanFileSize   = 1 << 20 // arquivos maiores (bundles, dumps) não são varridos
+	binarySniffBytes  = 8000
+	maxCommentText    = 300
+	maxCommentsInFile = 500
+)
+
+// Comment é um TODO/FIXME encontrado num comentário do código.
+type Comment struct {
+	Key    string `json:"key"`  // estável enquanto arquivo, marcador e texto não mudam (independe da linha)
+	Kind   string `json:"kind"` // TODO | FIXME | HACK | XXX
+	Author string `json:"author,omitempty"`
+	Text   string `json:"text"`
+	Path   string `json:"path"`   // relativo à raiz do workspace, com "/"
+	Line   int    `json:"line"`   // 1-based
+	Column int    `json:"column"` // 1-based, em runes, do marcador
+}
+
+// O marcador precisa vir logo depois de um início de comentário (//, #, /*, *, --, ;, <!--).
+var commentTaskRegex = regexp.MustCompile(`(?:^|\s)(?://+|#+|/\*+|\*|--|;+|<!--)\s*(TODO|FIXME|HACK|XXX)\b(?:\(([^)]{1,64})\))?\s*[:\-]?\s*(.*)$`)
+
+// ParseComments extrai os TODO/FIXME de um arquivo. Binários e arquivos grandes retornam nil.
+func ParseComments(path string, content []byte) []Comment {
+	if len(content) > maxScanFileSize || bytes.IndexByte(content[:min(len(content), binarySniffBytes)], 0) >= 0 {
+		return nil
+	}
+	if !bytes.Contains(content, []byte("TODO")) && !bytes.Contains(content, []byte("FIXME")) &&
+		!bytes.Contains(content, []byte("HACK")) && !bytes.Contains(content, []byte("XXX")) {
+		return nil
+	}
+
+	var comments []Comment
+	occurrences := make(map[string]int)
+	for i, line := range strings.Split(string(content), "\n") {
+		m := commentTaskRegex.FindStringSubmatchIndex(line)
+		if m == nil {
+			continue
+		}
+		kind := line[m[2]:m[3]]
+		author := ""
+		if m[4] >= 0 {
+			author = strings.TrimSpace(line[m[4]:m[5]])
+		}
+		text := cleanCommentText(line[m[6]:m[7]])
+
+		base := path + "\n" + kind + "\n" + text
+		occurrences[base]++
+		if n := occurrences[base]; n > 1 {
+			base += "\n" + strconv.Itoa(n)
+		}
+		sum := sha1.Sum([]byte(base))
+
+		comments = append(comments, Comment{
+			Key:    hex.EncodeToString(sum[:8]),
+			Kind:   kind,
+			Author: author,
+			Text:   text,
+			Path:   path,
+			Line:   i + 1,
+			Column: utf8.RuneCountInString(line[:m[2]]) + 1,
+		})
+		if len(comments) == maxCommentsInFile {
+			break
+		}
+	}
+	return comments
+}
+
+// cleanCommentText tira o fechamento do comentário e limita o tamanho do texto.
+func cleanCommentText(text string) string {
+	text = strings.TrimSpace(strings.TrimRight(text, "\r"))
+	for _, suffix := range []string{"*/", "-->"} {
+		text = strings.TrimSpace(strings.TrimSuffix(text, suffix))
+	}
+	if utf8.RuneCountInString(text) > maxCommentText {
+		text = string([]rune(text)[:maxCommentText]) + "…"
+	}
+	return text
+}
@@ -0,0 +1,220 @@
+package tasks
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	fw "orch/internal/filewatcher"
+)
+
+const (
+	// MaxComments limita os TODOs guardados por workspace; acima disso a lista é truncada.
+	MaxComments = 10000
+	// defaultStaleAfter revarre workspaces que não recebem atualizações do file watcher.
+	defaultStaleAfter = 30 * time.Second
+)
+
+// Lister lista os arquivos do workspace (relativos à raiz, com "/") e se a lista foi truncada.
+type Lister func(workspaceID uint, root string) ([]string, bool, error)
+
+// Index guarda os TODO/FIXME de cada workspace. A primeira leitura varre todos os arquivos;
+// depois os lotes do file watcher revarrem só os arquivos alterados.
+type Index struct {
+	mu         sync.Mutex
+	workspaces map[uint]*workspaceIndex
+	live       map[uint]bool
+	list       Lister
+	staleAfter time.Duration
+}
+
+type workspaceIndex struct {
+	buildMu sync.Mutex
+
+	mu        sync.RWMutex
+	root      string
+	files     map[string][]Comment // só arquivos com ao menos um TODO
+	truncated bool
+	builtAt   time.Time
+	dirty     bool
+	changes   uint64 // lotes aplicados (detecta mudanças durante a varredura)
+}
+
+// NewIndex cria o índice; list fornece os arquivos a varrer (ex: o índice do quick-open).
+func NewIndex(list Lister) *Index {
+	return &Index{
+		workspaces: make(map[uint]*workspaceIndex),
+		live:       make(map[uint]bool),
+		list:       list,
+		staleAfter: defaultStaleAfter,
+	}
+}
+
+// Comments retorna os TODOs do workspace ordenados por arquivo e linha, e se a lista foi truncada.
+func (x *Index) Comments(workspaceID uint, root string) ([]Comment, bool, error) {
+	idx, err := x.ensure(workspaceID, root)
+	if err != nil {
+		return nil, false, err
+	}
+	idx.mu.RLock()
+	comments := make([]Comment, 0, len(idx.files))
+	for _, fileComments := range idx.files {
+		comments = append(comments, fileComments...)
+	}
+	truncated := idx.truncated
+	idx.mu.RUnlock()
+
+	sort.Slice(comments, func(i, j int) bool {
+		if comments[i].Path != comments[j].Path {
+			return comments[i].Path < comments[j].Path
+		}
+		return comments[i].Line < comments[j].Line
+	})
+	if len(comments) > MaxComments {
+		comments, truncated = comments[:MaxComments], true
+	}
+	return comments, truncated, nil
+}
+
+// SetLiveUpdates informa se o workspace recebe eventos do file watcher. Sem eles a lista é
+// revarrida quando fica velha; ao ligar, a próxima leitura revarre uma vez.
+func (x *Index) SetLiveUpdates(workspaceID uint, live bool) {
+	x.mu.Lock()
+	idx, wasLive := x.workspaces[workspaceID], x.live[workspaceID]
+	x.live[workspaceID] = live
+	x.mu.Unlock()
+	if idx != nil && live && !wasLive {
+		idx.mu.Lock()
+		idx.dirty = true
+		idx.mu.Unlock()
+	}
+}
+
+// ApplyChange revarre os arquivos de um lote workspace:file_changed do file watcher.
+func (x *Index) ApplyChange(change fw.WorkspaceFileChange) {
+	x.mu.Lock()
+	idx := x.workspaces[change.WorkspaceID]
+	x.mu.Unlock()
+	if idx == nil || idx.root != filepath.Clean(change.Root) {
+		return
+	}
+	if change.Truncated {
+		idx.mu.Lock()
+		idx.changes++
+		idx.dirty = true
+		idx.mu.Unlock()
+		return
+	}
+
+	// Leitura fora do lock: o lote pode ter muitos arquivos.
+	parsed := make(map[string][]Comment, len(change.Paths))
+	removed := make([]string, 0)
+	needsRescan := false
+	for _, rel := range change.Paths {
+		abs := filepath.Join(idx.root, filepath.FromSlash(rel))
+		info, err := os.Lstat(abs)
+		switch {
+		case err != nil:
+			removed = append(removed, rel)
+		case info.IsDir():
+			needsRescan = true
+		case info.Mode().IsRegular() && info.Size() <= maxScanFileSize:
+			if content, readErr := os.ReadFile(abs); readErr == nil {
+				parsed[rel] = ParseComments(rel, content)
+			}
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.changes++
+	if needsRescan {
+		idx.dirty = true
+	}
+	for _, rel := range removed {
+		// Removido ou renomeado: pode ser um arquivo ou um diretório inteiro.
+		delete(idx.files, rel)
+		prefix := rel + "/"
+		for file := range idx.files {
+			if strings.HasPrefix(file, prefix) {
+				delete(idx.files, file)
+			}
+		}
+	}
+	for rel, comments := range parsed {
+		if len(comments) == 0 {
+			delete(idx.files, rel)
+			continue
+		}
+		idx.files[rel] = comments
+	}
+}
+
+// Drop descarta o índice do workspace.
+func (x *Index) Drop(workspaceID uint) {
+	x.mu.Lock()
+	delete(x.workspaces, workspaceID)
+	delete(x.live, workspaceID)
+	x.mu.Unlock()
+}
+
+func (x *Index) ensure(workspaceID uint, root string) (*workspaceIndex, error) {
+	root = filepath.Clean(root)
+	x.mu.Lock()
+	idx := x.workspaces[workspaceID]
+	if idx == nil || idx.root != root {
+		idx = &workspaceIndex{root: root, files: make(map[string][]Comment), dirty: true}
+		x.workspaces[workspaceID] = idx
+	}
+	live, staleAfter := x.live[workspaceID], x.staleAfter
+	x.mu.Unlock()
+
+	idx.buildMu.Lock()
+	defer idx.buildMu.Unlock()
+
+	idx.mu.RLock()
+	needsBuild := idx.dirty || (!live && time.Since(idx.builtAt) > staleAfter)
+	generation := idx.changes
+	idx.mu.RUnlock()
+	if !needsBuild {
+		return idx, nil
+	}
+
+	paths, truncated, err := x.list(workspaceID, root)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]Comment)
+	total := 0
+	for _, rel := range paths {
+		abs := filepath.Join(root, filepath.FromSlash(rel))
+		info, err := os.Stat(abs)
+		if err != nil || !info.Mode().IsRegular() || info.Size() > maxScanFileSize {
+			continue
+		}
+		content, err := os.ReadFile(abs)
+		if err != nil {
+			continue
+		}
+		if comments := ParseComments(rel, content); len(comments) > 0 {
+			files[rel] = comments
+			total += len(comments)
+		}
+		if total >= MaxComments {
+			truncated = true
+			break
+		}
+	}
+
+	idx.mu.Lock()
+	idx.files = files
+	idx.truncated = truncated
+	idx.builtAt = time.Now()
+	// Mudanças durante a varredura podem ter sido lidas antes de acontecer.
+	idx.dirty = idx.changes != generation
+	idx.mu.Unlock()
+	return idx, nil
+}
@@ -0,0 +1,95 @@
+package tasks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	fw "orch/internal/filewatcher"
+)
+
+func TestParseCommentsRecognizesCommentMarkers(t *testing.T) {
+	content := []byte(`package main
+
+// TODO: handle retries
+func run() {
+	x := "TODO: not a comment"
+	/* FIXME(ana) close the file */
+}
+# HACK - temporary workaround
+	// TODO: handle retries
+`)
+	comments := ParseComments("cmd/main.go", content)
+	if len(comments) != 4 {
+		t.Fatalf("expected 4 comments, got %+v", comments)
+	}
+	if comments[0].Kind != "TODO" || comments[0].Text != "handle retries" || comments[0].Line != 3 || comments[0].Column != 4 {
+		t.Fatalf("unexpected first comment: %+v", comments[0])
+	}
+	if comments[1].Kind != "FIXME" || comments[1].Author != "ana" || comments[1].Text != "close the file" {
+		t.Fatalf("unexpected FIXME: %+v", comments[1])
+	}
+	if comments[2].Kind != "HACK" || comments[2].Text != "temporary workaround" {
+		t.Fatalf("unexpected HACK: %+v", comments[2])
+	}
+	if comments[0].Key == comments[3].Key {
+		t.Fatalf("repeated TODOs in the same file need distinct keys")
+	}
+
+	moved := ParseComments("cmd/main.go", append([]byte("\n\n"), content...))
+	if moved[0].Key != comments[0].Key || moved[0].Line != 5 {
+		t.Fatalf("key must not depend on the line: %+v vs %+v", moved[0], comments[0])
+	}
+	if ParseComments("bin/tool", []byte("// TODO\x00binary")) != nil {
+		t.Fatalf("binary files must be skipped")
+	}
+}
+
+func TestIndexScansAndAppliesWatcherChanges(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+	write("a.go", "// TODO: first\n")
+	write("pkg/b.py", "x = 1  # FIXME: second\n")
+	write("c.txt", "nothing here\n")
+
+	lists := 0
+	index := NewIndex(func(uint, string) ([]string, bool, error) {
+		lists++
+		return []string{"a.go", "c.txt", "pkg/b.py"}, false, nil
+	})
+	index.SetLiveUpdates(1, true)
+
+	comments, _, err := index.Comments(1, root)
+	if err != nil || len(comments) != 2 || comments[0].Path != "a.go" || comments[1].Path != "pkg/b.py" {
+		t.Fatalf("unexpected initial scan: %+v (%v)", comments, err)
+	}
+
+	write("a.go", "// nothing left\n")
+	write("c.txt", "-- TODO: new one\n")
+	if err := os.RemoveAll(filepath.Join(root, "pkg")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	index.ApplyChange(fw.WorkspaceFileChange{WorkspaceID: 1, Root: root, Paths: []string{"a.go", "c.txt", "pkg"}})
+
+	comments, _, _ = index.Comments(1, root)
+	if len(comments) != 1 || comments[0].Path != "c.txt" || comments[0].Text != "new one" {
+		t.Fatalf("unexpected comments after change: %+v", comments)
+	}
+	if lists != 1 {
+		t.Fatalf("incremental changes must not trigger a full rescan, got %d scans", lists)
+	}
+
+	index.ApplyChange(fw.WorkspaceFileChange{WorkspaceID: 1, Root: root, Truncated: true})
+	index.Comments(1, root)
+	if lists != 2 {
+		t.Fatalf("truncated batch must trigger a rescan, got %d scans", lists)
+	}
+}
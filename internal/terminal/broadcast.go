@@ -0,0 +1,220 @@
+package terminal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BroadcastMember é um terminal dentro de um grupo de broadcast. Membros desabilitados não
+// recebem o input dos outros nem repassam o próprio.
+type BroadcastMember struct {
+	SessionID string `json:"sessionID"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// BroadcastGroup agrupa terminais que recebem o mesmo input ("digite uma vez, rode em todos").
+type BroadcastGroup struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Members   []BroadcastMember `json:"members"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+// broadcastGroup é a representação interna (protegida por PTYManager.mu).
+type broadcastGroup struct {
+	id        string
+	name      string
+	members   []string        // ordem de inclusão
+	enabled   map[string]bool // sessionID -> recebe/repassa input
+	createdAt time.Time
+}
+
+func (g *broadcastGroup) snapshot() BroadcastGroup {
+	members := make([]BroadcastMember, 0, len(g.members))
+	for _, sessionID := range g.members {
+		members = append(members, BroadcastMember{SessionID: sessionID, Enabled: g.enabled[sessionID]})
+	}
+	return BroadcastGroup{ID: g.id, Name: g.name, Members: members, CreatedAt: g.createdAt}
+}
+
+// CreateBroadcastGroup cria um grupo com os terminais informados, todos habilitados. Cada
+// terminal pertence a no máximo um grupo: quem já estava em outro grupo é movido.
+func (m *PTYManager) CreateBroadcastGroup(name string, sessionIDs []string) (BroadcastGroup, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	group := &broadcastGroup{
+		id:        uuid.NewString(),
+		name:      strings.TrimSpace(name),
+		enabled:   make(map[string]bool),
+		createdAt: time.Now(),
+	}
+	for _, sessionID := range sessionIDs {
+		sessionID = strings.TrimSpace(sessionID)
+		if sessionID == "" || group.enabled[sessionID] {
+			continue
+		}
+		if _, exists := m.sessions[sessionID]; !exists {
+			return BroadcastGroup{}, fmt.Errorf("session %s not found", sessionID)
+		}
+		group.members = append(group.members, sessionID)
+		group.enabled[sessionID] = true
+	}
+	if len(group.members) < 2 {
+		return BroadcastGroup{}, fmt.Errorf("a broadcast group needs at least two terminals")
+	}
+	if group.name == "" {
+		group.name = fmt.Sprintf("Broadcast (%d)", len(group.members))
+	}
+
+	if m.groups == nil {
+		m.groups = make(map[string]*broadcastGroup)
+	}
+	for _, sessionID := range group.members {
+		m.leaveBroadcastGroupLocked(sessionID)
+	}
+	m.groups[group.id] = group
+	return group.snapshot(), nil
+}
+
+// AddToBroadcastGroup inclui (habilitado) um terminal no grupo.
+func (m *PTYManager) AddToBroadcastGroup(groupID, sessionID string) (BroadcastGroup, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	group, exists := m.groups[groupID]
+	if !exists {
+		return BroadcastGroup{}, fmt.Errorf("broadcast group %s not found", groupID)
+	}
+	if _, exists := m.sessions[sessionID]; !exists {
+		return BroadcastGroup{}, fmt.Errorf("session %s not found", sessionID)
+	}
+	if _, member := group.enabled[sessionID]; !member {
+		m.leaveBroadcastGroupLocked(sessionID)
+		group.members = append(group.members, sessionID)
+	}
+	group.enabled[sessionID] = true
+	return group.snapshot(), nil
+}
+
+// RemoveFromBroadcastGroup tira o terminal do grupo em que estiver; grupos com menos de dois
+// membros deixam de existir.
+func (m *PTYManager) RemoveFromBroadcastGroup(sessionID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.leaveBroadcastGroupLocked(sessionID)
+}
+
+// SetBroadcastEnabled liga ou desliga o broadcast para um terminal sem tirá-lo do grupo.
+func (m *PTYManager) SetBroadcastEnabled(sessionID string, enabled bool) (BroadcastGroup, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	group := m.groupOfLocked(sessionID)
+	if group == nil {
+		return BroadcastGroup{}, fmt.Errorf("session %s is not in a broadcast group", sessionID)
+	}
+	group.enabled[sessionID] = enabled
+	return group.snapshot(), nil
+}
+
+// DeleteBroadcastGroup desfaz o grupo; os terminais continuam abertos.
+func (m *PTYManager) DeleteBroadcastGroup(groupID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.groups[groupID]; !exists {
+		return fmt.Errorf("broadcast group %s not found", groupID)
+	}
+	delete(m.groups, groupID)
+	return nil
+}
+
+// GetBroadcastGroups lista os grupos ativos, do mais antigo ao mais novo.
+func (m *PTYManager) GetBroadcastGroups() []BroadcastGroup {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	groups := make([]BroadcastGroup, 0, len(m.groups))
+	for _, group := range m.groups {
+		groups = append(groups, group.snapshot())
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].CreatedAt.Equal(groups[j].CreatedAt) {
+			return groups[i].ID < groups[j].ID
+		}
+		return groups[i].CreatedAt.Before(groups[j].CreatedAt)
+	})
+	return groups
+}
+
+// BroadcastGroupOf devolve o grupo do terminal, se houver.
+func (m *PTYManager) BroadcastGroupOf(sessionID string) (BroadcastGroup, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	group := m.groupOfLocked(sessionID)
+	if group == nil {
+		return BroadcastGroup{}, false
+	}
+	return group.snapshot(), true
+}
+
+// BroadcastTargets devolve os terminais que devem receber o input digitado em sessionID: ele
+// próprio primeiro e, se estiver habilitado num grupo, os demais membros habilitados e vivos.
+func (m *PTYManager) BroadcastTargets(sessionID string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	targets := []string{sessionID}
+	group := m.groupOfLocked(sessionID)
+	if group == nil || !group.enabled[sessionID] {
+		return targets
+	}
+	for _, memberID := range group.members {
+		if memberID == sessionID || !group.enabled[memberID] {
+			continue
+		}
+		if session, exists := m.sessions[memberID]; exists && session.alive() {
+			targets = append(targets, memberID)
+		}
+	}
+	return targets
+}
+
+func (m *PTYManager) groupOfLocked(sessionID string) *broadcastGroup {
+	for _, group := range m.groups {
+		if _, member := group.enabled[sessionID]; member {
+			return group
+		}
+	}
+	return nil
+}
+
+func (m *PTYManager) leaveBroadcastGroupLocked(sessionID string) {
+	group := m.groupOfLocked(sessionID)
+	if group == nil {
+		return
+	}
+	delete(group.enabled, sessionID)
+	members := group.members[:0]
+	for _, memberID := range group.members {
+		if memberID != sessionID {
+			members = append(members, memberID)
+		}
+	}
+	group.members = members
+	if len(group.members) < 2 {
+		delete(m.groups, group.id)
+	}
+}
+
+func (s *PTYSession) alive() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.IsAlive
+}
@@ -0,0 +1,133 @@
+package terminal
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+// addPipeSession registra uma sessão falsa cujo "PTY" é a ponta de escrita de um pipe.
+func addPipeSession(t *testing.T, m *PTYManager, id string) *os.File {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = reader.Close()
+		_ = writer.Close()
+	})
+	m.mu.Lock()
+	m.sessions[id] = &PTYSession{ID: id, IsAlive: true, pty: writer}
+	m.mu.Unlock()
+	return reader
+}
+
+func TestBroadcastTargetsHonorMembershipAndEnabledFlag(t *testing.T) {
+	m := NewPTYManager()
+	for _, id := range []string{"api", "web", "worker", "solo"} {
+		addPipeSession(t, m, id)
+	}
+
+	if _, err := m.CreateBroadcastGroup("", []string{"api"}); err == nil {
+		t.Fatalf("expected single-terminal group to be rejected")
+	}
+	if _, err := m.CreateBroadcastGroup("", []string{"api", "ghost"}); err == nil {
+		t.Fatalf("expected unknown terminal to be rejected")
+	}
+	group, err := m.CreateBroadcastGroup("services", []string{"api", "web", "web", "worker"})
+	if err != nil {
+		t.Fatalf("CreateBroadcastGroup() error: %v", err)
+	}
+	if group.Name != "services" || len(group.Members) != 3 {
+		t.Fatalf("unexpected group: %+v", group)
+	}
+
+	if got := m.BroadcastTargets("web"); !reflect.DeepEqual(got, []string{"web", "api", "worker"}) {
+		t.Fatalf("BroadcastTargets(web) = %v", got)
+	}
+	if got := m.BroadcastTargets("solo"); !reflect.DeepEqual(got, []string{"solo"}) {
+		t.Fatalf("BroadcastTargets(solo) = %v", got)
+	}
+
+	// Membro desabilitado nem recebe nem repassa.
+	if _, err := m.SetBroadcastEnabled("worker", false); err != nil {
+		t.Fatalf("SetBroadcastEnabled() error: %v", err)
+	}
+	if got := m.BroadcastTargets("api"); !reflect.DeepEqual(got, []string{"api", "web"}) {
+		t.Fatalf("BroadcastTargets(api) with worker disabled = %v", got)
+	}
+	if got := m.BroadcastTargets("worker"); !reflect.DeepEqual(got, []string{"worker"}) {
+		t.Fatalf("BroadcastTargets(worker) while disabled = %v", got)
+	}
+
+	// Terminais mortos são pulados.
+	m.sessions["web"].IsAlive = false
+	if got := m.BroadcastTargets("api"); !reflect.DeepEqual(got, []string{"api"}) {
+		t.Fatalf("BroadcastTargets(api) with web dead = %v", got)
+	}
+}
+
+func TestBroadcastGroupMembershipChanges(t *testing.T) {
+	m := NewPTYManager()
+	for _, id := range []string{"a", "b", "c", "d"} {
+		addPipeSession(t, m, id)
+	}
+	first, _ := m.CreateBroadcastGroup("first", []string{"a", "b", "c"})
+	second, err := m.CreateBroadcastGroup("second", []string{"c", "d"})
+	if err != nil {
+		t.Fatalf("CreateBroadcastGroup(second) error: %v", err)
+	}
+	if group, _ := m.BroadcastGroupOf("c"); group.ID != second.ID {
+		t.Fatalf("expected c to move to the second group, got %+v", group)
+	}
+	if group, _ := m.BroadcastGroupOf("a"); len(group.Members) != 2 {
+		t.Fatalf("expected first group to keep a and b, got %+v", group)
+	}
+
+	if _, err := m.AddToBroadcastGroup(first.ID, "d"); err != nil {
+		t.Fatalf("AddToBroadcastGroup() error: %v", err)
+	}
+	// "second" ficou só com "c" e deixou de existir.
+	if groups := m.GetBroadcastGroups(); len(groups) != 1 || groups[0].ID != first.ID {
+		t.Fatalf("unexpected groups after move: %+v", groups)
+	}
+
+	if err := m.Destroy("a"); err != nil {
+		t.Fatalf("Destroy() error: %v", err)
+	}
+	if group, ok := m.BroadcastGroupOf("b"); !ok || len(group.Members) != 2 {
+		t.Fatalf("expected destroyed terminal to leave the group, got %+v", group)
+	}
+	m.RemoveFromBroadcastGroup("b")
+	if len(m.GetBroadcastGroups()) != 0 {
+		t.Fatalf("expected group with one member to be dissolved")
+	}
+	if err := m.DeleteBroadcastGroup(first.ID); err == nil {
+		t.Fatalf("expected deleting a dissolved group to fail")
+	}
+}
+
+func TestBroadcastFanOutWritesToEveryTarget(t *testing.T) {
+	m := NewPTYManager()
+	readers := map[string]*os.File{}
+	for _, id := range []string{"left", "right"} {
+		readers[id] = addPipeSession(t, m, id)
+	}
+	if _, err := m.CreateBroadcastGroup("", []string{"left", "right"}); err != nil {
+		t.Fatalf("CreateBroadcastGroup() error: %v", err)
+	}
+
+	for _, target := range m.BroadcastTargets("left") {
+		if err := m.Write(target, []byte("ls\n")); err != nil {
+			t.Fatalf("Write(%s) error: %v", target, err)
+		}
+	}
+	for id, reader := range readers {
+		buf := make([]byte, 16)
+		n, err := reader.Read(buf)
+		if err != nil || string(buf[:n]) != "ls\n" {
+			t.Fatalf("terminal %s got %q (%v)", id, buf[:n], err)
+		}
+	}
+}
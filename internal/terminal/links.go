@@ -0,0 +1,250 @@
+package terminal
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Tipos de link reconhecidos na saída do terminal.
+const (
+	LinkFile      = "file"      // caminho com linha (main.go:12:5, src/a.ts(3,7), File "x.py", line 9)
+	LinkGitHub    = "github"    // URL do github.com
+	LinkLocalhost = "localhost" // servidor local (localhost:3000, 127.0.0.1:8080)
+)
+
+// Ações sugeridas para o clique no link.
+const (
+	LinkActionOpenEditor  = "open_editor"
+	LinkActionOpenPR      = "open_pr"
+	LinkActionOpenBrowser = "open_browser"
+)
+
+const (
+	maxLinksPerChunk  = 64
+	maxLinkLineLength = 4096
+)
+
+// OutputLink é um trecho clicável da saída. Text é o trecho exatamente como aparece na tela
+// (sem sequências ANSI), para o frontend localizá-lo no buffer do xterm.
+type OutputLink struct {
+	Kind   string `json:"kind"`
+	Action string `json:"action"`
+	Text   string `json:"text"`
+	Path   string `json:"path,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Owner  string `json:"owner,omitempty"`
+	Repo   string `json:"repo,omitempty"`
+	Number int    `json:"number,omitempty"`
+	Port   int    `json:"port,omitempty"`
+}
+
+var (
+	githubURLRegex    = regexp.MustCompile(`https?://(?:www\.)?github\.com/[\w.-]+/[\w.-]+(?:/[^\s<>"'\x60)\]]*)?`)
+	githubPRPathRegex = regexp.MustCompile(`^/([\w.-]+)/([\w.-]+)/pull/(\d+)`)
+	localhostRegex    = regexp.MustCompile(`(https?://)?(localhost|127\.0\.0\.1|0\.0\.0\.0|\[::1\]):(\d{2,5})(/[^\s<>"'\x60)\]]*)?`)
+	genericURLRegex   = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S+`)
+
+	// Caminho com extensão seguido de :linha[:coluna] ou (linha,coluna), no início da linha ou
+	// depois de espaço/aspas/parênteses.
+	fileRefRegex   = regexp.MustCompile(`(?:^|[\s('"\x60\[])((?:~|\.{1,2})?/?(?:[\w@+-][\w@.+-]*/)*[\w@+-][\w@.+-]*\.[A-Za-z][\w]{0,9})(?::(\d+)(?::(\d+))?|\((\d+),(\d+)\))`)
+	pythonRefRegex = regexp.MustCompile(`File "([^"]+)", line (\d+)`)
+)
+
+// LinkExtractor acumula a saída de cada sessão até fechar a linha e extrai os links das
+// linhas completas, para que um link partido entre dois chunks não se perca.
+type LinkExtractor struct {
+	mu      sync.Mutex
+	pending map[string]string
+}
+
+// NewLinkExtractor cria um extrator sem estado.
+func NewLinkExtractor() *LinkExtractor {
+	return &LinkExtractor{pending: make(map[string]string)}
+}
+
+// Observe processa um chunk de saída e retorna os links das linhas concluídas nele.
+func (e *LinkExtractor) Observe(sessionID string, data []byte) []OutputLink {
+	if sessionID == "" || len(data) == 0 {
+		return nil
+	}
+	e.mu.Lock()
+	text := e.pending[sessionID] + strings.ReplaceAll(string(data), "\r", "\n")
+	lines := strings.Split(text, "\n")
+	pending := lines[len(lines)-1]
+	if len(pending) > maxLinkLineLength {
+		pending = pending[len(pending)-maxLinkLineLength:]
+	}
+	e.pending[sessionID] = pending
+	e.mu.Unlock()
+
+	var links []OutputLink
+	seen := make(map[string]struct{})
+	for _, line := range lines[:len(lines)-1] {
+		for _, link := range ExtractLinks(line) {
+			key := link.Kind + "\x00" + link.Text
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			links = append(links, link)
+			if len(links) == maxLinksPerChunk {
+				return links
+			}
+		}
+	}
+	return links
+}
+
+// Forget descarta a linha parcial guardada da sessão encerrada.
+func (e *LinkExtractor) Forget(sessionID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.pending, sessionID)
+}
+
+// ExtractLinks retorna os links de uma linha de saída, na ordem em que aparecem.
+func ExtractLinks(raw string) []OutputLink {
+	line := ansiEscapeRegex.ReplaceAllString(raw, "")
+	if len(line) > maxLinkLineLength {
+		line = line[:maxLinkLineLength]
+	}
+	if strings.TrimSpace(line) == "" {
+		return nil
+	}
+
+	type span struct {
+		start, end int
+		link       OutputLink
+	}
+	var spans []span
+	overlaps := func(start, end int) bool {
+		for _, s := range spans {
+			if start < s.end && end > s.start {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, loc := range githubURLRegex.FindAllStringIndex(line, -1) {
+		text := trimURLPunctuation(line[loc[0]:loc[1]])
+		spans = append(spans, span{loc[0], loc[0] + len(text), githubLink(text)})
+	}
+	for _, m := range localhostRegex.FindAllStringSubmatchIndex(line, -1) {
+		if overlaps(m[0], m[1]) {
+			continue
+		}
+		if link, ok := localhostLink(line, m); ok {
+			spans = append(spans, span{m[0], m[0] + len(link.Text), link})
+		}
+	}
+	// Outras URLs não viram link aqui, mas também não podem gerar falsos caminhos de arquivo.
+	var urls [][]int
+	for _, loc := range genericURLRegex.FindAllStringIndex(line, -1) {
+		urls = append(urls, loc)
+	}
+	insideURL := func(start, end int) bool {
+		for _, loc := range urls {
+			if start < loc[1] && end > loc[0] {
+				return true
+			}
+		}
+		return overlaps(start, end)
+	}
+
+	for _, m := range pythonRefRegex.FindAllStringSubmatchIndex(line, -1) {
+		lineNumber, _ := strconv.Atoi(line[m[4]:m[5]])
+		spans = append(spans, span{m[0], m[1], OutputLink{
+			Kind:   LinkFile,
+			Action: LinkActionOpenEditor,
+			Text:   line[m[0]:m[1]],
+			Path:   line[m[2]:m[3]],
+			Line:   lineNumber,
+		}})
+	}
+	for _, m := range fileRefRegex.FindAllStringSubmatchIndex(line, -1) {
+		start, end := m[2], m[1]
+		if insideURL(start, end) {
+			continue
+		}
+		link := OutputLink{Kind: LinkFile, Action: LinkActionOpenEditor, Text: line[start:end], Path: line[m[2]:m[3]]}
+		if m[4] >= 0 {
+			link.Line, _ = strconv.Atoi(line[m[4]:m[5]])
+			if m[6] >= 0 {
+				link.Column, _ = strconv.Atoi(line[m[6]:m[7]])
+			}
+		} else {
+			link.Line, _ = strconv.Atoi(line[m[8]:m[9]])
+			link.Column, _ = strconv.Atoi(line[m[10]:m[11]])
+		}
+		if link.Line <= 0 {
+			continue
+		}
+		spans = append(spans, span{start, end, link})
+	}
+
+	// Ordem de aparição na linha.
+	for i := 1; i < len(spans); i++ {
+		for j := i; j > 0 && spans[j].start < spans[j-1].start; j-- {
+			spans[j], spans[j-1] = spans[j-1], spans[j]
+		}
+	}
+	links := make([]OutputLink, 0, len(spans))
+	for _, s := range spans {
+		links = append(links, s.link)
+	}
+	return links
+}
+
+func githubLink(text string) OutputLink {
+	link := OutputLink{Kind: LinkGitHub, Action: LinkActionOpenBrowser, Text: text, URL: text}
+	path := text[strings.Index(text, "github.com")+len("github.com"):]
+	if m := githubPRPathRegex.FindStringSubmatch(path); m != nil {
+		if number, err := strconv.Atoi(m[3]); err == nil && number > 0 {
+			link.Action = LinkActionOpenPR
+			link.Owner, link.Repo, link.Number = m[1], strings.TrimSuffix(m[2], ".git"), number
+		}
+	}
+	return link
+}
+
+func localhostLink(line string, m []int) (OutputLink, bool) {
+	port, err := strconv.Atoi(line[m[6]:m[7]])
+	if err != nil || port <= 0 || port > 65535 {
+		return OutputLink{}, false
+	}
+	// Uma porta grudada em mais dígitos/letras (localhost:3000abc) não é um endereço.
+	if m[7] < len(line) && m[8] < 0 && isWordByte(line[m[7]]) {
+		return OutputLink{}, false
+	}
+	text := trimURLPunctuation(line[m[0]:m[1]])
+	scheme := "http://"
+	if m[2] >= 0 {
+		scheme = line[m[2]:m[3]]
+	}
+	host := line[m[4]:m[5]]
+	if host == "0.0.0.0" {
+		host = "localhost"
+	}
+	rest := strings.TrimPrefix(text, line[m[0]:m[7]])
+	return OutputLink{
+		Kind:   LinkLocalhost,
+		Action: LinkActionOpenBrowser,
+		Text:   text,
+		URL:    scheme + host + ":" + strconv.Itoa(port) + rest,
+		Port:   port,
+	}, true
+}
+
+// trimURLPunctuation remove pontuação de frase que costuma grudar no fim de uma URL.
+func trimURLPunctuation(text string) string {
+	return strings.TrimRight(text, ".,;:!?")
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
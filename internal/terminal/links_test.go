@@ -0,0 +1,73 @@
+package terminal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractLinksRecognizesFilesGitHubAndLocalhost(t *testing.T) {
+	line := "\x1b[31mFAIL\x1b[0m ./internal/app/main.go:12:5: undefined: x, see https://github.com/octo/hello/pull/42. Server on http://localhost:5173/docs"
+	got := ExtractLinks(line)
+	want := []OutputLink{
+		{Kind: LinkFile, Action: LinkActionOpenEditor, Text: "./internal/app/main.go:12:5", Path: "./internal/app/main.go", Line: 12, Column: 5},
+		{Kind: LinkGitHub, Action: LinkActionOpenPR, Text: "https://github.com/octo/hello/pull/42", URL: "https://github.com/octo/hello/pull/42", Owner: "octo", Repo: "hello", Number: 42},
+		{Kind: LinkLocalhost, Action: LinkActionOpenBrowser, Text: "http://localhost:5173/docs", URL: "http://localhost:5173/docs", Port: 5173},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ExtractLinks() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestExtractLinksFileFormats(t *testing.T) {
+	tests := []struct {
+		line   string
+		path   string
+		lineNo int
+		column int
+	}{
+		{"src/app.ts(3,7): error TS2304", "src/app.ts", 3, 7},
+		{`  File "/srv/tool/run.py", line 9, in main`, "/srv/tool/run.py", 9, 0},
+		{"--- FAIL: TestX (0.00s)\n    handler_test.go:88: boom", "handler_test.go", 88, 0},
+	}
+	for _, tt := range tests {
+		links := ExtractLinks(tt.line)
+		if len(links) != 1 || links[0].Path != tt.path || links[0].Line != tt.lineNo || links[0].Column != tt.column {
+			t.Fatalf("ExtractLinks(%q) = %+v", tt.line, links)
+		}
+	}
+}
+
+func TestExtractLinksIgnoresFalsePositives(t *testing.T) {
+	for _, line := range []string{
+		"version 1.2.3:4 released",
+		"https://example.com/path/file.go:12",
+		"listening on localhost:3000abc",
+		"https://github.com/octo/hello/blob/main/main.go:10",
+	} {
+		for _, link := range ExtractLinks(line) {
+			if link.Kind == LinkFile || link.Kind == LinkLocalhost {
+				t.Fatalf("ExtractLinks(%q) returned unexpected link %+v", line, link)
+			}
+		}
+	}
+	if links := ExtractLinks("listening on 0.0.0.0:8080"); len(links) != 1 || links[0].URL != "http://localhost:8080" {
+		t.Fatalf("expected 0.0.0.0 to be opened as localhost, got %+v", links)
+	}
+}
+
+func TestLinkExtractorJoinsChunksAndDeduplicates(t *testing.T) {
+	extractor := NewLinkExtractor()
+	if links := extractor.Observe("s1", []byte("error at pkg/ser")); len(links) != 0 {
+		t.Fatalf("incomplete line must not produce links, got %+v", links)
+	}
+	links := extractor.Observe("s1", []byte("vice.go:7\r\npkg/service.go:7 again\r\n"))
+	if len(links) != 1 || links[0].Path != "pkg/service.go" || links[0].Line != 7 {
+		t.Fatalf("expected one joined, deduplicated link, got %+v", links)
+	}
+
+	extractor.Observe("s2", []byte("open localhost:30"))
+	extractor.Forget("s2")
+	if links := extractor.Observe("s2", []byte("00\n")); len(links) != 0 {
+		t.Fatalf("forgotten session must not keep partial output, got %+v", links)
+	}
+}
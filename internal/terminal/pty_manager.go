@@ -20,6 +20,7 @@ import (
 // PTYManager gerencia múltiplas sessões de terminal PTY
 type PTYManager struct {
 	sessions map[string]*PTYSession
+	groups   map[string]*broadcastGroup // grupos de broadcast de input (protegidos por mu)
 	mu       sync.RWMutex
 	seq      atomic.Uint64
 }
@@ -28,6 +29,7 @@ type PTYManager struct {
 func NewPTYManager() *PTYManager {
 	return &PTYManager{
 		sessions: make(map[string]*PTYSession),
+		groups:   make(map[string]*broadcastGroup),
 	}
 }
 
@@ -291,6 +293,7 @@ func (m *PTYManager) Destroy(sessionID string) error {
 		return fmt.Errorf("session %s not found", sessionID)
 	}
 	delete(m.sessions, sessionID)
+	m.leaveBroadcastGroupLocked(sessionID)
 	m.mu.Unlock()
 
 	// Fechar o PTY (isso vai encerrar o processo filho)
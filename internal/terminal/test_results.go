@@ -0,0 +1,102 @@
+package terminal
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxFailingTests limita os nomes de testes falhos guardados por execução.
+const maxFailingTests = 50
+
+var (
+	// go test (-v): --- FAIL: TestX (0.00s), inclusive subtestes TestX/caso.
+	goTestResultRegex = regexp.MustCompile(`^--- (PASS|FAIL|SKIP): (\S+)`)
+
+	// jest: "Tests:       1 failed, 2 skipped, 10 passed, 13 total"; vitest: "Tests  1 failed | 3 passed (4)".
+	jestTestsLineRegex = regexp.MustCompile(`^Tests:?\s+(.*\d+ (?:passed|failed|skipped|todo).*)$`)
+	jestFailingRegex   = regexp.MustCompile(`^● (.+ › .+|[^›]+)$`)
+	vitestFailingRegex = regexp.MustCompile(`^(?:FAIL|×|✗)\s+(\S+\s+>\s+.+?)(?:\s+\d+ms)?$`)
+
+	// pytest: "2 failed, 10 passed, 1 skipped in 0.12s" (os "=" já foram removidos).
+	pytestSummaryRegex = regexp.MustCompile(`^(.*\d+ (?:passed|failed|skipped|error|errors|xfailed).*) in [\d.]+s\b`)
+	pytestFailingRegex = regexp.MustCompile(`^(?:FAILED|ERROR) (\S+::\S+)|^(\S+::\S+) (?:FAILED|ERROR)\b`)
+
+	// cargo: "test result: FAILED. 3 passed; 1 failed; 0 ignored; ..." (uma por crate/doc-tests).
+	cargoSummaryRegex = regexp.MustCompile(`^test result: \w+\. (\d+) passed; (\d+) failed; (\d+) ignored`)
+	cargoFailingRegex = regexp.MustCompile(`^test (\S+) \.\.\. FAILED$`)
+
+	testCountRegex = regexp.MustCompile(`(\d+) (passed|failed|skipped|todo|error|errors|xfailed)\b`)
+)
+
+// parseTestResultLine extrai contagens e nomes de testes falhos de uma linha de saída,
+// conforme o runner da execução. Linhas de resumo definem os totais; linhas por teste somam.
+func parseTestResultLine(run *TestRun, line string) {
+	switch run.Framework {
+	case "go":
+		if m := goTestResultRegex.FindStringSubmatch(line); m != nil {
+			switch m[1] {
+			case "PASS":
+				run.Passed++
+			case "FAIL":
+				run.Failed++
+				addFailingTest(run, m[2])
+			case "SKIP":
+				run.Skipped++
+			}
+		}
+	case "jest", "vitest", "npm":
+		if m := jestTestsLineRegex.FindStringSubmatch(line); m != nil {
+			setTestCounts(run, m[1])
+		} else if m := jestFailingRegex.FindStringSubmatch(line); m != nil && !strings.HasPrefix(m[1], "Test suite failed") {
+			addFailingTest(run, m[1])
+		} else if m := vitestFailingRegex.FindStringSubmatch(line); m != nil {
+			addFailingTest(run, m[1])
+		}
+	case "pytest":
+		if m := pytestSummaryRegex.FindStringSubmatch(line); m != nil {
+			setTestCounts(run, m[1])
+		} else if m := pytestFailingRegex.FindStringSubmatch(line); m != nil {
+			addFailingTest(run, m[1]+m[2])
+		}
+	case "cargo":
+		if m := cargoSummaryRegex.FindStringSubmatch(line); m != nil {
+			passed, _ := strconv.Atoi(m[1])
+			failed, _ := strconv.Atoi(m[2])
+			ignored, _ := strconv.Atoi(m[3])
+			run.Passed += passed
+			run.Failed += failed
+			run.Skipped += ignored
+		} else if m := cargoFailingRegex.FindStringSubmatch(line); m != nil {
+			addFailingTest(run, m[1])
+		}
+	}
+}
+
+func setTestCounts(run *TestRun, summary string) {
+	run.Passed, run.Failed, run.Skipped = 0, 0, 0
+	for _, m := range testCountRegex.FindAllStringSubmatch(summary, -1) {
+		count, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "passed":
+			run.Passed += count
+		case "failed", "error", "errors":
+			run.Failed += count
+		case "skipped", "todo", "xfailed":
+			run.Skipped += count
+		}
+	}
+}
+
+func addFailingTest(run *TestRun, name string) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(run.FailingTests) >= maxFailingTests {
+		return
+	}
+	for _, existing := range run.FailingTests {
+		if existing == name {
+			return
+		}
+	}
+	run.FailingTests = append(run.FailingTests, name)
+}
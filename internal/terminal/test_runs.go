@@ -0,0 +1,388 @@
+package terminal
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Estados de uma execução de testes detectada no terminal.
+const (
+	TestRunRunning = "running"
+	TestRunPassed  = "passed"
+	TestRunFailed  = "failed"
+	TestRunUnknown = "unknown" // outro comando começou antes de aparecer um resultado reconhecível
+)
+
+// defaultTestRunSettle é o silêncio na saída, depois de um resultado, que conta como fim da execução.
+const defaultTestRunSettle = 1500 * time.Millisecond
+
+// TestRun é uma execução de testes digitada num terminal. Contagens e testes falhos vêm dos
+// parsers de go test, jest/vitest, pytest e cargo; nos demais runners ficam zerados.
+type TestRun struct {
+	SessionID    string    `json:"sessionId"`
+	WorkspaceID  uint      `json:"workspaceId,omitempty"`
+	AgentID      uint      `json:"agentId,omitempty"`
+	Command      string    `json:"command"`
+	Framework    string    `json:"framework"`
+	Status       string    `json:"status"`
+	Summary      string    `json:"summary,omitempty"` // linha de saída que definiu o status
+	Passed       int       `json:"passed"`
+	Failed       int       `json:"failed"`
+	Skipped      int       `json:"skipped"`
+	FailingTests []string  `json:"failingTests,omitempty"`
+	StartedAt    time.Time `json:"startedAt"`
+	FinishedAt   time.Time `json:"finishedAt,omitempty"`
+}
+
+type testCommand struct {
+	framework string
+	pattern   *regexp.Regexp
+}
+
+// testCommands reconhece o comando já sem variáveis de ambiente/sudo no início.
+var testCommands = []testCommand{
+	{"go", regexp.MustCompile(`^go\s+test\b`)},
+	{"cargo", regexp.MustCompile(`^cargo\s+(test|nextest)\b`)},
+	{"pytest", regexp.MustCompile(`^(pytest|py\.test|python3?\s+-m\s+pytest)\b`)},
+	{"unittest", regexp.MustCompile(`^python3?\s+-m\s+unittest\b`)},
+	{"jest", regexp.MustCompile(`^(npx\s+)?jest\b`)},
+	{"vitest", regexp.MustCompile(`^(npx\s+)?vitest\b`)},
+	{"npm", regexp.MustCompile(`^(npm|pnpm|yarn|bun)\s+(t|test|run\s+test\S*)(\s|$)`)},
+	{"rspec", regexp.MustCompile(`^(bundle\s+exec\s+)?rspec\b`)},
+	{"maven", regexp.MustCompile(`^mvnw?\s+(.*\s)?(test|verify)\b`)},
+	{"gradle", regexp.MustCompile(`^(\./)?gradlew?\s+(.*\s)?(test|check)\b`)},
+	{"dotnet", regexp.MustCompile(`^dotnet\s+test\b`)},
+	{"make", regexp.MustCompile(`^make\s+(.*\s)?(test|check)\b`)},
+}
+
+var (
+	ansiEscapeRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]|\x1b\][^\x07]*\x07`)
+	envPrefixRegex  = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*=\S*\s+)+`)
+
+	// Falha vence sucesso: "ok pkg" de um pacote Go não apaga um FAIL de outro.
+	testFailRegex = regexp.MustCompile(`^(FAIL\b|--- FAIL|test result: FAILED|BUILD FAILURE|FAILED \(|Failed!)|\b[1-9]\d* (failed|failing)\b|Tests:.*\b[1-9]\d* failed|\d+ examples?, [1-9]\d* failures?`)
+	testPassRegex = regexp.MustCompile(`^(ok\s|PASS$|OK$|test result: ok|BUILD SUCCESS|Passed!)|\b\d+ passed\b|Tests:.*\b\d+ passed|\d+ examples?, 0 failures`)
+)
+
+type trackedSession struct {
+	input   strings.Builder
+	pending string
+	run     *TestRun
+	settle  *time.Timer
+	dirty   bool // resultado mudou desde o último aviso de fim
+	emitted bool
+}
+
+// TestRunTracker detecta testes rodados nos terminais pelo comando digitado e o
+// resultado pela saída, guardando as últimas execuções em memória.
+type TestRunTracker struct {
+	mu          sync.Mutex
+	sessions    map[string]*trackedSession
+	runs        []*TestRun
+	lastByAgent map[uint]TestRun
+	maxRuns     int
+	now         func() time.Time
+	settleAfter time.Duration
+
+	resolveWorkspace func(sessionID string) uint
+	resolveAgent     func(sessionID string) uint
+	onFinished       func(TestRun)
+}
+
+// NewTestRunTracker cria o tracker mantendo até maxRuns execuções. resolveWorkspace (opcional)
+// associa a sessão a um workspace quando uma execução começa.
+func NewTestRunTracker(maxRuns int, resolveWorkspace func(sessionID string) uint) *TestRunTracker {
+	if maxRuns <= 0 {
+		maxRuns = 200
+	}
+	return &TestRunTracker{
+		sessions:    make(map[string]*trackedSession),
+		lastByAgent: make(map[uint]TestRun),
+		maxRuns:     maxRuns,
+		now:         time.Now,
+		settleAfter: defaultTestRunSettle,
+
+		resolveWorkspace: resolveWorkspace,
+	}
+}
+
+// SetAgentResolver associa a sessão ao agente quando uma execução começa (para LastRun).
+func (t *TestRunTracker) SetAgentResolver(resolve func(sessionID string) uint) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.resolveAgent = resolve
+}
+
+// SetOnFinished registra o callback de fim de execução. Ele roda quando a saída fica em
+// silêncio depois de um resultado, ou quando outro comando começa; se mais resultados
+// chegarem antes do próximo comando (ex: outro pacote do go test), roda de novo com a
+// execução atualizada.
+func (t *TestRunTracker) SetOnFinished(fn func(TestRun)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onFinished = fn
+}
+
+// LastRun retorna a execução mais recente concluída nos terminais do agente.
+func (t *TestRunTracker) LastRun(agentID uint) (TestRun, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	run, ok := t.lastByAgent[agentID]
+	return run, ok
+}
+
+// ObserveInput acompanha o que é digitado; Enter fecha a linha e, se for um comando de
+// teste, abre uma nova execução (encerrando a anterior da sessão).
+func (t *TestRunTracker) ObserveInput(sessionID string, data []byte) {
+	if sessionID == "" || len(data) == 0 {
+		return
+	}
+	for _, line := range t.collectLines(sessionID, data) {
+		framework, isTest := DetectTestCommand(line)
+		// Workspace e agente são resolvidos fora do lock: os resolvers podem consultar o banco.
+		var workspaceID, agentID uint
+		if isTest && t.resolveWorkspace != nil {
+			workspaceID = t.resolveWorkspace(sessionID)
+		}
+		t.mu.Lock()
+		resolveAgent := t.resolveAgent
+		t.mu.Unlock()
+		if isTest && resolveAgent != nil {
+			agentID = resolveAgent(sessionID)
+		}
+
+		t.mu.Lock()
+		session := t.sessionLocked(sessionID)
+		finished, notify := t.finishLocked(session)
+		if isTest {
+			t.startLocked(session, sessionID, workspaceID, agentID, line, framework)
+		}
+		t.mu.Unlock()
+		notify(finished)
+	}
+}
+
+// ObserveOutput procura linhas de resumo dos test runners na saída da sessão.
+func (t *TestRunTracker) ObserveOutput(sessionID string, data []byte) {
+	if sessionID == "" || len(data) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	session, ok := t.sessions[sessionID]
+	if !ok || session.run == nil {
+		return
+	}
+	text := session.pending + strings.ReplaceAll(string(data), "\r", "\n")
+	lines := strings.Split(text, "\n")
+	// A última parte pode ser uma linha ainda incompleta.
+	session.pending = lines[len(lines)-1]
+	if len(session.pending) > 4096 {
+		session.pending = session.pending[len(session.pending)-4096:]
+	}
+	for _, line := range lines[:len(lines)-1] {
+		t.classifyLineLocked(session, line)
+	}
+	if session.dirty {
+		t.armSettleLocked(sessionID, session)
+	}
+}
+
+// Forget descarta o estado da sessão encerrada; uma execução sem resultado vira "unknown".
+func (t *TestRunTracker) Forget(sessionID string) {
+	t.mu.Lock()
+	session, ok := t.sessions[sessionID]
+	if !ok {
+		t.mu.Unlock()
+		return
+	}
+	finished, notify := t.finishLocked(session)
+	delete(t.sessions, sessionID)
+	t.mu.Unlock()
+	notify(finished)
+}
+
+// Runs retorna as execuções iniciadas desde since (workspaceID 0 = todas), mais antigas primeiro.
+func (t *TestRunTracker) Runs(since time.Time, workspaceID uint) []TestRun {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]TestRun, 0, len(t.runs))
+	for _, run := range t.runs {
+		if run.StartedAt.Before(since) || (workspaceID != 0 && run.WorkspaceID != workspaceID) {
+			continue
+		}
+		result = append(result, *run)
+	}
+	return result
+}
+
+// DetectTestCommand indica se a linha digitada roda testes e qual ferramenta.
+func DetectTestCommand(line string) (string, bool) {
+	command := strings.TrimSpace(line)
+	command = strings.TrimPrefix(command, "sudo ")
+	command = envPrefixRegex.ReplaceAllString(command, "")
+	for _, candidate := range testCommands {
+		if candidate.pattern.MatchString(command) {
+			return candidate.framework, true
+		}
+	}
+	return "", false
+}
+
+func (t *TestRunTracker) sessionLocked(sessionID string) *trackedSession {
+	session, ok := t.sessions[sessionID]
+	if !ok {
+		session = &trackedSession{}
+		t.sessions[sessionID] = session
+	}
+	return session
+}
+
+// collectLines acumula o input da sessão e devolve as linhas concluídas com Enter.
+func (t *TestRunTracker) collectLines(sessionID string, data []byte) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return feedInputLine(&t.sessionLocked(sessionID).input, data)
+}
+
+// feedInputLine aplica o que foi digitado à linha em edição (backspace, Ctrl+C) e devolve as
+// linhas fechadas por Enter.
+func feedInputLine(input *strings.Builder, data []byte) []string {
+	var lines []string
+	for _, b := range data {
+		switch b {
+		case '\r', '\n':
+			if line := strings.TrimSpace(input.String()); line != "" {
+				lines = append(lines, line)
+			}
+			input.Reset()
+		case 0x03: // Ctrl+C
+			input.Reset()
+		case 0x08, 0x7f:
+			if text := []rune(input.String()); len(text) > 0 {
+				input.Reset()
+				input.WriteString(string(text[:len(text)-1]))
+			}
+		default:
+			if b >= 32 || b == '\t' {
+				input.WriteByte(b)
+			}
+		}
+	}
+	return lines
+}
+
+func (t *TestRunTracker) startLocked(session *trackedSession, sessionID string, workspaceID, agentID uint, line string, framework string) {
+	run := &TestRun{
+		SessionID:   sessionID,
+		WorkspaceID: workspaceID,
+		AgentID:     agentID,
+		Command:     line,
+		Framework:   framework,
+		Status:      TestRunRunning,
+		StartedAt:   t.now(),
+	}
+	session.run = run
+	session.pending = ""
+	session.dirty, session.emitted = false, false
+	t.runs = append(t.runs, run)
+	if len(t.runs) > t.maxRuns {
+		t.runs = t.runs[len(t.runs)-t.maxRuns:]
+	}
+}
+
+// finishLocked encerra a execução da sessão. O aviso de fim (se ainda devido) é devolvido
+// para ser entregue fora do lock.
+func (t *TestRunTracker) finishLocked(session *trackedSession) (TestRun, func(TestRun)) {
+	noop := func(TestRun) {}
+	if session.run == nil {
+		return TestRun{}, noop
+	}
+	if session.pending != "" {
+		t.classifyLineLocked(session, session.pending)
+		session.pending = ""
+	}
+	if session.run.Status == TestRunRunning {
+		session.run.Status = TestRunUnknown
+		session.dirty = true
+	}
+	if session.settle != nil {
+		session.settle.Stop()
+		session.settle = nil
+	}
+	run := session.run
+	session.run = nil
+	if !session.dirty && session.emitted {
+		return TestRun{}, noop
+	}
+	return t.snapshotLocked(session, run)
+}
+
+// armSettleLocked (re)agenda o aviso de fim para quando a saída ficar em silêncio.
+func (t *TestRunTracker) armSettleLocked(sessionID string, session *trackedSession) {
+	if session.settle != nil {
+		session.settle.Stop()
+	}
+	run := session.run
+	session.settle = time.AfterFunc(t.settleAfter, func() {
+		t.mu.Lock()
+		current, ok := t.sessions[sessionID]
+		if !ok || current.run != run || !current.dirty {
+			t.mu.Unlock()
+			return
+		}
+		current.settle = nil
+		finished, notify := t.snapshotLocked(current, run)
+		t.mu.Unlock()
+		notify(finished)
+	})
+}
+
+// snapshotLocked marca o resultado como avisado, guarda-o como último do agente e devolve a cópia.
+func (t *TestRunTracker) snapshotLocked(session *trackedSession, run *TestRun) (TestRun, func(TestRun)) {
+	session.dirty, session.emitted = false, true
+	if run.FinishedAt.IsZero() {
+		run.FinishedAt = t.now()
+	}
+	snapshot := *run
+	snapshot.FailingTests = append([]string(nil), run.FailingTests...)
+	if run.AgentID != 0 {
+		t.lastByAgent[run.AgentID] = snapshot
+	}
+	if t.onFinished == nil {
+		return snapshot, func(TestRun) {}
+	}
+	return snapshot, t.onFinished
+}
+
+func (t *TestRunTracker) classifyLineLocked(session *trackedSession, raw string) {
+	run := session.run
+	line := strings.TrimSpace(ansiEscapeRegex.ReplaceAllString(raw, ""))
+	line = strings.Trim(line, "= ")
+	if line == "" {
+		return
+	}
+	before := [4]int{run.Passed, run.Failed, run.Skipped, len(run.FailingTests)}
+	parseTestResultLine(run, line)
+	if before != [4]int{run.Passed, run.Failed, run.Skipped, len(run.FailingTests)} {
+		session.dirty = true
+	}
+	if run.Status == TestRunFailed {
+		return
+	}
+	switch {
+	case testFailRegex.MatchString(line):
+		run.Status = TestRunFailed
+	case testPassRegex.MatchString(line):
+		run.Status = TestRunPassed
+	default:
+		return
+	}
+	run.Summary = line
+	run.FinishedAt = t.now()
+	session.dirty = true
+}
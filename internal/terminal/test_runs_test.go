@@ -0,0 +1,164 @@
+package terminal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectTestCommand(t *testing.T) {
+	cases := map[string]string{
+		"go test ./...":                        "go",
+		"CGO_ENABLED=0 go test -run Foo ./pkg": "go",
+		"npm test":                             "npm",
+		"pnpm run test:unit":                   "npm",
+		"python -m pytest -x":                  "pytest",
+		"cargo test --workspace":               "cargo",
+		"./gradlew clean test":                 "gradle",
+		"bundle exec rspec spec/models":        "rspec",
+	}
+	for line, want := range cases {
+		got, ok := DetectTestCommand(line)
+		if !ok || got != want {
+			t.Fatalf("DetectTestCommand(%q) = %q, %v; want %q", line, got, ok, want)
+		}
+	}
+
+	for _, line := range []string{"go build ./...", "npm install", "git commit -m test", "cat test.txt"} {
+		if framework, ok := DetectTestCommand(line); ok {
+			t.Fatalf("DetectTestCommand(%q) should not match, got %q", line, framework)
+		}
+	}
+}
+
+func TestTestRunTrackerRecordsOutcomeFromOutput(t *testing.T) {
+	tracker := NewTestRunTracker(10, func(sessionID string) uint {
+		if sessionID == "s1" {
+			return 7
+		}
+		return 0
+	})
+	start := time.Now().Add(-time.Minute)
+
+	// Digitação caractere a caractere, com backspace corrigindo um erro.
+	for _, chunk := range []string{"go tesx", "\x7f", "t ./...", "\r"} {
+		tracker.ObserveInput("s1", []byte(chunk))
+	}
+	tracker.ObserveOutput("s1", []byte("ok  \torch/internal/a\t0.1s\r\n--- FAIL: TestX (0.00s)\r\nFA"))
+	tracker.ObserveOutput("s1", []byte("IL\torch/internal/b\t0.2s\r\nok  \torch/internal/c\t0.1s\r\n"))
+
+	tracker.ObserveInput("s2", []byte("npm test\r"))
+	tracker.ObserveOutput("s2", []byte("\x1b[32mTests:       12 passed, 12 total\x1b[0m\n"))
+
+	// Um comando comum encerra a execução sem resultado da sessão.
+	tracker.ObserveInput("s3", []byte("cargo test\r"))
+	tracker.ObserveInput("s3", []byte("ls\r"))
+
+	runs := tracker.Runs(start, 0)
+	if len(runs) != 3 {
+		t.Fatalf("expected 3 runs, got %#v", runs)
+	}
+	if runs[0].Command != "go test ./..." || runs[0].WorkspaceID != 7 || runs[0].Status != TestRunFailed {
+		t.Fatalf("unexpected go run: %#v", runs[0])
+	}
+	if runs[1].Framework != "npm" || runs[1].Status != TestRunPassed || runs[1].Summary != "Tests:       12 passed, 12 total" {
+		t.Fatalf("unexpected npm run: %#v", runs[1])
+	}
+	if runs[2].Status != TestRunUnknown {
+		t.Fatalf("expected interrupted run to be unknown, got %#v", runs[2])
+	}
+
+	if filtered := tracker.Runs(start, 7); len(filtered) != 1 || filtered[0].SessionID != "s1" {
+		t.Fatalf("expected workspace filter to keep only s1, got %#v", filtered)
+	}
+	if recent := tracker.Runs(time.Now().Add(time.Minute), 0); len(recent) != 0 {
+		t.Fatalf("expected no runs after since, got %#v", recent)
+	}
+}
+
+func TestTestRunTrackerParsesStructuredResults(t *testing.T) {
+	tracker := NewTestRunTracker(10, nil)
+	tracker.SetAgentResolver(func(sessionID string) uint {
+		if sessionID == "jest" {
+			return 3
+		}
+		return 0
+	})
+	finished := make(chan TestRun, 8)
+	tracker.SetOnFinished(func(run TestRun) { finished <- run })
+	tracker.settleAfter = time.Hour // os avisos aqui vêm do próximo comando
+
+	tracker.ObserveInput("go", []byte("go test -v ./...\r"))
+	tracker.ObserveOutput("go", []byte("=== RUN   TestA\n--- PASS: TestA (0.00s)\n=== RUN   TestB\n    --- FAIL: TestB/empty (0.00s)\n--- FAIL: TestB (0.00s)\n--- SKIP: TestC (0.00s)\nFAIL\n"))
+
+	tracker.ObserveInput("jest", []byte("npx jest\r"))
+	tracker.ObserveOutput("jest", []byte("  ● Cart › adds items\n\n  ● Cart › adds items\n  ● Test suite failed to run\nTests:       1 failed, 2 skipped, 10 passed, 13 total\n"))
+
+	tracker.ObserveInput("py", []byte("pytest -q\r"))
+	tracker.ObserveOutput("py", []byte("FAILED tests/test_api.py::test_login - AssertionError\n===== 1 failed, 4 passed, 1 skipped in 0.42s =====\n"))
+
+	tracker.ObserveInput("cargo", []byte("cargo test\r"))
+	tracker.ObserveOutput("cargo", []byte("test parser::tests::parses ... FAILED\ntest result: FAILED. 3 passed; 1 failed; 0 ignored; 0 measured\ntest result: ok. 2 passed; 0 failed; 1 ignored; 0 measured\n"))
+
+	for _, session := range []string{"go", "jest", "py", "cargo"} {
+		tracker.ObserveInput(session, []byte("clear\r"))
+	}
+
+	got := make(map[string]TestRun)
+	for i := 0; i < 4; i++ {
+		run := <-finished
+		got[run.Framework] = run
+	}
+	check := func(framework string, passed, failed, skipped int, failing ...string) {
+		t.Helper()
+		run := got[framework]
+		if run.Status != TestRunFailed || run.Passed != passed || run.Failed != failed || run.Skipped != skipped {
+			t.Fatalf("unexpected %s counts: %#v", framework, run)
+		}
+		if len(run.FailingTests) != len(failing) {
+			t.Fatalf("unexpected %s failing tests: %#v", framework, run.FailingTests)
+		}
+		for i := range failing {
+			if run.FailingTests[i] != failing[i] {
+				t.Fatalf("unexpected %s failing tests: %#v", framework, run.FailingTests)
+			}
+		}
+	}
+	check("go", 1, 2, 1, "TestB/empty", "TestB")
+	check("jest", 10, 1, 2, "Cart › adds items")
+	check("pytest", 4, 1, 1, "tests/test_api.py::test_login")
+	check("cargo", 5, 1, 1, "parser::tests::parses")
+
+	if run, ok := tracker.LastRun(3); !ok || run.Framework != "jest" || run.AgentID != 3 {
+		t.Fatalf("expected last jest run for agent 3, got %#v (%v)", run, ok)
+	}
+	if _, ok := tracker.LastRun(9); ok {
+		t.Fatalf("unexpected run for unknown agent")
+	}
+}
+
+func TestTestRunTrackerNotifiesWhenOutputSettles(t *testing.T) {
+	tracker := NewTestRunTracker(10, nil)
+	finished := make(chan TestRun, 4)
+	tracker.SetOnFinished(func(run TestRun) { finished <- run })
+	tracker.settleAfter = 10 * time.Millisecond
+
+	tracker.ObserveInput("s1", []byte("go test ./...\r"))
+	tracker.ObserveOutput("s1", []byte("ok  \torch/internal/a\t0.1s\n"))
+	if run := <-finished; run.Status != TestRunPassed {
+		t.Fatalf("unexpected first notification: %#v", run)
+	}
+
+	// Outro pacote terminou depois do silêncio: a execução é atualizada e avisada de novo.
+	tracker.ObserveOutput("s1", []byte("FAIL\torch/internal/b\t0.2s\n"))
+	if run := <-finished; run.Status != TestRunFailed {
+		t.Fatalf("unexpected updated notification: %#v", run)
+	}
+
+	// Sem mudanças desde o último aviso, o próximo comando não repete a notificação.
+	tracker.ObserveInput("s1", []byte("ls\r"))
+	select {
+	case run := <-finished:
+		t.Fatalf("unexpected duplicate notification: %#v", run)
+	case <-time.After(30 * time.Millisecond):
+	}
+}
@@ -0,0 +1,364 @@
+package terminal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"orch/internal/i18n"
+)
+
+// Formatos do transcript renderizado.
+const (
+	TranscriptFormatPlain    = "plain"
+	TranscriptFormatMarkdown = "markdown"
+)
+
+const (
+	maxTranscriptEntries     = 200
+	maxTranscriptEntryOutput = 64 * 1024 // bytes brutos por comando; o começo da saída é descartado
+)
+
+// TranscriptEntry é um comando digitado e a saída produzida até o próximo comando.
+type TranscriptEntry struct {
+	Index     int       `json:"index"`             // 0 = saída anterior ao primeiro comando
+	Command   string    `json:"command,omitempty"` // linha digitada (Enter)
+	StartedAt time.Time `json:"startedAt"`
+	Output    string    `json:"output"`    // sem sequências ANSI, com \r e backspace aplicados
+	Truncated bool      `json:"truncated"` // começo da saída descartado pelo limite
+}
+
+// Transcript é a leitura acessível de um terminal: texto linear para leitores de tela com os
+// limites de cada comando anotados, e as mesmas entradas estruturadas para a UI.
+type Transcript struct {
+	SessionID      string            `json:"sessionId"`
+	Format         string            `json:"format"`
+	Text           string            `json:"text"`
+	Entries        []TranscriptEntry `json:"entries"`
+	DroppedEntries int               `json:"droppedEntries"` // comandos antigos fora do limite
+}
+
+type transcriptEntry struct {
+	command   string
+	startedAt time.Time
+	output    []byte
+	truncated bool
+}
+
+type transcriptSession struct {
+	input    strings.Builder
+	entries  []*transcriptEntry
+	commands int // comandos já vistos, para numerar mesmo após descartar os antigos
+	dropped  int
+}
+
+// TranscriptRecorder guarda, por sessão, a saída do terminal separada pelos comandos que o
+// input observer vê passar (mesma detecção de Enter do TestRunTracker).
+type TranscriptRecorder struct {
+	mu       sync.Mutex
+	sessions map[string]*transcriptSession
+	now      func() time.Time
+}
+
+// NewTranscriptRecorder cria o gravador sem sessões.
+func NewTranscriptRecorder() *TranscriptRecorder {
+	return &TranscriptRecorder{sessions: make(map[string]*transcriptSession), now: time.Now}
+}
+
+// ObserveInput abre um novo trecho a cada linha fechada com Enter.
+func (r *TranscriptRecorder) ObserveInput(sessionID string, data []byte) {
+	if sessionID == "" || len(data) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session := r.sessionLocked(sessionID)
+	for _, line := range feedInputLine(&session.input, data) {
+		session.commands++
+		session.entries = append(session.entries, &transcriptEntry{command: line, startedAt: r.now()})
+		if len(session.entries) > maxTranscriptEntries {
+			session.entries = session.entries[1:]
+			session.dropped++
+		}
+	}
+}
+
+// ObserveOutput anexa a saída ao trecho do comando atual.
+func (r *TranscriptRecorder) ObserveOutput(sessionID string, data []byte) {
+	if sessionID == "" || len(data) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session := r.sessionLocked(sessionID)
+	if len(session.entries) == 0 {
+		session.entries = append(session.entries, &transcriptEntry{startedAt: r.now()})
+	}
+	entry := session.entries[len(session.entries)-1]
+	entry.output = append(entry.output, data...)
+	if excess := len(entry.output) - maxTranscriptEntryOutput; excess > 0 {
+		for excess < len(entry.output) && !utf8.RuneStart(entry.output[excess]) {
+			excess++
+		}
+		entry.output = append([]byte(nil), entry.output[excess:]...)
+		entry.truncated = true
+	}
+}
+
+// Forget descarta o transcript da sessão encerrada.
+func (r *TranscriptRecorder) Forget(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, sessionID)
+}
+
+// Transcript renderiza o transcript da sessão no idioma informado: texto simples (plainText)
+// ou Markdown com um título por comando. ok=false quando a sessão não produziu nada ainda.
+func (r *TranscriptRecorder) Transcript(sessionID string, plainText bool, locale string) (Transcript, bool) {
+	format := TranscriptFormatMarkdown
+	if plainText {
+		format = TranscriptFormatPlain
+	}
+	r.mu.Lock()
+	session, ok := r.sessions[sessionID]
+	if !ok {
+		r.mu.Unlock()
+		return Transcript{SessionID: sessionID, Format: format, Entries: []TranscriptEntry{}}, false
+	}
+	// O trecho inicial (sem comando) só existe antes do primeiro comando, então fica com índice 0.
+	firstIndex := session.commands - len(session.entries) + 1
+	entries := make([]TranscriptEntry, 0, len(session.entries))
+	for i, entry := range session.entries {
+		entries = append(entries, TranscriptEntry{
+			Index:     firstIndex + i,
+			Command:   entry.command,
+			StartedAt: entry.startedAt,
+			Output:    CleanTerminalOutput(string(entry.output)),
+			Truncated: entry.truncated,
+		})
+	}
+	dropped := session.dropped
+	r.mu.Unlock()
+
+	trimCommandEchoes(entries)
+	return Transcript{
+		SessionID:      sessionID,
+		Format:         format,
+		Text:           renderTranscript(entries, dropped, plainText, locale),
+		Entries:        entries,
+		DroppedEntries: dropped,
+	}, true
+}
+
+func (r *TranscriptRecorder) sessionLocked(sessionID string) *transcriptSession {
+	session, ok := r.sessions[sessionID]
+	if !ok {
+		session = &transcriptSession{}
+		r.sessions[sessionID] = session
+	}
+	return session
+}
+
+// trimCommandEchoes remove o eco do comando digitado: a linha "prompt + comando" que fecha o
+// trecho anterior e o pedaço ecoado depois do Enter (comando colado ou eco atrasado).
+func trimCommandEchoes(entries []TranscriptEntry) {
+	for i := range entries {
+		command := entries[i].Command
+		if command == "" {
+			continue
+		}
+		first, rest, _ := strings.Cut(entries[i].Output, "\n")
+		first = strings.TrimSpace(first)
+		previous := ""
+		var previousLines []string
+		if i > 0 {
+			previousLines = strings.Split(entries[i-1].Output, "\n")
+			previous = strings.TrimSpace(previousLines[len(previousLines)-1])
+		}
+
+		dropPrevious, dropFirst := false, false
+		switch {
+		case strings.HasSuffix(previous, command):
+			dropPrevious = true
+		case strings.HasSuffix(command, first) && strings.HasSuffix(previous+first, command):
+			// A linha anterior é onde o cursor estava no Enter: o prompt.
+			dropPrevious, dropFirst = previous != "", true
+		}
+		if dropPrevious {
+			entries[i-1].Output = strings.TrimRight(strings.Join(previousLines[:len(previousLines)-1], "\n"), "\n")
+		}
+		if dropFirst {
+			entries[i].Output = strings.TrimLeft(rest, "\n")
+		}
+	}
+}
+
+func renderTranscript(entries []TranscriptEntry, dropped int, plainText bool, locale string) string {
+	var b strings.Builder
+	if dropped > 0 {
+		b.WriteString(i18n.Localize(locale, i18n.TranscriptDropped, map[string]string{"count": strconv.Itoa(dropped)}))
+		b.WriteString("\n\n")
+	}
+	for _, entry := range entries {
+		if entry.Command == "" && entry.Output == "" {
+			continue
+		}
+		params := map[string]string{
+			"index":   strconv.Itoa(entry.Index),
+			"command": entry.Command,
+			"time":    entry.StartedAt.Format("15:04:05"),
+		}
+		heading := i18n.Localize(locale, i18n.TranscriptCommand, params)
+		if entry.Command == "" {
+			heading = i18n.Localize(locale, i18n.TranscriptInitialOutput, nil)
+		}
+		output := entry.Output
+		if output == "" {
+			output = i18n.Localize(locale, i18n.TranscriptNoOutput, nil)
+		}
+
+		if plainText {
+			b.WriteString(heading + "\n")
+			if entry.Truncated {
+				b.WriteString(i18n.Localize(locale, i18n.TranscriptTruncated, nil) + "\n")
+			}
+			b.WriteString(output + "\n")
+			if entry.Command != "" {
+				b.WriteString(i18n.Localize(locale, i18n.TranscriptCommandEnd, params) + "\n")
+			}
+			b.WriteString("\n")
+			continue
+		}
+
+		fmt.Fprintf(&b, "## %s\n\n", heading)
+		if entry.Truncated {
+			b.WriteString("_" + i18n.Localize(locale, i18n.TranscriptTruncated, nil) + "_\n\n")
+		}
+		fence := "```"
+		for strings.Contains(output, fence) {
+			fence += "`"
+		}
+		fmt.Fprintf(&b, "%stext\n%s\n%s\n\n", fence, output, fence)
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// CleanTerminalOutput converte a saída bruta do PTY no texto que ficou na tela: remove
+// sequências de escape, aplica \r (sobrescrita), backspace e "erase line", e junta linhas em
+// branco repetidas.
+func CleanTerminalOutput(raw string) string {
+	var out []string
+	var line []rune
+	col := 0
+	put := func(r rune) {
+		if col < len(line) {
+			line[col] = r
+		} else {
+			for len(line) < col {
+				line = append(line, ' ')
+			}
+			line = append(line, r)
+		}
+		col++
+	}
+	flush := func() {
+		out = append(out, strings.TrimRight(string(line), " \t"))
+		line, col = line[:0], 0
+	}
+
+	runes := []rune(raw)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '\n':
+			flush()
+		case r == '\r':
+			col = 0
+		case r == '\b':
+			if col > 0 {
+				col--
+			}
+		case r == '\t':
+			put(r)
+		case r == 0x1b:
+			i = applyEscape(runes, i, &line, &col)
+		case r < 0x20 || r == 0x7f || (r >= 0x80 && r < 0xa0):
+			// Controle sem representação na tela (BEL, SO/SI...).
+		default:
+			put(r)
+		}
+	}
+	if len(line) > 0 {
+		flush()
+	}
+
+	var b strings.Builder
+	blank := 0
+	for _, text := range out {
+		if text == "" {
+			blank++
+			continue
+		}
+		if b.Len() > 0 {
+			if blank > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+		}
+		blank = 0
+		b.WriteString(text)
+	}
+	return b.String()
+}
+
+// applyEscape consome a sequência iniciada em runes[i] (ESC) e devolve o índice do último
+// caractere dela. Só os comandos que mudam o texto da linha atual têm efeito.
+func applyEscape(runes []rune, i int, line *[]rune, col *int) int {
+	if i+1 >= len(runes) {
+		return i
+	}
+	switch runes[i+1] {
+	case '[': // CSI: parâmetros até o byte final 0x40–0x7e
+		j := i + 2
+		for j < len(runes) && (runes[j] < 0x40 || runes[j] > 0x7e) {
+			j++
+		}
+		if j >= len(runes) {
+			return len(runes) - 1
+		}
+		n, _ := strconv.Atoi(strings.Split(string(runes[i+2:j]), ";")[0])
+		switch runes[j] {
+		case 'K': // erase in line
+			switch n {
+			case 0:
+				if *col < len(*line) {
+					*line = (*line)[:*col]
+				}
+			case 2:
+				*line = (*line)[:0]
+			}
+		case 'G': // cursor para a coluna n
+			*col = max(n-1, 0)
+		case 'C':
+			*col += max(n, 1)
+		case 'D':
+			*col = max(*col-max(n, 1), 0)
+		}
+		return j
+	case ']', 'P', 'X', '^', '_': // strings (OSC, DCS...) terminadas por BEL ou ESC \
+		for j := i + 2; j < len(runes); j++ {
+			if runes[j] == 0x07 {
+				return j
+			}
+			if runes[j] == 0x1b && j+1 < len(runes) && runes[j+1] == '\\' {
+				return j + 1
+			}
+		}
+		return len(runes) - 1
+	case '(', ')', '*', '+': // seleção de charset: um caractere a mais
+		return min(i+2, len(runes)-1)
+	}
+	return i + 1
+}
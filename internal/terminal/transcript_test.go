@@ -0,0 +1,80 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"orch/internal/i18n"
+)
+
+func TestCleanTerminalOutputAppliesScreenSemantics(t *testing.T) {
+	raw := "\x1b]0;title\x07\x1b[1;32mok\x1b[0m\r\n" +
+		"downloading 10%\r\x1b[2Kdone\r\n" +
+		"abc\b\bX\r\n\r\n\r\n\r\n" +
+		"\x1b(Bnext\x1b[K line\x07\n"
+	want := "ok\ndone\naXc\n\nnext line"
+	if got := CleanTerminalOutput(raw); got != want {
+		t.Fatalf("CleanTerminalOutput() = %q, want %q", got, want)
+	}
+}
+
+func TestTranscriptAnnotatesCommandBoundaries(t *testing.T) {
+	recorder := NewTranscriptRecorder()
+	recorder.now = func() time.Time { return time.Date(2026, 3, 2, 10, 30, 0, 0, time.Local) }
+
+	recorder.ObserveOutput("s1", []byte("welcome\r\n$ "))
+	recorder.ObserveInput("s1", []byte("l"))
+	recorder.ObserveOutput("s1", []byte("l"))
+	recorder.ObserveInput("s1", []byte("s\r"))
+	recorder.ObserveOutput("s1", []byte("s\r\n\x1b[34mmain.go\x1b[0m\r\n$ "))
+	recorder.ObserveInput("s1", []byte("true\r"))
+	recorder.ObserveOutput("s1", []byte("true\r\n$ "))
+
+	transcript, ok := recorder.Transcript("s1", true, i18n.LocaleENUS)
+	if !ok || transcript.Format != TranscriptFormatPlain || len(transcript.Entries) != 3 {
+		t.Fatalf("unexpected transcript: %+v", transcript)
+	}
+	entries := transcript.Entries
+	if entries[0].Command != "" || entries[0].Output != "welcome" {
+		t.Fatalf("prompt echo should be trimmed from the initial output: %+v", entries[0])
+	}
+	if entries[1].Index != 1 || entries[1].Command != "ls" || entries[1].Output != "main.go" {
+		t.Fatalf("unexpected ls entry: %+v", entries[1])
+	}
+	if entries[2].Command != "true" || entries[2].Output != "$" {
+		t.Fatalf("pasted command echo should be trimmed: %+v", entries[2])
+	}
+	for _, want := range []string{"Output before the first command\nwelcome", "Command 1, at 10:30:00: ls\nmain.go\nEnd of command 1."} {
+		if !strings.Contains(transcript.Text, want) {
+			t.Fatalf("plain transcript missing %q:\n%s", want, transcript.Text)
+		}
+	}
+
+	markdown, _ := recorder.Transcript("s1", false, i18n.LocalePTBR)
+	if markdown.Format != TranscriptFormatMarkdown || !strings.Contains(markdown.Text, "## Comando 2, às 10:30:00: true") || !strings.Contains(markdown.Text, "```text\nmain.go\n```") {
+		t.Fatalf("unexpected markdown transcript:\n%s", markdown.Text)
+	}
+
+	recorder.Forget("s1")
+	if _, ok := recorder.Transcript("s1", true, ""); ok {
+		t.Fatalf("expected forgotten session")
+	}
+}
+
+func TestTranscriptBoundsEntriesAndOutput(t *testing.T) {
+	recorder := NewTranscriptRecorder()
+	for i := 0; i < maxTranscriptEntries+5; i++ {
+		recorder.ObserveInput("s1", []byte("echo\r"))
+	}
+	recorder.ObserveOutput("s1", []byte(strings.Repeat("é", maxTranscriptEntryOutput)))
+
+	transcript, _ := recorder.Transcript("s1", true, i18n.LocaleENUS)
+	last := transcript.Entries[len(transcript.Entries)-1]
+	if len(transcript.Entries) != maxTranscriptEntries || transcript.DroppedEntries != 5 || last.Index != maxTranscriptEntries+5 {
+		t.Fatalf("unexpected bounds: %d entries, %d dropped, last index %d", len(transcript.Entries), transcript.DroppedEntries, last.Index)
+	}
+	if !last.Truncated || !strings.HasPrefix(last.Output, "é") || !strings.HasPrefix(transcript.Text, "5 earlier commands omitted.") {
+		t.Fatalf("expected truncated output on a rune boundary")
+	}
+}
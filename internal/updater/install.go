@@ -0,0 +1,255 @@
+package updater
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// applyPackage extrai o pacote ao lado da instalação atual e troca os arquivos por rename.
+// Em um bundle macOS (.../ORCH.app/Contents/MacOS/orch) substitui o .app inteiro; fora dele, só o executável.
+// Retorna o caminho substituído.
+func applyPackage(packagePath, executable string) (string, error) {
+	target := executable
+	if bundle := enclosingAppBundle(executable); bundle != "" {
+		target = bundle
+	}
+
+	staging, err := os.MkdirTemp(filepath.Dir(target), ".orch-update-")
+	if err != nil {
+		return "", fmt.Errorf("install location is not writable: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	if err := extractPackage(packagePath, staging); err != nil {
+		return "", err
+	}
+
+	var replacement string
+	if target != executable {
+		replacement, err = findInPackage(staging, func(path string, entry fs.DirEntry) bool {
+			return entry.IsDir() && strings.HasSuffix(entry.Name(), ".app")
+		})
+	} else {
+		name := filepath.Base(executable)
+		replacement, err = findInPackage(staging, func(path string, entry fs.DirEntry) bool {
+			return entry.Type().IsRegular() && entry.Name() == name
+		})
+		if err == nil {
+			err = os.Chmod(replacement, 0755)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := swapInPlace(target, replacement); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+func enclosingAppBundle(executable string) string {
+	for dir := filepath.Dir(executable); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if strings.HasSuffix(dir, ".app") {
+			return dir
+		}
+	}
+	return ""
+}
+
+// swapInPlace troca target por replacement mantendo target.old até o fim para rollback.
+func swapInPlace(target, replacement string) error {
+	backup := target + ".old"
+	_ = os.RemoveAll(backup)
+	if err := os.Rename(target, backup); err != nil {
+		return fmt.Errorf("failed to move current installation aside: %w", err)
+	}
+	if err := os.Rename(replacement, target); err != nil {
+		if rollbackErr := os.Rename(backup, target); rollbackErr != nil {
+			return fmt.Errorf("failed to install update (%v) and to restore previous version: %w", err, rollbackErr)
+		}
+		return fmt.Errorf("failed to install update: %w", err)
+	}
+	// No Windows o executável em uso não pode ser removido; fica para a próxima atualização.
+	_ = os.RemoveAll(backup)
+	return nil
+}
+
+func findInPackage(root string, match func(path string, entry fs.DirEntry) bool) (string, error) {
+	var found string
+	errFound := errors.New("found")
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root && match(path, entry) {
+			found = path
+			return errFound
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errFound) {
+		return "", err
+	}
+	if found == "" {
+		return "", fmt.Errorf("update package does not contain a compatible app for this installation")
+	}
+	return found, nil
+}
+
+func extractPackage(packagePath, dest string) error {
+	name := strings.ToLower(packagePath)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return extractZip(packagePath, dest)
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return extractTarGz(packagePath, dest)
+	}
+	return fmt.Errorf("unsupported update package %s", filepath.Base(packagePath))
+}
+
+func extractZip(packagePath, dest string) error {
+	reader, err := zip.OpenReader(packagePath)
+	if err != nil {
+		return fmt.Errorf("invalid update package: %w", err)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		mode := file.Mode()
+		if mode&os.ModeSymlink != 0 {
+			rc, err := file.Open()
+			if err != nil {
+				return err
+			}
+			link, err := io.ReadAll(io.LimitReader(rc, 4096))
+			rc.Close()
+			if err != nil {
+				return err
+			}
+			if err := writeSymlink(dest, file.Name, string(link)); err != nil {
+				return err
+			}
+			continue
+		}
+		if file.FileInfo().IsDir() {
+			if err := makeDir(dest, file.Name); err != nil {
+				return err
+			}
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = writeFile(dest, file.Name, rc, mode.Perm())
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractTarGz(packagePath, dest string) error {
+	file, err := os.Open(packagePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("invalid update package: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid update package: %w", err)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = makeDir(dest, header.Name)
+		case tar.TypeReg:
+			err = writeFile(dest, header.Name, tr, os.FileMode(header.Mode).Perm())
+		case tar.TypeSymlink:
+			err = writeSymlink(dest, header.Name, header.Linkname)
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// safeJoin rejeita entradas absolutas ou com ".." que escapariam do diretório de extração.
+func safeJoin(root, name string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("update package contains unsafe path %q", name)
+	}
+	return filepath.Join(root, cleaned), nil
+}
+
+func makeDir(root, name string) error {
+	path, err := safeJoin(root, name)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(path, 0755)
+}
+
+func writeFile(root, name string, r io.Reader, perm os.FileMode) error {
+	path, err := safeJoin(root, name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if perm == 0 {
+		perm = 0644
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, io.LimitReader(r, maxPackageBytes)); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// writeSymlink aceita apenas links relativos que permanecem dentro do pacote (frameworks do bundle).
+func writeSymlink(root, name, target string) error {
+	path, err := safeJoin(root, name)
+	if err != nil {
+		return err
+	}
+	if filepath.IsAbs(target) {
+		return fmt.Errorf("update package contains absolute symlink %q", name)
+	}
+	resolved := filepath.Clean(filepath.Join(filepath.Dir(path), filepath.FromSlash(target)))
+	if rel, err := filepath.Rel(root, resolved); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("update package symlink %q escapes the package", name)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.Symlink(filepath.FromSlash(target), path)
+}
@@ -0,0 +1,522 @@
+// Package updater verifica releases do app no GitHub, baixa o pacote da plataforma atual,
+// valida a assinatura e substitui a instalação em uso.
+//
+// Cada pacote publicado acompanha "<pacote>.sig": assinatura Ed25519 (base64) do SHA-256 do pacote.
+// A chave pública é embutida no build via -ldflags "-X orch/internal/updater.releasePublicKey=<base64>".
+package updater
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"orch/internal/httpclient"
+)
+
+const (
+	ChannelStable = "stable"
+	ChannelBeta   = "beta"
+
+	githubAPIEndpoint    = "https://api.github.com"
+	maxReleasesPerCheck  = 30
+	maxPackageBytes      = 1 << 30
+	maxSignatureBytes    = 4 * 1024
+	progressEmitInterval = 200 * time.Millisecond
+)
+
+// releasePublicKey é a chave Ed25519 (base64) que assina os releases; vazia em builds de desenvolvimento.
+var releasePublicKey = ""
+
+// NormalizeChannel valida o canal ("" = stable).
+func NormalizeChannel(raw string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", ChannelStable:
+		return ChannelStable, nil
+	case ChannelBeta:
+		return ChannelBeta, nil
+	}
+	return "", fmt.Errorf("unknown update channel %q (expected stable or beta)", raw)
+}
+
+// UpdateInfo descreve o resultado de uma verificação.
+type UpdateInfo struct {
+	CurrentVersion string    `json:"currentVersion"`
+	LatestVersion  string    `json:"latestVersion,omitempty"`
+	Channel        string    `json:"channel"`
+	Available      bool      `json:"available"`
+	ReleaseName    string    `json:"releaseName,omitempty"`
+	ReleaseNotes   string    `json:"releaseNotes,omitempty"`
+	ReleaseURL     string    `json:"releaseUrl,omitempty"`
+	Prerelease     bool      `json:"prerelease"`
+	PublishedAt    time.Time `json:"publishedAt,omitempty"`
+	AssetName      string    `json:"assetName,omitempty"`
+	AssetSize      int64     `json:"assetSize,omitempty"`
+	CheckedAt      time.Time `json:"checkedAt"`
+}
+
+// Progress é o payload do evento "updater:progress".
+type Progress struct {
+	Stage      string  `json:"stage"` // downloading | verifying | installing | done | error
+	Version    string  `json:"version"`
+	Downloaded int64   `json:"downloaded"`
+	Total      int64   `json:"total"`
+	Percent    float64 `json:"percent"`
+	Message    string  `json:"message,omitempty"`
+}
+
+// InstallResult descreve uma atualização aplicada; o app novo vale após reiniciar.
+type InstallResult struct {
+	Version         string `json:"version"`
+	InstalledPath   string `json:"installedPath"`
+	RestartRequired bool   `json:"restartRequired"`
+}
+
+// Options configura o Service.
+type Options struct {
+	Repository     string // owner/repo dos releases
+	CurrentVersion string
+	CacheDir       string // downloads ficam em <CacheDir>/updates
+	PublicKey      string // base64; vazio usa a chave embutida no build
+	Emit           func(event string, payload interface{})
+}
+
+type releaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+type githubRelease struct {
+	TagName     string         `json:"tag_name"`
+	Name        string         `json:"name"`
+	Body        string         `json:"body"`
+	Draft       bool           `json:"draft"`
+	Prerelease  bool           `json:"prerelease"`
+	HTMLURL     string         `json:"html_url"`
+	PublishedAt time.Time      `json:"published_at"`
+	Assets      []releaseAsset `json:"assets"`
+}
+
+// candidate é o release escolhido na última verificação, com os assets da plataforma.
+type candidate struct {
+	info      UpdateInfo
+	asset     releaseAsset
+	signature releaseAsset
+}
+
+// Service verifica e instala atualizações.
+type Service struct {
+	repository string
+	current    string
+	cacheDir   string
+	publicKey  string
+	emit       func(event string, payload interface{})
+
+	apiBase    string
+	client     *http.Client
+	download   *http.Client
+	goos       string
+	goarch     string
+	executable func() (string, error)
+
+	mu         sync.Mutex
+	pending    *candidate
+	installing bool
+}
+
+// NewService cria o updater.
+func NewService(opts Options) *Service {
+	publicKey := strings.TrimSpace(opts.PublicKey)
+	if publicKey == "" {
+		publicKey = releasePublicKey
+	}
+	return &Service{
+		repository: strings.Trim(strings.TrimSpace(opts.Repository), "/"),
+		current:    strings.TrimSpace(opts.CurrentVersion),
+		cacheDir:   opts.CacheDir,
+		publicKey:  publicKey,
+		emit:       opts.Emit,
+		apiBase:    githubAPIEndpoint,
+		client:     httpclient.NewClient(httpclient.ServiceUpdater, 30*time.Second),
+		download:   httpclient.Client(httpclient.ServiceUpdater), // downloads longos: cancelamento via context
+		goos:       runtime.GOOS,
+		goarch:     runtime.GOARCH,
+		executable: os.Executable,
+	}
+}
+
+// CheckForUpdates busca o release mais recente do canal com pacote para esta plataforma.
+func (s *Service) CheckForUpdates(ctx context.Context, channel string) (*UpdateInfo, error) {
+	channel, err := NormalizeChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+	current, err := parseVersion(s.current)
+	if err != nil {
+		return nil, fmt.Errorf("current app version: %w", err)
+	}
+	releases, err := s.listReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &UpdateInfo{CurrentVersion: s.current, Channel: channel, CheckedAt: time.Now()}
+	var best *candidate
+	var bestVersion version
+	for _, release := range releases {
+		if release.Draft || (release.Prerelease && channel != ChannelBeta) {
+			continue
+		}
+		releaseVersion, err := parseVersion(release.TagName)
+		if err != nil {
+			continue
+		}
+		if releaseVersion.isPrerelease() && channel != ChannelBeta {
+			continue
+		}
+		asset, signature, ok := s.platformAssets(release.Assets)
+		if !ok {
+			continue
+		}
+		if best != nil && releaseVersion.compare(bestVersion) <= 0 {
+			continue
+		}
+		bestVersion = releaseVersion
+		best = &candidate{
+			info: UpdateInfo{
+				LatestVersion: releaseVersion.String(),
+				ReleaseName:   release.Name,
+				ReleaseNotes:  release.Body,
+				ReleaseURL:    release.HTMLURL,
+				Prerelease:    release.Prerelease || releaseVersion.isPrerelease(),
+				PublishedAt:   release.PublishedAt,
+				AssetName:     asset.Name,
+				AssetSize:     asset.Size,
+			},
+			asset:     asset,
+			signature: signature,
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = nil
+	if best == nil {
+		return info, nil
+	}
+	best.info.CurrentVersion = info.CurrentVersion
+	best.info.Channel = channel
+	best.info.CheckedAt = info.CheckedAt
+	best.info.Available = bestVersion.compare(current) > 0
+	if best.info.Available {
+		s.pending = best
+	}
+	result := best.info
+	return &result, nil
+}
+
+func (s *Service) listReleases(ctx context.Context) ([]githubRelease, error) {
+	if s.repository == "" {
+		return nil, fmt.Errorf("update repository is not configured")
+	}
+	endpoint := fmt.Sprintf("%s/repos/%s/releases?per_page=%d", strings.TrimRight(s.apiBase, "/"), s.repository, maxReleasesPerCheck)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "ORCH-App/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach GitHub releases: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4*1024))
+		return nil, fmt.Errorf("GitHub releases returned %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+	var releases []githubRelease
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 8<<20)).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("invalid releases response: %w", err)
+	}
+	return releases, nil
+}
+
+var (
+	osAliases = map[string][]string{
+		"darwin":  {"darwin", "macos", "mac"},
+		"linux":   {"linux"},
+		"windows": {"windows", "win"},
+	}
+	archAliases = map[string][]string{
+		"amd64": {"amd64", "x86_64", "x64"},
+		"arm64": {"arm64", "aarch64"},
+	}
+	packageExtensions = []string{".zip", ".tar.gz", ".tgz"}
+)
+
+// platformAssets escolhe o pacote do SO/arquitetura atual (ou universal no macOS) e sua assinatura.
+func (s *Service) platformAssets(assets []releaseAsset) (releaseAsset, releaseAsset, bool) {
+	byName := make(map[string]releaseAsset, len(assets))
+	for _, asset := range assets {
+		byName[asset.Name] = asset
+	}
+
+	archNames := append([]string(nil), archAliases[s.goarch]...)
+	if s.goos == "darwin" {
+		archNames = append(archNames, "universal")
+	}
+	for _, asset := range assets {
+		name := strings.ToLower(asset.Name)
+		if !hasAnySuffix(name, packageExtensions) || !containsAnyToken(name, osAliases[s.goos]) || !containsAnyToken(name, archNames) {
+			continue
+		}
+		signature, ok := byName[asset.Name+".sig"]
+		if !ok {
+			continue
+		}
+		return asset, signature, true
+	}
+	return releaseAsset{}, releaseAsset{}, false
+}
+
+func hasAnySuffix(name string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAnyToken procura o token delimitado por separadores (evita "win" casar com "darwin").
+func containsAnyToken(name string, tokens []string) bool {
+	isSeparator := func(b byte) bool {
+		return b == '-' || b == '_' || b == '.' || b == ' '
+	}
+	for _, token := range tokens {
+		for offset := 0; offset < len(name); {
+			idx := strings.Index(name[offset:], token)
+			if idx < 0 {
+				break
+			}
+			start := offset + idx
+			end := start + len(token)
+			if (start == 0 || isSeparator(name[start-1])) && (end == len(name) || isSeparator(name[end])) {
+				return true
+			}
+			offset = start + 1
+		}
+	}
+	return false
+}
+
+// InstallUpdate baixa, verifica e aplica o release encontrado na última verificação.
+func (s *Service) InstallUpdate(ctx context.Context) (*InstallResult, error) {
+	s.mu.Lock()
+	if s.installing {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("an update is already being installed")
+	}
+	pending := s.pending
+	if pending == nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("no update available; run CheckForUpdates first")
+	}
+	s.installing = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.installing = false
+		s.mu.Unlock()
+	}()
+
+	result, err := s.install(ctx, pending)
+	if err != nil {
+		s.emitProgress(Progress{Stage: "error", Version: pending.info.LatestVersion, Message: err.Error()})
+		return nil, err
+	}
+	s.mu.Lock()
+	s.pending = nil
+	s.mu.Unlock()
+	s.emitProgress(Progress{Stage: "done", Version: pending.info.LatestVersion, Percent: 100})
+	return result, nil
+}
+
+func (s *Service) install(ctx context.Context, pending *candidate) (*InstallResult, error) {
+	publicKey, err := decodePublicKey(s.publicKey)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(s.cacheDir, "updates", pending.info.LatestVersion)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create update dir: %w", err)
+	}
+
+	packagePath := filepath.Join(dir, filepath.Base(pending.asset.Name))
+	digest, err := s.downloadPackage(ctx, pending, packagePath)
+	if err != nil {
+		return nil, err
+	}
+
+	s.emitProgress(Progress{Stage: "verifying", Version: pending.info.LatestVersion, Percent: 100})
+	signature, err := s.fetchSignature(ctx, pending.signature.URL)
+	if err != nil {
+		_ = os.Remove(packagePath)
+		return nil, err
+	}
+	if !ed25519.Verify(publicKey, digest, signature) {
+		_ = os.Remove(packagePath)
+		return nil, fmt.Errorf("update signature verification failed for %s", pending.asset.Name)
+	}
+
+	s.emitProgress(Progress{Stage: "installing", Version: pending.info.LatestVersion, Percent: 100})
+	executable, err := s.executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate running executable: %w", err)
+	}
+	installedPath, err := applyPackage(packagePath, executable)
+	if err != nil {
+		return nil, err
+	}
+	_ = os.RemoveAll(dir)
+	return &InstallResult{Version: pending.info.LatestVersion, InstalledPath: installedPath, RestartRequired: true}, nil
+}
+
+func decodePublicKey(encoded string) (ed25519.PublicKey, error) {
+	if strings.TrimSpace(encoded) == "" {
+		return nil, fmt.Errorf("update signing key is not configured in this build")
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid update signing key")
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// downloadPackage grava o pacote emitindo progresso e retorna o SHA-256 do conteúdo.
+func (s *Service) downloadPackage(ctx context.Context, pending *candidate, dest string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pending.asset.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "ORCH-App/1.0")
+	resp, err := s.download.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download update: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("update download returned %d", resp.StatusCode)
+	}
+
+	total := resp.ContentLength
+	if total <= 0 {
+		total = pending.asset.Size
+	}
+	if total > maxPackageBytes {
+		return nil, fmt.Errorf("update package is too large (%d bytes)", total)
+	}
+
+	partial := dest + ".partial"
+	file, err := os.OpenFile(partial, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create update file: %w", err)
+	}
+	hasher := sha256.New()
+	progress := &progressWriter{
+		service: s,
+		hasher:  hasher,
+		version: pending.info.LatestVersion,
+		total:   total,
+	}
+	_, copyErr := io.Copy(io.MultiWriter(file, progress), io.LimitReader(resp.Body, maxPackageBytes+1))
+	closeErr := file.Close()
+	if copyErr == nil {
+		copyErr = closeErr
+	}
+	if copyErr == nil && progress.written > maxPackageBytes {
+		copyErr = fmt.Errorf("update package exceeds %d bytes", maxPackageBytes)
+	}
+	if copyErr != nil {
+		_ = os.Remove(partial)
+		return nil, fmt.Errorf("failed to download update: %w", copyErr)
+	}
+	progress.flush()
+	if err := os.Rename(partial, dest); err != nil {
+		return nil, err
+	}
+	return hasher.Sum(nil), nil
+}
+
+func (s *Service) fetchSignature(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "ORCH-App/1.0")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download update signature: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("update signature download returned %d", resp.StatusCode)
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxSignatureBytes))
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("invalid update signature")
+	}
+	return signature, nil
+}
+
+func (s *Service) emitProgress(progress Progress) {
+	if s.emit != nil {
+		s.emit("updater:progress", progress)
+	}
+}
+
+// progressWriter calcula o hash e emite "updater:progress" com intervalo mínimo entre eventos.
+type progressWriter struct {
+	service  *Service
+	hasher   hash.Hash
+	version  string
+	total    int64
+	written  int64
+	lastEmit time.Time
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, _ := p.hasher.Write(b)
+	p.written += int64(n)
+	if time.Since(p.lastEmit) >= progressEmitInterval {
+		p.flush()
+	}
+	return n, nil
+}
+
+func (p *progressWriter) flush() {
+	p.lastEmit = time.Now()
+	progress := Progress{Stage: "downloading", Version: p.version, Downloaded: p.written, Total: p.total}
+	if p.total > 0 {
+		progress.Percent = float64(p.written) * 100 / float64(p.total)
+	}
+	p.service.emitProgress(progress)
+}
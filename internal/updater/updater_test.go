@@ -0,0 +1,216 @@
+package updater
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVersionCompareFollowsSemver(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "v1.0.0", "1.0.1", "1.10.0"}
+	for i := 0; i+1 < len(ordered); i++ {
+		a, errA := parseVersion(ordered[i])
+		b, errB := parseVersion(ordered[i+1])
+		if errA != nil || errB != nil {
+			t.Fatalf("parse failed: %v %v", errA, errB)
+		}
+		if a.compare(b) >= 0 || b.compare(a) <= 0 {
+			t.Fatalf("expected %s < %s", ordered[i], ordered[i+1])
+		}
+	}
+	for _, invalid := range []string{"1.0", "1.0.0-", "1.0.0-beta/../x", "a.b.c"} {
+		if _, err := parseVersion(invalid); err == nil {
+			t.Fatalf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+type releaseFixture struct {
+	server  *httptest.Server
+	files   map[string][]byte
+	release []githubRelease
+}
+
+func newReleaseFixture(t *testing.T) *releaseFixture {
+	t.Helper()
+	fixture := &releaseFixture{files: make(map[string][]byte)}
+	fixture.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/peruccii/pando/releases" {
+			_ = json.NewEncoder(w).Encode(fixture.release)
+			return
+		}
+		body, ok := fixture.files[strings.TrimPrefix(r.URL.Path, "/download/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(fixture.server.Close)
+	return fixture
+}
+
+func (f *releaseFixture) addRelease(tag string, prerelease bool, files map[string][]byte) {
+	release := githubRelease{TagName: tag, Name: "ORCH " + tag, Prerelease: prerelease}
+	for name, body := range files {
+		f.files[name] = body
+		release.Assets = append(release.Assets, releaseAsset{Name: name, URL: f.server.URL + "/download/" + name, Size: int64(len(body))})
+	}
+	f.release = append(f.release, release)
+}
+
+func newTestUpdater(fixture *releaseFixture, publicKey ed25519.PublicKey, executable string, events *[]Progress) *Service {
+	svc := NewService(Options{
+		Repository:     "peruccii/pando",
+		CurrentVersion: "1.0.0",
+		CacheDir:       filepath.Join(filepath.Dir(executable), "cache"),
+		PublicKey:      base64.StdEncoding.EncodeToString(publicKey),
+		Emit: func(event string, payload interface{}) {
+			if progress, ok := payload.(Progress); ok && events != nil {
+				*events = append(*events, progress)
+			}
+		},
+	})
+	svc.apiBase = fixture.server.URL
+	svc.goos = "linux"
+	svc.goarch = "amd64"
+	svc.executable = func() (string, error) { return executable, nil }
+	return svc
+}
+
+func buildTarGz(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: "orch-linux/" + name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatalf("tar header: %v", err)
+	}
+	_, _ = tw.Write(content)
+	_ = tw.Close()
+	_ = gz.Close()
+	return buf.Bytes()
+}
+
+func sign(privateKey ed25519.PrivateKey, body []byte) []byte {
+	digest := sha256.Sum256(body)
+	return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, digest[:])))
+}
+
+func TestCheckForUpdatesHonorsChannelAndPlatform(t *testing.T) {
+	fixture := newReleaseFixture(t)
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	stable := buildTarGz(t, "orch", []byte("v1.1.0"))
+	fixture.addRelease("v1.1.0", false, map[string][]byte{"orch-linux-amd64.tar.gz": stable, "orch-linux-amd64.tar.gz.sig": sign(privateKey, stable)})
+	fixture.addRelease("v1.2.0-beta.1", true, map[string][]byte{"orch-linux-amd64.tar.gz": stable, "orch-linux-amd64.tar.gz.sig": sign(privateKey, stable)})
+	// Versão maior sem pacote para a plataforma/assinatura é ignorada.
+	fixture.addRelease("v2.0.0", false, map[string][]byte{"orch-darwin-arm64.zip": stable, "orch-linux-amd64.zip": stable})
+
+	svc := newTestUpdater(fixture, publicKey, filepath.Join(t.TempDir(), "orch"), nil)
+	info, err := svc.CheckForUpdates(context.Background(), "stable")
+	if err != nil {
+		t.Fatalf("CheckForUpdates: %v", err)
+	}
+	if !info.Available || info.LatestVersion != "1.1.0" || info.AssetName != "orch-linux-amd64.tar.gz" {
+		t.Fatalf("unexpected stable update %+v", info)
+	}
+
+	info, err = svc.CheckForUpdates(context.Background(), "beta")
+	if err != nil {
+		t.Fatalf("CheckForUpdates: %v", err)
+	}
+	if !info.Available || info.LatestVersion != "1.2.0-beta.1" || !info.Prerelease {
+		t.Fatalf("unexpected beta update %+v", info)
+	}
+
+	if _, err := svc.CheckForUpdates(context.Background(), "nightly"); err == nil {
+		t.Fatalf("expected unknown channel to be rejected")
+	}
+}
+
+func TestInstallUpdateVerifiesSignatureAndReplacesExecutable(t *testing.T) {
+	fixture := newReleaseFixture(t)
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	pkg := buildTarGz(t, "orch", []byte("new build"))
+	fixture.addRelease("v1.1.0", false, map[string][]byte{"orch_linux_x86_64.tar.gz": pkg, "orch_linux_x86_64.tar.gz.sig": sign(privateKey, pkg)})
+
+	executable := filepath.Join(t.TempDir(), "orch")
+	if err := os.WriteFile(executable, []byte("old build"), 0755); err != nil {
+		t.Fatalf("write executable: %v", err)
+	}
+	var events []Progress
+	svc := newTestUpdater(fixture, publicKey, executable, &events)
+
+	if _, err := svc.InstallUpdate(context.Background()); err == nil {
+		t.Fatalf("expected install without check to fail")
+	}
+	if _, err := svc.CheckForUpdates(context.Background(), "stable"); err != nil {
+		t.Fatalf("CheckForUpdates: %v", err)
+	}
+	result, err := svc.InstallUpdate(context.Background())
+	if err != nil {
+		t.Fatalf("InstallUpdate: %v", err)
+	}
+	if result.Version != "1.1.0" || result.InstalledPath != executable || !result.RestartRequired {
+		t.Fatalf("unexpected install result %+v", result)
+	}
+	if content, _ := os.ReadFile(executable); string(content) != "new build" {
+		t.Fatalf("executable not replaced: %q", content)
+	}
+	if _, err := os.Stat(executable + ".old"); !os.IsNotExist(err) {
+		t.Fatalf("expected previous version to be cleaned up")
+	}
+	if len(events) == 0 || events[0].Stage != "downloading" || events[len(events)-1].Stage != "done" {
+		t.Fatalf("unexpected progress events %+v", events)
+	}
+}
+
+func TestInstallUpdateRejectsBadSignature(t *testing.T) {
+	fixture := newReleaseFixture(t)
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	pkg := buildTarGz(t, "orch", []byte("tampered"))
+	fixture.addRelease("v1.1.0", false, map[string][]byte{"orch-linux-amd64.tar.gz": pkg, "orch-linux-amd64.tar.gz.sig": sign(otherKey, pkg)})
+
+	executable := filepath.Join(t.TempDir(), "orch")
+	_ = os.WriteFile(executable, []byte("old build"), 0755)
+	svc := newTestUpdater(fixture, publicKey, executable, nil)
+	if _, err := svc.CheckForUpdates(context.Background(), "stable"); err != nil {
+		t.Fatalf("CheckForUpdates: %v", err)
+	}
+	if _, err := svc.InstallUpdate(context.Background()); err == nil || !strings.Contains(err.Error(), "signature") {
+		t.Fatalf("expected signature error, got %v", err)
+	}
+	if content, _ := os.ReadFile(executable); string(content) != "old build" {
+		t.Fatalf("executable must stay untouched, got %q", content)
+	}
+}
+
+func TestExtractRejectsPathTraversal(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	_ = tw.WriteHeader(&tar.Header{Name: "../escape", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	_, _ = tw.Write([]byte("x"))
+	_ = tw.Close()
+	_ = gz.Close()
+
+	dir := t.TempDir()
+	pkg := filepath.Join(dir, "evil.tar.gz")
+	_ = os.WriteFile(pkg, buf.Bytes(), 0600)
+	if err := extractPackage(pkg, filepath.Join(dir, "out")); err == nil {
+		t.Fatalf("expected unsafe path to be rejected")
+	}
+}
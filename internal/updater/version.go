@@ -0,0 +1,121 @@
+package updater
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// version é uma versão semântica (major.minor.patch[-prerelease]); metadados de build são ignorados.
+type version struct {
+	major, minor, patch int
+	prerelease          []string
+}
+
+func parseVersion(raw string) (version, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	if idx := strings.IndexByte(value, '+'); idx >= 0 {
+		value = value[:idx]
+	}
+	var v version
+	core := value
+	if idx := strings.IndexByte(value, '-'); idx >= 0 {
+		core = value[:idx]
+		v.prerelease = strings.Split(value[idx+1:], ".")
+		for _, identifier := range v.prerelease {
+			if !isPrereleaseIdentifier(identifier) {
+				return version{}, fmt.Errorf("invalid version %q", raw)
+			}
+		}
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return version{}, fmt.Errorf("invalid version %q", raw)
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, fmt.Errorf("invalid version %q", raw)
+		}
+		numbers[i] = n
+	}
+	v.major, v.minor, v.patch = numbers[0], numbers[1], numbers[2]
+	return v, nil
+}
+
+func (v version) String() string {
+	core := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+	if v.isPrerelease() {
+		return core + "-" + strings.Join(v.prerelease, ".")
+	}
+	return core
+}
+
+func (v version) isPrerelease() bool {
+	return len(v.prerelease) > 0
+}
+
+// compare retorna -1, 0 ou 1 seguindo a precedência do SemVer 2.0.
+func (v version) compare(other version) int {
+	for _, pair := range [][2]int{{v.major, other.major}, {v.minor, other.minor}, {v.patch, other.patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case !v.isPrerelease() && !other.isPrerelease():
+		return 0
+	case !v.isPrerelease():
+		return 1
+	case !other.isPrerelease():
+		return -1
+	}
+	for i := 0; i < len(v.prerelease) && i < len(other.prerelease); i++ {
+		if cmp := comparePrereleaseIdentifier(v.prerelease[i], other.prerelease[i]); cmp != 0 {
+			return cmp
+		}
+	}
+	switch {
+	case len(v.prerelease) < len(other.prerelease):
+		return -1
+	case len(v.prerelease) > len(other.prerelease):
+		return 1
+	}
+	return 0
+}
+
+func comparePrereleaseIdentifier(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		if na == nb {
+			return 0
+		}
+		if na < nb {
+			return -1
+		}
+		return 1
+	case errA == nil:
+		return -1 // identificadores numéricos têm precedência menor
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isPrereleaseIdentifier(identifier string) bool {
+	if identifier == "" {
+		return false
+	}
+	for _, r := range identifier {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,321 @@
+package workspacefs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxFileSize é o maior arquivo lido ou gravado pelo visualizador/editor embutido.
+	MaxFileSize      = 10 << 20
+	binarySniffBytes = 8000
+)
+
+var (
+	ErrInvalidPath    = errors.New("invalid file path")
+	ErrOutsideRoot    = errors.New("path escapes the workspace root")
+	ErrNotRegularFile = errors.New("not a regular file")
+	ErrFileTooLarge   = fmt.Errorf("file exceeds the %d MB limit", MaxFileSize>>20)
+	ErrBinaryFile     = errors.New("binary files cannot be edited")
+	ErrProtectedPath  = errors.New("writing inside .git is not allowed")
+	// ErrHashMismatch indica que o arquivo mudou desde a leitura (concorrência otimista).
+	ErrHashMismatch = errors.New("file changed on disk")
+)
+
+// writeMu serializa a checagem de hash + gravação entre bindings concorrentes.
+var writeMu sync.Mutex
+
+// ReadRange seleciona linhas (1-based, inclusivas); zero lê do início / até o fim.
+type ReadRange struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+}
+
+// FileContent é o conteúdo (ou trecho) de um arquivo do workspace.
+type FileContent struct {
+	Path       string    `json:"path"`    // relativo à raiz do workspace, com "/"
+	Content    string    `json:"content"` // vazio para binários
+	Hash       string    `json:"hash"`    // sha256 do arquivo inteiro; usar como expectedHash na escrita
+	Size       int64     `json:"size"`
+	Binary     bool      `json:"binary"`
+	StartLine  int       `json:"startLine"`
+	EndLine    int       `json:"endLine"`
+	TotalLines int       `json:"totalLines"`
+	LineEnding string    `json:"lineEnding"` // lf | crlf
+	ModTime    time.Time `json:"modTime"`
+}
+
+// WriteResult descreve um arquivo gravado.
+type WriteResult struct {
+	Path    string `json:"path"`
+	Hash    string `json:"hash"`
+	Size    int64  `json:"size"`
+	Created bool   `json:"created"`
+}
+
+// ResolvePath valida um caminho (relativo à raiz ou absoluto dentro dela) e retorna o caminho
+// absoluto e o relativo. Symlinks que apontam para fora da raiz são rejeitados.
+func ResolvePath(root string, filePath string) (string, string, error) {
+	trimmed := strings.TrimSpace(filePath)
+	if trimmed == "" || strings.ContainsRune(trimmed, '\x00') {
+		return "", "", ErrInvalidPath
+	}
+	rootAbs, err := filepath.Abs(root)
+	if err != nil {
+		return "", "", err
+	}
+	target := filepath.FromSlash(trimmed)
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(rootAbs, target)
+	}
+	rel, err := filepath.Rel(rootAbs, filepath.Clean(target))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return "", "", ErrOutsideRoot
+	}
+
+	realRoot, err := filepath.EvalSymlinks(rootAbs)
+	if err != nil {
+		return "", "", fmt.Errorf("workspace root not accessible: %w", err)
+	}
+	realTarget, err := resolveExistingPrefix(filepath.Join(rootAbs, rel))
+	if err != nil {
+		return "", "", err
+	}
+	if realTarget != realRoot && !strings.HasPrefix(realTarget, realRoot+string(os.PathSeparator)) {
+		return "", "", ErrOutsideRoot
+	}
+	return filepath.Join(rootAbs, rel), filepath.ToSlash(rel), nil
+}
+
+// resolveExistingPrefix resolve symlinks do maior prefixo existente (arquivos novos ainda não existem).
+func resolveExistingPrefix(p string) (string, error) {
+	var missing []string
+	current := p
+	for {
+		real, err := filepath.EvalSymlinks(current)
+		if err == nil {
+			for i := len(missing) - 1; i >= 0; i-- {
+				real = filepath.Join(real, missing[i])
+			}
+			return real, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", err
+		}
+		missing = append(missing, filepath.Base(current))
+		current = parent
+	}
+}
+
+// ReadFile lê um arquivo do workspace, opcionalmente só algumas linhas.
+// O hash sempre cobre o arquivo inteiro.
+func ReadFile(root string, filePath string, lines ReadRange) (*FileContent, error) {
+	abs, rel, err := ResolvePath(root, filePath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, ErrNotRegularFile
+	}
+	if info.Size() > MaxFileSize {
+		return nil, ErrFileTooLarge
+	}
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, err
+	}
+
+	content := &FileContent{
+		Path:       rel,
+		Hash:       hashBytes(data),
+		Size:       int64(len(data)),
+		ModTime:    info.ModTime(),
+		Binary:     isBinary(data),
+		LineEnding: "lf",
+	}
+	if content.Binary {
+		return content, nil
+	}
+	if bytes.Contains(data, []byte("\r\n")) {
+		content.LineEnding = "crlf"
+	}
+
+	// Offsets de início de cada linha; a última linha pode não terminar em \n.
+	starts := []int{0}
+	for i, b := range data {
+		if b == '\n' && i+1 < len(data) {
+			starts = append(starts, i+1)
+		}
+	}
+	if len(data) == 0 {
+		starts = nil
+	}
+	content.TotalLines = len(starts)
+
+	start, end := max(lines.StartLine, 1), lines.EndLine
+	if end <= 0 || end > content.TotalLines {
+		end = content.TotalLines
+	}
+	content.StartLine, content.EndLine = start, end
+	if start > end {
+		content.EndLine = start - 1
+		return content, nil
+	}
+	to := len(data)
+	if end < content.TotalLines {
+		to = starts[end]
+	}
+	content.Content = string(data[starts[start-1]:to])
+	return content, nil
+}
+
+// WriteFile grava o arquivo de forma atômica se o hash atual for expectedHash
+// (vazio = o arquivo ainda não existe). Diretórios ausentes são criados.
+func WriteFile(root string, filePath string, content string, expectedHash string) (*WriteResult, error) {
+	abs, rel, err := ResolvePath(root, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if protected, err := insideGitDir(root, abs, rel); err != nil {
+		return nil, err
+	} else if protected {
+		return nil, ErrProtectedPath
+	}
+	if len(content) > MaxFileSize {
+		return nil, ErrFileTooLarge
+	}
+	if !utf8.ValidString(content) {
+		return nil, ErrBinaryFile
+	}
+
+	writeMu.Lock()
+	defer writeMu.Unlock()
+
+	mode := fs.FileMode(0o644)
+	info, statErr := os.Stat(abs)
+	switch {
+	case statErr == nil:
+		if !info.Mode().IsRegular() {
+			return nil, ErrNotRegularFile
+		}
+		current, err := os.ReadFile(abs)
+		if err != nil {
+			return nil, err
+		}
+		if currentHash := hashBytes(current); expectedHash != currentHash {
+			if expectedHash == "" {
+				return nil, fmt.Errorf("%w: file already exists (current hash %s)", ErrHashMismatch, currentHash)
+			}
+			return nil, fmt.Errorf("%w (current hash %s)", ErrHashMismatch, currentHash)
+		}
+		if isBinary(current) {
+			return nil, ErrBinaryFile
+		}
+		mode = info.Mode().Perm()
+	case errors.Is(statErr, fs.ErrNotExist):
+		if expectedHash != "" {
+			return nil, fmt.Errorf("%w: file no longer exists", ErrHashMismatch)
+		}
+	default:
+		return nil, statErr
+	}
+
+	if err := writeAtomic(abs, []byte(content), mode); err != nil {
+		return nil, err
+	}
+	return &WriteResult{
+		Path:    rel,
+		Hash:    hashBytes([]byte(content)),
+		Size:    int64(len(content)),
+		Created: statErr != nil,
+	}, nil
+}
+
+// insideGitDir verifica o caminho pedido e o caminho real (após symlinks) contra .git, sem
+// diferenciar maiúsculas: em sistemas de arquivos case-insensitive .GIT é o mesmo diretório.
+func insideGitDir(root string, abs string, rel string) (bool, error) {
+	rootAbs, err := filepath.Abs(root)
+	if err != nil {
+		return false, err
+	}
+	realRoot, err := filepath.EvalSymlinks(rootAbs)
+	if err != nil {
+		return false, err
+	}
+	realTarget, err := resolveExistingPrefix(abs)
+	if err != nil {
+		return false, err
+	}
+	realRel, err := filepath.Rel(realRoot, realTarget)
+	if err != nil {
+		return false, err
+	}
+	for _, candidate := range []string{rel, filepath.ToSlash(realRel)} {
+		for _, segment := range strings.Split(candidate, "/") {
+			if strings.EqualFold(segment, ".git") {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// writeAtomic grava num temporário do mesmo diretório e renomeia por cima do destino.
+// O sufixo .tmp faz o file watcher ignorar o temporário.
+func writeAtomic(path string, data []byte, mode fs.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// isBinary detecta binários por byte nulo no início ou UTF-8 inválido (não dá para editar sem perdas).
+func isBinary(data []byte) bool {
+	return bytes.IndexByte(data[:min(len(data), binarySniffBytes)], 0) >= 0 || !utf8.Valid(data)
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,113 @@
+package workspacefs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolvePathSandboxesToRoot(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	abs, rel, err := ResolvePath(root, "src/../main.go")
+	if err != nil || rel != "main.go" || abs != filepath.Join(root, "main.go") {
+		t.Fatalf("unexpected resolution: abs=%q rel=%q err=%v", abs, rel, err)
+	}
+	if _, rel, err := ResolvePath(root, filepath.Join(root, "docs", "a.md")); err != nil || rel != "docs/a.md" {
+		t.Fatalf("expected absolute path inside root to be accepted, got %q err=%v", rel, err)
+	}
+	for _, bad := range []string{"../escape.txt", filepath.Join(outside, "x"), "link/secret.txt", "link/new/file.txt", "."} {
+		if _, _, err := ResolvePath(root, bad); !errors.Is(err, ErrOutsideRoot) {
+			t.Errorf("ResolvePath(%q) = %v, want ErrOutsideRoot", bad, err)
+		}
+	}
+	if _, _, err := ResolvePath(root, " "); !errors.Is(err, ErrInvalidPath) {
+		t.Fatalf("expected empty path to be invalid, got %v", err)
+	}
+}
+
+func TestReadFileRangesAndBinary(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "notes.txt"), []byte("one\r\ntwo\r\nthree"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "logo.png"), []byte{0x89, 'P', 'N', 'G', 0, 1}, 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	full, err := ReadFile(root, "notes.txt", ReadRange{})
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if full.TotalLines != 3 || full.LineEnding != "crlf" || full.Content != "one\r\ntwo\r\nthree" || full.Hash == "" {
+		t.Fatalf("unexpected full read: %#v", full)
+	}
+	part, err := ReadFile(root, "notes.txt", ReadRange{StartLine: 2, EndLine: 2})
+	if err != nil || part.Content != "two\r\n" || part.StartLine != 2 || part.EndLine != 2 || part.Hash != full.Hash {
+		t.Fatalf("unexpected ranged read: %#v err=%v", part, err)
+	}
+	if past, err := ReadFile(root, "notes.txt", ReadRange{StartLine: 10}); err != nil || past.Content != "" {
+		t.Fatalf("expected empty content past the end, got %#v err=%v", past, err)
+	}
+
+	binary, err := ReadFile(root, "logo.png", ReadRange{})
+	if err != nil || !binary.Binary || binary.Content != "" {
+		t.Fatalf("expected binary detection, got %#v err=%v", binary, err)
+	}
+	if _, err := ReadFile(root, ".", ReadRange{}); err == nil {
+		t.Fatalf("expected the root itself to be rejected")
+	}
+}
+
+func TestWriteFileOptimisticConcurrency(t *testing.T) {
+	root := t.TempDir()
+
+	created, err := WriteFile(root, "src/app.go", "package app\n", "")
+	if err != nil || !created.Created {
+		t.Fatalf("expected file to be created, got %#v err=%v", created, err)
+	}
+	if _, err := WriteFile(root, "src/app.go", "package other\n", ""); !errors.Is(err, ErrHashMismatch) {
+		t.Fatalf("expected create over existing file to conflict, got %v", err)
+	}
+
+	updated, err := WriteFile(root, "src/app.go", "package app\n\nfunc Run() {}\n", created.Hash)
+	if err != nil || updated.Created {
+		t.Fatalf("expected update with matching hash, got %#v err=%v", updated, err)
+	}
+	_, err = WriteFile(root, "src/app.go", "stale\n", created.Hash)
+	if !errors.Is(err, ErrHashMismatch) || !strings.Contains(err.Error(), updated.Hash) {
+		t.Fatalf("expected stale hash to conflict with current hash, got %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "src", "app.go")); string(data) != "package app\n\nfunc Run() {}\n" {
+		t.Fatalf("conflicting write must not touch the file, got %q", data)
+	}
+
+	if _, err := WriteFile(root, ".git/config", "x", ""); !errors.Is(err, ErrProtectedPath) {
+		t.Fatalf("expected .git to be protected, got %v", err)
+	}
+	if _, err := WriteFile(root, ".GIT/config", "x", ""); !errors.Is(err, ErrProtectedPath) {
+		t.Fatalf("expected .GIT to be protected, got %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".git", "hooks"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, ".git", "hooks"), filepath.Join(root, "hooks")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WriteFile(root, "hooks/pre-commit", "x", ""); !errors.Is(err, ErrProtectedPath) {
+		t.Fatalf("expected symlink into .git to be protected, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".git", "hooks", "pre-commit")); !os.IsNotExist(err) {
+		t.Fatalf("protected write must not create the file, got %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Join(root, "src"))
+	if len(entries) != 1 {
+		t.Fatalf("expected no temp files left behind, got %d entries", len(entries))
+	}
+}